@@ -0,0 +1,136 @@
+// Command demodata seeds a demo or evaluation environment with synthetic
+// observability data: metrics, logs and traces for five fictitious shop
+// services over a time span, with a scripted incident (a payments
+// deployment that exhausts its database connection pool and breaks
+// checkout), plus matching KPI definitions to run correlation and RCA on.
+//
+// All data carries env="demo". Runs with the same -seed and -end produce
+// the same data.
+//
+// Example:
+//
+//	demodata -metrics http://localhost:8428 -logs http://localhost:9428 \
+//	    -traces http://localhost:10428 -mirador http://localhost:8010 -span 6h
+//
+// With -out, the data is written to files instead (metrics.jsonl for
+// /api/v1/import, logs.jsonl for /insert/jsonline, traces.pb as an OTLP
+// export request, kpis.json for /api/v1/kpi/defs/import).
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/demodata"
+	"github.com/mirastacklabs-ai/mirador-core/internal/loadreplay"
+)
+
+func main() {
+	metricsURL := flag.String("metrics", "", "VictoriaMetrics base URL to import metrics into (empty = skip)")
+	logsURL := flag.String("logs", "", "VictoriaLogs base URL to ingest logs into (empty = skip)")
+	tracesURL := flag.String("traces", "", "VictoriaTraces base URL to ingest traces into (empty = skip)")
+	miradorURL := flag.String("mirador", "", "mirador-core base URL to import the demo KPIs into (empty = skip)")
+	headers := flag.String("header", "", "Extra headers for every request, e.g. 'Authorization=Bearer x'")
+	out := flag.String("out", "", "Write the data to files in this directory instead of sending it")
+	span := flag.Duration("span", 6*time.Hour, "Time span of generated data, ending at -end")
+	step := flag.Duration("step", 30*time.Second, "Metrics sample interval")
+	end := flag.String("end", "", "End of the span (RFC3339; default now)")
+	incidentAgo := flag.Duration("incident-ago", 45*time.Minute, "How long before -end the incident starts")
+	incidentDuration := flag.Duration("incident-duration", 20*time.Minute, "Incident duration (0 = no incident)")
+	seed := flag.Uint64("seed", 1, "Random seed")
+	flag.Parse()
+
+	endAt := time.Now()
+	if *end != "" {
+		t, err := time.Parse(time.RFC3339, *end)
+		if err != nil {
+			log.Fatalf("end: %v", err)
+		}
+		endAt = t
+	}
+	sc, err := demodata.NewScenario(endAt, *span, *step, *incidentAgo, *incidentDuration, *seed)
+	if err != nil {
+		log.Fatalf("scenario: %v", err)
+	}
+
+	if *out != "" {
+		if err := writeFiles(*out, sc); err != nil {
+			log.Fatalf("write files: %v", err)
+		}
+		fmt.Printf("Demo data for %s - %s written to %s\n", sc.Start.Format(time.RFC3339), sc.End.Format(time.RFC3339), *out)
+		return
+	}
+	if *metricsURL == "" && *logsURL == "" && *tracesURL == "" && *miradorURL == "" {
+		log.Fatal("nothing to do: set -metrics, -logs, -traces, -mirador or -out")
+	}
+	hdrs, err := loadreplay.ParseKeyValues(*headers)
+	if err != nil {
+		log.Fatalf("header: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	sum, err := demodata.Load(ctx, sc, demodata.Targets{
+		Metrics: *metricsURL,
+		Logs:    *logsURL,
+		Traces:  *tracesURL,
+		Mirador: *miradorURL,
+		Headers: hdrs,
+	}, nil)
+	if err != nil {
+		log.Fatalf("load: %v", err)
+	}
+	fmt.Printf("Loaded %d series, %d log entries, %d spans and %d KPIs for %s - %s\n",
+		sum.Series, sum.LogEntries, sum.Spans, sum.KPIs, sum.Start.Format(time.RFC3339), sum.End.Format(time.RFC3339))
+	if sum.Incident != nil {
+		fmt.Printf("Incident starts at %s (payments deploy two minutes earlier)\n", sum.Incident.Format(time.RFC3339))
+	}
+}
+
+func writeFiles(dir string, sc demodata.Scenario) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	write := func(name string, fn func(f *os.File) error) error {
+		f, err := os.Create(filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+		if err := fn(f); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	}
+	if err := write("metrics.jsonl", func(f *os.File) error { _, err := sc.WriteMetrics(f); return err }); err != nil {
+		return err
+	}
+	if err := write("logs.jsonl", func(f *os.File) error { _, err := sc.WriteLogs(f); return err }); err != nil {
+		return err
+	}
+	if err := write("traces.pb", func(f *os.File) error {
+		data, err := proto.Marshal(sc.Traces())
+		if err != nil {
+			return err
+		}
+		_, err = f.Write(data)
+		return err
+	}); err != nil {
+		return err
+	}
+	return write("kpis.json", func(f *os.File) error {
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		return enc.Encode(demodata.KPIs(sc.End))
+	})
+}
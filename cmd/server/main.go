@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -14,12 +15,17 @@ import (
 
 	"github.com/mirastacklabs-ai/mirador-core/internal/api"
 	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/faultinject"
 	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
 	"github.com/mirastacklabs-ai/mirador-core/internal/mariadb"
+	"github.com/mirastacklabs-ai/mirador-core/internal/metrics"
+	"github.com/mirastacklabs-ai/mirador-core/internal/qos"
 	"github.com/mirastacklabs-ai/mirador-core/internal/repo"
+	"github.com/mirastacklabs-ai/mirador-core/internal/secrets"
 	"github.com/mirastacklabs-ai/mirador-core/internal/services"
 	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
-	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/redact"
 )
 
 // @title Mirador Core API
@@ -86,15 +92,55 @@ func main() {
 		return
 	}
 
+	// Validate configuration and exit: mirador-core --validate-config
+	if len(os.Args) > 1 && (os.Args[1] == "--validate-config" || os.Args[1] == "validate-config") {
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, configErrorReport(err))
+			os.Exit(1)
+		}
+		for _, w := range cfg.Warnings {
+			fmt.Fprintln(os.Stderr, "warning:", w)
+		}
+		fmt.Println("configuration is valid")
+		return
+	}
+
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
-		log.Fatalf("Failed to load configuration: %v", err)
+		log.Fatal(configErrorReport(err))
 	}
 
 	// Initialize logger
-	logger := logger.New(cfg.LogLevel)
+	logger := corelogger.New(cfg.LogLevel)
 	logger.Info("Starting MIRADOR-CORE", "version", version, "commit", commitHash, "built", buildTime, "environment", cfg.Environment)
+	for _, w := range cfg.Warnings {
+		logger.Warn(w)
+	}
+	// Subsystem levels from log_levels; PUT /api/v1/admin/log-levels
+	// adjusts them at runtime.
+	if lc, ok := logger.(corelogger.LevelController); ok {
+		for name, level := range cfg.LogLevels {
+			if err := lc.SetLevel(name, level); err != nil {
+				logger.Warn("Ignoring log level", "subsystem", name, "error", err)
+			}
+		}
+	}
+	cacheLogger := corelogger.Named(logger, corelogger.SubsystemCache)
+
+	// Resolve vault:/awssm:/k8s:/file: references in secret config fields
+	secretResolver := newSecretResolver(cfg)
+	secretRefs, err := config.ResolveSecretRefs(context.Background(), cfg, secretResolver)
+	if err != nil {
+		logger.Fatal("Failed to resolve secrets", "error", err)
+	}
+	if len(secretRefs) > 0 {
+		logger.Info("Resolved secrets from external providers", "fields", len(secretRefs))
+	}
+	// Scrub the resolved secret values from every log line and error
+	// response, wherever they end up.
+	redact.Register(config.SecretValues(cfg)...)
 
 	// Initialize Valkey cache: single-node when one address is provided; cluster otherwise
 	var valkeyCache cache.ValkeyCluster
@@ -103,8 +149,8 @@ func main() {
 		valkeyCache, err = cache.NewValkeySingle(cfg.Cache.Nodes[0], cfg.Cache.DB, cfg.Cache.Password, time.Duration(cfg.Cache.TTL)*time.Second)
 		if err != nil {
 			logger.Warn("Valkey single-node unavailable; starting with in-memory cache (auto-reconnect enabled)", "error", err)
-			fallback := cache.NewNoopValkeyCache(logger)
-			valkeyCache = cache.NewAutoSwapForSingle(cfg.Cache.Nodes[0], cfg.Cache.DB, cfg.Cache.Password, time.Duration(cfg.Cache.TTL)*time.Second, logger, fallback)
+			fallback := cache.NewNoopValkeyCache(cacheLogger)
+			valkeyCache = cache.NewAutoSwapForSingle(cfg.Cache.Nodes[0], cfg.Cache.DB, cfg.Cache.Password, time.Duration(cfg.Cache.TTL)*time.Second, cacheLogger, fallback)
 		} else {
 			logger.Info("Valkey single-node cache initialized", "addr", cfg.Cache.Nodes[0])
 		}
@@ -122,25 +168,99 @@ func main() {
 						logger.Info("Valkey single-node cache initialized via fallback", "addr", cfg.Cache.Nodes[0])
 					} else {
 						logger.Warn("Valkey single-node fallback unavailable; starting with in-memory cache (auto-reconnect to single)", "error", sErr)
-						fallback := cache.NewNoopValkeyCache(logger)
-						valkeyCache = cache.NewAutoSwapForSingle(cfg.Cache.Nodes[0], cfg.Cache.DB, cfg.Cache.Password, time.Duration(cfg.Cache.TTL)*time.Second, logger, fallback)
+						fallback := cache.NewNoopValkeyCache(cacheLogger)
+						valkeyCache = cache.NewAutoSwapForSingle(cfg.Cache.Nodes[0], cfg.Cache.DB, cfg.Cache.Password, time.Duration(cfg.Cache.TTL)*time.Second, cacheLogger, fallback)
 					}
 				}
 			} else {
 				logger.Warn("Valkey cluster unavailable; starting with in-memory cache (auto-reconnect to cluster)", "error", err)
-				fallback := cache.NewNoopValkeyCache(logger)
-				valkeyCache = cache.NewAutoSwapForCluster(cfg.Cache.Nodes, time.Duration(cfg.Cache.TTL)*time.Second, logger, fallback)
+				fallback := cache.NewNoopValkeyCache(cacheLogger)
+				valkeyCache = cache.NewAutoSwapForCluster(cfg.Cache.Nodes, time.Duration(cfg.Cache.TTL)*time.Second, cacheLogger, fallback)
 			}
 		} else {
 			logger.Info("Valkey cluster cache initialized", "nodes", len(cfg.Cache.Nodes))
 		}
 	}
 
+	// Dependency fault injection (staging/testing only; rejected in production
+	// by config validation). Valkey faults need the cache wrapper; HTTP
+	// clients pick faults up through their transports.
+	if cfg.FaultInjection.Enabled && !cfg.IsProduction() {
+		faultinject.Enable()
+		valkeyCache = faultinject.WrapCache(valkeyCache)
+		logger.Warn("Fault injection ENABLED: dependency faults can be injected via /api/v1/admin/faults")
+	}
+
+	// Sensitive values are encrypted beneath the tenant namespace, so each
+	// tenant's values get their own derived key.
+	if enc := cfg.Cache.Encryption; enc.Enabled {
+		keks, err := enc.DecodedKeys()
+		if err != nil {
+			logger.Fatal("Invalid cache encryption keys", "error", err)
+		}
+		valkeyCache, err = cache.NewEncrypted(valkeyCache, keks, enc.ActiveVersion, enc.Prefixes)
+		if err != nil {
+			logger.Fatal("Failed to enable cache encryption", "error", err)
+		}
+		logger.Info("Valkey values encrypted", "prefixes", enc.Prefixes, "key_version", enc.ActiveVersion)
+	}
+
+	// Tenant key namespace, so deployments sharing a Valkey can be flushed
+	// independently.
+	if cfg.Cache.TenantID != "" {
+		valkeyCache = cache.NewTenantNamespaced(valkeyCache, cfg.Cache.TenantID)
+		logger.Info("Valkey keys namespaced by tenant", "tenant", cfg.Cache.TenantID)
+	}
+
+	// Tenant overrides sit on top of the file and environment layers. Invalid
+	// stored overrides (e.g. after a config file change) are skipped rather
+	// than blocking startup.
+	if overrides, err := services.NewDynamicConfigService(valkeyCache, logger).GetConfigOverrides(context.Background()); err != nil {
+		logger.Warn("Failed to read tenant config overrides", "error", err)
+	} else if len(overrides) > 0 {
+		if effective, err := config.ApplyOverrides(cfg, overrides); err != nil {
+			logger.Error("Ignoring invalid tenant config overrides", "error", err)
+		} else {
+			cfg = effective
+			logger.Info("Applied tenant config overrides", "count", len(overrides))
+		}
+	}
+
+	// Backend query scheduling: interactive requests get freed slots first,
+	// background and batch work is capped below the total.
+	qosLimits := map[qos.Class]qos.ClassLimit{}
+	for name, c := range cfg.QoS.Classes {
+		qosLimits[qos.Class(name)] = qos.ClassLimit{MaxConcurrent: c.MaxConcurrent, QueueTimeout: c.QueueTimeout}
+	}
+	qos.Configure(cfg.QoS.MaxConcurrent, qosLimits)
+
 	// Initialize VictoriaMetrics services
 	vmServices, err := services.NewVictoriaMetricsServices(cfg.Database, logger)
 	if err != nil {
 		logger.Fatal("Failed to initialize VictoriaMetrics services", "error", err)
 	}
+	vmServices.Logs.SetExportLimits(cfg.Export)
+	vmServices.Logs.SetResultLimits(cfg.ResultLimits)
+	vmServices.Metrics.SetResultLimits(cfg.ResultLimits)
+	if len(cfg.TenantLabels) > 0 {
+		vmServices.Metrics.SetTenantLabels(cfg.TenantLabels)
+		vmServices.Logs.SetTenantLabels(cfg.TenantLabels)
+		logger.Info("Backend queries scoped to tenant labels", "labels", cfg.TenantLabels)
+	}
+	if r := cfg.Residency; r.Region != "" {
+		if r.Strict {
+			vmServices.Metrics.SetResidency(r)
+			vmServices.Logs.SetResidency(r)
+			vmServices.Traces.SetResidency(r)
+			logger.Info("Backend requests pinned to residency region", "region", r.Region)
+		} else {
+			for _, b := range cfg.BackendHosts() {
+				if !r.InRegion(b.Host) {
+					logger.Warn("Backend host outside residency region", "region", r.Region, "backend", b.Backend, "field", b.Field, "host", b.Host)
+				}
+			}
+		}
+	}
 
 	// Initialize MariaDB client (read-only access to tenant data)
 	var mariaDBClient *mariadb.Client
@@ -186,6 +306,7 @@ func main() {
 
 	// Initialize API server
 	apiServer := api.NewServer(cfg, logger, valkeyCache, vmServices, schemaStore, mariaDBClient)
+	apiServer.SetSecretRefs(secretRefs)
 
 	// Setup graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
@@ -204,6 +325,10 @@ func main() {
 		cancel()
 	}()
 
+	if len(secretRefs) > 0 && cfg.Secrets.RefreshInterval > 0 {
+		go watchSecretRotation(ctx, secretResolver, secretRefs, cfg.Secrets.RefreshInterval, logger)
+	}
+
 	// Start dynamic endpoint discovery (DNS-based) if configured
 	vmServices.StartDiscovery(ctx, cfg.Database, logger)
 
@@ -214,3 +339,55 @@ func main() {
 
 	logger.Info("MIRADOR-CORE shutdown complete")
 }
+
+// newSecretResolver registers the secret providers configured under secrets.
+func newSecretResolver(cfg *config.Config) *secrets.Resolver {
+	r := secrets.NewResolver(cfg.Secrets.CacheTTL)
+	r.Register(secrets.SchemeK8s, secrets.KubernetesProvider{MountPath: cfg.Secrets.Kubernetes.MountPath})
+	if v := cfg.Secrets.Vault; v.Address != "" {
+		r.Register(secrets.SchemeVault, &secrets.VaultProvider{
+			Address:   v.Address,
+			Token:     v.Token,
+			TokenFile: v.TokenFile,
+			Namespace: v.Namespace,
+			Mount:     v.Mount,
+		})
+	}
+	if a := cfg.Secrets.AWS; a.Region != "" {
+		r.Register(secrets.SchemeAWS, &secrets.AWSProvider{Region: a.Region, Endpoint: a.Endpoint})
+	}
+	return r
+}
+
+// watchSecretRotation periodically re-reads secret references. Clients are
+// built from the values resolved at startup, so a rotation is reported (log
+// and metric) for the deployment to be rolled rather than applied in place.
+func watchSecretRotation(ctx context.Context, r *secrets.Resolver, refs config.SecretRefs, interval time.Duration, l corelogger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			changed, err := r.Refresh(ctx)
+			if err != nil {
+				l.Warn("Secret refresh failed; keeping previous values", "error", err)
+			}
+			for _, field := range refs.FieldsFor(changed) {
+				metrics.SecretRotationsTotal.WithLabelValues(field).Inc()
+				l.Warn("Secret rotated; restart to apply the new value", "field", field)
+			}
+		}
+	}
+}
+
+// configErrorReport renders a config load failure, listing every problem
+// when the configuration was invalid.
+func configErrorReport(err error) string {
+	var loadErr *config.LoadError
+	if errors.As(err, &loadErr) {
+		return loadErr.Report()
+	}
+	return fmt.Sprintf("Failed to load configuration: %v", err)
+}
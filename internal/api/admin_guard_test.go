@@ -0,0 +1,120 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/mariadb"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const adminToken = "admin-t0ken"
+
+func newAdminTestServer(admins ...config.AdminTokenConfig) *Server {
+	log := logger.New("error")
+	cfg := &config.Config{Environment: "test", Port: 0}
+	cfg.Admin.Tokens = admins
+	cfg.Profiling.Enabled = true
+	vms := &services.VictoriaMetricsServices{
+		Metrics: services.NewVictoriaMetricsService(config.VictoriaMetricsConfig{}, log),
+		Logs:    services.NewVictoriaLogsService(config.VictoriaLogsConfig{}, log),
+		Traces:  services.NewVictoriaTracesService(config.VictoriaTracesConfig{}, log),
+	}
+	return NewServer(cfg, log, cache.NewNoopValkeyCache(log), vms, nil, (*mariadb.Client)(nil))
+}
+
+// TestAdminRoutesRequireToken walks every registered /api/v1/admin route:
+// each must answer 401 without an admin token or with a wrong one, and 404
+// when no admin tokens are configured, so admin routes added later are
+// covered automatically.
+func TestAdminRoutesRequireToken(t *testing.T) {
+	guarded := newAdminTestServer(config.AdminTokenConfig{Name: "ops", Token: adminToken})
+	disabled := newAdminTestServer()
+
+	admin := 0
+	for _, r := range guarded.router.Routes() {
+		if r.Path != "/api/v1/admin" && !strings.HasPrefix(r.Path, "/api/v1/admin/") {
+			continue
+		}
+		admin++
+		for _, tc := range []struct {
+			s    *Server
+			auth string
+			want int
+		}{
+			{guarded, "", http.StatusUnauthorized},
+			{guarded, "Bearer wrong", http.StatusUnauthorized},
+			{disabled, "Bearer " + adminToken, http.StatusNotFound},
+		} {
+			req := httptest.NewRequest(r.Method, concretePath(r.Path), strings.NewReader("{}"))
+			req.Header.Set("Content-Type", "application/json")
+			if tc.auth != "" {
+				req.Header.Set("Authorization", tc.auth)
+			}
+			w := httptest.NewRecorder()
+			tc.s.router.ServeHTTP(w, req)
+			if w.Code != tc.want {
+				t.Errorf("%s %s with %q: expected %d, got %d", r.Method, r.Path, tc.auth, tc.want, w.Code)
+			}
+		}
+	}
+	if admin == 0 {
+		t.Fatal("expected admin routes to be registered")
+	}
+
+	// A configured token opens them.
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/maintenance", nil)
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	guarded.router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("admin token on /api/v1/admin/maintenance: expected 200, got %d", w.Code)
+	}
+
+	// Non-admin routes are unaffected.
+	w = httptest.NewRecorder()
+	disabled.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("/api/openapi.json without a token: expected 200, got %d", w.Code)
+	}
+}
+
+// Query templates are reviewed by the admin named by the token, whatever
+// X-User-ID the caller sends.
+func TestQueryTemplateReviewerIsTheAdmin(t *testing.T) {
+	s := newAdminTestServer(
+		config.AdminTokenConfig{Name: "alice", Token: "alice-t0ken"},
+		config.AdminTokenConfig{Name: "bob", Token: "bob-t0ken"},
+	)
+	do := func(method, path, token, user, body string) int {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		if user != "" {
+			req.Header.Set("X-User-ID", user)
+		}
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := do(http.MethodPut, "/api/v1/query-templates/errors", "", "alice", `{"engine":"metrics","query":"sum(rate(errors_total[5m]))"}`); code != http.StatusOK {
+		t.Fatalf("put template: expected 200, got %d", code)
+	}
+	if code := do(http.MethodPost, "/api/v1/admin/query-templates/errors/approve", "", "bob", ""); code != http.StatusUnauthorized {
+		t.Fatalf("approve without an admin token: expected 401, got %d", code)
+	}
+	if code := do(http.MethodPost, "/api/v1/admin/query-templates/errors/approve", "alice-t0ken", "bob", ""); code != http.StatusForbidden {
+		t.Fatalf("author approving with another X-User-ID: expected 403, got %d", code)
+	}
+	if code := do(http.MethodPost, "/api/v1/admin/query-templates/errors/approve", "bob-t0ken", "alice", ""); code != http.StatusOK {
+		t.Fatalf("another admin approving: expected 200, got %d", code)
+	}
+}
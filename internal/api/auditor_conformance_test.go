@@ -0,0 +1,118 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/api/middleware"
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/mariadb"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const auditorToken = "auditor-t0ken"
+
+// TestAuditorConformance walks every registered route: an auditor token must
+// get 403 on each mutation and reach the handler on each read. A new route
+// is covered automatically; a mutating POST that only reads data must be
+// listed in readOnlyPostPaths to be served to auditors. Auditors may also
+// cancel their own queries.
+func TestAuditorConformance(t *testing.T) {
+	log := logger.New("error")
+	cfg := &config.Config{Environment: "test", Port: 0}
+	cfg.Auditors = []config.AuditorConfig{{Name: "soc2", Token: auditorToken}}
+	// Register the KPI routes too; nothing connects because every request
+	// below is rejected before reaching a handler.
+	cfg.Weaviate = config.WeaviateConfig{Enabled: true, Scheme: "http", Host: "127.0.0.1", Port: 1}
+	vms := &services.VictoriaMetricsServices{
+		Metrics: services.NewVictoriaMetricsService(config.VictoriaMetricsConfig{}, log),
+		Logs:    services.NewVictoriaLogsService(config.VictoriaLogsConfig{}, log),
+		Traces:  services.NewVictoriaTracesService(config.VictoriaTracesConfig{}, log),
+	}
+	s := NewServer(cfg, log, cache.NewNoopValkeyCache(log), vms, nil, (*mariadb.Client)(nil))
+	routes := s.router.Routes()
+	if len(routes) == 0 {
+		t.Fatal("no routes registered")
+	}
+
+	allowed := append([]string{queryCancelRoute}, readOnlyPostPaths...)
+	readOnly := map[string]bool{}
+	for _, p := range allowed {
+		readOnly[p] = true
+	}
+	isRead := func(method, path string) bool {
+		switch method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return true
+		}
+		return readOnly[path]
+	}
+
+	// Reads are checked against the same middleware with stub handlers, so
+	// the check does not depend on backends being reachable.
+	gin.SetMode(gin.TestMode)
+	stub := gin.New()
+	stub.Use(middleware.AuditorReadOnly(cfg.Auditors, allowed...))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+
+	mutations := 0
+	for _, r := range routes {
+		path := concretePath(r.Path)
+		req := httptest.NewRequest(r.Method, path, strings.NewReader("{}"))
+		req.Header.Set("Authorization", "Bearer "+auditorToken)
+		req.Header.Set("Content-Type", "application/json")
+		if isRead(r.Method, r.Path) {
+			stub.Handle(r.Method, r.Path, ok)
+			w := httptest.NewRecorder()
+			stub.ServeHTTP(w, req)
+			if w.Code != http.StatusOK {
+				t.Errorf("auditor read %s %s: expected 200, got %d", r.Method, r.Path, w.Code)
+			}
+			continue
+		}
+		mutations++
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		if w.Code != http.StatusForbidden {
+			t.Errorf("auditor mutation %s %s: expected 403, got %d", r.Method, r.Path, w.Code)
+		}
+	}
+	if mutations == 0 {
+		t.Fatal("expected mutating routes to be registered")
+	}
+
+	// The real router serves auditor reads.
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil)
+	req.Header.Set("Authorization", "Bearer "+auditorToken)
+	s.router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("auditor read of /api/openapi.json: expected 200, got %d", w.Code)
+	}
+
+	// Other tokens are not auditors.
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPut, "/api/v1/admin/maintenance", strings.NewReader("{}"))
+	req.Header.Set("Authorization", "Bearer someone-else")
+	s.router.ServeHTTP(w, req)
+	if w.Code == http.StatusForbidden {
+		t.Fatal("non-auditor token must not be treated as an auditor")
+	}
+}
+
+// concretePath fills route parameters with placeholder values.
+func concretePath(route string) string {
+	parts := strings.Split(route, "/")
+	for i, p := range parts {
+		if strings.HasPrefix(p, ":") || strings.HasPrefix(p, "*") {
+			parts[i] = "x"
+		}
+	}
+	return strings.Join(parts, "/")
+}
@@ -2,3 +2,17 @@ package constants
 
 // Anonymous tenant ID for unauthenticated requests
 const AnonymousTenantID = "anonymous"
+
+// Caller identity headers forwarded by the authenticating gateway
+const (
+	HeaderTenantID = "X-Tenant-ID"
+	HeaderUserID   = "X-User-ID"
+)
+
+// HeaderTrafficClass lets callers run a request as background or batch
+// traffic (see internal/qos); requests default to interactive.
+const HeaderTrafficClass = "X-Traffic-Class"
+
+// HeaderQueryID carries the ID of a tracked query request, which its caller
+// can cancel with DELETE /api/v1/queries/{id}.
+const HeaderQueryID = "X-Query-ID"
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/api/constants"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// AnonymizationHandler manages anonymization profiles and their reports.
+type AnonymizationHandler struct {
+	anonymization *services.AnonymizationService
+	logger        logging.Logger
+}
+
+// NewAnonymizationHandler creates a new anonymization handler.
+func NewAnonymizationHandler(anonymization *services.AnonymizationService, logger corelogger.Logger) *AnonymizationHandler {
+	return &AnonymizationHandler{
+		anonymization: anonymization,
+		logger:        logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/anonymization/profiles - List anonymization profiles
+func (h *AnonymizationHandler) List(c *gin.Context) {
+	list, err := h.anonymization.List(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to list anonymization profiles", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "error": "Failed to list anonymization profiles"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      gin.H{"profiles": list},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// GET /api/v1/anonymization/profiles/:name - Get an anonymization profile
+func (h *AnonymizationHandler) Get(c *gin.Context) {
+	p, err := h.anonymization.Get(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		h.respondError(c, err, "Failed to get anonymization profile")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      p,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// PUT /api/v1/anonymization/profiles/:name - Create or replace an anonymization profile
+func (h *AnonymizationHandler) Put(c *gin.Context) {
+	var req models.AnonymizationProfile
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": "Invalid anonymization profile payload"})
+		return
+	}
+	req.Name = c.Param("name")
+	req.UpdatedBy = c.GetHeader(constants.HeaderUserID)
+
+	p, err := h.anonymization.Put(c.Request.Context(), req)
+	if err != nil {
+		h.respondError(c, err, "Failed to store anonymization profile")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      p,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// DELETE /api/v1/anonymization/profiles/:name - Delete an anonymization profile
+func (h *AnonymizationHandler) Delete(c *gin.Context) {
+	if err := h.anonymization.Delete(c.Request.Context(), c.Param("name"), c.GetHeader(constants.HeaderUserID)); err != nil {
+		h.respondError(c, err, "Failed to delete anonymization profile")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      gin.H{"deleted": true},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// POST /api/v1/anonymization/profiles/:name/apply - Anonymize records such as a report artifact
+func (h *AnonymizationHandler) Apply(c *gin.Context) {
+	var req models.AnonymizationApplyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": "Invalid anonymization payload"})
+		return
+	}
+	result, err := h.anonymization.Apply(c.Request.Context(), c.Param("name"), req)
+	if err != nil {
+		h.respondError(c, err, "Failed to anonymize records")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      result,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// GET /api/v1/anonymization/reports/:id - Get the verification report of an anonymized export
+func (h *AnonymizationHandler) GetReport(c *gin.Context) {
+	report, err := h.anonymization.GetReport(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "Failed to get anonymization report")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      report,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+func (h *AnonymizationHandler) respondError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, services.ErrAnonymizationProfileNotFound), errors.Is(err, services.ErrAnonymizationReportNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "error": err.Error()})
+	case errors.Is(err, services.ErrInvalidAnonymizationProfile):
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": err.Error()})
+	case errors.Is(err, services.ErrConfigBusy):
+		c.JSON(http.StatusConflict, gin.H{"status": "error", "error": err.Error()})
+	default:
+		h.logger.Error(msg, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "error": msg})
+	}
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// BaselineComparisonHandler serves compare-to-baseline panel data.
+type BaselineComparisonHandler struct {
+	compare *services.BaselineComparisonService
+	logger  logging.Logger
+}
+
+// NewBaselineComparisonHandler creates a new baseline comparison handler.
+func NewBaselineComparisonHandler(compare *services.BaselineComparisonService, logger corelogger.Logger) *BaselineComparisonHandler {
+	return &BaselineComparisonHandler{
+		compare: compare,
+		logger:  logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/analytics/compare - a KPI (?kpi=id) or query (?query=) over start..end and offset baselines (?offsets=1w,4w&step=5m)
+func (h *BaselineComparisonHandler) Compare(c *gin.Context) {
+	now := time.Now().UTC()
+	req := models.BaselineComparisonRequest{
+		KPIID: strings.TrimSpace(c.Query("kpi")),
+		Query: c.Query("query"),
+		Start: now.Add(-time.Hour),
+		End:   now,
+	}
+	for name, dst := range map[string]*time.Time{"start": &req.Start, "end": &req.End} {
+		if v := c.Query(name); v != "" {
+			t, ok := parseNowLike(v, now)
+			if !ok {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"status": "error",
+					"error":  name + " must be an RFC3339 timestamp or now-<duration>",
+				})
+				return
+			}
+			*dst = t
+		}
+	}
+	if s := c.Query("step"); s != "" {
+		step, err := time.ParseDuration(s)
+		if err != nil || step <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error":  "step must be a positive duration such as 30s or 5m",
+			})
+			return
+		}
+		req.Step = step
+	}
+	for _, o := range strings.Split(c.Query("offsets"), ",") {
+		if o = strings.TrimSpace(o); o != "" {
+			req.Offsets = append(req.Offsets, o)
+		}
+	}
+
+	result, err := h.compare.Compare(c.Request.Context(), req)
+	switch {
+	case errors.Is(err, services.ErrKPINotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": "error",
+			"error":  "KPI not found",
+		})
+		return
+	case errors.Is(err, services.ErrInvalidBaselineRequest), errors.Is(err, services.ErrKPINoFormula):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  err.Error(),
+		})
+		return
+	case writeTooManyResults(c, err):
+		return
+	case err != nil:
+		h.logger.Error("Baseline comparison failed", "kpi", req.KPIID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to compare against baseline",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      result,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// CacheNamespaceHandler exposes per-tenant cache usage and flush.
+type CacheNamespaceHandler struct {
+	namespaces *services.CacheNamespaceService
+	logger     logging.Logger
+}
+
+// NewCacheNamespaceHandler creates a new cache namespace handler.
+func NewCacheNamespaceHandler(namespaces *services.CacheNamespaceService, logger corelogger.Logger) *CacheNamespaceHandler {
+	return &CacheNamespaceHandler{
+		namespaces: namespaces,
+		logger:     logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/admin/cache/tenants - Key counts per tenant namespace
+func (h *CacheNamespaceHandler) ListTenants(c *gin.Context) {
+	usage, err := h.namespaces.TenantUsage(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to collect tenant cache usage", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to collect tenant cache usage",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      gin.H{"tenants": usage},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// DELETE /api/v1/admin/cache/tenants/:tenant - Flush one tenant's namespace
+func (h *CacheNamespaceHandler) FlushTenant(c *gin.Context) {
+	tenant := c.Param("tenant")
+	deleted, err := h.namespaces.FlushTenant(c.Request.Context(), tenant)
+	if errors.Is(err, services.ErrInvalidTenant) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid tenant id",
+		})
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to flush tenant cache", "tenant", tenant, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to flush tenant cache",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      gin.H{"tenant": tenant, "deleted": deleted},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
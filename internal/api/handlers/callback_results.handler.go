@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// CallbackResultHandler serves job results pushed by AI engines over the
+// callback gRPC server.
+type CallbackResultHandler struct {
+	results *services.CallbackResultService
+	logger  logging.Logger
+}
+
+// NewCallbackResultHandler creates a new callback result handler.
+func NewCallbackResultHandler(results *services.CallbackResultService, logger corelogger.Logger) *CallbackResultHandler {
+	return &CallbackResultHandler{
+		results: results,
+		logger:  logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/callbacks/results/:job_id - Latest result an engine delivered for a job
+func (h *CallbackResultHandler) GetResult(c *gin.Context) {
+	result, err := h.results.Get(c.Request.Context(), c.Param("job_id"))
+	switch {
+	case errors.Is(err, services.ErrInvalidCallbackResult):
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": err.Error()})
+		return
+	case errors.Is(err, services.ErrCallbackResultNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "error": "No result delivered for this job yet"})
+		return
+	case err != nil:
+		h.logger.Error("Failed to load callback result", "job_id", c.Param("job_id"), "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to retrieve result",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      result,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// CapacityHandler serves capacity planning forecasts.
+type CapacityHandler struct {
+	capacity *services.CapacityService
+	logger   logging.Logger
+}
+
+// NewCapacityHandler creates a new capacity planning handler.
+func NewCapacityHandler(capacity *services.CapacityService, logger corelogger.Logger) *CapacityHandler {
+	return &CapacityHandler{
+		capacity: capacity,
+		logger:   logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/analytics/capacity - resource KPI series projected to breach within ?days=N (?kpis=id1,id2 selects KPIs, ?refresh=true recomputes)
+func (h *CapacityHandler) GetForecast(c *gin.Context) {
+	var req models.CapacityRequest
+	if d := c.Query("days"); d != "" {
+		days, err := strconv.Atoi(d)
+		if err != nil || days <= 0 || days > 365 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error":  "days must be an integer between 1 and 365",
+			})
+			return
+		}
+		req.HorizonDays = days
+	}
+	for _, id := range strings.Split(c.Query("kpis"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			req.KPIIDs = append(req.KPIIDs, id)
+		}
+	}
+
+	report, err := h.capacity.Report(c.Request.Context(), req, c.Query("refresh") == "true")
+	if err != nil {
+		h.logger.Error("Failed to compute capacity forecast", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to compute capacity forecast",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      report,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
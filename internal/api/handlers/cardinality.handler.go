@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// CardinalityHandler serves label cardinality analysis.
+type CardinalityHandler struct {
+	cardinality *services.LabelCardinalityService
+	logger      logging.Logger
+}
+
+// NewCardinalityHandler creates a new cardinality handler.
+func NewCardinalityHandler(cardinality *services.LabelCardinalityService, logger corelogger.Logger) *CardinalityHandler {
+	return &CardinalityHandler{
+		cardinality: cardinality,
+		logger:      logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/metrics/cardinality/labels - Rank labels by distinct values and flag high-cardinality ones
+func (h *CardinalityHandler) Scan(c *gin.Context) {
+	if c.Query("latest") == "true" {
+		scan, err := h.cardinality.LatestScan(c.Request.Context())
+		if err != nil {
+			h.respondError(c, err, "Failed to read cardinality scan")
+			return
+		}
+		if scan == nil {
+			c.JSON(http.StatusNotFound, gin.H{"status": "error", "error": "no cardinality scan stored yet"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"status":    "success",
+			"data":      scan,
+			"timestamp": time.Now().Format(time.RFC3339),
+		})
+		return
+	}
+	start, end, ok := cardinalityRange(c)
+	if !ok {
+		return
+	}
+	scan, err := h.cardinality.Scan(c.Request.Context(), models.CardinalityScanRequest{
+		Start: start,
+		End:   end,
+		Match: c.QueryArray("match[]"),
+	})
+	if err != nil {
+		h.respondError(c, err, "Cardinality scan failed")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      scan,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// GET /api/v1/metrics/cardinality/labels/:label - Estimate distinct and top values of a label
+func (h *CardinalityHandler) Analyze(c *gin.Context) {
+	start, end, ok := cardinalityRange(c)
+	if !ok {
+		return
+	}
+	topK := 0
+	if v := c.Query("topK"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": "topK must be a positive integer"})
+			return
+		}
+		topK = n
+	}
+	result, err := h.cardinality.Analyze(c.Request.Context(), models.LabelCardinalityRequest{
+		Label: c.Param("label"),
+		Start: start,
+		End:   end,
+		Match: c.QueryArray("match[]"),
+		TopK:  topK,
+	})
+	if err != nil {
+		h.respondError(c, err, "Cardinality analysis failed")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      result,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// cardinalityRange parses the optional RFC3339 start and end parameters.
+func cardinalityRange(c *gin.Context) (start, end time.Time, ok bool) {
+	for _, p := range []struct {
+		name string
+		dst  *time.Time
+	}{{"start", &start}, {"end", &end}} {
+		v := c.Query(p.name)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": p.name + " must be an RFC3339 time"})
+			return start, end, false
+		}
+		*p.dst = t
+	}
+	return start, end, true
+}
+
+func (h *CardinalityHandler) respondError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, services.ErrInvalidCardinalityRequest):
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": err.Error()})
+	case errors.Is(err, services.ErrFormulaCatalogUnavailable):
+		h.logger.Warn(msg, "error", err)
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "error", "error": err.Error()})
+	default:
+		h.logger.Error(msg, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "error": msg})
+	}
+}
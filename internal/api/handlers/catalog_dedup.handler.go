@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/api/constants"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// CatalogDedupHandler exposes catalog duplicate detection and merge.
+type CatalogDedupHandler struct {
+	dedup  *services.CatalogDedupService
+	logger logging.Logger
+}
+
+// NewCatalogDedupHandler creates a new catalog duplicate handler.
+func NewCatalogDedupHandler(dedup *services.CatalogDedupService, logger corelogger.Logger) *CatalogDedupHandler {
+	return &CatalogDedupHandler{
+		dedup:  dedup,
+		logger: logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/kpi/duplicates - Candidate duplicate pairs (?refresh=true to rescan)
+func (h *CatalogDedupHandler) ListDuplicates(c *gin.Context) {
+	var (
+		report *models.CatalogDuplicateReport
+		err    error
+	)
+	if c.Query("refresh") == "true" {
+		report, err = h.dedup.Detect(c.Request.Context())
+	} else {
+		report, err = h.dedup.LastReport(c.Request.Context())
+	}
+	if err != nil {
+		h.logger.Error("Failed to detect catalog duplicates", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to detect catalog duplicates",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      report,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// POST /api/v1/kpi/duplicates/merge - Merge a duplicate into a survivor
+func (h *CatalogDedupHandler) Merge(c *gin.Context) {
+	var req models.CatalogMergeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid request body: 'survivorId' and 'duplicateId' are required",
+		})
+		return
+	}
+	if req.MergedBy == "" {
+		req.MergedBy = c.GetHeader(constants.HeaderUserID)
+	}
+
+	record, err := h.dedup.Merge(c.Request.Context(), req)
+	switch {
+	case errors.Is(err, services.ErrCatalogEntryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "error": err.Error()})
+		return
+	case errors.Is(err, services.ErrCatalogSelfMerge), errors.Is(err, services.ErrCatalogTypeMismatch):
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": err.Error()})
+		return
+	case err != nil:
+		h.logger.Error("Failed to merge catalog entries", "survivor", req.SurvivorID, "duplicate", req.DuplicateID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to merge catalog entries",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      record,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// GET /api/v1/kpi/duplicates/history - Past merges, newest first
+func (h *CatalogDedupHandler) History(c *gin.Context) {
+	history, err := h.dedup.History(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to read catalog merge history", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to read catalog merge history",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      gin.H{"merges": history},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// CatalogRefreshHandler receives deploy webhooks from CI/CD.
+type CatalogRefreshHandler struct {
+	refresh *services.CatalogRefreshService
+	logger  logging.Logger
+}
+
+// NewCatalogRefreshHandler creates a new catalog refresh webhook handler.
+func NewCatalogRefreshHandler(refresh *services.CatalogRefreshService, logger corelogger.Logger) *CatalogRefreshHandler {
+	return &CatalogRefreshHandler{
+		refresh: refresh,
+		logger:  logging.FromCoreLogger(logger),
+	}
+}
+
+// POST /api/v1/catalog/refresh - Refresh the catalog for a deployed service/namespace
+func (h *CatalogRefreshHandler) Webhook(c *gin.Context) {
+	if !h.refresh.VerifyWebhookSecret(c.GetHeader("X-Webhook-Secret")) {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "error": "Invalid webhook secret"})
+		return
+	}
+	var req models.CatalogRefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid request body: 'service' or 'namespace' is required",
+		})
+		return
+	}
+
+	result, err := h.refresh.Refresh(c.Request.Context(), req)
+	switch {
+	case errors.Is(err, services.ErrInvalidCatalogRefresh):
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": err.Error()})
+		return
+	case errors.Is(err, services.ErrFormulaCatalogUnavailable):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "error", "error": err.Error()})
+		return
+	case err != nil:
+		h.logger.Error("Failed to refresh catalog", "service", req.Service, "namespace", req.Namespace, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "error": "Failed to refresh catalog"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      result,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	"github.com/mirastacklabs-ai/mirador-core/internal/utils/pagination"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// ChangeFeedHandler serves change feeds for offline-capable clients.
+type ChangeFeedHandler struct {
+	feed   *services.ChangeFeedService
+	logger logging.Logger
+}
+
+// NewChangeFeedHandler creates a new change feed handler.
+func NewChangeFeedHandler(feed *services.ChangeFeedService, logger corelogger.Logger) *ChangeFeedHandler {
+	return &ChangeFeedHandler{
+		feed:   feed,
+		logger: logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/changes/:resource - changes since a resume token (?since=<next>&limit=500)
+func (h *ChangeFeedHandler) Changes(c *gin.Context) {
+	limit := 0
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": "limit must be a positive integer"})
+			return
+		}
+		limit = n
+	}
+
+	page, err := h.feed.Changes(c.Request.Context(), c.Param("resource"), c.Query("since"), limit)
+	switch {
+	case errors.Is(err, services.ErrUnknownChangeFeed):
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "error": err.Error()})
+		return
+	case errors.Is(err, pagination.ErrInvalidCursor):
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": err.Error()})
+		return
+	case errors.Is(err, services.ErrChangeFeedExpired):
+		c.JSON(http.StatusGone, gin.H{"status": "error", "error": err.Error()})
+		return
+	case err != nil:
+		h.logger.Error("Failed to read change feed", "resource", c.Param("resource"), "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "error": "Failed to read change feed"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      page,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// ComplianceHandler serves the compliance control report.
+type ComplianceHandler struct {
+	compliance *services.ComplianceService
+	logger     logging.Logger
+}
+
+// NewComplianceHandler creates a new compliance handler.
+func NewComplianceHandler(compliance *services.ComplianceService, logger corelogger.Logger) *ComplianceHandler {
+	return &ComplianceHandler{
+		compliance: compliance,
+		logger:     logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/admin/compliance/report - Evaluate controls against the running configuration
+func (h *ComplianceHandler) GetReport(c *gin.Context) {
+	report, err := h.compliance.Report(c.Request.Context(), c.Query("framework"))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidComplianceFramework) {
+			c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": err.Error()})
+			return
+		}
+		h.logger.Error("Failed to build compliance report", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "error": "Failed to build compliance report"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      report,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/api/constants"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// ComputedColumnHandler manages computed columns for logs results.
+type ComputedColumnHandler struct {
+	columns *services.ComputedColumnService
+	logger  logging.Logger
+}
+
+// NewComputedColumnHandler creates a new computed column handler.
+func NewComputedColumnHandler(columns *services.ComputedColumnService, logger corelogger.Logger) *ComputedColumnHandler {
+	return &ComputedColumnHandler{
+		columns: columns,
+		logger:  logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/computed-columns - List computed columns
+func (h *ComputedColumnHandler) List(c *gin.Context) {
+	list, err := h.columns.List(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to list computed columns", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "error": "Failed to list computed columns"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      gin.H{"columns": list},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// GET /api/v1/computed-columns/:name - Get a computed column
+func (h *ComputedColumnHandler) Get(c *gin.Context) {
+	col, err := h.columns.Get(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		h.respondError(c, err, "Failed to get computed column")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      col,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// PUT /api/v1/computed-columns/:name - Create or replace a computed column
+func (h *ComputedColumnHandler) Put(c *gin.Context) {
+	var req models.ComputedColumn
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": "Invalid computed column payload"})
+		return
+	}
+	req.Name = c.Param("name")
+	req.UpdatedBy = c.GetHeader(constants.HeaderUserID)
+
+	col, err := h.columns.Put(c.Request.Context(), req)
+	if err != nil {
+		h.respondError(c, err, "Failed to store computed column")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      col,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// DELETE /api/v1/computed-columns/:name - Delete a computed column
+func (h *ComputedColumnHandler) Delete(c *gin.Context) {
+	if err := h.columns.Delete(c.Request.Context(), c.Param("name"), c.GetHeader(constants.HeaderUserID)); err != nil {
+		h.respondError(c, err, "Failed to delete computed column")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      gin.H{"deleted": true},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// POST /api/v1/computed-columns/preview - Evaluate an expression against sample rows
+func (h *ComputedColumnHandler) Preview(c *gin.Context) {
+	var req models.ComputedColumnPreviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": "Invalid preview payload"})
+		return
+	}
+	preview, err := h.columns.Preview(req)
+	if err != nil {
+		h.respondError(c, err, "Failed to preview computed column")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      preview,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+func (h *ComputedColumnHandler) respondError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, services.ErrComputedColumnNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "error": err.Error()})
+	case errors.Is(err, services.ErrInvalidComputedColumn):
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": err.Error()})
+	case errors.Is(err, services.ErrConfigBusy):
+		c.JSON(http.StatusConflict, gin.H{"status": "error", "error": err.Error()})
+	default:
+		h.logger.Error(msg, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "error": msg})
+	}
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/api/constants"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// CorrelationCollabHandler exposes share links and comments for
+// correlation runs.
+type CorrelationCollabHandler struct {
+	collab *services.CorrelationCollabService
+	logger logging.Logger
+}
+
+// NewCorrelationCollabHandler creates a new correlation sharing handler.
+func NewCorrelationCollabHandler(collab *services.CorrelationCollabService, logger corelogger.Logger) *CorrelationCollabHandler {
+	return &CorrelationCollabHandler{
+		collab: collab,
+		logger: logging.FromCoreLogger(logger),
+	}
+}
+
+// POST /api/v1/correlation/runs/:id/share - Create a share link for a run
+func (h *CorrelationCollabHandler) Share(c *gin.Context) {
+	var req models.CorrelationShareRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": "Invalid request body"})
+			return
+		}
+	}
+
+	share, err := h.collab.Share(c.Request.Context(), c.Param("id"), req, c.GetHeader(constants.HeaderUserID))
+	if err != nil {
+		h.respondError(c, err, "Failed to share correlation run")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status":    "success",
+		"data":      share,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// DELETE /api/v1/correlation/shares/:token - Revoke a share link
+func (h *CorrelationCollabHandler) Revoke(c *gin.Context) {
+	if err := h.collab.Revoke(c.Request.Context(), c.Param("token")); err != nil {
+		h.respondError(c, err, "Failed to revoke share link")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      gin.H{"revoked": true},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// GET /api/v1/correlation/shared/:token - Resolve a share link to the run and its comments
+func (h *CorrelationCollabHandler) Shared(c *gin.Context) {
+	shared, err := h.collab.Shared(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		h.respondError(c, err, "Failed to load shared correlation run")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      shared,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// GET /api/v1/correlation/runs/:id/comments - Comment threads of a run
+func (h *CorrelationCollabHandler) ListComments(c *gin.Context) {
+	comments, err := h.collab.Comments(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "Failed to list comments")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      gin.H{"comments": comments},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// POST /api/v1/correlation/runs/:id/comments - Comment on a run or one of its cause candidates
+func (h *CorrelationCollabHandler) AddComment(c *gin.Context) {
+	var req models.CorrelationCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid request body: 'body' is required",
+		})
+		return
+	}
+
+	comment, err := h.collab.AddComment(c.Request.Context(), c.Param("id"), req, c.GetHeader(constants.HeaderUserID))
+	if err != nil {
+		h.respondError(c, err, "Failed to add comment")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status":    "success",
+		"data":      comment,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+func (h *CorrelationCollabHandler) respondError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, services.ErrCorrelationRunNotFound), errors.Is(err, services.ErrShareNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "error": err.Error()})
+	case errors.Is(err, services.ErrInvalidComment):
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": err.Error()})
+	case errors.Is(err, services.ErrCommentsUnavailable):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "error", "error": err.Error()})
+	default:
+		h.logger.Error(msg, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "error": msg})
+	}
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/api/constants"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// CorrelationEvalHandler exposes correlation feedback and the A/B replay
+// harness for tuning engine scoring.
+type CorrelationEvalHandler struct {
+	eval   *services.CorrelationEvalService
+	logger logging.Logger
+}
+
+// NewCorrelationEvalHandler creates a new correlation evaluation handler.
+func NewCorrelationEvalHandler(eval *services.CorrelationEvalService, logger corelogger.Logger) *CorrelationEvalHandler {
+	return &CorrelationEvalHandler{
+		eval:   eval,
+		logger: logging.FromCoreLogger(logger),
+	}
+}
+
+// POST /api/v1/correlation/feedback - Label a recorded correlation run
+func (h *CorrelationEvalHandler) SubmitFeedback(c *gin.Context) {
+	var req models.FeedbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.CorrelationID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid request body: 'correlation_id' is required",
+		})
+		return
+	}
+
+	fb, err := h.eval.RecordFeedback(c.Request.Context(), req, c.GetHeader(constants.HeaderUserID))
+	switch {
+	case errors.Is(err, services.ErrCorrelationRunNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "error": err.Error()})
+		return
+	case err != nil:
+		h.logger.Error("Failed to store correlation feedback", "correlation_id", req.CorrelationID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to store correlation feedback",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      models.FeedbackResponse{CorrelationID: fb.CorrelationID, Accepted: true},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// POST /api/v1/admin/correlation/evaluate - Replay recorded runs under two scoring variants
+func (h *CorrelationEvalHandler) Evaluate(c *gin.Context) {
+	var req models.CorrelationEvalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid request body",
+		})
+		return
+	}
+
+	report, err := h.eval.Evaluate(c.Request.Context(), req)
+	if err != nil {
+		h.logger.Error("Correlation evaluation failed", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Correlation evaluation failed",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      report,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
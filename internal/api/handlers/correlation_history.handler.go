@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/api/constants"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// CorrelationHistoryHandler serves stored correlation results and their
+// annotations.
+type CorrelationHistoryHandler struct {
+	history *services.CorrelationHistoryService
+	logger  logging.Logger
+}
+
+// NewCorrelationHistoryHandler creates a new correlation history handler.
+func NewCorrelationHistoryHandler(history *services.CorrelationHistoryService, logger corelogger.Logger) *CorrelationHistoryHandler {
+	return &CorrelationHistoryHandler{
+		history: history,
+		logger:  logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/correlation/history - past correlation results, newest first (?incident_id, ?verdict, ?since=RFC3339, ?limit=N)
+func (h *CorrelationHistoryHandler) List(c *gin.Context) {
+	filter := models.CorrelationHistoryFilter{
+		IncidentID: c.Query("incident_id"),
+		Verdict:    c.Query("verdict"),
+	}
+	if raw := c.Query("since"); raw != "" {
+		since, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": "since must be an RFC3339 timestamp"})
+			return
+		}
+		filter.Since = since
+	}
+	if raw := c.Query("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": "limit must be a non-negative integer"})
+			return
+		}
+		filter.Limit = n
+	}
+
+	entries, err := h.history.List(c.Request.Context(), filter)
+	if err != nil {
+		h.respondError(c, err, "Failed to list correlation history")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      gin.H{"correlations": entries, "total": len(entries)},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// GET /api/v1/correlation/history/:id - A stored correlation result
+func (h *CorrelationHistoryHandler) Get(c *gin.Context) {
+	entry, err := h.history.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "Failed to load correlation result")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      entry,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// PUT /api/v1/correlation/history/:id/annotation - Mark a stored result confirmed or false positive
+func (h *CorrelationHistoryHandler) Annotate(c *gin.Context) {
+	var req models.CorrelationAnnotationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid request body: 'verdict' is required",
+		})
+		return
+	}
+
+	entry, err := h.history.Annotate(c.Request.Context(), c.Param("id"), req, c.GetHeader(constants.HeaderUserID))
+	if err != nil {
+		h.respondError(c, err, "Failed to annotate correlation result")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      entry,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+func (h *CorrelationHistoryHandler) respondError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, services.ErrCorrelationHistoryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "error": err.Error()})
+	case errors.Is(err, services.ErrInvalidCorrelationAnnotation):
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": err.Error()})
+	case errors.Is(err, services.ErrCorrelationHistoryUnavailable):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "error", "error": err.Error()})
+	default:
+		h.logger.Error(msg, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "error": msg})
+	}
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/api/constants"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// CorrelationSuppressionHandler exposes the correlation suppression rule API.
+type CorrelationSuppressionHandler struct {
+	suppressions *services.CorrelationSuppressionService
+	logger       logging.Logger
+}
+
+// NewCorrelationSuppressionHandler creates a new suppression rule handler.
+func NewCorrelationSuppressionHandler(suppressions *services.CorrelationSuppressionService, logger corelogger.Logger) *CorrelationSuppressionHandler {
+	return &CorrelationSuppressionHandler{
+		suppressions: suppressions,
+		logger:       logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/correlation/suppressions - List suppression rules
+func (h *CorrelationSuppressionHandler) ListRules(c *gin.Context) {
+	rules, err := h.suppressions.ListRules(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to list suppression rules", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to retrieve suppression rules",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      gin.H{"rules": rules, "total": len(rules)},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// POST /api/v1/correlation/suppressions - Create a suppression rule
+func (h *CorrelationSuppressionHandler) CreateRule(c *gin.Context) {
+	var req models.CorrelationSuppressionRule
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid request body",
+		})
+		return
+	}
+
+	rule, err := h.suppressions.CreateRule(c.Request.Context(), req, c.GetHeader(constants.HeaderUserID))
+	switch {
+	case errors.Is(err, services.ErrInvalidSuppressionRule):
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": err.Error()})
+		return
+	case errors.Is(err, services.ErrConfigBusy):
+		c.JSON(http.StatusConflict, gin.H{"status": "error", "error": err.Error()})
+		return
+	case err != nil:
+		h.logger.Error("Failed to create suppression rule", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to create suppression rule",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status":    "success",
+		"data":      rule,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// DELETE /api/v1/correlation/suppressions/:id - Delete a suppression rule
+func (h *CorrelationSuppressionHandler) DeleteRule(c *gin.Context) {
+	id := c.Param("id")
+	err := h.suppressions.DeleteRule(c.Request.Context(), id, c.GetHeader(constants.HeaderUserID))
+	switch {
+	case errors.Is(err, services.ErrSuppressionRuleNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "error": err.Error()})
+		return
+	case errors.Is(err, services.ErrConfigBusy):
+		c.JSON(http.StatusConflict, gin.H{"status": "error", "error": err.Error()})
+		return
+	case err != nil:
+		h.logger.Error("Failed to delete suppression rule", "id", id, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to delete suppression rule",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      gin.H{"id": id, "deleted": true},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// CORSHandler exposes the effective CORS policy for troubleshooting.
+type CORSHandler struct {
+	policy *services.CORSPolicyService
+	logger logging.Logger
+}
+
+// NewCORSHandler creates a new CORS policy handler.
+func NewCORSHandler(policy *services.CORSPolicyService, logger corelogger.Logger) *CORSHandler {
+	return &CORSHandler{
+		policy: policy,
+		logger: logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/admin/cors?origin=https://ops.example.com - Effective policy and the decision for an origin
+func (h *CORSHandler) TestOrigin(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      h.policy.Policy(c.Request.Context(), c.Query("origin")),
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/api/constants"
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// DynamicConfigHandler exposes the version history of dynamic config
+// documents (feature_flags, grpc_endpoints, config_overrides, log_levels),
+// rolls them back, and reports the effective configuration.
+type DynamicConfigHandler struct {
+	store  *services.DynamicConfigService
+	cfg    *config.Config // running config, tenant overrides applied
+	logger logging.Logger
+}
+
+// NewDynamicConfigHandler creates a new dynamic config handler.
+func NewDynamicConfigHandler(store *services.DynamicConfigService, cfg *config.Config, logger corelogger.Logger) *DynamicConfigHandler {
+	return &DynamicConfigHandler{
+		store:  store,
+		cfg:    cfg,
+		logger: logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/admin/config/effective - Resolved config with secrets redacted
+func (h *DynamicConfigHandler) Effective(c *gin.Context) {
+	overrides, err := h.store.GetConfigOverrides(c.Request.Context())
+	if err != nil {
+		h.writeError(c, services.DynamicConfigOverrides, err)
+		return
+	}
+
+	data := gin.H{
+		"precedence":      config.Precedence,
+		"sources":         h.cfg.Sources,
+		"overrides":       overrides,
+		"restartRequired": !sameOverrides(overrides, h.cfg.Overrides),
+	}
+	// Stored overrides are resolved against the static layers; if they no
+	// longer validate (e.g. after a config file change) the running config
+	// is reported instead.
+	effective, err := config.ApplyOverrides(h.cfg, overrides)
+	if err != nil {
+		data["overridesError"] = err.Error()
+		effective = h.cfg
+	}
+	data["config"] = config.RedactedSettings(effective)
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      data,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// PUT /api/v1/admin/config/overrides - Replace the tenant config overrides
+func (h *DynamicConfigHandler) SetOverrides(c *gin.Context) {
+	var req struct {
+		Overrides map[string]any `json:"overrides"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "invalid request body: " + err.Error(),
+		})
+		return
+	}
+	if req.Overrides == nil {
+		req.Overrides = map[string]any{}
+	}
+
+	version, err := h.store.SetConfigOverrides(c.Request.Context(), h.cfg, req.Overrides, c.GetHeader(constants.HeaderUserID))
+	var verrs config.ValidationErrors
+	if errors.As(err, &verrs) {
+		problems := make([]gin.H, 0, len(verrs))
+		for _, ve := range verrs {
+			problems = append(problems, gin.H{"field": ve.Field, "message": ve.Message})
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":   "error",
+			"error":    "invalid config overrides",
+			"problems": problems,
+		})
+		return
+	}
+	if err != nil {
+		h.writeError(c, services.DynamicConfigOverrides, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"version":         version,
+			"restartRequired": !sameOverrides(req.Overrides, h.cfg.Overrides),
+		},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// GET /api/v1/admin/config/:name/versions - List versions, newest first
+func (h *DynamicConfigHandler) ListVersions(c *gin.Context) {
+	name := c.Param("name")
+	versions, err := h.store.ListVersions(c.Request.Context(), name)
+	if err != nil {
+		h.writeError(c, name, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"name":     name,
+			"versions": versions,
+			"total":    len(versions),
+		},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// POST /api/v1/admin/config/:name/rollback - Restore a recorded version
+func (h *DynamicConfigHandler) Rollback(c *gin.Context) {
+	var req struct {
+		Version int `json:"version" binding:"required,min=1"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "version is required",
+		})
+		return
+	}
+
+	name := c.Param("name")
+	version, err := h.store.Rollback(c.Request.Context(), name, req.Version, c.GetHeader(constants.HeaderUserID))
+	if err != nil {
+		h.writeError(c, name, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      version,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// sameOverrides compares two override sets as JSON, treating nil as empty.
+func sameOverrides(a, b map[string]any) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(ja) == string(jb)
+}
+
+func (h *DynamicConfigHandler) writeError(c *gin.Context, name string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrUnknownDynamicConfig), errors.Is(err, services.ErrConfigVersionNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, services.ErrConfigBusy):
+		status = http.StatusConflict
+	default:
+		h.logger.Error("Dynamic config request failed", "config", name, "error", err)
+	}
+	c.JSON(status, gin.H{
+		"status": "error",
+		"error":  err.Error(),
+	})
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// ExecutiveSummaryHandler serves the aggregated tenant health overview.
+type ExecutiveSummaryHandler struct {
+	summary *services.ExecutiveSummaryService
+	logger  logging.Logger
+}
+
+// NewExecutiveSummaryHandler creates a new executive summary handler.
+func NewExecutiveSummaryHandler(summary *services.ExecutiveSummaryService, logger corelogger.Logger) *ExecutiveSummaryHandler {
+	return &ExecutiveSummaryHandler{
+		summary: summary,
+		logger:  logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/summary/executive - tenant health overview (?refresh=true bypasses the cache)
+func (h *ExecutiveSummaryHandler) GetSummary(c *gin.Context) {
+	summary, err := h.summary.Summary(c.Request.Context(), c.Query("refresh") == "true")
+	if err != nil {
+		h.logger.Error("Failed to compute executive summary", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to compute executive summary",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      summary,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// GET /api/v1/summary/executive/snapshots - scheduled summaries for reports, newest first
+func (h *ExecutiveSummaryHandler) ListSnapshots(c *gin.Context) {
+	snapshots, err := h.summary.Snapshots(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to load executive summary snapshots", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to load executive summary snapshots",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      gin.H{"snapshots": snapshots, "total": len(snapshots)},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/faultinject"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// FaultInjectionHandler exposes the dependency fault-injection admin API.
+// It is only registered when fault injection is enabled at startup.
+type FaultInjectionHandler struct {
+	injector *faultinject.Injector
+	logger   logging.Logger
+}
+
+// NewFaultInjectionHandler creates a new fault injection handler.
+func NewFaultInjectionHandler(injector *faultinject.Injector, logger corelogger.Logger) *FaultInjectionHandler {
+	return &FaultInjectionHandler{
+		injector: injector,
+		logger:   logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/admin/faults - List active faults
+func (h *FaultInjectionHandler) ListFaults(c *gin.Context) {
+	faults := h.injector.List()
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"faults":  faults,
+			"targets": faultinject.Targets,
+		},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// PUT /api/v1/admin/faults/:target - Install or replace a fault
+func (h *FaultInjectionHandler) SetFault(c *gin.Context) {
+	var req struct {
+		faultinject.Fault
+		// DurationSeconds auto-expires the fault; 0 keeps it until cleared.
+		DurationSeconds int `json:"durationSeconds"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid fault payload",
+		})
+		return
+	}
+	fault := req.Fault
+	fault.Target = faultinject.Target(c.Param("target"))
+	if req.DurationSeconds > 0 {
+		exp := time.Now().Add(time.Duration(req.DurationSeconds) * time.Second)
+		fault.ExpiresAt = &exp
+	}
+
+	if err := h.injector.Set(fault); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	h.logger.Warn("Fault injected", "target", fault.Target, "latency_ms", fault.LatencyMS, "error_rate", fault.ErrorRate)
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      fault,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// DELETE /api/v1/admin/faults/:target - Clear the fault for a target
+func (h *FaultInjectionHandler) ClearFault(c *gin.Context) {
+	target := faultinject.Target(c.Param("target"))
+	if !faultinject.IsValidTarget(target) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "unknown fault target",
+		})
+		return
+	}
+	h.injector.Clear(target)
+
+	h.logger.Info("Fault cleared", "target", target)
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"target":  target,
+			"cleared": true,
+		},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// DELETE /api/v1/admin/faults - Clear all faults
+func (h *FaultInjectionHandler) ClearAllFaults(c *gin.Context) {
+	h.injector.ClearAll()
+
+	h.logger.Info("All faults cleared")
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"cleared": true,
+		},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
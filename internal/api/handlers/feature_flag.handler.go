@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/api/constants"
+	"github.com/mirastacklabs-ai/mirador-core/internal/api/middleware"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// FeatureFlagHandler exposes feature flag evaluation and admin endpoints.
+type FeatureFlagHandler struct {
+	flags  *services.RuntimeFeatureFlagService
+	logger logging.Logger
+}
+
+// NewFeatureFlagHandler creates a new feature flag handler.
+func NewFeatureFlagHandler(flags *services.RuntimeFeatureFlagService, logger corelogger.Logger) *FeatureFlagHandler {
+	return &FeatureFlagHandler{
+		flags:  flags,
+		logger: logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/features/evaluate?flags=a,b - Evaluate flags for the caller
+func (h *FeatureFlagHandler) EvaluateFlags(c *gin.Context) {
+	var names []string
+	for _, n := range strings.Split(c.Query("flags"), ",") {
+		if n = strings.TrimSpace(n); n != "" {
+			names = append(names, n)
+		}
+	}
+
+	tenantID, userID := middleware.CallerIdentity(c)
+	fctx := services.FeatureFlagContext{TenantID: tenantID, UserID: userID}
+	result, err := h.flags.Evaluate(c.Request.Context(), fctx, names...)
+	if err != nil {
+		h.logger.Error("Failed to evaluate feature flags", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to evaluate feature flags",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"context": fctx,
+			"flags":   result,
+		},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// GET /api/v1/admin/feature-flags - List flag definitions
+func (h *FeatureFlagHandler) ListFlags(c *gin.Context) {
+	flags, err := h.flags.ListFlags(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to list feature flags", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to list feature flags",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"flags": flags,
+			"total": len(flags),
+		},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// PUT /api/v1/admin/feature-flags/:name - Create or replace a flag
+func (h *FeatureFlagHandler) UpsertFlag(c *gin.Context) {
+	var flag services.FeatureFlag
+	if err := c.ShouldBindJSON(&flag); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid feature flag payload",
+		})
+		return
+	}
+	flag.Name = c.Param("name")
+
+	updated, err := h.flags.UpsertFlag(c.Request.Context(), &flag, c.GetHeader(constants.HeaderUserID))
+	if errors.Is(err, services.ErrConfigBusy) {
+		c.JSON(http.StatusConflict, gin.H{
+			"status": "error",
+			"error":  err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      updated,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// DELETE /api/v1/admin/feature-flags/:name - Delete a flag
+func (h *FeatureFlagHandler) DeleteFlag(c *gin.Context) {
+	name := c.Param("name")
+	deleted, err := h.flags.DeleteFlag(c.Request.Context(), name, c.GetHeader(constants.HeaderUserID))
+	if err != nil {
+		h.logger.Error("Failed to delete feature flag", "flag", name, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to delete feature flag",
+		})
+		return
+	}
+	if !deleted {
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": "error",
+			"error":  "feature flag not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"name":    name,
+			"deleted": true,
+		},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// FleetHandler reports version skew across the deployment's instances.
+type FleetHandler struct {
+	fleet  *services.FleetService
+	logger logging.Logger
+}
+
+// NewFleetHandler creates a new fleet version handler.
+func NewFleetHandler(fleet *services.FleetService, logger corelogger.Logger) *FleetHandler {
+	return &FleetHandler{
+		fleet:  fleet,
+		logger: logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/admin/fleet/versions - Build, API and schema versions of live instances
+func (h *FleetHandler) GetVersions(c *gin.Context) {
+	status, err := h.fleet.Status(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to get fleet versions", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to get fleet versions",
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      status,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
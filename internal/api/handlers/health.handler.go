@@ -11,6 +11,7 @@ import (
 	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
 	"github.com/mirastacklabs-ai/mirador-core/internal/mariadb"
 	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	"github.com/mirastacklabs-ai/mirador-core/internal/weavstore"
 	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
 	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
 )
@@ -19,9 +20,52 @@ type HealthHandler struct {
 	vmServices    *services.VictoriaMetricsServices
 	cache         cache.ValkeyCluster // may be nil for legacy behavior
 	mariaDBClient *mariadb.Client     // may be nil if not enabled
+	maintenance   *services.MaintenanceService
+	replication   *services.ReplicationService
+	weaviate      *weavstore.Transport // may be nil if Weaviate is disabled
 	logger        logging.Logger
 }
 
+// SetReplicationService makes health and readiness responses report the
+// replication role and lag. A replica whose lag exceeds the configured
+// maximum reports not-ready so load balancers stop routing to it.
+func (h *HealthHandler) SetReplicationService(r *services.ReplicationService) {
+	h.replication = r
+}
+
+// SetWeaviateTransport makes the microservices status report the Weaviate
+// circuit breaker state.
+func (h *HealthHandler) SetWeaviateTransport(t *weavstore.Transport) {
+	h.weaviate = t
+}
+
+// SetMaintenanceService makes health and readiness responses report the
+// current maintenance mode state.
+func (h *HealthHandler) SetMaintenanceService(m *services.MaintenanceService) {
+	h.maintenance = m
+}
+
+// withMaintenance adds the maintenance and replication state to a health
+// response when known. Maintenance does not affect readiness: reads keep
+// being served.
+func (h *HealthHandler) withMaintenance(ctx context.Context, resp gin.H) gin.H {
+	if h.replication != nil {
+		resp["replication"] = h.replication.Status(ctx)
+	}
+	if h.maintenance == nil {
+		return resp
+	}
+	if st, err := h.maintenance.GetMaintenanceState(ctx); err == nil {
+		resp["maintenance"] = st
+	}
+	return resp
+}
+
+// replicaTooFarBehind reports whether this is a replica lagging beyond its limit.
+func (h *HealthHandler) replicaTooFarBehind(ctx context.Context) bool {
+	return h.replication != nil && h.replication.IsReplica() && !h.replication.Status(ctx).Healthy
+}
+
 // NewHealthHandlerWithCache constructs a HealthHandler with explicit cache dependency.
 func NewHealthHandlerWithCache(vmServices *services.VictoriaMetricsServices, c cache.ValkeyCluster, logger corelogger.Logger) *HealthHandler {
 	return &HealthHandler{
@@ -54,12 +98,12 @@ func NewHealthHandler(vmServices *services.VictoriaMetricsServices, logger corel
 
 // GET /health - Quick health check
 func (h *HealthHandler) HealthCheck(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
+	c.JSON(http.StatusOK, h.withMaintenance(c.Request.Context(), gin.H{
 		"status":    "healthy",
 		"service":   "mirador-core",
 		"version":   "v10.0.1",
 		"timestamp": time.Now().Format(time.RFC3339),
-	})
+	}))
 }
 
 // GET /ready - Comprehensive readiness check
@@ -86,6 +130,9 @@ func (h *HealthHandler) ReadinessCheck(c *gin.Context) {
 		if !ready {
 			status = "unhealthy"
 			httpStatus = http.StatusServiceUnavailable
+		} else if h.replicaTooFarBehind(ctx) {
+			status = "lagging"
+			httpStatus = http.StatusServiceUnavailable
 		}
 		resp := gin.H{
 			"status":    status,
@@ -96,7 +143,7 @@ func (h *HealthHandler) ReadinessCheck(c *gin.Context) {
 		if valkeyErr != nil {
 			resp["error"] = valkeyErr.Error()
 		}
-		c.JSON(httpStatus, resp)
+		c.JSON(httpStatus, h.withMaintenance(ctx, resp))
 		return
 	}
 
@@ -135,7 +182,7 @@ func (h *HealthHandler) ReadinessCheck(c *gin.Context) {
 		"checks":    checks,
 		"timestamp": time.Now().Format(time.RFC3339),
 	}
-	c.JSON(httpStatus, response)
+	c.JSON(httpStatus, h.withMaintenance(ctx, response))
 }
 
 // GET /microservices/status - report health of backends (VM, VL, VT, AI engines)
@@ -199,6 +246,16 @@ func (h *HealthHandler) MicroservicesStatus(c *gin.Context) {
 		}
 	}
 
+	// Weaviate is degraded while its circuit breaker fails requests fast
+	if h.weaviate != nil {
+		state := h.weaviate.State()
+		status := "healthy"
+		if state != weavstore.CircuitClosed {
+			status = "degraded"
+		}
+		checks["weaviate"] = map[string]interface{}{"status": status, "circuit": state}
+	}
+
 	httpStatus := http.StatusOK
 	status := "healthy"
 	if !overallHealthy {
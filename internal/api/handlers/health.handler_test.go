@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// probeOnlyCache hides the noop cache's HealthCheck (which always reports
+// "not connected") so readiness falls back to a Set probe.
+type probeOnlyCache struct{ cache.ValkeyCluster }
+
+func TestReadinessCheck_ReplicaLag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	l := logger.NewMockLogger(&strings.Builder{})
+	c := probeOnlyCache{cache.NewNoopValkeyCache(l)}
+
+	h := NewHealthHandlerWithCache(nil, c, l)
+	h.SetReplicationService(services.NewReplicationService(c, config.ReplicationConfig{
+		Role: services.RoleReplica, PrimaryURL: "https://primary.example", MaxLag: time.Minute,
+	}, l))
+	h.SetMaintenanceService(services.NewMaintenanceService(c, config.MaintenanceConfig{}, l))
+
+	r := gin.New()
+	r.GET("/ready", h.ReadinessCheck)
+
+	// No heartbeat from the primary yet: the replica must not take traffic.
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	if w.Code != http.StatusServiceUnavailable || !strings.Contains(w.Body.String(), `"role":"replica"`) {
+		t.Fatalf("expected 503 with replication status, got %d: %s", w.Code, w.Body.String())
+	}
+
+	primary := services.NewReplicationService(c, config.ReplicationConfig{HeartbeatInterval: time.Hour}, l)
+	ctx, cancel := context.WithCancel(context.Background())
+	go primary.Start(ctx)
+	defer cancel()
+	time.Sleep(20 * time.Millisecond)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"maintenance"`) {
+		t.Fatalf("expected ready replica, got %d: %s", w.Code, w.Body.String())
+	}
+}
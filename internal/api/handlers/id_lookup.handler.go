@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// IDLookupHandler serves the merged logs and traces view of one ID.
+type IDLookupHandler struct {
+	lookup *services.IDLookupService
+	logger logging.Logger
+}
+
+// NewIDLookupHandler creates a new ID lookup handler.
+func NewIDLookupHandler(lookup *services.IDLookupService, logger corelogger.Logger) *IDLookupHandler {
+	return &IDLookupHandler{
+		lookup: lookup,
+		logger: logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/lookup/:id - logs and spans for a trace, transaction or request ID (?start=now-24h&end=now&limit=1000)
+func (h *IDLookupHandler) Lookup(c *gin.Context) {
+	now := time.Now().UTC()
+	req := models.IDLookupRequest{ID: c.Param("id")}
+	for name, dst := range map[string]*time.Time{"start": &req.Start, "end": &req.End} {
+		if v := c.Query(name); v != "" {
+			t, ok := parseNowLike(v, now)
+			if !ok {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"status": "error",
+					"error":  name + " must be an RFC3339 timestamp or now-<duration>",
+				})
+				return
+			}
+			*dst = t
+		}
+	}
+	if v := c.Query("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error":  "limit must be a positive integer",
+			})
+			return
+		}
+		req.Limit = limit
+	}
+
+	result, err := h.lookup.Lookup(c.Request.Context(), req)
+	switch {
+	case errors.Is(err, services.ErrInvalidIDLookup):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  err.Error(),
+		})
+		return
+	case err != nil:
+		h.logger.Error("ID lookup failed", "id", req.ID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to look up id",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      result,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// InternalHealthHandler serves trends of mirador-core's own health.
+type InternalHealthHandler struct {
+	health *services.InternalHealthService
+	logger logging.Logger
+}
+
+// NewInternalHealthHandler creates a new internal health handler.
+func NewInternalHealthHandler(health *services.InternalHealthService, logger corelogger.Logger) *InternalHealthHandler {
+	return &InternalHealthHandler{
+		health: health,
+		logger: logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/admin/health/trends?window=6h&step=5m - cache, Weaviate, correlation, queue and error trends of this instance
+func (h *InternalHealthHandler) GetTrends(c *gin.Context) {
+	window, step := time.Hour, time.Duration(0)
+	for name, dst := range map[string]*time.Duration{"window": &window, "step": &step} {
+		if v := c.Query(name); v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"status": "error",
+					"error":  "invalid " + name + ": " + err.Error(),
+				})
+				return
+			}
+			*dst = d
+		}
+	}
+
+	trends, err := h.health.Trends(c.Request.Context(), window, step)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidHealthRange) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error":  err.Error(),
+			})
+			return
+		}
+		h.logger.Error("Failed to build internal health trends", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to build internal health trends",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      trends,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
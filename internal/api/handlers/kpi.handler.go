@@ -3,8 +3,10 @@ package handlers
 import (
 	"context"
 	"encoding/csv"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,6 +18,7 @@ import (
 	models "github.com/mirastacklabs-ai/mirador-core/internal/models"
 	"github.com/mirastacklabs-ai/mirador-core/internal/repo"
 	"github.com/mirastacklabs-ai/mirador-core/internal/utils/bleve"
+	"github.com/mirastacklabs-ai/mirador-core/internal/utils/pagination"
 	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
 	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
 )
@@ -31,6 +34,7 @@ type KPIHandler struct {
 	logger logging.Logger
 	core   corelogger.Logger
 	cfg    *config.Config
+	lint   *services.KPIFormulaLintService
 }
 
 // Validation response types for API consumers
@@ -45,7 +49,7 @@ type validationErrorResponse struct {
 }
 
 // NewKPIHandler creates a new KPI handler
-func NewKPIHandler(cfg *config.Config, kpiRepo repo.KPIRepo, cache cache.ValkeyCluster, l corelogger.Logger) *KPIHandler {
+func NewKPIHandler(cfg *config.Config, kpiRepo repo.KPIRepo, cache cache.ValkeyCluster, lint *services.KPIFormulaLintService, l corelogger.Logger) *KPIHandler {
 	if kpiRepo == nil {
 		// log via the core logger transformed to internal logging for consistency
 		logging.FromCoreLogger(l).Error("KPIRepo is nil - KPI functionality will not be available")
@@ -57,6 +61,7 @@ func NewKPIHandler(cfg *config.Config, kpiRepo repo.KPIRepo, cache cache.ValkeyC
 		logger: logging.FromCoreLogger(l),
 		core:   l,
 		cfg:    cfg,
+		lint:   lint,
 	}
 } // ------------------- KPI Definitions API -------------------
 
@@ -69,6 +74,7 @@ func NewKPIHandler(cfg *config.Config, kpiRepo repo.KPIRepo, cache cache.ValkeyC
 // @Param tags query []string false "Filter by tags (comma-separated)" collectionFormat(csv)
 // @Param limit query int false "Maximum number of results (default: 10)" minimum(1) maximum(100)
 // @Param offset query int false "Pagination offset (default: 0)" minimum(0)
+// @Param cursor query string false "Opaque page token; pass it empty to start cursor pagination, then nextCursor from the previous page"
 // @Success 200 {object} models.KPIListResponse
 // @Failure 400 {object} map[string]string "error: invalid query parameters"
 // @Failure 500 {object} map[string]string "error: failed to list KPIs"
@@ -88,6 +94,10 @@ func (h *KPIHandler) GetKPIDefinitions(c *gin.Context) {
 	if req.Offset < 0 {
 		req.Offset = 0
 	}
+	if cursor, ok := c.GetQuery(pagination.QueryParam); ok {
+		h.getKPIDefinitionsPage(c, req, cursor)
+		return
+	}
 
 	kpis, total, err := h.listKPIs(c.Request.Context(), req)
 	if err != nil {
@@ -108,6 +118,35 @@ func (h *KPIHandler) GetKPIDefinitions(c *gin.Context) {
 	})
 }
 
+// getKPIDefinitionsPage serves GetKPIDefinitions in cursor mode: KPIs are
+// ordered by name, then ID, and each page resumes after the previous one.
+func (h *KPIHandler) getKPIDefinitionsPage(c *gin.Context, req models.KPIListRequest, cursor string) {
+	filters := pagination.FiltersHash(req.Kind, strings.Join(req.Tags, ","), req.Layer, req.SignalType,
+		req.Classifier, req.Datastore, req.Sentiment, req.Domain, req.ServiceFamily, req.ComponentType)
+	limit := req.Limit
+	req.Limit, req.Offset = pagination.MaxScan, 0
+
+	kpis, _, err := h.listKPIs(c.Request.Context(), req)
+	if err != nil {
+		h.logger.Error("KPI list failed", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list KPIs"})
+		return
+	}
+	page, err := pagination.Paginate(kpis, func(k *models.KPIDefinition) string {
+		return pagination.AscKey(k.Name, k.ID)
+	}, cursor, filters, limit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.KPIListResponse{
+		KPIDefinitions: page.Items,
+		Total:          page.Total,
+		NextCursor:     page.NextCursor,
+	})
+}
+
 // CreateOrUpdateKPIDefinition creates or updates a KPI definition
 // @Summary Create or update KPI definition
 // @Description Create a new KPI definition or update an existing one. If ID is not provided, a new UUID will be generated.
@@ -115,7 +154,7 @@ func (h *KPIHandler) GetKPIDefinitions(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param kpi body models.KPIDefinitionRequest true "KPI definition payload"
-// @Success 200 {object} map[string]interface{} "status: ok, id: kpi_id"
+// @Success 200 {object} map[string]interface{} "status: ok, id: kpi_id, warnings: formula lint warnings"
 // @Failure 400 {object} map[string]string "error: invalid payload or validation error"
 // @Failure 500 {object} map[string]string "error: failed to upsert KPI"
 // @Router /api/v1/kpi/defs [post]
@@ -135,22 +174,7 @@ func (h *KPIHandler) CreateOrUpdateKPIDefinition(c *gin.Context) {
 	kpi := req.KPIDefinition
 
 	// Run semantic validation before generating IDs or persisting
-	if err := services.ValidateKPIDefinition(h.cfg, kpi); err != nil {
-		// If it's a ValidationError, respond with 400 and structured details
-		if ve, ok := err.(*services.ValidationError); ok {
-			resp := validationErrorResponse{
-				Message: "invalid KPI definition",
-				Details: make([]validationProblem, 0, len(ve.Problems)),
-			}
-			for _, p := range ve.Problems {
-				resp.Details = append(resp.Details, validationProblem{Field: p.Field, Error: p.Message})
-			}
-			c.JSON(http.StatusBadRequest, resp)
-			return
-		}
-		// Unexpected error from validator
-		h.logger.Error("KPI validation failed", "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to validate KPI definition"})
+	if !h.validateKPI(c, kpi) {
 		return
 	}
 
@@ -183,15 +207,242 @@ func (h *KPIHandler) CreateOrUpdateKPIDefinition(c *gin.Context) {
 	}
 
 	// HTTP semantics: created -> 201; no-change -> 204 No Content; updated -> 200 OK with id
+	if status == "no-change" {
+		c.Status(http.StatusNoContent)
+		return
+	}
+	resp := gin.H{"status": "ok", "id": kpi.ID}
+	if warnings := h.formulaWarnings(c, kpi); len(warnings) > 0 {
+		resp["warnings"] = warnings
+	}
 	if status == "created" {
-		c.JSON(http.StatusCreated, gin.H{"status": "created", "id": kpi.ID})
+		resp["status"] = "created"
+		c.JSON(http.StatusCreated, resp)
 		return
 	}
-	if status == "no-change" {
-		c.Status(http.StatusNoContent)
+	c.JSON(http.StatusOK, resp)
+}
+
+// LintKPIDefinition checks a KPI formula against the metrics and logs catalog
+// @Summary Lint KPI formula
+// @Description Report metric names, labels and log fields in the KPI's formula that do not exist in the catalog, deprecated names, and high-cardinality labels used for grouping or regex matching. Warnings carry byte offsets into the formula for inline display. Nothing is saved.
+// @Tags kpi-definitions
+// @Accept json
+// @Produce json
+// @Param body body models.KPIDefinition true "KPI definition"
+// @Success 200 {object} map[string]interface{} "warnings: []models.FormulaWarning"
+// @Failure 400 {object} map[string]string "error: invalid payload"
+// @Failure 503 {object} map[string]string "error: catalog unavailable"
+// @Router /api/v1/kpi/defs/lint [post]
+// (no internal auth)
+func (h *KPIHandler) LintKPIDefinition(c *gin.Context) {
+	var kpi models.KPIDefinition
+	if err := c.ShouldBindJSON(&kpi); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+	if h.lint == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "formula linting is not available"})
+		return
+	}
+	warnings, err := h.lint.Lint(c.Request.Context(), &kpi)
+	if errors.Is(err, services.ErrFormulaCatalogUnavailable) {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		h.logger.Error("KPI formula lint failed", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to lint formula"})
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"status": "ok", "id": kpi.ID})
+	c.JSON(http.StatusOK, gin.H{"warnings": warnings})
+}
+
+// formulaWarnings lints kpi for the save response. Lint failures are logged
+// and never fail the save.
+func (h *KPIHandler) formulaWarnings(c *gin.Context, kpi *models.KPIDefinition) []models.FormulaWarning {
+	if h.lint == nil {
+		return nil
+	}
+	warnings, err := h.lint.Lint(c.Request.Context(), kpi)
+	if err != nil {
+		h.logger.Warn("KPI formula lint skipped", "id", kpi.ID, "error", err)
+		return nil
+	}
+	return warnings
+}
+
+// PutKPIDefinition creates or replaces the KPI definition with the given ID
+// @Summary Create or replace KPI definition
+// @Description Idempotent create-or-replace of a KPI definition under a client-chosen, immutable ID. The body is the same object GET returns; timestamps in it are ignored. Replaying an unchanged definition writes nothing. The response carries the stored definition and its checksum as ETag; If-Match and If-None-Match make the write conditional.
+// @Tags kpi-definitions
+// @Accept json
+// @Produce json
+// @Param id path string true "KPI definition ID"
+// @Param body body models.KPIDefinition true "KPI definition"
+// @Param If-Match header string false "Only write when the stored definition has this ETag (* = exists)"
+// @Param If-None-Match header string false "* = only create"
+// @Success 200 {object} models.KPIDefinition
+// @Success 201 {object} models.KPIDefinition
+// @Failure 400 {object} map[string]string "error: invalid payload or id mismatch"
+// @Failure 412 {object} map[string]string "error: precondition failed"
+// @Failure 500 {object} map[string]string "error: failed to store KPI"
+// @Router /api/v1/kpi/defs/{id} [put]
+// (no internal auth)
+func (h *KPIHandler) PutKPIDefinition(c *gin.Context) {
+	id := c.Param("id")
+	var kpi models.KPIDefinition
+	if err := c.ShouldBindJSON(&kpi); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+	// IDs are immutable: a body naming another KPI is a client bug, not a rename.
+	if kpi.ID != "" && kpi.ID != id {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "kpi id in body does not match path; ids are immutable"})
+		return
+	}
+	kpi.ID = id
+	if !h.validateKPI(c, &kpi) {
+		return
+	}
+
+	ctx := c.Request.Context()
+	current, err := h.repo.GetKPI(ctx, id)
+	if err != nil {
+		h.logger.Error("failed to get KPI", "error", err, "id", id)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch KPI"})
+		return
+	}
+	if !h.kpiPreconditionsMet(c, current) {
+		return
+	}
+
+	sum, err := services.KPIChecksum(&kpi)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+		return
+	}
+	if current != nil {
+		if currentSum, _ := services.KPIChecksum(current); currentSum == sum {
+			c.Header("ETag", strconv.Quote(sum))
+			c.JSON(http.StatusOK, current)
+			return
+		}
+	}
+
+	kpi.UpdatedAt = time.Now()
+	kpi.CreatedAt = kpi.UpdatedAt
+	if current != nil && !current.CreatedAt.IsZero() {
+		kpi.CreatedAt = current.CreatedAt
+	}
+	if _, _, err := h.repo.ModifyKPI(ctx, &kpi); err != nil {
+		h.logger.Error("KPI put failed", "error", err, "id", id)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to store KPI"})
+		return
+	}
+
+	c.Header("ETag", strconv.Quote(sum))
+	if current == nil {
+		c.JSON(http.StatusCreated, &kpi)
+		return
+	}
+	c.JSON(http.StatusOK, &kpi)
+}
+
+// LookupKPIDefinition resolves a KPI by its natural key, for importing
+// existing KPIs into infrastructure-as-code state
+// @Summary Look up KPI definition by natural key
+// @Description Resolve the deterministic ID of a KPI from source/sourceId, namespace/name, dataSourceId/name or name (the same rules used when a KPI is created without an ID) and return the stored definition with its checksum as ETag.
+// @Tags kpi-definitions
+// @Produce json
+// @Param name query string false "KPI name"
+// @Param namespace query string false "KPI namespace"
+// @Param source query string false "External source"
+// @Param sourceId query string false "ID in the external source"
+// @Param dataSourceId query string false "Datasource ID"
+// @Success 200 {object} models.KPIDefinition
+// @Failure 400 {object} map[string]string "error: missing key"
+// @Failure 404 {object} map[string]string "error: not found"
+// @Router /api/v1/kpi/defs/lookup [get]
+// (no internal auth)
+func (h *KPIHandler) LookupKPIDefinition(c *gin.Context) {
+	key := models.KPIDefinition{
+		Name:         c.Query("name"),
+		Namespace:    c.Query("namespace"),
+		Source:       c.Query("source"),
+		SourceID:     c.Query("sourceId"),
+		DataSourceID: c.Query("dataSourceId"),
+	}
+	if strings.TrimSpace(key.Name) == "" && (strings.TrimSpace(key.Source) == "" || strings.TrimSpace(key.SourceID) == "") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name, or source and sourceId, are required"})
+		return
+	}
+	id, err := services.GenerateDeterministicKPIID(&key)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid lookup key"})
+		return
+	}
+	h.writeKPI(c, id)
+}
+
+// validateKPI runs semantic validation and writes the 400 (or 500) response
+// when it fails.
+func (h *KPIHandler) validateKPI(c *gin.Context, kpi *models.KPIDefinition) bool {
+	err := services.ValidateKPIDefinition(h.cfg, kpi)
+	if err == nil {
+		return true
+	}
+	// If it's a ValidationError, respond with 400 and structured details
+	if ve, ok := err.(*services.ValidationError); ok {
+		resp := validationErrorResponse{
+			Message: "invalid KPI definition",
+			Details: make([]validationProblem, 0, len(ve.Problems)),
+		}
+		for _, p := range ve.Problems {
+			resp.Details = append(resp.Details, validationProblem{Field: p.Field, Error: p.Message})
+		}
+		c.JSON(http.StatusBadRequest, resp)
+		return false
+	}
+	// Unexpected error from validator
+	h.logger.Error("KPI validation failed", "error", err)
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to validate KPI definition"})
+	return false
+}
+
+// kpiPreconditionsMet evaluates If-Match and If-None-Match against the
+// stored KPI (nil when absent) and writes 412 when they fail.
+func (h *KPIHandler) kpiPreconditionsMet(c *gin.Context, current *models.KPIDefinition) bool {
+	etag := ""
+	if current != nil {
+		if sum, err := services.KPIChecksum(current); err == nil {
+			etag = strconv.Quote(sum)
+		}
+	}
+	if m := c.GetHeader("If-Match"); m != "" && !etagMatches(m, etag) {
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": "kpi does not match If-Match"})
+		return false
+	}
+	if m := c.GetHeader("If-None-Match"); m != "" && etagMatches(m, etag) {
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": "kpi matches If-None-Match"})
+		return false
+	}
+	return true
+}
+
+// etagMatches reports whether a comma-separated If-Match / If-None-Match
+// header names etag or is "*". Nothing matches a missing resource ("").
+func etagMatches(header, etag string) bool {
+	if etag == "" {
+		return false
+	}
+	for _, v := range strings.Split(header, ",") {
+		v = strings.TrimSpace(v)
+		if v == "*" || strings.TrimPrefix(v, "W/") == etag {
+			return true
+		}
+	}
+	return false
 }
 
 // BulkJSONRequest represents a bulk JSON payload for KPI definitions
@@ -463,6 +714,19 @@ func (h *KPIHandler) DeleteKPIDefinition(c *gin.Context) {
 		return
 	}
 
+	// Conditional delete: only remove the definition the client last read.
+	if c.GetHeader("If-Match") != "" {
+		current, err := h.repo.GetKPI(c.Request.Context(), id)
+		if err != nil {
+			h.logger.Error("failed to get KPI", "error", err, "id", id)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch KPI"})
+			return
+		}
+		if !h.kpiPreconditionsMet(c, current) {
+			return
+		}
+	}
+
 	// Prepare result structure describing per-store actions
 	type storeResult struct {
 		Found   bool   `json:"found"`
@@ -566,7 +830,11 @@ func (h *KPIHandler) GetKPIDefinition(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "KPI id is required"})
 		return
 	}
+	h.writeKPI(c, id)
+}
 
+// writeKPI responds with the stored KPI and its checksum as ETag.
+func (h *KPIHandler) writeKPI(c *gin.Context, id string) {
 	kpi, err := h.repo.GetKPI(c.Request.Context(), id)
 	if err != nil {
 		h.logger.Error("failed to get KPI", "error", err, "id", id)
@@ -578,6 +846,9 @@ func (h *KPIHandler) GetKPIDefinition(c *gin.Context) {
 		return
 	}
 
+	if sum, err := services.KPIChecksum(kpi); err == nil {
+		c.Header("ETag", strconv.Quote(sum))
+	}
 	c.JSON(http.StatusOK, kpi)
 }
 
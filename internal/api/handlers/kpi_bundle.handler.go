@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/api/constants"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// maxKPIBundleBytes caps the size of an uploaded KPI bundle.
+const maxKPIBundleBytes = 32 << 20
+
+// KPIBundleHandler exposes KPI definition import and export.
+type KPIBundleHandler struct {
+	bundles    *services.KPIBundleService
+	operations *services.OperationsService
+	logger     logging.Logger
+}
+
+// NewKPIBundleHandler creates a new KPI import/export handler. operations
+// may be nil, in which case imports always run inline.
+func NewKPIBundleHandler(bundles *services.KPIBundleService, operations *services.OperationsService, logger corelogger.Logger) *KPIBundleHandler {
+	return &KPIBundleHandler{
+		bundles:    bundles,
+		operations: operations,
+		logger:     logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/kpi/defs/export?format=json|yaml - Download KPI definitions as a bundle
+func (h *KPIBundleHandler) Export(c *gin.Context) {
+	var filter models.KPIListRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+	format := bundleFormat(c.Query("format"), c.GetHeader("Accept"))
+
+	bundle, err := h.bundles.Export(c.Request.Context(), filter)
+	if err != nil {
+		h.logger.Error("Failed to export KPI definitions", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "error": "Failed to export KPI definitions"})
+		return
+	}
+	data, err := services.EncodeKPIBundle(bundle, format)
+	if err != nil {
+		h.logger.Error("Failed to encode KPI bundle", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "error": "Failed to export KPI definitions"})
+		return
+	}
+
+	contentType := "application/json"
+	if format == services.KPIBundleYAML {
+		contentType = "application/yaml"
+	}
+	c.Header("Content-Disposition", `attachment; filename="kpi-definitions-`+bundle.ExportedAt.Format("20060102T150405Z")+"."+format+`"`)
+	c.Data(http.StatusOK, contentType, data)
+}
+
+// POST /api/v1/kpi/defs/import?mode=skip|overwrite|merge&dryRun=true&async=true - Import a KPI bundle
+func (h *KPIBundleHandler) Import(c *gin.Context) {
+	data, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxKPIBundleBytes))
+	if err != nil {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"status": "error", "error": "Bundle too large or unreadable: " + err.Error()})
+		return
+	}
+	bundle, err := services.DecodeKPIBundle(data, bundleFormat(c.Query("format"), c.ContentType()))
+	if err != nil {
+		h.respondImportError(c, err)
+		return
+	}
+	mode, dryRun := c.Query("mode"), c.Query("dryRun") == "true"
+	if c.Query("async") == "true" && h.operations != nil {
+		h.importAsync(c, bundle, mode, dryRun)
+		return
+	}
+	res, err := h.bundles.Import(c.Request.Context(), bundle, mode, dryRun)
+	if err != nil {
+		h.respondImportError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      res,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// importAsync runs the import as an operation and answers with its handle.
+func (h *KPIBundleHandler) importAsync(c *gin.Context, bundle *models.KPIBundle, mode string, dryRun bool) {
+	if err := h.bundles.ValidateImport(bundle, mode); err != nil {
+		h.respondImportError(c, err)
+		return
+	}
+	op, err := h.operations.Start(c.Request.Context(), services.OperationKPIImport, len(bundle.KPIs), c.GetHeader(constants.HeaderUserID),
+		func(ctx context.Context, p *services.OperationProgress) (any, error) {
+			return h.bundles.ImportWithProgress(ctx, bundle, mode, dryRun, func(item models.KPIImportItem) error {
+				var itemErr error
+				if item.Action == models.KPIImportFailed {
+					itemErr = errors.New(item.Error)
+				}
+				return p.Step(cmp.Or(item.Name, item.ID, strconv.Itoa(item.Index)), itemErr)
+			})
+		})
+	if err != nil {
+		h.logger.Error("Failed to start KPI import", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "error": "Failed to import KPI definitions"})
+		return
+	}
+	writeOperationStarted(c, op)
+}
+
+func (h *KPIBundleHandler) respondImportError(c *gin.Context, err error) {
+	if errors.Is(err, services.ErrInvalidKPIBundle) {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": err.Error()})
+		return
+	}
+	h.logger.Error("Failed to import KPI definitions", "error", err)
+	c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "error": "Failed to import KPI definitions"})
+}
+
+// bundleFormat picks YAML when format says so, or when format is empty and
+// the media type mentions yaml; JSON otherwise.
+func bundleFormat(format, mediaType string) string {
+	if format == "" && strings.Contains(strings.ToLower(mediaType), "yaml") {
+		format = services.KPIBundleYAML
+	}
+	if strings.EqualFold(format, services.KPIBundleYAML) || strings.EqualFold(format, "yml") {
+		return services.KPIBundleYAML
+	}
+	return services.KPIBundleJSON
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// KPIDrilldownHandler serves KPI drill-downs to contributing label sets.
+type KPIDrilldownHandler struct {
+	drilldown *services.KPIDrilldownService
+	logger    logging.Logger
+}
+
+// NewKPIDrilldownHandler creates a new KPI drill-down handler.
+func NewKPIDrilldownHandler(drilldown *services.KPIDrilldownService, logger corelogger.Logger) *KPIDrilldownHandler {
+	return &KPIDrilldownHandler{
+		drilldown: drilldown,
+		logger:    logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/kpi/defs/:id/drilldown - top label sets behind the KPI's change (?by=instance,endpoint&window=1h&limit=10)
+func (h *KPIDrilldownHandler) Drilldown(c *gin.Context) {
+	req := models.KPIDrilldownRequest{KPIID: c.Param("id")}
+	for _, d := range strings.Split(c.Query("by"), ",") {
+		if d = strings.TrimSpace(d); d != "" {
+			req.Dimensions = append(req.Dimensions, d)
+		}
+	}
+	if w := c.Query("window"); w != "" {
+		window, err := time.ParseDuration(w)
+		if err != nil || window <= 0 || window > 30*24*time.Hour {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error":  "window must be a positive duration up to 720h",
+			})
+			return
+		}
+		req.Window = window
+	}
+	if l := c.Query("limit"); l != "" {
+		limit, err := strconv.Atoi(l)
+		if err != nil || limit <= 0 || limit > 100 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error":  "limit must be an integer between 1 and 100",
+			})
+			return
+		}
+		req.Limit = limit
+	}
+
+	result, err := h.drilldown.Drilldown(c.Request.Context(), req)
+	switch {
+	case errors.Is(err, services.ErrKPINotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": "error",
+			"error":  "KPI not found",
+		})
+		return
+	case errors.Is(err, services.ErrKPINoFormula), errors.Is(err, services.ErrNoDrilldownLabels),
+		errors.Is(err, services.ErrInvalidDrilldownDim):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  err.Error(),
+		})
+		return
+	case err != nil:
+		h.logger.Error("KPI drill-down failed", "kpi", req.KPIID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to drill down KPI",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      result,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// KPIHistoryHandler serves recorded KPI threshold states over time.
+type KPIHistoryHandler struct {
+	history *services.KPIHistoryService
+	logger  logging.Logger
+}
+
+// NewKPIHistoryHandler creates a new KPI history handler.
+func NewKPIHistoryHandler(history *services.KPIHistoryService, logger corelogger.Logger) *KPIHistoryHandler {
+	return &KPIHistoryHandler{
+		history: history,
+		logger:  logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/kpi/defs/:id/history - threshold state over time (?from=&to=RFC3339, default last 24h; &step=1h)
+func (h *KPIHistoryHandler) History(c *gin.Context) {
+	to := time.Now().UTC()
+	if v := c.Query("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": "to must be an RFC3339 time"})
+			return
+		}
+		to = t
+	}
+	from := to.Add(-24 * time.Hour)
+	if v := c.Query("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": "from must be an RFC3339 time"})
+			return
+		}
+		from = t
+	}
+	step := time.Hour
+	if v := c.Query("step"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": "step must be a duration such as 15m or 1h"})
+			return
+		}
+		step = d
+	}
+
+	history, err := h.history.History(c.Request.Context(), c.Param("id"), from, to, step)
+	switch {
+	case errors.Is(err, services.ErrInvalidKPIHistoryRequest):
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": err.Error()})
+		return
+	case errors.Is(err, services.ErrKPIHistoryUnavailable):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "error", "error": "KPI history requires Weaviate"})
+		return
+	case err != nil:
+		h.logger.Error("KPI history failed", "kpi", c.Param("id"), "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "error": "Failed to load KPI history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      history,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
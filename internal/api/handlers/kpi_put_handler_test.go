@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// mockRepoStore keeps modified KPIs so GET sees what PUT wrote.
+type mockRepoStore struct {
+	mockRepo
+	kpis   map[string]*models.KPIDefinition
+	writes int
+}
+
+func (m *mockRepoStore) GetKPI(ctx context.Context, id string) (*models.KPIDefinition, error) {
+	return m.kpis[id], nil
+}
+
+func (m *mockRepoStore) ModifyKPI(ctx context.Context, k *models.KPIDefinition) (*models.KPIDefinition, string, error) {
+	stored := *k
+	m.kpis[k.ID] = &stored
+	m.writes++
+	return k, "updated", nil
+}
+
+func putKPI(h *KPIHandler, id string, kpi *models.KPIDefinition, header map[string]string) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(kpi)
+	req := httptest.NewRequest("PUT", "/api/v1/kpi/defs/"+id, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{{Key: "id", Value: id}}
+	h.PutKPIDefinition(c)
+	return w
+}
+
+func TestPutKPIDefinition_Idempotent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mr := &mockRepoStore{mockRepo: mockRepo{}, kpis: map[string]*models.KPIDefinition{}}
+	h := &KPIHandler{repo: mr, logger: logger.NewMockLogger(&strings.Builder{}), cfg: &config.Config{}}
+
+	kpi := &models.KPIDefinition{Name: "checkout_errors", Layer: "impact", SignalType: "metrics", Sentiment: "negative", Definition: "errors", Dashboard: "123e4567-e89b-52d3-a456-426614174000"}
+	w := putKPI(h, "kpi-1", kpi, nil)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201 got %d body=%s", w.Code, w.Body.String())
+	}
+	etag := w.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("expected an ETag")
+	}
+
+	// The GET body is a valid PUT body; replaying it changes nothing.
+	var got models.KPIDefinition
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("failed to decode body: %v", err)
+	}
+	w = putKPI(h, "kpi-1", &got, map[string]string{"If-Match": etag})
+	if w.Code != http.StatusOK || w.Header().Get("ETag") != etag || mr.writes != 1 {
+		t.Fatalf("expected an unchanged 200, got %d etag=%s writes=%d", w.Code, w.Header().Get("ETag"), mr.writes)
+	}
+
+	got.Definition = "checkout errors"
+	w = putKPI(h, "kpi-1", &got, nil)
+	if w.Code != http.StatusOK || w.Header().Get("ETag") == etag || mr.writes != 2 {
+		t.Fatalf("expected a replace, got %d writes=%d", w.Code, mr.writes)
+	}
+	if !mr.kpis["kpi-1"].CreatedAt.Equal(got.CreatedAt) {
+		t.Fatal("expected createdAt to be kept on replace")
+	}
+
+	// Stale If-Match, create-only If-None-Match and a renamed ID are refused.
+	if w := putKPI(h, "kpi-1", &got, map[string]string{"If-Match": etag}); w.Code != http.StatusPreconditionFailed {
+		t.Fatalf("expected 412 for a stale If-Match, got %d", w.Code)
+	}
+	if w := putKPI(h, "kpi-1", &got, map[string]string{"If-None-Match": "*"}); w.Code != http.StatusPreconditionFailed {
+		t.Fatalf("expected 412 for If-None-Match on an existing KPI, got %d", w.Code)
+	}
+	if w := putKPI(h, "kpi-2", &got, nil); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an id mismatch, got %d", w.Code)
+	}
+}
+
+func TestLookupKPIDefinition(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	key := &models.KPIDefinition{Name: "checkout_errors", Namespace: "payments"}
+	id, _ := services.GenerateDeterministicKPIID(key)
+	key.ID = id
+	mr := &mockRepoStore{mockRepo: mockRepo{}, kpis: map[string]*models.KPIDefinition{id: key}}
+	h := &KPIHandler{repo: mr, logger: logger.NewMockLogger(&strings.Builder{}), cfg: &config.Config{}}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/api/v1/kpi/defs/lookup?name=Checkout_Errors&namespace=payments", nil)
+	h.LookupKPIDefinition(c)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), id) || w.Header().Get("ETag") == "" {
+		t.Fatalf("expected the KPI, got %d body=%s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/api/v1/kpi/defs/lookup", nil)
+	h.LookupKPIDefinition(c)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 without a key, got %d", w.Code)
+	}
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/api/constants"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// ThresholdAdjustmentHandler exposes bulk KPI threshold adjustments.
+type ThresholdAdjustmentHandler struct {
+	adjustments *services.ThresholdAdjustmentService
+	logger      logging.Logger
+}
+
+// NewThresholdAdjustmentHandler creates a new threshold adjustment handler.
+func NewThresholdAdjustmentHandler(adjustments *services.ThresholdAdjustmentService, logger corelogger.Logger) *ThresholdAdjustmentHandler {
+	return &ThresholdAdjustmentHandler{
+		adjustments: adjustments,
+		logger:      logging.FromCoreLogger(logger),
+	}
+}
+
+// POST /api/v1/kpi/thresholds/adjustments - Adjust thresholds of KPIs matching a selector
+func (h *ThresholdAdjustmentHandler) Apply(c *gin.Context) {
+	var req models.ThresholdAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	adj, err := h.adjustments.Apply(c.Request.Context(), req, c.GetHeader(constants.HeaderUserID))
+	switch {
+	case errors.Is(err, services.ErrInvalidThresholdAdjustment):
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": err.Error()})
+		return
+	case errors.Is(err, services.ErrNoKPIsMatched):
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "error": err.Error()})
+		return
+	case errors.Is(err, services.ErrThresholdAdjustmentBusy):
+		c.JSON(http.StatusConflict, gin.H{"status": "error", "error": err.Error()})
+		return
+	case err != nil:
+		h.logger.Error("Failed to adjust KPI thresholds", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to adjust KPI thresholds",
+		})
+		return
+	}
+
+	status := http.StatusCreated
+	if req.DryRun {
+		status = http.StatusOK
+	}
+	c.JSON(status, gin.H{
+		"status":    "success",
+		"data":      adj,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// GET /api/v1/kpi/thresholds/adjustments - Past and active adjustments, newest first
+func (h *ThresholdAdjustmentHandler) List(c *gin.Context) {
+	adjustments, err := h.adjustments.List(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to list threshold adjustments", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to list threshold adjustments",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      gin.H{"adjustments": adjustments},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// GET /api/v1/kpi/thresholds/adjustments/:id - A single adjustment with its original values
+func (h *ThresholdAdjustmentHandler) Get(c *gin.Context) {
+	adj, err := h.adjustments.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "Failed to read threshold adjustment")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      adj,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// POST /api/v1/kpi/thresholds/adjustments/:id/revert - Restore the original thresholds
+func (h *ThresholdAdjustmentHandler) Revert(c *gin.Context) {
+	adj, err := h.adjustments.Revert(c.Request.Context(), c.Param("id"), c.GetHeader(constants.HeaderUserID))
+	if err != nil {
+		h.respondError(c, err, "Failed to revert threshold adjustment")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      adj,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+func (h *ThresholdAdjustmentHandler) respondError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, services.ErrThresholdAdjustmentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "error": err.Error()})
+	case errors.Is(err, services.ErrThresholdAdjustmentReverted), errors.Is(err, services.ErrThresholdAdjustmentBusy):
+		c.JSON(http.StatusConflict, gin.H{"status": "error", "error": err.Error()})
+	default:
+		h.logger.Error(msg, "id", c.Param("id"), "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "error": msg})
+	}
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/api/constants"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// LogLevelHandler reports and adjusts subsystem log levels at runtime.
+type LogLevelHandler struct {
+	levels *services.LogLevelService
+	logger logging.Logger
+}
+
+// NewLogLevelHandler creates a new log level handler.
+func NewLogLevelHandler(levels *services.LogLevelService, logger corelogger.Logger) *LogLevelHandler {
+	return &LogLevelHandler{
+		levels: levels,
+		logger: logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/admin/log-levels - Effective, configured and runtime level of each subsystem
+func (h *LogLevelHandler) GetLevels(c *gin.Context) {
+	status, err := h.levels.Status(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to load log levels", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to retrieve log levels",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      status,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// PUT /api/v1/admin/log-levels - Set subsystem levels ({"levels":{"correlation":"debug"}}; "" restores the configured level)
+func (h *LogLevelHandler) SetLevels(c *gin.Context) {
+	var req struct {
+		Levels map[string]string `json:"levels"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || len(req.Levels) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Request body must contain a non-empty levels object",
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	version, err := h.levels.SetLevels(ctx, req.Levels, c.GetHeader(constants.HeaderUserID))
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, services.ErrInvalidLogLevel):
+			status = http.StatusBadRequest
+		case errors.Is(err, services.ErrConfigBusy):
+			status = http.StatusConflict
+		default:
+			h.logger.Error("Failed to update log levels", "error", err)
+		}
+		c.JSON(status, gin.H{"status": "error", "error": err.Error()})
+		return
+	}
+	levels, err := h.levels.Status(ctx)
+	if err != nil {
+		h.logger.Error("Failed to load log levels", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to retrieve log levels",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      gin.H{"version": version, "levels": levels},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"sync"
@@ -83,6 +84,11 @@ func (h *LogsHandler) TailWS(c *gin.Context) {
 		Data interface{} `json:"data"`
 	}
 
+	// The request context is not cancelled when a hijacked connection
+	// drops, so the reader cancels the query itself.
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+
 	// bounded channel for backpressure
 	const bufSize = 2048
 	rowsCh := make(chan map[string]any, bufSize)
@@ -109,17 +115,18 @@ func (h *LogsHandler) TailWS(c *gin.Context) {
 			case <-ticker.C:
 				conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
 				_ = conn.WriteJSON(msg{Type: "heartbeat", Data: map[string]any{"ts": time.Now().UnixMilli()}})
+			case <-ctx.Done():
+				return
 			}
 		}
 	}()
 
-	// reader (no-op: just to detect close)
-	wg.Add(1)
+	// reader (no-op: just to detect close); it exits once the connection
+	// is closed on return
 	go func() {
-		defer wg.Done()
 		for {
 			if _, _, err := conn.ReadMessage(); err != nil {
-				close(rowsCh)
+				cancel()
 				return
 			}
 		}
@@ -132,7 +139,7 @@ func (h *LogsHandler) TailWS(c *gin.Context) {
 	}
 	rowIdx := int64(0)
 
-	_, qerr := h.logs.ExecuteQueryStream(c, &models.LogsQLQueryRequest{
+	_, qerr := h.logs.ExecuteQueryStream(ctx, &models.LogsQLQueryRequest{
 		Query: query,
 		Start: since,
 		End:   time.Now().UnixMilli(),
@@ -150,6 +157,9 @@ func (h *LogsHandler) TailWS(c *gin.Context) {
 	})
 	close(rowsCh)
 	wg.Wait()
+	if ctx.Err() != nil {
+		return // client went away
+	}
 
 	// send final stats
 	_ = conn.WriteJSON(map[string]any{
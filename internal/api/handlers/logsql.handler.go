@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -172,6 +173,9 @@ func (h *LogsQLHandler) ExecuteQuery(c *gin.Context) {
 	// Execute LogsQL query
 	result, err := h.logsService.ExecuteQuery(c.Request.Context(), &request)
 	if err != nil {
+		if writeTooManyResults(c, err) {
+			return
+		}
 		executionTime := time.Since(start)
 		h.logger.Error("LogsQL query execution failed",
 			"query", request.Query,
@@ -400,12 +404,29 @@ func (h *LogsQLHandler) ExportLogs(c *gin.Context) {
 			"format", request.Format,
 			"error", err,
 		)
+		if errors.Is(err, services.ErrMemoryBudgetExceeded) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+				"status": "error",
+				"error":  err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, services.ErrAnonymizationProfileNotFound) || errors.Is(err, services.ErrInvalidAnonymizationProfile) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error":  err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"status": "error",
 			"error":  "Log export failed",
 		})
 		return
 	}
+	if exportResult.Body != nil {
+		defer exportResult.Body.Close()
+	}
 
 	// Stream the exported file bytes directly as an attachment.
 	// This avoids inventing a temporary URL and matches the service, which
@@ -427,6 +448,15 @@ func (h *LogsQLHandler) ExportLogs(c *gin.Context) {
 	}
 
 	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	if exportResult.Anonymization != nil {
+		// The verification report is served by /anonymization/reports/:id.
+		c.Header("X-Anonymization-Report-ID", exportResult.Anonymization.ID)
+	}
+	if exportResult.Body != nil {
+		// Spilled to disk by the service; stream it instead of loading it.
+		c.DataFromReader(http.StatusOK, int64(exportResult.Size), contentType, exportResult.Body, nil)
+		return
+	}
 	c.Header("Content-Type", contentType)
 	c.Header("Content-Length", strconv.Itoa(len(exportResult.Data)))
 	c.Data(http.StatusOK, contentType, exportResult.Data)
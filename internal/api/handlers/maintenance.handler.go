@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// MaintenanceHandler exposes the maintenance mode admin API.
+type MaintenanceHandler struct {
+	maintenance *services.MaintenanceService
+	logger      logging.Logger
+}
+
+// NewMaintenanceHandler creates a new maintenance mode handler.
+func NewMaintenanceHandler(maintenance *services.MaintenanceService, logger corelogger.Logger) *MaintenanceHandler {
+	return &MaintenanceHandler{
+		maintenance: maintenance,
+		logger:      logging.FromCoreLogger(logger),
+	}
+}
+
+// MaintenanceRequest is the payload for toggling maintenance mode.
+type MaintenanceRequest struct {
+	Enabled   *bool  `json:"enabled" binding:"required"`
+	Message   string `json:"message"`
+	UpdatedBy string `json:"updatedBy"`
+}
+
+// GET /api/v1/admin/maintenance - Get the effective maintenance state
+func (h *MaintenanceHandler) GetMaintenance(c *gin.Context) {
+	state, err := h.maintenance.GetMaintenanceState(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to get maintenance state", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to retrieve maintenance state",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      state,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// PUT /api/v1/admin/maintenance - Enable or disable maintenance mode
+func (h *MaintenanceHandler) SetMaintenance(c *gin.Context) {
+	var req MaintenanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid request body: 'enabled' is required",
+		})
+		return
+	}
+
+	state, err := h.maintenance.SetMaintenanceState(c.Request.Context(), *req.Enabled, req.Message, req.UpdatedBy)
+	if err != nil {
+		h.logger.Error("Failed to update maintenance state", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to update maintenance state",
+		})
+		return
+	}
+
+	// The platform-wide switch wins; tell the caller if disabling had no effect.
+	if !*req.Enabled && state.Enabled {
+		c.JSON(http.StatusConflict, gin.H{
+			"status":      "error",
+			"error":       "maintenance is enforced by platform configuration and cannot be disabled at runtime",
+			"maintenance": state,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      state,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
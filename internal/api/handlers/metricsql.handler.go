@@ -3,6 +3,7 @@ package handlers
 import (
 	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -148,6 +149,10 @@ func (h *MetricsQLHandler) ExecuteQuery(c *gin.Context) {
 	result, err := h.metricsService.ExecuteQuery(c.Request.Context(), &request)
 	if err != nil {
 		executionTime := time.Since(start)
+		if writeTooManyResults(c, err) {
+			metrics.HTTPRequestsTotal.WithLabelValues(c.Request.Method, c.FullPath(), "413").Inc()
+			return
+		}
 		metrics.HTTPRequestsTotal.WithLabelValues(c.Request.Method, c.FullPath(), "500").Inc()
 		metrics.QueryExecutionDuration.WithLabelValues("metricsql").Observe(executionTime.Seconds())
 
@@ -244,6 +249,9 @@ func (h *MetricsQLHandler) ExecuteRangeQuery(c *gin.Context) {
 	// Execute range query
 	result, err := h.metricsService.ExecuteRangeQuery(c.Request.Context(), &request)
 	if err != nil {
+		if writeTooManyResults(c, err) {
+			return
+		}
 		executionTime := time.Since(start)
 		h.logger.Error("MetricsQL range query failed",
 			"query", request.Query,
@@ -426,6 +434,26 @@ func generateQueryHash(query, timeParam string) string {
 	return fmt.Sprintf("%x", hash)
 }
 
+// writeTooManyResults answers 413 with the limit and suggested narrower
+// queries when err is a TooManyResultsError, and reports whether it did.
+func writeTooManyResults(c *gin.Context, err error) bool {
+	var tooMany *services.TooManyResultsError
+	if !errors.As(err, &tooMany) {
+		return false
+	}
+	c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+		"status":      "error",
+		"error":       tooMany.Error(),
+		"code":        "too_many_results",
+		"unit":        tooMany.Unit,
+		"count":       tooMany.Count,
+		"limit":       tooMany.Limit,
+		"atLeast":     tooMany.AtLeast,
+		"suggestions": tooMany.Suggestions,
+	})
+	return true
+}
+
 func (h *MetricsQLHandler) GetSeries(c *gin.Context) {
 
 	// Parse query parameters
@@ -17,6 +17,7 @@ import (
 	"github.com/mirastacklabs-ai/mirador-core/internal/models"
 	"github.com/mirastacklabs-ai/mirador-core/internal/services"
 	"github.com/mirastacklabs-ai/mirador-core/internal/utils"
+	"github.com/mirastacklabs-ai/mirador-core/internal/utils/pagination"
 	"github.com/mirastacklabs-ai/mirador-core/internal/weavstore"
 	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
 	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
@@ -264,7 +265,8 @@ func (h *MIRARCAAsyncHandler) HandleGetTaskStatus(c *gin.Context) {
 }
 
 // HandleListTasks handles GET /api/v1/rca_analyze/list
-// Query params: limit (int), offset (int)
+// Query params: limit (int), offset (int), cursor (opaque; "" starts cursor
+// pagination, newest first, and responses carry nextCursor)
 func (h *MIRARCAAsyncHandler) HandleListTasks(c *gin.Context) {
 	if h.weaviateStore == nil {
 		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "error", "error": "weaviate_not_configured"})
@@ -280,6 +282,11 @@ func (h *MIRARCAAsyncHandler) HandleListTasks(c *gin.Context) {
 		offset = o
 	}
 
+	if cursor, ok := c.GetQuery(pagination.QueryParam); ok {
+		h.listTasksPage(c, cursor, limit)
+		return
+	}
+
 	tasks, total, err := h.weaviateStore.ListMIRARCATasks(c.Request.Context(), limit, offset)
 	if err != nil {
 		h.logger.Error("failed to list mira rca tasks", "error", err)
@@ -290,6 +297,25 @@ func (h *MIRARCAAsyncHandler) HandleListTasks(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"status": "ok", "total": total, "items": tasks})
 }
 
+// listTasksPage serves HandleListTasks in cursor mode, newest tasks first.
+func (h *MIRARCAAsyncHandler) listTasksPage(c *gin.Context, cursor string, limit int) {
+	tasks, _, err := h.weaviateStore.ListMIRARCATasks(c.Request.Context(), pagination.MaxScan, 0)
+	if err != nil {
+		h.logger.Error("failed to list mira rca tasks", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "error": "weaviate_list_failed"})
+		return
+	}
+	page, err := pagination.Paginate(tasks, func(t *weavstore.MIRARCATask) string {
+		return pagination.NewestFirstKey(t.CreatedAt, t.TaskID)
+	}, cursor, "", limit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": "invalid_cursor"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "ok", "total": page.Total, "items": page.Items, "nextCursor": page.NextCursor})
+}
+
 // HandleSearchTasks handles GET /api/v1/rca_analyze/search?q=<query>&mode=<semantic|hybrid|keyword>&limit=&offset=
 func (h *MIRARCAAsyncHandler) HandleSearchTasks(c *gin.Context) {
 	if h.weaviateStore == nil {
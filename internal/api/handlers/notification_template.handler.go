@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/api/constants"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// NotificationTemplateHandler manages the tenant's notification templates.
+type NotificationTemplateHandler struct {
+	templates *services.NotificationTemplateService
+	logger    logging.Logger
+}
+
+// NewNotificationTemplateHandler creates a new notification template handler.
+func NewNotificationTemplateHandler(templates *services.NotificationTemplateService, logger corelogger.Logger) *NotificationTemplateHandler {
+	return &NotificationTemplateHandler{
+		templates: templates,
+		logger:    logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/notifications/templates - List notification templates
+func (h *NotificationTemplateHandler) List(c *gin.Context) {
+	list, err := h.templates.List(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to list notification templates", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "error": "Failed to list notification templates"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      gin.H{"templates": list},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// GET /api/v1/notifications/templates/:channel/:event/:locale - Get a notification template
+func (h *NotificationTemplateHandler) Get(c *gin.Context) {
+	t, err := h.templates.Get(c.Request.Context(), c.Param("channel"), c.Param("event"), c.Param("locale"))
+	if err != nil {
+		h.respondError(c, err, "Failed to get notification template")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      t,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// PUT /api/v1/notifications/templates/:channel/:event/:locale - Create or replace a notification template
+func (h *NotificationTemplateHandler) Put(c *gin.Context) {
+	var req models.NotificationTemplate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": "Invalid notification template payload"})
+		return
+	}
+	req.Channel, req.EventType, req.Locale = c.Param("channel"), c.Param("event"), c.Param("locale")
+	req.UpdatedBy = c.GetHeader(constants.HeaderUserID)
+
+	t, err := h.templates.Put(c.Request.Context(), req)
+	if err != nil {
+		h.respondError(c, err, "Failed to store notification template")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      t,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// DELETE /api/v1/notifications/templates/:channel/:event/:locale - Delete a notification template
+func (h *NotificationTemplateHandler) Delete(c *gin.Context) {
+	if err := h.templates.Delete(c.Request.Context(), c.Param("channel"), c.Param("event"), c.Param("locale"), c.GetHeader(constants.HeaderUserID)); err != nil {
+		h.respondError(c, err, "Failed to delete notification template")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      gin.H{"deleted": true},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// POST /api/v1/notifications/templates/preview - Render an unsaved template with sample data
+func (h *NotificationTemplateHandler) Preview(c *gin.Context) {
+	var req models.NotificationTemplatePreviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": "Invalid preview payload"})
+		return
+	}
+	preview, err := h.templates.Preview(c.Request.Context(), req)
+	if err != nil {
+		h.respondError(c, err, "Failed to render notification template")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      preview,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// POST /api/v1/notifications/templates/:channel/:event/:locale/render - Render with the stored templates
+func (h *NotificationTemplateHandler) Render(c *gin.Context) {
+	var n *models.Notification
+	if c.Request.ContentLength > 0 {
+		n = &models.Notification{}
+		if err := c.ShouldBindJSON(n); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": "Invalid notification payload"})
+			return
+		}
+	}
+	preview, err := h.templates.Render(c.Request.Context(), c.Param("channel"), c.Param("event"), c.Param("locale"), n)
+	if err != nil {
+		h.respondError(c, err, "Failed to render notification template")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      preview,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+func (h *NotificationTemplateHandler) respondError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, services.ErrNotificationTemplateNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "error": err.Error()})
+	case errors.Is(err, services.ErrInvalidNotificationTemplate):
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": err.Error()})
+	case errors.Is(err, services.ErrConfigBusy):
+		c.JSON(http.StatusConflict, gin.H{"status": "error", "error": err.Error()})
+	default:
+		h.logger.Error(msg, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "error": msg})
+	}
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// operationEventInterval is how often the event stream polls an operation.
+const operationEventInterval = time.Second
+
+// OperationsHandler exposes the status of long-running operations.
+type OperationsHandler struct {
+	operations *services.OperationsService
+	logger     logging.Logger
+}
+
+// NewOperationsHandler creates a new operations handler.
+func NewOperationsHandler(operations *services.OperationsService, logger corelogger.Logger) *OperationsHandler {
+	return &OperationsHandler{
+		operations: operations,
+		logger:     logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/operations - List operations of the last 24 hours
+func (h *OperationsHandler) ListOperations(c *gin.Context) {
+	ops, err := h.operations.List(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to list operations", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "error": "Failed to list operations"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      gin.H{"operations": ops, "total": len(ops)},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// GET /api/v1/operations/:id - Operation status and progress
+func (h *OperationsHandler) GetOperation(c *gin.Context) {
+	op, err := h.operations.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.writeError(c, err, "Failed to retrieve operation")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      op,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// POST /api/v1/operations/:id/cancel - Cancel a running operation
+func (h *OperationsHandler) CancelOperation(c *gin.Context) {
+	op, err := h.operations.Cancel(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.writeError(c, err, "Failed to cancel operation")
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{
+		"status":    "success",
+		"data":      op,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// GET /api/v1/operations/:id/events - Server-sent progress events until the operation finishes
+func (h *OperationsHandler) StreamOperation(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	op, err := h.operations.Get(ctx, id)
+	if err != nil {
+		h.writeError(c, err, "Failed to retrieve operation")
+		return
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	ticker := time.NewTicker(operationEventInterval)
+	defer ticker.Stop()
+	var sent time.Time
+	c.Stream(func(w io.Writer) bool {
+		if !op.UpdatedAt.Equal(sent) || op.Finished() {
+			sent = op.UpdatedAt
+			if op.Finished() {
+				c.SSEvent("done", op)
+				return false
+			}
+			c.SSEvent("progress", op)
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+		next, err := h.operations.Get(ctx, id)
+		if err != nil {
+			// Expired or unreadable; tell the client and stop.
+			c.SSEvent("error", gin.H{"error": err.Error()})
+			return false
+		}
+		op = next
+		return true
+	})
+}
+
+// writeOperationStarted answers a request that started op in the background.
+func writeOperationStarted(c *gin.Context, op *models.Operation) {
+	c.Header("Location", "/api/v1/operations/"+op.ID)
+	c.JSON(http.StatusAccepted, gin.H{
+		"status":    "success",
+		"data":      op,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+func (h *OperationsHandler) writeError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, services.ErrOperationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "error": err.Error()})
+	case errors.Is(err, services.ErrOperationFinished):
+		c.JSON(http.StatusConflict, gin.H{"status": "error", "error": err.Error()})
+	default:
+		h.logger.Error(msg, "id", c.Param("id"), "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "error": msg})
+	}
+}
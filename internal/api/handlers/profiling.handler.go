@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/pprof"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// ProfilingHandler serves live pprof profiles and the ones captured by the
+// profiling watchdog.
+type ProfilingHandler struct {
+	watchdog *services.ProfilingWatchdog
+	logger   logging.Logger
+}
+
+// NewProfilingHandler creates a new profiling handler. watchdog may be nil
+// when captures are not stored.
+func NewProfilingHandler(watchdog *services.ProfilingWatchdog, logger corelogger.Logger) *ProfilingHandler {
+	return &ProfilingHandler{
+		watchdog: watchdog,
+		logger:   logging.FromCoreLogger(logger),
+	}
+}
+
+// RegisterPprof mounts net/http/pprof on group (e.g. /admin/debug/pprof).
+// Keep ?seconds= below the server write timeout for CPU profiles and traces.
+func (h *ProfilingHandler) RegisterPprof(group gin.IRoutes) {
+	group.GET("/", gin.WrapF(pprof.Index))
+	group.GET("/cmdline", gin.WrapF(pprof.Cmdline))
+	group.GET("/profile", gin.WrapF(pprof.Profile))
+	group.GET("/symbol", gin.WrapF(pprof.Symbol))
+	group.POST("/symbol", gin.WrapF(pprof.Symbol))
+	group.GET("/trace", gin.WrapF(pprof.Trace))
+	group.GET("/:name", func(c *gin.Context) {
+		pprof.Handler(c.Param("name")).ServeHTTP(c.Writer, c.Request)
+	})
+}
+
+// GET /api/v1/admin/profiles - profiles captured by the watchdog, newest first
+func (h *ProfilingHandler) ListCaptures(c *gin.Context) {
+	if h.watchdog == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": "error",
+			"error":  "profiling watchdog is not enabled",
+		})
+		return
+	}
+	captures, err := h.watchdog.Captures(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to list profile captures", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to list profile captures",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      captures,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// GET /api/v1/admin/profiles/:name - download a captured profile (view with `go tool pprof`)
+func (h *ProfilingHandler) GetCapture(c *gin.Context) {
+	if h.watchdog == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": "error",
+			"error":  "profiling watchdog is not enabled",
+		})
+		return
+	}
+	name := c.Param("name")
+	data, err := h.watchdog.Capture(c.Request.Context(), name)
+	if errors.Is(err, services.ErrProfileNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": "error",
+			"error":  "profile not found",
+		})
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to read profile capture", "name", name, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to read profile capture",
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="`+name+`"`)
+	c.Data(http.StatusOK, "application/octet-stream", data)
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/api/constants"
+	"github.com/mirastacklabs-ai/mirador-core/internal/api/middleware"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// QueryTemplateHandler manages query templates, their review and execution.
+type QueryTemplateHandler struct {
+	templates *services.QueryTemplateService
+	logger    logging.Logger
+}
+
+// NewQueryTemplateHandler creates a new query template handler.
+func NewQueryTemplateHandler(templates *services.QueryTemplateService, logger corelogger.Logger) *QueryTemplateHandler {
+	return &QueryTemplateHandler{
+		templates: templates,
+		logger:    logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/query-templates - List query templates
+func (h *QueryTemplateHandler) List(c *gin.Context) {
+	list, err := h.templates.List(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to list query templates", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "error": "Failed to list query templates"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      gin.H{"templates": list},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// GET /api/v1/query-templates/:name - Get a query template
+func (h *QueryTemplateHandler) Get(c *gin.Context) {
+	t, err := h.templates.Get(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		h.respondError(c, err, "Failed to get query template")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      t,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// PUT /api/v1/query-templates/:name - Create or replace a query template, sending it to review
+func (h *QueryTemplateHandler) Put(c *gin.Context) {
+	var req models.QueryTemplate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": "Invalid query template payload"})
+		return
+	}
+	req.Name = c.Param("name")
+	req.UpdatedBy = c.GetHeader(constants.HeaderUserID)
+
+	t, err := h.templates.Put(c.Request.Context(), req)
+	if err != nil {
+		h.respondError(c, err, "Failed to store query template")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      t,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// DELETE /api/v1/query-templates/:name - Delete a query template
+func (h *QueryTemplateHandler) Delete(c *gin.Context) {
+	if err := h.templates.Delete(c.Request.Context(), c.Param("name"), c.GetHeader(constants.HeaderUserID)); err != nil {
+		h.respondError(c, err, "Failed to delete query template")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      gin.H{"deleted": true},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// POST /api/v1/admin/query-templates/:name/approve - Approve the current version of a query template
+func (h *QueryTemplateHandler) Approve(c *gin.Context) {
+	h.review(c, h.templates.Approve)
+}
+
+// POST /api/v1/admin/query-templates/:name/reject - Reject a query template or revoke its approval
+func (h *QueryTemplateHandler) Reject(c *gin.Context) {
+	h.review(c, h.templates.Reject)
+}
+
+func (h *QueryTemplateHandler) review(c *gin.Context, fn func(context.Context, string, models.QueryTemplateReview) (*models.QueryTemplate, error)) {
+	var req models.QueryTemplateReview
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": "Invalid review payload"})
+			return
+		}
+	}
+	// The reviewer is the authenticated admin, never a caller-supplied header.
+	req.Reviewer = c.GetString(middleware.AdminContextKey)
+
+	t, err := fn(c.Request.Context(), c.Param("name"), req)
+	if err != nil {
+		h.respondError(c, err, "Failed to review query template")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      t,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// POST /api/v1/query-templates/:name/execute - Run an approved query template
+func (h *QueryTemplateHandler) Execute(c *gin.Context) {
+	var req models.QueryTemplateExecuteRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": "Invalid query template execution payload"})
+			return
+		}
+	}
+	result, err := h.templates.Execute(c.Request.Context(), c.Param("name"), req)
+	if err != nil {
+		if writeTooManyResults(c, err) {
+			return
+		}
+		h.respondError(c, err, "Failed to execute query template")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      result,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+func (h *QueryTemplateHandler) respondError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, services.ErrQueryTemplateNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "error": err.Error()})
+	case errors.Is(err, services.ErrInvalidQueryTemplate), errors.Is(err, services.ErrInvalidQueryParameters),
+		errors.Is(err, services.ErrInvalidComputedColumn), errors.Is(err, services.ErrComputedColumnNotFound):
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": err.Error()})
+	case errors.Is(err, services.ErrQueryTemplateNotApproved), errors.Is(err, services.ErrQueryTemplateReviewForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"status": "error", "error": err.Error()})
+	case errors.Is(err, services.ErrQueryTemplateConflict), errors.Is(err, services.ErrConfigBusy):
+		c.JSON(http.StatusConflict, gin.H{"status": "error", "error": err.Error()})
+	default:
+		h.logger.Error(msg, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "error": msg})
+	}
+}
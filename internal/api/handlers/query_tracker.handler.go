@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/api/constants"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// QueryTrackerHandler lists and cancels the caller's in-flight queries.
+type QueryTrackerHandler struct {
+	tracker *services.QueryTrackerService
+	logger  logging.Logger
+}
+
+// NewQueryTrackerHandler creates a new in-flight query handler.
+func NewQueryTrackerHandler(tracker *services.QueryTrackerService, logger corelogger.Logger) *QueryTrackerHandler {
+	return &QueryTrackerHandler{
+		tracker: tracker,
+		logger:  logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/queries - List the caller's in-flight queries
+func (h *QueryTrackerHandler) List(c *gin.Context) {
+	queries, err := h.tracker.List(c.Request.Context(), c.GetHeader(constants.HeaderUserID))
+	if errors.Is(err, services.ErrQueryUserRequired) {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": err.Error()})
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to list in-flight queries", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "error": "Failed to list in-flight queries"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      gin.H{"queries": queries, "total": len(queries)},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// DELETE /api/v1/queries/:id - Cancel one of the caller's in-flight queries
+func (h *QueryTrackerHandler) Cancel(c *gin.Context) {
+	q, err := h.tracker.Cancel(c.Request.Context(), c.Param("id"), c.GetHeader(constants.HeaderUserID))
+	if err != nil {
+		if errors.Is(err, services.ErrQueryUserRequired) {
+			c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrQueryNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"status": "error", "error": err.Error()})
+			return
+		}
+		h.logger.Error("Failed to cancel query", "id", c.Param("id"), "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "error": "Failed to cancel query"})
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{
+		"status":    "success",
+		"data":      q,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
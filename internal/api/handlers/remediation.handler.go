@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/api/constants"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// RemediationHandler exposes the remediation rules API.
+type RemediationHandler struct {
+	remediation *services.RemediationService
+	logger      logging.Logger
+}
+
+// NewRemediationHandler creates a new remediation rules handler.
+func NewRemediationHandler(remediation *services.RemediationService, logger corelogger.Logger) *RemediationHandler {
+	return &RemediationHandler{
+		remediation: remediation,
+		logger:      logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/remediation/rules - List remediation rules
+func (h *RemediationHandler) ListRules(c *gin.Context) {
+	rules, err := h.remediation.ListRules(c.Request.Context())
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve remediation rules")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      gin.H{"rules": rules, "total": len(rules)},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// GET /api/v1/remediation/rules/:id - Get a remediation rule
+func (h *RemediationHandler) GetRule(c *gin.Context) {
+	rule, err := h.remediation.GetRule(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve remediation rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      rule,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// POST /api/v1/remediation/rules - Create a remediation rule
+func (h *RemediationHandler) CreateRule(c *gin.Context) {
+	var req models.RemediationRule
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": "Invalid request body"})
+		return
+	}
+
+	rule, err := h.remediation.CreateRule(c.Request.Context(), req, c.GetHeader(constants.HeaderUserID))
+	if err != nil {
+		h.respondError(c, err, "Failed to create remediation rule")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status":    "success",
+		"data":      rule,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// PUT /api/v1/remediation/rules/:id - Replace a remediation rule
+func (h *RemediationHandler) UpdateRule(c *gin.Context) {
+	var req models.RemediationRule
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": "Invalid request body"})
+		return
+	}
+
+	rule, err := h.remediation.UpdateRule(c.Request.Context(), c.Param("id"), req, c.GetHeader(constants.HeaderUserID))
+	if err != nil {
+		h.respondError(c, err, "Failed to update remediation rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      rule,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// DELETE /api/v1/remediation/rules/:id - Delete a remediation rule
+func (h *RemediationHandler) DeleteRule(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.remediation.DeleteRule(c.Request.Context(), id, c.GetHeader(constants.HeaderUserID)); err != nil {
+		h.respondError(c, err, "Failed to delete remediation rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      gin.H{"id": id, "deleted": true},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// POST /api/v1/remediation/rules/:id/test - Dry-run a rule against an event
+func (h *RemediationHandler) TestRule(c *gin.Context) {
+	var ev models.RemediationEvent
+	if err := c.ShouldBindJSON(&ev); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": "Invalid request body"})
+		return
+	}
+
+	exec, err := h.remediation.Test(c.Request.Context(), c.Param("id"), ev)
+	if err != nil {
+		h.respondError(c, err, "Failed to test remediation rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      gin.H{"matched": exec != nil, "execution": exec},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// GET /api/v1/remediation/rules/:id/executions - Execution audit of a rule
+func (h *RemediationHandler) Executions(c *gin.Context) {
+	execs, err := h.remediation.Executions(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve remediation executions")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      gin.H{"executions": execs, "total": len(execs)},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// POST /api/v1/remediation/events - Evaluate the rules for an incoming alert
+func (h *RemediationHandler) Ingest(c *gin.Context) {
+	var ev models.RemediationEvent
+	if err := c.ShouldBindJSON(&ev); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": "Invalid request body"})
+		return
+	}
+
+	execs, err := h.remediation.Evaluate(c.Request.Context(), ev)
+	if err != nil {
+		h.respondError(c, err, "Failed to evaluate remediation rules")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      gin.H{"executions": execs, "total": len(execs)},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+func (h *RemediationHandler) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrRemediationRuleNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "error": err.Error()})
+	case errors.Is(err, services.ErrInvalidRemediationRule), errors.Is(err, services.ErrInvalidRemediationEvent):
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": err.Error()})
+	case errors.Is(err, services.ErrConfigBusy):
+		c.JSON(http.StatusConflict, gin.H{"status": "error", "error": err.Error()})
+	default:
+		h.logger.Error(message, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "error": message})
+	}
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// ResidencyHandler serves the data residency audit.
+type ResidencyHandler struct {
+	residency *services.ResidencyService
+	logger    logging.Logger
+}
+
+// NewResidencyHandler creates a new residency handler.
+func NewResidencyHandler(residency *services.ResidencyService, logger corelogger.Logger) *ResidencyHandler {
+	return &ResidencyHandler{
+		residency: residency,
+		logger:    logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/admin/residency - Backend hosts the tenant's data flows to and their regions
+func (h *ResidencyHandler) GetReport(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      h.residency.Report(),
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
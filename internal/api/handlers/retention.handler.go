@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/api/constants"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// RetentionHandler exposes the retention policy admin API.
+type RetentionHandler struct {
+	retention  *services.RetentionService
+	operations *services.OperationsService
+	logger     logging.Logger
+}
+
+// NewRetentionHandler creates a new retention policy handler. operations
+// may be nil, in which case purges always run inline.
+func NewRetentionHandler(retention *services.RetentionService, operations *services.OperationsService, logger corelogger.Logger) *RetentionHandler {
+	return &RetentionHandler{
+		retention:  retention,
+		operations: operations,
+		logger:     logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/admin/retention/policies - List retention policies
+func (h *RetentionHandler) ListPolicies(c *gin.Context) {
+	policies, err := h.retention.ListPolicies(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to list retention policies", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to retrieve retention policies",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      gin.H{"policies": policies, "total": len(policies)},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// POST /api/v1/admin/retention/policies - Create a retention policy
+func (h *RetentionHandler) CreatePolicy(c *gin.Context) {
+	var req models.RetentionPolicy
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid request body",
+		})
+		return
+	}
+
+	policy, err := h.retention.CreatePolicy(c.Request.Context(), req, c.GetHeader(constants.HeaderUserID))
+	if err != nil {
+		h.writeError(c, err, "Failed to create retention policy")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status":    "success",
+		"data":      policy,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// PUT /api/v1/admin/retention/policies/:id - Update a retention policy
+func (h *RetentionHandler) UpdatePolicy(c *gin.Context) {
+	var req models.RetentionPolicy
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid request body",
+		})
+		return
+	}
+
+	policy, err := h.retention.UpdatePolicy(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		h.writeError(c, err, "Failed to update retention policy")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      policy,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// DELETE /api/v1/admin/retention/policies/:id - Delete a retention policy
+func (h *RetentionHandler) DeletePolicy(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.retention.DeletePolicy(c.Request.Context(), id); err != nil {
+		h.writeError(c, err, "Failed to delete retention policy")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      gin.H{"id": id, "deleted": true},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// GET /api/v1/admin/retention/policies/:id/preview - Dry-run a purge
+func (h *RetentionHandler) PreviewPolicy(c *gin.Context) {
+	preview, err := h.retention.Preview(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.writeError(c, err, "Failed to preview retention purge")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      preview,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// POST /api/v1/admin/retention/policies/:id/purge?async=true - Purge now
+func (h *RetentionHandler) PurgePolicy(c *gin.Context) {
+	if c.Query("async") == "true" && h.operations != nil {
+		h.purgeAsync(c)
+		return
+	}
+	purge, err := h.retention.Purge(c.Request.Context(), c.Param("id"), c.GetHeader(constants.HeaderUserID))
+	if err != nil {
+		h.writeError(c, err, "Failed to purge retention policy")
+		return
+	}
+
+	// The failed purge is still audited; return the record with the error.
+	if purge.Error != "" {
+		c.JSON(http.StatusBadGateway, gin.H{
+			"status": "error",
+			"error":  purge.Error,
+			"data":   purge,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      purge,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// purgeAsync runs the purge as a single-item operation and answers with
+// its handle.
+func (h *RetentionHandler) purgeAsync(c *gin.Context) {
+	id := c.Param("id")
+	if _, err := h.retention.GetPolicy(c.Request.Context(), id); err != nil {
+		h.writeError(c, err, "Failed to purge retention policy")
+		return
+	}
+	executedBy := c.GetHeader(constants.HeaderUserID)
+	op, err := h.operations.Start(c.Request.Context(), services.OperationRetentionPurge, 1, executedBy,
+		func(ctx context.Context, p *services.OperationProgress) (any, error) {
+			purge, err := h.retention.Purge(ctx, id, executedBy)
+			if err != nil {
+				return nil, err
+			}
+			var purgeErr error
+			if purge.Error != "" {
+				purgeErr = errors.New(purge.Error)
+			}
+			return purge, p.Step(id, purgeErr)
+		})
+	if err != nil {
+		h.writeError(c, err, "Failed to purge retention policy")
+		return
+	}
+	writeOperationStarted(c, op)
+}
+
+// GET /api/v1/admin/retention/audit - List executed purges
+func (h *RetentionHandler) ListAudit(c *gin.Context) {
+	audit, err := h.retention.Audit(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to read retention audit", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to retrieve retention audit",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      gin.H{"purges": audit, "total": len(audit)},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+func (h *RetentionHandler) writeError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, services.ErrInvalidRetentionPolicy):
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": err.Error()})
+	case errors.Is(err, services.ErrRetentionPolicyNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "error": err.Error()})
+	case errors.Is(err, services.ErrRetentionBusy):
+		c.JSON(http.StatusConflict, gin.H{"status": "error", "error": err.Error()})
+	case errors.Is(err, services.ErrRetentionUnsupported):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "error", "error": err.Error()})
+	default:
+		h.logger.Error(msg, "id", c.Param("id"), "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "error": msg})
+	}
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// SelfSLOHandler serves mirador-core's own endpoint SLOs.
+type SelfSLOHandler struct {
+	selfSLO *services.SelfSLOService
+	logger  logging.Logger
+}
+
+// NewSelfSLOHandler creates a new self-SLO handler.
+func NewSelfSLOHandler(selfSLO *services.SelfSLOService, logger corelogger.Logger) *SelfSLOHandler {
+	return &SelfSLOHandler{
+		selfSLO: selfSLO,
+		logger:  logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/admin/self-slo - latency and availability of key mirador-core endpoints against their objectives
+func (h *SelfSLOHandler) GetStatus(c *gin.Context) {
+	report, err := h.selfSLO.Status(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to evaluate self-SLOs", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to evaluate self-SLOs",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      report,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// ServiceHealthHandler serves computed service health scores.
+type ServiceHealthHandler struct {
+	health *services.ServiceHealthService
+	logger logging.Logger
+}
+
+// NewServiceHealthHandler creates a new service health handler.
+func NewServiceHealthHandler(health *services.ServiceHealthService, logger corelogger.Logger) *ServiceHealthHandler {
+	return &ServiceHealthHandler{
+		health: health,
+		logger: logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/services/:service/health-score - 0-100 score with component breakdown (?refresh=true bypasses the cache)
+func (h *ServiceHealthHandler) GetHealthScore(c *gin.Context) {
+	service := strings.TrimSpace(c.Param("service"))
+	if service == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "service is required",
+		})
+		return
+	}
+
+	score, err := h.health.Score(c.Request.Context(), service, c.Query("refresh") == "true")
+	if err != nil {
+		h.logger.Error("Failed to compute service health score", "service", service, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to compute service health score",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      score,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
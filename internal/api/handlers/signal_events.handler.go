@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// maxEventBatch bounds the events accepted per ingest request.
+const maxEventBatch = 1000
+
+// SignalEventHandler ingests and lists Kubernetes and CI/CD events.
+type SignalEventHandler struct {
+	events *services.SignalEventService
+	logger logging.Logger
+}
+
+// NewSignalEventHandler creates a new event handler.
+func NewSignalEventHandler(events *services.SignalEventService, logger corelogger.Logger) *SignalEventHandler {
+	return &SignalEventHandler{
+		events: events,
+		logger: logging.FromCoreLogger(logger),
+	}
+}
+
+// POST /api/v1/events - Ingest events, e.g. CI/CD deploys ({"events":[{"source":"ci","type":"deploy","service":"checkout",...}]})
+func (h *SignalEventHandler) Ingest(c *gin.Context) {
+	var req struct {
+		Events []models.SignalEvent `json:"events"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || len(req.Events) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Request body must contain a non-empty events array",
+		})
+		return
+	}
+	h.store(c, len(req.Events), func() (int, error) {
+		return h.events.Ingest(c.Request.Context(), req.Events)
+	})
+}
+
+// POST /api/v1/events/kubernetes - Ingest core/v1 Events from an event exporter webhook (one event or an array)
+func (h *SignalEventHandler) IngestKubernetes(c *gin.Context) {
+	body, err := io.ReadAll(c.Request.Body)
+	var events []models.KubernetesEvent
+	if err == nil {
+		if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+			err = json.Unmarshal(trimmed, &events)
+		} else {
+			var ev models.KubernetesEvent
+			if err = json.Unmarshal(trimmed, &ev); err == nil {
+				events = append(events, ev)
+			}
+		}
+	}
+	if err != nil || len(events) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Request body must be a Kubernetes Event or an array of them",
+		})
+		return
+	}
+	h.store(c, len(events), func() (int, error) {
+		return h.events.IngestKubernetes(c.Request.Context(), events)
+	})
+}
+
+// GET /api/v1/events - Events between start and end (default: the last hour), optionally for one ?service=
+func (h *SignalEventHandler) List(c *gin.Context) {
+	now := time.Now().UTC()
+	start, end := now.Add(-time.Hour), now
+	for name, dst := range map[string]*time.Time{"start": &start, "end": &end} {
+		if v := c.Query(name); v != "" {
+			t, ok := parseNowLike(v, now)
+			if !ok {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"status": "error",
+					"error":  name + " must be an RFC3339 timestamp or now-<duration>",
+				})
+				return
+			}
+			*dst = t
+		}
+	}
+
+	events, err := h.events.List(c.Request.Context(), start, end, strings.TrimSpace(c.Query("service")))
+	if errors.Is(err, services.ErrInvalidSignalEvent) {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": err.Error()})
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to list events", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to retrieve events",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      gin.H{"events": events, "total": len(events)},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+func (h *SignalEventHandler) store(c *gin.Context, received int, ingest func() (int, error)) {
+	if received > maxEventBatch {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+			"status": "error",
+			"error":  "at most 1000 events per request",
+		})
+		return
+	}
+	stored, err := ingest()
+	switch {
+	case errors.Is(err, services.ErrInvalidSignalEvent):
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": err.Error()})
+		return
+	case errors.Is(err, services.ErrSignalEventsBusy):
+		c.JSON(http.StatusConflict, gin.H{"status": "error", "error": err.Error()})
+		return
+	case err != nil:
+		h.logger.Error("Failed to ingest events", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to store events",
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"status":    "success",
+		"data":      gin.H{"received": received, "stored": stored},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
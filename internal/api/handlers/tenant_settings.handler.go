@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// TenantSettingsHandler exposes branding and localization settings for the
+// tenant served by this deployment, plus the i18n message catalog.
+type TenantSettingsHandler struct {
+	settings *services.TenantSettingsService
+	logger   logging.Logger
+}
+
+// NewTenantSettingsHandler creates a new tenant settings handler.
+func NewTenantSettingsHandler(settings *services.TenantSettingsService, logger corelogger.Logger) *TenantSettingsHandler {
+	return &TenantSettingsHandler{
+		settings: settings,
+		logger:   logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/tenant/settings - Get branding and localization settings
+func (h *TenantSettingsHandler) GetSettings(c *gin.Context) {
+	settings, err := h.settings.GetSettings(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to get tenant settings", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to retrieve tenant settings",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"settings":         settings,
+			"availableLocales": h.settings.Catalog().Locales(),
+		},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// PUT /api/v1/tenant/settings - Replace branding and localization settings
+func (h *TenantSettingsHandler) UpdateSettings(c *gin.Context) {
+	var req models.TenantSettings
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid tenant settings payload",
+		})
+		return
+	}
+
+	updated, err := h.settings.SetSettings(c.Request.Context(), &req)
+	if err != nil {
+		h.logger.Warn("Rejected tenant settings update", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"settings": updated,
+			"updated":  true,
+		},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// GET /api/v1/i18n/messages - Get the message catalog for the caller's locale.
+// The locale is resolved from ?lang= (per-user override), then the
+// Accept-Language header, then the tenant default.
+func (h *TenantSettingsHandler) GetMessages(c *gin.Context) {
+	settings, err := h.settings.GetSettings(c.Request.Context())
+	if err != nil {
+		settings = services.DefaultTenantSettings()
+	}
+
+	catalog := h.settings.Catalog()
+	locale := catalog.ResolveLocale(c.Query("lang"), c.GetHeader("Accept-Language"), settings.DefaultLocale)
+
+	c.Header("Content-Language", locale)
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"locale":     locale,
+			"dateFormat": settings.DateFormat,
+			"timeFormat": settings.TimeFormat,
+			"timezone":   settings.Timezone,
+			"messages":   catalog.Messages(locale),
+		},
+	})
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func setupTenantSettingsRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	l := logger.NewMockLogger(&strings.Builder{})
+	svc := services.NewTenantSettingsService(cache.NewNoopValkeyCache(l), nil, l)
+	h := NewTenantSettingsHandler(svc, l)
+
+	r := gin.New()
+	r.GET("/api/v1/tenant/settings", h.GetSettings)
+	r.PUT("/api/v1/tenant/settings", h.UpdateSettings)
+	r.GET("/api/v1/i18n/messages", h.GetMessages)
+	return r
+}
+
+func TestTenantSettings_UpdateRejectsInvalid(t *testing.T) {
+	r := setupTenantSettingsRouter()
+
+	body := `{"timezone":"Nowhere/Zone","branding":{"primaryColor":"red"}}`
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/tenant/settings", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestTenantSettings_MessagesUseTenantDefaultAndOverride(t *testing.T) {
+	r := setupTenantSettingsRouter()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/tenant/settings", strings.NewReader(`{"defaultLocale":"fr"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	cases := []struct {
+		url  string
+		want string
+	}{
+		{"/api/v1/i18n/messages", "fr"},
+		{"/api/v1/i18n/messages?lang=de", "de"},
+	}
+	for _, tc := range cases {
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.url, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d", tc.url, w.Code)
+		}
+		if got := w.Header().Get("Content-Language"); got != tc.want {
+			t.Fatalf("%s: expected Content-Language %q, got %q", tc.url, tc.want, got)
+		}
+		var resp map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("invalid json: %v", err)
+		}
+		data := resp["data"].(map[string]interface{})
+		if data["locale"] != tc.want {
+			t.Fatalf("%s: expected locale %q, got %v", tc.url, tc.want, data["locale"])
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// TenantStatsHandler serves the materialized tenant statistics.
+type TenantStatsHandler struct {
+	stats  *services.TenantStatsService
+	logger logging.Logger
+}
+
+// NewTenantStatsHandler creates a new tenant statistics handler.
+func NewTenantStatsHandler(stats *services.TenantStatsService, logger corelogger.Logger) *TenantStatsHandler {
+	return &TenantStatsHandler{
+		stats:  stats,
+		logger: logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/admin/stats - latest statistics snapshot with trend deltas (?refresh=true takes a new snapshot)
+func (h *TenantStatsHandler) GetOverview(c *gin.Context) {
+	overview, err := h.stats.Overview(c.Request.Context(), c.Query("refresh") == "true")
+	if err != nil {
+		h.logger.Error("Failed to load tenant statistics", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to load tenant statistics",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      overview,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// GET /api/v1/admin/stats/history - stored statistics snapshots, newest first (?limit=N)
+func (h *TenantStatsHandler) ListHistory(c *gin.Context) {
+	limit := 0
+	if raw := c.Query("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": "limit must be a non-negative integer"})
+			return
+		}
+		limit = n
+	}
+	snapshots, err := h.stats.History(c.Request.Context(), limit)
+	if err != nil {
+		h.logger.Error("Failed to load tenant statistics history", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error",
+			"error":  "Failed to load tenant statistics history",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      gin.H{"snapshots": snapshots, "total": len(snapshots)},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	"github.com/mirastacklabs-ai/mirador-core/internal/ticketing"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// maxTicketWebhookBytes caps the size of a provider webhook body.
+const maxTicketWebhookBytes = 1 << 20
+
+// TicketingHandler receives ticket status webhooks from Jira or ServiceNow.
+type TicketingHandler struct {
+	ticketing *services.TicketingService
+	logger    logging.Logger
+}
+
+// NewTicketingHandler creates a new ticketing webhook handler.
+func NewTicketingHandler(ticketing *services.TicketingService, logger corelogger.Logger) *TicketingHandler {
+	return &TicketingHandler{
+		ticketing: ticketing,
+		logger:    logging.FromCoreLogger(logger),
+	}
+}
+
+// POST /api/v1/integrations/ticketing/webhook - Record a ticket status change
+func (h *TicketingHandler) Webhook(c *gin.Context) {
+	if !h.ticketing.Enabled() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "error", "error": "Ticketing not configured"})
+		return
+	}
+	if !h.ticketing.VerifyWebhookSecret(c.GetHeader("X-Webhook-Secret")) {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "error": "Invalid webhook secret"})
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxTicketWebhookBytes))
+	if err != nil {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"status": "error", "error": "Body too large or unreadable"})
+		return
+	}
+
+	ticket, err := h.ticketing.ApplyWebhook(c.Request.Context(), body)
+	switch {
+	case errors.Is(err, ticketing.ErrInvalidWebhook):
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "error": err.Error()})
+		return
+	case err != nil:
+		h.logger.Error("Failed to apply ticket webhook", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "error": "Failed to apply ticket webhook"})
+		return
+	}
+
+	// Tickets not opened by mirador-core are acknowledged and ignored.
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      gin.H{"ticket": ticket, "matched": ticket != nil},
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
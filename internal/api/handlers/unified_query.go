@@ -2,7 +2,9 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -16,6 +18,7 @@ import (
 	"github.com/mirastacklabs-ai/mirador-core/internal/models"
 	"github.com/mirastacklabs-ai/mirador-core/internal/repo"
 	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	"github.com/mirastacklabs-ai/mirador-core/internal/utils/pagination"
 	"github.com/mirastacklabs-ai/mirador-core/internal/weavstore"
 	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
 )
@@ -26,6 +29,7 @@ type UnifiedQueryHandler struct {
 	kpiRepo       repo.KPIRepo
 	engineCfg     config.EngineConfig
 	failureStore  *weavstore.WeaviateFailureStore
+	ticketing     *services.TicketingService
 }
 
 func NewUnifiedQueryHandler(unifiedEngine services.UnifiedQueryEngine, logger corelogger.Logger, kpiRepo repo.KPIRepo, cfg config.EngineConfig) *UnifiedQueryHandler {
@@ -42,6 +46,31 @@ func (h *UnifiedQueryHandler) SetFailureStore(store *weavstore.WeaviateFailureSt
 	h.failureStore = store
 }
 
+// SetTicketing opens and tracks incident tickets for stored failures.
+func (h *UnifiedQueryHandler) SetTicketing(t *services.TicketingService) {
+	h.ticketing = t
+}
+
+// trackIncident opens a ticket for a newly stored failure, or notes a
+// re-detection on its ticket, in the background.
+func (h *UnifiedQueryHandler) trackIncident(ctx context.Context, f *weavstore.FailureRecord, status string) {
+	if !h.ticketing.Enabled() || (status != "created" && status != "updated") {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		var err error
+		if status == "created" {
+			_, err = h.ticketing.Open(ctx, f)
+		} else {
+			err = h.ticketing.NoteRedetected(ctx, f)
+		}
+		if err != nil {
+			h.logger.Warn("Failed to update incident ticket", "failure_id", f.FailureID, "error", err)
+		}
+	}()
+}
+
 // bindUnifiedQuery is tolerant: it accepts either a wrapped payload
 // `{"query": {...}}` or a direct `UnifiedQuery` JSON object. It reads
 // the raw request body and attempts to unmarshal into both shapes.
@@ -87,6 +116,9 @@ func (h *UnifiedQueryHandler) HandleUnifiedQuery(c *gin.Context) {
 	// Execute the unified query
 	result, err := h.unifiedEngine.ExecuteQuery(c.Request.Context(), req.Query)
 	if err != nil {
+		if writeTooManyResults(c, err) {
+			return
+		}
 		h.logger.Error("Failed to execute unified query", "error", err, "query_id", req.Query.ID)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":    "Query execution failed",
@@ -153,25 +185,8 @@ func (h *UnifiedQueryHandler) HandleUnifiedCorrelation(c *gin.Context) {
 		}
 
 		// Enforce Engine-configured Min/Max window constraints (AT-004)
-		windowDur := tr.End.Sub(tr.Start)
-		if h.engineCfg.MinWindow > 0 && windowDur < h.engineCfg.MinWindow {
-			msg := fmt.Sprintf("time window too small: %s < minWindow %s", windowDur.String(), h.engineCfg.MinWindow.String())
-			if h.engineCfg.StrictTimeWindow {
-				h.logger.Warn("Rejecting request due to small time window", "details", msg)
-				c.JSON(http.StatusBadRequest, gin.H{"error": msg})
-				return
-			}
-			// lenient: warn and continue
-			h.logger.Warn("Time window below MinWindow (lenient mode)", "details", msg)
-		}
-		if h.engineCfg.MaxWindow > 0 && windowDur > h.engineCfg.MaxWindow {
-			msg := fmt.Sprintf("time window too large: %s > maxWindow %s", windowDur.String(), h.engineCfg.MaxWindow.String())
-			if h.engineCfg.StrictTimeWindow {
-				h.logger.Warn("Rejecting request due to large time window", "details", msg)
-				c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": msg})
-				return
-			}
-			h.logger.Warn("Time window above MaxWindow (lenient mode)", "details", msg)
+		if !h.checkCorrelationWindow(c, tr) {
+			return
 		}
 
 		// Map TimeRange to a lightweight UnifiedQuery (canonical path: TimeWindow -> TimeRange -> internal)
@@ -187,7 +202,7 @@ func (h *UnifiedQueryHandler) HandleUnifiedCorrelation(c *gin.Context) {
 		result, err := h.unifiedEngine.ExecuteCorrelationQuery(c.Request.Context(), uquery)
 		if err != nil {
 			h.logger.Error("Failed to execute unified correlation (time-window)", "error", err)
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Correlation execution failed", "details": err.Error()})
+			c.JSON(correlationErrorStatus(err), gin.H{"error": "Correlation execution failed", "details": err.Error()})
 			return
 		}
 
@@ -209,25 +224,8 @@ func (h *UnifiedQueryHandler) HandleUnifiedCorrelation(c *gin.Context) {
 			}
 
 			// Enforce Engine-configured Min/Max window constraints (AT-004)
-			windowDur := tr.End.Sub(tr.Start)
-			if h.engineCfg.MinWindow > 0 && windowDur < h.engineCfg.MinWindow {
-				msg := fmt.Sprintf("time window too small: %s < minWindow %s", windowDur.String(), h.engineCfg.MinWindow.String())
-				if h.engineCfg.StrictTimeWindow {
-					h.logger.Warn("Rejecting request due to small time window", "details", msg)
-					c.JSON(http.StatusBadRequest, gin.H{"error": msg})
-					return
-				}
-				// lenient: warn and continue
-				h.logger.Warn("Time window below MinWindow (lenient mode)", "details", msg)
-			}
-			if h.engineCfg.MaxWindow > 0 && windowDur > h.engineCfg.MaxWindow {
-				msg := fmt.Sprintf("time window too large: %s > maxWindow %s", windowDur.String(), h.engineCfg.MaxWindow.String())
-				if h.engineCfg.StrictTimeWindow {
-					h.logger.Warn("Rejecting request due to large time window", "details", msg)
-					c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": msg})
-					return
-				}
-				h.logger.Warn("Time window above MaxWindow (lenient mode)", "details", msg)
+			if !h.checkCorrelationWindow(c, tr) {
+				return
 			}
 
 			// Map TimeRange to a lightweight UnifiedQuery (canonical path: TimeWindow -> TimeRange -> internal)
@@ -243,7 +241,7 @@ func (h *UnifiedQueryHandler) HandleUnifiedCorrelation(c *gin.Context) {
 			result, err := h.unifiedEngine.ExecuteCorrelationQuery(c.Request.Context(), uquery)
 			if err != nil {
 				h.logger.Error("Failed to execute unified correlation (time-window)", "error", err)
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Correlation execution failed", "details": err.Error()})
+				c.JSON(correlationErrorStatus(err), gin.H{"error": "Correlation execution failed", "details": err.Error()})
 				return
 			}
 
@@ -331,7 +329,7 @@ func (h *UnifiedQueryHandler) HandleUnifiedCorrelation(c *gin.Context) {
 			result, err := h.unifiedEngine.ExecuteCorrelationQuery(c.Request.Context(), uquery)
 			if err != nil {
 				h.logger.Error("Failed to execute unified correlation (all KPIs)", "error", err)
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Correlation execution failed", "details": err.Error()})
+				c.JSON(correlationErrorStatus(err), gin.H{"error": "Correlation execution failed", "details": err.Error()})
 				return
 			}
 
@@ -417,7 +415,7 @@ func (h *UnifiedQueryHandler) HandleUnifiedCorrelation(c *gin.Context) {
 	result, err := h.unifiedEngine.ExecuteCorrelationQuery(c.Request.Context(), req.Query)
 	if err != nil {
 		h.logger.Error("Failed to execute unified correlation", "error", err, "query_id", req.Query.ID)
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(correlationErrorStatus(err), gin.H{
 			"error":    "Correlation execution failed",
 			"details":  err.Error(),
 			"query_id": req.Query.ID,
@@ -483,6 +481,9 @@ func (h *UnifiedQueryHandler) HandleUnifiedSearch(c *gin.Context) {
 	// For search, we can route to the appropriate engine based on query content
 	result, err := h.unifiedEngine.ExecuteQuery(c.Request.Context(), req.Query)
 	if err != nil {
+		if writeTooManyResults(c, err) {
+			return
+		}
 		h.logger.Error("Failed to execute unified search", "error", err, "query_id", req.Query.ID)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":    "Search execution failed",
@@ -712,7 +713,7 @@ func (h *UnifiedQueryHandler) HandleFailureDetection(c *gin.Context) {
 					UpdatedAt:          time.Now(),
 				}
 
-				if _, _, err := h.failureStore.CreateOrUpdateFailure(c.Request.Context(), failureRecord); err != nil {
+				if _, status, err := h.failureStore.CreateOrUpdateFailure(c.Request.Context(), failureRecord); err != nil {
 					h.logger.Warn("Failed to persist failure record",
 						"failure_id", svc.FailureID,
 						"service", svc.Service,
@@ -724,6 +725,7 @@ func (h *UnifiedQueryHandler) HandleFailureDetection(c *gin.Context) {
 						"failure_id", svc.FailureID,
 						"service", svc.Service,
 						"component", svc.Component)
+					h.trackIncident(c.Request.Context(), failureRecord, status)
 				}
 			}
 		} else {
@@ -776,6 +778,9 @@ func (h *UnifiedQueryHandler) HandleGetFailures(c *gin.Context) {
 	var req struct {
 		Limit  int `json:"limit" binding:"omitempty"`
 		Offset int `json:"offset" binding:"omitempty"`
+		// Cursor switches to cursor pagination (newest first); send "" for
+		// the first page, then nextCursor from the previous response.
+		Cursor *string `json:"cursor" binding:"omitempty"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.logger.Error("Failed to parse list failures request", "error", err)
@@ -794,8 +799,12 @@ func (h *UnifiedQueryHandler) HandleGetFailures(c *gin.Context) {
 	if req.Offset >= 0 {
 		offset = req.Offset
 	}
+	fetchLimit, fetchOffset := limit, offset
+	if req.Cursor != nil {
+		fetchLimit, fetchOffset = pagination.MaxScan, 0
+	}
 
-	failures, total, err := h.failureStore.ListFailures(c.Request.Context(), limit, offset)
+	failures, total, err := h.failureStore.ListFailures(c.Request.Context(), fetchLimit, fetchOffset)
 	if err != nil {
 		h.logger.Error("Failed to retrieve failures from store", "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -804,6 +813,20 @@ func (h *UnifiedQueryHandler) HandleGetFailures(c *gin.Context) {
 		})
 		return
 	}
+	var nextCursor string
+	if req.Cursor != nil {
+		page, perr := pagination.Paginate(failures, func(f *weavstore.FailureRecord) string {
+			return pagination.NewestFirstKey(f.DetectionTimestamp, f.FailureUUID)
+		}, *req.Cursor, "", limit)
+		if perr != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid request format",
+				"details": perr.Error(),
+			})
+			return
+		}
+		failures, total, nextCursor = page.Items, int64(page.Total), page.NextCursor
+	}
 
 	// Map to minimal summary response
 	summaries := make([]gin.H, 0, len(failures))
@@ -826,15 +849,21 @@ func (h *UnifiedQueryHandler) HandleGetFailures(c *gin.Context) {
 				"updated_at": f.UpdatedAt,
 			},
 		}
+		if h.ticketing.Enabled() {
+			if t, _ := h.ticketing.Ticket(c.Request.Context(), f.FailureUUID); t != nil {
+				summary["ticket_url"] = t.URL
+			}
+		}
 		summaries = append(summaries, summary)
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"failures": summaries,
-		"count":    len(summaries),
-		"total":    total,
-		"limit":    limit,
-		"offset":   offset,
+		"failures":   summaries,
+		"count":      len(summaries),
+		"total":      total,
+		"limit":      limit,
+		"offset":     offset,
+		"nextCursor": nextCursor,
 	})
 }
 
@@ -880,8 +909,72 @@ func (h *UnifiedQueryHandler) HandleGetFailureDetail(c *gin.Context) {
 	}
 
 	// Return the full failure record with verbose output
+	resp := gin.H{"failure": failure}
+	if h.ticketing.Enabled() {
+		if t, err := h.ticketing.Ticket(c.Request.Context(), failure.FailureUUID); err != nil {
+			h.logger.Warn("Failed to load incident ticket", "failure_id", req.FailureID, "error", err)
+		} else if t != nil {
+			resp["ticket"] = t
+		}
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// HandleOpenFailureTicket opens a ticket for a stored failure, or returns
+// the ticket already linked to it.
+func (h *UnifiedQueryHandler) HandleOpenFailureTicket(c *gin.Context) {
+	if h.failureStore == nil {
+		h.logger.Error("Failure store not configured")
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "Failure store not available",
+		})
+		return
+	}
+	if !h.ticketing.Enabled() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "Ticketing not configured",
+		})
+		return
+	}
+
+	var req struct {
+		FailureID string `json:"failure_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	failure, err := h.failureStore.GetFailureByID(c.Request.Context(), req.FailureID)
+	if err != nil {
+		h.logger.Error("Failed to retrieve failure", "failure_id", req.FailureID, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve failure",
+			"details": err.Error(),
+		})
+		return
+	}
+	if failure == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Failure not found",
+		})
+		return
+	}
+
+	ticket, err := h.ticketing.Open(c.Request.Context(), failure)
+	if err != nil {
+		h.logger.Error("Failed to open incident ticket", "failure_id", req.FailureID, "error", err)
+		c.JSON(http.StatusBadGateway, gin.H{
+			"error":   "Failed to open ticket",
+			"details": err.Error(),
+		})
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{
-		"failure": failure,
+		"ticket": ticket,
 	})
 }
 
@@ -953,6 +1046,7 @@ func (h *UnifiedQueryHandler) HandleStoreFailure(c *gin.Context) {
 		})
 		return
 	}
+	h.trackIncident(c.Request.Context(), result, status)
 
 	statusCode := http.StatusCreated
 	if status == "updated" || status == "no-change" {
@@ -1002,3 +1096,113 @@ func (h *UnifiedQueryHandler) HandleClearFailures(c *gin.Context) {
 		"total":   len(failures),
 	})
 }
+
+// checkCorrelationWindow enforces the engine's MinWindow/MaxWindow on tr. In
+// strict mode it answers the request and returns false; otherwise it only
+// warns.
+func (h *UnifiedQueryHandler) checkCorrelationWindow(c *gin.Context, tr models.TimeRange) bool {
+	windowDur := tr.End.Sub(tr.Start)
+	if h.engineCfg.MinWindow > 0 && windowDur < h.engineCfg.MinWindow {
+		msg := fmt.Sprintf("time window too small: %s < minWindow %s", windowDur.String(), h.engineCfg.MinWindow.String())
+		if h.engineCfg.StrictTimeWindow {
+			h.logger.Warn("Rejecting request due to small time window", "details", msg)
+			c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+			return false
+		}
+		// lenient: warn and continue
+		h.logger.Warn("Time window below MinWindow (lenient mode)", "details", msg)
+	}
+	if h.engineCfg.MaxWindow > 0 && windowDur > h.engineCfg.MaxWindow {
+		msg := fmt.Sprintf("time window too large: %s > maxWindow %s", windowDur.String(), h.engineCfg.MaxWindow.String())
+		if h.engineCfg.StrictTimeWindow {
+			h.logger.Warn("Rejecting request due to large time window", "details", msg)
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": msg})
+			return false
+		}
+		h.logger.Warn("Time window above MaxWindow (lenient mode)", "details", msg)
+	}
+	return true
+}
+
+// correlationStreamBuffer is how many progress events may queue while the
+// client reads; the correlation waits beyond that.
+const correlationStreamBuffer = 64
+
+// HandleUnifiedCorrelationStream runs a correlation and streams its progress
+// as server-sent events: one event per stage update (rings, ring, candidate,
+// engine), then done with the full result or error.
+// The input is exactly startTime and endTime, like the time-window
+// correlation.
+// GET /api/v1/unified/correlation/stream?startTime=...&endTime=...
+func (h *UnifiedQueryHandler) HandleUnifiedCorrelationStream(c *gin.Context) {
+	tw := models.TimeWindowRequest{StartTime: c.Query("startTime"), EndTime: c.Query("endTime")}
+	tr, err := tw.ToTimeRange()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_time_window", "details": err.Error()})
+		return
+	}
+	if !h.checkCorrelationWindow(c, tr) {
+		return
+	}
+	st, et := tr.Start, tr.End
+	uquery := &models.UnifiedQuery{
+		ID:        fmt.Sprintf("timewindow_%d", time.Now().Unix()),
+		Type:      models.QueryTypeCorrelation,
+		StartTime: &st,
+		EndTime:   &et,
+	}
+
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+	events := make(chan models.CorrelationProgressEvent, correlationStreamBuffer)
+	ctx = services.WithCorrelationProgress(ctx, func(ev models.CorrelationProgressEvent) {
+		select {
+		case events <- ev:
+		case <-ctx.Done():
+		}
+	})
+	type outcome struct {
+		result *models.UnifiedResult
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		result, err := h.unifiedEngine.ExecuteCorrelationQuery(ctx, uquery)
+		done <- outcome{result, err}
+	}()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case ev := <-events:
+			c.SSEvent(ev.Stage, ev)
+			return true
+		case out := <-done:
+			// Progress is reported synchronously, so every event is queued
+			// before the result arrives.
+			for len(events) > 0 {
+				ev := <-events
+				c.SSEvent(ev.Stage, ev)
+			}
+			if out.err != nil {
+				h.logger.Error("Failed to execute streamed correlation", "error", out.err)
+				c.SSEvent("error", gin.H{"error": "Correlation execution failed", "details": out.err.Error()})
+				return false
+			}
+			c.SSEvent("done", models.UnifiedQueryResponse{Result: out.result})
+			return false
+		case <-ctx.Done():
+			return false
+		}
+	})
+}
+
+// correlationErrorStatus maps correlation failures to a status code; runs
+// rejected by the memory budget are the caller's to narrow.
+func correlationErrorStatus(err error) int {
+	if errors.Is(err, services.ErrMemoryBudgetExceeded) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusInternalServerError
+}
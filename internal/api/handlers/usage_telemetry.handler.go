@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// UsageTelemetryHandler serves the usage telemetry payload.
+type UsageTelemetryHandler struct {
+	telemetry *services.UsageTelemetryService
+	logger    logging.Logger
+}
+
+// NewUsageTelemetryHandler creates a new usage telemetry handler.
+func NewUsageTelemetryHandler(telemetry *services.UsageTelemetryService, logger corelogger.Logger) *UsageTelemetryHandler {
+	return &UsageTelemetryHandler{
+		telemetry: telemetry,
+		logger:    logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/admin/telemetry - Telemetry settings and the exact payload of the current period
+func (h *UsageTelemetryHandler) GetStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      h.telemetry.Status(c.Request.Context()),
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/weavstore"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// WeaviateSchemaHandler reports drift between the live Weaviate schema and
+// the classes mirador-core expects.
+type WeaviateSchemaHandler struct {
+	preflight *weavstore.SchemaPreflight
+	timeout   time.Duration
+	logger    logging.Logger
+}
+
+// NewWeaviateSchemaHandler creates a new Weaviate schema handler. Refreshes
+// are bounded by timeout when it is positive.
+func NewWeaviateSchemaHandler(preflight *weavstore.SchemaPreflight, timeout time.Duration, logger corelogger.Logger) *WeaviateSchemaHandler {
+	return &WeaviateSchemaHandler{
+		preflight: preflight,
+		timeout:   timeout,
+		logger:    logging.FromCoreLogger(logger),
+	}
+}
+
+// GET /api/v1/admin/weaviate/schema - Latest schema drift report (?refresh=true re-runs the check)
+func (h *WeaviateSchemaHandler) GetReport(c *gin.Context) {
+	report := h.preflight.Last()
+	if report == nil || c.Query("refresh") == "true" {
+		ctx := c.Request.Context()
+		if h.timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, h.timeout)
+			defer cancel()
+		}
+		report = h.preflight.Run(ctx)
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"data":      report,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+)
+
+// AdminContextKey holds the admin name on requests made with an admin token.
+const AdminContextKey = "admin"
+
+// AdminOnly guards every route under prefix with the configured admin
+// tokens: requests must send "Authorization: Bearer <token>" matching one of
+// them, and the matching admin's name is stored under AdminContextKey. Other
+// requests get 401, and every request gets 404 when no admin tokens are
+// configured, so the admin API is never served unauthenticated. Paths under
+// skip are left to a guard of their own. Auditor requests pass:
+// AuditorReadOnly has already limited them to reads.
+func AdminOnly(prefix string, admins []config.AdminTokenConfig, skip ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		path := c.Request.URL.Path
+		if !underPath(path, prefix) || c.GetString(AuditorContextKey) != "" {
+			c.Next()
+			return
+		}
+		for _, s := range skip {
+			if underPath(path, s) {
+				c.Next()
+				return
+			}
+		}
+		if len(admins) == 0 {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{
+				"status": "error",
+				"error":  "admin API is disabled: configure admin.tokens",
+			})
+			return
+		}
+		name, ok := adminFor(c.GetHeader("Authorization"), admins)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"status": "error",
+				"error":  "invalid or missing admin token",
+			})
+			return
+		}
+		c.Set(AdminContextKey, name)
+		c.Next()
+	}
+}
+
+// adminFor returns the admin whose token is in header. Every configured
+// token is compared in constant time.
+func adminFor(header string, admins []config.AdminTokenConfig) (string, bool) {
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok || token == "" {
+		return "", false
+	}
+	name := ""
+	for _, a := range admins {
+		if a.Token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(a.Token)) == 1 {
+			name = a.Name
+		}
+	}
+	return name, name != ""
+}
+
+// underPath reports whether path is prefix or a sub-path of it.
+func underPath(path, prefix string) bool {
+	rest, ok := strings.CutPrefix(path, prefix)
+	return ok && (rest == "" || strings.HasPrefix(rest, "/"))
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+)
+
+func TestAdminOnly(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	admins := []config.AdminTokenConfig{{Name: "ops", Token: "s3cret"}}
+	newRouter := func(admins []config.AdminTokenConfig) *gin.Engine {
+		r := gin.New()
+		r.Use(func(c *gin.Context) {
+			if c.GetHeader("X-Auditor") != "" {
+				c.Set(AuditorContextKey, c.GetHeader("X-Auditor"))
+			}
+		})
+		r.Use(AdminOnly("/api/v1/admin", admins, "/api/v1/admin/debug/pprof"))
+		handler := func(c *gin.Context) { c.String(http.StatusOK, c.GetString(AdminContextKey)) }
+		r.GET("/api/v1/admin", handler)
+		r.GET("/api/v1/admin/stats", handler)
+		r.GET("/api/v1/admin/debug/pprof/heap", handler)
+		r.GET("/api/v1/administrators", handler)
+		r.GET("/api/v1/health", handler)
+		return r
+	}
+
+	for _, tc := range []struct {
+		admins              []config.AdminTokenConfig
+		path, auth, auditor string
+		want                int
+		wantAdmin           string
+	}{
+		{admins, "/api/v1/admin", "", "", http.StatusUnauthorized, ""},
+		{admins, "/api/v1/admin/stats", "", "", http.StatusUnauthorized, ""},
+		{admins, "/api/v1/admin/stats", "Bearer wrong", "", http.StatusUnauthorized, ""},
+		{admins, "/api/v1/admin/stats", "s3cret", "", http.StatusUnauthorized, ""},
+		{admins, "/api/v1/admin/stats", "Bearer s3cret", "", http.StatusOK, "ops"},
+		{admins, "/api/v1/admin/stats", "Bearer audit", "soc2", http.StatusOK, ""},
+		{admins, "/api/v1/admin/debug/pprof/heap", "", "", http.StatusOK, ""},
+		{admins, "/api/v1/administrators", "", "", http.StatusOK, ""},
+		{admins, "/api/v1/health", "", "", http.StatusOK, ""},
+		{nil, "/api/v1/admin/stats", "Bearer s3cret", "", http.StatusNotFound, ""},
+		{nil, "/api/v1/health", "", "", http.StatusOK, ""},
+	} {
+		req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+		if tc.auth != "" {
+			req.Header.Set("Authorization", tc.auth)
+		}
+		if tc.auditor != "" {
+			req.Header.Set("X-Auditor", tc.auditor)
+		}
+		w := httptest.NewRecorder()
+		newRouter(tc.admins).ServeHTTP(w, req)
+		if w.Code != tc.want {
+			t.Fatalf("%s with %q (%d admins): expected %d, got %d", tc.path, tc.auth, len(tc.admins), tc.want, w.Code)
+		}
+		if w.Code == http.StatusOK && w.Body.String() != tc.wantAdmin {
+			t.Fatalf("%s with %q: expected admin %q, got %q", tc.path, tc.auth, tc.wantAdmin, w.Body.String())
+		}
+	}
+}
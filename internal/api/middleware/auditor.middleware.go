@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+)
+
+// AuditorContextKey holds the auditor name on requests made with an auditor
+// token.
+const AuditorContextKey = "auditor"
+
+// AuditorReadOnly enforces the read-only auditor capability. Requests whose
+// "Authorization: Bearer <token>" matches a configured auditor may use every
+// GET/HEAD/OPTIONS route and the endpoints in allow (exact paths or route
+// templates), which only read data or cancel the caller's own queries; any
+// other method is rejected with 403 before a handler runs, so new mutating
+// routes are covered without opting in. Other requests pass through
+// unchanged.
+func AuditorReadOnly(auditors []config.AuditorConfig, allow ...string) gin.HandlerFunc {
+	allowed := make(map[string]struct{}, len(allow))
+	for _, p := range allow {
+		allowed[p] = struct{}{}
+	}
+	return func(c *gin.Context) {
+		name, ok := auditorFor(c.GetHeader("Authorization"), auditors)
+		if !ok {
+			c.Next()
+			return
+		}
+		c.Set(AuditorContextKey, name)
+
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+		if allowedPath(allowed, c) {
+			c.Next()
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"status": "error",
+			"error":  "auditor access is read-only",
+		})
+	}
+}
+
+// auditorFor returns the auditor whose token is in header. Every configured
+// token is compared in constant time.
+func auditorFor(header string, auditors []config.AuditorConfig) (string, bool) {
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok || token == "" {
+		return "", false
+	}
+	name := ""
+	for _, a := range auditors {
+		if a.Token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(a.Token)) == 1 {
+			name = a.Name
+		}
+	}
+	return name, name != ""
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequireBearerToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/guarded", RequireBearerToken("s3cret"), func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.GET("/open", RequireBearerToken(""), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for _, tc := range []struct {
+		path, auth string
+		want       int
+	}{
+		{"/guarded", "", http.StatusUnauthorized},
+		{"/guarded", "Bearer wrong", http.StatusUnauthorized},
+		{"/guarded", "s3cret", http.StatusUnauthorized},
+		{"/guarded", "Bearer s3cret", http.StatusOK},
+		{"/open", "", http.StatusOK},
+	} {
+		req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+		if tc.auth != "" {
+			req.Header.Set("Authorization", tc.auth)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != tc.want {
+			t.Fatalf("%s with %q: expected %d, got %d", tc.path, tc.auth, tc.want, w.Code)
+		}
+	}
+}
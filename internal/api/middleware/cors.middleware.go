@@ -1,64 +1,50 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 
-	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
 )
 
-// CORSMiddleware handles Cross-Origin Resource Sharing for MIRADOR-UI communication
-func CORSMiddleware(corsConfig config.CORSConfig) gin.HandlerFunc {
+// CORSPolicyProvider returns the effective CORS policy and the decision for
+// a browser origin.
+type CORSPolicyProvider interface {
+	Policy(ctx context.Context, origin string) *models.CORSPolicy
+}
+
+// CORSMiddleware handles Cross-Origin Resource Sharing for MIRADOR-UI and
+// tenant-specific browser clients. Allowed origins are echoed back, with
+// Access-Control-Allow-Credentials only when the policy allows credentials
+// for that origin. Preflight requests are answered directly and cached by
+// the browser for the policy's max age.
+func CORSMiddleware(policy CORSPolicyProvider) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		origin := c.Request.Header.Get("Origin")
-
-		// Check if origin is allowed
-		if isOriginAllowed(origin, corsConfig.AllowedOrigins) {
-			c.Header("Access-Control-Allow-Origin", origin)
-		}
-
-		// Set allowed methods
-		if len(corsConfig.AllowedMethods) > 0 {
-			c.Header("Access-Control-Allow-Methods", strings.Join(corsConfig.AllowedMethods, ", "))
-		} else {
-			// Default methods for MIRADOR-CORE API
-			c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS, PATCH")
-		}
-
-		// Set allowed headers
-		if len(corsConfig.AllowedHeaders) > 0 {
-			c.Header("Access-Control-Allow-Headers", strings.Join(corsConfig.AllowedHeaders, ", "))
-		} else {
-			// Default headers for MIRADOR-CORE
-			c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization, X-Session-Token")
-		}
-
-		// Set exposed headers
-		if len(corsConfig.ExposedHeaders) > 0 {
-			c.Header("Access-Control-Expose-Headers", strings.Join(corsConfig.ExposedHeaders, ", "))
-		} else {
-			// Default exposed headers for MIRADOR-CORE
-			c.Header("Access-Control-Expose-Headers", "X-Rate-Limit-Limit, X-Rate-Limit-Remaining, X-Rate-Limit-Reset")
-		}
-
-		// Set credentials
-		if corsConfig.AllowCredentials {
-			c.Header("Access-Control-Allow-Credentials", "true")
+		c.Header("Vary", "Origin")
+		if origin == "" {
+			c.Next()
+			return
 		}
 
-		// Set max age
-		if corsConfig.MaxAge > 0 {
-			c.Header("Access-Control-Max-Age", strconv.Itoa(corsConfig.MaxAge))
-		} else {
-			// Default: 12 hours for MIRADOR-CORE
-			c.Header("Access-Control-Max-Age", "43200")
+		p := policy.Policy(c.Request.Context(), origin)
+		if p.Decision != nil && p.Decision.Allowed {
+			c.Header("Access-Control-Allow-Origin", origin)
+			if p.Decision.AllowCredentials {
+				c.Header("Access-Control-Allow-Credentials", "true")
+			}
 		}
+		c.Header("Access-Control-Allow-Methods", strings.Join(p.AllowedMethods, ", "))
+		c.Header("Access-Control-Allow-Headers", strings.Join(p.AllowedHeaders, ", "))
+		c.Header("Access-Control-Expose-Headers", strings.Join(p.ExposedHeaders, ", "))
+		c.Header("Access-Control-Max-Age", strconv.Itoa(p.MaxAgeSeconds))
 
 		// Handle preflight requests
-		if c.Request.Method == "OPTIONS" {
+		if c.Request.Method == http.MethodOptions {
 			c.AbortWithStatus(http.StatusNoContent)
 			return
 		}
@@ -66,29 +52,3 @@ func CORSMiddleware(corsConfig config.CORSConfig) gin.HandlerFunc {
 		c.Next()
 	}
 }
-
-// isOriginAllowed checks if the given origin is in the allowed origins list
-func isOriginAllowed(origin string, allowedOrigins []string) bool {
-	if len(allowedOrigins) == 0 {
-		// If no origins specified, allow localhost and common development origins
-		return strings.Contains(origin, "localhost") || strings.Contains(origin, "127.0.0.1") || strings.Contains(origin, "mirador-ui")
-	}
-
-	for _, allowedOrigin := range allowedOrigins {
-		if allowedOrigin == "*" {
-			return true
-		}
-		if origin == allowedOrigin {
-			return true
-		}
-		// Support wildcard subdomains (e.g., *.mirador.io)
-		if strings.HasPrefix(allowedOrigin, "*.") {
-			domain := strings.TrimPrefix(allowedOrigin, "*.")
-			if strings.HasSuffix(origin, domain) {
-				return true
-			}
-		}
-	}
-
-	return false
-}
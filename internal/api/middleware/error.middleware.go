@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/redact"
 )
 
 // ErrorResponse represents a standardized error response
@@ -32,7 +33,7 @@ func ErrorHandler(log logger.Logger) gin.HandlerFunc {
 
 			// Create standardized error response
 			errorResp := ErrorResponse{
-				Error: err.Err.Error(),
+				Error: redact.String(err.Err.Error()),
 				Code:  determineErrorCode(err.Err, statusCode),
 			}
 
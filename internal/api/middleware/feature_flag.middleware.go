@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/api/constants"
+)
+
+// FeatureEvaluator decides whether a feature flag is on for a caller.
+type FeatureEvaluator interface {
+	IsFeatureEnabled(ctx context.Context, flag, tenantID, userID string) bool
+}
+
+// CallerIdentity returns the tenant and user IDs forwarded by the gateway,
+// defaulting the tenant to the anonymous tenant.
+func CallerIdentity(c *gin.Context) (tenantID, userID string) {
+	tenantID = c.GetHeader(constants.HeaderTenantID)
+	if tenantID == "" {
+		tenantID = constants.AnonymousTenantID
+	}
+	return tenantID, c.GetHeader(constants.HeaderUserID)
+}
+
+// RequireFeature hides a route behind a feature flag: callers for whom the
+// flag is off get 404, as if the endpoint did not exist yet.
+func RequireFeature(evaluator FeatureEvaluator, flag string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID, userID := CallerIdentity(c)
+		if !evaluator.IsFeatureEnabled(c.Request.Context(), flag, tenantID, userID) {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{
+				"status": "error",
+				"error":  "feature not available",
+				"flag":   flag,
+			})
+			return
+		}
+		c.Next()
+	}
+}
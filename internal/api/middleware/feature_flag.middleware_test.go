@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/api/constants"
+)
+
+type userFlagEvaluator struct{ allowedUser string }
+
+func (e userFlagEvaluator) IsFeatureEnabled(_ context.Context, _, _, userID string) bool {
+	return userID == e.allowedUser
+}
+
+func TestRequireFeature(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/beta", RequireFeature(userFlagEvaluator{allowedUser: "alice"}, "beta"), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	for user, want := range map[string]int{"alice": http.StatusOK, "bob": http.StatusNotFound, "": http.StatusNotFound} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/beta", nil)
+		if user != "" {
+			req.Header.Set(constants.HeaderUserID, user)
+		}
+		r.ServeHTTP(w, req)
+		if w.Code != want {
+			t.Fatalf("user %q: expected %d, got %d", user, want, w.Code)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+)
+
+// MaintenanceStateProvider reports the current maintenance state.
+type MaintenanceStateProvider interface {
+	GetMaintenanceState(ctx context.Context) (*models.MaintenanceState, error)
+}
+
+// MaintenanceMode rejects mutating requests with 503 while maintenance is
+// active. Reads keep working and carry the banner in the X-Maintenance-Mode
+// header. Paths in allow (exact paths or route templates) are always let
+// through: read-only POST endpoints such as queries, query cancellation,
+// and the admin toggle itself so operators can switch maintenance off again.
+func MaintenanceMode(provider MaintenanceStateProvider, allow ...string) gin.HandlerFunc {
+	allowed := make(map[string]struct{}, len(allow))
+	for _, p := range allow {
+		allowed[p] = struct{}{}
+	}
+	return func(c *gin.Context) {
+		st, err := provider.GetMaintenanceState(c.Request.Context())
+		if err != nil || st == nil || !st.Enabled {
+			c.Next()
+			return
+		}
+
+		c.Header("X-Maintenance-Mode", st.Message)
+
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+		if allowedPath(allowed, c) {
+			c.Next()
+			return
+		}
+
+		c.Header("Retry-After", "300")
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+			"status":      "error",
+			"error":       "service is in maintenance mode; write operations are disabled",
+			"maintenance": st,
+		})
+	}
+}
+
+// allowedPath reports whether the request path, or the template of the route
+// it matched, is in allowed.
+func allowedPath(allowed map[string]struct{}, c *gin.Context) bool {
+	if _, ok := allowed[c.Request.URL.Path]; ok {
+		return true
+	}
+	_, ok := allowed[c.FullPath()]
+	return ok && c.FullPath() != ""
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+)
+
+type staticMaintenance struct{ state *models.MaintenanceState }
+
+func (s staticMaintenance) GetMaintenanceState(context.Context) (*models.MaintenanceState, error) {
+	return s.state, nil
+}
+
+func newMaintenanceRouter(enabled bool) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	st := &models.MaintenanceState{Enabled: enabled, Message: "upgrading", Source: "runtime"}
+	r.Use(MaintenanceMode(staticMaintenance{st}, "/api/v1/unified/query", "/api/v1/query-templates/:name/execute"))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.GET("/api/v1/kpi/defs", ok)
+	r.POST("/api/v1/kpi/defs", ok)
+	r.DELETE("/api/v1/kpi/defs/x", ok)
+	r.POST("/api/v1/unified/query", ok)
+	r.POST("/api/v1/query-templates/:name/execute", ok)
+	r.POST("/api/v1/admin/query-templates/:name/approve", ok)
+	return r
+}
+
+func TestMaintenanceMode_BlocksWritesWhenEnabled(t *testing.T) {
+	r := newMaintenanceRouter(true)
+
+	cases := []struct {
+		method, path string
+		want         int
+	}{
+		{http.MethodGet, "/api/v1/kpi/defs", http.StatusOK},
+		{http.MethodPost, "/api/v1/unified/query", http.StatusOK},
+		{http.MethodPost, "/api/v1/query-templates/errors/execute", http.StatusOK},
+		{http.MethodPost, "/api/v1/admin/query-templates/errors/approve", http.StatusServiceUnavailable},
+		{http.MethodPost, "/api/v1/kpi/defs", http.StatusServiceUnavailable},
+		{http.MethodDelete, "/api/v1/kpi/defs/x", http.StatusServiceUnavailable},
+	}
+	for _, tc := range cases {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(tc.method, tc.path, nil))
+		if w.Code != tc.want {
+			t.Fatalf("%s %s: expected %d, got %d", tc.method, tc.path, tc.want, w.Code)
+		}
+		if got := w.Header().Get("X-Maintenance-Mode"); got != "upgrading" {
+			t.Fatalf("%s %s: expected banner header, got %q", tc.method, tc.path, got)
+		}
+	}
+}
+
+func TestMaintenanceMode_PassesThroughWhenDisabled(t *testing.T) {
+	r := newMaintenanceRouter(false)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/kpi/defs", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if w.Header().Get("X-Maintenance-Mode") != "" {
+		t.Fatal("banner header should not be set when maintenance is off")
+	}
+}
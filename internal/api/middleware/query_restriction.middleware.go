@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RestrictedQueries rejects the ad-hoc query endpoints in paths (exact
+// match) with 403, leaving approved query templates as the only way to query
+// the backends. Regulated tenants run in this mode so that every query has
+// been reviewed.
+func RestrictedQueries(paths ...string) gin.HandlerFunc {
+	blocked := make(map[string]struct{}, len(paths))
+	for _, p := range paths {
+		blocked[p] = struct{}{}
+	}
+	return func(c *gin.Context) {
+		if _, ok := blocked[c.Request.URL.Path]; !ok {
+			c.Next()
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"status": "error",
+			"error":  "ad-hoc queries are disabled for this tenant; run an approved query template instead",
+			"code":   "query_templates_only",
+		})
+	}
+}
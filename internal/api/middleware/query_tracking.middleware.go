@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/api/constants"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+)
+
+// TrackQueries registers requests to the query routes in routes (route
+// templates, e.g. /api/v1/query-templates/:name/execute) as in-flight
+// queries of the calling user. The query ID is returned in the X-Query-ID
+// header before the handler runs, so the caller can cancel the query while
+// waiting for it. Requests without X-User-ID are served untracked, as
+// anonymous callers could otherwise list and cancel each other's queries.
+func TrackQueries(tracker *services.QueryTrackerService, routes ...string) gin.HandlerFunc {
+	tracked := make(map[string]struct{}, len(routes))
+	for _, r := range routes {
+		tracked[r] = struct{}{}
+	}
+	return func(c *gin.Context) {
+		user := c.GetHeader(constants.HeaderUserID)
+		if _, ok := tracked[c.FullPath()]; !ok || user == "" {
+			c.Next()
+			return
+		}
+		ctx, q, done := tracker.Start(c.Request.Context(), user, c.Request.Method, c.FullPath())
+		defer done()
+		c.Header(constants.HeaderQueryID, q.ID)
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
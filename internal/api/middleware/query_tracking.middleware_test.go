@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/api/constants"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func TestTrackQueries(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	log := logger.New("error")
+	tracker := services.NewQueryTrackerService(cache.NewNoopValkeyCache(log), log)
+
+	var running int
+	r := gin.New()
+	r.Use(TrackQueries(tracker, "/api/v1/uql/query"))
+	handler := func(c *gin.Context) {
+		queries, _ := tracker.List(c.Request.Context(), c.GetHeader(constants.HeaderUserID))
+		running = len(queries)
+		c.Status(http.StatusOK)
+	}
+	r.POST("/api/v1/uql/query", handler)
+	r.POST("/api/v1/other", handler)
+
+	for _, tc := range []struct {
+		path, user string
+		tracked    bool
+	}{
+		{"/api/v1/uql/query", "alice", true},
+		{"/api/v1/uql/query", "", false},
+		{"/api/v1/other", "alice", false},
+	} {
+		running = 0
+		req := httptest.NewRequest(http.MethodPost, tc.path, nil)
+		if tc.user != "" {
+			req.Header.Set(constants.HeaderUserID, tc.user)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if got := w.Header().Get(constants.HeaderQueryID) != ""; got != tc.tracked {
+			t.Fatalf("%s as %q: expected tracked=%v, got X-Query-ID %q", tc.path, tc.user, tc.tracked, w.Header().Get(constants.HeaderQueryID))
+		}
+		if tc.tracked && running != 1 {
+			t.Fatalf("%s as %q: expected the query to be listed while running, got %d", tc.path, tc.user, running)
+		}
+	}
+}
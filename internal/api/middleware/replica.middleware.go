@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReplicaReadOnly is installed on read-only replicas. Mutating requests are
+// answered with 307 Temporary Redirect to the same path on the primary, so
+// clients that follow redirects retry there with method and body intact and
+// others get a clear error naming the primary. Paths in allow (exact paths
+// or route templates) are endpoints that the replica serves itself, such as
+// read-only POST queries.
+func ReplicaReadOnly(primaryURL string, allow ...string) gin.HandlerFunc {
+	primaryURL = strings.TrimRight(primaryURL, "/")
+	allowed := make(map[string]struct{}, len(allow))
+	for _, p := range allow {
+		allowed[p] = struct{}{}
+	}
+	return func(c *gin.Context) {
+		c.Header("X-Mirador-Role", "replica")
+
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+		if allowedPath(allowed, c) {
+			c.Next()
+			return
+		}
+
+		location := primaryURL + c.Request.URL.RequestURI()
+		c.Header("Location", location)
+		c.AbortWithStatusJSON(http.StatusTemporaryRedirect, gin.H{
+			"status":   "error",
+			"error":    "this deployment is a read-only replica; send write requests to the primary",
+			"primary":  primaryURL,
+			"location": location,
+		})
+	}
+}
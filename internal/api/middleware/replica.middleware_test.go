@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestReplicaReadOnly(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(ReplicaReadOnly("https://primary.example/", "/api/v1/unified/query", "/api/v1/query-templates/:name/execute"))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.GET("/api/v1/kpi/defs", ok)
+	r.POST("/api/v1/kpi/defs", ok)
+	r.POST("/api/v1/unified/query", ok)
+	r.POST("/api/v1/query-templates/:name/execute", ok)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/kpi/defs", nil))
+	if w.Code != http.StatusOK || w.Header().Get("X-Mirador-Role") != "replica" {
+		t.Fatalf("reads must be served with role header, got %d", w.Code)
+	}
+
+	for _, path := range []string{"/api/v1/unified/query", "/api/v1/query-templates/errors/execute"} {
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("read-only POST %s must be served, got %d", path, w.Code)
+		}
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/kpi/defs?force=true", nil))
+	if w.Code != http.StatusTemporaryRedirect {
+		t.Fatalf("expected 307, got %d", w.Code)
+	}
+	if loc := w.Header().Get("Location"); loc != "https://primary.example/api/v1/kpi/defs?force=true" {
+		t.Fatalf("unexpected Location %q", loc)
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/mirastacklabs-ai/mirador-core/internal/api/constants"
 	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
 )
 
@@ -112,6 +113,7 @@ func RequestLoggerWithBody(log logger.Logger) gin.HandlerFunc {
 			"user_agent", c.Request.UserAgent(),
 			"session_id", sessionID,
 			"request_id", c.Request.Header.Get("X-Request-ID"),
+			"tenant_id", c.Request.Header.Get(constants.HeaderTenantID),
 			"content_length", c.Request.ContentLength,
 		}
 
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/api/constants"
+	"github.com/mirastacklabs-ai/mirador-core/internal/qos"
+)
+
+// TrafficClass tags the request context with the traffic class named in the
+// X-Traffic-Class header so backend queries it issues are scheduled in that
+// class. Untagged requests stay interactive; an unknown class is rejected.
+// Bulk callers (exports, scripted backfills) use it to step aside for
+// dashboards.
+func TrafficClass() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		raw := strings.ToLower(strings.TrimSpace(c.GetHeader(constants.HeaderTrafficClass)))
+		if raw == "" {
+			c.Next()
+			return
+		}
+		class, ok := qos.ParseClass(raw)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error":  "unknown " + constants.HeaderTrafficClass + " " + raw + ": expected interactive, background or batch",
+			})
+			return
+		}
+		c.Request = c.Request.WithContext(qos.WithClass(c.Request.Context(), class))
+		c.Next()
+	}
+}
@@ -0,0 +1,18 @@
+package middleware
+
+import "github.com/gin-gonic/gin"
+
+// UsageRecorder counts requests per route template.
+type UsageRecorder interface {
+	Record(method, route string, status int)
+}
+
+// UsageTelemetry records each request's method, route template and status
+// for opt-in usage telemetry. Only the template (c.FullPath()) is passed on;
+// parameters and query strings never are.
+func UsageTelemetry(recorder UsageRecorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		recorder.Record(c.Request.Method, c.FullPath(), c.Writer.Status())
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	wv "github.com/weaviate/weaviate-go-client/v5/weaviate"
@@ -16,7 +17,11 @@ import (
 	"github.com/mirastacklabs-ai/mirador-core/internal/api/handlers"
 	"github.com/mirastacklabs-ai/mirador-core/internal/api/middleware"
 	"github.com/mirastacklabs-ai/mirador-core/internal/bootstrap"
+	"github.com/mirastacklabs-ai/mirador-core/internal/callback"
 	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/datastore"
+	"github.com/mirastacklabs-ai/mirador-core/internal/discovery"
+	"github.com/mirastacklabs-ai/mirador-core/internal/faultinject"
 	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
 	"github.com/mirastacklabs-ai/mirador-core/internal/mariadb"
 
@@ -24,8 +29,10 @@ import (
 	"github.com/mirastacklabs-ai/mirador-core/internal/monitoring"
 	"github.com/mirastacklabs-ai/mirador-core/internal/rca"
 	"github.com/mirastacklabs-ai/mirador-core/internal/repo"
+	"github.com/mirastacklabs-ai/mirador-core/internal/residency"
 	"github.com/mirastacklabs-ai/mirador-core/internal/services"
 	"github.com/mirastacklabs-ai/mirador-core/internal/sync"
+	"github.com/mirastacklabs-ai/mirador-core/internal/ticketing"
 	"github.com/mirastacklabs-ai/mirador-core/internal/tracing"
 	"github.com/mirastacklabs-ai/mirador-core/internal/utils/bleve"
 	"github.com/mirastacklabs-ai/mirador-core/internal/utils/bleve/mapping"
@@ -46,18 +53,55 @@ type Server struct {
 	kpiRepo                     repo.KPIRepo
 	searchRouter                *search.SearchRouter
 	searchThrottling            *middleware.SearchQueryThrottlingMiddleware
+	featureFlags                *services.RuntimeFeatureFlagService
+	maintenance                 *services.MaintenanceService
+	cacheNamespaces             *services.CacheNamespaceService
+	catalogDedup                *services.CatalogDedupService
+	tenantSettings              *services.TenantSettingsService
+	notificationTemplates       *services.NotificationTemplateService
+	anonymization               *services.AnonymizationService
+	corsPolicy                  *services.CORSPolicyService
+	thresholdAdjustments        *services.ThresholdAdjustmentService
+	kpiHistory                  *services.KPIHistoryService
+	remediation                 *services.RemediationService
+	ticketing                   *services.TicketingService
+	serviceHealth               *services.ServiceHealthService
+	executiveSummary            *services.ExecutiveSummaryService
+	tenantStats                 *services.TenantStatsService
+	correlationHistory          *services.CorrelationHistoryService
+	capacity                    *services.CapacityService
+	selfSLO                     *services.SelfSLOService
+	internalHealth              *services.InternalHealthService
+	fleet                       *services.FleetService
+	cardinality                 *services.LabelCardinalityService
+	retention                   *services.RetentionService
+	compliance                  *services.ComplianceService
+	operations                  *services.OperationsService
+	queryTracker                *services.QueryTrackerService
+	usageTelemetry              *services.UsageTelemetryService
+	logLevels                   *services.LogLevelService
+	callbackServer              *callback.Server
+	profilingWatchdog           *services.ProfilingWatchdog
+	failureStore                *weavstore.WeaviateFailureStore
+	replication                 *services.ReplicationService
 	metricsMetadataIndexer      services.MetricsMetadataIndexer
 	metricsMetadataSynchronizer services.MetricsMetadataSynchronizer
 	router                      *gin.Engine
 	httpServer                  *http.Server
 	tracerProvider              *tracing.TracerProvider
 	weaviateClient              *wv.Client
+	weaviateEndpoints           *discovery.RoundRobinTransport
+	weaviateTransport           *weavstore.Transport
+	weaviateSchema              *weavstore.SchemaPreflight
 
 	// MariaDB integration (read-only tenant data)
 	mariaDBClient     *mariadb.Client
 	mariaDBDataSource *mariadb.DataSourceRepo
 	mariaDBKPI        *mariadb.KPIRepo
 	kpiSyncWorker     *sync.KPISyncWorker
+
+	// Warehouses holding SQL KPIs (kpi_datastores)
+	kpiDatastores *datastore.Registry
 }
 
 func NewServer(
@@ -103,6 +147,17 @@ func NewServer(
 		}
 	}
 
+	// KPI warehouses; KPIs referencing a failed one are skipped at evaluation
+	if len(cfg.KPIDatastores) > 0 {
+		stores, err := datastore.NewRegistry(cfg.KPIDatastores)
+		if err != nil {
+			log.Error("Failed to initialize KPI datastores", "error", err)
+		} else {
+			server.kpiDatastores = stores
+			log.Info("KPI datastores registered", "datastores", stores.Names())
+		}
+	}
+
 	// Initialize subsystems using helper methods to keep NewServer simple and
 	// reduce cyclomatic complexity.
 	server.tracerProvider = server.initTracing(cfg, log)
@@ -199,18 +254,164 @@ func (s *Server) initWeaviateStore(cfg *config.Config, log logger.Logger) (*weav
 		hostPort = fmt.Sprintf("%s:%d", cfg.Weaviate.Host, cfg.Weaviate.Port)
 	}
 	conf := wv.Config{Scheme: cfg.Weaviate.Scheme, Host: hostPort}
+	zapLogger := logging.ExtractZapLogger(logger.Named(log, logger.SubsystemWeaviate))
+	opts := weaviateTransportOptions(cfg.Weaviate.Transport)
+	transport := tracing.NewTransport(weavstore.NewPooledTransport(opts), tracing.BackendWeaviate)
+	transport = residency.WrapTransport(transport, cfg.Residency, "weaviate")
+	if faultinject.Default().Enabled() {
+		transport = faultinject.WrapTransport(transport, faultinject.TargetWeaviate)
+	}
+	if cfg.Weaviate.Discovery.Enabled {
+		// Requests go to Host until discovery (started in Start) finds pods.
+		s.weaviateEndpoints = discovery.NewRoundRobinTransport(transport)
+		transport = s.weaviateEndpoints
+	}
+	// Outermost, so retries can land on another discovered pod.
+	s.weaviateTransport = weavstore.NewTransport(transport, opts, zapLogger)
+	conf.ConnectionClient = &http.Client{Transport: s.weaviateTransport}
 	if client, err := wv.NewClient(conf); err == nil {
 		s.weaviateClient = client
-		zapLogger := logging.ExtractZapLogger(log)
+		if cfg.Weaviate.SchemaPreflight.Enabled {
+			expected := weavstore.ExpectedClasses(cfg.Weaviate.Vectorizer.Provider, weaviateReplicationPolicy(cfg.Weaviate))
+			s.weaviateSchema = weavstore.NewSchemaPreflight(client, expected, zapLogger)
+		}
 		// Pass vectorizer configuration so the store can create the class with
 		// the configured vectorizer provider and model (CPU-friendly defaults).
 		store := weavstore.NewWeaviateKPIStore(client, zapLogger, cfg.Weaviate.Vectorizer.Provider, cfg.Weaviate.Vectorizer.Model, cfg.Weaviate.Vectorizer.UseGPU)
+		store.SetReplicationPolicy(weaviateReplicationPolicy(cfg.Weaviate))
 		return store, zapLogger
 	}
 	log.Error("Failed to create Weaviate v5 client", "error", fmt.Errorf("weaviate client init failed"))
 	return nil, zap.NewNop()
 }
 
+// runSchemaPreflight compares the live Weaviate schema with the expected
+// classes. It only fails when BlockOnCritical is set and a property has the
+// wrong data type; an unreachable Weaviate is left to the stores' own
+// retries.
+func (s *Server) runSchemaPreflight(ctx context.Context) error {
+	cfg := s.config.Weaviate.SchemaPreflight
+	if cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.Timeout)
+		defer cancel()
+	}
+	report := s.weaviateSchema.Run(ctx)
+	if cfg.BlockOnCritical && report.Critical > 0 {
+		return fmt.Errorf("weaviate schema preflight found %d critical mismatches", report.Critical)
+	}
+	return nil
+}
+
+// weaviateTransportOptions converts the Weaviate transport config into
+// weavstore transport options.
+func weaviateTransportOptions(cfg config.WeaviateTransportConfig) weavstore.TransportOptions {
+	return weavstore.TransportOptions{
+		MaxIdleConnsPerHost: cfg.MaxIdleConnsPerHost,
+		MaxConnsPerHost:     cfg.MaxConnsPerHost,
+		IdleConnTimeout:     cfg.IdleConnTimeout,
+		MaxAttempts:         cfg.MaxAttempts,
+		InitialBackoff:      cfg.InitialBackoff,
+		MaxBackoff:          cfg.MaxBackoff,
+		BreakerFailures:     cfg.BreakerFailures,
+		BreakerCooldown:     cfg.BreakerCooldown,
+	}
+}
+
+// weaviateReplicationPolicy converts the per-class Weaviate config into the
+// policy applied by the weavstore stores.
+func weaviateReplicationPolicy(cfg config.WeaviateConfig) weavstore.ReplicationPolicy {
+	p := weavstore.ReplicationPolicy{DefaultConsistency: cfg.Consistency}
+	if len(cfg.Classes) > 0 {
+		p.Classes = make(map[string]weavstore.ClassReplication, len(cfg.Classes))
+		for name, c := range cfg.Classes {
+			p.Classes[name] = weavstore.ClassReplication{Factor: c.ReplicationFactor, Consistency: c.Consistency}
+		}
+	}
+	return p
+}
+
+// failureRecords returns the shared failure store, creating it on first use.
+// It is nil when Weaviate is not enabled.
+func (s *Server) failureRecords() *weavstore.WeaviateFailureStore {
+	if s.failureStore == nil && s.config.Weaviate.Enabled && s.weaviateClient != nil {
+		s.failureStore = weavstore.NewWeaviateFailureStore(s.weaviateClient, logging.ExtractZapLogger(logger.Named(s.logger, logger.SubsystemWeaviate)))
+		s.failureStore.SetReplicationPolicy(weaviateReplicationPolicy(s.config.Weaviate))
+	}
+	return s.failureStore
+}
+
+// correlationEngineConfig is the engine configuration of the correlation
+// engines. Replicas do not record runs: they must never write to the
+// replicated stores.
+func (s *Server) correlationEngineConfig() config.EngineConfig {
+	cfg := s.config.Engine
+	if s.replication != nil && s.replication.IsReplica() {
+		cfg.RecordRuns = false
+	}
+	return cfg
+}
+
+// recordCorrelationHistory stores the results of engine in the correlation
+// history when it has a store. Replicas read the history but never write it.
+func (s *Server) recordCorrelationHistory(engine services.CorrelationEngine) {
+	if s.correlationHistory == nil || !s.correlationHistory.Enabled() {
+		return
+	}
+	if s.replication != nil && s.replication.IsReplica() {
+		return
+	}
+	if impl, ok := engine.(*services.CorrelationEngineImpl); ok {
+		impl.SetHistory(s.correlationHistory)
+	}
+}
+
+// registerTenantStats registers the statistics of every configured store
+// with the tenant statistics snapshots.
+func (s *Server) registerTenantStats(queryTemplates *services.QueryTemplateService, computedColumns *services.ComputedColumnService) {
+	if s.kpiRepo != nil {
+		s.tenantStats.Register("kpis", func(ctx context.Context) (int64, error) {
+			_, total, err := s.kpiRepo.ListKPIs(ctx, models.KPIListRequest{Limit: 1})
+			return total, err
+		})
+	}
+	if s.weaviateClient != nil {
+		for _, class := range weavstore.ExpectedClasses("", weavstore.ReplicationPolicy{}) {
+			name := class.Class
+			s.tenantStats.Register("weaviate."+name, func(ctx context.Context) (int64, error) {
+				return weavstore.CountObjects(ctx, s.weaviateClient, name)
+			})
+		}
+	}
+	if s.config.Cache.TenantID != "" {
+		s.tenantStats.Register("cache_keys", func(ctx context.Context) (int64, error) {
+			usage, err := s.cacheNamespaces.TenantUsage(ctx)
+			if err != nil {
+				return 0, err
+			}
+			for _, u := range usage {
+				if u.Tenant == s.config.Cache.TenantID {
+					return u.Keys, nil
+				}
+			}
+			return 0, nil
+		})
+	}
+	s.tenantStats.Register("query_templates", countOf(queryTemplates.List))
+	s.tenantStats.Register("computed_columns", countOf(computedColumns.List))
+	s.tenantStats.Register("notification_templates", countOf(s.notificationTemplates.List))
+	s.tenantStats.Register("anonymization_profiles", countOf(s.anonymization.List))
+	s.tenantStats.Register("operations_24h", countOf(s.operations.List))
+}
+
+// countOf counts the items returned by list.
+func countOf[T any](list func(context.Context) ([]T, error)) services.TenantStatsCounter {
+	return func(ctx context.Context) (int64, error) {
+		items, err := list(ctx)
+		return int64(len(items)), err
+	}
+}
+
 // initKPIRepo wires the KPIRepo: prefer schemaRepo if it implements KPIRepo,
 // otherwise construct DefaultKPIRepo using the provided weaviate store and zap logger.
 func (s *Server) initKPIRepo(schemaRepo repo.SchemaStore, kpiStore *weavstore.WeaviateKPIStore, zapLogger *zap.Logger) {
@@ -230,25 +431,121 @@ func (s *Server) initKPIRepo(schemaRepo repo.SchemaStore, kpiStore *weavstore.We
 	s.kpiRepo = kpiRepo
 }
 
+// readOnlyPostPaths are POST endpoints that only read data (queries). They
+// stay available in maintenance mode and on read-only replicas.
+var readOnlyPostPaths = []string{
+	"/api/v1/kpi/search",
+	"/api/v1/unified/query",
+	"/api/v1/unified/correlation",
+	"/api/v1/unified/failures/correlate",
+	"/api/v1/unified/failures/list",
+	"/api/v1/unified/failures/get",
+	"/api/v1/unified/search",
+	"/api/v1/unified/rca",
+	"/api/v1/unified/service-graph",
+	"/api/v1/uql/query",
+	"/api/v1/uql/validate",
+	"/api/v1/uql/explain",
+	"/api/v1/computed-columns/preview",
+	"/api/v1/query-templates/:name/execute",
+}
+
+// adHocQueryPaths run caller-written MetricsQL, LogsQL or UQL. They are
+// disabled when query_templates.restricted is set.
+var adHocQueryPaths = []string{
+	"/api/v1/unified/query",
+	"/api/v1/unified/search",
+	"/api/v1/unified/correlation",
+	"/api/v1/unified/correlation/stream",
+	"/api/v1/uql/query",
+	"/api/v1/analytics/compare",
+}
+
+// trackedQueryRoutes are the route templates of queries callers can list
+// and cancel while they run (GET /api/v1/queries).
+var trackedQueryRoutes = []string{
+	"/api/v1/unified/query",
+	"/api/v1/unified/correlation",
+	"/api/v1/unified/correlation/stream",
+	"/api/v1/unified/failures/correlate",
+	"/api/v1/unified/search",
+	"/api/v1/unified/rca",
+	"/api/v1/unified/service-graph",
+	"/api/v1/uql/query",
+	"/api/v1/query-templates/:name/execute",
+	"/api/v1/metrics/cardinality/labels",
+	"/api/v1/metrics/cardinality/labels/:label",
+}
+
+// queryCancelRoute cancels a tracked query. It stays available to auditors,
+// in maintenance mode and on replicas, which serve queries themselves.
+const queryCancelRoute = "/api/v1/queries/:id"
+
 func (s *Server) setupMiddleware() {
 	// Recovery middleware
 	s.router.Use(gin.Recovery())
 
 	// Error handling middleware (must be early)
-	s.router.Use(middleware.ErrorHandler(s.logger))
+	s.router.Use(middleware.ErrorHandler(logger.Named(s.logger, logger.SubsystemAPI)))
 
 	// CORS for MIRADOR-UI communication
-	s.router.Use(middleware.CORSMiddleware(s.config.CORS))
+	s.tenantSettings = services.NewTenantSettingsService(s.cache, services.NewMessageCatalog(), s.logger)
+	s.notificationTemplates = services.NewNotificationTemplateService(s.cache, s.tenantSettings, s.logger)
+	s.corsPolicy = services.NewCORSPolicyService(s.config.CORS, s.tenantSettings, s.logger)
+	s.router.Use(middleware.CORSMiddleware(s.corsPolicy))
 
 	// Request logging
-	s.router.Use(middleware.RequestLogger(s.logger))
+	s.router.Use(middleware.RequestLogger(logger.Named(s.logger, logger.SubsystemAPI)))
 
 	// Prometheus request metrics
 	s.router.Use(middleware.MetricsMiddleware())
 
+	// Opt-in anonymized usage counters per route template
+	s.usageTelemetry = services.NewUsageTelemetryService(s.config.UsageTelemetry, s.cache, s.logger)
+	if s.usageTelemetry.Enabled() {
+		s.router.Use(middleware.UsageTelemetry(s.usageTelemetry))
+	}
+
+	// Backend query traffic class (X-Traffic-Class)
+	s.router.Use(middleware.TrafficClass())
+
 	// Rate limiting using Valkey cluster
 	s.router.Use(middleware.RateLimiter(s.cache))
 
+	// Read-only auditors: reject their writes with 403 before maintenance or
+	// replica handling could answer differently.
+	if len(s.config.Auditors) > 0 {
+		s.router.Use(middleware.AuditorReadOnly(s.config.Auditors, append([]string{queryCancelRoute}, readOnlyPostPaths...)...))
+	}
+
+	// Admin API: named admin tokens, or not served at all. Profiling routes
+	// check profiling.token instead when it is set.
+	var ownToken []string
+	if s.config.Profiling.Token != "" {
+		ownToken = []string{"/api/v1/admin/debug/pprof", "/api/v1/admin/profiles"}
+	}
+	s.router.Use(middleware.AdminOnly("/api/v1/admin", s.config.Admin.Tokens, ownToken...))
+
+	// Maintenance mode: reject writes with 503 while keeping reads (including
+	// read-only POST query endpoints) working.
+	s.maintenance = services.NewMaintenanceService(s.cache, s.config.Maintenance, s.logger)
+	s.router.Use(middleware.MaintenanceMode(s.maintenance, append([]string{"/api/v1/admin/maintenance", queryCancelRoute}, readOnlyPostPaths...)...))
+
+	// Read-only replica: redirect writes to the primary region
+	s.replication = services.NewReplicationService(s.cache, s.config.Replication, s.logger)
+	if s.replication.IsReplica() {
+		s.router.Use(middleware.ReplicaReadOnly(s.replication.PrimaryURL(), append([]string{queryCancelRoute}, readOnlyPostPaths...)...))
+	}
+
+	// Restricted tenants may only run approved query templates
+	if s.config.QueryTemplates.Restricted {
+		s.router.Use(middleware.RestrictedQueries(adHocQueryPaths...))
+	}
+
+	// In-flight query tracking: X-Query-ID and DELETE /api/v1/queries/:id
+	s.queryTracker = services.NewQueryTrackerService(s.cache, s.logger)
+	s.router.Use(middleware.TrackQueries(s.queryTracker, trackedQueryRoutes...))
+
 	// Search query throttling based on complexity
 	s.searchThrottling = middleware.NewSearchQueryThrottlingMiddleware(s.cache, s.logger)
 
@@ -267,6 +564,9 @@ func (s *Server) setupMiddleware() {
 func (s *Server) setupRoutes() {
 	// Create health handler instance with MariaDB support
 	healthHandler := handlers.NewHealthHandlerWithMariaDB(s.vmServices, s.cache, s.mariaDBClient, s.logger)
+	healthHandler.SetMaintenanceService(s.maintenance)
+	healthHandler.SetReplicationService(s.replication)
+	healthHandler.SetWeaviateTransport(s.weaviateTransport)
 
 	// Public health endpoints - now using handler instance methods
 	s.router.GET("/health", healthHandler.HealthCheck)
@@ -288,6 +588,276 @@ func (s *Server) setupRoutes() {
 	// Also expose metrics under /api/v1 for consistency
 	monitoring.SetupPrometheusMetrics(v1)
 
+	// Maintenance mode admin API
+	maintenanceHandler := handlers.NewMaintenanceHandler(s.maintenance, s.logger)
+	v1.GET("/admin/maintenance", maintenanceHandler.GetMaintenance)
+	v1.PUT("/admin/maintenance", maintenanceHandler.SetMaintenance)
+
+	// Per-tenant cache namespaces
+	s.cacheNamespaces = services.NewCacheNamespaceService(s.cache, s.logger)
+	cacheNamespaceHandler := handlers.NewCacheNamespaceHandler(s.cacheNamespaces, s.logger)
+	v1.GET("/admin/cache/tenants", cacheNamespaceHandler.ListTenants)
+	v1.DELETE("/admin/cache/tenants/:tenant", cacheNamespaceHandler.FlushTenant)
+
+	// Self-SLOs for mirador-core's own endpoints
+	s.selfSLO = services.NewSelfSLOService(prometheus.DefaultGatherer, s.newNotificationService(), s.config.SelfSLO, s.logger)
+	selfSLOHandler := handlers.NewSelfSLOHandler(s.selfSLO, s.logger)
+	v1.GET("/admin/self-slo", selfSLOHandler.GetStatus)
+
+	// Pre-aggregated trends of mirador-core's own health for the admin page
+	s.internalHealth = services.NewInternalHealthService(prometheus.DefaultGatherer, s.config.InternalHealth, s.logger)
+	v1.GET("/admin/health/trends", handlers.NewInternalHealthHandler(s.internalHealth, s.logger).GetTrends)
+
+	// Version handshake between instances during rolling upgrades. Weaviate
+	// classes are created on first use, so no layout needs a migration yet.
+	s.fleet = services.NewFleetService(s.cache, s.config.Fleet, nil, s.logger)
+	v1.GET("/admin/fleet/versions", handlers.NewFleetHandler(s.fleet, s.logger).GetVersions)
+
+	// Weaviate schema drift report from the startup preflight
+	if s.weaviateSchema != nil {
+		v1.GET("/admin/weaviate/schema", handlers.NewWeaviateSchemaHandler(s.weaviateSchema, s.config.Weaviate.SchemaPreflight.Timeout, s.logger).GetReport)
+	}
+
+	// Usage telemetry payload, shown whether or not reporting is enabled
+	usageTelemetryHandler := handlers.NewUsageTelemetryHandler(s.usageTelemetry, s.logger)
+	v1.GET("/admin/telemetry", usageTelemetryHandler.GetStatus)
+
+	// Long-running operations started by bulk endpoints (?async=true)
+	s.operations = services.NewOperationsService(s.cache, s.logger)
+	operationsHandler := handlers.NewOperationsHandler(s.operations, s.logger)
+	v1.GET("/operations", operationsHandler.ListOperations)
+	v1.GET("/operations/:id", operationsHandler.GetOperation)
+	v1.POST("/operations/:id/cancel", operationsHandler.CancelOperation)
+	v1.GET("/operations/:id/events", operationsHandler.StreamOperation)
+
+	// In-flight queries of the caller
+	queryTrackerHandler := handlers.NewQueryTrackerHandler(s.queryTracker, s.logger)
+	v1.GET("/queries", queryTrackerHandler.List)
+	v1.DELETE("/queries/:id", queryTrackerHandler.Cancel)
+
+	// Retention policies enforced by deletes against VictoriaMetrics/Logs
+	var retentionMetrics services.RetentionMetricsBackend
+	var retentionLogs services.RetentionLogsBackend
+	if s.vmServices != nil && s.vmServices.Metrics != nil {
+		retentionMetrics = s.vmServices.Metrics
+	}
+	if s.vmServices != nil && s.vmServices.Logs != nil {
+		retentionLogs = s.vmServices.Logs
+	}
+	s.retention = services.NewRetentionService(retentionMetrics, retentionLogs, s.cache, s.config.Retention, s.logger)
+	retentionHandler := handlers.NewRetentionHandler(s.retention, s.operations, s.logger)
+	v1.GET("/admin/retention/policies", retentionHandler.ListPolicies)
+	v1.POST("/admin/retention/policies", retentionHandler.CreatePolicy)
+	v1.PUT("/admin/retention/policies/:id", retentionHandler.UpdatePolicy)
+	v1.DELETE("/admin/retention/policies/:id", retentionHandler.DeletePolicy)
+	v1.GET("/admin/retention/policies/:id/preview", retentionHandler.PreviewPolicy)
+	v1.POST("/admin/retention/policies/:id/purge", retentionHandler.PurgePolicy)
+	v1.GET("/admin/retention/audit", retentionHandler.ListAudit)
+
+	// SOC2/ISO control status of the running configuration
+	s.compliance = services.NewComplianceService(s.config, s.retention, s.logger)
+	v1.GET("/admin/compliance/report", handlers.NewComplianceHandler(s.compliance, s.logger).GetReport)
+
+	// Data residency audit: where the tenant's data flows
+	v1.GET("/admin/residency", handlers.NewResidencyHandler(services.NewResidencyService(s.config, s.logger), s.logger).GetReport)
+
+	// Job results pushed by AI engines over the callback gRPC server
+	callbackResults := services.NewCallbackResultService(s.cache, s.config.Callbacks, s.logger)
+	callbackResultHandler := handlers.NewCallbackResultHandler(callbackResults, s.logger)
+	v1.GET("/callbacks/results/:job_id", callbackResultHandler.GetResult)
+	if s.config.Callbacks.Enabled {
+		if srv, err := callback.NewServer(s.config.Callbacks, callbackResults, s.logger); err != nil {
+			s.logger.Error("Failed to create callback gRPC server", "error", err)
+		} else {
+			s.callbackServer = srv
+		}
+	}
+
+	// Profile capture on high load, and guarded pprof endpoints
+	if s.config.Profiling.Watchdog.Enabled {
+		if store, err := services.NewFileProfileStore(s.config.Profiling.Watchdog.Dir); err != nil {
+			s.logger.Error("Profiling watchdog disabled", "error", err)
+		} else {
+			s.profilingWatchdog = services.NewProfilingWatchdog(prometheus.DefaultGatherer, store, s.config.Profiling.Watchdog, s.logger)
+		}
+	}
+	if s.config.Profiling.Enabled {
+		profilingHandler := handlers.NewProfilingHandler(s.profilingWatchdog, s.logger)
+		profiling := v1.Group("/admin", middleware.RequireBearerToken(s.config.Profiling.Token))
+		profilingHandler.RegisterPprof(profiling.Group("/debug/pprof"))
+		profiling.GET("/profiles", profilingHandler.ListCaptures)
+		profiling.GET("/profiles/:name", profilingHandler.GetCapture)
+	}
+
+	// Dependency fault injection admin API (only when enabled at startup)
+	if faultinject.Default().Enabled() {
+		faultHandler := handlers.NewFaultInjectionHandler(faultinject.Default(), s.logger)
+		v1.GET("/admin/faults", faultHandler.ListFaults)
+		v1.PUT("/admin/faults/:target", faultHandler.SetFault)
+		v1.DELETE("/admin/faults/:target", faultHandler.ClearFault)
+		v1.DELETE("/admin/faults", faultHandler.ClearAllFaults)
+	}
+
+	// Feature flags: evaluation for clients and runtime admin toggles.
+	// Gate new routes with middleware.RequireFeature(s.featureFlags, "<flag>").
+	dynamicConfig := services.NewDynamicConfigService(s.cache, s.logger)
+	s.featureFlags = services.NewRuntimeFeatureFlagService(s.cache, s.logger)
+	s.featureFlags.SetConfigStore(dynamicConfig)
+	featureFlagHandler := handlers.NewFeatureFlagHandler(s.featureFlags, s.logger)
+	v1.GET("/features/evaluate", featureFlagHandler.EvaluateFlags)
+	v1.GET("/admin/feature-flags", featureFlagHandler.ListFlags)
+	v1.PUT("/admin/feature-flags/:name", featureFlagHandler.UpsertFlag)
+	v1.DELETE("/admin/feature-flags/:name", featureFlagHandler.DeleteFlag)
+
+	// Dynamic config version history and rollback; effective config and
+	// tenant overrides
+	dynamicConfigHandler := handlers.NewDynamicConfigHandler(dynamicConfig, s.config, s.logger)
+	v1.GET("/admin/config/effective", dynamicConfigHandler.Effective)
+	v1.PUT("/admin/config/overrides", dynamicConfigHandler.SetOverrides)
+	v1.GET("/admin/config/:name/versions", dynamicConfigHandler.ListVersions)
+	v1.POST("/admin/config/:name/rollback", dynamicConfigHandler.Rollback)
+
+	// Per-subsystem log levels, stored as the log_levels dynamic config
+	s.logLevels = services.NewLogLevelService(dynamicConfig, s.logger, s.config.LogLevel, s.config.LogLevels)
+	logLevelHandler := handlers.NewLogLevelHandler(s.logLevels, s.logger)
+	v1.GET("/admin/log-levels", logLevelHandler.GetLevels)
+	v1.PUT("/admin/log-levels", logLevelHandler.SetLevels)
+
+	// Correlation feedback and offline A/B evaluation of engine scoring
+	correlationEval := services.NewCorrelationEvalService(s.cache, s.config.Engine, s.logger)
+	correlationEvalHandler := handlers.NewCorrelationEvalHandler(correlationEval, s.logger)
+	v1.POST("/correlation/feedback", correlationEvalHandler.SubmitFeedback)
+	v1.POST("/admin/correlation/evaluate", correlationEvalHandler.Evaluate)
+
+	// Stored correlation results, which RCA is built from, and their
+	// annotations; annotations also feed the evaluation above
+	var historyStore services.CorrelationHistoryStore
+	if s.config.Weaviate.Enabled && s.weaviateClient != nil {
+		store := weavstore.NewWeaviateCorrelationHistoryStore(s.weaviateClient, logging.ExtractZapLogger(logger.Named(s.logger, logger.SubsystemWeaviate)))
+		store.SetReplicationPolicy(weaviateReplicationPolicy(s.config.Weaviate))
+		historyStore = store
+	}
+	s.correlationHistory = services.NewCorrelationHistoryService(historyStore, correlationEval, s.config.Cache.TenantID, s.logger)
+	correlationHistoryHandler := handlers.NewCorrelationHistoryHandler(s.correlationHistory, s.logger)
+	v1.GET("/correlation/history", correlationHistoryHandler.List)
+	v1.GET("/correlation/history/:id", correlationHistoryHandler.Get)
+	v1.PUT("/correlation/history/:id/annotation", correlationHistoryHandler.Annotate)
+
+	// Share links and comment threads on recorded correlation runs
+	var commentStore services.CorrelationCommentStore
+	if s.config.Weaviate.Enabled && s.weaviateClient != nil {
+		store := weavstore.NewWeaviateCorrelationCommentStore(s.weaviateClient, logging.ExtractZapLogger(logger.Named(s.logger, logger.SubsystemWeaviate)))
+		store.SetReplicationPolicy(weaviateReplicationPolicy(s.config.Weaviate))
+		commentStore = store
+	}
+	correlationCollab := services.NewCorrelationCollabService(s.cache, commentStore, s.newNotificationService(), s.config.Cache.TenantID, s.logger)
+	if s.correlationHistory.Enabled() {
+		correlationCollab.SetHistory(s.correlationHistory)
+	}
+	correlationCollabHandler := handlers.NewCorrelationCollabHandler(correlationCollab, s.logger)
+	v1.POST("/correlation/runs/:id/share", correlationCollabHandler.Share)
+	v1.DELETE("/correlation/shares/:token", correlationCollabHandler.Revoke)
+	v1.GET("/correlation/shared/:token", correlationCollabHandler.Shared)
+	v1.GET("/correlation/runs/:id/comments", correlationCollabHandler.ListComments)
+	v1.POST("/correlation/runs/:id/comments", correlationCollabHandler.AddComment)
+
+	// Suppression rules for known-noisy KPI pairs
+	suppressionHandler := handlers.NewCorrelationSuppressionHandler(services.NewCorrelationSuppressionService(s.cache, s.logger), s.logger)
+	v1.GET("/correlation/suppressions", suppressionHandler.ListRules)
+	v1.POST("/correlation/suppressions", suppressionHandler.CreateRule)
+	v1.DELETE("/correlation/suppressions/:id", suppressionHandler.DeleteRule)
+
+	// Kubernetes and CI/CD events weighed by correlation
+	eventHandler := handlers.NewSignalEventHandler(services.NewSignalEventService(s.cache, config.MergeEngineConfigWithDefaults(s.config.Engine).Events, s.logger), s.logger)
+	v1.GET("/events", eventHandler.List)
+	v1.POST("/events", eventHandler.Ingest)
+	v1.POST("/events/kubernetes", eventHandler.IngestKubernetes)
+
+	// Jira/ServiceNow tickets for incidents (failure records)
+	ticketCfg := s.config.Integrations.Ticketing
+	ticketProvider, err := ticketing.New(ticketCfg, &http.Client{Timeout: ticketCfg.Timeout})
+	if err != nil {
+		s.internalLogger.Error("Ticketing disabled", "error", err)
+	}
+	s.ticketing = services.NewTicketingService(ticketProvider, s.cache, ticketCfg, s.logger)
+	ticketingHandler := handlers.NewTicketingHandler(s.ticketing, s.logger)
+	v1.POST("/integrations/ticketing/webhook", ticketingHandler.Webhook)
+
+	// Remediation rules run on KPI state changes and ingested alerts
+	s.remediation = services.NewRemediationService(s.cache, s.newNotificationService(), s.config.Remediation, s.logger)
+	remediationHandler := handlers.NewRemediationHandler(s.remediation, s.logger)
+	v1.GET("/remediation/rules", remediationHandler.ListRules)
+	v1.POST("/remediation/rules", remediationHandler.CreateRule)
+	v1.GET("/remediation/rules/:id", remediationHandler.GetRule)
+	v1.PUT("/remediation/rules/:id", remediationHandler.UpdateRule)
+	v1.DELETE("/remediation/rules/:id", remediationHandler.DeleteRule)
+	v1.POST("/remediation/rules/:id/test", remediationHandler.TestRule)
+	v1.GET("/remediation/rules/:id/executions", remediationHandler.Executions)
+	v1.POST("/remediation/events", remediationHandler.Ingest)
+
+	// Tenant branding/localization settings and i18n message catalog
+	tenantSettingsHandler := handlers.NewTenantSettingsHandler(s.tenantSettings, s.logger)
+	v1.GET("/tenant/settings", tenantSettingsHandler.GetSettings)
+	v1.PUT("/tenant/settings", tenantSettingsHandler.UpdateSettings)
+	v1.GET("/i18n/messages", tenantSettingsHandler.GetMessages)
+
+	// Notification templates per channel, event type and locale
+	notificationTemplateHandler := handlers.NewNotificationTemplateHandler(s.notificationTemplates, s.logger)
+	v1.GET("/notifications/templates", notificationTemplateHandler.List)
+	v1.POST("/notifications/templates/preview", notificationTemplateHandler.Preview)
+	v1.GET("/notifications/templates/:channel/:event/:locale", notificationTemplateHandler.Get)
+	v1.PUT("/notifications/templates/:channel/:event/:locale", notificationTemplateHandler.Put)
+	v1.DELETE("/notifications/templates/:channel/:event/:locale", notificationTemplateHandler.Delete)
+	v1.POST("/notifications/templates/:channel/:event/:locale/render", notificationTemplateHandler.Render)
+
+	// Anonymization profiles applied to data shared outside the tenant
+	s.anonymization = services.NewAnonymizationService(s.cache, s.config.Export, s.logger)
+	if s.vmServices != nil && s.vmServices.Logs != nil {
+		s.vmServices.Logs.SetAnonymization(s.anonymization)
+	}
+	anonymizationHandler := handlers.NewAnonymizationHandler(s.anonymization, s.logger)
+	v1.GET("/anonymization/profiles", anonymizationHandler.List)
+	v1.GET("/anonymization/profiles/:name", anonymizationHandler.Get)
+	v1.PUT("/anonymization/profiles/:name", anonymizationHandler.Put)
+	v1.DELETE("/anonymization/profiles/:name", anonymizationHandler.Delete)
+	v1.POST("/anonymization/profiles/:name/apply", anonymizationHandler.Apply)
+	v1.GET("/anonymization/reports/:id", anonymizationHandler.GetReport)
+
+	// Computed columns added to logs query results
+	computedColumns := services.NewComputedColumnService(s.cache, s.logger)
+	if s.vmServices != nil && s.vmServices.Logs != nil {
+		s.vmServices.Logs.SetComputedColumns(computedColumns)
+	}
+	computedColumnHandler := handlers.NewComputedColumnHandler(computedColumns, s.logger)
+	v1.GET("/computed-columns", computedColumnHandler.List)
+	v1.POST("/computed-columns/preview", computedColumnHandler.Preview)
+	v1.GET("/computed-columns/:name", computedColumnHandler.Get)
+	v1.PUT("/computed-columns/:name", computedColumnHandler.Put)
+	v1.DELETE("/computed-columns/:name", computedColumnHandler.Delete)
+
+	// Reviewed, parameterized queries; the only queries restricted tenants can run
+	var templateMetrics services.QueryTemplateMetricsBackend
+	var templateLogs services.QueryTemplateLogsBackend
+	if s.vmServices != nil && s.vmServices.Metrics != nil {
+		templateMetrics = s.vmServices.Metrics
+	}
+	if s.vmServices != nil && s.vmServices.Logs != nil {
+		templateLogs = s.vmServices.Logs
+	}
+	queryTemplates := services.NewQueryTemplateService(s.cache, templateMetrics, templateLogs, s.config.QueryTemplates, s.logger)
+	queryTemplateHandler := handlers.NewQueryTemplateHandler(queryTemplates, s.logger)
+	v1.GET("/query-templates", queryTemplateHandler.List)
+	v1.GET("/query-templates/:name", queryTemplateHandler.Get)
+	v1.PUT("/query-templates/:name", queryTemplateHandler.Put)
+	v1.DELETE("/query-templates/:name", queryTemplateHandler.Delete)
+	v1.POST("/admin/query-templates/:name/approve", queryTemplateHandler.Approve)
+	v1.POST("/admin/query-templates/:name/reject", queryTemplateHandler.Reject)
+	v1.POST("/query-templates/:name/execute", queryTemplateHandler.Execute)
+
+	// Effective CORS policy, including the tenant's allowedOrigins
+	corsHandler := handlers.NewCORSHandler(s.corsPolicy, s.logger)
+	v1.GET("/admin/cors", corsHandler.TestOrigin)
+
 	// MetricsQL endpoints (VictoriaMetrics integration)
 	// var metricsHandler *handlers.MetricsQLHandler
 	// if s.schemaRepo != nil {
@@ -325,10 +895,12 @@ func (s *Server) setupRoutes() {
 		s.vmServices.Traces,
 		s.kpiRepo,
 		s.cache,
-		s.logger,
-		s.config.Engine,
+		logger.Named(s.logger, logger.SubsystemCorrelation),
+		s.correlationEngineConfig(),
 	)
 
+	s.recordCorrelationHistory(correlationEngineForProvider)
+
 	anomalyProviderForEngine := &correlationAnomalyProvider{ce: correlationEngineForProvider, logger: s.logger}
 
 	// Create anomaly collector and candidate cause service
@@ -342,10 +914,37 @@ func (s *Server) setupRoutes() {
 	// Create RCA engine for endpoints (wire correlation engine for TimeRange API)
 	rcaEngineForEndpoints := rca.NewRCAEngine(candidateCauseServiceForEngine, rcaServiceGraphForEngine, s.logger, s.config.Engine, correlationEngineForProvider)
 
+	// Label cardinality analysis; flagged labels feed formula lint and the
+	// series-limit suggestions
+	var cardinalityBackend services.CardinalityBackend
+	if s.vmServices != nil && s.vmServices.Metrics != nil {
+		cardinalityBackend = s.vmServices.Metrics
+	}
+	s.cardinality = services.NewLabelCardinalityService(cardinalityBackend, s.cache, s.config.Cardinality, s.logger)
+	if cardinalityBackend != nil {
+		s.vmServices.Metrics.SetCardinality(s.cardinality)
+	}
+	cardinalityHandler := handlers.NewCardinalityHandler(s.cardinality, s.logger)
+	v1.GET("/metrics/cardinality/labels", cardinalityHandler.Scan)
+	v1.GET("/metrics/cardinality/labels/:label", cardinalityHandler.Analyze)
+
 	// KPI APIs (primary interface for schema definitions)
 	if s.kpiRepo != nil {
-		kpiHandler := handlers.NewKPIHandler(s.config, s.kpiRepo, s.cache, s.logger)
+		// Formula lint against the metrics and logs catalog
+		var lintMetrics services.FormulaLintMetricsCatalog
+		var lintLogs services.FormulaLintLogsCatalog
+		if s.vmServices != nil && s.vmServices.Metrics != nil {
+			lintMetrics = s.vmServices.Metrics
+		}
+		if s.vmServices != nil && s.vmServices.Logs != nil {
+			lintLogs = s.vmServices.Logs
+		}
+		formulaLint := services.NewKPIFormulaLintService(lintMetrics, lintLogs, s.config.KPIFormulaLint, s.logger)
+		formulaLint.SetCardinality(s.cardinality)
+		kpiHandler := handlers.NewKPIHandler(s.config, s.kpiRepo, s.cache, formulaLint, s.logger)
 		if kpiHandler != nil {
+			kpiBundleHandler := handlers.NewKPIBundleHandler(services.NewKPIBundleService(s.config, s.kpiRepo, s.logger), s.operations, s.logger)
+
 			// KPI Definitions API
 			kpiDefsGroup := v1.Group("/kpi/defs")
 			{
@@ -353,18 +952,122 @@ func (s *Server) setupRoutes() {
 				kpiDefsGroup.POST("", kpiHandler.CreateOrUpdateKPIDefinition)
 				kpiDefsGroup.POST("/bulk-json", kpiHandler.BulkIngestJSON)
 				kpiDefsGroup.POST("/bulk-csv", kpiHandler.BulkIngestCSV)
+				kpiDefsGroup.POST("/import", kpiBundleHandler.Import)
+				kpiDefsGroup.GET("/export", kpiBundleHandler.Export)
+				kpiDefsGroup.GET("/lookup", kpiHandler.LookupKPIDefinition)
+				kpiDefsGroup.POST("/lint", kpiHandler.LintKPIDefinition)
 				kpiDefsGroup.GET("/:id", kpiHandler.GetKPIDefinition)
+				kpiDefsGroup.PUT("/:id", kpiHandler.PutKPIDefinition)
 				kpiDefsGroup.DELETE("/:id", kpiHandler.DeleteKPIDefinition)
 			}
 
 			// Human-friendly KPI search endpoint (natural language)
 			v1.POST("/kpi/search", kpiHandler.SearchKPIs)
 		}
+
+		// Catalog duplicate detection and merge
+		s.catalogDedup = services.NewCatalogDedupService(s.kpiRepo, s.cache, s.config.Catalog.DedupThreshold, s.logger)
+		catalogDedupHandler := handlers.NewCatalogDedupHandler(s.catalogDedup, s.logger)
+		v1.GET("/kpi/duplicates", catalogDedupHandler.ListDuplicates)
+		v1.POST("/kpi/duplicates/merge", catalogDedupHandler.Merge)
+		v1.GET("/kpi/duplicates/history", catalogDedupHandler.History)
+
+		// Deploy webhook: targeted catalog refresh and KPI revalidation
+		catalogRefreshHandler := handlers.NewCatalogRefreshHandler(services.NewCatalogRefreshService(
+			s.kpiRepo, lintMetrics, s.metricsMetadataIndexer, formulaLint, s.cache, s.config.Catalog, s.logger), s.logger)
+		v1.POST("/catalog/refresh", catalogRefreshHandler.Webhook)
+
+		// Tag-based bulk threshold adjustment with scheduled revert
+		s.thresholdAdjustments = services.NewThresholdAdjustmentService(s.kpiRepo, s.cache, s.logger)
+		thresholdAdjustmentHandler := handlers.NewThresholdAdjustmentHandler(s.thresholdAdjustments, s.logger)
+		v1.POST("/kpi/thresholds/adjustments", thresholdAdjustmentHandler.Apply)
+		v1.GET("/kpi/thresholds/adjustments", thresholdAdjustmentHandler.List)
+		v1.GET("/kpi/thresholds/adjustments/:id", thresholdAdjustmentHandler.Get)
+		v1.POST("/kpi/thresholds/adjustments/:id/revert", thresholdAdjustmentHandler.Revert)
+
+		// Drill-down from a KPI to its contributing label sets
+		var drilldownQuerier services.DrilldownMetricsQuerier
+		if s.vmServices != nil && s.vmServices.Metrics != nil {
+			drilldownQuerier = s.vmServices.Metrics
+		}
+		drilldownHandler := handlers.NewKPIDrilldownHandler(services.NewKPIDrilldownService(drilldownQuerier, s.kpiRepo, s.logger), s.logger)
+		v1.GET("/kpi/defs/:id/drilldown", drilldownHandler.Drilldown)
+
+		// Recorded threshold states over time
+		var evaluations services.KPIEvaluationStore
+		if s.config.Weaviate.Enabled && s.weaviateClient != nil {
+			store := weavstore.NewWeaviateKPIEvaluationStore(s.weaviateClient, logging.ExtractZapLogger(logger.Named(s.logger, logger.SubsystemWeaviate)))
+			store.SetReplicationPolicy(weaviateReplicationPolicy(s.config.Weaviate))
+			evaluations = store
+		}
+		s.kpiHistory = services.NewKPIHistoryService(drilldownQuerier, s.kpiRepo, evaluations, s.config.KPIHistory, s.logger)
+		s.kpiHistory.SetDatastores(s.kpiDatastores)
+		s.kpiHistory.SetRemediation(s.remediation)
+		s.kpiHistory.SetLocks(s.cache)
+		kpiHistoryHandler := handlers.NewKPIHistoryHandler(s.kpiHistory, s.logger)
+		v1.GET("/kpi/defs/:id/history", kpiHistoryHandler.History)
+
+		// Change feeds for clients keeping an offline copy of the catalog
+		changeFeedHandler := handlers.NewChangeFeedHandler(services.NewChangeFeedService(s.kpiRepo, s.cache, s.logger), s.logger)
+		v1.GET("/changes/:resource", changeFeedHandler.Changes)
 	}
 
 	// If an external MIRA service is configured, proxy registration happens
 	// earlier during server setup; no embedded conversational handler is registered here.
 
+	// Service health scores
+	var metricsQuerier services.HealthMetricsQuerier
+	if s.vmServices != nil && s.vmServices.Metrics != nil {
+		metricsQuerier = s.vmServices.Metrics
+	}
+	var incidents services.HealthIncidentSource
+	if fs := s.failureRecords(); fs != nil {
+		incidents = fs
+	}
+	s.serviceHealth = services.NewServiceHealthService(metricsQuerier, s.kpiRepo, incidents, s.cache, s.config.HealthScore, s.logger)
+	s.serviceHealth.SetDatastores(s.kpiDatastores)
+	serviceHealthHandler := handlers.NewServiceHealthHandler(s.serviceHealth, s.logger)
+	v1.GET("/services/:service/health-score", serviceHealthHandler.GetHealthScore)
+
+	// Tenant executive summary
+	s.executiveSummary = services.NewExecutiveSummaryService(metricsQuerier, s.kpiRepo, incidents, s.cache, s.config.ExecutiveSummary, s.config.HealthScore, s.logger)
+	s.executiveSummary.SetDatastores(s.kpiDatastores)
+	executiveSummaryHandler := handlers.NewExecutiveSummaryHandler(s.executiveSummary, s.logger)
+	v1.GET("/summary/executive", executiveSummaryHandler.GetSummary)
+	v1.GET("/summary/executive/snapshots", executiveSummaryHandler.ListSnapshots)
+
+	// Materialized tenant statistics for the admin overview
+	s.tenantStats = services.NewTenantStatsService(s.cache, s.config.StatsSnapshots, s.config.Cache.TenantID, s.logger)
+	s.registerTenantStats(queryTemplates, computedColumns)
+	tenantStatsHandler := handlers.NewTenantStatsHandler(s.tenantStats, s.logger)
+	v1.GET("/admin/stats", tenantStatsHandler.GetOverview)
+	v1.GET("/admin/stats/history", tenantStatsHandler.ListHistory)
+
+	// Capacity planning forecasts
+	var rangeQuerier services.CapacityMetricsQuerier
+	if s.vmServices != nil && s.vmServices.Metrics != nil {
+		rangeQuerier = s.vmServices.Metrics
+	}
+	s.capacity = services.NewCapacityService(rangeQuerier, s.kpiRepo, s.cache, s.config.Capacity, s.logger)
+	capacityHandler := handlers.NewCapacityHandler(s.capacity, s.logger)
+	v1.GET("/analytics/capacity", capacityHandler.GetForecast)
+
+	// Current range vs offset baselines for compare overlays
+	baselineHandler := handlers.NewBaselineComparisonHandler(services.NewBaselineComparisonService(rangeQuerier, s.kpiRepo, s.logger), s.logger)
+	v1.GET("/analytics/compare", baselineHandler.Compare)
+
+	// Logs and traces for one trace, transaction or request ID
+	var lookupLogs services.LogsService
+	var lookupTraces services.TraceLookupBackend
+	if s.vmServices != nil && s.vmServices.Logs != nil {
+		lookupLogs = s.vmServices.Logs
+	}
+	if s.vmServices != nil && s.vmServices.Traces != nil {
+		lookupTraces = s.vmServices.Traces
+	}
+	idLookupHandler := handlers.NewIDLookupHandler(services.NewIDLookupService(lookupLogs, lookupTraces, s.logger), s.logger)
+	v1.GET("/lookup/:id", idLookupHandler.Lookup)
+
 	// Unified Query Engine (Phase 1.5: Unified API Implementation)
 	if s.config.UnifiedQuery.Enabled {
 		s.setupUnifiedQueryEngine(v1, rcaEngineForEndpoints)
@@ -382,10 +1085,12 @@ func (s *Server) setupUnifiedQueryEngine(router *gin.RouterGroup, rcaEngineForEn
 		s.vmServices.Traces,
 		s.kpiRepo,
 		s.cache,
-		s.logger,
-		s.config.Engine,
+		logger.Named(s.logger, logger.SubsystemCorrelation),
+		s.correlationEngineConfig(),
 	)
 
+	s.recordCorrelationHistory(correlationEngine)
+
 	// Create unified query engine
 	// Note: BleveSearchService is optional, can be nil if not configured
 	unifiedEngine := services.NewUnifiedQueryEngine(
@@ -401,12 +1106,11 @@ func (s *Server) setupUnifiedQueryEngine(router *gin.RouterGroup, rcaEngineForEn
 	// Create unified query handler
 	unifiedHandler := handlers.NewUnifiedQueryHandler(unifiedEngine, s.logger, s.kpiRepo, s.config.Engine)
 
-	// Initialize failure store if Weaviate is enabled and client is available
-	if s.config.Weaviate.Enabled && s.weaviateClient != nil {
-		zapLogger := logging.ExtractZapLogger(s.logger)
-		failureStore := weavstore.NewWeaviateFailureStore(s.weaviateClient, zapLogger)
-		unifiedHandler.SetFailureStore(failureStore)
+	// Attach the failure store if Weaviate is enabled and client is available
+	if fs := s.failureRecords(); fs != nil {
+		unifiedHandler.SetFailureStore(fs)
 	}
+	unifiedHandler.SetTicketing(s.ticketing)
 
 	// Create RCA handler for unified RCA endpoints
 	rcaServiceGraph := services.NewServiceGraphService(s.vmServices.Metrics, s.logger)
@@ -422,11 +1126,13 @@ func (s *Server) setupUnifiedQueryEngine(router *gin.RouterGroup, rcaEngineForEn
 	{
 		unifiedGroup.POST("/query", unifiedHandler.HandleUnifiedQuery)
 		unifiedGroup.POST("/correlation", unifiedHandler.HandleUnifiedCorrelation)
+		unifiedGroup.GET("/correlation/stream", middleware.RequireFeature(s.featureFlags, "live_correlation"), unifiedHandler.HandleUnifiedCorrelationStream)
 		unifiedGroup.POST("/failures/detect", unifiedHandler.HandleFailureDetection)
 		unifiedGroup.POST("/failures/correlate", unifiedHandler.HandleTransactionFailureCorrelation)
 		unifiedGroup.POST("/failures/list", unifiedHandler.HandleGetFailures)
 		unifiedGroup.POST("/failures/get", unifiedHandler.HandleGetFailureDetail)
 		unifiedGroup.POST("/failures/delete", unifiedHandler.HandleDeleteFailure)
+		unifiedGroup.POST("/failures/ticket", unifiedHandler.HandleOpenFailureTicket)
 		unifiedGroup.GET("/metadata", unifiedHandler.HandleQueryMetadata)
 		unifiedGroup.GET("/health", unifiedHandler.HandleHealthCheck)
 		unifiedGroup.POST("/search", unifiedHandler.HandleUnifiedSearch)
@@ -460,6 +1166,13 @@ func (s *Server) Start(ctx context.Context) error {
 		return fmt.Errorf("invalid port number: %d", s.config.Port)
 	}
 
+	// Weaviate schema preflight, before anything writes to the classes
+	if s.weaviateSchema != nil {
+		if err := s.runSchemaPreflight(ctx); err != nil {
+			return err
+		}
+	}
+
 	// Start metrics metadata synchronizer
 	if s.metricsMetadataSynchronizer != nil {
 		s.logger.Info("Starting metrics metadata synchronizer")
@@ -469,6 +1182,95 @@ func (s *Server) Start(ctx context.Context) error {
 		}
 	}
 
+	// Replication heartbeat (primary only; no-op on replicas)
+	if s.replication != nil {
+		go s.replication.Start(ctx)
+	}
+
+	// Weaviate endpoint discovery
+	if s.weaviateEndpoints != nil {
+		discovery.Start(ctx, "weaviate", services.DiscoveryConfig(s.config.Weaviate.Discovery), s.weaviateEndpoints, s.logger)
+	}
+
+	// Per-tenant cache key gauges
+	if s.cacheNamespaces != nil && s.config.Cache.TenantStatsInterval > 0 {
+		go s.cacheNamespaces.Start(ctx, s.config.Cache.TenantStatsInterval)
+	}
+
+	// Scheduled catalog duplicate detection
+	if s.catalogDedup != nil && s.config.Catalog.DedupInterval > 0 {
+		go s.catalogDedup.Start(ctx, s.config.Catalog.DedupInterval)
+	}
+
+	// Scheduled revert of bulk threshold adjustments
+	if s.thresholdAdjustments != nil && (s.replication == nil || !s.replication.IsReplica()) {
+		go s.thresholdAdjustments.Start(ctx, time.Minute)
+	}
+
+	// Incident ticket status polling (primary only)
+	if s.ticketing != nil && (s.replication == nil || !s.replication.IsReplica()) {
+		go s.ticketing.Start(ctx)
+	}
+
+	// Scheduled KPI state recording
+	if s.kpiHistory != nil && (s.replication == nil || !s.replication.IsReplica()) {
+		go s.kpiHistory.Start(ctx)
+	}
+
+	// Scheduled service health score refresh
+	if s.serviceHealth != nil {
+		go s.serviceHealth.Start(ctx)
+	}
+
+	// Scheduled executive summary snapshots
+	if s.executiveSummary != nil {
+		go s.executiveSummary.Start(ctx)
+	}
+
+	// Scheduled tenant statistics snapshots
+	if s.tenantStats != nil {
+		go s.tenantStats.Start(ctx)
+	}
+
+	// Scheduled capacity report rebuild
+	if s.capacity != nil {
+		go s.capacity.Start(ctx)
+	}
+
+	// Self-SLO evaluation and breach notifications
+	if s.selfSLO != nil {
+		go s.selfSLO.Start(ctx)
+	}
+	if s.internalHealth != nil {
+		go s.internalHealth.Start(ctx)
+	}
+	if s.fleet != nil {
+		go s.fleet.Start(ctx)
+	}
+	if s.cardinality != nil {
+		go s.cardinality.Start(ctx)
+	}
+
+	// Scheduled retention purges (primary only)
+	if s.retention != nil && s.config.Retention.Interval > 0 && (s.replication == nil || !s.replication.IsReplica()) {
+		go s.retention.Start(ctx)
+	}
+
+	// Usage telemetry reports to the configured collector
+	if s.usageTelemetry != nil {
+		go s.usageTelemetry.Start(ctx)
+	}
+
+	// Runtime subsystem log levels, kept in sync across instances
+	if s.logLevels != nil {
+		go s.logLevels.Start(ctx)
+	}
+
+	// Profiling watchdog
+	if s.profilingWatchdog != nil {
+		go s.profilingWatchdog.Start(ctx)
+	}
+
 	s.httpServer = &http.Server{
 		Addr:         fmt.Sprintf(":%d", s.config.Port),
 		Handler:      s.router,
@@ -485,6 +1287,18 @@ func (s *Server) Start(ctx context.Context) error {
 		}
 	}()
 
+	// Callback gRPC server for AI engine results
+	if s.callbackServer != nil {
+		go func() {
+			if err := s.callbackServer.ListenAndServe(); err != nil {
+				select {
+				case errCh <- err:
+				default:
+				}
+			}
+		}()
+	}
+
 	// Wait for shutdown signal or error
 	select {
 	case err := <-errCh:
@@ -501,6 +1315,16 @@ func (s *Server) gracefulShutdown() error {
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	// Stop replication heartbeat
+	if s.replication != nil {
+		s.replication.Stop()
+	}
+
+	// Stop accepting engine callbacks
+	if s.callbackServer != nil {
+		s.callbackServer.Stop()
+	}
+
 	// Stop KPI sync worker
 	if s.kpiSyncWorker != nil {
 		s.logger.Info("Stopping KPI sync worker")
@@ -515,6 +1339,11 @@ func (s *Server) gracefulShutdown() error {
 		}
 	}
 
+	// Close KPI datastore connections
+	if err := s.kpiDatastores.Close(); err != nil {
+		s.logger.Error("Failed to close KPI datastores", "error", err)
+	}
+
 	// Stop metrics metadata synchronizer
 	if s.metricsMetadataSynchronizer != nil {
 		s.logger.Info("Stopping metrics metadata synchronizer")
@@ -606,6 +1435,14 @@ func (s *Server) initializeMetricsMetadataComponents() error {
 	return nil
 }
 
+// newNotificationService creates a notification service that renders the
+// tenant's notification templates.
+func (s *Server) newNotificationService() *services.NotificationService {
+	n := services.NewNotificationService(s.config.Integrations, s.logger)
+	n.SetTemplates(s.notificationTemplates)
+	return n
+}
+
 // parseSyncStrategy converts string strategy to enum
 func (s *Server) parseSyncStrategy(strategy string) models.SyncStrategy {
 	switch strategy {
@@ -620,6 +1457,14 @@ func (s *Server) parseSyncStrategy(strategy string) models.SyncStrategy {
 	}
 }
 
+// SetSecretRefs tells the compliance report which secret fields were
+// resolved from external providers.
+func (s *Server) SetSecretRefs(refs config.SecretRefs) {
+	if s.compliance != nil {
+		s.compliance.SetSecretRefs(refs)
+	}
+}
+
 // Handler returns the underlying Gin engine so tests (or embedders) can mount it.
 func (s *Server) Handler() http.Handler {
 	return s.router
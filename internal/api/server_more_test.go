@@ -1,8 +1,10 @@
 package api
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"testing"
 
 	"github.com/mirastacklabs-ai/mirador-core/internal/config"
@@ -49,6 +51,94 @@ func TestServer_OpenAPI_AndRootRedirect(t *testing.T) {
 	}
 }
 
+// The live correlation stream is gated by the live_correlation flag.
+func TestServer_LiveCorrelationFeatureFlag(t *testing.T) {
+	log := logger.New("error")
+	cfg := &config.Config{Environment: "test", Port: 0}
+	cfg.UnifiedQuery.Enabled = true
+	vms := &services.VictoriaMetricsServices{
+		Metrics: services.NewVictoriaMetricsService(config.VictoriaMetricsConfig{}, log),
+		Logs:    services.NewVictoriaLogsService(config.VictoriaLogsConfig{}, log),
+		Traces:  services.NewVictoriaTracesService(config.VictoriaTracesConfig{}, log),
+	}
+	s := NewServer(cfg, log, cache.NewNoopValkeyCache(log), vms, nil, (*mariadb.Client)(nil))
+
+	flag := &services.FeatureFlag{Name: "live_correlation", Enabled: true, Tenants: []string{"beta"}}
+	if _, err := s.featureFlags.UpsertFlag(context.Background(), flag, ""); err != nil {
+		t.Fatalf("upsert flag: %v", err)
+	}
+
+	for _, tc := range []struct {
+		tenant string
+		gated  bool
+	}{
+		{"acme", true},
+		{"beta", false},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/unified/correlation/stream", nil)
+		req.Header.Set("X-Tenant-ID", tc.tenant)
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		if gated := w.Code == http.StatusNotFound; gated != tc.gated {
+			t.Fatalf("tenant %s: expected gated=%v, got %d %s", tc.tenant, tc.gated, w.Code, w.Body.String())
+		}
+	}
+}
+
+// Restricted tenants cannot reach any endpoint that runs caller-written
+// queries.
+func TestServer_RestrictedQueries(t *testing.T) {
+	log := logger.New("error")
+	cfg := &config.Config{Environment: "test", Port: 0}
+	cfg.UnifiedQuery.Enabled = true
+	cfg.QueryTemplates.Restricted = true
+	vms := &services.VictoriaMetricsServices{
+		Metrics: services.NewVictoriaMetricsService(config.VictoriaMetricsConfig{}, log),
+		Logs:    services.NewVictoriaLogsService(config.VictoriaLogsConfig{}, log),
+		Traces:  services.NewVictoriaTracesService(config.VictoriaTracesConfig{}, log),
+	}
+	s := NewServer(cfg, log, cache.NewNoopValkeyCache(log), vms, nil, (*mariadb.Client)(nil))
+
+	registered := map[string]bool{}
+	for _, r := range s.router.Routes() {
+		registered[r.Path] = true
+	}
+	for _, path := range adHocQueryPaths {
+		if !registered[path] {
+			t.Fatalf("ad-hoc query path %s is not a registered route", path)
+		}
+	}
+	for _, r := range s.router.Routes() {
+		if !slices.Contains(adHocQueryPaths, r.Path) {
+			continue
+		}
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, httptest.NewRequest(r.Method, r.Path+"?query=up", nil))
+		if w.Code != http.StatusForbidden {
+			t.Fatalf("%s %s in restricted mode: expected 403, got %d", r.Method, r.Path, w.Code)
+		}
+	}
+}
+
 // Cover Start/Stop path (graceful shutdown)
 // Note: Start/Stop path is exercised via integration/runtime, not unit tests, to avoid
 // closing uninitialized gRPC clients. The server handler is covered via other tests.
+
+func TestServer_ReplicaDoesNotRecordRuns(t *testing.T) {
+	log := logger.New("error")
+	vms := &services.VictoriaMetricsServices{
+		Metrics: services.NewVictoriaMetricsService(config.VictoriaMetricsConfig{}, log),
+		Logs:    services.NewVictoriaLogsService(config.VictoriaLogsConfig{}, log),
+		Traces:  services.NewVictoriaTracesService(config.VictoriaTracesConfig{}, log),
+	}
+	for _, role := range []string{"primary", "replica"} {
+		cfg := &config.Config{Environment: "test", Port: 0}
+		cfg.Engine.RecordRuns = true
+		cfg.Replication.Role = role
+		cfg.Replication.PrimaryURL = "http://primary:8010"
+		s := NewServer(cfg, log, cache.NewNoopValkeyCache(log), vms, nil, (*mariadb.Client)(nil))
+		if got, want := s.correlationEngineConfig().RecordRuns, role == "primary"; got != want {
+			t.Fatalf("%s: expected RecordRuns=%v, got %v", role, want, got)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package callback
+
+import (
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	_ "google.golang.org/protobuf/types/known/structpb" // registers google/protobuf/struct.proto
+)
+
+// The callback service, as in api/proto/mirador/callback/v1/callback.proto.
+// The descriptor is built here rather than generated so no protoc step is
+// needed; it is registered globally so gRPC reflection can serve it.
+const (
+	protoFile          = "mirador/callback/v1/callback.proto"
+	serviceName        = "mirador.callback.v1.CallbackService"
+	submitResultMethod = "/" + serviceName + "/SubmitResult"
+)
+
+var (
+	descOnce sync.Once
+	descFile protoreflect.FileDescriptor
+	descErr  error
+)
+
+// fileDescriptor returns the registered callback.proto descriptor.
+func fileDescriptor() (protoreflect.FileDescriptor, error) {
+	descOnce.Do(func() {
+		descFile, descErr = protodesc.NewFile(callbackProto(), protoregistry.GlobalFiles)
+		if descErr == nil {
+			descErr = protoregistry.GlobalFiles.RegisterFile(descFile)
+		}
+	})
+	return descFile, descErr
+}
+
+func callbackProto() *descriptorpb.FileDescriptorProto {
+	field := func(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, typeName string) *descriptorpb.FieldDescriptorProto {
+		f := &descriptorpb.FieldDescriptorProto{
+			Name:   proto.String(name),
+			Number: proto.Int32(number),
+			Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:   typ.Enum(),
+		}
+		if typeName != "" {
+			f.TypeName = proto.String(typeName)
+		}
+		return f
+	}
+	str := descriptorpb.FieldDescriptorProto_TYPE_STRING
+	return &descriptorpb.FileDescriptorProto{
+		Name:       proto.String(protoFile),
+		Package:    proto.String("mirador.callback.v1"),
+		Dependency: []string{"google/protobuf/struct.proto"},
+		Syntax:     proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("SubmitResultRequest"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("job_id", 1, str, ""),
+					field("kind", 2, str, ""),
+					field("status", 3, str, ""),
+					field("result", 4, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.Struct"),
+					field("error", 5, str, ""),
+				},
+			},
+			{
+				Name: proto.String("SubmitResultResponse"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("job_id", 1, str, ""),
+					field("accepted", 2, descriptorpb.FieldDescriptorProto_TYPE_BOOL, ""),
+				},
+			},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("CallbackService"),
+			Method: []*descriptorpb.MethodDescriptorProto{{
+				Name:       proto.String("SubmitResult"),
+				InputType:  proto.String(".mirador.callback.v1.SubmitResultRequest"),
+				OutputType: proto.String(".mirador.callback.v1.SubmitResultResponse"),
+			}},
+		}},
+	}
+}
@@ -0,0 +1,190 @@
+// Package callback implements the gRPC server AI engines use to push the
+// results of long-running jobs back to mirador-core instead of being polled.
+//
+// Engines call mirador.callback.v1.CallbackService/SubmitResult with an
+// "authorization: Bearer <token>" metadata entry holding their configured
+// token. The server also serves the standard gRPC health service and server
+// reflection, so grpcurl and load balancers work without the .proto file.
+package callback
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// resultSubmitter is the handler type of the callback service.
+type resultSubmitter interface {
+	SubmitResult(ctx context.Context, req *dynamicpb.Message) (*dynamicpb.Message, error)
+}
+
+// Server is the callback gRPC server.
+type Server struct {
+	cfg      config.CallbackConfig
+	results  *services.CallbackResultService
+	grpc     *grpc.Server
+	health   *health.Server
+	request  protoreflect.MessageDescriptor
+	response protoreflect.MessageDescriptor
+	logger   logging.Logger
+}
+
+// NewServer creates a callback server storing results in results.
+func NewServer(cfg config.CallbackConfig, results *services.CallbackResultService, logger corelogger.Logger) (*Server, error) {
+	fd, err := fileDescriptor()
+	if err != nil {
+		return nil, fmt.Errorf("callback descriptor: %w", err)
+	}
+	var opts []grpc.ServerOption
+	if cfg.MaxMessageBytes > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(cfg.MaxMessageBytes))
+	}
+	s := &Server{
+		cfg:      cfg,
+		results:  results,
+		grpc:     grpc.NewServer(opts...),
+		health:   health.NewServer(),
+		request:  fd.Messages().ByName("SubmitResultRequest"),
+		response: fd.Messages().ByName("SubmitResultResponse"),
+		logger:   logging.FromCoreLogger(logger),
+	}
+	s.grpc.RegisterService(&grpc.ServiceDesc{
+		ServiceName: serviceName,
+		HandlerType: (*resultSubmitter)(nil),
+		Methods:     []grpc.MethodDesc{{MethodName: "SubmitResult", Handler: s.handleSubmitResult}},
+		Metadata:    protoFile,
+	}, s)
+	healthpb.RegisterHealthServer(s.grpc, s.health)
+	reflection.Register(s.grpc)
+	s.health.SetServingStatus(serviceName, healthpb.HealthCheckResponse_SERVING)
+	return s, nil
+}
+
+// ListenAndServe serves on the configured port until Stop is called.
+func (s *Server) ListenAndServe() error {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.cfg.Port))
+	if err != nil {
+		return fmt.Errorf("callback server listen: %w", err)
+	}
+	return s.Serve(lis)
+}
+
+// Serve serves on lis until Stop is called.
+func (s *Server) Serve(lis net.Listener) error {
+	s.logger.Info("Callback gRPC server starting", "addr", lis.Addr().String(), "engines", len(s.cfg.Engines))
+	return s.grpc.Serve(lis)
+}
+
+// Stop reports NOT_SERVING and lets in-flight submissions finish.
+func (s *Server) Stop() {
+	s.health.Shutdown()
+	s.grpc.GracefulStop()
+}
+
+// SubmitResult stores a job result from an authenticated engine.
+func (s *Server) SubmitResult(ctx context.Context, req *dynamicpb.Message) (*dynamicpb.Message, error) {
+	engine, ok := s.authenticate(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid engine token")
+	}
+	fields := s.request.Fields()
+	r := &models.CallbackResult{
+		JobID:  req.Get(fields.ByName("job_id")).String(),
+		Engine: engine,
+		Kind:   req.Get(fields.ByName("kind")).String(),
+		Status: req.Get(fields.ByName("status")).String(),
+		Error:  req.Get(fields.ByName("error")).String(),
+	}
+	if f := fields.ByName("result"); req.Has(f) {
+		result, err := toStruct(req.Get(f).Message().Interface())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "result must be a google.protobuf.Struct")
+		}
+		r.Result = result.AsMap()
+	}
+
+	err := s.results.Submit(ctx, r)
+	switch {
+	case errors.Is(err, services.ErrInvalidCallbackResult):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, services.ErrCallbackJobOwned):
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	case err != nil:
+		s.logger.Error("Failed to store callback result", "job_id", r.JobID, "engine", engine, "error", err)
+		return nil, status.Error(codes.Internal, "failed to store result")
+	}
+
+	resp := dynamicpb.NewMessage(s.response)
+	resp.Set(s.response.Fields().ByName("job_id"), protoreflect.ValueOfString(r.JobID))
+	resp.Set(s.response.Fields().ByName("accepted"), protoreflect.ValueOfBool(true))
+	return resp, nil
+}
+
+func (s *Server) handleSubmitResult(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := dynamicpb.NewMessage(s.request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return s.SubmitResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: submitResultMethod}
+	return interceptor(ctx, in, info, func(ctx context.Context, req any) (any, error) {
+		return s.SubmitResult(ctx, req.(*dynamicpb.Message))
+	})
+}
+
+// authenticate returns the engine whose token is in the authorization
+// metadata. Every configured token is compared in constant time.
+func (s *Server) authenticate(ctx context.Context) (string, bool) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var token string
+	for _, v := range md.Get("authorization") {
+		if after, ok := strings.CutPrefix(v, "Bearer "); ok {
+			token = strings.TrimSpace(after)
+		}
+	}
+	if token == "" {
+		return "", false
+	}
+	engine := ""
+	for _, e := range s.cfg.Engines {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(e.Token)) == 1 {
+			engine = e.Name
+		}
+	}
+	return engine, engine != ""
+}
+
+func toStruct(m proto.Message) (*structpb.Struct, error) {
+	data, err := proto.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	st := &structpb.Struct{}
+	if err := proto.Unmarshal(data, st); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
@@ -0,0 +1,108 @@
+package callback
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func TestServer_SubmitResult(t *testing.T) {
+	log := logger.New("error")
+	cfg := config.CallbackConfig{Engines: []config.CallbackEngineConfig{
+		{Name: "anomaly", Token: "anomaly-token"},
+		{Name: "forecast", Token: "forecast-token"},
+	}}
+	results := services.NewCallbackResultService(cache.NewNoopValkeyCache(log), cfg, log)
+	srv, err := NewServer(cfg, results, log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	ctx := context.Background()
+
+	health, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: serviceName})
+	if err != nil || health.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("expected SERVING, got %v, %v", health, err)
+	}
+
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: serviceName},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if r, err := stream.Recv(); err != nil || r.GetFileDescriptorResponse() == nil {
+		t.Fatalf("expected reflection to resolve %s, got %v, %v", serviceName, r, err)
+	}
+	_ = stream.CloseSend()
+
+	submit := func(token, jobID string) (*dynamicpb.Message, error) {
+		req := dynamicpb.NewMessage(srv.request)
+		fields := srv.request.Fields()
+		req.Set(fields.ByName("job_id"), protoreflect.ValueOfString(jobID))
+		req.Set(fields.ByName("kind"), protoreflect.ValueOfString("anomaly_detection"))
+		payload, _ := structpb.NewStruct(map[string]any{"anomalies": 3.0})
+		req.Set(fields.ByName("result"), protoreflect.ValueOfMessage(payload.ProtoReflect()))
+		resp := dynamicpb.NewMessage(srv.response)
+		callCtx := ctx
+		if token != "" {
+			callCtx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+		}
+		return resp, conn.Invoke(callCtx, submitResultMethod, req, resp)
+	}
+
+	if _, err := submit("", "job-1"); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated without a token, got %v", err)
+	}
+	if _, err := submit("anomaly-token", "bad job id"); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for an invalid job id, got %v", err)
+	}
+	resp, err := submit("anomaly-token", "job-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Get(srv.response.Fields().ByName("accepted")).Bool() {
+		t.Fatalf("expected the result to be accepted")
+	}
+	if _, err := submit("forecast-token", "job-1"); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied for another engine's job, got %v", err)
+	}
+
+	stored, err := results.Get(ctx, "job-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Engine != "anomaly" || stored.Status != "succeeded" || stored.Result["anomalies"] != 3.0 {
+		t.Fatalf("unexpected stored result %+v", stored)
+	}
+}
@@ -6,6 +6,9 @@ type Config struct {
 	Environment string `mapstructure:"environment" yaml:"environment"`
 	Port        int    `mapstructure:"port" yaml:"port"`
 	LogLevel    string `mapstructure:"log_level" yaml:"log_level"`
+	// LogLevels sets the level of individual subsystems (api, cache,
+	// correlation, weaviate); unlisted subsystems use LogLevel.
+	LogLevels map[string]string `mapstructure:"log_levels" yaml:"log_levels"`
 
 	Database     DatabaseConfig     `mapstructure:"database" yaml:"database"`
 	GRPC         GRPCConfig         `mapstructure:"grpc" yaml:"grpc"`
@@ -20,10 +23,110 @@ type Config struct {
 	Search       SearchConfig       `mapstructure:"search" yaml:"search"`
 	UnifiedQuery UnifiedQueryConfig `mapstructure:"unified_query" yaml:"unified_query"`
 	RCA          RCAConfig          `mapstructure:"rca" yaml:"rca"`
+	Maintenance  MaintenanceConfig  `mapstructure:"maintenance" yaml:"maintenance"`
+
+	// Named bearer tokens for the /api/v1/admin API
+	Admin AdminConfig `mapstructure:"admin" yaml:"admin"`
 
 	// Engine configuration for Correlation & RCA engines (AT-004)
 	Engine EngineConfig `mapstructure:"engine" yaml:"engine"`
 
+	// Dependency fault injection for resilience testing (non-production only)
+	FaultInjection FaultInjectionConfig `mapstructure:"fault_injection" yaml:"fault_injection"`
+
+	// Active/passive multi-region role of this deployment
+	Replication ReplicationConfig `mapstructure:"replication" yaml:"replication"`
+
+	// External secret providers for vault:/awssm:/k8s:/file: references
+	Secrets SecretsConfig `mapstructure:"secrets" yaml:"secrets"`
+
+	// KPI/schema catalog maintenance (duplicate detection)
+	Catalog CatalogConfig `mapstructure:"catalog" yaml:"catalog"`
+
+	// Per-service health scores for the UI service overview
+	HealthScore HealthScoreConfig `mapstructure:"health_score" yaml:"health_score"`
+
+	// Tenant-wide executive summary and its scheduled snapshots
+	ExecutiveSummary ExecutiveSummaryConfig `mapstructure:"executive_summary" yaml:"executive_summary"`
+
+	// Periodic tenant statistics snapshots for the admin overview
+	StatsSnapshots StatsSnapshotConfig `mapstructure:"stats_snapshots" yaml:"stats_snapshots"`
+
+	// Capacity planning forecasts for resource KPIs
+	Capacity CapacityConfig `mapstructure:"capacity" yaml:"capacity"`
+
+	// Recorded KPI threshold states for state-over-time views
+	KPIHistory KPIHistoryConfig `mapstructure:"kpi_history" yaml:"kpi_history"`
+
+	// Rules that run remediation actions on KPI state changes and alerts
+	Remediation RemediationConfig `mapstructure:"remediation" yaml:"remediation"`
+
+	// Latency and availability objectives for mirador-core's own API
+	SelfSLO SelfSLOConfig `mapstructure:"self_slo" yaml:"self_slo"`
+
+	// Sampled trends of mirador-core's own health for the admin page
+	InternalHealth InternalHealthConfig `mapstructure:"internal_health" yaml:"internal_health"`
+
+	// Version handshake between instances during rolling upgrades
+	Fleet FleetConfig `mapstructure:"fleet" yaml:"fleet"`
+
+	// pprof endpoints and automatic profile capture under load
+	Profiling ProfilingConfig `mapstructure:"profiling" yaml:"profiling"`
+
+	// Memory limits for log exports
+	Export ExportConfig `mapstructure:"export" yaml:"export"`
+
+	// Backend query scheduling by traffic class
+	QoS QoSConfig `mapstructure:"qos" yaml:"qos"`
+
+	// Series/row limits for metrics and logs query results
+	ResultLimits ResultLimitsConfig `mapstructure:"result_limits" yaml:"result_limits"`
+
+	// Warehouses holding SQL KPIs (ClickHouse, PostgreSQL)
+	KPIDatastores []KPIDatastoreConfig `mapstructure:"kpi_datastores" yaml:"kpi_datastores"`
+
+	// Scheduled enforcement of retention policies
+	Retention RetentionConfig `mapstructure:"retention" yaml:"retention"`
+
+	// Catalog checks on KPI formulas (unknown, deprecated, high-cardinality names)
+	KPIFormulaLint KPIFormulaLintConfig `mapstructure:"kpi_formula_lint" yaml:"kpi_formula_lint"`
+
+	// Label cardinality analysis; flagged labels feed formula lint and
+	// series-limit suggestions
+	Cardinality CardinalityConfig `mapstructure:"cardinality" yaml:"cardinality"`
+
+	// Opt-in anonymized feature usage counters
+	UsageTelemetry UsageTelemetryConfig `mapstructure:"usage_telemetry" yaml:"usage_telemetry"`
+
+	// gRPC server AI engines push long-running job results to
+	Callbacks CallbackConfig `mapstructure:"callbacks" yaml:"callbacks"`
+
+	// Bearer tokens granting read-only auditor access
+	Auditors []AuditorConfig `mapstructure:"auditors" yaml:"auditors"`
+
+	// Evidence for compliance controls that cannot be inspected from config
+	Compliance ComplianceConfig `mapstructure:"compliance" yaml:"compliance"`
+
+	// Approved, parameterized queries; restricted tenants may only run these
+	QueryTemplates QueryTemplatesConfig `mapstructure:"query_templates" yaml:"query_templates"`
+
+	// Labels every metrics/logs query is restricted to (e.g. cost_center,
+	// environment), so tenants can share VictoriaMetrics/VictoriaLogs
+	TenantLabels map[string]string `mapstructure:"tenant_labels" yaml:"tenant_labels"`
+
+	// Region the tenant's data is pinned to and each region's backend hosts
+	Residency ResidencyConfig `mapstructure:"residency" yaml:"residency"`
+
+	// Notes from Load about retired sections still present in the config
+	Warnings []string `mapstructure:"-" yaml:"-"`
+
+	// Config files merged by Load, lowest precedence first
+	Sources []string `mapstructure:"-" yaml:"-"`
+
+	// Tenant overrides applied by ApplyOverrides, keyed by dotted path
+	Overrides map[string]any `mapstructure:"-" yaml:"-"`
+	static    *Config
+
 	// MIRA configuration removed; MIRA is now a standalone microservice.
 }
 
@@ -63,6 +166,113 @@ type EngineConfig struct {
 	// Telemetry contains platform-standard telemetry connector and processor definitions
 	// (OTel spanmetrics, servicegraph connectors and isolationforest processor).
 	Telemetry TelemetryConfig `mapstructure:"telemetry" yaml:"telemetry"`
+
+	// Scoring weighs the components of a candidate's suspicion score; zero
+	// fields use DefaultScoringWeights.
+	Scoring ScoringWeights `mapstructure:"scoring" yaml:"scoring"`
+
+	// RecordRuns persists the per-ring sample vectors of each correlation run
+	// (the newest RunRetention runs, for at most 30 days) so other
+	// configurations can be replayed against them offline. Runs are stored
+	// in the background after the response, and never by replicas.
+	RecordRuns   bool `mapstructure:"record_runs" yaml:"record_runs"`
+	RunRetention int  `mapstructure:"run_retention" yaml:"run_retention"`
+
+	// MaxCorrelationMemoryMB bounds the estimated size of a correlation
+	// result; larger runs are rejected before the result is assembled.
+	MaxCorrelationMemoryMB int `mapstructure:"max_correlation_memory_mb" yaml:"max_correlation_memory_mb"`
+
+	// Events weighs ingested Kubernetes and CI/CD events in correlation.
+	Events EventSignalConfig `mapstructure:"events" yaml:"events"`
+
+	// LabelMatch weighs shared labels in label-based correlation and
+	// controls how label values are normalized before comparison.
+	LabelMatch LabelMatchConfig `mapstructure:"label_match" yaml:"label_match"`
+
+	// Pruning screens correlation candidates cheaply before their full
+	// per-ring statistics are computed.
+	Pruning CandidatePruningConfig `mapstructure:"pruning" yaml:"pruning"`
+}
+
+// CandidatePruningConfig bounds the work Correlate spends on candidates.
+// Once more than MinCandidates are discovered, each is sampled only at the
+// end of the first and the core ring; those whose relative change is below
+// MinRelativeDelta are pruned, and of the rest only the MaxCandidates that
+// changed most get full statistics (0 = no budget).
+type CandidatePruningConfig struct {
+	Enabled          bool    `mapstructure:"enabled" yaml:"enabled"`
+	MinCandidates    int     `mapstructure:"min_candidates" yaml:"min_candidates"`
+	MinRelativeDelta float64 `mapstructure:"min_relative_delta" yaml:"min_relative_delta"`
+	MaxCandidates    int     `mapstructure:"max_candidates" yaml:"max_candidates"`
+}
+
+// LabelMatchConfig controls label-based correlation. Weights gives each
+// canonical label's importance; labels not listed weigh DefaultWeight, and
+// only Significant labels count toward the weight a full match would reach.
+// With an empty Weights list DefaultLabelWeights apply. The normalizations
+// let values match that differ only in case (IgnoreCase), in a pod's
+// generated ReplicaSet hash, pod suffix or StatefulSet ordinal
+// (StripPodSuffix), or as FQDN vs short hostname (ShortHostnames).
+type LabelMatchConfig struct {
+	Weights        []LabelWeight `mapstructure:"weights" yaml:"weights"`
+	DefaultWeight  float64       `mapstructure:"default_weight" yaml:"default_weight"`
+	IgnoreCase     bool          `mapstructure:"ignore_case" yaml:"ignore_case"`
+	StripPodSuffix bool          `mapstructure:"strip_pod_suffix" yaml:"strip_pod_suffix"`
+	ShortHostnames bool          `mapstructure:"short_hostnames" yaml:"short_hostnames"`
+}
+
+// LabelWeight is the weight of one canonical label in label matching.
+type LabelWeight struct {
+	Label       string  `mapstructure:"label" yaml:"label" json:"label"`
+	Weight      float64 `mapstructure:"weight" yaml:"weight" json:"weight"`
+	Significant bool    `mapstructure:"significant" yaml:"significant" json:"significant"`
+}
+
+// DefaultLabelWeights are the label weights label matching was tuned with.
+var DefaultLabelWeights = []LabelWeight{
+	{Label: "service", Weight: 1.0, Significant: true},
+	{Label: "pod", Weight: 0.9, Significant: true},
+	{Label: "namespace", Weight: 0.8, Significant: true},
+	{Label: "deployment", Weight: 0.8, Significant: true},
+	{Label: "container", Weight: 0.7, Significant: true},
+	{Label: "operation", Weight: 0.8, Significant: true},
+	{Label: "host", Weight: 0.6},
+	{Label: "level", Weight: 0.3},
+}
+
+// EventSignalConfig controls how ingested events take part in correlation.
+// Events from Lookback before the range through its end count toward their
+// service; a service with BurstThreshold or more events, or any deploy, has
+// its candidates' suspicion raised by Boost. Events are kept for Retention.
+// ServiceLabels are the involved-object labels, in order of preference, that
+// name a Kubernetes event's service.
+type EventSignalConfig struct {
+	Lookback       time.Duration `mapstructure:"lookback" yaml:"lookback"`
+	BurstThreshold int           `mapstructure:"burst_threshold" yaml:"burst_threshold"`
+	Boost          float64       `mapstructure:"boost" yaml:"boost"`
+	Retention      time.Duration `mapstructure:"retention" yaml:"retention"`
+	ServiceLabels  []string      `mapstructure:"service_labels" yaml:"service_labels"`
+}
+
+// ScoringWeights are the suspicion score weights: Pearson, Spearman and
+// CrossCorr weigh the correlation strengths, LagBonus is added when the
+// cause leads the impact and AnomalyDensity scales the cause's anomaly
+// density.
+type ScoringWeights struct {
+	Pearson        float64 `mapstructure:"pearson" yaml:"pearson" json:"pearson"`
+	Spearman       float64 `mapstructure:"spearman" yaml:"spearman" json:"spearman"`
+	CrossCorr      float64 `mapstructure:"cross_corr" yaml:"cross_corr" json:"crossCorr"`
+	LagBonus       float64 `mapstructure:"lag_bonus" yaml:"lag_bonus" json:"lagBonus"`
+	AnomalyDensity float64 `mapstructure:"anomaly_density" yaml:"anomaly_density" json:"anomalyDensity"`
+}
+
+// DefaultScoringWeights are the weights the suspicion score was tuned with.
+var DefaultScoringWeights = ScoringWeights{
+	Pearson:        0.5,
+	Spearman:       0.3,
+	CrossCorr:      0.2,
+	LagBonus:       0.12,
+	AnomalyDensity: 0.12,
 }
 
 // TelemetryMetricConfig describes a single telemetry metric exposed by a connector
@@ -162,7 +372,9 @@ type VictoriaTracesConfig struct {
 // K8sDiscoveryConfig enables dynamic endpoint discovery for a Service
 type K8sDiscoveryConfig struct {
 	Enabled        bool   `mapstructure:"enabled" yaml:"enabled"`
-	Service        string `mapstructure:"service" yaml:"service"` // e.g. vmselect.vm-select.svc.cluster.local
+	Mode           string `mapstructure:"mode" yaml:"mode"`           // dns (default) | endpointslice
+	Service        string `mapstructure:"service" yaml:"service"`     // e.g. vmselect.vm-select.svc.cluster.local
+	Namespace      string `mapstructure:"namespace" yaml:"namespace"` // endpointslice; defaults from service FQDN or pod
 	Port           int    `mapstructure:"port" yaml:"port"`
 	Scheme         string `mapstructure:"scheme" yaml:"scheme"` // http | https
 	RefreshSeconds int    `mapstructure:"refresh_seconds" yaml:"refresh_seconds"`
@@ -193,6 +405,33 @@ type CacheConfig struct {
 	TTL      int      `mapstructure:"ttl" yaml:"ttl"` // seconds
 	Password string   `mapstructure:"password" yaml:"password"`
 	DB       int      `mapstructure:"db" yaml:"db"`
+
+	// TenantID namespaces every key as "tenant:<id>:..." so deployments can
+	// share a keyspace and be flushed independently. Empty keeps legacy keys.
+	TenantID string `mapstructure:"tenant_id" yaml:"tenant_id"`
+	// TenantStatsInterval controls how often per-tenant key counts are
+	// exported as metrics (SCAN over the keyspace); 0 disables.
+	TenantStatsInterval time.Duration `mapstructure:"tenant_stats_interval" yaml:"tenant_stats_interval"`
+
+	// Encryption encrypts sensitive values before they reach Valkey.
+	Encryption CacheEncryptionConfig `mapstructure:"encryption" yaml:"encryption"`
+}
+
+// CacheEncryptionConfig encrypts the values of keys under Prefixes with a
+// per-tenant key derived from a key encryption key (KEK). Keys lists the
+// KEKs by version: ActiveVersion encrypts new values and the others still
+// decrypt, so a KEK can be rotated by adding a version and switching to it.
+type CacheEncryptionConfig struct {
+	Enabled       bool                 `mapstructure:"enabled" yaml:"enabled"`
+	Keys          []CacheEncryptionKey `mapstructure:"keys" yaml:"keys"`
+	ActiveVersion int                  `mapstructure:"active_version" yaml:"active_version"`
+	Prefixes      []string             `mapstructure:"prefixes" yaml:"prefixes"`
+}
+
+// CacheEncryptionKey is one KEK version: 32 random bytes, base64-encoded.
+type CacheEncryptionKey struct {
+	Version int    `mapstructure:"version" yaml:"version"`
+	Key     string `mapstructure:"key" yaml:"key"`
 }
 
 // MariaDBConfig handles MariaDB connection for reading data sources and KPIs.
@@ -245,9 +484,36 @@ type CORSConfig struct {
 
 // IntegrationsConfig handles external service integrations
 type IntegrationsConfig struct {
-	Slack   SlackConfig   `mapstructure:"slack" yaml:"slack"`
-	MSTeams MSTeamsConfig `mapstructure:"ms_teams" yaml:"ms_teams"`
-	Email   EmailConfig   `mapstructure:"email" yaml:"email"`
+	Slack     SlackConfig     `mapstructure:"slack" yaml:"slack"`
+	MSTeams   MSTeamsConfig   `mapstructure:"ms_teams" yaml:"ms_teams"`
+	Email     EmailConfig     `mapstructure:"email" yaml:"email"`
+	Ticketing TicketingConfig `mapstructure:"ticketing" yaml:"ticketing"`
+}
+
+// TicketingConfig opens a Jira or ServiceNow ticket for every new incident
+// (failure record) and keeps the ticket's status in sync.
+type TicketingConfig struct {
+	// Provider is jira or servicenow; empty disables ticketing.
+	Provider string `mapstructure:"provider" yaml:"provider"`
+	BaseURL  string `mapstructure:"base_url" yaml:"base_url"`
+	Username string `mapstructure:"username" yaml:"username"`
+	APIToken string `mapstructure:"api_token" yaml:"api_token"`
+	// Project and IssueType select where Jira issues are created.
+	Project   string `mapstructure:"project" yaml:"project"`
+	IssueType string `mapstructure:"issue_type" yaml:"issue_type"`
+	// Table is the ServiceNow table records are created in.
+	Table string `mapstructure:"table" yaml:"table"`
+	// FieldMapping sets further ticket fields by provider field name. Values
+	// may use the {failure_id}, {services}, {components}, {confidence},
+	// {start} and {end} placeholders.
+	FieldMapping map[string]string `mapstructure:"field_mapping" yaml:"field_mapping"`
+	// SyncInterval polls open tickets for status changes (0 disables
+	// polling; webhooks still apply).
+	SyncInterval time.Duration `mapstructure:"sync_interval" yaml:"sync_interval"`
+	// WebhookSecret must be sent in X-Webhook-Secret by provider webhooks;
+	// empty disables the webhook endpoint.
+	WebhookSecret string        `mapstructure:"webhook_secret" yaml:"webhook_secret"`
+	Timeout       time.Duration `mapstructure:"timeout" yaml:"timeout"`
 }
 
 type SlackConfig struct {
@@ -276,6 +542,372 @@ type UploadsConfig struct {
 	BulkMaxBytes int64 `mapstructure:"bulk_max_bytes" yaml:"bulk_max_bytes"`
 }
 
+// MaintenanceConfig is the platform-wide maintenance switch. When enabled,
+// mutating API requests are rejected regardless of the runtime toggle.
+type MaintenanceConfig struct {
+	Enabled bool   `mapstructure:"enabled" yaml:"enabled"`
+	Message string `mapstructure:"message" yaml:"message"`
+}
+
+// AdminConfig lists the tokens accepted on /api/v1/admin as
+// "Authorization: Bearer <token>". The name of the matching token identifies
+// the caller, e.g. as the reviewer of a query template. Without tokens the
+// admin API is not served.
+type AdminConfig struct {
+	Tokens []AdminTokenConfig `mapstructure:"tokens" yaml:"tokens"`
+}
+
+// AdminTokenConfig is one named admin token.
+type AdminTokenConfig struct {
+	Name  string `mapstructure:"name" yaml:"name"`
+	Token string `mapstructure:"token" yaml:"token"`
+}
+
+// FaultInjectionConfig gates the dependency fault-injection admin API used to
+// exercise degraded-mode behaviour in non-production environments.
+type FaultInjectionConfig struct {
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+}
+
+// SecretsConfig configures the providers used to resolve secret references
+// (e.g. "vault:mirador/weaviate#api_key") in secret-bearing config fields.
+type SecretsConfig struct {
+	// CacheTTL bounds how long a resolved value is reused before refetching.
+	CacheTTL time.Duration `mapstructure:"cache_ttl" yaml:"cache_ttl"`
+	// RefreshInterval re-reads all references to detect rotation; 0 disables.
+	RefreshInterval time.Duration           `mapstructure:"refresh_interval" yaml:"refresh_interval"`
+	Vault           VaultSecretsConfig      `mapstructure:"vault" yaml:"vault"`
+	AWS             AWSSecretsConfig        `mapstructure:"aws" yaml:"aws"`
+	Kubernetes      KubernetesSecretsConfig `mapstructure:"kubernetes" yaml:"kubernetes"`
+}
+
+// VaultSecretsConfig points at a Vault KV v2 engine.
+type VaultSecretsConfig struct {
+	Address   string `mapstructure:"address" yaml:"address"`
+	Token     string `mapstructure:"token" yaml:"token"`
+	TokenFile string `mapstructure:"token_file" yaml:"token_file"`
+	Namespace string `mapstructure:"namespace" yaml:"namespace"`
+	Mount     string `mapstructure:"mount" yaml:"mount"`
+}
+
+// AWSSecretsConfig selects the Secrets Manager region. Credentials are taken
+// from the standard AWS_* environment variables.
+type AWSSecretsConfig struct {
+	Region   string `mapstructure:"region" yaml:"region"`
+	Endpoint string `mapstructure:"endpoint" yaml:"endpoint"`
+}
+
+// KubernetesSecretsConfig is the directory under which secrets are mounted,
+// one subdirectory per secret.
+type KubernetesSecretsConfig struct {
+	MountPath string `mapstructure:"mount_path" yaml:"mount_path"`
+}
+
+// CatalogConfig tunes the catalog duplicate-detection job.
+type CatalogConfig struct {
+	// DedupInterval is how often duplicate candidates are recomputed; 0
+	// disables the job (candidates are still computed on demand).
+	DedupInterval time.Duration `mapstructure:"dedup_interval" yaml:"dedup_interval"`
+	// DedupThreshold is the minimum similarity (0-1) for a candidate pair;
+	// 0 uses the built-in default.
+	DedupThreshold float64 `mapstructure:"dedup_threshold" yaml:"dedup_threshold"`
+	// RefreshWebhookSecret must be sent in X-Webhook-Secret by CI/CD calls
+	// to the catalog refresh webhook; the webhook is refused while empty.
+	RefreshWebhookSecret string `mapstructure:"refresh_webhook_secret" yaml:"refresh_webhook_secret"`
+	// RefreshLookback is how far back a refresh looks for the target's series.
+	RefreshLookback time.Duration `mapstructure:"refresh_lookback" yaml:"refresh_lookback"`
+	// ServiceLabel and NamespaceLabel are the metric labels a refresh
+	// target is matched on.
+	ServiceLabel   string `mapstructure:"service_label" yaml:"service_label"`
+	NamespaceLabel string `mapstructure:"namespace_label" yaml:"namespace_label"`
+}
+
+// HealthScoreConfig controls the computed 0-100 service health score.
+// Queries may contain the {service} placeholder.
+type HealthScoreConfig struct {
+	// Services are refreshed every RefreshInterval (0 disables); other
+	// services are scored on demand.
+	Services        []string      `mapstructure:"services" yaml:"services"`
+	RefreshInterval time.Duration `mapstructure:"refresh_interval" yaml:"refresh_interval"`
+	CacheTTL        time.Duration `mapstructure:"cache_ttl" yaml:"cache_ttl"`
+	// AlertsQuery selects the service's firing alerts (vmalert ALERTS series).
+	AlertsQuery string `mapstructure:"alerts_query" yaml:"alerts_query"`
+	// IncidentWindow is how far back failure records count as recent.
+	IncidentWindow time.Duration `mapstructure:"incident_window" yaml:"incident_window"`
+	// ErrorRatioQuery returns the service's error ratio (0-1); together with
+	// SLOTarget it gives the error-budget burn rate. Empty skips the component.
+	ErrorRatioQuery string  `mapstructure:"error_ratio_query" yaml:"error_ratio_query"`
+	SLOTarget       float64 `mapstructure:"slo_target" yaml:"slo_target"`
+}
+
+// ExecutiveSummaryConfig controls the aggregated tenant health overview.
+// Incident windows, SLO target and error-ratio query come from health_score.
+type ExecutiveSummaryConfig struct {
+	CacheTTL time.Duration `mapstructure:"cache_ttl" yaml:"cache_ttl"`
+	// SnapshotInterval stores a summary snapshot for reports (0 disables);
+	// SnapshotRetention is how many snapshots are kept.
+	SnapshotInterval  time.Duration `mapstructure:"snapshot_interval" yaml:"snapshot_interval"`
+	SnapshotRetention int           `mapstructure:"snapshot_retention" yaml:"snapshot_retention"`
+	// TopN bounds the violated KPI, SLO burn and noisy service lists.
+	TopN int `mapstructure:"top_n" yaml:"top_n"`
+	// AlertsQuery selects all firing alerts; series are grouped by their
+	// service label.
+	AlertsQuery string `mapstructure:"alerts_query" yaml:"alerts_query"`
+}
+
+// StatsSnapshotConfig controls the tenant statistics snapshots served to the
+// admin overview. Every Interval the object counts and sizes are stored as a
+// snapshot (0 disables the job); Retention is how many snapshots are kept.
+type StatsSnapshotConfig struct {
+	Interval  time.Duration `mapstructure:"interval" yaml:"interval"`
+	Retention int           `mapstructure:"retention" yaml:"retention"`
+}
+
+// CapacityConfig controls capacity planning forecasts. A KPI is a resource
+// KPI when one of its tags equals, or its name contains, a ResourceTags entry.
+type CapacityConfig struct {
+	ResourceTags []string `mapstructure:"resource_tags" yaml:"resource_tags"`
+	// Lookback is the trend window sampled every Step.
+	Lookback    time.Duration `mapstructure:"lookback" yaml:"lookback"`
+	Step        time.Duration `mapstructure:"step" yaml:"step"`
+	HorizonDays int           `mapstructure:"horizon_days" yaml:"horizon_days"`
+	// RefreshInterval rebuilds the default report for scheduled reports
+	// (0 disables).
+	RefreshInterval time.Duration `mapstructure:"refresh_interval" yaml:"refresh_interval"`
+}
+
+// KPIHistoryConfig controls the recorded history of KPI threshold states.
+// History is kept in Weaviate, one summary object per KPI and day.
+type KPIHistoryConfig struct {
+	// Interval evaluates every KPI with a formula and thresholds and records
+	// its state (0 disables).
+	Interval time.Duration `mapstructure:"interval" yaml:"interval"`
+	// MaxKPIs bounds the KPIs evaluated per interval.
+	MaxKPIs int `mapstructure:"max_kpis" yaml:"max_kpis"`
+	// RetentionDays is how many days of history are kept.
+	RetentionDays int `mapstructure:"retention_days" yaml:"retention_days"`
+}
+
+// RemediationConfig controls the remediation rules engine, which runs
+// webhook, notification and job actions when KPI states or alerts match a
+// rule.
+type RemediationConfig struct {
+	// Enabled evaluates rules against incoming events.
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+	// ActionTimeout bounds one webhook or job trigger call.
+	ActionTimeout time.Duration `mapstructure:"action_timeout" yaml:"action_timeout"`
+	// AuditLimit is how many executions are kept per rule.
+	AuditLimit int `mapstructure:"audit_limit" yaml:"audit_limit"`
+	// AllowedHosts restricts webhook and job URLs to these hosts; empty
+	// allows any host.
+	AllowedHosts []string `mapstructure:"allowed_hosts" yaml:"allowed_hosts"`
+}
+
+// SelfSLOConfig holds the objectives mirador-core keeps for its own API,
+// evaluated over a rolling Window from the in-process HTTP metrics.
+type SelfSLOConfig struct {
+	// Window is the rolling period the objectives are measured over.
+	Window time.Duration `mapstructure:"window" yaml:"window"`
+	// EvaluationInterval samples the counters and raises breach
+	// notifications (0 disables; the report then covers all time since
+	// startup).
+	EvaluationInterval time.Duration `mapstructure:"evaluation_interval" yaml:"evaluation_interval"`
+	// MinRequests is the traffic a target needs in the window before it can
+	// breach, so idle endpoints do not page on a single failure.
+	MinRequests int  `mapstructure:"min_requests" yaml:"min_requests"`
+	Notify      bool `mapstructure:"notify" yaml:"notify"`
+	// Targets replaces DefaultSelfSLOTargets when set.
+	Targets []SelfSLOTarget `mapstructure:"targets" yaml:"targets"`
+}
+
+// InternalHealthConfig controls the in-process sampling behind the admin
+// health trends: every Interval the cache, Weaviate, correlation, queue and
+// error metrics are diffed against the previous sample, and Retention of
+// samples is kept in memory.
+type InternalHealthConfig struct {
+	// Interval is the sampling period and the finest trend step (0
+	// disables sampling).
+	Interval  time.Duration `mapstructure:"interval" yaml:"interval"`
+	Retention time.Duration `mapstructure:"retention" yaml:"retention"`
+}
+
+// FleetConfig controls the version handshake between the instances of a
+// deployment. Every HeartbeatInterval each instance publishes its build,
+// API and schema versions to Valkey; instances not seen for three
+// intervals are considered drained.
+type FleetConfig struct {
+	// InstanceID identifies this instance; empty uses the hostname (the
+	// pod name on Kubernetes).
+	InstanceID        string        `mapstructure:"instance_id" yaml:"instance_id"`
+	HeartbeatInterval time.Duration `mapstructure:"heartbeat_interval" yaml:"heartbeat_interval"`
+}
+
+// SelfSLOTarget is the objective for one route. Endpoint is the route
+// template as registered (e.g. "/api/v1/kpi/defs/:id"). LatencyThreshold is
+// rounded up to the nearest request-duration histogram bucket.
+type SelfSLOTarget struct {
+	Name                  string        `mapstructure:"name" yaml:"name"`
+	Method                string        `mapstructure:"method" yaml:"method"`
+	Endpoint              string        `mapstructure:"endpoint" yaml:"endpoint"`
+	LatencyThreshold      time.Duration `mapstructure:"latency_threshold" yaml:"latency_threshold"`
+	LatencyObjective      float64       `mapstructure:"latency_objective" yaml:"latency_objective"`
+	AvailabilityObjective float64       `mapstructure:"availability_objective" yaml:"availability_objective"`
+}
+
+// DefaultSelfSLOTargets covers the query, correlation and KPI catalog paths.
+var DefaultSelfSLOTargets = []SelfSLOTarget{
+	{Name: "unified-query", Method: "POST", Endpoint: "/api/v1/unified/query", LatencyThreshold: 2500 * time.Millisecond, LatencyObjective: 0.99, AvailabilityObjective: 0.995},
+	{Name: "unified-correlation", Method: "POST", Endpoint: "/api/v1/unified/correlation", LatencyThreshold: 10 * time.Second, LatencyObjective: 0.95, AvailabilityObjective: 0.99},
+	{Name: "unified-rca", Method: "POST", Endpoint: "/api/v1/unified/rca", LatencyThreshold: 10 * time.Second, LatencyObjective: 0.95, AvailabilityObjective: 0.99},
+	{Name: "kpi-definitions", Method: "GET", Endpoint: "/api/v1/kpi/defs", LatencyThreshold: 500 * time.Millisecond, LatencyObjective: 0.99, AvailabilityObjective: 0.999},
+}
+
+// ProfilingConfig exposes net/http/pprof under /api/v1/admin/debug/pprof.
+// The endpoints are only registered when Enabled. They require
+// "Authorization: Bearer <Token>" when Token is set, and an admin token
+// otherwise.
+type ProfilingConfig struct {
+	Enabled  bool                    `mapstructure:"enabled" yaml:"enabled"`
+	Token    string                  `mapstructure:"token" yaml:"token"`
+	Watchdog ProfilingWatchdogConfig `mapstructure:"watchdog" yaml:"watchdog"`
+}
+
+// ProfilingWatchdogConfig captures CPU, heap and goroutine profiles when any
+// threshold is crossed (zero thresholds are ignored). Captures are written
+// to Dir and pruned to Retention sets no older than MaxAge.
+type ProfilingWatchdogConfig struct {
+	Enabled  bool          `mapstructure:"enabled" yaml:"enabled"`
+	Interval time.Duration `mapstructure:"interval" yaml:"interval"`
+	// CPUThreshold is process CPU utilisation (0-1) of GOMAXPROCS.
+	CPUThreshold       float64 `mapstructure:"cpu_threshold" yaml:"cpu_threshold"`
+	HeapThresholdMB    int     `mapstructure:"heap_threshold_mb" yaml:"heap_threshold_mb"`
+	GoroutineThreshold int     `mapstructure:"goroutine_threshold" yaml:"goroutine_threshold"`
+	// LatencyThreshold is the mean HTTP request duration over an Interval.
+	LatencyThreshold   time.Duration `mapstructure:"latency_threshold" yaml:"latency_threshold"`
+	CPUProfileDuration time.Duration `mapstructure:"cpu_profile_duration" yaml:"cpu_profile_duration"`
+	// Cooldown is the minimum time between captures.
+	Cooldown  time.Duration `mapstructure:"cooldown" yaml:"cooldown"`
+	Dir       string        `mapstructure:"dir" yaml:"dir"`
+	Retention int           `mapstructure:"retention" yaml:"retention"`
+	MaxAge    time.Duration `mapstructure:"max_age" yaml:"max_age"`
+}
+
+// ExportConfig bounds the memory used by log exports. Exports larger than
+// MemoryMB are spilled to a temp file in SpillDir (os.TempDir() when empty)
+// and streamed from there; exports larger than MaxMB are rejected.
+type ExportConfig struct {
+	MemoryMB int    `mapstructure:"memory_mb" yaml:"memory_mb"`
+	MaxMB    int    `mapstructure:"max_mb" yaml:"max_mb"` // 0 = unlimited
+	SpillDir string `mapstructure:"spill_dir" yaml:"spill_dir"`
+
+	// AnonymizationHashKey keys the hash strategy of anonymization
+	// profiles. Hashes stay joinable across exports only while it is
+	// unchanged; hash rules are refused while it is empty.
+	AnonymizationHashKey string `mapstructure:"anonymization_hash_key" yaml:"anonymization_hash_key"`
+}
+
+// KPIDatastoreConfig registers a warehouse that KPI definitions reference
+// by Name in their datastore field. URL is the ClickHouse HTTP endpoint
+// (http://host:8123) or a postgres:// connection URL; Username/Password
+// take precedence over credentials in the URL.
+type KPIDatastoreConfig struct {
+	Name     string        `mapstructure:"name" yaml:"name"`
+	Type     string        `mapstructure:"type" yaml:"type"` // clickhouse | postgresql
+	URL      string        `mapstructure:"url" yaml:"url"`
+	Database string        `mapstructure:"database" yaml:"database"`
+	Username string        `mapstructure:"username" yaml:"username"`
+	Password string        `mapstructure:"password" yaml:"password"`
+	Timeout  time.Duration `mapstructure:"timeout" yaml:"timeout"`
+}
+
+// ResultLimitsConfig rejects metrics queries returning more than MaxSeries
+// series and logs queries returning more than MaxLogRows rows, answering with
+// suggestions for a narrower query instead of a partial result. 0 disables a
+// limit.
+type ResultLimitsConfig struct {
+	MaxSeries  int `mapstructure:"max_series" yaml:"max_series"`
+	MaxLogRows int `mapstructure:"max_log_rows" yaml:"max_log_rows"`
+}
+
+// RetentionConfig controls enforcement of retention policies. Enabled
+// policies are purged every Interval (0 disables the schedule; purges can
+// still be run on demand). MaxSeriesPerRun bounds the metrics series one
+// purge deletes (0 uses the default of 10000).
+type RetentionConfig struct {
+	Interval        time.Duration `mapstructure:"interval" yaml:"interval"`
+	MaxSeriesPerRun int           `mapstructure:"max_series_per_run" yaml:"max_series_per_run"`
+}
+
+// KPIFormulaLintConfig controls the warnings raised for KPI formulas against
+// the metrics and logs catalog. Names are looked up over the last Lookback;
+// a label with more than MaxLabelValues values (0 disables the check) is
+// flagged as high cardinality. DeprecatedMetrics and DeprecatedLabels list
+// retired names; DeprecatedLabels also covers log fields.
+type KPIFormulaLintConfig struct {
+	Lookback          time.Duration    `mapstructure:"lookback" yaml:"lookback"`
+	MaxLabelValues    int              `mapstructure:"max_label_values" yaml:"max_label_values"`
+	DeprecatedMetrics []DeprecatedName `mapstructure:"deprecated_metrics" yaml:"deprecated_metrics"`
+	DeprecatedLabels  []DeprecatedName `mapstructure:"deprecated_labels" yaml:"deprecated_labels"`
+}
+
+// CardinalityConfig controls label cardinality analysis. A label with more
+// than MaxLabelValues distinct values is flagged as high cardinality. Labels
+// are scanned every ScanInterval (0 disables the schedule; scans can still
+// be run on demand) over the last ScanLookback, and analyses span at most
+// MaxRangeDays days. TopK is the default number of top values reported.
+type CardinalityConfig struct {
+	MaxLabelValues int           `mapstructure:"max_label_values" yaml:"max_label_values"`
+	TopK           int           `mapstructure:"top_k" yaml:"top_k"`
+	MaxRangeDays   int           `mapstructure:"max_range_days" yaml:"max_range_days"`
+	ScanInterval   time.Duration `mapstructure:"scan_interval" yaml:"scan_interval"`
+	ScanLookback   time.Duration `mapstructure:"scan_lookback" yaml:"scan_lookback"`
+}
+
+// DeprecatedName is a retired metric, label or field name and the name to
+// use instead ("" when there is none).
+type DeprecatedName struct {
+	Name        string `mapstructure:"name" yaml:"name"`
+	Replacement string `mapstructure:"replacement" yaml:"replacement"`
+}
+
+// UsageTelemetryConfig controls opt-in feature usage telemetry. When Enabled,
+// per-route request counters (route templates only, never parameters,
+// tenants or users) are kept in memory and served at
+// /api/v1/admin/telemetry. CollectorURL, when set, receives the same payload
+// every ReportInterval.
+type UsageTelemetryConfig struct {
+	Enabled        bool          `mapstructure:"enabled" yaml:"enabled"`
+	CollectorURL   string        `mapstructure:"collector_url" yaml:"collector_url"`
+	ReportInterval time.Duration `mapstructure:"report_interval" yaml:"report_interval"`
+}
+
+// QoSConfig bounds concurrent backend queries. MaxConcurrent caps all
+// traffic (0 disables scheduling); Classes caps the interactive, background
+// and batch classes individually. Freed slots go to interactive requests
+// first, so background and batch work queues behind dashboards.
+type QoSConfig struct {
+	MaxConcurrent int                       `mapstructure:"max_concurrent" yaml:"max_concurrent"`
+	Classes       map[string]QoSClassConfig `mapstructure:"classes" yaml:"classes"`
+}
+
+// QoSClassConfig bounds one traffic class. MaxConcurrent 0 lets the class
+// use every slot; QueueTimeout 0 waits as long as the caller allows.
+type QoSClassConfig struct {
+	MaxConcurrent int           `mapstructure:"max_concurrent" yaml:"max_concurrent"`
+	QueueTimeout  time.Duration `mapstructure:"queue_timeout" yaml:"queue_timeout"`
+}
+
+// ReplicationConfig controls active/passive operation. A "replica" serves
+// read-only traffic against replicated Weaviate/Valkey and points writers at
+// PrimaryURL. Lag is measured from a heartbeat the primary writes to Valkey.
+type ReplicationConfig struct {
+	Role              string        `mapstructure:"role" yaml:"role"` // primary | replica
+	PrimaryURL        string        `mapstructure:"primary_url" yaml:"primary_url"`
+	HeartbeatInterval time.Duration `mapstructure:"heartbeat_interval" yaml:"heartbeat_interval"`
+	// MaxLag marks a replica not ready once the heartbeat is older than this.
+	MaxLag time.Duration `mapstructure:"max_lag" yaml:"max_lag"`
+}
+
 // WebSocketConfig handles real-time streaming configuration
 type WebSocketConfig struct {
 	Enabled         bool `mapstructure:"enabled" yaml:"enabled"`
@@ -312,6 +944,51 @@ type WeaviateConfig struct {
 	// and which model to select. Designed to be CPU-friendly by default (small
 	// transformer models) to avoid the need for GPU infra in many deployments.
 	Vectorizer WeaviateVectorizerConfig `mapstructure:"vectorizer" yaml:"vectorizer"`
+	// Classes overrides replication factor and write consistency per class,
+	// keyed by class name (matched case-insensitively). Classes without an
+	// entry use Consistency and the cluster's default replication factor.
+	Classes map[string]WeaviateClassConfig `mapstructure:"classes" yaml:"classes"`
+	// Discovery spreads requests across discovered Weaviate pods instead of Host.
+	Discovery K8sDiscoveryConfig `mapstructure:"discovery" yaml:"discovery"`
+	// Transport tunes connection pooling, retries and the circuit breaker.
+	Transport WeaviateTransportConfig `mapstructure:"transport" yaml:"transport"`
+	// SchemaPreflight compares the live schema with the expected classes at startup.
+	SchemaPreflight WeaviateSchemaPreflightConfig `mapstructure:"schema_preflight" yaml:"schema_preflight"`
+}
+
+// WeaviateSchemaPreflightConfig controls the schema check run at startup.
+// It compares the classes mirador-core creates with the live Weaviate
+// schema and logs a drift report. With BlockOnCritical set, startup fails
+// when a property has the wrong data type.
+type WeaviateSchemaPreflightConfig struct {
+	Enabled         bool          `mapstructure:"enabled" yaml:"enabled"`
+	BlockOnCritical bool          `mapstructure:"block_on_critical" yaml:"block_on_critical"`
+	Timeout         time.Duration `mapstructure:"timeout" yaml:"timeout"`
+}
+
+// WeaviateTransportConfig controls the HTTP connections to Weaviate.
+// Idempotent requests failing with a timeout, connection error or 502/503/504
+// are retried up to MaxAttempts times with jittered exponential backoff
+// between InitialBackoff and MaxBackoff. After BreakerFailures consecutive
+// failures, requests fail fast for BreakerCooldown before a single probe is
+// let through (0 disables the breaker).
+type WeaviateTransportConfig struct {
+	MaxIdleConnsPerHost int           `mapstructure:"max_idle_conns_per_host" yaml:"max_idle_conns_per_host"`
+	MaxConnsPerHost     int           `mapstructure:"max_conns_per_host" yaml:"max_conns_per_host"` // 0 = unlimited
+	IdleConnTimeout     time.Duration `mapstructure:"idle_conn_timeout" yaml:"idle_conn_timeout"`
+	MaxAttempts         int           `mapstructure:"max_attempts" yaml:"max_attempts"`
+	InitialBackoff      time.Duration `mapstructure:"initial_backoff" yaml:"initial_backoff"`
+	MaxBackoff          time.Duration `mapstructure:"max_backoff" yaml:"max_backoff"`
+	BreakerFailures     int           `mapstructure:"breaker_failures" yaml:"breaker_failures"`
+	BreakerCooldown     time.Duration `mapstructure:"breaker_cooldown" yaml:"breaker_cooldown"`
+}
+
+// WeaviateClassConfig holds replication settings for a single Weaviate class.
+// ReplicationFactor only applies when the class is first created; Weaviate
+// does not change the factor of an existing class on startup.
+type WeaviateClassConfig struct {
+	ReplicationFactor int    `mapstructure:"replication_factor" yaml:"replication_factor"`
+	Consistency       string `mapstructure:"consistency" yaml:"consistency"` // ONE, QUORUM or ALL
 }
 
 // WeaviateVectorizerConfig controls runtime vectorizer provider & model selection
@@ -426,3 +1103,70 @@ type RCAConfig struct {
 	// Default: 0.1 (10% bonus per aligned dimension).
 	AlignmentBonus float64 `mapstructure:"alignment_bonus" yaml:"alignment_bonus"`
 }
+
+// CallbackConfig controls the gRPC server AI engines use to deliver the
+// results of long-running jobs instead of being polled. Each engine
+// authenticates with its own token; results are kept for ResultTTL.
+type CallbackConfig struct {
+	Enabled   bool          `mapstructure:"enabled" yaml:"enabled"`
+	Port      int           `mapstructure:"port" yaml:"port"`
+	ResultTTL time.Duration `mapstructure:"result_ttl" yaml:"result_ttl"`
+	// MaxMessageBytes bounds one submitted result.
+	MaxMessageBytes int                    `mapstructure:"max_message_bytes" yaml:"max_message_bytes"`
+	Engines         []CallbackEngineConfig `mapstructure:"engines" yaml:"engines"`
+}
+
+// CallbackEngineConfig is an engine allowed to submit results.
+type CallbackEngineConfig struct {
+	Name  string `mapstructure:"name" yaml:"name"`
+	Token string `mapstructure:"token" yaml:"token"`
+}
+
+// QueryTemplatesConfig controls parameterized query templates. With
+// Restricted set, ad-hoc MetricsQL, LogsQL and UQL queries are rejected and
+// only approved templates can be executed; regulated tenants enable it
+// through their tenant overrides. MaxRange bounds the time range of one
+// template execution.
+type QueryTemplatesConfig struct {
+	Restricted bool          `mapstructure:"restricted" yaml:"restricted"`
+	MaxRange   time.Duration `mapstructure:"max_range" yaml:"max_range"`
+}
+
+// ResidencyConfig pins the tenant's data to one region. Regions maps each
+// region to the hosts of its backend stack: exact names, "*.suffix"
+// wildcards or CIDR ranges (for discovered pods). With Region set, every
+// configured backend must be in that region's set; with Strict also set,
+// this is enforced at startup and requests to any other host are refused.
+type ResidencyConfig struct {
+	Region  string                           `mapstructure:"region" yaml:"region"`
+	Strict  bool                             `mapstructure:"strict" yaml:"strict"`
+	Regions map[string]ResidencyRegionConfig `mapstructure:"regions" yaml:"regions"`
+}
+
+// ResidencyRegionConfig lists the backend hosts of one region.
+type ResidencyRegionConfig struct {
+	Hosts []string `mapstructure:"hosts" yaml:"hosts"`
+}
+
+// ComplianceConfig holds operator attestations for compliance report
+// controls that mirador-core cannot verify itself, such as disk encryption
+// of the backends or MFA at the gateway.
+type ComplianceConfig struct {
+	Attestations []ComplianceAttestation `mapstructure:"attestations" yaml:"attestations"`
+}
+
+// ComplianceAttestation records the evidence for one manual control. It only
+// applies to controls the report marks manual; automated checks cannot be
+// overridden.
+type ComplianceAttestation struct {
+	Control  string `mapstructure:"control" yaml:"control"`
+	Evidence string `mapstructure:"evidence" yaml:"evidence"`
+}
+
+// AuditorConfig is a read-only auditor. Requests carrying its token as
+// "Authorization: Bearer <token>" may read everything but every mutating
+// request is rejected with 403.
+type AuditorConfig struct {
+	Name  string `mapstructure:"name" yaml:"name"`
+	Token string `mapstructure:"token" yaml:"token"`
+}
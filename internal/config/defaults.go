@@ -153,6 +153,31 @@ func GetDefaultConfig() *Config {
 			// Empty list forces engines to use KPI registry metadata for service discovery.
 			ServiceCandidates: []string{},
 			DefaultQueryLimit: 1000,
+			Scoring:           DefaultScoringWeights,
+			RecordRuns:        true,
+			RunRetention:      200,
+			// Roughly 500k time-window correlations.
+			MaxCorrelationMemoryMB: 256,
+			Events: EventSignalConfig{
+				Lookback:       30 * time.Minute,
+				BurstThreshold: 5,
+				Boost:          0.15,
+				Retention:      7 * 24 * time.Hour,
+				ServiceLabels:  []string{"app.kubernetes.io/name", "app"},
+			},
+			LabelMatch: LabelMatchConfig{
+				Weights:        DefaultLabelWeights,
+				DefaultWeight:  0.5,
+				IgnoreCase:     true,
+				StripPodSuffix: true,
+				ShortHostnames: true,
+			},
+			Pruning: CandidatePruningConfig{
+				Enabled:          true,
+				MinCandidates:    50,
+				MinRelativeDelta: 0.05,
+				MaxCandidates:    200,
+			},
 			Labels: LabelSchemaConfig{
 				Service:    []string{"service", "service.name", "serviceName"},
 				Pod:        []string{"pod", "kubernetes.pod_name"},
@@ -64,6 +64,12 @@
 //   - Numeric ranges (ports, timeouts, pool sizes)
 //   - Duration parsing
 //   - URL formats
+//   - Unknown keys (typos, settings from another version)
+//   - Cross-field constraints (e.g. ring_step when pre/post rings are set)
+//
+// Load reports every problem at once as a [LoadError]; its Report method
+// lists them one per line. Run the binary with --validate-config to check a
+// configuration without starting the server.
 //
 // # Defaults
 //
@@ -83,8 +89,19 @@
 //
 // Configuration files are located in the configs/ directory:
 //   - config.yaml: Base configuration
-//   - config.development.yaml: Development overrides
-//   - config.production.yaml: Production overrides
+//   - config.development.yaml: Development overlay
+//   - config.production.yaml: Production overlay
+//
+// [Load] merges the layers below, each overriding the one before; see
+// [Precedence]:
+//
+//  1. defaults
+//  2. config.yaml (or CONFIG_PATH)
+//  3. config.<env>.yaml (or CONFIG_OVERLAYS)
+//  4. environment variables
+//  5. tenant overrides ([ApplyOverrides])
 //
-// The loader automatically merges environment-specific files.
+// Overlays hold only the settings that differ from the base. Tenant overrides
+// are dotted paths stored in the dynamic config store and applied at startup;
+// [RedactedSettings] renders the result with secrets hidden.
 package config
@@ -56,6 +56,46 @@ func MergeEngineConfigWithDefaults(cfg EngineConfig) EngineConfig {
 	if cfg.DefaultQueryLimit == 0 {
 		cfg.DefaultQueryLimit = def.DefaultQueryLimit
 	}
+	if cfg.RunRetention == 0 {
+		cfg.RunRetention = def.RunRetention
+	}
+	if cfg.MaxCorrelationMemoryMB == 0 {
+		cfg.MaxCorrelationMemoryMB = def.MaxCorrelationMemoryMB
+	}
+
+	// Event signals: fill each zero setting
+	if cfg.Events.Lookback == 0 {
+		cfg.Events.Lookback = def.Events.Lookback
+	}
+	if cfg.Events.BurstThreshold == 0 {
+		cfg.Events.BurstThreshold = def.Events.BurstThreshold
+	}
+	if cfg.Events.Boost == 0 {
+		cfg.Events.Boost = def.Events.Boost
+	}
+	if cfg.Events.Retention == 0 {
+		cfg.Events.Retention = def.Events.Retention
+	}
+	if len(cfg.Events.ServiceLabels) == 0 {
+		cfg.Events.ServiceLabels = def.Events.ServiceLabels
+	}
+
+	// Scoring weights: fill each zero weight
+	if cfg.Scoring.Pearson == 0 {
+		cfg.Scoring.Pearson = def.Scoring.Pearson
+	}
+	if cfg.Scoring.Spearman == 0 {
+		cfg.Scoring.Spearman = def.Scoring.Spearman
+	}
+	if cfg.Scoring.CrossCorr == 0 {
+		cfg.Scoring.CrossCorr = def.Scoring.CrossCorr
+	}
+	if cfg.Scoring.LagBonus == 0 {
+		cfg.Scoring.LagBonus = def.Scoring.LagBonus
+	}
+	if cfg.Scoring.AnomalyDensity == 0 {
+		cfg.Scoring.AnomalyDensity = def.Scoring.AnomalyDensity
+	}
 
 	// Labels: if any canonical slice is empty, copy from defaults
 	if len(cfg.Labels.Service) == 0 {
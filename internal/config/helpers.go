@@ -55,6 +55,11 @@ func (c *Config) IsTest() bool {
 	return c.Environment == "test"
 }
 
+// IsReplica returns true if this deployment is a read-only replica
+func (c *Config) IsReplica() bool {
+	return c.Replication.Role == "replica"
+}
+
 // GetDatabaseTimeout returns the appropriate database timeout
 func (c *Config) GetDatabaseTimeout() time.Duration {
 	timeout := c.Database.VictoriaMetrics.Timeout
@@ -1,20 +1,36 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
 	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/go-viper/mapstructure/v2"
 	"github.com/spf13/viper"
 )
 
-// Load loads configuration with priority:
+// Load loads configuration in layers, each overriding the one before:
 //
-// 1) CONFIG_PATH (absolute path to a YAML file)
-// 2) ./configs/config.<env>.yaml  (env = MIRADOR_ENV | ENV | "development")
-// 3) ./configs/config.yaml
-// 4) /etc/mirador/config.(<env>|yaml) then current directory fallback
+// 1) defaults (setDefaults)
+// 2) base file: CONFIG_PATH, else ./configs/config.yaml
+// 3) environment overlays: CONFIG_OVERLAYS, else ./configs/config.<env>.yaml
+// 4) environment variables
+//
+// env = MIRADOR_ENV | ENV | ENVIRONMENT | "development". CONFIG_OVERLAYS is a
+// comma-separated list of paths, used with CONFIG_PATH. Overlays only need
+// the keys that differ from the base: maps merge key by key, lists replace.
+// Without either ./configs file, the first of config.<env>.yaml and
+// config.yaml found in /etc/mirador/, ./configs/ or the current directory is
+// read alone. Tenant overrides (ApplyOverrides) sit on top of everything
+// Load returns.
 //
 // Env vars override file values. We support both MIRADOR_* variables via Viper
 // and a few legacy plain env vars via overrideWithEnvVars.
@@ -29,30 +45,24 @@ func Load() (*Config, error) {
 	v.SetEnvPrefix("MIRADOR")
 	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 
-	// 1) explicit config file path
+	var sources []string
 	if explicit := strings.TrimSpace(os.Getenv("CONFIG_PATH")); explicit != "" {
-		v.SetConfigFile(explicit)
-		if err := tryRead(v); err != nil {
-			return nil, err
-		}
+		sources = append([]string{explicit}, splitCSV(os.Getenv("CONFIG_OVERLAYS"))...)
 	} else {
-		// 2) ./configs/config.<env>.yaml  or 3) ./configs/config.yaml
 		// Honor common env selectors in this order: MIRADOR_ENV, ENV, ENVIRONMENT
 		env := firstNonEmpty(os.Getenv("MIRADOR_ENV"), os.Getenv("ENV"), os.Getenv("ENVIRONMENT"), "development")
 		envPath := "./configs/config." + strings.ToLower(env) + ".yaml"
 
-		if fileExists(envPath) {
-			v.SetConfigFile(envPath)
-			if err := tryRead(v); err != nil {
-				return nil, err
+		switch {
+		case fileExists("./configs/config.yaml"):
+			sources = []string{"./configs/config.yaml"}
+			if fileExists(envPath) {
+				sources = append(sources, envPath)
 			}
-		} else if fileExists("./configs/config.yaml") {
-			v.SetConfigFile("./configs/config.yaml")
-			if err := tryRead(v); err != nil {
-				return nil, err
-			}
-		} else {
-			// 4) search standard locations
+		case fileExists(envPath):
+			sources = []string{envPath}
+		default:
+			// search standard locations
 			v.SetConfigType("yaml")
 			v.SetConfigName("config." + strings.ToLower(env))
 			v.AddConfigPath("/etc/mirador/")
@@ -68,16 +78,40 @@ func Load() (*Config, error) {
 					// not found anywhere → proceed with env + defaults
 				}
 			}
+			if used := v.ConfigFileUsed(); used != "" {
+				sources = []string{used}
+			}
+		}
+	}
+	if len(sources) > 0 && v.ConfigFileUsed() == "" {
+		v.SetConfigFile(sources[0])
+		if err := tryRead(v); err != nil {
+			return nil, err
+		}
+		for _, overlay := range sources[1:] {
+			v.SetConfigFile(overlay)
+			if err := v.MergeInConfig(); err != nil {
+				return nil, fmt.Errorf("failed to read config overlay %s: %w", overlay, err)
+			}
 		}
 	}
 
 	// Legacy/plain env overrides for compatibility
 	overrideWithEnvVars(v)
 
+	// Strict decode: type errors and unknown keys are collected, not fatal,
+	// so the report below lists every problem at once.
 	var cfg Config
+	var errs ValidationErrors
 	if err := v.Unmarshal(&cfg); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+		errs = append(errs, decodeValidationErrors(err)...)
 	}
+	var unknownKeys []string
+	findUnknownKeys("", v.AllSettings(), reflect.TypeOf(cfg), &unknownKeys)
+	unknown, warnings := unknownFieldErrors(unknownKeys)
+	errs = append(errs, unknown...)
+	cfg.Warnings = warnings
+	cfg.Sources = sources
 
 	// Ensure telemetry maps are initialized to empty maps when not provided
 	// so consumers can safely range over them without nil checks.
@@ -88,9 +122,28 @@ func Load() (*Config, error) {
 		cfg.Engine.Telemetry.Processors = map[string]ProcessorConfig{}
 	}
 
-	// Validate (config validation)
+	// Validate (config validation). Fields that failed to decode hold zero
+	// values, so their range errors would only repeat the decode error.
 	if err := validateConfig(&cfg); err != nil {
-		return nil, fmt.Errorf("config validation failed: %w", err)
+		var verrs ValidationErrors
+		if !errors.As(err, &verrs) {
+			return nil, fmt.Errorf("config validation failed: %w", err)
+		}
+		undecoded := map[string]bool{}
+		for _, e := range errs {
+			undecoded[e.Field] = true
+		}
+		for _, e := range verrs {
+			if !undecoded[e.Field] {
+				errs = append(errs, e)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		for range errs {
+			RecordValidationError()
+		}
+		return nil, &LoadError{File: strings.Join(sources, " + "), Errors: errs}
 	}
 
 	return &cfg, nil
@@ -173,14 +226,17 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("grpc.alert_engine.rules_path", "/etc/mirador/alert-rules.yaml")
 	v.SetDefault("grpc.alert_engine.timeout", 30000)
 
-	// Auth
-	v.SetDefault("auth.enabled", true)
-	// Note: Auth is now handled externally (API gateway, service mesh, etc.)
+	// Auth is handled externally (API gateway, service mesh, etc.)
 
 	// Cache (Valkey)
 	v.SetDefault("cache.nodes", []string{"localhost:6379"})
 	v.SetDefault("cache.ttl", 300)
 	v.SetDefault("cache.db", 0)
+	v.SetDefault("cache.tenant_id", "")
+	v.SetDefault("cache.tenant_stats_interval", "5m")
+	v.SetDefault("cache.encryption.enabled", false)
+	v.SetDefault("cache.encryption.active_version", 1)
+	v.SetDefault("cache.encryption.prefixes", []string{"cfg:", "correlation:share:"})
 
 	// CORS
 	v.SetDefault("cors.allowed_origins", []string{"*"})
@@ -195,6 +251,10 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("integrations.ms_teams.enabled", false)
 	v.SetDefault("integrations.email.enabled", false)
 	v.SetDefault("integrations.email.smtp_port", 587)
+	v.SetDefault("integrations.ticketing.issue_type", "Task")
+	v.SetDefault("integrations.ticketing.table", "incident")
+	v.SetDefault("integrations.ticketing.sync_interval", "5m")
+	v.SetDefault("integrations.ticketing.timeout", "10s")
 
 	// WebSocket
 	v.SetDefault("websocket.enabled", true)
@@ -213,12 +273,168 @@ func setDefaults(v *viper.Viper) {
 	// Uploads
 	v.SetDefault("uploads.bulk_max_bytes", int64(5<<20)) // 5 MiB default
 
+	// Maintenance mode (platform-wide switch)
+	v.SetDefault("maintenance.enabled", false)
+	v.SetDefault("maintenance.message", "")
+
+	// Fault injection (non-production testing only)
+	v.SetDefault("fault_injection.enabled", false)
+
+	// Replication (active/passive)
+	v.SetDefault("replication.role", "primary")
+	v.SetDefault("replication.primary_url", "")
+	v.SetDefault("replication.heartbeat_interval", "5s")
+	v.SetDefault("replication.max_lag", "60s")
+
+	// External secret providers
+	v.SetDefault("secrets.cache_ttl", "5m")
+	v.SetDefault("secrets.refresh_interval", "5m")
+	v.SetDefault("secrets.vault.mount", "secret")
+	v.SetDefault("secrets.kubernetes.mount_path", "/var/run/secrets/mirador")
+
+	// Catalog duplicate detection
+	v.SetDefault("catalog.dedup_interval", "6h")
+	v.SetDefault("catalog.dedup_threshold", 0.85)
+	v.SetDefault("catalog.refresh_webhook_secret", "")
+	v.SetDefault("catalog.refresh_lookback", "1h")
+	v.SetDefault("catalog.service_label", "service")
+	v.SetDefault("catalog.namespace_label", "namespace")
+
+	// Service health scores
+	v.SetDefault("health_score.refresh_interval", "5m")
+	v.SetDefault("health_score.cache_ttl", "10m")
+	v.SetDefault("health_score.alerts_query", `ALERTS{alertstate="firing",service="{service}"}`)
+	v.SetDefault("health_score.incident_window", "24h")
+	v.SetDefault("health_score.slo_target", 0.999)
+
+	// Executive summary
+	v.SetDefault("executive_summary.cache_ttl", "15m")
+	v.SetDefault("executive_summary.snapshot_interval", "24h")
+	v.SetDefault("executive_summary.snapshot_retention", 90)
+	v.SetDefault("executive_summary.top_n", 10)
+	v.SetDefault("executive_summary.alerts_query", `ALERTS{alertstate="firing"}`)
+
+	// Tenant statistics snapshots (a little over a week at the default
+	// interval, so week-over-week deltas are available)
+	v.SetDefault("stats_snapshots.interval", "15m")
+	v.SetDefault("stats_snapshots.retention", 700)
+
+	// Capacity planning
+	v.SetDefault("capacity.resource_tags", []string{"cpu", "memory", "disk", "queue"})
+	v.SetDefault("capacity.lookback", "168h")
+	v.SetDefault("capacity.step", "1h")
+	v.SetDefault("capacity.horizon_days", 30)
+	v.SetDefault("capacity.refresh_interval", "6h")
+
+	// KPI state history
+	v.SetDefault("kpi_history.interval", "5m")
+	v.SetDefault("kpi_history.max_kpis", 200)
+	v.SetDefault("kpi_history.retention_days", 30)
+
+	// Remediation rules
+	v.SetDefault("remediation.enabled", true)
+	v.SetDefault("remediation.action_timeout", "10s")
+	v.SetDefault("remediation.audit_limit", 100)
+
+	// Self-SLOs (targets default to DefaultSelfSLOTargets)
+	v.SetDefault("self_slo.window", "1h")
+	v.SetDefault("self_slo.evaluation_interval", "1m")
+	v.SetDefault("self_slo.min_requests", 100)
+	v.SetDefault("self_slo.notify", false)
+
+	// Internal health trends for the admin page
+	v.SetDefault("internal_health.interval", "1m")
+	v.SetDefault("internal_health.retention", "24h")
+	v.SetDefault("fleet.instance_id", "")
+	v.SetDefault("fleet.heartbeat_interval", "15s")
+
+	// Profiling (pprof endpoints off by default)
+	v.SetDefault("profiling.enabled", false)
+	v.SetDefault("profiling.watchdog.enabled", false)
+	v.SetDefault("profiling.watchdog.interval", "15s")
+	v.SetDefault("profiling.watchdog.cpu_threshold", 0.85)
+	v.SetDefault("profiling.watchdog.heap_threshold_mb", 0)
+	v.SetDefault("profiling.watchdog.goroutine_threshold", 10000)
+	v.SetDefault("profiling.watchdog.latency_threshold", "5s")
+	v.SetDefault("profiling.watchdog.cpu_profile_duration", "10s")
+	v.SetDefault("profiling.watchdog.cooldown", "15m")
+	v.SetDefault("profiling.watchdog.dir", "/tmp/mirador-profiles")
+	v.SetDefault("profiling.watchdog.retention", 20)
+	v.SetDefault("profiling.watchdog.max_age", "168h")
+
+	// Export memory limits
+	v.SetDefault("export.memory_mb", 32)
+	v.SetDefault("export.max_mb", 1024)
+	v.SetDefault("export.spill_dir", "")
+	v.SetDefault("export.anonymization_hash_key", "")
+
+	// Backend query scheduling: background and batch work together never
+	// hold more than 12 of 64 slots
+	v.SetDefault("qos.max_concurrent", 64)
+	v.SetDefault("qos.classes.interactive.queue_timeout", "30s")
+	v.SetDefault("qos.classes.background.max_concurrent", 8)
+	v.SetDefault("qos.classes.background.queue_timeout", "2m")
+	v.SetDefault("qos.classes.batch.max_concurrent", 4)
+	v.SetDefault("qos.classes.batch.queue_timeout", "10m")
+
+	// Warehouses for SQL KPIs (none by default)
+	v.SetDefault("kpi_datastores", []map[string]any{})
+
+	// Query result limits
+	v.SetDefault("result_limits.max_series", 10000)
+	v.SetDefault("result_limits.max_log_rows", 50000)
+
+	// Retention policy enforcement
+	v.SetDefault("retention.interval", "24h")
+	v.SetDefault("retention.max_series_per_run", 10000)
+
+	// KPI formula lint
+	v.SetDefault("kpi_formula_lint.lookback", "24h")
+	v.SetDefault("kpi_formula_lint.max_label_values", 10000)
+
+	// Label cardinality analysis
+	v.SetDefault("cardinality.max_label_values", 10000)
+	v.SetDefault("cardinality.top_k", 10)
+	v.SetDefault("cardinality.max_range_days", 7)
+	v.SetDefault("cardinality.scan_interval", "1h")
+	v.SetDefault("cardinality.scan_lookback", "24h")
+
+	// Query templates
+	v.SetDefault("query_templates.restricted", false)
+	v.SetDefault("query_templates.max_range", "168h")
+
+	// Data residency (off until a region is set)
+	v.SetDefault("residency.region", "")
+	v.SetDefault("residency.strict", true)
+
+	// Usage telemetry (opt-in)
+	v.SetDefault("usage_telemetry.enabled", false)
+	v.SetDefault("usage_telemetry.report_interval", "24h")
+	v.SetDefault("callbacks.enabled", false)
+	v.SetDefault("callbacks.port", 9091)
+	v.SetDefault("callbacks.result_ttl", "24h")
+	v.SetDefault("callbacks.max_message_bytes", 4<<20)
+
 	// Weaviate (disabled by default)
 	v.SetDefault("weaviate.enabled", false)
 	v.SetDefault("weaviate.scheme", "http")
 	v.SetDefault("weaviate.host", "weaviate.mirador.svc.cluster.local")
 	v.SetDefault("weaviate.port", 8080)
 	v.SetDefault("weaviate.use_official", false)
+	v.SetDefault("weaviate.discovery.enabled", false)
+	v.SetDefault("weaviate.discovery.scheme", "http")
+	v.SetDefault("weaviate.discovery.refresh_seconds", 30)
+	v.SetDefault("weaviate.transport.max_idle_conns_per_host", 16)
+	v.SetDefault("weaviate.transport.max_conns_per_host", 0)
+	v.SetDefault("weaviate.transport.idle_conn_timeout", "90s")
+	v.SetDefault("weaviate.transport.max_attempts", 3)
+	v.SetDefault("weaviate.transport.initial_backoff", "100ms")
+	v.SetDefault("weaviate.transport.max_backoff", "2s")
+	v.SetDefault("weaviate.transport.breaker_failures", 5)
+	v.SetDefault("weaviate.transport.breaker_cooldown", "30s")
+	v.SetDefault("weaviate.schema_preflight.enabled", true)
+	v.SetDefault("weaviate.schema_preflight.block_on_critical", false)
+	v.SetDefault("weaviate.schema_preflight.timeout", "10s")
 
 	// Unified Query Engine (Phase 1.5)
 	v.SetDefault("unified_query.enabled", true)
@@ -242,6 +458,32 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("engine.strict_time_window", false)
 	// AT-013: strict payload validation for correlation/rca endpoints
 	v.SetDefault("engine.strict_timewindow_payload", false)
+	// Suspicion score weights and run recording for offline evaluation
+	v.SetDefault("engine.scoring.pearson", DefaultScoringWeights.Pearson)
+	v.SetDefault("engine.scoring.spearman", DefaultScoringWeights.Spearman)
+	v.SetDefault("engine.scoring.cross_corr", DefaultScoringWeights.CrossCorr)
+	v.SetDefault("engine.scoring.lag_bonus", DefaultScoringWeights.LagBonus)
+	v.SetDefault("engine.scoring.anomaly_density", DefaultScoringWeights.AnomalyDensity)
+	v.SetDefault("engine.record_runs", true)
+	v.SetDefault("engine.run_retention", 200)
+	v.SetDefault("engine.max_correlation_memory_mb", 256)
+	// Kubernetes and CI/CD events in correlation
+	v.SetDefault("engine.events.lookback", "30m")
+	v.SetDefault("engine.events.burst_threshold", 5)
+	v.SetDefault("engine.events.boost", 0.15)
+	v.SetDefault("engine.events.retention", "168h")
+	v.SetDefault("engine.events.service_labels", []string{"app.kubernetes.io/name", "app"})
+	// Label-based correlation weights and value normalization
+	v.SetDefault("engine.label_match.weights", DefaultLabelWeights)
+	v.SetDefault("engine.label_match.default_weight", 0.5)
+	v.SetDefault("engine.label_match.ignore_case", true)
+	v.SetDefault("engine.label_match.strip_pod_suffix", true)
+	v.SetDefault("engine.label_match.short_hostnames", true)
+	// Early pruning of correlation candidates
+	v.SetDefault("engine.pruning.enabled", true)
+	v.SetDefault("engine.pruning.min_candidates", 50)
+	v.SetDefault("engine.pruning.min_relative_delta", 0.05)
+	v.SetDefault("engine.pruning.max_candidates", 200)
 }
 
 /* ---------------------------- legacy overrides --------------------------- */
@@ -289,20 +531,12 @@ func overrideWithEnvVars(v *viper.Viper) {
 			v.Set("cache.ttl", i)
 		}
 	}
-
-	if ldapURL := os.Getenv("LDAP_URL"); ldapURL != "" {
-		v.Set("auth.ldap.url", ldapURL)
-		v.Set("auth.ldap.enabled", true)
-	}
-	if ldapBase := os.Getenv("LDAP_BASE_DN"); ldapBase != "" {
-		v.Set("auth.ldap.base_dn", ldapBase)
-	}
-	if ae := os.Getenv("AUTH_ENABLED"); ae != "" {
-		if b, err := strconv.ParseBool(ae); err == nil {
-			v.Set("auth.enabled", b)
-		}
+	if t := os.Getenv("CACHE_TENANT_ID"); t != "" {
+		v.Set("cache.tenant_id", t)
 	}
-	// Auth env vars no longer supported - handled externally
+
+	// Auth env vars (LDAP_URL, LDAP_BASE_DN, AUTH_ENABLED) are no longer
+	// supported - handled externally
 
 	if slack := os.Getenv("SLACK_WEBHOOK_URL"); slack != "" {
 		v.Set("integrations.slack.webhook_url", slack)
@@ -357,6 +591,45 @@ func overrideWithEnvVars(v *viper.Viper) {
 			v.Set("uploads.bulk_max_bytes", vmib*(1<<20))
 		}
 	}
+
+	// Maintenance mode
+	if s := os.Getenv("MAINTENANCE_MODE"); s != "" {
+		if b, err := strconv.ParseBool(s); err == nil {
+			v.Set("maintenance.enabled", b)
+		}
+	}
+	if s := os.Getenv("MAINTENANCE_MESSAGE"); s != "" {
+		v.Set("maintenance.message", s)
+	}
+
+	// Fault injection
+	if s := os.Getenv("FAULT_INJECTION_ENABLED"); s != "" {
+		if b, err := strconv.ParseBool(s); err == nil {
+			v.Set("fault_injection.enabled", b)
+		}
+	}
+
+	// Replication
+	if s := os.Getenv("REPLICATION_ROLE"); s != "" {
+		v.Set("replication.role", s)
+	}
+	if s := os.Getenv("REPLICATION_PRIMARY_URL"); s != "" {
+		v.Set("replication.primary_url", s)
+	}
+
+	// Secret providers (standard Vault/AWS variables)
+	if s := os.Getenv("VAULT_ADDR"); s != "" {
+		v.Set("secrets.vault.address", s)
+	}
+	if s := os.Getenv("VAULT_TOKEN"); s != "" {
+		v.Set("secrets.vault.token", s)
+	}
+	if s := os.Getenv("VAULT_NAMESPACE"); s != "" {
+		v.Set("secrets.vault.namespace", s)
+	}
+	if s := firstNonEmpty(os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION")); s != "" {
+		v.Set("secrets.aws.region", s)
+	}
 }
 
 /* ------------------------------- validation ------------------------------ */
@@ -389,6 +662,175 @@ func (e ValidationErrors) Error() string {
 	return fmt.Sprintf("config validation failed with %d errors: %s", len(e), strings.Join(msgs, "; "))
 }
 
+// Report formats the errors one per line for people reading startup output.
+func (e ValidationErrors) Report() string {
+	var b strings.Builder
+	for _, ve := range e {
+		fmt.Fprintf(&b, "  - %s: %s", ve.Field, ve.Message)
+		if ve.Value != nil && !isSecretField(ve.Field) && !strings.Contains(ve.Message, "got ") {
+			fmt.Fprintf(&b, " (got %v)", ve.Value)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// isSecretField reports whether a field's value must not be echoed.
+func isSecretField(field string) bool {
+	name := field[strings.LastIndex(field, ".")+1:]
+	for _, marker := range []string{"password", "token", "api_key", "secret"} {
+		if strings.Contains(name, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadError is returned by Load when the configuration is invalid. It
+// unwraps to the ValidationErrors found across decoding and validation.
+type LoadError struct {
+	File   string // config files read, joined with " + "; empty when only defaults and env were used
+	Errors ValidationErrors
+}
+
+func (e *LoadError) Error() string {
+	return fmt.Sprintf("config validation failed: %s", e.Errors.Error())
+}
+
+func (e *LoadError) Unwrap() error { return e.Errors }
+
+// Report is a human-readable summary of every problem found.
+func (e *LoadError) Report() string {
+	source := e.File
+	if source == "" {
+		source = "defaults and environment"
+	}
+	return fmt.Sprintf("invalid configuration (%s): %d problem(s)\n%s", source, len(e.Errors), e.Errors.Report())
+}
+
+// decodeValidationErrors flattens a mapstructure decode error into one
+// ValidationError per offending key.
+func decodeValidationErrors(err error) ValidationErrors {
+	var out ValidationErrors
+	var walk func(error)
+	walk = func(err error) {
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, e := range joined.Unwrap() {
+				walk(e)
+			}
+			return
+		}
+		var de *mapstructure.DecodeError
+		if !errors.As(err, &de) {
+			out = append(out, ValidationError{Field: "config", Message: err.Error()})
+			return
+		}
+		inner := de.Unwrap()
+		var nested *mapstructure.DecodeError
+		if _, ok := inner.(interface{ Unwrap() []error }); ok || errors.As(inner, &nested) {
+			walk(inner)
+			return
+		}
+		out = append(out, ValidationError{Field: de.Name(), Message: inner.Error()})
+	}
+	walk(err)
+	return out
+}
+
+// findUnknownKeys appends the paths in settings that match no field of t,
+// following mapstructure tags through nested structs, slices and maps.
+func findUnknownKeys(prefix string, settings map[string]any, t reflect.Type, out *[]string) {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("mapstructure"), ",")
+		if name == "-" || !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[strings.ToLower(name)] = f.Type
+	}
+	for key, val := range settings {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		ft, ok := fields[strings.ToLower(key)]
+		if !ok {
+			*out = append(*out, path)
+			continue
+		}
+		findUnknownKeysIn(path, val, ft, out)
+	}
+}
+
+func findUnknownKeysIn(path string, val any, t reflect.Type, out *[]string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	rv := reflect.ValueOf(val)
+	switch t.Kind() {
+	case reflect.Struct:
+		if m, ok := val.(map[string]any); ok && t != reflect.TypeOf(time.Time{}) {
+			findUnknownKeys(path, m, t, out)
+		}
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice {
+			for i := 0; i < rv.Len(); i++ {
+				findUnknownKeysIn(fmt.Sprintf("%s[%d]", path, i), rv.Index(i).Interface(), t.Elem(), out)
+			}
+		}
+	case reflect.Map:
+		if m, ok := val.(map[string]any); ok {
+			for k, v := range m {
+				findUnknownKeysIn(path+"."+k, v, t.Elem(), out)
+			}
+		}
+	}
+}
+
+// retiredConfigSections were supported by earlier releases. Keys under them
+// produce a warning instead of failing startup so old config files keep
+// working.
+var retiredConfigSections = map[string]string{
+	"auth":                "authentication is handled by the API gateway",
+	"api_keys":            "API keys are handled by the API gateway",
+	"mira":                "MIRA runs as a standalone service",
+	"grpc.predict_engine": "the predict engine is no longer used",
+}
+
+// unknownFieldErrors reports keys that match no config field, which are
+// usually typos or settings from another version. Keys under a retired
+// section are returned as warnings instead, one per section.
+func unknownFieldErrors(keys []string) (ValidationErrors, []string) {
+	sort.Strings(keys)
+	var errs ValidationErrors
+	var warnings []string
+	warned := map[string]bool{}
+	for _, k := range keys {
+		if section, reason := retiredSection(k); section != "" {
+			if !warned[section] {
+				warned[section] = true
+				warnings = append(warnings, fmt.Sprintf("config section %q is ignored: %s", section, reason))
+			}
+			continue
+		}
+		errs = append(errs, ValidationError{Field: k, Message: "unknown field"})
+	}
+	return errs, warnings
+}
+
+func retiredSection(key string) (string, string) {
+	for section, reason := range retiredConfigSections {
+		if key == section || strings.HasPrefix(key, section+".") {
+			return section, reason
+		}
+	}
+	return "", ""
+}
+
 func validateConfig(cfg *Config) error {
 	var errs ValidationErrors
 
@@ -410,6 +852,25 @@ func validateConfig(cfg *Config) error {
 		})
 	}
 
+	// Subsystems with their own logger, see pkg/logger.Subsystems.
+	validSubsystems := []string{"api", "cache", "correlation", "weaviate"}
+	validSubsystemLog := []string{"debug", "info", "warn", "error"}
+	for name, level := range cfg.LogLevels {
+		if !contains(validSubsystems, name) {
+			errs = append(errs, ValidationError{
+				Field:   "log_levels." + name,
+				Value:   level,
+				Message: fmt.Sprintf("unknown subsystem, must be one of %v", validSubsystems),
+			})
+		} else if !contains(validSubsystemLog, level) {
+			errs = append(errs, ValidationError{
+				Field:   "log_levels." + name,
+				Value:   level,
+				Message: fmt.Sprintf("must be one of %v", validSubsystemLog),
+			})
+		}
+	}
+
 	validEnv := []string{"development", "staging", "production", "test"}
 	if !contains(validEnv, cfg.Environment) {
 		errs = append(errs, ValidationError{
@@ -436,6 +897,41 @@ func validateConfig(cfg *Config) error {
 			Message: "must be at least 1 second",
 		})
 	}
+	if cfg.Cache.TenantID != "" && !ValidTenantID(cfg.Cache.TenantID) {
+		errs = append(errs, ValidationError{
+			Field:   "cache.tenant_id",
+			Value:   cfg.Cache.TenantID,
+			Message: "must be 1-64 letters, digits, '.', '_' or '-'",
+		})
+	}
+	if cfg.Cache.TenantStatsInterval < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "cache.tenant_stats_interval",
+			Value:   cfg.Cache.TenantStatsInterval,
+			Message: "must not be negative",
+		})
+	}
+	// Key values may still be secret references here; they are decoded once
+	// resolved at startup.
+	if enc := cfg.Cache.Encryption; enc.Enabled {
+		active := false
+		for _, k := range enc.Keys {
+			active = active || k.Version == enc.ActiveVersion
+		}
+		if !active {
+			errs = append(errs, ValidationError{
+				Field:   "cache.encryption.active_version",
+				Value:   enc.ActiveVersion,
+				Message: "must match one of cache.encryption.keys",
+			})
+		}
+		if len(enc.Prefixes) == 0 {
+			errs = append(errs, ValidationError{
+				Field:   "cache.encryption.prefixes",
+				Message: "at least one key prefix is required when encryption is enabled",
+			})
+		}
+	}
 
 	// gRPC validations
 	if cfg.GRPC.RCAEngine.Endpoint == "" {
@@ -458,6 +954,208 @@ func validateConfig(cfg *Config) error {
 		})
 	}
 
+	if cfg.HealthScore.RefreshInterval < 0 || cfg.HealthScore.CacheTTL < 0 || cfg.HealthScore.IncidentWindow < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "health_score",
+			Message: "refresh_interval, cache_ttl and incident_window must not be negative",
+		})
+	}
+	if cfg.HealthScore.SLOTarget < 0 || cfg.HealthScore.SLOTarget >= 1 {
+		errs = append(errs, ValidationError{
+			Field:   "health_score.slo_target",
+			Value:   cfg.HealthScore.SLOTarget,
+			Message: "must be at least 0 and below 1 (e.g. 0.999)",
+		})
+	}
+
+	if cfg.ExecutiveSummary.CacheTTL < 0 || cfg.ExecutiveSummary.SnapshotInterval < 0 ||
+		cfg.ExecutiveSummary.SnapshotRetention < 0 || cfg.ExecutiveSummary.TopN < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "executive_summary",
+			Message: "cache_ttl, snapshot_interval, snapshot_retention and top_n must not be negative",
+		})
+	}
+
+	if cfg.StatsSnapshots.Interval < 0 || cfg.StatsSnapshots.Retention < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "stats_snapshots",
+			Message: "interval and retention must not be negative",
+		})
+	}
+
+	if cfg.Capacity.Lookback < 0 || cfg.Capacity.Step < 0 || cfg.Capacity.HorizonDays < 0 || cfg.Capacity.RefreshInterval < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "capacity",
+			Message: "lookback, step, horizon_days and refresh_interval must not be negative",
+		})
+	} else if cfg.Capacity.Step > 0 && cfg.Capacity.Lookback > 0 && cfg.Capacity.Step*2 > cfg.Capacity.Lookback {
+		errs = append(errs, ValidationError{
+			Field:   "capacity.step",
+			Value:   cfg.Capacity.Step.String(),
+			Message: "must fit at least twice into capacity.lookback",
+		})
+	}
+
+	if cfg.KPIHistory.Interval < 0 || cfg.KPIHistory.MaxKPIs < 0 || cfg.KPIHistory.RetentionDays < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "kpi_history",
+			Message: "interval, max_kpis and retention_days must not be negative",
+		})
+	} else if cfg.KPIHistory.Interval > 0 && cfg.KPIHistory.Interval < time.Minute {
+		errs = append(errs, ValidationError{
+			Field:   "kpi_history.interval",
+			Value:   cfg.KPIHistory.Interval.String(),
+			Message: "must be at least 1m",
+		})
+	}
+
+	if cfg.Remediation.ActionTimeout < 0 || cfg.Remediation.AuditLimit < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "remediation",
+			Message: "action_timeout and audit_limit must not be negative",
+		})
+	}
+
+	if cfg.SelfSLO.Window < 0 || cfg.SelfSLO.EvaluationInterval < 0 || cfg.SelfSLO.MinRequests < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "self_slo",
+			Message: "window, evaluation_interval and min_requests must not be negative",
+		})
+	}
+	if h := cfg.InternalHealth; h.Interval < 0 || h.Retention < 0 || (h.Interval > 0 && h.Retention < h.Interval) {
+		errs = append(errs, ValidationError{
+			Field:   "internal_health",
+			Message: "interval and retention must not be negative, and retention must cover at least one interval",
+		})
+	}
+	if cfg.Fleet.HeartbeatInterval < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "fleet.heartbeat_interval",
+			Value:   cfg.Fleet.HeartbeatInterval,
+			Message: "must not be negative",
+		})
+	}
+	for i, t := range cfg.SelfSLO.Targets {
+		field := fmt.Sprintf("self_slo.targets[%d]", i)
+		switch {
+		case t.Endpoint == "" || t.Method == "":
+			errs = append(errs, ValidationError{Field: field, Value: t.Name, Message: "method and endpoint are required"})
+		case t.LatencyThreshold <= 0:
+			errs = append(errs, ValidationError{Field: field + ".latency_threshold", Value: t.LatencyThreshold.String(), Message: "must be positive"})
+		case t.LatencyObjective <= 0 || t.LatencyObjective >= 1 || t.AvailabilityObjective <= 0 || t.AvailabilityObjective >= 1:
+			errs = append(errs, ValidationError{Field: field, Value: t.Name, Message: "latency_objective and availability_objective must be between 0 and 1 (exclusive)"})
+		}
+	}
+
+	if w := cfg.Profiling.Watchdog; w.Enabled {
+		switch {
+		case w.Interval <= 0 || w.CPUProfileDuration <= 0:
+			errs = append(errs, ValidationError{
+				Field:   "profiling.watchdog",
+				Message: "interval and cpu_profile_duration must be positive",
+			})
+		case w.CPUThreshold < 0 || w.CPUThreshold > 1:
+			errs = append(errs, ValidationError{
+				Field:   "profiling.watchdog.cpu_threshold",
+				Value:   fmt.Sprint(w.CPUThreshold),
+				Message: "must be between 0 and 1",
+			})
+		case w.Dir == "":
+			errs = append(errs, ValidationError{
+				Field:   "profiling.watchdog.dir",
+				Message: "is required when the watchdog is enabled",
+			})
+		}
+	}
+
+	if cfg.Export.MemoryMB < 0 || cfg.Export.MaxMB < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "export",
+			Message: "memory_mb and max_mb must not be negative",
+		})
+	} else if cfg.Export.MaxMB > 0 && cfg.Export.MemoryMB > cfg.Export.MaxMB {
+		errs = append(errs, ValidationError{
+			Field:   "export.memory_mb",
+			Value:   cfg.Export.MemoryMB,
+			Message: "must not exceed export.max_mb",
+		})
+	}
+
+	errs = append(errs, validateQoSConfig(&cfg.QoS)...)
+	errs = append(errs, validateKPIDatastores(cfg.KPIDatastores)...)
+	errs = append(errs, validateCallbackConfig(&cfg.Callbacks, cfg.Port)...)
+	errs = append(errs, validateAuditors(cfg.Auditors)...)
+	errs = append(errs, validateComplianceAttestations(cfg.Compliance.Attestations)...)
+	errs = append(errs, validateTenantLabels(cfg.TenantLabels)...)
+	errs = append(errs, validateResidency(cfg)...)
+
+	if cfg.ResultLimits.MaxSeries < 0 || cfg.ResultLimits.MaxLogRows < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "result_limits",
+			Message: "max_series and max_log_rows must not be negative",
+		})
+	}
+
+	if cfg.Retention.Interval < 0 || cfg.Retention.MaxSeriesPerRun < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "retention",
+			Message: "interval and max_series_per_run must not be negative",
+		})
+	}
+
+	if cfg.KPIFormulaLint.Lookback < 0 || cfg.KPIFormulaLint.MaxLabelValues < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "kpi_formula_lint",
+			Message: "lookback and max_label_values must not be negative",
+		})
+	}
+
+	if cfg.Cardinality.MaxLabelValues < 0 || cfg.Cardinality.ScanInterval < 0 || cfg.Cardinality.ScanLookback < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "cardinality",
+			Message: "max_label_values, scan_interval and scan_lookback must not be negative",
+		})
+	}
+	if cfg.Cardinality.TopK < 0 || cfg.Cardinality.TopK > 1000 {
+		errs = append(errs, ValidationError{
+			Field:   "cardinality.top_k",
+			Value:   cfg.Cardinality.TopK,
+			Message: "must be between 0 and 1000",
+		})
+	}
+	if cfg.Cardinality.MaxRangeDays < 0 || cfg.Cardinality.MaxRangeDays > 90 {
+		errs = append(errs, ValidationError{
+			Field:   "cardinality.max_range_days",
+			Value:   cfg.Cardinality.MaxRangeDays,
+			Message: "must be between 0 and 90",
+		})
+	}
+
+	if cfg.QueryTemplates.MaxRange < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "query_templates.max_range",
+			Value:   cfg.QueryTemplates.MaxRange,
+			Message: "must not be negative",
+		})
+	}
+
+	if cfg.UsageTelemetry.ReportInterval < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "usage_telemetry.report_interval",
+			Message: "report_interval must not be negative",
+		})
+	}
+	if u := cfg.UsageTelemetry.CollectorURL; u != "" {
+		if parsed, err := url.Parse(u); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			errs = append(errs, ValidationError{
+				Field:   "usage_telemetry.collector_url",
+				Message: "collector_url must be an http or https URL",
+			})
+		}
+	}
+
+	errs = append(errs, validateTicketingConfig(&cfg.Integrations.Ticketing)...)
+
 	// MariaDB validations
 	errs = append(errs, validateMariaDBConfig(&cfg.MariaDB)...)
 
@@ -470,12 +1168,83 @@ func validateConfig(cfg *Config) error {
 	// Weaviate validations
 	errs = append(errs, validateWeaviateConfig(&cfg.Weaviate)...)
 
+	// Admin token validations
+	errs = append(errs, validateAdminTokens(cfg.Admin.Tokens)...)
+
+	// Fault injection must never be reachable in production
+	if cfg.FaultInjection.Enabled && cfg.Environment == "production" {
+		errs = append(errs, ValidationError{
+			Field:   "fault_injection.enabled",
+			Value:   true,
+			Message: "fault injection cannot be enabled in production",
+		})
+	}
+
+	// Replication validations
+	errs = append(errs, validateReplicationConfig(&cfg.Replication)...)
+
+	if cfg.Secrets.CacheTTL < 0 || cfg.Secrets.RefreshInterval < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "secrets",
+			Message: "cache_ttl and refresh_interval must not be negative",
+		})
+	}
+
+	if cfg.Catalog.DedupInterval < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "catalog.dedup_interval",
+			Value:   cfg.Catalog.DedupInterval,
+			Message: "must not be negative",
+		})
+	}
+	if cfg.Catalog.DedupThreshold < 0 || cfg.Catalog.DedupThreshold > 1 {
+		errs = append(errs, ValidationError{
+			Field:   "catalog.dedup_threshold",
+			Value:   cfg.Catalog.DedupThreshold,
+			Message: "must be between 0 and 1",
+		})
+	}
+	if cfg.Catalog.RefreshLookback < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "catalog.refresh_lookback",
+			Value:   cfg.Catalog.RefreshLookback,
+			Message: "must not be negative",
+		})
+	}
+
 	if len(errs) > 0 {
 		return errs
 	}
 	return nil
 }
 
+func validateReplicationConfig(r *ReplicationConfig) ValidationErrors {
+	var errs ValidationErrors
+	switch r.Role {
+	case "", "primary":
+	case "replica":
+		if r.PrimaryURL == "" {
+			errs = append(errs, ValidationError{
+				Field:   "replication.primary_url",
+				Message: "required when replication.role is replica",
+			})
+		}
+	default:
+		errs = append(errs, ValidationError{
+			Field:   "replication.role",
+			Value:   r.Role,
+			Message: "must be one of [primary replica]",
+		})
+	}
+	if r.HeartbeatInterval < 0 || r.MaxLag < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "replication",
+			Message: "heartbeat_interval and max_lag must not be negative",
+		})
+	}
+	return errs
+}
+
 func validateDatabaseConfig(db *DatabaseConfig) ValidationErrors {
 	var errs ValidationErrors
 
@@ -527,6 +1296,264 @@ func validateDatabaseConfig(db *DatabaseConfig) ValidationErrors {
 		})
 	}
 
+	errs = append(errs, validateDiscoveryConfig("database.victoria_metrics.discovery", &db.VictoriaMetrics.Discovery)...)
+	errs = append(errs, validateDiscoveryConfig("database.victoria_logs.discovery", &db.VictoriaLogs.Discovery)...)
+	errs = append(errs, validateDiscoveryConfig("database.victoria_traces.discovery", &db.VictoriaTraces.Discovery)...)
+
+	return errs
+}
+
+func validateDiscoveryConfig(field string, d *K8sDiscoveryConfig) ValidationErrors {
+	var errs ValidationErrors
+	if !d.Enabled {
+		return errs
+	}
+	switch d.Mode {
+	case "", "dns", "endpointslice":
+	default:
+		errs = append(errs, ValidationError{
+			Field:   field + ".mode",
+			Value:   d.Mode,
+			Message: "must be dns or endpointslice",
+		})
+	}
+	if d.Service == "" {
+		errs = append(errs, ValidationError{
+			Field:   field + ".service",
+			Message: "service is required when discovery is enabled",
+		})
+	}
+	return errs
+}
+
+// qosClasses are the traffic classes accepted under qos.classes.
+var qosClasses = []string{"interactive", "background", "batch"}
+
+func validateQoSConfig(q *QoSConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	if q.MaxConcurrent < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "qos.max_concurrent",
+			Value:   q.MaxConcurrent,
+			Message: "must not be negative",
+		})
+	}
+	for name, c := range q.Classes {
+		field := "qos.classes." + name
+		if !slices.Contains(qosClasses, name) {
+			errs = append(errs, ValidationError{
+				Field:   field,
+				Message: fmt.Sprintf("unknown traffic class (expected one of %s)", strings.Join(qosClasses, ", ")),
+			})
+			continue
+		}
+		if c.MaxConcurrent < 0 || c.QueueTimeout < 0 {
+			errs = append(errs, ValidationError{
+				Field:   field,
+				Message: "max_concurrent and queue_timeout must not be negative",
+			})
+		} else if q.MaxConcurrent > 0 && c.MaxConcurrent > q.MaxConcurrent {
+			errs = append(errs, ValidationError{
+				Field:   field + ".max_concurrent",
+				Value:   c.MaxConcurrent,
+				Message: "must not exceed qos.max_concurrent",
+			})
+		}
+	}
+	if q.MaxConcurrent > 0 {
+		// Interactive queries must always find a slot that lower classes
+		// cannot occupy.
+		background, batch := q.Classes["background"].MaxConcurrent, q.Classes["batch"].MaxConcurrent
+		if background == 0 || batch == 0 || background+batch >= q.MaxConcurrent {
+			errs = append(errs, ValidationError{
+				Field:   "qos.classes",
+				Message: "background and batch max_concurrent must be set and together stay below qos.max_concurrent",
+			})
+		}
+	}
+	return errs
+}
+
+func validateKPIDatastores(stores []KPIDatastoreConfig) ValidationErrors {
+	var errs ValidationErrors
+	seen := map[string]bool{}
+	for i, d := range stores {
+		field := fmt.Sprintf("kpi_datastores[%d]", i)
+		name := strings.ToLower(strings.TrimSpace(d.Name))
+		switch {
+		case name == "":
+			errs = append(errs, ValidationError{Field: field + ".name", Message: "is required"})
+		case name == "victoriametrics" || seen[name]:
+			errs = append(errs, ValidationError{Field: field + ".name", Value: d.Name, Message: "must be unique and not a Victoria datastore name"})
+		}
+		seen[name] = true
+		if d.Type != "clickhouse" && d.Type != "postgresql" {
+			errs = append(errs, ValidationError{Field: field + ".type", Value: d.Type, Message: "must be clickhouse or postgresql"})
+		}
+		if strings.TrimSpace(d.URL) == "" {
+			errs = append(errs, ValidationError{Field: field + ".url", Message: "is required"})
+		}
+		if d.Timeout < 0 {
+			errs = append(errs, ValidationError{Field: field + ".timeout", Value: d.Timeout, Message: "must not be negative"})
+		}
+	}
+	return errs
+}
+
+func validateCallbackConfig(c *CallbackConfig, httpPort int) ValidationErrors {
+	var errs ValidationErrors
+	if c.ResultTTL < 0 || c.MaxMessageBytes < 0 {
+		errs = append(errs, ValidationError{Field: "callbacks", Message: "result_ttl and max_message_bytes must not be negative"})
+	}
+	if !c.Enabled {
+		return errs
+	}
+	if c.Port < 1 || c.Port > 65535 || c.Port == httpPort {
+		errs = append(errs, ValidationError{Field: "callbacks.port", Value: c.Port, Message: "must be between 1 and 65535 and differ from port"})
+	}
+	if len(c.Engines) == 0 {
+		errs = append(errs, ValidationError{Field: "callbacks.engines", Message: "at least one engine is required when callbacks are enabled"})
+	}
+	seen := map[string]bool{}
+	for i, e := range c.Engines {
+		field := fmt.Sprintf("callbacks.engines[%d]", i)
+		name := strings.TrimSpace(e.Name)
+		if name == "" || seen[name] {
+			errs = append(errs, ValidationError{Field: field + ".name", Value: e.Name, Message: "is required and must be unique"})
+		}
+		seen[name] = true
+		if e.Token == "" {
+			errs = append(errs, ValidationError{Field: field + ".token", Message: "is required"})
+		}
+	}
+	return errs
+}
+
+func validateAuditors(auditors []AuditorConfig) ValidationErrors {
+	var errs ValidationErrors
+	seen := map[string]bool{}
+	for i, a := range auditors {
+		field := fmt.Sprintf("auditors[%d]", i)
+		name := strings.TrimSpace(a.Name)
+		if name == "" || seen[name] {
+			errs = append(errs, ValidationError{Field: field + ".name", Value: a.Name, Message: "is required and must be unique"})
+		}
+		seen[name] = true
+		if a.Token == "" {
+			errs = append(errs, ValidationError{Field: field + ".token", Message: "is required"})
+		}
+	}
+	return errs
+}
+
+func validateComplianceAttestations(attestations []ComplianceAttestation) ValidationErrors {
+	var errs ValidationErrors
+	seen := map[string]bool{}
+	for i, a := range attestations {
+		field := fmt.Sprintf("compliance.attestations[%d]", i)
+		control := strings.TrimSpace(a.Control)
+		if control == "" || seen[control] {
+			errs = append(errs, ValidationError{Field: field + ".control", Value: a.Control, Message: "is required and must be unique"})
+		}
+		seen[control] = true
+		if strings.TrimSpace(a.Evidence) == "" {
+			errs = append(errs, ValidationError{Field: field + ".evidence", Message: "is required"})
+		}
+	}
+	return errs
+}
+
+func validateTenantLabels(labels map[string]string) ValidationErrors {
+	var errs ValidationErrors
+	for name, value := range labels {
+		if !labelNamePattern.MatchString(name) {
+			errs = append(errs, ValidationError{Field: "tenant_labels." + name, Value: name, Message: "must be a valid label name"})
+		} else if value == "" {
+			errs = append(errs, ValidationError{Field: "tenant_labels." + name, Message: "must not be empty"})
+		}
+	}
+	return errs
+}
+
+// validateResidency checks the region definitions and, in strict mode, that
+// every configured backend is in the tenant's region.
+func validateResidency(cfg *Config) ValidationErrors {
+	var errs ValidationErrors
+	r := cfg.Residency
+	for name, region := range r.Regions {
+		field := "residency.regions." + name + ".hosts"
+		if len(region.Hosts) == 0 {
+			errs = append(errs, ValidationError{Field: field, Message: "must list at least one host"})
+		}
+		for i, h := range region.Hosts {
+			if !validResidencyHost(h) {
+				errs = append(errs, ValidationError{Field: fmt.Sprintf("%s[%d]", field, i), Value: h, Message: "must be a host name, *.domain wildcard or CIDR range"})
+			}
+		}
+	}
+	if r.Region == "" {
+		return errs
+	}
+	// Viper lowercases map keys, so region names are matched lowercased.
+	if _, ok := r.Regions[strings.ToLower(r.Region)]; !ok {
+		return append(errs, ValidationError{Field: "residency.region", Value: r.Region, Message: "is not defined under residency.regions"})
+	}
+	if !r.Strict {
+		return errs
+	}
+	for _, b := range cfg.BackendHosts() {
+		if !r.InRegion(b.Host) {
+			errs = append(errs, ValidationError{
+				Field:   b.Field,
+				Value:   b.Host,
+				Message: fmt.Sprintf("%s host is outside residency region %s", b.Backend, r.Region),
+			})
+		}
+	}
+	return errs
+}
+
+// validateTicketingConfig checks the ticketing provider settings; nothing is
+// required while no provider is set.
+func validateTicketingConfig(t *TicketingConfig) ValidationErrors {
+	var errs ValidationErrors
+	if t.SyncInterval < 0 || t.Timeout < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "integrations.ticketing",
+			Message: "sync_interval and timeout must not be negative",
+		})
+	}
+	switch strings.ToLower(t.Provider) {
+	case "":
+		return errs
+	case "jira":
+		if t.Project == "" || t.IssueType == "" {
+			errs = append(errs, ValidationError{
+				Field:   "integrations.ticketing.project",
+				Message: "project and issue_type are required for jira",
+			})
+		}
+	case "servicenow":
+		if t.Table == "" {
+			errs = append(errs, ValidationError{
+				Field:   "integrations.ticketing.table",
+				Message: "table is required for servicenow",
+			})
+		}
+	default:
+		errs = append(errs, ValidationError{
+			Field:   "integrations.ticketing.provider",
+			Value:   t.Provider,
+			Message: "must be jira or servicenow",
+		})
+	}
+	if parsed, err := url.Parse(t.BaseURL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		errs = append(errs, ValidationError{
+			Field:   "integrations.ticketing.base_url",
+			Message: "base_url must be an http or https URL",
+		})
+	}
 	return errs
 }
 
@@ -669,6 +1696,61 @@ func validateEngineConfig(e *EngineConfig) ValidationErrors {
 			Message: "must be non-negative",
 		})
 	}
+	if w := e.Scoring; w.Pearson < 0 || w.Spearman < 0 || w.CrossCorr < 0 || w.LagBonus < 0 || w.AnomalyDensity < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "engine.scoring",
+			Value:   w,
+			Message: "weights must be non-negative",
+		})
+	}
+	if e.RunRetention < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "engine.run_retention",
+			Value:   e.RunRetention,
+			Message: "must be non-negative",
+		})
+	}
+	if e.MaxCorrelationMemoryMB < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "engine.max_correlation_memory_mb",
+			Value:   e.MaxCorrelationMemoryMB,
+			Message: "must be non-negative",
+		})
+	}
+
+	if ev := e.Events; ev.Lookback < 0 || ev.BurstThreshold < 0 || ev.Retention < 0 || ev.Boost < 0 || ev.Boost > 1 {
+		errs = append(errs, ValidationError{
+			Field:   "engine.events",
+			Value:   ev,
+			Message: "lookback, burst_threshold and retention must be non-negative and boost within [0,1]",
+		})
+	}
+
+	if lm := e.LabelMatch; lm.DefaultWeight < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "engine.label_match.default_weight",
+			Value:   lm.DefaultWeight,
+			Message: "must be non-negative",
+		})
+	}
+	for _, w := range e.LabelMatch.Weights {
+		if strings.TrimSpace(w.Label) == "" || w.Weight < 0 {
+			errs = append(errs, ValidationError{
+				Field:   "engine.label_match.weights",
+				Value:   w,
+				Message: "each weight needs a label and a non-negative weight",
+			})
+			break
+		}
+	}
+
+	if p := e.Pruning; p.MinCandidates < 0 || p.MinRelativeDelta < 0 || p.MaxCandidates < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "engine.pruning",
+			Value:   p,
+			Message: "min_candidates, min_relative_delta and max_candidates must be non-negative",
+		})
+	}
 
 	// Bucket validations
 	if e.Buckets.CoreWindowSize < 0 {
@@ -692,6 +1774,24 @@ func validateEngineConfig(e *EngineConfig) ValidationErrors {
 			Message: "must be non-negative",
 		})
 	}
+	if e.Buckets.RingStep < 0 {
+		msg := "must be non-negative"
+		if e.Buckets.PreRings > 0 || e.Buckets.PostRings > 0 {
+			msg = "must be positive when pre_rings or post_rings are set (0 uses the default step)"
+		}
+		errs = append(errs, ValidationError{
+			Field:   "engine.buckets.ring_step",
+			Value:   e.Buckets.RingStep,
+			Message: msg,
+		})
+	}
+	if e.MaxWindow > 0 && e.Buckets.CoreWindowSize > e.MaxWindow {
+		errs = append(errs, ValidationError{
+			Field:   "engine.buckets.core_window_size",
+			Value:   e.Buckets.CoreWindowSize,
+			Message: "cannot exceed engine.max_window",
+		})
+	}
 
 	return errs
 }
@@ -762,6 +1862,90 @@ func validateWeaviateConfig(w *WeaviateConfig) ValidationErrors {
 			Message: "must be between 0 and 65535",
 		})
 	}
+	errs = append(errs, validateDiscoveryConfig("weaviate.discovery", &w.Discovery)...)
+	if t := w.Transport; t.MaxIdleConnsPerHost < 0 || t.MaxConnsPerHost < 0 || t.IdleConnTimeout < 0 ||
+		t.InitialBackoff < 0 || t.MaxBackoff < 0 || t.BreakerFailures < 0 || t.BreakerCooldown < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "weaviate.transport",
+			Message: "connection limits, timeouts, backoffs and breaker settings must not be negative",
+		})
+	}
+	if w.Transport.MaxAttempts < 0 || w.Transport.MaxAttempts > 10 {
+		errs = append(errs, ValidationError{
+			Field:   "weaviate.transport.max_attempts",
+			Value:   w.Transport.MaxAttempts,
+			Message: "must be between 0 and 10",
+		})
+	}
+	if w.SchemaPreflight.Timeout < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "weaviate.schema_preflight.timeout",
+			Value:   w.SchemaPreflight.Timeout,
+			Message: "must not be negative",
+		})
+	}
+	if !isWeaviateConsistency(w.Consistency) {
+		errs = append(errs, ValidationError{
+			Field:   "weaviate.consistency",
+			Value:   w.Consistency,
+			Message: "must be one of ONE, QUORUM, ALL",
+		})
+	}
+	for name, c := range w.Classes {
+		if c.ReplicationFactor < 0 {
+			errs = append(errs, ValidationError{
+				Field:   "weaviate.classes." + name + ".replication_factor",
+				Value:   c.ReplicationFactor,
+				Message: "must not be negative",
+			})
+		}
+		if !isWeaviateConsistency(c.Consistency) {
+			errs = append(errs, ValidationError{
+				Field:   "weaviate.classes." + name + ".consistency",
+				Value:   c.Consistency,
+				Message: "must be one of ONE, QUORUM, ALL",
+			})
+		}
+	}
+
+	return errs
+}
+
+// labelNamePattern is the Prometheus label name syntax.
+var labelNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// tenantIDPattern restricts tenant IDs to characters that are safe inside
+// cache keys and SCAN patterns.
+var tenantIDPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+
+// ValidTenantID reports whether id can be used as a cache namespace.
+func ValidTenantID(id string) bool {
+	return tenantIDPattern.MatchString(id)
+}
+
+// isWeaviateConsistency reports whether level is empty or a consistency
+// level Weaviate accepts.
+func isWeaviateConsistency(level string) bool {
+	switch strings.ToUpper(level) {
+	case "", "ONE", "QUORUM", "ALL":
+		return true
+	}
+	return false
+}
 
+func validateAdminTokens(tokens []AdminTokenConfig) ValidationErrors {
+	var errs ValidationErrors
+	seen := map[string]bool{}
+	for i, t := range tokens {
+		field := fmt.Sprintf("admin.tokens[%d]", i)
+		name := strings.TrimSpace(t.Name)
+		if name == "" || seen[name] {
+			errs = append(errs, ValidationError{Field: field + ".name", Value: t.Name, Message: "is required and must be unique"})
+		}
+		seen[name] = true
+		if t.Token == "" {
+			errs = append(errs, ValidationError{Field: field + ".token", Message: "is required"})
+		}
+	}
 	return errs
 }
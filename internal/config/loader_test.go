@@ -0,0 +1,244 @@
+package config
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func loadYAML(t *testing.T, yaml string) (*Config, error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(yaml), 0o600))
+	t.Setenv("CONFIG_PATH", path)
+	return Load()
+}
+
+func TestLoad_StrictReport(t *testing.T) {
+	_, err := loadYAML(t, `
+port: not-a-port
+databse:
+  victoria_metrics:
+    endpoints: ["http://vm:8428"]
+engine:
+  max_window: 1m
+  buckets:
+    core_window_size: 5m
+    pre_rings: 2
+    ring_step: -15s
+`)
+	require.Error(t, err)
+
+	var loadErr *LoadError
+	require.True(t, errors.As(err, &loadErr))
+	fields := map[string]bool{}
+	for _, ve := range loadErr.Errors {
+		fields[ve.Field] = true
+	}
+	for _, f := range []string{"port", "databse", "engine.buckets.ring_step", "engine.buckets.core_window_size"} {
+		assert.True(t, fields[f], "expected a problem reported for %s, got %v", f, loadErr.Errors)
+	}
+
+	var verrs ValidationErrors
+	require.True(t, errors.As(err, &verrs), "LoadError should unwrap to ValidationErrors")
+	report := loadErr.Report()
+	assert.Contains(t, report, "databse: unknown field")
+	assert.Contains(t, report, "engine.buckets.ring_step: must be positive when pre_rings or post_rings are set")
+}
+
+func TestLoad_RetiredSectionsWarn(t *testing.T) {
+	cfg, err := loadYAML(t, `
+auth:
+  ldap:
+    enabled: false
+grpc:
+  predict_engine:
+    endpoint: "predict:9091"
+`)
+	require.NoError(t, err)
+	require.Len(t, cfg.Warnings, 2)
+	assert.Contains(t, cfg.Warnings[0], `"auth"`)
+	assert.Contains(t, cfg.Warnings[1], `"grpc.predict_engine"`)
+}
+
+func TestLoad_EnvironmentOverlay(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "config.yaml")
+	overlay := filepath.Join(dir, "config.staging.yaml")
+	require.NoError(t, os.WriteFile(base, []byte(`
+log_level: info
+cache:
+  nodes: ["base:6379"]
+  ttl: 300
+cors:
+  allowed_origins: ["https://a", "https://b"]
+`), 0o600))
+	require.NoError(t, os.WriteFile(overlay, []byte(`
+log_level: debug
+cache:
+  ttl: 60
+cors:
+  allowed_origins: ["https://staging"]
+`), 0o600))
+	t.Setenv("CONFIG_PATH", base)
+	t.Setenv("CONFIG_OVERLAYS", overlay)
+	t.Setenv("MIRADOR_PORT", "9000")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, []string{base, overlay}, cfg.Sources)
+	assert.Equal(t, "debug", cfg.LogLevel)
+	assert.Equal(t, 60, cfg.Cache.TTL, "overlay value wins")
+	assert.Equal(t, []string{"base:6379"}, cfg.Cache.Nodes, "maps merge key by key")
+	assert.Equal(t, []string{"https://staging"}, cfg.CORS.AllowedOrigins, "lists replace")
+	assert.Equal(t, 9000, cfg.Port, "environment variables win over files")
+}
+
+func TestApplyOverrides(t *testing.T) {
+	cfg, err := loadYAML(t, `
+mariadb:
+  enabled: false
+weaviate:
+  api_key: "s3cret"
+unified_query:
+  cache_ttl: 5m
+`)
+	require.NoError(t, err)
+
+	effective, err := ApplyOverrides(cfg, map[string]any{
+		"engine.min_correlation":      0.7,
+		"unified_query.cache_ttl":     "1m",
+		"unified_query.default_limit": float64(250), // as decoded from JSON
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0.7, effective.Engine.MinCorrelation)
+	assert.Equal(t, time.Minute, effective.UnifiedQuery.CacheTTL)
+	assert.Equal(t, 250, effective.UnifiedQuery.DefaultLimit)
+	assert.Equal(t, "s3cret", effective.Weaviate.APIKey)
+	assert.Equal(t, 5*time.Minute, cfg.UnifiedQuery.CacheTTL, "base is not modified")
+	assert.Same(t, cfg, effective.Static())
+
+	again, err := ApplyOverrides(effective, map[string]any{"engine.min_correlation": 0.9})
+	require.NoError(t, err)
+	assert.Equal(t, 0.9, again.Engine.MinCorrelation)
+	assert.Equal(t, 5*time.Minute, again.UnifiedQuery.CacheTTL, "overrides replace, not stack")
+
+	_, err = ApplyOverrides(cfg, map[string]any{
+		"cache.ttl":              1,
+		"tenant_labels.env":      "dev",
+		"weaviate.api_key":       "other",
+		"engine.min_corelation":  0.5,
+		"engine.min_correlation": 2,
+	})
+	var verrs ValidationErrors
+	require.True(t, errors.As(err, &verrs), "got %v", err)
+	fields := map[string]bool{}
+	for _, ve := range verrs {
+		fields[ve.Field] = true
+	}
+	for _, f := range []string{"cache.ttl", "tenant_labels.env", "weaviate.api_key", "engine.min_corelation"} {
+		assert.True(t, fields[f], "expected a problem reported for %s, got %v", f, verrs)
+	}
+
+	redacted := RedactedSettings(effective)
+	assert.Equal(t, "[REDACTED]", redacted["weaviate"].(map[string]any)["api_key"])
+	assert.Equal(t, "1m0s", redacted["unified_query"].(map[string]any)["cache_ttl"])
+	assert.Equal(t, "s3cret", effective.Weaviate.APIKey)
+}
+
+// TestLoad_ShippedConfigs loads every config the repo ships, so a key the
+// strict loader rejects cannot reach a release.
+func TestLoad_ShippedConfigs(t *testing.T) {
+	root := filepath.Join("..", "..")
+	load := func(t *testing.T, name string, files ...string) {
+		t.Helper()
+		t.Setenv("CONFIG_PATH", files[0])
+		t.Setenv("CONFIG_OVERLAYS", strings.Join(files[1:], ","))
+		if _, err := Load(); err != nil {
+			var loadErr *LoadError
+			if errors.As(err, &loadErr) {
+				t.Fatalf("%s is rejected:\n%s", name, loadErr.Report())
+			}
+			t.Fatalf("%s is rejected: %v", name, err)
+		}
+	}
+	writeConfig := func(t *testing.T, doc any) string {
+		t.Helper()
+		out, err := yaml.Marshal(doc)
+		require.NoError(t, err)
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(path, out, 0o600))
+		return path
+	}
+
+	base := filepath.Join(root, "configs", "config.yaml")
+	overlays, err := filepath.Glob(filepath.Join(root, "configs", "config.*.yaml"))
+	require.NoError(t, err)
+	t.Run("configs/config.yaml", func(t *testing.T) { load(t, base, base) })
+	for _, overlay := range overlays {
+		name := filepath.ToSlash(strings.TrimPrefix(overlay, root+string(filepath.Separator)))
+		// Loaded over config.yaml, and alone as the localdev compose file does.
+		t.Run(name, func(t *testing.T) { load(t, name, base, overlay) })
+		t.Run(name+"/alone", func(t *testing.T) { load(t, name, overlay) })
+	}
+
+	// Helm values: the mirador section is rendered as config.yaml, with the
+	// Valkey subchart wired in as the configmap template does.
+	raw, err := os.ReadFile(filepath.Join(root, "deployments", "chart", "values.yaml"))
+	require.NoError(t, err)
+	var values struct {
+		Mirador map[string]any `yaml:"mirador"`
+		Valkey  struct {
+			Enabled bool `yaml:"enabled"`
+		} `yaml:"valkey"`
+	}
+	require.NoError(t, yaml.Unmarshal(raw, &values))
+	require.NotEmpty(t, values.Mirador)
+	if values.Valkey.Enabled {
+		cache, _ := values.Mirador["cache"].(map[string]any)
+		if cache == nil {
+			cache = map[string]any{}
+		}
+		cache["nodes"] = []string{"release-valkey-headless:6379"}
+		values.Mirador["cache"] = cache
+	}
+	t.Run("deployments/chart/values.yaml", func(t *testing.T) {
+		load(t, "deployments/chart/values.yaml", writeConfig(t, values.Mirador))
+	})
+
+	// Manifests: every ConfigMap carrying a config.yaml.
+	manifests, err := filepath.Glob(filepath.Join(root, "deployments", "k8s", "*.yaml"))
+	require.NoError(t, err)
+	for _, manifest := range manifests {
+		name := filepath.ToSlash(strings.TrimPrefix(manifest, root+string(filepath.Separator)))
+		f, err := os.Open(manifest)
+		require.NoError(t, err)
+		dec := yaml.NewDecoder(f)
+		for {
+			var doc struct {
+				Kind string            `yaml:"kind"`
+				Data map[string]string `yaml:"data"`
+			}
+			if err := dec.Decode(&doc); err != nil {
+				require.ErrorIs(t, err, io.EOF, name)
+				break
+			}
+			if cfg, ok := doc.Data["config.yaml"]; ok && doc.Kind == "ConfigMap" {
+				t.Run(name, func(t *testing.T) {
+					path := filepath.Join(t.TempDir(), "config.yaml")
+					require.NoError(t, os.WriteFile(path, []byte(cfg), 0o600))
+					load(t, name, path)
+				})
+			}
+		}
+		f.Close()
+	}
+}
@@ -0,0 +1,180 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Precedence lists the configuration layers from lowest to highest priority.
+var Precedence = []string{
+	"defaults",
+	"base file (config.yaml or CONFIG_PATH)",
+	"environment overlay (config.<env>.yaml or CONFIG_OVERLAYS)",
+	"environment variables",
+	"tenant overrides",
+}
+
+// nonOverridableSections are read at startup before the tenant override
+// store is reachable, so overrides could never take effect for them.
+// tenant_labels and residency are the tenant's data boundary and must not be
+// self-served.
+var nonOverridableSections = []string{"environment", "log_level", "cache", "secrets", "fault_injection", "tenant_labels", "residency"}
+
+// ApplyOverrides returns a copy of base with tenant overrides applied on top.
+// Overrides are keyed by dotted config path, e.g. {"engine.min_correlation":
+// 0.7}; a path naming a section replaces its keys one by one, a list is
+// replaced whole. The result is decoded and validated like a loaded config,
+// and secrets cannot be changed. base may itself carry overrides; they are
+// replaced, not stacked.
+func ApplyOverrides(base *Config, overrides map[string]any) (*Config, error) {
+	base = base.Static()
+	if len(overrides) == 0 {
+		return base, nil
+	}
+
+	var errs ValidationErrors
+	v := viper.New()
+	if err := v.MergeConfigMap(Settings(base)); err != nil {
+		return nil, fmt.Errorf("failed to load base config: %w", err)
+	}
+	paths := make([]string, 0, len(overrides))
+	for p := range overrides {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		key := strings.ToLower(strings.TrimSpace(p))
+		if section := nonOverridableSection(key); section != "" {
+			errs = append(errs, ValidationError{Field: p, Message: fmt.Sprintf("%s cannot be overridden at runtime", section)})
+			continue
+		}
+		v.Set(key, overrides[p])
+	}
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		errs = append(errs, decodeValidationErrors(err)...)
+	}
+	var unknown []string
+	findUnknownKeys("", v.AllSettings(), reflect.TypeOf(cfg), &unknown)
+	sort.Strings(unknown)
+	for _, k := range unknown {
+		errs = append(errs, ValidationError{Field: k, Message: "unknown field"})
+	}
+	if len(errs) == 0 {
+		if err := validateConfig(&cfg); err != nil {
+			var verrs ValidationErrors
+			if !errors.As(err, &verrs) {
+				return nil, err
+			}
+			errs = append(errs, verrs...)
+		}
+	}
+
+	baseSecrets := secretFields(base)
+	for field, ptr := range secretFields(&cfg) {
+		if old, ok := baseSecrets[field]; (ok && *old != *ptr) || (!ok && *ptr != "") {
+			errs = append(errs, ValidationError{Field: field, Message: "secrets cannot be overridden at runtime"})
+		}
+	}
+	if len(errs) > 0 {
+		sort.SliceStable(errs, func(i, j int) bool { return errs[i].Field < errs[j].Field })
+		return nil, errs
+	}
+
+	cfg.Warnings = base.Warnings
+	cfg.Sources = base.Sources
+	cfg.Overrides = overrides
+	cfg.static = base
+	return &cfg, nil
+}
+
+// Static returns the configuration before tenant overrides were applied.
+func (c *Config) Static() *Config {
+	if c.static != nil {
+		return c.static
+	}
+	return c
+}
+
+func nonOverridableSection(key string) string {
+	for _, s := range nonOverridableSections {
+		if key == s || strings.HasPrefix(key, s+".") {
+			return s
+		}
+	}
+	return ""
+}
+
+// Settings returns cfg in config-file shape: keys as in YAML, durations as
+// strings.
+func Settings(cfg *Config) map[string]any {
+	return settingsValue(reflect.ValueOf(cfg).Elem()).(map[string]any)
+}
+
+// RedactedSettings is Settings with every non-empty secret replaced.
+func RedactedSettings(cfg *Config) map[string]any {
+	c := *cfg
+	c.Database.MetricsSources = append([]VictoriaMetricsConfig(nil), cfg.Database.MetricsSources...)
+	c.Database.LogsSources = append([]VictoriaLogsConfig(nil), cfg.Database.LogsSources...)
+	c.Database.TracesSources = append([]VictoriaTracesConfig(nil), cfg.Database.TracesSources...)
+	for _, ptr := range secretFields(&c) {
+		if *ptr != "" {
+			*ptr = "[REDACTED]"
+		}
+	}
+	return Settings(&c)
+}
+
+func settingsValue(v reflect.Value) any {
+	if v.Type() == reflect.TypeOf(time.Duration(0)) {
+		return time.Duration(v.Int()).String()
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return settingsValue(v.Elem())
+	case reflect.Struct:
+		out := map[string]any{}
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			name, _, _ := strings.Cut(f.Tag.Get("mapstructure"), ",")
+			if name == "-" || !f.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			out[strings.ToLower(name)] = settingsValue(v.Field(i))
+		}
+		return out
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		out := make([]any, v.Len())
+		for i := range out {
+			out[i] = settingsValue(v.Index(i))
+		}
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		out := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out[fmt.Sprint(iter.Key().Interface())] = settingsValue(iter.Value())
+		}
+		return out
+	}
+	return v.Interface()
+}
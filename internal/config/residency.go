@@ -0,0 +1,138 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// BackendHost is one host a configured backend reaches.
+type BackendHost struct {
+	Backend string `json:"backend"`
+	Field   string `json:"field"`
+	Host    string `json:"host"`
+}
+
+// BackendHosts lists the hosts of every configured backend holding or
+// serving the tenant's data, in config order. Discovery-enabled sources
+// contribute their discovery Service name.
+func (c *Config) BackendHosts() []BackendHost {
+	var out []BackendHost
+	add := func(backend, field string, endpoints []string, d K8sDiscoveryConfig) {
+		for i, e := range endpoints {
+			if h := EndpointHost(e); h != "" {
+				out = append(out, BackendHost{backend, fmt.Sprintf("%s.endpoints[%d]", field, i), h})
+			}
+		}
+		if d.Enabled && d.Service != "" {
+			out = append(out, BackendHost{backend, field + ".discovery.service", EndpointHost(d.Service)})
+		}
+	}
+
+	db := c.Database
+	add("victoria_metrics", "database.victoria_metrics", db.VictoriaMetrics.Endpoints, db.VictoriaMetrics.Discovery)
+	for i, src := range db.MetricsSources {
+		add("victoria_metrics", fmt.Sprintf("database.metrics_sources[%d]", i), src.Endpoints, src.Discovery)
+	}
+	add("victoria_logs", "database.victoria_logs", db.VictoriaLogs.Endpoints, db.VictoriaLogs.Discovery)
+	for i, src := range db.LogsSources {
+		add("victoria_logs", fmt.Sprintf("database.logs_sources[%d]", i), src.Endpoints, src.Discovery)
+	}
+	add("victoria_traces", "database.victoria_traces", db.VictoriaTraces.Endpoints, db.VictoriaTraces.Discovery)
+	for i, src := range db.TracesSources {
+		add("victoria_traces", fmt.Sprintf("database.traces_sources[%d]", i), src.Endpoints, src.Discovery)
+	}
+	if c.Weaviate.Enabled {
+		if c.Weaviate.Host != "" {
+			out = append(out, BackendHost{"weaviate", "weaviate.host", EndpointHost(c.Weaviate.Host)})
+		}
+		add("weaviate", "weaviate", nil, c.Weaviate.Discovery)
+	}
+	for i, n := range c.Cache.Nodes {
+		if h := EndpointHost(n); h != "" {
+			out = append(out, BackendHost{"valkey", fmt.Sprintf("cache.nodes[%d]", i), h})
+		}
+	}
+	if c.MariaDB.Enabled && c.MariaDB.Host != "" {
+		out = append(out, BackendHost{"mariadb", "mariadb.host", EndpointHost(c.MariaDB.Host)})
+	}
+	for i, d := range c.KPIDatastores {
+		if h := EndpointHost(d.URL); h != "" {
+			out = append(out, BackendHost{"kpi_datastore." + d.Name, fmt.Sprintf("kpi_datastores[%d].url", i), h})
+		}
+	}
+	return out
+}
+
+// EndpointHost returns the lowercased host of a URL, host:port or bare host.
+func EndpointHost(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if strings.Contains(raw, "://") {
+		if u, err := url.Parse(raw); err == nil {
+			return strings.ToLower(u.Hostname())
+		}
+		return ""
+	}
+	if h, _, err := net.SplitHostPort(raw); err == nil {
+		return strings.ToLower(h)
+	}
+	return strings.ToLower(strings.Trim(raw, "[]"))
+}
+
+// InRegion reports whether host belongs to the tenant's region. It is
+// always true when no region is set.
+func (r ResidencyConfig) InRegion(host string) bool {
+	if r.Region == "" {
+		return true
+	}
+	return residencyHostMatches(r.Regions[strings.ToLower(r.Region)].Hosts, host)
+}
+
+// RegionOf returns the first region, in name order, whose hosts include
+// host, or "" when none does.
+func (r ResidencyConfig) RegionOf(host string) string {
+	names := make([]string, 0, len(r.Regions))
+	for name := range r.Regions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if residencyHostMatches(r.Regions[name].Hosts, host) {
+			return name
+		}
+	}
+	return ""
+}
+
+func residencyHostMatches(patterns []string, host string) bool {
+	host = strings.ToLower(host)
+	ip := net.ParseIP(host)
+	for _, p := range patterns {
+		p = strings.ToLower(strings.TrimSpace(p))
+		switch {
+		case strings.Contains(p, "/"):
+			if _, n, err := net.ParseCIDR(p); err == nil && ip != nil && n.Contains(ip) {
+				return true
+			}
+		case strings.HasPrefix(p, "*."):
+			if strings.HasSuffix(host, p[1:]) {
+				return true
+			}
+		case p == host:
+			return true
+		}
+	}
+	return false
+}
+
+func validResidencyHost(p string) bool {
+	p = strings.TrimSpace(p)
+	if strings.Contains(p, "/") {
+		_, _, err := net.ParseCIDR(p)
+		return err == nil
+	}
+	p = strings.TrimPrefix(p, "*.")
+	return p != "" && !strings.ContainsAny(p, "*:/ ")
+}
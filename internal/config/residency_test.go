@@ -0,0 +1,72 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func residencyTestConfig() *Config {
+	cfg := &Config{}
+	cfg.Database.VictoriaMetrics.Endpoints = []string{"http://vmselect.eu.internal:8481"}
+	cfg.Database.LogsSources = []VictoriaLogsConfig{{Endpoints: []string{"https://vlogs.us.internal"}}}
+	cfg.Database.VictoriaTraces.Discovery = K8sDiscoveryConfig{Enabled: true, Service: "vtraces.eu.internal"}
+	cfg.Weaviate = WeaviateConfig{Enabled: true, Host: "weaviate.eu.internal", Port: 8080}
+	cfg.Cache.Nodes = []string{"10.20.1.5:6379"}
+	cfg.KPIDatastores = []KPIDatastoreConfig{{Name: "warehouse", URL: "postgres://pg.eu.internal:5432/kpis"}}
+	cfg.Residency = ResidencyConfig{
+		Region: "EU",
+		Strict: true,
+		Regions: map[string]ResidencyRegionConfig{
+			"eu": {Hosts: []string{"*.eu.internal", "10.20.0.0/16"}},
+			"us": {Hosts: []string{"*.us.internal"}},
+		},
+	}
+	return cfg
+}
+
+func TestBackendHosts(t *testing.T) {
+	cfg := residencyTestConfig()
+	hosts := map[string]string{}
+	for _, b := range cfg.BackendHosts() {
+		hosts[b.Field] = b.Host
+	}
+	assert.Equal(t, map[string]string{
+		"database.victoria_metrics.endpoints[0]":     "vmselect.eu.internal",
+		"database.logs_sources[0].endpoints[0]":      "vlogs.us.internal",
+		"database.victoria_traces.discovery.service": "vtraces.eu.internal",
+		"weaviate.host":         "weaviate.eu.internal",
+		"cache.nodes[0]":        "10.20.1.5",
+		"kpi_datastores[0].url": "pg.eu.internal",
+	}, hosts)
+
+	r := cfg.Residency
+	assert.True(t, r.InRegion("10.20.1.5"))
+	assert.False(t, r.InRegion("vlogs.us.internal"))
+	assert.False(t, r.InRegion("eu.internal.evil.com"))
+	assert.Equal(t, "us", r.RegionOf("vlogs.us.internal"))
+	assert.Empty(t, r.RegionOf("example.com"))
+}
+
+func TestValidateResidency(t *testing.T) {
+	cfg := residencyTestConfig()
+	errs := validateResidency(cfg)
+	if assert.Len(t, errs, 1) {
+		assert.Equal(t, "database.logs_sources[0].endpoints[0]", errs[0].Field)
+		assert.Contains(t, errs[0].Message, "outside residency region EU")
+	}
+
+	// Non-strict mode only reports violations in the audit.
+	cfg.Residency.Strict = false
+	assert.Empty(t, validateResidency(cfg))
+
+	cfg.Residency.Region = "apac"
+	cfg.Residency.Regions["us"] = ResidencyRegionConfig{Hosts: []string{"vm:8428", "10.0.0.0/33"}}
+	fields := map[string]bool{}
+	for _, e := range validateResidency(cfg) {
+		fields[e.Field] = true
+	}
+	assert.True(t, fields["residency.region"])
+	assert.True(t, fields["residency.regions.us.hosts[0]"])
+	assert.True(t, fields["residency.regions.us.hosts[1]"])
+}
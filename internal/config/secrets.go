@@ -1,9 +1,11 @@
 package config
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 )
 
@@ -35,6 +37,139 @@ func LoadSecrets(config *Config) error {
 	return nil
 }
 
+// SecretResolver resolves external secret references such as
+// "vault:mirador/weaviate#api_key". Implemented by secrets.Resolver.
+type SecretResolver interface {
+	IsRef(value string) bool
+	Resolve(ctx context.Context, ref string) (string, error)
+}
+
+// SecretRefs maps config field paths to the reference each was resolved from,
+// so rotation of a reference can be traced back to the affected fields.
+type SecretRefs map[string]string
+
+// secretFields lists the secret-bearing config fields by path.
+func secretFields(cfg *Config) map[string]*string {
+	fields := map[string]*string{
+		"weaviate.api_key":                      &cfg.Weaviate.APIKey,
+		"cache.password":                        &cfg.Cache.Password,
+		"mariadb.password":                      &cfg.MariaDB.Password,
+		"integrations.email.password":           &cfg.Integrations.Email.Password,
+		"integrations.ticketing.api_token":      &cfg.Integrations.Ticketing.APIToken,
+		"integrations.ticketing.webhook_secret": &cfg.Integrations.Ticketing.WebhookSecret,
+		"catalog.refresh_webhook_secret":        &cfg.Catalog.RefreshWebhookSecret,
+		"profiling.token":                       &cfg.Profiling.Token,
+		"export.anonymization_hash_key":         &cfg.Export.AnonymizationHashKey,
+		"database.victoria_metrics.password":    &cfg.Database.VictoriaMetrics.Password,
+		"database.victoria_logs.password":       &cfg.Database.VictoriaLogs.Password,
+		"database.victoria_traces.password":     &cfg.Database.VictoriaTraces.Password,
+	}
+	for i := range cfg.Database.MetricsSources {
+		fields[fmt.Sprintf("database.metrics_sources[%d].password", i)] = &cfg.Database.MetricsSources[i].Password
+	}
+	for i := range cfg.Database.LogsSources {
+		fields[fmt.Sprintf("database.logs_sources[%d].password", i)] = &cfg.Database.LogsSources[i].Password
+	}
+	for i := range cfg.Database.TracesSources {
+		fields[fmt.Sprintf("database.traces_sources[%d].password", i)] = &cfg.Database.TracesSources[i].Password
+	}
+	for i := range cfg.KPIDatastores {
+		fields[fmt.Sprintf("kpi_datastores[%d].password", i)] = &cfg.KPIDatastores[i].Password
+	}
+	for i := range cfg.Callbacks.Engines {
+		fields[fmt.Sprintf("callbacks.engines[%d].token", i)] = &cfg.Callbacks.Engines[i].Token
+	}
+	for i := range cfg.Cache.Encryption.Keys {
+		fields[fmt.Sprintf("cache.encryption.keys[%d].key", i)] = &cfg.Cache.Encryption.Keys[i].Key
+	}
+	for i := range cfg.Admin.Tokens {
+		fields[fmt.Sprintf("admin.tokens[%d].token", i)] = &cfg.Admin.Tokens[i].Token
+	}
+	for i := range cfg.Auditors {
+		fields[fmt.Sprintf("auditors[%d].token", i)] = &cfg.Auditors[i].Token
+	}
+	return fields
+}
+
+// DecodedKeys returns the KEKs by version.
+func (c CacheEncryptionConfig) DecodedKeys() (map[int][]byte, error) {
+	keys := make(map[int][]byte, len(c.Keys))
+	for _, k := range c.Keys {
+		b, err := base64.StdEncoding.DecodeString(k.Key)
+		if err != nil {
+			return nil, fmt.Errorf("key version %d: %w", k.Version, err)
+		}
+		if len(b) != 32 {
+			return nil, fmt.Errorf("key version %d: must decode to 32 bytes, got %d", k.Version, len(b))
+		}
+		if _, dup := keys[k.Version]; dup {
+			return nil, fmt.Errorf("key version %d: duplicate", k.Version)
+		}
+		keys[k.Version] = b
+	}
+	return keys, nil
+}
+
+// PlaintextSecrets returns the sorted paths of the non-empty secret fields
+// that were not resolved from an external reference.
+func PlaintextSecrets(cfg *Config, refs SecretRefs) []string {
+	var out []string
+	for field, ptr := range secretFields(cfg) {
+		if _, ok := refs[field]; !ok && *ptr != "" {
+			out = append(out, field)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// SecretValues returns the non-empty values of the secret config fields, for
+// scrubbing from logs and error messages.
+func SecretValues(cfg *Config) []string {
+	var out []string
+	for _, ptr := range secretFields(cfg) {
+		if *ptr != "" {
+			out = append(out, *ptr)
+		}
+	}
+	return out
+}
+
+// ResolveSecretRefs replaces secret references in cfg with their resolved
+// values and returns which fields were bound to which reference. Fields
+// holding plain values are left untouched.
+func ResolveSecretRefs(ctx context.Context, cfg *Config, r SecretResolver) (SecretRefs, error) {
+	refs := SecretRefs{}
+	for field, ptr := range secretFields(cfg) {
+		if !r.IsRef(*ptr) {
+			continue
+		}
+		value, err := r.Resolve(ctx, *ptr)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", field, err)
+		}
+		refs[field] = *ptr
+		*ptr = value
+	}
+	return refs, nil
+}
+
+// FieldsFor returns the sorted config fields bound to any of refs.
+func (s SecretRefs) FieldsFor(refs []string) []string {
+	want := make(map[string]bool, len(refs))
+	for _, r := range refs {
+		want[r] = true
+	}
+	var fields []string
+	for field, ref := range s {
+		if want[ref] {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
 // EncodeSecret base64 encodes a secret for storage
 func EncodeSecret(secret string) string {
 	return base64.StdEncoding.EncodeToString([]byte(secret))
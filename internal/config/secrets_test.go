@@ -0,0 +1,76 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/redact"
+)
+
+type mapResolver map[string]string
+
+func (m mapResolver) IsRef(v string) bool { return strings.HasPrefix(v, "vault:") }
+
+func (m mapResolver) Resolve(_ context.Context, ref string) (string, error) { return m[ref], nil }
+
+func TestResolveSecretRefs(t *testing.T) {
+	cfg := validConfig()
+	cfg.Weaviate.APIKey = "vault:mirador/weaviate#api_key"
+	cfg.Cache.Password = "plain"
+	cfg.Database.MetricsSources = []VictoriaMetricsConfig{{Password: "vault:vm#pw"}}
+
+	r := mapResolver{"vault:mirador/weaviate#api_key": "wv-key", "vault:vm#pw": "vm-pw"}
+	refs, err := ResolveSecretRefs(context.Background(), cfg, r)
+	require.NoError(t, err)
+
+	assert.Equal(t, "wv-key", cfg.Weaviate.APIKey)
+	assert.Equal(t, "plain", cfg.Cache.Password)
+	assert.Equal(t, "vm-pw", cfg.Database.MetricsSources[0].Password)
+	assert.Len(t, refs, 2)
+	assert.Equal(t, []string{"database.metrics_sources[0].password"}, refs.FieldsFor([]string{"vault:vm#pw"}))
+}
+
+func TestSecretValuesNeverLogged(t *testing.T) {
+	cfg := validConfig()
+	cfg.Database.MetricsSources = []VictoriaMetricsConfig{{}}
+	cfg.Database.LogsSources = []VictoriaLogsConfig{{}}
+	cfg.Database.TracesSources = []VictoriaTracesConfig{{}}
+	cfg.KPIDatastores = []KPIDatastoreConfig{{}}
+	cfg.Admin.Tokens = []AdminTokenConfig{{}}
+	cfg.Auditors = []AuditorConfig{{}}
+	i := 0
+	for _, ptr := range secretFields(cfg) {
+		i++
+		*ptr = fmt.Sprintf("s3cr3t-value-%02d", i)
+	}
+	values := SecretValues(cfg)
+	require.Len(t, values, i)
+	redact.Register(values...)
+
+	var buf bytes.Buffer
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(&buf), zapcore.DebugLevel)
+	log := logger.NewWithCore("debug", core)
+	for _, v := range values {
+		log.Info("connecting with "+v, "dsn", "mysql://mirador:"+v+"@db:3306/mirador")
+		log.Error("request failed", "error", errors.New("auth rejected for "+v), "hint", v)
+		log.Debug("weaviate request", "headers", map[string]string{"X-Api-Key": v})
+	}
+	log.Warn("loaded config", "config", cfg)
+	log.ZapLogger().Info("zap logger", zap.String("value", values[0]))
+
+	out := buf.String()
+	require.Contains(t, out, redact.Placeholder)
+	for _, v := range values {
+		assert.NotContains(t, out, v)
+	}
+}
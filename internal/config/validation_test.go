@@ -94,6 +94,56 @@ func TestValidateConfig_InvalidLogLevel(t *testing.T) {
 	assert.Contains(t, err.Error(), "log_level")
 }
 
+func TestValidateConfig_SubsystemLogLevels(t *testing.T) {
+	cfg := validConfig()
+	cfg.LogLevels = map[string]string{"correlation": "debug", "cache": "warn"}
+	require.NoError(t, validateConfig(cfg))
+
+	cfg.LogLevels = map[string]string{"rbac": "debug", "api": "verbose"}
+	err := validateConfig(cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "log_levels.rbac")
+	assert.Contains(t, err.Error(), "log_levels.api")
+}
+
+func TestValidateConfig_Callbacks(t *testing.T) {
+	cfg := validConfig()
+	cfg.Callbacks = CallbackConfig{Enabled: true, Port: 9091, Engines: []CallbackEngineConfig{{Name: "anomaly", Token: "t0ken"}}}
+	require.NoError(t, validateConfig(cfg))
+
+	cfg.Callbacks.Port = cfg.Port
+	cfg.Callbacks.Engines = append(cfg.Callbacks.Engines, CallbackEngineConfig{Name: "anomaly"})
+	err := validateConfig(cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "callbacks.port")
+	assert.Contains(t, err.Error(), "callbacks.engines[1].name")
+	assert.Contains(t, err.Error(), "callbacks.engines[1].token")
+}
+
+func TestValidateConfig_Auditors(t *testing.T) {
+	cfg := validConfig()
+	cfg.Auditors = []AuditorConfig{{Name: "soc2", Token: "t0ken"}}
+	require.NoError(t, validateConfig(cfg))
+
+	cfg.Auditors = append(cfg.Auditors, AuditorConfig{Name: "soc2"})
+	err := validateConfig(cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "auditors[1].name")
+	assert.Contains(t, err.Error(), "auditors[1].token")
+}
+
+func TestValidateConfig_TenantLabels(t *testing.T) {
+	cfg := validConfig()
+	cfg.TenantLabels = map[string]string{"cost_center": "cc-12", "environment": "prod"}
+	require.NoError(t, validateConfig(cfg))
+
+	cfg.TenantLabels = map[string]string{"cost-center": "cc-12", "environment": ""}
+	err := validateConfig(cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "tenant_labels.cost-center")
+	assert.Contains(t, err.Error(), "tenant_labels.environment")
+}
+
 func TestValidateConfig_InvalidEnvironment(t *testing.T) {
 	cfg := validConfig()
 	cfg.Environment = "invalid"
@@ -102,6 +152,34 @@ func TestValidateConfig_InvalidEnvironment(t *testing.T) {
 	assert.Contains(t, err.Error(), "environment")
 }
 
+func TestValidateConfig_FaultInjectionRejectedInProduction(t *testing.T) {
+	cfg := validConfig()
+	cfg.FaultInjection.Enabled = true
+	cfg.Environment = "staging"
+	require.NoError(t, validateConfig(cfg))
+
+	cfg.Environment = "production"
+	err := validateConfig(cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "fault_injection.enabled")
+}
+
+func TestValidateConfig_Replication(t *testing.T) {
+	cfg := validConfig()
+	cfg.Replication.Role = "replica"
+	err := validateConfig(cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "replication.primary_url")
+
+	cfg.Replication.PrimaryURL = "https://primary.example"
+	require.NoError(t, validateConfig(cfg))
+
+	cfg.Replication.Role = "standby"
+	err = validateConfig(cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "replication.role")
+}
+
 func TestValidateConfig_MissingVictoriaMetrics(t *testing.T) {
 	cfg := validConfig()
 	cfg.Database.VictoriaMetrics.Endpoints = nil
@@ -276,6 +354,14 @@ func TestValidateEngineConfig(t *testing.T) {
 		assert.Contains(t, err.Error(), "min_correlation")
 	})
 
+	t.Run("negative_scoring_weight", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Engine.Scoring.LagBonus = -0.1
+		err := validateConfig(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "engine.scoring")
+	})
+
 	t.Run("invalid_anomaly_score_range", func(t *testing.T) {
 		cfg := validConfig()
 		cfg.Engine.MinAnomalyScore = -0.1
@@ -339,6 +425,196 @@ func TestValidateWeaviateConfig(t *testing.T) {
 		err := validateConfig(cfg)
 		assert.NoError(t, err)
 	})
+
+	t.Run("discovery_mode", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Weaviate.Enabled = true
+		cfg.Weaviate.Host = "localhost"
+		cfg.Weaviate.Discovery = K8sDiscoveryConfig{Enabled: true, Mode: "consul", Service: "weaviate"}
+		err := validateConfig(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "weaviate.discovery.mode")
+
+		cfg.Weaviate.Discovery.Mode = "endpointslice"
+		assert.NoError(t, validateConfig(cfg))
+	})
+
+	t.Run("class_replication", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Weaviate.Enabled = true
+		cfg.Weaviate.Host = "localhost"
+		cfg.Weaviate.Consistency = "quorum"
+		cfg.Weaviate.Classes = map[string]WeaviateClassConfig{
+			"kpi_definition": {ReplicationFactor: 3, Consistency: "ONE"},
+		}
+		assert.NoError(t, validateConfig(cfg))
+
+		cfg.Weaviate.Classes["miraracatask"] = WeaviateClassConfig{ReplicationFactor: -1, Consistency: "most"}
+		err := validateConfig(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "weaviate.classes.miraracatask.replication_factor")
+		assert.Contains(t, err.Error(), "weaviate.classes.miraracatask.consistency")
+	})
+
+	t.Run("cache_tenant", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Cache.TenantID = "acme-prod"
+		assert.NoError(t, validateConfig(cfg))
+
+		cfg.Cache.TenantID = "acme:*"
+		cfg.Cache.TenantStatsInterval = -time.Second
+		err := validateConfig(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cache.tenant_id")
+		assert.Contains(t, err.Error(), "cache.tenant_stats_interval")
+	})
+
+	t.Run("catalog_dedup", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Catalog = CatalogConfig{DedupInterval: -time.Minute, DedupThreshold: 1.5}
+		err := validateConfig(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "catalog.dedup_interval")
+		assert.Contains(t, err.Error(), "catalog.dedup_threshold")
+	})
+
+	t.Run("health_score", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.HealthScore = HealthScoreConfig{RefreshInterval: -time.Minute, SLOTarget: 1}
+		err := validateConfig(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "health_score")
+		assert.Contains(t, err.Error(), "health_score.slo_target")
+	})
+
+	t.Run("kpi_history", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.KPIHistory = KPIHistoryConfig{Interval: 10 * time.Second}
+		err := validateConfig(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "kpi_history.interval")
+	})
+
+	t.Run("ticketing", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Integrations.Ticketing = TicketingConfig{Provider: "jira", BaseURL: "jira.example.com"}
+		err := validateConfig(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "integrations.ticketing.project")
+		assert.Contains(t, err.Error(), "integrations.ticketing.base_url")
+
+		cfg.Integrations.Ticketing = TicketingConfig{Provider: "servicenow", BaseURL: "https://acme.service-now.com", Table: "incident"}
+		assert.NoError(t, validateConfig(cfg))
+	})
+
+	t.Run("remediation", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Remediation = RemediationConfig{AuditLimit: -1}
+		err := validateConfig(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "remediation")
+	})
+
+	t.Run("executive_summary", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.ExecutiveSummary = ExecutiveSummaryConfig{TopN: -1}
+		err := validateConfig(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "executive_summary")
+	})
+
+	t.Run("capacity", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Capacity = CapacityConfig{Lookback: time.Hour, Step: time.Hour}
+		err := validateConfig(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "capacity.step")
+
+		cfg.Capacity = CapacityConfig{HorizonDays: -1}
+		err = validateConfig(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "capacity")
+	})
+
+	t.Run("self_slo", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.SelfSLO.Targets = DefaultSelfSLOTargets
+		require.NoError(t, validateConfig(cfg))
+
+		cfg.SelfSLO.Targets = []SelfSLOTarget{{Name: "q", Method: "POST", Endpoint: "/api/v1/unified/query", LatencyThreshold: time.Second, LatencyObjective: 1, AvailabilityObjective: 0.99}}
+		err := validateConfig(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "self_slo.targets[0]")
+
+		cfg.SelfSLO.Targets = []SelfSLOTarget{{Name: "q", Method: "POST", Endpoint: "/api/v1/unified/query", LatencyObjective: 0.99, AvailabilityObjective: 0.99}}
+		err = validateConfig(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "latency_threshold")
+	})
+
+	t.Run("profiling watchdog", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Profiling.Watchdog = ProfilingWatchdogConfig{Enabled: true, Interval: 15 * time.Second, CPUProfileDuration: 10 * time.Second, CPUThreshold: 0.85, Dir: "/tmp/profiles"}
+		require.NoError(t, validateConfig(cfg))
+
+		cfg.Profiling.Watchdog.CPUThreshold = 85
+		err := validateConfig(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "profiling.watchdog.cpu_threshold")
+
+		cfg.Profiling.Watchdog = ProfilingWatchdogConfig{Enabled: true}
+		err = validateConfig(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "profiling.watchdog")
+	})
+
+	t.Run("export memory limits", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Export = ExportConfig{MemoryMB: 32, MaxMB: 1024}
+		require.NoError(t, validateConfig(cfg))
+
+		cfg.Export.MemoryMB = 2048
+		err := validateConfig(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "export.memory_mb")
+	})
+
+	t.Run("kpi datastores", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.KPIDatastores = []KPIDatastoreConfig{
+			{Name: "finance_dw", Type: "postgresql", URL: "postgres://dw/finance"},
+			{Name: "events", Type: "clickhouse", URL: "http://clickhouse:8123"},
+		}
+		require.NoError(t, validateConfig(cfg))
+
+		cfg.KPIDatastores = append(cfg.KPIDatastores, KPIDatastoreConfig{Name: "Finance_DW", Type: "oracle"})
+		err := validateConfig(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "kpi_datastores[2].name")
+		assert.Contains(t, err.Error(), "kpi_datastores[2].type")
+		assert.Contains(t, err.Error(), "kpi_datastores[2].url")
+	})
+
+	t.Run("qos classes", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.QoS = QoSConfig{MaxConcurrent: 16, Classes: map[string]QoSClassConfig{
+			"interactive": {QueueTimeout: 30 * time.Second},
+			"background":  {MaxConcurrent: 4},
+			"batch":       {MaxConcurrent: 2},
+		}}
+		require.NoError(t, validateConfig(cfg))
+
+		cfg.QoS.Classes["batch"] = QoSClassConfig{MaxConcurrent: 12}
+		err := validateConfig(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "qos.classes")
+
+		cfg.QoS.Classes["batch"] = QoSClassConfig{MaxConcurrent: 2}
+		cfg.QoS.Classes["bulk"] = QoSClassConfig{}
+		err = validateConfig(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "qos.classes.bulk")
+	})
 }
 
 func TestValidationErrors_Error(t *testing.T) {
@@ -380,3 +656,15 @@ func TestMultipleValidationErrors(t *testing.T) {
 	require.ErrorAs(t, err, &verrs)
 	assert.GreaterOrEqual(t, len(verrs), 4)
 }
+
+func TestValidateConfig_AdminTokens(t *testing.T) {
+	cfg := validConfig()
+	cfg.Admin.Tokens = []AdminTokenConfig{{Name: "ops", Token: "t0ken"}}
+	require.NoError(t, validateConfig(cfg))
+
+	cfg.Admin.Tokens = append(cfg.Admin.Tokens, AdminTokenConfig{Name: "ops"})
+	err := validateConfig(cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "admin.tokens[1].name")
+	assert.Contains(t, err.Error(), "admin.tokens[1].token")
+}
@@ -0,0 +1,137 @@
+package datastore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+)
+
+const defaultTimeout = 30 * time.Second
+
+// ClickHouse queries ClickHouse over its HTTP interface. Placeholders are
+// sent as ClickHouse query parameters ({name:Type} plus param_name).
+type ClickHouse struct {
+	name     string
+	endpoint string
+	database string
+	username string
+	password string
+	client   *http.Client
+}
+
+// NewClickHouse creates a ClickHouse adapter for cfg.URL
+// (e.g. http://clickhouse:8123).
+func NewClickHouse(cfg config.KPIDatastoreConfig) (*ClickHouse, error) {
+	if _, err := url.ParseRequestURI(cfg.URL); err != nil {
+		return nil, fmt.Errorf("invalid url: %w", err)
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return &ClickHouse{
+		name:     cfg.Name,
+		endpoint: strings.TrimRight(cfg.URL, "/") + "/",
+		database: cfg.Database,
+		username: cfg.Username,
+		password: cfg.Password,
+		client:   &http.Client{Timeout: timeout},
+	}, nil
+}
+
+func (c *ClickHouse) Name() string { return c.name }
+func (c *ClickHouse) Type() string { return TypeClickHouse }
+func (c *ClickHouse) Close() error { return nil }
+
+// Ping checks the server answers SELECT 1.
+func (c *ClickHouse) Ping(ctx context.Context) error {
+	_, err := c.do(ctx, "SELECT 1", url.Values{})
+	return err
+}
+
+func (c *ClickHouse) Query(ctx context.Context, query string, params map[string]any) ([]Sample, error) {
+	// ClickHouse binds by name, so the positional args are not needed.
+	sql, _ := bind(query, params, func(name string, _ int) string {
+		return fmt.Sprintf("{%s:%s}", name, clickHouseType(params[name]))
+	})
+	values := url.Values{}
+	for name, v := range params {
+		values.Set("param_"+name, clickHouseValue(v))
+	}
+	body, err := c.do(ctx, sql, values)
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		Data []map[string]any `json:"data"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("clickhouse: decode result: %w", err)
+	}
+	samples := make([]Sample, 0, len(result.Data))
+	for _, row := range result.Data {
+		s, err := rowSample(row)
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, s)
+	}
+	return samples, nil
+}
+
+func (c *ClickHouse) do(ctx context.Context, sql string, values url.Values) ([]byte, error) {
+	values.Set("default_format", "JSON")
+	values.Set("readonly", "1")
+	if c.database != "" {
+		values.Set("database", c.database)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+"?"+values.Encode(), bytes.NewBufferString(sql))
+	if err != nil {
+		return nil, err
+	}
+	if c.username != "" {
+		req.Header.Set("X-ClickHouse-User", c.username)
+		req.Header.Set("X-ClickHouse-Key", c.password)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("clickhouse: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("clickhouse: read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("clickhouse: " + resp.Status + ": " + strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
+
+func clickHouseType(v any) string {
+	switch v.(type) {
+	case time.Time:
+		return "DateTime64(3, 'UTC')"
+	case int, int32, int64:
+		return "Int64"
+	case float32, float64:
+		return "Float64"
+	}
+	return "String"
+}
+
+func clickHouseValue(v any) string {
+	if t, ok := v.(time.Time); ok {
+		return t.UTC().Format("2006-01-02 15:04:05.000")
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,220 @@
+// Package datastore evaluates KPIs stored outside the Victoria backends.
+//
+// Business KPIs often live in a warehouse. An Adapter runs a KPI's SQL
+// formula against one and returns samples in the same shape as a metrics
+// instant vector, so the KPI registry, health scores and the executive
+// summary treat warehouse KPIs like observability KPIs. Adapters are
+// declared under kpi_datastores in the deployment's (tenant's) config and
+// referenced by name from KPIDefinition.Datastore.
+//
+// SQL formulas bind parameters with {name} placeholders: {at} (evaluation
+// time), {start}/{end} and {service}. Values are always sent as query
+// parameters, never spliced into the SQL. Each result row becomes a sample:
+// the "value" column is the sample value, a "ts" column (optional) its time,
+// and every other column a label.
+package datastore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+)
+
+// Adapter types accepted in kpi_datastores[].type.
+const (
+	TypeClickHouse = "clickhouse"
+	TypePostgreSQL = "postgresql"
+)
+
+// QueryTypeSQL is the KPIDefinition.QueryType of warehouse KPIs.
+const QueryTypeSQL = "SQL"
+
+var (
+	// ErrUnknownDatastore is returned for a name with no registered adapter.
+	ErrUnknownDatastore = errors.New("datastore: not registered")
+	// ErrNoValueColumn is returned when a KPI query result has no value column.
+	ErrNoValueColumn = errors.New(`datastore: query result has no "value" column`)
+)
+
+// Sample is one row of a KPI query result.
+type Sample struct {
+	Labels map[string]string
+	Value  float64
+	Time   time.Time // zero when the query returns no ts column
+}
+
+// Adapter runs KPI queries against one datastore.
+type Adapter interface {
+	Name() string
+	Type() string
+	// Query runs query with its {name} placeholders bound from params.
+	Query(ctx context.Context, query string, params map[string]any) ([]Sample, error)
+	Ping(ctx context.Context) error
+	Close() error
+}
+
+// Registry holds the adapters of a deployment by lower-cased name.
+type Registry struct {
+	adapters map[string]Adapter
+}
+
+// NewRegistry creates an adapter for each configured datastore.
+func NewRegistry(cfgs []config.KPIDatastoreConfig) (*Registry, error) {
+	r := &Registry{adapters: map[string]Adapter{}}
+	for _, cfg := range cfgs {
+		var (
+			a   Adapter
+			err error
+		)
+		switch strings.ToLower(cfg.Type) {
+		case TypeClickHouse:
+			a, err = NewClickHouse(cfg)
+		case TypePostgreSQL:
+			a, err = NewPostgreSQL(cfg)
+		default:
+			err = fmt.Errorf("unsupported type %q", cfg.Type)
+		}
+		if err != nil {
+			_ = r.Close()
+			return nil, fmt.Errorf("datastore %s: %w", cfg.Name, err)
+		}
+		r.Register(a)
+	}
+	return r, nil
+}
+
+// Register adds or replaces an adapter.
+func (r *Registry) Register(a Adapter) {
+	r.adapters[strings.ToLower(a.Name())] = a
+}
+
+// Get returns the adapter registered under name (case-insensitive). A nil
+// registry has no adapters.
+func (r *Registry) Get(name string) (Adapter, bool) {
+	if r == nil {
+		return nil, false
+	}
+	a, ok := r.adapters[strings.ToLower(strings.TrimSpace(name))]
+	return a, ok
+}
+
+// Query runs query on the named datastore.
+func (r *Registry) Query(ctx context.Context, name, query string, params map[string]any) ([]Sample, error) {
+	a, ok := r.Get(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownDatastore, name)
+	}
+	return a.Query(ctx, query, params)
+}
+
+// Names lists the registered datastore names.
+func (r *Registry) Names() []string {
+	if r == nil {
+		return nil
+	}
+	names := make([]string, 0, len(r.adapters))
+	for name := range r.adapters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Close closes every adapter.
+func (r *Registry) Close() error {
+	if r == nil {
+		return nil
+	}
+	var errs []error
+	for _, a := range r.adapters {
+		errs = append(errs, a.Close())
+	}
+	return errors.Join(errs...)
+}
+
+var placeholderRE = regexp.MustCompile(`\{([a-z_][a-z0-9_]*)\}`)
+
+// bind replaces the {name} placeholders of query that have a value in
+// params using placeholder(name, index), and returns the values in the order
+// they were bound. Placeholders without a value are left untouched.
+func bind(query string, params map[string]any, placeholder func(name string, index int) string) (string, []any) {
+	var args []any
+	out := placeholderRE.ReplaceAllStringFunc(query, func(m string) string {
+		name := m[1 : len(m)-1]
+		v, ok := params[name]
+		if !ok {
+			return m
+		}
+		args = append(args, v)
+		return placeholder(name, len(args))
+	})
+	return out, args
+}
+
+// rowSample converts a result row to a Sample.
+func rowSample(row map[string]any) (Sample, error) {
+	raw, ok := row["value"]
+	if !ok {
+		return Sample{}, ErrNoValueColumn
+	}
+	value, err := toFloat(raw)
+	if err != nil {
+		return Sample{}, fmt.Errorf("datastore: value column: %w", err)
+	}
+	s := Sample{Labels: map[string]string{}, Value: value}
+	for col, v := range row {
+		switch col {
+		case "value":
+		case "ts":
+			s.Time, _ = toTime(v)
+		default:
+			if v != nil {
+				s.Labels[col] = fmt.Sprint(v)
+			}
+		}
+	}
+	return s, nil
+}
+
+func toFloat(v any) (float64, error) {
+	switch x := v.(type) {
+	case float64:
+		return x, nil
+	case float32:
+		return float64(x), nil
+	case int64:
+		return float64(x), nil
+	case int32:
+		return float64(x), nil
+	case int:
+		return float64(x), nil
+	case string:
+		return strconv.ParseFloat(x, 64)
+	case []byte:
+		return strconv.ParseFloat(string(x), 64)
+	case fmt.Stringer: // numeric types such as pgtype.Numeric
+		return strconv.ParseFloat(x.String(), 64)
+	}
+	return 0, fmt.Errorf("not numeric: %T", v)
+}
+
+func toTime(v any) (time.Time, bool) {
+	switch x := v.(type) {
+	case time.Time:
+		return x, true
+	case string:
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999", "2006-01-02"} {
+			if t, err := time.Parse(layout, x); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
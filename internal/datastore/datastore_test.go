@@ -0,0 +1,94 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+)
+
+func TestBind_PositionalAndUnknownPlaceholders(t *testing.T) {
+	query := "SELECT sum(x) AS value FROM t WHERE svc = {service} AND ts <= {at} AND tag = '{literal}' AND ts > {at} - 1"
+	got, args := bind(query, map[string]any{"service": "checkout", "at": 42}, func(_ string, i int) string {
+		return "$" + string(rune('0'+i))
+	})
+	want := "SELECT sum(x) AS value FROM t WHERE svc = $1 AND ts <= $2 AND tag = '{literal}' AND ts > $3 - 1"
+	if got != want {
+		t.Fatalf("bind:\n got  %s\n want %s", got, want)
+	}
+	if len(args) != 3 || args[0] != "checkout" || args[1] != 42 || args[2] != 42 {
+		t.Fatalf("unexpected args %v", args)
+	}
+}
+
+func TestRowSample(t *testing.T) {
+	s, err := rowSample(map[string]any{"value": "12.5", "ts": "2024-05-01 10:00:00", "region": "eu", "empty": nil})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Value != 12.5 || s.Labels["region"] != "eu" || s.Time.IsZero() || len(s.Labels) != 1 {
+		t.Fatalf("unexpected sample %+v", s)
+	}
+	if _, err := rowSample(map[string]any{"total": 1}); !errors.Is(err, ErrNoValueColumn) {
+		t.Fatalf("expected ErrNoValueColumn, got %v", err)
+	}
+}
+
+func TestClickHouse_QuerySendsParameters(t *testing.T) {
+	var gotSQL string
+	var gotParams map[string][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotSQL, gotParams = string(body), r.URL.Query()
+		if r.Header.Get("X-ClickHouse-User") != "kpi" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"meta":[],"data":[{"value":"1250","region":"eu"},{"value":980.5,"region":"us"}],"rows":2}`))
+	}))
+	defer srv.Close()
+
+	ch, err := NewClickHouse(config.KPIDatastoreConfig{Name: "dw", URL: srv.URL, Database: "sales", Username: "kpi"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	samples, err := ch.Query(context.Background(), "SELECT region, sum(amount) AS value FROM orders WHERE ts <= {at} GROUP BY region", map[string]any{"at": at})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotSQL != "SELECT region, sum(amount) AS value FROM orders WHERE ts <= {at:DateTime64(3, 'UTC')} GROUP BY region" {
+		t.Fatalf("placeholder not typed: %s", gotSQL)
+	}
+	if gotParams["param_at"][0] != "2024-05-01 10:00:00.000" || gotParams["database"][0] != "sales" || gotParams["readonly"][0] != "1" {
+		t.Fatalf("unexpected parameters %v", gotParams)
+	}
+	if len(samples) != 2 || samples[0].Value != 1250 || samples[1].Labels["region"] != "us" {
+		t.Fatalf("unexpected samples %+v", samples)
+	}
+}
+
+func TestNewRegistry(t *testing.T) {
+	r, err := NewRegistry([]config.KPIDatastoreConfig{
+		{Name: "Finance_DW", Type: TypePostgreSQL, URL: "postgres://dw:5432/finance"},
+		{Name: "events", Type: TypeClickHouse, URL: "http://clickhouse:8123"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if a, ok := r.Get("finance_dw"); !ok || a.Type() != TypePostgreSQL {
+		t.Fatalf("expected case-insensitive lookup, got %v %v", a, ok)
+	}
+	if _, err := r.Query(context.Background(), "missing", "SELECT 1", nil); !errors.Is(err, ErrUnknownDatastore) {
+		t.Fatalf("expected ErrUnknownDatastore, got %v", err)
+	}
+	if _, err := NewRegistry([]config.KPIDatastoreConfig{{Name: "x", Type: "oracle"}}); err == nil {
+		t.Fatal("expected an error for an unsupported type")
+	}
+}
@@ -0,0 +1,105 @@
+package datastore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	_ "github.com/jackc/pgx/v5/stdlib" // PostgreSQL driver ("pgx")
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+)
+
+// Default connection pool settings
+const (
+	pgMaxOpenConns = 4
+	pgMaxIdleConns = 2
+)
+
+// PostgreSQL queries PostgreSQL (or a compatible warehouse) through
+// database/sql. Placeholders become positional $n parameters.
+type PostgreSQL struct {
+	name string
+	db   *sql.DB
+}
+
+// NewPostgreSQL creates a PostgreSQL adapter for cfg.URL
+// (e.g. postgres://warehouse:5432/kpis?sslmode=require). Username and
+// Password override any credentials in the URL. The pool connects lazily.
+func NewPostgreSQL(cfg config.KPIDatastoreConfig) (*PostgreSQL, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil || (u.Scheme != "postgres" && u.Scheme != "postgresql") {
+		return nil, fmt.Errorf("invalid url: expected postgres://host:port/database")
+	}
+	if cfg.Username != "" {
+		u.User = url.UserPassword(cfg.Username, cfg.Password)
+	}
+	if cfg.Database != "" {
+		u.Path = "/" + cfg.Database
+	}
+	q := u.Query()
+	if cfg.Timeout > 0 && q.Get("statement_timeout") == "" {
+		q.Set("statement_timeout", strconv.FormatInt(cfg.Timeout.Milliseconds(), 10))
+	}
+	u.RawQuery = q.Encode()
+
+	db, err := sql.Open("pgx", u.String())
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(pgMaxOpenConns)
+	db.SetMaxIdleConns(pgMaxIdleConns)
+	return &PostgreSQL{name: cfg.Name, db: db}, nil
+}
+
+func (p *PostgreSQL) Name() string                   { return p.name }
+func (p *PostgreSQL) Type() string                   { return TypePostgreSQL }
+func (p *PostgreSQL) Close() error                   { return p.db.Close() }
+func (p *PostgreSQL) Ping(ctx context.Context) error { return p.db.PingContext(ctx) }
+
+func (p *PostgreSQL) Query(ctx context.Context, query string, params map[string]any) ([]Sample, error) {
+	stmt, args := bind(query, params, func(_ string, index int) string {
+		return "$" + strconv.Itoa(index)
+	})
+	tx, err := p.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("postgresql: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	rows, err := tx.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, fmt.Errorf("postgresql: %w", err)
+	}
+	defer rows.Close()
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("postgresql: %w", err)
+	}
+	var samples []Sample
+	for rows.Next() {
+		vals := make([]any, len(cols))
+		ptrs := make([]any, len(cols))
+		for i := range vals {
+			ptrs[i] = &vals[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, fmt.Errorf("postgresql: %w", err)
+		}
+		row := make(map[string]any, len(cols))
+		for i, col := range cols {
+			row[col] = vals[i]
+		}
+		s, err := rowSample(row)
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgresql: %w", err)
+	}
+	return samples, nil
+}
@@ -0,0 +1,119 @@
+package demodata
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/services"
+)
+
+var testEnd = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func TestScenario_IncidentShowsInMetricsAndLogs(t *testing.T) {
+	sc, err := NewScenario(testEnd, 2*time.Hour, time.Minute, 45*time.Minute, 20*time.Minute, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	series, err := sc.WriteMetrics(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again := bytes.Buffer{}
+	if _, err := sc.WriteMetrics(&again); err != nil || !bytes.Equal(buf.Bytes(), again.Bytes()) {
+		t.Fatal("expected the same seed to produce the same metrics")
+	}
+
+	var p95 []float64
+	sc2 := bufio.NewScanner(&buf)
+	sc2.Buffer(nil, 1<<20)
+	for sc2.Scan() {
+		var s importSeries
+		if err := json.Unmarshal(sc2.Bytes(), &s); err != nil {
+			t.Fatal(err)
+		}
+		if len(s.Values) != 120 || len(s.Timestamps) != 120 {
+			t.Fatalf("expected 120 samples, got %d", len(s.Values))
+		}
+		if s.Metric["__name__"] == "http_request_duration_seconds" && s.Metric["instance"] == "payments-0" && s.Metric["quantile"] == "0.95" {
+			p95 = s.Values
+		}
+	}
+	if series != 49 || p95 == nil {
+		t.Fatalf("unexpected series count %d", series)
+	}
+	// Mid-incident payments latency dwarfs the pre-incident baseline.
+	mid := int(sc.IncidentStart.Add(10*time.Minute).Sub(sc.Start) / time.Minute)
+	if p95[mid] < 10*p95[0] {
+		t.Fatalf("expected an incident latency spike, got %v vs %v", p95[mid], p95[0])
+	}
+
+	var logs bytes.Buffer
+	if _, err := sc.WriteLogs(&logs); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(logs.String(), "connection pool exhausted") || !strings.Contains(logs.String(), "deployed payments") {
+		t.Fatal("expected incident logs")
+	}
+	if len(sc.Traces().ResourceSpans) != 4 {
+		t.Fatalf("expected spans from the four called services, got %d", len(sc.Traces().ResourceSpans))
+	}
+
+	if _, err := NewScenario(testEnd, time.Hour, time.Minute, 2*time.Hour, time.Minute, 1); err == nil {
+		t.Fatal("expected an incident outside the span to be rejected")
+	}
+}
+
+func TestKPIs_AreValid(t *testing.T) {
+	for _, k := range KPIs(testEnd).KPIs {
+		if err := services.ValidateKPIDefinition(nil, k); err != nil {
+			t.Fatalf("%s: %v", k.Name, err)
+		}
+	}
+}
+
+func TestLoad_WritesEveryTarget(t *testing.T) {
+	var mu sync.Mutex
+	paths := map[string]string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		mu.Lock()
+		paths[r.URL.Path] = r.Header.Get("Content-Type") + " " + r.Header.Get("Authorization")
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	sc, err := NewScenario(testEnd, time.Hour, 30*time.Second, 30*time.Minute, 10*time.Minute, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum, err := Load(context.Background(), sc, Targets{
+		Metrics: srv.URL, Logs: srv.URL, Traces: srv.URL, Mirador: srv.URL + "/",
+		Headers: map[string]string{"Authorization": "Bearer t"},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sum.Series == 0 || sum.LogEntries == 0 || sum.Spans == 0 || sum.KPIs == 0 || sum.Incident == nil {
+		t.Fatalf("unexpected summary %+v", sum)
+	}
+	for path, ct := range map[string]string{
+		"/api/v1/import":                  "application/json",
+		"/insert/jsonline":                "application/stream+json",
+		"/insert/opentelemetry/v1/traces": "application/x-protobuf",
+		"/api/v1/kpi/defs/import":         "application/json",
+	} {
+		if paths[path] != ct+" Bearer t" {
+			t.Fatalf("%s: got %q", path, paths[path])
+		}
+	}
+}
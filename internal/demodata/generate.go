@@ -0,0 +1,356 @@
+package demodata
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+// Env is the env label/field on all generated data, so demo data is easy to
+// tell apart and delete.
+const Env = "demo"
+
+// importSeries is one line of the VictoriaMetrics /api/v1/import format.
+type importSeries struct {
+	Metric     map[string]string `json:"metric"`
+	Values     []float64         `json:"values"`
+	Timestamps []int64           `json:"timestamps"`
+}
+
+// WriteMetrics writes the scenario's metrics in the VictoriaMetrics
+// /api/v1/import JSON lines format and returns the number of series.
+//
+// Per instance: http_requests_total{status="2xx|5xx"} (counter),
+// http_request_duration_seconds{quantile="0.5|0.95"} and process_cpu_usage;
+// for payments also db_connections_in_use and db_connections_max.
+func (sc Scenario) WriteMetrics(w io.Writer) (int, error) {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	var timestamps []int64
+	for t := sc.Start; t.Before(sc.End); t = t.Add(sc.Step) {
+		timestamps = append(timestamps, t.UnixMilli())
+	}
+
+	series := 0
+	for _, svc := range Services {
+		for i := 0; i < svc.Instances; i++ {
+			instance := fmt.Sprintf("%s-%d", svc.Name, i)
+			rng := sc.rng(streamID("metrics", instance))
+			values := map[string][]float64{}
+			var ok, failed float64
+			for _, ms := range timestamps {
+				l := sc.loadAt(svc, time.UnixMilli(ms).UTC(), rng)
+				n := l.RPS / float64(svc.Instances) * sc.Step.Seconds()
+				ok += math.Round(n * (1 - l.ErrorRatio))
+				failed += math.Round(n * l.ErrorRatio)
+				values["2xx"] = append(values["2xx"], ok)
+				values["5xx"] = append(values["5xx"], failed)
+				values["0.5"] = append(values["0.5"], round(l.P50, 4))
+				values["0.95"] = append(values["0.95"], round(l.P95, 4))
+				values["cpu"] = append(values["cpu"], round(l.CPU, 3))
+				if svc.Name == "payments" {
+					values["db"] = append(values["db"], math.Round(l.DBConnections/float64(svc.Instances)))
+					values["dbmax"] = append(values["dbmax"], dbPoolSize/float64(svc.Instances))
+				}
+			}
+
+			base := func(name string, extra ...string) map[string]string {
+				m := map[string]string{"__name__": name, "service": svc.Name, "job": svc.Name, "instance": instance, "env": Env}
+				for j := 0; j+1 < len(extra); j += 2 {
+					m[extra[j]] = extra[j+1]
+				}
+				return m
+			}
+			out := []importSeries{
+				{Metric: base("http_requests_total", "status", "2xx"), Values: values["2xx"]},
+				{Metric: base("http_requests_total", "status", "5xx"), Values: values["5xx"]},
+				{Metric: base("http_request_duration_seconds", "quantile", "0.5"), Values: values["0.5"]},
+				{Metric: base("http_request_duration_seconds", "quantile", "0.95"), Values: values["0.95"]},
+				{Metric: base("process_cpu_usage"), Values: values["cpu"]},
+			}
+			if svc.Name == "payments" {
+				out = append(out,
+					importSeries{Metric: base("db_connections_in_use", "pool", "primary"), Values: values["db"]},
+					importSeries{Metric: base("db_connections_max", "pool", "primary"), Values: values["dbmax"]})
+			}
+			for _, s := range out {
+				s.Timestamps = timestamps
+				if err := enc.Encode(s); err != nil {
+					return series, err
+				}
+				series++
+			}
+		}
+	}
+	return series, bw.Flush()
+}
+
+// span is one span of a sampled request.
+type span struct {
+	service, name string
+	id, parent    []byte
+	start         time.Time
+	duration      time.Duration
+	err           string
+	database      bool // client span of a database query
+}
+
+// request is a sampled end-to-end request and its spans.
+type request struct {
+	traceID []byte
+	spans   []span
+}
+
+// requests samples end-to-end requests through the gateway: one a minute,
+// four a minute during the incident. Failed requests are over-sampled (as
+// tail sampling would keep them) so errors show up in traces and logs.
+func (sc Scenario) requests() []request {
+	rng := sc.rng(streamID("requests", ""))
+	byName := map[string]Service{}
+	for _, s := range Services {
+		byName[s.Name] = s
+	}
+
+	var out []request
+	for t := sc.Start; t.Before(sc.End); t = t.Add(time.Minute) {
+		n := 1
+		if sc.severity(t) > 0 {
+			n = 4
+		}
+		for i := 0; i < n; i++ {
+			start := t.Add(time.Duration(rng.Int64N(int64(time.Minute))))
+			req := request{traceID: randomBytes(rng, 16)}
+			target := "inventory"
+			if rng.IntN(2) == 0 {
+				target = "checkout"
+			}
+			sc.call(&req, byName, byName["api-gateway"], target, nil, start, rng)
+			out = append(out, req)
+		}
+	}
+	return out
+}
+
+// call adds the server span of svc, which calls the next service (if any),
+// and returns it. A failing callee fails its caller.
+func (sc Scenario) call(req *request, byName map[string]Service, svc Service, next string, parent []byte, start time.Time, rng *rand.Rand) span {
+	l := sc.loadAt(svc, start, rng)
+	s := span{service: svc.Name, name: operationName(svc.Name), id: randomBytes(rng, 8), parent: parent, start: start}
+	s.duration = time.Duration((l.P50 + (l.P95-l.P50)*math.Pow(rng.Float64(), 3)) * float64(time.Second))
+
+	idx := len(req.spans)
+	req.spans = append(req.spans, s)
+	var child *span
+	switch {
+	case next != "":
+		callee := byName[next]
+		after := ""
+		if len(callee.Calls) > 0 {
+			after = callee.Calls[0]
+		}
+		c := sc.call(req, byName, callee, after, s.id, start.Add(s.duration/10), rng)
+		child = &c
+	case svc.Name == "payments":
+		db := span{service: svc.Name, name: "INSERT payments", id: randomBytes(rng, 8), parent: s.id, start: start.Add(s.duration / 20), database: true}
+		db.duration = s.duration * 7 / 10
+		if sc.severity(start) > 0 && rng.Float64() < l.ErrorRatio*5 {
+			db.err = fmt.Sprintf("connection pool exhausted (%d/%d in use), timed out after %s", dbPoolSize, dbPoolSize, db.duration.Round(time.Millisecond))
+		}
+		req.spans = append(req.spans, db)
+		child = &req.spans[len(req.spans)-1]
+	}
+
+	s = req.spans[idx]
+	if child != nil {
+		if end := child.start.Add(child.duration).Add(s.duration / 10); end.After(s.start.Add(s.duration)) {
+			s.duration = end.Sub(s.start)
+		}
+		switch {
+		case child.err != "" && child.database:
+			s.err = "database query failed: " + child.err
+		case child.err != "":
+			s.err = fmt.Sprintf("call to %s failed: %s", child.service, child.err)
+		}
+	}
+	if s.err == "" && rng.Float64() < l.ErrorRatio*3 {
+		s.err = "internal error"
+	}
+	req.spans[idx] = s
+	return s
+}
+
+func operationName(service string) string {
+	switch service {
+	case "api-gateway":
+		return "GET /api/cart"
+	case "checkout":
+		return "POST /checkout"
+	case "payments":
+		return "POST /payments/charge"
+	case "inventory":
+		return "GET /inventory/items"
+	default:
+		return "POST /notify"
+	}
+}
+
+// Traces returns the sampled requests as an OTLP export request.
+func (sc Scenario) Traces() *coltracepb.ExportTraceServiceRequest {
+	byService := map[string]*tracepb.ResourceSpans{}
+	var order []string
+	for _, req := range sc.requests() {
+		for _, s := range req.spans {
+			rs := byService[s.service]
+			if rs == nil {
+				rs = &tracepb.ResourceSpans{
+					Resource: &resourcepb.Resource{Attributes: []*commonpb.KeyValue{
+						stringAttr("service.name", s.service),
+						stringAttr("deployment.environment", Env),
+					}},
+					ScopeSpans: []*tracepb.ScopeSpans{{Scope: &commonpb.InstrumentationScope{Name: "mirador-demodata"}}},
+				}
+				byService[s.service] = rs
+				order = append(order, s.service)
+			}
+			ps := &tracepb.Span{
+				TraceId:           req.traceID,
+				SpanId:            s.id,
+				ParentSpanId:      s.parent,
+				Name:              s.name,
+				Kind:              tracepb.Span_SPAN_KIND_SERVER,
+				StartTimeUnixNano: uint64(s.start.UnixNano()),
+				EndTimeUnixNano:   uint64(s.start.Add(s.duration).UnixNano()),
+			}
+			if s.database {
+				ps.Kind = tracepb.Span_SPAN_KIND_CLIENT
+				ps.Attributes = []*commonpb.KeyValue{stringAttr("db.system", "postgresql")}
+			}
+			if s.err != "" {
+				ps.Status = &tracepb.Status{Code: tracepb.Status_STATUS_CODE_ERROR, Message: s.err}
+			}
+			rs.ScopeSpans[0].Spans = append(rs.ScopeSpans[0].Spans, ps)
+		}
+	}
+	out := &coltracepb.ExportTraceServiceRequest{}
+	for _, name := range order {
+		out.ResourceSpans = append(out.ResourceSpans, byService[name])
+	}
+	return out
+}
+
+// logEntry is one line of the VictoriaLogs /insert/jsonline format.
+type logEntry struct {
+	Time    string `json:"_time"`
+	Msg     string `json:"_msg"`
+	Level   string `json:"level"`
+	Service string `json:"service.name"`
+	Env     string `json:"env"`
+	TraceID string `json:"trace_id,omitempty"`
+	SpanID  string `json:"span_id,omitempty"`
+}
+
+// WriteLogs writes the scenario's logs in the VictoriaLogs jsonline format
+// and returns the number of entries: a per-minute summary per service, an
+// error line (with trace_id) for every failed span of the sampled
+// requests, pool warnings from payments during the incident, and the
+// payments deploy that starts it.
+func (sc Scenario) WriteLogs(w io.Writer) (int, error) {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	count := 0
+	write := func(e logEntry) error {
+		e.Env = Env
+		count++
+		return enc.Encode(e)
+	}
+
+	for _, svc := range Services {
+		rng := sc.rng(streamID("logs", svc.Name))
+		for t := sc.Start; t.Before(sc.End); t = t.Add(time.Minute) {
+			l := sc.loadAt(svc, t, rng)
+			n := int(l.RPS * 60)
+			if err := write(logEntry{
+				Time:    t.Add(59 * time.Second).Format(time.RFC3339Nano),
+				Msg:     fmt.Sprintf("served %d requests, %d failed, p95 %.0fms", n, int(float64(n)*l.ErrorRatio), l.P95*1000),
+				Level:   "info",
+				Service: svc.Name,
+			}); err != nil {
+				return count, err
+			}
+			if svc.Name == "payments" && l.DBConnections >= dbPoolSize*0.9 {
+				if err := write(logEntry{
+					Time:    t.Add(30 * time.Second).Format(time.RFC3339Nano),
+					Msg:     fmt.Sprintf("connection pool primary at %.0f/%d connections", l.DBConnections, dbPoolSize),
+					Level:   "warn",
+					Service: svc.Name,
+				}); err != nil {
+					return count, err
+				}
+			}
+		}
+	}
+
+	if !sc.IncidentStart.IsZero() {
+		if err := write(logEntry{
+			Time:    sc.IncidentStart.Add(-2 * time.Minute).Format(time.RFC3339Nano),
+			Msg:     "deployed payments v2.4.1 (connection handling refactor)",
+			Level:   "info",
+			Service: "payments",
+		}); err != nil {
+			return count, err
+		}
+	}
+
+	for _, req := range sc.requests() {
+		for _, s := range req.spans {
+			if s.err == "" {
+				continue
+			}
+			if err := write(logEntry{
+				Time:    s.start.Add(s.duration).Format(time.RFC3339Nano),
+				Msg:     fmt.Sprintf("%s failed: %s", s.name, s.err),
+				Level:   "error",
+				Service: s.service,
+				TraceID: hex.EncodeToString(req.traceID),
+				SpanID:  hex.EncodeToString(s.id),
+			}); err != nil {
+				return count, err
+			}
+		}
+	}
+	return count, bw.Flush()
+}
+
+func stringAttr(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{Key: key, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}}}
+}
+
+func randomBytes(rng *rand.Rand, n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(rng.UintN(256))
+	}
+	return b
+}
+
+func streamID(kind, name string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(kind + "/" + name))
+	return h.Sum64()
+}
+
+func round(v float64, digits int) float64 {
+	p := math.Pow(10, float64(digits))
+	return math.Round(v*p) / p
+}
@@ -0,0 +1,66 @@
+package demodata
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+)
+
+// Namespace of the demo KPIs.
+const Namespace = "demo_shop"
+
+// Dashboard is the dashboard ID the demo KPIs reference. mirador-core does
+// not manage dashboards, so this is a stable UUIDv5 for the UI to group by.
+var Dashboard = uuid.NewV5(uuid.NamespaceURL, "https://mirador.local/dashboards/"+Namespace).String()
+
+// KPIs returns the KPI definitions matching the generated metrics, as a
+// bundle for POST /api/v1/kpi/defs/import.
+func KPIs(now time.Time) *models.KPIBundle {
+	errorRate := func(service string) string {
+		return `sum(rate(http_requests_total{env="demo",service="` + service + `",status="5xx"}[5m])) / sum(rate(http_requests_total{env="demo",service="` + service + `"}[5m]))`
+	}
+	p95 := func(service string) string {
+		return `max(http_request_duration_seconds{env="demo",service="` + service + `",quantile="0.95"})`
+	}
+	kpi := func(name, layer, classifier, unit, formula, definition string) *models.KPIDefinition {
+		k := &models.KPIDefinition{
+			Name:       name,
+			Namespace:  Namespace,
+			Source:     "demodata",
+			Kind:       "tech",
+			Layer:      layer,
+			SignalType: "metrics",
+			Classifier: classifier,
+			Unit:       unit,
+			Formula:    formula,
+			Definition: definition,
+			Sentiment:  "negative",
+			Domain:     "shop",
+			Tags:       []string{"demo"},
+			Dashboard:  Dashboard,
+		}
+		if layer == "impact" {
+			k.Kind = "business"
+			k.BusinessImpact = "Customers cannot complete purchases; revenue is lost."
+		}
+		return k
+	}
+	return &models.KPIBundle{
+		Version:    models.KPIBundleVersion,
+		ExportedAt: now.UTC(),
+		KPIs: []*models.KPIDefinition{
+			kpi("checkout_error_rate", "impact", "errors", "ratio", errorRate("checkout"), "Share of checkout requests that fail."),
+			kpi("checkout_p95_latency", "impact", "latency", "seconds", p95("checkout"), "95th percentile checkout latency."),
+			kpi("gateway_error_rate", "impact", "errors", "ratio", errorRate("api-gateway"), "Share of gateway requests that fail."),
+			kpi("payments_error_rate", "cause", "errors", "ratio", errorRate("payments"), "Share of payment charges that fail."),
+			kpi("payments_p95_latency", "cause", "latency", "seconds", p95("payments"), "95th percentile payment charge latency."),
+			kpi("payments_db_pool_saturation", "cause", "saturation", "ratio",
+				`sum(db_connections_in_use{env="demo",service="payments"}) / sum(db_connections_max{env="demo",service="payments"})`,
+				"Share of the payments database connection pool in use."),
+			kpi("payments_cpu_usage", "cause", "cpu_utilization", "ratio", `avg(process_cpu_usage{env="demo",service="payments"})`, "Average CPU usage of payments instances."),
+			kpi("inventory_error_rate", "cause", "errors", "ratio", errorRate("inventory"), "Share of inventory lookups that fail."),
+		},
+	}
+}
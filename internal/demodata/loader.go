@@ -0,0 +1,121 @@
+package demodata
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Targets are the base URLs data is written to. Empty targets are skipped.
+type Targets struct {
+	// VictoriaMetrics (vminsert or single-node); metrics go to /api/v1/import.
+	Metrics string
+	// VictoriaLogs; logs go to /insert/jsonline.
+	Logs string
+	// VictoriaTraces; traces go to /insert/opentelemetry/v1/traces.
+	Traces string
+	// mirador-core; KPIs go to /api/v1/kpi/defs/import.
+	Mirador string
+	// Headers are added to every request (e.g. credentials).
+	Headers map[string]string
+}
+
+// Summary reports what a Load wrote.
+type Summary struct {
+	Series     int        `json:"series"`
+	LogEntries int        `json:"logEntries"`
+	Spans      int        `json:"spans"`
+	KPIs       int        `json:"kpis"`
+	Start      time.Time  `json:"start"`
+	End        time.Time  `json:"end"`
+	Incident   *time.Time `json:"incidentStart,omitempty"`
+}
+
+// Load generates sc and writes it to every configured target. A nil client
+// uses one with a one-minute timeout.
+func Load(ctx context.Context, sc Scenario, targets Targets, client *http.Client) (*Summary, error) {
+	if client == nil {
+		client = &http.Client{Timeout: time.Minute}
+	}
+	sum := &Summary{Start: sc.Start, End: sc.End}
+	if !sc.IncidentStart.IsZero() {
+		sum.Incident = &sc.IncidentStart
+	}
+	post := func(base, path, contentType string, body []byte) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(base, "/")+path, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", contentType)
+		for k, v := range targets.Headers {
+			req.Header.Set(k, v)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode/100 != 2 {
+			msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+			return fmt.Errorf("POST %s: status %d: %s", path, resp.StatusCode, strings.TrimSpace(string(msg)))
+		}
+		return nil
+	}
+
+	if targets.Metrics != "" {
+		var buf bytes.Buffer
+		n, err := sc.WriteMetrics(&buf)
+		if err != nil {
+			return sum, fmt.Errorf("generate metrics: %w", err)
+		}
+		if err := post(targets.Metrics, "/api/v1/import", "application/json", buf.Bytes()); err != nil {
+			return sum, fmt.Errorf("write metrics: %w", err)
+		}
+		sum.Series = n
+	}
+	if targets.Logs != "" {
+		var buf bytes.Buffer
+		n, err := sc.WriteLogs(&buf)
+		if err != nil {
+			return sum, fmt.Errorf("generate logs: %w", err)
+		}
+		if err := post(targets.Logs, "/insert/jsonline?_stream_fields=service.name,env", "application/stream+json", buf.Bytes()); err != nil {
+			return sum, fmt.Errorf("write logs: %w", err)
+		}
+		sum.LogEntries = n
+	}
+	if targets.Traces != "" {
+		traces := sc.Traces()
+		body, err := proto.Marshal(traces)
+		if err != nil {
+			return sum, fmt.Errorf("encode traces: %w", err)
+		}
+		if err := post(targets.Traces, "/insert/opentelemetry/v1/traces", "application/x-protobuf", body); err != nil {
+			return sum, fmt.Errorf("write traces: %w", err)
+		}
+		for _, rs := range traces.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				sum.Spans += len(ss.Spans)
+			}
+		}
+	}
+	if targets.Mirador != "" {
+		bundle := KPIs(sc.End)
+		body, err := json.Marshal(bundle)
+		if err != nil {
+			return sum, fmt.Errorf("encode KPIs: %w", err)
+		}
+		if err := post(targets.Mirador, "/api/v1/kpi/defs/import?mode=overwrite", "application/json", body); err != nil {
+			return sum, fmt.Errorf("import KPIs: %w", err)
+		}
+		sum.KPIs = len(bundle.KPIs)
+	}
+	return sum, nil
+}
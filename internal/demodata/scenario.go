@@ -0,0 +1,129 @@
+// Package demodata generates synthetic metrics, logs and traces for a few
+// fictitious services, with a scripted incident, so demo and evaluation
+// environments have realistic data to run correlation and RCA on.
+package demodata
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// Service is a fictitious service of the demo shop. Calls lists the
+// services it calls for every request.
+type Service struct {
+	Name      string
+	Instances int
+	// Baseline load: requests per second, error ratio and latencies.
+	RPS        float64
+	ErrorRatio float64
+	P50, P95   float64 // seconds
+	Calls      []string
+}
+
+// Services of the demo shop: the gateway fronts checkout and inventory,
+// checkout calls payments, which owns a database connection pool.
+var Services = []Service{
+	{Name: "api-gateway", Instances: 2, RPS: 120, ErrorRatio: 0.002, P50: 0.035, P95: 0.12, Calls: []string{"checkout", "inventory"}},
+	{Name: "checkout", Instances: 2, RPS: 40, ErrorRatio: 0.003, P50: 0.08, P95: 0.25, Calls: []string{"payments"}},
+	{Name: "payments", Instances: 2, RPS: 35, ErrorRatio: 0.004, P50: 0.06, P95: 0.15},
+	{Name: "inventory", Instances: 2, RPS: 80, ErrorRatio: 0.001, P50: 0.02, P95: 0.06},
+	{Name: "notifications", Instances: 1, RPS: 10, ErrorRatio: 0.001, P50: 0.01, P95: 0.04},
+}
+
+// dbPoolSize is the payments database connection pool size.
+const dbPoolSize = 100
+
+// Scenario is a generation run: data every Step over [Start, End), with
+// the incident over [IncidentStart, IncidentEnd). Seed makes runs
+// reproducible.
+type Scenario struct {
+	Start, End                 time.Time
+	Step                       time.Duration
+	IncidentStart, IncidentEnd time.Time
+	Seed                       uint64
+}
+
+// NewScenario returns a scenario spanning span up to end, with an incident
+// of incidentDuration starting incidentAgo before end.
+func NewScenario(end time.Time, span, step, incidentAgo, incidentDuration time.Duration, seed uint64) (Scenario, error) {
+	switch {
+	case span <= 0 || step <= 0:
+		return Scenario{}, fmt.Errorf("span and step must be positive")
+	case step > span:
+		return Scenario{}, fmt.Errorf("step must not exceed span")
+	case incidentDuration < 0 || incidentAgo < 0:
+		return Scenario{}, fmt.Errorf("incident offsets must not be negative")
+	case incidentAgo > span:
+		return Scenario{}, fmt.Errorf("incident must start within the span")
+	}
+	end = end.UTC().Truncate(step)
+	sc := Scenario{Start: end.Add(-span), End: end, Step: step, Seed: seed}
+	if incidentDuration > 0 {
+		sc.IncidentStart = end.Add(-incidentAgo)
+		sc.IncidentEnd = sc.IncidentStart.Add(incidentDuration)
+	}
+	return sc, nil
+}
+
+// load is a service's state at one instant.
+type load struct {
+	RPS, ErrorRatio, P50, P95, CPU float64
+	DBConnections                  float64 // payments only
+}
+
+// severity is how far into the incident t is: 0 outside it, ramping up to 1
+// over the first quarter and back down over the last.
+func (sc Scenario) severity(t time.Time) float64 {
+	if sc.IncidentStart.IsZero() || t.Before(sc.IncidentStart) || !t.Before(sc.IncidentEnd) {
+		return 0
+	}
+	d := float64(sc.IncidentEnd.Sub(sc.IncidentStart))
+	x := float64(t.Sub(sc.IncidentStart)) / d
+	return math.Min(1, math.Min(x, 1-x)*4)
+}
+
+// loadAt returns svc's load at t: a daily traffic curve with noise, and
+// during the incident a saturated payments connection pool that slows
+// payments down and makes checkout and the gateway fail.
+func (sc Scenario) loadAt(svc Service, t time.Time, rng *rand.Rand) load {
+	hour := float64(t.Hour()) + float64(t.Minute())/60
+	daily := 1 + 0.35*math.Sin((hour-9)/24*2*math.Pi)
+	noise := func(scale float64) float64 { return 1 + scale*(rng.Float64()*2-1) }
+
+	l := load{
+		RPS:        svc.RPS * daily * noise(0.08),
+		ErrorRatio: svc.ErrorRatio * noise(0.3),
+		P50:        svc.P50 * noise(0.1),
+		P95:        svc.P95 * noise(0.12),
+	}
+	sev := sc.severity(t)
+	switch svc.Name {
+	case "payments":
+		l.DBConnections = math.Min(dbPoolSize, dbPoolSize*(0.25*daily*noise(0.1)+0.75*sev))
+		l.ErrorRatio += 0.18 * sev
+		l.P50 += 0.8 * sev
+		l.P95 += 2.4 * sev
+	case "checkout":
+		l.ErrorRatio += 0.09 * sev
+		l.P50 += 0.9 * sev
+		l.P95 += 2.6 * sev
+	case "api-gateway":
+		l.ErrorRatio += 0.03 * sev
+		l.P95 += 1.2 * sev
+	}
+	l.CPU = math.Min(0.95, 0.1+l.RPS/svc.RPS*0.25*noise(0.1)+0.2*sev*boolFloat(svc.Name == "payments"))
+	return l
+}
+
+func boolFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func (sc Scenario) rng(stream uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(sc.Seed, stream))
+}
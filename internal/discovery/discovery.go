@@ -0,0 +1,111 @@
+package discovery
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/metrics"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// Discovery modes.
+const (
+	ModeDNS           = "dns"
+	ModeEndpointSlice = "endpointslice"
+)
+
+// Config selects how a backend's endpoints are discovered.
+type Config struct {
+	Mode           string // dns (default) | endpointslice
+	Service        string // DNS name, or Service name (short or FQDN) for endpointslice
+	Namespace      string // endpointslice only; defaults to the Service FQDN or pod namespace
+	Port           int    // endpoint port; endpointslice falls back to the slice port
+	Scheme         string // http | https
+	RefreshSeconds int    // dns: re-resolve interval; endpointslice: retry backoff
+	UseSRV         bool   // dns only
+}
+
+// Start discovers endpoints for backend and pushes them into sink without a
+// restart. Only actual changes to the endpoint set reach the sink; each one is
+// logged as a discovery event and counted in mirador_core_discovery_* metrics.
+func Start(ctx context.Context, backend string, cfg Config, sink EndpointsSink, log logger.Logger) {
+	if sink == nil {
+		return
+	}
+	tracked := newChangeTracker(backend, sink, log)
+	switch cfg.Mode {
+	case ModeEndpointSlice:
+		StartEndpointSliceDiscovery(ctx, cfg, tracked, log)
+	default:
+		StartDNSDiscovery(ctx, DNSConfig{
+			Enabled:        true,
+			Service:        cfg.Service,
+			Port:           cfg.Port,
+			Scheme:         cfg.Scheme,
+			RefreshSeconds: cfg.RefreshSeconds,
+			UseSRV:         cfg.UseSRV,
+		}, tracked, log)
+	}
+}
+
+// changeTracker forwards endpoint sets to the wrapped sink only when they
+// differ from the last set, emitting a change event for each update.
+type changeTracker struct {
+	backend string
+	sink    EndpointsSink
+	log     logger.Logger
+
+	mu      sync.Mutex
+	current map[string]struct{}
+}
+
+func newChangeTracker(backend string, sink EndpointsSink, log logger.Logger) *changeTracker {
+	return &changeTracker{backend: backend, sink: sink, log: log}
+}
+
+func (t *changeTracker) ReplaceEndpoints(eps []string) {
+	if len(eps) == 0 {
+		// Keep serving the last known endpoints rather than none at all.
+		t.log.Warn("Discovery found no endpoints; keeping previous set", "backend", t.backend)
+		return
+	}
+	next := make(map[string]struct{}, len(eps))
+	for _, e := range eps {
+		next[e] = struct{}{}
+	}
+
+	t.mu.Lock()
+	var added, removed []string
+	for e := range next {
+		if _, ok := t.current[e]; !ok {
+			added = append(added, e)
+		}
+	}
+	for e := range t.current {
+		if _, ok := next[e]; !ok {
+			removed = append(removed, e)
+		}
+	}
+	first := t.current == nil
+	if !first && len(added) == 0 && len(removed) == 0 {
+		t.mu.Unlock()
+		return
+	}
+	t.current = next
+	t.mu.Unlock()
+
+	sort.Strings(added)
+	sort.Strings(removed)
+	t.sink.ReplaceEndpoints(eps)
+	metrics.DiscoveryEndpoints.WithLabelValues(t.backend).Set(float64(len(next)))
+	if !first {
+		metrics.DiscoveryEndpointChangesTotal.WithLabelValues(t.backend).Inc()
+	}
+	t.log.Info("Discovery endpoints changed",
+		"backend", t.backend,
+		"endpoints", len(next),
+		"added", added,
+		"removed", removed,
+	)
+}
@@ -0,0 +1,141 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+type recordingSink struct {
+	mu    sync.Mutex
+	calls [][]string
+}
+
+func (r *recordingSink) ReplaceEndpoints(eps []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, append([]string(nil), eps...))
+}
+
+func (r *recordingSink) snapshot() [][]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([][]string(nil), r.calls...)
+}
+
+func TestChangeTracker_ForwardsOnlyChanges(t *testing.T) {
+	sink := &recordingSink{}
+	tr := newChangeTracker("test", sink, logger.New("error"))
+
+	tr.ReplaceEndpoints([]string{"http://a:1", "http://b:1"})
+	tr.ReplaceEndpoints([]string{"http://b:1", "http://a:1"}) // same set, new order
+	tr.ReplaceEndpoints(nil)                                  // empty keeps previous
+	tr.ReplaceEndpoints([]string{"http://a:1"})
+
+	calls := sink.snapshot()
+	if len(calls) != 2 || !reflect.DeepEqual(calls[1], []string{"http://a:1"}) {
+		t.Fatalf("unexpected forwarded calls: %v", calls)
+	}
+}
+
+func TestServiceNamespace(t *testing.T) {
+	if n, ns := serviceNamespace("weaviate.vector.svc.cluster.local", "", "pod-ns"); n != "weaviate" || ns != "vector" {
+		t.Fatalf("got %s/%s", n, ns)
+	}
+	if n, ns := serviceNamespace("vmselect", "", "pod-ns"); n != "vmselect" || ns != "pod-ns" {
+		t.Fatalf("got %s/%s", n, ns)
+	}
+}
+
+func TestListAndWatch_EndpointSlices(t *testing.T) {
+	slice := func(name string, ready bool, addrs ...string) map[string]interface{} {
+		return map[string]interface{}{
+			"metadata":  map[string]string{"name": name},
+			"endpoints": []map[string]interface{}{{"addresses": addrs, "conditions": map[string]bool{"ready": ready}}},
+			"ports":     []map[string]int{{"port": 8080}},
+		}
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/apis/discovery.k8s.io/v1/namespaces/vector/endpointslices" ||
+			r.URL.Query().Get("labelSelector") != "kubernetes.io/service-name=weaviate" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		enc := json.NewEncoder(w)
+		if r.URL.Query().Get("watch") != "true" {
+			_ = enc.Encode(map[string]interface{}{
+				"metadata": map[string]string{"resourceVersion": "10"},
+				"items":    []interface{}{slice("weaviate-abc", true, "10.0.0.1")},
+			})
+			return
+		}
+		_ = enc.Encode(map[string]interface{}{"type": "ADDED", "object": slice("weaviate-def", true, "10.0.0.2")})
+		_ = enc.Encode(map[string]interface{}{"type": "MODIFIED", "object": slice("weaviate-abc", false, "10.0.0.1")})
+	}))
+	defer srv.Close()
+
+	k := &kubeClient{baseURL: srv.URL, http: srv.Client()}
+	sink := &recordingSink{}
+	cfg := Config{Service: "weaviate.vector.svc.cluster.local"}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := listAndWatch(ctx, k, "/apis/discovery.k8s.io/v1/namespaces/vector/endpointslices", "kubernetes.io/service-name=weaviate", cfg, sink); err != nil {
+		t.Fatalf("listAndWatch: %v", err)
+	}
+	want := [][]string{
+		{"http://10.0.0.1:8080"},
+		{"http://10.0.0.1:8080", "http://10.0.0.2:8080"},
+		{"http://10.0.0.2:8080"},
+	}
+	if got := sink.snapshot(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected endpoint updates:\n got  %v\n want %v", got, want)
+	}
+}
+
+func TestRoundRobinTransport(t *testing.T) {
+	var mu sync.Mutex
+	hits := map[string]int{}
+	newBackend := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			hits[name]++
+			mu.Unlock()
+		}))
+	}
+	a, b := newBackend("a"), newBackend("b")
+	defer a.Close()
+	defer b.Close()
+
+	rt := NewRoundRobinTransport(nil)
+	client := &http.Client{Transport: rt}
+
+	// Before discovery the original host is used.
+	resp, err := client.Get(a.URL + "/v1/meta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	rt.ReplaceEndpoints([]string{a.URL, b.URL, "not a url"})
+	for i := 0; i < 4; i++ {
+		resp, err := client.Get(strings.Replace(a.URL, "127.0.0.1", "localhost", 1) + "/v1/meta")
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if hits["a"] != 3 || hits["b"] != 2 {
+		t.Fatalf("expected requests spread across endpoints, got %v", hits)
+	}
+}
@@ -0,0 +1,235 @@
+package discovery
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+var ErrNotInCluster = errors.New("not running inside a Kubernetes cluster")
+
+// StartEndpointSliceDiscovery watches discovery.k8s.io/v1 EndpointSlices of
+// a Service through the in-cluster API and pushes ready endpoints to sink as
+// they change. The pod's service account needs list/watch on endpointslices.
+func StartEndpointSliceDiscovery(ctx context.Context, cfg Config, sink EndpointsSink, log logger.Logger) {
+	client, err := newInClusterClient()
+	if err != nil {
+		log.Error("EndpointSlice discovery unavailable", "service", cfg.Service, "error", err)
+		return
+	}
+	go watchEndpointSlices(ctx, client, cfg, sink, log)
+}
+
+// kubeClient is the minimal API client needed to list and watch slices.
+type kubeClient struct {
+	baseURL   string
+	tokenFile string // re-read per request; projected tokens rotate
+	namespace string // pod namespace
+	http      *http.Client
+}
+
+func newInClusterClient() (*kubeClient, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, ErrNotInCluster
+	}
+	ca, err := os.ReadFile(serviceAccountDir + "/ca.crt")
+	if err != nil {
+		return nil, fmt.Errorf("read service account CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM(ca)
+	ns, _ := os.ReadFile(serviceAccountDir + "/namespace")
+	return &kubeClient{
+		baseURL:   "https://" + net.JoinHostPort(host, port),
+		tokenFile: serviceAccountDir + "/token",
+		namespace: strings.TrimSpace(string(ns)),
+		http: &http.Client{Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12},
+		}},
+	}, nil
+}
+
+func (k *kubeClient) get(ctx context.Context, path string, query url.Values) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, k.baseURL+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if k.tokenFile != "" {
+		if tok, err := os.ReadFile(k.tokenFile); err == nil {
+			req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(tok)))
+		}
+	}
+	resp, err := k.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("kubernetes API %s returned %d", path, resp.StatusCode)
+	}
+	return resp, nil
+}
+
+type endpointSlice struct {
+	Metadata struct {
+		Name            string `json:"name"`
+		ResourceVersion string `json:"resourceVersion"`
+	} `json:"metadata"`
+	Endpoints []struct {
+		Addresses  []string `json:"addresses"`
+		Conditions struct {
+			Ready *bool `json:"ready"`
+		} `json:"conditions"`
+	} `json:"endpoints"`
+	Ports []struct {
+		Port *int32 `json:"port"`
+	} `json:"ports"`
+}
+
+type endpointSliceList struct {
+	Metadata struct {
+		ResourceVersion string `json:"resourceVersion"`
+	} `json:"metadata"`
+	Items []endpointSlice `json:"items"`
+}
+
+type watchEvent struct {
+	Type   string        `json:"type"`
+	Object endpointSlice `json:"object"`
+}
+
+// serviceNamespace splits "svc.ns.svc.cluster.local" style names.
+func serviceNamespace(service, namespace, podNamespace string) (string, string) {
+	parts := strings.Split(service, ".")
+	name := parts[0]
+	if namespace == "" && len(parts) > 1 {
+		namespace = parts[1]
+	}
+	if namespace == "" {
+		namespace = podNamespace
+	}
+	return name, namespace
+}
+
+func watchEndpointSlices(ctx context.Context, k *kubeClient, cfg Config, sink EndpointsSink, log logger.Logger) {
+	name, ns := serviceNamespace(cfg.Service, cfg.Namespace, k.namespace)
+	path := "/apis/discovery.k8s.io/v1/namespaces/" + ns + "/endpointslices"
+	selector := "kubernetes.io/service-name=" + name
+	backoff := time.Duration(cfg.RefreshSeconds) * time.Second
+	if backoff <= 0 {
+		backoff = 5 * time.Second
+	}
+
+	for ctx.Err() == nil {
+		if err := listAndWatch(ctx, k, path, selector, cfg, sink); err != nil && ctx.Err() == nil {
+			log.Warn("EndpointSlice watch interrupted; relisting", "service", name, "namespace", ns, "error", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+		}
+	}
+}
+
+// listAndWatch lists the current slices, then follows the watch stream until
+// it ends. A nil error means the server closed the watch normally.
+func listAndWatch(ctx context.Context, k *kubeClient, path, selector string, cfg Config, sink EndpointsSink) error {
+	resp, err := k.get(ctx, path, url.Values{"labelSelector": {selector}})
+	if err != nil {
+		return err
+	}
+	var list endpointSliceList
+	err = json.NewDecoder(resp.Body).Decode(&list)
+	resp.Body.Close()
+	if err != nil {
+		return fmt.Errorf("decode endpointslice list: %w", err)
+	}
+
+	slices := make(map[string]endpointSlice, len(list.Items))
+	for _, s := range list.Items {
+		slices[s.Metadata.Name] = s
+	}
+	sink.ReplaceEndpoints(sliceEndpoints(slices, cfg))
+
+	resp, err = k.get(ctx, path, url.Values{
+		"labelSelector":       {selector},
+		"watch":               {"true"},
+		"resourceVersion":     {list.Metadata.ResourceVersion},
+		"allowWatchBookmarks": {"true"},
+		"timeoutSeconds":      {"300"},
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	dec := json.NewDecoder(resp.Body)
+	for {
+		var ev watchEvent
+		if err := dec.Decode(&ev); err != nil {
+			if ctx.Err() != nil || errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("decode watch event: %w", err)
+		}
+		switch ev.Type {
+		case "ADDED", "MODIFIED":
+			slices[ev.Object.Metadata.Name] = ev.Object
+		case "DELETED":
+			delete(slices, ev.Object.Metadata.Name)
+		case "ERROR":
+			// Usually 410 Gone: our resourceVersion expired, so relist.
+			return errors.New("watch returned an error event")
+		default:
+			continue
+		}
+		sink.ReplaceEndpoints(sliceEndpoints(slices, cfg))
+	}
+}
+
+// sliceEndpoints flattens ready addresses into sorted endpoint URLs.
+func sliceEndpoints(slices map[string]endpointSlice, cfg Config) []string {
+	scheme := cfg.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	seen := map[string]struct{}{}
+	out := []string{}
+	for _, s := range slices {
+		port := cfg.Port
+		if port == 0 && len(s.Ports) > 0 && s.Ports[0].Port != nil {
+			port = int(*s.Ports[0].Port)
+		}
+		for _, ep := range s.Endpoints {
+			if ep.Conditions.Ready != nil && !*ep.Conditions.Ready {
+				continue
+			}
+			for _, addr := range ep.Addresses {
+				u := fmt.Sprintf("%s://%s", scheme, net.JoinHostPort(addr, fmt.Sprint(port)))
+				if _, ok := seen[u]; !ok {
+					seen[u] = struct{}{}
+					out = append(out, u)
+				}
+			}
+		}
+	}
+	sort.Strings(out)
+	return out
+}
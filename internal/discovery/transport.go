@@ -0,0 +1,60 @@
+package discovery
+
+import (
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+)
+
+// RoundRobinTransport rewrites each request to the next discovered endpoint.
+// It lets clients that take a single fixed host (such as the Weaviate SDK)
+// follow discovery: until endpoints are discovered requests go to the
+// original host.
+type RoundRobinTransport struct {
+	base http.RoundTripper
+
+	mu        sync.RWMutex
+	endpoints []*url.URL
+	next      atomic.Uint64
+}
+
+// NewRoundRobinTransport wraps base (http.DefaultTransport when nil).
+func NewRoundRobinTransport(base http.RoundTripper) *RoundRobinTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &RoundRobinTransport{base: base}
+}
+
+// ReplaceEndpoints implements EndpointsSink. Unparseable entries are skipped.
+func (t *RoundRobinTransport) ReplaceEndpoints(eps []string) {
+	parsed := make([]*url.URL, 0, len(eps))
+	for _, e := range eps {
+		if u, err := url.Parse(e); err == nil && u.Host != "" {
+			parsed = append(parsed, u)
+		}
+	}
+	t.mu.Lock()
+	t.endpoints = parsed
+	t.mu.Unlock()
+}
+
+func (t *RoundRobinTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.RLock()
+	n := len(t.endpoints)
+	var target *url.URL
+	if n > 0 {
+		target = t.endpoints[int(t.next.Add(1)-1)%n]
+	}
+	t.mu.RUnlock()
+	if target == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	out := req.Clone(req.Context())
+	out.URL.Scheme = target.Scheme
+	out.URL.Host = target.Host
+	out.Host = target.Host
+	return t.base.RoundTrip(out)
+}
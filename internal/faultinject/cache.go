@@ -0,0 +1,159 @@
+package faultinject
+
+import (
+	"context"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+)
+
+// faultyCache applies Valkey faults before delegating to the wrapped cache.
+type faultyCache struct {
+	inner cache.ValkeyCluster
+	inj   *Injector
+}
+
+// WrapCache returns a cache that applies TargetValkey faults from the
+// process-wide injector. Only wrap when injection is enabled so production
+// paths keep the concrete cache type.
+func WrapCache(inner cache.ValkeyCluster) cache.ValkeyCluster {
+	return NewCache(inner, defaultInjector)
+}
+
+// NewCache is WrapCache with an explicit injector.
+func NewCache(inner cache.ValkeyCluster, inj *Injector) cache.ValkeyCluster {
+	return &faultyCache{inner: inner, inj: inj}
+}
+
+func (c *faultyCache) apply(ctx context.Context) error { return c.inj.Apply(ctx, TargetValkey) }
+
+func (c *faultyCache) Get(ctx context.Context, key string) ([]byte, error) {
+	if err := c.apply(ctx); err != nil {
+		return nil, err
+	}
+	return c.inner.Get(ctx, key)
+}
+
+func (c *faultyCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	if err := c.apply(ctx); err != nil {
+		return err
+	}
+	return c.inner.Set(ctx, key, value, ttl)
+}
+
+func (c *faultyCache) Delete(ctx context.Context, key string) error {
+	if err := c.apply(ctx); err != nil {
+		return err
+	}
+	return c.inner.Delete(ctx, key)
+}
+
+func (c *faultyCache) AcquireLock(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	if err := c.apply(ctx); err != nil {
+		return false, err
+	}
+	return c.inner.AcquireLock(ctx, key, ttl)
+}
+
+func (c *faultyCache) ReleaseLock(ctx context.Context, key string) error {
+	if err := c.apply(ctx); err != nil {
+		return err
+	}
+	return c.inner.ReleaseLock(ctx, key)
+}
+
+func (c *faultyCache) CacheQueryResult(ctx context.Context, queryHash string, result interface{}, ttl time.Duration) error {
+	if err := c.apply(ctx); err != nil {
+		return err
+	}
+	return c.inner.CacheQueryResult(ctx, queryHash, result, ttl)
+}
+
+func (c *faultyCache) GetCachedQueryResult(ctx context.Context, queryHash string) ([]byte, error) {
+	if err := c.apply(ctx); err != nil {
+		return nil, err
+	}
+	return c.inner.GetCachedQueryResult(ctx, queryHash)
+}
+
+func (c *faultyCache) AddToPatternIndex(ctx context.Context, patternKey string, cacheKey string) error {
+	if err := c.apply(ctx); err != nil {
+		return err
+	}
+	return c.inner.AddToPatternIndex(ctx, patternKey, cacheKey)
+}
+
+func (c *faultyCache) GetPatternIndexKeys(ctx context.Context, patternKey string) ([]string, error) {
+	if err := c.apply(ctx); err != nil {
+		return nil, err
+	}
+	return c.inner.GetPatternIndexKeys(ctx, patternKey)
+}
+
+func (c *faultyCache) DeletePatternIndex(ctx context.Context, patternKey string) error {
+	if err := c.apply(ctx); err != nil {
+		return err
+	}
+	return c.inner.DeletePatternIndex(ctx, patternKey)
+}
+
+func (c *faultyCache) DeleteMultiple(ctx context.Context, keys []string) error {
+	if err := c.apply(ctx); err != nil {
+		return err
+	}
+	return c.inner.DeleteMultiple(ctx, keys)
+}
+
+func (c *faultyCache) GetMemoryInfo(ctx context.Context) (*cache.CacheMemoryInfo, error) {
+	if err := c.apply(ctx); err != nil {
+		return nil, err
+	}
+	return c.inner.GetMemoryInfo(ctx)
+}
+
+func (c *faultyCache) AdjustCacheTTL(ctx context.Context, keyPattern string, newTTL time.Duration) error {
+	if err := c.apply(ctx); err != nil {
+		return err
+	}
+	return c.inner.AdjustCacheTTL(ctx, keyPattern, newTTL)
+}
+
+func (c *faultyCache) CleanupExpiredEntries(ctx context.Context, keyPattern string) (int64, error) {
+	if err := c.apply(ctx); err != nil {
+		return 0, err
+	}
+	return c.inner.CleanupExpiredEntries(ctx, keyPattern)
+}
+
+func (c *faultyCache) ScanKeys(ctx context.Context, prefix string, fn func(keys []string) error) error {
+	if err := c.apply(ctx); err != nil {
+		return err
+	}
+	return c.inner.ScanKeys(ctx, prefix, fn)
+}
+
+func (c *faultyCache) DeleteByPrefix(ctx context.Context, prefix string) (int64, error) {
+	if err := c.apply(ctx); err != nil {
+		return 0, err
+	}
+	return c.inner.DeleteByPrefix(ctx, prefix)
+}
+
+// HealthCheck keeps readiness probes meaningful: it fails while a Valkey
+// fault fires and otherwise defers to the wrapped cache when it supports it.
+func (c *faultyCache) HealthCheck(ctx context.Context) error {
+	if err := c.apply(ctx); err != nil {
+		return err
+	}
+	if hc, ok := c.inner.(interface{ HealthCheck(context.Context) error }); ok {
+		return hc.HealthCheck(ctx)
+	}
+	return nil
+}
+
+// Stop forwards shutdown to the wrapped cache when supported.
+func (c *faultyCache) Stop() {
+	if s, ok := c.inner.(interface{ Stop() }); ok {
+		s.Stop()
+	}
+}
@@ -0,0 +1,198 @@
+// Package faultinject simulates latency and errors from backing dependencies
+// (Weaviate, Valkey, VictoriaMetrics/Logs/Traces) at the client layer so that
+// degraded-mode behaviour can be integration-tested in staging.
+//
+// Injection is off unless Enable is called at startup, which the server only
+// does when fault_injection.enabled is set outside production. While disabled,
+// Apply is a single atomic load.
+package faultinject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Target names a dependency that faults can be injected into.
+type Target string
+
+const (
+	TargetWeaviate        Target = "weaviate"
+	TargetValkey          Target = "valkey"
+	TargetVictoriaMetrics Target = "victoria_metrics"
+	TargetVictoriaLogs    Target = "victoria_logs"
+	TargetVictoriaTraces  Target = "victoria_traces"
+)
+
+// Targets lists every supported target.
+var Targets = []Target{TargetWeaviate, TargetValkey, TargetVictoriaMetrics, TargetVictoriaLogs, TargetVictoriaTraces}
+
+// ErrInjected is wrapped by every error produced by an injected fault.
+var ErrInjected = errors.New("injected fault")
+
+// Fault describes the misbehaviour to simulate for one target.
+type Fault struct {
+	Target Target `json:"target"`
+	// LatencyMS is added before each call; JitterMS adds up to that much more.
+	LatencyMS int `json:"latencyMs"`
+	JitterMS  int `json:"jitterMs,omitempty"`
+	// ErrorRate (0-1) is the probability that a call fails.
+	ErrorRate    float64    `json:"errorRate"`
+	ErrorMessage string     `json:"errorMessage,omitempty"`
+	ExpiresAt    *time.Time `json:"expiresAt,omitempty"`
+}
+
+// Validate checks a fault definition.
+func (f *Fault) Validate() error {
+	if !IsValidTarget(f.Target) {
+		return fmt.Errorf("unknown target %q, expected one of %v", f.Target, Targets)
+	}
+	if f.LatencyMS < 0 || f.JitterMS < 0 {
+		return fmt.Errorf("latencyMs and jitterMs must not be negative")
+	}
+	if f.ErrorRate < 0 || f.ErrorRate > 1 {
+		return fmt.Errorf("errorRate must be between 0 and 1, got %v", f.ErrorRate)
+	}
+	return nil
+}
+
+// IsValidTarget reports whether t is a supported target.
+func IsValidTarget(t Target) bool {
+	for _, known := range Targets {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Injector holds the active faults.
+type Injector struct {
+	enabled atomic.Bool
+
+	mu     sync.RWMutex
+	faults map[Target]*Fault
+	rnd    *rand.Rand
+}
+
+// NewInjector returns a disabled injector.
+func NewInjector() *Injector {
+	return &Injector{
+		faults: make(map[Target]*Fault),
+		rnd:    rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+var defaultInjector = NewInjector()
+
+// Default returns the process-wide injector used by client wrappers.
+func Default() *Injector { return defaultInjector }
+
+// Enable turns injection on for the process-wide injector.
+func Enable() { defaultInjector.SetEnabled(true) }
+
+// SetEnabled turns injection on or off. Disabling also clears all faults.
+func (i *Injector) SetEnabled(on bool) {
+	i.enabled.Store(on)
+	if !on {
+		i.ClearAll()
+	}
+}
+
+// Enabled reports whether injection is on.
+func (i *Injector) Enabled() bool { return i.enabled.Load() }
+
+// Set installs or replaces the fault for f.Target.
+func (i *Injector) Set(f Fault) error {
+	if !i.Enabled() {
+		return fmt.Errorf("fault injection is disabled")
+	}
+	if err := f.Validate(); err != nil {
+		return err
+	}
+	i.mu.Lock()
+	i.faults[f.Target] = &f
+	i.mu.Unlock()
+	return nil
+}
+
+// Clear removes the fault for a target.
+func (i *Injector) Clear(t Target) {
+	i.mu.Lock()
+	delete(i.faults, t)
+	i.mu.Unlock()
+}
+
+// ClearAll removes all faults.
+func (i *Injector) ClearAll() {
+	i.mu.Lock()
+	i.faults = make(map[Target]*Fault)
+	i.mu.Unlock()
+}
+
+// List returns the active (non-expired) faults ordered by target.
+func (i *Injector) List() []Fault {
+	now := time.Now()
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	out := make([]Fault, 0, len(i.faults))
+	for _, f := range i.faults {
+		if f.ExpiresAt == nil || now.Before(*f.ExpiresAt) {
+			out = append(out, *f)
+		}
+	}
+	sort.Slice(out, func(a, b int) bool { return out[a].Target < out[b].Target })
+	return out
+}
+
+// Apply simulates the configured fault for a target: it sleeps for the
+// configured latency (returning early if ctx is done) and then fails with the
+// configured probability. It returns nil when no fault applies.
+func (i *Injector) Apply(ctx context.Context, t Target) error {
+	if !i.Enabled() {
+		return nil
+	}
+
+	i.mu.Lock()
+	f, ok := i.faults[t]
+	if ok && f.ExpiresAt != nil && time.Now().After(*f.ExpiresAt) {
+		delete(i.faults, t)
+		ok = false
+	}
+	var delay time.Duration
+	var fail bool
+	if ok {
+		delay = time.Duration(f.LatencyMS) * time.Millisecond
+		if f.JitterMS > 0 {
+			delay += time.Duration(i.rnd.Intn(f.JitterMS+1)) * time.Millisecond
+		}
+		fail = f.ErrorRate > 0 && i.rnd.Float64() < f.ErrorRate
+	}
+	i.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	if fail {
+		msg := f.ErrorMessage
+		if msg == "" {
+			msg = "simulated failure"
+		}
+		return fmt.Errorf("%w: %s: %s", ErrInjected, t, msg)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package faultinject
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func TestInjector_DisabledIsNoop(t *testing.T) {
+	inj := NewInjector()
+	if err := inj.Set(Fault{Target: TargetValkey, ErrorRate: 1}); err == nil {
+		t.Fatal("expected Set to fail while disabled")
+	}
+	if err := inj.Apply(context.Background(), TargetValkey); err != nil {
+		t.Fatalf("expected no fault while disabled, got %v", err)
+	}
+}
+
+func TestInjector_ErrorsLatencyAndExpiry(t *testing.T) {
+	inj := NewInjector()
+	inj.SetEnabled(true)
+	ctx := context.Background()
+
+	if err := inj.Set(Fault{Target: "mysql"}); err == nil {
+		t.Fatal("expected unknown target to be rejected")
+	}
+	if err := inj.Set(Fault{Target: TargetWeaviate, ErrorRate: 1.5}); err == nil {
+		t.Fatal("expected invalid error rate to be rejected")
+	}
+
+	if err := inj.Set(Fault{Target: TargetWeaviate, ErrorRate: 1, ErrorMessage: "boom"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := inj.Apply(ctx, TargetWeaviate); !errors.Is(err, ErrInjected) {
+		t.Fatalf("expected injected error, got %v", err)
+	}
+	if err := inj.Apply(ctx, TargetValkey); err != nil {
+		t.Fatalf("other targets must be unaffected, got %v", err)
+	}
+
+	if err := inj.Set(Fault{Target: TargetValkey, LatencyMS: 1000}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if err := inj.Apply(cctx, TargetValkey); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected latency to honour context deadline, got %v", err)
+	}
+
+	past := time.Now().Add(-time.Second)
+	if err := inj.Set(Fault{Target: TargetValkey, ErrorRate: 1, ExpiresAt: &past}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := inj.Apply(ctx, TargetValkey); err != nil {
+		t.Fatalf("expired fault must not apply, got %v", err)
+	}
+	if got := len(inj.List()); got != 1 {
+		t.Fatalf("expected only the weaviate fault listed, got %d", got)
+	}
+
+	inj.SetEnabled(false)
+	if got := len(inj.List()); got != 0 {
+		t.Fatalf("disabling must clear faults, got %d", got)
+	}
+}
+
+func TestTransportAndCacheWrappers(t *testing.T) {
+	inj := NewInjector()
+	inj.SetEnabled(true)
+	_ = inj.Set(Fault{Target: TargetVictoriaMetrics, ErrorRate: 1})
+	_ = inj.Set(Fault{Target: TargetValkey, ErrorRate: 1})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	faulty := &http.Client{Transport: NewTransport(nil, TargetVictoriaMetrics, inj)}
+	if _, err := faulty.Get(srv.URL); !errors.Is(err, ErrInjected) {
+		t.Fatalf("expected injected transport error, got %v", err)
+	}
+	healthy := &http.Client{Transport: NewTransport(nil, TargetVictoriaLogs, inj)}
+	resp, err := healthy.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	c := NewCache(cache.NewNoopValkeyCache(logger.New("error")), inj)
+	if err := c.Set(context.Background(), "k", "v", time.Minute); !errors.Is(err, ErrInjected) {
+		t.Fatalf("expected injected cache error, got %v", err)
+	}
+	inj.Clear(TargetValkey)
+	if err := c.Set(context.Background(), "k", "v", time.Minute); err != nil {
+		t.Fatalf("unexpected error after clearing fault: %v", err)
+	}
+}
@@ -0,0 +1,32 @@
+package faultinject
+
+import (
+	"net/http"
+)
+
+type transport struct {
+	base   http.RoundTripper
+	target Target
+	inj    *Injector
+}
+
+// WrapTransport returns a RoundTripper that applies faults for target from the
+// process-wide injector before delegating to base (http.DefaultTransport when nil).
+func WrapTransport(base http.RoundTripper, target Target) http.RoundTripper {
+	return NewTransport(base, target, defaultInjector)
+}
+
+// NewTransport is WrapTransport with an explicit injector.
+func NewTransport(base http.RoundTripper, target Target, inj *Injector) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{base: base, target: target, inj: inj}
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.inj.Apply(req.Context(), t.target); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,108 @@
+package loadreplay
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const sampleLog = `{"level":"info","timestamp":"2025-01-02T10:00:01.000Z","message":"HTTP Request","method":"POST","path":"/api/v1/unified/query","query":"","status":200,"tenant_id":"prod-a","content_length":15,"request_body":"{\"query\":\"up\"}"}
+{"level":"info","timestamp":"2025-01-02T10:00:00.000Z","message":"HTTP Request","method":"GET","path":"/api/v1/kpi/defs/3f2b9c1e-8d4a-4c2b-9a77-1c2d3e4f5a6b","query":"x=1","status":200,"tenant_id":"prod-b","content_length":0}
+{"level":"info","timestamp":"2025-01-02T10:00:02.000Z","message":"HTTP Request","method":"POST","path":"/api/v1/kpi/defs","status":200,"content_length":4096}
+{"level":"info","timestamp":"2025-01-02T10:00:03.000Z","message":"HTTP Request","method":"GET","path":"/health","status":200}
+not json
+`
+
+func TestParseRecords(t *testing.T) {
+	recs, stats, err := ParseRecords(strings.NewReader(sampleLog), []string{"/api/v1/"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(recs) != 2 {
+		t.Fatalf("expected 2 records, got %d (%+v)", len(recs), stats)
+	}
+	if stats.Invalid != 1 || stats.Filtered != 1 || stats.Truncated != 1 {
+		t.Fatalf("unexpected parse stats: %+v", stats)
+	}
+	if recs[0].Method != "GET" || recs[1].Body != `{"query":"up"}` || recs[1].Tenant != "prod-a" {
+		t.Fatalf("records not parsed/sorted as expected: %+v", recs)
+	}
+}
+
+func TestReplayer_TenantMappingAndReport(t *testing.T) {
+	var mu sync.Mutex
+	tenants := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		tenants[r.Header.Get(TenantHeader)]++
+		mu.Unlock()
+		if strings.HasPrefix(r.URL.Path, "/api/v1/kpi/") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	recs, _, err := ParseRecords(strings.NewReader(sampleLog), []string{"/api/v1/"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	r, err := NewReplayer(Config{Target: srv.URL, Speed: 0, Concurrency: 2, TenantMap: map[string]string{"prod-a": "stage-a"}}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rep := r.Run(context.Background(), recs)
+
+	if rep.Requests != 2 || rep.Errors != 1 {
+		t.Fatalf("unexpected totals: requests=%d errors=%d", rep.Requests, rep.Errors)
+	}
+	if tenants["stage-a"] != 1 || tenants["prod-b"] != 1 {
+		t.Fatalf("tenant mapping not applied: %v", tenants)
+	}
+	var found bool
+	for _, ep := range rep.Endpoints {
+		if ep.Endpoint == "GET /api/v1/kpi/defs/:id" {
+			found = ep.StatusCodes["500"] == 1
+		}
+	}
+	if !found {
+		t.Fatalf("expected normalized kpi endpoint with a 500, got %+v", rep.Endpoints)
+	}
+}
+
+func TestReplayer_HonoursSpeed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	base := time.Now()
+	recs := []Record{
+		{Timestamp: base, Method: "GET", Path: "/a"},
+		{Timestamp: base.Add(200 * time.Millisecond), Method: "GET", Path: "/b"},
+	}
+	r, _ := NewReplayer(Config{Target: srv.URL, Speed: 4, Concurrency: 1}, nil)
+	start := time.Now()
+	r.Run(context.Background(), recs)
+	if elapsed := time.Since(start); elapsed < 45*time.Millisecond {
+		t.Fatalf("expected ~50ms of scheduled spacing at 4x speed, took %v", elapsed)
+	}
+}
+
+func TestNormalizePath(t *testing.T) {
+	cases := map[string]string{
+		"/api/v1/kpi/defs/12345":           "/api/v1/kpi/defs/:id",
+		"/api/v1/kpi/defs/kpi_a1b2c3d4e5":  "/api/v1/kpi/defs/:id",
+		"/api/v1/unified/failures/list":    "/api/v1/unified/failures/list",
+		"/api/v1/admin/feature-flags/uql2": "/api/v1/admin/feature-flags/uql2",
+	}
+	for in, want := range cases {
+		if got := NormalizePath(in); got != want {
+			t.Fatalf("NormalizePath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
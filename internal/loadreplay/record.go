@@ -0,0 +1,151 @@
+// Package loadreplay replays recorded API requests against a target
+// environment and reports per-endpoint latency distributions. It backs the
+// cmd/loadreplay capacity-planning tool.
+package loadreplay
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Record is a single recorded request.
+type Record struct {
+	Timestamp time.Time
+	Method    string
+	Path      string
+	Query     string
+	Body      string
+	Tenant    string
+}
+
+// rawRecord accepts both the request log lines written by
+// middleware.RequestLoggerWithBody and a minimal hand-written form using
+// "body"/"tenant" keys.
+type rawRecord struct {
+	Timestamp     string `json:"timestamp"`
+	Method        string `json:"method"`
+	Path          string `json:"path"`
+	Query         string `json:"query"`
+	RequestBody   string `json:"request_body"`
+	Body          string `json:"body"`
+	TenantID      string `json:"tenant_id"`
+	Tenant        string `json:"tenant"`
+	ContentLength int64  `json:"content_length"`
+}
+
+// ParseStats counts the input lines that were not turned into records.
+type ParseStats struct {
+	Lines     int `json:"lines"`
+	Invalid   int `json:"invalid"`   // not JSON or no method/path/timestamp
+	Filtered  int `json:"filtered"`  // path outside the include prefixes
+	Truncated int `json:"truncated"` // body was not captured in the log
+}
+
+// ParseRecords reads JSON Lines from r and returns the replayable records in
+// timestamp order. Only paths starting with one of include are kept (all
+// paths when include is empty). Requests whose body was dropped by the
+// logger are skipped, since replaying them without a body would not be
+// representative.
+func ParseRecords(r io.Reader, include []string) ([]Record, ParseStats, error) {
+	var (
+		out   []Record
+		stats ParseStats
+	)
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		stats.Lines++
+
+		var raw rawRecord
+		if err := json.Unmarshal([]byte(line), &raw); err != nil || raw.Method == "" || raw.Path == "" {
+			stats.Invalid++
+			continue
+		}
+		ts, err := parseTimestamp(raw.Timestamp)
+		if err != nil {
+			stats.Invalid++
+			continue
+		}
+		if !hasAnyPrefix(raw.Path, include) {
+			stats.Filtered++
+			continue
+		}
+		body := firstNonEmpty(raw.RequestBody, raw.Body)
+		if body == "" && raw.ContentLength > 0 {
+			stats.Truncated++
+			continue
+		}
+		out = append(out, Record{
+			Timestamp: ts,
+			Method:    strings.ToUpper(raw.Method),
+			Path:      raw.Path,
+			Query:     raw.Query,
+			Body:      body,
+			Tenant:    firstNonEmpty(raw.TenantID, raw.Tenant),
+		})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, stats, fmt.Errorf("read records: %w", err)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Timestamp.Before(out[j].Timestamp) })
+	return out, stats, nil
+}
+
+// ParseKeyValues parses "k=v,k2=v2" into a map. It is used for the tenant
+// mapping ("src=dst") and extra request headers.
+func ParseKeyValues(s string) (map[string]string, error) {
+	m := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(k) == "" {
+			return nil, fmt.Errorf("invalid pair %q, expected key=value", pair)
+		}
+		m[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return m, nil
+}
+
+func parseTimestamp(s string) (time.Time, error) {
+	// zap's ISO8601 encoder writes "2006-01-02T15:04:05.000Z0700".
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.000Z0700"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognised timestamp %q", s)
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func firstNonEmpty(vals ...string) string {
+	for _, v := range vals {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
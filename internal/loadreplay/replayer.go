@@ -0,0 +1,148 @@
+package loadreplay
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TenantHeader carries the (mapped) tenant of each replayed request.
+const TenantHeader = "X-Tenant-ID"
+
+// Config controls a replay run.
+type Config struct {
+	// Target is the base URL of the environment under test.
+	Target string
+	// Speed scales the recorded inter-arrival times: 1 replays in real
+	// time, 2 twice as fast. 0 replays as fast as the workers allow.
+	Speed float64
+	// Concurrency is the number of in-flight requests allowed.
+	Concurrency int
+	// TenantMap rewrites recorded tenants; unmapped tenants are kept.
+	TenantMap map[string]string
+	// Headers are added to every request (e.g. gateway credentials).
+	Headers map[string]string
+	Timeout time.Duration
+}
+
+// Replayer sends recorded requests to a target.
+type Replayer struct {
+	cfg    Config
+	client *http.Client
+}
+
+// NewReplayer creates a replayer. A nil client uses one with cfg.Timeout.
+func NewReplayer(cfg Config, client *http.Client) (*Replayer, error) {
+	if cfg.Target == "" {
+		return nil, fmt.Errorf("target URL is required")
+	}
+	if cfg.Speed < 0 {
+		return nil, fmt.Errorf("speed must not be negative")
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 1
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+	cfg.Target = strings.TrimRight(cfg.Target, "/")
+	if client == nil {
+		client = &http.Client{Timeout: cfg.Timeout}
+	}
+	return &Replayer{cfg: cfg, client: client}, nil
+}
+
+// Run replays records in order, honouring their recorded spacing scaled by
+// Speed, and returns the collected report. Cancelling ctx stops dispatching;
+// in-flight requests are awaited.
+func (r *Replayer) Run(ctx context.Context, records []Record) *Report {
+	rep := newReport()
+	if len(records) == 0 {
+		return rep.finish(0)
+	}
+
+	jobs := make(chan Record)
+	var wg sync.WaitGroup
+	for i := 0; i < r.cfg.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for rec := range jobs {
+				r.send(ctx, rec, rep)
+			}
+		}()
+	}
+
+	start := time.Now()
+	first := records[0].Timestamp
+dispatch:
+	for _, rec := range records {
+		if r.cfg.Speed > 0 {
+			due := start.Add(time.Duration(float64(rec.Timestamp.Sub(first)) / r.cfg.Speed))
+			if wait := time.Until(due); wait > 0 {
+				select {
+				case <-ctx.Done():
+					break dispatch
+				case <-time.After(wait):
+				}
+			} else {
+				rep.recordLag(-wait)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case jobs <- rec:
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	return rep.finish(time.Since(start))
+}
+
+func (r *Replayer) send(ctx context.Context, rec Record, rep *Report) {
+	url := r.cfg.Target + rec.Path
+	if rec.Query != "" {
+		url += "?" + rec.Query
+	}
+	var body io.Reader
+	if rec.Body != "" {
+		body = strings.NewReader(rec.Body)
+	}
+	req, err := http.NewRequestWithContext(ctx, rec.Method, url, body)
+	if err != nil {
+		rep.observe(rec, 0, 0, err)
+		return
+	}
+	if rec.Body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range r.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+	if tenant := r.mapTenant(rec.Tenant); tenant != "" {
+		req.Header.Set(TenantHeader, tenant)
+	}
+
+	began := time.Now()
+	resp, err := r.client.Do(req)
+	latency := time.Since(began)
+	if err != nil {
+		rep.observe(rec, 0, latency, err)
+		return
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	rep.observe(rec, resp.StatusCode, latency, nil)
+}
+
+func (r *Replayer) mapTenant(tenant string) string {
+	if mapped, ok := r.cfg.TenantMap[tenant]; ok {
+		return mapped
+	}
+	return tenant
+}
@@ -0,0 +1,158 @@
+package loadreplay
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EndpointStats is the latency distribution for one method+route.
+type EndpointStats struct {
+	Endpoint    string         `json:"endpoint"`
+	Requests    int            `json:"requests"`
+	Errors      int            `json:"errors"` // transport errors and 5xx
+	StatusCodes map[string]int `json:"statusCodes"`
+	MeanMS      float64        `json:"meanMs"`
+	P50MS       float64        `json:"p50Ms"`
+	P90MS       float64        `json:"p90Ms"`
+	P95MS       float64        `json:"p95Ms"`
+	P99MS       float64        `json:"p99Ms"`
+	MaxMS       float64        `json:"maxMs"`
+
+	latencies []time.Duration
+}
+
+// Report summarises a replay run.
+type Report struct {
+	Requests   int     `json:"requests"`
+	Errors     int     `json:"errors"`
+	DurationMS float64 `json:"durationMs"`
+	Throughput float64 `json:"throughputRps"`
+	// MaxLagMS is how far dispatch fell behind the recorded schedule; a large
+	// value means the target (or -concurrency) could not keep up.
+	MaxLagMS  float64          `json:"maxLagMs"`
+	Endpoints []*EndpointStats `json:"endpoints"`
+
+	mu     sync.Mutex
+	byKey  map[string]*EndpointStats
+	maxLag time.Duration
+}
+
+func newReport() *Report {
+	return &Report{byKey: make(map[string]*EndpointStats)}
+}
+
+func (r *Report) observe(rec Record, status int, latency time.Duration, err error) {
+	key := rec.Method + " " + NormalizePath(rec.Path)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	st, ok := r.byKey[key]
+	if !ok {
+		st = &EndpointStats{Endpoint: key, StatusCodes: make(map[string]int)}
+		r.byKey[key] = st
+	}
+	st.Requests++
+	r.Requests++
+	code := "error"
+	if err == nil {
+		code = fmt.Sprintf("%d", status)
+	}
+	st.StatusCodes[code]++
+	if err != nil || status >= 500 {
+		st.Errors++
+		r.Errors++
+	}
+	if err == nil {
+		st.latencies = append(st.latencies, latency)
+	}
+}
+
+func (r *Report) recordLag(lag time.Duration) {
+	r.mu.Lock()
+	if lag > r.maxLag {
+		r.maxLag = lag
+	}
+	r.mu.Unlock()
+}
+
+func (r *Report) finish(elapsed time.Duration) *Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.DurationMS = ms(elapsed)
+	if elapsed > 0 {
+		r.Throughput = float64(r.Requests) / elapsed.Seconds()
+	}
+	r.MaxLagMS = ms(r.maxLag)
+	r.Endpoints = r.Endpoints[:0]
+	for _, st := range r.byKey {
+		sort.Slice(st.latencies, func(i, j int) bool { return st.latencies[i] < st.latencies[j] })
+		if n := len(st.latencies); n > 0 {
+			var sum time.Duration
+			for _, l := range st.latencies {
+				sum += l
+			}
+			st.MeanMS = ms(sum / time.Duration(n))
+			st.P50MS = ms(percentile(st.latencies, 0.50))
+			st.P90MS = ms(percentile(st.latencies, 0.90))
+			st.P95MS = ms(percentile(st.latencies, 0.95))
+			st.P99MS = ms(percentile(st.latencies, 0.99))
+			st.MaxMS = ms(st.latencies[n-1])
+		}
+		r.Endpoints = append(r.Endpoints, st)
+	}
+	sort.Slice(r.Endpoints, func(i, j int) bool {
+		if r.Endpoints[i].Requests != r.Endpoints[j].Requests {
+			return r.Endpoints[i].Requests > r.Endpoints[j].Requests
+		}
+		return r.Endpoints[i].Endpoint < r.Endpoints[j].Endpoint
+	})
+	return r
+}
+
+// WriteTable prints a human-readable summary.
+func (r *Report) WriteTable(w io.Writer) {
+	fmt.Fprintf(w, "Requests: %d  Errors: %d  Duration: %.1fs  Throughput: %.1f req/s  Max lag: %.0fms\n\n",
+		r.Requests, r.Errors, r.DurationMS/1000, r.Throughput, r.MaxLagMS)
+	fmt.Fprintf(w, "%-50s %8s %7s %9s %9s %9s %9s %9s\n", "ENDPOINT", "REQS", "ERRS", "P50(ms)", "P90(ms)", "P95(ms)", "P99(ms)", "MAX(ms)")
+	for _, st := range r.Endpoints {
+		fmt.Fprintf(w, "%-50s %8d %7d %9.1f %9.1f %9.1f %9.1f %9.1f\n",
+			truncate(st.Endpoint, 50), st.Requests, st.Errors, st.P50MS, st.P90MS, st.P95MS, st.P99MS, st.MaxMS)
+	}
+}
+
+var idSegment = regexp.MustCompile(`^([0-9a-fA-F-]{16,}|[0-9]+|[A-Za-z0-9_-]*[0-9][A-Za-z0-9_-]{7,})$`)
+
+// NormalizePath collapses ID-like path segments to ":id" so that
+// /api/v1/kpi/defs/abc123... and /api/v1/kpi/defs/def456... group together.
+func NormalizePath(path string) string {
+	parts := strings.Split(path, "/")
+	for i, p := range parts {
+		if p != "" && idSegment.MatchString(p) {
+			parts[i] = ":id"
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// percentile uses the nearest-rank method on sorted latencies.
+func percentile(sorted []time.Duration, q float64) time.Duration {
+	idx := int(math.Ceil(q*float64(len(sorted)))) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	return sorted[idx]
+}
+
+func ms(d time.Duration) float64 { return float64(d) / float64(time.Millisecond) }
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-3] + "..."
+}
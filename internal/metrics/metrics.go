@@ -273,4 +273,112 @@ var (
 		},
 		[]string{"operation"},
 	)
+
+	WeaviateRetriesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "mirador_core_weaviate_retries_total",
+			Help: "Total number of retried Weaviate HTTP requests by cause",
+		},
+		[]string{"reason"}, // transport, 502, 503, 504
+	)
+
+	WeaviateCircuitState = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "mirador_core_weaviate_circuit_state",
+			Help: "Weaviate circuit breaker state (0 closed, 1 half-open, 2 open)",
+		},
+	)
+
+	WeaviateCircuitRejectionsTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "mirador_core_weaviate_circuit_rejections_total",
+			Help: "Total number of Weaviate requests failed fast by the open circuit breaker",
+		},
+	)
+
+	BackendRetriesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "mirador_core_backend_retries_total",
+			Help: "Total number of retried VictoriaMetrics/VictoriaLogs HTTP requests by backend and cause",
+		},
+		[]string{"backend", "reason"}, // victoriametrics/victorialogs; transport, 500, 502, ...
+	)
+
+	// Data residency metrics
+	ResidencyBlockedRequestsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "mirador_core_residency_blocked_requests_total",
+			Help: "Total number of backend requests refused because the host is outside the tenant's region",
+		},
+		[]string{"backend"},
+	)
+
+	// Endpoint discovery metrics
+	DiscoveryEndpoints = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "mirador_core_discovery_endpoints",
+			Help: "Number of endpoints currently discovered per backend",
+		},
+		[]string{"backend"},
+	)
+
+	DiscoveryEndpointChangesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "mirador_core_discovery_endpoint_changes_total",
+			Help: "Total number of discovered endpoint set changes per backend",
+		},
+		[]string{"backend"},
+	)
+
+	// Secret provider metrics
+	SecretRotationsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "mirador_core_secret_rotations_total",
+			Help: "Total number of rotated secret values detected by the refresh loop",
+		},
+		[]string{"field"},
+	)
+
+	// Backend query scheduling (traffic classes)
+	QueryInFlight = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "mirador_core_query_in_flight",
+			Help: "Backend requests currently running per traffic class",
+		},
+		[]string{"class"},
+	)
+
+	QueryQueueDepth = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "mirador_core_query_queue_depth",
+			Help: "Backend requests waiting for a slot per traffic class",
+		},
+		[]string{"class"},
+	)
+
+	// Query cancellation (in-flight query tracking)
+	QueriesCancelledTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "mirador_core_queries_cancelled_total",
+			Help: "Query requests cancelled before completing, by reason (api, disconnect)",
+		},
+		[]string{"reason"},
+	)
+
+	QueryQueueWait = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "mirador_core_query_queue_wait_seconds",
+			Help:    "Time backend requests waited for a slot per traffic class",
+			Buckets: []float64{0.001, 0.01, 0.05, 0.1, 0.5, 1.0, 5.0, 30.0, 120.0},
+		},
+		[]string{"class"},
+	)
+
+	QueryQueueTimeouts = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "mirador_core_query_queue_timeouts_total",
+			Help: "Backend requests that gave up waiting for a slot per traffic class",
+		},
+		[]string{"class"},
+	)
 )
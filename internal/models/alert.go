@@ -63,4 +63,7 @@ type Notification struct {
 	Component string    `json:"component"`
 	Severity  string    `json:"severity"`
 	Timestamp time.Time `json:"timestamp"`
+	// Data carries event-specific values for notification templates
+	// (e.g. rootCause and confidence for correlations).
+	Data map[string]interface{} `json:"data,omitempty"`
 }
@@ -0,0 +1,73 @@
+package models
+
+import "time"
+
+// Anonymization strategies.
+const (
+	// AnonymizeHash replaces a value with a keyed hash. Equal values hash
+	// alike across exports, so anonymized datasets can still be joined.
+	AnonymizeHash = "hash"
+	// AnonymizeGeneralize coarsens a value: IP addresses to their network,
+	// timestamps to a time bucket, numbers to a bucket and email addresses
+	// to their domain.
+	AnonymizeGeneralize = "generalize"
+	// AnonymizeDrop removes the field.
+	AnonymizeDrop = "drop"
+)
+
+// AnonymizationRule maps the fields matching Field, an exact name or a
+// glob such as "user.*", to a strategy. Granularity tunes generalize: an
+// IP prefix length ("24"), a duration ("1h") or a numeric bucket ("100").
+type AnonymizationRule struct {
+	Field       string `json:"field"`
+	Strategy    string `json:"strategy"`
+	Granularity string `json:"granularity,omitempty"`
+}
+
+// AnonymizationProfile is a named set of rules applied to exported data
+// before it is shared outside the tenant. The first rule matching a field
+// wins.
+type AnonymizationProfile struct {
+	Name        string              `json:"name"`
+	Description string              `json:"description,omitempty"`
+	Rules       []AnonymizationRule `json:"rules"`
+	UpdatedAt   time.Time           `json:"updatedAt"`
+	UpdatedBy   string              `json:"updatedBy,omitempty"`
+}
+
+// AnonymizationFieldReport counts what one rule did.
+type AnonymizationFieldReport struct {
+	Field       string `json:"field"`
+	Strategy    string `json:"strategy"`
+	Transformed int    `json:"transformed"`
+	// Fallback counts values generalize could not interpret; they were
+	// replaced with a placeholder.
+	Fallback int `json:"fallback,omitempty"`
+}
+
+// AnonymizationReport is the verification report of one anonymized export:
+// what each rule transformed, which rules never matched, and which fields
+// were passed through unchanged.
+type AnonymizationReport struct {
+	ID          string                     `json:"id"`
+	Profile     string                     `json:"profile"`
+	Source      string                     `json:"source"`
+	Records     int                        `json:"records"`
+	Fields      []AnonymizationFieldReport `json:"fields"`
+	Unmatched   []string                   `json:"unmatchedRules"`
+	Passthrough []string                   `json:"passthroughFields"`
+	CreatedAt   time.Time                  `json:"createdAt"`
+}
+
+// AnonymizationApplyRequest carries records, e.g. a report artifact or a
+// trace export, to anonymize with a profile.
+type AnonymizationApplyRequest struct {
+	Source  string                   `json:"source,omitempty"`
+	Records []map[string]interface{} `json:"records" binding:"required"`
+}
+
+// AnonymizationApplyResult is the anonymized records and their report.
+type AnonymizationApplyResult struct {
+	Records []map[string]interface{} `json:"records"`
+	Report  *AnonymizationReport     `json:"report"`
+}
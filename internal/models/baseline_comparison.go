@@ -0,0 +1,67 @@
+package models
+
+import "time"
+
+// BaselinePoint is one sample of a compared series. Baseline points carry
+// the timestamp they align to in the current range and Delta, the current
+// value minus the baseline value at that timestamp.
+type BaselinePoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	Value     float64   `json:"value"`
+	Delta     *float64  `json:"delta,omitempty"`
+}
+
+// BaselineSeries is one label set of a compared query.
+type BaselineSeries struct {
+	Labels map[string]string `json:"labels"`
+	Points []BaselinePoint   `json:"points"`
+}
+
+// BaselineDeltaStats summarises how the current range differs from a
+// baseline over the timestamps both have samples for.
+type BaselineDeltaStats struct {
+	AlignedPoints int     `json:"alignedPoints"`
+	CurrentMean   float64 `json:"currentMean"`
+	BaselineMean  float64 `json:"baselineMean"`
+	MeanDelta     float64 `json:"meanDelta"`
+	// MeanDeltaPct is MeanDelta relative to BaselineMean; not set when the
+	// baseline mean is zero.
+	MeanDeltaPct *float64 `json:"meanDeltaPct,omitempty"`
+	MaxAbsDelta  float64  `json:"maxAbsDelta"`
+	// Correlation is the Pearson correlation of the aligned samples, i.e.
+	// whether the current range follows the baseline's shape.
+	Correlation float64 `json:"correlation"`
+}
+
+// BaselineWindow is the query evaluated over the current range shifted back
+// by Offset, aligned onto the current range's timestamps.
+type BaselineWindow struct {
+	Offset string             `json:"offset"`
+	Start  time.Time          `json:"start"`
+	End    time.Time          `json:"end"`
+	Series []BaselineSeries   `json:"series"`
+	Stats  BaselineDeltaStats `json:"stats"`
+}
+
+// BaselineComparison is a query over a range and over one or more offset
+// baselines of the same range.
+type BaselineComparison struct {
+	KPIID     string           `json:"kpiId,omitempty"`
+	Query     string           `json:"query"`
+	Start     time.Time        `json:"start"`
+	End       time.Time        `json:"end"`
+	Step      string           `json:"step"`
+	Current   []BaselineSeries `json:"current"`
+	Baselines []BaselineWindow `json:"baselines"`
+}
+
+// BaselineComparisonRequest selects what to compare. Exactly one of KPIID
+// and Query is set; Offsets are durations such as 1d, 1w or 4w.
+type BaselineComparisonRequest struct {
+	KPIID   string
+	Query   string
+	Start   time.Time
+	End     time.Time
+	Step    time.Duration
+	Offsets []string
+}
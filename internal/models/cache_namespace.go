@@ -0,0 +1,8 @@
+package models
+
+// TenantCacheUsage is the number of Valkey keys held under a tenant's
+// namespace ("tenant:<id>:...").
+type TenantCacheUsage struct {
+	Tenant string `json:"tenant"`
+	Keys   int64  `json:"keys"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// CallbackResult is the result of a long-running job (e.g. an anomaly
+// detection run) pushed back by an AI engine over the callback gRPC server.
+type CallbackResult struct {
+	JobID      string         `json:"jobId"`
+	Engine     string         `json:"engine"`
+	Kind       string         `json:"kind,omitempty"`   // e.g. anomaly_detection
+	Status     string         `json:"status"`           // succeeded, failed, running, ...
+	Result     map[string]any `json:"result,omitempty"` // engine-defined payload
+	Error      string         `json:"error,omitempty"`
+	ReceivedAt time.Time      `json:"receivedAt"`
+}
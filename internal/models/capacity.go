@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// CapacityForecast is the linear trend of one resource KPI series and its
+// projected time to the nearest threshold.
+type CapacityForecast struct {
+	KPIID   string            `json:"kpiId"`
+	KPIName string            `json:"kpiName"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Current float64           `json:"current"`
+	// SlopePerDay is the fitted change per day; R2 (0-1) is how well a
+	// straight line explains the lookback window.
+	SlopePerDay  float64   `json:"slopePerDay"`
+	R2           float64   `json:"r2"`
+	Level        string    `json:"level"`
+	Threshold    float64   `json:"threshold"`
+	DaysToBreach float64   `json:"daysToBreach"` // 0 when already breached
+	BreachAt     time.Time `json:"breachAt"`
+}
+
+// CapacityReport ranks resource KPI series projected to breach a threshold
+// within HorizonDays, soonest first.
+type CapacityReport struct {
+	HorizonDays int                `json:"horizonDays"`
+	Lookback    string             `json:"lookback"`
+	KPIs        int                `json:"kpis"`   // resource KPIs considered
+	Series      int                `json:"series"` // series with a usable trend
+	Forecasts   []CapacityForecast `json:"forecasts"`
+	GeneratedAt time.Time          `json:"generatedAt"`
+}
+
+// CapacityRequest selects the KPIs and horizon of a capacity report. Empty
+// fields use the configured defaults.
+type CapacityRequest struct {
+	KPIIDs      []string
+	HorizonDays int
+}
@@ -0,0 +1,98 @@
+package models
+
+import "time"
+
+// TSDBStatusRequest asks the metrics backend for its cardinality statistics
+// of one day. Date is YYYY-MM-DD (UTC); FocusLabel adds the series count per
+// value of that label.
+type TSDBStatusRequest struct {
+	Date       string
+	TopN       int
+	Match      []string
+	FocusLabel string
+}
+
+// TSDBStatusEntry is one name or value with its count.
+type TSDBStatusEntry struct {
+	Name  string `json:"name"`
+	Value int64  `json:"value"`
+}
+
+// TSDBStatus is the /api/v1/status/tsdb response of VictoriaMetrics. Every
+// list holds the TopN largest entries only.
+type TSDBStatus struct {
+	TotalSeries                  int64             `json:"totalSeries"`
+	TotalLabelValuePairs         int64             `json:"totalLabelValuePairs"`
+	SeriesCountByMetricName      []TSDBStatusEntry `json:"seriesCountByMetricName"`
+	SeriesCountByLabelName       []TSDBStatusEntry `json:"seriesCountByLabelName"`
+	SeriesCountByFocusLabelValue []TSDBStatusEntry `json:"seriesCountByFocusLabelValue"`
+	SeriesCountByLabelValuePair  []TSDBStatusEntry `json:"seriesCountByLabelValuePair"`
+	LabelValueCountByLabelName   []TSDBStatusEntry `json:"labelValueCountByLabelName"`
+}
+
+// LabelValueCount is a label value and the most series it had on one day.
+type LabelValueCount struct {
+	Value  string `json:"value"`
+	Series int64  `json:"series"`
+}
+
+// LabelCardinality is the cardinality analysis of one label over a time
+// range.
+type LabelCardinality struct {
+	Label string    `json:"label"`
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Days  int       `json:"days"`
+	// DistinctEstimate is the approximate number of distinct values over
+	// the whole range. LowerBound is set when some day had more values than
+	// could be read, so the true count may be higher.
+	DistinctEstimate int64 `json:"distinctEstimate"`
+	LowerBound       bool  `json:"lowerBound,omitempty"`
+	// DailyMax is the largest exact per-day count reported by the backend.
+	DailyMax int64 `json:"dailyMax"`
+	// TopValues are the values with the most series, approximate because
+	// each day only reports its own top values.
+	TopValues       []LabelValueCount `json:"topValues"`
+	Threshold       int               `json:"threshold"`
+	HighCardinality bool              `json:"highCardinality"`
+}
+
+// LabelCardinalitySummary is one label of a cardinality scan, with the
+// largest per-day counts in the scanned range.
+type LabelCardinalitySummary struct {
+	Label           string `json:"label"`
+	Values          int64  `json:"values"`
+	Series          int64  `json:"series"`
+	HighCardinality bool   `json:"highCardinality"`
+}
+
+// CardinalityScan ranks the labels with the most values over a time range
+// and flags those over the threshold.
+type CardinalityScan struct {
+	Start       time.Time                 `json:"start"`
+	End         time.Time                 `json:"end"`
+	Match       []string                  `json:"match,omitempty"`
+	Threshold   int                       `json:"threshold"`
+	TotalSeries int64                     `json:"totalSeries"`
+	Labels      []LabelCardinalitySummary `json:"labels"`
+	Flagged     []string                  `json:"flagged"`
+	ScannedAt   time.Time                 `json:"scannedAt"`
+}
+
+// LabelCardinalityRequest asks for the cardinality of one label. Zero times
+// default to the configured scan lookback ending now; TopK 0 uses the
+// configured default.
+type LabelCardinalityRequest struct {
+	Label string
+	Start time.Time
+	End   time.Time
+	Match []string
+	TopK  int
+}
+
+// CardinalityScanRequest asks for a cardinality scan of all labels.
+type CardinalityScanRequest struct {
+	Start time.Time
+	End   time.Time
+	Match []string
+}
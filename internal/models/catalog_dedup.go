@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// CatalogEntryRef identifies one side of a duplicate candidate pair.
+type CatalogEntryRef struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Source    string    `json:"source,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt,omitempty"`
+}
+
+// DuplicateCandidate is a pair of catalog entries of the same type that
+// likely describe the same entity.
+type DuplicateCandidate struct {
+	EntityType string `json:"entityType"` // kpi, metric, trace_service, ...
+	// Score combines NameScore and VectorScore (0-1).
+	Score float64 `json:"score"`
+	// NameScore compares normalized names (1 means identical after
+	// normalization, e.g. "HTTP Requests" and "http_requests").
+	NameScore float64 `json:"nameScore"`
+	// VectorScore is the cosine similarity of name+description text vectors.
+	VectorScore float64         `json:"vectorScore"`
+	Left        CatalogEntryRef `json:"left"`
+	Right       CatalogEntryRef `json:"right"`
+}
+
+// CatalogDuplicateReport is the result of one duplicate-detection run.
+type CatalogDuplicateReport struct {
+	GeneratedAt time.Time            `json:"generatedAt"`
+	Scanned     int                  `json:"scanned"`
+	Threshold   float64              `json:"threshold"`
+	Candidates  []DuplicateCandidate `json:"candidates"`
+}
+
+// CatalogMergeRequest merges DuplicateID into SurvivorID.
+type CatalogMergeRequest struct {
+	SurvivorID  string `json:"survivorId" binding:"required"`
+	DuplicateID string `json:"duplicateId" binding:"required"`
+	MergedBy    string `json:"mergedBy,omitempty"`
+}
+
+// CatalogMergeRecord is the history entry kept for every merge. Duplicate
+// is the merged-away entry as it was before deletion.
+type CatalogMergeRecord struct {
+	SurvivorID   string         `json:"survivorId"`
+	DuplicateID  string         `json:"duplicateId"`
+	MergedBy     string         `json:"mergedBy,omitempty"`
+	MergedAt     time.Time      `json:"mergedAt"`
+	FieldsFilled []string       `json:"fieldsFilled,omitempty"`
+	Duplicate    *KPIDefinition `json:"duplicate"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// CatalogRefreshRequest names the service and/or namespace whose metrics
+// were just deployed. At least one is required.
+type CatalogRefreshRequest struct {
+	Service   string `json:"service,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// KPIRevalidation is the formula lint outcome of one KPI referencing the
+// refreshed target, compared with its previous revalidation.
+type KPIRevalidation struct {
+	ID       string           `json:"id"`
+	Name     string           `json:"name"`
+	Warnings []FormulaWarning `json:"warnings"`
+	// Added and Resolved are warnings that appeared or went away since the
+	// previous revalidation. A KPI revalidated for the first time reports
+	// all of its warnings as added.
+	Added    []FormulaWarning `json:"added,omitempty"`
+	Resolved []FormulaWarning `json:"resolved,omitempty"`
+	Changed  bool             `json:"changed"`
+	Error    string           `json:"error,omitempty"`
+}
+
+// CatalogRefreshResult reports what a targeted catalog refresh changed.
+type CatalogRefreshResult struct {
+	Service     string    `json:"service,omitempty"`
+	Namespace   string    `json:"namespace,omitempty"`
+	RefreshedAt time.Time `json:"refreshedAt"`
+
+	// Metrics are the metric names the target currently reports;
+	// MetricsAdded and MetricsRemoved compare them with the previous
+	// refresh of the same target.
+	Metrics        []string `json:"metrics"`
+	MetricsAdded   []string `json:"metricsAdded,omitempty"`
+	MetricsRemoved []string `json:"metricsRemoved,omitempty"`
+	// MetricsIndexed is the number of metrics written to the metadata
+	// search index (0 when metadata indexing is disabled).
+	MetricsIndexed int `json:"metricsIndexed"`
+
+	KPIs        []KPIRevalidation `json:"kpis"`
+	KPIsChanged int               `json:"kpisChanged"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// Change feed operations.
+const (
+	ChangeUpsert = "upsert"
+	ChangeDelete = "delete"
+)
+
+// KPITombstone records a deleted KPI definition.
+type KPITombstone struct {
+	ID        string    `json:"id"`
+	DeletedAt time.Time `json:"deletedAt"`
+}
+
+// ResourceChange is one entry of a change feed. Object is the current
+// resource for upserts and empty for deletes.
+type ResourceChange struct {
+	Op     string    `json:"op"`
+	ID     string    `json:"id"`
+	At     time.Time `json:"at"`
+	Object any       `json:"object,omitempty"`
+}
+
+// ChangeFeedPage is a batch of changes in the order they happened. Next is
+// the resume token to pass as since on the following request; it is
+// returned even when HasMore is false so clients can poll from it.
+type ChangeFeedPage struct {
+	Resource string           `json:"resource"`
+	Changes  []ResourceChange `json:"changes"`
+	Next     string           `json:"next"`
+	HasMore  bool             `json:"hasMore"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// Compliance control statuses. Manual controls cannot be verified from the
+// running configuration and need an operator attestation.
+const (
+	ComplianceStatusPass          = "pass"
+	ComplianceStatusFail          = "fail"
+	ComplianceStatusWarning       = "warning"
+	ComplianceStatusManual        = "manual"
+	ComplianceStatusNotApplicable = "not_applicable"
+)
+
+// Compliance frameworks controls are mapped to.
+const (
+	ComplianceFrameworkSOC2 = "SOC2"
+	ComplianceFrameworkISO  = "ISO27001"
+)
+
+// ComplianceMapping is a control of a framework, e.g. SOC2 CC6.1 or
+// ISO27001 A.8.24 (2022 Annex A numbering).
+type ComplianceMapping struct {
+	Framework string `json:"framework"`
+	Control   string `json:"control"`
+}
+
+// ComplianceControl is the status of one control with the evidence it was
+// derived from. Evidence names config fields, never their values.
+type ComplianceControl struct {
+	ID          string              `json:"id"`
+	Title       string              `json:"title"`
+	Status      string              `json:"status"`
+	Evidence    []string            `json:"evidence"`
+	Remediation string              `json:"remediation,omitempty"`
+	Attested    bool                `json:"attested,omitempty"`
+	Frameworks  []ComplianceMapping `json:"frameworks"`
+}
+
+// ComplianceReport is the control status of the running deployment.
+type ComplianceReport struct {
+	GeneratedAt time.Time           `json:"generatedAt"`
+	Environment string              `json:"environment"`
+	Framework   string              `json:"framework,omitempty"`
+	Summary     map[string]int      `json:"summary"`
+	Controls    []ComplianceControl `json:"controls"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// ComputedColumn is a named expression evaluated on every row of a logs
+// result, e.g. a latency bucket or a region extracted from the hostname.
+// Queries and templates name the columns to add by Name.
+type ComputedColumn struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Expression  string    `json:"expression"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	UpdatedBy   string    `json:"updatedBy,omitempty"`
+}
+
+// ComputedColumnPreviewRequest evaluates an expression against sample rows
+// without storing it.
+type ComputedColumnPreviewRequest struct {
+	Expression string           `json:"expression"`
+	Rows       []map[string]any `json:"rows"`
+}
+
+// ComputedColumnPreview holds the value of the expression for each row.
+type ComputedColumnPreview struct {
+	Values []any `json:"values"`
+}
@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// CorrelationShareRequest creates a share link for a recorded correlation run.
+type CorrelationShareRequest struct {
+	// ExpiresInHours defaults to 168 (7 days) and is capped at 720.
+	ExpiresInHours int `json:"expiresInHours,omitempty"`
+}
+
+// CorrelationShare is a link to a recorded correlation run. Links are only
+// valid on the tenant's own deployment.
+type CorrelationShare struct {
+	Token         string    `json:"token"`
+	CorrelationID string    `json:"correlationId"`
+	Path          string    `json:"path"`
+	CreatedBy     string    `json:"createdBy,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+	ExpiresAt     time.Time `json:"expiresAt"`
+}
+
+// CorrelationCommentRequest adds a comment to a correlation run. Set
+// CandidateKPIID to comment on one cause candidate and ParentID to reply to
+// another comment. "@name" mentions in Body are notified.
+type CorrelationCommentRequest struct {
+	Body           string `json:"body" binding:"required"`
+	CandidateKPIID string `json:"candidateKpiId,omitempty"`
+	ParentID       string `json:"parentId,omitempty"`
+}
+
+// CorrelationComment is a comment on a correlation run or cause candidate.
+type CorrelationComment struct {
+	ID             string               `json:"id"`
+	CorrelationID  string               `json:"correlationId"`
+	CandidateKPIID string               `json:"candidateKpiId,omitempty"`
+	ParentID       string               `json:"parentId,omitempty"`
+	Author         string               `json:"author,omitempty"`
+	Body           string               `json:"body"`
+	Mentions       []string             `json:"mentions,omitempty"`
+	CreatedAt      time.Time            `json:"createdAt"`
+	Replies        []CorrelationComment `json:"replies,omitempty"`
+}
+
+// SharedCorrelationRun is what a share link resolves to: the stored
+// correlation result, the recorded run while it is still retained, and the
+// comment threads.
+type SharedCorrelationRun struct {
+	Share    CorrelationShare         `json:"share"`
+	Result   *CorrelationHistoryEntry `json:"result,omitempty"`
+	Run      *CorrelationRunRecord    `json:"run,omitempty"`
+	Comments []CorrelationComment     `json:"comments"`
+}
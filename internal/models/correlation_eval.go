@@ -0,0 +1,97 @@
+package models
+
+import "time"
+
+// CorrelationRunCandidate holds the aligned per-ring samples a candidate was
+// scored from.
+type CorrelationRunCandidate struct {
+	KPIID      string    `json:"kpiId"`
+	KPI        string    `json:"kpi"`
+	Impact     []float64 `json:"impact"`
+	Cause      []float64 `json:"cause"`
+	Confounder []float64 `json:"confounder,omitempty"`
+}
+
+// CorrelationRunRecord is the persisted input of a correlation run.
+type CorrelationRunRecord struct {
+	CorrelationID string                    `json:"correlationId"`
+	TimeRange     TimeRange                 `json:"timeRange"`
+	Candidates    []CorrelationRunCandidate `json:"candidates"`
+	// TopCause is the KPI ID the run ranked first.
+	TopCause  string    `json:"topCause"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// CorrelationFeedback is an operator's label for a correlation run: whether
+// its top cause was right and, optionally, which KPI actually was.
+type CorrelationFeedback struct {
+	CorrelationID string    `json:"correlationId"`
+	Correct       bool      `json:"correct"`
+	CauseKPI      string    `json:"causeKpi,omitempty"`
+	Notes         string    `json:"notes,omitempty"`
+	SubmittedBy   string    `json:"submittedBy,omitempty"`
+	SubmittedAt   time.Time `json:"submittedAt"`
+}
+
+// CorrelationEvalVariant overrides engine scoring settings for a replay;
+// unset fields keep the running configuration.
+type CorrelationEvalVariant struct {
+	Name           string   `json:"name"`
+	MinCorrelation *float64 `json:"minCorrelation,omitempty"`
+	Pearson        *float64 `json:"pearson,omitempty"`
+	Spearman       *float64 `json:"spearman,omitempty"`
+	CrossCorr      *float64 `json:"crossCorr,omitempty"`
+	LagBonus       *float64 `json:"lagBonus,omitempty"`
+	AnomalyDensity *float64 `json:"anomalyDensity,omitempty"`
+}
+
+// CorrelationEvalRequest replays up to Limit recorded runs (newest first)
+// under variants A and B.
+type CorrelationEvalRequest struct {
+	A     CorrelationEvalVariant `json:"a"`
+	B     CorrelationEvalVariant `json:"b"`
+	Limit int                    `json:"limit,omitempty"`
+}
+
+// CorrelationEvalRun compares the two rankings of one run.
+type CorrelationEvalRun struct {
+	CorrelationID string `json:"correlationId"`
+	Candidates    int    `json:"candidates"`
+	TopA          string `json:"topA"`
+	TopB          string `json:"topB"`
+	// KendallTau is the rank correlation of the two rankings (-1..1).
+	KendallTau float64 `json:"kendallTau"`
+	// LabelRankA/B are the 1-based ranks of the labelled cause, 0 when the
+	// run has no labelled cause KPI.
+	LabelRankA int  `json:"labelRankA,omitempty"`
+	LabelRankB int  `json:"labelRankB,omitempty"`
+	Labeled    bool `json:"labeled"`
+}
+
+// CorrelationEvalVariantSummary is a variant's agreement with operator
+// feedback across labelled runs.
+type CorrelationEvalVariantSummary struct {
+	Name           string  `json:"name"`
+	MinCorrelation float64 `json:"minCorrelation"`
+	Pearson        float64 `json:"pearson"`
+	Spearman       float64 `json:"spearman"`
+	CrossCorr      float64 `json:"crossCorr"`
+	LagBonus       float64 `json:"lagBonus"`
+	AnomalyDensity float64 `json:"anomalyDensity"`
+	// Top1Agreement is the fraction of labelled runs whose top cause agrees
+	// with the label; MeanReciprocalRank covers runs labelled with a cause KPI.
+	Top1Agreement      float64 `json:"top1Agreement"`
+	MeanReciprocalRank float64 `json:"meanReciprocalRank"`
+}
+
+// CorrelationEvalReport summarizes an A/B replay.
+type CorrelationEvalReport struct {
+	Runs           int                           `json:"runs"`
+	LabeledRuns    int                           `json:"labeledRuns"`
+	Top1Changed    int                           `json:"top1Changed"`
+	MeanKendallTau float64                       `json:"meanKendallTau"`
+	A              CorrelationEvalVariantSummary `json:"a"`
+	B              CorrelationEvalVariantSummary `json:"b"`
+	PerRun         []CorrelationEvalRun          `json:"perRun"`
+	GeneratedAt    time.Time                     `json:"generatedAt"`
+}
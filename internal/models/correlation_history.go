@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// Operator verdicts on a stored correlation result.
+const (
+	CorrelationVerdictConfirmed     = "confirmed"
+	CorrelationVerdictFalsePositive = "false_positive"
+)
+
+// CorrelationHistoryEntry is a stored correlation result. Result is only
+// set when a single entry is fetched; listings carry the summary.
+type CorrelationHistoryEntry struct {
+	CorrelationID    string                 `json:"correlationId"`
+	IncidentIDs      []string               `json:"incidentIds,omitempty"`
+	TimeRange        TimeRange              `json:"timeRange"`
+	RootCause        string                 `json:"rootCause,omitempty"`
+	Confidence       float64                `json:"confidence"`
+	AffectedServices []string               `json:"affectedServices,omitempty"`
+	Annotation       *CorrelationAnnotation `json:"annotation,omitempty"`
+	Result           *CorrelationResult     `json:"result,omitempty"`
+	CreatedAt        time.Time              `json:"createdAt"`
+}
+
+// CorrelationAnnotation is an operator's verdict on a stored result.
+type CorrelationAnnotation struct {
+	Verdict     string    `json:"verdict"`
+	CauseKPI    string    `json:"causeKpi,omitempty"`
+	Notes       string    `json:"notes,omitempty"`
+	AnnotatedBy string    `json:"annotatedBy,omitempty"`
+	AnnotatedAt time.Time `json:"annotatedAt"`
+}
+
+// CorrelationAnnotationRequest sets the verdict on a stored result and
+// optionally links it to more incidents.
+type CorrelationAnnotationRequest struct {
+	Verdict string `json:"verdict"`
+	// CauseKPI optionally names the actual root-cause KPI (id or name).
+	CauseKPI    string   `json:"causeKpi,omitempty"`
+	Notes       string   `json:"notes,omitempty"`
+	IncidentIDs []string `json:"incidentIds,omitempty"`
+}
+
+// CorrelationHistoryFilter narrows a history listing. Zero fields match
+// everything.
+type CorrelationHistoryFilter struct {
+	IncidentID string
+	Verdict    string
+	Since      time.Time
+	Limit      int
+}
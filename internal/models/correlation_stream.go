@@ -0,0 +1,30 @@
+package models
+
+// Stages of the progress events streamed while a correlation runs.
+const (
+	// CorrelationStageRings reports the temporal rings of a time-window run.
+	CorrelationStageRings = "rings"
+	// CorrelationStageRing reports one ring sampled for one candidate KPI.
+	CorrelationStageRing = "ring"
+	// CorrelationStageCandidate carries a scored candidate cause.
+	CorrelationStageCandidate = "candidate"
+	// CorrelationStageEngine reports one engine's expressions completing,
+	// with the correlations found so far.
+	CorrelationStageEngine = "engine"
+)
+
+// CorrelationProgressEvent is one progress update of a running correlation.
+// Completed and Total count the units of the event's stage: ring samples,
+// candidates or engines.
+type CorrelationProgressEvent struct {
+	Stage     string                    `json:"stage"`
+	Rings     []TimeRange               `json:"rings,omitempty"`
+	Ring      *int                      `json:"ring,omitempty"`
+	KPI       string                    `json:"kpi,omitempty"`
+	Candidate *CauseCandidate           `json:"candidate,omitempty"`
+	Engine    QueryType                 `json:"engine,omitempty"`
+	Error     string                    `json:"error,omitempty"`
+	Partial   *UnifiedCorrelationResult `json:"partial,omitempty"`
+	Completed int                       `json:"completed"`
+	Total     int                       `json:"total"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// CorrelationSuppressionRule excludes known-noisy impact/candidate KPI pairs
+// from correlation ranking. Impact and Candidate are case-insensitive glob
+// patterns (* and ?) matched against a KPI's name or ID; an empty pattern
+// matches any KPI. When Tags is set the candidate KPI must carry all of them.
+type CorrelationSuppressionRule struct {
+	ID        string    `json:"id"`
+	Impact    string    `json:"impact,omitempty"`
+	Candidate string    `json:"candidate,omitempty"`
+	Tags      []string  `json:"tags,omitempty"`
+	Reason    string    `json:"reason"`
+	CreatedBy string    `json:"createdBy,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ExcludedCause is a candidate removed from ranking by a suppression rule.
+// It is still reported so operators can see what was hidden and why.
+type ExcludedCause struct {
+	CauseCandidate
+	RuleID string `json:"rule_id"`
+	Reason string `json:"reason"`
+}
@@ -0,0 +1,35 @@
+package models
+
+// CORS decision sources: which part of the policy allowed an origin.
+const (
+	CORSSourceGlobal  = "global"  // cors.allowed_origins
+	CORSSourceTenant  = "tenant"  // tenant settings allowedOrigins
+	CORSSourceDefault = "default" // built-in development origins
+)
+
+// CORSDecision is the result of checking a browser origin against the
+// effective CORS policy.
+type CORSDecision struct {
+	Origin  string `json:"origin"`
+	Allowed bool   `json:"allowed"`
+	// Source and Rule name the policy entry that allowed the origin.
+	Source string `json:"source,omitempty"`
+	Rule   string `json:"rule,omitempty"`
+	// AllowCredentials is true when cookies and Authorization headers may
+	// be sent; a "*" entry never allows credentials.
+	AllowCredentials bool   `json:"allowCredentials"`
+	Reason           string `json:"reason,omitempty"`
+}
+
+// CORSPolicy is the effective CORS policy and, when an origin was given,
+// the decision for it.
+type CORSPolicy struct {
+	GlobalOrigins    []string      `json:"globalOrigins"`
+	TenantOrigins    []string      `json:"tenantOrigins"`
+	AllowedMethods   []string      `json:"allowedMethods"`
+	AllowedHeaders   []string      `json:"allowedHeaders"`
+	ExposedHeaders   []string      `json:"exposedHeaders"`
+	AllowCredentials bool          `json:"allowCredentials"`
+	MaxAgeSeconds    int           `json:"maxAgeSeconds"`
+	Decision         *CORSDecision `json:"decision,omitempty"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Dynamic config change actions.
+const (
+	ConfigActionUpdate   = "update"
+	ConfigActionRollback = "rollback"
+)
+
+// ConfigChange is one leaf that differs between two config versions. Paths
+// are dot-separated JSON object keys; arrays are compared as a whole.
+type ConfigChange struct {
+	Path string `json:"path"`
+	Old  any    `json:"old,omitempty"`
+	New  any    `json:"new,omitempty"`
+}
+
+// ConfigVersion is one applied revision of a dynamic config document. The
+// version history doubles as the change audit trail.
+type ConfigVersion struct {
+	Name      string          `json:"name"`
+	Version   int             `json:"version"`
+	Action    string          `json:"action"`
+	Author    string          `json:"author,omitempty"`
+	Timestamp time.Time       `json:"timestamp"`
+	Diff      []ConfigChange  `json:"diff"`
+	Value     json.RawMessage `json:"value"`
+	// RolledBackTo is the version restored by a rollback.
+	RolledBackTo int `json:"rolledBackTo,omitempty"`
+}
@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+// KPIViolation is a KPI whose threshold is currently breached.
+type KPIViolation struct {
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	ServiceFamily string  `json:"serviceFamily,omitempty"`
+	Level         string  `json:"level"`
+	Value         float64 `json:"value"`
+}
+
+// IncidentSummary counts open incidents (failure records detected within the
+// incident window) by severity.
+type IncidentSummary struct {
+	Total      int            `json:"total"`
+	BySeverity map[string]int `json:"bySeverity"`
+}
+
+// ServiceBurn is a service's current error-budget burn rate.
+type ServiceBurn struct {
+	Service    string  `json:"service"`
+	ErrorRatio float64 `json:"errorRatio"`
+	BurnRate   float64 `json:"burnRate"`
+}
+
+// NoisyService ranks services by firing alerts plus incidents over the last
+// week.
+type NoisyService struct {
+	Service      string `json:"service"`
+	FiringAlerts int    `json:"firingAlerts"`
+	Incidents    int    `json:"incidents"`
+}
+
+// SummaryTrend compares a tenant-wide count with the same count a week ago.
+type SummaryTrend struct {
+	Metric   string `json:"metric"` // incidents | violated_kpis | firing_alerts
+	Current  int    `json:"current"`
+	Previous int    `json:"previous"`
+	// ChangePct is nil when Previous is 0.
+	ChangePct *float64 `json:"changePct,omitempty"`
+}
+
+// ExecutiveSummary is the tenant health overview returned in one call.
+// Sections whose backend is unavailable are left empty and listed in
+// Unavailable.
+type ExecutiveSummary struct {
+	TopViolatedKPIs []KPIViolation  `json:"topViolatedKpis"`
+	OpenIncidents   IncidentSummary `json:"openIncidents"`
+	SLOBurn         []ServiceBurn   `json:"sloBurn"`
+	NoisyServices   []NoisyService  `json:"noisyServices"`
+	Trends          []SummaryTrend  `json:"trends"`
+	Unavailable     []string        `json:"unavailable,omitempty"`
+	GeneratedAt     time.Time       `json:"generatedAt"`
+}
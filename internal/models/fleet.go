@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// FleetInstance is what one mirador-core instance publishes about itself.
+type FleetInstance struct {
+	InstanceID  string   `json:"instanceId"`
+	Version     string   `json:"version,omitempty"`
+	Commit      string   `json:"commit,omitempty"`
+	APIVersions []string `json:"apiVersions"`
+	// SchemaVersion is the Weaviate schema layout the instance's build
+	// applies; MinSchemaVersion the oldest layout it tolerates on other
+	// instances once its own layout is applied.
+	SchemaVersion    int       `json:"schemaVersion"`
+	MinSchemaVersion int       `json:"minSchemaVersion"`
+	StartedAt        time.Time `json:"startedAt"`
+	SeenAt           time.Time `json:"seenAt"`
+}
+
+// Schema migration states.
+const (
+	SchemaMigrationCurrent = "current" // the newest layout in the fleet is applied
+	SchemaMigrationPending = "pending" // a newer layout will be applied on the next heartbeat
+	SchemaMigrationBlocked = "blocked" // waiting for instances of older builds to drain
+)
+
+// SchemaMigrationStatus reports the shared Weaviate schema layout.
+type SchemaMigrationStatus struct {
+	Applied int    `json:"applied"` // layout recorded in the shared store (0 when none yet)
+	Target  int    `json:"target"`  // newest layout any instance applies
+	State   string `json:"state"`
+	// BlockedBy lists the instances too old for the target layout.
+	BlockedBy []string `json:"blockedBy,omitempty"`
+}
+
+// FleetVersionStatus is the version skew across a deployment's instances.
+type FleetVersionStatus struct {
+	Self      string          `json:"self"`
+	Instances []FleetInstance `json:"instances"`
+	// Versions and SchemaVersions are the distinct build and schema
+	// versions running; Skewed is true when either has more than one.
+	Versions       []string `json:"versions"`
+	SchemaVersions []int    `json:"schemaVersions"`
+	Skewed         bool     `json:"skewed"`
+	// CommonAPIVersions are the API versions every instance serves.
+	CommonAPIVersions []string              `json:"commonApiVersions"`
+	Schema            SchemaMigrationStatus `json:"schema"`
+}
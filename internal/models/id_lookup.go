@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// Sources of ID lookup entries.
+const (
+	IDLookupSourceLogs   = "logs"
+	IDLookupSourceTraces = "traces"
+)
+
+// IDLookupRequest finds everything recorded for one trace ID, transaction
+// ID or request ID between Start and End. Limit bounds the log lines and
+// the spans returned.
+type IDLookupRequest struct {
+	ID    string    `json:"id"`
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Limit int       `json:"limit,omitempty"`
+}
+
+// IDLookupEntry is one log line or span. Message is the log message or the
+// span's operation name.
+type IDLookupEntry struct {
+	Timestamp      time.Time      `json:"timestamp"`
+	Source         string         `json:"source"`
+	Service        string         `json:"service,omitempty"`
+	Message        string         `json:"message,omitempty"`
+	TraceID        string         `json:"traceId,omitempty"`
+	SpanID         string         `json:"spanId,omitempty"`
+	DurationMicros int64          `json:"durationMicros,omitempty"`
+	Data           map[string]any `json:"data"`
+}
+
+// IDLookupSource reports how one backend contributed. Truncated is set
+// when the limit cut its results short.
+type IDLookupSource struct {
+	Count     int    `json:"count"`
+	Truncated bool   `json:"truncated,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// IDLookupResult is the merged, time-ordered result of an ID lookup.
+// TraceIDs lists the traces found, whether the ID was a trace ID or the
+// traces were referenced by matching log lines.
+type IDLookupResult struct {
+	ID       string                    `json:"id"`
+	Start    time.Time                 `json:"start"`
+	End      time.Time                 `json:"end"`
+	TraceIDs []string                  `json:"traceIds"`
+	Entries  []IDLookupEntry           `json:"entries"`
+	Sources  map[string]IDLookupSource `json:"sources"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// IncidentTicket links an incident (failure record) to the Jira issue or
+// ServiceNow record opened for it. Status is the provider's status name as
+// last seen by a webhook or poll.
+type IncidentTicket struct {
+	FailureUUID string    `json:"failure_uuid"`
+	FailureID   string    `json:"failure_id"`
+	Provider    string    `json:"provider"`
+	TicketID    string    `json:"ticket_id,omitempty"`
+	Key         string    `json:"key"`
+	URL         string    `json:"url"`
+	Status      string    `json:"status,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	SyncedAt    time.Time `json:"synced_at"`
+}
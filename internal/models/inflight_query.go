@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// InFlightQuery is a query request still being served. Path is the route
+// template of the request (e.g. /api/v1/unified/query).
+type InFlightQuery struct {
+	ID              string    `json:"id"`
+	User            string    `json:"user,omitempty"`
+	Method          string    `json:"method"`
+	Path            string    `json:"path"`
+	StartedAt       time.Time `json:"startedAt"`
+	CancelRequested bool      `json:"cancelRequested,omitempty"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// LatencyPercentiles summarises the durations observed over a trend step,
+// estimated from histogram buckets. Values are in seconds.
+type LatencyPercentiles struct {
+	Count uint64  `json:"count"`
+	P50   float64 `json:"p50"`
+	P95   float64 `json:"p95"`
+	P99   float64 `json:"p99"`
+}
+
+// InternalHealthPoint is mirador-core's own health over one trend step.
+// Fields without activity in the step are omitted.
+type InternalHealthPoint struct {
+	Time time.Time `json:"time"` // start of the step
+
+	// CacheHitRate is the share of cache reads that hit.
+	CacheHitRate *float64 `json:"cacheHitRate,omitempty"`
+	CacheReads   uint64   `json:"cacheReads"`
+
+	WeaviateLatency     *LatencyPercentiles `json:"weaviateLatency,omitempty"`
+	CorrelationDuration *LatencyPercentiles `json:"correlationDuration,omitempty"`
+
+	// QueueDepth is the mean number of backend requests waiting for a slot,
+	// per traffic class.
+	QueueDepth map[string]float64 `json:"queueDepth,omitempty"`
+
+	// RequestRate is HTTP requests per second; ErrorRate the share of them
+	// answered with a 5xx.
+	RequestRate float64 `json:"requestRate"`
+	ErrorRate   float64 `json:"errorRate"`
+	// Errors counts internal errors by type (cache, weaviate, correlation, ...).
+	Errors map[string]uint64 `json:"errors,omitempty"`
+}
+
+// InternalHealthTrends is a series of health points over Window at Step
+// resolution, aggregated from in-memory samples of this instance.
+type InternalHealthTrends struct {
+	Window   string                `json:"window"`
+	Step     string                `json:"step"`
+	Interval string                `json:"sampleInterval"`
+	Points   []InternalHealthPoint `json:"points"`
+}
@@ -134,6 +134,8 @@ type KPIListResponse struct {
 	KPIDefinitions []*KPIDefinition `json:"kpiDefinitions"`
 	Total          int              `json:"total"`
 	NextOffset     int              `json:"nextOffset,omitempty"`
+	// NextCursor is set in cursor mode (?cursor=) when more pages remain.
+	NextCursor string `json:"nextCursor,omitempty"`
 }
 
 // KPISearchRequest is a user-facing search request for KPI catalog.
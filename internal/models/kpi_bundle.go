@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+// KPIBundleVersion is the bundle format written by KPI export.
+const KPIBundleVersion = 1
+
+// KPIBundle is a portable set of KPI definitions, exported from one
+// environment and imported into another.
+type KPIBundle struct {
+	Version    int              `json:"version" yaml:"version"`
+	ExportedAt time.Time        `json:"exportedAt,omitempty" yaml:"exportedAt,omitempty"`
+	KPIs       []*KPIDefinition `json:"kpis" yaml:"kpis"`
+}
+
+// Conflict resolution modes for KPI imports, applied when a KPI with the
+// same ID already exists.
+const (
+	KPIImportSkip      = "skip"      // keep the existing KPI
+	KPIImportOverwrite = "overwrite" // replace it with the imported one
+	KPIImportMerge     = "merge"     // apply the imported non-empty fields, union tags
+)
+
+// Per-KPI import outcomes. In a dry run they describe what would happen.
+const (
+	KPIImportCreated   = "created"
+	KPIImportUpdated   = "updated"
+	KPIImportUnchanged = "unchanged"
+	KPIImportSkipped   = "skipped"
+	KPIImportFailed    = "failed"
+)
+
+// KPIImportItem is the outcome for one KPI of a bundle.
+type KPIImportItem struct {
+	Index   int      `json:"index"`
+	ID      string   `json:"id,omitempty"`
+	Name    string   `json:"name,omitempty"`
+	Action  string   `json:"action"`
+	Error   string   `json:"error,omitempty"`
+	Details []string `json:"details,omitempty"`
+}
+
+// KPIImportResult summarises a KPI bundle import.
+type KPIImportResult struct {
+	Mode      string          `json:"mode"`
+	DryRun    bool            `json:"dryRun"`
+	Total     int             `json:"total"`
+	Created   int             `json:"created"`
+	Updated   int             `json:"updated"`
+	Unchanged int             `json:"unchanged"`
+	Skipped   int             `json:"skipped"`
+	Failed    int             `json:"failed"`
+	Items     []KPIImportItem `json:"items"`
+}
@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// DrilldownPoint is one sample of a contributor's series.
+type DrilldownPoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	Value     float64   `json:"value"`
+}
+
+// DrilldownContributor is one label set of a decomposed KPI and its part in
+// the KPI's change over the window.
+type DrilldownContributor struct {
+	Labels   map[string]string `json:"labels"`
+	Current  float64           `json:"current"`
+	Baseline float64           `json:"baseline"`
+	Change   float64           `json:"change"`
+	// Share is Change as a fraction of the aggregate change; not set for
+	// avg/min/max, where contributions do not add up.
+	Share  *float64         `json:"share,omitempty"`
+	Series []DrilldownPoint `json:"series"`
+}
+
+// KPIDrilldown decomposes a KPI's query by label dimensions and ranks the
+// label sets by how much they moved the aggregate.
+type KPIDrilldown struct {
+	KPIID      string   `json:"kpiId"`
+	Query      string   `json:"query"` // decomposed query
+	Dimensions []string `json:"dimensions"`
+	// Aggregation is the KPI's outer aggregation, empty when the formula is
+	// not a single aggregation and was grouped as-is.
+	Aggregation  string                 `json:"aggregation,omitempty"`
+	Window       string                 `json:"window"`
+	Current      float64                `json:"current"`
+	Baseline     float64                `json:"baseline"`
+	Change       float64                `json:"change"`
+	Contributors []DrilldownContributor `json:"contributors"`
+	GeneratedAt  time.Time              `json:"generatedAt"`
+}
+
+// KPIDrilldownRequest selects the dimensions and window of a drill-down.
+// Empty Dimensions are discovered from the KPI's series.
+type KPIDrilldownRequest struct {
+	KPIID      string
+	Dimensions []string
+	Window     time.Duration
+	Limit      int
+}
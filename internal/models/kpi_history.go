@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// KPI states recorded by the KPI history. Breached threshold levels other
+// than critical are recorded as warning.
+const (
+	KPIStateOK       = "ok"
+	KPIStateWarning  = "warning"
+	KPIStateCritical = "critical"
+	KPIStateNoData   = "no_data"
+)
+
+// KPIStateBucket summarises the recorded states of a KPI over one step.
+// State is the worst state seen; empty buckets have state no_data and no
+// counts.
+type KPIStateBucket struct {
+	Start    time.Time `json:"start"`
+	State    string    `json:"state"`
+	OK       int       `json:"ok"`
+	Warning  int       `json:"warning"`
+	Critical int       `json:"critical"`
+	NoData   int       `json:"noData"`
+	// Last is the last value recorded in the bucket.
+	Last *float64 `json:"last,omitempty"`
+}
+
+// KPIStateHistory is the threshold state of a KPI over time, bucketed for
+// availability and violation heatmaps.
+type KPIStateHistory struct {
+	KPIID string    `json:"kpiId"`
+	From  time.Time `json:"from"`
+	To    time.Time `json:"to"`
+	Step  string    `json:"step"`
+	// Availability is the share of evaluations with data that were ok; nil
+	// when nothing was recorded.
+	Availability *float64         `json:"availability,omitempty"`
+	Buckets      []KPIStateBucket `json:"buckets"`
+}
@@ -0,0 +1,22 @@
+package models
+
+// Formula lint warning codes.
+const (
+	FormulaWarnUnknownMetric   = "unknown_metric"
+	FormulaWarnUnknownLabel    = "unknown_label"
+	FormulaWarnUnknownField    = "unknown_field"
+	FormulaWarnDeprecated      = "deprecated"
+	FormulaWarnHighCardinality = "high_cardinality"
+)
+
+// FormulaWarning is a non-blocking problem found in a KPI formula. Start
+// and End are the byte offsets of Token in the formula, so editors can
+// underline it. Replacement is set for deprecated names that have one.
+type FormulaWarning struct {
+	Code        string `json:"code"`
+	Message     string `json:"message"`
+	Token       string `json:"token"`
+	Start       int    `json:"start"`
+	End         int    `json:"end"`
+	Replacement string `json:"replacement,omitempty"`
+}
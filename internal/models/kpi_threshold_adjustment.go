@@ -0,0 +1,60 @@
+package models
+
+import "time"
+
+// Threshold adjustment statuses.
+const (
+	ThresholdAdjustmentActive   = "active"
+	ThresholdAdjustmentReverted = "reverted"
+	ThresholdAdjustmentDryRun   = "dry_run"
+)
+
+// ThresholdAdjustmentSelector picks the KPIs an adjustment applies to. A KPI
+// matches when it carries every tag and, if set, belongs to ServiceFamily.
+type ThresholdAdjustmentSelector struct {
+	Tags          []string `json:"tags,omitempty"`
+	ServiceFamily string   `json:"serviceFamily,omitempty"`
+	// Levels limits the adjustment to these threshold levels (e.g.
+	// "warning"); empty adjusts every level.
+	Levels []string `json:"levels,omitempty"`
+}
+
+// ThresholdAdjustmentRequest changes the thresholds of every selected KPI by
+// Percent (20 raises values by 20%) or by Delta, optionally reverting them
+// automatically at RevertAt.
+type ThresholdAdjustmentRequest struct {
+	Selector ThresholdAdjustmentSelector `json:"selector"`
+	Percent  *float64                    `json:"percent,omitempty"`
+	Delta    *float64                    `json:"delta,omitempty"`
+	RevertAt *time.Time                  `json:"revertAt,omitempty"`
+	Reason   string                      `json:"reason,omitempty"`
+	// DryRun returns the adjusted values without storing them.
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// ThresholdAdjustmentKPI records one KPI's thresholds before and after an
+// adjustment, so it can be audited and reverted.
+type ThresholdAdjustmentKPI struct {
+	KPIID    string      `json:"kpiId"`
+	Name     string      `json:"name"`
+	Original []Threshold `json:"original"`
+	Adjusted []Threshold `json:"adjusted"`
+	// Error is set when the KPI could not be adjusted or reverted.
+	Error string `json:"error,omitempty"`
+}
+
+// ThresholdAdjustment is the audit record of a bulk threshold adjustment.
+type ThresholdAdjustment struct {
+	ID         string                      `json:"id"`
+	Selector   ThresholdAdjustmentSelector `json:"selector"`
+	Percent    *float64                    `json:"percent,omitempty"`
+	Delta      *float64                    `json:"delta,omitempty"`
+	Reason     string                      `json:"reason,omitempty"`
+	Status     string                      `json:"status"`
+	CreatedBy  string                      `json:"createdBy,omitempty"`
+	CreatedAt  time.Time                   `json:"createdAt"`
+	RevertAt   *time.Time                  `json:"revertAt,omitempty"`
+	RevertedBy string                      `json:"revertedBy,omitempty"`
+	RevertedAt *time.Time                  `json:"revertedAt,omitempty"`
+	KPIs       []ThresholdAdjustmentKPI    `json:"kpis"`
+}
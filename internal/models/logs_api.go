@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"io"
+	"time"
+)
 
 // Generic LogSQL query
 func (r *LogsQLQueryRequest) GetExtra() map[string]string { // used by service
@@ -27,6 +30,11 @@ type LogsExportResult struct {
 	Filename string `json:"filename,omitempty"`
 	Size     int    `json:"size,omitempty"`
 	Data     []byte `json:"-"`
+	// Body replaces Data for exports spilled to disk; the caller must
+	// Close it.
+	Body io.ReadCloser `json:"-"`
+	// Anonymization is the verification report when a profile was applied.
+	Anonymization *AnonymizationReport `json:"anonymization,omitempty"`
 }
 
 // Histogram
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// MaintenanceState describes whether the API is currently in maintenance
+// (read-only) mode and which switch put it there.
+type MaintenanceState struct {
+	Enabled bool `json:"enabled"`
+	// Message is the banner shown to clients while maintenance is active.
+	Message string `json:"message,omitempty"`
+	// Source is "config" for the platform-wide switch or "runtime" for the
+	// deployment-level toggle set via the admin API.
+	Source    string     `json:"source,omitempty"`
+	Since     *time.Time `json:"since,omitempty"`
+	UpdatedBy string     `json:"updatedBy,omitempty"`
+}
@@ -67,6 +67,9 @@ type MetricMetadataSyncRequest struct {
 	ForceFullSync bool       `json:"force_full_sync"`      // Force full resync instead of incremental
 	TimeRange     *TimeRange `json:"time_range,omitempty"` // Time range to scan for metrics
 	BatchSize     int        `json:"batch_size,omitempty"` // Batch size for processing
+	// Match restricts the sync to metrics with series matching one of these
+	// selectors (e.g. {service="checkout"}); empty syncs every metric.
+	Match []string `json:"match,omitempty"`
 }
 
 // MetricMetadataSyncResult represents the result of a metadata sync operation
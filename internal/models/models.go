@@ -27,6 +27,28 @@ type CorrelationResult struct {
 	RedAnchors      []*RedAnchor     `json:"red_anchors"`
 	Recommendations []string         `json:"recommendations"`
 	CreatedAt       time.Time        `json:"created_at"`
+	// Excluded lists candidates dropped by correlation suppression rules.
+	Excluded []ExcludedCause `json:"excluded,omitempty"`
+	// Pruning reports how many candidates the early pruning stages dropped
+	// before full statistics; omitted when no pruning ran.
+	Pruning *CandidatePruning `json:"pruning,omitempty"`
+}
+
+// CandidatePruning summarises early candidate pruning: Candidates were
+// discovered, each stage dropped Pruned of them, and Evaluated got full
+// statistics.
+type CandidatePruning struct {
+	Candidates int            `json:"candidates"`
+	Stages     []PruningStage `json:"stages"`
+	Evaluated  int            `json:"evaluated"`
+}
+
+// PruningStage is one pruning step: "screen" drops candidates that barely
+// moved between the first and the core ring, "budget" keeps the ones that
+// moved most.
+type PruningStage struct {
+	Name   string `json:"name"`
+	Pruned int    `json:"pruned"`
 }
 
 // CorrelationStats holds statistical correlation outputs for an Impact<->Cause pair.
@@ -83,7 +105,7 @@ type TimelineEvent struct {
 	Service      string    `json:"service"`
 	Severity     string    `json:"severity"`
 	AnomalyScore float64   `json:"anomaly_score"`
-	DataSource   string    `json:"data_source"` // metrics, logs, traces
+	DataSource   string    `json:"data_source"` // metrics, logs, traces, events
 }
 
 // RCA List Correlations models
@@ -137,7 +159,9 @@ type GetPatternsResponse struct {
 type FeedbackRequest struct {
 	CorrelationID string `json:"correlation_id"`
 	Correct       bool   `json:"correct"`
-	Notes         string `json:"notes,omitempty"`
+	// CauseKPI optionally names the actual root-cause KPI (id or name).
+	CauseKPI string `json:"cause_kpi,omitempty"`
+	Notes    string `json:"notes,omitempty"`
 }
 
 type FeedbackResponse struct {
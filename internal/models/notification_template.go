@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// Notification channels and event types a template can target. "any"
+// matches every channel or event type without a more specific template.
+const (
+	NotificationChannelSlack = "slack"
+	NotificationChannelTeams = "teams"
+	NotificationChannelEmail = "email"
+	NotificationTemplateAny  = "any"
+)
+
+// NotificationTemplate overrides the title and message of notifications of
+// one event type sent to one channel in one locale. Title and Body are Go
+// text/template sources rendered with the notification; see
+// docs/api-docs.md for the data and functions available.
+type NotificationTemplate struct {
+	Channel   string    `json:"channel"`   // slack, teams, email or any
+	EventType string    `json:"eventType"` // correlation, mention, remediation, self_slo or any
+	Locale    string    `json:"locale"`    // e.g. en, de, fr-ca
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	UpdatedAt time.Time `json:"updatedAt,omitempty"`
+	UpdatedBy string    `json:"updatedBy,omitempty"`
+}
+
+// NotificationTemplatePreviewRequest renders a template without storing it.
+// Notification is the sample to render; a built-in sample for the event
+// type is used when it is omitted.
+type NotificationTemplatePreviewRequest struct {
+	Template     NotificationTemplate `json:"template"`
+	Notification *Notification        `json:"notification,omitempty"`
+}
+
+// NotificationTemplatePreview is a rendered template.
+type NotificationTemplatePreview struct {
+	Channel   string `json:"channel"`
+	EventType string `json:"eventType"`
+	Locale    string `json:"locale"`
+	Title     string `json:"title"`
+	Message   string `json:"message"`
+	// Template is false when no template matched and the built-in text is
+	// shown.
+	Template bool `json:"template"`
+}
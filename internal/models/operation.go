@@ -0,0 +1,47 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Operation states.
+const (
+	OperationRunning   = "running"
+	OperationSucceeded = "succeeded"
+	OperationFailed    = "failed"
+	OperationCancelled = "cancelled"
+)
+
+// OperationItemError is the failure of one item of a bulk operation.
+type OperationItemError struct {
+	Item  string `json:"item"`
+	Error string `json:"error"`
+}
+
+// Operation tracks a long-running job (KPI import, retention purge, ...)
+// started in the background. Done counts processed items, failed or not;
+// Percent is Done over Total. Result is the job's own result once it has
+// finished; Error is set when the job as a whole failed.
+type Operation struct {
+	ID              string               `json:"id"`
+	Kind            string               `json:"kind"`
+	Status          string               `json:"status"`
+	Total           int                  `json:"total"`
+	Done            int                  `json:"done"`
+	Failed          int                  `json:"failed"`
+	Percent         float64              `json:"percent"`
+	Errors          []OperationItemError `json:"errors,omitempty"`
+	Error           string               `json:"error,omitempty"`
+	Result          json.RawMessage      `json:"result,omitempty"`
+	CancelRequested bool                 `json:"cancelRequested,omitempty"`
+	CreatedBy       string               `json:"createdBy,omitempty"`
+	CreatedAt       time.Time            `json:"createdAt"`
+	UpdatedAt       time.Time            `json:"updatedAt"`
+	FinishedAt      *time.Time           `json:"finishedAt,omitempty"`
+}
+
+// Finished reports whether the operation has reached a final state.
+func (o *Operation) Finished() bool {
+	return o.Status != OperationRunning
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// ProfileCapture is one stored pprof profile. Captures taken together share
+// a CapturedAt and Trigger.
+type ProfileCapture struct {
+	Name       string    `json:"name"`
+	Kind       string    `json:"kind"`    // cpu | heap | goroutine
+	Trigger    string    `json:"trigger"` // cpu | heap | goroutines | latency
+	Size       int64     `json:"size"`
+	CapturedAt time.Time `json:"capturedAt"`
+}
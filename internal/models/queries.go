@@ -124,6 +124,8 @@ type LogsQLQueryRequest struct {
 	QueryLanguage string            `json:"query_language,omitempty"`
 	SearchEngine  string            `json:"search_engine,omitempty"`  // "lucene" or "bleve"
 	Extra         map[string]string `json:"extra,omitempty" form:"-"` // passthrough flags (dedup, order, etc.)
+	// Computed columns added to each row of the result, by name
+	Columns []string `json:"columns,omitempty" form:"columns"`
 }
 
 type LogsQLQueryResult struct {
@@ -149,6 +151,8 @@ type LogExportRequest struct {
 	End           int64  `json:"end,omitempty"`
 	Limit         int    `json:"limit,omitempty"`
 	QueryLanguage string `json:"query_language,omitempty"`
+	// Profile names the anonymization profile applied to the rows.
+	Profile string `json:"profile,omitempty"`
 }
 
 type LogExportResult struct {
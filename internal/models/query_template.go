@@ -0,0 +1,92 @@
+package models
+
+import "time"
+
+// Query template review states. Only approved templates can be executed;
+// any edit sends a template back to pending.
+const (
+	QueryTemplatePending  = "pending"
+	QueryTemplateApproved = "approved"
+	QueryTemplateRejected = "rejected"
+)
+
+// Query template engines.
+const (
+	QueryTemplateMetrics = "metrics" // MetricsQL
+	QueryTemplateLogs    = "logs"    // LogsQL
+)
+
+// Query template parameter types.
+const (
+	QueryParamString   = "string"
+	QueryParamInt      = "int"
+	QueryParamFloat    = "float"
+	QueryParamDuration = "duration"
+	QueryParamEnum     = "enum"
+)
+
+// QueryTemplateParameter declares a ${name} placeholder of a template.
+// String values are substituted as quoted, escaped literals, so a template
+// writes {service=${service}} rather than {service="${service}"}. Enum
+// values are substituted as-is and must be one of Values. Min and Max bound
+// int, float and duration (e.g. "5m") values. Parameters without a Default
+// must be supplied on every execution.
+type QueryTemplateParameter struct {
+	Name        string   `json:"name"`
+	Type        string   `json:"type"`
+	Description string   `json:"description,omitempty"`
+	Default     string   `json:"default,omitempty"`
+	Min         string   `json:"min,omitempty"`
+	Max         string   `json:"max,omitempty"`
+	MaxLength   int      `json:"maxLength,omitempty"`
+	Pattern     string   `json:"pattern,omitempty"`
+	Values      []string `json:"values,omitempty"`
+}
+
+// QueryTemplate is a parameterized MetricsQL or LogsQL query that runs
+// only once approved by someone other than its last editor.
+type QueryTemplate struct {
+	Name          string                   `json:"name"`
+	Description   string                   `json:"description,omitempty"`
+	Engine        string                   `json:"engine"`
+	Query         string                   `json:"query"`
+	Parameters    []QueryTemplateParameter `json:"parameters,omitempty"`
+	Columns       []string                 `json:"columns,omitempty"` // computed columns (logs)
+	Status        string                   `json:"status"`
+	Version       int                      `json:"version"`
+	UpdatedBy     string                   `json:"updatedBy,omitempty"`
+	UpdatedAt     time.Time                `json:"updatedAt"`
+	ReviewedBy    string                   `json:"reviewedBy,omitempty"`
+	ReviewedAt    *time.Time               `json:"reviewedAt,omitempty"`
+	ReviewComment string                   `json:"reviewComment,omitempty"`
+}
+
+// QueryTemplateReview approves or rejects a template. A non-zero Version
+// must match the template's, so a reviewer never approves an edit they have
+// not seen.
+type QueryTemplateReview struct {
+	Version  int    `json:"version,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+	Reviewer string `json:"-"`
+}
+
+// QueryTemplateExecuteRequest runs an approved template. Metrics templates
+// run as an instant query at End (default now) unless Start is set; logs
+// templates default to the hour before End.
+type QueryTemplateExecuteRequest struct {
+	Parameters map[string]any `json:"parameters,omitempty"`
+	Start      *time.Time     `json:"start,omitempty"`
+	End        *time.Time     `json:"end,omitempty"`
+	Step       string         `json:"step,omitempty"`  // metrics range queries
+	Limit      int            `json:"limit,omitempty"` // logs
+}
+
+// QueryTemplateResult is the outcome of a template execution, with the
+// query that was actually run.
+type QueryTemplateResult struct {
+	Template string `json:"template"`
+	Version  int    `json:"version"`
+	Engine   string `json:"engine"`
+	Query    string `json:"query"`
+	Data     any    `json:"data"`
+}
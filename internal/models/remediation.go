@@ -0,0 +1,102 @@
+package models
+
+import "time"
+
+// Remediation event types.
+const (
+	// RemediationEventKPIState is raised when a KPI enters a warning or
+	// critical threshold state.
+	RemediationEventKPIState = "kpi_state"
+	// RemediationEventAlert is an alert posted by an external system.
+	RemediationEventAlert = "alert"
+)
+
+// Remediation action types.
+const (
+	RemediationActionWebhook      = "webhook"
+	RemediationActionNotification = "notification"
+	RemediationActionJob          = "job"
+)
+
+// Outcomes of one remediation action.
+const (
+	RemediationActionSucceeded = "succeeded"
+	RemediationActionFailed    = "failed"
+	RemediationActionDryRun    = "dry_run"
+)
+
+// RemediationEvent is an incident or alert evaluated against the
+// remediation rules.
+type RemediationEvent struct {
+	Type     string            `json:"type"`
+	Service  string            `json:"service,omitempty"`
+	KPIID    string            `json:"kpiId,omitempty"`
+	KPIName  string            `json:"kpiName,omitempty"`
+	Severity string            `json:"severity,omitempty"` // warning, critical, ...
+	Summary  string            `json:"summary,omitempty"`
+	Value    *float64          `json:"value,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Time     time.Time         `json:"time"`
+}
+
+// RemediationMatch selects the events a rule acts on. Every set condition
+// must hold. Services, KPIs and label values are case-insensitive glob
+// patterns (* and ?); KPIs match a KPI's name or ID. Within one list any
+// entry may match.
+type RemediationMatch struct {
+	EventTypes []string          `json:"eventTypes,omitempty"`
+	Services   []string          `json:"services,omitempty"`
+	KPIs       []string          `json:"kpis,omitempty"`
+	Severities []string          `json:"severities,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+}
+
+// RemediationAction is one step run when a rule matches. Webhooks POST the
+// event to URL; job triggers POST Job and Params to URL; notifications go
+// to the configured integrations (Slack, Teams, email). Param values may
+// use the {service}, {kpi} and {severity} placeholders.
+type RemediationAction struct {
+	Type    string            `json:"type"`
+	URL     string            `json:"url,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Job     string            `json:"job,omitempty"`
+	Params  map[string]string `json:"params,omitempty"`
+	Title   string            `json:"title,omitempty"`
+}
+
+// RemediationRule runs its actions for every event it matches. A dry-run
+// rule records what it would have done without running any action.
+// CooldownSeconds suppresses repeated runs for the same service and KPI.
+type RemediationRule struct {
+	ID              string              `json:"id"`
+	Name            string              `json:"name"`
+	Description     string              `json:"description,omitempty"`
+	Enabled         bool                `json:"enabled"`
+	DryRun          bool                `json:"dryRun"`
+	Match           RemediationMatch    `json:"match"`
+	Actions         []RemediationAction `json:"actions"`
+	CooldownSeconds int                 `json:"cooldownSeconds,omitempty"`
+	CreatedBy       string              `json:"createdBy,omitempty"`
+	CreatedAt       time.Time           `json:"createdAt"`
+	UpdatedAt       time.Time           `json:"updatedAt"`
+}
+
+// RemediationActionResult is the outcome of one action of an execution.
+type RemediationActionResult struct {
+	Type       string `json:"type"`
+	Target     string `json:"target,omitempty"`
+	Status     string `json:"status"`
+	StatusCode int    `json:"statusCode,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// RemediationExecution records one run of a rule for an event.
+type RemediationExecution struct {
+	ID       string                    `json:"id"`
+	RuleID   string                    `json:"ruleId"`
+	RuleName string                    `json:"ruleName"`
+	DryRun   bool                      `json:"dryRun"`
+	Event    RemediationEvent          `json:"event"`
+	Actions  []RemediationActionResult `json:"actions"`
+	Time     time.Time                 `json:"time"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// ReplicationStatus reports the active/passive role of a deployment and, for
+// replicas, how far behind the primary the replicated data is.
+type ReplicationStatus struct {
+	Role          string     `json:"role"`
+	PrimaryURL    string     `json:"primaryUrl,omitempty"`
+	LastHeartbeat *time.Time `json:"lastHeartbeat,omitempty"`
+	// LagSeconds is -1 when no heartbeat from the primary has been seen.
+	LagSeconds float64 `json:"lagSeconds"`
+	// Healthy is false for a replica whose lag exceeds the configured maximum.
+	Healthy bool `json:"healthy"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// ResidencyFlow is one backend host the tenant's data is sent to.
+type ResidencyFlow struct {
+	Backend string `json:"backend"`
+	Field   string `json:"field"`
+	Host    string `json:"host"`
+	// Region is the configured region whose hosts include Host, if any.
+	Region   string `json:"region,omitempty"`
+	InRegion bool   `json:"in_region"`
+}
+
+// ResidencyReport lists where the tenant's data flows and whether every
+// backend is in the tenant's region.
+type ResidencyReport struct {
+	Tenant      string          `json:"tenant,omitempty"`
+	Region      string          `json:"region,omitempty"`
+	Strict      bool            `json:"strict"`
+	Compliant   bool            `json:"compliant"`
+	Flows       []ResidencyFlow `json:"flows"`
+	GeneratedAt time.Time       `json:"generated_at"`
+}
@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+// Retention policy signal types.
+const (
+	RetentionSignalMetrics = "metrics"
+	RetentionSignalLogs    = "logs"
+)
+
+// RetentionPolicy keeps data matched by Selector for Retention and purges
+// the rest. For logs Selector is a LogsQL filter and logs older than the
+// cutoff are deleted. For metrics Selector is a series selector; because
+// VictoriaMetrics only deletes whole series, series that have had no samples
+// since the cutoff are deleted.
+type RetentionPolicy struct {
+	ID          string     `json:"id"`
+	Signal      string     `json:"signal"`
+	Selector    string     `json:"selector"`
+	Retention   string     `json:"retention"` // e.g. 30d, 12w, 720h
+	Enabled     bool       `json:"enabled"`
+	Description string     `json:"description,omitempty"`
+	CreatedBy   string     `json:"createdBy,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	LastPurgeAt *time.Time `json:"lastPurgeAt,omitempty"`
+}
+
+// RetentionPreview describes what a purge of a policy would delete.
+type RetentionPreview struct {
+	PolicyID string    `json:"policyId"`
+	Signal   string    `json:"signal"`
+	Cutoff   time.Time `json:"cutoff"`
+	// Filter is the LogsQL delete filter or the series selector scanned.
+	Filter string `json:"filter"`
+	// Matched counts the log rows or series that would be deleted; for
+	// metrics it is capped at the per-run series limit.
+	Matched int      `json:"matched"`
+	Sample  []string `json:"sample,omitempty"` // up to 20 series that would be deleted
+}
+
+// RetentionPurge is the audit record of one executed purge.
+type RetentionPurge struct {
+	ID         string    `json:"id"`
+	PolicyID   string    `json:"policyId"`
+	Signal     string    `json:"signal"`
+	Selector   string    `json:"selector"`
+	Retention  string    `json:"retention"`
+	Cutoff     time.Time `json:"cutoff"`
+	Matched    int       `json:"matched"`
+	Status     string    `json:"status"` // succeeded | failed
+	Error      string    `json:"error,omitempty"`
+	Tasks      []string  `json:"tasks,omitempty"` // VictoriaLogs delete task IDs
+	ExecutedBy string    `json:"executedBy"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+}
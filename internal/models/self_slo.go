@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// SelfSLOStatus is one mirador-core endpoint measured against its objectives
+// over the report window.
+type SelfSLOStatus struct {
+	Name     string `json:"name"`
+	Method   string `json:"method"`
+	Endpoint string `json:"endpoint"`
+	Requests uint64 `json:"requests"`
+	Errors   uint64 `json:"errors"` // 5xx responses
+
+	Availability          float64 `json:"availability"`
+	AvailabilityObjective float64 `json:"availabilityObjective"`
+	// ErrorBudgetRemaining is the unspent share of the allowed errors; it
+	// goes negative once the budget is exhausted.
+	ErrorBudgetRemaining float64 `json:"errorBudgetRemaining"`
+
+	// LatencyThreshold is the histogram bucket bound actually measured
+	// (the configured threshold rounded up).
+	LatencyThreshold  string  `json:"latencyThreshold"`
+	LatencyCompliance float64 `json:"latencyCompliance"`
+	LatencyObjective  float64 `json:"latencyObjective"`
+
+	Breached bool     `json:"breached"`
+	Reasons  []string `json:"reasons,omitempty"`
+}
+
+// SelfSLOReport is the current state of mirador-core's own SLOs. Coverage is
+// how much of Window has been observed since startup.
+type SelfSLOReport struct {
+	Window      string          `json:"window"`
+	Coverage    string          `json:"coverage"`
+	Targets     []SelfSLOStatus `json:"targets"`
+	Breached    int             `json:"breached"`
+	EvaluatedAt time.Time       `json:"evaluatedAt"`
+}
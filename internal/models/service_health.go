@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// Service health statuses derived from the overall score.
+const (
+	HealthStatusHealthy  = "healthy"  // score >= 80
+	HealthStatusDegraded = "degraded" // score >= 50
+	HealthStatusCritical = "critical"
+	HealthStatusUnknown  = "unknown" // no component could be evaluated
+)
+
+// HealthComponent is one input to a service health score.
+type HealthComponent struct {
+	Name string `json:"name"` // kpis | alerts | incidents | error_budget
+	// Score is 0-100; Weight is the component's share of the overall score
+	// before unavailable components are excluded.
+	Score     float64 `json:"score"`
+	Weight    float64 `json:"weight"`
+	Available bool    `json:"available"`
+	// Detail explains why a component is unavailable.
+	Detail  string         `json:"detail,omitempty"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// ServiceHealthScore combines KPI threshold states, firing alerts, recent
+// incidents and error-budget burn into a single 0-100 score.
+type ServiceHealthScore struct {
+	Service    string            `json:"service"`
+	Score      int               `json:"score"`
+	Status     string            `json:"status"`
+	Components []HealthComponent `json:"components"`
+	ComputedAt time.Time         `json:"computedAt"`
+}
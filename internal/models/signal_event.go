@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// Signal event sources and the deploy type.
+const (
+	EventSourceKubernetes = "kubernetes"
+	EventSourceCI         = "ci"
+	EventTypeDeploy       = "deploy"
+)
+
+// SignalEvent is a discrete operational event, such as a Kubernetes event or
+// a CI/CD deploy, ingested so correlation can weigh it against the impact
+// window and show it on the incident timeline.
+type SignalEvent struct {
+	ID        string            `json:"id"`
+	Source    string            `json:"source"` // kubernetes, ci, ...
+	Type      string            `json:"type"`   // deploy, Warning, Normal, ...
+	Reason    string            `json:"reason,omitempty"`
+	Service   string            `json:"service"`
+	Namespace string            `json:"namespace,omitempty"`
+	Object    string            `json:"object,omitempty"` // e.g. Pod/checkout-7d9f8b6c4-x2kqz
+	Message   string            `json:"message,omitempty"`
+	Count     int               `json:"count,omitempty"` // repeats folded into this event
+	Labels    map[string]string `json:"labels,omitempty"`
+	Time      time.Time         `json:"time"`
+}
+
+// KubernetesEvent is the subset of a core/v1 Event posted by event
+// exporters.
+type KubernetesEvent struct {
+	Metadata struct {
+		UID               string    `json:"uid"`
+		Namespace         string    `json:"namespace"`
+		CreationTimestamp time.Time `json:"creationTimestamp"`
+	} `json:"metadata"`
+	InvolvedObject struct {
+		Kind      string            `json:"kind"`
+		Namespace string            `json:"namespace"`
+		Name      string            `json:"name"`
+		Labels    map[string]string `json:"labels,omitempty"`
+	} `json:"involvedObject"`
+	Reason         string    `json:"reason"`
+	Message        string    `json:"message"`
+	Type           string    `json:"type"`
+	Count          int       `json:"count"`
+	FirstTimestamp time.Time `json:"firstTimestamp"`
+	LastTimestamp  time.Time `json:"lastTimestamp"`
+	EventTime      time.Time `json:"eventTime"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// TenantSettings holds presentation defaults for the tenant served by this
+// mirador-core deployment. Each deployment is tenant-specific, so there is a
+// single settings document per deployment.
+type TenantSettings struct {
+	// DefaultLocale is the BCP 47 language tag used when a caller does not
+	// request a specific language (e.g. "en", "de", "fr").
+	DefaultLocale string `json:"defaultLocale"`
+	// DateFormat and TimeFormat are Go reference layouts used when rendering
+	// timestamps in reports and notifications.
+	DateFormat string `json:"dateFormat"`
+	TimeFormat string `json:"timeFormat"`
+	// Timezone is an IANA zone name (e.g. "Europe/Berlin").
+	Timezone string           `json:"timezone"`
+	Branding BrandingSettings `json:"branding"`
+	// AllowedOrigins are browser origins (e.g. "https://ops.acme.com" or
+	// "*.acme.com") allowed by CORS in addition to cors.allowed_origins.
+	AllowedOrigins []string `json:"allowedOrigins,omitempty"`
+	// UpdatedAt is set by the server when settings are stored.
+	UpdatedAt time.Time `json:"updatedAt,omitempty"`
+}
+
+// BrandingSettings controls the tenant branding applied to reports and emails.
+type BrandingSettings struct {
+	DisplayName    string `json:"displayName,omitempty"`
+	LogoURL        string `json:"logoUrl,omitempty"`
+	PrimaryColor   string `json:"primaryColor,omitempty"`   // hex, e.g. "#1F6FEB"
+	SecondaryColor string `json:"secondaryColor,omitempty"` // hex
+	EmailFooter    string `json:"emailFooter,omitempty"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// TenantStatsSnapshot holds the tenant's object counts and sizes at one
+// point in time, keyed by statistic (e.g. "kpis", "weaviate.FailureRecord").
+// Unavailable lists the statistics that could not be collected, with why.
+type TenantStatsSnapshot struct {
+	Tenant      string           `json:"tenant,omitempty"`
+	TakenAt     time.Time        `json:"takenAt"`
+	Counts      map[string]int64 `json:"counts"`
+	Unavailable []string         `json:"unavailable,omitempty"`
+}
+
+// TenantStatsDelta is how much a statistic changed since the previous
+// snapshot, and since the snapshots closest to one day and one week before
+// the latest. A delta is omitted when no snapshot is old enough or the
+// statistic was not collected then.
+type TenantStatsDelta struct {
+	Previous *int64 `json:"previous,omitempty"`
+	Day      *int64 `json:"day,omitempty"`
+	Week     *int64 `json:"week,omitempty"`
+}
+
+// TenantStatsOverview is the latest snapshot with the trend of each
+// statistic.
+type TenantStatsOverview struct {
+	Snapshot TenantStatsSnapshot         `json:"snapshot"`
+	Deltas   map[string]TenantStatsDelta `json:"deltas"`
+}
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// UsageReport is the anonymized usage telemetry payload. It holds only the
+// random deployment ID, the build version and request counts per route
+// template; it is served verbatim at /api/v1/admin/telemetry and is exactly
+// what the collector receives.
+type UsageReport struct {
+	DeploymentID string          `json:"deploymentId"`
+	Version      string          `json:"version,omitempty"`
+	PeriodStart  time.Time       `json:"periodStart"`
+	PeriodEnd    time.Time       `json:"periodEnd"`
+	Features     []FeatureUsage  `json:"features"`
+	Endpoints    []EndpointUsage `json:"endpoints"`
+}
+
+// FeatureUsage rolls endpoint counts up to a feature area (the first path
+// segment under /api/v1, e.g. "unified" or "kpi").
+type FeatureUsage struct {
+	Feature  string `json:"feature"`
+	Requests int64  `json:"requests"`
+}
+
+// EndpointUsage counts requests to one route template.
+type EndpointUsage struct {
+	Method   string `json:"method"`
+	Route    string `json:"route"`
+	Requests int64  `json:"requests"`
+	Errors   int64  `json:"errors"` // 5xx responses
+}
+
+// UsageTelemetryStatus reports whether telemetry is on, where it is sent and
+// the payload of the current period.
+type UsageTelemetryStatus struct {
+	Enabled      bool        `json:"enabled"`
+	CollectorURL string      `json:"collectorUrl,omitempty"`
+	LastSentAt   *time.Time  `json:"lastSentAt,omitempty"`
+	LastError    string      `json:"lastError,omitempty"`
+	Pending      UsageReport `json:"pending"`
+}
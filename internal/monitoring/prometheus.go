@@ -138,6 +138,23 @@ var (
 		[]string{"operation", "result"}, // result: hit, miss, error
 	)
 
+	// Per-tenant cache metrics for capacity analysis
+	cacheTenantOperationsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "mirador_core_cache_tenant_operations_total",
+			Help: "Total number of cache operations per tenant namespace",
+		},
+		[]string{"tenant", "operation", "result"},
+	)
+
+	cacheTenantKeys = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "mirador_core_cache_tenant_keys",
+			Help: "Number of cache keys per tenant namespace at the last scan",
+		},
+		[]string{"tenant"},
+	)
+
 	// API operation metrics
 	apiOperationsTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
@@ -429,6 +446,8 @@ func SetupPrometheusMetrics(router gin.IRoutes) {
 	_ = prometheus.Register(dbOperationsTotal)            //nolint:errcheck
 	_ = prometheus.Register(dbOperationDuration)          //nolint:errcheck
 	_ = prometheus.Register(cacheOperationsTotal)         //nolint:errcheck
+	_ = prometheus.Register(cacheTenantOperationsTotal)   //nolint:errcheck
+	_ = prometheus.Register(cacheTenantKeys)              //nolint:errcheck
 	_ = prometheus.Register(apiOperationsTotal)           //nolint:errcheck
 	_ = prometheus.Register(apiOperationDuration)         //nolint:errcheck
 	_ = prometheus.Register(victoriaMetricsQueriesTotal)  //nolint:errcheck
@@ -521,6 +540,16 @@ func RecordCacheOperation(operation, result string) {
 	}
 }
 
+// RecordCacheTenantOperation records cache operation metrics for a tenant namespace
+func RecordCacheTenantOperation(tenant, operation, result string) {
+	cacheTenantOperationsTotal.WithLabelValues(tenant, operation, result).Inc()
+}
+
+// RecordCacheTenantKeys records the key count of a tenant namespace
+func RecordCacheTenantKeys(tenant string, keys int64) {
+	cacheTenantKeys.WithLabelValues(tenant).Set(float64(keys))
+}
+
 // RecordAPIOperation records API operation metrics
 func RecordAPIOperation(operation, resource string, duration time.Duration, success bool) {
 	status := "success"
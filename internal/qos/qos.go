@@ -0,0 +1,223 @@
+// Package qos schedules backend queries by traffic class so scheduled and
+// bulk work cannot starve interactive dashboard queries.
+//
+// Requests carry a Class in their context (interactive unless tagged
+// otherwise). The process-wide Scheduler bounds how many backend requests
+// run at once, caps each class separately, and hands freed slots to waiting
+// requests in priority order. Scheduling is off until Configure is called
+// with a non-zero limit; until then Acquire returns immediately.
+package qos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/metrics"
+)
+
+// Class is the traffic class of a backend request.
+type Class string
+
+const (
+	// Interactive is user-facing traffic (API and dashboard queries).
+	Interactive Class = "interactive"
+	// Background is scheduled refresh work (health scores, summaries).
+	Background Class = "background"
+	// Batch is bulk work such as metadata sync and report generation.
+	Batch Class = "batch"
+)
+
+// Classes lists the classes from highest to lowest priority.
+var Classes = []Class{Interactive, Background, Batch}
+
+// ErrQueueTimeout is wrapped by the error returned when a request waited
+// longer than its class's queue timeout.
+var ErrQueueTimeout = errors.New("query queue timeout")
+
+// ParseClass returns the class named s.
+func ParseClass(s string) (Class, bool) {
+	for _, c := range Classes {
+		if string(c) == s {
+			return c, true
+		}
+	}
+	return "", false
+}
+
+type classKey struct{}
+
+// WithClass tags ctx with a traffic class.
+func WithClass(ctx context.Context, c Class) context.Context {
+	return context.WithValue(ctx, classKey{}, c)
+}
+
+// ClassFrom returns the traffic class of ctx, Interactive when untagged.
+func ClassFrom(ctx context.Context) Class {
+	if c, ok := ctx.Value(classKey{}).(Class); ok {
+		return c
+	}
+	return Interactive
+}
+
+// ClassLimit bounds one class. MaxConcurrent 0 lets the class use every
+// slot; QueueTimeout 0 waits as long as the request context allows.
+type ClassLimit struct {
+	MaxConcurrent int
+	QueueTimeout  time.Duration
+}
+
+type waiter struct {
+	ready   chan struct{}
+	granted bool
+}
+
+// Scheduler hands out backend request slots by class priority.
+type Scheduler struct {
+	mu       sync.Mutex
+	total    int
+	limits   map[Class]ClassLimit
+	inFlight map[Class]int
+	running  int
+	queues   map[Class][]*waiter
+}
+
+// NewScheduler creates a scheduler running at most total requests at once;
+// total 0 disables scheduling.
+func NewScheduler(total int, limits map[Class]ClassLimit) *Scheduler {
+	return &Scheduler{
+		total:    total,
+		limits:   limits,
+		inFlight: map[Class]int{},
+		queues:   map[Class][]*waiter{},
+	}
+}
+
+var defaultScheduler = NewScheduler(0, nil)
+
+// Default returns the process-wide scheduler used by client wrappers.
+func Default() *Scheduler { return defaultScheduler }
+
+// Configure replaces the limits of the process-wide scheduler. Call it at
+// startup before traffic flows.
+func Configure(total int, limits map[Class]ClassLimit) {
+	defaultScheduler = NewScheduler(total, limits)
+}
+
+// Acquire waits for a slot for the class of ctx and returns the function
+// that frees it. It fails when ctx ends or the class's queue timeout passes.
+func (s *Scheduler) Acquire(ctx context.Context) (func(), error) {
+	if s.total <= 0 {
+		return func() {}, nil
+	}
+	class := ClassFrom(ctx)
+	start := time.Now()
+
+	s.mu.Lock()
+	if len(s.queues[class]) == 0 && !s.higherWaiting(class) && s.canRun(class) {
+		s.start(class)
+		s.mu.Unlock()
+		metrics.QueryQueueWait.WithLabelValues(string(class)).Observe(0)
+		return s.releaser(class), nil
+	}
+	w := &waiter{ready: make(chan struct{})}
+	s.queues[class] = append(s.queues[class], w)
+	metrics.QueryQueueDepth.WithLabelValues(string(class)).Inc()
+	s.mu.Unlock()
+
+	var timeout <-chan time.Time
+	if limit := s.limits[class].QueueTimeout; limit > 0 {
+		timer := time.NewTimer(limit)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	var err error
+	select {
+	case <-w.ready:
+		metrics.QueryQueueWait.WithLabelValues(string(class)).Observe(time.Since(start).Seconds())
+		return s.releaser(class), nil
+	case <-ctx.Done():
+		err = ctx.Err()
+	case <-timeout:
+		err = fmt.Errorf("%w: %s request waited %s for a backend slot", ErrQueueTimeout, class, s.limits[class].QueueTimeout)
+		metrics.QueryQueueTimeouts.WithLabelValues(string(class)).Inc()
+	}
+
+	s.mu.Lock()
+	if w.granted {
+		// Granted while giving up: pass the slot on.
+		s.finish(class)
+	} else {
+		s.remove(class, w)
+	}
+	s.mu.Unlock()
+	return nil, err
+}
+
+func (s *Scheduler) releaser(class Class) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.mu.Lock()
+			s.finish(class)
+			s.mu.Unlock()
+		})
+	}
+}
+
+// canRun reports whether class may start a request now. Callers hold s.mu.
+func (s *Scheduler) canRun(class Class) bool {
+	limit := s.limits[class].MaxConcurrent
+	return s.running < s.total && (limit <= 0 || s.inFlight[class] < limit)
+}
+
+// higherWaiting reports whether a higher-priority request is queued and
+// could use a free slot. Callers hold s.mu.
+func (s *Scheduler) higherWaiting(class Class) bool {
+	for _, c := range Classes {
+		if c == class {
+			return false
+		}
+		if len(s.queues[c]) > 0 && s.canRun(c) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Scheduler) start(class Class) {
+	s.running++
+	s.inFlight[class]++
+	metrics.QueryInFlight.WithLabelValues(string(class)).Inc()
+}
+
+// finish frees a slot and grants freed capacity to queued requests, highest
+// priority first. Callers hold s.mu.
+func (s *Scheduler) finish(class Class) {
+	s.running--
+	s.inFlight[class]--
+	metrics.QueryInFlight.WithLabelValues(string(class)).Dec()
+	for _, c := range Classes {
+		for len(s.queues[c]) > 0 && s.canRun(c) {
+			w := s.queues[c][0]
+			s.queues[c] = s.queues[c][1:]
+			metrics.QueryQueueDepth.WithLabelValues(string(c)).Dec()
+			w.granted = true
+			s.start(c)
+			close(w.ready)
+		}
+	}
+}
+
+func (s *Scheduler) remove(class Class, w *waiter) {
+	q := s.queues[class]
+	for i := range q {
+		if q[i] == w {
+			s.queues[class] = append(q[:i:i], q[i+1:]...)
+			metrics.QueryQueueDepth.WithLabelValues(string(class)).Dec()
+			return
+		}
+	}
+}
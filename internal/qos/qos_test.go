@@ -0,0 +1,120 @@
+package qos
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func acquireAsync(s *Scheduler, class Class) <-chan func() {
+	ch := make(chan func(), 1)
+	go func() {
+		release, err := s.Acquire(WithClass(context.Background(), class))
+		if err != nil {
+			close(ch)
+			return
+		}
+		ch <- release
+	}()
+	return ch
+}
+
+func waitQueued(t *testing.T, s *Scheduler, class Class, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		s.mu.Lock()
+		queued := len(s.queues[class])
+		s.mu.Unlock()
+		if queued == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("expected %d queued %s requests", n, class)
+}
+
+func TestScheduler_InteractiveBeforeBatch(t *testing.T) {
+	s := NewScheduler(1, nil)
+	release, err := s.Acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	batch := acquireAsync(s, Batch)
+	waitQueued(t, s, Batch, 1)
+	interactive := acquireAsync(s, Interactive)
+	waitQueued(t, s, Interactive, 1)
+
+	release()
+	select {
+	case next := <-interactive:
+		next()
+	case <-batch:
+		t.Fatal("batch request ran before the queued interactive one")
+	case <-time.After(2 * time.Second):
+		t.Fatal("interactive request never ran")
+	}
+	select {
+	case next := <-batch:
+		next()
+	case <-time.After(2 * time.Second):
+		t.Fatal("batch request never ran")
+	}
+}
+
+func TestScheduler_ClassCapLeavesRoomForInteractive(t *testing.T) {
+	s := NewScheduler(3, map[Class]ClassLimit{Background: {MaxConcurrent: 1}})
+	bg := WithClass(context.Background(), Background)
+	release, err := s.Acquire(bg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	second := acquireAsync(s, Background)
+	waitQueued(t, s, Background, 1)
+
+	// The queued background request must not block interactive traffic.
+	for i := 0; i < 2; i++ {
+		r, err := s.Acquire(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer r()
+	}
+	select {
+	case <-second:
+		t.Fatal("background request exceeded its cap")
+	default:
+	}
+}
+
+func TestScheduler_QueueTimeout(t *testing.T) {
+	s := NewScheduler(1, map[Class]ClassLimit{Batch: {QueueTimeout: 10 * time.Millisecond}})
+	release, err := s.Acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	_, err = s.Acquire(WithClass(context.Background(), Batch))
+	if !errors.Is(err, ErrQueueTimeout) {
+		t.Fatalf("expected queue timeout, got %v", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.queues[Batch]) != 0 {
+		t.Fatal("timed-out request left in the queue")
+	}
+}
+
+func TestScheduler_DisabledPassesThrough(t *testing.T) {
+	s := NewScheduler(0, nil)
+	for i := 0; i < 100; i++ {
+		if _, err := s.Acquire(WithClass(context.Background(), Batch)); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package qos
+
+import (
+	"net/http"
+)
+
+type transport struct {
+	base http.RoundTripper
+}
+
+// WrapTransport returns a RoundTripper that holds a slot of the process-wide
+// scheduler for the duration of each request (until the response headers
+// arrive) before delegating to base (http.DefaultTransport when nil).
+func WrapTransport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{base: base}
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	release, err := Default().Acquire(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,29 @@
+package repo
+
+import "context"
+
+// maxAliasHops bounds alias chains (a merged into b, later b into c).
+const maxAliasHops = 5
+
+// KPIAliasKey is the Valkey key recording that the KPI with the given ID was
+// merged into another KPI; its value is the surviving KPI's ID.
+func KPIAliasKey(id string) string {
+	return "kpi:alias:" + id
+}
+
+// resolveAlias follows merge aliases from id and returns the ID of the KPI
+// that absorbed it, or false when id was never merged.
+func (r *DefaultKPIRepo) resolveAlias(ctx context.Context, id string) (string, bool) {
+	if r.valkey == nil {
+		return "", false
+	}
+	target := id
+	for i := 0; i < maxAliasHops; i++ {
+		b, err := r.valkey.Get(ctx, KPIAliasKey(target))
+		if err != nil || len(b) == 0 {
+			break
+		}
+		target = string(b)
+	}
+	return target, target != id
+}
@@ -0,0 +1,43 @@
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/weavstore"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// mapKPIStore returns KPIs from a map and nil for unknown IDs.
+type mapKPIStore struct {
+	mockKPIStoreMissing
+	kpis map[string]*weavstore.KPIDefinition
+}
+
+func (m *mapKPIStore) GetKPI(ctx context.Context, id string) (*weavstore.KPIDefinition, error) {
+	return m.kpis[id], nil
+}
+
+func Test_GetKPI_FollowsMergeAlias(t *testing.T) {
+	ctx := context.Background()
+	store := &mapKPIStore{kpis: map[string]*weavstore.KPIDefinition{"c": {ID: "c", Name: "checkout"}}}
+	valkey := cache.NewNoopValkeyCache(logger.New("error"))
+	r := NewDefaultKPIRepo(store, nil, valkey, nil)
+
+	// a was merged into b, later b into c.
+	require.NoError(t, valkey.Set(ctx, KPIAliasKey("a"), "b", time.Hour))
+	require.NoError(t, valkey.Set(ctx, KPIAliasKey("b"), "c", time.Hour))
+
+	k, err := r.GetKPI(ctx, "a")
+	require.NoError(t, err)
+	require.NotNil(t, k)
+	require.Equal(t, "c", k.ID)
+
+	k, err = r.GetKPI(ctx, "unknown")
+	require.NoError(t, err)
+	require.Nil(t, k)
+}
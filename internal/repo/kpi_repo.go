@@ -2,6 +2,7 @@ package repo
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -16,6 +17,13 @@ import (
 	"go.uber.org/zap"
 )
 
+// Deleted KPIs leave a models.KPITombstone under KPITombstoneKeyPrefix plus
+// the ID for KPITombstoneRetention, so change feeds can report deletions.
+const (
+	KPITombstoneKeyPrefix = "kpi:tombstone:"
+	KPITombstoneRetention = 30 * 24 * time.Hour
+)
+
 // KPIRepo extends SchemaStore with KPI-specific operations
 
 type KPIRepo interface {
@@ -241,6 +249,9 @@ func (r *DefaultKPIRepo) DeleteKPI(ctx context.Context, id string) (DeleteResult
 		return res, err
 	}
 
+	// Tombstone for change feeds, so offline clients learn of the delete
+	r.recordTombstone(ctx, id, &res)
+
 	// 2) Valkey best-effort cleanup
 	r.deleteFromValkey(ctx, id, &res)
 
@@ -278,6 +289,18 @@ func (r *DefaultKPIRepo) deleteFromWeaviate(ctx context.Context, id string, res
 	return nil
 }
 
+// recordTombstone stores a KPITombstone for a KPI deleted from Weaviate.
+// Failures are logged; the delete itself has succeeded.
+func (r *DefaultKPIRepo) recordTombstone(ctx context.Context, id string, res *DeleteResult) {
+	if r.valkey == nil || !res.Weaviate.Found {
+		return
+	}
+	data, _ := json.Marshal(models.KPITombstone{ID: id, DeletedAt: time.Now().UTC()})
+	if err := r.valkey.Set(ctx, KPITombstoneKeyPrefix+id, data, KPITombstoneRetention); err != nil && r.logger != nil {
+		r.logger.Warn("failed to record KPI tombstone", zap.String("id", id), zap.Error(err))
+	}
+}
+
 // deleteFromValkey handles best-effort cleanup from Valkey cache
 func (r *DefaultKPIRepo) deleteFromValkey(ctx context.Context, id string, res *DeleteResult) {
 	if r.valkey == nil {
@@ -423,6 +446,15 @@ func (r *DefaultKPIRepo) GetKPI(ctx context.Context, id string) (*models.KPIDefi
 	if err != nil {
 		return nil, err
 	}
+	if wk == nil {
+		// KPIs merged away as duplicates resolve to the surviving entry so
+		// existing references keep working.
+		if target, ok := r.resolveAlias(ctx, id); ok {
+			if wk, err = r.store.GetKPI(ctx, target); err != nil {
+				return nil, err
+			}
+		}
+	}
 	if wk == nil {
 		return nil, nil
 	}
@@ -0,0 +1,47 @@
+// Package residency keeps backend requests inside the tenant's region. The
+// check runs at the transport, after endpoint selection and discovery, so
+// endpoints refreshed from MariaDB or discovered at runtime are covered as
+// well as the configured ones.
+package residency
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/metrics"
+)
+
+// ErrOutsideRegion is returned for requests to hosts outside the region.
+var ErrOutsideRegion = errors.New("backend host is outside the tenant's residency region")
+
+type transport struct {
+	base    http.RoundTripper
+	backend string
+	policy  config.ResidencyConfig
+}
+
+// WrapTransport returns a RoundTripper refusing requests to hosts outside
+// policy's region. It returns base unchanged unless a region is set in
+// strict mode.
+func WrapTransport(base http.RoundTripper, policy config.ResidencyConfig, backend string) http.RoundTripper {
+	if policy.Region == "" || !policy.Strict {
+		return base
+	}
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{base: base, backend: backend, policy: policy}
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if host := req.URL.Hostname(); !t.policy.InRegion(host) {
+		metrics.ResidencyBlockedRequestsTotal.WithLabelValues(t.backend).Inc()
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, fmt.Errorf("%w: %s request to %s refused (region %s)", ErrOutsideRegion, t.backend, host, t.policy.Region)
+	}
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,42 @@
+package residency
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+)
+
+func TestWrapTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	policy := config.ResidencyConfig{
+		Region: "eu",
+		Strict: true,
+		Regions: map[string]config.ResidencyRegionConfig{
+			"eu": {Hosts: []string{"127.0.0.0/8", "*.eu.internal"}},
+			"us": {Hosts: []string{"*.us.internal"}},
+		},
+	}
+	client := &http.Client{Transport: WrapTransport(nil, policy, "victoria_metrics")}
+
+	resp, err := client.Get(srv.URL + "/api/v1/query")
+	if err != nil {
+		t.Fatalf("in-region request failed: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	_, err = client.Get("http://vmselect.us.internal:8481/api/v1/query")
+	if !errors.Is(err, ErrOutsideRegion) {
+		t.Fatalf("expected ErrOutsideRegion, got %v", err)
+	}
+
+	// Non-strict policies only report violations.
+	policy.Strict = false
+	if base := http.DefaultTransport; WrapTransport(base, policy, "victoria_metrics") != base {
+		t.Fatalf("expected base transport for a non-strict policy")
+	}
+}
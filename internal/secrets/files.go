@@ -0,0 +1,42 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileProvider reads "file:/path" references. The key part is ignored.
+type FileProvider struct{}
+
+func (FileProvider) Fetch(_ context.Context, ref Ref) (string, error) {
+	return readSecretFile(ref.Name)
+}
+
+// KubernetesProvider reads "k8s:<secret>/<key>" references from secrets
+// mounted as volumes under MountPath. Kubelet updates the files in place on
+// rotation, which Resolver.Refresh picks up.
+type KubernetesProvider struct {
+	MountPath string
+}
+
+func (p KubernetesProvider) Fetch(_ context.Context, ref Ref) (string, error) {
+	rel := filepath.Clean(ref.Name)
+	if ref.Key != "" {
+		rel = filepath.Join(rel, ref.Key)
+	}
+	if filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, "../") {
+		return "", fmt.Errorf("%w: %q escapes the secrets mount", ErrInvalidRef, ref.String())
+	}
+	return readSecretFile(filepath.Join(p.MountPath, rel))
+}
+
+func readSecretFile(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
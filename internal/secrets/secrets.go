@@ -0,0 +1,206 @@
+// Package secrets resolves secret references in configuration values from
+// external providers: HashiCorp Vault (KV v2), AWS Secrets Manager and
+// Kubernetes secrets mounted as files.
+//
+// A reference is a config value of the form "<scheme>:<name>[#<key>]":
+//
+//	vault:mirador/weaviate#api_key   KV v2 secret "mirador/weaviate", field "api_key"
+//	awssm:prod/mirador/valkey#pass   Secrets Manager secret, JSON field "pass"
+//	k8s:valkey-auth/password         file <mount_path>/valkey-auth/password
+//	file:/run/secrets/smtp           file contents
+//
+// Values without a known scheme are returned unchanged, so plain secrets and
+// env overrides keep working.
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Schemes understood by the resolver.
+const (
+	SchemeVault = "vault"
+	SchemeAWS   = "awssm"
+	SchemeK8s   = "k8s"
+	SchemeFile  = "file"
+)
+
+var (
+	ErrInvalidRef          = errors.New("invalid secret reference")
+	ErrProviderUnavailable = errors.New("secret provider not configured")
+	ErrSecretKeyNotFound   = errors.New("secret key not found")
+)
+
+// Ref is a parsed secret reference.
+type Ref struct {
+	Scheme string
+	Name   string
+	Key    string
+}
+
+func (r Ref) String() string {
+	if r.Key == "" {
+		return r.Scheme + ":" + r.Name
+	}
+	return r.Scheme + ":" + r.Name + "#" + r.Key
+}
+
+// ParseRef parses value as a secret reference. ok is false when value does
+// not start with a known scheme.
+func ParseRef(value string) (ref Ref, ok bool, err error) {
+	scheme, rest, found := strings.Cut(value, ":")
+	if !found {
+		return Ref{}, false, nil
+	}
+	switch scheme {
+	case SchemeVault, SchemeAWS, SchemeK8s, SchemeFile:
+	default:
+		return Ref{}, false, nil
+	}
+	name, key, _ := strings.Cut(rest, "#")
+	if name == "" {
+		return Ref{}, true, fmt.Errorf("%w: %q has no name", ErrInvalidRef, value)
+	}
+	return Ref{Scheme: scheme, Name: name, Key: key}, true, nil
+}
+
+// Provider fetches the current value of a reference from one backend.
+type Provider interface {
+	Fetch(ctx context.Context, ref Ref) (string, error)
+}
+
+type cachedSecret struct {
+	value     string
+	fetchedAt time.Time
+}
+
+// Resolver resolves references through registered providers and caches the
+// results for the configured TTL. It is safe for concurrent use.
+type Resolver struct {
+	providers map[string]Provider
+	ttl       time.Duration
+	now       func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cachedSecret
+}
+
+// NewResolver creates a resolver that caches values for ttl (0 caches until
+// the next Refresh). The file provider is always registered.
+func NewResolver(ttl time.Duration) *Resolver {
+	r := &Resolver{
+		providers: map[string]Provider{},
+		ttl:       ttl,
+		now:       time.Now,
+		cache:     map[string]cachedSecret{},
+	}
+	r.Register(SchemeFile, FileProvider{})
+	return r
+}
+
+// Register installs p for scheme, replacing any previous provider.
+func (r *Resolver) Register(scheme string, p Provider) {
+	r.providers[scheme] = p
+}
+
+// IsRef reports whether value is a secret reference.
+func (r *Resolver) IsRef(value string) bool {
+	_, ok, _ := ParseRef(value)
+	return ok
+}
+
+// Resolve returns the secret value for a reference, or value unchanged when
+// it is not a reference.
+func (r *Resolver) Resolve(ctx context.Context, value string) (string, error) {
+	ref, ok, err := ParseRef(value)
+	if err != nil || !ok {
+		return value, err
+	}
+
+	r.mu.Lock()
+	c, hit := r.cache[value]
+	r.mu.Unlock()
+	if hit && (r.ttl <= 0 || r.now().Sub(c.fetchedAt) < r.ttl) {
+		return c.value, nil
+	}
+
+	secret, err := r.fetch(ctx, ref)
+	if err != nil {
+		if hit {
+			// Keep serving the last known value while the provider is down.
+			return c.value, nil
+		}
+		return "", err
+	}
+	r.store(value, secret)
+	return secret, nil
+}
+
+// Refresh re-fetches every cached reference and returns the ones whose value
+// changed since they were last fetched. References that fail to refresh keep
+// their previous value and are reported in the returned error.
+func (r *Resolver) Refresh(ctx context.Context) ([]string, error) {
+	r.mu.Lock()
+	refs := make(map[string]string, len(r.cache))
+	for k, v := range r.cache {
+		refs[k] = v.value
+	}
+	r.mu.Unlock()
+
+	var changed []string
+	var errs []error
+	for value, old := range refs {
+		ref, _, _ := ParseRef(value)
+		secret, err := r.fetch(ctx, ref)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		r.store(value, secret)
+		if secret != old {
+			changed = append(changed, value)
+		}
+	}
+	return changed, errors.Join(errs...)
+}
+
+func (r *Resolver) fetch(ctx context.Context, ref Ref) (string, error) {
+	p, ok := r.providers[ref.Scheme]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrProviderUnavailable, ref.Scheme)
+	}
+	secret, err := p.Fetch(ctx, ref)
+	if err != nil {
+		return "", fmt.Errorf("resolve %s: %w", ref, err)
+	}
+	return secret, nil
+}
+
+func (r *Resolver) store(value, secret string) {
+	r.mu.Lock()
+	r.cache[value] = cachedSecret{value: secret, fetchedAt: r.now()}
+	r.mu.Unlock()
+}
+
+// pickKey selects key from a decoded secret payload. An empty key is allowed
+// when the payload holds exactly one field.
+func pickKey(data map[string]interface{}, key string) (string, error) {
+	if key == "" {
+		if len(data) != 1 {
+			return "", fmt.Errorf("%w: reference needs #key (secret has %d fields)", ErrSecretKeyNotFound, len(data))
+		}
+		for _, v := range data {
+			return fmt.Sprint(v), nil
+		}
+	}
+	v, ok := data[key]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrSecretKeyNotFound, key)
+	}
+	return fmt.Sprint(v), nil
+}
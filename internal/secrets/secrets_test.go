@@ -0,0 +1,125 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRef(t *testing.T) {
+	ref, ok, err := ParseRef("vault:mirador/weaviate#api_key")
+	if err != nil || !ok || ref.Scheme != SchemeVault || ref.Name != "mirador/weaviate" || ref.Key != "api_key" {
+		t.Fatalf("unexpected parse: %+v ok=%v err=%v", ref, ok, err)
+	}
+	if _, ok, _ := ParseRef("s3cr3t:with-colon"); ok {
+		t.Fatal("unknown scheme must not be treated as a reference")
+	}
+	if _, ok, err := ParseRef("vault:#key"); !ok || !errors.Is(err, ErrInvalidRef) {
+		t.Fatalf("expected ErrInvalidRef, got ok=%v err=%v", ok, err)
+	}
+}
+
+func TestResolver_CacheRefreshAndPlainValues(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "valkey-auth"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "valkey-auth", "password")
+	if err := os.WriteFile(path, []byte("one\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewResolver(time.Hour)
+	r.Register(SchemeK8s, KubernetesProvider{MountPath: dir})
+	ctx := context.Background()
+
+	if got, _ := r.Resolve(ctx, "plain-password"); got != "plain-password" {
+		t.Fatalf("plain values must pass through, got %q", got)
+	}
+	if got, err := r.Resolve(ctx, "k8s:valkey-auth/password"); err != nil || got != "one" {
+		t.Fatalf("expected one, got %q err=%v", got, err)
+	}
+	if _, err := r.Resolve(ctx, "k8s:../etc/passwd"); !errors.Is(err, ErrInvalidRef) {
+		t.Fatalf("expected traversal to be rejected, got %v", err)
+	}
+	if _, err := r.Resolve(ctx, "vault:x#y"); !errors.Is(err, ErrProviderUnavailable) {
+		t.Fatalf("expected ErrProviderUnavailable, got %v", err)
+	}
+
+	// Rotate the mounted file: cached value holds until Refresh.
+	if err := os.WriteFile(path, []byte("two"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := r.Resolve(ctx, "k8s:valkey-auth/password"); got != "one" {
+		t.Fatalf("expected cached value, got %q", got)
+	}
+	changed, err := r.Refresh(ctx)
+	if err != nil || len(changed) != 1 || changed[0] != "k8s:valkey-auth/password" {
+		t.Fatalf("expected one changed ref, got %v err=%v", changed, err)
+	}
+	if got, _ := r.Resolve(ctx, "k8s:valkey-auth/password"); got != "two" {
+		t.Fatalf("expected rotated value, got %q", got)
+	}
+}
+
+func TestVaultProvider(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/v1/kv/data/mirador/weaviate" || req.Header.Get("X-Vault-Token") != "tok" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"data": map[string]string{"api_key": "wv-key"}},
+		})
+	}))
+	defer srv.Close()
+
+	p := &VaultProvider{Address: srv.URL, Token: "tok", Mount: "kv"}
+	got, err := p.Fetch(context.Background(), Ref{Scheme: SchemeVault, Name: "mirador/weaviate", Key: "api_key"})
+	if err != nil || got != "wv-key" {
+		t.Fatalf("expected wv-key, got %q err=%v", got, err)
+	}
+	if _, err := p.Fetch(context.Background(), Ref{Scheme: SchemeVault, Name: "mirador/weaviate", Key: "missing"}); !errors.Is(err, ErrSecretKeyNotFound) {
+		t.Fatalf("expected ErrSecretKeyNotFound, got %v", err)
+	}
+}
+
+func TestAWSProvider(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_SESSION_TOKEN", "")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		auth := req.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20250102/eu-west-1/secretsmanager/aws4_request") ||
+			req.Header.Get("X-Amz-Target") != "secretsmanager.GetSecretValue" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		var in map[string]string
+		_ = json.NewDecoder(req.Body).Decode(&in)
+		_ = json.NewEncoder(w).Encode(map[string]string{"SecretString": `{"password":"` + in["SecretId"] + `-pw"}`})
+	}))
+	defer srv.Close()
+
+	p := &AWSProvider{
+		Region:   "eu-west-1",
+		Endpoint: srv.URL,
+		now:      func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) },
+	}
+	got, err := p.Fetch(context.Background(), Ref{Scheme: SchemeAWS, Name: "prod/valkey", Key: "password"})
+	if err != nil || got != "prod/valkey-pw" {
+		t.Fatalf("expected prod/valkey-pw, got %q err=%v", got, err)
+	}
+	raw, err := p.Fetch(context.Background(), Ref{Scheme: SchemeAWS, Name: "prod/valkey"})
+	if err != nil || raw != `{"password":"prod/valkey-pw"}` {
+		t.Fatalf("expected raw SecretString, got %q err=%v", raw, err)
+	}
+}
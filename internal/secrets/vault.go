@@ -0,0 +1,73 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// VaultProvider reads "vault:<path>#<key>" references from a KV v2 engine.
+type VaultProvider struct {
+	Address   string
+	Token     string
+	TokenFile string // re-read on every fetch so agent-renewed tokens apply
+	Namespace string
+	Mount     string // KV v2 mount, default "secret"
+	Client    *http.Client
+}
+
+func (p *VaultProvider) Fetch(ctx context.Context, ref Ref) (string, error) {
+	token := p.Token
+	if p.TokenFile != "" {
+		t, err := readSecretFile(p.TokenFile)
+		if err != nil {
+			return "", fmt.Errorf("read vault token: %w", err)
+		}
+		token = t
+	}
+	mount := p.Mount
+	if mount == "" {
+		mount = "secret"
+	}
+
+	url := strings.TrimRight(p.Address, "/") + "/v1/" + strings.Trim(mount, "/") + "/data/" + strings.TrimLeft(ref.Name, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if p.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.Namespace)
+	}
+
+	resp, err := httpClient(p.Client).Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("vault returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var out struct {
+		Data struct {
+			Data map[string]interface{} `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", fmt.Errorf("decode vault response: %w", err)
+	}
+	return pickKey(out.Data.Data, ref.Key)
+}
+
+func httpClient(c *http.Client) *http.Client {
+	if c != nil {
+		return c
+	}
+	return &http.Client{Timeout: 10 * time.Second}
+}
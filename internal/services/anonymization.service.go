@@ -0,0 +1,381 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const (
+	anonymizationReportKeyPrefix = "anonymization:report:"
+
+	// anonymizationReportTTL is how long verification reports are kept.
+	anonymizationReportTTL = 30 * 24 * time.Hour
+	// maxAnonymizationApplyRecords caps the records of one apply request;
+	// log exports are streamed and not capped.
+	maxAnonymizationApplyRecords = 10000
+
+	// generalizedPlaceholder replaces values generalize cannot interpret.
+	generalizedPlaceholder = "[generalized]"
+)
+
+var (
+	ErrInvalidAnonymizationProfile  = errors.New("invalid anonymization profile")
+	ErrAnonymizationProfileNotFound = errors.New("anonymization profile not found")
+	ErrAnonymizationReportNotFound  = errors.New("anonymization report not found")
+)
+
+var (
+	anonymizationStrategies = []string{models.AnonymizeHash, models.AnonymizeGeneralize, models.AnonymizeDrop}
+	anonymizationNameRe     = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
+)
+
+// AnonymizationService manages the tenant's anonymization profiles and
+// applies them to data leaving the tenant, e.g. exports shared with
+// vendors. Profiles are stored as the anonymization_profiles dynamic config
+// document, so changes are locked across replicas, versioned and can be
+// rolled back. Every application produces a verification report that is
+// kept for anonymizationReportTTL.
+type AnonymizationService struct {
+	cache   cache.ValkeyCluster
+	config  *DynamicConfigService
+	hashKey []byte
+	logger  logging.Logger
+}
+
+// NewAnonymizationService creates a new anonymization service.
+func NewAnonymizationService(cache cache.ValkeyCluster, cfg config.ExportConfig, logger corelogger.Logger) *AnonymizationService {
+	return &AnonymizationService{
+		cache:   cache,
+		config:  NewDynamicConfigService(cache, logger),
+		hashKey: []byte(cfg.AnonymizationHashKey),
+		logger:  logging.FromCoreLogger(logger),
+	}
+}
+
+// List returns the stored profiles ordered by name.
+func (s *AnonymizationService) List(ctx context.Context) ([]models.AnonymizationProfile, error) {
+	list := []models.AnonymizationProfile{}
+	if err := s.config.getDocument(ctx, DynamicConfigAnonymizationProfiles, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Get returns the profile called name.
+func (s *AnonymizationService) Get(ctx context.Context, name string) (*models.AnonymizationProfile, error) {
+	list, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range list {
+		if list[i].Name == name {
+			return &list[i], nil
+		}
+	}
+	return nil, ErrAnonymizationProfileNotFound
+}
+
+// Put creates or replaces a profile.
+func (s *AnonymizationService) Put(ctx context.Context, p models.AnonymizationProfile) (*models.AnonymizationProfile, error) {
+	if err := s.validate(p); err != nil {
+		return nil, err
+	}
+	p.UpdatedAt = time.Now().UTC()
+	var list []models.AnonymizationProfile
+	if _, err := s.config.updateDocument(ctx, DynamicConfigAnonymizationProfiles, p.UpdatedBy, &list, func() error {
+		list = append(slices.DeleteFunc(list, func(o models.AnonymizationProfile) bool { return o.Name == p.Name }), p)
+		sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	s.logger.Info("Anonymization profile stored", "name", p.Name, "rules", len(p.Rules))
+	return &p, nil
+}
+
+// Delete removes a profile. Reports produced with it are kept.
+func (s *AnonymizationService) Delete(ctx context.Context, name, deletedBy string) error {
+	var list []models.AnonymizationProfile
+	_, err := s.config.updateDocument(ctx, DynamicConfigAnonymizationProfiles, deletedBy, &list, func() error {
+		kept := slices.DeleteFunc(slices.Clone(list), func(o models.AnonymizationProfile) bool { return o.Name == name })
+		if len(kept) == len(list) {
+			return ErrAnonymizationProfileNotFound
+		}
+		list = kept
+		return nil
+	})
+	return err
+}
+
+func (s *AnonymizationService) validate(p models.AnonymizationProfile) error {
+	var problems []string
+	if !anonymizationNameRe.MatchString(p.Name) {
+		problems = append(problems, fmt.Sprintf("name %q must be lowercase letters, digits, '-' or '_'", p.Name))
+	}
+	if len(p.Rules) == 0 {
+		problems = append(problems, "at least one rule is required")
+	}
+	for i, r := range p.Rules {
+		if r.Field == "" {
+			problems = append(problems, fmt.Sprintf("rules[%d]: field is required", i))
+		} else if _, err := path.Match(r.Field, ""); err != nil {
+			problems = append(problems, fmt.Sprintf("rules[%d]: field %q is not a valid pattern", i, r.Field))
+		}
+		if !slices.Contains(anonymizationStrategies, r.Strategy) {
+			problems = append(problems, fmt.Sprintf("rules[%d]: strategy %q must be one of %s", i, r.Strategy, strings.Join(anonymizationStrategies, ", ")))
+		}
+		if r.Granularity != "" {
+			if r.Strategy != models.AnonymizeGeneralize {
+				problems = append(problems, fmt.Sprintf("rules[%d]: granularity only applies to %s", i, models.AnonymizeGeneralize))
+			} else if !validGranularity(r.Granularity) {
+				problems = append(problems, fmt.Sprintf("rules[%d]: granularity %q must be a positive number or duration", i, r.Granularity))
+			}
+		}
+		if r.Strategy == models.AnonymizeHash && len(s.hashKey) == 0 {
+			problems = append(problems, fmt.Sprintf("rules[%d]: %s requires export.anonymization_hash_key", i, models.AnonymizeHash))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidAnonymizationProfile, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func validGranularity(g string) bool {
+	if f, err := strconv.ParseFloat(g, 64); err == nil {
+		return f > 0
+	}
+	d, err := time.ParseDuration(g)
+	return err == nil && d > 0
+}
+
+// Anonymizer returns a fresh anonymizer for the profile called name.
+func (s *AnonymizationService) Anonymizer(ctx context.Context, name string) (*Anonymizer, error) {
+	p, err := s.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	// The hash key may have been removed since the profile was stored.
+	if err := s.validate(*p); err != nil {
+		return nil, err
+	}
+	a := &Anonymizer{
+		profile:     *p,
+		hashKey:     s.hashKey,
+		fields:      make([]models.AnonymizationFieldReport, len(p.Rules)),
+		ruleOf:      map[string]int{},
+		passthrough: map[string]bool{},
+	}
+	for i, r := range p.Rules {
+		a.fields[i] = models.AnonymizationFieldReport{Field: r.Field, Strategy: r.Strategy}
+	}
+	return a, nil
+}
+
+// Apply anonymizes req's records with the profile called name and stores
+// the verification report.
+func (s *AnonymizationService) Apply(ctx context.Context, name string, req models.AnonymizationApplyRequest) (*models.AnonymizationApplyResult, error) {
+	if len(req.Records) > maxAnonymizationApplyRecords {
+		return nil, fmt.Errorf("%w: at most %d records per request", ErrInvalidAnonymizationProfile, maxAnonymizationApplyRecords)
+	}
+	a, err := s.Anonymizer(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	for _, rec := range req.Records {
+		a.Apply(rec)
+	}
+	report, err := s.SaveReport(ctx, a.Report(cmp.Or(req.Source, "api")))
+	if err != nil {
+		return nil, err
+	}
+	return &models.AnonymizationApplyResult{Records: req.Records, Report: report}, nil
+}
+
+// SaveReport assigns report an ID and stores it.
+func (s *AnonymizationService) SaveReport(ctx context.Context, report *models.AnonymizationReport) (*models.AnonymizationReport, error) {
+	report.ID = uuid.NewString()
+	if err := s.cache.Set(ctx, anonymizationReportKeyPrefix+report.ID, report, anonymizationReportTTL); err != nil {
+		return nil, fmt.Errorf("failed to store anonymization report: %w", err)
+	}
+	s.logger.Info("Data anonymized", "profile", report.Profile, "source", report.Source,
+		"records", report.Records, "report", report.ID)
+	return report, nil
+}
+
+// GetReport returns a stored verification report.
+func (s *AnonymizationService) GetReport(ctx context.Context, id string) (*models.AnonymizationReport, error) {
+	data, err := s.cache.Get(ctx, anonymizationReportKeyPrefix+id)
+	if err != nil || len(data) == 0 {
+		return nil, ErrAnonymizationReportNotFound
+	}
+	var report models.AnonymizationReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal anonymization report: %w", err)
+	}
+	return &report, nil
+}
+
+// Anonymizer applies one profile to a stream of records and counts what it
+// did. It is not safe for concurrent use.
+type Anonymizer struct {
+	profile     models.AnonymizationProfile
+	hashKey     []byte
+	records     int
+	fields      []models.AnonymizationFieldReport
+	ruleOf      map[string]int // field name -> rule index, -1 for none
+	passthrough map[string]bool
+}
+
+// Apply anonymizes rec in place.
+func (a *Anonymizer) Apply(rec map[string]interface{}) {
+	a.records++
+	for field, v := range rec {
+		i := a.rule(field)
+		if i < 0 {
+			a.passthrough[field] = true
+			continue
+		}
+		r := a.profile.Rules[i]
+		if v == nil && r.Strategy != models.AnonymizeDrop {
+			continue
+		}
+		switch r.Strategy {
+		case models.AnonymizeDrop:
+			delete(rec, field)
+		case models.AnonymizeHash:
+			rec[field] = a.hash(v)
+		case models.AnonymizeGeneralize:
+			g, ok := generalizeValue(v, r.Granularity)
+			rec[field] = g
+			if !ok {
+				a.fields[i].Fallback++
+			}
+		}
+		a.fields[i].Transformed++
+	}
+}
+
+// rule returns the index of the first rule matching field, or -1.
+func (a *Anonymizer) rule(field string) int {
+	if i, ok := a.ruleOf[field]; ok {
+		return i
+	}
+	i := slices.IndexFunc(a.profile.Rules, func(r models.AnonymizationRule) bool {
+		if r.Field == field {
+			return true
+		}
+		ok, _ := path.Match(r.Field, field)
+		return ok
+	})
+	a.ruleOf[field] = i
+	return i
+}
+
+// hash is a keyed HMAC-SHA256, so equal values map to equal hashes across
+// exports while the originals cannot be recovered by hashing guesses.
+func (a *Anonymizer) hash(v interface{}) string {
+	mac := hmac.New(sha256.New, a.hashKey)
+	mac.Write([]byte(toScalarString(v)))
+	return "h:" + hex.EncodeToString(mac.Sum(nil)[:16])
+}
+
+// Report summarizes the records anonymized so far.
+func (a *Anonymizer) Report(source string) *models.AnonymizationReport {
+	report := &models.AnonymizationReport{
+		Profile:     a.profile.Name,
+		Source:      source,
+		Records:     a.records,
+		Fields:      slices.Clone(a.fields),
+		Unmatched:   []string{},
+		Passthrough: []string{},
+		CreatedAt:   time.Now().UTC(),
+	}
+	for _, f := range a.fields {
+		if f.Transformed == 0 {
+			report.Unmatched = append(report.Unmatched, f.Field)
+		}
+	}
+	for field := range a.passthrough {
+		report.Passthrough = append(report.Passthrough, field)
+	}
+	sort.Strings(report.Passthrough)
+	return report
+}
+
+// generalizeValue coarsens v and reports whether it could interpret it.
+// Strings are tried as IP address, number, RFC 3339 time and email
+// address, in that order.
+func generalizeValue(v interface{}, granularity string) (interface{}, bool) {
+	switch x := v.(type) {
+	case float64:
+		return bucketNumber(x, granularity), true
+	case json.Number:
+		if f, err := x.Float64(); err == nil {
+			return json.Number(strconv.FormatFloat(bucketNumber(f, granularity), 'f', -1, 64)), true
+		}
+	case string:
+		if ip := net.ParseIP(x); ip != nil {
+			return generalizeIP(ip, granularity), true
+		}
+		if f, err := strconv.ParseFloat(x, 64); err == nil && !math.IsNaN(f) && !math.IsInf(f, 0) {
+			return strconv.FormatFloat(bucketNumber(f, granularity), 'f', -1, 64), true
+		}
+		if t, err := time.Parse(time.RFC3339Nano, x); err == nil {
+			step, err := time.ParseDuration(granularity)
+			if err != nil || step <= 0 {
+				step = time.Hour
+			}
+			return t.UTC().Truncate(step).Format(time.RFC3339), true
+		}
+		if at := strings.LastIndexByte(x, '@'); at > 0 && strings.Contains(x[at+1:], ".") && !strings.ContainsAny(x, " \t") {
+			return "*@" + strings.ToLower(x[at+1:]), true
+		}
+	}
+	return generalizedPlaceholder, false
+}
+
+// generalizeIP returns ip's network, /24 for IPv4 and /48 for IPv6 unless
+// granularity sets the prefix length.
+func generalizeIP(ip net.IP, granularity string) string {
+	bits, prefix := 128, 48
+	if v4 := ip.To4(); v4 != nil {
+		ip, bits, prefix = v4, 32, 24
+	}
+	if n, err := strconv.Atoi(granularity); err == nil && n >= 0 && n <= bits {
+		prefix = n
+	}
+	return (&net.IPNet{IP: ip.Mask(net.CIDRMask(prefix, bits)), Mask: net.CIDRMask(prefix, bits)}).String()
+}
+
+// bucketNumber rounds x down to a multiple of granularity (default 10).
+func bucketNumber(x float64, granularity string) float64 {
+	step, err := strconv.ParseFloat(granularity, 64)
+	if err != nil || step <= 0 {
+		step = 10
+	}
+	return math.Floor(x/step) * step
+}
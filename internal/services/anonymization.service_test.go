@@ -0,0 +1,174 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func vendorProfile() models.AnonymizationProfile {
+	return models.AnonymizationProfile{
+		Name: "vendor",
+		Rules: []models.AnonymizationRule{
+			{Field: "user.*", Strategy: models.AnonymizeHash},
+			{Field: "client_ip", Strategy: models.AnonymizeGeneralize},
+			{Field: "latency_ms", Strategy: models.AnonymizeGeneralize, Granularity: "100"},
+			{Field: "_time", Strategy: models.AnonymizeGeneralize, Granularity: "1h"},
+			{Field: "email", Strategy: models.AnonymizeGeneralize},
+			{Field: "token", Strategy: models.AnonymizeDrop},
+			{Field: "session", Strategy: models.AnonymizeDrop},
+		},
+	}
+}
+
+func TestAnonymizationService_Profiles(t *testing.T) {
+	log := logger.New("error")
+	ctx := context.Background()
+	svc := NewAnonymizationService(cache.NewNoopValkeyCache(log), config.ExportConfig{AnonymizationHashKey: "k1"}, log)
+
+	_, err := svc.Put(ctx, models.AnonymizationProfile{
+		Name:  "Bad Name",
+		Rules: []models.AnonymizationRule{{Field: "[", Strategy: "mask"}, {Field: "ip", Strategy: models.AnonymizeDrop, Granularity: "24"}},
+	})
+	require.ErrorIs(t, err, ErrInvalidAnonymizationProfile)
+	assert.Contains(t, err.Error(), "name")
+	assert.Contains(t, err.Error(), "not a valid pattern")
+	assert.Contains(t, err.Error(), `strategy "mask"`)
+	assert.Contains(t, err.Error(), "granularity only applies")
+
+	p, err := svc.Put(ctx, vendorProfile())
+	require.NoError(t, err)
+	assert.False(t, p.UpdatedAt.IsZero())
+	list, err := svc.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+
+	require.NoError(t, svc.Delete(ctx, "vendor", ""))
+	_, err = svc.Get(ctx, "vendor")
+	assert.ErrorIs(t, err, ErrAnonymizationProfileNotFound)
+	assert.ErrorIs(t, svc.Delete(ctx, "vendor", ""), ErrAnonymizationProfileNotFound)
+	versions, err := svc.config.ListVersions(ctx, DynamicConfigAnonymizationProfiles)
+	require.NoError(t, err)
+	assert.Len(t, versions, 2, "the put and the delete are versioned")
+
+	// Hash rules need a key.
+	unkeyed := NewAnonymizationService(cache.NewNoopValkeyCache(log), config.ExportConfig{}, log)
+	_, err = unkeyed.Put(ctx, vendorProfile())
+	require.ErrorIs(t, err, ErrInvalidAnonymizationProfile)
+	assert.Contains(t, err.Error(), "anonymization_hash_key")
+}
+
+func TestAnonymizationService_Apply(t *testing.T) {
+	log := logger.New("error")
+	ctx := context.Background()
+	svc := NewAnonymizationService(cache.NewNoopValkeyCache(log), config.ExportConfig{AnonymizationHashKey: "k1"}, log)
+	_, err := svc.Put(ctx, vendorProfile())
+	require.NoError(t, err)
+
+	res, err := svc.Apply(ctx, "vendor", models.AnonymizationApplyRequest{
+		Source: "rca_report",
+		Records: []map[string]interface{}{
+			{"user.id": "alice", "client_ip": "10.1.2.3", "latency_ms": "347", "_time": "2026-10-17T10:42:13Z", "token": "t", "service": "checkout"},
+			{"user.id": "alice", "client_ip": "2001:db8:1:2::7", "email": "Bob@Example.com", "session": nil},
+			{"user.id": "bob", "client_ip": "not-an-ip"},
+		},
+	})
+	require.NoError(t, err)
+	recs := res.Records
+
+	assert.Equal(t, recs[0]["user.id"], recs[1]["user.id"], "hashes are deterministic")
+	assert.NotEqual(t, recs[0]["user.id"], recs[2]["user.id"])
+	assert.Regexp(t, `^h:[0-9a-f]{32}$`, recs[0]["user.id"])
+	assert.Equal(t, "10.1.2.0/24", recs[0]["client_ip"])
+	assert.Equal(t, "2001:db8:1::/48", recs[1]["client_ip"])
+	assert.Equal(t, generalizedPlaceholder, recs[2]["client_ip"])
+	assert.Equal(t, "300", recs[0]["latency_ms"])
+	assert.Equal(t, "2026-10-17T10:00:00Z", recs[0]["_time"])
+	assert.Equal(t, "*@example.com", recs[1]["email"])
+	assert.NotContains(t, recs[0], "token")
+	assert.NotContains(t, recs[1], "session")
+	assert.Equal(t, "checkout", recs[0]["service"])
+
+	report := res.Report
+	assert.NotEmpty(t, report.ID)
+	assert.Equal(t, "rca_report", report.Source)
+	assert.Equal(t, 3, report.Records)
+	assert.Equal(t, models.AnonymizationFieldReport{Field: "client_ip", Strategy: models.AnonymizeGeneralize, Transformed: 3, Fallback: 1}, report.Fields[1])
+	assert.Equal(t, 3, report.Fields[0].Transformed)
+	assert.Empty(t, report.Unmatched)
+	assert.Equal(t, []string{"service"}, report.Passthrough)
+
+	stored, err := svc.GetReport(ctx, report.ID)
+	require.NoError(t, err)
+	assert.Equal(t, report.Fields, stored.Fields)
+
+	// A different key breaks joinability with earlier exports.
+	rekeyed := NewAnonymizationService(cache.NewNoopValkeyCache(log), config.ExportConfig{AnonymizationHashKey: "k2"}, log)
+	_, err = rekeyed.Put(ctx, vendorProfile())
+	require.NoError(t, err)
+	res2, err := rekeyed.Apply(ctx, "vendor", models.AnonymizationApplyRequest{Records: []map[string]interface{}{{"user.id": "alice"}}})
+	require.NoError(t, err)
+	assert.NotEqual(t, recs[0]["user.id"], res2.Records[0]["user.id"])
+	assert.Equal(t, "api", res2.Report.Source)
+	assert.ElementsMatch(t, []string{"client_ip", "latency_ms", "_time", "email", "token", "session"}, res2.Report.Unmatched)
+}
+
+func TestVictoriaLogsService_ExportLogsAnonymized(t *testing.T) {
+	var gotFormat string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotFormat = r.URL.Query().Get("format")
+		w.Header().Set("Content-Type", "application/stream+json")
+		_, _ = w.Write([]byte(`{"_msg":"login <ok>","user.id":"alice","client_ip":"10.9.8.7"}` + "\n" +
+			`{"_msg":"logout","user.id":"alice","token":"secret"}` + "\n"))
+	}))
+	defer srv.Close()
+
+	log := logger.New("error")
+	ctx := context.Background()
+	anon := NewAnonymizationService(cache.NewNoopValkeyCache(log), config.ExportConfig{AnonymizationHashKey: "k1"}, log)
+	_, err := anon.Put(ctx, vendorProfile())
+	require.NoError(t, err)
+	logs := NewVictoriaLogsService(config.VictoriaLogsConfig{Endpoints: []string{srv.URL}}, log)
+	logs.SetAnonymization(anon)
+
+	res, err := logs.ExportLogs(ctx, &models.LogsExportRequest{Query: "*", Format: "json", Profile: "vendor"})
+	require.NoError(t, err)
+	assert.Equal(t, "json", gotFormat)
+	require.NotNil(t, res.Anonymization)
+	assert.Equal(t, "logs_export", res.Anonymization.Source)
+	assert.Equal(t, 2, res.Anonymization.Records)
+
+	var rows []map[string]interface{}
+	sc := bufio.NewScanner(bytes.NewReader(res.Data))
+	for sc.Scan() {
+		var row map[string]interface{}
+		require.NoError(t, json.Unmarshal(sc.Bytes(), &row))
+		rows = append(rows, row)
+	}
+	require.Len(t, rows, 2)
+	assert.Equal(t, "login <ok>", rows[0]["_msg"])
+	assert.Equal(t, "10.9.8.0/24", rows[0]["client_ip"])
+	assert.Equal(t, rows[0]["user.id"], rows[1]["user.id"])
+	assert.NotContains(t, rows[1], "token")
+	assert.NotContains(t, string(res.Data), "alice")
+
+	csvRes, err := logs.ExportLogs(ctx, &models.LogsExportRequest{Query: "*", Format: "csv", Profile: "vendor"})
+	require.NoError(t, err)
+	assert.Equal(t, "json", gotFormat, "anonymized exports are fetched as NDJSON")
+	assert.Contains(t, string(csvRes.Data), "10.9.8.0/24")
+
+	_, err = logs.ExportLogs(ctx, &models.LogsExportRequest{Query: "*", Profile: "missing"})
+	assert.ErrorIs(t, err, ErrAnonymizationProfileNotFound)
+}
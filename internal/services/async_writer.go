@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+)
+
+// asyncWriter runs store writes off the request path, one at a time on a
+// background goroutine. Its queue is bounded: a write that does not fit is
+// dropped and logged rather than slowing the request down.
+type asyncWriter struct {
+	queue   chan func(context.Context)
+	timeout time.Duration
+	logger  logging.Logger
+	once    sync.Once
+	pending sync.WaitGroup
+}
+
+// newAsyncWriter creates a writer queueing up to size writes, each given
+// timeout to finish.
+func newAsyncWriter(size int, timeout time.Duration, logger logging.Logger) *asyncWriter {
+	return &asyncWriter{
+		queue:   make(chan func(context.Context), size),
+		timeout: timeout,
+		logger:  logger,
+	}
+}
+
+// enqueue schedules write and reports whether it was queued. A nil writer
+// runs write inline.
+func (w *asyncWriter) enqueue(what string, write func(context.Context)) bool {
+	if w == nil {
+		write(context.Background())
+		return true
+	}
+	w.once.Do(func() { go w.run() })
+	w.pending.Add(1)
+	select {
+	case w.queue <- write:
+		return true
+	default:
+		w.pending.Done()
+		if w.logger != nil {
+			w.logger.Warn("Write queue full, dropping write", "write", what)
+		}
+		return false
+	}
+}
+
+// wait blocks until every queued write has finished.
+func (w *asyncWriter) wait() {
+	if w != nil {
+		w.pending.Wait()
+	}
+}
+
+func (w *asyncWriter) run() {
+	for write := range w.queue {
+		ctx, cancel := context.WithTimeout(context.Background(), w.timeout)
+		write(ctx)
+		cancel()
+		w.pending.Done()
+	}
+}
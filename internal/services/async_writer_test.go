@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func TestAsyncWriter(t *testing.T) {
+	w := newAsyncWriter(1, time.Second, logging.FromCoreLogger(logger.New("error")))
+
+	release := make(chan struct{})
+	var done atomic.Int32
+	write := func(ctx context.Context) {
+		<-release
+		done.Add(1)
+	}
+	if !w.enqueue("first", write) {
+		t.Fatal("expected the first write to be queued")
+	}
+	// The first write is running or queued; once it runs, one more fits.
+	deadline := time.Now().Add(time.Second)
+	for !w.enqueue("second", write) {
+		if time.Now().After(deadline) {
+			t.Fatal("expected room for a second write")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if w.enqueue("third", write) {
+		t.Fatal("expected a full queue to drop the write")
+	}
+	close(release)
+	w.wait()
+	if done.Load() != 2 {
+		t.Fatalf("expected two writes, got %d", done.Load())
+	}
+
+	var inline *asyncWriter
+	ran := false
+	inline.enqueue("inline", func(context.Context) { ran = true })
+	if !ran {
+		t.Fatal("a nil writer must run the write inline")
+	}
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/metrics"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/resilience"
+)
+
+// maxBackendBackoff caps the wait between tries of a VictoriaMetrics or
+// VictoriaLogs request.
+const maxBackendBackoff = 10 * time.Second
+
+// backendStatusError is a 5xx response of a Victoria backend; the request
+// is retried.
+type backendStatusError struct {
+	code int
+	body string
+}
+
+func (e *backendStatusError) Error() string {
+	return fmt.Sprintf("status %d: %s", e.code, e.body)
+}
+
+// backendRetry returns the retry policy of a request to backend (a
+// tracing.Backend* name): up to attempts tries, waiting a jittered
+// exponential backoff from backoffMS. Retries are counted in
+// BackendRetriesTotal and logged.
+func backendRetry(backend string, attempts, backoffMS int, logger logging.Logger, method, url string) resilience.Retry {
+	return resilience.Retry{
+		MaxAttempts: attempts,
+		Backoff: resilience.Backoff{
+			Initial: time.Duration(backoffMS) * time.Millisecond,
+			Max:     maxBackendBackoff,
+			Jitter:  true,
+		},
+		OnRetry: func(attempt int, err error, wait time.Duration) {
+			reason := "transport"
+			var se *backendStatusError
+			if errors.As(err, &se) {
+				reason = strconv.Itoa(se.code)
+			}
+			metrics.BackendRetriesTotal.WithLabelValues(backend, reason).Inc()
+			logger.Warn("Backend request failed, retrying",
+				"backend", backend, "attempt", attempt, "method", method, "url", url,
+				"reason", reason, "backoff", wait, "error", err)
+		},
+	}
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func TestVictoriaMetricsService_DoRequestWithRetry(t *testing.T) {
+	var calls atomic.Int32
+	var status atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer srv.Close()
+
+	s := NewVictoriaMetricsService(config.VictoriaMetricsConfig{Endpoints: []string{srv.URL}, Timeout: 1000}, logger.New("error"))
+	s.backoffMS = 1
+	ctx := context.Background()
+
+	// A 5xx is retried.
+	status.Store(http.StatusOK)
+	resp, err := s.doRequestWithRetry(ctx, http.MethodGet, srv.URL, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || calls.Load() != 2 {
+		t.Fatalf("got %d after %d calls, want 200 after one retry", resp.StatusCode, calls.Load())
+	}
+
+	// A 4xx is returned as-is.
+	calls.Store(1)
+	status.Store(http.StatusBadRequest)
+	resp, err = s.doRequestWithRetry(ctx, http.MethodGet, srv.URL, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest || calls.Load() != 2 {
+		t.Fatalf("got %d after %d calls, want one 400", resp.StatusCode, calls.Load())
+	}
+
+	// Attempts run out on a persistent 5xx.
+	calls.Store(1)
+	status.Store(http.StatusBadGateway)
+	_, err = s.doRequestWithRetry(ctx, http.MethodGet, srv.URL, nil, nil)
+	var se *backendStatusError
+	if !errors.As(err, &se) || se.code != http.StatusBadGateway || calls.Load() != 1+int32(s.retries) {
+		t.Fatalf("got %v after %d calls, want a 502 error after %d tries", err, calls.Load()-1, s.retries)
+	}
+}
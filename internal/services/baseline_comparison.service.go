@@ -0,0 +1,272 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/repo"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const (
+	// baselinePoints is the target number of samples per series when no
+	// step is requested; maxBaselinePoints bounds an explicit step.
+	baselinePoints    = 240
+	maxBaselinePoints = 11000
+	maxBaselineOffset = 366 * 24 * time.Hour
+	// MaxBaselineOffsets bounds the baselines evaluated per request.
+	MaxBaselineOffsets = 4
+)
+
+// DefaultBaselineOffsets compares against the same range one week earlier.
+var DefaultBaselineOffsets = []string{"1w"}
+
+var ErrInvalidBaselineRequest = errors.New("invalid baseline comparison request")
+
+// BaselineComparisonService evaluates a KPI or query over a range and over
+// the same range shifted back by one or more offsets, aligned so dashboards
+// can overlay them without issuing and aligning the queries themselves.
+type BaselineComparisonService struct {
+	metrics CapacityMetricsQuerier
+	kpis    repo.KPIRepo
+	logger  logging.Logger
+}
+
+// NewBaselineComparisonService creates a new baseline comparison service.
+func NewBaselineComparisonService(metrics CapacityMetricsQuerier, kpis repo.KPIRepo, logger corelogger.Logger) *BaselineComparisonService {
+	return &BaselineComparisonService{
+		metrics: metrics,
+		kpis:    kpis,
+		logger:  logging.FromCoreLogger(logger),
+	}
+}
+
+// Compare runs the query over the requested range and each offset baseline.
+func (s *BaselineComparisonService) Compare(ctx context.Context, req models.BaselineComparisonRequest) (*models.BaselineComparison, error) {
+	if s.metrics == nil {
+		return nil, fmt.Errorf("metrics backend not configured")
+	}
+	if (req.KPIID == "") == (strings.TrimSpace(req.Query) == "") {
+		return nil, fmt.Errorf("%w: exactly one of kpi and query is required", ErrInvalidBaselineRequest)
+	}
+	if !req.End.After(req.Start) {
+		return nil, fmt.Errorf("%w: end must be after start", ErrInvalidBaselineRequest)
+	}
+	rng := req.End.Sub(req.Start)
+	if req.Step <= 0 {
+		req.Step = rng / baselinePoints
+		if req.Step < 15*time.Second {
+			req.Step = 15 * time.Second
+		}
+		req.Step = req.Step.Truncate(time.Second)
+	}
+	if req.Step < time.Second {
+		return nil, fmt.Errorf("%w: step must be at least 1s", ErrInvalidBaselineRequest)
+	}
+	if rng/req.Step > maxBaselinePoints {
+		return nil, fmt.Errorf("%w: step %s gives more than %d points over the range", ErrInvalidBaselineRequest, req.Step, maxBaselinePoints)
+	}
+	if len(req.Offsets) == 0 {
+		req.Offsets = DefaultBaselineOffsets
+	}
+	if len(req.Offsets) > MaxBaselineOffsets {
+		return nil, fmt.Errorf("%w: at most %d offsets are supported", ErrInvalidBaselineRequest, MaxBaselineOffsets)
+	}
+	offsets := make([]time.Duration, len(req.Offsets))
+	for i, o := range req.Offsets {
+		d, err := parseBaselineOffset(o)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidBaselineRequest, err)
+		}
+		offsets[i] = d
+	}
+
+	query := strings.TrimSpace(req.Query)
+	if req.KPIID != "" {
+		if s.kpis == nil {
+			return nil, fmt.Errorf("KPI registry not configured")
+		}
+		kpi, err := s.kpis.GetKPI(ctx, req.KPIID)
+		if err != nil {
+			return nil, err
+		}
+		if kpi == nil {
+			return nil, ErrKPINotFound
+		}
+		if query = strings.TrimSpace(kpi.Formula); query == "" {
+			return nil, ErrKPINoFormula
+		}
+	}
+
+	current, err := s.rangeSeries(ctx, query, req.Start, req.End, req.Step)
+	if err != nil {
+		return nil, err
+	}
+	out := &models.BaselineComparison{
+		KPIID:     req.KPIID,
+		Query:     query,
+		Start:     req.Start,
+		End:       req.End,
+		Step:      req.Step.String(),
+		Current:   make([]models.BaselineSeries, 0, len(current)),
+		Baselines: make([]models.BaselineWindow, 0, len(offsets)),
+	}
+	for _, ser := range current {
+		out.Current = append(out.Current, models.BaselineSeries{Labels: ser.labels, Points: seriesPoints(ser, 0)})
+	}
+
+	for i, offset := range offsets {
+		start, end := req.Start.Add(-offset), req.End.Add(-offset)
+		baseline, err := s.rangeSeries(ctx, query, start, end, req.Step)
+		if err != nil {
+			return nil, err
+		}
+		out.Baselines = append(out.Baselines, alignBaseline(req.Offsets[i], offset, start, end, req.Start, req.Step, current, baseline))
+	}
+	return out, nil
+}
+
+func (s *BaselineComparisonService) rangeSeries(ctx context.Context, query string, start, end time.Time, step time.Duration) ([]promSeries, error) {
+	res, err := s.metrics.ExecuteRangeQuery(ctx, &models.MetricsQLRangeQueryRequest{
+		Query: query,
+		Start: start.Format(time.RFC3339),
+		End:   end.Format(time.RFC3339),
+		Step:  strconv.Itoa(int(step.Seconds())),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res == nil || res.Data == nil {
+		return nil, nil
+	}
+	series, err := decodeRangeMatrix(res.Data)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected range query result: %w", err)
+	}
+	sort.Slice(series, func(i, j int) bool { return seriesKey(series[i].labels) < seriesKey(series[j].labels) })
+	return series, nil
+}
+
+// alignBaseline shifts the baseline series forward by offset onto the
+// current range's step grid and computes per-point and aggregate deltas for
+// label sets present in both.
+func alignBaseline(label string, offset time.Duration, start, end, origin time.Time, step time.Duration, current, baseline []promSeries) models.BaselineWindow {
+	win := models.BaselineWindow{
+		Offset: label,
+		Start:  start,
+		End:    end,
+		Series: make([]models.BaselineSeries, 0, len(baseline)),
+	}
+	slot := func(t time.Time) int64 { return int64(math.Round(float64(t.Sub(origin)) / float64(step))) }
+	currentByKey := make(map[string]map[int64]float64, len(current))
+	for _, ser := range current {
+		values := make(map[int64]float64, len(ser.times))
+		for i, t := range ser.times {
+			values[slot(t)] = ser.values[i]
+		}
+		currentByKey[seriesKey(ser.labels)] = values
+	}
+
+	var cur, base []float64
+	for _, ser := range baseline {
+		points := seriesPoints(ser, offset)
+		if values, ok := currentByKey[seriesKey(ser.labels)]; ok {
+			for i := range points {
+				c, ok := values[slot(points[i].Timestamp)]
+				if !ok {
+					continue
+				}
+				d := c - points[i].Value
+				points[i].Delta = &d
+				cur, base = append(cur, c), append(base, points[i].Value)
+			}
+		}
+		win.Series = append(win.Series, models.BaselineSeries{Labels: ser.labels, Points: points})
+	}
+	win.Stats = baselineDeltaStats(cur, base)
+	return win
+}
+
+func baselineDeltaStats(cur, base []float64) models.BaselineDeltaStats {
+	stats := models.BaselineDeltaStats{AlignedPoints: len(cur)}
+	if len(cur) == 0 {
+		return stats
+	}
+	var sumCur, sumBase float64
+	for i := range cur {
+		sumCur += cur[i]
+		sumBase += base[i]
+		if d := math.Abs(cur[i] - base[i]); d > stats.MaxAbsDelta {
+			stats.MaxAbsDelta = d
+		}
+	}
+	n := float64(len(cur))
+	stats.CurrentMean, stats.BaselineMean = sumCur/n, sumBase/n
+	stats.MeanDelta = stats.CurrentMean - stats.BaselineMean
+	if stats.BaselineMean != 0 {
+		pct := stats.MeanDelta / math.Abs(stats.BaselineMean) * 100
+		stats.MeanDeltaPct = &pct
+	}
+	if len(cur) >= 2 {
+		if r := ComputePearson(cur, base); !math.IsNaN(r) {
+			stats.Correlation = r
+		}
+	}
+	return stats
+}
+
+// seriesPoints returns the samples of ser shifted forward by offset.
+func seriesPoints(ser promSeries, offset time.Duration) []models.BaselinePoint {
+	points := make([]models.BaselinePoint, len(ser.times))
+	for i, t := range ser.times {
+		points[i] = models.BaselinePoint{Timestamp: t.Add(offset).UTC(), Value: ser.values[i]}
+	}
+	return points
+}
+
+// seriesKey returns a stable key for a full label set.
+func seriesKey(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	key, _ := labelSetKey(labels, names)
+	return key
+}
+
+// parseBaselineOffset parses a positive offset of at most 366 days.
+func parseBaselineOffset(s string) (time.Duration, error) {
+	d, ok := parseDayDuration(s)
+	if !ok {
+		return 0, fmt.Errorf("offset %q must be a duration such as 1d, 1w or 4w", s)
+	}
+	if d <= 0 || d > maxBaselineOffset {
+		return 0, fmt.Errorf("offset %q must be positive and at most 366d", s)
+	}
+	return d, nil
+}
+
+// parseDayDuration parses a Go duration (36h) or a whole number of days or
+// weeks (1d, 1w, 4w).
+func parseDayDuration(s string) (time.Duration, bool) {
+	s = strings.TrimSpace(s)
+	days := map[string]int{"d": 1, "w": 7}
+	if len(s) > 1 && days[s[len(s)-1:]] > 0 {
+		n, err := strconv.Atoi(s[:len(s)-1])
+		if err != nil {
+			return 0, false
+		}
+		return time.Duration(n*days[s[len(s)-1:]]) * 24 * time.Hour, true
+	}
+	d, err := time.ParseDuration(s)
+	return d, err == nil
+}
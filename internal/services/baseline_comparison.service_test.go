@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// shiftedQuerier returns one series sampled every step from start to end
+// whose value is level(start) plus the sample index.
+type shiftedQuerier struct {
+	level func(start time.Time) float64
+}
+
+func (q shiftedQuerier) ExecuteRangeQuery(ctx context.Context, req *models.MetricsQLRangeQueryRequest) (*models.MetricsQLRangeQueryResult, error) {
+	start, _ := time.Parse(time.RFC3339, req.Start)
+	end, _ := time.Parse(time.RFC3339, req.End)
+	secs, _ := strconv.Atoi(req.Step)
+	step := time.Duration(secs) * time.Second
+	points := []interface{}{}
+	for i, ts := 0, start; !ts.After(end); i, ts = i+1, ts.Add(step) {
+		points = append(points, []interface{}{float64(ts.Unix()), fmt.Sprint(q.level(start) + float64(i))})
+	}
+	return &models.MetricsQLRangeQueryResult{Data: map[string]interface{}{"resultType": "matrix", "result": []interface{}{
+		map[string]interface{}{"metric": map[string]interface{}{"job": "api"}, "values": points},
+	}}}, nil
+}
+
+func TestBaselineComparisonService_Compare(t *testing.T) {
+	end := time.Date(2024, 5, 8, 12, 0, 0, 0, time.UTC)
+	start := end.Add(-time.Hour)
+	// The current range sits 10 above last week and 40 above four weeks ago.
+	querier := shiftedQuerier{level: func(s time.Time) float64 {
+		switch {
+		case s.Equal(start):
+			return 100
+		case s.Equal(start.Add(-7 * 24 * time.Hour)):
+			return 90
+		default:
+			return 60
+		}
+	}}
+	kpis := newFakeKPIRepo()
+	kpis.kpis["rps"] = &models.KPIDefinition{ID: "rps", Formula: "sum(rate(http_requests_total[5m]))"}
+	svc := NewBaselineComparisonService(querier, kpis, logger.New("error"))
+
+	out, err := svc.Compare(context.Background(), models.BaselineComparisonRequest{
+		KPIID: "rps", Start: start, End: end, Step: 5 * time.Minute, Offsets: []string{"1w", "4w"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Query != "sum(rate(http_requests_total[5m]))" || len(out.Current) != 1 || len(out.Current[0].Points) != 13 {
+		t.Fatalf("unexpected current series: %+v", out)
+	}
+	if len(out.Baselines) != 2 {
+		t.Fatalf("expected two baselines, got %d", len(out.Baselines))
+	}
+	week := out.Baselines[0]
+	first := week.Series[0].Points[0]
+	if week.Offset != "1w" || !first.Timestamp.Equal(start) || first.Delta == nil || *first.Delta != 10 {
+		t.Fatalf("expected last week's first point aligned to start with delta 10, got %+v", first)
+	}
+	if week.Stats.AlignedPoints != 13 || week.Stats.MeanDelta != 10 || week.Stats.MaxAbsDelta != 10 || week.Stats.Correlation < 0.999 {
+		t.Fatalf("unexpected week stats: %+v", week.Stats)
+	}
+	if month := out.Baselines[1].Stats; month.MeanDelta != 40 || month.MeanDeltaPct == nil {
+		t.Fatalf("unexpected four-week stats: %+v", month)
+	}
+
+	for _, req := range []models.BaselineComparisonRequest{
+		{Query: "up", KPIID: "rps", Start: start, End: end},
+		{Query: "up", Start: end, End: start},
+		{Query: "up", Start: start, End: end, Offsets: []string{"-1w"}},
+		{Query: "up", Start: start, End: end, Offsets: []string{"1d", "2d", "3d", "4d", "5d"}},
+	} {
+		if _, err := svc.Compare(context.Background(), req); !errors.Is(err, ErrInvalidBaselineRequest) {
+			t.Fatalf("expected ErrInvalidBaselineRequest for %+v, got %v", req, err)
+		}
+	}
+	if _, err := svc.Compare(context.Background(), models.BaselineComparisonRequest{KPIID: "missing", Start: start, End: end}); err == nil {
+		t.Fatal("expected an error for an unknown KPI")
+	}
+}
+
+func TestParseBaselineOffset(t *testing.T) {
+	for in, want := range map[string]time.Duration{"1d": 24 * time.Hour, "4w": 28 * 24 * time.Hour, "36h": 36 * time.Hour} {
+		if got, err := parseBaselineOffset(in); err != nil || got != want {
+			t.Fatalf("parseBaselineOffset(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "w", "1y", "0d", "400d"} {
+		if _, err := parseBaselineOffset(in); err == nil {
+			t.Fatalf("expected an error for %q", in)
+		}
+	}
+}
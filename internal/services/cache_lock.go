@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+)
+
+// cacheLockPoll is how often lockCache retries a lock held elsewhere.
+const cacheLockPoll = 50 * time.Millisecond
+
+// lockCache takes the cross-replica lock key, held for at most ttl, waiting
+// up to wait for another holder to release it; busy is returned when it
+// does not. The returned func releases the lock; failures to release are
+// logged when logger is set.
+func lockCache(ctx context.Context, c cache.ValkeyCluster, key string, ttl, wait time.Duration, busy error, logger logging.Logger) (func(), error) {
+	deadline := time.Now().Add(wait)
+	for {
+		acquired, err := c.AcquireLock(ctx, key, ttl)
+		if err != nil {
+			return nil, fmt.Errorf("lock %s: %w", key, err)
+		}
+		if acquired {
+			return func() {
+				if err := c.ReleaseLock(context.WithoutCancel(ctx), key); err != nil && logger != nil {
+					logger.Warn("Failed to release lock", "key", key, "error", err)
+				}
+			}, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, busy
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(cacheLockPoll):
+		}
+	}
+}
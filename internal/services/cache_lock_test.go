@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// lockingCache grants each lock key once until it is released, like
+// Valkey's SET NX before the TTL expires.
+type lockingCache struct {
+	cache.ValkeyCluster
+	mu    sync.Mutex
+	locks map[string]bool
+}
+
+func newLockingCache() *lockingCache {
+	log := logger.New("error")
+	return &lockingCache{ValkeyCluster: cache.NewNoopValkeyCache(log), locks: map[string]bool{}}
+}
+
+func (c *lockingCache) AcquireLock(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.locks[key] {
+		return false, nil
+	}
+	c.locks[key] = true
+	return true, nil
+}
+
+func (c *lockingCache) ReleaseLock(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.locks, key)
+	return nil
+}
+
+func TestLockCache(t *testing.T) {
+	c := newLockingCache()
+	log := logging.FromCoreLogger(logger.New("error"))
+	ctx := context.Background()
+	busy := errors.New("busy")
+
+	unlock, err := lockCache(ctx, c, "k", time.Second, 0, busy, log)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := lockCache(ctx, c, "k", time.Second, 100*time.Millisecond, busy, log); !errors.Is(err, busy) {
+		t.Fatalf("expected busy while held, got %v", err)
+	}
+
+	released := make(chan struct{})
+	go func() {
+		time.Sleep(60 * time.Millisecond)
+		unlock()
+		close(released)
+	}()
+	unlock2, err := lockCache(ctx, c, "k", time.Second, time.Second, busy, log)
+	if err != nil {
+		t.Fatalf("expected the lock once released, got %v", err)
+	}
+	<-released
+	unlock2()
+}
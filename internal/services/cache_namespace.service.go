@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/monitoring"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// ErrInvalidTenant is returned for tenant IDs that cannot be a cache namespace.
+var ErrInvalidTenant = errors.New("invalid tenant id")
+
+// CacheNamespaceService reports and flushes per-tenant Valkey namespaces. It
+// operates beneath this deployment's own namespace so it sees every tenant
+// sharing the keyspace.
+type CacheNamespaceService struct {
+	cache  cache.ValkeyCluster
+	logger logging.Logger
+}
+
+// NewCacheNamespaceService creates a new cache namespace service.
+func NewCacheNamespaceService(c cache.ValkeyCluster, logger corelogger.Logger) *CacheNamespaceService {
+	return &CacheNamespaceService{
+		cache:  cache.Unwrap(c),
+		logger: logging.FromCoreLogger(logger),
+	}
+}
+
+// TenantUsage counts keys per tenant namespace and publishes the counts as
+// mirador_core_cache_tenant_keys.
+func (s *CacheNamespaceService) TenantUsage(ctx context.Context) ([]models.TenantCacheUsage, error) {
+	counts := map[string]int64{}
+	err := s.cache.ScanKeys(ctx, cache.TenantKeyPrefix, func(keys []string) error {
+		for _, k := range keys {
+			if tenant, ok := cache.TenantFromKey(k); ok {
+				counts[tenant]++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make([]models.TenantCacheUsage, 0, len(counts))
+	for tenant, n := range counts {
+		usage = append(usage, models.TenantCacheUsage{Tenant: tenant, Keys: n})
+		monitoring.RecordCacheTenantKeys(tenant, n)
+	}
+	sort.Slice(usage, func(i, j int) bool { return usage[i].Tenant < usage[j].Tenant })
+	return usage, nil
+}
+
+// FlushTenant deletes every key in tenant's namespace and returns how many
+// were removed. Other tenants and unprefixed keys are untouched.
+func (s *CacheNamespaceService) FlushTenant(ctx context.Context, tenant string) (int64, error) {
+	if !config.ValidTenantID(tenant) {
+		return 0, ErrInvalidTenant
+	}
+	deleted, err := s.cache.DeleteByPrefix(ctx, cache.TenantPrefix(tenant))
+	if err != nil {
+		return deleted, err
+	}
+	monitoring.RecordCacheTenantKeys(tenant, 0)
+	s.logger.Info("Flushed tenant cache namespace", "tenant", tenant, "deleted", deleted)
+	return deleted, nil
+}
+
+// Start refreshes the per-tenant key gauges every interval until ctx ends.
+func (s *CacheNamespaceService) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.TenantUsage(ctx); err != nil && ctx.Err() == nil {
+			s.logger.Warn("Failed to collect tenant cache usage", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func TestCacheNamespaceService_UsageAndFlush(t *testing.T) {
+	log := logger.New("error")
+	inner := cache.NewNoopValkeyCache(log)
+	own := cache.NewTenantNamespaced(inner, "acme")
+	other := cache.NewTenantNamespaced(inner, "globex")
+	ctx := context.Background()
+
+	_ = own.Set(ctx, "kpi:1", "x", time.Minute)
+	_ = own.Set(ctx, "kpi:2", "x", time.Minute)
+	_ = other.Set(ctx, "kpi:1", "x", time.Minute)
+
+	// Built on the namespaced cache, the service still sees every tenant.
+	svc := NewCacheNamespaceService(own, log)
+	usage, err := svc.TenantUsage(ctx)
+	if err != nil {
+		t.Fatalf("usage: %v", err)
+	}
+	if len(usage) != 2 || usage[0].Tenant != "acme" || usage[0].Keys != 2 || usage[1].Tenant != "globex" || usage[1].Keys != 1 {
+		t.Fatalf("unexpected usage: %+v", usage)
+	}
+
+	deleted, err := svc.FlushTenant(ctx, "globex")
+	if err != nil || deleted != 1 {
+		t.Fatalf("flush: deleted=%d err=%v", deleted, err)
+	}
+	if _, err := own.Get(ctx, "kpi:1"); err != nil {
+		t.Fatalf("acme keys should survive a globex flush: %v", err)
+	}
+
+	if _, err := svc.FlushTenant(ctx, "*"); !errors.Is(err, ErrInvalidTenant) {
+		t.Fatalf("expected ErrInvalidTenant, got %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const (
+	callbackResultKeyPrefix  = "callback:result:"
+	defaultCallbackResultTTL = 24 * time.Hour
+)
+
+var (
+	ErrInvalidCallbackResult  = errors.New("invalid callback result")
+	ErrCallbackResultNotFound = errors.New("callback result not found")
+	// ErrCallbackJobOwned is returned when an engine submits a result for a
+	// job another engine already reported.
+	ErrCallbackJobOwned = errors.New("job belongs to another engine")
+)
+
+var callbackJobIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// CallbackResultService stores job results delivered by AI engines, so
+// clients can fetch them instead of the engines being polled.
+type CallbackResultService struct {
+	cache  cache.ValkeyCluster
+	ttl    time.Duration
+	logger logging.Logger
+}
+
+// NewCallbackResultService creates a new callback result store.
+func NewCallbackResultService(cache cache.ValkeyCluster, cfg config.CallbackConfig, logger corelogger.Logger) *CallbackResultService {
+	ttl := cfg.ResultTTL
+	if ttl <= 0 {
+		ttl = defaultCallbackResultTTL
+	}
+	return &CallbackResultService{
+		cache:  cache,
+		ttl:    ttl,
+		logger: logging.FromCoreLogger(logger),
+	}
+}
+
+// Submit validates and stores a result. Later submissions for the same job,
+// e.g. progress followed by completion, replace the earlier one.
+func (s *CallbackResultService) Submit(ctx context.Context, r *models.CallbackResult) error {
+	r.JobID = strings.TrimSpace(r.JobID)
+	r.Status = strings.ToLower(strings.TrimSpace(r.Status))
+	if !callbackJobIDPattern.MatchString(r.JobID) {
+		return fmt.Errorf("%w: job_id must be 1-128 letters, digits, '.', '_', ':' or '-'", ErrInvalidCallbackResult)
+	}
+	if r.Engine == "" {
+		return fmt.Errorf("%w: engine is required", ErrInvalidCallbackResult)
+	}
+	if r.Status == "" {
+		r.Status = "succeeded"
+	}
+	existing, err := s.Get(ctx, r.JobID)
+	if err == nil && existing.Engine != r.Engine {
+		return fmt.Errorf("%w: %s", ErrCallbackJobOwned, r.JobID)
+	}
+	r.ReceivedAt = time.Now().UTC()
+	if err := s.cache.Set(ctx, callbackResultKeyPrefix+r.JobID, r, s.ttl); err != nil {
+		return fmt.Errorf("store callback result: %w", err)
+	}
+	s.logger.Info("Callback result received", "job_id", r.JobID, "engine", r.Engine, "kind", r.Kind, "status", r.Status)
+	return nil
+}
+
+// Get returns the latest result delivered for a job.
+func (s *CallbackResultService) Get(ctx context.Context, jobID string) (*models.CallbackResult, error) {
+	if !callbackJobIDPattern.MatchString(jobID) {
+		return nil, fmt.Errorf("%w: invalid job_id", ErrInvalidCallbackResult)
+	}
+	data, err := s.cache.Get(ctx, callbackResultKeyPrefix+jobID)
+	if err != nil || len(data) == 0 {
+		return nil, ErrCallbackResultNotFound
+	}
+	var r models.CallbackResult
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("decode callback result: %w", err)
+	}
+	return &r, nil
+}
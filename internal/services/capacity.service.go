@@ -0,0 +1,327 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/qos"
+	"github.com/mirastacklabs-ai/mirador-core/internal/repo"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const (
+	capacityReportKey = "capacity:report"
+
+	// maxCapacityKPIs bounds the range queries issued per report.
+	maxCapacityKPIs = 100
+	// minCapacityPoints is the fewest samples a series needs for a trend.
+	minCapacityPoints = 3
+)
+
+// CapacityMetricsQuerier runs MetricsQL range queries.
+type CapacityMetricsQuerier interface {
+	ExecuteRangeQuery(ctx context.Context, request *models.MetricsQLRangeQueryRequest) (*models.MetricsQLRangeQueryResult, error)
+}
+
+// CapacityService fits linear trends to resource KPIs and projects when each
+// series crosses its thresholds.
+type CapacityService struct {
+	metrics CapacityMetricsQuerier
+	kpis    repo.KPIRepo
+	cache   cache.ValkeyCluster
+	cfg     config.CapacityConfig
+	logger  logging.Logger
+}
+
+// NewCapacityService creates a new capacity planning service.
+func NewCapacityService(metrics CapacityMetricsQuerier, kpis repo.KPIRepo, cache cache.ValkeyCluster, cfg config.CapacityConfig, logger corelogger.Logger) *CapacityService {
+	if cfg.Lookback <= 0 {
+		cfg.Lookback = 7 * 24 * time.Hour
+	}
+	if cfg.Step <= 0 {
+		cfg.Step = time.Hour
+	}
+	if cfg.HorizonDays <= 0 {
+		cfg.HorizonDays = 30
+	}
+	return &CapacityService{
+		metrics: metrics,
+		kpis:    kpis,
+		cache:   cache,
+		cfg:     cfg,
+		logger:  logging.FromCoreLogger(logger),
+	}
+}
+
+// Report returns the series projected to breach within the horizon. The
+// default report (no KPI selection, configured horizon) is served from the
+// last scheduled run unless refresh is set.
+func (s *CapacityService) Report(ctx context.Context, req models.CapacityRequest, refresh bool) (*models.CapacityReport, error) {
+	if req.HorizonDays <= 0 {
+		req.HorizonDays = s.cfg.HorizonDays
+	}
+	isDefault := len(req.KPIIDs) == 0 && req.HorizonDays == s.cfg.HorizonDays
+	if isDefault && !refresh {
+		if data, err := s.cache.Get(ctx, capacityReportKey); err == nil && len(data) > 0 {
+			var report models.CapacityReport
+			if err := json.Unmarshal(data, &report); err == nil {
+				return &report, nil
+			}
+		}
+	}
+	report, err := s.compute(ctx, req, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if isDefault {
+		if err := s.cache.Set(ctx, capacityReportKey, report, 0); err != nil {
+			s.logger.Warn("Failed to store capacity report", "error", err)
+		}
+	}
+	return report, nil
+}
+
+// Start rebuilds the default report every RefreshInterval until ctx ends.
+func (s *CapacityService) Start(ctx context.Context) {
+	if s.cfg.RefreshInterval <= 0 {
+		return
+	}
+	ctx = qos.WithClass(ctx, qos.Background)
+	ticker := time.NewTicker(s.cfg.RefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.Report(ctx, models.CapacityRequest{}, true); err != nil && ctx.Err() == nil {
+				s.logger.Warn("Capacity report refresh failed", "error", err)
+			}
+		}
+	}
+}
+
+func (s *CapacityService) compute(ctx context.Context, req models.CapacityRequest, now time.Time) (*models.CapacityReport, error) {
+	if s.kpis == nil {
+		return nil, fmt.Errorf("KPI registry not configured")
+	}
+	if s.metrics == nil {
+		return nil, fmt.Errorf("metrics backend not configured")
+	}
+	kpis, err := s.selectKPIs(ctx, req.KPIIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.CapacityReport{
+		HorizonDays: req.HorizonDays,
+		Lookback:    s.cfg.Lookback.String(),
+		KPIs:        len(kpis),
+		Forecasts:   []models.CapacityForecast{},
+		GeneratedAt: now,
+	}
+	horizon := float64(req.HorizonDays)
+	for _, k := range kpis {
+		res, err := s.metrics.ExecuteRangeQuery(ctx, &models.MetricsQLRangeQueryRequest{
+			Query: k.Formula,
+			Start: now.Add(-s.cfg.Lookback).Format(time.RFC3339),
+			End:   now.Format(time.RFC3339),
+			Step:  strconv.Itoa(int(s.cfg.Step.Seconds())),
+		})
+		if err != nil {
+			s.logger.Debug("Capacity range query failed", "kpi", k.ID, "error", err)
+			continue
+		}
+		if res == nil || res.Data == nil {
+			continue
+		}
+		series, err := decodeRangeMatrix(res.Data)
+		if err != nil {
+			s.logger.Debug("Unexpected capacity query result", "kpi", k.ID, "error", err)
+			continue
+		}
+		for _, ser := range series {
+			f, ok := forecastSeries(k, ser, now)
+			if !ok {
+				continue
+			}
+			report.Series++
+			if f.Level != "" && f.DaysToBreach <= horizon {
+				report.Forecasts = append(report.Forecasts, f)
+			}
+		}
+	}
+	sort.SliceStable(report.Forecasts, func(i, j int) bool {
+		return report.Forecasts[i].DaysToBreach < report.Forecasts[j].DaysToBreach
+	})
+	return report, nil
+}
+
+// selectKPIs returns the requested KPIs, or every resource KPI, that have a
+// formula and thresholds.
+func (s *CapacityService) selectKPIs(ctx context.Context, ids []string) ([]*models.KPIDefinition, error) {
+	all, _, err := s.kpis.ListKPIs(ctx, models.KPIListRequest{Limit: catalogScanLimit})
+	if err != nil {
+		return nil, err
+	}
+	wanted := map[string]bool{}
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	out := []*models.KPIDefinition{}
+	for _, k := range all {
+		if len(out) >= maxCapacityKPIs {
+			break
+		}
+		if k == nil || k.Formula == "" || len(k.Thresholds) == 0 {
+			continue
+		}
+		if len(wanted) > 0 && !wanted[k.ID] {
+			continue
+		}
+		if len(wanted) == 0 && !isResourceKPI(k, s.cfg.ResourceTags) {
+			continue
+		}
+		out = append(out, k)
+	}
+	return out, nil
+}
+
+func isResourceKPI(k *models.KPIDefinition, resourceTags []string) bool {
+	name := strings.ToLower(k.Name)
+	for _, rt := range resourceTags {
+		rt = strings.ToLower(rt)
+		if rt == "" {
+			continue
+		}
+		if strings.Contains(name, rt) {
+			return true
+		}
+		for _, t := range k.Tags {
+			if strings.EqualFold(t, rt) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// forecastSeries fits a line to the series and finds the soonest threshold
+// crossing in the trend's direction: upper bounds (gt/gte) for rising series,
+// lower bounds (lt/lte) for falling ones. A series already past a threshold
+// breaches now. Level is empty when no threshold is projected to be crossed.
+func forecastSeries(k *models.KPIDefinition, ser promSeries, now time.Time) (models.CapacityForecast, bool) {
+	if len(ser.times) < minCapacityPoints {
+		return models.CapacityForecast{}, false
+	}
+	days := make([]float64, len(ser.times))
+	for i, ts := range ser.times {
+		days[i] = ts.Sub(now).Hours() / 24
+	}
+	slope, _, r2 := LinearFit(days, ser.values)
+	current := ser.values[len(ser.values)-1]
+
+	f := models.CapacityForecast{
+		KPIID:       k.ID,
+		KPIName:     k.Name,
+		Labels:      ser.labels,
+		Current:     current,
+		SlopePerDay: slope,
+		R2:          math.Round(r2*1000) / 1000,
+	}
+	best := math.Inf(1)
+	for _, t := range k.Thresholds {
+		var eta float64
+		op := strings.ToLower(t.Operator)
+		switch {
+		case thresholdBreached(t, current):
+			eta = 0
+		case (op == "gt" || op == "gte") && slope > 0:
+			eta = (t.Value - current) / slope
+		case (op == "lt" || op == "lte") && slope < 0:
+			eta = (t.Value - current) / slope
+		default:
+			continue
+		}
+		if eta < best {
+			best = eta
+			f.Level, f.Threshold = t.Level, t.Value
+		}
+	}
+	if f.Level != "" {
+		f.DaysToBreach = math.Round(best*10) / 10
+		f.BreachAt = now.Add(time.Duration(best * 24 * float64(time.Hour))).Truncate(time.Minute)
+	}
+	return f, true
+}
+
+// promSeries is one series of a range (matrix) query result.
+type promSeries struct {
+	labels map[string]string
+	times  []time.Time
+	values []float64
+}
+
+// decodeRangeMatrix parses the data of a matrix range query result,
+// skipping non-numeric (NaN/Inf) samples.
+func decodeRangeMatrix(data interface{}) ([]promSeries, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var payload struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Metric map[string]string `json:"metric"`
+			Values [][]interface{}   `json:"values"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil, err
+	}
+	if payload.ResultType != "matrix" {
+		return nil, fmt.Errorf("unexpected result type %q", payload.ResultType)
+	}
+
+	out := make([]promSeries, 0, len(payload.Result))
+	for _, r := range payload.Result {
+		ser := promSeries{labels: r.Metric}
+		for _, pair := range r.Values {
+			if len(pair) != 2 {
+				continue
+			}
+			ts, ok := pair[0].(float64)
+			if !ok {
+				continue
+			}
+			var v float64
+			switch x := pair[1].(type) {
+			case string:
+				if v, err = strconv.ParseFloat(x, 64); err != nil {
+					continue
+				}
+			case float64:
+				v = x
+			default:
+				continue
+			}
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				continue
+			}
+			ser.times = append(ser.times, time.Unix(0, int64(ts*float64(time.Second))))
+			ser.values = append(ser.values, v)
+		}
+		out = append(out, ser)
+	}
+	return out, nil
+}
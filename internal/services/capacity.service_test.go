@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// rangeQuerier answers range queries with one series per entry, sampled
+// daily over the last len(values) days.
+type rangeQuerier map[string][][]float64
+
+func (q rangeQuerier) ExecuteRangeQuery(ctx context.Context, req *models.MetricsQLRangeQueryRequest) (*models.MetricsQLRangeQueryResult, error) {
+	now := time.Now()
+	result := []interface{}{}
+	for i, vals := range q[req.Query] {
+		points := []interface{}{}
+		for d, v := range vals {
+			ts := now.Add(-time.Duration(len(vals)-1-d) * 24 * time.Hour)
+			points = append(points, []interface{}{float64(ts.Unix()), fmt.Sprint(v)})
+		}
+		result = append(result, map[string]interface{}{
+			"metric": map[string]interface{}{"instance": fmt.Sprintf("node-%d", i)},
+			"values": points,
+		})
+	}
+	return &models.MetricsQLRangeQueryResult{Data: map[string]interface{}{"resultType": "matrix", "result": result}}, nil
+}
+
+func TestCapacityService_Report(t *testing.T) {
+	log := logger.New("error")
+	kpis := newFakeKPIRepo()
+	kpis.kpis["disk"] = &models.KPIDefinition{ID: "disk", Name: "Disk usage", Formula: "disk_used",
+		Thresholds: []models.Threshold{{Level: "warning", Operator: "gt", Value: 80}, {Level: "critical", Operator: "gt", Value: 95}}}
+	kpis.kpis["free"] = &models.KPIDefinition{ID: "free", Name: "free bytes", Tags: []string{"memory"}, Formula: "mem_free",
+		Thresholds: []models.Threshold{{Level: "critical", Operator: "lt", Value: 10}}}
+	kpis.kpis["latency"] = &models.KPIDefinition{ID: "latency", Name: "p99 latency", Formula: "latency",
+		Thresholds: []models.Threshold{{Level: "critical", Operator: "gt", Value: 0}}}
+
+	querier := rangeQuerier{
+		"disk_used": {
+			{50, 52, 54, 56, 58}, // +2/day: warning (80) in 11 days
+			{60, 60, 60, 60, 60}, // flat: never
+		},
+		"mem_free": {{40, 35, 30, 25, 20}}, // -5/day: below 10 in 2 days
+		"latency":  {{1, 1, 1}},            // not a resource KPI
+	}
+	svc := NewCapacityService(querier, kpis, cache.NewNoopValkeyCache(log), config.CapacityConfig{
+		ResourceTags: []string{"cpu", "memory", "disk"},
+		Step:         24 * time.Hour,
+		HorizonDays:  30,
+	}, log)
+
+	report, err := svc.Report(context.Background(), models.CapacityRequest{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.KPIs != 2 || report.Series != 3 || len(report.Forecasts) != 2 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	first, second := report.Forecasts[0], report.Forecasts[1]
+	if first.KPIID != "free" || first.DaysToBreach != 2 || first.Level != "critical" {
+		t.Fatalf("expected memory to breach first in 2 days, got %+v", first)
+	}
+	if second.KPIID != "disk" || second.DaysToBreach != 11 || second.Level != "warning" || second.Labels["instance"] != "node-0" {
+		t.Fatalf("expected disk warning in 11 days, got %+v", second)
+	}
+
+	// A shorter horizon drops the disk forecast; explicit KPIs bypass the
+	// resource tag filter.
+	report, err = svc.Report(context.Background(), models.CapacityRequest{HorizonDays: 5, KPIIDs: []string{"disk", "latency"}}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.KPIs != 2 || len(report.Forecasts) != 1 || report.Forecasts[0].KPIID != "latency" || report.Forecasts[0].DaysToBreach != 0 {
+		t.Fatalf("unexpected filtered report: %+v", report)
+	}
+}
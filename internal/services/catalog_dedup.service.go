@@ -0,0 +1,428 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/repo"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const (
+	catalogDuplicatesKey   = "catalog:duplicates"
+	catalogMergeHistoryKey = "catalog:merge_history"
+
+	// DefaultDuplicateThreshold is used when no threshold is configured.
+	DefaultDuplicateThreshold = 0.85
+
+	// maxMergeHistory caps the merge history kept in Valkey.
+	maxMergeHistory = 500
+	// catalogScanLimit matches the KPI store's object listing limit.
+	catalogScanLimit = 10000
+)
+
+var (
+	ErrCatalogEntryNotFound = errors.New("catalog entry not found")
+	ErrCatalogSelfMerge     = errors.New("survivor and duplicate must differ")
+	ErrCatalogTypeMismatch  = errors.New("survivor and duplicate are different entity types")
+)
+
+// catalogEntityTypes are the schema tags that give a KPI registry entry its
+// entity type; untagged entries are plain KPIs. Only entries of the same
+// type are compared.
+var catalogEntityTypes = []models.SchemaType{
+	models.SchemaTypeMetric,
+	models.SchemaTypeLabel,
+	models.SchemaTypeLogField,
+	models.SchemaTypeTraceService,
+	models.SchemaTypeTraceOperation,
+}
+
+// CatalogDedupService finds likely duplicate catalog entries (e.g. a metric
+// created by catalog sync and again by hand under a slightly different name)
+// and merges them. A merge folds the duplicate's fields into the survivor,
+// leaves an alias so lookups of the old ID resolve to the survivor, records
+// the duplicate in the merge history and deletes it.
+type CatalogDedupService struct {
+	repo      repo.KPIRepo
+	cache     cache.ValkeyCluster
+	threshold float64
+	logger    logging.Logger
+}
+
+// NewCatalogDedupService creates a new catalog duplicate detection service.
+func NewCatalogDedupService(kpiRepo repo.KPIRepo, cache cache.ValkeyCluster, threshold float64, logger corelogger.Logger) *CatalogDedupService {
+	if threshold <= 0 || threshold > 1 {
+		threshold = DefaultDuplicateThreshold
+	}
+	return &CatalogDedupService{
+		repo:      kpiRepo,
+		cache:     cache,
+		threshold: threshold,
+		logger:    logging.FromCoreLogger(logger),
+	}
+}
+
+// Detect scans the catalog, stores and returns the duplicate candidates.
+func (s *CatalogDedupService) Detect(ctx context.Context) (*models.CatalogDuplicateReport, error) {
+	kpis, _, err := s.repo.ListKPIs(ctx, models.KPIListRequest{Limit: catalogScanLimit})
+	if err != nil {
+		return nil, fmt.Errorf("list catalog: %w", err)
+	}
+	report := &models.CatalogDuplicateReport{
+		GeneratedAt: time.Now().UTC(),
+		Scanned:     len(kpis),
+		Threshold:   s.threshold,
+		Candidates:  findDuplicates(kpis, s.threshold),
+	}
+	if err := s.cache.Set(ctx, catalogDuplicatesKey, report, 0); err != nil {
+		s.logger.Warn("Failed to store catalog duplicate report", "error", err)
+	}
+	s.logger.Info("Catalog duplicate detection finished", "scanned", report.Scanned, "candidates", len(report.Candidates))
+	return report, nil
+}
+
+// LastReport returns the most recent stored report, running detection when
+// none exists yet.
+func (s *CatalogDedupService) LastReport(ctx context.Context) (*models.CatalogDuplicateReport, error) {
+	if data, err := s.cache.Get(ctx, catalogDuplicatesKey); err == nil && len(data) > 0 {
+		var report models.CatalogDuplicateReport
+		if err := json.Unmarshal(data, &report); err == nil {
+			return &report, nil
+		}
+		s.logger.Warn("Failed to unmarshal catalog duplicate report, recomputing")
+	}
+	return s.Detect(ctx)
+}
+
+// Merge folds req.DuplicateID into req.SurvivorID.
+func (s *CatalogDedupService) Merge(ctx context.Context, req models.CatalogMergeRequest) (*models.CatalogMergeRecord, error) {
+	if req.SurvivorID == req.DuplicateID {
+		return nil, ErrCatalogSelfMerge
+	}
+	survivor, err := s.repo.GetKPI(ctx, req.SurvivorID)
+	if err != nil {
+		return nil, err
+	}
+	duplicate, err := s.repo.GetKPI(ctx, req.DuplicateID)
+	if err != nil {
+		return nil, err
+	}
+	// GetKPI follows aliases, so an already merged ID resolves to its
+	// survivor; only merge entries that still exist under their own ID.
+	if survivor == nil || duplicate == nil || survivor.ID != req.SurvivorID || duplicate.ID != req.DuplicateID {
+		return nil, ErrCatalogEntryNotFound
+	}
+	if catalogEntityType(survivor) != catalogEntityType(duplicate) {
+		return nil, ErrCatalogTypeMismatch
+	}
+
+	snapshot := *duplicate
+	filled := mergeCatalogEntry(survivor, duplicate)
+	if len(filled) > 0 {
+		survivor.UpdatedAt = time.Now().UTC()
+		if _, _, err := s.repo.ModifyKPI(ctx, survivor); err != nil {
+			return nil, fmt.Errorf("update survivor: %w", err)
+		}
+	}
+	if err := s.cache.Set(ctx, repo.KPIAliasKey(duplicate.ID), survivor.ID, 0); err != nil {
+		return nil, fmt.Errorf("record alias: %w", err)
+	}
+
+	record := &models.CatalogMergeRecord{
+		SurvivorID:   survivor.ID,
+		DuplicateID:  duplicate.ID,
+		MergedBy:     req.MergedBy,
+		MergedAt:     time.Now().UTC(),
+		FieldsFilled: filled,
+		Duplicate:    &snapshot,
+	}
+	s.appendHistory(ctx, record)
+
+	if _, err := s.repo.DeleteKPI(ctx, duplicate.ID); err != nil {
+		return record, fmt.Errorf("delete duplicate: %w", err)
+	}
+	s.dropFromReport(ctx, duplicate.ID)
+	s.logger.Info("Merged duplicate catalog entry",
+		"survivor", survivor.ID, "duplicate", duplicate.ID, "merged_by", req.MergedBy, "fields_filled", filled)
+	return record, nil
+}
+
+// History returns past merges, newest first.
+func (s *CatalogDedupService) History(ctx context.Context) ([]models.CatalogMergeRecord, error) {
+	data, err := s.cache.Get(ctx, catalogMergeHistoryKey)
+	if err != nil || len(data) == 0 {
+		return []models.CatalogMergeRecord{}, nil
+	}
+	var history []models.CatalogMergeRecord
+	if err := json.Unmarshal(data, &history); err != nil {
+		return nil, fmt.Errorf("decode merge history: %w", err)
+	}
+	return history, nil
+}
+
+// Start runs detection every interval until ctx ends.
+func (s *CatalogDedupService) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.Detect(ctx); err != nil && ctx.Err() == nil {
+				s.logger.Warn("Catalog duplicate detection failed", "error", err)
+			}
+		}
+	}
+}
+
+func (s *CatalogDedupService) appendHistory(ctx context.Context, record *models.CatalogMergeRecord) {
+	history, err := s.History(ctx)
+	if err != nil {
+		s.logger.Warn("Resetting unreadable catalog merge history", "error", err)
+		history = nil
+	}
+	history = append([]models.CatalogMergeRecord{*record}, history...)
+	if len(history) > maxMergeHistory {
+		history = history[:maxMergeHistory]
+	}
+	if err := s.cache.Set(ctx, catalogMergeHistoryKey, history, 0); err != nil {
+		s.logger.Warn("Failed to store catalog merge history", "error", err)
+	}
+}
+
+// dropFromReport removes pairs involving a merged-away entry from the stored
+// report so the UI does not offer it again before the next run.
+func (s *CatalogDedupService) dropFromReport(ctx context.Context, id string) {
+	data, err := s.cache.Get(ctx, catalogDuplicatesKey)
+	if err != nil || len(data) == 0 {
+		return
+	}
+	var report models.CatalogDuplicateReport
+	if json.Unmarshal(data, &report) != nil {
+		return
+	}
+	kept := report.Candidates[:0]
+	for _, c := range report.Candidates {
+		if c.Left.ID != id && c.Right.ID != id {
+			kept = append(kept, c)
+		}
+	}
+	report.Candidates = kept
+	_ = s.cache.Set(ctx, catalogDuplicatesKey, report, 0)
+}
+
+// mergeCatalogEntry copies fields the survivor lacks from dup and unions its
+// tags and dimension hints. It returns the names of the fields it changed.
+func mergeCatalogEntry(survivor, dup *models.KPIDefinition) []string {
+	var filled []string
+	fill := func(name string, dst *string, src string) {
+		if strings.TrimSpace(*dst) == "" && strings.TrimSpace(src) != "" {
+			*dst = src
+			filled = append(filled, name)
+		}
+	}
+	fill("definition", &survivor.Definition, dup.Definition)
+	fill("description", &survivor.Description, dup.Description)
+	fill("unit", &survivor.Unit, dup.Unit)
+	fill("formula", &survivor.Formula, dup.Formula)
+	fill("queryType", &survivor.QueryType, dup.QueryType)
+	fill("layer", &survivor.Layer, dup.Layer)
+	fill("signalType", &survivor.SignalType, dup.SignalType)
+	fill("classifier", &survivor.Classifier, dup.Classifier)
+	fill("datastore", &survivor.Datastore, dup.Datastore)
+	fill("domain", &survivor.Domain, dup.Domain)
+	fill("serviceFamily", &survivor.ServiceFamily, dup.ServiceFamily)
+	fill("componentType", &survivor.ComponentType, dup.ComponentType)
+	fill("category", &survivor.Category, dup.Category)
+	fill("businessImpact", &survivor.BusinessImpact, dup.BusinessImpact)
+
+	union := func(name string, dst *[]string, src []string) {
+		seen := make(map[string]struct{}, len(*dst))
+		for _, v := range *dst {
+			seen[v] = struct{}{}
+		}
+		added := false
+		for _, v := range src {
+			if _, ok := seen[v]; !ok {
+				seen[v] = struct{}{}
+				*dst = append(*dst, v)
+				added = true
+			}
+		}
+		if added {
+			filled = append(filled, name)
+		}
+	}
+	union("tags", &survivor.Tags, dup.Tags)
+	union("dimensionsHint", &survivor.DimensionsHint, dup.DimensionsHint)
+	return filled
+}
+
+func catalogEntityType(k *models.KPIDefinition) string {
+	for _, t := range catalogEntityTypes {
+		for _, tag := range k.Tags {
+			if strings.EqualFold(tag, string(t)) {
+				return string(t)
+			}
+		}
+	}
+	return string(models.SchemaTypeKPI)
+}
+
+// normalizeCatalogName lowercases a name and splits it into words on case
+// changes and punctuation, so "HttpRequests", "http_requests" and
+// "HTTP Requests" all normalize to "http requests".
+func normalizeCatalogName(name string) string {
+	var b strings.Builder
+	prevLower := false
+	for _, r := range name {
+		switch {
+		case unicode.IsUpper(r):
+			if prevLower {
+				b.WriteByte(' ')
+			}
+			b.WriteRune(unicode.ToLower(r))
+			prevLower = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+			prevLower = true
+		default:
+			b.WriteByte(' ')
+			prevLower = false
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// trigrams returns the character trigram counts of s, padded so short words
+// still produce grams.
+func trigrams(s string) map[string]float64 {
+	out := map[string]float64{}
+	rs := []rune(" " + s + " ")
+	for i := 0; i+3 <= len(rs); i++ {
+		out[string(rs[i:i+3])]++
+	}
+	return out
+}
+
+func cosine(a, b map[string]float64) float64 {
+	var dot, na, nb float64
+	for k, va := range a {
+		na += va * va
+		if vb, ok := b[k]; ok {
+			dot += va * vb
+		}
+	}
+	for _, vb := range b {
+		nb += vb * vb
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(na*nb)
+}
+
+type catalogEntry struct {
+	kpi        *models.KPIDefinition
+	entityType string
+	name       string
+	nameVec    map[string]float64
+	textVec    map[string]float64
+}
+
+// findDuplicates compares entries of the same type that share name
+// trigrams and returns pairs scoring at least threshold, best first. Names
+// are the primary signal; similar descriptions can raise a pair's score.
+func findDuplicates(kpis []*models.KPIDefinition, threshold float64) []models.DuplicateCandidate {
+	entries := make([]catalogEntry, 0, len(kpis))
+	for _, k := range kpis {
+		if k == nil || k.ID == "" {
+			continue
+		}
+		name := normalizeCatalogName(k.Name)
+		if name == "" {
+			continue
+		}
+		text := normalizeCatalogName(k.Name + " " + k.Definition + " " + k.Description)
+		entries = append(entries, catalogEntry{
+			kpi:        k,
+			entityType: catalogEntityType(k),
+			name:       name,
+			nameVec:    trigrams(name),
+			textVec:    trigrams(text),
+		})
+	}
+
+	// Inverted index on name trigrams keeps this well below O(n^2) for
+	// large catalogs: only entries sharing grams are ever compared.
+	index := map[string][]int{}
+	for i, e := range entries {
+		for g := range e.nameVec {
+			key := e.entityType + "|" + g
+			index[key] = append(index[key], i)
+		}
+	}
+
+	out := []models.DuplicateCandidate{}
+	for i, e := range entries {
+		shared := map[int]int{}
+		for g := range e.nameVec {
+			for _, j := range index[e.entityType+"|"+g] {
+				if j > i {
+					shared[j]++
+				}
+			}
+		}
+		for j, n := range shared {
+			o := entries[j]
+			// Cheap pre-filter: too few shared grams can't reach threshold.
+			if float64(n) < threshold*0.5*math.Min(float64(len(e.nameVec)), float64(len(o.nameVec))) {
+				continue
+			}
+			nameScore := cosine(e.nameVec, o.nameVec)
+			if e.name == o.name {
+				nameScore = 1
+			}
+			vecScore := cosine(e.textVec, o.textVec)
+			score := math.Max(nameScore, 0.6*nameScore+0.4*vecScore)
+			if score < threshold {
+				continue
+			}
+			out = append(out, models.DuplicateCandidate{
+				EntityType:  e.entityType,
+				Score:       round3(score),
+				NameScore:   round3(nameScore),
+				VectorScore: round3(vecScore),
+				Left:        catalogRef(e.kpi),
+				Right:       catalogRef(o.kpi),
+			})
+		}
+	}
+	sort.Slice(out, func(a, b int) bool {
+		if out[a].Score != out[b].Score {
+			return out[a].Score > out[b].Score
+		}
+		return out[a].Left.ID+out[a].Right.ID < out[b].Left.ID+out[b].Right.ID
+	})
+	return out
+}
+
+func catalogRef(k *models.KPIDefinition) models.CatalogEntryRef {
+	return models.CatalogEntryRef{ID: k.ID, Name: k.Name, Source: k.Source, UpdatedAt: k.UpdatedAt}
+}
+
+func round3(f float64) float64 {
+	return math.Round(f*1000) / 1000
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/repo"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// dedupRepo extends fakeKPIRepo with working modify/delete and nil-on-miss
+// lookups, matching DefaultKPIRepo.
+type dedupRepo struct {
+	*fakeKPIRepo
+}
+
+func (r dedupRepo) GetKPI(ctx context.Context, id string) (*models.KPIDefinition, error) {
+	return r.kpis[id], nil
+}
+
+func (r dedupRepo) ModifyKPI(ctx context.Context, k *models.KPIDefinition) (*models.KPIDefinition, string, error) {
+	r.kpis[k.ID] = k
+	return k, "updated", nil
+}
+
+func (r dedupRepo) DeleteKPI(ctx context.Context, id string) (repo.DeleteResult, error) {
+	delete(r.kpis, id)
+	return repo.DeleteResult{}, nil
+}
+
+func TestNormalizeCatalogName(t *testing.T) {
+	for _, in := range []string{"HttpRequests", "http_requests", "HTTP Requests", " http-requests "} {
+		if got := normalizeCatalogName(in); got != "http requests" {
+			t.Fatalf("normalizeCatalogName(%q) = %q", in, got)
+		}
+	}
+}
+
+func TestFindDuplicates(t *testing.T) {
+	kpis := []*models.KPIDefinition{
+		{ID: "1", Name: "checkout_service", Tags: []string{"trace_service"}},
+		{ID: "2", Name: "CheckoutService", Tags: []string{"trace_service"}},
+		{ID: "3", Name: "checkout_service", Tags: []string{"metric"}}, // different type
+		{ID: "4", Name: "payment_gateway", Tags: []string{"trace_service"}},
+		{ID: "5", Name: "http_request_latency_p99", Definition: "p99 latency of HTTP requests"},
+		{ID: "6", Name: "http_requests_latency_p99", Definition: "p99 latency of HTTP requests"},
+	}
+
+	got := findDuplicates(kpis, DefaultDuplicateThreshold)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 candidate pairs, got %+v", got)
+	}
+	if got[0].EntityType != "trace_service" || got[0].NameScore != 1 || got[0].Left.ID != "1" || got[0].Right.ID != "2" {
+		t.Fatalf("unexpected top candidate: %+v", got[0])
+	}
+	if got[1].EntityType != "kpi" || got[1].Left.ID != "5" || got[1].Right.ID != "6" {
+		t.Fatalf("unexpected second candidate: %+v", got[1])
+	}
+}
+
+func TestCatalogDedupService_Merge(t *testing.T) {
+	ctx := context.Background()
+	log := logger.New("error")
+	store := dedupRepo{newFakeKPIRepo()}
+	store.kpis["a"] = &models.KPIDefinition{ID: "a", Name: "checkout", Tags: []string{"trace_service"}}
+	store.kpis["b"] = &models.KPIDefinition{ID: "b", Name: "Checkout", Tags: []string{"trace_service", "team-x"}, Definition: "Checkout API"}
+	c := cache.NewNoopValkeyCache(log)
+	svc := NewCatalogDedupService(store, c, 0, log)
+
+	report, err := svc.Detect(ctx)
+	if err != nil || len(report.Candidates) != 1 {
+		t.Fatalf("detect: %+v err=%v", report, err)
+	}
+
+	rec, err := svc.Merge(ctx, models.CatalogMergeRequest{SurvivorID: "a", DuplicateID: "b", MergedBy: "ops"})
+	if err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	if _, ok := store.kpis["b"]; ok {
+		t.Fatal("duplicate should be deleted")
+	}
+	if a := store.kpis["a"]; a.Definition != "Checkout API" || len(a.Tags) != 2 {
+		t.Fatalf("survivor not enriched: %+v", a)
+	}
+	if rec.Duplicate == nil || rec.Duplicate.Definition != "Checkout API" {
+		t.Fatalf("history should snapshot the duplicate: %+v", rec)
+	}
+	if alias, err := c.Get(ctx, repo.KPIAliasKey("b")); err != nil || string(alias) != "a" {
+		t.Fatalf("expected alias b->a, got %q err=%v", alias, err)
+	}
+	if history, _ := svc.History(ctx); len(history) != 1 || history[0].DuplicateID != "b" {
+		t.Fatalf("unexpected history: %+v", history)
+	}
+	if report, _ := svc.LastReport(ctx); len(report.Candidates) != 0 {
+		t.Fatalf("merged pair should be dropped from the report: %+v", report.Candidates)
+	}
+
+	if _, err := svc.Merge(ctx, models.CatalogMergeRequest{SurvivorID: "a", DuplicateID: "b"}); err != ErrCatalogEntryNotFound {
+		t.Fatalf("expected ErrCatalogEntryNotFound, got %v", err)
+	}
+}
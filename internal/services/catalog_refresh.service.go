@@ -0,0 +1,279 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/repo"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const (
+	catalogRefreshMetricsPrefix = "catalog:refresh:metrics:"
+	catalogRefreshKPIPrefix     = "catalog:refresh:kpi:"
+
+	// catalogRefreshStateTTL bounds how long the previous refresh of a
+	// target, and the previous revalidation of a KPI, are remembered.
+	catalogRefreshStateTTL = 30 * 24 * time.Hour
+	// catalogRefreshMetricLimit caps the metric names fetched for a target.
+	catalogRefreshMetricLimit = 5000
+)
+
+var ErrInvalidCatalogRefresh = errors.New("invalid catalog refresh request")
+
+// CatalogRefreshService refreshes the catalog for one service or namespace
+// right after it deploys, instead of waiting for the next metadata sync:
+// the target's metrics are re-indexed and the KPIs referencing it are
+// linted again. Results are compared with the previous refresh of the same
+// target so callers see what changed.
+type CatalogRefreshService struct {
+	kpis    repo.KPIRepo
+	metrics FormulaLintMetricsCatalog
+	indexer MetricsMetadataIndexer
+	lint    *KPIFormulaLintService
+	cache   cache.ValkeyCluster
+	cfg     config.CatalogConfig
+	logger  logging.Logger
+}
+
+// NewCatalogRefreshService creates a new catalog refresh service. indexer
+// may be nil when metrics metadata indexing is disabled.
+func NewCatalogRefreshService(kpiRepo repo.KPIRepo, metrics FormulaLintMetricsCatalog, indexer MetricsMetadataIndexer, lint *KPIFormulaLintService, cache cache.ValkeyCluster, cfg config.CatalogConfig, logger corelogger.Logger) *CatalogRefreshService {
+	if cfg.RefreshLookback <= 0 {
+		cfg.RefreshLookback = time.Hour
+	}
+	if cfg.ServiceLabel == "" {
+		cfg.ServiceLabel = "service"
+	}
+	if cfg.NamespaceLabel == "" {
+		cfg.NamespaceLabel = "namespace"
+	}
+	return &CatalogRefreshService{
+		kpis:    kpiRepo,
+		metrics: metrics,
+		indexer: indexer,
+		lint:    lint,
+		cache:   cache,
+		cfg:     cfg,
+		logger:  logging.FromCoreLogger(logger),
+	}
+}
+
+// VerifyWebhookSecret reports whether secret matches the configured refresh
+// webhook secret. Webhooks are refused while no secret is configured.
+func (s *CatalogRefreshService) VerifyWebhookSecret(secret string) bool {
+	return s.cfg.RefreshWebhookSecret != "" && subtle.ConstantTimeCompare([]byte(secret), []byte(s.cfg.RefreshWebhookSecret)) == 1
+}
+
+// Refresh re-indexes the metrics of req's target and revalidates the KPIs
+// whose formula selects on the target's labels or uses one of its metrics.
+func (s *CatalogRefreshService) Refresh(ctx context.Context, req models.CatalogRefreshRequest) (*models.CatalogRefreshResult, error) {
+	req.Service, req.Namespace = strings.TrimSpace(req.Service), strings.TrimSpace(req.Namespace)
+	if req.Service == "" && req.Namespace == "" {
+		return nil, fmt.Errorf("%w: service or namespace is required", ErrInvalidCatalogRefresh)
+	}
+	if s.metrics == nil {
+		return nil, fmt.Errorf("%w: metrics backend not configured", ErrFormulaCatalogUnavailable)
+	}
+	targetLabels := map[string]string{}
+	var matchers []string
+	if req.Service != "" {
+		targetLabels[s.cfg.ServiceLabel] = req.Service
+		matchers = append(matchers, fmt.Sprintf("%s=%q", s.cfg.ServiceLabel, req.Service))
+	}
+	if req.Namespace != "" {
+		targetLabels[s.cfg.NamespaceLabel] = req.Namespace
+		matchers = append(matchers, fmt.Sprintf("%s=%q", s.cfg.NamespaceLabel, req.Namespace))
+	}
+	selector := "{" + strings.Join(matchers, ",") + "}"
+
+	end := time.Now().UTC()
+	start := end.Add(-s.cfg.RefreshLookback)
+	names, err := s.metrics.GetLabelValues(ctx, &models.LabelValuesRequest{
+		Label: "__name__",
+		Start: start.Format(time.RFC3339),
+		End:   end.Format(time.RFC3339),
+		Match: []string{selector},
+		Limit: catalogRefreshMetricLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrFormulaCatalogUnavailable, err)
+	}
+	sort.Strings(names)
+	result := &models.CatalogRefreshResult{
+		Service:     req.Service,
+		Namespace:   req.Namespace,
+		RefreshedAt: end,
+		Metrics:     names,
+		KPIs:        []models.KPIRevalidation{},
+	}
+
+	metricsKey := catalogRefreshMetricsPrefix + req.Service + "|" + req.Namespace
+	var previous []string
+	if data, err := s.cache.Get(ctx, metricsKey); err == nil && len(data) > 0 {
+		if err := json.Unmarshal(data, &previous); err != nil {
+			s.logger.Warn("Failed to unmarshal previous catalog refresh", "key", metricsKey, "error", err)
+		}
+	}
+	result.MetricsAdded, result.MetricsRemoved = diffStrings(previous, names)
+	if err := s.cache.Set(ctx, metricsKey, names, catalogRefreshStateTTL); err != nil {
+		s.logger.Warn("Failed to store catalog refresh", "key", metricsKey, "error", err)
+	}
+
+	if s.indexer != nil && len(names) > 0 {
+		sync, err := s.indexer.SyncMetadata(ctx, &models.MetricMetadataSyncRequest{
+			TimeRange: &models.TimeRange{Start: start, End: end},
+			Match:     []string{selector},
+		})
+		if err != nil {
+			s.logger.Warn("Targeted metrics metadata sync failed", "selector", selector, "error", err)
+		} else {
+			result.MetricsIndexed = sync.MetricsProcessed
+		}
+	}
+
+	if err := s.revalidate(ctx, result, targetLabels); err != nil {
+		return nil, err
+	}
+	s.logger.Info("Catalog refreshed", "service", req.Service, "namespace", req.Namespace,
+		"metrics", len(names), "metricsAdded", len(result.MetricsAdded), "kpis", len(result.KPIs), "kpisChanged", result.KPIsChanged)
+	return result, nil
+}
+
+// revalidate lints the KPIs referencing the target and records the outcome.
+func (s *CatalogRefreshService) revalidate(ctx context.Context, result *models.CatalogRefreshResult, targetLabels map[string]string) error {
+	if s.kpis == nil || s.lint == nil {
+		return nil
+	}
+	kpis, _, err := s.kpis.ListKPIs(ctx, models.KPIListRequest{Limit: catalogScanLimit})
+	if err != nil {
+		return fmt.Errorf("list catalog: %w", err)
+	}
+	metrics := make(map[string]bool, len(result.Metrics))
+	for _, m := range result.Metrics {
+		metrics[m] = true
+	}
+	sort.Slice(kpis, func(i, j int) bool { return kpis[i].ID < kpis[j].ID })
+	for _, k := range kpis {
+		if !kpiReferencesTarget(k, targetLabels, metrics) {
+			continue
+		}
+		rv := models.KPIRevalidation{ID: k.ID, Name: k.Name, Warnings: []models.FormulaWarning{}}
+		warnings, err := s.lint.Lint(ctx, k)
+		if err != nil {
+			rv.Error = err.Error()
+			result.KPIs = append(result.KPIs, rv)
+			continue
+		}
+		rv.Warnings = warnings
+
+		key := catalogRefreshKPIPrefix + k.ID
+		var previous []models.FormulaWarning
+		if data, err := s.cache.Get(ctx, key); err == nil && len(data) > 0 {
+			if err := json.Unmarshal(data, &previous); err != nil {
+				s.logger.Warn("Failed to unmarshal previous KPI revalidation", "id", k.ID, "error", err)
+			}
+		}
+		rv.Added, rv.Resolved = diffFormulaWarnings(previous, warnings)
+		rv.Changed = len(rv.Added) > 0 || len(rv.Resolved) > 0
+		if rv.Changed {
+			result.KPIsChanged++
+		}
+		if err := s.cache.Set(ctx, key, warnings, catalogRefreshStateTTL); err != nil {
+			s.logger.Warn("Failed to store KPI revalidation", "id", k.ID, "error", err)
+		}
+		result.KPIs = append(result.KPIs, rv)
+	}
+	return nil
+}
+
+// kpiReferencesTarget reports whether k's MetricsQL formula matches one of
+// the target labels or uses one of the target's metrics.
+func kpiReferencesTarget(k *models.KPIDefinition, targetLabels map[string]string, metrics map[string]bool) bool {
+	if formulaLanguage(k) != "metricsql" {
+		return false
+	}
+	selectors, _ := parseMetricsFormula(k.Formula)
+	for _, sel := range selectors {
+		if metrics[sel.metric.name] {
+			return true
+		}
+		for _, m := range sel.matchers {
+			want, ok := targetLabels[m.label.name]
+			if !ok {
+				continue
+			}
+			switch m.op {
+			case "=":
+				if m.value.name == want {
+					return true
+				}
+			case "=~":
+				if re, err := regexp.Compile("^(?:" + m.value.name + ")$"); err == nil && re.MatchString(want) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// diffStrings returns the sorted values of next missing from prev, and of
+// prev missing from next.
+func diffStrings(prev, next []string) (added, removed []string) {
+	old := make(map[string]bool, len(prev))
+	for _, v := range prev {
+		old[v] = true
+	}
+	cur := make(map[string]bool, len(next))
+	for _, v := range next {
+		cur[v] = true
+		if !old[v] {
+			added = append(added, v)
+		}
+	}
+	for _, v := range prev {
+		if !cur[v] {
+			removed = append(removed, v)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+// diffFormulaWarnings compares warnings by code, token and position.
+func diffFormulaWarnings(prev, next []models.FormulaWarning) (added, resolved []models.FormulaWarning) {
+	id := func(w models.FormulaWarning) string {
+		return w.Code + "|" + w.Token + "|" + strconv.Itoa(w.Start)
+	}
+	old := make(map[string]bool, len(prev))
+	for _, w := range prev {
+		old[id(w)] = true
+	}
+	cur := make(map[string]bool, len(next))
+	for _, w := range next {
+		cur[id(w)] = true
+		if !old[id(w)] {
+			added = append(added, w)
+		}
+	}
+	for _, w := range prev {
+		if !cur[id(w)] {
+			resolved = append(resolved, w)
+		}
+	}
+	return added, resolved
+}
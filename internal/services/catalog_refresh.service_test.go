@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// refreshCatalog serves the metric names of {service="checkout"} on top of
+// fakeLintCatalog's label names.
+type refreshCatalog struct {
+	*fakeLintCatalog
+	names []string
+}
+
+func (f *refreshCatalog) GetLabelValues(ctx context.Context, req *models.LabelValuesRequest) ([]string, error) {
+	if req.Label == "__name__" && len(req.Match) == 1 && req.Match[0] == `{service="checkout"}` {
+		return append([]string(nil), f.names...), nil
+	}
+	return f.fakeLintCatalog.GetLabelValues(ctx, req)
+}
+
+func TestCatalogRefreshService_Refresh(t *testing.T) {
+	log := logger.New("error")
+	catalog := &refreshCatalog{
+		fakeLintCatalog: &fakeLintCatalog{labels: map[string][]string{"checkout_requests_total": {"service"}}},
+		names:           []string{"checkout_requests_total"},
+	}
+	kpis := newFakeKPIRepo()
+	for _, k := range []*models.KPIDefinition{
+		{ID: "k1", Name: "checkout rate", QueryType: "MetricsQL", Formula: `sum(rate(checkout_requests_total{service="checkout"}[5m]))`},
+		{ID: "k2", Name: "payments rate", QueryType: "MetricsQL", Formula: `sum(rate(payments_total{service="payments"}[5m]))`},
+		{ID: "k3", Name: "checkout latency", QueryType: "MetricsQL", Formula: `rate(checkout_latency_seconds_count[5m])`},
+		{ID: "k4", Name: "checkout up", QueryType: "MetricsQL", Formula: `min(up{service=~"check.*"})`},
+	} {
+		kpis.kpis[k.ID] = k
+	}
+	lint := NewKPIFormulaLintService(catalog, nil, config.KPIFormulaLintConfig{}, log)
+	svc := NewCatalogRefreshService(kpis, catalog, nil, lint, cache.NewNoopValkeyCache(log), config.CatalogConfig{}, log)
+	ctx := context.Background()
+
+	first, err := svc.Refresh(ctx, models.CatalogRefreshRequest{Service: "checkout"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"checkout_requests_total"}, first.MetricsAdded)
+	require.Len(t, first.KPIs, 2)
+	assert.Equal(t, "k1", first.KPIs[0].ID)
+	assert.False(t, first.KPIs[0].Changed)
+	assert.Equal(t, "k4", first.KPIs[1].ID)
+	require.Len(t, first.KPIs[1].Added, 1)
+	assert.Equal(t, models.FormulaWarnUnknownMetric, first.KPIs[1].Added[0].Code)
+	assert.Equal(t, 1, first.KPIsChanged)
+
+	// The deploy brings new metrics: the latency KPI now references the
+	// target and the unknown metric warning on "up" is resolved.
+	catalog.names = []string{"checkout_requests_total", "checkout_latency_seconds_count", "up"}
+	catalog.labels["checkout_latency_seconds_count"] = []string{"service"}
+	catalog.labels["up"] = []string{"service"}
+	second, err := svc.Refresh(ctx, models.CatalogRefreshRequest{Service: "checkout"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"checkout_latency_seconds_count", "up"}, second.MetricsAdded)
+	assert.Empty(t, second.MetricsRemoved)
+	require.Len(t, second.KPIs, 3)
+	assert.Equal(t, []string{"k1", "k3", "k4"}, []string{second.KPIs[0].ID, second.KPIs[1].ID, second.KPIs[2].ID})
+	assert.Empty(t, second.KPIs[2].Warnings)
+	require.Len(t, second.KPIs[2].Resolved, 1)
+	assert.True(t, second.KPIs[2].Changed)
+	assert.Equal(t, 1, second.KPIsChanged)
+}
+
+func TestCatalogRefreshService_RequestAndSecret(t *testing.T) {
+	log := logger.New("error")
+	svc := NewCatalogRefreshService(newFakeKPIRepo(), &fakeLintCatalog{}, nil, nil, cache.NewNoopValkeyCache(log), config.CatalogConfig{}, log)
+	_, err := svc.Refresh(context.Background(), models.CatalogRefreshRequest{Service: " "})
+	assert.True(t, errors.Is(err, ErrInvalidCatalogRefresh))
+	assert.False(t, svc.VerifyWebhookSecret(""), "refused without a configured secret")
+
+	svc = NewCatalogRefreshService(nil, nil, nil, nil, cache.NewNoopValkeyCache(log), config.CatalogConfig{RefreshWebhookSecret: "s3cret"}, log)
+	assert.True(t, svc.VerifyWebhookSecret("s3cret"))
+	assert.False(t, svc.VerifyWebhookSecret("wrong"))
+	_, err = svc.Refresh(context.Background(), models.CatalogRefreshRequest{Namespace: "shop"})
+	assert.True(t, errors.Is(err, ErrFormulaCatalogUnavailable))
+}
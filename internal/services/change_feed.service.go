@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/repo"
+	"github.com/mirastacklabs-ai/mirador-core/internal/utils/pagination"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// Change feed resources.
+const (
+	ChangeFeedKPIs = "kpis"
+)
+
+const (
+	defaultChangeFeedLimit = 500
+	maxChangeFeedLimit     = 1000
+)
+
+var (
+	ErrUnknownChangeFeed = errors.New("unknown change feed resource")
+	ErrChangeFeedExpired = errors.New("resume token is older than the deletion history; resync required")
+)
+
+// ChangeFeedService serves per-resource change feeds for clients that keep
+// an offline copy: every resource updated, and every resource deleted
+// (from tombstones), since a resume token, oldest first. Changes are
+// ordered by updatedAt (deletedAt for deletes) and ID; the token encodes the
+// last change returned.
+type ChangeFeedService struct {
+	kpis   repo.KPIRepo
+	cache  cache.ValkeyCluster
+	logger logging.Logger
+}
+
+// NewChangeFeedService creates a new change feed service.
+func NewChangeFeedService(kpis repo.KPIRepo, cache cache.ValkeyCluster, logger corelogger.Logger) *ChangeFeedService {
+	return &ChangeFeedService{
+		kpis:   kpis,
+		cache:  cache,
+		logger: logging.FromCoreLogger(logger),
+	}
+}
+
+// Changes returns up to limit changes of resource after the since token
+// (empty for everything). Tokens older than the tombstone retention fail
+// with ErrChangeFeedExpired, since deletions before it are no longer known.
+func (s *ChangeFeedService) Changes(ctx context.Context, resource, since string, limit int) (*models.ChangeFeedPage, error) {
+	if resource != ChangeFeedKPIs || s.kpis == nil {
+		return nil, fmt.Errorf("%w: %q (supported: %s)", ErrUnknownChangeFeed, resource, ChangeFeedKPIs)
+	}
+	if limit <= 0 {
+		limit = defaultChangeFeedLimit
+	}
+	limit = min(limit, maxChangeFeedLimit)
+
+	after, err := pagination.Decode(since, resource)
+	if err != nil {
+		return nil, err
+	}
+	if after != "" {
+		ns, err := strconv.ParseInt(strings.SplitN(after, "\x00", 2)[0], 10, 64)
+		if err != nil {
+			return nil, pagination.ErrInvalidCursor
+		}
+		if ns > 0 && time.Since(time.Unix(0, ns)) > repo.KPITombstoneRetention {
+			return nil, ErrChangeFeedExpired
+		}
+	}
+
+	changes, err := s.kpiChanges(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return pagination.OldestFirstKey(changes[i].At, changes[i].ID) < pagination.OldestFirstKey(changes[j].At, changes[j].ID)
+	})
+
+	page := &models.ChangeFeedPage{Resource: resource, Changes: []models.ResourceChange{}, Next: since}
+	last := after
+	for _, c := range changes {
+		key := pagination.OldestFirstKey(c.At, c.ID)
+		if key <= after {
+			continue
+		}
+		if len(page.Changes) == limit {
+			page.HasMore = true
+			break
+		}
+		page.Changes = append(page.Changes, c)
+		last = key
+	}
+	if last != "" {
+		page.Next = pagination.Encode(last, resource)
+	}
+	return page, nil
+}
+
+// kpiChanges lists every KPI as an upsert and every tombstone of a KPI
+// that does not exist any more as a delete.
+func (s *ChangeFeedService) kpiChanges(ctx context.Context) ([]models.ResourceChange, error) {
+	kpis, _, err := s.kpis.ListKPIs(ctx, models.KPIListRequest{Limit: catalogScanLimit})
+	if err != nil {
+		return nil, fmt.Errorf("list KPIs: %w", err)
+	}
+	changes := make([]models.ResourceChange, 0, len(kpis))
+	live := make(map[string]bool, len(kpis))
+	for _, k := range kpis {
+		live[k.ID] = true
+		changes = append(changes, models.ResourceChange{Op: models.ChangeUpsert, ID: k.ID, At: k.UpdatedAt.UTC(), Object: k})
+	}
+	if s.cache == nil {
+		return changes, nil
+	}
+
+	var keys []string
+	if err := s.cache.ScanKeys(ctx, repo.KPITombstoneKeyPrefix, func(batch []string) error {
+		keys = append(keys, batch...)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("list KPI tombstones: %w", err)
+	}
+	for _, key := range keys {
+		data, err := s.cache.Get(ctx, key)
+		if err != nil || len(data) == 0 {
+			continue
+		}
+		var t models.KPITombstone
+		if err := json.Unmarshal(data, &t); err != nil {
+			s.logger.Warn("Skipping unreadable KPI tombstone", "key", key, "error", err)
+			continue
+		}
+		if !live[t.ID] {
+			changes = append(changes, models.ResourceChange{Op: models.ChangeDelete, ID: t.ID, At: t.DeletedAt.UTC()})
+		}
+	}
+	return changes, nil
+}
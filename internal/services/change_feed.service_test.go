@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/repo"
+	"github.com/mirastacklabs-ai/mirador-core/internal/utils/pagination"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func TestChangeFeedService_KPIs(t *testing.T) {
+	log := logger.New("error")
+	ctx := context.Background()
+	now := time.Now().UTC()
+	kpis := newFakeKPIRepo()
+	c := cache.NewNoopValkeyCache(log)
+	for i, id := range []string{"a", "b", "c"} {
+		kpis.kpis[id] = &models.KPIDefinition{ID: id, Name: id, UpdatedAt: now.Add(time.Duration(i-10) * time.Minute)}
+	}
+	// "gone" was deleted; the tombstone of "a" predates its re-creation.
+	_ = c.Set(ctx, repo.KPITombstoneKeyPrefix+"gone", models.KPITombstone{ID: "gone", DeletedAt: now.Add(-5 * time.Minute)}, 0)
+	_ = c.Set(ctx, repo.KPITombstoneKeyPrefix+"a", models.KPITombstone{ID: "a", DeletedAt: now.Add(-20 * time.Minute)}, 0)
+	svc := NewChangeFeedService(kpis, c, log)
+
+	page, err := svc.Changes(ctx, ChangeFeedKPIs, "", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Changes) != 2 || !page.HasMore || page.Changes[0].ID != "a" || page.Changes[1].ID != "b" {
+		t.Fatalf("unexpected first page %+v", page)
+	}
+	page, err = svc.Changes(ctx, ChangeFeedKPIs, page.Next, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Changes) != 2 || page.HasMore || page.Changes[0].ID != "c" ||
+		page.Changes[1].ID != "gone" || page.Changes[1].Op != models.ChangeDelete {
+		t.Fatalf("unexpected second page %+v", page)
+	}
+
+	// Polling from the last token returns only what changed since.
+	next := page.Next
+	kpis.kpis["b"].UpdatedAt = now
+	page, err = svc.Changes(ctx, ChangeFeedKPIs, next, 0)
+	if err != nil || len(page.Changes) != 1 || page.Changes[0].ID != "b" || page.Changes[0].Op != models.ChangeUpsert {
+		t.Fatalf("unexpected delta %+v, %v", page, err)
+	}
+	if page, err = svc.Changes(ctx, ChangeFeedKPIs, page.Next, 0); err != nil || len(page.Changes) != 0 || page.Next == "" {
+		t.Fatalf("expected an empty page that keeps the token, got %+v, %v", page, err)
+	}
+
+	stale := pagination.Encode(pagination.OldestFirstKey(now.Add(-repo.KPITombstoneRetention-time.Hour), "a"), ChangeFeedKPIs)
+	if _, err := svc.Changes(ctx, ChangeFeedKPIs, stale, 0); !errors.Is(err, ErrChangeFeedExpired) {
+		t.Fatalf("expected ErrChangeFeedExpired, got %v", err)
+	}
+	if _, err := svc.Changes(ctx, "dashboards", "", 0); !errors.Is(err, ErrUnknownChangeFeed) {
+		t.Fatalf("expected ErrUnknownChangeFeed, got %v", err)
+	}
+	if _, err := svc.Changes(ctx, ChangeFeedKPIs, "not-a-token", 0); !errors.Is(err, pagination.ErrInvalidCursor) {
+		t.Fatalf("expected ErrInvalidCursor, got %v", err)
+	}
+}
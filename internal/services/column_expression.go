@@ -0,0 +1,616 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	maxColumnExpressionLength = 1024
+	maxColumnExpressionNodes  = 256
+)
+
+// ColumnExpression is a compiled computed-column expression. Expressions use
+// Go expression syntax restricted to literals, field references, arithmetic,
+// comparisons, boolean operators and the functions in columnFunctions;
+// nothing else parses, so an expression cannot loop, allocate without bound
+// or reach anything but the row it is evaluated on.
+//
+// Bare identifiers refer to row fields; fields whose names are not
+// identifiers (host.name, _msg) are read with field("host.name"). Values are
+// strings, numbers, booleans or null. Arithmetic and ordering convert
+// numeric strings to numbers, so fields returned as strings by VictoriaLogs
+// can be used directly. Operations on null or on values that do not convert
+// yield null instead of failing the query.
+type ColumnExpression struct {
+	source string
+	eval   columnEval
+}
+
+type columnEval func(row map[string]any) any
+
+// columnFunction describes a whitelisted function. Arguments listed in
+// literal must be literals; compile validates and pre-processes them.
+type columnFunction struct {
+	minArgs, maxArgs int // maxArgs < 0: variadic
+	literal          []int
+	compile          func(args []columnEval, lits []any) (columnEval, error)
+}
+
+var columnFunctions map[string]columnFunction
+
+func init() {
+	str1 := func(fn func(string) any) func([]columnEval, []any) (columnEval, error) {
+		return func(args []columnEval, _ []any) (columnEval, error) {
+			return func(row map[string]any) any {
+				s, ok := columnString(args[0](row))
+				if !ok {
+					return nil
+				}
+				return fn(s)
+			}, nil
+		}
+	}
+	num1 := func(fn func(float64) float64) func([]columnEval, []any) (columnEval, error) {
+		return func(args []columnEval, _ []any) (columnEval, error) {
+			return func(row map[string]any) any {
+				n, ok := columnNumber(args[0](row))
+				if !ok {
+					return nil
+				}
+				return fn(n)
+			}, nil
+		}
+	}
+	str2 := func(fn func(a, b string) any) func([]columnEval, []any) (columnEval, error) {
+		return func(args []columnEval, _ []any) (columnEval, error) {
+			return func(row map[string]any) any {
+				a, ok1 := columnString(args[0](row))
+				b, ok2 := columnString(args[1](row))
+				if !ok1 || !ok2 {
+					return nil
+				}
+				return fn(a, b)
+			}, nil
+		}
+	}
+	extreme := func(less bool) func([]columnEval, []any) (columnEval, error) {
+		return func(args []columnEval, _ []any) (columnEval, error) {
+			return func(row map[string]any) any {
+				var best *float64
+				for _, a := range args {
+					n, ok := columnNumber(a(row))
+					if !ok {
+						return nil
+					}
+					if best == nil || (n < *best) == less {
+						best = &n
+					}
+				}
+				return *best
+			}, nil
+		}
+	}
+
+	columnFunctions = map[string]columnFunction{
+		"field": {minArgs: 1, maxArgs: 1, literal: []int{0}, compile: func(_ []columnEval, lits []any) (columnEval, error) {
+			name, ok := lits[0].(string)
+			if !ok {
+				return nil, errors.New("field name must be a string")
+			}
+			return func(row map[string]any) any { return columnValue(row[name]) }, nil
+		}},
+		"lower":       {minArgs: 1, maxArgs: 1, compile: str1(func(s string) any { return strings.ToLower(s) })},
+		"upper":       {minArgs: 1, maxArgs: 1, compile: str1(func(s string) any { return strings.ToUpper(s) })},
+		"trim":        {minArgs: 1, maxArgs: 1, compile: str1(func(s string) any { return strings.TrimSpace(s) })},
+		"len":         {minArgs: 1, maxArgs: 1, compile: str1(func(s string) any { return float64(len([]rune(s))) })},
+		"contains":    {minArgs: 2, maxArgs: 2, compile: str2(func(a, b string) any { return strings.Contains(a, b) })},
+		"starts_with": {minArgs: 2, maxArgs: 2, compile: str2(func(a, b string) any { return strings.HasPrefix(a, b) })},
+		"ends_with":   {minArgs: 2, maxArgs: 2, compile: str2(func(a, b string) any { return strings.HasSuffix(a, b) })},
+		"concat": {minArgs: 1, maxArgs: -1, compile: func(args []columnEval, _ []any) (columnEval, error) {
+			return func(row map[string]any) any {
+				var b strings.Builder
+				for _, a := range args {
+					if s, ok := columnString(a(row)); ok {
+						b.WriteString(s)
+					}
+				}
+				return b.String()
+			}, nil
+		}},
+		"replace": {minArgs: 3, maxArgs: 3, compile: func(args []columnEval, _ []any) (columnEval, error) {
+			return func(row map[string]any) any {
+				s, ok1 := columnString(args[0](row))
+				old, ok2 := columnString(args[1](row))
+				repl, ok3 := columnString(args[2](row))
+				if !ok1 || !ok2 || !ok3 || old == "" {
+					return nil
+				}
+				return strings.ReplaceAll(s, old, repl)
+			}, nil
+		}},
+		"substr": {minArgs: 2, maxArgs: 3, compile: func(args []columnEval, _ []any) (columnEval, error) {
+			return func(row map[string]any) any {
+				s, ok1 := columnString(args[0](row))
+				start, ok2 := columnNumber(args[1](row))
+				if !ok1 || !ok2 {
+					return nil
+				}
+				r := []rune(s)
+				from := min(max(int(start), 0), len(r))
+				to := len(r)
+				if len(args) == 3 {
+					n, ok := columnNumber(args[2](row))
+					if !ok {
+						return nil
+					}
+					to = min(from+max(int(n), 0), len(r))
+				}
+				return string(r[from:to])
+			}, nil
+		}},
+		"split_part": {minArgs: 3, maxArgs: 3, compile: func(args []columnEval, _ []any) (columnEval, error) {
+			return func(row map[string]any) any {
+				s, ok1 := columnString(args[0](row))
+				sep, ok2 := columnString(args[1](row))
+				n, ok3 := columnNumber(args[2](row))
+				if !ok1 || !ok2 || !ok3 || sep == "" {
+					return nil
+				}
+				parts := strings.Split(s, sep)
+				i := int(n)
+				if i < 0 {
+					i = len(parts) + i + 1
+				}
+				if i < 1 || i > len(parts) {
+					return nil
+				}
+				return parts[i-1]
+			}, nil
+		}},
+		"regex_match": {minArgs: 2, maxArgs: 2, literal: []int{1}, compile: func(args []columnEval, lits []any) (columnEval, error) {
+			re, err := columnRegexp(lits[1])
+			if err != nil {
+				return nil, err
+			}
+			return func(row map[string]any) any {
+				s, ok := columnString(args[0](row))
+				return ok && re.MatchString(s)
+			}, nil
+		}},
+		"regex_extract": {minArgs: 2, maxArgs: 3, literal: []int{1, 2}, compile: func(args []columnEval, lits []any) (columnEval, error) {
+			re, err := columnRegexp(lits[1])
+			if err != nil {
+				return nil, err
+			}
+			group := min(1, re.NumSubexp())
+			if len(lits) == 3 {
+				n, ok := lits[2].(float64)
+				if !ok || n != math.Trunc(n) || n < 0 || int(n) > re.NumSubexp() {
+					return nil, fmt.Errorf("group must be between 0 and %d", re.NumSubexp())
+				}
+				group = int(n)
+			}
+			return func(row map[string]any) any {
+				s, ok := columnString(args[0](row))
+				if !ok {
+					return nil
+				}
+				m := re.FindStringSubmatch(s)
+				if m == nil {
+					return nil
+				}
+				return m[group]
+			}, nil
+		}},
+		"coalesce": {minArgs: 1, maxArgs: -1, compile: func(args []columnEval, _ []any) (columnEval, error) {
+			return func(row map[string]any) any {
+				for _, a := range args {
+					if v := a(row); v != nil && v != "" {
+						return v
+					}
+				}
+				return nil
+			}, nil
+		}},
+		"when": {minArgs: 3, maxArgs: 3, compile: func(args []columnEval, _ []any) (columnEval, error) {
+			return func(row map[string]any) any {
+				if columnTruthy(args[0](row)) {
+					return args[1](row)
+				}
+				return args[2](row)
+			}, nil
+		}},
+		"number": {minArgs: 1, maxArgs: 1, compile: func(args []columnEval, _ []any) (columnEval, error) {
+			return func(row map[string]any) any {
+				if n, ok := columnNumber(args[0](row)); ok {
+					return n
+				}
+				return nil
+			}, nil
+		}},
+		"string": {minArgs: 1, maxArgs: 1, compile: func(args []columnEval, _ []any) (columnEval, error) {
+			return func(row map[string]any) any {
+				if s, ok := columnString(args[0](row)); ok {
+					return s
+				}
+				return nil
+			}, nil
+		}},
+		"abs":   {minArgs: 1, maxArgs: 1, compile: num1(math.Abs)},
+		"floor": {minArgs: 1, maxArgs: 1, compile: num1(math.Floor)},
+		"ceil":  {minArgs: 1, maxArgs: 1, compile: num1(math.Ceil)},
+		"round": {minArgs: 1, maxArgs: 2, literal: []int{1}, compile: func(args []columnEval, lits []any) (columnEval, error) {
+			scale := 1.0
+			if len(lits) == 2 {
+				d, ok := lits[1].(float64)
+				if !ok || d != math.Trunc(d) || d < 0 || d > 15 {
+					return nil, errors.New("digits must be an integer between 0 and 15")
+				}
+				scale = math.Pow(10, d)
+			}
+			return func(row map[string]any) any {
+				n, ok := columnNumber(args[0](row))
+				if !ok {
+					return nil
+				}
+				return math.Round(n*scale) / scale
+			}, nil
+		}},
+		"min": {minArgs: 1, maxArgs: -1, compile: extreme(true)},
+		"max": {minArgs: 1, maxArgs: -1, compile: extreme(false)},
+		"bucket": {minArgs: 2, maxArgs: -1, literal: []int{-1}, compile: func(args []columnEval, lits []any) (columnEval, error) {
+			bounds := make([]float64, 0, len(lits)-1)
+			for _, l := range lits[1:] {
+				b, ok := l.(float64)
+				if !ok || (len(bounds) > 0 && b <= bounds[len(bounds)-1]) {
+					return nil, errors.New("bounds must be ascending numbers")
+				}
+				bounds = append(bounds, b)
+			}
+			format := func(f float64) string { return strconv.FormatFloat(f, 'g', -1, 64) }
+			return func(row map[string]any) any {
+				n, ok := columnNumber(args[0](row))
+				if !ok {
+					return nil
+				}
+				i, _ := slices.BinarySearch(bounds, n)
+				if i < len(bounds) && bounds[i] == n {
+					i++
+				}
+				switch {
+				case i == 0:
+					return "<" + format(bounds[0])
+				case i == len(bounds):
+					return ">=" + format(bounds[i-1])
+				}
+				return format(bounds[i-1]) + "-" + format(bounds[i])
+			}, nil
+		}},
+	}
+}
+
+// CompileColumnExpression parses and checks a computed-column expression.
+func CompileColumnExpression(source string) (*ColumnExpression, error) {
+	if strings.TrimSpace(source) == "" {
+		return nil, errors.New("expression is required")
+	}
+	if len(source) > maxColumnExpressionLength {
+		return nil, fmt.Errorf("expression is longer than %d characters", maxColumnExpressionLength)
+	}
+	node, err := parser.ParseExpr(source)
+	if err != nil {
+		return nil, fmt.Errorf("syntax error: %v", err)
+	}
+	nodes := 0
+	ast.Inspect(node, func(n ast.Node) bool {
+		if n != nil {
+			nodes++
+		}
+		return true
+	})
+	if nodes > maxColumnExpressionNodes {
+		return nil, fmt.Errorf("expression has more than %d terms", maxColumnExpressionNodes)
+	}
+	eval, err := compileColumnNode(node)
+	if err != nil {
+		return nil, err
+	}
+	return &ColumnExpression{source: source, eval: eval}, nil
+}
+
+// Eval evaluates the expression against row.
+func (e *ColumnExpression) Eval(row map[string]any) any {
+	return e.eval(row)
+}
+
+func (e *ColumnExpression) String() string { return e.source }
+
+func compileColumnNode(node ast.Expr) (columnEval, error) {
+	switch n := node.(type) {
+	case *ast.ParenExpr:
+		return compileColumnNode(n.X)
+
+	case *ast.BasicLit, *ast.Ident:
+		if v, ok, err := columnLiteral(n); ok || err != nil {
+			return func(map[string]any) any { return v }, err
+		}
+		name := n.(*ast.Ident).Name
+		return func(row map[string]any) any { return columnValue(row[name]) }, nil
+
+	case *ast.UnaryExpr:
+		x, err := compileColumnNode(n.X)
+		if err != nil {
+			return nil, err
+		}
+		switch n.Op {
+		case token.NOT:
+			return func(row map[string]any) any { return !columnTruthy(x(row)) }, nil
+		case token.SUB, token.ADD:
+			neg := n.Op == token.SUB
+			return func(row map[string]any) any {
+				v, ok := columnNumber(x(row))
+				if !ok {
+					return nil
+				}
+				if neg {
+					return -v
+				}
+				return v
+			}, nil
+		}
+		return nil, fmt.Errorf("operator %s is not supported", n.Op)
+
+	case *ast.BinaryExpr:
+		x, err := compileColumnNode(n.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := compileColumnNode(n.Y)
+		if err != nil {
+			return nil, err
+		}
+		return compileColumnBinary(n.Op, x, y)
+
+	case *ast.CallExpr:
+		ident, ok := n.Fun.(*ast.Ident)
+		if !ok {
+			return nil, errors.New("only named functions can be called")
+		}
+		fn, ok := columnFunctions[ident.Name]
+		if !ok {
+			return nil, fmt.Errorf("unknown function %s", ident.Name)
+		}
+		if n.Ellipsis.IsValid() {
+			return nil, errors.New("... is not supported")
+		}
+		if len(n.Args) < fn.minArgs || (fn.maxArgs >= 0 && len(n.Args) > fn.maxArgs) {
+			return nil, fmt.Errorf("%s: wrong number of arguments", ident.Name)
+		}
+		args := make([]columnEval, len(n.Args))
+		lits := make([]any, len(n.Args))
+		for i, a := range n.Args {
+			if slices.Contains(fn.literal, i) || (slices.Contains(fn.literal, -1) && i > 0) {
+				v, ok, err := columnLiteral(a)
+				if err != nil {
+					return nil, err
+				}
+				if !ok {
+					return nil, fmt.Errorf("%s: argument %d must be a literal", ident.Name, i+1)
+				}
+				lits[i] = v
+				continue
+			}
+			eval, err := compileColumnNode(a)
+			if err != nil {
+				return nil, err
+			}
+			args[i] = eval
+		}
+		eval, err := fn.compile(args, lits)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", ident.Name, err)
+		}
+		return eval, nil
+	}
+	return nil, fmt.Errorf("unsupported syntax %T", node)
+}
+
+func compileColumnBinary(op token.Token, x, y columnEval) (columnEval, error) {
+	switch op {
+	case token.LAND:
+		return func(row map[string]any) any { return columnTruthy(x(row)) && columnTruthy(y(row)) }, nil
+	case token.LOR:
+		return func(row map[string]any) any { return columnTruthy(x(row)) || columnTruthy(y(row)) }, nil
+	case token.EQL, token.NEQ:
+		eq := op == token.EQL
+		return func(row map[string]any) any {
+			c, ok := columnCompare(x(row), y(row))
+			if !ok {
+				return !eq // null against a value
+			}
+			return (c == 0) == eq
+		}, nil
+	case token.LSS, token.LEQ, token.GTR, token.GEQ:
+		return func(row map[string]any) any {
+			c, ok := columnCompare(x(row), y(row))
+			if !ok {
+				return nil
+			}
+			switch op {
+			case token.LSS:
+				return c < 0
+			case token.LEQ:
+				return c <= 0
+			case token.GTR:
+				return c > 0
+			}
+			return c >= 0
+		}, nil
+	case token.ADD, token.SUB, token.MUL, token.QUO, token.REM:
+		return func(row map[string]any) any {
+			a, ok1 := columnNumber(x(row))
+			b, ok2 := columnNumber(y(row))
+			if !ok1 || !ok2 {
+				return nil
+			}
+			switch op {
+			case token.ADD:
+				return a + b
+			case token.SUB:
+				return a - b
+			case token.MUL:
+				return a * b
+			}
+			if b == 0 {
+				return nil
+			}
+			if op == token.REM {
+				return math.Mod(a, b)
+			}
+			return a / b
+		}, nil
+	}
+	return nil, fmt.Errorf("operator %s is not supported", op)
+}
+
+// columnLiteral returns the value of a literal node. ok is false for field
+// references and other non-literal nodes.
+func columnLiteral(node ast.Expr) (v any, ok bool, err error) {
+	switch n := node.(type) {
+	case *ast.ParenExpr:
+		return columnLiteral(n.X)
+	case *ast.Ident:
+		switch n.Name {
+		case "true":
+			return true, true, nil
+		case "false":
+			return false, true, nil
+		case "null":
+			return nil, true, nil
+		}
+		return nil, false, nil
+	case *ast.UnaryExpr:
+		if n.Op == token.SUB {
+			if v, ok, err := columnLiteral(n.X); ok && err == nil {
+				if f, isNum := v.(float64); isNum {
+					return -f, true, nil
+				}
+			}
+		}
+		return nil, false, nil
+	case *ast.BasicLit:
+		switch n.Kind {
+		case token.INT, token.FLOAT:
+			f, err := strconv.ParseFloat(strings.ReplaceAll(n.Value, "_", ""), 64)
+			if err != nil {
+				return nil, false, fmt.Errorf("invalid number %s", n.Value)
+			}
+			return f, true, nil
+		case token.STRING:
+			s, err := strconv.Unquote(n.Value)
+			if err != nil {
+				return nil, false, fmt.Errorf("invalid string %s", n.Value)
+			}
+			return s, true, nil
+		}
+		return nil, false, fmt.Errorf("literal %s is not supported", n.Value)
+	}
+	return nil, false, nil
+}
+
+func columnRegexp(lit any) (*regexp.Regexp, error) {
+	s, ok := lit.(string)
+	if !ok {
+		return nil, errors.New("pattern must be a string")
+	}
+	re, err := regexp.Compile(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern: %v", err)
+	}
+	return re, nil
+}
+
+// columnValue normalizes a row value to the expression types.
+func columnValue(v any) any {
+	switch x := v.(type) {
+	case nil, string, bool, float64:
+		return x
+	case int:
+		return float64(x)
+	case int64:
+		return float64(x)
+	}
+	return fmt.Sprint(v)
+}
+
+func columnNumber(v any) (float64, bool) {
+	switch x := v.(type) {
+	case float64:
+		return x, !math.IsNaN(x)
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(x), 64)
+		return f, err == nil && !math.IsNaN(f)
+	}
+	return 0, false
+}
+
+func columnString(v any) (string, bool) {
+	switch x := v.(type) {
+	case string:
+		return x, true
+	case float64:
+		return strconv.FormatFloat(x, 'g', -1, 64), true
+	case bool:
+		return strconv.FormatBool(x), true
+	}
+	return "", false
+}
+
+func columnTruthy(v any) bool {
+	switch x := v.(type) {
+	case bool:
+		return x
+	case string:
+		return x != ""
+	case float64:
+		return x != 0
+	}
+	return false
+}
+
+// columnCompare orders a and b numerically when both are numbers (or
+// numeric strings) and as strings otherwise. null only equals null.
+func columnCompare(a, b any) (int, bool) {
+	if a == nil || b == nil {
+		if a == nil && b == nil {
+			return 0, true
+		}
+		return 0, false
+	}
+	if x, ok := columnNumber(a); ok {
+		if y, ok := columnNumber(b); ok {
+			switch {
+			case x < y:
+				return -1, true
+			case x > y:
+				return 1, true
+			}
+			return 0, true
+		}
+	}
+	x, ok1 := columnString(a)
+	y, ok2 := columnString(b)
+	if !ok1 || !ok2 {
+		return 0, false
+	}
+	return strings.Compare(x, y), true
+}
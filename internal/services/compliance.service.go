@@ -0,0 +1,585 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// minServiceTokenLength is the shortest bearer token or webhook secret not
+// reported as weak (32 characters, about 190 bits when random).
+const minServiceTokenLength = 32
+
+var ErrInvalidComplianceFramework = errors.New("unknown compliance framework")
+
+// ComplianceCheck evaluates one further control for the compliance report.
+type ComplianceCheck func(ctx context.Context) models.ComplianceControl
+
+// StorageEncryptionCheck reports whether a storage backend encrypts its data
+// at rest, with evidence such as the volume or KMS key. An error leaves the
+// control unverified.
+type StorageEncryptionCheck func(ctx context.Context) (encrypted bool, evidence string, err error)
+
+// RetentionPolicyLister lists retention policies (implemented by
+// RetentionService).
+type RetentionPolicyLister interface {
+	ListPolicies(ctx context.Context) ([]models.RetentionPolicy, error)
+}
+
+// ComplianceService reports the status of security controls mapped to SOC2
+// and ISO 27001, derived from the running configuration each time a report
+// is requested. Encryption at rest of the storage backends cannot be seen
+// from here: backends report it through a registered
+// StorageEncryptionCheck, or the control stays manual until an operator
+// attests it under compliance.attestations.
+type ComplianceService struct {
+	cfg       *config.Config
+	retention RetentionPolicyLister
+	logger    logging.Logger
+
+	mu         sync.RWMutex
+	secretRefs config.SecretRefs
+	checks     []ComplianceCheck
+	storage    map[string]StorageEncryptionCheck
+}
+
+// NewComplianceService creates a new compliance report service. A nil
+// retention lister reports the retention control as not applicable.
+func NewComplianceService(cfg *config.Config, retention RetentionPolicyLister, logger corelogger.Logger) *ComplianceService {
+	return &ComplianceService{
+		cfg:       cfg,
+		retention: retention,
+		logger:    logging.FromCoreLogger(logger),
+		storage:   map[string]StorageEncryptionCheck{},
+	}
+}
+
+// SetSecretRefs records which secret fields were resolved from external
+// providers at startup.
+func (s *ComplianceService) SetSecretRefs(refs config.SecretRefs) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.secretRefs = refs
+}
+
+// Register adds a control evaluated on every report.
+func (s *ComplianceService) Register(check ComplianceCheck) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checks = append(s.checks, check)
+}
+
+// RegisterStorageEncryption sets how the encryption at rest of backend is
+// verified, replacing the manual control reported for it by default.
+func (s *ComplianceService) RegisterStorageEncryption(backend string, check StorageEncryptionCheck) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.storage[backend] = check
+}
+
+// Report evaluates every control. A non-empty framework (SOC2 or ISO27001)
+// keeps only the controls mapped to it.
+func (s *ComplianceService) Report(ctx context.Context, framework string) (*models.ComplianceReport, error) {
+	switch {
+	case framework == "":
+	case strings.EqualFold(framework, models.ComplianceFrameworkSOC2):
+		framework = models.ComplianceFrameworkSOC2
+	case strings.EqualFold(framework, models.ComplianceFrameworkISO):
+		framework = models.ComplianceFrameworkISO
+	default:
+		return nil, fmt.Errorf("%w: %q (want %s or %s)", ErrInvalidComplianceFramework, framework, models.ComplianceFrameworkSOC2, models.ComplianceFrameworkISO)
+	}
+
+	s.mu.RLock()
+	checks := slices.Clone(s.checks)
+	s.mu.RUnlock()
+
+	controls := []models.ComplianceControl{s.backendTLS(), s.datastoreTLS(), s.cacheEncryption()}
+	controls = append(controls, s.storageEncryption(ctx)...)
+	controls = append(controls,
+		s.secretStorage(),
+		s.retentionEnforcement(ctx),
+		s.passwordPolicy(),
+		s.mfa(),
+		s.serviceTokens(),
+		s.corsOrigins(),
+		s.tenantIsolation(),
+	)
+	for _, check := range checks {
+		controls = append(controls, check(ctx))
+	}
+
+	attestations := map[string]string{}
+	for _, a := range s.cfg.Compliance.Attestations {
+		attestations[strings.TrimSpace(a.Control)] = a.Evidence
+	}
+	report := &models.ComplianceReport{
+		GeneratedAt: time.Now().UTC(),
+		Environment: s.cfg.Environment,
+		Framework:   framework,
+		Summary:     map[string]int{},
+		Controls:    []models.ComplianceControl{},
+	}
+	for _, c := range controls {
+		if evidence, ok := attestations[c.ID]; ok && c.Status == models.ComplianceStatusManual {
+			c.Status = models.ComplianceStatusPass
+			c.Attested = true
+			c.Evidence = append(c.Evidence, "Attested: "+evidence)
+			c.Remediation = ""
+		}
+		if framework != "" && !slices.ContainsFunc(c.Frameworks, func(m models.ComplianceMapping) bool { return m.Framework == framework }) {
+			continue
+		}
+		report.Summary[c.Status]++
+		report.Controls = append(report.Controls, c)
+	}
+	return report, nil
+}
+
+// complianceFrameworks maps a control to SOC2 trust services criteria and
+// ISO 27001:2022 Annex A controls.
+func complianceFrameworks(soc2, iso []string) []models.ComplianceMapping {
+	var out []models.ComplianceMapping
+	for _, c := range soc2 {
+		out = append(out, models.ComplianceMapping{Framework: models.ComplianceFrameworkSOC2, Control: c})
+	}
+	for _, c := range iso {
+		out = append(out, models.ComplianceMapping{Framework: models.ComplianceFrameworkISO, Control: c})
+	}
+	return out
+}
+
+// backendEndpoints is one configured metrics, logs or traces cluster.
+type backendEndpoints struct {
+	signal    string
+	field     string
+	endpoints []string
+	discovery config.K8sDiscoveryConfig
+}
+
+func (s *ComplianceService) backendEndpoints() []backendEndpoints {
+	db := s.cfg.Database
+	out := []backendEndpoints{
+		{"victoria_metrics", "database.victoria_metrics", db.VictoriaMetrics.Endpoints, db.VictoriaMetrics.Discovery},
+		{"victoria_logs", "database.victoria_logs", db.VictoriaLogs.Endpoints, db.VictoriaLogs.Discovery},
+		{"victoria_traces", "database.victoria_traces", db.VictoriaTraces.Endpoints, db.VictoriaTraces.Discovery},
+	}
+	for i, src := range db.MetricsSources {
+		out = append(out, backendEndpoints{"victoria_metrics", fmt.Sprintf("database.metrics_sources[%d]", i), src.Endpoints, src.Discovery})
+	}
+	for i, src := range db.LogsSources {
+		out = append(out, backendEndpoints{"victoria_logs", fmt.Sprintf("database.logs_sources[%d]", i), src.Endpoints, src.Discovery})
+	}
+	for i, src := range db.TracesSources {
+		out = append(out, backendEndpoints{"victoria_traces", fmt.Sprintf("database.traces_sources[%d]", i), src.Endpoints, src.Discovery})
+	}
+	return out
+}
+
+// transportEncrypted reports whether connecting to raw is encrypted.
+// PostgreSQL URLs must require TLS: pgx otherwise falls back to plaintext.
+func transportEncrypted(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "https":
+		return true
+	case "postgres", "postgresql":
+		switch u.Query().Get("sslmode") {
+		case "require", "verify-ca", "verify-full":
+			return true
+		}
+	}
+	return false
+}
+
+func (s *ComplianceService) backendTLS() models.ComplianceControl {
+	c := models.ComplianceControl{
+		ID:         "transport.backend-tls",
+		Title:      "Backend connections are encrypted in transit",
+		Frameworks: complianceFrameworks([]string{"CC6.7"}, []string{"A.8.24", "A.5.14"}),
+		Evidence:   []string{},
+	}
+	secure := 0
+	check := func(field, raw string) {
+		if transportEncrypted(raw) {
+			secure++
+		} else {
+			c.Evidence = append(c.Evidence, field+" is not encrypted")
+		}
+	}
+	for _, b := range s.backendEndpoints() {
+		for i, e := range b.endpoints {
+			check(fmt.Sprintf("%s.endpoints[%d]", b.field, i), e)
+		}
+		if b.discovery.Enabled {
+			check(b.field+".discovery.scheme", b.discovery.Scheme+"://")
+		}
+	}
+	if s.cfg.Weaviate.Enabled {
+		check("weaviate.scheme", s.cfg.Weaviate.Scheme+"://")
+	}
+	for i, d := range s.cfg.KPIDatastores {
+		check(fmt.Sprintf("kpi_datastores[%d].url", i), d.URL)
+	}
+	if s.cfg.Integrations.Ticketing.Provider != "" {
+		check("integrations.ticketing.base_url", s.cfg.Integrations.Ticketing.BaseURL)
+	}
+	if s.cfg.Secrets.Vault.Address != "" {
+		check("secrets.vault.address", s.cfg.Secrets.Vault.Address)
+	}
+	if s.cfg.Replication.PrimaryURL != "" {
+		check("replication.primary_url", s.cfg.Replication.PrimaryURL)
+	}
+
+	plain := len(c.Evidence)
+	switch {
+	case plain > 0:
+		c.Status = models.ComplianceStatusFail
+		c.Remediation = "Use https:// endpoints (sslmode=require or stricter for PostgreSQL) for the listed connections"
+	case secure == 0:
+		c.Status = models.ComplianceStatusNotApplicable
+		c.Evidence = append(c.Evidence, "no backend connections configured")
+		return c
+	default:
+		c.Status = models.ComplianceStatusPass
+	}
+	c.Evidence = append(c.Evidence, fmt.Sprintf("%d of %d backend connections use TLS", secure, secure+plain))
+	return c
+}
+
+func (s *ComplianceService) datastoreTLS() models.ComplianceControl {
+	c := models.ComplianceControl{
+		ID:         "transport.datastore-tls",
+		Title:      "Valkey and MariaDB connections are encrypted in transit",
+		Frameworks: complianceFrameworks([]string{"CC6.7"}, []string{"A.8.24", "A.8.20"}),
+		Evidence:   []string{},
+	}
+	if len(s.cfg.Cache.Nodes) > 0 {
+		c.Evidence = append(c.Evidence, "cache.nodes: the Valkey client connects without TLS")
+	}
+	if s.cfg.MariaDB.Enabled {
+		c.Evidence = append(c.Evidence, "mariadb: the MariaDB client connects without TLS")
+	}
+	if len(c.Evidence) == 0 {
+		c.Status = models.ComplianceStatusNotApplicable
+		return c
+	}
+	c.Status = models.ComplianceStatusManual
+	c.Remediation = "Encrypt the network path (e.g. service mesh mTLS) and attest it under compliance.attestations"
+	return c
+}
+
+func (s *ComplianceService) cacheEncryption() models.ComplianceControl {
+	c := models.ComplianceControl{
+		ID:         "encryption.cache-values",
+		Title:      "Sensitive values are encrypted before they are stored in Valkey",
+		Frameworks: complianceFrameworks([]string{"CC6.1"}, []string{"A.8.24"}),
+		Evidence:   []string{},
+	}
+	enc := s.cfg.Cache.Encryption
+	if !enc.Enabled {
+		c.Status = models.ComplianceStatusFail
+		c.Evidence = append(c.Evidence, "cache.encryption.enabled is false")
+		c.Remediation = "Enable cache.encryption with a 32-byte key and the key prefixes holding sensitive values"
+		return c
+	}
+	if _, err := enc.DecodedKeys(); err != nil {
+		c.Status = models.ComplianceStatusFail
+		c.Evidence = append(c.Evidence, "cache.encryption.keys: "+err.Error())
+		c.Remediation = "Configure base64-encoded 32-byte keys"
+		return c
+	}
+	c.Status = models.ComplianceStatusPass
+	c.Evidence = append(c.Evidence,
+		fmt.Sprintf("values under %s are encrypted with key version %d", strings.Join(enc.Prefixes, ", "), enc.ActiveVersion),
+		fmt.Sprintf("%d key versions configured for rotation", len(enc.Keys)),
+	)
+	return c
+}
+
+// storageBackends lists the configured backends holding data at rest.
+func (s *ComplianceService) storageBackends() []string {
+	var out []string
+	seen := map[string]bool{}
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			out = append(out, name)
+		}
+	}
+	for _, b := range s.backendEndpoints() {
+		if len(b.endpoints) > 0 || b.discovery.Enabled {
+			add(b.signal)
+		}
+	}
+	if s.cfg.Weaviate.Enabled {
+		add("weaviate")
+	}
+	if len(s.cfg.Cache.Nodes) > 0 {
+		add("valkey")
+	}
+	if s.cfg.MariaDB.Enabled {
+		add("mariadb")
+	}
+	for _, d := range s.cfg.KPIDatastores {
+		add("kpi_datastore." + d.Name)
+	}
+	s.mu.RLock()
+	registered := make([]string, 0, len(s.storage))
+	for name := range s.storage {
+		registered = append(registered, name)
+	}
+	s.mu.RUnlock()
+	sort.Strings(registered)
+	for _, name := range registered {
+		add(name)
+	}
+	return out
+}
+
+func (s *ComplianceService) storageEncryption(ctx context.Context) []models.ComplianceControl {
+	var out []models.ComplianceControl
+	for _, backend := range s.storageBackends() {
+		c := models.ComplianceControl{
+			ID:         "encryption.storage." + backend,
+			Title:      "Data stored in " + backend + " is encrypted at rest",
+			Frameworks: complianceFrameworks([]string{"CC6.1"}, []string{"A.8.24"}),
+			Evidence:   []string{},
+		}
+		s.mu.RLock()
+		check := s.storage[backend]
+		s.mu.RUnlock()
+		if check == nil {
+			c.Status = models.ComplianceStatusManual
+			c.Evidence = append(c.Evidence, "encryption at rest is not reported by "+backend)
+			c.Remediation = "Attest disk or volume encryption of " + backend + " under compliance.attestations"
+			out = append(out, c)
+			continue
+		}
+		encrypted, evidence, err := check(ctx)
+		switch {
+		case err != nil:
+			s.logger.Warn("Storage encryption check failed", "backend", backend, "error", err)
+			c.Status = models.ComplianceStatusWarning
+			c.Evidence = append(c.Evidence, "verification failed: "+err.Error())
+		case encrypted:
+			c.Status = models.ComplianceStatusPass
+		default:
+			c.Status = models.ComplianceStatusFail
+			c.Remediation = "Enable encryption at rest for " + backend
+		}
+		if evidence != "" {
+			c.Evidence = append(c.Evidence, evidence)
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+func (s *ComplianceService) secretStorage() models.ComplianceControl {
+	c := models.ComplianceControl{
+		ID:         "secrets.external-store",
+		Title:      "Credentials are kept in a secret store, not in configuration",
+		Frameworks: complianceFrameworks([]string{"CC6.1"}, []string{"A.5.17", "A.8.24"}),
+		Evidence:   []string{},
+	}
+	s.mu.RLock()
+	refs := s.secretRefs
+	s.mu.RUnlock()
+	for _, field := range config.PlaintextSecrets(s.cfg, refs) {
+		c.Evidence = append(c.Evidence, field+" is set in plain configuration")
+	}
+	switch {
+	case len(c.Evidence) == 0:
+		c.Status = models.ComplianceStatusPass
+	case s.cfg.IsProduction():
+		c.Status = models.ComplianceStatusFail
+	default:
+		c.Status = models.ComplianceStatusWarning
+	}
+	if len(c.Evidence) > 0 {
+		c.Remediation = "Replace the listed values with vault:, awssm:, k8s: or file: secret references"
+	}
+	c.Evidence = append(c.Evidence, fmt.Sprintf("%d secret fields resolved from external providers", len(refs)))
+	return c
+}
+
+func (s *ComplianceService) retentionEnforcement(ctx context.Context) models.ComplianceControl {
+	c := models.ComplianceControl{
+		ID:         "data.retention",
+		Title:      "Retention policies are enforced and purges are audited",
+		Frameworks: complianceFrameworks([]string{"C1.2"}, []string{"A.8.10", "A.8.15"}),
+		Evidence:   []string{},
+	}
+	if s.retention == nil {
+		c.Status = models.ComplianceStatusNotApplicable
+		return c
+	}
+	policies, err := s.retention.ListPolicies(ctx)
+	if err != nil {
+		c.Status = models.ComplianceStatusWarning
+		c.Evidence = append(c.Evidence, "retention policies could not be read: "+err.Error())
+		return c
+	}
+	enabled := 0
+	for _, p := range policies {
+		if p.Enabled {
+			enabled++
+		}
+	}
+	c.Evidence = append(c.Evidence, fmt.Sprintf("%d of %d retention policies enabled", enabled, len(policies)))
+	if s.cfg.Retention.Interval > 0 {
+		c.Evidence = append(c.Evidence, "enabled policies are purged every "+s.cfg.Retention.Interval.String())
+	} else {
+		c.Evidence = append(c.Evidence, "retention.interval is 0: purges only run on demand")
+	}
+	c.Evidence = append(c.Evidence, fmt.Sprintf("the purge audit trail keeps the last %d purges", maxRetentionAudit))
+	switch {
+	case enabled == 0:
+		c.Status = models.ComplianceStatusWarning
+		c.Remediation = "Create retention policies; until then data is kept for the backends' cluster-wide retention"
+	case s.cfg.Retention.Interval <= 0:
+		c.Status = models.ComplianceStatusWarning
+		c.Remediation = "Set retention.interval so policies are enforced on a schedule"
+	default:
+		c.Status = models.ComplianceStatusPass
+	}
+	return c
+}
+
+// gatewayAuthEvidence explains why user authentication controls are manual.
+const gatewayAuthEvidence = "mirador-core has no local user accounts; users authenticate at the API gateway, which forwards X-User-ID"
+
+func (s *ComplianceService) passwordPolicy() models.ComplianceControl {
+	return models.ComplianceControl{
+		ID:          "access.password-policy",
+		Title:       "User passwords follow the password policy",
+		Status:      models.ComplianceStatusManual,
+		Evidence:    []string{gatewayAuthEvidence},
+		Remediation: "Attest the identity provider's password policy under compliance.attestations",
+		Frameworks:  complianceFrameworks([]string{"CC6.1"}, []string{"A.5.17"}),
+	}
+}
+
+func (s *ComplianceService) mfa() models.ComplianceControl {
+	return models.ComplianceControl{
+		ID:          "access.mfa",
+		Title:       "Multi-factor authentication is enforced for users",
+		Status:      models.ComplianceStatusManual,
+		Evidence:    []string{gatewayAuthEvidence},
+		Remediation: "Attest MFA enforcement at the identity provider under compliance.attestations",
+		Frameworks:  complianceFrameworks([]string{"CC6.1"}, []string{"A.8.5"}),
+	}
+}
+
+func (s *ComplianceService) serviceTokens() models.ComplianceControl {
+	c := models.ComplianceControl{
+		ID:         "access.service-tokens",
+		Title:      "Admin and machine access requires strong tokens",
+		Frameworks: complianceFrameworks([]string{"CC6.1", "CC6.2"}, []string{"A.8.2", "A.5.17"}),
+		Evidence:   []string{},
+	}
+	tokens := map[string]string{
+		"profiling.token":                       s.cfg.Profiling.Token,
+		"integrations.ticketing.webhook_secret": s.cfg.Integrations.Ticketing.WebhookSecret,
+		"catalog.refresh_webhook_secret":        s.cfg.Catalog.RefreshWebhookSecret,
+	}
+	for i, a := range s.cfg.Admin.Tokens {
+		tokens[fmt.Sprintf("admin.tokens[%d].token", i)] = a.Token
+	}
+	for i, a := range s.cfg.Auditors {
+		tokens[fmt.Sprintf("auditors[%d].token", i)] = a.Token
+	}
+	if s.cfg.Callbacks.Enabled {
+		for i, e := range s.cfg.Callbacks.Engines {
+			tokens[fmt.Sprintf("callbacks.engines[%d].token", i)] = e.Token
+		}
+	}
+	fields := make([]string, 0, len(tokens))
+	for field, token := range tokens {
+		if token != "" {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	weak := 0
+	for _, field := range fields {
+		if len(tokens[field]) < minServiceTokenLength {
+			weak++
+			c.Evidence = append(c.Evidence, fmt.Sprintf("%s is shorter than %d characters", field, minServiceTokenLength))
+		}
+	}
+	switch {
+	case weak > 0:
+		c.Status = models.ComplianceStatusWarning
+		c.Remediation = fmt.Sprintf("Use random tokens of at least %d characters", minServiceTokenLength)
+	default:
+		c.Status = models.ComplianceStatusPass
+	}
+	c.Evidence = append(c.Evidence, fmt.Sprintf("%d service tokens configured", len(fields)))
+	return c
+}
+
+func (s *ComplianceService) corsOrigins() models.ComplianceControl {
+	c := models.ComplianceControl{
+		ID:         "access.cors",
+		Title:      "Browsers may only call the API from trusted origins",
+		Frameworks: complianceFrameworks([]string{"CC6.6"}, []string{"A.8.26"}),
+		Evidence:   []string{fmt.Sprintf("%d allowed origins", len(s.cfg.CORS.AllowedOrigins))},
+	}
+	switch {
+	case !slices.Contains(s.cfg.CORS.AllowedOrigins, "*"):
+		c.Status = models.ComplianceStatusPass
+	case s.cfg.CORS.AllowCredentials:
+		c.Status = models.ComplianceStatusFail
+		c.Evidence = append(c.Evidence, "cors.allowed_origins allows any origin with credentials")
+		c.Remediation = "List the UI origins in cors.allowed_origins instead of *"
+	default:
+		c.Status = models.ComplianceStatusWarning
+		c.Evidence = append(c.Evidence, "cors.allowed_origins allows any origin")
+		c.Remediation = "List the UI origins in cors.allowed_origins instead of *"
+	}
+	return c
+}
+
+func (s *ComplianceService) tenantIsolation() models.ComplianceControl {
+	c := models.ComplianceControl{
+		ID:         "isolation.tenant",
+		Title:      "Tenant data is partitioned in shared backends",
+		Frameworks: complianceFrameworks([]string{"CC6.1"}, []string{"A.8.3"}),
+		Evidence:   []string{},
+	}
+	if s.cfg.Cache.TenantID != "" {
+		c.Evidence = append(c.Evidence, "Valkey keys are namespaced by cache.tenant_id")
+	}
+	if len(s.cfg.TenantLabels) > 0 {
+		labels := make([]string, 0, len(s.cfg.TenantLabels))
+		for name := range s.cfg.TenantLabels {
+			labels = append(labels, name)
+		}
+		sort.Strings(labels)
+		c.Evidence = append(c.Evidence, "metrics and logs queries are restricted by tenant_labels "+strings.Join(labels, ", "))
+	}
+	if len(c.Evidence) == 0 {
+		c.Status = models.ComplianceStatusWarning
+		c.Evidence = append(c.Evidence, "neither cache.tenant_id nor tenant_labels is set")
+		c.Remediation = "Set cache.tenant_id and tenant_labels unless Valkey and the Victoria backends are dedicated to this tenant"
+		return c
+	}
+	c.Status = models.ComplianceStatusPass
+	return c
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+type fakeRetentionPolicies []models.RetentionPolicy
+
+func (f fakeRetentionPolicies) ListPolicies(ctx context.Context) ([]models.RetentionPolicy, error) {
+	return f, nil
+}
+
+func complianceControl(t *testing.T, report *models.ComplianceReport, id string) models.ComplianceControl {
+	t.Helper()
+	for _, c := range report.Controls {
+		if c.ID == id {
+			return c
+		}
+	}
+	t.Fatalf("control %s not in report", id)
+	return models.ComplianceControl{}
+}
+
+func TestComplianceService_Report(t *testing.T) {
+	cfg := &config.Config{
+		Environment: "production",
+		Database: config.DatabaseConfig{
+			VictoriaMetrics: config.VictoriaMetricsConfig{Endpoints: []string{"https://vm:8481"}},
+			LogsSources:     []config.VictoriaLogsConfig{{Endpoints: []string{"http://vl:9428"}, Password: "vl-secret-value"}},
+		},
+		KPIDatastores: []config.KPIDatastoreConfig{{Name: "wh", URL: "postgres://wh:5432/kpis?sslmode=verify-full"}},
+		Cache: config.CacheConfig{
+			Nodes:    []string{"valkey:6379"},
+			Password: "vault:mirador/valkey#password-resolved",
+			TenantID: "acme",
+			Encryption: config.CacheEncryptionConfig{
+				Enabled:       true,
+				Keys:          []config.CacheEncryptionKey{{Version: 1, Key: "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="}},
+				ActiveVersion: 1,
+				Prefixes:      []string{"cfg:"},
+			},
+		},
+		Profiling: config.ProfilingConfig{Enabled: true, Token: "short"},
+		CORS:      config.CORSConfig{AllowedOrigins: []string{"*"}, AllowCredentials: true},
+		Retention: config.RetentionConfig{Interval: time.Hour},
+		Compliance: config.ComplianceConfig{Attestations: []config.ComplianceAttestation{
+			{Control: "access.mfa", Evidence: "Okta policy requires WebAuthn"},
+			{Control: "access.cors", Evidence: "ignored: automated checks cannot be attested"},
+		}},
+	}
+	retention := fakeRetentionPolicies{{ID: "a", Enabled: true}, {ID: "b"}}
+	svc := NewComplianceService(cfg, retention, logger.New("error"))
+	svc.SetSecretRefs(config.SecretRefs{"cache.password": "vault:mirador/valkey#password"})
+	svc.RegisterStorageEncryption("victoria_metrics", func(ctx context.Context) (bool, string, error) {
+		return true, "EBS volumes encrypted with KMS key alias/vm", nil
+	})
+	svc.RegisterStorageEncryption("weaviate", func(ctx context.Context) (bool, string, error) {
+		return false, "", errors.New("volume API unreachable")
+	})
+
+	report, err := svc.Report(context.Background(), "")
+	require.NoError(t, err)
+
+	tls := complianceControl(t, report, "transport.backend-tls")
+	assert.Equal(t, models.ComplianceStatusFail, tls.Status)
+	assert.Equal(t, []string{"database.logs_sources[0].endpoints[0] is not encrypted", "2 of 3 backend connections use TLS"}, tls.Evidence)
+
+	assert.Equal(t, models.ComplianceStatusPass, complianceControl(t, report, "encryption.cache-values").Status)
+	assert.Equal(t, models.ComplianceStatusPass, complianceControl(t, report, "encryption.storage.victoria_metrics").Status)
+	assert.Equal(t, models.ComplianceStatusManual, complianceControl(t, report, "encryption.storage.victoria_logs").Status)
+	assert.Equal(t, models.ComplianceStatusWarning, complianceControl(t, report, "encryption.storage.weaviate").Status, "registered backends are reported even when not configured")
+
+	secretsCtl := complianceControl(t, report, "secrets.external-store")
+	assert.Equal(t, models.ComplianceStatusFail, secretsCtl.Status, "plain secrets fail in production")
+	assert.Contains(t, secretsCtl.Evidence, "database.logs_sources[0].password is set in plain configuration")
+	assert.Contains(t, secretsCtl.Evidence, "profiling.token is set in plain configuration")
+	assert.NotContains(t, secretsCtl.Evidence, "cache.password is set in plain configuration")
+
+	assert.Equal(t, models.ComplianceStatusPass, complianceControl(t, report, "data.retention").Status)
+	assert.Equal(t, models.ComplianceStatusWarning, complianceControl(t, report, "access.service-tokens").Status)
+	assert.Equal(t, models.ComplianceStatusFail, complianceControl(t, report, "access.cors").Status)
+	assert.Equal(t, models.ComplianceStatusPass, complianceControl(t, report, "isolation.tenant").Status)
+	assert.Equal(t, models.ComplianceStatusManual, complianceControl(t, report, "access.password-policy").Status)
+
+	mfa := complianceControl(t, report, "access.mfa")
+	assert.Equal(t, models.ComplianceStatusPass, mfa.Status)
+	assert.True(t, mfa.Attested)
+	assert.Contains(t, mfa.Evidence, "Attested: Okta policy requires WebAuthn")
+
+	total := 0
+	for _, n := range report.Summary {
+		total += n
+	}
+	assert.Equal(t, len(report.Controls), total)
+
+	// Evidence names fields, never their values.
+	raw, err := json.Marshal(report)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "vl-secret-value")
+	assert.NotContains(t, string(raw), "AAAAAAAA")
+}
+
+func TestComplianceService_FrameworkFilter(t *testing.T) {
+	svc := NewComplianceService(&config.Config{}, nil, logger.New("error"))
+	svc.Register(func(ctx context.Context) models.ComplianceControl {
+		return models.ComplianceControl{
+			ID:         "custom.soc2-only",
+			Status:     models.ComplianceStatusPass,
+			Frameworks: []models.ComplianceMapping{{Framework: models.ComplianceFrameworkSOC2, Control: "CC8.1"}},
+		}
+	})
+	ctx := context.Background()
+
+	soc2, err := svc.Report(ctx, "soc2")
+	require.NoError(t, err)
+	assert.Equal(t, models.ComplianceFrameworkSOC2, soc2.Framework)
+	complianceControl(t, soc2, "custom.soc2-only")
+
+	iso, err := svc.Report(ctx, "ISO27001")
+	require.NoError(t, err)
+	assert.Len(t, iso.Controls, len(soc2.Controls)-1)
+	assert.Equal(t, models.ComplianceStatusNotApplicable, complianceControl(t, iso, "data.retention").Status)
+
+	_, err = svc.Report(ctx, "pci")
+	assert.ErrorIs(t, err, ErrInvalidComplianceFramework)
+}
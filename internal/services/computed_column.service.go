@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const (
+	// maxComputedColumnsPerQuery bounds the columns one query may request.
+	maxComputedColumnsPerQuery = 20
+	// maxComputedColumnPreviewRows caps the sample rows of a preview.
+	maxComputedColumnPreviewRows = 100
+)
+
+var (
+	ErrInvalidComputedColumn  = errors.New("invalid computed column")
+	ErrComputedColumnNotFound = errors.New("computed column not found")
+)
+
+// Column names must not collide with VictoriaLogs' reserved _msg, _time and
+// _stream fields, so they start with a letter.
+var computedColumnNameRe = regexp.MustCompile(`^[a-z][a-z0-9_]{0,62}$`)
+
+// ComputedColumnService manages the tenant's computed-column definitions
+// and adds requested columns to logs results. Definitions are shared, so a
+// column defined once can be used by any query, template or dashboard.
+// They are stored as the computed_columns dynamic config document, so
+// changes are locked across replicas, versioned and can be rolled back.
+type ComputedColumnService struct {
+	config *DynamicConfigService
+	logger logging.Logger
+}
+
+// NewComputedColumnService creates a new computed column service.
+func NewComputedColumnService(cache cache.ValkeyCluster, logger corelogger.Logger) *ComputedColumnService {
+	return &ComputedColumnService{
+		config: NewDynamicConfigService(cache, logger),
+		logger: logging.FromCoreLogger(logger),
+	}
+}
+
+// List returns the stored columns ordered by name.
+func (s *ComputedColumnService) List(ctx context.Context) ([]models.ComputedColumn, error) {
+	list := []models.ComputedColumn{}
+	if err := s.config.getDocument(ctx, DynamicConfigComputedColumns, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Get returns the column called name.
+func (s *ComputedColumnService) Get(ctx context.Context, name string) (*models.ComputedColumn, error) {
+	list, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range list {
+		if list[i].Name == name {
+			return &list[i], nil
+		}
+	}
+	return nil, ErrComputedColumnNotFound
+}
+
+// Put creates or replaces a column.
+func (s *ComputedColumnService) Put(ctx context.Context, col models.ComputedColumn) (*models.ComputedColumn, error) {
+	var problems []string
+	if !computedColumnNameRe.MatchString(col.Name) {
+		problems = append(problems, fmt.Sprintf("name %q must start with a lowercase letter followed by lowercase letters, digits or '_'", col.Name))
+	}
+	if _, err := CompileColumnExpression(col.Expression); err != nil {
+		problems = append(problems, "expression: "+err.Error())
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidComputedColumn, strings.Join(problems, "; "))
+	}
+
+	col.UpdatedAt = time.Now().UTC()
+	var list []models.ComputedColumn
+	if _, err := s.config.updateDocument(ctx, DynamicConfigComputedColumns, col.UpdatedBy, &list, func() error {
+		list = append(slices.DeleteFunc(list, func(o models.ComputedColumn) bool { return o.Name == col.Name }), col)
+		sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	s.logger.Info("Computed column stored", "name", col.Name)
+	return &col, nil
+}
+
+// Delete removes a column. Queries still naming it fail until updated.
+func (s *ComputedColumnService) Delete(ctx context.Context, name, deletedBy string) error {
+	var list []models.ComputedColumn
+	_, err := s.config.updateDocument(ctx, DynamicConfigComputedColumns, deletedBy, &list, func() error {
+		kept := slices.DeleteFunc(slices.Clone(list), func(o models.ComputedColumn) bool { return o.Name == name })
+		if len(kept) == len(list) {
+			return ErrComputedColumnNotFound
+		}
+		list = kept
+		return nil
+	})
+	return err
+}
+
+// Preview evaluates an expression against sample rows.
+func (s *ComputedColumnService) Preview(req models.ComputedColumnPreviewRequest) (*models.ComputedColumnPreview, error) {
+	expr, err := CompileColumnExpression(req.Expression)
+	if err != nil {
+		return nil, fmt.Errorf("%w: expression: %v", ErrInvalidComputedColumn, err)
+	}
+	if len(req.Rows) > maxComputedColumnPreviewRows {
+		return nil, fmt.Errorf("%w: at most %d rows can be previewed", ErrInvalidComputedColumn, maxComputedColumnPreviewRows)
+	}
+	out := &models.ComputedColumnPreview{Values: make([]any, len(req.Rows))}
+	for i, row := range req.Rows {
+		out.Values[i] = expr.Eval(row)
+	}
+	return out, nil
+}
+
+// Apply adds the named columns to every row, in the order given; a column
+// replaces a row field of the same name. Columns are evaluated on the
+// original row, so they cannot refer to each other.
+func (s *ComputedColumnService) Apply(ctx context.Context, names []string, rows []map[string]any) error {
+	if len(names) == 0 {
+		return nil
+	}
+	if len(names) > maxComputedColumnsPerQuery {
+		return fmt.Errorf("%w: at most %d columns per query", ErrInvalidComputedColumn, maxComputedColumnsPerQuery)
+	}
+	list, err := s.List(ctx)
+	if err != nil {
+		return err
+	}
+	exprs := make([]*ColumnExpression, len(names))
+	for i, name := range names {
+		j := slices.IndexFunc(list, func(o models.ComputedColumn) bool { return o.Name == name })
+		if j < 0 {
+			return fmt.Errorf("%w: %s", ErrComputedColumnNotFound, name)
+		}
+		if exprs[i], err = CompileColumnExpression(list[j].Expression); err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidComputedColumn, name, err)
+		}
+	}
+	values := make([]any, len(names))
+	for _, row := range rows {
+		for i, e := range exprs {
+			values[i] = e.Eval(row)
+		}
+		for i, name := range names {
+			row[name] = values[i]
+		}
+	}
+	return nil
+}
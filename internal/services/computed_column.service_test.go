@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func TestColumnExpression_Eval(t *testing.T) {
+	row := map[string]any{
+		"duration_ms": "312",
+		"host.name":   "web-3.eu-west-1.prod.example.com",
+		"status":      float64(503),
+		"level":       "error",
+		"empty":       "",
+	}
+	cases := []struct {
+		expr string
+		want any
+	}{
+		{`bucket(duration_ms, 100, 250, 1000)`, "250-1000"},
+		{`bucket(duration_ms, 500, 1000)`, "<500"},
+		{`bucket(5000, 500, 1000)`, ">=1000"},
+		{`split_part(field("host.name"), ".", 2)`, "eu-west-1"},
+		{`regex_extract(field("host.name"), "^([a-z]+)-\\d+")`, "web"},
+		{`regex_match(field("host.name"), "prod")`, true},
+		{`duration_ms / 1000`, 0.312},
+		{`round(duration_ms / 7, 2)`, 44.57},
+		{`status >= 500 && level == "error"`, true},
+		{`when(status >= 500, "server", when(status >= 400, "client", "ok"))`, "server"},
+		{`coalesce(missing, empty, upper(level))`, "ERROR"},
+		{`concat(level, ":", status)`, "error:503"},
+		{`substr(level, 1, 3)`, "rro"},
+		{`missing + 1`, nil},
+		{`missing == null`, true},
+		{`level != null`, true},
+		{`duration_ms / 0`, nil},
+		{`len(level) * -1`, float64(-5)},
+		{`max(status, duration_ms)`, float64(503)},
+	}
+	for _, tc := range cases {
+		expr, err := CompileColumnExpression(tc.expr)
+		require.NoError(t, err, tc.expr)
+		assert.Equal(t, tc.want, expr.Eval(row), tc.expr)
+	}
+
+	for _, bad := range []string{
+		``,
+		`level +`,
+		`os.Exit(1)`,
+		`func() { for {} }()`,
+		`level[0]`,
+		`exec("rm")`,
+		`regex_match(level, level)`,
+		`regex_extract(level, "(")`,
+		`bucket(status, 500, 100)`,
+		`lower()`,
+		`'c'`,
+	} {
+		_, err := CompileColumnExpression(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestComputedColumnService_Apply(t *testing.T) {
+	ctx := context.Background()
+	svc := NewComputedColumnService(cache.NewNoopValkeyCache(logger.New("error")), logger.New("error"))
+
+	_, err := svc.Put(ctx, models.ComputedColumn{Name: "latency_bucket", Expression: `bucket(duration_ms, 100, 500)`})
+	require.NoError(t, err)
+	_, err = svc.Put(ctx, models.ComputedColumn{Name: "region", Expression: `split_part(field("host.name"), ".", 2)`})
+	require.NoError(t, err)
+	_, err = svc.Put(ctx, models.ComputedColumn{Name: "_msg", Expression: `level`})
+	assert.ErrorIs(t, err, ErrInvalidComputedColumn)
+	_, err = svc.Put(ctx, models.ComputedColumn{Name: "broken", Expression: `sleep(10)`})
+	assert.ErrorIs(t, err, ErrInvalidComputedColumn)
+
+	rows := []map[string]any{
+		{"duration_ms": "42", "host.name": "api-1.us-east-1.prod"},
+		{"duration_ms": "900"},
+	}
+	require.NoError(t, svc.Apply(ctx, []string{"latency_bucket", "region"}, rows))
+	assert.Equal(t, "<100", rows[0]["latency_bucket"])
+	assert.Equal(t, "us-east-1", rows[0]["region"])
+	assert.Equal(t, ">=500", rows[1]["latency_bucket"])
+	assert.Nil(t, rows[1]["region"])
+
+	err = svc.Apply(ctx, []string{"missing"}, rows)
+	assert.ErrorIs(t, err, ErrComputedColumnNotFound)
+
+	preview, err := svc.Preview(models.ComputedColumnPreviewRequest{Expression: `number(duration_ms) > 100`, Rows: rows})
+	require.NoError(t, err)
+	assert.Equal(t, []any{false, true}, preview.Values)
+
+	require.NoError(t, svc.Delete(ctx, "region", "sre"))
+	assert.ErrorIs(t, svc.Delete(ctx, "region", "sre"), ErrComputedColumnNotFound)
+	versions, err := svc.config.ListVersions(ctx, DynamicConfigComputedColumns)
+	require.NoError(t, err)
+	require.Len(t, versions, 3, "both puts and the delete are versioned")
+	assert.Equal(t, "sre", versions[0].Author)
+}
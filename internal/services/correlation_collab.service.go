@@ -0,0 +1,377 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/weavstore"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const (
+	correlationShareKeyPrefix = "correlation:share:"
+	correlationSharePath      = "/api/v1/correlation/shared/"
+
+	defaultShareExpiry = 7 * 24 * time.Hour
+	maxShareExpiry     = 30 * 24 * time.Hour
+	maxCommentLength   = 10000
+	mentionNotifyTime  = 30 * time.Second
+)
+
+var (
+	ErrShareNotFound         = errors.New("share link not found or expired")
+	ErrInvalidComment        = errors.New("invalid comment")
+	ErrCommentsUnavailable   = errors.New("comments require Weaviate")
+	correlationMentionRegexp = regexp.MustCompile(`(?:^|[^\w@])@([A-Za-z0-9][A-Za-z0-9._-]{0,63})`)
+)
+
+// CorrelationCommentStore persists correlation run comments.
+type CorrelationCommentStore interface {
+	CreateCorrelationComment(ctx context.Context, c *weavstore.CorrelationComment) error
+	ListCorrelationComments(ctx context.Context, tenant, correlationID string) ([]*weavstore.CorrelationComment, error)
+}
+
+// CorrelationResultSource returns stored correlation results;
+// CorrelationHistoryService satisfies it.
+type CorrelationResultSource interface {
+	Get(ctx context.Context, correlationID string) (*models.CorrelationHistoryEntry, error)
+}
+
+// MentionNotifier delivers mention notifications; NotificationService
+// satisfies it.
+type MentionNotifier interface {
+	SendNotification(ctx context.Context, notification *models.Notification) error
+}
+
+// CorrelationCollabService lets incident responders discuss a correlation
+// run: share links resolve to the run and its comments, and comments can
+// target the run or one of its cause candidates, reply to each other and
+// mention people. Runs are resolved against the correlation history when it
+// is set, so links outlive the recorded runs evicted after run_retention.
+// Share links live in the tenant's Valkey namespace and comments carry the
+// tenant, so neither crosses tenants.
+type CorrelationCollabService struct {
+	cache    cache.ValkeyCluster
+	history  CorrelationResultSource
+	comments CorrelationCommentStore
+	notifier MentionNotifier
+	tenant   string
+	logger   logging.Logger
+}
+
+// NewCorrelationCollabService creates a new sharing and commenting service.
+// comments and notifier may be nil; without a comment store only sharing works.
+func NewCorrelationCollabService(cache cache.ValkeyCluster, comments CorrelationCommentStore, notifier MentionNotifier, tenant string, logger corelogger.Logger) *CorrelationCollabService {
+	return &CorrelationCollabService{
+		cache:    cache,
+		comments: comments,
+		notifier: notifier,
+		tenant:   tenant,
+		logger:   logging.FromCoreLogger(logger),
+	}
+}
+
+// SetHistory resolves runs against the durable correlation history. Call
+// before serving.
+func (s *CorrelationCollabService) SetHistory(h CorrelationResultSource) {
+	s.history = h
+}
+
+// Share creates a share link for a run.
+func (s *CorrelationCollabService) Share(ctx context.Context, correlationID string, req models.CorrelationShareRequest, user string) (*models.CorrelationShare, error) {
+	if _, _, err := s.resolve(ctx, correlationID); err != nil {
+		return nil, err
+	}
+	expiry := defaultShareExpiry
+	if req.ExpiresInHours > 0 {
+		expiry = min(time.Duration(req.ExpiresInHours)*time.Hour, maxShareExpiry)
+	}
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("generate share token: %w", err)
+	}
+	now := time.Now().UTC()
+	share := &models.CorrelationShare{
+		Token:         hex.EncodeToString(buf),
+		CorrelationID: correlationID,
+		CreatedBy:     user,
+		CreatedAt:     now,
+		ExpiresAt:     now.Add(expiry),
+	}
+	share.Path = correlationSharePath + share.Token
+	if err := s.cache.Set(ctx, correlationShareKeyPrefix+share.Token, share, expiry); err != nil {
+		return nil, fmt.Errorf("store share link: %w", err)
+	}
+	s.logger.Info("Correlation run shared", "correlation_id", correlationID, "created_by", user, "expires_at", share.ExpiresAt)
+	return share, nil
+}
+
+// Revoke deletes a share link.
+func (s *CorrelationCollabService) Revoke(ctx context.Context, token string) error {
+	if _, err := s.loadShare(ctx, token); err != nil {
+		return err
+	}
+	return s.cache.Delete(ctx, correlationShareKeyPrefix+token)
+}
+
+// Shared resolves a share link to the run and its comment threads.
+func (s *CorrelationCollabService) Shared(ctx context.Context, token string) (*models.SharedCorrelationRun, error) {
+	share, err := s.loadShare(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	result, run, err := s.resolve(ctx, share.CorrelationID)
+	if err != nil {
+		return nil, err
+	}
+	out := &models.SharedCorrelationRun{Share: *share, Result: result, Run: run, Comments: []models.CorrelationComment{}}
+	if s.comments != nil {
+		comments, err := s.Comments(ctx, share.CorrelationID)
+		if err != nil {
+			s.logger.Warn("Failed to load comments for shared run", "correlation_id", share.CorrelationID, "error", err)
+		} else {
+			out.Comments = comments
+		}
+	}
+	return out, nil
+}
+
+// AddComment stores a comment on a run or one of its cause candidates and
+// notifies anyone it mentions.
+func (s *CorrelationCollabService) AddComment(ctx context.Context, correlationID string, req models.CorrelationCommentRequest, author string) (*models.CorrelationComment, error) {
+	if s.comments == nil {
+		return nil, ErrCommentsUnavailable
+	}
+	body := strings.TrimSpace(req.Body)
+	if body == "" || len(body) > maxCommentLength {
+		return nil, fmt.Errorf("%w: body must be 1-%d characters", ErrInvalidComment, maxCommentLength)
+	}
+	result, run, err := s.resolve(ctx, correlationID)
+	if err != nil {
+		return nil, err
+	}
+	if req.CandidateKPIID != "" && !hasCandidate(result, run, req.CandidateKPIID) {
+		return nil, fmt.Errorf("%w: %s is not a cause candidate of this run", ErrInvalidComment, req.CandidateKPIID)
+	}
+	if req.ParentID != "" {
+		existing, err := s.comments.ListCorrelationComments(ctx, s.tenant, correlationID)
+		if err != nil {
+			return nil, err
+		}
+		if !hasComment(existing, req.ParentID) {
+			return nil, fmt.Errorf("%w: parent comment %s not found on this run", ErrInvalidComment, req.ParentID)
+		}
+	}
+
+	c := &weavstore.CorrelationComment{
+		ID:             uuid.NewString(),
+		Tenant:         s.tenant,
+		CorrelationID:  correlationID,
+		CandidateKPIID: req.CandidateKPIID,
+		ParentID:       req.ParentID,
+		Author:         author,
+		Body:           body,
+		Mentions:       parseMentions(body),
+		CreatedAt:      time.Now().UTC(),
+	}
+	if err := s.comments.CreateCorrelationComment(ctx, c); err != nil {
+		return nil, err
+	}
+	if len(c.Mentions) > 0 {
+		s.notifyMentions(c)
+	}
+	out := toCorrelationComment(c)
+	return &out, nil
+}
+
+// Comments returns the comment threads of a run, oldest first, with replies
+// nested under their parent.
+func (s *CorrelationCollabService) Comments(ctx context.Context, correlationID string) ([]models.CorrelationComment, error) {
+	if s.comments == nil {
+		return nil, ErrCommentsUnavailable
+	}
+	if _, _, err := s.resolve(ctx, correlationID); err != nil {
+		return nil, err
+	}
+	stored, err := s.comments.ListCorrelationComments(ctx, s.tenant, correlationID)
+	if err != nil {
+		return nil, err
+	}
+	return threadComments(stored), nil
+}
+
+// resolve returns the stored result of a run, when there is a history, and
+// its recorded run while it is retained. A run found in neither is
+// ErrCorrelationRunNotFound.
+func (s *CorrelationCollabService) resolve(ctx context.Context, correlationID string) (*models.CorrelationHistoryEntry, *models.CorrelationRunRecord, error) {
+	var result *models.CorrelationHistoryEntry
+	if s.history != nil {
+		entry, err := s.history.Get(ctx, correlationID)
+		switch {
+		case err == nil:
+			result = entry
+		case !errors.Is(err, ErrCorrelationHistoryNotFound):
+			return nil, nil, err
+		}
+	}
+	run, err := loadCorrelationRun(ctx, s.cache, correlationID)
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrCorrelationRunNotFound) && result != nil:
+		run = nil
+	default:
+		return nil, nil, err
+	}
+	return result, run, nil
+}
+
+func (s *CorrelationCollabService) loadShare(ctx context.Context, token string) (*models.CorrelationShare, error) {
+	data, err := s.cache.Get(ctx, correlationShareKeyPrefix+token)
+	if err != nil || len(data) == 0 {
+		return nil, ErrShareNotFound
+	}
+	var share models.CorrelationShare
+	if err := json.Unmarshal(data, &share); err != nil {
+		return nil, fmt.Errorf("decode share link: %w", err)
+	}
+	return &share, nil
+}
+
+// notifyMentions sends the mention notification in the background so slow
+// integrations do not hold up the comment request.
+func (s *CorrelationCollabService) notifyMentions(c *weavstore.CorrelationComment) {
+	if s.notifier == nil {
+		return
+	}
+	target := "correlation run " + c.CorrelationID
+	if c.CandidateKPIID != "" {
+		target = fmt.Sprintf("cause %s of correlation run %s", c.CandidateKPIID, c.CorrelationID)
+	}
+	author := c.Author
+	if author == "" {
+		author = "someone"
+	}
+	n := &models.Notification{
+		ID:        "comment-" + c.ID,
+		Type:      "mention",
+		Title:     fmt.Sprintf("%s mentioned %s on %s", author, "@"+strings.Join(c.Mentions, ", @"), target),
+		Message:   c.Body,
+		Component: "correlation",
+		Severity:  "low",
+		Timestamp: c.CreatedAt,
+		Data: map[string]interface{}{
+			"author":        author,
+			"mentions":      c.Mentions,
+			"correlationId": c.CorrelationID,
+			"kpiId":         c.CandidateKPIID,
+		},
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), mentionNotifyTime)
+		defer cancel()
+		if err := s.notifier.SendNotification(ctx, n); err != nil {
+			s.logger.Warn("Mention notification failed", "comment_id", c.ID, "error", err)
+		}
+	}()
+}
+
+// parseMentions returns the distinct "@name" mentions in body, in order.
+func parseMentions(body string) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, m := range correlationMentionRegexp.FindAllStringSubmatch(body, -1) {
+		name := strings.TrimRight(m[1], ".-")
+		if name != "" && !seen[strings.ToLower(name)] {
+			seen[strings.ToLower(name)] = true
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+// hasCandidate reports whether kpiID is a cause candidate of the stored
+// result or the recorded run.
+func hasCandidate(result *models.CorrelationHistoryEntry, run *models.CorrelationRunRecord, kpiID string) bool {
+	if result != nil && result.Result != nil {
+		for _, c := range result.Result.Causes {
+			if c.KPIUUID == kpiID {
+				return true
+			}
+		}
+	}
+	if run != nil {
+		for _, c := range run.Candidates {
+			if c.KPIID == kpiID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasComment(comments []*weavstore.CorrelationComment, id string) bool {
+	for _, c := range comments {
+		if c.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// threadComments nests replies under their parents; replies whose parent is
+// missing are kept at the top level.
+func threadComments(stored []*weavstore.CorrelationComment) []models.CorrelationComment {
+	children := map[string][]*weavstore.CorrelationComment{}
+	ids := map[string]bool{}
+	for _, c := range stored {
+		ids[c.ID] = true
+	}
+	var roots []*weavstore.CorrelationComment
+	for _, c := range stored {
+		if c.ParentID != "" && ids[c.ParentID] && c.ParentID != c.ID {
+			children[c.ParentID] = append(children[c.ParentID], c)
+		} else {
+			roots = append(roots, c)
+		}
+	}
+	var build func(c *weavstore.CorrelationComment, depth int) models.CorrelationComment
+	build = func(c *weavstore.CorrelationComment, depth int) models.CorrelationComment {
+		out := toCorrelationComment(c)
+		if depth < 32 {
+			for _, r := range children[c.ID] {
+				out.Replies = append(out.Replies, build(r, depth+1))
+			}
+		}
+		return out
+	}
+	out := make([]models.CorrelationComment, 0, len(roots))
+	for _, c := range roots {
+		out = append(out, build(c, 0))
+	}
+	return out
+}
+
+func toCorrelationComment(c *weavstore.CorrelationComment) models.CorrelationComment {
+	return models.CorrelationComment{
+		ID:             c.ID,
+		CorrelationID:  c.CorrelationID,
+		CandidateKPIID: c.CandidateKPIID,
+		ParentID:       c.ParentID,
+		Author:         c.Author,
+		Body:           c.Body,
+		Mentions:       c.Mentions,
+		CreatedAt:      c.CreatedAt,
+	}
+}
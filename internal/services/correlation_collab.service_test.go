@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/weavstore"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+type memCommentStore struct {
+	comments []*weavstore.CorrelationComment
+}
+
+func (m *memCommentStore) CreateCorrelationComment(ctx context.Context, c *weavstore.CorrelationComment) error {
+	m.comments = append(m.comments, c)
+	return nil
+}
+
+func (m *memCommentStore) ListCorrelationComments(ctx context.Context, tenant, correlationID string) ([]*weavstore.CorrelationComment, error) {
+	var out []*weavstore.CorrelationComment
+	for _, c := range m.comments {
+		if c.Tenant == tenant && c.CorrelationID == correlationID {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+type channelNotifier struct {
+	mu   sync.Mutex
+	sent []*models.Notification
+	done chan struct{}
+}
+
+func (r *channelNotifier) SendNotification(ctx context.Context, n *models.Notification) error {
+	r.mu.Lock()
+	r.sent = append(r.sent, n)
+	r.mu.Unlock()
+	r.done <- struct{}{}
+	return nil
+}
+
+func TestCorrelationCollabService(t *testing.T) {
+	ctx := context.Background()
+	log := logger.New("error")
+	c := cache.NewNoopValkeyCache(log)
+	run := &models.CorrelationRunRecord{
+		CorrelationID: "run-1",
+		Candidates:    []models.CorrelationRunCandidate{{KPIID: "db_latency"}, {KPIID: "cpu"}},
+		TopCause:      "db_latency",
+		CreatedAt:     time.Now(),
+	}
+	if err := saveCorrelationRun(ctx, c, run, 10); err != nil {
+		t.Fatal(err)
+	}
+	store := &memCommentStore{}
+	notifier := &channelNotifier{done: make(chan struct{}, 4)}
+	svc := NewCorrelationCollabService(c, store, notifier, "acme", log)
+
+	if _, err := svc.Share(ctx, "missing", models.CorrelationShareRequest{}, "alice"); !errors.Is(err, ErrCorrelationRunNotFound) {
+		t.Fatalf("expected ErrCorrelationRunNotFound, got %v", err)
+	}
+	share, err := svc.Share(ctx, "run-1", models.CorrelationShareRequest{ExpiresInHours: 10000}, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(share.Token) != 48 || share.ExpiresAt.Sub(share.CreatedAt) != maxShareExpiry {
+		t.Fatalf("unexpected share %+v", share)
+	}
+
+	root, err := svc.AddComment(ctx, "run-1", models.CorrelationCommentRequest{
+		Body: "Looks like the DB failover, cc @bob and @carol.", CandidateKPIID: "db_latency",
+	}, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(root.Mentions) != 2 || root.Mentions[0] != "bob" || root.Mentions[1] != "carol" {
+		t.Fatalf("unexpected mentions %v", root.Mentions)
+	}
+	select {
+	case <-notifier.done:
+	case <-time.After(time.Second):
+		t.Fatal("expected a mention notification")
+	}
+	if n := notifier.sent[0]; n.Type != "mention" || n.Message != root.Body {
+		t.Fatalf("unexpected notification %+v", n)
+	}
+
+	if _, err := svc.AddComment(ctx, "run-1", models.CorrelationCommentRequest{Body: "agreed", ParentID: root.ID}, "bob"); err != nil {
+		t.Fatal(err)
+	}
+	for _, bad := range []models.CorrelationCommentRequest{
+		{Body: "  "},
+		{Body: "x", CandidateKPIID: "unknown"},
+		{Body: "x", ParentID: "unknown"},
+	} {
+		if _, err := svc.AddComment(ctx, "run-1", bad, "bob"); !errors.Is(err, ErrInvalidComment) {
+			t.Fatalf("expected ErrInvalidComment for %+v, got %v", bad, err)
+		}
+	}
+
+	shared, err := svc.Shared(ctx, share.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if shared.Run == nil || shared.Run.CorrelationID != "run-1" || len(shared.Comments) != 1 || len(shared.Comments[0].Replies) != 1 {
+		t.Fatalf("expected one thread with one reply, got %+v", shared.Comments)
+	}
+
+	// Other tenants sharing the comment store do not see the thread.
+	other := NewCorrelationCollabService(c, store, nil, "globex", log)
+	if comments, err := other.Comments(ctx, "run-1"); err != nil || len(comments) != 0 {
+		t.Fatalf("expected no comments for another tenant, got %v, %v", comments, err)
+	}
+
+	if err := svc.Revoke(ctx, share.Token); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Shared(ctx, share.Token); !errors.Is(err, ErrShareNotFound) {
+		t.Fatalf("expected ErrShareNotFound after revoke, got %v", err)
+	}
+}
+
+type memResultSource map[string]*models.CorrelationHistoryEntry
+
+func (m memResultSource) Get(ctx context.Context, correlationID string) (*models.CorrelationHistoryEntry, error) {
+	if e, ok := m[correlationID]; ok {
+		return e, nil
+	}
+	return nil, ErrCorrelationHistoryNotFound
+}
+
+func TestCorrelationCollabService_HistoryOutlivesRuns(t *testing.T) {
+	ctx := context.Background()
+	log := logger.New("error")
+	c := cache.NewNoopValkeyCache(log)
+	svc := NewCorrelationCollabService(c, &memCommentStore{}, nil, "acme", log)
+	svc.SetHistory(memResultSource{"run-old": {
+		CorrelationID: "run-old",
+		Result:        &models.CorrelationResult{CorrelationID: "run-old", Causes: []models.CauseCandidate{{KPI: "db latency", KPIUUID: "db_latency"}}},
+	}})
+
+	// The run was evicted from the recorded runs; the history still has it.
+	share, err := svc.Share(ctx, "run-old", models.CorrelationShareRequest{}, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.AddComment(ctx, "run-old", models.CorrelationCommentRequest{Body: "failover", CandidateKPIID: "db_latency"}, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.AddComment(ctx, "run-old", models.CorrelationCommentRequest{Body: "x", CandidateKPIID: "cpu"}, "alice"); !errors.Is(err, ErrInvalidComment) {
+		t.Fatalf("expected ErrInvalidComment for a KPI that is not a cause, got %v", err)
+	}
+	shared, err := svc.Shared(ctx, share.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if shared.Result == nil || shared.Result.CorrelationID != "run-old" || shared.Run != nil || len(shared.Comments) != 1 {
+		t.Fatalf("expected the stored result without a recorded run, got %+v", shared)
+	}
+	if _, err := svc.Share(ctx, "missing", models.CorrelationShareRequest{}, "alice"); !errors.Is(err, ErrCorrelationRunNotFound) {
+		t.Fatalf("expected ErrCorrelationRunNotFound, got %v", err)
+	}
+}
+
+func TestParseMentions(t *testing.T) {
+	got := parseMentions("@alice see this, mail bob@example.com, @Alice again and @ops.team.")
+	if len(got) != 2 || got[0] != "alice" || got[1] != "ops.team" {
+		t.Fatalf("unexpected mentions %v", got)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"net"
 	"sort"
 	"strconv"
 	"strings"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/gofrs/uuid/v5"
 	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 
 	"github.com/mirastacklabs-ai/mirador-core/internal/config"
 	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
@@ -34,6 +36,13 @@ func containsString(hay []string, needle string) bool {
 	return false
 }
 
+const (
+	// correlationWriteQueueSize bounds the run records and history entries
+	// waiting to be stored; correlationWriteTimeout bounds storing one.
+	correlationWriteQueueSize = 64
+	correlationWriteTimeout   = 10 * time.Second
+)
+
 // CorrelationEngine handles correlation queries across multiple engines
 type CorrelationEngine interface {
 	// ExecuteCorrelation executes a correlation query
@@ -85,6 +94,9 @@ type CorrelationEngineImpl struct {
 	resultMerger   *CorrelationResultMerger
 	tracer         *tracing.QueryTracer
 	engineCfg      config.EngineConfig
+	history        CorrelationHistoryRecorder
+	// writes stores run records and history after Correlate has returned
+	writes *asyncWriter
 }
 
 // NewCorrelationEngine creates a new correlation engine
@@ -101,20 +113,27 @@ func NewCorrelationEngine(
 	// the engine implementation never hardcodes raw label/metric keys.
 	// Defaults are centralised in the `config` package (configs/defaults).
 	cfg = config.MergeEngineConfigWithDefaults(cfg)
+	log := logging.FromCoreLogger(logger)
 	return &CorrelationEngineImpl{
 		metricsService: metricsSvc,
 		logsService:    logsSvc,
 		tracesService:  tracesSvc,
 		kpiRepo:        kpiRepo,
 		cache:          cache,
-		logger:         logging.FromCoreLogger(logger),
+		logger:         log,
 		parser:         models.NewCorrelationQueryParser(),
-		resultMerger:   NewCorrelationResultMerger(logging.FromCoreLogger(logger)),
+		resultMerger:   NewCorrelationResultMerger(log),
 		tracer:         tracing.GetGlobalTracer(),
 		engineCfg:      cfg,
+		writes:         newAsyncWriter(correlationWriteQueueSize, correlationWriteTimeout, log),
 	}
 }
 
+// SetHistory stores every correlation result in h. Call before serving.
+func (ce *CorrelationEngineImpl) SetHistory(h CorrelationHistoryRecorder) {
+	ce.history = h
+}
+
 // ExecuteCorrelation executes a correlation query across multiple engines
 func (ce *CorrelationEngineImpl) ExecuteCorrelation(ctx context.Context, query *models.CorrelationQuery) (*models.UnifiedCorrelationResult, error) {
 	start := time.Now()
@@ -139,7 +158,7 @@ func (ce *CorrelationEngineImpl) ExecuteCorrelation(ctx context.Context, query *
 	}
 
 	// Execute expressions in parallel
-	results, err := ce.executeExpressionsParallel(corrCtx, query)
+	results, engineSpans, err := ce.executeExpressionsParallel(corrCtx, query)
 	if err != nil {
 		// Record failed correlation metrics
 		monitoring.RecordUnifiedQueryCorrelationOperation("correlation", len(query.Expressions), time.Since(start), false)
@@ -147,8 +166,18 @@ func (ce *CorrelationEngineImpl) ExecuteCorrelation(ctx context.Context, query *
 		return nil, fmt.Errorf("failed to execute expressions: %w", err)
 	}
 
-	// Correlate results
-	correlations := ce.correlateResults(query, results)
+	// Correlate results; the span links back to the engine query spans
+	_, matchSpan := ce.startCorrelationMatchSpan(corrCtx, engineSpans)
+	correlations, err := ce.correlateResults(query, results)
+	if err != nil {
+		ce.tracer.RecordError(matchSpan, err)
+	}
+	matchSpan.End()
+	if err != nil {
+		monitoring.RecordUnifiedQueryCorrelationOperation("correlation", len(query.Expressions), time.Since(start), false)
+		ce.tracer.RecordError(corrSpan, err)
+		return nil, err
+	}
 
 	// Merge and deduplicate correlations
 	correlations = ce.resultMerger.MergeResults(correlations)
@@ -519,6 +548,44 @@ func (ce *CorrelationEngineImpl) Correlate(ctx context.Context, tr models.TimeRa
 		})
 	}
 
+	// Screen large candidate sets cheaply before computing full statistics.
+	candidateKPIs, corr.Pruning = ce.pruneCandidates(ctx, candidateKPIs, rings)
+
+	// Stream progress when the caller asked for it: the rings, each ring
+	// sampled and each candidate scored. Candidates may still be suppressed
+	// afterwards; the returned result is authoritative.
+	progress := correlationProgress(ctx)
+	ringTotal, ringsSampled := 0, 0
+	if len(impactKPIs) > 0 {
+		ringTotal = len(candidateKPIs) * len(rings)
+	}
+	if progress != nil {
+		progress(models.CorrelationProgressEvent{Stage: models.CorrelationStageRings, Rings: rings, Total: len(rings)})
+	}
+	addCause := func(cand models.CauseCandidate) {
+		corr.Causes = append(corr.Causes, cand)
+		if progress != nil {
+			progress(models.CorrelationProgressEvent{
+				Stage:     models.CorrelationStageCandidate,
+				KPI:       cand.KPI,
+				Candidate: &cand,
+				Completed: len(corr.Causes),
+				Total:     len(candidateKPIs),
+			})
+		}
+	}
+
+	// Record the per-ring samples so the run can be replayed offline under
+	// other engine configurations.
+	var run *models.CorrelationRunRecord
+	if ce.engineCfg.RecordRuns && ce.cache != nil {
+		run = &models.CorrelationRunRecord{
+			CorrelationID: corr.CorrelationID,
+			TimeRange:     tr,
+			CreatedAt:     corr.CreatedAt,
+		}
+	}
+
 	// Populate Causes with a deterministic baseline suspicion score so downstream
 	// RCA machinery can consume candidate scoring during AT-007 work.
 	// Replace baseline scoring with real statistical wiring across rings.
@@ -552,7 +619,7 @@ func (ce *CorrelationEngineImpl) Correlate(ctx context.Context, tr models.TimeRa
 		if len(impactKPIs) == 0 {
 			// No impact KPI discovered; keep zero suspicion but include as candidate
 			cand.Reasons = append(cand.Reasons, "no_impact_kpi")
-			corr.Causes = append(corr.Causes, cand)
+			addCause(cand)
 			continue
 		}
 		impactKPI := impactKPIs[0]
@@ -560,7 +627,7 @@ func (ce *CorrelationEngineImpl) Correlate(ctx context.Context, tr models.TimeRa
 		// Build per-ring sample vectors by computing a ring-level aggregate (mean)
 		var impactVals []float64
 		var causeVals []float64
-		for _, r := range rings {
+		for i, r := range rings {
 			// Use instant query at ring end time for deterministic per-ring sampling
 			// Query impact KPI for ring
 			if impactKPI != nil && impactKPI.Formula != "" && ce.metricsService != nil {
@@ -583,6 +650,18 @@ func (ce *CorrelationEngineImpl) Correlate(ctx context.Context, tr models.TimeRa
 					}
 				}
 			}
+
+			if progress != nil {
+				ring := i
+				ringsSampled++
+				progress(models.CorrelationProgressEvent{
+					Stage:     models.CorrelationStageRing,
+					Ring:      &ring,
+					KPI:       cand.KPI,
+					Completed: ringsSampled,
+					Total:     ringTotal,
+				})
+			}
 		}
 
 		// Need at least 2 samples to compute correlations
@@ -592,10 +671,6 @@ func (ce *CorrelationEngineImpl) Correlate(ctx context.Context, tr models.TimeRa
 			// simple confounder series via KPI registry heuristics (Stage-01
 			// supports a single confounder heuristic). NOTE(AT-012): do not
 			// hardcode KPI names; rely on KPI metadata when available.
-			pearson, spearman, crossMax := 0.0, 0.0, 0.0
-			crossLag := 0
-			partial := 0.0
-
 			// Try to find a confounder KPI by kind/tags (e.g. infra/global/load)
 			var confounderVals []float64
 			if ce.kpiRepo != nil {
@@ -632,61 +707,19 @@ func (ce *CorrelationEngineImpl) Correlate(ctx context.Context, tr models.TimeRa
 				}
 			}
 
-			if len(confounderVals) == n {
-				pearson, spearman, crossMax, crossLag, partial, _ = ComputeCorrelationStats(impactVals, causeVals, n-1, confounderVals)
-			} else {
-				pearson, spearman, crossMax, crossLag, partial, _ = ComputeCorrelationStats(impactVals, causeVals, n-1)
-			}
-
-			stats := &models.CorrelationStats{
-				Pearson:      pearson,
-				Spearman:     spearman,
-				CrossCorrMax: crossMax,
-				CrossCorrLag: crossLag,
-				Partial:      partial,
-				SampleSize:   n,
-				PValue:       0.0,
-				Confidence:   0.0,
-			}
-
-			// Derive a lightweight confidence score from absolute correlations
-			stats.Confidence = (math.Abs(stats.Pearson) + math.Abs(stats.Spearman)) / 2.0
-
-			// Compute a lightweight anomaly density for the candidate using the
-			// per-ring aggregated values we already have. This is Stage-01
-			// heuristic: fraction of rings with values beyond mean +/- 2*std.
-			anomalyDensity := 0.0
-			if len(causeVals) > 0 {
-				mean := 0.0
-				for _, v := range causeVals {
-					mean += v
-				}
-				mean /= float64(len(causeVals))
-				sd := 0.0
-				for _, v := range causeVals {
-					d := v - mean
-					sd += d * d
-				}
-				if len(causeVals) > 1 {
-					sd = math.Sqrt(sd / float64(len(causeVals)))
-				} else {
-					sd = 0.0
-				}
-				if sd > 0 {
-					threshHigh := mean + 2*sd
-					threshLow := mean - 2*sd
-					count := 0
-					for _, v := range causeVals {
-						if v > threshHigh || v < threshLow {
-							count++
-						}
-					}
-					anomalyDensity = float64(count) / float64(len(causeVals))
-				}
+			if len(confounderVals) != n {
+				confounderVals = nil
+			}
+			stats, anomalyDensity, susp := scoreCandidateSamples(impactVals, causeVals, confounderVals, ce.engineCfg)
+			if run != nil {
+				run.Candidates = append(run.Candidates, models.CorrelationRunCandidate{
+					KPIID:      candKPI.ID,
+					KPI:        cand.KPI,
+					Impact:     impactVals,
+					Cause:      causeVals,
+					Confounder: confounderVals,
+				})
 			}
-
-			// Compute suspicion score driven by engine config; include partial and anomaly density
-			susp := ComputeSuspicionScore(stats.Pearson, stats.Spearman, stats.CrossCorrMax, stats.CrossCorrLag, stats.SampleSize, ce.engineCfg.MinCorrelation, stats.Partial, anomalyDensity)
 
 			// Populate candidate entry
 			cand.Stats = stats
@@ -733,17 +766,305 @@ func (ce *CorrelationEngineImpl) Correlate(ctx context.Context, tr models.TimeRa
 				cand.Service = candKPI.ServiceFamily
 			}
 
-			corr.Causes = append(corr.Causes, cand)
+			addCause(cand)
 		} else {
 			// Not enough data; mark candidate with a diagnostic reason
 			cand.Reasons = append(cand.Reasons, "insufficient_data")
-			corr.Causes = append(corr.Causes, cand)
+			addCause(cand)
+		}
+	}
+
+	ce.applyEventSignals(ctx, corr, tr)
+
+	var impactKPI *models.KPIDefinition
+	if len(impactKPIs) > 0 {
+		impactKPI = impactKPIs[0]
+	}
+	ce.applySuppressions(ctx, corr, run, impactKPI, candidateKPIs)
+
+	if run != nil && len(run.Candidates) > 0 {
+		best := -1.0
+		for _, c := range corr.Causes {
+			if c.Stats != nil && c.SuspicionScore > best {
+				best, run.TopCause = c.SuspicionScore, c.KPIUUID
+			}
+		}
+		ce.writes.enqueue("correlation run", func(wctx context.Context) {
+			if err := saveCorrelationRun(wctx, ce.cache, run, ce.engineCfg.RunRetention); err != nil && ce.logger != nil {
+				ce.logger.Warn("failed to record correlation run", "correlation_id", run.CorrelationID, "err", err)
+			}
+		})
+	}
+
+	if ce.history != nil {
+		// Callers may still change corr; the history gets a copy as returned.
+		var snapshot models.CorrelationResult
+		data, err := json.Marshal(corr)
+		if err == nil {
+			err = json.Unmarshal(data, &snapshot)
+		}
+		if err != nil {
+			if ce.logger != nil {
+				ce.logger.Warn("failed to store correlation result", "correlation_id", corr.CorrelationID, "err", err)
+			}
+		} else {
+			ce.writes.enqueue("correlation history", func(wctx context.Context) {
+				if err := ce.history.Record(wctx, tr, &snapshot); err != nil && ce.logger != nil {
+					ce.logger.Warn("failed to store correlation result", "correlation_id", snapshot.CorrelationID, "err", err)
+				}
+			})
 		}
 	}
 
 	return corr, nil
 }
 
+// pruneCandidates runs the early pruning stages configured in
+// EngineConfig.Pruning over candidates (sorted by ID) and returns the ones
+// to evaluate in full, still sorted by ID, with a report of what each stage
+// dropped. The report is nil when pruning is off or too few candidates were
+// discovered.
+func (ce *CorrelationEngineImpl) pruneCandidates(ctx context.Context, candidates []*models.KPIDefinition, rings []models.TimeRange) ([]*models.KPIDefinition, *models.CandidatePruning) {
+	cfg := ce.engineCfg.Pruning
+	if !cfg.Enabled || len(candidates) <= cfg.MinCandidates || len(rings) < 2 || ce.metricsService == nil {
+		return candidates, nil
+	}
+	report := &models.CandidatePruning{Candidates: len(candidates)}
+
+	// Screen: two instant queries per candidate, at the end of the first
+	// and of the core ring, instead of one per ring plus a confounder.
+	baselineAt, coreAt := rings[0].End, rings[len(rings)/2].End
+	sampleAt := func(kp *models.KPIDefinition, at time.Time) float64 {
+		res, err := ce.metricsService.ExecuteQuery(ctx, &models.MetricsQLQueryRequest{Query: kp.Formula, Time: at.Format(time.RFC3339)})
+		if err != nil {
+			return math.NaN()
+		}
+		return extractAverageFromMetricsResult(res)
+	}
+	type screened struct {
+		kp    *models.KPIDefinition
+		delta float64
+	}
+	var kept []screened
+	for _, kp := range candidates {
+		if ctx.Err() != nil {
+			break
+		}
+		if kp.Formula == "" {
+			continue
+		}
+		base, core := sampleAt(kp, baselineAt), sampleAt(kp, coreAt)
+		if math.IsNaN(base) || math.IsNaN(core) {
+			continue
+		}
+		delta := math.Abs(core-base) / math.Max(math.Abs(base), 1e-9)
+		if delta < cfg.MinRelativeDelta {
+			continue
+		}
+		kept = append(kept, screened{kp: kp, delta: delta})
+	}
+	report.Stages = append(report.Stages, models.PruningStage{Name: "screen", Pruned: len(candidates) - len(kept)})
+
+	// Budget: keep the candidates that moved most.
+	if cfg.MaxCandidates > 0 && len(kept) > cfg.MaxCandidates {
+		sort.SliceStable(kept, func(i, j int) bool { return kept[i].delta > kept[j].delta })
+		report.Stages = append(report.Stages, models.PruningStage{Name: "budget", Pruned: len(kept) - cfg.MaxCandidates})
+		kept = kept[:cfg.MaxCandidates]
+		sort.Slice(kept, func(i, j int) bool { return kept[i].kp.ID < kept[j].kp.ID })
+	} else {
+		report.Stages = append(report.Stages, models.PruningStage{Name: "budget"})
+	}
+
+	out := make([]*models.KPIDefinition, len(kept))
+	for i, k := range kept {
+		out[i] = k.kp
+	}
+	report.Evaluated = len(out)
+	if ce.logger != nil {
+		ce.logger.Debug("pruned correlation candidates", "candidates", report.Candidates, "evaluated", report.Evaluated)
+	}
+	return out, report
+}
+
+// applyEventSignals adds ingested events (Kubernetes events, CI/CD deploys)
+// from Lookback before the range through its end to the timeline, and raises
+// the suspicion of candidates whose service had a burst of events or a
+// deploy in that window.
+func (ce *CorrelationEngineImpl) applyEventSignals(ctx context.Context, corr *models.CorrelationResult, tr models.TimeRange) {
+	cfg := ce.engineCfg.Events
+	events := loadSignalEvents(ctx, ce.cache, tr.Start.Add(-cfg.Lookback), tr.End)
+	if len(events) == 0 {
+		return
+	}
+
+	type serviceEvents struct {
+		count  int
+		deploy bool
+	}
+	byService := map[string]*serviceEvents{}
+	for _, ev := range events {
+		key := strings.ToLower(ev.Service)
+		se := byService[key]
+		if se == nil {
+			se = &serviceEvents{}
+			byService[key] = se
+		}
+		se.count += max(ev.Count, 1)
+		if strings.EqualFold(ev.Type, models.EventTypeDeploy) {
+			se.deploy = true
+		}
+	}
+	for i := range corr.Causes {
+		cand := &corr.Causes[i]
+		se := byService[strings.ToLower(cand.Service)]
+		if se == nil {
+			continue
+		}
+		burst := cfg.BurstThreshold > 0 && se.count >= cfg.BurstThreshold
+		if !burst && !se.deploy {
+			continue
+		}
+		if se.deploy {
+			cand.Reasons = append(cand.Reasons, "deploy_near_impact")
+		}
+		if burst {
+			cand.Reasons = append(cand.Reasons, "event_burst_near_impact")
+		}
+		cand.SuspicionScore = math.Min(1, cand.SuspicionScore+cfg.Boost)
+	}
+
+	if len(events) > maxEventTimelineEntries {
+		events = events[len(events)-maxEventTimelineEntries:]
+	}
+	for _, ev := range events {
+		severity := "info"
+		if strings.EqualFold(ev.Type, "warning") {
+			severity = "warning"
+		}
+		corr.Timeline = append(corr.Timeline, models.TimelineEvent{
+			Time:       ev.Time,
+			Event:      strings.TrimSpace(ev.Type + " " + ev.Reason),
+			Service:    ev.Service,
+			Severity:   severity,
+			DataSource: "events",
+		})
+	}
+	sort.SliceStable(corr.Timeline, func(i, j int) bool { return corr.Timeline[i].Time.Before(corr.Timeline[j].Time) })
+}
+
+// applySuppressions moves candidates matched by a suppression rule from
+// Causes to Excluded and drops them from the recorded run, so they no longer
+// compete in ranking or offline evaluation.
+func (ce *CorrelationEngineImpl) applySuppressions(ctx context.Context, corr *models.CorrelationResult, run *models.CorrelationRunRecord, impact *models.KPIDefinition, candidates []*models.KPIDefinition) {
+	rules, err := loadSuppressions(ctx, ce.cache)
+	if err != nil {
+		if ce.logger != nil {
+			ce.logger.Warn("failed to load correlation suppression rules", "err", err)
+		}
+		return
+	}
+	if len(rules) == 0 {
+		return
+	}
+	byID := make(map[string]*models.KPIDefinition, len(candidates))
+	for _, kp := range candidates {
+		byID[kp.ID] = kp
+	}
+
+	suppressed := make(map[string]bool)
+	kept := corr.Causes[:0]
+	for _, cand := range corr.Causes {
+		kp := byID[cand.KPIUUID]
+		if kp == nil {
+			kept = append(kept, cand)
+			continue
+		}
+		rule := matchSuppression(rules, impact, kp)
+		if rule == nil {
+			kept = append(kept, cand)
+			continue
+		}
+		suppressed[cand.KPIUUID] = true
+		corr.Excluded = append(corr.Excluded, models.ExcludedCause{CauseCandidate: cand, RuleID: rule.ID, Reason: rule.Reason})
+	}
+	corr.Causes = kept
+
+	if run != nil && len(suppressed) > 0 {
+		runKept := run.Candidates[:0]
+		for _, rc := range run.Candidates {
+			if !suppressed[rc.KPIID] {
+				runKept = append(runKept, rc)
+			}
+		}
+		run.Candidates = runKept
+	}
+}
+
+// scoreCandidateSamples computes the correlation statistics, anomaly density
+// and suspicion score of a candidate from its aligned per-ring samples.
+// confounder may be nil. Used both by Correlate and by offline replays.
+func scoreCandidateSamples(impactVals, causeVals, confounderVals []float64, cfg config.EngineConfig) (*models.CorrelationStats, float64, float64) {
+	n := len(impactVals)
+	var pearson, spearman, crossMax, partial float64
+	var crossLag int
+	if len(confounderVals) == n {
+		pearson, spearman, crossMax, crossLag, partial, _ = ComputeCorrelationStats(impactVals, causeVals, n-1, confounderVals)
+	} else {
+		pearson, spearman, crossMax, crossLag, partial, _ = ComputeCorrelationStats(impactVals, causeVals, n-1)
+	}
+
+	stats := &models.CorrelationStats{
+		Pearson:      pearson,
+		Spearman:     spearman,
+		CrossCorrMax: crossMax,
+		CrossCorrLag: crossLag,
+		Partial:      partial,
+		SampleSize:   n,
+		PValue:       0.0,
+		Confidence:   0.0,
+	}
+
+	// Derive a lightweight confidence score from absolute correlations
+	stats.Confidence = (math.Abs(stats.Pearson) + math.Abs(stats.Spearman)) / 2.0
+
+	// Compute a lightweight anomaly density for the candidate using the
+	// per-ring aggregated values we already have. This is Stage-01
+	// heuristic: fraction of rings with values beyond mean +/- 2*std.
+	anomalyDensity := 0.0
+	if len(causeVals) > 0 {
+		mean := 0.0
+		for _, v := range causeVals {
+			mean += v
+		}
+		mean /= float64(len(causeVals))
+		sd := 0.0
+		for _, v := range causeVals {
+			d := v - mean
+			sd += d * d
+		}
+		if len(causeVals) > 1 {
+			sd = math.Sqrt(sd / float64(len(causeVals)))
+		} else {
+			sd = 0.0
+		}
+		if sd > 0 {
+			threshHigh := mean + 2*sd
+			threshLow := mean - 2*sd
+			count := 0
+			for _, v := range causeVals {
+				if v > threshHigh || v < threshLow {
+					count++
+				}
+			}
+			anomalyDensity = float64(count) / float64(len(causeVals))
+		}
+	}
+
+	// Compute suspicion score driven by engine config; include partial and anomaly density
+	susp := ComputeSuspicionScoreWeighted(cfg.Scoring, stats.Pearson, stats.Spearman, stats.CrossCorrMax, stats.CrossCorrLag, stats.SampleSize, cfg.MinCorrelation, stats.Partial, anomalyDensity)
+	return stats, anomalyDensity, susp
+}
+
 // BuildRings constructs pre/core/post rings for a given TimeRange using EngineConfig
 func BuildRings(tr models.TimeRange, cfg config.EngineConfig) []models.TimeRange {
 	var rings []models.TimeRange
@@ -839,10 +1160,12 @@ func BuildRings(tr models.TimeRange, cfg config.EngineConfig) []models.TimeRange
 	return rings
 }
 
-// executeExpressionsParallel executes all expressions in the correlation query in parallel
-func (ce *CorrelationEngineImpl) executeExpressionsParallel(ctx context.Context, query *models.CorrelationQuery) (map[models.QueryType]*models.UnifiedResult, error) {
+// executeExpressionsParallel executes all expressions in the correlation query in parallel,
+// alongside the span context of each engine query.
+func (ce *CorrelationEngineImpl) executeExpressionsParallel(ctx context.Context, query *models.CorrelationQuery) (map[models.QueryType]*models.UnifiedResult, []trace.SpanContext, error) {
 	parallelStart := time.Now()
 	results := make(map[models.QueryType]*models.UnifiedResult)
+	var engineSpans []trace.SpanContext
 	var mu sync.Mutex
 	var wg sync.WaitGroup
 	var firstError error
@@ -854,6 +1177,10 @@ func (ce *CorrelationEngineImpl) executeExpressionsParallel(ctx context.Context,
 		engineExpressions[expr.Engine] = append(engineExpressions[expr.Engine], expr)
 	}
 
+	// Engines completed so far, for progress streaming
+	progress := correlationProgress(ctx)
+	completed := 0
+
 	// Execute queries for each engine in parallel
 	for engine, expressions := range engineExpressions {
 		wg.Add(1)
@@ -861,11 +1188,21 @@ func (ce *CorrelationEngineImpl) executeExpressionsParallel(ctx context.Context,
 			defer wg.Done()
 
 			engineStart := time.Now()
-			result, err := ce.executeEngineQuery(ctx, engine, expressions, query)
+			engineCtx, engineSpan := ce.startEngineQuerySpan(ctx, engine, expressions)
+			defer engineSpan.End()
+			result, err := ce.executeEngineQuery(engineCtx, engine, expressions, query)
 			engineDuration := time.Since(engineStart)
 
 			// Record individual engine query duration
 			monitoring.RecordCorrelationEngineQueryDuration(string(engine), query.ID, engineDuration)
+			recordCount := int64(0)
+			if result != nil && result.Metadata != nil {
+				recordCount = int64(result.Metadata.TotalRecords)
+			}
+			ce.tracer.RecordEngineMetrics(engineSpan, string(engine), engineDuration, recordCount, err == nil)
+			mu.Lock()
+			engineSpans = append(engineSpans, engineSpan.SpanContext())
+			mu.Unlock()
 
 			if err != nil {
 				errorOnce.Do(func() {
@@ -876,11 +1213,37 @@ func (ce *CorrelationEngineImpl) executeExpressionsParallel(ctx context.Context,
 						"engine", engine,
 						"error", err)
 				}
+				if progress != nil {
+					mu.Lock()
+					completed++
+					ev := models.CorrelationProgressEvent{
+						Stage:     models.CorrelationStageEngine,
+						Engine:    engine,
+						Error:     err.Error(),
+						Completed: completed,
+						Total:     len(engineExpressions),
+					}
+					mu.Unlock()
+					progress(ev)
+				}
 				return
 			}
 
+			// The event is built under mu but reported after it is released,
+			// so a slow progress consumer never holds up the other engines.
+			var ev *models.CorrelationProgressEvent
 			mu.Lock()
 			results[engine] = result
+			if progress != nil {
+				completed++
+				ev = &models.CorrelationProgressEvent{
+					Stage:     models.CorrelationStageEngine,
+					Engine:    engine,
+					Partial:   ce.partialCorrelations(query, results, time.Since(parallelStart)),
+					Completed: completed,
+					Total:     len(engineExpressions),
+				}
+			}
 			// Log brief summary of the engine result for debugging correlation effectiveness
 			if ce.logger != nil {
 				recCount := 0
@@ -894,6 +1257,9 @@ func (ce *CorrelationEngineImpl) executeExpressionsParallel(ctx context.Context,
 					"duration_ms", engineDuration.Milliseconds())
 			}
 			mu.Unlock()
+			if ev != nil {
+				progress(*ev)
+			}
 		}(engine, expressions)
 	}
 
@@ -904,10 +1270,10 @@ func (ce *CorrelationEngineImpl) executeExpressionsParallel(ctx context.Context,
 	monitoring.RecordCorrelationParallelExecutionDuration(len(engineExpressions), parallelDuration)
 
 	if firstError != nil {
-		return nil, firstError
+		return nil, nil, firstError
 	}
 
-	return results, nil
+	return results, engineSpans, nil
 }
 
 // executeEngineQuery executes queries for a specific engine
@@ -1147,25 +1513,52 @@ func (ce *CorrelationEngineImpl) executeTracesCorrelationQuery(
 func (ce *CorrelationEngineImpl) correlateResults(
 	query *models.CorrelationQuery,
 	results map[models.QueryType]*models.UnifiedResult,
-) []models.Correlation {
-	var correlations []models.Correlation
-
+) ([]models.Correlation, error) {
 	if query.TimeWindow != nil {
 		// Time-window correlation
-		correlations = ce.correlateByTimeWindow(query, results)
-	} else {
-		// Label-based correlation
-		correlations = ce.correlateByLabels(query, results)
+		return ce.correlateByTimeWindow(query, results)
 	}
+	// Label-based correlation
+	return ce.correlateByLabels(query, results), nil
+}
 
-	return correlations
+// partialCorrelations correlates the engine results available so far for
+// progress streaming. It returns nil until two engines have results, or
+// when they cannot be correlated.
+func (ce *CorrelationEngineImpl) partialCorrelations(
+	query *models.CorrelationQuery,
+	results map[models.QueryType]*models.UnifiedResult,
+	elapsed time.Duration,
+) *models.UnifiedCorrelationResult {
+	if len(results) < 2 {
+		return nil
+	}
+	correlations, err := ce.correlateResults(query, results)
+	if err != nil {
+		return nil
+	}
+	correlations = ce.resultMerger.MergeResults(correlations)
+	if correlations == nil {
+		correlations = make([]models.Correlation, 0)
+	}
+	summary := ce.createCorrelationSummary(correlations, elapsed)
+	if summary.EnginesInvolved == nil {
+		summary.EnginesInvolved = make([]models.QueryType, 0)
+	}
+	return &models.UnifiedCorrelationResult{Correlations: correlations, Summary: summary}
 }
 
-// correlateByTimeWindow correlates results within a time window
+// correlationBytesEstimate is the approximate retained size of one
+// time-window correlation (struct, engines map and metadata).
+const correlationBytesEstimate = 512
+
+// correlateByTimeWindow correlates results within a time window. Matching
+// pairs are counted first so a run whose result would exceed
+// MaxCorrelationMemoryMB is rejected before anything is assembled.
 func (ce *CorrelationEngineImpl) correlateByTimeWindow(
 	query *models.CorrelationQuery,
 	results map[models.QueryType]*models.UnifiedResult,
-) []models.Correlation {
+) ([]models.Correlation, error) {
 	var correlations []models.Correlation
 
 	// For time-window correlation, we expect exactly 2 expressions
@@ -1174,7 +1567,7 @@ func (ce *CorrelationEngineImpl) correlateByTimeWindow(
 			ce.logger.Warn("Time-window correlation requires exactly 2 expressions",
 				"expressions_count", len(query.Expressions))
 		}
-		return correlations
+		return correlations, nil
 	}
 
 	expr1 := query.Expressions[0]
@@ -1189,18 +1582,27 @@ func (ce *CorrelationEngineImpl) correlateByTimeWindow(
 				"expr1_engine", expr1.Engine, "has_result1", exists1,
 				"expr2_engine", expr2.Engine, "has_result2", exists2)
 		}
-		return correlations
+		return correlations, nil
 	}
 
 	// Extract timestamps and data points from results
 	dataPoints1 := ce.extractDataPointsWithTimestamps(result1, expr1.Engine)
 	dataPoints2 := ce.extractDataPointsWithTimestamps(result2, expr2.Engine)
+	sortDataPointsByTime(dataPoints2)
 
-	// Find correlations within the time window
-	windowCorrelations := ce.findTimeWindowCorrelations(dataPoints1, dataPoints2, *query.TimeWindow)
+	pairs := countTimeWindowPairs(dataPoints1, dataPoints2, *query.TimeWindow)
+	if budget := int64(ce.engineCfg.MaxCorrelationMemoryMB) << 20; budget > 0 && int64(pairs)*correlationBytesEstimate > budget {
+		return nil, &MemoryBudgetError{
+			Operation: fmt.Sprintf("time-window correlation (%d matches)", pairs),
+			Estimated: int64(pairs) * correlationBytesEstimate,
+			Budget:    budget,
+			Hint:      "narrow the time range, tighten the queries or shorten the correlation window",
+		}
+	}
+	correlations = make([]models.Correlation, 0, pairs)
 
-	// Convert to correlation objects
-	for _, wc := range windowCorrelations {
+	// Emit correlations within the time window straight into the result
+	ce.forEachTimeWindowPair(dataPoints1, dataPoints2, *query.TimeWindow, func(wc timeWindowCorrelation) {
 		correlation := models.Correlation{
 			ID:         fmt.Sprintf("%s_time_window_%d", query.ID, len(correlations)+1),
 			Timestamp:  wc.Timestamp,
@@ -1221,9 +1623,9 @@ func (ce *CorrelationEngineImpl) correlateByTimeWindow(
 		}
 
 		correlations = append(correlations, correlation)
-	}
+	})
 
-	return correlations
+	return correlations, nil
 }
 
 // correlateByLabels correlates results by shared labels
@@ -1267,6 +1669,9 @@ func (ce *CorrelationEngineImpl) correlateByLabels(
 						"label_matches":    labelMatches,
 					},
 				}
+				if applied := labelNormalizations(labelMatches); len(applied) > 0 {
+					correlation.Metadata["label_normalizations"] = applied
+				}
 
 				// Add sample data from both engines (first match)
 				if len(labels1) > 0 && labels1[0].Data != nil {
@@ -1585,40 +1990,52 @@ func (ce *CorrelationEngineImpl) parseTimestamp(ts interface{}) (time.Time, erro
 	return time.Time{}, fmt.Errorf("unsupported timestamp format: %T", ts)
 }
 
-// findTimeWindowCorrelations finds correlations between two sets of data points within a time window
-func (ce *CorrelationEngineImpl) findTimeWindowCorrelations(
-	dataPoints1, dataPoints2 []timeWindowDataPoint,
+// forEachTimeWindowPair calls emit for every pair of data points within
+// timeWindow of each other. sortedDataPoints2 must be sorted by timestamp.
+func (ce *CorrelationEngineImpl) forEachTimeWindowPair(
+	dataPoints1, sortedDataPoints2 []timeWindowDataPoint,
 	timeWindow time.Duration,
-) []timeWindowCorrelation {
-	var correlations []timeWindowCorrelation
-
-	// Sort data points by timestamp for efficient correlation
-	// For simplicity, we'll do a nested loop (O(n*m) complexity)
-	// In production, this should be optimized with sorting and binary search
-
+	emit func(timeWindowCorrelation),
+) {
 	for _, dp1 := range dataPoints1 {
-		for _, dp2 := range dataPoints2 {
+		lo, hi := timeWindowBounds(sortedDataPoints2, dp1.Timestamp, timeWindow)
+		for _, dp2 := range sortedDataPoints2[lo:hi] {
 			timeDiff := dp1.Timestamp.Sub(dp2.Timestamp)
 			if timeDiff < 0 {
 				timeDiff = -timeDiff
 			}
-
-			// Check if within time window
-			if timeDiff <= timeWindow {
-				// Calculate confidence based on time proximity
-				confidence := ce.calculateTimeWindowConfidence(timeDiff, timeWindow)
-
-				correlations = append(correlations, timeWindowCorrelation{
-					Timestamp:  ce.calculateCorrelationTimestamp(dp1.Timestamp, dp2.Timestamp),
-					DataPoint1: dp1.Data,
-					DataPoint2: dp2.Data,
-					Confidence: confidence,
-				})
-			}
+			emit(timeWindowCorrelation{
+				Timestamp:  ce.calculateCorrelationTimestamp(dp1.Timestamp, dp2.Timestamp),
+				DataPoint1: dp1.Data,
+				DataPoint2: dp2.Data,
+				Confidence: ce.calculateTimeWindowConfidence(timeDiff, timeWindow),
+			})
 		}
 	}
+}
 
-	return correlations
+// countTimeWindowPairs counts the pairs forEachTimeWindowPair would emit
+// without materializing them.
+func countTimeWindowPairs(dataPoints1, sortedDataPoints2 []timeWindowDataPoint, timeWindow time.Duration) int {
+	n := 0
+	for _, dp1 := range dataPoints1 {
+		lo, hi := timeWindowBounds(sortedDataPoints2, dp1.Timestamp, timeWindow)
+		n += hi - lo
+	}
+	return n
+}
+
+// timeWindowBounds returns the index range of sorted points within
+// timeWindow of t (inclusive on both ends).
+func timeWindowBounds(sorted []timeWindowDataPoint, t time.Time, timeWindow time.Duration) (int, int) {
+	from, to := t.Add(-timeWindow), t.Add(timeWindow)
+	lo := sort.Search(len(sorted), func(i int) bool { return !sorted[i].Timestamp.Before(from) })
+	hi := sort.Search(len(sorted), func(i int) bool { return sorted[i].Timestamp.After(to) })
+	return lo, hi
+}
+
+func sortDataPointsByTime(points []timeWindowDataPoint) {
+	sort.SliceStable(points, func(i, j int) bool { return points[i].Timestamp.Before(points[j].Timestamp) })
 }
 
 // calculateTimeWindowConfidence calculates confidence based on time proximity
@@ -1659,6 +2076,11 @@ type labelMatch struct {
 	Key    string
 	Value  string
 	Weight float64 // Importance weight for this label match
+	// MatchedValue and Normalizations are set when the values only matched
+	// after normalization: the other side's raw value and the
+	// normalizations applied.
+	MatchedValue   string   `json:",omitempty"`
+	Normalizations []string `json:",omitempty"`
 }
 
 // extractLabelsFromResult extracts labels from a unified result
@@ -1882,34 +2304,35 @@ func (ce *CorrelationEngineImpl) getServiceLabelFromMap(obj map[string]interface
 func (ce *CorrelationEngineImpl) findLabelMatches(labels1, labels2 []dataLabels) []labelMatch {
 	var matches []labelMatch
 
-	// Define label weights (importance for correlation)
-	labelWeights := map[string]float64{
-		"service":    1.0,
-		"pod":        0.9,
-		"namespace":  0.8,
-		"deployment": 0.8,
-		"container":  0.7,
-		"operation":  0.8,
-		"host":       0.6,
-		"level":      0.3, // Less important for correlation
-	}
+	weights, fallback, _ := ce.labelMatchWeights()
 
 	// For each data point in first set, find matches in second set
 	for _, dl1 := range labels1 {
 		for _, dl2 := range labels2 {
 			for key1, value1 := range dl1.Labels {
-				if value2, exists := dl2.Labels[key1]; exists && value1 == value2 {
-					weight := labelWeights[key1]
-					if weight == 0 {
-						weight = 0.5 // Default weight for unknown labels
-					}
+				value2, exists := dl2.Labels[key1]
+				if !exists {
+					continue
+				}
+				normalizations, ok := ce.matchLabelValues(key1, value1, value2)
+				if !ok {
+					continue
+				}
+				weight, listed := weights[key1]
+				if !listed {
+					weight = fallback
+				}
 
-					matches = append(matches, labelMatch{
-						Key:    key1,
-						Value:  value1,
-						Weight: weight,
-					})
+				m := labelMatch{
+					Key:    key1,
+					Value:  value1,
+					Weight: weight,
 				}
+				if len(normalizations) > 0 {
+					m.MatchedValue = value2
+					m.Normalizations = normalizations
+				}
+				matches = append(matches, m)
 			}
 		}
 	}
@@ -1917,31 +2340,108 @@ func (ce *CorrelationEngineImpl) findLabelMatches(labels1, labels2 []dataLabels)
 	return matches
 }
 
+// Label value normalizations, reported per match in label_matches and per
+// correlation in label_normalizations.
+const (
+	labelNormalizeCase      = "case"
+	labelNormalizePodSuffix = "pod_suffix"
+	labelNormalizeShortHost = "short_hostname"
+)
+
+// matchLabelValues reports whether two values of the canonical label key
+// match, either exactly or after the normalizations enabled in
+// EngineConfig.LabelMatch, and which normalizations the match needed.
+// Normalizations are applied in order until the values are equal.
+func (ce *CorrelationEngineImpl) matchLabelValues(key, a, b string) ([]string, bool) {
+	if a == b {
+		return nil, true
+	}
+	lm := ce.engineCfg.LabelMatch
+	var applied []string
+	apply := func(name string, fn func(string) string) bool {
+		na, nb := fn(a), fn(b)
+		if na == a && nb == b {
+			return false
+		}
+		a, b = na, nb
+		applied = append(applied, name)
+		return a == b
+	}
+	if lm.IgnoreCase && apply(labelNormalizeCase, strings.ToLower) {
+		return applied, true
+	}
+	if key == "pod" && lm.StripPodSuffix && apply(labelNormalizePodSuffix, func(v string) string { return kubernetesWorkload("Pod", v) }) {
+		return applied, true
+	}
+	if key == "host" && lm.ShortHostnames && apply(labelNormalizeShortHost, shortHostname) {
+		return applied, true
+	}
+	return nil, false
+}
+
+// shortHostname returns the first label of a hostname; IP addresses are
+// returned unchanged.
+func shortHostname(host string) string {
+	if net.ParseIP(host) != nil {
+		return host
+	}
+	if i := strings.IndexByte(host, '.'); i > 0 {
+		return host[:i]
+	}
+	return host
+}
+
+// labelMatchWeights returns the configured per-label weights (or
+// DefaultLabelWeights when none are configured), the weight of unlisted
+// labels and the summed weight of the significant labels.
+func (ce *CorrelationEngineImpl) labelMatchWeights() (map[string]float64, float64, float64) {
+	lm := ce.engineCfg.LabelMatch
+	list := lm.Weights
+	if len(list) == 0 {
+		list = config.DefaultLabelWeights
+	}
+	fallback := lm.DefaultWeight
+	if fallback <= 0 {
+		fallback = 0.5
+	}
+	weights := make(map[string]float64, len(list))
+	significant := 0.0
+	for _, w := range list {
+		weights[w.Label] = w.Weight
+		if w.Significant {
+			significant += w.Weight
+		}
+	}
+	return weights, fallback, significant
+}
+
+// labelNormalizations returns the distinct normalizations the matches
+// needed, sorted.
+func labelNormalizations(matches []labelMatch) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, m := range matches {
+		for _, n := range m.Normalizations {
+			if !seen[n] {
+				seen[n] = true
+				out = append(out, n)
+			}
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
 // calculateLabelMatchConfidence calculates confidence based on label matches
 func (ce *CorrelationEngineImpl) calculateLabelMatchConfidence(matches []labelMatch) float64 {
 	if len(matches) == 0 {
 		return 0.0
 	}
 
-	// Calculate weighted confidence
-	totalWeight := 0.0
+	// Normalize by the weight of all significant labels
+	_, _, totalWeight := ce.labelMatchWeights()
 	matchedWeight := 0.0
 
-	// Define all possible important labels for normalization
-	allImportantLabels := []string{"service", "pod", "namespace", "deployment", "container", "operation"}
-	for _, label := range allImportantLabels {
-		if weight, exists := map[string]float64{
-			"service":    1.0,
-			"pod":        0.9,
-			"namespace":  0.8,
-			"deployment": 0.8,
-			"container":  0.7,
-			"operation":  0.8,
-		}[label]; exists {
-			totalWeight += weight
-		}
-	}
-
 	// Sum weights of matched labels
 	for _, match := range matches {
 		matchedWeight += match.Weight
@@ -3469,6 +3969,27 @@ func (ce *CorrelationEngineImpl) removeDuplicates(slice []string) []string {
 	return result
 }
 
+// startEngineQuerySpan starts the span of one engine's share of a correlation
+// query; backend client spans nest under it.
+func (ce *CorrelationEngineImpl) startEngineQuerySpan(ctx context.Context, engine models.QueryType, expressions []models.CorrelationExpression) (context.Context, trace.Span) {
+	if ce.tracer == nil {
+		return ctx, noop.Span{}
+	}
+	queries := make([]string, len(expressions))
+	for i, expr := range expressions {
+		queries[i] = expr.Query
+	}
+	return ce.tracer.StartEngineQuerySpan(ctx, string(engine), strings.Join(queries, "; "))
+}
+
+// startCorrelationMatchSpan starts the span for correlating engine results.
+func (ce *CorrelationEngineImpl) startCorrelationMatchSpan(ctx context.Context, engineSpans []trace.SpanContext) (context.Context, trace.Span) {
+	if ce.tracer == nil {
+		return ctx, noop.Span{}
+	}
+	return ce.tracer.StartCorrelationMatchSpan(ctx, engineSpans)
+}
+
 // startCorrelationSpan starts a tracing span for correlation operations
 func (ce *CorrelationEngineImpl) startCorrelationSpan(ctx context.Context, query *models.CorrelationQuery) (context.Context, trace.Span) {
 	if ce.tracer == nil {
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -87,6 +88,8 @@ func TestCorrelationEngine_KPIDiscoveryAndLabelExtraction(t *testing.T) {
 	mockLogs := &MockVictoriaLogsService{}
 	mockTraces := &MockVictoriaTracesService{}
 	mockCache := &MockValkeyCluster{}
+	mockCache.On("Get", mock.Anything, "cfg:"+DynamicConfigCorrelationSuppressions).Return([]byte(nil), nil)
+	mockCache.On("Get", mock.Anything, mock.MatchedBy(func(key string) bool { return strings.HasPrefix(key, signalEventsKeyPrefix) })).Return([]byte(nil), nil)
 	mockLogger := logger.New("info")
 	mockKPIRepo := &MockKPIRepoForTest{}
 
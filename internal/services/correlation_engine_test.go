@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -130,6 +131,16 @@ func (m *MockValkeyCluster) CleanupExpiredEntries(ctx context.Context, keyPatter
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockValkeyCluster) ScanKeys(ctx context.Context, prefix string, fn func(keys []string) error) error {
+	args := m.Called(ctx, prefix, fn)
+	return args.Error(0)
+}
+
+func (m *MockValkeyCluster) DeleteByPrefix(ctx context.Context, prefix string) (int64, error) {
+	args := m.Called(ctx, prefix)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func TestCorrelationEngineImpl_ExecuteCorrelation(t *testing.T) {
 	// Setup mocks
 	mockMetrics := &MockVictoriaMetricsService{}
@@ -363,6 +374,8 @@ func TestCorrelationEngineImpl_Correlate(t *testing.T) {
 	mockLogs := &MockVictoriaLogsService{}
 	mockTraces := &MockVictoriaTracesService{}
 	mockCache := &MockValkeyCluster{}
+	mockCache.On("Get", mock.Anything, "cfg:"+DynamicConfigCorrelationSuppressions).Return([]byte(nil), nil)
+	mockCache.On("Get", mock.Anything, mock.MatchedBy(func(key string) bool { return strings.HasPrefix(key, signalEventsKeyPrefix) })).Return([]byte(nil), nil)
 	mockLogger := logger.New("info")
 
 	// NOTE(HCB-001): Since we removed hardcoded probes, tests must explicitly provide
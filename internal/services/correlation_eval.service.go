@@ -0,0 +1,344 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const (
+	correlationRunKeyPrefix      = "correlation:run:"
+	correlationRunIndexKey       = "correlation:runs"
+	correlationFeedbackKeyPrefix = "correlation:feedback:"
+	correlationRunIndexLockKey   = "correlation:runs:lock"
+
+	// correlationRunTTL expires recorded runs and their feedback that an
+	// index update failed to trim, so they cannot pile up in Valkey.
+	correlationRunTTL = 30 * 24 * time.Hour
+
+	// correlationRunLockTTL bounds how long a crashed replica can hold the
+	// run index; correlationRunLockWait is how long a save waits for it.
+	correlationRunLockTTL  = 10 * time.Second
+	correlationRunLockWait = 2 * time.Second
+
+	defaultEvalLimit = 100
+)
+
+var (
+	ErrCorrelationRunNotFound = errors.New("correlation run not found")
+	ErrCorrelationRunsBusy    = errors.New("correlation run index is locked by another replica")
+)
+
+// CorrelationEvalService stores operator feedback on correlation runs and
+// replays recorded runs under alternative scoring configurations.
+type CorrelationEvalService struct {
+	cache     cache.ValkeyCluster
+	engineCfg config.EngineConfig
+	logger    logging.Logger
+}
+
+// NewCorrelationEvalService creates a new correlation evaluation service.
+// engineCfg is the running configuration that variants are applied to.
+func NewCorrelationEvalService(cache cache.ValkeyCluster, engineCfg config.EngineConfig, logger corelogger.Logger) *CorrelationEvalService {
+	return &CorrelationEvalService{
+		cache:     cache,
+		engineCfg: config.MergeEngineConfigWithDefaults(engineCfg),
+		logger:    logging.FromCoreLogger(logger),
+	}
+}
+
+// RecordFeedback stores an operator label for a recorded run, replacing any
+// earlier label.
+func (s *CorrelationEvalService) RecordFeedback(ctx context.Context, req models.FeedbackRequest, submittedBy string) (*models.CorrelationFeedback, error) {
+	if _, err := loadCorrelationRun(ctx, s.cache, req.CorrelationID); err != nil {
+		return nil, err
+	}
+	fb := &models.CorrelationFeedback{
+		CorrelationID: req.CorrelationID,
+		Correct:       req.Correct,
+		CauseKPI:      strings.TrimSpace(req.CauseKPI),
+		Notes:         req.Notes,
+		SubmittedBy:   submittedBy,
+		SubmittedAt:   time.Now().UTC(),
+	}
+	if err := s.cache.Set(ctx, correlationFeedbackKeyPrefix+req.CorrelationID, fb, correlationRunTTL); err != nil {
+		return nil, fmt.Errorf("store feedback: %w", err)
+	}
+	return fb, nil
+}
+
+// Evaluate re-scores the newest recorded runs under variants A and B and
+// compares the rankings with each other and with operator feedback.
+func (s *CorrelationEvalService) Evaluate(ctx context.Context, req models.CorrelationEvalRequest) (*models.CorrelationEvalReport, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultEvalLimit
+	}
+	ids, err := loadCorrelationRunIndex(ctx, s.cache)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	cfgA, cfgB := applyEvalVariant(s.engineCfg, req.A), applyEvalVariant(s.engineCfg, req.B)
+	report := &models.CorrelationEvalReport{
+		A:           evalVariantSummary(req.A.Name, "a", cfgA),
+		B:           evalVariantSummary(req.B.Name, "b", cfgB),
+		PerRun:      []models.CorrelationEvalRun{},
+		GeneratedAt: time.Now().UTC(),
+	}
+	var tauSum, rrA, rrB float64
+	agreeA, agreeB, withCause := 0, 0, 0
+	for _, id := range ids {
+		run, err := loadCorrelationRun(ctx, s.cache, id)
+		if err != nil || len(run.Candidates) == 0 {
+			continue
+		}
+		rankA, scoresA := rankRunCandidates(run, cfgA)
+		rankB, scoresB := rankRunCandidates(run, cfgB)
+		r := models.CorrelationEvalRun{
+			CorrelationID: id,
+			Candidates:    len(run.Candidates),
+			TopA:          rankA[0],
+			TopB:          rankB[0],
+			KendallTau:    math.Round(kendallTau(scoresA, scoresB)*1000) / 1000,
+		}
+		report.Runs++
+		tauSum += r.KendallTau
+		if r.TopA != r.TopB {
+			report.Top1Changed++
+		}
+
+		if fb := s.loadFeedback(ctx, id); fb != nil {
+			r.Labeled = true
+			report.LabeledRuns++
+			cause := labelledCause(run, fb)
+			if cause != "" {
+				withCause++
+				r.LabelRankA, r.LabelRankB = indexOf(rankA, cause)+1, indexOf(rankB, cause)+1
+				if r.LabelRankA > 0 {
+					rrA += 1 / float64(r.LabelRankA)
+				}
+				if r.LabelRankB > 0 {
+					rrB += 1 / float64(r.LabelRankB)
+				}
+				if r.TopA == cause {
+					agreeA++
+				}
+				if r.TopB == cause {
+					agreeB++
+				}
+			} else {
+				// Labelled wrong without naming the cause: agreement means
+				// not ranking the rejected cause first.
+				if r.TopA != run.TopCause {
+					agreeA++
+				}
+				if r.TopB != run.TopCause {
+					agreeB++
+				}
+			}
+		}
+		report.PerRun = append(report.PerRun, r)
+	}
+
+	if report.Runs > 0 {
+		report.MeanKendallTau = math.Round(tauSum/float64(report.Runs)*1000) / 1000
+	}
+	if report.LabeledRuns > 0 {
+		report.A.Top1Agreement = math.Round(float64(agreeA)/float64(report.LabeledRuns)*1000) / 1000
+		report.B.Top1Agreement = math.Round(float64(agreeB)/float64(report.LabeledRuns)*1000) / 1000
+	}
+	if withCause > 0 {
+		report.A.MeanReciprocalRank = math.Round(rrA/float64(withCause)*1000) / 1000
+		report.B.MeanReciprocalRank = math.Round(rrB/float64(withCause)*1000) / 1000
+	}
+	return report, nil
+}
+
+func (s *CorrelationEvalService) loadFeedback(ctx context.Context, id string) *models.CorrelationFeedback {
+	data, err := s.cache.Get(ctx, correlationFeedbackKeyPrefix+id)
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+	var fb models.CorrelationFeedback
+	if err := json.Unmarshal(data, &fb); err != nil {
+		return nil
+	}
+	return &fb
+}
+
+// labelledCause returns the KPI ID the feedback names as the cause: the named
+// cause KPI (matched by ID or name), else the run's top cause when it was
+// marked correct, else "".
+func labelledCause(run *models.CorrelationRunRecord, fb *models.CorrelationFeedback) string {
+	if fb.CauseKPI != "" {
+		for _, c := range run.Candidates {
+			if c.KPIID == fb.CauseKPI || strings.EqualFold(c.KPI, fb.CauseKPI) {
+				return c.KPIID
+			}
+		}
+		return fb.CauseKPI
+	}
+	if fb.Correct {
+		return run.TopCause
+	}
+	return ""
+}
+
+func applyEvalVariant(cfg config.EngineConfig, v models.CorrelationEvalVariant) config.EngineConfig {
+	set := func(dst *float64, src *float64) {
+		if src != nil {
+			*dst = *src
+		}
+	}
+	set(&cfg.MinCorrelation, v.MinCorrelation)
+	set(&cfg.Scoring.Pearson, v.Pearson)
+	set(&cfg.Scoring.Spearman, v.Spearman)
+	set(&cfg.Scoring.CrossCorr, v.CrossCorr)
+	set(&cfg.Scoring.LagBonus, v.LagBonus)
+	set(&cfg.Scoring.AnomalyDensity, v.AnomalyDensity)
+	return cfg
+}
+
+func evalVariantSummary(name, fallback string, cfg config.EngineConfig) models.CorrelationEvalVariantSummary {
+	if name == "" {
+		name = fallback
+	}
+	return models.CorrelationEvalVariantSummary{
+		Name:           name,
+		MinCorrelation: cfg.MinCorrelation,
+		Pearson:        cfg.Scoring.Pearson,
+		Spearman:       cfg.Scoring.Spearman,
+		CrossCorr:      cfg.Scoring.CrossCorr,
+		LagBonus:       cfg.Scoring.LagBonus,
+		AnomalyDensity: cfg.Scoring.AnomalyDensity,
+	}
+}
+
+// rankRunCandidates scores a run's candidates under cfg and returns their
+// KPI IDs by descending score, plus the scores in candidate order.
+func rankRunCandidates(run *models.CorrelationRunRecord, cfg config.EngineConfig) ([]string, []float64) {
+	scores := make([]float64, len(run.Candidates))
+	order := make([]int, len(run.Candidates))
+	for i, c := range run.Candidates {
+		_, _, scores[i] = scoreCandidateSamples(c.Impact, c.Cause, c.Confounder, cfg)
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		if scores[order[a]] != scores[order[b]] {
+			return scores[order[a]] > scores[order[b]]
+		}
+		return run.Candidates[order[a]].KPIID < run.Candidates[order[b]].KPIID
+	})
+	ranked := make([]string, len(order))
+	for i, idx := range order {
+		ranked[i] = run.Candidates[idx].KPIID
+	}
+	return ranked, scores
+}
+
+// kendallTau is Kendall's tau-a of two score vectors; ties count as neither
+// concordant nor discordant. A single candidate ranks identically (1).
+func kendallTau(a, b []float64) float64 {
+	n := len(a)
+	if n < 2 || n != len(b) {
+		return 1
+	}
+	sum := 0.0
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			sum += sign(a[i]-a[j]) * sign(b[i]-b[j])
+		}
+	}
+	return sum / float64(n*(n-1)/2)
+}
+
+func sign(v float64) float64 {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
+
+func indexOf(items []string, s string) int {
+	for i, v := range items {
+		if v == s {
+			return i
+		}
+	}
+	return -1
+}
+
+// saveCorrelationRun stores run and keeps the newest retention runs,
+// deleting the ones that fall off the index. The index is updated under a
+// cross-replica lock; runs expire after correlationRunTTL regardless.
+func saveCorrelationRun(ctx context.Context, c cache.ValkeyCluster, run *models.CorrelationRunRecord, retention int) error {
+	if err := c.Set(ctx, correlationRunKeyPrefix+run.CorrelationID, run, correlationRunTTL); err != nil {
+		return err
+	}
+	unlock, err := lockCache(ctx, c, correlationRunIndexLockKey, correlationRunLockTTL, correlationRunLockWait, ErrCorrelationRunsBusy, nil)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	ids, err := loadCorrelationRunIndex(ctx, c)
+	if err != nil {
+		ids = nil
+	}
+	next := []string{run.CorrelationID}
+	for _, id := range ids {
+		if id != run.CorrelationID {
+			next = append(next, id)
+		}
+	}
+	if retention > 0 && len(next) > retention {
+		for _, id := range next[retention:] {
+			_ = c.Delete(ctx, correlationRunKeyPrefix+id)
+			_ = c.Delete(ctx, correlationFeedbackKeyPrefix+id)
+		}
+		next = next[:retention]
+	}
+	return c.Set(ctx, correlationRunIndexKey, next, correlationRunTTL)
+}
+
+func loadCorrelationRunIndex(ctx context.Context, c cache.ValkeyCluster) ([]string, error) {
+	data, err := c.Get(ctx, correlationRunIndexKey)
+	if err != nil || len(data) == 0 {
+		return []string{}, nil
+	}
+	var ids []string
+	if err := json.Unmarshal(data, &ids); err != nil {
+		return nil, fmt.Errorf("decode correlation run index: %w", err)
+	}
+	return ids, nil
+}
+
+func loadCorrelationRun(ctx context.Context, c cache.ValkeyCluster, id string) (*models.CorrelationRunRecord, error) {
+	data, err := c.Get(ctx, correlationRunKeyPrefix+id)
+	if err != nil || len(data) == 0 {
+		return nil, ErrCorrelationRunNotFound
+	}
+	var run models.CorrelationRunRecord
+	if err := json.Unmarshal(data, &run); err != nil {
+		return nil, fmt.Errorf("decode correlation run: %w", err)
+	}
+	return &run, nil
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func TestCorrelationEvalService_Evaluate(t *testing.T) {
+	log := logger.New("error")
+	ctx := context.Background()
+	c := cache.NewNoopValkeyCache(log)
+
+	impact := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	candidates := []models.CorrelationRunCandidate{
+		// Tracks the impact exactly but never spikes.
+		{KPIID: "corr", KPI: "Correlated", Impact: impact, Cause: impact},
+		// Weakly correlated with one anomalous ring.
+		{KPIID: "spike", KPI: "Spiky", Impact: impact, Cause: []float64{0, 0, 0, 0, 0, 0, 0, 0, 0, 10}},
+	}
+	for _, id := range []string{"run-1", "run-2", "run-3"} {
+		run := &models.CorrelationRunRecord{CorrelationID: id, Candidates: candidates, TopCause: "corr", CreatedAt: time.Now()}
+		if err := saveCorrelationRun(ctx, c, run, 10); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	svc := NewCorrelationEvalService(c, config.EngineConfig{}, log)
+	if _, err := svc.RecordFeedback(ctx, models.FeedbackRequest{CorrelationID: "missing"}, ""); !errors.Is(err, ErrCorrelationRunNotFound) {
+		t.Fatalf("expected ErrCorrelationRunNotFound, got %v", err)
+	}
+	for _, fb := range []models.FeedbackRequest{
+		{CorrelationID: "run-1", CauseKPI: "Spiky"}, // named by KPI name
+		{CorrelationID: "run-2", Correct: true},     // top cause confirmed
+		{CorrelationID: "run-3", Correct: false},    // top cause rejected
+	} {
+		if _, err := svc.RecordFeedback(ctx, fb, "oncall"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	zero, one := 0.0, 1.0
+	report, err := svc.Evaluate(ctx, models.CorrelationEvalRequest{
+		A: models.CorrelationEvalVariant{Name: "current"},
+		B: models.CorrelationEvalVariant{Name: "anomaly-only", Pearson: &zero, Spearman: &zero, CrossCorr: &zero, LagBonus: &zero, AnomalyDensity: &one},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Runs != 3 || report.LabeledRuns != 3 || report.Top1Changed != 3 || report.MeanKendallTau != -1 {
+		t.Fatalf("unexpected report totals: %+v", report)
+	}
+	if r := report.PerRun[2]; r.CorrelationID != "run-1" || r.TopA != "corr" || r.TopB != "spike" || r.LabelRankA != 2 || r.LabelRankB != 1 {
+		t.Fatalf("unexpected run-1 comparison: %+v", r)
+	}
+	if report.A.Pearson != config.DefaultScoringWeights.Pearson || report.B.Pearson != 0 {
+		t.Fatalf("expected variant weights in summaries: %+v / %+v", report.A, report.B)
+	}
+	// A agrees with run-2 only; B with run-1 and run-3.
+	if report.A.Top1Agreement != 0.333 || report.B.Top1Agreement != 0.667 {
+		t.Fatalf("unexpected top-1 agreement: A=%v B=%v", report.A.Top1Agreement, report.B.Top1Agreement)
+	}
+	if report.A.MeanReciprocalRank != 0.75 || report.B.MeanReciprocalRank != 0.75 {
+		t.Fatalf("unexpected MRR: A=%v B=%v", report.A.MeanReciprocalRank, report.B.MeanReciprocalRank)
+	}
+}
+
+func TestSaveCorrelationRun_Retention(t *testing.T) {
+	log := logger.New("error")
+	ctx := context.Background()
+	c := cache.NewNoopValkeyCache(log)
+
+	for _, id := range []string{"a", "b", "c"} {
+		if err := saveCorrelationRun(ctx, c, &models.CorrelationRunRecord{CorrelationID: id}, 2); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ids, err := loadCorrelationRunIndex(ctx, c)
+	if err != nil || len(ids) != 2 || ids[0] != "c" || ids[1] != "b" {
+		t.Fatalf("expected newest two runs, got %v (%v)", ids, err)
+	}
+	if _, err := loadCorrelationRun(ctx, c, "a"); !errors.Is(err, ErrCorrelationRunNotFound) {
+		t.Fatalf("expected the oldest run to be dropped, got %v", err)
+	}
+}
+
+// ttlCache records the TTL of every Set.
+type ttlCache struct {
+	*lockingCache
+	ttlMu sync.Mutex
+	ttls  map[string]time.Duration
+}
+
+func (c *ttlCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	c.ttlMu.Lock()
+	c.ttls[key] = ttl
+	c.ttlMu.Unlock()
+	return c.lockingCache.Set(ctx, key, value, ttl)
+}
+
+func TestSaveCorrelationRun_ConcurrentReplicas(t *testing.T) {
+	ctx := context.Background()
+	c := &ttlCache{lockingCache: newLockingCache(), ttls: map[string]time.Duration{}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := saveCorrelationRun(ctx, c, &models.CorrelationRunRecord{CorrelationID: fmt.Sprintf("run-%d", i)}, 100); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	ids, err := loadCorrelationRunIndex(ctx, c)
+	if err != nil || len(ids) != 10 {
+		t.Fatalf("expected every concurrently saved run in the index, got %v (%v)", ids, err)
+	}
+	for key, ttl := range c.ttls {
+		if ttl != correlationRunTTL {
+			t.Errorf("expected %s to expire after %v, got %v", key, correlationRunTTL, ttl)
+		}
+	}
+}
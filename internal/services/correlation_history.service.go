@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/weavstore"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const (
+	defaultCorrelationHistoryLimit = 50
+	maxCorrelationHistoryLimit     = 500
+)
+
+var (
+	ErrCorrelationHistoryNotFound    = errors.New("correlation result not found")
+	ErrCorrelationHistoryUnavailable = errors.New("correlation history requires Weaviate")
+	ErrInvalidCorrelationAnnotation  = errors.New("invalid annotation: verdict must be 'confirmed' or 'false_positive'")
+)
+
+// CorrelationHistoryStore persists correlation results.
+type CorrelationHistoryStore interface {
+	PutCorrelationHistory(ctx context.Context, r *weavstore.CorrelationHistoryRecord) error
+	GetCorrelationHistory(ctx context.Context, tenant, correlationID string) (*weavstore.CorrelationHistoryRecord, error)
+	ListCorrelationHistory(ctx context.Context, q weavstore.CorrelationHistoryQuery) ([]*weavstore.CorrelationHistoryRecord, error)
+}
+
+// CorrelationHistoryRecorder stores the result of a correlation run;
+// CorrelationHistoryService satisfies it.
+type CorrelationHistoryRecorder interface {
+	Record(ctx context.Context, tr models.TimeRange, corr *models.CorrelationResult) error
+}
+
+// CorrelationHistoryService keeps the results of past correlation runs, and
+// so of the RCA built from them, so responders can look them up by incident
+// and annotate them as confirmed or false positives.
+//
+// History is the durable record of results and verdicts. The Valkey run
+// records of CorrelationEvalService (correlation:run:<id>) are a separate,
+// bounded store of scoring inputs, kept for the newest engine.run_retention
+// runs, that evaluation replays, share links and comments read. Both are
+// keyed by correlation ID and written at the end of the same run. Annotate
+// is the only place they meet: while the run record is retained, the
+// verdict is also stored as its feedback.
+type CorrelationHistoryService struct {
+	store    CorrelationHistoryStore
+	feedback *CorrelationEvalService
+	tenant   string
+	logger   logging.Logger
+}
+
+// NewCorrelationHistoryService creates a new correlation history service.
+// store may be nil, in which case nothing is stored and lookups return
+// ErrCorrelationHistoryUnavailable; feedback may be nil.
+func NewCorrelationHistoryService(store CorrelationHistoryStore, feedback *CorrelationEvalService, tenant string, logger corelogger.Logger) *CorrelationHistoryService {
+	return &CorrelationHistoryService{
+		store:    store,
+		feedback: feedback,
+		tenant:   tenant,
+		logger:   logging.FromCoreLogger(logger),
+	}
+}
+
+// Enabled reports whether results are stored.
+func (s *CorrelationHistoryService) Enabled() bool {
+	return s.store != nil
+}
+
+// Record stores the result of a correlation run over tr.
+func (s *CorrelationHistoryService) Record(ctx context.Context, tr models.TimeRange, corr *models.CorrelationResult) error {
+	if s.store == nil {
+		return ErrCorrelationHistoryUnavailable
+	}
+	if corr == nil {
+		return nil
+	}
+	data, err := json.Marshal(corr)
+	if err != nil {
+		return fmt.Errorf("encode correlation result: %w", err)
+	}
+	r := &weavstore.CorrelationHistoryRecord{
+		CorrelationID:    corr.CorrelationID,
+		Tenant:           s.tenant,
+		RootCause:        corr.RootCause,
+		Confidence:       corr.Confidence,
+		AffectedServices: corr.AffectedServices,
+		StartTime:        tr.Start,
+		EndTime:          tr.End,
+		Result:           string(data),
+		CreatedAt:        corr.CreatedAt,
+	}
+	if corr.IncidentID != "" {
+		r.IncidentIDs = []string{corr.IncidentID}
+	}
+	if r.CreatedAt.IsZero() {
+		r.CreatedAt = time.Now().UTC()
+	}
+	return s.store.PutCorrelationHistory(ctx, r)
+}
+
+// List returns the stored results matching filter, newest first, without
+// their full result.
+func (s *CorrelationHistoryService) List(ctx context.Context, filter models.CorrelationHistoryFilter) ([]models.CorrelationHistoryEntry, error) {
+	if s.store == nil {
+		return nil, ErrCorrelationHistoryUnavailable
+	}
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultCorrelationHistoryLimit
+	}
+	records, err := s.store.ListCorrelationHistory(ctx, weavstore.CorrelationHistoryQuery{
+		Tenant:     s.tenant,
+		IncidentID: filter.IncidentID,
+		Verdict:    filter.Verdict,
+		Since:      filter.Since,
+		Limit:      min(limit, maxCorrelationHistoryLimit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]models.CorrelationHistoryEntry, 0, len(records))
+	for _, r := range records {
+		out = append(out, *correlationHistoryEntry(r))
+	}
+	return out, nil
+}
+
+// Get returns a stored result with its full correlation result.
+func (s *CorrelationHistoryService) Get(ctx context.Context, correlationID string) (*models.CorrelationHistoryEntry, error) {
+	r, err := s.get(ctx, correlationID)
+	if err != nil {
+		return nil, err
+	}
+	entry := correlationHistoryEntry(r)
+	if r.Result != "" {
+		var result models.CorrelationResult
+		if err := json.Unmarshal([]byte(r.Result), &result); err != nil {
+			return nil, fmt.Errorf("decode correlation result: %w", err)
+		}
+		entry.Result = &result
+	}
+	return entry, nil
+}
+
+// Annotate sets the verdict of user on a stored result, replacing any
+// earlier one, and links it to the given incidents.
+func (s *CorrelationHistoryService) Annotate(ctx context.Context, correlationID string, req models.CorrelationAnnotationRequest, user string) (*models.CorrelationHistoryEntry, error) {
+	if req.Verdict != models.CorrelationVerdictConfirmed && req.Verdict != models.CorrelationVerdictFalsePositive {
+		return nil, ErrInvalidCorrelationAnnotation
+	}
+	r, err := s.get(ctx, correlationID)
+	if err != nil {
+		return nil, err
+	}
+	r.Verdict = req.Verdict
+	r.CauseKPI = strings.TrimSpace(req.CauseKPI)
+	r.Notes = req.Notes
+	r.AnnotatedBy = user
+	r.AnnotatedAt = time.Now().UTC()
+	for _, id := range req.IncidentIDs {
+		if id = strings.TrimSpace(id); id != "" && !slices.Contains(r.IncidentIDs, id) {
+			r.IncidentIDs = append(r.IncidentIDs, id)
+		}
+	}
+	if err := s.store.PutCorrelationHistory(ctx, r); err != nil {
+		return nil, err
+	}
+
+	if s.feedback != nil {
+		_, err := s.feedback.RecordFeedback(ctx, models.FeedbackRequest{
+			CorrelationID: correlationID,
+			Correct:       req.Verdict == models.CorrelationVerdictConfirmed,
+			CauseKPI:      req.CauseKPI,
+			Notes:         req.Notes,
+		}, user)
+		if err != nil && !errors.Is(err, ErrCorrelationRunNotFound) {
+			s.logger.Warn("Failed to store feedback for annotated correlation", "correlation_id", correlationID, "error", err)
+		}
+	}
+	return correlationHistoryEntry(r), nil
+}
+
+func (s *CorrelationHistoryService) get(ctx context.Context, correlationID string) (*weavstore.CorrelationHistoryRecord, error) {
+	if s.store == nil {
+		return nil, ErrCorrelationHistoryUnavailable
+	}
+	r, err := s.store.GetCorrelationHistory(ctx, s.tenant, correlationID)
+	if err != nil {
+		return nil, err
+	}
+	if r == nil {
+		return nil, ErrCorrelationHistoryNotFound
+	}
+	return r, nil
+}
+
+func correlationHistoryEntry(r *weavstore.CorrelationHistoryRecord) *models.CorrelationHistoryEntry {
+	entry := &models.CorrelationHistoryEntry{
+		CorrelationID:    r.CorrelationID,
+		IncidentIDs:      r.IncidentIDs,
+		TimeRange:        models.TimeRange{Start: r.StartTime, End: r.EndTime},
+		RootCause:        r.RootCause,
+		Confidence:       r.Confidence,
+		AffectedServices: r.AffectedServices,
+		CreatedAt:        r.CreatedAt,
+	}
+	if r.Verdict != "" {
+		entry.Annotation = &models.CorrelationAnnotation{
+			Verdict:     r.Verdict,
+			CauseKPI:    r.CauseKPI,
+			Notes:       r.Notes,
+			AnnotatedBy: r.AnnotatedBy,
+			AnnotatedAt: r.AnnotatedAt,
+		}
+	}
+	return entry
+}
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/weavstore"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+type memHistoryStore struct {
+	records map[string]weavstore.CorrelationHistoryRecord
+}
+
+func (m *memHistoryStore) PutCorrelationHistory(ctx context.Context, r *weavstore.CorrelationHistoryRecord) error {
+	m.records[r.Tenant+"|"+r.CorrelationID] = *r
+	return nil
+}
+
+func (m *memHistoryStore) GetCorrelationHistory(ctx context.Context, tenant, correlationID string) (*weavstore.CorrelationHistoryRecord, error) {
+	r, ok := m.records[tenant+"|"+correlationID]
+	if !ok {
+		return nil, nil
+	}
+	return &r, nil
+}
+
+func (m *memHistoryStore) ListCorrelationHistory(ctx context.Context, q weavstore.CorrelationHistoryQuery) ([]*weavstore.CorrelationHistoryRecord, error) {
+	var out []*weavstore.CorrelationHistoryRecord
+	for _, r := range m.records {
+		switch {
+		case r.Tenant != q.Tenant,
+			q.IncidentID != "" && !slices.Contains(r.IncidentIDs, q.IncidentID),
+			q.Verdict != "" && r.Verdict != q.Verdict,
+			!q.Since.IsZero() && r.CreatedAt.Before(q.Since):
+			continue
+		}
+		r.Result = ""
+		out = append(out, &r)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	if q.Limit > 0 && len(out) > q.Limit {
+		out = out[:q.Limit]
+	}
+	return out, nil
+}
+
+func TestCorrelationHistoryService(t *testing.T) {
+	ctx := context.Background()
+	log := logger.New("error")
+	c := cache.NewNoopValkeyCache(log)
+	store := &memHistoryStore{records: map[string]weavstore.CorrelationHistoryRecord{}}
+	svc := NewCorrelationHistoryService(store, NewCorrelationEvalService(c, config.EngineConfig{}, log), "acme", log)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	tr := models.TimeRange{Start: now.Add(-time.Hour), End: now}
+	for i, id := range []string{"corr_1", "corr_2", "corr_3"} {
+		corr := &models.CorrelationResult{
+			CorrelationID: id,
+			RootCause:     "db_latency",
+			Confidence:    0.8,
+			Causes:        []models.CauseCandidate{{KPI: "db_latency", SuspicionScore: 0.8}},
+			CreatedAt:     now.Add(time.Duration(i) * time.Minute),
+		}
+		if id == "corr_1" {
+			corr.IncidentID = "INC-1"
+		}
+		if err := svc.Record(ctx, tr, corr); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, err := svc.List(ctx, models.CorrelationHistoryFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 || entries[0].CorrelationID != "corr_3" || entries[0].Result != nil {
+		t.Fatalf("expected 3 summaries newest first, got %+v", entries)
+	}
+	if entries, _ := svc.List(ctx, models.CorrelationHistoryFilter{IncidentID: "INC-1"}); len(entries) != 1 || entries[0].CorrelationID != "corr_1" {
+		t.Fatalf("expected corr_1 for INC-1, got %+v", entries)
+	}
+	if entries, _ := svc.List(ctx, models.CorrelationHistoryFilter{Limit: 2}); len(entries) != 2 {
+		t.Fatalf("expected limit 2, got %d", len(entries))
+	}
+
+	entry, err := svc.Get(ctx, "corr_2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Result == nil || len(entry.Result.Causes) != 1 || !entry.TimeRange.End.Equal(now) {
+		t.Fatalf("expected the full result, got %+v", entry)
+	}
+	if _, err := svc.Get(ctx, "missing"); !errors.Is(err, ErrCorrelationHistoryNotFound) {
+		t.Fatalf("expected ErrCorrelationHistoryNotFound, got %v", err)
+	}
+
+	if _, err := svc.Annotate(ctx, "corr_2", models.CorrelationAnnotationRequest{Verdict: "maybe"}, "alice"); !errors.Is(err, ErrInvalidCorrelationAnnotation) {
+		t.Fatalf("expected ErrInvalidCorrelationAnnotation, got %v", err)
+	}
+
+	// A verdict on a recorded run is also stored as feedback.
+	if err := saveCorrelationRun(ctx, c, &models.CorrelationRunRecord{CorrelationID: "corr_2"}, 10); err != nil {
+		t.Fatal(err)
+	}
+	entry, err = svc.Annotate(ctx, "corr_2", models.CorrelationAnnotationRequest{
+		Verdict:     models.CorrelationVerdictFalsePositive,
+		CauseKPI:    "cache_misses",
+		IncidentIDs: []string{"INC-2", "INC-2"},
+	}, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Annotation == nil || entry.Annotation.Verdict != models.CorrelationVerdictFalsePositive || entry.Annotation.AnnotatedBy != "alice" {
+		t.Fatalf("unexpected annotation %+v", entry.Annotation)
+	}
+	if len(entry.IncidentIDs) != 1 || entry.IncidentIDs[0] != "INC-2" {
+		t.Fatalf("expected incident INC-2 linked once, got %v", entry.IncidentIDs)
+	}
+	fb := NewCorrelationEvalService(c, config.EngineConfig{}, log).loadFeedback(ctx, "corr_2")
+	if fb == nil || fb.Correct || fb.CauseKPI != "cache_misses" || fb.SubmittedBy != "alice" {
+		t.Fatalf("expected feedback from the annotation, got %+v", fb)
+	}
+
+	// Runs without recorded inputs are still annotated.
+	if _, err := svc.Annotate(ctx, "corr_3", models.CorrelationAnnotationRequest{Verdict: models.CorrelationVerdictConfirmed}, "bob"); err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ := svc.List(ctx, models.CorrelationHistoryFilter{Verdict: models.CorrelationVerdictConfirmed}); len(entries) != 1 || entries[0].CorrelationID != "corr_3" {
+		t.Fatalf("expected corr_3 confirmed, got %+v", entries)
+	}
+}
+
+func TestCorrelationHistoryService_WithoutStore(t *testing.T) {
+	svc := NewCorrelationHistoryService(nil, nil, "acme", logger.New("error"))
+	if svc.Enabled() {
+		t.Fatal("expected history disabled without a store")
+	}
+	if _, err := svc.List(context.Background(), models.CorrelationHistoryFilter{}); !errors.Is(err, ErrCorrelationHistoryUnavailable) {
+		t.Fatalf("expected ErrCorrelationHistoryUnavailable, got %v", err)
+	}
+}
+
+// blockingRecorder records results once release is closed.
+type blockingRecorder struct {
+	release  chan struct{}
+	recorded chan *models.CorrelationResult
+}
+
+func (r *blockingRecorder) Record(ctx context.Context, tr models.TimeRange, corr *models.CorrelationResult) error {
+	<-r.release
+	r.recorded <- corr
+	return nil
+}
+
+func TestCorrelate_RecordsHistoryInBackground(t *testing.T) {
+	now := time.Now()
+	repo := newFakeKPIRepo()
+	repo.kpis["impact_kpi"] = &models.KPIDefinition{
+		ID: "impact_kpi", Name: "impact_kpi", Layer: "impact", Formula: "impact_kpi",
+		ServiceFamily: "svc-impact", SignalType: "metric", Datastore: "metrics",
+	}
+	repo.kpis["cause_A"] = &models.KPIDefinition{
+		ID: "cause_A", Name: "cause_A", Layer: "cause", Formula: "cause_A",
+		ServiceFamily: "svc-a", SignalType: "metric", Datastore: "metrics",
+	}
+	metrics := newSeqMetrics()
+	metrics.SetupSequence("impact_kpi", []float64{10, 10, 12, 11, 13})
+	metrics.SetupSequence("cause_A", []float64{20, 20, 24, 22, 26})
+	engCfg := config.EngineConfig{
+		MinAnomalyScore: 0.1,
+		MinCorrelation:  0.2,
+		Buckets: config.BucketConfig{
+			CoreWindowSize: 2 * time.Minute,
+			PreRings:       2,
+			PostRings:      1,
+			RingStep:       time.Minute,
+		},
+	}
+	engine := NewCorrelationEngine(metrics, nil, nil, repo, nil, logger.New("error"), engCfg).(*CorrelationEngineImpl)
+	rec := &blockingRecorder{release: make(chan struct{}), recorded: make(chan *models.CorrelationResult, 1)}
+	engine.SetHistory(rec)
+
+	// Correlate returns while the history write is still blocked.
+	res, err := engine.Correlate(context.Background(), models.TimeRange{Start: now.Add(-10 * time.Minute), End: now})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.RootCause = "changed by the caller"
+	close(rec.release)
+	engine.writes.wait()
+
+	got := <-rec.recorded
+	if got.CorrelationID != res.CorrelationID || got.RootCause == res.RootCause {
+		t.Fatalf("expected a snapshot of the returned result, got %+v", got)
+	}
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+)
+
+func TestFindLabelMatches_Normalization(t *testing.T) {
+	ce := &CorrelationEngineImpl{engineCfg: config.EngineConfig{LabelMatch: config.LabelMatchConfig{
+		IgnoreCase:     true,
+		StripPodSuffix: true,
+		ShortHostnames: true,
+	}}}
+
+	cases := []struct {
+		key, a, b string
+		match     bool
+		applied   []string
+	}{
+		{"service", "checkout", "checkout", true, nil},
+		{"service", "Checkout", "checkout", true, []string{labelNormalizeCase}},
+		{"pod", "checkout-7d9f8b6c4-x2kqz", "checkout-7d9f8b6c4-p9mzt", true, []string{labelNormalizePodSuffix}},
+		{"pod", "DB-0", "db-1", true, []string{labelNormalizeCase, labelNormalizePodSuffix}},
+		{"host", "node-1.eu-west.internal", "node-1", true, []string{labelNormalizeShortHost}},
+		{"host", "10.0.0.1", "10.0.0.2", false, nil},
+		{"service", "checkout-7d9f8b6c4-x2kqz", "checkout", false, nil},
+		{"pod", "checkout-7d9f8b6c4-x2kqz", "payments-7d9f8b6c4-x2kqz", false, nil},
+	}
+	for _, tc := range cases {
+		applied, ok := ce.matchLabelValues(tc.key, tc.a, tc.b)
+		assert.Equal(t, tc.match, ok, "%s %s %s", tc.key, tc.a, tc.b)
+		assert.Equal(t, tc.applied, applied, "%s %s %s", tc.key, tc.a, tc.b)
+	}
+
+	// Without normalization only exact values match.
+	exact := &CorrelationEngineImpl{}
+	_, ok := exact.matchLabelValues("service", "Checkout", "checkout")
+	assert.False(t, ok)
+
+	matches := ce.findLabelMatches(
+		[]dataLabels{{Labels: map[string]string{"service": "Checkout", "level": "error"}}},
+		[]dataLabels{{Labels: map[string]string{"service": "checkout", "level": "warn"}}},
+	)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "checkout", matches[0].MatchedValue)
+	assert.Equal(t, []string{labelNormalizeCase}, labelNormalizations(matches))
+}
+
+func TestLabelMatchWeights_Configurable(t *testing.T) {
+	ce := &CorrelationEngineImpl{}
+	weights, fallback, significant := ce.labelMatchWeights()
+	assert.Equal(t, 1.0, weights["service"])
+	assert.Equal(t, 0.5, fallback)
+	assert.InDelta(t, 5.0, significant, 1e-9)
+
+	ce.engineCfg.LabelMatch = config.LabelMatchConfig{
+		Weights:       []config.LabelWeight{{Label: "service", Weight: 2, Significant: true}, {Label: "region", Weight: 1}},
+		DefaultWeight: 0.1,
+	}
+	matches := ce.findLabelMatches(
+		[]dataLabels{{Labels: map[string]string{"service": "checkout", "region": "eu", "team": "shop"}}},
+		[]dataLabels{{Labels: map[string]string{"service": "checkout", "region": "eu", "team": "shop"}}},
+	)
+	byKey := map[string]float64{}
+	for _, m := range matches {
+		byKey[m.Key] = m.Weight
+	}
+	assert.Equal(t, map[string]float64{"service": 2, "region": 1, "team": 0.1}, byKey)
+	assert.Equal(t, 0.95, ce.calculateLabelMatchConfidence(matches))
+}
@@ -0,0 +1,25 @@
+package services
+
+import (
+	"context"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+)
+
+// CorrelationProgressFunc receives the progress events of a running
+// correlation. It may be called from several goroutines, one at a time.
+type CorrelationProgressFunc func(models.CorrelationProgressEvent)
+
+type correlationProgressKey struct{}
+
+// WithCorrelationProgress returns a context whose correlation runs report
+// their progress to fn, so callers can stream partial results.
+func WithCorrelationProgress(ctx context.Context, fn CorrelationProgressFunc) context.Context {
+	return context.WithValue(ctx, correlationProgressKey{}, fn)
+}
+
+// correlationProgress returns the progress function of ctx, or nil.
+func correlationProgress(ctx context.Context) CorrelationProgressFunc {
+	fn, _ := ctx.Value(correlationProgressKey{}).(CorrelationProgressFunc)
+	return fn
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func TestCorrelate_ReportsProgress(t *testing.T) {
+	now := time.Now()
+
+	repo := newFakeKPIRepo()
+	repo.kpis["impact_kpi"] = &models.KPIDefinition{
+		ID: "impact_kpi", Name: "impact_kpi", Layer: "impact", Formula: "impact_kpi",
+		ServiceFamily: "svc-impact", SignalType: "metric", Datastore: "metrics",
+	}
+	repo.kpis["cause_A"] = &models.KPIDefinition{
+		ID: "cause_A", Name: "cause_A", Layer: "cause", Formula: "cause_A",
+		ServiceFamily: "svc-a", SignalType: "metric", Datastore: "metrics",
+	}
+
+	metrics := newSeqMetrics()
+	metrics.SetupSequence("impact_kpi", []float64{10, 10, 12, 11, 13})
+	metrics.SetupSequence("cause_A", []float64{20, 20, 24, 22, 26})
+
+	engCfg := config.EngineConfig{
+		MinAnomalyScore: 0.1,
+		MinCorrelation:  0.2,
+		Buckets: config.BucketConfig{
+			CoreWindowSize: 2 * time.Minute,
+			PreRings:       2,
+			PostRings:      1,
+			RingStep:       1 * time.Minute,
+		},
+	}
+	engine := NewCorrelationEngine(metrics, nil, nil, repo, nil, logger.New("error"), engCfg).(*CorrelationEngineImpl)
+
+	var (
+		mu     sync.Mutex
+		events []models.CorrelationProgressEvent
+	)
+	ctx := WithCorrelationProgress(context.Background(), func(ev models.CorrelationProgressEvent) {
+		mu.Lock()
+		events = append(events, ev)
+		mu.Unlock()
+	})
+
+	res, err := engine.Correlate(ctx, models.TimeRange{Start: now.Add(-10 * time.Minute), End: now})
+	require.NoError(t, err)
+	require.NotNil(t, res)
+
+	stages := map[string]int{}
+	for _, ev := range events {
+		stages[ev.Stage]++
+	}
+	require.NotEmpty(t, events)
+	assert.Equal(t, models.CorrelationStageRings, events[0].Stage)
+	rings := len(events[0].Rings)
+	require.NotZero(t, rings)
+	// Every candidate KPI is sampled once per ring.
+	ringTotal := stages[models.CorrelationStageCandidate] * rings
+	assert.Equal(t, ringTotal, stages[models.CorrelationStageRing])
+	assert.Equal(t, len(res.Causes), stages[models.CorrelationStageCandidate])
+
+	for _, ev := range events {
+		if ev.Stage == models.CorrelationStageRing {
+			assert.Equal(t, ringTotal, ev.Total)
+			assert.LessOrEqual(t, ev.Completed, ev.Total)
+		}
+		if ev.Stage == models.CorrelationStageCandidate {
+			require.NotNil(t, ev.Candidate)
+		}
+	}
+}
+
+func TestCorrelate_NoProgressWithoutCallback(t *testing.T) {
+	assert.Nil(t, correlationProgress(context.Background()))
+}
+
+func TestExecuteCorrelation_ProgressOutsideLock(t *testing.T) {
+	mockMetrics := &MockVictoriaMetricsService{}
+	mockLogs := &MockVictoriaLogsService{}
+	mockMetrics.On("ExecuteQuery", mock.Anything, mock.Anything).Return(&models.MetricsQLQueryResult{Status: "success"}, nil)
+	mockLogs.On("ExecuteQuery", mock.Anything, mock.Anything).Return(&models.LogsQLQueryResult{}, nil)
+	engine := NewCorrelationEngine(mockMetrics, mockLogs, &MockVictoriaTracesService{}, nil, &MockValkeyCluster{}, logger.New("error"), config.EngineConfig{})
+
+	// The first engine's consumer waits for the second engine's event. That
+	// only arrives if the callback runs without the engines' shared lock.
+	var (
+		mu       sync.Mutex
+		calls    int
+		waited   bool
+		released = make(chan struct{})
+	)
+	ctx := WithCorrelationProgress(context.Background(), func(ev models.CorrelationProgressEvent) {
+		if ev.Stage != models.CorrelationStageEngine {
+			return
+		}
+		mu.Lock()
+		calls++
+		first := calls == 1
+		mu.Unlock()
+		if !first {
+			close(released)
+			return
+		}
+		select {
+		case <-released:
+			waited = true
+		case <-time.After(2 * time.Second):
+		}
+	})
+
+	window := 5 * time.Minute
+	_, err := engine.ExecuteCorrelation(ctx, &models.CorrelationQuery{
+		ID: "progress-lock",
+		Expressions: []models.CorrelationExpression{
+			{Engine: models.QueryTypeLogs, Query: "error"},
+			{Engine: models.QueryTypeMetrics, Query: "cpu_usage"},
+		},
+		TimeWindow: &window,
+		Operator:   models.CorrelationOpAND,
+	})
+	require.NoError(t, err)
+	assert.True(t, waited, "a blocked progress consumer must not hold up the other engines")
+}
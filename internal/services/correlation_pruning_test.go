@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+)
+
+func TestPruneCandidates_ScreenAndBudget(t *testing.T) {
+	m := newSeqMetrics()
+	var candidates []*models.KPIDefinition
+	// kpi-0..kpi-3 move 10%..40% between baseline and core; kpi-4 is flat
+	// and kpi-5 has no data.
+	for i := 0; i < 6; i++ {
+		q := fmt.Sprintf("q%d", i)
+		switch {
+		case i < 4:
+			m.SetupSequence(q, []float64{100, 100 + 10*float64(i+1)})
+		case i == 4:
+			m.SetupSequence(q, []float64{100, 101})
+		}
+		candidates = append(candidates, &models.KPIDefinition{ID: fmt.Sprintf("kpi-%d", i), Formula: q})
+	}
+	now := time.Now()
+	rings := []models.TimeRange{
+		{Start: now.Add(-3 * time.Minute), End: now.Add(-2 * time.Minute)},
+		{Start: now.Add(-2 * time.Minute), End: now.Add(-time.Minute)},
+		{Start: now.Add(-time.Minute), End: now},
+	}
+
+	ce := &CorrelationEngineImpl{
+		metricsService: m,
+		engineCfg: config.EngineConfig{Pruning: config.CandidatePruningConfig{
+			Enabled: true, MinCandidates: 2, MinRelativeDelta: 0.05, MaxCandidates: 2,
+		}},
+	}
+	kept, report := ce.pruneCandidates(context.Background(), candidates, rings)
+	require.NotNil(t, report)
+	require.Len(t, kept, 2)
+	assert.Equal(t, "kpi-2", kept[0].ID)
+	assert.Equal(t, "kpi-3", kept[1].ID)
+	assert.Equal(t, 6, report.Candidates)
+	assert.Equal(t, 2, report.Evaluated)
+	assert.Equal(t, []models.PruningStage{{Name: "screen", Pruned: 2}, {Name: "budget", Pruned: 2}}, report.Stages)
+}
+
+func TestPruneCandidates_SkippedForSmallSets(t *testing.T) {
+	candidates := []*models.KPIDefinition{{ID: "a", Formula: "qa"}, {ID: "b", Formula: "qb"}}
+	rings := []models.TimeRange{{End: time.Now().Add(-time.Minute)}, {End: time.Now()}}
+
+	ce := &CorrelationEngineImpl{
+		metricsService: newSeqMetrics(),
+		engineCfg:      config.EngineConfig{Pruning: config.CandidatePruningConfig{Enabled: true, MinCandidates: 50}},
+	}
+	kept, report := ce.pruneCandidates(context.Background(), candidates, rings)
+	assert.Nil(t, report)
+	assert.Equal(t, candidates, kept)
+
+	ce.engineCfg.Pruning = config.CandidatePruningConfig{}
+	kept, report = ce.pruneCandidates(context.Background(), candidates, rings)
+	assert.Nil(t, report)
+	assert.Equal(t, candidates, kept)
+}
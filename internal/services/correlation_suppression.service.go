@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+var (
+	ErrInvalidSuppressionRule  = errors.New("invalid suppression rule")
+	ErrSuppressionRuleNotFound = errors.New("suppression rule not found")
+)
+
+// CorrelationSuppressionService manages the rules that keep known-noisy KPI
+// pairs (e.g. request count vs bandwidth) out of correlation ranking. Rules
+// are stored as the correlation_suppressions dynamic config document, so
+// every change is locked across replicas, versioned and can be rolled back;
+// the correlation engine reads them on every run.
+type CorrelationSuppressionService struct {
+	config *DynamicConfigService
+	logger logging.Logger
+}
+
+// NewCorrelationSuppressionService creates a suppression rule service backed by the cache.
+func NewCorrelationSuppressionService(cache cache.ValkeyCluster, logger corelogger.Logger) *CorrelationSuppressionService {
+	return &CorrelationSuppressionService{
+		config: NewDynamicConfigService(cache, logger),
+		logger: logging.FromCoreLogger(logger),
+	}
+}
+
+// ListRules returns the stored rules, oldest first.
+func (s *CorrelationSuppressionService) ListRules(ctx context.Context) ([]models.CorrelationSuppressionRule, error) {
+	rules := []models.CorrelationSuppressionRule{}
+	if err := s.config.getDocument(ctx, DynamicConfigCorrelationSuppressions, &rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// CreateRule validates and stores a new rule.
+func (s *CorrelationSuppressionService) CreateRule(ctx context.Context, rule models.CorrelationSuppressionRule, createdBy string) (*models.CorrelationSuppressionRule, error) {
+	rule.Impact = strings.TrimSpace(rule.Impact)
+	rule.Candidate = strings.TrimSpace(rule.Candidate)
+	rule.Reason = strings.TrimSpace(rule.Reason)
+	var tags []string
+	for _, t := range rule.Tags {
+		if t = strings.TrimSpace(t); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	rule.Tags = tags
+	if rule.Impact == "" && rule.Candidate == "" && len(rule.Tags) == 0 {
+		return nil, fmt.Errorf("%w: at least one of impact, candidate or tags is required", ErrInvalidSuppressionRule)
+	}
+	if rule.Reason == "" {
+		return nil, fmt.Errorf("%w: reason is required", ErrInvalidSuppressionRule)
+	}
+
+	rule.ID = uuid.NewString()
+	rule.CreatedBy = createdBy
+	rule.CreatedAt = time.Now().UTC()
+	var rules []models.CorrelationSuppressionRule
+	if _, err := s.config.updateDocument(ctx, DynamicConfigCorrelationSuppressions, createdBy, &rules, func() error {
+		rules = append(rules, rule)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	s.logger.Info("Correlation suppression rule created", "id", rule.ID, "impact", rule.Impact, "candidate", rule.Candidate, "tags", rule.Tags)
+	return &rule, nil
+}
+
+// DeleteRule removes the rule with the given ID.
+func (s *CorrelationSuppressionService) DeleteRule(ctx context.Context, id, deletedBy string) error {
+	var rules []models.CorrelationSuppressionRule
+	if _, err := s.config.updateDocument(ctx, DynamicConfigCorrelationSuppressions, deletedBy, &rules, func() error {
+		kept := make([]models.CorrelationSuppressionRule, 0, len(rules))
+		for _, r := range rules {
+			if r.ID != id {
+				kept = append(kept, r)
+			}
+		}
+		if len(kept) == len(rules) {
+			return ErrSuppressionRuleNotFound
+		}
+		rules = kept
+		return nil
+	}); err != nil {
+		return err
+	}
+	s.logger.Info("Correlation suppression rule deleted", "id", id, "deleted_by", deletedBy)
+	return nil
+}
+
+// suppressionMatcher is a rule with its patterns compiled.
+type suppressionMatcher struct {
+	rule      *models.CorrelationSuppressionRule
+	impact    *regexp.Regexp
+	candidate *regexp.Regexp
+}
+
+// loadSuppressions reads the stored rules and compiles each once for the
+// run's matches.
+func loadSuppressions(ctx context.Context, c cache.ValkeyCluster) ([]suppressionMatcher, error) {
+	if c == nil {
+		return nil, nil
+	}
+	var rules []models.CorrelationSuppressionRule
+	if err := loadConfigDocument(ctx, c, DynamicConfigCorrelationSuppressions, &rules); err != nil {
+		return nil, err
+	}
+	return compileSuppressions(rules), nil
+}
+
+func compileSuppressions(rules []models.CorrelationSuppressionRule) []suppressionMatcher {
+	out := make([]suppressionMatcher, 0, len(rules))
+	for i := range rules {
+		m := suppressionMatcher{rule: &rules[i]}
+		if rules[i].Impact != "" {
+			m.impact = compileGlob(rules[i].Impact)
+		}
+		if rules[i].Candidate != "" {
+			m.candidate = compileGlob(rules[i].Candidate)
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+// matchSuppression returns the first rule that suppresses the impact/cause
+// pair, or nil. impact may be nil when the run found no impact KPI; only
+// rules without an impact pattern apply then.
+func matchSuppression(matchers []suppressionMatcher, impact, cause *models.KPIDefinition) *models.CorrelationSuppressionRule {
+	for _, m := range matchers {
+		if m.impact != nil && (impact == nil || !kpiMatchesPattern(m.impact, impact)) {
+			continue
+		}
+		if m.candidate != nil && !kpiMatchesPattern(m.candidate, cause) {
+			continue
+		}
+		if !hasAllTags(cause.Tags, m.rule.Tags) {
+			continue
+		}
+		return m.rule
+	}
+	return nil
+}
+
+func kpiMatchesPattern(pattern *regexp.Regexp, kpi *models.KPIDefinition) bool {
+	return pattern.MatchString(kpi.Name) || pattern.MatchString(kpi.ID)
+}
+
+// compileGlob compiles a case-insensitive pattern where * matches any run
+// of characters and ? a single character.
+func compileGlob(pattern string) *regexp.Regexp {
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.NewReplacer(`\*`, ".*", `\?`, ".").Replace(expr)
+	return regexp.MustCompile("(?i)^" + expr + "$")
+}
+
+func hasAllTags(have, want []string) bool {
+	for _, w := range want {
+		found := false
+		for _, h := range have {
+			if strings.EqualFold(h, w) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func TestCorrelationSuppressionService_Rules(t *testing.T) {
+	log := logger.New("error")
+	ctx := context.Background()
+	svc := NewCorrelationSuppressionService(cache.NewNoopValkeyCache(log), log)
+
+	if _, err := svc.CreateRule(ctx, models.CorrelationSuppressionRule{Reason: "noise"}, ""); !errors.Is(err, ErrInvalidSuppressionRule) {
+		t.Fatalf("expected ErrInvalidSuppressionRule without patterns, got %v", err)
+	}
+	if _, err := svc.CreateRule(ctx, models.CorrelationSuppressionRule{Candidate: "bandwidth*"}, ""); !errors.Is(err, ErrInvalidSuppressionRule) {
+		t.Fatalf("expected ErrInvalidSuppressionRule without reason, got %v", err)
+	}
+	rule, err := svc.CreateRule(ctx, models.CorrelationSuppressionRule{Impact: "request_count", Candidate: " Bandwidth* ", Reason: "traffic volume drives both"}, "sre")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule.ID == "" || rule.Candidate != "Bandwidth*" || rule.CreatedBy != "sre" {
+		t.Fatalf("unexpected rule %+v", rule)
+	}
+	rules, _ := svc.ListRules(ctx)
+	if len(rules) != 1 {
+		t.Fatalf("expected one rule, got %d", len(rules))
+	}
+	if err := svc.DeleteRule(ctx, "missing", "sre"); !errors.Is(err, ErrSuppressionRuleNotFound) {
+		t.Fatalf("expected ErrSuppressionRuleNotFound, got %v", err)
+	}
+	if err := svc.DeleteRule(ctx, rule.ID, "sre"); err != nil {
+		t.Fatal(err)
+	}
+	if rules, _ := svc.ListRules(ctx); len(rules) != 0 {
+		t.Fatalf("expected no rules after delete, got %d", len(rules))
+	}
+}
+
+func TestMatchSuppression(t *testing.T) {
+	rules := compileSuppressions([]models.CorrelationSuppressionRule{
+		{ID: "pair", Impact: "request_count", Candidate: "bandwidth_*", Reason: "volume"},
+		{ID: "tagged", Tags: []string{"Synthetic"}, Reason: "synthetic checks"},
+	})
+	impact := &models.KPIDefinition{ID: "k1", Name: "request_count"}
+
+	if r := matchSuppression(rules, impact, &models.KPIDefinition{ID: "k2", Name: "Bandwidth_Egress"}); r == nil || r.ID != "pair" {
+		t.Fatalf("expected pair rule, got %+v", r)
+	}
+	if r := matchSuppression(rules, &models.KPIDefinition{ID: "k3", Name: "latency_p99"}, &models.KPIDefinition{ID: "k2", Name: "bandwidth_egress"}); r != nil {
+		t.Fatalf("pair rule must not match another impact KPI, got %+v", r)
+	}
+	if r := matchSuppression(rules, nil, &models.KPIDefinition{ID: "k4", Name: "probe", Tags: []string{"synthetic"}}); r == nil || r.ID != "tagged" {
+		t.Fatalf("expected tag rule without an impact KPI, got %+v", r)
+	}
+	if r := matchSuppression(rules, impact, &models.KPIDefinition{ID: "k5", Name: "error_rate"}); r != nil {
+		t.Fatalf("unexpected match %+v", r)
+	}
+}
+
+func TestCorrelationEngine_ApplySuppressions(t *testing.T) {
+	log := logger.New("error")
+	ctx := context.Background()
+	c := cache.NewNoopValkeyCache(log)
+	if _, err := NewCorrelationSuppressionService(c, log).CreateRule(ctx, models.CorrelationSuppressionRule{Candidate: "bandwidth", Reason: "always tracks requests"}, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	ce := &CorrelationEngineImpl{cache: c}
+	candidates := []*models.KPIDefinition{{ID: "bw", Name: "bandwidth"}, {ID: "err", Name: "error_rate"}}
+	corr := &models.CorrelationResult{Causes: []models.CauseCandidate{
+		{KPI: "bandwidth", KPIUUID: "bw", SuspicionScore: 0.9},
+		{KPI: "error_rate", KPIUUID: "err", SuspicionScore: 0.4},
+	}}
+	run := &models.CorrelationRunRecord{Candidates: []models.CorrelationRunCandidate{{KPIID: "bw"}, {KPIID: "err"}}}
+
+	ce.applySuppressions(ctx, corr, run, &models.KPIDefinition{ID: "req", Name: "request_count"}, candidates)
+
+	if len(corr.Causes) != 1 || corr.Causes[0].KPIUUID != "err" {
+		t.Fatalf("expected only error_rate to be ranked, got %+v", corr.Causes)
+	}
+	if len(corr.Excluded) != 1 || corr.Excluded[0].KPIUUID != "bw" || corr.Excluded[0].SuspicionScore != 0.9 || corr.Excluded[0].Reason != "always tracks requests" {
+		t.Fatalf("expected bandwidth under excluded with its score, got %+v", corr.Excluded)
+	}
+	if len(run.Candidates) != 1 || run.Candidates[0].KPIID != "err" {
+		t.Fatalf("expected suppressed candidate dropped from the run record, got %+v", run.Candidates)
+	}
+}
+
+// failingGetCache fails every read with err.
+type failingGetCache struct {
+	cache.ValkeyCluster
+	err error
+}
+
+func (c failingGetCache) Get(ctx context.Context, key string) ([]byte, error) {
+	return nil, c.err
+}
+
+func TestCorrelationSuppressionService_StoredAsDynamicConfig(t *testing.T) {
+	log := logger.New("error")
+	ctx := context.Background()
+	shared := newLockingCache()
+	replicas := []*CorrelationSuppressionService{
+		NewCorrelationSuppressionService(shared, log),
+		NewCorrelationSuppressionService(shared, log),
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rule := models.CorrelationSuppressionRule{Candidate: fmt.Sprintf("kpi_%d", i), Reason: "noise"}
+			if _, err := replicas[i%2].CreateRule(ctx, rule, "sre"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if rules, err := replicas[0].ListRules(ctx); err != nil || len(rules) != 10 {
+		t.Fatalf("expected every concurrently created rule, got %d (%v)", len(rules), err)
+	}
+	versions, err := NewDynamicConfigService(shared, log).ListVersions(ctx, DynamicConfigCorrelationSuppressions)
+	if err != nil || len(versions) != 10 || versions[0].Author != "sre" {
+		t.Fatalf("expected a version per change, got %d (%v)", len(versions), err)
+	}
+
+	// A failed read is an error, not an empty rule set.
+	broken := failingGetCache{ValkeyCluster: shared, err: errors.New("connection refused")}
+	if _, err := loadSuppressions(ctx, broken); err == nil {
+		t.Fatal("expected the read error from loadSuppressions")
+	}
+	if _, err := NewCorrelationSuppressionService(broken, log).CreateRule(ctx, models.CorrelationSuppressionRule{Candidate: "x", Reason: "noise"}, ""); err == nil {
+		t.Fatal("a create must not overwrite the rules after a failed read")
+	}
+}
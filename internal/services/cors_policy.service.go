@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// tenantOriginsRefresh bounds how long a tenant settings change takes to
+// reach the CORS policy, without a Valkey read on every request.
+const tenantOriginsRefresh = 30 * time.Second
+
+// Defaults used when the cors section leaves a list empty.
+var (
+	defaultCORSMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH"}
+	defaultCORSHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Session-Token"}
+	defaultCORSExposed = []string{"X-Rate-Limit-Limit", "X-Rate-Limit-Remaining", "X-Rate-Limit-Reset"}
+)
+
+// defaultCORSMaxAge is the preflight cache time when cors.max_age is unset.
+const defaultCORSMaxAge = 12 * 60 * 60
+
+// CORSPolicyService combines cors.allowed_origins with the tenant's own
+// origins from tenant settings and decides, per browser origin, whether it
+// may call the API and send credentials.
+type CORSPolicyService struct {
+	cfg      config.CORSConfig
+	settings *TenantSettingsService
+	logger   logging.Logger
+
+	mu       sync.Mutex
+	tenant   []string
+	loadedAt time.Time
+}
+
+// NewCORSPolicyService creates a CORS policy. settings may be nil, in which
+// case only the configured origins apply.
+func NewCORSPolicyService(cfg config.CORSConfig, settings *TenantSettingsService, logger corelogger.Logger) *CORSPolicyService {
+	return &CORSPolicyService{
+		cfg:      cfg,
+		settings: settings,
+		logger:   logging.FromCoreLogger(logger),
+	}
+}
+
+// Policy returns the effective policy, with the decision for origin when it
+// is non-empty.
+func (s *CORSPolicyService) Policy(ctx context.Context, origin string) *models.CORSPolicy {
+	p := &models.CORSPolicy{
+		GlobalOrigins:    nonNil(s.cfg.AllowedOrigins),
+		TenantOrigins:    nonNil(s.TenantOrigins(ctx)),
+		AllowedMethods:   orDefault(s.cfg.AllowedMethods, defaultCORSMethods),
+		AllowedHeaders:   orDefault(s.cfg.AllowedHeaders, defaultCORSHeaders),
+		ExposedHeaders:   orDefault(s.cfg.ExposedHeaders, defaultCORSExposed),
+		AllowCredentials: s.cfg.AllowCredentials,
+		MaxAgeSeconds:    s.cfg.MaxAge,
+	}
+	if p.MaxAgeSeconds <= 0 {
+		p.MaxAgeSeconds = defaultCORSMaxAge
+	}
+	if origin != "" {
+		d := s.Evaluate(ctx, origin)
+		p.Decision = &d
+	}
+	return p
+}
+
+// Evaluate checks origin against the configured and tenant origins. Without
+// configured origins, local development origins are allowed. Credentials are
+// only allowed for origins matched by an explicit entry, never by "*".
+func (s *CORSPolicyService) Evaluate(ctx context.Context, origin string) models.CORSDecision {
+	d := models.CORSDecision{Origin: origin}
+	if origin == "" {
+		d.Reason = "no Origin header"
+		return d
+	}
+	if rule, ok := matchOrigin(origin, s.cfg.AllowedOrigins); ok {
+		d.Allowed, d.Source, d.Rule = true, models.CORSSourceGlobal, rule
+	} else if rule, ok := matchOrigin(origin, s.TenantOrigins(ctx)); ok {
+		d.Allowed, d.Source, d.Rule = true, models.CORSSourceTenant, rule
+	} else if len(s.cfg.AllowedOrigins) == 0 && isDevelopmentOrigin(origin) {
+		d.Allowed, d.Source = true, models.CORSSourceDefault
+	}
+	switch {
+	case !d.Allowed:
+		d.Reason = "origin is not in cors.allowed_origins or the tenant's allowedOrigins"
+	case !s.cfg.AllowCredentials:
+		d.Reason = "credentials are disabled by cors.allow_credentials"
+	case d.Rule == "*":
+		d.Reason = "credentials are never allowed for origins matched only by \"*\""
+	default:
+		d.AllowCredentials = true
+	}
+	return d
+}
+
+// TenantOrigins returns the tenant's additional origins, re-read from tenant
+// settings at most every tenantOriginsRefresh. On a read error the last
+// known origins are kept.
+func (s *CORSPolicyService) TenantOrigins(ctx context.Context) []string {
+	if s.settings == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.loadedAt.IsZero() && time.Since(s.loadedAt) < tenantOriginsRefresh {
+		return s.tenant
+	}
+	settings, err := s.settings.GetSettings(ctx)
+	if err != nil {
+		s.logger.Warn("Failed to read tenant CORS origins, keeping previous", "error", err)
+	} else {
+		s.tenant = settings.AllowedOrigins
+	}
+	s.loadedAt = time.Now()
+	return s.tenant
+}
+
+// matchOrigin returns the entry of allowed that matches origin. "*.example.com"
+// matches any subdomain of example.com but not example.com itself.
+func matchOrigin(origin string, allowed []string) (string, bool) {
+	host := originHost(origin)
+	for _, a := range allowed {
+		switch {
+		case a == "*" || strings.EqualFold(a, origin):
+			return a, true
+		case strings.HasPrefix(a, "*."):
+			if host != "" && strings.HasSuffix(host, strings.ToLower(a[1:])) {
+				return a, true
+			}
+		}
+	}
+	return "", false
+}
+
+func isDevelopmentOrigin(origin string) bool {
+	host := originHost(origin)
+	return host == "localhost" || host == "127.0.0.1" || strings.Contains(host, "mirador-ui")
+}
+
+func originHost(origin string) string {
+	u, err := url.Parse(origin)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
+
+// validCORSOrigin accepts "*.domain" or a bare http(s) origin without path.
+func validCORSOrigin(origin string) bool {
+	if d, ok := strings.CutPrefix(origin, "*."); ok {
+		return d != "" && !strings.ContainsAny(d, "/:*")
+	}
+	u, err := url.Parse(origin)
+	return err == nil && (u.Scheme == "https" || u.Scheme == "http") && u.Host != "" &&
+		u.Path == "" && u.RawQuery == "" && u.User == nil
+}
+
+func orDefault(v, def []string) []string {
+	if len(v) > 0 {
+		return v
+	}
+	return def
+}
+
+func nonNil(v []string) []string {
+	if v == nil {
+		return []string{}
+	}
+	return v
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func TestCORSPolicyService_Evaluate(t *testing.T) {
+	ctx := context.Background()
+	log := logger.New("error")
+	settings := NewTenantSettingsService(cache.NewNoopValkeyCache(log), nil, log)
+	if _, err := settings.SetSettings(ctx, &models.TenantSettings{AllowedOrigins: []string{"https://ops.acme.com", "*.acme.io"}}); err != nil {
+		t.Fatal(err)
+	}
+	svc := NewCORSPolicyService(config.CORSConfig{
+		AllowedOrigins:   []string{"https://mirador.example.com", "*.mirador.io"},
+		AllowCredentials: true,
+	}, settings, log)
+
+	cases := []struct {
+		origin      string
+		allowed     bool
+		source      string
+		credentials bool
+	}{
+		{"https://mirador.example.com", true, models.CORSSourceGlobal, true},
+		{"https://eu.mirador.io", true, models.CORSSourceGlobal, true},
+		{"https://evilmirador.io", false, "", false},
+		{"https://ops.acme.com", true, models.CORSSourceTenant, true},
+		{"https://grafana.acme.io", true, models.CORSSourceTenant, true},
+		{"https://ops.acme.com.evil.net", false, "", false},
+		{"http://localhost:3000", false, "", false},
+	}
+	for _, tc := range cases {
+		d := svc.Evaluate(ctx, tc.origin)
+		if d.Allowed != tc.allowed || d.Source != tc.source || d.AllowCredentials != tc.credentials {
+			t.Errorf("%s: unexpected decision %+v", tc.origin, d)
+		}
+	}
+
+	// "*" allows any origin but never with credentials; without configured
+	// origins only local development origins are allowed.
+	wildcard := NewCORSPolicyService(config.CORSConfig{AllowedOrigins: []string{"*"}, AllowCredentials: true}, nil, log)
+	if d := wildcard.Evaluate(ctx, "https://any.example.org"); !d.Allowed || d.AllowCredentials {
+		t.Fatalf("expected wildcard without credentials, got %+v", d)
+	}
+	dev := NewCORSPolicyService(config.CORSConfig{}, nil, log)
+	if d := dev.Evaluate(ctx, "http://localhost:3000"); !d.Allowed || d.Source != models.CORSSourceDefault {
+		t.Fatalf("expected localhost allowed by default, got %+v", d)
+	}
+	if d := dev.Evaluate(ctx, "https://localhost.evil.net"); d.Allowed {
+		t.Fatalf("expected host-based development match, got %+v", d)
+	}
+
+	p := svc.Policy(ctx, "https://ops.acme.com")
+	if len(p.TenantOrigins) != 2 || p.MaxAgeSeconds != defaultCORSMaxAge || p.Decision == nil || !p.Decision.Allowed {
+		t.Fatalf("unexpected policy %+v", p)
+	}
+}
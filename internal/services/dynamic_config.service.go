@@ -1,17 +1,71 @@
 package services
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"reflect"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/mirastacklabs-ai/mirador-core/internal/config"
 	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
 	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
 	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
 )
 
+// Dynamic config documents.
+const (
+	DynamicConfigGRPC         = "grpc_endpoints"
+	DynamicConfigFeatureFlags = "feature_flags"
+	DynamicConfigOverrides    = "config_overrides"
+	DynamicConfigLogLevels    = "log_levels"
+
+	DynamicConfigCorrelationSuppressions = "correlation_suppressions"
+	DynamicConfigAnonymizationProfiles   = "anonymization_profiles"
+	DynamicConfigComputedColumns         = "computed_columns"
+	DynamicConfigNotificationTemplates   = "notification_templates"
+	DynamicConfigQueryTemplates          = "query_templates"
+	DynamicConfigRemediationRules        = "remediation_rules"
+)
+
+// dynamicConfigTTLs lists the versioned documents and how long each value
+// lives. gRPC overrides expire so a stale endpoint falls back to static
+// config; feature flags, tenant config overrides, subsystem log levels and
+// the rule documents persist until changed.
+var dynamicConfigTTLs = map[string]time.Duration{
+	DynamicConfigGRPC:         24 * time.Hour,
+	DynamicConfigFeatureFlags: 0,
+	DynamicConfigOverrides:    0,
+	DynamicConfigLogLevels:    0,
+
+	DynamicConfigCorrelationSuppressions: 0,
+	DynamicConfigAnonymizationProfiles:   0,
+	DynamicConfigComputedColumns:         0,
+	DynamicConfigNotificationTemplates:   0,
+	DynamicConfigQueryTemplates:          0,
+	DynamicConfigRemediationRules:        0,
+}
+
+const (
+	maxConfigVersions = 50
+	configLockTTL     = 10 * time.Second
+	configLockWait    = 2 * time.Second
+)
+
+var (
+	ErrUnknownDynamicConfig  = errors.New("unknown dynamic config")
+	ErrConfigVersionNotFound = errors.New("config version not found")
+	ErrConfigBusy            = errors.New("config is being updated by another request; retry")
+
+	// errConfigUnchanged aborts an update without writing a version.
+	errConfigUnchanged = errors.New("config unchanged")
+)
+
 // DynamicGRPCConfig represents the configurable gRPC endpoint settings
 type DynamicGRPCConfig struct {
 	RCAEngine   RCAEngineConfig   `json:"rca_engine"`
@@ -32,10 +86,13 @@ type AlertEngineConfig struct {
 	Timeout   int    `json:"timeout"`
 }
 
-// DynamicConfigService manages dynamic configuration updates stored in cache
+// DynamicConfigService manages dynamic configuration updates stored in cache.
+// Every update is an atomic read-modify-write under a per-document lock and
+// records a version with its author and diff, which can be rolled back.
 type DynamicConfigService struct {
 	cache  cache.ValkeyCluster
 	logger logging.Logger
+	mu     sync.Mutex // serialises updates in this replica; the cache lock covers the others
 }
 
 // NewDynamicConfigService creates a new dynamic configuration service
@@ -74,27 +131,368 @@ func (s *DynamicConfigService) GetGRPCConfig(ctx context.Context, defaultConfig
 }
 
 // SetGRPCConfig updates the gRPC endpoint configuration in cache
-func (s *DynamicConfigService) SetGRPCConfig(ctx context.Context, cfg *DynamicGRPCConfig) error {
-	key := s.getConfigKey("grpc_endpoints")
+func (s *DynamicConfigService) SetGRPCConfig(ctx context.Context, cfg *DynamicGRPCConfig, author string) (*models.ConfigVersion, error) {
+	return s.update(ctx, DynamicConfigGRPC, models.ConfigActionUpdate, author, func([]byte, []models.ConfigVersion) ([]byte, int, error) {
+		data, err := json.Marshal(cfg)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to marshal gRPC config: %w", err)
+		}
+		return data, 0, nil
+	})
+}
 
-	data, err := json.Marshal(cfg)
-	if err != nil {
-		return fmt.Errorf("failed to marshal gRPC config: %w", err)
+// GetFeatureFlagSet retrieves all feature flag definitions from cache.
+// A missing entry yields an empty set.
+func (s *DynamicConfigService) GetFeatureFlagSet(ctx context.Context) (map[string]*FeatureFlag, error) {
+	key := s.getConfigKey("feature_flags")
+
+	data, err := s.cache.Get(ctx, key)
+	if err != nil || len(data) == 0 {
+		return map[string]*FeatureFlag{}, nil
+	}
+
+	flags := map[string]*FeatureFlag{}
+	if err := json.Unmarshal(data, &flags); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal feature flags: %w", err)
+	}
+	return flags, nil
+}
+
+// UpdateFeatureFlagSet atomically applies fn to the stored flag set. Flags
+// are stored without expiry; they must survive until explicitly changed.
+// Returning errConfigUnchanged from fn skips the write.
+func (s *DynamicConfigService) UpdateFeatureFlagSet(ctx context.Context, author string, fn func(map[string]*FeatureFlag) error) (*models.ConfigVersion, error) {
+	return s.update(ctx, DynamicConfigFeatureFlags, models.ConfigActionUpdate, author, func(current []byte, _ []models.ConfigVersion) ([]byte, int, error) {
+		flags := map[string]*FeatureFlag{}
+		if len(current) > 0 {
+			if err := json.Unmarshal(current, &flags); err != nil {
+				return nil, 0, fmt.Errorf("failed to unmarshal feature flags: %w", err)
+			}
+		}
+		if err := fn(flags); err != nil {
+			return nil, 0, err
+		}
+		data, err := json.Marshal(flags)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to marshal feature flags: %w", err)
+		}
+		return data, 0, nil
+	})
+}
+
+// GetConfigOverrides returns the stored tenant config overrides, keyed by
+// dotted config path. A missing entry yields an empty set.
+func (s *DynamicConfigService) GetConfigOverrides(ctx context.Context) (map[string]any, error) {
+	data, err := s.cache.Get(ctx, s.getConfigKey(DynamicConfigOverrides))
+	if err != nil || len(data) == 0 {
+		return map[string]any{}, nil
 	}
 
-	// Store with a reasonable TTL (24 hours)
-	if err := s.cache.Set(ctx, key, string(data), 24*time.Hour); err != nil {
-		return fmt.Errorf("failed to store gRPC config in cache: %w", err)
+	overrides := map[string]any{}
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config overrides: %w", err)
 	}
+	return overrides, nil
+}
 
-	s.logger.Info("Updated gRPC endpoint configuration")
+// SetConfigOverrides replaces the tenant config overrides. They are checked
+// against base with config.ApplyOverrides first, so an invalid set returns
+// config.ValidationErrors and is not stored. They take effect on restart.
+func (s *DynamicConfigService) SetConfigOverrides(ctx context.Context, base *config.Config, overrides map[string]any, author string) (*models.ConfigVersion, error) {
+	if _, err := config.ApplyOverrides(base, overrides); err != nil {
+		return nil, err
+	}
+	return s.update(ctx, DynamicConfigOverrides, models.ConfigActionUpdate, author, func([]byte, []models.ConfigVersion) ([]byte, int, error) {
+		data, err := json.Marshal(overrides)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to marshal config overrides: %w", err)
+		}
+		return data, 0, nil
+	})
+}
+
+// GetLogLevels returns the runtime subsystem log levels. A missing entry
+// yields an empty set.
+func (s *DynamicConfigService) GetLogLevels(ctx context.Context) (map[string]string, error) {
+	data, err := s.cache.Get(ctx, s.getConfigKey(DynamicConfigLogLevels))
+	if err != nil || len(data) == 0 {
+		return map[string]string{}, nil
+	}
+
+	levels := map[string]string{}
+	if err := json.Unmarshal(data, &levels); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal log levels: %w", err)
+	}
+	return levels, nil
+}
+
+// UpdateLogLevels atomically applies fn to the stored runtime log levels.
+// Returning errConfigUnchanged from fn skips the write.
+func (s *DynamicConfigService) UpdateLogLevels(ctx context.Context, author string, fn func(map[string]string) error) (*models.ConfigVersion, error) {
+	return s.update(ctx, DynamicConfigLogLevels, models.ConfigActionUpdate, author, func(current []byte, _ []models.ConfigVersion) ([]byte, int, error) {
+		levels := map[string]string{}
+		if len(current) > 0 {
+			if err := json.Unmarshal(current, &levels); err != nil {
+				return nil, 0, fmt.Errorf("failed to unmarshal log levels: %w", err)
+			}
+		}
+		if err := fn(levels); err != nil {
+			return nil, 0, err
+		}
+		data, err := json.Marshal(levels)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to marshal log levels: %w", err)
+		}
+		return data, 0, nil
+	})
+}
+
+// getDocument decodes the current value of a document into out.
+func (s *DynamicConfigService) getDocument(ctx context.Context, name string, out any) error {
+	return loadConfigDocument(ctx, s.cache, name, out)
+}
+
+// updateDocument atomically decodes the current value of a document into
+// out, applies fn and stores out as a new version. Returning
+// errConfigUnchanged from fn skips the write.
+func (s *DynamicConfigService) updateDocument(ctx context.Context, name, author string, out any, fn func() error) (*models.ConfigVersion, error) {
+	return s.update(ctx, name, models.ConfigActionUpdate, author, func(current []byte, _ []models.ConfigVersion) ([]byte, int, error) {
+		if len(current) > 0 {
+			if err := json.Unmarshal(current, out); err != nil {
+				return nil, 0, fmt.Errorf("failed to unmarshal %s: %w", name, err)
+			}
+		}
+		if err := fn(); err != nil {
+			return nil, 0, err
+		}
+		data, err := json.Marshal(out)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to marshal %s: %w", name, err)
+		}
+		return data, 0, nil
+	})
+}
+
+// loadConfigDocument decodes the current value of a document in c into out.
+// A missing document leaves out as it is; other cache errors are returned,
+// so a failed read is never mistaken for an empty document.
+func loadConfigDocument(ctx context.Context, c cache.ValkeyCluster, name string, out any) error {
+	data, err := c.Get(ctx, fmt.Sprintf("cfg:%s", name))
+	if errors.Is(err, cache.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to unmarshal %s: %w", name, err)
+	}
 	return nil
 }
 
 // ResetGRPCConfig resets the gRPC configuration to defaults
-func (s *DynamicConfigService) ResetGRPCConfig(ctx context.Context, defaultConfig *config.GRPCConfig) error {
+func (s *DynamicConfigService) ResetGRPCConfig(ctx context.Context, defaultConfig *config.GRPCConfig, author string) (*models.ConfigVersion, error) {
 	cfg := s.convertToDynamicConfig(defaultConfig)
-	return s.SetGRPCConfig(ctx, cfg)
+	return s.SetGRPCConfig(ctx, cfg, author)
+}
+
+// ListVersions returns the recorded versions of a document, newest first.
+func (s *DynamicConfigService) ListVersions(ctx context.Context, name string) ([]models.ConfigVersion, error) {
+	if _, ok := dynamicConfigTTLs[name]; !ok {
+		return nil, ErrUnknownDynamicConfig
+	}
+	versions, err := s.versions(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Version > versions[j].Version })
+	return versions, nil
+}
+
+// Rollback restores the value of a recorded version as a new version.
+func (s *DynamicConfigService) Rollback(ctx context.Context, name string, version int, author string) (*models.ConfigVersion, error) {
+	return s.update(ctx, name, models.ConfigActionRollback, author, func(_ []byte, history []models.ConfigVersion) ([]byte, int, error) {
+		for _, v := range history {
+			if v.Version == version {
+				return v.Value, version, nil
+			}
+		}
+		return nil, 0, ErrConfigVersionNotFound
+	})
+}
+
+// update runs mutate on the current value under the document lock, then
+// stores the result and appends a version. mutate returns the new value and,
+// for rollbacks, the restored version.
+func (s *DynamicConfigService) update(
+	ctx context.Context,
+	name, action, author string,
+	mutate func(current []byte, history []models.ConfigVersion) ([]byte, int, error),
+) (*models.ConfigVersion, error) {
+	ttl, ok := dynamicConfigTTLs[name]
+	if !ok {
+		return nil, ErrUnknownDynamicConfig
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	unlock, err := s.lock(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// A missing key is empty; any other read error must not be mistaken for
+	// it, or the update would overwrite the stored value.
+	current, err := s.cache.Get(ctx, s.getConfigKey(name))
+	if err != nil && !errors.Is(err, cache.ErrNotFound) {
+		return nil, fmt.Errorf("failed to read %s config: %w", name, err)
+	}
+	history, err := s.versions(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	next, restored, err := mutate(current, history)
+	if err != nil {
+		if errors.Is(err, errConfigUnchanged) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	diff, err := diffConfig(current, next)
+	if err != nil {
+		return nil, err
+	}
+
+	v := models.ConfigVersion{
+		Name:         name,
+		Version:      1,
+		Action:       action,
+		Author:       author,
+		Timestamp:    time.Now().UTC(),
+		Diff:         diff,
+		Value:        json.RawMessage(next),
+		RolledBackTo: restored,
+	}
+	if n := len(history); n > 0 {
+		v.Version = history[n-1].Version + 1
+	}
+	if err := s.cache.Set(ctx, s.getConfigKey(name), next, ttl); err != nil {
+		return nil, fmt.Errorf("failed to store %s config in cache: %w", name, err)
+	}
+	history = append(history, v)
+	if len(history) > maxConfigVersions {
+		history = history[len(history)-maxConfigVersions:]
+	}
+	data, err := json.Marshal(history)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s versions: %w", name, err)
+	}
+	if err := s.cache.Set(ctx, s.getConfigKey(name+":versions"), data, 0); err != nil {
+		return nil, fmt.Errorf("failed to store %s versions in cache: %w", name, err)
+	}
+
+	s.logger.Info("Dynamic config changed",
+		"config", name, "version", v.Version, "action", action, "author", author, "changes", len(diff))
+	return &v, nil
+}
+
+// lock takes the cross-replica lock of a document, waiting up to
+// configLockWait for a concurrent update to finish.
+func (s *DynamicConfigService) lock(ctx context.Context, name string) (func(), error) {
+	key := s.getConfigKey(name + ":lock")
+	deadline := time.Now().Add(configLockWait)
+	for {
+		acquired, err := s.cache.AcquireLock(ctx, key, configLockTTL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to lock %s config: %w", name, err)
+		}
+		if acquired {
+			return func() {
+				if err := s.cache.ReleaseLock(context.WithoutCancel(ctx), key); err != nil {
+					s.logger.Warn("Failed to release config lock", "config", name, "error", err)
+				}
+			}, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, ErrConfigBusy
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
+
+func (s *DynamicConfigService) versions(ctx context.Context, name string) ([]models.ConfigVersion, error) {
+	data, err := s.cache.Get(ctx, s.getConfigKey(name+":versions"))
+	if err != nil || len(data) == 0 {
+		return nil, nil
+	}
+	var versions []models.ConfigVersion
+	if err := json.Unmarshal(data, &versions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s versions: %w", name, err)
+	}
+	return versions, nil
+}
+
+// diffConfig lists the leaves that differ between two JSON documents.
+func diffConfig(before, after []byte) ([]models.ConfigChange, error) {
+	old, cur := map[string]any{}, map[string]any{}
+	if err := flattenConfig(before, old); err != nil {
+		return nil, err
+	}
+	if err := flattenConfig(after, cur); err != nil {
+		return nil, err
+	}
+	paths := make([]string, 0, len(old)+len(cur))
+	for p := range old {
+		paths = append(paths, p)
+	}
+	for p := range cur {
+		if _, ok := old[p]; !ok {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+
+	diff := []models.ConfigChange{}
+	for _, p := range paths {
+		if !reflect.DeepEqual(old[p], cur[p]) {
+			diff = append(diff, models.ConfigChange{Path: p, Old: old[p], New: cur[p]})
+		}
+	}
+	return diff, nil
+}
+
+func flattenConfig(data []byte, out map[string]any) error {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("failed to decode config for diff: %w", err)
+	}
+	flattenConfigValue("", v, out)
+	return nil
+}
+
+func flattenConfigValue(prefix string, v any, out map[string]any) {
+	m, ok := v.(map[string]any)
+	if !ok || len(m) == 0 {
+		out[prefix] = v
+		return
+	}
+	for k, child := range m {
+		if prefix != "" {
+			k = prefix + "." + k
+		}
+		flattenConfigValue(k, child, out)
+	}
 }
 
 // convertToDynamicConfig converts static config to dynamic config format
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func TestDynamicConfigService_VersionsAndRollback(t *testing.T) {
+	log := logger.New("error")
+	svc := NewDynamicConfigService(cache.NewNoopValkeyCache(log), log)
+	ctx := context.Background()
+
+	cfg := &DynamicGRPCConfig{RCAEngine: RCAEngineConfig{Endpoint: "rca:9090", Timeout: 30}}
+	if _, err := svc.SetGRPCConfig(ctx, cfg, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	cfg.RCAEngine.Endpoint = "rca-v2:9090"
+	v2, err := svc.SetGRPCConfig(ctx, cfg, "bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v2.Version != 2 || v2.Author != "bob" || len(v2.Diff) != 1 {
+		t.Fatalf("unexpected version: %+v", v2)
+	}
+	if d := v2.Diff[0]; d.Path != "rca_engine.endpoint" || d.Old != "rca:9090" || d.New != "rca-v2:9090" {
+		t.Fatalf("unexpected diff: %+v", d)
+	}
+
+	rb, err := svc.Rollback(ctx, DynamicConfigGRPC, 1, "carol")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rb.Version != 3 || rb.Action != models.ConfigActionRollback || rb.RolledBackTo != 1 {
+		t.Fatalf("unexpected rollback version: %+v", rb)
+	}
+	got, _ := svc.GetGRPCConfig(ctx, nil)
+	if got.RCAEngine.Endpoint != "rca:9090" {
+		t.Fatalf("rollback not applied: %+v", got)
+	}
+
+	versions, err := svc.ListVersions(ctx, DynamicConfigGRPC)
+	if err != nil || len(versions) != 3 || versions[0].Version != 3 {
+		t.Fatalf("expected 3 versions newest first, got %+v (%v)", versions, err)
+	}
+	if _, err := svc.Rollback(ctx, DynamicConfigGRPC, 9, ""); !errors.Is(err, ErrConfigVersionNotFound) {
+		t.Fatalf("expected ErrConfigVersionNotFound, got %v", err)
+	}
+	if _, err := svc.ListVersions(ctx, "nope"); !errors.Is(err, ErrUnknownDynamicConfig) {
+		t.Fatalf("expected ErrUnknownDynamicConfig, got %v", err)
+	}
+}
+
+func TestDynamicConfigService_ConcurrentUpdates(t *testing.T) {
+	svc := newTestFeatureFlagService()
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := svc.UpsertFlag(ctx, &FeatureFlag{Name: fmt.Sprintf("flag-%d", i), Enabled: true}, "ops"); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	flags, _ := svc.ListFlags(ctx)
+	if len(flags) != 20 {
+		t.Fatalf("expected 20 flags, lost updates: got %d", len(flags))
+	}
+	versions, _ := svc.store.ListVersions(ctx, DynamicConfigFeatureFlags)
+	for i, v := range versions {
+		if v.Version != 20-i || len(v.Diff) == 0 {
+			t.Fatalf("expected sequential versions each with a diff, got %+v", v)
+		}
+	}
+}
+
+func TestDynamicConfigService_ConfigOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("mariadb:\n  enabled: false\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CONFIG_PATH", path)
+	base, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	log := logger.New("error")
+	svc := NewDynamicConfigService(cache.NewNoopValkeyCache(log), log)
+	ctx := context.Background()
+
+	v, err := svc.SetConfigOverrides(ctx, base, map[string]any{"engine.min_correlation": 0.7}, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Name != DynamicConfigOverrides || len(v.Diff) != 1 || v.Diff[0].Path != "engine.min_correlation" {
+		t.Fatalf("unexpected version: %+v", v)
+	}
+
+	var verrs config.ValidationErrors
+	if _, err := svc.SetConfigOverrides(ctx, base, map[string]any{"cache.ttl": 1}, "bob"); !errors.As(err, &verrs) {
+		t.Fatalf("expected validation errors, got %v", err)
+	}
+	overrides, err := svc.GetConfigOverrides(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(overrides) != 1 || overrides["engine.min_correlation"] != 0.7 {
+		t.Fatalf("rejected overrides must not be stored, got %v", overrides)
+	}
+}
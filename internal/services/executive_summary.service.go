@@ -0,0 +1,364 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/datastore"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/qos"
+	"github.com/mirastacklabs-ai/mirador-core/internal/repo"
+	"github.com/mirastacklabs-ai/mirador-core/internal/weavstore"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const (
+	executiveSummaryKey          = "summary:executive"
+	executiveSummarySnapshotsKey = "summary:executive:snapshots"
+
+	// maxSummaryKPIs bounds the instant queries issued per summary (each KPI
+	// is evaluated now and a week ago).
+	maxSummaryKPIs = 200
+
+	defaultSummaryTopN = 10
+	summaryTrendWindow = 7 * 24 * time.Hour
+)
+
+// Incident severities derived from the failure record's detection
+// confidence; failure records carry no severity of their own.
+const (
+	IncidentSeverityCritical = "critical" // confidence >= 0.8
+	IncidentSeverityMajor    = "major"    // confidence >= 0.5
+	IncidentSeverityMinor    = "minor"
+)
+
+// ExecutiveSummaryService aggregates KPI violations, incidents, error-budget
+// burn and alert noise into a cached tenant overview, and snapshots it on a
+// schedule for reports.
+type ExecutiveSummaryService struct {
+	metrics   HealthMetricsQuerier
+	kpis      repo.KPIRepo
+	incidents HealthIncidentSource
+	cache     cache.ValkeyCluster
+	cfg       config.ExecutiveSummaryConfig
+	health    config.HealthScoreConfig
+	logger    logging.Logger
+
+	// warehouses for SQL KPIs; see SetDatastores
+	datastores *datastore.Registry
+}
+
+// NewExecutiveSummaryService creates a new executive summary service. Any of
+// metrics, kpis and incidents may be nil; their sections are then reported
+// unavailable.
+func NewExecutiveSummaryService(metrics HealthMetricsQuerier, kpis repo.KPIRepo, incidents HealthIncidentSource, cache cache.ValkeyCluster, cfg config.ExecutiveSummaryConfig, health config.HealthScoreConfig, logger corelogger.Logger) *ExecutiveSummaryService {
+	if cfg.TopN <= 0 {
+		cfg.TopN = defaultSummaryTopN
+	}
+	return &ExecutiveSummaryService{
+		metrics:   metrics,
+		kpis:      kpis,
+		incidents: incidents,
+		cache:     cache,
+		cfg:       cfg,
+		health:    health,
+		logger:    logging.FromCoreLogger(logger),
+	}
+}
+
+// SetDatastores lets KPIs stored in the registered warehouses count towards
+// the summary's violations.
+func (s *ExecutiveSummaryService) SetDatastores(stores *datastore.Registry) {
+	s.datastores = stores
+}
+
+// Summary returns the cached summary, computing it when absent, expired or
+// refresh is set.
+func (s *ExecutiveSummaryService) Summary(ctx context.Context, refresh bool) (*models.ExecutiveSummary, error) {
+	if !refresh {
+		if data, err := s.cache.Get(ctx, executiveSummaryKey); err == nil && len(data) > 0 {
+			var summary models.ExecutiveSummary
+			if err := json.Unmarshal(data, &summary); err == nil {
+				return &summary, nil
+			}
+		}
+	}
+	summary := s.compute(ctx, time.Now().UTC())
+	if err := s.cache.Set(ctx, executiveSummaryKey, summary, s.cfg.CacheTTL); err != nil {
+		s.logger.Warn("Failed to cache executive summary", "error", err)
+	}
+	return summary, nil
+}
+
+// Snapshots returns the stored scheduled summaries, newest first.
+func (s *ExecutiveSummaryService) Snapshots(ctx context.Context) ([]models.ExecutiveSummary, error) {
+	data, err := s.cache.Get(ctx, executiveSummarySnapshotsKey)
+	if err != nil || len(data) == 0 {
+		return []models.ExecutiveSummary{}, nil
+	}
+	var snapshots []models.ExecutiveSummary
+	if err := json.Unmarshal(data, &snapshots); err != nil {
+		return nil, fmt.Errorf("decode summary snapshots: %w", err)
+	}
+	return snapshots, nil
+}
+
+// Start stores a fresh summary snapshot every SnapshotInterval until ctx
+// ends.
+func (s *ExecutiveSummaryService) Start(ctx context.Context) {
+	if s.cfg.SnapshotInterval <= 0 {
+		return
+	}
+	ctx = qos.WithClass(ctx, qos.Background)
+	ticker := time.NewTicker(s.cfg.SnapshotInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.snapshot(ctx)
+		}
+	}
+}
+
+func (s *ExecutiveSummaryService) snapshot(ctx context.Context) {
+	summary, _ := s.Summary(ctx, true)
+	snapshots, err := s.Snapshots(ctx)
+	if err != nil {
+		s.logger.Warn("Resetting unreadable summary snapshots", "error", err)
+		snapshots = nil
+	}
+	snapshots = append([]models.ExecutiveSummary{*summary}, snapshots...)
+	if s.cfg.SnapshotRetention > 0 && len(snapshots) > s.cfg.SnapshotRetention {
+		snapshots = snapshots[:s.cfg.SnapshotRetention]
+	}
+	if err := s.cache.Set(ctx, executiveSummarySnapshotsKey, snapshots, 0); err != nil {
+		s.logger.Warn("Failed to store summary snapshot", "error", err)
+	}
+}
+
+func (s *ExecutiveSummaryService) compute(ctx context.Context, now time.Time) *models.ExecutiveSummary {
+	out := &models.ExecutiveSummary{
+		TopViolatedKPIs: []models.KPIViolation{},
+		OpenIncidents:   models.IncidentSummary{BySeverity: map[string]int{}},
+		SLOBurn:         []models.ServiceBurn{},
+		NoisyServices:   []models.NoisyService{},
+		Trends:          []models.SummaryTrend{},
+		GeneratedAt:     now,
+	}
+	noise := map[string]*models.NoisyService{}
+	noisy := func(svc string) *models.NoisyService {
+		if noise[svc] == nil {
+			noise[svc] = &models.NoisyService{Service: svc}
+		}
+		return noise[svc]
+	}
+
+	// KPI violations now and a week ago.
+	if current, previous, err := s.kpiViolations(ctx, now); err != nil {
+		out.Unavailable = append(out.Unavailable, "kpis: "+err.Error())
+	} else {
+		out.TopViolatedKPIs = topN(current, s.cfg.TopN)
+		out.Trends = append(out.Trends, weekTrend("violated_kpis", len(current), previous))
+	}
+
+	// Incidents: open ones by severity, last week per service, and the
+	// week-over-week count.
+	if records, err := s.listIncidents(ctx); err != nil {
+		out.Unavailable = append(out.Unavailable, "incidents: "+err.Error())
+	} else {
+		openSince := now.Add(-s.health.IncidentWindow)
+		thisWeek, lastWeek := 0, 0
+		for _, r := range records {
+			ts := r.DetectionTimestamp
+			switch {
+			case !ts.Before(now.Add(-summaryTrendWindow)):
+				thisWeek++
+				for _, svc := range r.Services {
+					noisy(svc).Incidents++
+				}
+			case !ts.Before(now.Add(-2 * summaryTrendWindow)):
+				lastWeek++
+			}
+			if !ts.Before(openSince) {
+				out.OpenIncidents.Total++
+				out.OpenIncidents.BySeverity[incidentSeverity(r)]++
+			}
+		}
+		out.Trends = append(out.Trends, weekTrend("incidents", thisWeek, lastWeek))
+	}
+
+	// Firing alerts by service now and a week ago.
+	if current, previous, err := s.firingAlerts(ctx, now, noisy); err != nil {
+		out.Unavailable = append(out.Unavailable, "alerts: "+err.Error())
+	} else {
+		out.Trends = append(out.Trends, weekTrend("firing_alerts", current, previous))
+	}
+
+	for _, n := range noise {
+		out.NoisyServices = append(out.NoisyServices, *n)
+	}
+	sort.Slice(out.NoisyServices, func(i, j int) bool {
+		a, b := out.NoisyServices[i], out.NoisyServices[j]
+		if a.FiringAlerts+a.Incidents != b.FiringAlerts+b.Incidents {
+			return a.FiringAlerts+a.Incidents > b.FiringAlerts+b.Incidents
+		}
+		return a.Service < b.Service
+	})
+	out.NoisyServices = topN(out.NoisyServices, s.cfg.TopN)
+
+	if burns, err := s.sloBurn(ctx); err != nil {
+		out.Unavailable = append(out.Unavailable, "slo_burn: "+err.Error())
+	} else {
+		out.SLOBurn = topN(burns, s.cfg.TopN)
+	}
+	return out
+}
+
+// kpiViolations evaluates every KPI with thresholds and returns the current
+// violations (critical first, then by KPI ID) and the violation count a week
+// earlier.
+func (s *ExecutiveSummaryService) kpiViolations(ctx context.Context, now time.Time) ([]models.KPIViolation, int, error) {
+	if s.kpis == nil {
+		return nil, 0, fmt.Errorf("KPI registry not configured")
+	}
+	if s.metrics == nil {
+		return nil, 0, fmt.Errorf("metrics backend not configured")
+	}
+	all, _, err := s.kpis.ListKPIs(ctx, models.KPIListRequest{Limit: catalogScanLimit})
+	if err != nil {
+		return nil, 0, err
+	}
+	violations := []models.KPIViolation{}
+	previous, evaluated := 0, 0
+	for _, k := range all {
+		if evaluated >= maxSummaryKPIs {
+			break
+		}
+		if k == nil || k.Formula == "" || len(k.Thresholds) == 0 {
+			continue
+		}
+		evaluated++
+		if samples, err := kpiInstant(ctx, s.metrics, s.datastores, k, k.Formula, nil, time.Time{}); err == nil {
+			if level, value := worstBreach(k.Thresholds, samples); level != "" {
+				violations = append(violations, models.KPIViolation{
+					ID: k.ID, Name: k.Name, ServiceFamily: k.ServiceFamily, Level: level, Value: value,
+				})
+			}
+		}
+		if samples, err := kpiInstant(ctx, s.metrics, s.datastores, k, k.Formula, nil, now.Add(-summaryTrendWindow)); err == nil {
+			if level, _ := worstBreach(k.Thresholds, samples); level != "" {
+				previous++
+			}
+		}
+	}
+	sort.SliceStable(violations, func(i, j int) bool {
+		ci, cj := strings.EqualFold(violations[i].Level, "critical"), strings.EqualFold(violations[j].Level, "critical")
+		if ci != cj {
+			return ci
+		}
+		return violations[i].ID < violations[j].ID
+	})
+	return violations, previous, nil
+}
+
+func (s *ExecutiveSummaryService) listIncidents(ctx context.Context) ([]*weavstore.FailureRecord, error) {
+	if s.incidents == nil {
+		return nil, fmt.Errorf("failure store not configured")
+	}
+	records, _, err := s.incidents.ListFailures(ctx, catalogScanLimit, 0)
+	if err != nil {
+		return nil, err
+	}
+	out := records[:0]
+	for _, r := range records {
+		if r != nil {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+// firingAlerts records each service's currently firing alerts via noisy and
+// returns the total firing now and a week ago.
+func (s *ExecutiveSummaryService) firingAlerts(ctx context.Context, now time.Time, noisy func(string) *models.NoisyService) (int, int, error) {
+	if s.cfg.AlertsQuery == "" {
+		return 0, 0, fmt.Errorf("alerts_query not configured")
+	}
+	current, err := instantQuery(ctx, s.metrics, s.cfg.AlertsQuery, time.Time{})
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, smp := range current {
+		if svc := smp.labels["service"]; svc != "" {
+			noisy(svc).FiringAlerts++
+		}
+	}
+	previous, err := instantQuery(ctx, s.metrics, s.cfg.AlertsQuery, now.Add(-summaryTrendWindow))
+	if err != nil {
+		return 0, 0, err
+	}
+	return len(current), len(previous), nil
+}
+
+// sloBurn returns the error-budget burn rate of each health_score service,
+// highest first.
+func (s *ExecutiveSummaryService) sloBurn(ctx context.Context) ([]models.ServiceBurn, error) {
+	if s.health.ErrorRatioQuery == "" || s.health.SLOTarget <= 0 || s.health.SLOTarget >= 1 {
+		return nil, fmt.Errorf("health_score.error_ratio_query or slo_target not configured")
+	}
+	if len(s.health.Services) == 0 {
+		return nil, fmt.Errorf("health_score.services is empty")
+	}
+	burns := []models.ServiceBurn{}
+	for _, svc := range s.health.Services {
+		samples, err := instantQuery(ctx, s.metrics, strings.ReplaceAll(s.health.ErrorRatioQuery, servicePlaceholder, svc), time.Time{})
+		if err != nil || len(samples) == 0 || math.IsNaN(samples[0].value) {
+			continue
+		}
+		ratio := samples[0].value
+		burns = append(burns, models.ServiceBurn{
+			Service:    svc,
+			ErrorRatio: ratio,
+			BurnRate:   math.Round(ratio/(1-s.health.SLOTarget)*100) / 100,
+		})
+	}
+	sort.SliceStable(burns, func(i, j int) bool { return burns[i].BurnRate > burns[j].BurnRate })
+	return burns, nil
+}
+
+func incidentSeverity(r *weavstore.FailureRecord) string {
+	switch {
+	case r.ConfidenceScore >= 0.8:
+		return IncidentSeverityCritical
+	case r.ConfidenceScore >= 0.5:
+		return IncidentSeverityMajor
+	default:
+		return IncidentSeverityMinor
+	}
+}
+
+func weekTrend(metric string, current, previous int) models.SummaryTrend {
+	t := models.SummaryTrend{Metric: metric, Current: current, Previous: previous}
+	if previous > 0 {
+		pct := math.Round(float64(current-previous)/float64(previous)*1000) / 10
+		t.ChangePct = &pct
+	}
+	return t
+}
+
+func topN[T any](items []T, n int) []T {
+	if len(items) > n {
+		return items[:n]
+	}
+	return items
+}
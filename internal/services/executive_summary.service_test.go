@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// weekAgoQuerier answers queries evaluated at a past time from "<query>@past"
+// entries and current ones from the plain query.
+type weekAgoQuerier healthQuerier
+
+func (q weekAgoQuerier) ExecuteQuery(ctx context.Context, req *models.MetricsQLQueryRequest) (*models.MetricsQLQueryResult, error) {
+	key := req.Query
+	if req.Time != "" {
+		key += "@past"
+	}
+	return healthQuerier(q).ExecuteQuery(ctx, &models.MetricsQLQueryRequest{Query: key})
+}
+
+func TestExecutiveSummaryService_Summary(t *testing.T) {
+	log := logger.New("error")
+	kpis := newFakeKPIRepo()
+	kpis.kpis["lat"] = &models.KPIDefinition{ID: "lat", ServiceFamily: "checkout", Formula: "latency",
+		Thresholds: []models.Threshold{{Level: "warning", Operator: "gt", Value: 1}}}
+	kpis.kpis["err"] = &models.KPIDefinition{ID: "err", ServiceFamily: "payments", Formula: "errors",
+		Thresholds: []models.Threshold{{Level: "critical", Operator: "gt", Value: 10}}}
+
+	querier := weekAgoQuerier{
+		"latency":      {vecSample("2", nil)},
+		"errors":       {vecSample("50", nil)},
+		"latency@past": {vecSample("0.5", nil)},
+		"errors@past":  {vecSample("50", nil)},
+		"ALERTS": {
+			vecSample("1", map[string]interface{}{"service": "payments"}),
+			vecSample("1", map[string]interface{}{"service": "payments"}),
+			vecSample("1", map[string]interface{}{"service": "checkout"}),
+		},
+		"ALERTS@past":           {vecSample("1", map[string]interface{}{"service": "payments"})},
+		`ratio{svc="checkout"}`: {vecSample("0.001", nil)},
+		`ratio{svc="payments"}`: {vecSample("0.005", nil)},
+	}
+	now := time.Now()
+	incidents := healthIncidents{
+		{Services: []string{"checkout"}, DetectionTimestamp: now.Add(-time.Hour), ConfidenceScore: 0.9},
+		{Services: []string{"checkout"}, DetectionTimestamp: now.Add(-2 * time.Hour), ConfidenceScore: 0.6},
+		{Services: []string{"payments"}, DetectionTimestamp: now.Add(-72 * time.Hour), ConfidenceScore: 0.9},
+		{Services: []string{"payments"}, DetectionTimestamp: now.Add(-10 * 24 * time.Hour)},
+	}
+	health := config.HealthScoreConfig{
+		Services:        []string{"checkout", "payments"},
+		IncidentWindow:  24 * time.Hour,
+		ErrorRatioQuery: `ratio{svc="{service}"}`,
+		SLOTarget:       0.999,
+	}
+	cfg := config.ExecutiveSummaryConfig{AlertsQuery: "ALERTS", SnapshotRetention: 1}
+	svc := NewExecutiveSummaryService(querier, kpis, incidents, cache.NewNoopValkeyCache(log), cfg, health, log)
+
+	sum, err := svc.Summary(context.Background(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sum.Unavailable) != 0 {
+		t.Fatalf("unexpected unavailable sections: %v", sum.Unavailable)
+	}
+	if len(sum.TopViolatedKPIs) != 2 || sum.TopViolatedKPIs[0].ID != "err" {
+		t.Fatalf("expected critical violation first, got %+v", sum.TopViolatedKPIs)
+	}
+	if sum.OpenIncidents.Total != 2 || sum.OpenIncidents.BySeverity[IncidentSeverityCritical] != 1 ||
+		sum.OpenIncidents.BySeverity[IncidentSeverityMajor] != 1 {
+		t.Fatalf("unexpected open incidents: %+v", sum.OpenIncidents)
+	}
+	if len(sum.SLOBurn) != 2 || sum.SLOBurn[0].Service != "payments" || sum.SLOBurn[0].BurnRate != 5 {
+		t.Fatalf("unexpected SLO burn: %+v", sum.SLOBurn)
+	}
+	// checkout: 1 alert + 2 incidents; payments: 2 alerts + 1 incident -> tie broken by name.
+	if len(sum.NoisyServices) != 2 || sum.NoisyServices[0].Service != "checkout" || sum.NoisyServices[1].FiringAlerts != 2 {
+		t.Fatalf("unexpected noisy services: %+v", sum.NoisyServices)
+	}
+
+	trends := map[string]models.SummaryTrend{}
+	for _, tr := range sum.Trends {
+		trends[tr.Metric] = tr
+	}
+	if tr := trends["violated_kpis"]; tr.Current != 2 || tr.Previous != 1 || tr.ChangePct == nil || *tr.ChangePct != 100 {
+		t.Fatalf("unexpected KPI trend: %+v", tr)
+	}
+	if tr := trends["incidents"]; tr.Current != 3 || tr.Previous != 1 {
+		t.Fatalf("unexpected incident trend: %+v", tr)
+	}
+	if tr := trends["firing_alerts"]; tr.Current != 3 || tr.Previous != 1 {
+		t.Fatalf("unexpected alert trend: %+v", tr)
+	}
+}
+
+func TestExecutiveSummaryService_UnavailableSections(t *testing.T) {
+	log := logger.New("error")
+	svc := NewExecutiveSummaryService(nil, nil, nil, cache.NewNoopValkeyCache(log), config.ExecutiveSummaryConfig{}, config.HealthScoreConfig{}, log)
+
+	sum, err := svc.Summary(context.Background(), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sum.Unavailable) != 4 || len(sum.Trends) != 0 || sum.OpenIncidents.BySeverity == nil {
+		t.Fatalf("expected every section unavailable, got %+v", sum)
+	}
+}
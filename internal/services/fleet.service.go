@@ -0,0 +1,281 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/version"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const (
+	fleetInstanceKeyPrefix = "fleet:instance:"
+	fleetSchemaKey         = "fleet:schema_version"
+	fleetSchemaLockKey     = "fleet:schema_migration"
+
+	// fleetMissedHeartbeats is how many intervals an instance may miss
+	// before it counts as drained.
+	fleetMissedHeartbeats = 3
+)
+
+// SchemaMigration moves the shared Weaviate schema from layout from to
+// layout to. It must be idempotent: it runs under a fleet-wide lock but is
+// retried after failures.
+type SchemaMigration func(ctx context.Context, from, to int) error
+
+// FleetService is the version handshake between the instances of a
+// deployment during rolling upgrades. Each instance publishes its build,
+// API and schema versions to Valkey every heartbeat. An instance whose
+// build brings a newer schema layout applies it only once no live instance
+// runs a layout older than the build's MinSchemaVersion, i.e. breaking
+// migrations wait until old instances have drained.
+type FleetService struct {
+	cache   cache.ValkeyCluster
+	cfg     config.FleetConfig
+	migrate SchemaMigration
+	self    models.FleetInstance
+	logger  logging.Logger
+
+	mu      sync.Mutex
+	blocked bool
+}
+
+// NewFleetService creates a new fleet version service. migrate may be nil
+// when moving to a new layout needs nothing beyond recording it.
+func NewFleetService(cache cache.ValkeyCluster, cfg config.FleetConfig, migrate SchemaMigration, logger corelogger.Logger) *FleetService {
+	if cfg.HeartbeatInterval <= 0 {
+		cfg.HeartbeatInterval = 15 * time.Second
+	}
+	if cfg.InstanceID == "" {
+		host, err := os.Hostname()
+		if err != nil || host == "" {
+			host = "mirador-core-" + strconv.Itoa(os.Getpid())
+		}
+		cfg.InstanceID = host
+	}
+	return &FleetService{
+		cache:   cache,
+		cfg:     cfg,
+		migrate: migrate,
+		self: models.FleetInstance{
+			InstanceID:       cfg.InstanceID,
+			Version:          cmp.Or(version.Version, "dev"),
+			Commit:           version.CommitHash,
+			APIVersions:      []string{config.APIVersion},
+			SchemaVersion:    version.SchemaVersion,
+			MinSchemaVersion: version.MinSchemaVersion,
+			StartedAt:        time.Now().UTC(),
+		},
+		logger: logging.FromCoreLogger(logger),
+	}
+}
+
+// Start publishes heartbeats and applies pending schema migrations until
+// ctx is cancelled. The instance's entry is removed on shutdown so a
+// rolling upgrade does not wait for it to expire.
+func (s *FleetService) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.HeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		s.beat(ctx)
+		if err := s.reconcileSchema(ctx); err != nil {
+			s.logger.Warn("Schema migration failed", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			dctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			if err := s.cache.Delete(dctx, fleetInstanceKeyPrefix+s.self.InstanceID); err != nil {
+				s.logger.Warn("Failed to remove fleet instance entry", "error", err)
+			}
+			cancel()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *FleetService) beat(ctx context.Context) {
+	self := s.self
+	self.SeenAt = time.Now().UTC()
+	ttl := fleetMissedHeartbeats * s.cfg.HeartbeatInterval
+	if err := s.cache.Set(ctx, fleetInstanceKeyPrefix+self.InstanceID, self, ttl); err != nil {
+		s.logger.Warn("Fleet heartbeat write failed", "error", err)
+	}
+}
+
+// reconcileSchema applies this build's schema layout when it is newer than
+// the recorded one and no live instance is too old for it.
+func (s *FleetService) reconcileSchema(ctx context.Context) error {
+	applied, err := s.appliedSchema(ctx)
+	if err != nil || applied >= s.self.SchemaVersion {
+		return err
+	}
+	instances, err := s.instances(ctx)
+	if err != nil {
+		return err
+	}
+	if blockers := s.schemaBlockers(instances); len(blockers) > 0 {
+		s.setBlocked(true, "Schema migration waiting for older instances to drain",
+			"from", applied, "to", s.self.SchemaVersion, "blocked_by", blockers)
+		return nil
+	}
+
+	ok, err := s.cache.AcquireLock(ctx, fleetSchemaLockKey, time.Minute)
+	if err != nil || !ok {
+		return err // another instance is migrating; check again next beat
+	}
+	defer func() {
+		if err := s.cache.ReleaseLock(context.WithoutCancel(ctx), fleetSchemaLockKey); err != nil {
+			s.logger.Warn("Failed to release schema migration lock", "error", err)
+		}
+	}()
+	if applied, err = s.appliedSchema(ctx); err != nil || applied >= s.self.SchemaVersion {
+		return err
+	}
+	if s.migrate != nil {
+		if err := s.migrate(ctx, applied, s.self.SchemaVersion); err != nil {
+			return fmt.Errorf("migrate schema %d to %d: %w", applied, s.self.SchemaVersion, err)
+		}
+	}
+	if err := s.cache.Set(ctx, fleetSchemaKey, s.self.SchemaVersion, 0); err != nil {
+		return fmt.Errorf("record schema version: %w", err)
+	}
+	s.setBlocked(false, "")
+	s.logger.Info("Schema migrated", "from", applied, "to", s.self.SchemaVersion)
+	return nil
+}
+
+// setBlocked logs state transitions only, not every heartbeat.
+func (s *FleetService) setBlocked(blocked bool, msg string, kv ...interface{}) {
+	s.mu.Lock()
+	changed := s.blocked != blocked
+	s.blocked = blocked
+	s.mu.Unlock()
+	if changed && blocked {
+		s.logger.Warn(msg, kv...)
+	}
+}
+
+// schemaBlockers returns the other live instances running a schema layout
+// older than this build tolerates.
+func (s *FleetService) schemaBlockers(instances []models.FleetInstance) []string {
+	var out []string
+	for _, in := range instances {
+		if in.InstanceID != s.self.InstanceID && in.SchemaVersion < s.self.MinSchemaVersion {
+			out = append(out, in.InstanceID)
+		}
+	}
+	return out
+}
+
+func (s *FleetService) appliedSchema(ctx context.Context) (int, error) {
+	data, err := s.cache.Get(ctx, fleetSchemaKey)
+	if err != nil || len(data) == 0 {
+		// Missing key: no instance has recorded a layout yet.
+		return 0, nil
+	}
+	v, err := strconv.Atoi(string(data))
+	if err != nil {
+		return 0, fmt.Errorf("unreadable schema version %q", data)
+	}
+	return v, nil
+}
+
+// instances returns the live instances ordered by ID.
+func (s *FleetService) instances(ctx context.Context) ([]models.FleetInstance, error) {
+	var keys []string
+	if err := s.cache.ScanKeys(ctx, fleetInstanceKeyPrefix, func(batch []string) error {
+		keys = append(keys, batch...)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("list fleet instances: %w", err)
+	}
+	cutoff := time.Now().Add(-fleetMissedHeartbeats * s.cfg.HeartbeatInterval)
+	out := make([]models.FleetInstance, 0, len(keys))
+	for _, k := range keys {
+		data, err := s.cache.Get(ctx, k)
+		if err != nil || len(data) == 0 {
+			continue // expired between scan and read
+		}
+		var in models.FleetInstance
+		if err := json.Unmarshal(data, &in); err != nil {
+			s.logger.Warn("Unreadable fleet instance entry", "key", k, "error", err)
+			continue
+		}
+		if in.SeenAt.Before(cutoff) {
+			continue
+		}
+		out = append(out, in)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].InstanceID < out[j].InstanceID })
+	return out, nil
+}
+
+// Status reports the live instances, their version skew and the state of
+// the shared schema.
+func (s *FleetService) Status(ctx context.Context) (*models.FleetVersionStatus, error) {
+	instances, err := s.instances(ctx)
+	if err != nil {
+		return nil, err
+	}
+	applied, err := s.appliedSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	st := &models.FleetVersionStatus{
+		Self:              s.self.InstanceID,
+		Instances:         instances,
+		Versions:          []string{},
+		SchemaVersions:    []int{},
+		CommonAPIVersions: []string{},
+		Schema:            models.SchemaMigrationStatus{Applied: applied, Target: applied, State: models.SchemaMigrationCurrent},
+	}
+	var newest *models.FleetInstance
+	for i, in := range instances {
+		if !slices.Contains(st.Versions, in.Version) {
+			st.Versions = append(st.Versions, in.Version)
+		}
+		if !slices.Contains(st.SchemaVersions, in.SchemaVersion) {
+			st.SchemaVersions = append(st.SchemaVersions, in.SchemaVersion)
+		}
+		if i == 0 {
+			st.CommonAPIVersions = append(st.CommonAPIVersions, in.APIVersions...)
+		} else {
+			st.CommonAPIVersions = slices.DeleteFunc(st.CommonAPIVersions, func(v string) bool {
+				return !slices.Contains(in.APIVersions, v)
+			})
+		}
+		if newest == nil || in.SchemaVersion > newest.SchemaVersion {
+			newest = &instances[i]
+		}
+	}
+	sort.Strings(st.Versions)
+	sort.Ints(st.SchemaVersions)
+	st.Skewed = len(st.Versions) > 1 || len(st.SchemaVersions) > 1
+
+	if newest != nil && newest.SchemaVersion > applied {
+		st.Schema.Target = newest.SchemaVersion
+		st.Schema.State = models.SchemaMigrationPending
+		for _, in := range instances {
+			if in.SchemaVersion < newest.MinSchemaVersion {
+				st.Schema.BlockedBy = append(st.Schema.BlockedBy, in.InstanceID)
+			}
+		}
+		if len(st.Schema.BlockedBy) > 0 {
+			st.Schema.State = models.SchemaMigrationBlocked
+		}
+	}
+	return st, nil
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func TestFleetService_BreakingMigrationWaitsForDrain(t *testing.T) {
+	log := logger.New("error")
+	c := cache.NewNoopValkeyCache(log)
+	ctx := context.Background()
+
+	old := NewFleetService(c, config.FleetConfig{InstanceID: "old", HeartbeatInterval: time.Minute}, nil, log)
+	old.self.SchemaVersion, old.self.MinSchemaVersion = 1, 1
+	old.beat(ctx)
+	require.NoError(t, old.reconcileSchema(ctx))
+
+	type step struct{ from, to int }
+	var migrations []step
+	next := NewFleetService(c, config.FleetConfig{InstanceID: "new", HeartbeatInterval: time.Minute},
+		func(ctx context.Context, from, to int) error {
+			migrations = append(migrations, step{from, to})
+			return nil
+		}, log)
+	next.self.Version = "2.0.0"
+	next.self.SchemaVersion, next.self.MinSchemaVersion = 2, 2
+	next.beat(ctx)
+	require.NoError(t, next.reconcileSchema(ctx))
+	assert.Empty(t, migrations, "breaking migration must wait for the old instance")
+
+	st, err := next.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "new", st.Self)
+	require.Len(t, st.Instances, 2)
+	assert.True(t, st.Skewed)
+	assert.Equal(t, []int{1, 2}, st.SchemaVersions)
+	assert.Equal(t, []string{"v1"}, st.CommonAPIVersions)
+	assert.Equal(t, models.SchemaMigrationStatus{Applied: 1, Target: 2, State: models.SchemaMigrationBlocked, BlockedBy: []string{"old"}}, st.Schema)
+
+	// The old instance drains.
+	require.NoError(t, c.Delete(ctx, fleetInstanceKeyPrefix+"old"))
+	require.NoError(t, next.reconcileSchema(ctx))
+	assert.Equal(t, []step{{1, 2}}, migrations)
+
+	st, err = next.Status(ctx)
+	require.NoError(t, err)
+	assert.False(t, st.Skewed)
+	assert.Equal(t, models.SchemaMigrationStatus{Applied: 2, Target: 2, State: models.SchemaMigrationCurrent}, st.Schema)
+}
+
+func TestFleetService_CompatibleMigrationAndStaleInstances(t *testing.T) {
+	log := logger.New("error")
+	c := cache.NewNoopValkeyCache(log)
+	ctx := context.Background()
+
+	old := NewFleetService(c, config.FleetConfig{InstanceID: "old", HeartbeatInterval: time.Minute}, nil, log)
+	old.self.SchemaVersion, old.self.MinSchemaVersion = 1, 1
+	old.beat(ctx)
+	require.NoError(t, old.reconcileSchema(ctx))
+
+	// An instance that stopped heartbeating no longer counts.
+	gone := models.FleetInstance{InstanceID: "gone", SchemaVersion: 0, SeenAt: time.Now().Add(-time.Hour)}
+	require.NoError(t, c.Set(ctx, fleetInstanceKeyPrefix+"gone", gone, 0))
+
+	next := NewFleetService(c, config.FleetConfig{InstanceID: "new", HeartbeatInterval: time.Minute}, nil, log)
+	next.self.SchemaVersion, next.self.MinSchemaVersion = 2, 1
+	next.beat(ctx)
+	require.NoError(t, next.reconcileSchema(ctx))
+
+	st, err := next.Status(ctx)
+	require.NoError(t, err)
+	assert.Len(t, st.Instances, 2)
+	assert.Equal(t, 2, st.Schema.Applied)
+	assert.Equal(t, models.SchemaMigrationCurrent, st.Schema.State)
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultLocale is the fallback language for all localized messages.
+const DefaultLocale = "en"
+
+// Message keys used by notification and report templates.
+const (
+	MsgCorrelationTitle   = "notification.correlation.title"
+	MsgCorrelationMessage = "notification.correlation.message"
+)
+
+// MessageCatalog is an in-memory i18n catalog keyed by locale and message key.
+// Messages are fmt format strings. Lookups fall back from the requested
+// locale to its base language (e.g. "de-AT" -> "de") and then to English.
+type MessageCatalog struct {
+	mu       sync.RWMutex
+	messages map[string]map[string]string
+}
+
+// NewMessageCatalog returns a catalog pre-populated with the built-in messages.
+func NewMessageCatalog() *MessageCatalog {
+	c := &MessageCatalog{messages: make(map[string]map[string]string)}
+	for locale, msgs := range builtinMessages {
+		c.Register(locale, msgs)
+	}
+	return c
+}
+
+// Register adds or overrides messages for a locale.
+func (c *MessageCatalog) Register(locale string, msgs map[string]string) {
+	locale = normalizeLocale(locale)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.messages[locale] == nil {
+		c.messages[locale] = make(map[string]string, len(msgs))
+	}
+	for k, v := range msgs {
+		c.messages[locale][k] = v
+	}
+}
+
+// Locales returns the sorted list of locales known to the catalog.
+func (c *MessageCatalog) Locales() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	out := make([]string, 0, len(c.messages))
+	for l := range c.messages {
+		out = append(out, l)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Supports reports whether the catalog has messages for the locale or its base language.
+func (c *MessageCatalog) Supports(locale string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	l := normalizeLocale(locale)
+	if _, ok := c.messages[l]; ok {
+		return true
+	}
+	if i := strings.Index(l, "-"); i > 0 {
+		_, ok := c.messages[l[:i]]
+		return ok
+	}
+	return false
+}
+
+// Messages returns the effective message set for a locale with fallbacks applied.
+func (c *MessageCatalog) Messages(locale string) map[string]string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	out := make(map[string]string)
+	chain := localeChain(locale)
+	// Apply from least to most specific so specific entries win.
+	for i := len(chain) - 1; i >= 0; i-- {
+		for k, v := range c.messages[chain[i]] {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// T translates key into locale and formats it with args. Unknown keys
+// return the key itself so missing translations are visible but harmless.
+func (c *MessageCatalog) T(locale, key string, args ...interface{}) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, l := range localeChain(locale) {
+		if msg, ok := c.messages[l][key]; ok {
+			if len(args) == 0 {
+				return msg
+			}
+			return fmt.Sprintf(msg, args...)
+		}
+	}
+	return key
+}
+
+// ResolveLocale picks the first supported locale from an explicit user
+// override, an Accept-Language header value and the tenant default.
+func (c *MessageCatalog) ResolveLocale(override, acceptLanguage, tenantDefault string) string {
+	if override != "" && c.Supports(override) {
+		return normalizeLocale(override)
+	}
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		if tag != "" && tag != "*" && c.Supports(tag) {
+			return normalizeLocale(tag)
+		}
+	}
+	if tenantDefault != "" && c.Supports(tenantDefault) {
+		return normalizeLocale(tenantDefault)
+	}
+	return DefaultLocale
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+// localeChain returns the lookup order for a locale: exact, base language, default.
+func localeChain(locale string) []string {
+	l := normalizeLocale(locale)
+	chain := make([]string, 0, 3)
+	if l != "" {
+		chain = append(chain, l)
+		if i := strings.Index(l, "-"); i > 0 {
+			chain = append(chain, l[:i])
+		}
+	}
+	if l != DefaultLocale {
+		chain = append(chain, DefaultLocale)
+	}
+	return chain
+}
+
+var builtinMessages = map[string]map[string]string{
+	"en": {
+		MsgCorrelationTitle:   "Root Cause Found: %s",
+		MsgCorrelationMessage: "Root cause identified: %s (Confidence: %0.1f%%). Affected services: %v",
+	},
+	"de": {
+		MsgCorrelationTitle:   "Ursache gefunden: %s",
+		MsgCorrelationMessage: "Ermittelte Ursache: %s (Konfidenz: %0.1f%%). Betroffene Dienste: %v",
+	},
+	"fr": {
+		MsgCorrelationTitle:   "Cause racine trouvée : %s",
+		MsgCorrelationMessage: "Cause racine identifiée : %s (Confiance : %0.1f%%). Services affectés : %v",
+	},
+	"es": {
+		MsgCorrelationTitle:   "Causa raíz encontrada: %s",
+		MsgCorrelationMessage: "Causa raíz identificada: %s (Confianza: %0.1f%%). Servicios afectados: %v",
+	},
+}
@@ -0,0 +1,289 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const (
+	defaultIDLookupWindow = 24 * time.Hour
+	maxIDLookupWindow     = 7 * 24 * time.Hour
+	defaultIDLookupLimit  = 1000
+	maxIDLookupLimit      = 10000
+	// maxLinkedTraces bounds the traces fetched because matching log lines
+	// reference them.
+	maxLinkedTraces = 10
+)
+
+var ErrInvalidIDLookup = errors.New("invalid id lookup")
+
+var (
+	// lookupIDPattern admits the characters trace, transaction and request
+	// IDs are made of, so the ID can be quoted into LogsQL as is.
+	lookupIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+	// traceIDPattern matches 64- and 128-bit hex trace IDs.
+	traceIDPattern = regexp.MustCompile(`^(?:[0-9a-fA-F]{16}|[0-9a-fA-F]{32})$`)
+)
+
+// idLookupLogFields are the log fields searched for the ID.
+var idLookupLogFields = []string{"trace_id", "traceID", "transaction_id", "request_id"}
+
+// TraceLookupBackend fetches a trace by ID.
+type TraceLookupBackend interface {
+	GetTrace(ctx context.Context, traceID string) (*models.Trace, error)
+}
+
+// IDLookupService answers "what happened to this request": it queries logs
+// and traces for one trace, transaction or request ID at the same time and
+// merges the log lines and spans into one time-ordered result.
+type IDLookupService struct {
+	logs   LogsService
+	traces TraceLookupBackend
+	logger logging.Logger
+}
+
+// NewIDLookupService creates a new ID lookup service. Either backend may be
+// nil; its source is then skipped.
+func NewIDLookupService(logs LogsService, traces TraceLookupBackend, logger corelogger.Logger) *IDLookupService {
+	return &IDLookupService{
+		logs:   logs,
+		traces: traces,
+		logger: logging.FromCoreLogger(logger),
+	}
+}
+
+// Lookup searches the log fields in idLookupLogFields for the ID and, when
+// the ID looks like a trace ID, fetches that trace; both run concurrently.
+// Traces referenced by matching log lines are fetched too. A failing
+// backend is reported in Sources instead of failing the lookup.
+func (s *IDLookupService) Lookup(ctx context.Context, req models.IDLookupRequest) (*models.IDLookupResult, error) {
+	if s.logs == nil && s.traces == nil {
+		return nil, fmt.Errorf("logs and traces backends not configured")
+	}
+	req.ID = strings.TrimSpace(req.ID)
+	if !lookupIDPattern.MatchString(req.ID) {
+		return nil, fmt.Errorf("%w: id must be 1-128 letters, digits, '.', '_', ':' or '-'", ErrInvalidIDLookup)
+	}
+	if req.End.IsZero() {
+		req.End = time.Now().UTC()
+	}
+	if req.Start.IsZero() {
+		req.Start = req.End.Add(-defaultIDLookupWindow)
+	}
+	if !req.End.After(req.Start) {
+		return nil, fmt.Errorf("%w: end must be after start", ErrInvalidIDLookup)
+	}
+	if req.End.Sub(req.Start) > maxIDLookupWindow {
+		return nil, fmt.Errorf("%w: range must not exceed %s", ErrInvalidIDLookup, maxIDLookupWindow)
+	}
+	if req.Limit <= 0 {
+		req.Limit = defaultIDLookupLimit
+	}
+	req.Limit = min(req.Limit, maxIDLookupLimit)
+
+	result := &models.IDLookupResult{
+		ID:       req.ID,
+		Start:    req.Start,
+		End:      req.End,
+		TraceIDs: []string{},
+		Entries:  []models.IDLookupEntry{},
+		Sources:  map[string]models.IDLookupSource{},
+	}
+
+	var (
+		wg      sync.WaitGroup
+		logRows []map[string]any
+		logErr  error
+		direct  *models.Trace
+		dirErr  error
+	)
+	if s.logs != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			logRows, logErr = s.queryLogs(ctx, req)
+		}()
+	}
+	if s.traces != nil && traceIDPattern.MatchString(req.ID) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			direct, dirErr = s.traces.GetTrace(ctx, req.ID)
+		}()
+	}
+	wg.Wait()
+
+	if s.logs != nil {
+		src := models.IDLookupSource{}
+		if logErr != nil {
+			s.logger.Warn("ID lookup log query failed", "id", req.ID, "error", logErr)
+			src.Error = logErr.Error()
+		}
+		if len(logRows) > req.Limit {
+			logRows, src.Truncated = logRows[:req.Limit], true
+		}
+		for _, row := range logRows {
+			result.Entries = append(result.Entries, logLookupEntry(row))
+		}
+		src.Count = len(logRows)
+		result.Sources[models.IDLookupSourceLogs] = src
+	}
+
+	if s.traces != nil {
+		src := models.IDLookupSource{}
+		var traces []*models.Trace
+		switch {
+		case direct != nil:
+			traces = append(traces, direct)
+		case dirErr != nil && !errors.Is(dirErr, ErrTraceNotFound):
+			src.Error = dirErr.Error()
+		}
+		linked, err := s.linkedTraces(ctx, result.Entries, req.ID)
+		if err != nil && src.Error == "" {
+			src.Error = err.Error()
+		}
+		traces = append(traces, linked...)
+
+		spans := 0
+		for _, tr := range traces {
+			result.TraceIDs = append(result.TraceIDs, tr.TraceID)
+			for _, span := range tr.Spans {
+				if spans == req.Limit {
+					src.Truncated = true
+					break
+				}
+				result.Entries = append(result.Entries, spanLookupEntry(tr, span))
+				spans++
+			}
+		}
+		src.Count = spans
+		if src.Error != "" {
+			s.logger.Warn("ID lookup trace fetch failed", "id", req.ID, "error", src.Error)
+		}
+		result.Sources[models.IDLookupSourceTraces] = src
+	}
+
+	sort.SliceStable(result.Entries, func(i, j int) bool {
+		return result.Entries[i].Timestamp.Before(result.Entries[j].Timestamp)
+	})
+	return result, nil
+}
+
+// queryLogs returns up to Limit+1 log lines carrying the ID in one of
+// idLookupLogFields, so the caller can tell the result was truncated.
+func (s *IDLookupService) queryLogs(ctx context.Context, req models.IDLookupRequest) ([]map[string]any, error) {
+	terms := make([]string, len(idLookupLogFields))
+	for i, f := range idLookupLogFields {
+		terms[i] = fmt.Sprintf(`%s:=%q`, f, req.ID)
+	}
+	res, err := s.logs.ExecuteQuery(ctx, &models.LogsQLQueryRequest{
+		Query: strings.Join(terms, " OR "),
+		Start: req.Start.UnixMilli(),
+		End:   req.End.UnixMilli(),
+		Limit: req.Limit + 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.Logs, nil
+}
+
+// linkedTraces fetches, concurrently, up to maxLinkedTraces traces that the
+// log entries reference, other than the looked-up ID itself. Unknown trace
+// IDs are skipped; the first other error is returned with the traces found.
+func (s *IDLookupService) linkedTraces(ctx context.Context, entries []models.IDLookupEntry, id string) ([]*models.Trace, error) {
+	seen := map[string]bool{id: true}
+	var ids []string
+	for _, e := range entries {
+		if e.TraceID != "" && !seen[e.TraceID] && len(ids) < maxLinkedTraces {
+			seen[e.TraceID] = true
+			ids = append(ids, e.TraceID)
+		}
+	}
+	traces := make([]*models.Trace, len(ids))
+	errs := make([]error, len(ids))
+	var wg sync.WaitGroup
+	for i, traceID := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			traces[i], errs[i] = s.traces.GetTrace(ctx, traceID)
+		}()
+	}
+	wg.Wait()
+
+	var found []*models.Trace
+	var firstErr error
+	for i, tr := range traces {
+		switch {
+		case tr != nil:
+			found = append(found, tr)
+		case errs[i] != nil && !errors.Is(errs[i], ErrTraceNotFound) && firstErr == nil:
+			firstErr = errs[i]
+		}
+	}
+	return found, firstErr
+}
+
+// logLookupEntry maps a VictoriaLogs row to an entry.
+func logLookupEntry(row map[string]any) models.IDLookupEntry {
+	e := models.IDLookupEntry{
+		Source:  models.IDLookupSourceLogs,
+		Service: firstString(row, "service.name", "service", "service_name", "app"),
+		Message: firstString(row, "_msg", "message", "msg"),
+		TraceID: firstString(row, "trace_id", "traceID"),
+		SpanID:  firstString(row, "span_id", "spanID"),
+		Data:    row,
+	}
+	if ts := firstString(row, "_time", "timestamp"); ts != "" {
+		if t, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+			e.Timestamp = t.UTC()
+		}
+	}
+	return e
+}
+
+// spanLookupEntry maps a Jaeger span of tr to an entry; the service comes
+// from the span's process.
+func spanLookupEntry(tr *models.Trace, span map[string]any) models.IDLookupEntry {
+	e := models.IDLookupEntry{
+		Source:  models.IDLookupSourceTraces,
+		Message: firstString(span, "operationName"),
+		TraceID: cmp.Or(firstString(span, "traceID"), tr.TraceID),
+		SpanID:  firstString(span, "spanID"),
+		Data:    span,
+	}
+	if us, ok := span["startTime"].(float64); ok {
+		e.Timestamp = time.UnixMicro(int64(us)).UTC()
+	}
+	if us, ok := span["duration"].(float64); ok {
+		e.DurationMicros = int64(us)
+	}
+	if pid := firstString(span, "processID"); pid != "" {
+		if proc, ok := tr.Processes[pid].(map[string]any); ok {
+			e.Service = firstString(proc, "serviceName")
+		}
+	}
+	return e
+}
+
+// firstString returns the first non-empty string value of keys in m.
+func firstString(m map[string]any, keys ...string) string {
+	for _, k := range keys {
+		if v, ok := m[k].(string); ok && v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+type fakeLookupLogs struct {
+	rows  []map[string]any
+	err   error
+	query string
+}
+
+func (f *fakeLookupLogs) ExecuteQuery(ctx context.Context, req *models.LogsQLQueryRequest) (*models.LogsQLQueryResult, error) {
+	f.query = req.Query
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &models.LogsQLQueryResult{Logs: f.rows}, nil
+}
+
+type fakeLookupTraces map[string]*models.Trace
+
+func (f fakeLookupTraces) GetTrace(ctx context.Context, traceID string) (*models.Trace, error) {
+	if tr, ok := f[traceID]; ok {
+		return tr, nil
+	}
+	return nil, ErrTraceNotFound
+}
+
+func lookupTrace(id string, start time.Time) *models.Trace {
+	return &models.Trace{
+		TraceID: id,
+		Spans: []map[string]any{
+			{"traceID": id, "spanID": "s1", "operationName": "POST /pay", "startTime": float64(start.UnixMicro()), "duration": float64(250000), "processID": "p1"},
+			{"traceID": id, "spanID": "s2", "operationName": "SELECT", "startTime": float64(start.Add(50 * time.Millisecond).UnixMicro()), "duration": float64(1000), "processID": "p1"},
+		},
+		Processes: map[string]any{"p1": map[string]any{"serviceName": "payments"}},
+	}
+}
+
+func TestIDLookupService_MergesLogsAndLinkedTraces(t *testing.T) {
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+	logs := &fakeLookupLogs{rows: []map[string]any{
+		{"_time": start.Add(100 * time.Millisecond).Format(time.RFC3339Nano), "_msg": "payment declined", "service.name": "payments", "transaction_id": "tx-42", "trace_id": traceID},
+		{"_time": start.Add(-time.Second).Format(time.RFC3339Nano), "_msg": "checkout started", "service": "web", "transaction_id": "tx-42"},
+	}}
+	svc := NewIDLookupService(logs, fakeLookupTraces{traceID: lookupTrace(traceID, start)}, logger.New("error"))
+
+	res, err := svc.Lookup(context.Background(), models.IDLookupRequest{ID: " tx-42 ", End: start.Add(time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(logs.query, `transaction_id:="tx-42"`) {
+		t.Fatalf("unexpected logs query %q", logs.query)
+	}
+	if len(res.TraceIDs) != 1 || res.TraceIDs[0] != traceID {
+		t.Fatalf("expected the trace referenced by the logs, got %v", res.TraceIDs)
+	}
+	if len(res.Entries) != 4 {
+		t.Fatalf("expected 2 log lines and 2 spans, got %d", len(res.Entries))
+	}
+	want := []string{"checkout started", "POST /pay", "SELECT", "payment declined"}
+	for i, e := range res.Entries {
+		if e.Message != want[i] {
+			t.Fatalf("entry %d: expected %q, got %q (%s)", i, want[i], e.Message, e.Source)
+		}
+	}
+	if res.Entries[1].Source != models.IDLookupSourceTraces || res.Entries[1].Service != "payments" || res.Entries[1].DurationMicros != 250000 {
+		t.Fatalf("unexpected span entry %+v", res.Entries[1])
+	}
+	if res.Sources[models.IDLookupSourceLogs].Count != 2 || res.Sources[models.IDLookupSourceTraces].Count != 2 {
+		t.Fatalf("unexpected sources %+v", res.Sources)
+	}
+}
+
+func TestIDLookupService_TraceIDAndFailures(t *testing.T) {
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	traceID := "a3ce929d0e0e4736"
+	logs := &fakeLookupLogs{err: errors.New("victorialogs unavailable")}
+	svc := NewIDLookupService(logs, fakeLookupTraces{traceID: lookupTrace(traceID, start)}, logger.New("error"))
+
+	// A failing backend is reported, not fatal; the limit truncates spans.
+	res, err := svc.Lookup(context.Background(), models.IDLookupRequest{ID: traceID, Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Sources[models.IDLookupSourceLogs].Error == "" {
+		t.Fatal("expected the logs error to be reported")
+	}
+	if tr := res.Sources[models.IDLookupSourceTraces]; tr.Count != 1 || !tr.Truncated || tr.Error != "" {
+		t.Fatalf("unexpected traces source %+v", tr)
+	}
+
+	for _, req := range []models.IDLookupRequest{
+		{ID: ""},
+		{ID: `x" OR *`},
+		{ID: "tx-1", Start: start, End: start.Add(-time.Minute)},
+		{ID: "tx-1", Start: start, End: start.Add(8 * 24 * time.Hour)},
+	} {
+		if _, err := svc.Lookup(context.Background(), req); !errors.Is(err, ErrInvalidIDLookup) {
+			t.Fatalf("%+v: expected ErrInvalidIDLookup, got %v", req, err)
+		}
+	}
+}
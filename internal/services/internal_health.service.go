@@ -0,0 +1,370 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const (
+	cacheOperationsMetric     = "mirador_core_cache_operations_total"
+	weaviateDurationMetric    = "mirador_core_weaviate_operation_duration_seconds"
+	correlationDurationMetric = "mirador_core_unified_query_correlation_duration_seconds"
+	queryQueueDepthMetric     = "mirador_core_query_queue_depth"
+	internalErrorsMetric      = "mirador_core_errors_total"
+
+	maxInternalHealthPoints = 1000
+)
+
+var ErrInvalidHealthRange = errors.New("invalid health trend range")
+
+// bucketCounts is a histogram's cumulative bucket counts by upper bound,
+// summed over every series of the metric.
+type bucketCounts map[float64]uint64
+
+// internalHealthSample holds the cumulative counters and current gauges of
+// one sampling tick.
+type internalHealthSample struct {
+	at          time.Time
+	cacheHits   uint64
+	cacheMisses uint64
+	weaviate    bucketCounts
+	correlation bucketCounts
+	requests    uint64
+	errors5xx   uint64
+	errors      map[string]uint64
+	queueDepth  map[string]float64
+}
+
+// internalHealthDelta is the activity between two samples.
+type internalHealthDelta struct {
+	at          time.Time // end of the interval
+	seconds     float64
+	cacheHits   uint64
+	cacheMisses uint64
+	weaviate    bucketCounts
+	correlation bucketCounts
+	requests    uint64
+	errors5xx   uint64
+	errors      map[string]uint64
+	queueDepth  map[string]float64
+}
+
+// InternalHealthService samples mirador-core's own metrics (cache hit rate,
+// Weaviate latency, correlation durations, backend queue depths and error
+// rates) and serves them as trends, so the admin page needs no Prometheus
+// access. Samples are kept in memory per instance.
+type InternalHealthService struct {
+	gatherer prometheus.Gatherer
+	cfg      config.InternalHealthConfig
+	logger   logging.Logger
+
+	mu     sync.Mutex
+	last   *internalHealthSample
+	deltas []internalHealthDelta
+}
+
+// NewInternalHealthService creates a new internal health service.
+func NewInternalHealthService(gatherer prometheus.Gatherer, cfg config.InternalHealthConfig, logger corelogger.Logger) *InternalHealthService {
+	if cfg.Retention <= 0 {
+		cfg.Retention = 24 * time.Hour
+	}
+	return &InternalHealthService{
+		gatherer: gatherer,
+		cfg:      cfg,
+		logger:   logging.FromCoreLogger(logger),
+	}
+}
+
+// Start samples the metrics every Interval until ctx is done.
+func (s *InternalHealthService) Start(ctx context.Context) {
+	if s.cfg.Interval <= 0 {
+		return
+	}
+	if err := s.sample(time.Now()); err != nil {
+		s.logger.Warn("Internal health sampling failed", "error", err)
+	}
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.sample(now); err != nil && ctx.Err() == nil {
+				s.logger.Warn("Internal health sampling failed", "error", err)
+			}
+		}
+	}
+}
+
+// Trends aggregates the samples of the last window into points of step.
+// step is rounded up to a multiple of the sampling interval; zero picks one
+// giving about 60 points.
+func (s *InternalHealthService) Trends(ctx context.Context, window, step time.Duration) (*models.InternalHealthTrends, error) {
+	if s.cfg.Interval <= 0 {
+		return nil, fmt.Errorf("%w: sampling is disabled", ErrInvalidHealthRange)
+	}
+	switch {
+	case window <= 0:
+		return nil, fmt.Errorf("%w: window must be positive", ErrInvalidHealthRange)
+	case window > s.cfg.Retention:
+		return nil, fmt.Errorf("%w: window exceeds the retained %s", ErrInvalidHealthRange, s.cfg.Retention)
+	case step < 0:
+		return nil, fmt.Errorf("%w: step must not be negative", ErrInvalidHealthRange)
+	}
+	if step == 0 {
+		step = window / 60
+	}
+	if rem := step % s.cfg.Interval; rem != 0 || step == 0 {
+		step += s.cfg.Interval - rem
+	}
+	if int(window/step) > maxInternalHealthPoints {
+		return nil, fmt.Errorf("%w: more than %d points; use a larger step", ErrInvalidHealthRange, maxInternalHealthPoints)
+	}
+
+	s.mu.Lock()
+	deltas := append([]internalHealthDelta(nil), s.deltas...)
+	s.mu.Unlock()
+
+	now := time.Now()
+	start := now.Add(-window).Truncate(step)
+	var buckets []*internalHealthDelta
+	var starts []time.Time
+	for _, d := range deltas {
+		if d.at.Before(start) || d.at.After(now) {
+			continue
+		}
+		t := d.at.Add(-time.Nanosecond).Truncate(step)
+		if len(starts) == 0 || !starts[len(starts)-1].Equal(t) {
+			starts = append(starts, t)
+			buckets = append(buckets, &internalHealthDelta{})
+		}
+		mergeHealthDelta(buckets[len(buckets)-1], d)
+	}
+
+	trends := &models.InternalHealthTrends{
+		Window:   window.String(),
+		Step:     step.String(),
+		Interval: s.cfg.Interval.String(),
+		Points:   make([]models.InternalHealthPoint, 0, len(buckets)),
+	}
+	for i, b := range buckets {
+		trends.Points = append(trends.Points, healthPoint(starts[i], b))
+	}
+	return trends, nil
+}
+
+// sample gathers the metrics and records the activity since the previous
+// sample, dropping deltas older than Retention.
+func (s *InternalHealthService) sample(now time.Time) error {
+	cur, err := s.collect(now)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev := s.last
+	s.last = cur
+	if prev == nil {
+		return nil
+	}
+	s.deltas = append(s.deltas, diffHealthSamples(prev, cur))
+	cutoff := now.Add(-s.cfg.Retention)
+	drop := 0
+	for drop < len(s.deltas) && s.deltas[drop].at.Before(cutoff) {
+		drop++
+	}
+	s.deltas = s.deltas[drop:]
+	return nil
+}
+
+func (s *InternalHealthService) collect(now time.Time) (*internalHealthSample, error) {
+	families, err := s.gatherer.Gather()
+	if err != nil {
+		return nil, fmt.Errorf("gather metrics: %w", err)
+	}
+	cur := &internalHealthSample{
+		at:          now,
+		weaviate:    bucketCounts{},
+		correlation: bucketCounts{},
+		errors:      map[string]uint64{},
+		queueDepth:  map[string]float64{},
+	}
+	for _, mf := range families {
+		for _, m := range mf.GetMetric() {
+			switch mf.GetName() {
+			case cacheOperationsMetric:
+				if labelValue(m, "operation") != "get" {
+					continue
+				}
+				switch labelValue(m, "result") {
+				case "hit":
+					cur.cacheHits += uint64(m.GetCounter().GetValue())
+				case "miss":
+					cur.cacheMisses += uint64(m.GetCounter().GetValue())
+				}
+			case weaviateDurationMetric:
+				cur.weaviate.add(m.GetHistogram())
+			case correlationDurationMetric:
+				cur.correlation.add(m.GetHistogram())
+			case queryQueueDepthMetric:
+				cur.queueDepth[labelValue(m, "class")] += m.GetGauge().GetValue()
+			case httpRequestsMetric:
+				n := uint64(m.GetCounter().GetValue())
+				cur.requests += n
+				if code, _ := strconv.Atoi(labelValue(m, "status_code")); code >= 500 {
+					cur.errors5xx += n
+				}
+			case internalErrorsMetric:
+				cur.errors[labelValue(m, "type")] += uint64(m.GetCounter().GetValue())
+			}
+		}
+	}
+	return cur, nil
+}
+
+func (b bucketCounts) add(h *dto.Histogram) {
+	for _, bucket := range h.GetBucket() {
+		b[bucket.GetUpperBound()] += bucket.GetCumulativeCount()
+	}
+	b[math.Inf(1)] += h.GetSampleCount()
+}
+
+func diffHealthSamples(prev, cur *internalHealthSample) internalHealthDelta {
+	d := internalHealthDelta{
+		at:          cur.at,
+		seconds:     cur.at.Sub(prev.at).Seconds(),
+		cacheHits:   counterDelta(cur.cacheHits, prev.cacheHits),
+		cacheMisses: counterDelta(cur.cacheMisses, prev.cacheMisses),
+		weaviate:    bucketCounts{},
+		correlation: bucketCounts{},
+		requests:    counterDelta(cur.requests, prev.requests),
+		errors5xx:   counterDelta(cur.errors5xx, prev.errors5xx),
+		errors:      map[string]uint64{},
+		queueDepth:  map[string]float64{},
+	}
+	for bound, n := range cur.weaviate {
+		d.weaviate[bound] = counterDelta(n, prev.weaviate[bound])
+	}
+	for bound, n := range cur.correlation {
+		d.correlation[bound] = counterDelta(n, prev.correlation[bound])
+	}
+	for typ, n := range cur.errors {
+		if delta := counterDelta(n, prev.errors[typ]); delta > 0 {
+			d.errors[typ] = delta
+		}
+	}
+	// Gauges are weighed by interval length so merged steps report a mean.
+	for class, v := range cur.queueDepth {
+		d.queueDepth[class] = v * d.seconds
+	}
+	return d
+}
+
+// mergeHealthDelta adds d into into.
+func mergeHealthDelta(into *internalHealthDelta, d internalHealthDelta) {
+	if into.weaviate == nil {
+		into.weaviate, into.correlation = bucketCounts{}, bucketCounts{}
+		into.errors, into.queueDepth = map[string]uint64{}, map[string]float64{}
+	}
+	into.seconds += d.seconds
+	into.cacheHits += d.cacheHits
+	into.cacheMisses += d.cacheMisses
+	into.requests += d.requests
+	into.errors5xx += d.errors5xx
+	for bound, n := range d.weaviate {
+		into.weaviate[bound] += n
+	}
+	for bound, n := range d.correlation {
+		into.correlation[bound] += n
+	}
+	for typ, n := range d.errors {
+		into.errors[typ] += n
+	}
+	for class, v := range d.queueDepth {
+		into.queueDepth[class] += v
+	}
+}
+
+func healthPoint(start time.Time, d *internalHealthDelta) models.InternalHealthPoint {
+	p := models.InternalHealthPoint{
+		Time:                start,
+		CacheReads:          d.cacheHits + d.cacheMisses,
+		WeaviateLatency:     histogramPercentiles(d.weaviate),
+		CorrelationDuration: histogramPercentiles(d.correlation),
+	}
+	if p.CacheReads > 0 {
+		rate := float64(d.cacheHits) / float64(p.CacheReads)
+		p.CacheHitRate = &rate
+	}
+	if d.seconds > 0 {
+		p.RequestRate = float64(d.requests) / d.seconds
+		for class, v := range d.queueDepth {
+			if p.QueueDepth == nil {
+				p.QueueDepth = map[string]float64{}
+			}
+			p.QueueDepth[class] = v / d.seconds
+		}
+	}
+	if d.requests > 0 {
+		p.ErrorRate = float64(d.errors5xx) / float64(d.requests)
+	}
+	if len(d.errors) > 0 {
+		p.Errors = d.errors
+	}
+	return p
+}
+
+// histogramPercentiles estimates percentiles from cumulative bucket counts,
+// interpolating linearly within a bucket like PromQL's histogram_quantile.
+// It returns nil when nothing was observed.
+func histogramPercentiles(b bucketCounts) *models.LatencyPercentiles {
+	total := b[math.Inf(1)]
+	if total == 0 {
+		return nil
+	}
+	bounds := make([]float64, 0, len(b))
+	for bound := range b {
+		bounds = append(bounds, bound)
+	}
+	sort.Float64s(bounds)
+	quantile := func(q float64) float64 {
+		rank := q * float64(total)
+		lower, below := 0.0, uint64(0)
+		for _, bound := range bounds {
+			n := b[bound]
+			if float64(n) >= rank {
+				if math.IsInf(bound, 1) {
+					// Above the highest finite bucket: report its bound.
+					return lower
+				}
+				if n == below {
+					return bound
+				}
+				return lower + (bound-lower)*(rank-float64(below))/float64(n-below)
+			}
+			lower, below = bound, n
+		}
+		return lower
+	}
+	return &models.LatencyPercentiles{
+		Count: total,
+		P50:   quantile(0.5),
+		P95:   quantile(0.95),
+		P99:   quantile(0.99),
+	}
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func TestInternalHealthService_Trends(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	cacheOps := prometheus.NewCounterVec(prometheus.CounterOpts{Name: cacheOperationsMetric, Help: "cache"}, []string{"operation", "result"})
+	weaviate := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: weaviateDurationMetric, Help: "weaviate", Buckets: []float64{.1, .25, .5, 1}}, []string{"operation", "collection"})
+	queue := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: queryQueueDepthMetric, Help: "queue"}, []string{"class"})
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{Name: httpRequestsMetric, Help: "requests"}, []string{"method", "endpoint", "status_code"})
+	errs := prometheus.NewCounterVec(prometheus.CounterOpts{Name: internalErrorsMetric, Help: "errors"}, []string{"type", "component"})
+	reg.MustRegister(cacheOps, weaviate, queue, requests, errs)
+
+	svc := NewInternalHealthService(reg, config.InternalHealthConfig{Interval: time.Minute, Retention: time.Hour}, logger.New("error"))
+	now := time.Now().Truncate(time.Minute)
+
+	// Activity before the first sample is not attributed to any step.
+	cacheOps.WithLabelValues("get", "miss").Add(100)
+	if err := svc.sample(now.Add(-2 * time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	cacheOps.WithLabelValues("get", "hit").Add(30)
+	cacheOps.WithLabelValues("get", "miss").Add(10)
+	cacheOps.WithLabelValues("set", "success").Add(50)
+	for i := 0; i < 100; i++ {
+		weaviate.WithLabelValues("get", "KPIDefinition").Observe(0.2)
+	}
+	requests.WithLabelValues("GET", "/api/v1/kpi/defs", "200").Add(110)
+	requests.WithLabelValues("GET", "/api/v1/kpi/defs", "503").Add(10)
+	errs.WithLabelValues("weaviate", "KPIDefinition").Add(3)
+	queue.WithLabelValues("interactive").Set(4)
+	if err := svc.sample(now.Add(-time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	queue.WithLabelValues("interactive").Set(0)
+	if err := svc.sample(now); err != nil {
+		t.Fatal(err)
+	}
+
+	trends, err := svc.Trends(context.Background(), 10*time.Minute, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trends.Step != "1m0s" || len(trends.Points) != 2 {
+		t.Fatalf("unexpected trends: %+v", trends)
+	}
+	p := trends.Points[0]
+	if p.CacheHitRate == nil || *p.CacheHitRate != 0.75 || p.CacheReads != 40 {
+		t.Fatalf("unexpected cache hit rate: %+v", p)
+	}
+	if p.WeaviateLatency == nil || p.WeaviateLatency.Count != 100 || p.WeaviateLatency.P50 <= 0.1 || p.WeaviateLatency.P99 > 0.25 {
+		t.Fatalf("unexpected Weaviate latency: %+v", p.WeaviateLatency)
+	}
+	if p.ErrorRate != 10.0/120 || p.RequestRate != 2 || p.Errors["weaviate"] != 3 || p.QueueDepth["interactive"] != 4 {
+		t.Fatalf("unexpected rates: %+v", p)
+	}
+	if idle := trends.Points[1]; idle.CacheHitRate != nil || idle.WeaviateLatency != nil || idle.QueueDepth["interactive"] != 0 {
+		t.Fatalf("expected an idle second step: %+v", idle)
+	}
+
+	// Steps aggregate several samples; the queue depth is the mean.
+	trends, err = svc.Trends(context.Background(), 10*time.Minute, 90*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trends.Step != "2m0s" {
+		t.Fatalf("expected the step rounded up to the interval, got %s", trends.Step)
+	}
+
+	for _, tc := range []struct{ window, step time.Duration }{{2 * time.Hour, 0}, {0, 0}, {time.Hour, -time.Minute}} {
+		if _, err := svc.Trends(context.Background(), tc.window, tc.step); !errors.Is(err, ErrInvalidHealthRange) {
+			t.Fatalf("window %s step %s: expected ErrInvalidHealthRange, got %v", tc.window, tc.step, err)
+		}
+	}
+}
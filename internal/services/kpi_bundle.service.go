@@ -0,0 +1,363 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/repo"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// Bundle encodings accepted by DecodeKPIBundle and produced by
+// EncodeKPIBundle.
+const (
+	KPIBundleJSON = "json"
+	KPIBundleYAML = "yaml"
+)
+
+// maxKPIBundleSize caps the KPIs in one import.
+const maxKPIBundleSize = 5000
+
+var ErrInvalidKPIBundle = errors.New("invalid KPI bundle")
+
+// KPIBundleService exports KPI definitions as a portable bundle and imports
+// bundles into this environment, so catalogs can be migrated between
+// environments without seeding tools. IDs are deterministic (see
+// GenerateDeterministicKPIID), so the same KPI keeps its ID across
+// environments and conflicts are detected by ID.
+type KPIBundleService struct {
+	cfg    *config.Config
+	repo   repo.KPIRepo
+	logger logging.Logger
+}
+
+// NewKPIBundleService creates a new KPI import/export service.
+func NewKPIBundleService(cfg *config.Config, kpiRepo repo.KPIRepo, logger corelogger.Logger) *KPIBundleService {
+	return &KPIBundleService{
+		cfg:    cfg,
+		repo:   kpiRepo,
+		logger: logging.FromCoreLogger(logger),
+	}
+}
+
+// Export returns the KPIs matching filter (tags and semantic filters;
+// paging is ignored) as a bundle.
+func (s *KPIBundleService) Export(ctx context.Context, filter models.KPIListRequest) (*models.KPIBundle, error) {
+	filter.Limit, filter.Offset = catalogScanLimit, 0
+	kpis, _, err := s.repo.ListKPIs(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list KPIs: %w", err)
+	}
+	bundle := &models.KPIBundle{
+		Version:    models.KPIBundleVersion,
+		ExportedAt: time.Now().UTC(),
+		KPIs:       make([]*models.KPIDefinition, 0, len(kpis)),
+	}
+	for _, k := range kpis {
+		if k != nil {
+			bundle.KPIs = append(bundle.KPIs, k)
+		}
+	}
+	slices.SortFunc(bundle.KPIs, func(a, b *models.KPIDefinition) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.ID, b.ID))
+	})
+	return bundle, nil
+}
+
+// Import validates every KPI of bundle and writes the valid ones, resolving
+// conflicts with existing KPIs according to mode. Invalid KPIs are reported
+// and do not stop the others. A dry run reports the same outcomes without
+// writing anything.
+func (s *KPIBundleService) Import(ctx context.Context, bundle *models.KPIBundle, mode string, dryRun bool) (*models.KPIImportResult, error) {
+	return s.ImportWithProgress(ctx, bundle, mode, dryRun, nil)
+}
+
+// ValidateImport checks bundle and mode without reading the catalog, so
+// callers that import in the background can reject bad requests up front.
+func (s *KPIBundleService) ValidateImport(bundle *models.KPIBundle, mode string) error {
+	if mode == "" {
+		mode = models.KPIImportSkip
+	}
+	switch {
+	case bundle == nil:
+		return fmt.Errorf("%w: bundle is required", ErrInvalidKPIBundle)
+	case bundle.Version > models.KPIBundleVersion:
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidKPIBundle, bundle.Version)
+	case len(bundle.KPIs) == 0:
+		return fmt.Errorf("%w: no KPIs", ErrInvalidKPIBundle)
+	case len(bundle.KPIs) > maxKPIBundleSize:
+		return fmt.Errorf("%w: at most %d KPIs per import", ErrInvalidKPIBundle, maxKPIBundleSize)
+	case mode != models.KPIImportSkip && mode != models.KPIImportOverwrite && mode != models.KPIImportMerge:
+		return fmt.Errorf("%w: mode must be skip, overwrite or merge", ErrInvalidKPIBundle)
+	}
+	return nil
+}
+
+// ImportWithProgress is Import calling onItem (when set) after every KPI.
+// An error from onItem stops the import; the partial result is returned
+// with it.
+func (s *KPIBundleService) ImportWithProgress(ctx context.Context, bundle *models.KPIBundle, mode string, dryRun bool, onItem func(models.KPIImportItem) error) (*models.KPIImportResult, error) {
+	if err := s.ValidateImport(bundle, mode); err != nil {
+		return nil, err
+	}
+	if mode == "" {
+		mode = models.KPIImportSkip
+	}
+
+	all, _, err := s.repo.ListKPIs(ctx, models.KPIListRequest{Limit: catalogScanLimit})
+	if err != nil {
+		return nil, fmt.Errorf("list KPIs: %w", err)
+	}
+	existing := make(map[string]*models.KPIDefinition, len(all))
+	for _, k := range all {
+		if k != nil {
+			existing[k.ID] = k
+		}
+	}
+
+	res := &models.KPIImportResult{Mode: mode, DryRun: dryRun, Total: len(bundle.KPIs), Items: []models.KPIImportItem{}}
+	seen := map[string]int{}
+	for i, k := range bundle.KPIs {
+		item := s.importOne(ctx, i, k, existing, seen, mode, dryRun)
+		switch item.Action {
+		case models.KPIImportCreated:
+			res.Created++
+		case models.KPIImportUpdated:
+			res.Updated++
+		case models.KPIImportUnchanged:
+			res.Unchanged++
+		case models.KPIImportSkipped:
+			res.Skipped++
+		default:
+			res.Failed++
+		}
+		res.Items = append(res.Items, item)
+		if onItem != nil {
+			if err := onItem(item); err != nil {
+				return res, err
+			}
+		}
+	}
+	if !dryRun {
+		s.logger.Info("KPI bundle imported", "mode", mode, "created", res.Created, "updated", res.Updated, "skipped", res.Skipped, "failed", res.Failed)
+	}
+	return res, nil
+}
+
+func (s *KPIBundleService) importOne(ctx context.Context, index int, k *models.KPIDefinition, existing map[string]*models.KPIDefinition, seen map[string]int, mode string, dryRun bool) models.KPIImportItem {
+	item := models.KPIImportItem{Index: index, Action: models.KPIImportFailed}
+	if k == nil {
+		item.Error = "item is null"
+		return item
+	}
+	item.Name = k.Name
+	in := *k
+	if in.ID == "" {
+		id, err := GenerateDeterministicKPIID(&in)
+		if err != nil {
+			item.Error = "failed to generate id"
+			return item
+		}
+		in.ID = id
+	}
+	item.ID = in.ID
+	if first, dup := seen[in.ID]; dup {
+		item.Error = fmt.Sprintf("duplicate of item %d", first)
+		return item
+	}
+	seen[in.ID] = index
+
+	current := existing[in.ID]
+	target := &in
+	switch {
+	case current != nil && mode == models.KPIImportSkip:
+		item.Action = models.KPIImportSkipped
+		return item
+	case current != nil && mode == models.KPIImportMerge:
+		merged, err := mergeKPIDefinitions(current, &in)
+		if err != nil {
+			item.Error = err.Error()
+			return item
+		}
+		target = merged
+	}
+
+	if err := ValidateKPIDefinition(s.cfg, target); err != nil {
+		item.Error = "invalid KPI definition"
+		var ve *ValidationError
+		if errors.As(err, &ve) {
+			for _, p := range ve.Problems {
+				item.Details = append(item.Details, p.Field+": "+p.Message)
+			}
+		}
+		return item
+	}
+
+	item.Action = models.KPIImportCreated
+	if current != nil {
+		target.CreatedAt = current.CreatedAt
+		if sameKPIDefinition(current, target) {
+			item.Action = models.KPIImportUnchanged
+			return item
+		}
+		item.Action = models.KPIImportUpdated
+	}
+	if dryRun {
+		return item
+	}
+	target.UpdatedAt = time.Now().UTC()
+	if target.CreatedAt.IsZero() {
+		target.CreatedAt = target.UpdatedAt
+	}
+	if _, _, err := s.repo.ModifyKPI(ctx, target); err != nil {
+		s.logger.Error("KPI import write failed", "id", target.ID, "error", err)
+		item.Action = models.KPIImportFailed
+		item.Error = err.Error()
+	}
+	return item
+}
+
+// mergeKPIDefinitions applies the non-empty fields of in on top of current.
+// Tags and dimension hints are unioned rather than replaced.
+func mergeKPIDefinitions(current, in *models.KPIDefinition) (*models.KPIDefinition, error) {
+	base, err := kpiFields(current)
+	if err != nil {
+		return nil, err
+	}
+	overlay, err := kpiFields(in)
+	if err != nil {
+		return nil, err
+	}
+	for key, v := range overlay {
+		switch key {
+		case "id", "createdAt", "updatedAt":
+			continue
+		case "tags":
+			base[key] = unionStrings(current.Tags, in.Tags)
+			continue
+		case "dimensionsHint":
+			base[key] = unionStrings(current.DimensionsHint, in.DimensionsHint)
+			continue
+		}
+		if !emptyJSONValue(v) {
+			base[key] = v
+		}
+	}
+	data, err := json.Marshal(base)
+	if err != nil {
+		return nil, err
+	}
+	var merged models.KPIDefinition
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return nil, err
+	}
+	return &merged, nil
+}
+
+func kpiFields(k *models.KPIDefinition) (map[string]any, error) {
+	data, err := json.Marshal(k)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]any{}
+	return fields, json.Unmarshal(data, &fields)
+}
+
+func unionStrings(a, b []string) []string {
+	out := append([]string{}, a...)
+	for _, v := range b {
+		if !slices.Contains(out, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func emptyJSONValue(v any) bool {
+	switch t := v.(type) {
+	case nil:
+		return true
+	case string:
+		return t == ""
+	case bool:
+		return !t
+	case float64:
+		return t == 0
+	case []any:
+		return len(t) == 0
+	case map[string]any:
+		return len(t) == 0
+	}
+	return false
+}
+
+// sameKPIDefinition compares two KPIs ignoring their timestamps.
+func sameKPIDefinition(a, b *models.KPIDefinition) bool {
+	x, errX := KPIChecksum(a)
+	y, errY := KPIChecksum(b)
+	return errX == nil && errY == nil && x == y
+}
+
+// KPIChecksum returns the hex SHA-256 of the KPI's JSON encoding without
+// its timestamps. It changes exactly when the stored definition does, so
+// clients can detect drift and make conditional writes (ETag / If-Match).
+func KPIChecksum(k *models.KPIDefinition) (string, error) {
+	x := *k
+	x.CreatedAt, x.UpdatedAt = time.Time{}, time.Time{}
+	data, err := json.Marshal(x)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// DecodeKPIBundle parses a JSON or YAML bundle. YAML uses the same field
+// names as JSON. A bare list of KPI definitions is accepted as a bundle.
+func DecodeKPIBundle(data []byte, format string) (*models.KPIBundle, error) {
+	if format == KPIBundleYAML {
+		var doc any
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidKPIBundle, err)
+		}
+		converted, err := json.Marshal(doc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidKPIBundle, err)
+		}
+		data = converted
+	}
+	var bundle models.KPIBundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		var list []*models.KPIDefinition
+		if json.Unmarshal(data, &list) != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidKPIBundle, err)
+		}
+		bundle.KPIs = list
+	}
+	return &bundle, nil
+}
+
+// EncodeKPIBundle renders bundle as JSON or YAML.
+func EncodeKPIBundle(bundle *models.KPIBundle, format string) ([]byte, error) {
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil || format != KPIBundleYAML {
+		return data, err
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(doc)
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func bundleKPI(name string, tags ...string) *models.KPIDefinition {
+	return &models.KPIDefinition{
+		Name:           name,
+		Namespace:      "payments",
+		Layer:          "impact",
+		SignalType:     "metrics",
+		Sentiment:      "negative",
+		Formula:        name + "_total",
+		Category:       "payments-ops",
+		Dashboard:      uuid.NewSHA1(uuid.NameSpaceURL, []byte("payments")).String(),
+		BusinessImpact: "Customers cannot pay",
+		Tags:           tags,
+	}
+}
+
+func TestKPIBundleService_ImportModes(t *testing.T) {
+	ctx := context.Background()
+	repo := dedupRepo{newFakeKPIRepo()}
+	svc := NewKPIBundleService(&config.Config{}, repo, logger.New("error"))
+
+	res, err := svc.Import(ctx, &models.KPIBundle{KPIs: []*models.KPIDefinition{
+		bundleKPI("errors", "team-a"),
+		bundleKPI("latency"),
+		{Name: "broken"},
+		bundleKPI("errors"),
+	}}, "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Mode != models.KPIImportSkip || res.Created != 2 || res.Failed != 2 || len(repo.kpis) != 2 {
+		t.Fatalf("unexpected first import %+v", res)
+	}
+	if res.Items[2].Details == nil || res.Items[3].Error != "duplicate of item 0" {
+		t.Fatalf("expected validation details and a duplicate error, got %+v", res.Items)
+	}
+	id := res.Items[0].ID
+
+	changed := bundleKPI("errors", "team-b")
+	changed.Category = ""
+	changed.Definition = "Failed payments"
+
+	res, _ = svc.Import(ctx, &models.KPIBundle{KPIs: []*models.KPIDefinition{changed}}, models.KPIImportSkip, false)
+	if res.Skipped != 1 || repo.kpis[id].Definition != "" {
+		t.Fatalf("skip mode must keep the existing KPI: %+v", res)
+	}
+
+	res, _ = svc.Import(ctx, &models.KPIBundle{KPIs: []*models.KPIDefinition{changed}}, models.KPIImportMerge, true)
+	if res.Updated != 1 || repo.kpis[id].Definition != "" {
+		t.Fatalf("dry run must report without writing: %+v", res)
+	}
+
+	res, _ = svc.Import(ctx, &models.KPIBundle{KPIs: []*models.KPIDefinition{changed}}, models.KPIImportMerge, false)
+	merged := repo.kpis[id]
+	if res.Updated != 1 || merged.Definition != "Failed payments" || merged.Category != "payments-ops" || len(merged.Tags) != 2 {
+		t.Fatalf("merge should overlay non-empty fields and union tags: %+v", merged)
+	}
+
+	res, _ = svc.Import(ctx, &models.KPIBundle{KPIs: []*models.KPIDefinition{changed}}, models.KPIImportMerge, false)
+	if res.Unchanged != 1 {
+		t.Fatalf("re-importing the same merge should be unchanged: %+v", res)
+	}
+
+	res, _ = svc.Import(ctx, &models.KPIBundle{KPIs: []*models.KPIDefinition{changed}}, models.KPIImportOverwrite, false)
+	if res.Updated != 1 || repo.kpis[id].Category != "" || len(repo.kpis[id].Tags) != 1 {
+		t.Fatalf("overwrite should replace the KPI: %+v", repo.kpis[id])
+	}
+
+	if _, err := svc.Import(ctx, &models.KPIBundle{KPIs: []*models.KPIDefinition{changed}}, "replace", false); err == nil {
+		t.Fatal("expected an unknown mode to be rejected")
+	}
+}
+
+func TestKPIBundle_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	src := dedupRepo{newFakeKPIRepo()}
+	svc := NewKPIBundleService(&config.Config{}, src, logger.New("error"))
+	if _, err := svc.Import(ctx, &models.KPIBundle{KPIs: []*models.KPIDefinition{bundleKPI("latency", "slo"), bundleKPI("errors")}}, "", false); err != nil {
+		t.Fatal(err)
+	}
+	bundle, err := svc.Export(ctx, models.KPIListRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bundle.Version != models.KPIBundleVersion || len(bundle.KPIs) != 2 || bundle.KPIs[0].Name != "errors" {
+		t.Fatalf("unexpected export %+v", bundle)
+	}
+
+	for _, format := range []string{KPIBundleJSON, KPIBundleYAML} {
+		data, err := EncodeKPIBundle(bundle, format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded, err := DecodeKPIBundle(data, format)
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		dst := dedupRepo{newFakeKPIRepo()}
+		res, err := NewKPIBundleService(&config.Config{}, dst, logger.New("error")).Import(ctx, decoded, "", false)
+		if err != nil || res.Created != 2 {
+			t.Fatalf("%s: unexpected import %+v, %v", format, res, err)
+		}
+		if got := dst.kpis[bundle.KPIs[1].ID]; got == nil || got.Tags[0] != "slo" {
+			t.Fatalf("%s: KPI not carried over: %+v", format, got)
+		}
+	}
+
+	if _, err := DecodeKPIBundle([]byte("- name: a\n"), KPIBundleYAML); err != nil {
+		t.Fatalf("a bare list should decode as a bundle: %v", err)
+	}
+}
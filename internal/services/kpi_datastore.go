@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/datastore"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+)
+
+// kpiInstant evaluates query (k's formula, possibly rewritten) at the given
+// time (now when zero). KPIs whose datastore is a registered warehouse run
+// there as SQL with {at} and params bound; all others go to metrics.
+func kpiInstant(ctx context.Context, metrics HealthMetricsQuerier, stores *datastore.Registry, k *models.KPIDefinition, query string, params map[string]any, at time.Time) ([]promSample, error) {
+	if _, ok := stores.Get(k.Datastore); !ok {
+		return instantQuery(ctx, metrics, query, at)
+	}
+	if at.IsZero() {
+		at = time.Now()
+	}
+	bound := map[string]any{"at": at.UTC()}
+	for name, v := range params {
+		bound[name] = v
+	}
+	rows, err := stores.Query(ctx, k.Datastore, query, bound)
+	if err != nil {
+		return nil, err
+	}
+	samples := make([]promSample, 0, len(rows))
+	for _, r := range rows {
+		samples = append(samples, promSample{labels: r.Labels, value: r.Value})
+	}
+	return samples, nil
+}
@@ -0,0 +1,393 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/repo"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const (
+	defaultDrilldownWindow = time.Hour
+	defaultDrilldownLimit  = 10
+	// Discovered dimensions must split the KPI into 2..maxDrilldownCardinality
+	// label values; at most maxDrilldownDimensions are used together.
+	maxDrilldownCardinality = 100
+	maxDrilldownDimensions  = 3
+	// drilldownPoints is the target number of samples per contributor series.
+	drilldownPoints = 60
+)
+
+var (
+	ErrKPINotFound         = errors.New("kpi not found")
+	ErrKPINoFormula        = errors.New("kpi has no formula to decompose")
+	ErrNoDrilldownLabels   = errors.New("no label dimensions found to decompose the kpi by")
+	ErrInvalidDrilldownDim = errors.New("dimensions must be label names")
+)
+
+// drilldownAggregations are the outer aggregations a KPI formula can be
+// regrouped by; sum and count are additive, so contributions add up.
+var drilldownAggregations = map[string]bool{"sum": true, "count": true, "avg": true, "min": true, "max": true}
+
+// DrilldownMetricsQuerier runs MetricsQL instant and range queries.
+type DrilldownMetricsQuerier interface {
+	HealthMetricsQuerier
+	CapacityMetricsQuerier
+}
+
+// KPIDrilldownService decomposes a KPI's query by label dimensions to find
+// which label sets drove a change in the aggregate.
+type KPIDrilldownService struct {
+	metrics DrilldownMetricsQuerier
+	kpis    repo.KPIRepo
+	logger  logging.Logger
+}
+
+// NewKPIDrilldownService creates a new KPI drill-down service.
+func NewKPIDrilldownService(metrics DrilldownMetricsQuerier, kpis repo.KPIRepo, logger corelogger.Logger) *KPIDrilldownService {
+	return &KPIDrilldownService{
+		metrics: metrics,
+		kpis:    kpis,
+		logger:  logging.FromCoreLogger(logger),
+	}
+}
+
+// Drilldown compares each label set of the KPI now against Window ago and
+// returns the Limit largest movers with their series over the window.
+func (s *KPIDrilldownService) Drilldown(ctx context.Context, req models.KPIDrilldownRequest) (*models.KPIDrilldown, error) {
+	if s.metrics == nil {
+		return nil, fmt.Errorf("metrics backend not configured")
+	}
+	if req.Window <= 0 {
+		req.Window = defaultDrilldownWindow
+	}
+	if req.Limit <= 0 {
+		req.Limit = defaultDrilldownLimit
+	}
+	for _, d := range req.Dimensions {
+		if !validLabelName(d) {
+			return nil, ErrInvalidDrilldownDim
+		}
+	}
+
+	kpi, err := s.kpis.GetKPI(ctx, req.KPIID)
+	if err != nil {
+		return nil, err
+	}
+	if kpi == nil {
+		return nil, ErrKPINotFound
+	}
+	formula := strings.TrimSpace(kpi.Formula)
+	if formula == "" {
+		return nil, ErrKPINoFormula
+	}
+
+	now := time.Now().UTC()
+	baselineAt := now.Add(-req.Window)
+	agg, inner, ok := splitAggregation(formula)
+	if !ok {
+		agg, inner = "", formula
+	}
+	dims := req.Dimensions
+	if len(dims) == 0 {
+		if dims, err = s.discoverDimensions(ctx, inner); err != nil {
+			return nil, err
+		}
+	}
+	query := formula
+	if agg != "" {
+		query = fmt.Sprintf("%s by (%s) (%s)", agg, strings.Join(dims, ", "), inner)
+	}
+
+	out := &models.KPIDrilldown{
+		KPIID:        kpi.ID,
+		Query:        query,
+		Dimensions:   dims,
+		Aggregation:  agg,
+		Window:       req.Window.String(),
+		Contributors: []models.DrilldownContributor{},
+		GeneratedAt:  now,
+	}
+	if out.Current, err = s.aggregate(ctx, formula, time.Time{}); err != nil {
+		return nil, err
+	}
+	if out.Baseline, err = s.aggregate(ctx, formula, baselineAt); err != nil {
+		return nil, err
+	}
+	out.Change = out.Current - out.Baseline
+
+	current, err := instantQuery(ctx, s.metrics, query, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	baseline, err := instantQuery(ctx, s.metrics, query, baselineAt)
+	if err != nil {
+		return nil, err
+	}
+	byKey := map[string]*models.DrilldownContributor{}
+	contributor := func(labels map[string]string) *models.DrilldownContributor {
+		key, projected := labelSetKey(labels, dims)
+		if byKey[key] == nil {
+			byKey[key] = &models.DrilldownContributor{Labels: projected, Series: []models.DrilldownPoint{}}
+		}
+		return byKey[key]
+	}
+	for _, smp := range current {
+		contributor(smp.labels).Current += smp.value
+	}
+	for _, smp := range baseline {
+		contributor(smp.labels).Baseline += smp.value
+	}
+
+	// Ungrouped formulas are summed like sum/count, so shares add up too.
+	additive := agg == "" || agg == "sum" || agg == "count"
+	ranked := make([]*models.DrilldownContributor, 0, len(byKey))
+	for _, c := range byKey {
+		c.Change = c.Current - c.Baseline
+		if additive && out.Change != 0 {
+			share := math.Round(c.Change/out.Change*1000) / 1000
+			c.Share = &share
+		}
+		ranked = append(ranked, c)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		ci, cj := math.Abs(ranked[i].Change), math.Abs(ranked[j].Change)
+		if ci != cj {
+			return ci > cj
+		}
+		ki, _ := labelSetKey(ranked[i].Labels, dims)
+		kj, _ := labelSetKey(ranked[j].Labels, dims)
+		return ki < kj
+	})
+	if len(ranked) > req.Limit {
+		ranked = ranked[:req.Limit]
+	}
+
+	s.attachSeries(ctx, query, dims, ranked, baselineAt, now, req.Window)
+	for _, c := range ranked {
+		out.Contributors = append(out.Contributors, *c)
+	}
+	return out, nil
+}
+
+// aggregate evaluates the KPI itself, summing if it returns several series.
+func (s *KPIDrilldownService) aggregate(ctx context.Context, formula string, at time.Time) (float64, error) {
+	samples, err := instantQuery(ctx, s.metrics, formula, at)
+	if err != nil {
+		return 0, err
+	}
+	total := 0.0
+	for _, smp := range samples {
+		if !math.IsNaN(smp.value) {
+			total += smp.value
+		}
+	}
+	return total, nil
+}
+
+// discoverDimensions picks the labels of the KPI's underlying series that
+// split it into a useful number of groups, most selective first.
+func (s *KPIDrilldownService) discoverDimensions(ctx context.Context, inner string) ([]string, error) {
+	samples, err := instantQuery(ctx, s.metrics, inner, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]map[string]struct{}{}
+	for _, smp := range samples {
+		for k, v := range smp.labels {
+			if k == "__name__" {
+				continue
+			}
+			if values[k] == nil {
+				values[k] = map[string]struct{}{}
+			}
+			values[k][v] = struct{}{}
+		}
+	}
+	dims := []string{}
+	for k, vs := range values {
+		if len(vs) >= 2 && len(vs) <= maxDrilldownCardinality {
+			dims = append(dims, k)
+		}
+	}
+	if len(dims) == 0 {
+		return nil, ErrNoDrilldownLabels
+	}
+	sort.Slice(dims, func(i, j int) bool {
+		if len(values[dims[i]]) != len(values[dims[j]]) {
+			return len(values[dims[i]]) > len(values[dims[j]])
+		}
+		return dims[i] < dims[j]
+	})
+	if len(dims) > maxDrilldownDimensions {
+		dims = dims[:maxDrilldownDimensions]
+	}
+	sort.Strings(dims)
+	return dims, nil
+}
+
+// attachSeries fills in each contributor's series over the window. A failed
+// range query leaves the series empty rather than failing the drill-down.
+func (s *KPIDrilldownService) attachSeries(ctx context.Context, query string, dims []string, contributors []*models.DrilldownContributor, start, end time.Time, window time.Duration) {
+	if len(contributors) == 0 {
+		return
+	}
+	step := window / drilldownPoints
+	if step < 15*time.Second {
+		step = 15 * time.Second
+	}
+	res, err := s.metrics.ExecuteRangeQuery(ctx, &models.MetricsQLRangeQueryRequest{
+		Query: query,
+		Start: start.Format(time.RFC3339),
+		End:   end.Format(time.RFC3339),
+		Step:  strconv.Itoa(int(step.Seconds())),
+	})
+	if err != nil || res == nil || res.Data == nil {
+		s.logger.Debug("Drill-down range query failed", "query", query, "error", err)
+		return
+	}
+	series, err := decodeRangeMatrix(res.Data)
+	if err != nil {
+		s.logger.Debug("Unexpected drill-down range result", "query", query, "error", err)
+		return
+	}
+	byKey := map[string]*models.DrilldownContributor{}
+	for _, c := range contributors {
+		key, _ := labelSetKey(c.Labels, dims)
+		byKey[key] = c
+	}
+	for _, ser := range series {
+		key, _ := labelSetKey(ser.labels, dims)
+		c := byKey[key]
+		if c == nil {
+			continue
+		}
+		for i := range ser.times {
+			c.Series = append(c.Series, models.DrilldownPoint{Timestamp: ser.times[i].UTC(), Value: ser.values[i]})
+		}
+	}
+	for _, c := range contributors {
+		sort.Slice(c.Series, func(i, j int) bool { return c.Series[i].Timestamp.Before(c.Series[j].Timestamp) })
+	}
+}
+
+// labelSetKey projects labels onto dims and returns a stable key for the
+// projection.
+func labelSetKey(labels map[string]string, dims []string) (string, map[string]string) {
+	projected := make(map[string]string, len(dims))
+	parts := make([]string, 0, len(dims))
+	for _, d := range dims {
+		projected[d] = labels[d]
+		parts = append(parts, d+"="+strconv.Quote(labels[d]))
+	}
+	return strings.Join(parts, ","), projected
+}
+
+func validLabelName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (i > 0 && r >= '0' && r <= '9') {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// splitAggregation recognizes formulas that are a single outer aggregation,
+// e.g. "sum(rate(x[5m]))", "sum by (job) (x)" or "avg(x) without (pod)",
+// and returns the aggregation and its argument. Any existing grouping is
+// dropped since the drill-down regroups by its own dimensions.
+func splitAggregation(formula string) (string, string, bool) {
+	rest := strings.TrimSpace(formula)
+	i := 0
+	for i < len(rest) && rest[i] >= 'a' && rest[i] <= 'z' {
+		i++
+	}
+	agg := rest[:i]
+	if !drilldownAggregations[agg] {
+		return "", "", false
+	}
+	rest = strings.TrimSpace(rest[i:])
+	if r, ok := skipGrouping(rest); ok {
+		rest = r
+	}
+	if !strings.HasPrefix(rest, "(") {
+		return "", "", false
+	}
+	end := matchingParen(rest)
+	if end < 0 {
+		return "", "", false
+	}
+	inner := strings.TrimSpace(rest[1:end])
+	tail := strings.TrimSpace(rest[end+1:])
+	if tail != "" {
+		r, ok := skipGrouping(tail)
+		if !ok || r != "" {
+			return "", "", false
+		}
+	}
+	if inner == "" {
+		return "", "", false
+	}
+	return agg, inner, true
+}
+
+// skipGrouping strips a leading "by (...)" or "without (...)" clause.
+func skipGrouping(s string) (string, bool) {
+	for _, kw := range []string{"by", "without"} {
+		r, ok := strings.CutPrefix(s, kw)
+		if !ok {
+			continue
+		}
+		r = strings.TrimSpace(r)
+		if !strings.HasPrefix(r, "(") {
+			continue
+		}
+		end := matchingParen(r)
+		if end < 0 {
+			return s, false
+		}
+		return strings.TrimSpace(r[end+1:]), true
+	}
+	return s, false
+}
+
+// matchingParen returns the index of the parenthesis closing s[0], skipping
+// quoted strings.
+func matchingParen(s string) int {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
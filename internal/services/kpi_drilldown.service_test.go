@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// drilldownQuerier answers instant queries like weekAgoQuerier and range
+// queries with a two-point matrix per instant series.
+type drilldownQuerier struct{ weekAgoQuerier }
+
+func (q drilldownQuerier) ExecuteRangeQuery(ctx context.Context, req *models.MetricsQLRangeQueryRequest) (*models.MetricsQLRangeQueryResult, error) {
+	result := []interface{}{}
+	for _, s := range q.weekAgoQuerier[req.Query] {
+		v := s["value"].([]interface{})[1]
+		result = append(result, map[string]interface{}{
+			"metric": s["metric"],
+			"values": []interface{}{[]interface{}{float64(1700000000), "0"}, []interface{}{float64(1700000060), v}},
+		})
+	}
+	return &models.MetricsQLRangeQueryResult{Data: map[string]interface{}{"resultType": "matrix", "result": result}}, nil
+}
+
+func TestSplitAggregation(t *testing.T) {
+	cases := []struct {
+		formula, agg, inner string
+		ok                  bool
+	}{
+		{"sum(rate(http_errors_total[5m]))", "sum", "rate(http_errors_total[5m])", true},
+		{"sum by (job) (rate(x[5m]))", "sum", "rate(x[5m])", true},
+		{`avg(x{path="/a)"}) without (pod)`, "avg", `x{path="/a)"}`, true},
+		{"sum(x) / sum(y)", "", "", false},
+		{"sum_over_time(x[5m])", "", "", false},
+		{"rate(x[5m])", "", "", false},
+	}
+	for _, tc := range cases {
+		agg, inner, ok := splitAggregation(tc.formula)
+		if agg != tc.agg || inner != tc.inner || ok != tc.ok {
+			t.Errorf("splitAggregation(%q) = %q, %q, %v", tc.formula, agg, inner, ok)
+		}
+	}
+}
+
+func TestKPIDrilldownService_Drilldown(t *testing.T) {
+	log := logger.New("error")
+	kpis := newFakeKPIRepo()
+	kpis.kpis["errs"] = &models.KPIDefinition{ID: "errs", Formula: "sum(rate(errors[5m]))"}
+	kpis.kpis["ratio"] = &models.KPIDefinition{ID: "ratio", Formula: "sum(a) / sum(b)"}
+
+	inst := func(i string) map[string]interface{} { return map[string]interface{}{"instance": i, "job": "api"} }
+	querier := drilldownQuerier{weekAgoQuerier{
+		"sum(rate(errors[5m]))":      {vecSample("30", nil)},
+		"sum(rate(errors[5m]))@past": {vecSample("10", nil)},
+		"rate(errors[5m])":           {vecSample("25", inst("a")), vecSample("5", inst("b"))},
+		"sum by (instance) (rate(errors[5m]))": {
+			vecSample("25", map[string]interface{}{"instance": "a"}),
+			vecSample("5", map[string]interface{}{"instance": "b"}),
+		},
+		"sum by (instance) (rate(errors[5m]))@past": {
+			vecSample("6", map[string]interface{}{"instance": "a"}),
+			vecSample("4", map[string]interface{}{"instance": "b"}),
+		},
+		"sum(a) / sum(b)": {vecSample("1", nil)},
+	}}
+	svc := NewKPIDrilldownService(querier, kpis, log)
+
+	// job has a single value, so only instance is discovered.
+	res, err := svc.Drilldown(context.Background(), models.KPIDrilldownRequest{KPIID: "errs"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Query != "sum by (instance) (rate(errors[5m]))" || res.Change != 20 || len(res.Contributors) != 2 {
+		t.Fatalf("unexpected drill-down: %+v", res)
+	}
+	top := res.Contributors[0]
+	if top.Labels["instance"] != "a" || top.Change != 19 || top.Share == nil || *top.Share != 0.95 {
+		t.Fatalf("expected instance a to explain 95%% of the change, got %+v", top)
+	}
+	if len(top.Series) != 2 || top.Series[1].Value != 25 {
+		t.Fatalf("expected contributor series, got %+v", top.Series)
+	}
+
+	if _, err := svc.Drilldown(context.Background(), models.KPIDrilldownRequest{KPIID: "ratio"}); !errors.Is(err, ErrNoDrilldownLabels) {
+		t.Fatalf("expected ErrNoDrilldownLabels for an undecomposable ratio, got %v", err)
+	}
+	if _, err := svc.Drilldown(context.Background(), models.KPIDrilldownRequest{KPIID: "errs", Dimensions: []string{"bad-label"}}); !errors.Is(err, ErrInvalidDrilldownDim) {
+		t.Fatalf("expected ErrInvalidDrilldownDim, got %v", err)
+	}
+}
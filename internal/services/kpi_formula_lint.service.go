@@ -0,0 +1,531 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+var ErrFormulaCatalogUnavailable = errors.New("formula catalog unavailable")
+
+// FormulaLintMetricsCatalog lists the label names and values known to the
+// metrics backend (implemented by VictoriaMetricsService).
+type FormulaLintMetricsCatalog interface {
+	GetLabels(ctx context.Context, request *models.LabelsRequest) ([]string, error)
+	GetLabelValues(ctx context.Context, request *models.LabelValuesRequest) ([]string, error)
+}
+
+// FormulaLintLogsCatalog lists the fields seen in recent logs (implemented
+// by VictoriaLogsService).
+type FormulaLintLogsCatalog interface {
+	GetFields(ctx context.Context) ([]string, error)
+}
+
+// Log fields every VictoriaLogs entry has.
+var builtinLogFields = map[string]bool{"_time": true, "_msg": true, "_stream": true, "_stream_id": true}
+
+// MetricsQL words that look like metric names but are not; the grouping
+// ones take a label list.
+var (
+	metricsQLKeywords = map[string]bool{
+		"and": true, "or": true, "unless": true, "bool": true, "offset": true, "keep_metric_names": true,
+		"inf": true, "nan": true, "if": true, "ifnot": true, "default": true, "limit": true,
+	}
+	metricsQLGroupingKeywords = map[string]bool{
+		"by": true, "without": true, "on": true, "ignoring": true, "group_left": true, "group_right": true,
+	}
+)
+
+// KPIFormulaLintService checks KPI formulas against the metrics and logs
+// catalog and reports metric names, labels and log fields that do not
+// exist, deprecated names, and high-cardinality labels used for grouping or
+// regex matching. Findings are warnings: they never block saving a KPI.
+type KPIFormulaLintService struct {
+	metrics     FormulaLintMetricsCatalog
+	logs        FormulaLintLogsCatalog
+	cardinality HighCardinalityLabels
+	cfg         config.KPIFormulaLintConfig
+	logger      logging.Logger
+}
+
+// NewKPIFormulaLintService creates a new KPI formula lint service. Either
+// catalog may be nil when its backend is not configured.
+func NewKPIFormulaLintService(metrics FormulaLintMetricsCatalog, logs FormulaLintLogsCatalog, cfg config.KPIFormulaLintConfig, logger corelogger.Logger) *KPIFormulaLintService {
+	if cfg.Lookback <= 0 {
+		cfg.Lookback = 24 * time.Hour
+	}
+	return &KPIFormulaLintService{
+		metrics: metrics,
+		logs:    logs,
+		cfg:     cfg,
+		logger:  logging.FromCoreLogger(logger),
+	}
+}
+
+// SetCardinality makes labels flagged by the latest cardinality scan count
+// as high cardinality without asking the backend.
+func (s *KPIFormulaLintService) SetCardinality(c HighCardinalityLabels) {
+	s.cardinality = c
+}
+
+// Lint returns the warnings for k's formula, in formula order. KPIs without
+// a MetricsQL or LogsQL formula have none.
+func (s *KPIFormulaLintService) Lint(ctx context.Context, k *models.KPIDefinition) ([]models.FormulaWarning, error) {
+	if k == nil || strings.TrimSpace(k.Formula) == "" {
+		return []models.FormulaWarning{}, nil
+	}
+	var (
+		warnings []models.FormulaWarning
+		err      error
+	)
+	switch formulaLanguage(k) {
+	case "metricsql":
+		warnings, err = s.lintMetrics(ctx, k.Formula)
+	case "logsql":
+		warnings, err = s.lintLogs(ctx, k.Formula)
+	default:
+		return []models.FormulaWarning{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(warnings, func(i, j int) bool { return warnings[i].Start < warnings[j].Start })
+	return warnings, nil
+}
+
+// formulaLanguage tells which query language k's formula is written in, or
+// "" when it is not linted (SQL, opaque business formulas).
+func formulaLanguage(k *models.KPIDefinition) string {
+	qt := strings.ToLower(strings.TrimSpace(k.QueryType))
+	switch qt {
+	case "metricsql", "promql":
+		return "metricsql"
+	case "logsql":
+		return "logsql"
+	case "":
+	default:
+		return ""
+	}
+	switch strings.ToLower(strings.TrimSpace(k.SignalType)) {
+	case "metrics", "metricdef", "metriclabeldef", "metricformula":
+		return "metricsql"
+	case "logs", "logaggrformula", "logfielddef":
+		return "logsql"
+	}
+	return ""
+}
+
+func (s *KPIFormulaLintService) lintMetrics(ctx context.Context, formula string) ([]models.FormulaWarning, error) {
+	if s.metrics == nil {
+		return nil, fmt.Errorf("%w: metrics backend not configured", ErrFormulaCatalogUnavailable)
+	}
+	selectors, grouping := parseMetricsFormula(formula)
+	end := time.Now().UTC()
+	start := end.Add(-s.cfg.Lookback)
+	w := newFormulaWarnings()
+
+	labelsOf := map[string]map[string]bool{}
+	known := []string{}
+	all := map[string]bool{}
+	for _, sel := range selectors {
+		metric := sel.metric.name
+		if metric == "" {
+			continue
+		}
+		w.deprecated(sel.metric, "metric", s.cfg.DeprecatedMetrics)
+		labels, seen := labelsOf[metric]
+		if !seen {
+			names, err := s.metrics.GetLabels(ctx, &models.LabelsRequest{
+				Start: start.Format(time.RFC3339),
+				End:   end.Format(time.RFC3339),
+				Match: []string{fmt.Sprintf("{__name__=%q}", metric)},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrFormulaCatalogUnavailable, err)
+			}
+			labels = map[string]bool{}
+			for _, n := range names {
+				if n != "__name__" {
+					labels[n], all[n] = true, true
+				}
+			}
+			labelsOf[metric] = labels
+			if len(names) > 0 {
+				known = append(known, metric)
+			}
+		}
+		if !slices.Contains(known, metric) {
+			w.add(models.FormulaWarnUnknownMetric, sel.metric,
+				fmt.Sprintf("metric %q has no series in the last %s", metric, s.cfg.Lookback), "")
+			continue
+		}
+		for _, m := range sel.matchers {
+			if m.label.name == "__name__" {
+				continue
+			}
+			w.deprecated(m.label, "label", s.cfg.DeprecatedLabels)
+			if !labels[m.label.name] {
+				w.add(models.FormulaWarnUnknownLabel, m.label,
+					fmt.Sprintf("label %q does not exist on metric %q", m.label.name, metric), "")
+			} else if m.op == "=~" || m.op == "!~" {
+				if err := s.checkCardinality(ctx, w, m.label, []string{metric}, start, end); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	// Matchers of selectors without a metric name can only be checked for
+	// deprecation.
+	for _, sel := range selectors {
+		if sel.metric.name == "" {
+			for _, m := range sel.matchers {
+				w.deprecated(m.label, "label", s.cfg.DeprecatedLabels)
+			}
+		}
+	}
+
+	for _, g := range grouping {
+		w.deprecated(g, "label", s.cfg.DeprecatedLabels)
+		if len(known) == 0 {
+			continue
+		}
+		if !all[g.name] {
+			w.add(models.FormulaWarnUnknownLabel, g,
+				fmt.Sprintf("label %q does not exist on any metric of the formula", g.name), "")
+			continue
+		}
+		if err := s.checkCardinality(ctx, w, g, known, start, end); err != nil {
+			return nil, err
+		}
+	}
+	return w.list, nil
+}
+
+// checkCardinality flags label when the latest cardinality scan flagged it,
+// or when the metrics have more than MaxLabelValues distinct values of it.
+func (s *KPIFormulaLintService) checkCardinality(ctx context.Context, w *formulaWarnings, label formulaToken, metrics []string, start, end time.Time) error {
+	if s.cardinality != nil {
+		if n, ok := s.cardinality.FlaggedLabels(ctx)[label.name]; ok {
+			w.add(models.FormulaWarnHighCardinality, label,
+				fmt.Sprintf("label %q has about %d values across all metrics; grouping or regex matching on it is expensive", label.name, n), "")
+			return nil
+		}
+	}
+	if s.cfg.MaxLabelValues <= 0 {
+		return nil
+	}
+	values, err := s.metrics.GetLabelValues(ctx, &models.LabelValuesRequest{
+		Label: label.name,
+		Start: start.Format(time.RFC3339),
+		End:   end.Format(time.RFC3339),
+		Match: []string{fmt.Sprintf("{__name__=~%q}", strings.Join(metrics, "|"))},
+		Limit: s.cfg.MaxLabelValues + 1,
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrFormulaCatalogUnavailable, err)
+	}
+	if len(values) > s.cfg.MaxLabelValues {
+		w.add(models.FormulaWarnHighCardinality, label,
+			fmt.Sprintf("label %q has more than %d values; grouping or regex matching on it is expensive", label.name, s.cfg.MaxLabelValues), "")
+	}
+	return nil
+}
+
+func (s *KPIFormulaLintService) lintLogs(ctx context.Context, formula string) ([]models.FormulaWarning, error) {
+	if s.logs == nil {
+		return nil, fmt.Errorf("%w: logs backend not configured", ErrFormulaCatalogUnavailable)
+	}
+	fields, err := s.logs.GetFields(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrFormulaCatalogUnavailable, err)
+	}
+	known := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		known[f] = true
+	}
+	w := newFormulaWarnings()
+	for _, f := range parseLogsFormula(formula) {
+		w.deprecated(f, "field", s.cfg.DeprecatedLabels)
+		if !builtinLogFields[f.name] && len(known) > 0 && !known[f.name] {
+			w.add(models.FormulaWarnUnknownField, f,
+				fmt.Sprintf("field %q was not seen in recent logs", f.name), "")
+		}
+	}
+	return w.list, nil
+}
+
+// formulaWarnings collects warnings, once per code and position.
+type formulaWarnings struct {
+	list []models.FormulaWarning
+	seen map[string]bool
+}
+
+func newFormulaWarnings() *formulaWarnings {
+	return &formulaWarnings{list: []models.FormulaWarning{}, seen: map[string]bool{}}
+}
+
+func (w *formulaWarnings) add(code string, tok formulaToken, msg, replacement string) {
+	key := fmt.Sprintf("%s/%d", code, tok.start)
+	if w.seen[key] {
+		return
+	}
+	w.seen[key] = true
+	w.list = append(w.list, models.FormulaWarning{
+		Code:        code,
+		Message:     msg,
+		Token:       tok.name,
+		Start:       tok.start,
+		End:         tok.start + len(tok.name),
+		Replacement: replacement,
+	})
+}
+
+func (w *formulaWarnings) deprecated(tok formulaToken, kind string, names []config.DeprecatedName) {
+	for _, d := range names {
+		if d.Name != tok.name {
+			continue
+		}
+		msg := fmt.Sprintf("%s %q is deprecated", kind, tok.name)
+		if d.Replacement != "" {
+			msg += fmt.Sprintf("; use %q", d.Replacement)
+		}
+		w.add(models.FormulaWarnDeprecated, tok, msg, d.Replacement)
+		return
+	}
+}
+
+// formulaToken is a name in a formula and its byte offset.
+type formulaToken struct {
+	name  string
+	start int
+}
+
+type formulaMatcher struct {
+	label formulaToken
+	op    string
+	value formulaToken
+}
+
+// formulaSelector is a series selector: a metric name, label matchers, or
+// both.
+type formulaSelector struct {
+	metric   formulaToken
+	matchers []formulaMatcher
+}
+
+// parseMetricsFormula extracts the series selectors of a MetricsQL/PromQL
+// formula and the labels it groups or joins on (by, without, on,
+// ignoring, group_left, group_right). It is a scanner, not a full parser:
+// string literals, durations, numbers and comments are skipped, and a name
+// followed by "(" is taken as a function call.
+func parseMetricsFormula(f string) ([]formulaSelector, []formulaToken) {
+	var selectors []formulaSelector
+	var grouping []formulaToken
+	for i := 0; i < len(f); {
+		c := f[i]
+		switch {
+		case c == '"' || c == '\'' || c == '`':
+			i = skipFormulaString(f, i)
+		case c == '#':
+			for i < len(f) && f[i] != '\n' {
+				i++
+			}
+		case c == '[':
+			for i < len(f) && f[i] != ']' {
+				i++
+			}
+		case c == '{':
+			var sel formulaSelector
+			sel.matchers, i = parseFormulaMatchers(f, i)
+			selectors = append(selectors, withNameMatcher(sel))
+		case isFormulaIdentStart(c):
+			start := i
+			for i < len(f) && isFormulaIdentChar(f[i]) {
+				i++
+			}
+			name := f[start:i]
+			next := skipFormulaSpace(f, i)
+			lower := strings.ToLower(name)
+			switch {
+			case next < len(f) && f[next] == '(' && metricsQLGroupingKeywords[lower]:
+				var labels []formulaToken
+				labels, i = parseFormulaLabelList(f, next)
+				grouping = append(grouping, labels...)
+			case next < len(f) && f[next] == '(':
+				// function call or parenthesised operand; scanned in turn
+			case strings.HasPrefix(f[next:], "by") || strings.HasPrefix(f[next:], "without"):
+				// aggregation with a leading grouping: "sum by (job) (...)"
+			case metricsQLKeywords[lower] || metricsQLGroupingKeywords[lower]:
+			case next < len(f) && f[next] == '{':
+				sel := formulaSelector{metric: formulaToken{name: name, start: start}}
+				sel.matchers, i = parseFormulaMatchers(f, next)
+				selectors = append(selectors, sel)
+			default:
+				selectors = append(selectors, formulaSelector{metric: formulaToken{name: name, start: start}})
+			}
+		case c >= '0' && c <= '9' || c == '.':
+			for i < len(f) && (isFormulaIdentChar(f[i]) || f[i] == '.') {
+				i++
+			}
+		default:
+			i++
+		}
+	}
+	return selectors, grouping
+}
+
+// withNameMatcher takes the metric name of a {__name__="x"} selector from
+// its matcher.
+func withNameMatcher(sel formulaSelector) formulaSelector {
+	for _, m := range sel.matchers {
+		if m.label.name == "__name__" && m.op == "=" && m.value.name != "" {
+			sel.metric = m.value
+		}
+	}
+	return sel
+}
+
+// parseFormulaMatchers parses the label matchers of the selector whose "{"
+// is at open and returns them with the offset after the closing "}".
+func parseFormulaMatchers(f string, open int) ([]formulaMatcher, int) {
+	var matchers []formulaMatcher
+	i := open + 1
+	for i < len(f) && f[i] != '}' {
+		i = skipFormulaSpace(f, i)
+		if i >= len(f) || f[i] == '}' {
+			break
+		}
+		if f[i] == ',' {
+			i++
+			continue
+		}
+		if !isFormulaIdentStart(f[i]) {
+			if f[i] == '"' || f[i] == '\'' || f[i] == '`' {
+				i = skipFormulaString(f, i)
+			} else {
+				i++
+			}
+			continue
+		}
+		start := i
+		for i < len(f) && isFormulaIdentChar(f[i]) {
+			i++
+		}
+		label := formulaToken{name: f[start:i], start: start}
+		i = skipFormulaSpace(f, i)
+		op := ""
+		for _, candidate := range []string{"=~", "!~", "!=", "="} {
+			if strings.HasPrefix(f[i:], candidate) {
+				op = candidate
+				break
+			}
+		}
+		if op == "" {
+			// "or" between MetricsQL filter groups, or junk
+			continue
+		}
+		i = skipFormulaSpace(f, i+len(op))
+		var value formulaToken
+		if i < len(f) && (f[i] == '"' || f[i] == '\'' || f[i] == '`') {
+			end := skipFormulaString(f, i)
+			value = formulaToken{name: strings.Trim(f[i:end], "\"'`"), start: i + 1}
+			i = end
+		}
+		matchers = append(matchers, formulaMatcher{label: label, op: op, value: value})
+	}
+	return matchers, min(i+1, len(f))
+}
+
+// parseFormulaLabelList parses "(a, b)" at open and returns the labels with
+// the offset after ")".
+func parseFormulaLabelList(f string, open int) ([]formulaToken, int) {
+	var labels []formulaToken
+	i := open + 1
+	for i < len(f) && f[i] != ')' {
+		if isFormulaIdentStart(f[i]) {
+			start := i
+			for i < len(f) && isFormulaIdentChar(f[i]) {
+				i++
+			}
+			labels = append(labels, formulaToken{name: f[start:i], start: start})
+			continue
+		}
+		i++
+	}
+	return labels, min(i+1, len(f))
+}
+
+func skipFormulaString(f string, i int) int {
+	quote := f[i]
+	for i++; i < len(f); i++ {
+		if f[i] == '\\' && quote != '`' {
+			i++
+			continue
+		}
+		if f[i] == quote {
+			return i + 1
+		}
+	}
+	return len(f)
+}
+
+func skipFormulaSpace(f string, i int) int {
+	for i < len(f) && (f[i] == ' ' || f[i] == '\t' || f[i] == '\n' || f[i] == '\r') {
+		i++
+	}
+	return i
+}
+
+func isFormulaIdentStart(c byte) bool {
+	return c == '_' || c == ':' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isFormulaIdentChar(c byte) bool {
+	return isFormulaIdentStart(c) || c >= '0' && c <= '9'
+}
+
+var (
+	logsFieldFilterRe = regexp.MustCompile(`(?:^|[\s(!-])([A-Za-z_][\w.]*)\s*:`)
+	logsByClauseRe    = regexp.MustCompile(`\bby\s*\(([^)]*)\)`)
+	logsFieldNameRe   = regexp.MustCompile(`\b[A-Za-z_][\w.]*`)
+)
+
+// parseLogsFormula extracts the fields a LogsQL formula filters on
+// ("field:value") or groups by ("by (a, b)"), outside string literals.
+func parseLogsFormula(f string) []formulaToken {
+	blank := []byte(f)
+	for i := 0; i < len(blank); {
+		if c := blank[i]; c == '"' || c == '\'' || c == '`' {
+			end := skipFormulaString(f, i)
+			for j := i + 1; j < end-1; j++ {
+				blank[j] = ' '
+			}
+			i = end
+			continue
+		}
+		i++
+	}
+	stripped := string(blank)
+
+	var fields []formulaToken
+	for _, m := range logsFieldFilterRe.FindAllStringSubmatchIndex(stripped, -1) {
+		fields = append(fields, formulaToken{name: stripped[m[2]:m[3]], start: m[2]})
+	}
+	for _, m := range logsByClauseRe.FindAllStringSubmatchIndex(stripped, -1) {
+		list := stripped[m[2]:m[3]]
+		for _, n := range logsFieldNameRe.FindAllStringIndex(list, -1) {
+			fields = append(fields, formulaToken{name: list[n[0]:n[1]], start: m[2] + n[0]})
+		}
+	}
+	return fields
+}
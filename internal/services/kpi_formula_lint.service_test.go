@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// fakeLintCatalog serves label names per metric and label values per label.
+type fakeLintCatalog struct {
+	labels map[string][]string
+	values map[string]int
+	fields []string
+	err    error
+}
+
+func (f *fakeLintCatalog) GetLabels(ctx context.Context, req *models.LabelsRequest) ([]string, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	for metric, labels := range f.labels {
+		if req.Match[0] == fmt.Sprintf("{__name__=%q}", metric) {
+			return append([]string{"__name__"}, labels...), nil
+		}
+	}
+	return []string{}, nil
+}
+
+func (f *fakeLintCatalog) GetLabelValues(ctx context.Context, req *models.LabelValuesRequest) ([]string, error) {
+	n := min(f.values[req.Label], req.Limit)
+	return make([]string, n), nil
+}
+
+func (f *fakeLintCatalog) GetFields(ctx context.Context) ([]string, error) {
+	return f.fields, f.err
+}
+
+func lintCodes(ws []models.FormulaWarning) string {
+	parts := make([]string, 0, len(ws))
+	for _, w := range ws {
+		parts = append(parts, w.Code+":"+w.Token)
+	}
+	return strings.Join(parts, ",")
+}
+
+func TestKPIFormulaLintService_Metrics(t *testing.T) {
+	catalog := &fakeLintCatalog{
+		labels: map[string][]string{
+			"http_requests_total": {"job", "status", "path", "instance"},
+			"http_errors_total":   {"job", "status"},
+		},
+		values: map[string]int{"path": 50000, "job": 12},
+	}
+	cfg := config.KPIFormulaLintConfig{
+		MaxLabelValues:    1000,
+		DeprecatedMetrics: []config.DeprecatedName{{Name: "http_errors_total", Replacement: "http_requests_total"}},
+		DeprecatedLabels:  []config.DeprecatedName{{Name: "instance"}},
+	}
+	svc := NewKPIFormulaLintService(catalog, catalog, cfg, logger.New("error"))
+
+	formula := `sum by (job, path) (rate(http_requests_total{status=~"5..", region="eu", instance!=""}[5m]))` +
+		` / on (job) group_left sum(rate(http_errors_total[5m])) + missing_metric offset 1h > bool 0.5`
+	ws, err := svc.Lint(context.Background(), &models.KPIDefinition{QueryType: "MetricsQL", Formula: formula})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "high_cardinality:path,unknown_label:region,deprecated:instance,deprecated:http_errors_total,unknown_metric:missing_metric"
+	if got := lintCodes(ws); got != want {
+		t.Fatalf("unexpected warnings\n got %s\nwant %s", got, want)
+	}
+	for _, w := range ws {
+		if formula[w.Start:w.End] != w.Token {
+			t.Fatalf("offsets of %+v do not point at the token", w)
+		}
+	}
+	if ws[3].Replacement != "http_requests_total" {
+		t.Fatalf("expected the replacement, got %+v", ws[3])
+	}
+
+	// SQL and opaque formulas are not linted; catalog failures are reported.
+	if ws, err := svc.Lint(context.Background(), &models.KPIDefinition{QueryType: "SQL", Formula: "SELECT 1"}); err != nil || len(ws) != 0 {
+		t.Fatalf("expected no warnings for SQL, got %v, %v", ws, err)
+	}
+	catalog.err = errors.New("connection refused")
+	if _, err := svc.Lint(context.Background(), &models.KPIDefinition{SignalType: "metrics", Formula: "up"}); !errors.Is(err, ErrFormulaCatalogUnavailable) {
+		t.Fatalf("expected ErrFormulaCatalogUnavailable, got %v", err)
+	}
+}
+
+func TestKPIFormulaLintService_Logs(t *testing.T) {
+	catalog := &fakeLintCatalog{fields: []string{"_msg", "level", "service.name", "kubernetes.pod"}}
+	cfg := config.KPIFormulaLintConfig{DeprecatedLabels: []config.DeprecatedName{{Name: "kubernetes.pod", Replacement: "k8s.pod.name"}}}
+	svc := NewKPIFormulaLintService(nil, catalog, cfg, logger.New("error"))
+
+	formula := `_time:5m level:error -servce.name:"checkout:v2" | stats by (kubernetes.pod, service.name) count() hits`
+	ws, err := svc.Lint(context.Background(), &models.KPIDefinition{SignalType: "logs", Formula: formula})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := lintCodes(ws), "unknown_field:servce.name,deprecated:kubernetes.pod"; got != want {
+		t.Fatalf("unexpected warnings\n got %s\nwant %s", got, want)
+	}
+}
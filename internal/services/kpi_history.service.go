@@ -0,0 +1,355 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/datastore"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/qos"
+	"github.com/mirastacklabs-ai/mirador-core/internal/repo"
+	"github.com/mirastacklabs-ai/mirador-core/internal/weavstore"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const (
+	// maxKPIHistoryDays bounds the range of one history request.
+	maxKPIHistoryDays = 92
+
+	// maxKPIHistoryBuckets bounds the buckets of one history request.
+	maxKPIHistoryBuckets = 2000
+
+	// kpiHistoryLockTTL bounds how long a crashed replica can hold the lock
+	// of a KPI's day; kpiHistoryLockWait is how long an append waits for it.
+	kpiHistoryLockTTL  = 10 * time.Second
+	kpiHistoryLockWait = 2 * time.Second
+)
+
+var (
+	ErrKPIHistoryUnavailable    = errors.New("KPI history store not configured")
+	ErrInvalidKPIHistoryRequest = errors.New("invalid KPI history request")
+	ErrKPIHistoryBusy           = errors.New("KPI history day is locked by another replica")
+)
+
+// KPIEvaluationStore persists recorded KPI evaluations, one record per KPI
+// and UTC day.
+type KPIEvaluationStore interface {
+	GetKPIEvaluationDay(ctx context.Context, kpiID, day string) (*weavstore.KPIEvaluationDay, error)
+	PutKPIEvaluationDay(ctx context.Context, d *weavstore.KPIEvaluationDay) error
+	PruneKPIEvaluations(ctx context.Context, before string) (int, error)
+}
+
+// RemediationEvaluator runs the remediation rules matching an event;
+// RemediationService satisfies it.
+type RemediationEvaluator interface {
+	Evaluate(ctx context.Context, ev models.RemediationEvent) ([]models.RemediationExecution, error)
+}
+
+// KPIHistoryService periodically evaluates every KPI with a formula and
+// thresholds, records its value and threshold state, and serves the
+// recorded states bucketed over time for availability/violation heatmaps.
+type KPIHistoryService struct {
+	metrics HealthMetricsQuerier
+	kpis    repo.KPIRepo
+	store   KPIEvaluationStore
+	cfg     config.KPIHistoryConfig
+	logger  logging.Logger
+
+	// warehouses for SQL KPIs; see SetDatastores
+	datastores *datastore.Registry
+
+	// notified of KPIs entering a warning or critical state; see
+	// SetRemediation
+	remediation RemediationEvaluator
+
+	// appendMu serializes the read-modify-write of appendPoint in this
+	// process; locks, when set, does so across replicas. See SetLocks.
+	appendMu sync.Mutex
+	locks    cache.ValkeyCluster
+}
+
+// NewKPIHistoryService creates a new KPI history service. store may be nil;
+// recording is then disabled and History returns ErrKPIHistoryUnavailable.
+func NewKPIHistoryService(metrics HealthMetricsQuerier, kpis repo.KPIRepo, store KPIEvaluationStore, cfg config.KPIHistoryConfig, logger corelogger.Logger) *KPIHistoryService {
+	return &KPIHistoryService{
+		metrics: metrics,
+		kpis:    kpis,
+		store:   store,
+		cfg:     cfg,
+		logger:  logging.FromCoreLogger(logger),
+	}
+}
+
+// SetDatastores lets KPIs stored in the registered warehouses be recorded;
+// their SQL gets the KPI's service family bound to {service}.
+func (s *KPIHistoryService) SetDatastores(stores *datastore.Registry) {
+	s.datastores = stores
+}
+
+// SetRemediation has every KPI that enters a warning or critical state
+// evaluated against the remediation rules.
+func (s *KPIHistoryService) SetRemediation(r RemediationEvaluator) {
+	s.remediation = r
+}
+
+// SetLocks makes every append take a lock on the KPI's day in c, so
+// several recording replicas do not overwrite each other's points.
+func (s *KPIHistoryService) SetLocks(c cache.ValkeyCluster) {
+	s.locks = c
+}
+
+// Start records KPI states every Interval and prunes history older than
+// RetentionDays once a day, until ctx ends.
+func (s *KPIHistoryService) Start(ctx context.Context) {
+	if s.store == nil || s.kpis == nil || s.cfg.Interval <= 0 {
+		return
+	}
+	ctx = qos.WithClass(ctx, qos.Background)
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+	pruned := ""
+	for {
+		now := time.Now().UTC()
+		if _, err := s.Record(ctx, now); err != nil {
+			s.logger.Warn("Failed to record KPI states", "error", err)
+		}
+		if day := now.Format(weavstore.KPIEvaluationDayFormat); s.cfg.RetentionDays > 0 && day != pruned {
+			cutoff := now.AddDate(0, 0, -s.cfg.RetentionDays).Format(weavstore.KPIEvaluationDayFormat)
+			if n, err := s.store.PruneKPIEvaluations(ctx, cutoff); err != nil {
+				s.logger.Warn("Failed to prune KPI history", "error", err)
+			} else {
+				pruned = day
+				if n > 0 {
+					s.logger.Info("Pruned KPI history", "days", n, "before", cutoff)
+				}
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Record evaluates the KPIs with a formula and thresholds (at most MaxKPIs,
+// by ID) at now and appends their states to the day's history. It returns
+// how many KPIs were recorded.
+func (s *KPIHistoryService) Record(ctx context.Context, now time.Time) (int, error) {
+	if s.store == nil {
+		return 0, ErrKPIHistoryUnavailable
+	}
+	all, _, err := s.kpis.ListKPIs(ctx, models.KPIListRequest{Limit: catalogScanLimit})
+	if err != nil {
+		return 0, fmt.Errorf("list KPIs: %w", err)
+	}
+	kpis := make([]*models.KPIDefinition, 0, len(all))
+	for _, k := range all {
+		if k != nil && k.ID != "" && strings.TrimSpace(k.Formula) != "" && len(k.Thresholds) > 0 {
+			kpis = append(kpis, k)
+		}
+	}
+	slices.SortFunc(kpis, func(a, b *models.KPIDefinition) int { return cmp.Compare(a.ID, b.ID) })
+
+	now = now.UTC()
+	day := now.Format(weavstore.KPIEvaluationDayFormat)
+	recorded := 0
+	for _, k := range kpis {
+		if s.cfg.MaxKPIs > 0 && recorded >= s.cfg.MaxKPIs {
+			break
+		}
+		if ctx.Err() != nil {
+			return recorded, ctx.Err()
+		}
+		point := s.evaluate(ctx, k, now)
+		prev, err := s.appendPoint(ctx, k.ID, day, point)
+		if err != nil {
+			s.logger.Warn("Failed to store KPI state", "kpi", k.ID, "error", err)
+			continue
+		}
+		recorded++
+		if s.remediation != nil && point.State != prev &&
+			(point.State == models.KPIStateWarning || point.State == models.KPIStateCritical) {
+			s.raiseKPIState(ctx, k, point)
+		}
+	}
+	return recorded, nil
+}
+
+// evaluate returns the state of k at now. Formulas with a {service}
+// placeholder are evaluated for the KPI's service family.
+func (s *KPIHistoryService) evaluate(ctx context.Context, k *models.KPIDefinition, now time.Time) weavstore.KPIEvaluationPoint {
+	point := weavstore.KPIEvaluationPoint{Time: now, State: models.KPIStateNoData}
+	query := k.Formula
+	if _, ok := s.datastores.Get(k.Datastore); !ok {
+		if strings.Contains(query, servicePlaceholder) && k.ServiceFamily == "" {
+			return point
+		}
+		query = strings.ReplaceAll(query, servicePlaceholder, k.ServiceFamily)
+	}
+	samples, err := kpiInstant(ctx, s.metrics, s.datastores, k, query, map[string]any{"service": k.ServiceFamily}, now)
+	if err != nil || len(samples) == 0 {
+		return point
+	}
+	level, value := worstBreach(k.Thresholds, samples)
+	switch {
+	case level == "":
+		value = samples[0].value
+		point.State = models.KPIStateOK
+	case strings.EqualFold(level, "critical"):
+		point.State = models.KPIStateCritical
+	default:
+		point.State = models.KPIStateWarning
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		point.State = models.KPIStateNoData
+		return point
+	}
+	point.Value = value
+	return point
+}
+
+// appendPoint adds point to the day's history and returns the state
+// recorded before it that day ("" for the first point). The day is stored
+// as one object, so the read and write happen under a lock.
+func (s *KPIHistoryService) appendPoint(ctx context.Context, kpiID, day string, point weavstore.KPIEvaluationPoint) (string, error) {
+	s.appendMu.Lock()
+	defer s.appendMu.Unlock()
+	unlock, err := s.lockDay(ctx, kpiID, day)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	d, err := s.store.GetKPIEvaluationDay(ctx, kpiID, day)
+	if err != nil {
+		return "", err
+	}
+	if d == nil {
+		d = &weavstore.KPIEvaluationDay{KPIID: kpiID, Day: day}
+	}
+	prev := ""
+	if len(d.Points) > 0 {
+		prev = d.Points[len(d.Points)-1].State
+	}
+	d.Points = append(d.Points, point)
+	return prev, s.store.PutKPIEvaluationDay(ctx, d)
+}
+
+// lockDay takes the cross-replica lock of a KPI's day, waiting up to
+// kpiHistoryLockWait for another replica's append to finish.
+func (s *KPIHistoryService) lockDay(ctx context.Context, kpiID, day string) (func(), error) {
+	if s.locks == nil {
+		return func() {}, nil
+	}
+	return lockCache(ctx, s.locks, "kpi:history:lock:"+kpiID+":"+day, kpiHistoryLockTTL, kpiHistoryLockWait, ErrKPIHistoryBusy, s.logger)
+}
+
+// raiseKPIState evaluates the remediation rules for k entering point.State.
+func (s *KPIHistoryService) raiseKPIState(ctx context.Context, k *models.KPIDefinition, point weavstore.KPIEvaluationPoint) {
+	value := point.Value
+	ev := models.RemediationEvent{
+		Type:     models.RemediationEventKPIState,
+		Service:  k.ServiceFamily,
+		KPIID:    k.ID,
+		KPIName:  k.Name,
+		Severity: point.State,
+		Summary:  fmt.Sprintf("KPI %s is %s (value %g)", cmp.Or(k.Name, k.ID), point.State, value),
+		Value:    &value,
+		Time:     point.Time,
+	}
+	if _, err := s.remediation.Evaluate(ctx, ev); err != nil {
+		s.logger.Warn("Failed to evaluate remediation rules", "kpi", k.ID, "error", err)
+	}
+}
+
+// History returns the recorded states of kpiID in [from, to), bucketed by
+// step.
+func (s *KPIHistoryService) History(ctx context.Context, kpiID string, from, to time.Time, step time.Duration) (*models.KPIStateHistory, error) {
+	if s.store == nil {
+		return nil, ErrKPIHistoryUnavailable
+	}
+	from, to = from.UTC(), to.UTC()
+	switch {
+	case kpiID == "":
+		return nil, fmt.Errorf("%w: kpi id is required", ErrInvalidKPIHistoryRequest)
+	case !to.After(from):
+		return nil, fmt.Errorf("%w: to must be after from", ErrInvalidKPIHistoryRequest)
+	case to.Sub(from) > maxKPIHistoryDays*24*time.Hour:
+		return nil, fmt.Errorf("%w: range must not exceed %d days", ErrInvalidKPIHistoryRequest, maxKPIHistoryDays)
+	case step <= 0 || int64(to.Sub(from)/step) >= maxKPIHistoryBuckets:
+		return nil, fmt.Errorf("%w: step must be positive and give at most %d buckets", ErrInvalidKPIHistoryRequest, maxKPIHistoryBuckets)
+	}
+
+	n := int((to.Sub(from) + step - 1) / step)
+	buckets := make([]models.KPIStateBucket, n)
+	for i := range buckets {
+		buckets[i] = models.KPIStateBucket{Start: from.Add(time.Duration(i) * step), State: models.KPIStateNoData}
+	}
+	withData, ok := 0, 0
+	for day := from.Truncate(24 * time.Hour); day.Before(to); day = day.Add(24 * time.Hour) {
+		d, err := s.store.GetKPIEvaluationDay(ctx, kpiID, day.Format(weavstore.KPIEvaluationDayFormat))
+		if err != nil {
+			return nil, err
+		}
+		if d == nil {
+			continue
+		}
+		for _, p := range d.Points {
+			if p.Time.Before(from) || !p.Time.Before(to) {
+				continue
+			}
+			b := &buckets[int(p.Time.Sub(from)/step)]
+			switch p.State {
+			case models.KPIStateOK:
+				b.OK++
+			case models.KPIStateWarning:
+				b.Warning++
+			case models.KPIStateCritical:
+				b.Critical++
+			default:
+				b.NoData++
+				continue
+			}
+			v := p.Value
+			b.Last = &v
+			withData++
+			if p.State == models.KPIStateOK {
+				ok++
+			}
+		}
+	}
+	for i := range buckets {
+		buckets[i].State = bucketState(buckets[i])
+	}
+
+	h := &models.KPIStateHistory{KPIID: kpiID, From: from, To: to, Step: step.String(), Buckets: buckets}
+	if withData > 0 {
+		availability := float64(ok) / float64(withData)
+		h.Availability = &availability
+	}
+	return h, nil
+}
+
+// bucketState is the worst state recorded in b.
+func bucketState(b models.KPIStateBucket) string {
+	switch {
+	case b.Critical > 0:
+		return models.KPIStateCritical
+	case b.Warning > 0:
+		return models.KPIStateWarning
+	case b.OK > 0:
+		return models.KPIStateOK
+	}
+	return models.KPIStateNoData
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/weavstore"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// memoryEvaluationStore keeps KPI evaluation days in memory.
+type memoryEvaluationStore map[string]*weavstore.KPIEvaluationDay
+
+func (m memoryEvaluationStore) GetKPIEvaluationDay(ctx context.Context, kpiID, day string) (*weavstore.KPIEvaluationDay, error) {
+	if d, ok := m[kpiID+"|"+day]; ok {
+		cp := *d
+		cp.Points = append([]weavstore.KPIEvaluationPoint(nil), d.Points...)
+		return &cp, nil
+	}
+	return nil, nil
+}
+
+func (m memoryEvaluationStore) PutKPIEvaluationDay(ctx context.Context, d *weavstore.KPIEvaluationDay) error {
+	m[d.KPIID+"|"+d.Day] = d
+	return nil
+}
+
+func (m memoryEvaluationStore) PruneKPIEvaluations(ctx context.Context, before string) (int, error) {
+	n := 0
+	for k, d := range m {
+		if d.Day < before {
+			delete(m, k)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func TestKPIHistoryService_RecordAndHistory(t *testing.T) {
+	ctx := context.Background()
+	kpis := newFakeKPIRepo()
+	kpis.kpis["lat"] = &models.KPIDefinition{ID: "lat", ServiceFamily: "checkout", Formula: `latency_p99{service="{service}"}`,
+		Thresholds: []models.Threshold{{Level: "warning", Operator: "gt", Value: 0.5}, {Level: "critical", Operator: "gt", Value: 2}}}
+	kpis.kpis["err"] = &models.KPIDefinition{ID: "err", Formula: "errors",
+		Thresholds: []models.Threshold{{Level: "critical", Operator: "gt", Value: 10}}}
+	kpis.kpis["none"] = &models.KPIDefinition{ID: "none", Formula: "no_thresholds"}
+
+	querier := healthQuerier{`latency_p99{service="checkout"}`: {vecSample("0.8", nil)}}
+	store := memoryEvaluationStore{}
+	svc := NewKPIHistoryService(querier, kpis, store, config.KPIHistoryConfig{MaxKPIs: 10}, logger.New("error"))
+
+	start := time.Date(2026, 3, 1, 23, 50, 0, 0, time.UTC)
+	if n, err := svc.Record(ctx, start); err != nil || n != 2 {
+		t.Fatalf("expected 2 KPIs recorded, got %d, %v", n, err)
+	}
+	querier[`latency_p99{service="checkout"}`] = []map[string]interface{}{vecSample("0.1", nil)}
+	if _, err := svc.Record(ctx, start.Add(5*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	querier[`latency_p99{service="checkout"}`] = []map[string]interface{}{vecSample("3", nil)}
+	if _, err := svc.Record(ctx, start.Add(15*time.Minute)); err != nil { // next day
+		t.Fatal(err)
+	}
+	if len(store) != 4 {
+		t.Fatalf("expected one record per KPI and day, got %d", len(store))
+	}
+
+	h, err := svc.History(ctx, "lat", start.Add(-10*time.Minute), start.Add(20*time.Minute), 10*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h.Buckets) != 3 {
+		t.Fatalf("expected 3 buckets, got %+v", h.Buckets)
+	}
+	if b := h.Buckets[0]; b.State != models.KPIStateNoData || b.Last != nil {
+		t.Fatalf("expected an empty first bucket, got %+v", b)
+	}
+	if b := h.Buckets[1]; b.State != models.KPIStateWarning || b.Warning != 1 || b.OK != 1 || *b.Last != 0.1 {
+		t.Fatalf("expected the worst state of the bucket, got %+v", b)
+	}
+	if b := h.Buckets[2]; b.State != models.KPIStateCritical {
+		t.Fatalf("expected the next day's critical state, got %+v", b)
+	}
+	if h.Availability == nil || *h.Availability < 0.33 || *h.Availability > 0.34 {
+		t.Fatalf("expected 1/3 availability, got %v", h.Availability)
+	}
+
+	h, _ = svc.History(ctx, "err", start, start.Add(time.Hour), time.Hour)
+	if h.Buckets[0].NoData != 3 || h.Buckets[0].State != models.KPIStateNoData || h.Availability != nil {
+		t.Fatalf("KPIs without data should be recorded as no_data, got %+v", h)
+	}
+
+	if _, err := svc.History(ctx, "lat", start, start.Add(24*time.Hour), time.Second); !errors.Is(err, ErrInvalidKPIHistoryRequest) {
+		t.Fatalf("expected too many buckets to be rejected, got %v", err)
+	}
+}
+
+type recordingRemediation struct {
+	events []models.RemediationEvent
+}
+
+func (r *recordingRemediation) Evaluate(ctx context.Context, ev models.RemediationEvent) ([]models.RemediationExecution, error) {
+	r.events = append(r.events, ev)
+	return nil, nil
+}
+
+func TestKPIHistoryService_RaisesStateChanges(t *testing.T) {
+	ctx := context.Background()
+	kpis := newFakeKPIRepo()
+	kpis.kpis["disk"] = &models.KPIDefinition{ID: "disk", Name: "disk_used", ServiceFamily: "db", Formula: "disk_used",
+		Thresholds: []models.Threshold{{Level: "critical", Operator: "gt", Value: 90}}}
+
+	querier := healthQuerier{"disk_used": {vecSample("95", nil)}}
+	remediation := &recordingRemediation{}
+	svc := NewKPIHistoryService(querier, kpis, memoryEvaluationStore{}, config.KPIHistoryConfig{}, logger.New("error"))
+	svc.SetRemediation(remediation)
+
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	for i, value := range []string{"95", "96", "50", "97"} {
+		querier["disk_used"] = []map[string]interface{}{vecSample(value, nil)}
+		if _, err := svc.Record(ctx, start.Add(time.Duration(i)*5*time.Minute)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(remediation.events) != 2 {
+		t.Fatalf("expected an event per transition into critical, got %+v", remediation.events)
+	}
+	ev := remediation.events[1]
+	if ev.Type != models.RemediationEventKPIState || ev.Service != "db" || ev.KPIName != "disk_used" ||
+		ev.Severity != models.KPIStateCritical || ev.Value == nil || *ev.Value != 97 {
+		t.Fatalf("unexpected event %+v", ev)
+	}
+}
+
+func TestKPIHistoryService_ConcurrentAppendsKeepEveryPoint(t *testing.T) {
+	ctx := context.Background()
+	log := logger.New("error")
+	store := memoryEvaluationStore{}
+	svc := NewKPIHistoryService(nil, newFakeKPIRepo(), store, config.KPIHistoryConfig{}, log)
+	svc.SetLocks(cache.NewNoopValkeyCache(log))
+
+	at := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			point := weavstore.KPIEvaluationPoint{Time: at.Add(time.Duration(i) * time.Second), State: models.KPIStateOK}
+			if _, err := svc.appendPoint(ctx, "lat", "2026-03-01", point); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if d := store["lat|2026-03-01"]; d == nil || len(d.Points) != 20 {
+		t.Fatalf("expected 20 points, got %+v", d)
+	}
+}
@@ -0,0 +1,414 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/repo"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const (
+	thresholdAdjustmentsKey     = "kpi:threshold_adjustments"
+	thresholdAdjustmentsLockKey = "kpi:threshold_adjustments:lock"
+
+	// thresholdAdjustmentLockTTL bounds how long a crashed replica can hold
+	// the adjustments lock while it rewrites KPI thresholds;
+	// thresholdAdjustmentLockWait is how long a request waits for it.
+	thresholdAdjustmentLockTTL  = 30 * time.Second
+	thresholdAdjustmentLockWait = 5 * time.Second
+
+	// maxThresholdAdjustments caps the finished adjustments kept in Valkey;
+	// active ones are always kept so they can still be reverted.
+	maxThresholdAdjustments = 500
+
+	// thresholdAutoRevertUser is recorded as RevertedBy for scheduled reverts.
+	thresholdAutoRevertUser = "system:auto-revert"
+)
+
+var (
+	ErrInvalidThresholdAdjustment  = errors.New("invalid threshold adjustment")
+	ErrThresholdAdjustmentNotFound = errors.New("threshold adjustment not found")
+	ErrThresholdAdjustmentReverted = errors.New("threshold adjustment already reverted")
+	ErrNoKPIsMatched               = errors.New("no KPIs with thresholds match the selector")
+	ErrThresholdAdjustmentBusy     = errors.New("threshold adjustments are locked by another replica")
+)
+
+// ThresholdAdjustmentService raises or lowers the thresholds of every KPI
+// matching a tag/service selector in one operation, e.g. to loosen alerting
+// during a planned peak. Each adjustment keeps the original thresholds of
+// every KPI it touched so it can be audited and reverted, by hand or
+// automatically at its RevertAt time.
+type ThresholdAdjustmentService struct {
+	repo   repo.KPIRepo
+	cache  cache.ValkeyCluster
+	logger logging.Logger
+	mu     sync.Mutex
+}
+
+// NewThresholdAdjustmentService creates a new bulk threshold adjustment service.
+func NewThresholdAdjustmentService(kpiRepo repo.KPIRepo, cache cache.ValkeyCluster, logger corelogger.Logger) *ThresholdAdjustmentService {
+	return &ThresholdAdjustmentService{
+		repo:   kpiRepo,
+		cache:  cache,
+		logger: logging.FromCoreLogger(logger),
+	}
+}
+
+// Apply adjusts the thresholds of the KPIs matching req.Selector. KPIs
+// already under an active adjustment are listed with an error and left
+// alone, so reverts never stack. A dry run returns the record without
+// changing or storing anything.
+func (s *ThresholdAdjustmentService) Apply(ctx context.Context, req models.ThresholdAdjustmentRequest, user string) (*models.ThresholdAdjustment, error) {
+	if err := validateThresholdAdjustment(req); err != nil {
+		return nil, err
+	}
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	history, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	locked := map[string]string{}
+	for _, a := range history {
+		if a.Status != models.ThresholdAdjustmentActive {
+			continue
+		}
+		for _, k := range a.KPIs {
+			if k.Error == "" {
+				locked[k.KPIID] = a.ID
+			}
+		}
+	}
+
+	kpis, _, err := s.repo.ListKPIs(ctx, models.KPIListRequest{Limit: catalogScanLimit})
+	if err != nil {
+		return nil, fmt.Errorf("list KPIs: %w", err)
+	}
+	adj := &models.ThresholdAdjustment{
+		ID:        uuid.NewString(),
+		Selector:  req.Selector,
+		Percent:   req.Percent,
+		Delta:     req.Delta,
+		Reason:    req.Reason,
+		Status:    models.ThresholdAdjustmentActive,
+		CreatedBy: user,
+		CreatedAt: time.Now().UTC(),
+		RevertAt:  req.RevertAt,
+		KPIs:      []models.ThresholdAdjustmentKPI{},
+	}
+	if req.DryRun {
+		adj.Status = models.ThresholdAdjustmentDryRun
+	}
+	for _, k := range kpis {
+		if k == nil || !matchesThresholdSelector(k, req.Selector) {
+			continue
+		}
+		adjusted, changed := adjustThresholds(k.Thresholds, req)
+		if !changed {
+			continue
+		}
+		entry := models.ThresholdAdjustmentKPI{
+			KPIID:    k.ID,
+			Name:     k.Name,
+			Original: k.Thresholds,
+			Adjusted: adjusted,
+		}
+		if other, ok := locked[k.ID]; ok {
+			entry.Error = "already under active adjustment " + other
+		} else if !req.DryRun {
+			updated := *k
+			updated.Thresholds = adjusted
+			updated.UpdatedAt = time.Now().UTC()
+			if _, _, err := s.repo.ModifyKPI(ctx, &updated); err != nil {
+				entry.Error = err.Error()
+			}
+		}
+		adj.KPIs = append(adj.KPIs, entry)
+	}
+	if len(adj.KPIs) == 0 {
+		return nil, ErrNoKPIsMatched
+	}
+	if req.DryRun {
+		return adj, nil
+	}
+
+	history = append([]models.ThresholdAdjustment{*adj}, history...)
+	if err := s.store(ctx, history); err != nil {
+		return adj, err
+	}
+	s.logger.Info("Applied bulk threshold adjustment",
+		"id", adj.ID, "kpis", len(adj.KPIs), "created_by", user, "reason", req.Reason)
+	return adj, nil
+}
+
+// Revert restores the original thresholds of an active adjustment. A KPI
+// whose thresholds were edited since the adjustment is left as it is and
+// reported with an error rather than overwritten.
+func (s *ThresholdAdjustmentService) Revert(ctx context.Context, id, user string) (*models.ThresholdAdjustment, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	history, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range history {
+		if history[i].ID != id {
+			continue
+		}
+		if history[i].Status != models.ThresholdAdjustmentActive {
+			return nil, ErrThresholdAdjustmentReverted
+		}
+		s.revert(ctx, &history[i], user)
+		if err := s.store(ctx, history); err != nil {
+			return nil, err
+		}
+		return &history[i], nil
+	}
+	return nil, ErrThresholdAdjustmentNotFound
+}
+
+// List returns the stored adjustments, newest first.
+func (s *ThresholdAdjustmentService) List(ctx context.Context) ([]models.ThresholdAdjustment, error) {
+	return s.load(ctx)
+}
+
+// Get returns a single adjustment.
+func (s *ThresholdAdjustmentService) Get(ctx context.Context, id string) (*models.ThresholdAdjustment, error) {
+	history, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range history {
+		if history[i].ID == id {
+			return &history[i], nil
+		}
+	}
+	return nil, ErrThresholdAdjustmentNotFound
+}
+
+// RevertDue reverts the active adjustments whose RevertAt has passed.
+func (s *ThresholdAdjustmentService) RevertDue(ctx context.Context, now time.Time) (int, error) {
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	history, err := s.load(ctx)
+	if err != nil {
+		return 0, err
+	}
+	reverted := 0
+	for i := range history {
+		a := &history[i]
+		if a.Status == models.ThresholdAdjustmentActive && a.RevertAt != nil && !a.RevertAt.After(now) {
+			s.revert(ctx, a, thresholdAutoRevertUser)
+			reverted++
+		}
+	}
+	if reverted == 0 {
+		return 0, nil
+	}
+	return reverted, s.store(ctx, history)
+}
+
+// Start reverts due adjustments every interval until ctx ends.
+func (s *ThresholdAdjustmentService) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if _, err := s.RevertDue(ctx, now); errors.Is(err, ErrThresholdAdjustmentBusy) {
+				s.logger.Debug("Skipped scheduled threshold revert, another replica holds the lock")
+			} else if err != nil && ctx.Err() == nil {
+				s.logger.Warn("Scheduled threshold revert failed", "error", err)
+			}
+		}
+	}
+}
+
+// lock serialises load/modify/store of the adjustments within this process
+// and, through a Valkey lock, across replicas: Start runs on every
+// non-replica instance.
+func (s *ThresholdAdjustmentService) lock(ctx context.Context) (func(), error) {
+	s.mu.Lock()
+	release, err := lockCache(ctx, s.cache, thresholdAdjustmentsLockKey,
+		thresholdAdjustmentLockTTL, thresholdAdjustmentLockWait, ErrThresholdAdjustmentBusy, s.logger)
+	if err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+	return func() {
+		release()
+		s.mu.Unlock()
+	}, nil
+}
+
+func (s *ThresholdAdjustmentService) revert(ctx context.Context, a *models.ThresholdAdjustment, user string) {
+	for i := range a.KPIs {
+		entry := &a.KPIs[i]
+		if entry.Error != "" {
+			continue
+		}
+		k, err := s.repo.GetKPI(ctx, entry.KPIID)
+		switch {
+		case err != nil:
+			entry.Error = "revert: " + err.Error()
+		case k == nil:
+			entry.Error = "revert: KPI no longer exists"
+		case !equalThresholds(k.Thresholds, entry.Adjusted):
+			entry.Error = "revert: thresholds changed since the adjustment, left unchanged"
+		default:
+			updated := *k
+			updated.Thresholds = entry.Original
+			updated.UpdatedAt = time.Now().UTC()
+			if _, _, err := s.repo.ModifyKPI(ctx, &updated); err != nil {
+				entry.Error = "revert: " + err.Error()
+			}
+		}
+	}
+	now := time.Now().UTC()
+	a.Status = models.ThresholdAdjustmentReverted
+	a.RevertedBy = user
+	a.RevertedAt = &now
+	s.logger.Info("Reverted bulk threshold adjustment", "id", a.ID, "reverted_by", user)
+}
+
+func (s *ThresholdAdjustmentService) load(ctx context.Context) ([]models.ThresholdAdjustment, error) {
+	data, err := s.cache.Get(ctx, thresholdAdjustmentsKey)
+	if err != nil || len(data) == 0 {
+		return []models.ThresholdAdjustment{}, nil
+	}
+	var history []models.ThresholdAdjustment
+	if err := json.Unmarshal(data, &history); err != nil {
+		return nil, fmt.Errorf("decode threshold adjustments: %w", err)
+	}
+	return history, nil
+}
+
+func (s *ThresholdAdjustmentService) store(ctx context.Context, history []models.ThresholdAdjustment) error {
+	kept := make([]models.ThresholdAdjustment, 0, len(history))
+	finished := 0
+	for _, a := range history {
+		if a.Status != models.ThresholdAdjustmentActive {
+			if finished >= maxThresholdAdjustments {
+				continue
+			}
+			finished++
+		}
+		kept = append(kept, a)
+	}
+	if err := s.cache.Set(ctx, thresholdAdjustmentsKey, kept, 0); err != nil {
+		return fmt.Errorf("store threshold adjustments: %w", err)
+	}
+	return nil
+}
+
+func validateThresholdAdjustment(req models.ThresholdAdjustmentRequest) error {
+	if len(req.Selector.Tags) == 0 && strings.TrimSpace(req.Selector.ServiceFamily) == "" {
+		return fmt.Errorf("%w: selector needs tags or serviceFamily", ErrInvalidThresholdAdjustment)
+	}
+	if (req.Percent == nil) == (req.Delta == nil) {
+		return fmt.Errorf("%w: set exactly one of percent or delta", ErrInvalidThresholdAdjustment)
+	}
+	if req.Percent != nil && (*req.Percent <= -100 || *req.Percent == 0) {
+		return fmt.Errorf("%w: percent must be non-zero and greater than -100", ErrInvalidThresholdAdjustment)
+	}
+	if req.Delta != nil && *req.Delta == 0 {
+		return fmt.Errorf("%w: delta must be non-zero", ErrInvalidThresholdAdjustment)
+	}
+	if req.RevertAt != nil && !req.RevertAt.After(time.Now()) {
+		return fmt.Errorf("%w: revertAt must be in the future", ErrInvalidThresholdAdjustment)
+	}
+	return nil
+}
+
+// matchesThresholdSelector reports whether k carries every selector tag and
+// belongs to the selector's service family; both compare case-insensitively.
+func matchesThresholdSelector(k *models.KPIDefinition, sel models.ThresholdAdjustmentSelector) bool {
+	if sel.ServiceFamily != "" && !strings.EqualFold(k.ServiceFamily, strings.TrimSpace(sel.ServiceFamily)) {
+		return false
+	}
+	for _, want := range sel.Tags {
+		found := false
+		for _, tag := range k.Tags {
+			if strings.EqualFold(tag, strings.TrimSpace(want)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// adjustThresholds returns a copy of ts with the selected levels adjusted,
+// and whether any threshold was selected.
+func adjustThresholds(ts []models.Threshold, req models.ThresholdAdjustmentRequest) ([]models.Threshold, bool) {
+	out := make([]models.Threshold, len(ts))
+	changed := false
+	for i, t := range ts {
+		out[i] = t
+		if !thresholdLevelSelected(t.Level, req.Selector.Levels) {
+			continue
+		}
+		v := t.Value
+		if req.Percent != nil {
+			v *= 1 + *req.Percent/100
+		} else {
+			v += *req.Delta
+		}
+		out[i].Value = math.Round(v*1e6) / 1e6
+		changed = true
+	}
+	return out, changed
+}
+
+func thresholdLevelSelected(level string, levels []string) bool {
+	if len(levels) == 0 {
+		return true
+	}
+	for _, l := range levels {
+		if strings.EqualFold(l, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func equalThresholds(a, b []models.Threshold) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func TestThresholdAdjustmentService_ApplyAndRevert(t *testing.T) {
+	ctx := context.Background()
+	log := logger.New("error")
+	r := dedupRepo{newFakeKPIRepo()}
+	r.kpis["latency"] = &models.KPIDefinition{
+		ID: "latency", Name: "p99 latency", Tags: []string{"Payments", "latency"}, ServiceFamily: "oltp",
+		Thresholds: []models.Threshold{{Level: "warning", Operator: "gt", Value: 200}, {Level: "critical", Operator: "gt", Value: 500}},
+	}
+	r.kpis["errors"] = &models.KPIDefinition{
+		ID: "errors", Name: "error rate", Tags: []string{"payments"}, ServiceFamily: "oltp",
+		Thresholds: []models.Threshold{{Level: "warning", Operator: "gt", Value: 1}},
+	}
+	r.kpis["other"] = &models.KPIDefinition{
+		ID: "other", Name: "other", Tags: []string{"latency"}, ServiceFamily: "apigw",
+		Thresholds: []models.Threshold{{Level: "warning", Operator: "gt", Value: 100}},
+	}
+	svc := NewThresholdAdjustmentService(r, cache.NewNoopValkeyCache(log), log)
+
+	pct := 20.0
+	req := models.ThresholdAdjustmentRequest{
+		Selector: models.ThresholdAdjustmentSelector{Tags: []string{"payments"}, Levels: []string{"warning"}},
+		Percent:  &pct,
+		Reason:   "planned peak",
+	}
+	if _, err := svc.Apply(ctx, models.ThresholdAdjustmentRequest{Selector: req.Selector}, "alice"); !errors.Is(err, ErrInvalidThresholdAdjustment) {
+		t.Fatalf("expected ErrInvalidThresholdAdjustment without percent or delta, got %v", err)
+	}
+
+	dry := req
+	dry.DryRun = true
+	preview, err := svc.Apply(ctx, dry, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(preview.KPIs) != 2 || r.kpis["latency"].Thresholds[0].Value != 200 {
+		t.Fatalf("dry run should preview two KPIs without changing them, got %+v", preview.KPIs)
+	}
+
+	adj, err := svc.Apply(ctx, req, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := r.kpis["latency"].Thresholds; got[0].Value != 240 || got[1].Value != 500 {
+		t.Fatalf("expected only the warning level raised by 20%%, got %+v", got)
+	}
+	if r.kpis["errors"].Thresholds[0].Value != 1.2 || r.kpis["other"].Thresholds[0].Value != 100 {
+		t.Fatalf("unexpected thresholds after adjustment: errors=%+v other=%+v", r.kpis["errors"].Thresholds, r.kpis["other"].Thresholds)
+	}
+
+	// A second adjustment must not stack on KPIs still under the first.
+	delta := 5.0
+	second, err := svc.Apply(ctx, models.ThresholdAdjustmentRequest{
+		Selector: models.ThresholdAdjustmentSelector{ServiceFamily: "OLTP"},
+		Delta:    &delta,
+	}, "bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, k := range second.KPIs {
+		if k.Error == "" {
+			t.Fatalf("expected %s to be skipped while under adjustment %s", k.KPIID, adj.ID)
+		}
+	}
+
+	// Hand edits after the adjustment are kept on revert.
+	r.kpis["errors"].Thresholds = []models.Threshold{{Level: "warning", Operator: "gt", Value: 3}}
+	reverted, err := svc.Revert(ctx, adj.ID, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reverted.Status != models.ThresholdAdjustmentReverted || reverted.RevertedBy != "alice" {
+		t.Fatalf("unexpected revert record %+v", reverted)
+	}
+	if got := r.kpis["latency"].Thresholds[0].Value; got != 200 {
+		t.Fatalf("expected latency warning restored to 200, got %v", got)
+	}
+	if got := r.kpis["errors"].Thresholds[0].Value; got != 3 {
+		t.Fatalf("expected the hand-edited threshold to be kept, got %v", got)
+	}
+	if _, err := svc.Revert(ctx, adj.ID, "alice"); !errors.Is(err, ErrThresholdAdjustmentReverted) {
+		t.Fatalf("expected ErrThresholdAdjustmentReverted, got %v", err)
+	}
+
+	stored, err := svc.Get(ctx, adj.ID)
+	if err != nil || stored.KPIs[0].Original == nil {
+		t.Fatalf("expected the stored record to keep the original values, got %+v, %v", stored, err)
+	}
+}
+
+func TestThresholdAdjustmentService_RevertDue(t *testing.T) {
+	ctx := context.Background()
+	log := logger.New("error")
+	r := dedupRepo{newFakeKPIRepo()}
+	r.kpis["cpu"] = &models.KPIDefinition{
+		ID: "cpu", Tags: []string{"infra"},
+		Thresholds: []models.Threshold{{Level: "critical", Operator: "gt", Value: 90}},
+	}
+	svc := NewThresholdAdjustmentService(r, cache.NewNoopValkeyCache(log), log)
+
+	delta := -10.0
+	revertAt := time.Now().Add(time.Hour)
+	adj, err := svc.Apply(ctx, models.ThresholdAdjustmentRequest{
+		Selector: models.ThresholdAdjustmentSelector{Tags: []string{"infra"}},
+		Delta:    &delta,
+		RevertAt: &revertAt,
+	}, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.kpis["cpu"].Thresholds[0].Value != 80 {
+		t.Fatalf("expected 80 after adjustment, got %v", r.kpis["cpu"].Thresholds[0].Value)
+	}
+
+	if n, err := svc.RevertDue(ctx, time.Now()); err != nil || n != 0 {
+		t.Fatalf("expected nothing due yet, got %d, %v", n, err)
+	}
+	if n, err := svc.RevertDue(ctx, revertAt.Add(time.Second)); err != nil || n != 1 {
+		t.Fatalf("expected one scheduled revert, got %d, %v", n, err)
+	}
+	if r.kpis["cpu"].Thresholds[0].Value != 90 {
+		t.Fatalf("expected 90 after scheduled revert, got %v", r.kpis["cpu"].Thresholds[0].Value)
+	}
+	stored, _ := svc.Get(ctx, adj.ID)
+	if stored.RevertedBy != thresholdAutoRevertUser {
+		t.Fatalf("expected RevertedBy %q, got %q", thresholdAutoRevertUser, stored.RevertedBy)
+	}
+}
+
+func TestThresholdAdjustmentService_LockAcrossReplicas(t *testing.T) {
+	ctx := context.Background()
+	log := logger.New("error")
+	r := dedupRepo{newFakeKPIRepo()}
+	r.kpis["cpu"] = &models.KPIDefinition{
+		ID: "cpu", Tags: []string{"infra"},
+		Thresholds: []models.Threshold{{Level: "critical", Operator: "gt", Value: 90}},
+	}
+	shared := newLockingCache()
+	a := NewThresholdAdjustmentService(r, shared, log)
+	b := NewThresholdAdjustmentService(r, shared, log)
+
+	delta := -10.0
+	adj, err := a.Apply(ctx, models.ThresholdAdjustmentRequest{
+		Selector: models.ThresholdAdjustmentSelector{Tags: []string{"infra"}},
+		Delta:    &delta,
+	}, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Another replica is rewriting the adjustments: the revert waits for it.
+	if ok, _ := shared.AcquireLock(ctx, thresholdAdjustmentsLockKey, time.Minute); !ok {
+		t.Fatal("expected the adjustments lock to be free after Apply")
+	}
+	released := make(chan struct{})
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		_ = shared.ReleaseLock(ctx, thresholdAdjustmentsLockKey)
+		close(released)
+	}()
+	if _, err := b.Revert(ctx, adj.ID, "bob"); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-released:
+	default:
+		t.Fatal("revert must not run while another replica holds the lock")
+	}
+	if r.kpis["cpu"].Thresholds[0].Value != 90 {
+		t.Fatalf("expected 90 after revert, got %v", r.kpis["cpu"].Thresholds[0].Value)
+	}
+	if _, err := a.Revert(ctx, adj.ID, "alice"); !errors.Is(err, ErrThresholdAdjustmentReverted) {
+		t.Fatalf("expected the other replica to see the revert, got %v", err)
+	}
+}
@@ -93,6 +93,8 @@ func ValidateKPIDefinition(cfg *config.Config, k *models.KPIDefinition) error {
 
 	// Build allowed datastore names from config (victoriametrics and named metrics sources)
 	allowedDatastores := map[string]bool{}
+	// warehouses from kpi_datastores, queried with SQL
+	sqlDatastores := map[string]bool{}
 	// include literal "victoriametrics" if default block present
 	if cfg != nil {
 		if cfg.Database.VictoriaMetrics.Name != "" || len(cfg.Database.VictoriaMetrics.Endpoints) > 0 {
@@ -106,13 +108,26 @@ func ValidateKPIDefinition(cfg *config.Config, k *models.KPIDefinition) error {
 				allowedDatastores[strings.ToLower(s.Name)] = true
 			}
 		}
+		for _, d := range cfg.KPIDatastores {
+			allowedDatastores[strings.ToLower(d.Name)] = true
+			sqlDatastores[strings.ToLower(d.Name)] = true
+		}
 	}
 
 	if ds != "" {
 		if !allowedDatastores[ds] {
 			ve.add("datastore", "datastore is not configured in server config")
 		} else {
-			if ds == "victoriametrics" {
+			if sqlDatastores[ds] {
+				if qt != "" && qt != "sql" {
+					ve.add("queryType", fmt.Sprintf("for datastore '%s' queryType must be 'SQL'", ds))
+				} else {
+					k.QueryType = "SQL"
+				}
+				if strings.TrimSpace(k.Formula) == "" {
+					ve.add("formula", fmt.Sprintf("datastore '%s' needs the KPI's SQL in 'formula'", ds))
+				}
+			} else if ds == "victoriametrics" {
 				if qt == "" {
 					ve.add("queryType", "queryType is required for datastore 'victoriametrics' and must be 'MetricsQL' or 'PromQL'")
 				} else {
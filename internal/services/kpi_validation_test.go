@@ -185,6 +185,24 @@ func TestValidateKPIDefinition_DatastoreQueryCombinations(t *testing.T) {
 	}
 }
 
+func TestValidateKPIDefinition_WarehouseDatastore(t *testing.T) {
+	cfg := makeTestConfig()
+	cfg.KPIDatastores = []config.KPIDatastoreConfig{{Name: "finance_dw", Type: "postgresql", URL: "postgres://dw/finance"}}
+
+	k := &models.KPIDefinition{Name: "revenue", Layer: "cause", SignalType: "business", Sentiment: "positive", Classifier: "revenue", Datastore: "Finance_DW", Formula: "SELECT sum(amount) AS value FROM orders", Dashboard: "123e4567-e89b-52d3-a456-426614174000"}
+	if err := ValidateKPIDefinition(cfg, k); err != nil {
+		t.Fatalf("expected warehouse KPI to validate, got: %v", err)
+	}
+	if k.QueryType != "SQL" {
+		t.Fatalf("expected queryType normalized to SQL, got %q", k.QueryType)
+	}
+
+	k = &models.KPIDefinition{Name: "revenue", Layer: "cause", SignalType: "business", Sentiment: "positive", Classifier: "revenue", Datastore: "finance_dw", QueryType: "MetricsQL", Formula: "sum(x)", Dashboard: "123e4567-e89b-52d3-a456-426614174000"}
+	if err := ValidateKPIDefinition(cfg, k); err == nil || !strings.Contains(err.Error(), "SQL") {
+		t.Fatalf("expected queryType error for MetricsQL on a warehouse, got: %v", err)
+	}
+}
+
 func TestValidateKPIDefinition_MultipleErrorAggregation(t *testing.T) {
 	cfg := makeTestConfig()
 
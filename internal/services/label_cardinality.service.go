@@ -0,0 +1,324 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/utils/hll"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const (
+	cardinalityScanKey = "cardinality:scan"
+
+	// cardinalityValuesLimit caps the values of one label read for one day;
+	// beyond it the distinct estimate is a lower bound.
+	cardinalityValuesLimit = 100000
+	// cardinalityScanTopN is how many labels a scan ranks per day.
+	cardinalityScanTopN = 1000
+	// cardinalityScanTTL is how long a stored scan is trusted when no
+	// schedule refreshes it sooner.
+	cardinalityScanTTL = 24 * time.Hour
+)
+
+var ErrInvalidCardinalityRequest = errors.New("invalid cardinality request")
+
+// CardinalityBackend reports per-day cardinality statistics and label
+// values (implemented by VictoriaMetricsService).
+type CardinalityBackend interface {
+	GetTSDBStatus(ctx context.Context, request *models.TSDBStatusRequest) (*models.TSDBStatus, error)
+	GetLabelValues(ctx context.Context, request *models.LabelValuesRequest) ([]string, error)
+}
+
+// HighCardinalityLabels reports the labels the latest cardinality scan
+// flagged, with their value counts.
+type HighCardinalityLabels interface {
+	FlaggedLabels(ctx context.Context) map[string]int64
+}
+
+// LabelCardinalityService estimates how many distinct values labels have.
+// The backend's TSDB status gives exact counts and top values per day;
+// a HyperLogLog sketch of each day's values combines them into a distinct
+// count for the whole range. Scans of all labels flag those over the
+// threshold; the latest unfiltered scan is stored for formula lint and
+// query guardrails.
+type LabelCardinalityService struct {
+	backend CardinalityBackend
+	cache   cache.ValkeyCluster
+	cfg     config.CardinalityConfig
+	logger  logging.Logger
+}
+
+// NewLabelCardinalityService creates a new label cardinality service.
+func NewLabelCardinalityService(backend CardinalityBackend, cache cache.ValkeyCluster, cfg config.CardinalityConfig, logger corelogger.Logger) *LabelCardinalityService {
+	if cfg.TopK <= 0 {
+		cfg.TopK = 10
+	}
+	if cfg.MaxRangeDays <= 0 {
+		cfg.MaxRangeDays = 7
+	}
+	if cfg.ScanLookback <= 0 {
+		cfg.ScanLookback = 24 * time.Hour
+	}
+	return &LabelCardinalityService{
+		backend: backend,
+		cache:   cache,
+		cfg:     cfg,
+		logger:  logging.FromCoreLogger(logger),
+	}
+}
+
+// Start rescans all labels every ScanInterval until ctx is cancelled.
+func (s *LabelCardinalityService) Start(ctx context.Context) {
+	if s.cfg.ScanInterval <= 0 || s.backend == nil {
+		return
+	}
+	ticker := time.NewTicker(s.cfg.ScanInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.Scan(ctx, models.CardinalityScanRequest{}); err != nil && ctx.Err() == nil {
+			s.logger.Warn("Scheduled cardinality scan failed", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// days returns the UTC dates covered by [start, end], defaulting the range
+// to the scan lookback ending now.
+func (s *LabelCardinalityService) days(start, end time.Time) (time.Time, time.Time, []time.Time, error) {
+	if end.IsZero() {
+		end = time.Now()
+	}
+	if start.IsZero() {
+		start = end.Add(-s.cfg.ScanLookback)
+	}
+	start, end = start.UTC(), end.UTC()
+	if !start.Before(end) {
+		return start, end, nil, fmt.Errorf("%w: start must be before end", ErrInvalidCardinalityRequest)
+	}
+	var days []time.Time
+	for d := start.Truncate(24 * time.Hour); d.Before(end); d = d.Add(24 * time.Hour) {
+		days = append(days, d)
+	}
+	if len(days) > s.cfg.MaxRangeDays {
+		return start, end, nil, fmt.Errorf("%w: range covers %d days, at most %d are allowed", ErrInvalidCardinalityRequest, len(days), s.cfg.MaxRangeDays)
+	}
+	return start, end, days, nil
+}
+
+// Analyze estimates the distinct values and top values of one label.
+func (s *LabelCardinalityService) Analyze(ctx context.Context, req models.LabelCardinalityRequest) (*models.LabelCardinality, error) {
+	req.Label = strings.TrimSpace(req.Label)
+	if req.Label == "" {
+		return nil, fmt.Errorf("%w: label is required", ErrInvalidCardinalityRequest)
+	}
+	if req.TopK <= 0 {
+		req.TopK = s.cfg.TopK
+	}
+	if req.TopK > cardinalityScanTopN {
+		return nil, fmt.Errorf("%w: topK must not exceed %d", ErrInvalidCardinalityRequest, cardinalityScanTopN)
+	}
+	if s.backend == nil {
+		return nil, fmt.Errorf("%w: metrics backend not configured", ErrFormulaCatalogUnavailable)
+	}
+	start, end, days, err := s.days(req.Start, req.End)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &models.LabelCardinality{Label: req.Label, Start: start, End: end, Days: len(days), Threshold: s.cfg.MaxLabelValues}
+	sketch := hll.New()
+	peak := map[string]int64{}
+	for _, day := range days {
+		// Ask for more top values than reported so values ranked just
+		// below the cut on some days are still counted.
+		status, err := s.backend.GetTSDBStatus(ctx, &models.TSDBStatusRequest{
+			Date:       day.Format(time.DateOnly),
+			TopN:       max(2*req.TopK, 20),
+			Match:      req.Match,
+			FocusLabel: req.Label,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrFormulaCatalogUnavailable, err)
+		}
+		for _, e := range status.LabelValueCountByLabelName {
+			if e.Name == req.Label {
+				out.DailyMax = max(out.DailyMax, e.Value)
+			}
+		}
+		for _, e := range status.SeriesCountByFocusLabelValue {
+			peak[e.Name] = max(peak[e.Name], e.Value)
+		}
+
+		values, err := s.backend.GetLabelValues(ctx, &models.LabelValuesRequest{
+			Label: req.Label,
+			Start: maxTime(day, start).Format(time.RFC3339),
+			End:   minTime(day.Add(24*time.Hour), end).Format(time.RFC3339),
+			Match: req.Match,
+			Limit: cardinalityValuesLimit,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrFormulaCatalogUnavailable, err)
+		}
+		if len(values) >= cardinalityValuesLimit {
+			out.LowerBound = true
+		}
+		for _, v := range values {
+			sketch.Add(v)
+		}
+	}
+	// The sketch can undershoot the exact daily count by its error, and
+	// misses values past the read limit.
+	out.DistinctEstimate = max(int64(sketch.Estimate()), out.DailyMax)
+
+	out.TopValues = make([]models.LabelValueCount, 0, len(peak))
+	for v, n := range peak {
+		out.TopValues = append(out.TopValues, models.LabelValueCount{Value: v, Series: n})
+	}
+	sort.Slice(out.TopValues, func(i, j int) bool {
+		a, b := out.TopValues[i], out.TopValues[j]
+		if a.Series != b.Series {
+			return a.Series > b.Series
+		}
+		return a.Value < b.Value
+	})
+	if len(out.TopValues) > req.TopK {
+		out.TopValues = out.TopValues[:req.TopK]
+	}
+	out.HighCardinality = s.cfg.MaxLabelValues > 0 && out.DistinctEstimate > int64(s.cfg.MaxLabelValues)
+	return out, nil
+}
+
+// Scan ranks the labels with the most values per day over the range and
+// flags those over MaxLabelValues. Unfiltered scans are stored as the
+// latest scan.
+func (s *LabelCardinalityService) Scan(ctx context.Context, req models.CardinalityScanRequest) (*models.CardinalityScan, error) {
+	if s.backend == nil {
+		return nil, fmt.Errorf("%w: metrics backend not configured", ErrFormulaCatalogUnavailable)
+	}
+	start, end, days, err := s.days(req.Start, req.End)
+	if err != nil {
+		return nil, err
+	}
+	scan := &models.CardinalityScan{
+		Start:     start,
+		End:       end,
+		Match:     req.Match,
+		Threshold: s.cfg.MaxLabelValues,
+		Labels:    []models.LabelCardinalitySummary{},
+		Flagged:   []string{},
+		ScannedAt: time.Now().UTC(),
+	}
+	labels := map[string]*models.LabelCardinalitySummary{}
+	label := func(name string) *models.LabelCardinalitySummary {
+		if labels[name] == nil {
+			labels[name] = &models.LabelCardinalitySummary{Label: name}
+		}
+		return labels[name]
+	}
+	for _, day := range days {
+		status, err := s.backend.GetTSDBStatus(ctx, &models.TSDBStatusRequest{
+			Date:  day.Format(time.DateOnly),
+			TopN:  cardinalityScanTopN,
+			Match: req.Match,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrFormulaCatalogUnavailable, err)
+		}
+		scan.TotalSeries = max(scan.TotalSeries, status.TotalSeries)
+		for _, e := range status.LabelValueCountByLabelName {
+			l := label(e.Name)
+			l.Values = max(l.Values, e.Value)
+		}
+		for _, e := range status.SeriesCountByLabelName {
+			l := label(e.Name)
+			l.Series = max(l.Series, e.Value)
+		}
+	}
+	delete(labels, "__name__")
+	for _, l := range labels {
+		l.HighCardinality = s.cfg.MaxLabelValues > 0 && l.Values > int64(s.cfg.MaxLabelValues)
+		scan.Labels = append(scan.Labels, *l)
+	}
+	sort.Slice(scan.Labels, func(i, j int) bool {
+		a, b := scan.Labels[i], scan.Labels[j]
+		if a.Values != b.Values {
+			return a.Values > b.Values
+		}
+		return a.Label < b.Label
+	})
+	for _, l := range scan.Labels {
+		if l.HighCardinality {
+			scan.Flagged = append(scan.Flagged, l.Label)
+		}
+	}
+
+	if len(req.Match) == 0 {
+		if err := s.cache.Set(ctx, cardinalityScanKey, scan, max(cardinalityScanTTL, 2*s.cfg.ScanInterval)); err != nil {
+			s.logger.Warn("Failed to store cardinality scan", "error", err)
+		}
+		if len(scan.Flagged) > 0 {
+			s.logger.Info("High-cardinality labels found", "labels", scan.Flagged, "threshold", s.cfg.MaxLabelValues)
+		}
+	}
+	return scan, nil
+}
+
+// LatestScan returns the latest stored unfiltered scan, or nil.
+func (s *LabelCardinalityService) LatestScan(ctx context.Context) (*models.CardinalityScan, error) {
+	data, err := s.cache.Get(ctx, cardinalityScanKey)
+	if err != nil || len(data) == 0 {
+		return nil, nil
+	}
+	var scan models.CardinalityScan
+	if err := json.Unmarshal(data, &scan); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cardinality scan: %w", err)
+	}
+	return &scan, nil
+}
+
+// FlaggedLabels returns the labels flagged by the latest stored scan and
+// their value counts; empty when there is none.
+func (s *LabelCardinalityService) FlaggedLabels(ctx context.Context) map[string]int64 {
+	scan, err := s.LatestScan(ctx)
+	if err != nil {
+		s.logger.Warn("Failed to read cardinality scan", "error", err)
+	}
+	out := map[string]int64{}
+	if scan == nil {
+		return out
+	}
+	for _, l := range scan.Labels {
+		if l.HighCardinality {
+			out[l.Label] = l.Values
+		}
+	}
+	return out
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
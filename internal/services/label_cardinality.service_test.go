@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// fakeCardinalityBackend serves per-day statistics: pods roll over daily,
+// so each day has its own 3000 pod values, while job has the same 4.
+type fakeCardinalityBackend struct {
+	statusDates []string
+}
+
+func (f *fakeCardinalityBackend) GetTSDBStatus(ctx context.Context, req *models.TSDBStatusRequest) (*models.TSDBStatus, error) {
+	f.statusDates = append(f.statusDates, req.Date)
+	st := &models.TSDBStatus{
+		TotalSeries: 9000,
+		LabelValueCountByLabelName: []models.TSDBStatusEntry{
+			{Name: "pod", Value: 3000}, {Name: "__name__", Value: 40}, {Name: "job", Value: 4},
+		},
+		SeriesCountByLabelName: []models.TSDBStatusEntry{
+			{Name: "__name__", Value: 9000}, {Name: "pod", Value: 9000}, {Name: "job", Value: 9000},
+		},
+	}
+	if req.FocusLabel == "job" {
+		// checkout leads on the first day, payments on the second.
+		if req.Date == "2026-10-15" {
+			st.SeriesCountByFocusLabelValue = []models.TSDBStatusEntry{{Name: "checkout", Value: 500}, {Name: "payments", Value: 100}}
+		} else {
+			st.SeriesCountByFocusLabelValue = []models.TSDBStatusEntry{{Name: "payments", Value: 800}, {Name: "search", Value: 50}}
+		}
+	}
+	return st, nil
+}
+
+func (f *fakeCardinalityBackend) GetLabelValues(ctx context.Context, req *models.LabelValuesRequest) ([]string, error) {
+	switch req.Label {
+	case "pod":
+		out := make([]string, 3000)
+		for i := range out {
+			out[i] = fmt.Sprintf("pod-%s-%d", req.Start[:10], i)
+		}
+		return out, nil
+	case "job":
+		return []string{"checkout", "payments", "search", "cart"}, nil
+	}
+	return []string{}, nil
+}
+
+func TestLabelCardinalityService_Analyze(t *testing.T) {
+	log := logger.New("error")
+	backend := &fakeCardinalityBackend{}
+	svc := NewLabelCardinalityService(backend, cache.NewNoopValkeyCache(log), config.CardinalityConfig{MaxLabelValues: 5000, MaxRangeDays: 3}, log)
+	ctx := context.Background()
+	start := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	pod, err := svc.Analyze(ctx, models.LabelCardinalityRequest{Label: "pod", Start: start, End: end})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2026-10-15", "2026-10-16"}, backend.statusDates)
+	assert.Equal(t, 2, pod.Days)
+	assert.Equal(t, int64(3000), pod.DailyMax)
+	// Distinct values over both days, not the per-day count.
+	assert.InDelta(t, 6000, pod.DistinctEstimate, 180)
+	assert.True(t, pod.HighCardinality)
+	assert.False(t, pod.LowerBound)
+
+	job, err := svc.Analyze(ctx, models.LabelCardinalityRequest{Label: "job", Start: start, End: end, TopK: 2})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), job.DistinctEstimate)
+	assert.False(t, job.HighCardinality)
+	assert.Equal(t, []models.LabelValueCount{{Value: "payments", Series: 800}, {Value: "checkout", Series: 500}}, job.TopValues)
+
+	_, err = svc.Analyze(ctx, models.LabelCardinalityRequest{Label: "pod", Start: start.Add(-72 * time.Hour), End: end})
+	assert.ErrorIs(t, err, ErrInvalidCardinalityRequest)
+	_, err = svc.Analyze(ctx, models.LabelCardinalityRequest{Start: start, End: end})
+	assert.ErrorIs(t, err, ErrInvalidCardinalityRequest)
+}
+
+func TestLabelCardinalityService_ScanFeedsLintAndSuggestions(t *testing.T) {
+	log := logger.New("error")
+	ctx := context.Background()
+	svc := NewLabelCardinalityService(&fakeCardinalityBackend{}, cache.NewNoopValkeyCache(log), config.CardinalityConfig{MaxLabelValues: 1000}, log)
+
+	assert.Empty(t, svc.FlaggedLabels(ctx), "nothing flagged before the first scan")
+	scan, err := svc.Scan(ctx, models.CardinalityScanRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(9000), scan.TotalSeries)
+	assert.Equal(t, []string{"pod"}, scan.Flagged)
+	require.Len(t, scan.Labels, 2, "__name__ is not a label")
+	assert.Equal(t, models.LabelCardinalitySummary{Label: "pod", Values: 3000, Series: 9000, HighCardinality: true}, scan.Labels[0])
+	assert.Equal(t, map[string]int64{"pod": 3000}, svc.FlaggedLabels(ctx))
+
+	// Filtered scans are not stored.
+	_, err = svc.Scan(ctx, models.CardinalityScanRequest{Match: []string{`{job="checkout"}`}})
+	require.NoError(t, err)
+	latest, err := svc.LatestScan(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, latest.Match)
+
+	// Lint flags the scanned label without asking the catalog.
+	catalog := &fakeLintCatalog{labels: map[string][]string{"up": {"job", "pod"}}}
+	lint := NewKPIFormulaLintService(catalog, nil, config.KPIFormulaLintConfig{}, log)
+	lint.SetCardinality(svc)
+	ws, err := lint.Lint(ctx, &models.KPIDefinition{QueryType: "MetricsQL", Formula: `count by (pod, job) (up)`})
+	require.NoError(t, err)
+	assert.Equal(t, "high_cardinality:pod", lintCodes(ws))
+	assert.Contains(t, ws[0].Message, "about 3000 values")
+
+	// Over the series limit, the flagged label is aggregated away.
+	series := []map[string]string{}
+	for i := 0; i < 30; i++ {
+		series = append(series, map[string]string{"__name__": "up", "job": fmt.Sprint(i % 3), "pod": fmt.Sprint(i)})
+	}
+	sug, ok := highCardinalitySuggestion("up", series, svc.FlaggedLabels(ctx), 10)
+	require.True(t, ok)
+	assert.Equal(t, "sum without (pod) (up)", sug.Query)
+	assert.Equal(t, 3, sug.EstimatedResults)
+	_, ok = highCardinalitySuggestion("up", series, svc.FlaggedLabels(ctx), 2)
+	assert.False(t, ok, "no suggestion when it would still be over the limit")
+}
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/qos"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// logLevelSyncInterval is how often every instance re-reads the runtime log
+// levels, so changes made on another instance or by a rollback apply
+// cluster-wide without a restart.
+const logLevelSyncInterval = 10 * time.Second
+
+var ErrInvalidLogLevel = errors.New("invalid log level")
+
+// LogLevelStatus reports the log level of each subsystem and where it comes
+// from.
+type LogLevelStatus struct {
+	Global     string            `json:"global"`
+	Subsystems map[string]string `json:"subsystems"`
+	// Configured holds the levels from the log_levels config section.
+	Configured map[string]string `json:"configured"`
+	// Runtime holds the levels set through the admin API; they win over
+	// Configured.
+	Runtime map[string]string `json:"runtime"`
+}
+
+// LogLevelService adjusts subsystem log levels at runtime. Runtime levels are
+// stored through DynamicConfigService, so they are versioned and can be
+// rolled back, and are applied over the configured levels.
+type LogLevelService struct {
+	store      *DynamicConfigService
+	levels     corelogger.LevelController
+	global     string
+	configured map[string]string
+	logger     logging.Logger
+}
+
+// NewLogLevelService creates a log level service for root. When root does not
+// support subsystem levels, levels are still stored but have no effect.
+func NewLogLevelService(store *DynamicConfigService, root corelogger.Logger, global string, configured map[string]string) *LogLevelService {
+	levels, _ := root.(corelogger.LevelController)
+	if configured == nil {
+		configured = map[string]string{}
+	}
+	return &LogLevelService{
+		store:      store,
+		levels:     levels,
+		global:     global,
+		configured: configured,
+		logger:     logging.FromCoreLogger(root),
+	}
+}
+
+// Status returns the effective, configured and runtime levels.
+func (s *LogLevelService) Status(ctx context.Context) (*LogLevelStatus, error) {
+	runtime, err := s.store.GetLogLevels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &LogLevelStatus{
+		Global:     s.global,
+		Subsystems: s.effective(runtime),
+		Configured: s.configured,
+		Runtime:    runtime,
+	}, nil
+}
+
+// SetLevels merges levels into the runtime levels and applies them
+// immediately on this instance. An empty level removes the runtime level of
+// that subsystem, restoring its configured or global level.
+func (s *LogLevelService) SetLevels(ctx context.Context, levels map[string]string, author string) (*models.ConfigVersion, error) {
+	for name, level := range levels {
+		if !isLogSubsystem(name) {
+			return nil, fmt.Errorf("%w: unknown subsystem %q, expected one of %v", ErrInvalidLogLevel, name, corelogger.Subsystems)
+		}
+		if _, ok := corelogger.ParseLevel(level); level != "" && !ok {
+			return nil, fmt.Errorf("%w: %q for %s, expected debug, info, warn or error", ErrInvalidLogLevel, level, name)
+		}
+	}
+	v, err := s.store.UpdateLogLevels(ctx, author, func(runtime map[string]string) error {
+		changed := false
+		for name, level := range levels {
+			if runtime[name] == level {
+				continue
+			}
+			if level == "" {
+				if _, ok := runtime[name]; !ok {
+					continue
+				}
+				delete(runtime, name)
+			} else {
+				runtime[name] = level
+			}
+			changed = true
+		}
+		if !changed {
+			return errConfigUnchanged
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Apply(ctx); err != nil {
+		s.logger.Warn("Failed to apply log levels", "error", err)
+	}
+	if v != nil {
+		s.logger.Info("Log levels updated", "author", author, "levels", levels, "version", v.Version)
+	}
+	return v, nil
+}
+
+// Apply sets every subsystem logger to its effective level.
+func (s *LogLevelService) Apply(ctx context.Context) error {
+	if s.levels == nil {
+		return nil
+	}
+	runtime, err := s.store.GetLogLevels(ctx)
+	if err != nil {
+		return err
+	}
+	for name, level := range s.effective(runtime) {
+		if level == s.global {
+			level = ""
+		}
+		if err := s.levels.SetLevel(name, level); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Start applies the stored levels and keeps them in sync until ctx is done.
+func (s *LogLevelService) Start(ctx context.Context) {
+	ctx = qos.WithClass(ctx, qos.Background)
+	if err := s.Apply(ctx); err != nil {
+		s.logger.Warn("Failed to apply log levels", "error", err)
+	}
+	ticker := time.NewTicker(logLevelSyncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Apply(ctx); err != nil {
+				s.logger.Warn("Failed to apply log levels", "error", err)
+			}
+		}
+	}
+}
+
+// effective resolves each subsystem's level: runtime, then configured, then
+// the global level. Stored levels that are no longer valid are ignored.
+func (s *LogLevelService) effective(runtime map[string]string) map[string]string {
+	out := make(map[string]string, len(corelogger.Subsystems))
+	for _, name := range corelogger.Subsystems {
+		out[name] = s.global
+		for _, level := range []string{s.configured[name], runtime[name]} {
+			if _, ok := corelogger.ParseLevel(level); ok {
+				out[name] = level
+			}
+		}
+	}
+	return out
+}
+
+func isLogSubsystem(name string) bool {
+	for _, s := range corelogger.Subsystems {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func TestLogLevelService_SetLevels(t *testing.T) {
+	root := logger.New("info")
+	ctx := context.Background()
+	store := NewDynamicConfigService(cache.NewNoopValkeyCache(root), root)
+	svc := NewLogLevelService(store, root, "info", map[string]string{"cache": "warn"})
+	levels := root.(logger.LevelController)
+
+	if err := svc.Apply(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := levels.Levels()["cache"]; got != "warn" {
+		t.Fatalf("expected the configured cache level, got %s", got)
+	}
+
+	if _, err := svc.SetLevels(ctx, map[string]string{"rbac": "debug"}, "ops"); !errors.Is(err, ErrInvalidLogLevel) {
+		t.Fatalf("expected ErrInvalidLogLevel for an unknown subsystem, got %v", err)
+	}
+	if _, err := svc.SetLevels(ctx, map[string]string{"api": "trace"}, "ops"); !errors.Is(err, ErrInvalidLogLevel) {
+		t.Fatalf("expected ErrInvalidLogLevel for an invalid level, got %v", err)
+	}
+
+	v, err := svc.SetLevels(ctx, map[string]string{"correlation": "debug", "cache": "error"}, "ops")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v == nil || v.Version != 1 || v.Author != "ops" {
+		t.Fatalf("expected a recorded version, got %+v", v)
+	}
+	if got := levels.Levels(); got["correlation"] != "debug" || got["cache"] != "error" || got["api"] != "info" {
+		t.Fatalf("runtime levels not applied: %v", got)
+	}
+	if v, err := svc.SetLevels(ctx, map[string]string{"correlation": "debug"}, "ops"); err != nil || v != nil {
+		t.Fatalf("expected an unchanged set to skip the write, got %+v, %v", v, err)
+	}
+
+	// Clearing a runtime level restores the configured one.
+	if _, err := svc.SetLevels(ctx, map[string]string{"cache": ""}, "ops"); err != nil {
+		t.Fatal(err)
+	}
+	status, err := svc.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Subsystems["cache"] != "warn" || status.Runtime["correlation"] != "debug" || len(status.Runtime) != 1 {
+		t.Fatalf("unexpected status %+v", status)
+	}
+}
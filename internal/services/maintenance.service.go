@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const (
+	maintenanceCacheKey = "cfg:maintenance_mode"
+
+	// DefaultMaintenanceMessage is used when maintenance is enabled without a banner.
+	DefaultMaintenanceMessage = "Mirador is undergoing maintenance; write operations are temporarily disabled."
+
+	// maintenanceRefreshInterval bounds how often the middleware hits the
+	// cache; toggles made on other replicas become visible within this window.
+	maintenanceRefreshInterval = 2 * time.Second
+)
+
+// MaintenanceService combines the platform-wide maintenance switch from
+// config with the deployment-level runtime toggle stored in Valkey.
+type MaintenanceService struct {
+	cache  cache.ValkeyCluster
+	global config.MaintenanceConfig
+	logger logging.Logger
+
+	mu        sync.RWMutex
+	current   *models.MaintenanceState
+	fetchedAt time.Time
+}
+
+// NewMaintenanceService creates a new maintenance mode service.
+func NewMaintenanceService(cache cache.ValkeyCluster, global config.MaintenanceConfig, logger corelogger.Logger) *MaintenanceService {
+	return &MaintenanceService{
+		cache:  cache,
+		global: global,
+		logger: logging.FromCoreLogger(logger),
+	}
+}
+
+// GetMaintenanceState returns the effective maintenance state. The
+// platform-wide switch takes precedence over the runtime toggle.
+func (s *MaintenanceService) GetMaintenanceState(ctx context.Context) (*models.MaintenanceState, error) {
+	if s.global.Enabled {
+		msg := s.global.Message
+		if msg == "" {
+			msg = DefaultMaintenanceMessage
+		}
+		return &models.MaintenanceState{Enabled: true, Message: msg, Source: "config"}, nil
+	}
+
+	s.mu.RLock()
+	if s.current != nil && time.Since(s.fetchedAt) < maintenanceRefreshInterval {
+		st := *s.current
+		s.mu.RUnlock()
+		return &st, nil
+	}
+	s.mu.RUnlock()
+
+	st := &models.MaintenanceState{}
+	if s.cache != nil {
+		cached, err := s.cache.Get(ctx, maintenanceCacheKey)
+		if err == nil && len(cached) > 0 {
+			if err := json.Unmarshal(cached, st); err != nil {
+				s.logger.Warn("Failed to unmarshal maintenance state, assuming disabled", "error", err)
+				st = &models.MaintenanceState{}
+			}
+		}
+	}
+
+	s.remember(st)
+	out := *st
+	return &out, nil
+}
+
+// SetMaintenanceState enables or disables the deployment-level maintenance
+// toggle. It cannot override the platform-wide switch from config.
+func (s *MaintenanceService) SetMaintenanceState(ctx context.Context, enabled bool, message, updatedBy string) (*models.MaintenanceState, error) {
+	st := &models.MaintenanceState{Enabled: enabled, UpdatedBy: updatedBy}
+	if enabled {
+		now := time.Now().UTC()
+		st.Since = &now
+		st.Source = "runtime"
+		st.Message = message
+		if st.Message == "" {
+			st.Message = DefaultMaintenanceMessage
+		}
+	}
+
+	data, err := json.Marshal(st)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal maintenance state: %w", err)
+	}
+	if err := s.cache.Set(ctx, maintenanceCacheKey, data, 0); err != nil {
+		return nil, fmt.Errorf("failed to store maintenance state in cache: %w", err)
+	}
+	s.remember(st)
+
+	s.logger.Info("Maintenance mode updated", "enabled", enabled, "updated_by", updatedBy)
+	return s.GetMaintenanceState(ctx)
+}
+
+func (s *MaintenanceService) remember(st *models.MaintenanceState) {
+	cp := *st
+	s.mu.Lock()
+	s.current = &cp
+	s.fetchedAt = time.Now()
+	s.mu.Unlock()
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func TestMaintenanceService_RuntimeToggle(t *testing.T) {
+	log := logger.New("error")
+	svc := NewMaintenanceService(cache.NewNoopValkeyCache(log), config.MaintenanceConfig{}, log)
+	ctx := context.Background()
+
+	st, err := svc.GetMaintenanceState(ctx)
+	if err != nil || st.Enabled {
+		t.Fatalf("expected maintenance off by default, got %+v err=%v", st, err)
+	}
+
+	st, err = svc.SetMaintenanceState(ctx, true, "", "ops")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !st.Enabled || st.Source != "runtime" || st.Message != DefaultMaintenanceMessage || st.Since == nil {
+		t.Fatalf("unexpected state after enabling: %+v", st)
+	}
+
+	st, _ = svc.SetMaintenanceState(ctx, false, "", "ops")
+	if st.Enabled {
+		t.Fatalf("expected maintenance off, got %+v", st)
+	}
+}
+
+func TestMaintenanceService_GlobalSwitchWins(t *testing.T) {
+	log := logger.New("error")
+	svc := NewMaintenanceService(cache.NewNoopValkeyCache(log),
+		config.MaintenanceConfig{Enabled: true, Message: "platform upgrade"}, log)
+
+	st, err := svc.SetMaintenanceState(context.Background(), false, "", "ops")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !st.Enabled || st.Source != "config" || st.Message != "platform upgrade" {
+		t.Fatalf("expected platform switch to win, got %+v", st)
+	}
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ErrMemoryBudgetExceeded is matched (errors.Is) by every MemoryBudgetError.
+var ErrMemoryBudgetExceeded = errors.New("memory budget exceeded")
+
+// MemoryBudgetError rejects work whose estimated memory exceeds its budget.
+type MemoryBudgetError struct {
+	Operation string
+	Estimated int64
+	Budget    int64
+	Hint      string
+}
+
+func (e *MemoryBudgetError) Error() string {
+	msg := fmt.Sprintf("%s needs an estimated %s, over its %s memory budget", e.Operation, formatBytes(e.Estimated), formatBytes(e.Budget))
+	if e.Hint != "" {
+		msg += "; " + e.Hint
+	}
+	return msg
+}
+
+func (e *MemoryBudgetError) Is(target error) bool { return target == ErrMemoryBudgetExceeded }
+
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<30:
+		return fmt.Sprintf("%.1fGiB", float64(n)/(1<<30))
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1fMiB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1fKiB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%dB", n)
+}
+
+// spillBuffer buffers up to memLimit bytes in memory (0 = no limit) and moves
+// everything to a temp file in dir beyond that. Writes past maxBytes
+// (0 = unlimited) fail with a MemoryBudgetError.
+type spillBuffer struct {
+	operation string
+	memLimit  int64
+	maxBytes  int64
+	dir       string
+
+	mem  bytes.Buffer
+	file *os.File
+	size int64
+}
+
+func newSpillBuffer(operation string, memLimit, maxBytes int64, dir string) *spillBuffer {
+	return &spillBuffer{operation: operation, memLimit: memLimit, maxBytes: maxBytes, dir: dir}
+}
+
+func (b *spillBuffer) Write(p []byte) (int, error) {
+	if b.maxBytes > 0 && b.size+int64(len(p)) > b.maxBytes {
+		return 0, &MemoryBudgetError{Operation: b.operation, Estimated: b.size + int64(len(p)), Budget: b.maxBytes, Hint: "narrow the query or time range"}
+	}
+	if b.file == nil && b.memLimit > 0 && b.size+int64(len(p)) > b.memLimit {
+		f, err := os.CreateTemp(b.dir, "mirador-spill-*")
+		if err != nil {
+			return 0, fmt.Errorf("create spill file: %w", err)
+		}
+		if _, err := f.Write(b.mem.Bytes()); err != nil {
+			f.Close()
+			os.Remove(f.Name())
+			return 0, fmt.Errorf("spill to disk: %w", err)
+		}
+		b.file = f
+		b.mem = bytes.Buffer{}
+	}
+	var n int
+	var err error
+	if b.file != nil {
+		n, err = b.file.Write(p)
+	} else {
+		n, err = b.mem.Write(p)
+	}
+	b.size += int64(n)
+	return n, err
+}
+
+// Spilled reports whether the content lives in a temp file.
+func (b *spillBuffer) Spilled() bool { return b.file != nil }
+
+// Size is the number of bytes written.
+func (b *spillBuffer) Size() int64 { return b.size }
+
+// Bytes returns the in-memory content; nil once spilled.
+func (b *spillBuffer) Bytes() []byte {
+	if b.file != nil {
+		return nil
+	}
+	return b.mem.Bytes()
+}
+
+// Reader returns the content from the start. For a spilled buffer, closing
+// the reader removes the temp file; the buffer must not be reused after.
+func (b *spillBuffer) Reader() (io.ReadSeekCloser, error) {
+	if b.file == nil {
+		return nopSeekCloser{bytes.NewReader(b.mem.Bytes())}, nil
+	}
+	if _, err := b.file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	f := b.file
+	b.file = nil
+	return &tempFileReader{f}, nil
+}
+
+// Discard releases the buffer and removes any temp file.
+func (b *spillBuffer) Discard() {
+	if b.file != nil {
+		b.file.Close()
+		os.Remove(b.file.Name())
+		b.file = nil
+	}
+	b.mem = bytes.Buffer{}
+}
+
+type nopSeekCloser struct{ *bytes.Reader }
+
+func (nopSeekCloser) Close() error { return nil }
+
+type tempFileReader struct{ *os.File }
+
+func (t *tempFileReader) Close() error {
+	err := t.File.Close()
+	os.Remove(t.Name())
+	return err
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+)
+
+func TestSpillBuffer(t *testing.T) {
+	dir := t.TempDir()
+	buf := newSpillBuffer("export", 8, 32, dir)
+	if _, err := buf.Write([]byte("0123")); err != nil || buf.Spilled() {
+		t.Fatalf("expected in-memory write, spilled=%v err=%v", buf.Spilled(), err)
+	}
+	if _, err := buf.Write([]byte("456789")); err != nil || !buf.Spilled() {
+		t.Fatalf("expected spill past the memory limit, spilled=%v err=%v", buf.Spilled(), err)
+	}
+	_, err := buf.Write(make([]byte, 32))
+	var budgetErr *MemoryBudgetError
+	if !errors.As(err, &budgetErr) || !errors.Is(err, ErrMemoryBudgetExceeded) || budgetErr.Budget != 32 {
+		t.Fatalf("expected a budget error, got %v", err)
+	}
+
+	r, err := buf.Reader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(r)
+	if string(data) != "0123456789" {
+		t.Fatalf("unexpected content %q", data)
+	}
+	r.Close()
+	if left, _ := os.ReadDir(dir); len(left) != 0 {
+		t.Fatalf("spill file not removed: %v", left)
+	}
+}
+
+func TestStreamNDJSONToCSV(t *testing.T) {
+	src := strings.NewReader(`{"_msg":"a","level":"info"}` + "\n" + `{"_msg":"b","host":"h1"}` + "\n")
+	var out strings.Builder
+	if err := streamNDJSONToCSV(src, &out); err != nil {
+		t.Fatal(err)
+	}
+	want := "_msg,host,level\na,,info\nb,h1,\n"
+	if out.String() != want {
+		t.Fatalf("got %q, want %q", out.String(), want)
+	}
+}
+
+func TestCorrelateByTimeWindow_MemoryBudget(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	entries := func(n int) []map[string]interface{} {
+		out := make([]map[string]interface{}, n)
+		for i := range out {
+			ts := base.Add(time.Duration(i) * time.Second).Format(time.RFC3339)
+			out[i] = map[string]interface{}{"timestamp": ts, "startTime": ts}
+		}
+		return out
+	}
+	window := 2 * time.Second
+	query := &models.CorrelationQuery{
+		ID:         "q",
+		TimeWindow: &window,
+		Expressions: []models.CorrelationExpression{
+			{Engine: models.QueryTypeLogs},
+			{Engine: models.QueryTypeTraces},
+		},
+	}
+	results := map[models.QueryType]*models.UnifiedResult{
+		models.QueryTypeLogs:   {Data: entries(100)},
+		models.QueryTypeTraces: {Data: entries(100)},
+	}
+
+	ce := &CorrelationEngineImpl{engineCfg: config.EngineConfig{MaxCorrelationMemoryMB: 1}}
+	correlations, err := ce.correlateByTimeWindow(query, results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Every point matches the 5 points within ±2s, minus the clipped edges.
+	if len(correlations) != 100*5-6 {
+		t.Fatalf("expected 494 correlations, got %d", len(correlations))
+	}
+
+	results[models.QueryTypeLogs].Data = entries(3000)
+	results[models.QueryTypeTraces].Data = entries(3000)
+	if _, err := ce.correlateByTimeWindow(query, results); !errors.Is(err, ErrMemoryBudgetExceeded) {
+		t.Fatalf("expected the run to exceed its budget, got %v", err)
+	}
+}
@@ -174,6 +174,7 @@ func (m *MetricsMetadataIndexerImpl) extractMetricsMetadata(ctx context.Context,
 		Start: request.TimeRange.Start.Format(time.RFC3339),
 		End:   request.TimeRange.End.Format(time.RFC3339),
 		Limit: batchLimit,
+		Match: request.Match,
 	}
 
 	metricNames, err := m.victoriaMetricsSvc.GetLabelValues(ctx, nameReq)
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/qos"
 	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
 	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
 )
@@ -117,6 +118,7 @@ func (s *MetricsMetadataSynchronizerImpl) Stop() error {
 func (s *MetricsMetadataSynchronizerImpl) syncLoop(ctx context.Context) {
 	defer s.wg.Done()
 	defer close(s.doneCh)
+	ctx = qos.WithClass(ctx, qos.Batch)
 
 	s.ticker = time.NewTicker(s.config.Interval)
 	defer s.ticker.Stop()
@@ -284,6 +286,7 @@ func (s *MetricsMetadataSynchronizerImpl) performSync(ctx context.Context, strat
 
 // SyncNow triggers an immediate sync
 func (s *MetricsMetadataSynchronizerImpl) SyncNow(ctx context.Context, forceFull bool) (*models.MetricMetadataSyncResult, error) {
+	ctx = qos.WithClass(ctx, qos.Batch)
 	return s.performSync(ctx, s.determineSyncStrategy(s.getSyncState(), forceFull))
 }
 
@@ -15,21 +15,51 @@ import (
 type NotificationService struct {
 	integrations *IntegrationsService
 	logger       logging.Logger
+	catalog      *MessageCatalog
+	locale       string
+	templates    *NotificationTemplateService
 }
 
 func NewNotificationService(cfg config.IntegrationsConfig, logger corelogger.Logger) *NotificationService {
 	return &NotificationService{
 		integrations: NewIntegrationsService(cfg, logger),
 		logger:       logging.FromCoreLogger(logger),
+		catalog:      NewMessageCatalog(),
+		locale:       DefaultLocale,
 	}
 }
 
+// SetLocalization sets the message catalog and the locale used for
+// notification text. An empty locale keeps the current one.
+func (s *NotificationService) SetLocalization(catalog *MessageCatalog, locale string) {
+	if catalog != nil {
+		s.catalog = catalog
+	}
+	if locale != "" {
+		s.locale = locale
+	}
+}
+
+// SetTemplates sets the tenant's notification templates. Without them the
+// built-in text is sent.
+func (s *NotificationService) SetTemplates(templates *NotificationTemplateService) {
+	s.templates = templates
+}
+
+// templated returns notification rendered with the template for channel.
+func (s *NotificationService) templated(ctx context.Context, channel string, notification *models.Notification) *models.Notification {
+	if s.templates == nil {
+		return notification
+	}
+	return s.templates.Apply(ctx, channel, notification)
+}
+
 // SendNotification dispatches notifications to configured integrations
 func (s *NotificationService) SendNotification(ctx context.Context, notification *models.Notification) error {
 	var errors []error
 
 	// Send to Slack if enabled
-	if err := s.integrations.SendSlackNotification(ctx, notification); err != nil {
+	if err := s.integrations.SendSlackNotification(ctx, s.templated(ctx, models.NotificationChannelSlack, notification)); err != nil {
 		s.logger.Error("Slack notification failed", "error", err)
 		errors = append(errors, err)
 		metrics.NotificationsSent.WithLabelValues("slack", notification.Type, "false").Inc()
@@ -38,7 +68,7 @@ func (s *NotificationService) SendNotification(ctx context.Context, notification
 	}
 
 	// Send to MS Teams if enabled
-	if err := s.integrations.SendMSTeamsNotification(ctx, notification); err != nil {
+	if err := s.integrations.SendMSTeamsNotification(ctx, s.templated(ctx, models.NotificationChannelTeams, notification)); err != nil {
 		s.logger.Error("MS Teams notification failed", "error", err)
 		errors = append(errors, err)
 		metrics.NotificationsSent.WithLabelValues("teams", notification.Type, "false").Inc()
@@ -47,7 +77,7 @@ func (s *NotificationService) SendNotification(ctx context.Context, notification
 	}
 
 	// Send email notification
-	if err := s.integrations.SendEmailNotification(ctx, notification); err != nil {
+	if err := s.integrations.SendEmailNotification(ctx, s.templated(ctx, models.NotificationChannelEmail, notification)); err != nil {
 		s.logger.Error("Email notification failed", "error", err)
 		errors = append(errors, err)
 		metrics.NotificationsSent.WithLabelValues("email", notification.Type, "false").Inc()
@@ -67,14 +97,20 @@ func (s *NotificationService) ProcessCorrelationNotification(ctx context.Context
 	notification := &models.Notification{
 		ID:    fmt.Sprintf("rca-%s", correlation.CorrelationID),
 		Type:  "correlation",
-		Title: fmt.Sprintf("Root Cause Found: %s", correlation.IncidentID),
-		Message: fmt.Sprintf("Root cause identified: %s (Confidence: %0.1f%%). Affected services: %v",
+		Title: s.catalog.T(s.locale, MsgCorrelationTitle, correlation.IncidentID),
+		Message: s.catalog.T(s.locale, MsgCorrelationMessage,
 			correlation.RootCause,
 			correlation.Confidence*100,
 			correlation.AffectedServices),
 		Component: "rca-engine",
 		Severity:  determineSeverityFromConfidence(correlation.Confidence),
 		Timestamp: time.Now(),
+		Data: map[string]interface{}{
+			"incidentId":       correlation.IncidentID,
+			"rootCause":        correlation.RootCause,
+			"confidence":       correlation.Confidence,
+			"affectedServices": correlation.AffectedServices,
+		},
 	}
 
 	return s.SendNotification(ctx, notification)
@@ -0,0 +1,416 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const (
+	// maxNotificationTemplateBytes caps a template source;
+	// maxNotificationRenderBytes caps its rendered output.
+	maxNotificationTemplateBytes = 16 << 10
+	maxNotificationRenderBytes   = 64 << 10
+)
+
+var (
+	ErrInvalidNotificationTemplate  = errors.New("invalid notification template")
+	ErrNotificationTemplateNotFound = errors.New("notification template not found")
+)
+
+var (
+	notificationTemplateChannels = []string{
+		models.NotificationChannelSlack, models.NotificationChannelTeams, models.NotificationChannelEmail, models.NotificationTemplateAny,
+	}
+	notificationTemplateEvents = []string{
+		"correlation", "mention", remediationNotificationType, selfSLONotificationType, models.NotificationTemplateAny,
+	}
+)
+
+// notificationTemplateData is what a template is rendered with. Title and
+// Message hold the built-in text, so templates can wrap rather than replace
+// it.
+type notificationTemplateData struct {
+	ID        string
+	Type      string
+	Title     string
+	Message   string
+	Component string
+	Severity  string
+	Timestamp time.Time
+	Data      map[string]interface{}
+	Locale    string
+	Tenant    string
+}
+
+// NotificationTemplateService stores the tenant's notification templates
+// and renders notifications with them. Templates are Go text/templates with
+// a fixed set of functions; a missing, broken or failing template falls back
+// to the built-in text, so a bad edit never drops a notification. They are
+// stored as the notification_templates dynamic config document, so changes
+// are locked across replicas, versioned and can be rolled back.
+type NotificationTemplateService struct {
+	config   *DynamicConfigService
+	settings *TenantSettingsService
+	logger   logging.Logger
+}
+
+// NewNotificationTemplateService creates a new notification template
+// service. settings supplies the tenant locale, timezone and message
+// catalog; it may be nil to use the defaults.
+func NewNotificationTemplateService(cache cache.ValkeyCluster, settings *TenantSettingsService, logger corelogger.Logger) *NotificationTemplateService {
+	return &NotificationTemplateService{
+		config:   NewDynamicConfigService(cache, logger),
+		settings: settings,
+		logger:   logging.FromCoreLogger(logger),
+	}
+}
+
+// List returns the stored templates ordered by channel, event type and locale.
+func (s *NotificationTemplateService) List(ctx context.Context) ([]models.NotificationTemplate, error) {
+	list := []models.NotificationTemplate{}
+	if err := s.config.getDocument(ctx, DynamicConfigNotificationTemplates, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Get returns the template stored for exactly channel, eventType and locale.
+func (s *NotificationTemplateService) Get(ctx context.Context, channel, eventType, locale string) (*models.NotificationTemplate, error) {
+	list, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	locale = normalizeLocale(locale)
+	for i := range list {
+		if list[i].Channel == channel && list[i].EventType == eventType && list[i].Locale == locale {
+			return &list[i], nil
+		}
+	}
+	return nil, ErrNotificationTemplateNotFound
+}
+
+// Put validates and stores t, replacing the template with the same channel,
+// event type and locale.
+func (s *NotificationTemplateService) Put(ctx context.Context, t models.NotificationTemplate) (*models.NotificationTemplate, error) {
+	t.Locale = normalizeLocale(t.Locale)
+	if err := s.validate(t); err != nil {
+		return nil, err
+	}
+	t.UpdatedAt = time.Now().UTC()
+	var list []models.NotificationTemplate
+	if _, err := s.config.updateDocument(ctx, DynamicConfigNotificationTemplates, t.UpdatedBy, &list, func() error {
+		list = append(slices.DeleteFunc(list, func(o models.NotificationTemplate) bool {
+			return o.Channel == t.Channel && o.EventType == t.EventType && o.Locale == t.Locale
+		}), t)
+		sortNotificationTemplates(list)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	s.logger.Info("Notification template stored", "channel", t.Channel, "event_type", t.EventType, "locale", t.Locale)
+	return &t, nil
+}
+
+// Delete removes a template; notifications fall back to the next matching
+// template or the built-in text.
+func (s *NotificationTemplateService) Delete(ctx context.Context, channel, eventType, locale, deletedBy string) error {
+	locale = normalizeLocale(locale)
+	var list []models.NotificationTemplate
+	_, err := s.config.updateDocument(ctx, DynamicConfigNotificationTemplates, deletedBy, &list, func() error {
+		kept := slices.DeleteFunc(slices.Clone(list), func(o models.NotificationTemplate) bool {
+			return o.Channel == channel && o.EventType == eventType && o.Locale == locale
+		})
+		if len(kept) == len(list) {
+			return ErrNotificationTemplateNotFound
+		}
+		list = kept
+		return nil
+	})
+	return err
+}
+
+func sortNotificationTemplates(list []models.NotificationTemplate) {
+	sort.Slice(list, func(i, j int) bool {
+		a, b := list[i], list[j]
+		if a.Channel != b.Channel {
+			return a.Channel < b.Channel
+		}
+		if a.EventType != b.EventType {
+			return a.EventType < b.EventType
+		}
+		return a.Locale < b.Locale
+	})
+}
+
+func (s *NotificationTemplateService) validate(t models.NotificationTemplate) error {
+	var problems []string
+	if !slices.Contains(notificationTemplateChannels, t.Channel) {
+		problems = append(problems, fmt.Sprintf("channel %q must be one of %s", t.Channel, strings.Join(notificationTemplateChannels, ", ")))
+	}
+	if !slices.Contains(notificationTemplateEvents, t.EventType) {
+		problems = append(problems, fmt.Sprintf("eventType %q must be one of %s", t.EventType, strings.Join(notificationTemplateEvents, ", ")))
+	}
+	if catalog := s.catalog(); !catalog.Supports(t.Locale) {
+		problems = append(problems, fmt.Sprintf("locale %q is not supported (available: %s)", t.Locale, strings.Join(catalog.Locales(), ", ")))
+	}
+	if t.Title == "" && t.Body == "" {
+		problems = append(problems, "title or body is required")
+	}
+	for name, src := range map[string]string{"title": t.Title, "body": t.Body} {
+		if len(src) > maxNotificationTemplateBytes {
+			problems = append(problems, fmt.Sprintf("%s exceeds %d bytes", name, maxNotificationTemplateBytes))
+		} else if _, err := s.parse(name, src, t.Locale, nil); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", name, err))
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("%w: %s", ErrInvalidNotificationTemplate, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// Apply returns n rendered with the best template for channel, or n itself
+// when no template matches or rendering fails.
+func (s *NotificationTemplateService) Apply(ctx context.Context, channel string, n *models.Notification) *models.Notification {
+	list, err := s.List(ctx)
+	if err != nil {
+		s.logger.Warn("Notification templates unavailable, using built-in text", "error", err)
+		return n
+	}
+	if len(list) == 0 {
+		return n
+	}
+	settings := s.tenantSettings(ctx)
+	t := matchNotificationTemplate(list, channel, n.Type, settings.DefaultLocale)
+	if t == nil {
+		return n
+	}
+	out, err := s.render(t, n, settings)
+	if err != nil {
+		s.logger.Warn("Notification template failed, using built-in text",
+			"channel", t.Channel, "event_type", t.EventType, "locale", t.Locale, "error", err)
+		return n
+	}
+	return out
+}
+
+// Preview renders req.Template, which need not be stored, with the sample
+// notification. Unlike Apply it reports rendering errors.
+func (s *NotificationTemplateService) Preview(ctx context.Context, req models.NotificationTemplatePreviewRequest) (*models.NotificationTemplatePreview, error) {
+	t := req.Template
+	t.Locale = normalizeLocale(t.Locale)
+	if err := s.validate(t); err != nil {
+		return nil, err
+	}
+	n := req.Notification
+	if n == nil {
+		n = sampleNotification(t.EventType)
+	}
+	out, err := s.render(&t, n, s.tenantSettings(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidNotificationTemplate, err)
+	}
+	return &models.NotificationTemplatePreview{
+		Channel: t.Channel, EventType: t.EventType, Locale: t.Locale,
+		Title: out.Title, Message: out.Message, Template: true,
+	}, nil
+}
+
+// Render shows what a notification of eventType sent to channel in locale
+// looks like with the stored templates, including fallbacks. n defaults to
+// a built-in sample.
+func (s *NotificationTemplateService) Render(ctx context.Context, channel, eventType, locale string, n *models.Notification) (*models.NotificationTemplatePreview, error) {
+	if n == nil {
+		n = sampleNotification(eventType)
+	}
+	n.Type = eventType
+	list, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	settings := s.tenantSettings(ctx)
+	if locale != "" {
+		settings.DefaultLocale = normalizeLocale(locale)
+	}
+	preview := &models.NotificationTemplatePreview{
+		Channel: channel, EventType: eventType, Locale: settings.DefaultLocale,
+		Title: n.Title, Message: n.Message,
+	}
+	t := matchNotificationTemplate(list, channel, eventType, settings.DefaultLocale)
+	if t == nil {
+		return preview, nil
+	}
+	out, err := s.render(t, n, settings)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s/%s/%s: %v", ErrInvalidNotificationTemplate, t.Channel, t.EventType, t.Locale, err)
+	}
+	preview.Title, preview.Message, preview.Template = out.Title, out.Message, true
+	return preview, nil
+}
+
+// matchNotificationTemplate picks the template for channel, eventType and
+// locale. The locale's fallback chain is tried first; within a locale an
+// exact channel beats "any", then an exact event type beats "any".
+func matchNotificationTemplate(list []models.NotificationTemplate, channel, eventType, locale string) *models.NotificationTemplate {
+	for _, l := range localeChain(locale) {
+		for _, c := range []string{channel, models.NotificationTemplateAny} {
+			for _, e := range []string{eventType, models.NotificationTemplateAny} {
+				for i := range list {
+					if list[i].Locale == l && list[i].Channel == c && list[i].EventType == e {
+						return &list[i]
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// render returns a copy of n with the template's title and body applied.
+// An empty title or body keeps the built-in text.
+func (s *NotificationTemplateService) render(t *models.NotificationTemplate, n *models.Notification, settings *models.TenantSettings) (*models.Notification, error) {
+	data := notificationTemplateData{
+		ID: n.ID, Type: n.Type, Title: n.Title, Message: n.Message,
+		Component: n.Component, Severity: n.Severity, Timestamp: n.Timestamp,
+		Data: n.Data, Locale: t.Locale, Tenant: settings.Branding.DisplayName,
+	}
+	if data.Data == nil {
+		data.Data = map[string]interface{}{}
+	}
+	out := *n
+	for _, f := range []struct {
+		name, src string
+		dst       *string
+	}{{"title", t.Title, &out.Title}, {"body", t.Body, &out.Message}} {
+		if f.src == "" {
+			continue
+		}
+		tmpl, err := s.parse(f.name, f.src, t.Locale, settings)
+		if err != nil {
+			return nil, err
+		}
+		w := &limitedBuffer{max: maxNotificationRenderBytes}
+		if err := tmpl.Execute(w, data); err != nil {
+			return nil, err
+		}
+		*f.dst = strings.TrimSpace(w.String())
+	}
+	return &out, nil
+}
+
+func (s *NotificationTemplateService) parse(name, src, locale string, settings *models.TenantSettings) (*template.Template, error) {
+	return template.New(name).Option("missingkey=error").Funcs(s.funcs(locale, settings)).Parse(src)
+}
+
+// funcs is the whitelist of functions available to templates, in addition
+// to the text/template builtins.
+func (s *NotificationTemplateService) funcs(locale string, settings *models.TenantSettings) template.FuncMap {
+	catalog := s.catalog()
+	return template.FuncMap{
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"trim":  strings.TrimSpace,
+		"join": func(sep string, v interface{}) string {
+			switch x := v.(type) {
+			case []string:
+				return strings.Join(x, sep)
+			case []interface{}:
+				parts := make([]string, len(x))
+				for i, p := range x {
+					parts[i] = fmt.Sprint(p)
+				}
+				return strings.Join(parts, sep)
+			}
+			return fmt.Sprint(v)
+		},
+		"truncate": func(n int, v string) string {
+			if r := []rune(v); len(r) > n && n > 0 {
+				return string(r[:n-1]) + "…"
+			}
+			return v
+		},
+		"default": func(def, v interface{}) interface{} {
+			if v == nil || v == "" {
+				return def
+			}
+			return v
+		},
+		"percent": func(v float64) string {
+			return fmt.Sprintf("%.1f%%", v*100)
+		},
+		"formatTime": func(t time.Time) string {
+			return FormatTimestamp(settings, t)
+		},
+		"t": func(key string, args ...interface{}) string {
+			return catalog.T(locale, key, args...)
+		},
+	}
+}
+
+func (s *NotificationTemplateService) catalog() *MessageCatalog {
+	if s.settings != nil {
+		return s.settings.Catalog()
+	}
+	return NewMessageCatalog()
+}
+
+func (s *NotificationTemplateService) tenantSettings(ctx context.Context) *models.TenantSettings {
+	if s.settings != nil {
+		if settings, err := s.settings.GetSettings(ctx); err == nil && settings != nil {
+			return settings
+		}
+	}
+	return DefaultTenantSettings()
+}
+
+// sampleNotification is the notification previews render when the caller
+// supplies none.
+func sampleNotification(eventType string) *models.Notification {
+	n := &models.Notification{
+		ID:        "sample",
+		Type:      eventType,
+		Title:     "Sample notification",
+		Message:   "This is a sample notification.",
+		Component: "checkout",
+		Severity:  "high",
+		Timestamp: time.Date(2026, 1, 15, 9, 30, 0, 0, time.UTC),
+		Data:      map[string]interface{}{},
+	}
+	if eventType == "correlation" {
+		n.Title = "Root Cause Found: INC-1042"
+		n.Message = "Root cause identified: checkout-db connection pool exhausted (Confidence: 92.0%). Affected services: [checkout payments]"
+		n.Data = map[string]interface{}{
+			"incidentId":       "INC-1042",
+			"rootCause":        "checkout-db connection pool exhausted",
+			"confidence":       0.92,
+			"affectedServices": []string{"checkout", "payments"},
+		}
+	}
+	return n
+}
+
+// limitedBuffer fails writes once max bytes have been written.
+type limitedBuffer struct {
+	bytes.Buffer
+	max int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.max {
+		return 0, fmt.Errorf("rendered output exceeds %d bytes", b.max)
+	}
+	return b.Buffer.Write(p)
+}
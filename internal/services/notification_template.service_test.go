@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func newTestNotificationTemplates(t *testing.T, locale string) *NotificationTemplateService {
+	t.Helper()
+	log := logger.New("error")
+	c := cache.NewNoopValkeyCache(log)
+	settings := NewTenantSettingsService(c, NewMessageCatalog(), log)
+	s := DefaultTenantSettings()
+	s.DefaultLocale = locale
+	_, err := settings.SetSettings(context.Background(), s)
+	require.NoError(t, err)
+	return NewNotificationTemplateService(c, settings, log)
+}
+
+func TestNotificationTemplateService_Validation(t *testing.T) {
+	svc := newTestNotificationTemplates(t, "en")
+	ctx := context.Background()
+
+	for _, tc := range []models.NotificationTemplate{
+		{Channel: "pager", EventType: "correlation", Locale: "en", Title: "x"},
+		{Channel: "slack", EventType: "deploy", Locale: "en", Title: "x"},
+		{Channel: "slack", EventType: "correlation", Locale: "xx", Title: "x"},
+		{Channel: "slack", EventType: "correlation", Locale: "en"},
+		{Channel: "slack", EventType: "correlation", Locale: "en", Title: "{{ .Title "},
+		{Channel: "slack", EventType: "correlation", Locale: "en", Body: `{{ exec "rm" }}`},
+	} {
+		_, err := svc.Put(ctx, tc)
+		assert.True(t, errors.Is(err, ErrInvalidNotificationTemplate), "%+v: %v", tc, err)
+	}
+
+	_, err := svc.Put(ctx, models.NotificationTemplate{Channel: "slack", EventType: "correlation", Locale: "DE", Title: "x"})
+	require.NoError(t, err)
+	got, err := svc.Get(ctx, "slack", "correlation", "de")
+	require.NoError(t, err)
+	assert.Equal(t, "de", got.Locale)
+
+	require.NoError(t, svc.Delete(ctx, "slack", "correlation", "de", "sre"))
+	assert.True(t, errors.Is(svc.Delete(ctx, "slack", "correlation", "de", "sre"), ErrNotificationTemplateNotFound))
+	versions, err := svc.config.ListVersions(ctx, DynamicConfigNotificationTemplates)
+	require.NoError(t, err)
+	assert.Equal(t, "sre", versions[0].Author, "the delete is versioned")
+}
+
+func TestNotificationTemplateService_Apply(t *testing.T) {
+	svc := newTestNotificationTemplates(t, "de-at")
+	ctx := context.Background()
+	for _, tpl := range []models.NotificationTemplate{
+		{Channel: "any", EventType: "any", Locale: "en", Title: "[{{ upper .Severity }}] {{ .Title }}"},
+		{Channel: "slack", EventType: "correlation", Locale: "de",
+			Body: `Ursache: {{ .Data.rootCause }} ({{ percent .Data.confidence }}), {{ join ", " .Data.affectedServices }}`},
+		{Channel: "email", EventType: "correlation", Locale: "de", Body: "{{ .Data.missing }}"},
+	} {
+		_, err := svc.Put(ctx, tpl)
+		require.NoError(t, err)
+	}
+	n := &models.Notification{
+		Type: "correlation", Title: "Built-in title", Message: "Built-in message", Severity: "high",
+		Timestamp: time.Now(),
+		Data: map[string]interface{}{
+			"rootCause":        "db pool exhausted",
+			"confidence":       0.92,
+			"affectedServices": []string{"checkout", "payments"},
+		},
+	}
+
+	// de-at falls back to de; the empty title keeps the built-in one.
+	slack := svc.Apply(ctx, "slack", n)
+	assert.Equal(t, "Built-in title", slack.Title)
+	assert.Equal(t, "Ursache: db pool exhausted (92.0%), checkout, payments", slack.Message)
+
+	// No German teams template: the English catch-all applies.
+	teams := svc.Apply(ctx, "teams", n)
+	assert.Equal(t, "[HIGH] Built-in title", teams.Title)
+	assert.Equal(t, "Built-in message", teams.Message)
+
+	// A failing template falls back to the built-in text.
+	email := svc.Apply(ctx, "email", n)
+	assert.Same(t, n, email)
+	assert.Equal(t, "Built-in message", n.Message, "the original notification is not modified")
+
+	_, err := svc.Render(ctx, "email", "correlation", "de", nil)
+	assert.True(t, errors.Is(err, ErrInvalidNotificationTemplate))
+}
+
+func TestNotificationTemplateService_Preview(t *testing.T) {
+	svc := newTestNotificationTemplates(t, "en")
+	preview, err := svc.Preview(context.Background(), models.NotificationTemplatePreviewRequest{
+		Template: models.NotificationTemplate{
+			Channel: "email", EventType: "correlation", Locale: "fr",
+			Title: `{{ t "notification.correlation.title" .Data.incidentId }}`,
+			Body:  `{{ truncate 10 .Data.rootCause }} {{ default "n/a" .Component }}`,
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Cause racine trouvée : INC-1042", preview.Title)
+	assert.Equal(t, "checkout-… checkout", preview.Message)
+	assert.True(t, preview.Template)
+}
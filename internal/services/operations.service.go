@@ -0,0 +1,272 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/qos"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// Operation kinds.
+const (
+	OperationKPIImport      = "kpi_import"
+	OperationRetentionPurge = "retention_purge"
+)
+
+const (
+	// Operations are stored under operationKeyPrefix plus the ID; a cancel
+	// request under operationCancelKeyPrefix plus the ID, so any replica can
+	// cancel an operation another one runs.
+	operationKeyPrefix       = "operation:"
+	operationCancelKeyPrefix = "operation_cancel:"
+	operationTTL             = 24 * time.Hour
+
+	// maxOperationErrors bounds the item errors kept per operation.
+	maxOperationErrors = 500
+	// operationSaveInterval throttles progress writes.
+	operationSaveInterval = 500 * time.Millisecond
+)
+
+var (
+	ErrOperationNotFound = errors.New("operation not found")
+	ErrOperationFinished = errors.New("operation already finished")
+)
+
+// OperationFunc runs the work of an operation, reporting every processed
+// item through p. It should stop when p.Step returns an error (the
+// operation was cancelled). The returned value becomes the result.
+type OperationFunc func(ctx context.Context, p *OperationProgress) (any, error)
+
+// OperationsService runs long-running jobs in the background and tracks
+// their progress as operation resources, so every bulk endpoint can return
+// a handle clients poll, stream or cancel. Operations are kept in Valkey
+// for 24 hours.
+type OperationsService struct {
+	cache  cache.ValkeyCluster
+	logger logging.Logger
+
+	mu      sync.Mutex
+	cancels map[string]context.CancelFunc
+}
+
+// NewOperationsService creates a new operations service.
+func NewOperationsService(cache cache.ValkeyCluster, logger corelogger.Logger) *OperationsService {
+	return &OperationsService{
+		cache:   cache,
+		logger:  logging.FromCoreLogger(logger),
+		cancels: map[string]context.CancelFunc{},
+	}
+}
+
+// Start records a running operation of kind over total items and runs fn
+// in the background. The job keeps ctx's values but not its cancellation,
+// so it outlives the request that started it.
+func (s *OperationsService) Start(ctx context.Context, kind string, total int, createdBy string, fn OperationFunc) (*models.Operation, error) {
+	now := time.Now().UTC()
+	op := &models.Operation{
+		ID:        uuid.NewString(),
+		Kind:      kind,
+		Status:    models.OperationRunning,
+		Total:     total,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.save(ctx, op); err != nil {
+		return nil, err
+	}
+	snapshot := *op
+
+	runCtx, cancel := context.WithCancel(qos.WithClass(context.WithoutCancel(ctx), qos.Batch))
+	s.mu.Lock()
+	s.cancels[op.ID] = cancel
+	s.mu.Unlock()
+	go s.run(runCtx, cancel, op, fn)
+	return &snapshot, nil
+}
+
+func (s *OperationsService) run(ctx context.Context, cancel context.CancelFunc, op *models.Operation, fn OperationFunc) {
+	p := &OperationProgress{svc: s, ctx: ctx, cancel: cancel, op: op}
+	defer func() {
+		cancel()
+		s.mu.Lock()
+		delete(s.cancels, op.ID)
+		s.mu.Unlock()
+	}()
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Error("Operation panicked", "id", op.ID, "kind", op.Kind, "panic", r)
+			p.finish(nil, fmt.Errorf("internal error: %v", r))
+		}
+	}()
+	result, err := fn(ctx, p)
+	p.finish(result, err)
+}
+
+// Get returns an operation.
+func (s *OperationsService) Get(ctx context.Context, id string) (*models.Operation, error) {
+	data, err := s.cache.Get(ctx, operationKeyPrefix+id)
+	if err != nil || len(data) == 0 {
+		return nil, ErrOperationNotFound
+	}
+	var op models.Operation
+	if err := json.Unmarshal(data, &op); err != nil {
+		return nil, fmt.Errorf("decode operation: %w", err)
+	}
+	if !op.Finished() && s.cancelRequested(ctx, id) {
+		op.CancelRequested = true
+	}
+	return &op, nil
+}
+
+// List returns the operations of the last 24 hours, newest first.
+func (s *OperationsService) List(ctx context.Context) ([]models.Operation, error) {
+	var keys []string
+	if err := s.cache.ScanKeys(ctx, operationKeyPrefix, func(batch []string) error {
+		keys = append(keys, batch...)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("list operations: %w", err)
+	}
+	ops := make([]models.Operation, 0, len(keys))
+	for _, key := range keys {
+		if op, err := s.Get(ctx, strings.TrimPrefix(key, operationKeyPrefix)); err == nil {
+			ops = append(ops, *op)
+		}
+	}
+	sort.Slice(ops, func(i, j int) bool { return ops[i].CreatedAt.After(ops[j].CreatedAt) })
+	return ops, nil
+}
+
+// Cancel asks a running operation to stop. The job stops at its next
+// processed item and the operation ends as cancelled.
+func (s *OperationsService) Cancel(ctx context.Context, id string) (*models.Operation, error) {
+	op, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if op.Finished() {
+		return nil, ErrOperationFinished
+	}
+	if err := s.cache.Set(ctx, operationCancelKeyPrefix+id, "1", operationTTL); err != nil {
+		return nil, fmt.Errorf("store operation cancel request: %w", err)
+	}
+	s.mu.Lock()
+	if cancel, ok := s.cancels[id]; ok {
+		cancel()
+	}
+	s.mu.Unlock()
+	op.CancelRequested = true
+	return op, nil
+}
+
+func (s *OperationsService) cancelRequested(ctx context.Context, id string) bool {
+	data, err := s.cache.Get(ctx, operationCancelKeyPrefix+id)
+	return err == nil && len(data) > 0
+}
+
+func (s *OperationsService) save(ctx context.Context, op *models.Operation) error {
+	data, err := json.Marshal(op)
+	if err != nil {
+		return fmt.Errorf("encode operation: %w", err)
+	}
+	if err := s.cache.Set(ctx, operationKeyPrefix+op.ID, data, operationTTL); err != nil {
+		return fmt.Errorf("store operation: %w", err)
+	}
+	return nil
+}
+
+// OperationProgress reports the progress of a running operation.
+type OperationProgress struct {
+	svc    *OperationsService
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu    sync.Mutex
+	op    *models.Operation
+	saved time.Time
+}
+
+// SetTotal sets the item count for jobs that learn it after starting.
+func (p *OperationProgress) SetTotal(total int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.op.Total = total
+	p.op.Percent = operationPercent(p.op.Done, total)
+}
+
+// Step records one processed item; a non-nil itemErr marks it failed. It
+// returns the context error once the operation has been cancelled.
+func (p *OperationProgress) Step(item string, itemErr error) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.op.Done++
+	if itemErr != nil {
+		p.op.Failed++
+		if len(p.op.Errors) < maxOperationErrors {
+			p.op.Errors = append(p.op.Errors, models.OperationItemError{Item: item, Error: itemErr.Error()})
+		}
+	}
+	p.op.Percent = operationPercent(p.op.Done, p.op.Total)
+	if time.Since(p.saved) >= operationSaveInterval || p.op.Done == p.op.Total {
+		if p.svc.cancelRequested(p.ctx, p.op.ID) {
+			p.op.CancelRequested = true
+			p.cancel()
+		}
+		p.flush()
+	}
+	return p.ctx.Err()
+}
+
+func (p *OperationProgress) finish(result any, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now().UTC()
+	switch {
+	case p.ctx.Err() != nil:
+		p.op.Status = models.OperationCancelled
+	case err != nil:
+		p.op.Status, p.op.Error = models.OperationFailed, err.Error()
+	default:
+		p.op.Status = models.OperationSucceeded
+	}
+	if result != nil {
+		if data, merr := json.Marshal(result); merr == nil {
+			p.op.Result = data
+		}
+	}
+	p.op.FinishedAt = &now
+	p.flush()
+	p.svc.logger.Info("Operation finished", "id", p.op.ID, "kind", p.op.Kind, "status", p.op.Status, "done", p.op.Done, "failed", p.op.Failed)
+}
+
+// flush persists the operation; the caller holds p.mu. Writes outlive a
+// cancelled job context so the final state is recorded.
+func (p *OperationProgress) flush() {
+	p.op.UpdatedAt = time.Now().UTC()
+	p.saved = p.op.UpdatedAt
+	if err := p.svc.save(context.WithoutCancel(p.ctx), p.op); err != nil {
+		p.svc.logger.Warn("Failed to store operation progress", "id", p.op.ID, "error", err)
+	}
+}
+
+// operationPercent is done/total as a percentage with one decimal.
+func operationPercent(done, total int) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return math.Min(100, math.Round(float64(done)*1000/float64(total))/10)
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func waitOperation(t *testing.T, svc *OperationsService, id string) *models.Operation {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		op, err := svc.Get(context.Background(), id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if op.Finished() {
+			return op
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("operation %s did not finish", id)
+	return nil
+}
+
+func TestOperationsService_ProgressAndResult(t *testing.T) {
+	log := logger.New("error")
+	svc := NewOperationsService(cache.NewNoopValkeyCache(log), log)
+
+	op, err := svc.Start(context.Background(), OperationKPIImport, 4, "alice", func(ctx context.Context, p *OperationProgress) (any, error) {
+		for _, item := range []string{"a", "b", "c", "d"} {
+			var itemErr error
+			if item == "c" {
+				itemErr = errors.New("invalid formula")
+			}
+			if err := p.Step(item, itemErr); err != nil {
+				return nil, err
+			}
+		}
+		return map[string]int{"created": 3}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if op.Status != models.OperationRunning || op.CreatedBy != "alice" {
+		t.Fatalf("unexpected started operation %+v", op)
+	}
+
+	op = waitOperation(t, svc, op.ID)
+	if op.Status != models.OperationSucceeded || op.Done != 4 || op.Failed != 1 || op.Percent != 100 || op.FinishedAt == nil {
+		t.Fatalf("unexpected finished operation %+v", op)
+	}
+	if len(op.Errors) != 1 || op.Errors[0].Item != "c" || string(op.Result) != `{"created":3}` {
+		t.Fatalf("unexpected errors/result %+v %s", op.Errors, op.Result)
+	}
+	if _, err := svc.Cancel(context.Background(), op.ID); !errors.Is(err, ErrOperationFinished) {
+		t.Fatalf("expected ErrOperationFinished, got %v", err)
+	}
+	ops, err := svc.List(context.Background())
+	if err != nil || len(ops) != 1 || ops[0].ID != op.ID {
+		t.Fatalf("unexpected list %+v, %v", ops, err)
+	}
+	if _, err := svc.Get(context.Background(), "missing"); !errors.Is(err, ErrOperationNotFound) {
+		t.Fatalf("expected ErrOperationNotFound, got %v", err)
+	}
+}
+
+func TestOperationsService_Cancel(t *testing.T) {
+	log := logger.New("error")
+	svc := NewOperationsService(cache.NewNoopValkeyCache(log), log)
+	started := make(chan struct{})
+
+	op, err := svc.Start(context.Background(), OperationRetentionPurge, 1000, "", func(ctx context.Context, p *OperationProgress) (any, error) {
+		close(started)
+		for i := 0; i < 1000; i++ {
+			if err := p.Step("item", nil); err != nil {
+				return nil, err
+			}
+			time.Sleep(time.Millisecond)
+		}
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-started
+	if got, err := svc.Cancel(context.Background(), op.ID); err != nil || !got.CancelRequested {
+		t.Fatalf("unexpected cancel %+v, %v", got, err)
+	}
+	op = waitOperation(t, svc, op.ID)
+	if op.Status != models.OperationCancelled || op.Done >= 1000 {
+		t.Fatalf("expected a cancelled operation, got %+v", op)
+	}
+}
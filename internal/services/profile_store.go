@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+)
+
+// profileTimeLayout keeps capture names sortable and free of path separators.
+const profileTimeLayout = "20060102T150405Z"
+
+var ErrProfileNotFound = errors.New("profile not found")
+
+// ProfileStore persists captured pprof profiles.
+type ProfileStore interface {
+	Put(ctx context.Context, capture models.ProfileCapture, data []byte) error
+	// List returns captures newest first.
+	List(ctx context.Context) ([]models.ProfileCapture, error)
+	Get(ctx context.Context, name string) ([]byte, error)
+	Delete(ctx context.Context, name string) error
+}
+
+// profileName is "<time>_<trigger>_<kind>.pprof".
+func profileName(capturedAt time.Time, trigger, kind string) string {
+	return fmt.Sprintf("%s_%s_%s.pprof", capturedAt.UTC().Format(profileTimeLayout), trigger, kind)
+}
+
+func parseProfileName(name string) (models.ProfileCapture, bool) {
+	parts := strings.Split(strings.TrimSuffix(name, ".pprof"), "_")
+	if len(parts) != 3 || !strings.HasSuffix(name, ".pprof") {
+		return models.ProfileCapture{}, false
+	}
+	at, err := time.Parse(profileTimeLayout, parts[0])
+	if err != nil {
+		return models.ProfileCapture{}, false
+	}
+	return models.ProfileCapture{Name: name, Trigger: parts[1], Kind: parts[2], CapturedAt: at}, true
+}
+
+// FileProfileStore keeps profiles as files in a local directory, typically a
+// mounted volume.
+type FileProfileStore struct {
+	dir string
+}
+
+// NewFileProfileStore creates dir if needed and stores profiles in it.
+func NewFileProfileStore(dir string) (*FileProfileStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("create profile dir: %w", err)
+	}
+	return &FileProfileStore{dir: dir}, nil
+}
+
+func (f *FileProfileStore) path(name string) (string, error) {
+	if _, ok := parseProfileName(name); !ok || filepath.Base(name) != name {
+		return "", ErrProfileNotFound
+	}
+	return filepath.Join(f.dir, name), nil
+}
+
+func (f *FileProfileStore) Put(ctx context.Context, capture models.ProfileCapture, data []byte) error {
+	p, err := f.path(capture.Name)
+	if err != nil {
+		return fmt.Errorf("invalid profile name %q", capture.Name)
+	}
+	// Write then rename so List never sees a partial profile.
+	tmp := p + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o640); err != nil {
+		return err
+	}
+	return os.Rename(tmp, p)
+}
+
+func (f *FileProfileStore) List(ctx context.Context) ([]models.ProfileCapture, error) {
+	entries, err := os.ReadDir(f.dir)
+	if err != nil {
+		return nil, err
+	}
+	out := []models.ProfileCapture{}
+	for _, e := range entries {
+		c, ok := parseProfileName(e.Name())
+		if !ok || e.IsDir() {
+			continue
+		}
+		if info, err := e.Info(); err == nil {
+			c.Size = info.Size()
+		}
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name > out[j].Name })
+	return out, nil
+}
+
+func (f *FileProfileStore) Get(ctx context.Context, name string) ([]byte, error) {
+	p, err := f.path(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrProfileNotFound
+	}
+	return data, err
+}
+
+func (f *FileProfileStore) Delete(ctx context.Context, name string) error {
+	p, err := f.path(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
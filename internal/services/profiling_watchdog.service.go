@@ -0,0 +1,229 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime"
+	"runtime/metrics"
+	"runtime/pprof"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// Watchdog triggers, recorded in capture names.
+const (
+	ProfileTriggerCPU        = "cpu"
+	ProfileTriggerHeap       = "heap"
+	ProfileTriggerGoroutines = "goroutines"
+	ProfileTriggerLatency    = "latency"
+)
+
+const processCPUMetric = "process_cpu_seconds_total"
+
+// loadSample holds the cumulative counters the watchdog diffs between ticks.
+type loadSample struct {
+	at           time.Time
+	cpuSeconds   float64 // -1 when the process collector is unavailable
+	latencySum   float64
+	latencyCount uint64
+}
+
+// ProfilingWatchdog captures CPU, heap and goroutine profiles when the
+// process crosses a load or latency threshold, so spikes can be diagnosed
+// without reproducing them.
+type ProfilingWatchdog struct {
+	gatherer prometheus.Gatherer
+	store    ProfileStore
+	cfg      config.ProfilingWatchdogConfig
+	logger   logging.Logger
+
+	lastCapture time.Time
+	last        *loadSample
+}
+
+// NewProfilingWatchdog creates a new profiling watchdog.
+func NewProfilingWatchdog(gatherer prometheus.Gatherer, store ProfileStore, cfg config.ProfilingWatchdogConfig, logger corelogger.Logger) *ProfilingWatchdog {
+	if cfg.Interval <= 0 {
+		cfg.Interval = 15 * time.Second
+	}
+	if cfg.CPUProfileDuration <= 0 {
+		cfg.CPUProfileDuration = 10 * time.Second
+	}
+	return &ProfilingWatchdog{
+		gatherer: gatherer,
+		store:    store,
+		cfg:      cfg,
+		logger:   logging.FromCoreLogger(logger),
+	}
+}
+
+// Captures lists the stored profiles, newest first.
+func (w *ProfilingWatchdog) Captures(ctx context.Context) ([]models.ProfileCapture, error) {
+	return w.store.List(ctx)
+}
+
+// Capture returns the raw pprof data of a stored profile.
+func (w *ProfilingWatchdog) Capture(ctx context.Context, name string) ([]byte, error) {
+	return w.store.Get(ctx, name)
+}
+
+// Start checks the thresholds every Interval until ctx is done.
+func (w *ProfilingWatchdog) Start(ctx context.Context) {
+	if !w.cfg.Enabled {
+		return
+	}
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			trigger, detail := w.check(now)
+			if trigger == "" || now.Sub(w.lastCapture) < w.cfg.Cooldown {
+				continue
+			}
+			w.logger.Warn("Profiling watchdog threshold crossed; capturing profiles", "trigger", trigger, "value", detail)
+			if err := w.capture(ctx, trigger, now); err != nil && ctx.Err() == nil {
+				w.logger.Error("Profile capture failed", "trigger", trigger, "error", err)
+			}
+			w.prune(ctx, time.Now())
+		}
+	}
+}
+
+// check samples the process and returns the first crossed threshold.
+func (w *ProfilingWatchdog) check(now time.Time) (string, string) {
+	cur := w.sample(now)
+	prev := w.last
+	w.last = &cur
+
+	if w.cfg.GoroutineThreshold > 0 {
+		if n := runtime.NumGoroutine(); n >= w.cfg.GoroutineThreshold {
+			return ProfileTriggerGoroutines, fmt.Sprint(n)
+		}
+	}
+	if w.cfg.HeapThresholdMB > 0 {
+		if mb := heapObjectBytes() >> 20; mb >= uint64(w.cfg.HeapThresholdMB) {
+			return ProfileTriggerHeap, fmt.Sprintf("%dMB", mb)
+		}
+	}
+	if prev == nil {
+		return "", ""
+	}
+	if w.cfg.CPUThreshold > 0 && cur.cpuSeconds >= 0 && prev.cpuSeconds >= 0 {
+		if wall := cur.at.Sub(prev.at).Seconds() * float64(runtime.GOMAXPROCS(0)); wall > 0 {
+			if util := (cur.cpuSeconds - prev.cpuSeconds) / wall; util >= w.cfg.CPUThreshold {
+				return ProfileTriggerCPU, fmt.Sprintf("%.2f", util)
+			}
+		}
+	}
+	if w.cfg.LatencyThreshold > 0 && cur.latencyCount > prev.latencyCount {
+		mean := (cur.latencySum - prev.latencySum) / float64(cur.latencyCount-prev.latencyCount)
+		if mean >= w.cfg.LatencyThreshold.Seconds() {
+			return ProfileTriggerLatency, time.Duration(mean * float64(time.Second)).String()
+		}
+	}
+	return "", ""
+}
+
+// sample reads process CPU time and the HTTP latency histogram totals.
+func (w *ProfilingWatchdog) sample(now time.Time) loadSample {
+	s := loadSample{at: now, cpuSeconds: -1}
+	families, err := w.gatherer.Gather()
+	if err != nil {
+		w.logger.Debug("Profiling watchdog could not gather metrics", "error", err)
+	}
+	for _, mf := range families {
+		switch mf.GetName() {
+		case processCPUMetric:
+			if ms := mf.GetMetric(); len(ms) > 0 {
+				s.cpuSeconds = ms[0].GetCounter().GetValue()
+			}
+		case httpRequestDurationMetric:
+			for _, m := range mf.GetMetric() {
+				s.latencySum += m.GetHistogram().GetSampleSum()
+				s.latencyCount += m.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+	return s
+}
+
+func heapObjectBytes() uint64 {
+	sample := []metrics.Sample{{Name: "/memory/classes/heap/objects:bytes"}}
+	metrics.Read(sample)
+	return sample[0].Value.Uint64()
+}
+
+// capture stores a heap, goroutine and CPU profile under one timestamp. The
+// CPU profile is skipped if another one (e.g. /debug/pprof/profile) is
+// already running.
+func (w *ProfilingWatchdog) capture(ctx context.Context, trigger string, now time.Time) error {
+	w.lastCapture = now
+
+	for _, kind := range []string{"heap", "goroutine"} {
+		var buf bytes.Buffer
+		if err := pprof.Lookup(kind).WriteTo(&buf, 0); err != nil {
+			return fmt.Errorf("write %s profile: %w", kind, err)
+		}
+		if err := w.put(ctx, trigger, kind, now, buf.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := pprof.StartCPUProfile(&buf); err != nil {
+		w.logger.Warn("CPU profile skipped", "error", err)
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+	case <-time.After(w.cfg.CPUProfileDuration):
+	}
+	pprof.StopCPUProfile()
+	return w.put(context.WithoutCancel(ctx), trigger, "cpu", now, buf.Bytes())
+}
+
+func (w *ProfilingWatchdog) put(ctx context.Context, trigger, kind string, now time.Time, data []byte) error {
+	capture := models.ProfileCapture{
+		Name:       profileName(now, trigger, kind),
+		Kind:       kind,
+		Trigger:    trigger,
+		Size:       int64(len(data)),
+		CapturedAt: now,
+	}
+	if err := w.store.Put(ctx, capture, data); err != nil {
+		return fmt.Errorf("store %s profile: %w", kind, err)
+	}
+	w.logger.Info("Profile captured", "name", capture.Name, "bytes", capture.Size)
+	return nil
+}
+
+// prune keeps the newest Retention captures (counting each timestamp once)
+// and drops any older than MaxAge.
+func (w *ProfilingWatchdog) prune(ctx context.Context, now time.Time) {
+	captures, err := w.store.List(ctx)
+	if err != nil {
+		w.logger.Warn("Failed to list profiles for retention", "error", err)
+		return
+	}
+	seen := map[time.Time]bool{}
+	for _, c := range captures {
+		seen[c.CapturedAt] = true
+		expired := w.cfg.MaxAge > 0 && now.Sub(c.CapturedAt) > w.cfg.MaxAge
+		if !expired && (w.cfg.Retention <= 0 || len(seen) <= w.cfg.Retention) {
+			continue
+		}
+		if err := w.store.Delete(ctx, c.Name); err != nil {
+			w.logger.Warn("Failed to delete expired profile", "name", c.Name, "error", err)
+		}
+	}
+}
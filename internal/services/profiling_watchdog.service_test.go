@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func TestProfilingWatchdog_Check(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: httpRequestDurationMetric, Help: "duration"}, []string{"method", "endpoint"})
+	reg.MustRegister(duration)
+
+	w := NewProfilingWatchdog(reg, nil, config.ProfilingWatchdogConfig{LatencyThreshold: time.Second}, logger.New("error"))
+	now := time.Now()
+	if trigger, _ := w.check(now); trigger != "" {
+		t.Fatalf("first sample has nothing to compare, got %q", trigger)
+	}
+
+	duration.WithLabelValues("GET", "/a").Observe(0.1)
+	if trigger, _ := w.check(now.Add(time.Second)); trigger != "" {
+		t.Fatalf("fast requests must not trigger, got %q", trigger)
+	}
+
+	duration.WithLabelValues("GET", "/a").Observe(2)
+	duration.WithLabelValues("POST", "/b").Observe(1)
+	if trigger, detail := w.check(now.Add(2 * time.Second)); trigger != ProfileTriggerLatency || detail != "1.5s" {
+		t.Fatalf("expected latency trigger at 1.5s, got %q %q", trigger, detail)
+	}
+
+	w.cfg.GoroutineThreshold = 1
+	if trigger, _ := w.check(now.Add(3 * time.Second)); trigger != ProfileTriggerGoroutines {
+		t.Fatalf("expected goroutine trigger, got %q", trigger)
+	}
+}
+
+func TestProfilingWatchdog_CaptureAndRetention(t *testing.T) {
+	store, err := NewFileProfileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := NewProfilingWatchdog(prometheus.NewRegistry(), store, config.ProfilingWatchdogConfig{
+		CPUProfileDuration: 10 * time.Millisecond,
+		Retention:          2,
+		MaxAge:             24 * time.Hour,
+	}, logger.New("error"))
+	ctx := context.Background()
+
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		if err := w.capture(ctx, ProfileTriggerCPU, base.Add(time.Duration(i)*time.Hour)); err != nil {
+			t.Fatalf("capture: %v", err)
+		}
+	}
+	captures, err := w.Captures(ctx)
+	if err != nil || len(captures) != 9 {
+		t.Fatalf("expected 3 sets of 3 profiles, got %d (%v)", len(captures), err)
+	}
+	if c := captures[0]; c.Trigger != ProfileTriggerCPU || !c.CapturedAt.Equal(base.Add(2*time.Hour)) || c.Size == 0 {
+		t.Fatalf("unexpected newest capture: %+v", c)
+	}
+	if data, err := w.Capture(ctx, captures[0].Name); err != nil || len(data) == 0 {
+		t.Fatalf("expected profile data, got %d bytes (%v)", len(data), err)
+	}
+	if _, err := w.Capture(ctx, "../config.yaml"); !errors.Is(err, ErrProfileNotFound) {
+		t.Fatalf("expected ErrProfileNotFound for a path outside the store, got %v", err)
+	}
+
+	w.prune(ctx, base.Add(3*time.Hour))
+	if captures, _ = w.Captures(ctx); len(captures) != 6 {
+		t.Fatalf("expected retention to keep 2 sets, got %d profiles", len(captures))
+	}
+	w.prune(ctx, base.Add(25*time.Hour+30*time.Minute))
+	if captures, _ = w.Captures(ctx); len(captures) != 3 || !captures[0].CapturedAt.Equal(base.Add(2*time.Hour)) {
+		t.Fatalf("expected max_age to drop the older set, got %+v", captures)
+	}
+}
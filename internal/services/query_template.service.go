@@ -0,0 +1,535 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const (
+	// Logs template executions default to the last hour and 1000 rows.
+	defaultTemplateLogsRange = time.Hour
+	defaultTemplateLogsLimit = 1000
+	maxTemplateLogsLimit     = 10000
+	// defaultTemplatePoints sizes the default step of metrics range queries.
+	defaultTemplatePoints = 200
+)
+
+var (
+	ErrInvalidQueryTemplate         = errors.New("invalid query template")
+	ErrInvalidQueryParameters       = errors.New("invalid query template parameters")
+	ErrQueryTemplateNotFound        = errors.New("query template not found")
+	ErrQueryTemplateNotApproved     = errors.New("query template not approved")
+	ErrQueryTemplateReviewForbidden = errors.New("query template review not allowed")
+	ErrQueryTemplateConflict        = errors.New("query template changed")
+)
+
+var (
+	queryTemplateEngines    = []string{models.QueryTemplateMetrics, models.QueryTemplateLogs}
+	queryTemplateParamTypes = []string{models.QueryParamString, models.QueryParamInt, models.QueryParamFloat, models.QueryParamDuration, models.QueryParamEnum}
+	queryTemplatePlaceRe    = regexp.MustCompile(`\$\{([^}]*)\}`)
+	queryParamNameRe        = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,62}$`)
+	// Enum values are substituted unquoted, so they are limited to
+	// identifiers, numbers and durations.
+	queryEnumValueRe = regexp.MustCompile(`^[A-Za-z0-9_.:-]{1,128}$`)
+)
+
+// QueryTemplateMetricsBackend runs rendered MetricsQL templates.
+type QueryTemplateMetricsBackend interface {
+	ExecuteQuery(ctx context.Context, req *models.MetricsQLQueryRequest) (*models.MetricsQLQueryResult, error)
+	ExecuteRangeQuery(ctx context.Context, req *models.MetricsQLRangeQueryRequest) (*models.MetricsQLRangeQueryResult, error)
+}
+
+// QueryTemplateLogsBackend runs rendered LogsQL templates.
+type QueryTemplateLogsBackend interface {
+	ExecuteQuery(ctx context.Context, req *models.LogsQLQueryRequest) (*models.LogsQLQueryResult, error)
+}
+
+// QueryTemplateService manages parameterized MetricsQL/LogsQL templates and
+// runs the approved ones. Every edit sends a template back to review, and a
+// template is approved by someone other than its last editor, so restricted
+// tenants only ever run queries two people have seen. Parameter values are
+// type-checked and bounded before substitution; string values are quoted,
+// so a caller cannot change the shape of the query. Templates are stored as
+// the query_templates dynamic config document, so edits and reviews are
+// locked across replicas, versioned and can be rolled back.
+type QueryTemplateService struct {
+	config  *DynamicConfigService
+	metrics QueryTemplateMetricsBackend
+	logs    QueryTemplateLogsBackend
+	cfg     config.QueryTemplatesConfig
+	logger  logging.Logger
+}
+
+// NewQueryTemplateService creates a new query template service. Either
+// backend may be nil when not configured.
+func NewQueryTemplateService(cache cache.ValkeyCluster, metrics QueryTemplateMetricsBackend, logs QueryTemplateLogsBackend, cfg config.QueryTemplatesConfig, logger corelogger.Logger) *QueryTemplateService {
+	return &QueryTemplateService{
+		config:  NewDynamicConfigService(cache, logger),
+		metrics: metrics,
+		logs:    logs,
+		cfg:     cfg,
+		logger:  logging.FromCoreLogger(logger),
+	}
+}
+
+// List returns the stored templates ordered by name.
+func (s *QueryTemplateService) List(ctx context.Context) ([]models.QueryTemplate, error) {
+	list := []models.QueryTemplate{}
+	if err := s.config.getDocument(ctx, DynamicConfigQueryTemplates, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Get returns the template called name.
+func (s *QueryTemplateService) Get(ctx context.Context, name string) (*models.QueryTemplate, error) {
+	list, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range list {
+		if list[i].Name == name {
+			return &list[i], nil
+		}
+	}
+	return nil, ErrQueryTemplateNotFound
+}
+
+// Put creates or replaces a template. The stored template is pending review
+// with its version bumped, whatever its previous state.
+func (s *QueryTemplateService) Put(ctx context.Context, t models.QueryTemplate) (*models.QueryTemplate, error) {
+	if err := validateQueryTemplate(t); err != nil {
+		return nil, err
+	}
+	t.Status = models.QueryTemplatePending
+	t.UpdatedAt = time.Now().UTC()
+	t.ReviewedBy, t.ReviewedAt, t.ReviewComment = "", nil, ""
+	var list []models.QueryTemplate
+	if _, err := s.config.updateDocument(ctx, DynamicConfigQueryTemplates, t.UpdatedBy, &list, func() error {
+		t.Version = 1
+		for _, o := range list {
+			if o.Name == t.Name {
+				t.Version = o.Version + 1
+			}
+		}
+		list = append(slices.DeleteFunc(list, func(o models.QueryTemplate) bool { return o.Name == t.Name }), t)
+		sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	s.logger.Info("Query template stored", "name", t.Name, "version", t.Version, "updated_by", t.UpdatedBy)
+	return &t, nil
+}
+
+// Delete removes a template.
+func (s *QueryTemplateService) Delete(ctx context.Context, name, deletedBy string) error {
+	var list []models.QueryTemplate
+	_, err := s.config.updateDocument(ctx, DynamicConfigQueryTemplates, deletedBy, &list, func() error {
+		kept := slices.DeleteFunc(slices.Clone(list), func(o models.QueryTemplate) bool { return o.Name == name })
+		if len(kept) == len(list) {
+			return ErrQueryTemplateNotFound
+		}
+		list = kept
+		return nil
+	})
+	return err
+}
+
+// Approve approves a pending template, making it executable.
+func (s *QueryTemplateService) Approve(ctx context.Context, name string, review models.QueryTemplateReview) (*models.QueryTemplate, error) {
+	return s.review(ctx, name, review, models.QueryTemplateApproved)
+}
+
+// Reject rejects a pending template or revokes the approval of an approved
+// one. A rejected template runs again only after an edit and a new review.
+func (s *QueryTemplateService) Reject(ctx context.Context, name string, review models.QueryTemplateReview) (*models.QueryTemplate, error) {
+	return s.review(ctx, name, review, models.QueryTemplateRejected)
+}
+
+func (s *QueryTemplateService) review(ctx context.Context, name string, review models.QueryTemplateReview, status string) (*models.QueryTemplate, error) {
+	var (
+		list []models.QueryTemplate
+		t    *models.QueryTemplate
+	)
+	if _, err := s.config.updateDocument(ctx, DynamicConfigQueryTemplates, review.Reviewer, &list, func() error {
+		i := slices.IndexFunc(list, func(o models.QueryTemplate) bool { return o.Name == name })
+		if i < 0 {
+			return ErrQueryTemplateNotFound
+		}
+		t = &list[i]
+		switch {
+		case review.Reviewer == "":
+			return fmt.Errorf("%w: the reviewer must be identified", ErrQueryTemplateReviewForbidden)
+		case review.Reviewer == t.UpdatedBy:
+			return fmt.Errorf("%w: %s cannot review their own change", ErrQueryTemplateReviewForbidden, review.Reviewer)
+		case review.Version != 0 && review.Version != t.Version:
+			return fmt.Errorf("%w: reviewing version %d but the template is at version %d", ErrQueryTemplateConflict, review.Version, t.Version)
+		case t.Status == status || t.Status == models.QueryTemplateRejected:
+			return fmt.Errorf("%w: version %d is already %s", ErrQueryTemplateConflict, t.Version, t.Status)
+		}
+		now := time.Now().UTC()
+		t.Status = status
+		t.ReviewedBy = review.Reviewer
+		t.ReviewedAt = &now
+		t.ReviewComment = review.Comment
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	s.logger.Info("Query template reviewed", "name", t.Name, "version", t.Version, "status", status, "reviewed_by", review.Reviewer)
+	out := *t
+	return &out, nil
+}
+
+// Execute renders an approved template with the given parameters and runs
+// it against its engine.
+func (s *QueryTemplateService) Execute(ctx context.Context, name string, req models.QueryTemplateExecuteRequest) (*models.QueryTemplateResult, error) {
+	t, err := s.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if t.Status != models.QueryTemplateApproved {
+		return nil, fmt.Errorf("%w: %s version %d is %s", ErrQueryTemplateNotApproved, t.Name, t.Version, t.Status)
+	}
+	query, err := RenderQueryTemplate(*t, req.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	end := time.Now().UTC()
+	if req.End != nil {
+		end = *req.End
+	}
+	start := req.Start
+	if start == nil && t.Engine == models.QueryTemplateLogs {
+		st := end.Add(-defaultTemplateLogsRange)
+		start = &st
+	}
+	if start != nil {
+		if !start.Before(end) {
+			return nil, fmt.Errorf("%w: start must be before end", ErrInvalidQueryParameters)
+		}
+		if s.cfg.MaxRange > 0 && end.Sub(*start) > s.cfg.MaxRange {
+			return nil, fmt.Errorf("%w: time range %s exceeds the maximum of %s", ErrInvalidQueryParameters, end.Sub(*start), s.cfg.MaxRange)
+		}
+	}
+
+	result := &models.QueryTemplateResult{Template: t.Name, Version: t.Version, Engine: t.Engine, Query: query}
+	switch t.Engine {
+	case models.QueryTemplateMetrics:
+		result.Data, err = s.executeMetrics(ctx, query, start, end, req.Step)
+	case models.QueryTemplateLogs:
+		result.Data, err = s.executeLogs(ctx, query, t.Columns, *start, end, req.Limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("Query template executed", "name", t.Name, "version", t.Version, "engine", t.Engine)
+	return result, nil
+}
+
+func (s *QueryTemplateService) executeMetrics(ctx context.Context, query string, start *time.Time, end time.Time, step string) (any, error) {
+	if s.metrics == nil {
+		return nil, errors.New("metrics backend not configured")
+	}
+	if start == nil {
+		if step != "" {
+			return nil, fmt.Errorf("%w: step requires start", ErrInvalidQueryParameters)
+		}
+		return s.metrics.ExecuteQuery(ctx, &models.MetricsQLQueryRequest{Query: query, Time: end.Format(time.RFC3339)})
+	}
+	d := max((end.Sub(*start) / defaultTemplatePoints).Truncate(time.Second), time.Second)
+	if step != "" {
+		var err error
+		if d, err = time.ParseDuration(step); err != nil || d <= 0 {
+			return nil, fmt.Errorf("%w: step %q must be a positive duration", ErrInvalidQueryParameters, step)
+		}
+	}
+	return s.metrics.ExecuteRangeQuery(ctx, &models.MetricsQLRangeQueryRequest{
+		Query: query,
+		Start: start.Format(time.RFC3339),
+		End:   end.Format(time.RFC3339),
+		Step:  metricsQLDuration(d),
+	})
+}
+
+func (s *QueryTemplateService) executeLogs(ctx context.Context, query string, columns []string, start, end time.Time, limit int) (any, error) {
+	if s.logs == nil {
+		return nil, errors.New("logs backend not configured")
+	}
+	if limit == 0 {
+		limit = defaultTemplateLogsLimit
+	}
+	if limit < 0 || limit > maxTemplateLogsLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidQueryParameters, maxTemplateLogsLimit)
+	}
+	return s.logs.ExecuteQuery(ctx, &models.LogsQLQueryRequest{
+		Query:   query,
+		Start:   start.UnixMilli(),
+		End:     end.UnixMilli(),
+		Limit:   limit,
+		Columns: columns,
+	})
+}
+
+// RenderQueryTemplate substitutes the ${name} placeholders of t with the
+// checked and formatted parameter values, falling back to defaults.
+// Parameters the template does not declare are rejected.
+func RenderQueryTemplate(t models.QueryTemplate, values map[string]any) (string, error) {
+	var problems []string
+	for name := range values {
+		if !slices.ContainsFunc(t.Parameters, func(p models.QueryTemplateParameter) bool { return p.Name == name }) {
+			problems = append(problems, fmt.Sprintf("unknown parameter %q", name))
+		}
+	}
+	rendered := make(map[string]string, len(t.Parameters))
+	for _, p := range t.Parameters {
+		v, ok := values[p.Name]
+		if !ok || v == nil {
+			if p.Default == "" {
+				problems = append(problems, fmt.Sprintf("parameter %q is required", p.Name))
+				continue
+			}
+			v = p.Default
+		}
+		out, err := renderQueryParam(p, v)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("parameter %q: %v", p.Name, err))
+			continue
+		}
+		rendered[p.Name] = out
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return "", fmt.Errorf("%w: %s", ErrInvalidQueryParameters, strings.Join(problems, "; "))
+	}
+	return queryTemplatePlaceRe.ReplaceAllStringFunc(t.Query, func(m string) string {
+		return rendered[m[2:len(m)-1]]
+	}), nil
+}
+
+// renderQueryParam checks v against the declaration of p and formats it for
+// substitution. JSON numbers arrive as float64; strings are accepted for
+// every type so defaults and query-string values share one path.
+func renderQueryParam(p models.QueryTemplateParameter, v any) (string, error) {
+	switch p.Type {
+	case models.QueryParamString:
+		s, ok := v.(string)
+		if !ok {
+			return "", errors.New("must be a string")
+		}
+		if p.MaxLength > 0 && len([]rune(s)) > p.MaxLength {
+			return "", fmt.Errorf("longer than %d characters", p.MaxLength)
+		}
+		if p.Pattern != "" {
+			re, err := regexp.Compile(`^(?:` + p.Pattern + `)$`)
+			if err != nil || !re.MatchString(s) {
+				return "", fmt.Errorf("does not match %q", p.Pattern)
+			}
+		}
+		return strconv.Quote(s), nil
+
+	case models.QueryParamEnum:
+		s, ok := v.(string)
+		if !ok || !slices.Contains(p.Values, s) {
+			return "", fmt.Errorf("must be one of %s", strings.Join(p.Values, ", "))
+		}
+		return s, nil
+
+	case models.QueryParamInt:
+		n, err := queryParamFloat(v)
+		if err != nil || n != math.Trunc(n) || math.Abs(n) > 1<<53 {
+			return "", errors.New("must be an integer")
+		}
+		if err := checkQueryParamBounds(p, n, func(s string) (float64, error) {
+			i, err := strconv.ParseInt(s, 10, 64)
+			return float64(i), err
+		}); err != nil {
+			return "", err
+		}
+		return strconv.FormatInt(int64(n), 10), nil
+
+	case models.QueryParamFloat:
+		n, err := queryParamFloat(v)
+		if err != nil {
+			return "", errors.New("must be a number")
+		}
+		if err := checkQueryParamBounds(p, n, func(s string) (float64, error) { return strconv.ParseFloat(s, 64) }); err != nil {
+			return "", err
+		}
+		return strconv.FormatFloat(n, 'g', -1, 64), nil
+
+	case models.QueryParamDuration:
+		s, ok := v.(string)
+		d, err := time.ParseDuration(s)
+		if !ok || err != nil || d < time.Millisecond {
+			return "", errors.New("must be a duration of at least 1ms")
+		}
+		if err := checkQueryParamBounds(p, float64(d), func(s string) (float64, error) {
+			d, err := time.ParseDuration(s)
+			return float64(d), err
+		}); err != nil {
+			return "", err
+		}
+		return metricsQLDuration(d), nil
+	}
+	return "", fmt.Errorf("unsupported type %q", p.Type)
+}
+
+func queryParamFloat(v any) (float64, error) {
+	var n float64
+	switch x := v.(type) {
+	case float64:
+		n = x
+	case int:
+		n = float64(x)
+	case string:
+		var err error
+		if n, err = strconv.ParseFloat(x, 64); err != nil {
+			return 0, err
+		}
+	default:
+		return 0, fmt.Errorf("unexpected %T", v)
+	}
+	if math.IsNaN(n) || math.IsInf(n, 0) {
+		return 0, errors.New("not finite")
+	}
+	return n, nil
+}
+
+// checkQueryParamBounds checks n against p.Min and p.Max, parsed with parse.
+func checkQueryParamBounds(p models.QueryTemplateParameter, n float64, parse func(string) (float64, error)) error {
+	if p.Min != "" {
+		if lo, err := parse(p.Min); err == nil && n < lo {
+			return fmt.Errorf("must be at least %s", p.Min)
+		}
+	}
+	if p.Max != "" {
+		if hi, err := parse(p.Max); err == nil && n > hi {
+			return fmt.Errorf("must be at most %s", p.Max)
+		}
+	}
+	return nil
+}
+
+// metricsQLDuration formats d in the duration syntax shared by MetricsQL and
+// LogsQL, which has no compound units like Go's "1m30s".
+func metricsQLDuration(d time.Duration) string {
+	if d%time.Second == 0 {
+		return strconv.FormatInt(int64(d/time.Second), 10) + "s"
+	}
+	return strconv.FormatInt(d.Milliseconds(), 10) + "ms"
+}
+
+func validateQueryTemplate(t models.QueryTemplate) error {
+	var problems []string
+	if !anonymizationNameRe.MatchString(t.Name) {
+		problems = append(problems, fmt.Sprintf("name %q must be lowercase letters, digits, '-' or '_'", t.Name))
+	}
+	if !slices.Contains(queryTemplateEngines, t.Engine) {
+		problems = append(problems, fmt.Sprintf("engine %q must be one of %s", t.Engine, strings.Join(queryTemplateEngines, ", ")))
+	}
+	if strings.TrimSpace(t.Query) == "" {
+		problems = append(problems, "query is required")
+	}
+	if len(t.Columns) > 0 && t.Engine != models.QueryTemplateLogs {
+		problems = append(problems, "columns only apply to logs templates")
+	}
+	for i, c := range t.Columns {
+		if !computedColumnNameRe.MatchString(c) {
+			problems = append(problems, fmt.Sprintf("columns[%d]: %q is not a computed column name", i, c))
+		}
+	}
+
+	declared := map[string]bool{}
+	for i, p := range t.Parameters {
+		if !queryParamNameRe.MatchString(p.Name) {
+			problems = append(problems, fmt.Sprintf("parameters[%d]: name %q must be a letter or '_' followed by letters, digits or '_'", i, p.Name))
+		} else if _, dup := declared[p.Name]; dup {
+			problems = append(problems, fmt.Sprintf("parameters[%d]: %s is declared twice", i, p.Name))
+		}
+		declared[p.Name] = false
+		if !slices.Contains(queryTemplateParamTypes, p.Type) {
+			problems = append(problems, fmt.Sprintf("parameters[%d]: type %q must be one of %s", i, p.Type, strings.Join(queryTemplateParamTypes, ", ")))
+			continue
+		}
+		problems = append(problems, validateQueryParam(i, p)...)
+	}
+
+	for _, m := range queryTemplatePlaceRe.FindAllStringSubmatch(t.Query, -1) {
+		if _, ok := declared[m[1]]; !ok {
+			problems = append(problems, fmt.Sprintf("placeholder ${%s} has no declared parameter", m[1]))
+			continue
+		}
+		declared[m[1]] = true
+	}
+	for _, p := range t.Parameters {
+		if used, ok := declared[p.Name]; ok && !used {
+			problems = append(problems, fmt.Sprintf("parameter %s is not used in the query", p.Name))
+			declared[p.Name] = true
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidQueryTemplate, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// validateQueryParam checks the type-specific settings of one parameter.
+func validateQueryParam(i int, p models.QueryTemplateParameter) []string {
+	var problems []string
+	bounded := p.Type == models.QueryParamInt || p.Type == models.QueryParamFloat || p.Type == models.QueryParamDuration
+	if !bounded && (p.Min != "" || p.Max != "") {
+		problems = append(problems, fmt.Sprintf("parameters[%d]: min and max only apply to int, float and duration", i))
+	}
+	for _, b := range []string{p.Min, p.Max} {
+		if b == "" || !bounded {
+			continue
+		}
+		if _, err := renderQueryParam(models.QueryTemplateParameter{Type: p.Type}, b); err != nil {
+			problems = append(problems, fmt.Sprintf("parameters[%d]: bound %q %v", i, b, err))
+		}
+	}
+	if p.Type != models.QueryParamString && (p.MaxLength != 0 || p.Pattern != "") {
+		problems = append(problems, fmt.Sprintf("parameters[%d]: maxLength and pattern only apply to string", i))
+	}
+	if p.Pattern != "" {
+		if _, err := regexp.Compile(p.Pattern); err != nil {
+			problems = append(problems, fmt.Sprintf("parameters[%d]: pattern %q is not a valid regular expression", i, p.Pattern))
+		}
+	}
+	if p.Type == models.QueryParamEnum {
+		if len(p.Values) == 0 {
+			problems = append(problems, fmt.Sprintf("parameters[%d]: enum requires values", i))
+		}
+		for _, v := range p.Values {
+			if !queryEnumValueRe.MatchString(v) {
+				problems = append(problems, fmt.Sprintf("parameters[%d]: enum value %q must be letters, digits, '_', '.', ':' or '-'", i, v))
+			}
+		}
+	} else if len(p.Values) > 0 {
+		problems = append(problems, fmt.Sprintf("parameters[%d]: values only apply to enum", i))
+	}
+	if p.Default != "" && len(problems) == 0 {
+		if _, err := renderQueryParam(p, p.Default); err != nil {
+			problems = append(problems, fmt.Sprintf("parameters[%d]: default %q %v", i, p.Default, err))
+		}
+	}
+	return problems
+}
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+type fakeTemplateMetrics struct {
+	instant *models.MetricsQLQueryRequest
+	ranged  *models.MetricsQLRangeQueryRequest
+}
+
+func (f *fakeTemplateMetrics) ExecuteQuery(ctx context.Context, req *models.MetricsQLQueryRequest) (*models.MetricsQLQueryResult, error) {
+	f.instant = req
+	return &models.MetricsQLQueryResult{Status: "success"}, nil
+}
+
+func (f *fakeTemplateMetrics) ExecuteRangeQuery(ctx context.Context, req *models.MetricsQLRangeQueryRequest) (*models.MetricsQLRangeQueryResult, error) {
+	f.ranged = req
+	return &models.MetricsQLRangeQueryResult{Status: "success"}, nil
+}
+
+type fakeTemplateLogs struct{ req *models.LogsQLQueryRequest }
+
+func (f *fakeTemplateLogs) ExecuteQuery(ctx context.Context, req *models.LogsQLQueryRequest) (*models.LogsQLQueryResult, error) {
+	f.req = req
+	return &models.LogsQLQueryResult{}, nil
+}
+
+func errorRateTemplate() models.QueryTemplate {
+	return models.QueryTemplate{
+		Name:   "error-rate",
+		Engine: models.QueryTemplateMetrics,
+		Query:  `sum(rate(http_requests_total{service=${service},code=~"5.."}[${window}])) by (${by}) > ${threshold}`,
+		Parameters: []models.QueryTemplateParameter{
+			{Name: "service", Type: models.QueryParamString, MaxLength: 32, Pattern: `[a-z0-9-]+`},
+			{Name: "window", Type: models.QueryParamDuration, Default: "5m", Min: "1m", Max: "1h"},
+			{Name: "by", Type: models.QueryParamEnum, Values: []string{"instance", "pod"}, Default: "pod"},
+			{Name: "threshold", Type: models.QueryParamFloat, Default: "0", Min: "0"},
+		},
+		UpdatedBy: "alice",
+	}
+}
+
+func TestQueryTemplateService_ReviewWorkflow(t *testing.T) {
+	ctx := context.Background()
+	metrics := &fakeTemplateMetrics{}
+	svc := NewQueryTemplateService(cache.NewNoopValkeyCache(logger.New("error")), metrics, nil,
+		config.QueryTemplatesConfig{MaxRange: 24 * time.Hour}, logger.New("error"))
+
+	stored, err := svc.Put(ctx, errorRateTemplate())
+	require.NoError(t, err)
+	assert.Equal(t, models.QueryTemplatePending, stored.Status)
+	assert.Equal(t, 1, stored.Version)
+
+	exec := models.QueryTemplateExecuteRequest{Parameters: map[string]any{"service": "checkout"}}
+	_, err = svc.Execute(ctx, "error-rate", exec)
+	assert.ErrorIs(t, err, ErrQueryTemplateNotApproved)
+
+	_, err = svc.Approve(ctx, "error-rate", models.QueryTemplateReview{Reviewer: "alice"})
+	assert.ErrorIs(t, err, ErrQueryTemplateReviewForbidden, "editors cannot approve their own change")
+	_, err = svc.Approve(ctx, "error-rate", models.QueryTemplateReview{Reviewer: "bob", Version: 2})
+	assert.ErrorIs(t, err, ErrQueryTemplateConflict)
+	approved, err := svc.Approve(ctx, "error-rate", models.QueryTemplateReview{Reviewer: "bob", Version: 1, Comment: "lgtm"})
+	require.NoError(t, err)
+	assert.Equal(t, models.QueryTemplateApproved, approved.Status)
+	assert.Equal(t, "bob", approved.ReviewedBy)
+
+	res, err := svc.Execute(ctx, "error-rate", exec)
+	require.NoError(t, err)
+	assert.Equal(t, `sum(rate(http_requests_total{service="checkout",code=~"5.."}[300s])) by (pod) > 0`, res.Query)
+	require.NotNil(t, metrics.instant)
+	assert.Equal(t, res.Query, metrics.instant.Query)
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+	_, err = svc.Execute(ctx, "error-rate", models.QueryTemplateExecuteRequest{
+		Parameters: map[string]any{"service": "checkout", "window": "90s", "by": "instance", "threshold": 0.5},
+		Start:      &start,
+		End:        &end,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, metrics.ranged)
+	assert.Contains(t, metrics.ranged.Query, "[90s])) by (instance) > 0.5")
+	assert.Equal(t, "36s", metrics.ranged.Step)
+
+	long := end.Add(-48 * time.Hour)
+	_, err = svc.Execute(ctx, "error-rate", models.QueryTemplateExecuteRequest{Parameters: exec.Parameters, Start: &long, End: &end})
+	assert.ErrorIs(t, err, ErrInvalidQueryParameters)
+
+	// Any edit needs a new review.
+	edited := errorRateTemplate()
+	edited.UpdatedBy = "bob"
+	stored, err = svc.Put(ctx, edited)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stored.Version)
+	assert.Empty(t, stored.ReviewedBy)
+	_, err = svc.Execute(ctx, "error-rate", exec)
+	assert.ErrorIs(t, err, ErrQueryTemplateNotApproved)
+
+	_, err = svc.Reject(ctx, "error-rate", models.QueryTemplateReview{Reviewer: "carol"})
+	require.NoError(t, err)
+	_, err = svc.Approve(ctx, "error-rate", models.QueryTemplateReview{Reviewer: "carol"})
+	assert.ErrorIs(t, err, ErrQueryTemplateConflict, "rejected templates need an edit first")
+
+	// Edits and reviews are versioned, with the editor or reviewer as author.
+	versions, err := svc.config.ListVersions(ctx, DynamicConfigQueryTemplates)
+	require.NoError(t, err)
+	require.Len(t, versions, 4)
+	assert.Equal(t, "carol", versions[0].Author)
+	require.NoError(t, svc.Delete(ctx, "error-rate", "carol"))
+	assert.ErrorIs(t, svc.Delete(ctx, "error-rate", "carol"), ErrQueryTemplateNotFound)
+}
+
+func TestRenderQueryTemplate_Parameters(t *testing.T) {
+	tpl := errorRateTemplate()
+	cases := map[string]map[string]any{
+		"missing required": {},
+		"injection":        {"service": `x"} or vector(1) #`},
+		"too long":         {"service": "a-very-long-service-name-exceeding-limit"},
+		"below min":        {"service": "api", "window": "10s"},
+		"above max":        {"service": "api", "window": "2h"},
+		"not in enum":      {"service": "api", "by": "node"},
+		"wrong type":       {"service": 42.0},
+		"negative":         {"service": "api", "threshold": -1.0},
+		"unknown":          {"service": "api", "tenant": "other"},
+	}
+	for name, params := range cases {
+		_, err := RenderQueryTemplate(tpl, params)
+		assert.ErrorIs(t, err, ErrInvalidQueryParameters, name)
+	}
+
+	// Quoting keeps string values inside the label matcher.
+	tpl.Parameters[0].Pattern = ""
+	q, err := RenderQueryTemplate(tpl, map[string]any{"service": `x"} or vector(1) #`})
+	require.NoError(t, err)
+	assert.Contains(t, q, `service="x\"} or vector(1) #"`)
+}
+
+func TestQueryTemplateService_Validation(t *testing.T) {
+	ctx := context.Background()
+	logs := &fakeTemplateLogs{}
+	svc := NewQueryTemplateService(cache.NewNoopValkeyCache(logger.New("error")), nil, logs, config.QueryTemplatesConfig{}, logger.New("error"))
+
+	bad := []models.QueryTemplate{
+		{Name: "Bad Name", Engine: models.QueryTemplateLogs, Query: "error"},
+		{Name: "engine", Engine: "sql", Query: "select 1"},
+		{Name: "undeclared", Engine: models.QueryTemplateLogs, Query: "service:${service}"},
+		{Name: "unused", Engine: models.QueryTemplateLogs, Query: "error",
+			Parameters: []models.QueryTemplateParameter{{Name: "service", Type: models.QueryParamString}}},
+		{Name: "enum-values", Engine: models.QueryTemplateLogs, Query: "${field}:error",
+			Parameters: []models.QueryTemplateParameter{{Name: "field", Type: models.QueryParamEnum, Values: []string{"msg or *"}}}},
+		{Name: "bounds", Engine: models.QueryTemplateLogs, Query: "error | limit ${n}",
+			Parameters: []models.QueryTemplateParameter{{Name: "n", Type: models.QueryParamInt, Min: "1.5", Default: "1"}}},
+	}
+	for _, tpl := range bad {
+		_, err := svc.Put(ctx, tpl)
+		assert.ErrorIs(t, err, ErrInvalidQueryTemplate, tpl.Name)
+	}
+
+	_, err := svc.Put(ctx, models.QueryTemplate{
+		Name:       "service-errors",
+		Engine:     models.QueryTemplateLogs,
+		Query:      "service:${service} level:error",
+		Parameters: []models.QueryTemplateParameter{{Name: "service", Type: models.QueryParamString}},
+		UpdatedBy:  "alice",
+	})
+	require.NoError(t, err)
+	_, err = svc.Approve(ctx, "service-errors", models.QueryTemplateReview{Reviewer: "bob"})
+	require.NoError(t, err)
+
+	res, err := svc.Execute(ctx, "service-errors", models.QueryTemplateExecuteRequest{Parameters: map[string]any{"service": "api"}})
+	require.NoError(t, err)
+	assert.Equal(t, `service:"api" level:error`, res.Query)
+	require.NotNil(t, logs.req)
+	assert.Equal(t, 1000, logs.req.Limit)
+	assert.Equal(t, time.Hour.Milliseconds(), logs.req.End-logs.req.Start)
+
+	_, err = svc.Execute(ctx, "service-errors", models.QueryTemplateExecuteRequest{Parameters: map[string]any{"service": "api"}, Limit: 50000})
+	assert.ErrorIs(t, err, ErrInvalidQueryParameters)
+	_, err = svc.Execute(ctx, "missing", models.QueryTemplateExecuteRequest{})
+	assert.ErrorIs(t, err, ErrQueryTemplateNotFound)
+}
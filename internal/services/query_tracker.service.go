@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/metrics"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const (
+	// In-flight queries are stored under inFlightQueryKeyPrefix plus the ID;
+	// a cancel request under queryCancelKeyPrefix plus the ID, so any
+	// replica can cancel a query another one serves.
+	inFlightQueryKeyPrefix = "query_inflight:"
+	queryCancelKeyPrefix   = "query_cancel:"
+	// inFlightQueryTTL bounds how long a record outlives a replica that
+	// died while serving the query.
+	inFlightQueryTTL = time.Hour
+
+	// queryCancelPollInterval is how often a running query checks for a
+	// cancel request made on another replica.
+	queryCancelPollInterval = time.Second
+)
+
+// Reasons a query was cancelled, as counted by QueriesCancelledTotal.
+const (
+	QueryCancelledByAPI        = "api"
+	QueryCancelledByDisconnect = "disconnect"
+)
+
+var (
+	ErrQueryNotFound = errors.New("query not found")
+	// ErrQueryUserRequired is returned when listing or cancelling queries
+	// without a user: queries are owned by their caller's user ID.
+	ErrQueryUserRequired = errors.New("a user ID (X-User-ID) is required to list or cancel queries")
+	// ErrQueryCancelled is the cancellation cause of queries cancelled
+	// through Cancel.
+	ErrQueryCancelled = errors.New("query cancelled")
+)
+
+// QueryTrackerService tracks the query requests being served so their
+// callers can list and cancel them. Cancelling a query cancels its request
+// context, which stops the backend requests it issues. Queries also end
+// when their caller disconnects, as the HTTP server cancels the request
+// context then.
+type QueryTrackerService struct {
+	cache  cache.ValkeyCluster
+	logger logging.Logger
+
+	mu      sync.Mutex
+	cancels map[string]context.CancelCauseFunc
+}
+
+// NewQueryTrackerService creates a new query tracker.
+func NewQueryTrackerService(cache cache.ValkeyCluster, logger corelogger.Logger) *QueryTrackerService {
+	return &QueryTrackerService{
+		cache:   cache,
+		logger:  logging.FromCoreLogger(logger),
+		cancels: map[string]context.CancelCauseFunc{},
+	}
+}
+
+// Start records a query of user and returns the context to serve it with.
+// The caller must call done once the query has been served. Queries without
+// a user must not be tracked: they could not be told apart from other
+// anonymous callers' queries.
+func (s *QueryTrackerService) Start(ctx context.Context, user, method, path string) (context.Context, *models.InFlightQuery, func()) {
+	q := &models.InFlightQuery{
+		ID:        uuid.NewString(),
+		User:      user,
+		Method:    method,
+		Path:      path,
+		StartedAt: time.Now().UTC(),
+	}
+	if data, err := json.Marshal(q); err == nil {
+		if err := s.cache.Set(ctx, inFlightQueryKeyPrefix+q.ID, data, inFlightQueryTTL); err != nil {
+			s.logger.Warn("Failed to store in-flight query", "id", q.ID, "error", err)
+		}
+	}
+
+	queryCtx, cancel := context.WithCancelCause(ctx)
+	s.mu.Lock()
+	s.cancels[q.ID] = cancel
+	s.mu.Unlock()
+
+	stop := make(chan struct{})
+	go s.watch(queryCtx, q.ID, cancel, stop)
+
+	done := func() {
+		close(stop)
+		switch {
+		case errors.Is(context.Cause(queryCtx), ErrQueryCancelled):
+			metrics.QueriesCancelledTotal.WithLabelValues(QueryCancelledByAPI).Inc()
+		case ctx.Err() != nil:
+			metrics.QueriesCancelledTotal.WithLabelValues(QueryCancelledByDisconnect).Inc()
+		}
+		cancel(nil)
+		s.mu.Lock()
+		delete(s.cancels, q.ID)
+		s.mu.Unlock()
+
+		cleanup := context.WithoutCancel(ctx)
+		for _, key := range []string{inFlightQueryKeyPrefix + q.ID, queryCancelKeyPrefix + q.ID} {
+			if err := s.cache.Delete(cleanup, key); err != nil {
+				s.logger.Warn("Failed to remove in-flight query", "id", q.ID, "error", err)
+			}
+		}
+	}
+	return queryCtx, q, done
+}
+
+// watch cancels the query once a cancel request shows up in Valkey.
+func (s *QueryTrackerService) watch(ctx context.Context, id string, cancel context.CancelCauseFunc, stop <-chan struct{}) {
+	ticker := time.NewTicker(queryCancelPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if s.cancelRequested(ctx, id) {
+				cancel(ErrQueryCancelled)
+				return
+			}
+		}
+	}
+}
+
+// List returns the in-flight queries of user, oldest first.
+func (s *QueryTrackerService) List(ctx context.Context, user string) ([]models.InFlightQuery, error) {
+	if user == "" {
+		return nil, ErrQueryUserRequired
+	}
+	var keys []string
+	if err := s.cache.ScanKeys(ctx, inFlightQueryKeyPrefix, func(batch []string) error {
+		keys = append(keys, batch...)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("list in-flight queries: %w", err)
+	}
+	queries := make([]models.InFlightQuery, 0, len(keys))
+	for _, key := range keys {
+		if q, err := s.get(ctx, strings.TrimPrefix(key, inFlightQueryKeyPrefix)); err == nil && q.User == user {
+			queries = append(queries, *q)
+		}
+	}
+	sort.Slice(queries, func(i, j int) bool { return queries[i].StartedAt.Before(queries[j].StartedAt) })
+	return queries, nil
+}
+
+// Cancel stops an in-flight query of user. Queries of other users are
+// reported as not found.
+func (s *QueryTrackerService) Cancel(ctx context.Context, id, user string) (*models.InFlightQuery, error) {
+	if user == "" {
+		return nil, ErrQueryUserRequired
+	}
+	q, err := s.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if q.User != user {
+		return nil, ErrQueryNotFound
+	}
+	if err := s.cache.Set(ctx, queryCancelKeyPrefix+id, "1", inFlightQueryTTL); err != nil {
+		return nil, fmt.Errorf("store query cancel request: %w", err)
+	}
+	s.mu.Lock()
+	if cancel, ok := s.cancels[id]; ok {
+		cancel(ErrQueryCancelled)
+	}
+	s.mu.Unlock()
+	q.CancelRequested = true
+	return q, nil
+}
+
+func (s *QueryTrackerService) get(ctx context.Context, id string) (*models.InFlightQuery, error) {
+	data, err := s.cache.Get(ctx, inFlightQueryKeyPrefix+id)
+	if err != nil || len(data) == 0 {
+		return nil, ErrQueryNotFound
+	}
+	var q models.InFlightQuery
+	if err := json.Unmarshal(data, &q); err != nil {
+		return nil, fmt.Errorf("decode in-flight query: %w", err)
+	}
+	q.CancelRequested = s.cancelRequested(ctx, id)
+	return &q, nil
+}
+
+func (s *QueryTrackerService) cancelRequested(ctx context.Context, id string) bool {
+	data, err := s.cache.Get(ctx, queryCancelKeyPrefix+id)
+	return err == nil && len(data) > 0
+}
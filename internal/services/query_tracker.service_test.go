@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func TestQueryTrackerService_ListAndCancel(t *testing.T) {
+	log := logger.New("error")
+	svc := NewQueryTrackerService(cache.NewNoopValkeyCache(log), log)
+	ctx := context.Background()
+
+	aliceCtx, alice, aliceDone := svc.Start(ctx, "alice", "POST", "/api/v1/unified/query")
+	_, _, bobDone := svc.Start(ctx, "bob", "POST", "/api/v1/uql/query")
+	defer bobDone()
+
+	queries, err := svc.List(ctx, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(queries) != 1 || queries[0].ID != alice.ID || queries[0].Path != "/api/v1/unified/query" {
+		t.Fatalf("unexpected queries %+v", queries)
+	}
+
+	if _, err := svc.Cancel(ctx, alice.ID, "bob"); !errors.Is(err, ErrQueryNotFound) {
+		t.Fatalf("expected another user's query to be not found, got %v", err)
+	}
+	if aliceCtx.Err() != nil {
+		t.Fatal("query cancelled by another user")
+	}
+
+	q, err := svc.Cancel(ctx, alice.ID, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !q.CancelRequested {
+		t.Fatalf("expected cancel to be recorded, got %+v", q)
+	}
+	if !errors.Is(context.Cause(aliceCtx), ErrQueryCancelled) {
+		t.Fatalf("expected query context cancelled, got %v", context.Cause(aliceCtx))
+	}
+
+	aliceDone()
+	if queries, _ := svc.List(ctx, "alice"); len(queries) != 0 {
+		t.Fatalf("expected finished query to be removed, got %+v", queries)
+	}
+	if _, err := svc.Cancel(ctx, alice.ID, "alice"); !errors.Is(err, ErrQueryNotFound) {
+		t.Fatalf("expected finished query to be not found, got %v", err)
+	}
+}
+
+func TestQueryTrackerService_RequiresUser(t *testing.T) {
+	log := logger.New("error")
+	svc := NewQueryTrackerService(cache.NewNoopValkeyCache(log), log)
+	ctx := context.Background()
+
+	_, q, done := svc.Start(ctx, "alice", "POST", "/api/v1/unified/query")
+	defer done()
+
+	if _, err := svc.List(ctx, ""); !errors.Is(err, ErrQueryUserRequired) {
+		t.Fatalf("expected ErrQueryUserRequired listing without a user, got %v", err)
+	}
+	if _, err := svc.Cancel(ctx, q.ID, ""); !errors.Is(err, ErrQueryUserRequired) {
+		t.Fatalf("expected ErrQueryUserRequired cancelling without a user, got %v", err)
+	}
+}
+
+func TestQueryTrackerService_CancelFromAnotherReplica(t *testing.T) {
+	log := logger.New("error")
+	shared := cache.NewNoopValkeyCache(log)
+	serving := NewQueryTrackerService(shared, log)
+	other := NewQueryTrackerService(shared, log)
+
+	ctx, q, done := serving.Start(context.Background(), "alice", "POST", "/api/v1/unified/correlation")
+	defer done()
+	if _, err := other.Cancel(context.Background(), q.ID, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * queryCancelPollInterval):
+		t.Fatal("query not cancelled by the other replica's request")
+	}
+	if !errors.Is(context.Cause(ctx), ErrQueryCancelled) {
+		t.Fatalf("unexpected cause %v", context.Cause(ctx))
+	}
+}
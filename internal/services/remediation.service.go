@@ -0,0 +1,541 @@
+package services
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const (
+	remediationAuditKeyPrefix    = "remediation:audit:"
+	remediationCooldownKeyPrefix = "remediation:cooldown:"
+
+	remediationNotificationType = "remediation"
+
+	// maxRemediationActions bounds the actions of one rule.
+	maxRemediationActions = 10
+
+	defaultRemediationActionTimeout = 10 * time.Second
+	defaultRemediationAuditLimit    = 100
+)
+
+var (
+	ErrInvalidRemediationRule  = errors.New("invalid remediation rule")
+	ErrRemediationRuleNotFound = errors.New("remediation rule not found")
+	ErrInvalidRemediationEvent = errors.New("invalid remediation event")
+)
+
+// RemediationNotifier delivers notification actions; NotificationService
+// satisfies it.
+type RemediationNotifier interface {
+	SendNotification(ctx context.Context, notification *models.Notification) error
+}
+
+// RemediationService stores remediation rules and runs their actions for
+// the KPI state changes and alerts they match. Rules are stored as the
+// remediation_rules dynamic config document, so changes are locked across
+// replicas, versioned and can be rolled back; every execution, including dry
+// runs, is kept in a per-rule audit list.
+type RemediationService struct {
+	cache    cache.ValkeyCluster
+	config   *DynamicConfigService
+	notifier RemediationNotifier
+	client   *http.Client
+	cfg      config.RemediationConfig
+	logger   logging.Logger
+}
+
+// NewRemediationService creates a new remediation rules service. notifier
+// may be nil; notification actions then fail.
+func NewRemediationService(cache cache.ValkeyCluster, notifier RemediationNotifier, cfg config.RemediationConfig, logger corelogger.Logger) *RemediationService {
+	if cfg.ActionTimeout <= 0 {
+		cfg.ActionTimeout = defaultRemediationActionTimeout
+	}
+	if cfg.AuditLimit <= 0 {
+		cfg.AuditLimit = defaultRemediationAuditLimit
+	}
+	return &RemediationService{
+		cache:    cache,
+		config:   NewDynamicConfigService(cache, logger),
+		notifier: notifier,
+		client:   &http.Client{Timeout: cfg.ActionTimeout},
+		cfg:      cfg,
+		logger:   logging.FromCoreLogger(logger),
+	}
+}
+
+// ListRules returns the stored rules, oldest first.
+func (s *RemediationService) ListRules(ctx context.Context) ([]models.RemediationRule, error) {
+	return s.load(ctx)
+}
+
+// GetRule returns the rule with the given ID.
+func (s *RemediationService) GetRule(ctx context.Context, id string) (*models.RemediationRule, error) {
+	rules, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rules {
+		if rules[i].ID == id {
+			return &rules[i], nil
+		}
+	}
+	return nil, ErrRemediationRuleNotFound
+}
+
+// CreateRule validates and stores a new rule.
+func (s *RemediationService) CreateRule(ctx context.Context, rule models.RemediationRule, createdBy string) (*models.RemediationRule, error) {
+	if err := s.normalizeRule(&rule); err != nil {
+		return nil, err
+	}
+	rule.ID = uuid.NewString()
+	rule.CreatedBy = createdBy
+	rule.CreatedAt = time.Now().UTC()
+	rule.UpdatedAt = rule.CreatedAt
+	var rules []models.RemediationRule
+	if _, err := s.config.updateDocument(ctx, DynamicConfigRemediationRules, createdBy, &rules, func() error {
+		rules = append(rules, rule)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	s.logger.Info("Remediation rule created", "id", rule.ID, "name", rule.Name, "dryRun", rule.DryRun)
+	return &rule, nil
+}
+
+// UpdateRule replaces the rule with the given ID, keeping its creator and
+// creation time.
+func (s *RemediationService) UpdateRule(ctx context.Context, id string, rule models.RemediationRule, updatedBy string) (*models.RemediationRule, error) {
+	if err := s.normalizeRule(&rule); err != nil {
+		return nil, err
+	}
+	var rules []models.RemediationRule
+	if _, err := s.config.updateDocument(ctx, DynamicConfigRemediationRules, updatedBy, &rules, func() error {
+		i := slices.IndexFunc(rules, func(r models.RemediationRule) bool { return r.ID == id })
+		if i < 0 {
+			return ErrRemediationRuleNotFound
+		}
+		rule.ID = id
+		rule.CreatedBy = rules[i].CreatedBy
+		rule.CreatedAt = rules[i].CreatedAt
+		rule.UpdatedAt = time.Now().UTC()
+		rules[i] = rule
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	s.logger.Info("Remediation rule updated", "id", id, "name", rule.Name, "dryRun", rule.DryRun)
+	return &rule, nil
+}
+
+// DeleteRule removes the rule with the given ID and its audit.
+func (s *RemediationService) DeleteRule(ctx context.Context, id, deletedBy string) error {
+	var rules []models.RemediationRule
+	if _, err := s.config.updateDocument(ctx, DynamicConfigRemediationRules, deletedBy, &rules, func() error {
+		kept := slices.DeleteFunc(slices.Clone(rules), func(r models.RemediationRule) bool { return r.ID == id })
+		if len(kept) == len(rules) {
+			return ErrRemediationRuleNotFound
+		}
+		rules = kept
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := s.cache.Delete(ctx, remediationAuditKeyPrefix+id); err != nil {
+		s.logger.Warn("Failed to delete remediation audit", "id", id, "error", err)
+	}
+	s.logger.Info("Remediation rule deleted", "id", id, "deleted_by", deletedBy)
+	return nil
+}
+
+// Executions returns the audited executions of a rule, newest first.
+func (s *RemediationService) Executions(ctx context.Context, id string) ([]models.RemediationExecution, error) {
+	if _, err := s.GetRule(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.loadAudit(ctx, id)
+}
+
+// Evaluate runs every enabled rule matching ev and returns the executions.
+// Dry-run rules are audited without running their actions. A rule with a
+// cooldown runs at most once per cooldown for the same event type, service
+// and KPI.
+func (s *RemediationService) Evaluate(ctx context.Context, ev models.RemediationEvent) ([]models.RemediationExecution, error) {
+	if err := normalizeRemediationEvent(&ev); err != nil {
+		return nil, err
+	}
+	executions := []models.RemediationExecution{}
+	if !s.cfg.Enabled {
+		return executions, nil
+	}
+	rules, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rules {
+		r := &rules[i]
+		if !r.Enabled || !compileRemediationMatch(r.Match).matches(ev) {
+			continue
+		}
+		if !r.DryRun && r.CooldownSeconds > 0 {
+			key := remediationCooldownKeyPrefix + r.ID + ":" + ev.Type + "|" + ev.Service + "|" + ev.KPIID
+			ok, err := s.cache.AcquireLock(ctx, key, time.Duration(r.CooldownSeconds)*time.Second)
+			if err != nil {
+				s.logger.Warn("Remediation cooldown check failed", "rule", r.ID, "error", err)
+			} else if !ok {
+				continue
+			}
+		}
+		exec := s.run(ctx, r, ev, r.DryRun)
+		if err := s.appendAudit(ctx, exec); err != nil {
+			s.logger.Warn("Failed to audit remediation execution", "rule", r.ID, "error", err)
+		}
+		s.logger.Info("Remediation rule executed", "rule", r.ID, "name", r.Name, "event", ev.Type, "service", ev.Service, "dryRun", exec.DryRun)
+		executions = append(executions, exec)
+	}
+	return executions, nil
+}
+
+// Test reports what the rule with the given ID would do for ev, without
+// running any action or recording an execution. It returns nil when the
+// rule does not match.
+func (s *RemediationService) Test(ctx context.Context, id string, ev models.RemediationEvent) (*models.RemediationExecution, error) {
+	if err := normalizeRemediationEvent(&ev); err != nil {
+		return nil, err
+	}
+	rule, err := s.GetRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !compileRemediationMatch(rule.Match).matches(ev) {
+		return nil, nil
+	}
+	exec := s.run(ctx, rule, ev, true)
+	return &exec, nil
+}
+
+func (s *RemediationService) run(ctx context.Context, r *models.RemediationRule, ev models.RemediationEvent, dryRun bool) models.RemediationExecution {
+	exec := models.RemediationExecution{
+		ID:       uuid.NewString(),
+		RuleID:   r.ID,
+		RuleName: r.Name,
+		DryRun:   dryRun,
+		Event:    ev,
+		Actions:  make([]models.RemediationActionResult, 0, len(r.Actions)),
+		Time:     time.Now().UTC(),
+	}
+	for _, a := range r.Actions {
+		res := models.RemediationActionResult{Type: a.Type, Target: remediationTarget(a), Status: models.RemediationActionDryRun}
+		if !dryRun {
+			code, err := s.runAction(ctx, r, a, ev)
+			res.StatusCode, res.Status = code, models.RemediationActionSucceeded
+			if err != nil {
+				res.Status, res.Error = models.RemediationActionFailed, err.Error()
+				s.logger.Warn("Remediation action failed", "rule", r.ID, "type", a.Type, "target", res.Target, "error", err)
+			}
+		}
+		exec.Actions = append(exec.Actions, res)
+	}
+	return exec
+}
+
+// runAction runs a and returns the HTTP status of webhooks and job triggers.
+func (s *RemediationService) runAction(ctx context.Context, r *models.RemediationRule, a models.RemediationAction, ev models.RemediationEvent) (int, error) {
+	payload := map[string]any{
+		"ruleId": r.ID,
+		"rule":   r.Name,
+		"event":  ev,
+		"time":   time.Now().UTC(),
+	}
+	switch a.Type {
+	case models.RemediationActionNotification:
+		if s.notifier == nil {
+			return 0, errors.New("notifications not configured")
+		}
+		return 0, s.notifier.SendNotification(ctx, remediationNotification(r, a, ev))
+	case models.RemediationActionJob:
+		payload["job"] = a.Job
+		params := make(map[string]string, len(a.Params))
+		expand := strings.NewReplacer("{service}", ev.Service, "{kpi}", cmp.Or(ev.KPIName, ev.KPIID), "{severity}", ev.Severity)
+		for k, v := range a.Params {
+			params[k] = expand.Replace(v)
+		}
+		payload["params"] = params
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range a.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+func remediationNotification(r *models.RemediationRule, a models.RemediationAction, ev models.RemediationEvent) *models.Notification {
+	message := ev.Summary
+	if message == "" {
+		message = fmt.Sprintf("%s event for %s", ev.Type, cmp.Or(ev.Service, "unknown service"))
+		if kpi := cmp.Or(ev.KPIName, ev.KPIID); kpi != "" {
+			message += " (KPI " + kpi + ")"
+		}
+	}
+	return &models.Notification{
+		ID:        uuid.NewString(),
+		Type:      remediationNotificationType,
+		Title:     cmp.Or(a.Title, r.Name),
+		Message:   message,
+		Component: ev.Service,
+		Severity:  ev.Severity,
+		Timestamp: ev.Time,
+		Data: map[string]interface{}{
+			"rule":    r.Name,
+			"event":   ev.Type,
+			"service": ev.Service,
+			"kpi":     cmp.Or(ev.KPIName, ev.KPIID),
+		},
+	}
+}
+
+// remediationTarget names where an action goes, without the URL's query
+// string or credentials.
+func remediationTarget(a models.RemediationAction) string {
+	if a.Type == models.RemediationActionNotification {
+		return "integrations"
+	}
+	u, err := url.Parse(a.URL)
+	if err != nil {
+		return ""
+	}
+	return u.Scheme + "://" + u.Host + u.Path
+}
+
+// remediationMatcher is a rule's match with its patterns compiled.
+type remediationMatcher struct {
+	match    models.RemediationMatch
+	services []*regexp.Regexp
+	kpis     []*regexp.Regexp
+	labels   map[string]*regexp.Regexp
+}
+
+// compileRemediationMatch compiles the patterns of m once, for matching it
+// against any number of events.
+func compileRemediationMatch(m models.RemediationMatch) *remediationMatcher {
+	compileAll := func(patterns []string) []*regexp.Regexp {
+		out := make([]*regexp.Regexp, len(patterns))
+		for i, p := range patterns {
+			out[i] = compileGlob(p)
+		}
+		return out
+	}
+	rm := &remediationMatcher{
+		match:    m,
+		services: compileAll(m.Services),
+		kpis:     compileAll(m.KPIs),
+		labels:   make(map[string]*regexp.Regexp, len(m.Labels)),
+	}
+	for k, p := range m.Labels {
+		rm.labels[k] = compileGlob(p)
+	}
+	return rm
+}
+
+// matches reports whether ev satisfies every condition of the match.
+func (rm *remediationMatcher) matches(ev models.RemediationEvent) bool {
+	containsFold := func(list []string, v string) bool {
+		return slices.ContainsFunc(list, func(s string) bool { return strings.EqualFold(s, v) })
+	}
+	anyGlob := func(patterns []*regexp.Regexp, values ...string) bool {
+		for _, v := range values {
+			if v == "" {
+				continue
+			}
+			for _, re := range patterns {
+				if re.MatchString(v) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	m := rm.match
+	switch {
+	case len(m.EventTypes) > 0 && !containsFold(m.EventTypes, ev.Type):
+		return false
+	case len(m.Severities) > 0 && !containsFold(m.Severities, ev.Severity):
+		return false
+	case len(rm.services) > 0 && !anyGlob(rm.services, ev.Service):
+		return false
+	case len(rm.kpis) > 0 && !anyGlob(rm.kpis, ev.KPIName, ev.KPIID):
+		return false
+	}
+	for k, re := range rm.labels {
+		if v, ok := ev.Labels[k]; !ok || !re.MatchString(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func normalizeRemediationEvent(ev *models.RemediationEvent) error {
+	ev.Type = strings.ToLower(strings.TrimSpace(ev.Type))
+	if ev.Type == "" {
+		ev.Type = models.RemediationEventAlert
+	}
+	ev.Service = strings.TrimSpace(ev.Service)
+	ev.Severity = strings.ToLower(strings.TrimSpace(ev.Severity))
+	if ev.Service == "" && ev.KPIID == "" && ev.KPIName == "" && len(ev.Labels) == 0 {
+		return fmt.Errorf("%w: one of service, kpiId, kpiName or labels is required", ErrInvalidRemediationEvent)
+	}
+	if ev.Time.IsZero() {
+		ev.Time = time.Now().UTC()
+	}
+	return nil
+}
+
+func (s *RemediationService) normalizeRule(r *models.RemediationRule) error {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Description = strings.TrimSpace(r.Description)
+	if r.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidRemediationRule)
+	}
+	if r.CooldownSeconds < 0 {
+		return fmt.Errorf("%w: cooldownSeconds must not be negative", ErrInvalidRemediationRule)
+	}
+
+	m := &r.Match
+	m.EventTypes = trimmedLower(m.EventTypes)
+	m.Severities = trimmedLower(m.Severities)
+	m.Services = trimmedStrings(m.Services)
+	m.KPIs = trimmedStrings(m.KPIs)
+	for _, t := range m.EventTypes {
+		if t != models.RemediationEventKPIState && t != models.RemediationEventAlert {
+			return fmt.Errorf("%w: unknown event type %q", ErrInvalidRemediationRule, t)
+		}
+	}
+	if len(m.Severities) == 0 && len(m.Services) == 0 && len(m.KPIs) == 0 && len(m.Labels) == 0 {
+		return fmt.Errorf("%w: match needs at least one of severities, services, kpis or labels", ErrInvalidRemediationRule)
+	}
+
+	if len(r.Actions) == 0 || len(r.Actions) > maxRemediationActions {
+		return fmt.Errorf("%w: between 1 and %d actions are required", ErrInvalidRemediationRule, maxRemediationActions)
+	}
+	for i := range r.Actions {
+		a := &r.Actions[i]
+		a.Type = strings.ToLower(strings.TrimSpace(a.Type))
+		a.URL = strings.TrimSpace(a.URL)
+		a.Job = strings.TrimSpace(a.Job)
+		switch a.Type {
+		case models.RemediationActionNotification:
+			continue
+		case models.RemediationActionWebhook:
+		case models.RemediationActionJob:
+			if a.Job == "" {
+				return fmt.Errorf("%w: action %d: job is required", ErrInvalidRemediationRule, i)
+			}
+		default:
+			return fmt.Errorf("%w: action %d: type must be webhook, notification or job", ErrInvalidRemediationRule, i)
+		}
+		if err := s.checkActionURL(a.URL); err != nil {
+			return fmt.Errorf("%w: action %d: %v", ErrInvalidRemediationRule, i, err)
+		}
+	}
+	return nil
+}
+
+// checkActionURL requires an absolute http(s) URL on an allowed host.
+func (s *RemediationService) checkActionURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("url must be an absolute http or https URL")
+	}
+	if len(s.cfg.AllowedHosts) > 0 && !slices.ContainsFunc(s.cfg.AllowedHosts, func(h string) bool { return strings.EqualFold(h, u.Hostname()) }) {
+		return fmt.Errorf("host %q is not in remediation.allowed_hosts", u.Hostname())
+	}
+	return nil
+}
+
+func trimmedStrings(in []string) []string {
+	var out []string
+	for _, v := range in {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func trimmedLower(in []string) []string {
+	out := trimmedStrings(in)
+	for i := range out {
+		out[i] = strings.ToLower(out[i])
+	}
+	return out
+}
+
+func (s *RemediationService) load(ctx context.Context) ([]models.RemediationRule, error) {
+	rules := []models.RemediationRule{}
+	if err := s.config.getDocument(ctx, DynamicConfigRemediationRules, &rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (s *RemediationService) loadAudit(ctx context.Context, id string) ([]models.RemediationExecution, error) {
+	data, err := s.cache.Get(ctx, remediationAuditKeyPrefix+id)
+	if err != nil || len(data) == 0 {
+		return []models.RemediationExecution{}, nil
+	}
+	var execs []models.RemediationExecution
+	if err := json.Unmarshal(data, &execs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal remediation audit: %w", err)
+	}
+	return execs, nil
+}
+
+// appendAudit prepends exec to its rule's audit, keeping AuditLimit entries.
+func (s *RemediationService) appendAudit(ctx context.Context, exec models.RemediationExecution) error {
+	execs, err := s.loadAudit(ctx, exec.RuleID)
+	if err != nil {
+		return err
+	}
+	execs = append([]models.RemediationExecution{exec}, execs...)
+	if len(execs) > s.cfg.AuditLimit {
+		execs = execs[:s.cfg.AuditLimit]
+	}
+	data, err := json.Marshal(execs)
+	if err != nil {
+		return err
+	}
+	return s.cache.Set(ctx, remediationAuditKeyPrefix+exec.RuleID, data, 0)
+}
@@ -0,0 +1,203 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func TestRemediationService_RuleValidation(t *testing.T) {
+	log := logger.New("error")
+	ctx := context.Background()
+	svc := NewRemediationService(cache.NewNoopValkeyCache(log), nil, config.RemediationConfig{Enabled: true, AllowedHosts: []string{"hooks.internal"}}, log)
+
+	notify := []models.RemediationAction{{Type: models.RemediationActionNotification}}
+	invalid := []models.RemediationRule{
+		{Match: models.RemediationMatch{Services: []string{"checkout"}}, Actions: notify},
+		{Name: "no match", Actions: notify},
+		{Name: "bad type", Match: models.RemediationMatch{EventTypes: []string{"page"}, Services: []string{"x"}}, Actions: notify},
+		{Name: "no actions", Match: models.RemediationMatch{Services: []string{"x"}}},
+		{Name: "job name", Match: models.RemediationMatch{Services: []string{"x"}}, Actions: []models.RemediationAction{{Type: "job", URL: "https://hooks.internal/run"}}},
+		{Name: "host", Match: models.RemediationMatch{Services: []string{"x"}}, Actions: []models.RemediationAction{{Type: "webhook", URL: "https://elsewhere.example/hook"}}},
+		{Name: "scheme", Match: models.RemediationMatch{Services: []string{"x"}}, Actions: []models.RemediationAction{{Type: "webhook", URL: "file:///etc/passwd"}}},
+	}
+	for _, r := range invalid {
+		if _, err := svc.CreateRule(ctx, r, ""); !errors.Is(err, ErrInvalidRemediationRule) {
+			t.Fatalf("rule %q: expected ErrInvalidRemediationRule, got %v", r.Name, err)
+		}
+	}
+
+	rule, err := svc.CreateRule(ctx, models.RemediationRule{
+		Name:    " disk ",
+		Match:   models.RemediationMatch{Severities: []string{" Critical "}, Services: []string{"checkout"}},
+		Actions: []models.RemediationAction{{Type: " Webhook ", URL: "https://hooks.internal/disk"}},
+	}, "sre")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule.ID == "" || rule.Name != "disk" || rule.CreatedBy != "sre" || rule.Match.Severities[0] != "critical" || rule.Actions[0].Type != "webhook" {
+		t.Fatalf("unexpected rule %+v", rule)
+	}
+
+	rule.Enabled = true
+	updated, err := svc.UpdateRule(ctx, rule.ID, *rule, "sre")
+	if err != nil || !updated.Enabled || updated.CreatedBy != "sre" || !updated.CreatedAt.Equal(rule.CreatedAt) {
+		t.Fatalf("unexpected update %+v, %v", updated, err)
+	}
+	if _, err := svc.UpdateRule(ctx, "missing", *rule, "sre"); !errors.Is(err, ErrRemediationRuleNotFound) {
+		t.Fatalf("expected ErrRemediationRuleNotFound, got %v", err)
+	}
+	if err := svc.DeleteRule(ctx, rule.ID, "sre"); err != nil {
+		t.Fatal(err)
+	}
+	if rules, _ := svc.ListRules(ctx); len(rules) != 0 {
+		t.Fatalf("expected no rules after delete, got %d", len(rules))
+	}
+	if versions, err := svc.config.ListVersions(ctx, DynamicConfigRemediationRules); err != nil || len(versions) != 3 {
+		t.Fatalf("expected the create, update and delete to be versioned, got %d (%v)", len(versions), err)
+	}
+}
+
+func TestRemediationMatches(t *testing.T) {
+	m := models.RemediationMatch{
+		EventTypes: []string{models.RemediationEventKPIState},
+		Severities: []string{"critical"},
+		Services:   []string{"checkout*"},
+		KPIs:       []string{"disk_*"},
+	}
+	ev := models.RemediationEvent{Type: "kpi_state", Service: "checkout-api", KPIID: "k1", KPIName: "Disk_Used_Pct", Severity: "critical"}
+	if !compileRemediationMatch(m).matches(ev) {
+		t.Fatal("expected match")
+	}
+	for name, mutate := range map[string]func(*models.RemediationEvent){
+		"type":     func(e *models.RemediationEvent) { e.Type = models.RemediationEventAlert },
+		"severity": func(e *models.RemediationEvent) { e.Severity = "warning" },
+		"service":  func(e *models.RemediationEvent) { e.Service = "payments" },
+		"kpi":      func(e *models.RemediationEvent) { e.KPIName, e.KPIID = "", "" },
+	} {
+		e := ev
+		mutate(&e)
+		if compileRemediationMatch(m).matches(e) {
+			t.Fatalf("%s: expected no match", name)
+		}
+	}
+
+	labels := models.RemediationMatch{Labels: map[string]string{"alertname": "Disk*"}}
+	if !compileRemediationMatch(labels).matches(models.RemediationEvent{Labels: map[string]string{"alertname": "DiskFull"}}) {
+		t.Fatal("expected label match")
+	}
+	if compileRemediationMatch(labels).matches(models.RemediationEvent{Labels: map[string]string{"severity": "page"}}) {
+		t.Fatal("expected no match without the label")
+	}
+}
+
+func TestRemediationService_Evaluate(t *testing.T) {
+	log := logger.New("error")
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	var received []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		received = append(received, body)
+		mu.Unlock()
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+
+	notifier := &recordingNotifier{}
+	c := newLockingCache()
+	svc := NewRemediationService(c, notifier, config.RemediationConfig{Enabled: true}, log)
+
+	match := models.RemediationMatch{KPIs: []string{"disk*"}, Severities: []string{"critical"}}
+	live, err := svc.CreateRule(ctx, models.RemediationRule{
+		Name: "expand volume", Enabled: true, Match: match, CooldownSeconds: 600,
+		Actions: []models.RemediationAction{
+			{Type: models.RemediationActionJob, URL: srv.URL + "/jobs", Job: "expand", Params: map[string]string{"target": "{service}/{kpi}"}},
+			{Type: models.RemediationActionNotification},
+			{Type: models.RemediationActionWebhook, URL: srv.URL + "/fail"},
+		},
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dry, err := svc.CreateRule(ctx, models.RemediationRule{
+		Name: "ticket", Enabled: true, DryRun: true, Match: match,
+		Actions: []models.RemediationAction{{Type: models.RemediationActionWebhook, URL: srv.URL + "/ticket"}},
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.CreateRule(ctx, models.RemediationRule{
+		Name: "disabled", Match: match,
+		Actions: []models.RemediationAction{{Type: models.RemediationActionWebhook, URL: srv.URL + "/disabled"}},
+	}, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	ev := models.RemediationEvent{Type: models.RemediationEventKPIState, Service: "checkout", KPIID: "k1", KPIName: "disk_used", Severity: "critical"}
+	execs, err := svc.Evaluate(ctx, ev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(execs) != 2 {
+		t.Fatalf("expected the live and dry-run rules to run, got %d", len(execs))
+	}
+	results := execs[0].Actions
+	if results[0].Status != models.RemediationActionSucceeded || results[1].Status != models.RemediationActionSucceeded ||
+		results[2].Status != models.RemediationActionFailed || results[2].StatusCode != http.StatusBadGateway {
+		t.Fatalf("unexpected action results %+v", results)
+	}
+	if !execs[1].DryRun || execs[1].Actions[0].Status != models.RemediationActionDryRun {
+		t.Fatalf("expected a dry run, got %+v", execs[1])
+	}
+	if len(received) != 2 || received[0]["job"] != "expand" || received[0]["params"].(map[string]any)["target"] != "checkout/disk_used" {
+		t.Fatalf("unexpected requests %+v", received)
+	}
+	if len(notifier.sent) != 1 || notifier.sent[0].Type != remediationNotificationType || notifier.sent[0].Title != "expand volume" {
+		t.Fatalf("unexpected notifications %+v", notifier.sent)
+	}
+
+	// The cooldown holds the live rule back; the dry-run rule is audited again.
+	execs, err = svc.Evaluate(ctx, ev)
+	if err != nil || len(execs) != 1 || execs[0].RuleID != dry.ID {
+		t.Fatalf("expected only the dry-run rule within the cooldown, got %+v, %v", execs, err)
+	}
+	if audit, _ := svc.Executions(ctx, live.ID); len(audit) != 1 {
+		t.Fatalf("expected one audited live execution, got %d", len(audit))
+	}
+	if audit, _ := svc.Executions(ctx, dry.ID); len(audit) != 2 {
+		t.Fatalf("expected two audited dry runs, got %d", len(audit))
+	}
+
+	// Testing a rule runs nothing and records nothing.
+	exec, err := svc.Test(ctx, live.ID, ev)
+	if err != nil || exec == nil || exec.Actions[0].Status != models.RemediationActionDryRun {
+		t.Fatalf("unexpected test result %+v, %v", exec, err)
+	}
+	if exec, _ := svc.Test(ctx, live.ID, models.RemediationEvent{Service: "checkout", Severity: "warning"}); exec != nil {
+		t.Fatalf("expected no match, got %+v", exec)
+	}
+	if len(received) != 2 {
+		t.Fatalf("expected no further requests, got %d", len(received))
+	}
+	if audit, _ := svc.Executions(ctx, live.ID); len(audit) != 1 {
+		t.Fatalf("expected the audit unchanged, got %d", len(audit))
+	}
+	if _, err := svc.Evaluate(ctx, models.RemediationEvent{}); !errors.Is(err, ErrInvalidRemediationEvent) {
+		t.Fatalf("expected ErrInvalidRemediationEvent, got %v", err)
+	}
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const (
+	RolePrimary = "primary"
+	RoleReplica = "replica"
+
+	replicationHeartbeatKey = "replication:heartbeat"
+)
+
+// ReplicationService tracks the active/passive role. The primary writes a
+// heartbeat timestamp to Valkey; a replica reads it from its replicated Valkey
+// and derives replication lag from its age.
+type ReplicationService struct {
+	cache  cache.ValkeyCluster
+	cfg    config.ReplicationConfig
+	logger logging.Logger
+
+	mu      sync.Mutex
+	running bool
+	stopCh  chan struct{}
+}
+
+// NewReplicationService creates a new replication service
+func NewReplicationService(cache cache.ValkeyCluster, cfg config.ReplicationConfig, logger corelogger.Logger) *ReplicationService {
+	if cfg.Role == "" {
+		cfg.Role = RolePrimary
+	}
+	if cfg.HeartbeatInterval <= 0 {
+		cfg.HeartbeatInterval = 5 * time.Second
+	}
+	return &ReplicationService{
+		cache:  cache,
+		cfg:    cfg,
+		logger: logging.FromCoreLogger(logger),
+		stopCh: make(chan struct{}),
+	}
+}
+
+// IsReplica reports whether this deployment serves read-only traffic.
+func (s *ReplicationService) IsReplica() bool { return s.cfg.Role == RoleReplica }
+
+// PrimaryURL returns the primary's base URL (replicas only).
+func (s *ReplicationService) PrimaryURL() string { return s.cfg.PrimaryURL }
+
+// Start writes heartbeats until Stop is called. It is a no-op on replicas,
+// which must never write to the replicated stores.
+func (s *ReplicationService) Start(ctx context.Context) {
+	if s.IsReplica() {
+		s.logger.Info("Replication: running as read-only replica", "primary", s.cfg.PrimaryURL)
+		return
+	}
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return
+	}
+	s.running = true
+	s.mu.Unlock()
+
+	ticker := time.NewTicker(s.cfg.HeartbeatInterval)
+	defer ticker.Stop()
+	s.beat(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.beat(ctx)
+		}
+	}
+}
+
+// Stop ends the heartbeat loop.
+func (s *ReplicationService) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running {
+		close(s.stopCh)
+		s.running = false
+	}
+}
+
+func (s *ReplicationService) beat(ctx context.Context) {
+	now := time.Now().UTC().Format(time.RFC3339Nano)
+	if err := s.cache.Set(ctx, replicationHeartbeatKey, now, 0); err != nil {
+		s.logger.Warn("Replication heartbeat write failed", "error", err)
+	}
+}
+
+// Status returns the role and, for replicas, the observed lag.
+func (s *ReplicationService) Status(ctx context.Context) *models.ReplicationStatus {
+	st := &models.ReplicationStatus{Role: s.cfg.Role, Healthy: true}
+	if !s.IsReplica() {
+		return st
+	}
+
+	st.PrimaryURL = s.cfg.PrimaryURL
+	st.LagSeconds = -1
+	if s.cache == nil {
+		st.Healthy = false
+		return st
+	}
+	data, err := s.cache.Get(ctx, replicationHeartbeatKey)
+	if err != nil || len(data) == 0 {
+		st.Healthy = false
+		return st
+	}
+	ts, err := time.Parse(time.RFC3339Nano, string(data))
+	if err != nil {
+		s.logger.Warn("Replication heartbeat unreadable", "error", err)
+		st.Healthy = false
+		return st
+	}
+	lag := time.Since(ts)
+	if lag < 0 {
+		lag = 0
+	}
+	st.LastHeartbeat = &ts
+	st.LagSeconds = lag.Seconds()
+	st.Healthy = s.cfg.MaxLag <= 0 || lag <= s.cfg.MaxLag
+	return st
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func TestReplicationService_ReplicaLagFromPrimaryHeartbeat(t *testing.T) {
+	log := logger.New("error")
+	shared := cache.NewNoopValkeyCache(log) // stands in for the replicated Valkey
+	ctx := context.Background()
+
+	replica := NewReplicationService(shared, config.ReplicationConfig{
+		Role: RoleReplica, PrimaryURL: "https://primary.example", MaxLag: time.Minute,
+	}, log)
+
+	st := replica.Status(ctx)
+	if st.Healthy || st.LagSeconds != -1 {
+		t.Fatalf("replica without heartbeat must be unhealthy, got %+v", st)
+	}
+
+	primary := NewReplicationService(shared, config.ReplicationConfig{Role: RolePrimary}, log)
+	primary.beat(ctx)
+
+	st = replica.Status(ctx)
+	if !st.Healthy || st.LastHeartbeat == nil || st.LagSeconds < 0 || st.LagSeconds > 5 {
+		t.Fatalf("expected fresh heartbeat, got %+v", st)
+	}
+
+	stale := time.Now().Add(-2 * time.Minute).UTC().Format(time.RFC3339Nano)
+	_ = shared.Set(ctx, replicationHeartbeatKey, stale, 0)
+	st = replica.Status(ctx)
+	if st.Healthy || st.LagSeconds < 119 {
+		t.Fatalf("expected lagging replica, got %+v", st)
+	}
+}
+
+func TestReplicationService_StartStop(t *testing.T) {
+	log := logger.New("error")
+	c := cache.NewNoopValkeyCache(log)
+	svc := NewReplicationService(c, config.ReplicationConfig{HeartbeatInterval: 10 * time.Millisecond}, log)
+
+	done := make(chan struct{})
+	go func() {
+		svc.Start(context.Background())
+		close(done)
+	}()
+	deadline := time.Now().Add(time.Second)
+	for {
+		if b, _ := c.Get(context.Background(), replicationHeartbeatKey); len(b) > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("primary did not write a heartbeat")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	svc.Stop()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("heartbeat loop did not stop")
+	}
+
+	replica := NewReplicationService(cache.NewNoopValkeyCache(log), config.ReplicationConfig{Role: RoleReplica}, log)
+	replica.Start(context.Background()) // must return immediately without writing
+	if b, _ := replica.cache.Get(context.Background(), replicationHeartbeatKey); len(b) > 0 {
+		t.Fatal("replica must not write heartbeats")
+	}
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// ResidencyService reports which backend hosts the tenant's data flows to
+// and whether they are in the tenant's residency region. Enforcement
+// happens in config validation and the residency transports; this only
+// audits the configuration.
+type ResidencyService struct {
+	cfg    *config.Config
+	logger logging.Logger
+}
+
+// NewResidencyService creates a new residency audit service.
+func NewResidencyService(cfg *config.Config, logger corelogger.Logger) *ResidencyService {
+	return &ResidencyService{
+		cfg:    cfg,
+		logger: logging.FromCoreLogger(logger),
+	}
+}
+
+// Report lists every configured backend host with the region it belongs to.
+// Without a region set the report is always compliant.
+func (s *ResidencyService) Report() *models.ResidencyReport {
+	r := s.cfg.Residency
+	report := &models.ResidencyReport{
+		Tenant:      s.cfg.Cache.TenantID,
+		Region:      r.Region,
+		Strict:      r.Strict,
+		Compliant:   true,
+		Flows:       []models.ResidencyFlow{},
+		GeneratedAt: time.Now().UTC(),
+	}
+	for _, b := range s.cfg.BackendHosts() {
+		flow := models.ResidencyFlow{
+			Backend:  b.Backend,
+			Field:    b.Field,
+			Host:     b.Host,
+			Region:   r.RegionOf(b.Host),
+			InRegion: r.InRegion(b.Host),
+		}
+		if !flow.InRegion {
+			report.Compliant = false
+		}
+		report.Flows = append(report.Flows, flow)
+	}
+	return report
+}
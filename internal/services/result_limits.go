@@ -0,0 +1,317 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrTooManyResults is matched (errors.Is) by every TooManyResultsError.
+var ErrTooManyResults = errors.New("too many results")
+
+// TooManyResultsError rejects a query whose result exceeds the configured
+// series or row limit. Suggestions are rewrites computed from the result
+// that would bring it under the limit.
+type TooManyResultsError struct {
+	Query       string             `json:"query"`
+	Unit        string             `json:"unit"` // series | rows
+	Count       int                `json:"count"`
+	Limit       int                `json:"limit"`
+	AtLeast     bool               `json:"atLeast,omitempty"` // Count is a lower bound; reading stopped at the limit
+	Suggestions []ResultSuggestion `json:"suggestions"`
+}
+
+// ResultSuggestion is one way to narrow a query. Start/End are set for
+// time range suggestions, in the request's own units.
+type ResultSuggestion struct {
+	Kind             string `json:"kind"` // topk | aggregate | limit | stats | time_range
+	Description      string `json:"description"`
+	Query            string `json:"query,omitempty"`
+	Start            string `json:"start,omitempty"`
+	End              string `json:"end,omitempty"`
+	EstimatedResults int    `json:"estimatedResults,omitempty"`
+}
+
+func (e *TooManyResultsError) Error() string {
+	more := ""
+	if e.AtLeast {
+		more = "at least "
+	}
+	return fmt.Sprintf("query returned %s%d %s, over the limit of %d", more, e.Count, e.Unit, e.Limit)
+}
+
+func (e *TooManyResultsError) Is(target error) bool { return target == ErrTooManyResults }
+
+// seriesLabels extracts the label sets of a VM/Prometheus query result.
+func seriesLabels(data any) []map[string]string {
+	m, _ := data.(map[string]any)
+	result, _ := m["result"].([]any)
+	out := make([]map[string]string, 0, len(result))
+	for _, it := range result {
+		series, _ := it.(map[string]any)
+		metric, _ := series["metric"].(map[string]any)
+		labels := make(map[string]string, len(metric))
+		for k, v := range metric {
+			labels[k] = fmt.Sprint(v)
+		}
+		out = append(out, labels)
+	}
+	return out
+}
+
+// metricsSuggestions proposes topk and a "sum by" over the labels that keep
+// the series count under limit, dropping the highest-cardinality labels
+// first.
+func metricsSuggestions(query string, series []map[string]string, limit int) []ResultSuggestion {
+	k := min(limit, 10)
+	out := []ResultSuggestion{{
+		Kind:             "topk",
+		Description:      fmt.Sprintf("keep only the %d series with the highest values", k),
+		Query:            fmt.Sprintf("topk(%d, %s)", k, query),
+		EstimatedResults: k,
+	}}
+
+	cardinality := map[string]map[string]struct{}{}
+	for _, labels := range series {
+		for name, value := range labels {
+			if name == "__name__" {
+				continue
+			}
+			if cardinality[name] == nil {
+				cardinality[name] = map[string]struct{}{}
+			}
+			cardinality[name][value] = struct{}{}
+		}
+	}
+	keep := make([]string, 0, len(cardinality))
+	for name := range cardinality {
+		keep = append(keep, name)
+	}
+	// Highest cardinality last, so the loop below drops from the end.
+	sort.Slice(keep, func(i, j int) bool {
+		ci, cj := len(cardinality[keep[i]]), len(cardinality[keep[j]])
+		if ci != cj {
+			return ci < cj
+		}
+		return keep[i] < keep[j]
+	})
+	var dropped []string
+	groups := len(series)
+	for len(keep) > 0 && groups > limit {
+		dropped = append(dropped, keep[len(keep)-1])
+		keep = keep[:len(keep)-1]
+		groups = countGroups(series, keep)
+	}
+	if len(dropped) == 0 || groups > limit {
+		return out
+	}
+	byClause := ""
+	if len(keep) > 0 {
+		sorted := append([]string(nil), keep...)
+		sort.Strings(sorted)
+		byClause = " by (" + strings.Join(sorted, ", ") + ")"
+	}
+	return append(out, ResultSuggestion{
+		Kind:             "aggregate",
+		Description:      "aggregate away the highest-cardinality labels: " + strings.Join(dropped, ", "),
+		Query:            fmt.Sprintf("sum%s (%s)", byClause, query),
+		EstimatedResults: groups,
+	})
+}
+
+// highCardinalitySuggestion aggregates away the labels of series listed in
+// flagged, if the remaining label sets number at most limit.
+func highCardinalitySuggestion(query string, series []map[string]string, flagged map[string]int64, limit int) (ResultSuggestion, bool) {
+	present := map[string]bool{}
+	for _, labels := range series {
+		for name := range labels {
+			present[name] = true
+		}
+	}
+	var drop, keep []string
+	for name := range present {
+		if name == "__name__" {
+			continue
+		}
+		if _, ok := flagged[name]; ok {
+			drop = append(drop, name)
+		} else {
+			keep = append(keep, name)
+		}
+	}
+	if len(drop) == 0 {
+		return ResultSuggestion{}, false
+	}
+	groups := countGroups(series, keep)
+	if groups > limit {
+		return ResultSuggestion{}, false
+	}
+	sort.Strings(drop)
+	described := make([]string, len(drop))
+	for i, name := range drop {
+		described[i] = fmt.Sprintf("%s (~%d values)", name, flagged[name])
+	}
+	return ResultSuggestion{
+		Kind:             "aggregate",
+		Description:      "aggregate away labels flagged as high cardinality: " + strings.Join(described, ", "),
+		Query:            fmt.Sprintf("sum without (%s) (%s)", strings.Join(drop, ", "), query),
+		EstimatedResults: groups,
+	}, true
+}
+
+func countGroups(series []map[string]string, labels []string) int {
+	groups := map[string]struct{}{}
+	var key strings.Builder
+	for _, s := range series {
+		key.Reset()
+		for _, l := range labels {
+			key.WriteString(s[l])
+			key.WriteByte(0)
+		}
+		groups[key.String()] = struct{}{}
+	}
+	return len(groups)
+}
+
+// rangeSuggestion narrows a range query to the most recent window in which
+// at most limit series are still active. It returns false when series do not
+// churn, so a shorter range would not help.
+func rangeSuggestion(data any, start, end string, limit int) (ResultSuggestion, bool) {
+	startTS, ok1 := parsePromTime(start)
+	endTS, ok2 := parsePromTime(end)
+	if !ok1 || !ok2 {
+		return ResultSuggestion{}, false
+	}
+	m, _ := data.(map[string]any)
+	result, _ := m["result"].([]any)
+	lastSeen := make([]float64, 0, len(result))
+	for _, it := range result {
+		series, _ := it.(map[string]any)
+		values, _ := series["values"].([]any)
+		if len(values) == 0 {
+			continue
+		}
+		point, _ := values[len(values)-1].([]any)
+		if len(point) == 0 {
+			continue
+		}
+		if ts, ok := point[0].(float64); ok {
+			lastSeen = append(lastSeen, ts)
+		}
+	}
+	if len(lastSeen) <= limit {
+		return ResultSuggestion{}, false
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(lastSeen)))
+	// Series last seen at or before cutoff drop out of a range starting after it.
+	cutoff := lastSeen[limit]
+	if cutoff < startTS || cutoff >= endTS {
+		return ResultSuggestion{}, false
+	}
+	newStart := cutoff + 1
+	active := 0
+	for _, ts := range lastSeen {
+		if ts >= newStart {
+			active++
+		}
+	}
+	return ResultSuggestion{
+		Kind:             "time_range",
+		Description:      fmt.Sprintf("query the last %s instead; older series have stopped reporting", time.Duration((endTS-newStart)*float64(time.Second)).Round(time.Second)),
+		Start:            strconv.FormatFloat(newStart, 'f', -1, 64),
+		End:              end,
+		EstimatedResults: active,
+	}, true
+}
+
+// parsePromTime parses the unix-seconds and RFC3339 forms accepted by the
+// Prometheus query API.
+func parsePromTime(s string) (float64, bool) {
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f, true
+	}
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return float64(t.UnixNano()) / 1e9, true
+	}
+	return 0, false
+}
+
+// maxStatsFieldValues bounds the cardinality of a field suggested for
+// "stats by".
+const maxStatsFieldValues = 50
+
+// logsSuggestions proposes a limit pipe, a "stats by" over the
+// lowest-cardinality field seen in rows, and a time range covering the span
+// the sampled rows came from.
+func logsSuggestions(req string, rows []map[string]any, limit int, start, end int64) []ResultSuggestion {
+	out := []ResultSuggestion{{
+		Kind:             "limit",
+		Description:      fmt.Sprintf("return at most %d rows", limit),
+		Query:            fmt.Sprintf("%s | limit %d", req, limit),
+		EstimatedResults: limit,
+	}}
+
+	values := map[string]map[string]struct{}{}
+	var minT, maxT time.Time
+	for _, row := range rows {
+		for field, v := range row {
+			switch field {
+			case "_msg", "_stream_id":
+				continue
+			case "_time":
+				if t, err := time.Parse(time.RFC3339Nano, fmt.Sprint(v)); err == nil {
+					if minT.IsZero() || t.Before(minT) {
+						minT = t
+					}
+					if t.After(maxT) {
+						maxT = t
+					}
+				}
+				continue
+			}
+			if values[field] == nil {
+				values[field] = map[string]struct{}{}
+			}
+			if len(values[field]) <= maxStatsFieldValues {
+				values[field][fmt.Sprint(v)] = struct{}{}
+			}
+		}
+	}
+	best, bestCount := "", 0
+	for field, vals := range values {
+		n := len(vals)
+		if n < 2 || n > maxStatsFieldValues {
+			continue
+		}
+		if best == "" || n < bestCount || (n == bestCount && field < best) {
+			best, bestCount = field, n
+		}
+	}
+	if best != "" {
+		out = append(out, ResultSuggestion{
+			Kind:             "stats",
+			Description:      fmt.Sprintf("count rows by %s instead of listing them", best),
+			Query:            fmt.Sprintf("%s | stats by (%s) count() rows", req, best),
+			EstimatedResults: bestCount,
+		})
+	}
+
+	// limit rows arrived within [minT, maxT]; a window of that width ending
+	// at end holds about as many.
+	if span := maxT.Sub(minT); span > 0 && end > 0 {
+		endMS := normalizeToMillis(end)
+		if newStart := endMS - span.Milliseconds(); newStart > normalizeToMillis(start) {
+			out = append(out, ResultSuggestion{
+				Kind:             "time_range",
+				Description:      fmt.Sprintf("narrow the range to the last %s", span.Round(time.Second)),
+				Start:            strconv.FormatInt(newStart, 10),
+				End:              strconv.FormatInt(endMS, 10),
+				EstimatedResults: limit,
+			})
+		}
+	}
+	return out
+}
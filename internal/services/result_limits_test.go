@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// fakeVMSeries serves an instant query result with one series per pod:
+// 6 pods across 2 namespaces.
+func fakeVMSeries(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		result := make([]map[string]any, 0, 6)
+		for i := 0; i < 6; i++ {
+			result = append(result, map[string]any{
+				"metric": map[string]any{"__name__": "up", "namespace": fmt.Sprintf("ns-%d", i%2), "pod": fmt.Sprintf("pod-%d", i)},
+				"value":  []any{1700000000, "1"},
+			})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"status": "success",
+			"data":   map[string]any{"resultType": "vector", "result": result},
+		})
+	}))
+}
+
+func TestVictoriaMetrics_ExecuteQuery_TooManySeries(t *testing.T) {
+	srv := fakeVMSeries(t)
+	defer srv.Close()
+	svc := NewVictoriaMetricsService(config.VictoriaMetricsConfig{Endpoints: []string{srv.URL}, Timeout: 2000}, logger.New("error"))
+	svc.SetResultLimits(config.ResultLimitsConfig{MaxSeries: 3})
+
+	_, err := svc.ExecuteQuery(context.Background(), &models.MetricsQLQueryRequest{Query: "up"})
+	if !errors.Is(err, ErrTooManyResults) {
+		t.Fatalf("expected ErrTooManyResults, got %v", err)
+	}
+	var tooMany *TooManyResultsError
+	errors.As(err, &tooMany)
+	if tooMany.Count != 6 || tooMany.Limit != 3 || tooMany.Unit != "series" {
+		t.Fatalf("unexpected error details: %+v", tooMany)
+	}
+	queries := map[string]string{}
+	for _, s := range tooMany.Suggestions {
+		queries[s.Kind] = s.Query
+	}
+	if queries["topk"] != "topk(3, up)" {
+		t.Errorf("topk suggestion = %q", queries["topk"])
+	}
+	if queries["aggregate"] != "sum by (namespace) (up)" {
+		t.Errorf("aggregate suggestion = %q, want pod dropped", queries["aggregate"])
+	}
+
+	svc.SetResultLimits(config.ResultLimitsConfig{MaxSeries: 6})
+	if _, err := svc.ExecuteQuery(context.Background(), &models.MetricsQLQueryRequest{Query: "up"}); err != nil {
+		t.Fatalf("result at the limit rejected: %v", err)
+	}
+}
+
+func TestVictoriaLogs_ExecuteQuery_TooManyRows(t *testing.T) {
+	rows := make([][]any, 0, 10)
+	for i := 0; i < 10; i++ {
+		rows = append(rows, []any{fmt.Sprintf("2024-01-01T00:00:%02dZ", i), "msg", []string{"info", "error"}[i%2], fmt.Sprintf("req-%d", i)})
+	}
+	srv := fakeVL(t, []string{"_time", "_msg", "level", "request_id"}, rows)
+	defer srv.Close()
+	svc := NewVictoriaLogsService(config.VictoriaLogsConfig{Endpoints: []string{srv.URL}, Timeout: 2000}, logger.New("error"))
+	svc.SetResultLimits(config.ResultLimitsConfig{MaxLogRows: 4})
+
+	_, err := svc.ExecuteQuery(context.Background(), &models.LogsQLQueryRequest{Query: "*", Start: 1704067000, End: 1704067300})
+	var tooMany *TooManyResultsError
+	if !errors.As(err, &tooMany) {
+		t.Fatalf("expected TooManyResultsError, got %v", err)
+	}
+	if !tooMany.AtLeast || tooMany.Count != 5 {
+		t.Fatalf("expected reading to stop after limit+1 rows: %+v", tooMany)
+	}
+	kinds := map[string]ResultSuggestion{}
+	for _, s := range tooMany.Suggestions {
+		kinds[s.Kind] = s
+	}
+	if kinds["stats"].Query != "* | stats by (level) count() rows" {
+		t.Errorf("stats suggestion = %q", kinds["stats"].Query)
+	}
+	if kinds["limit"].Query != "* | limit 4" {
+		t.Errorf("limit suggestion = %q", kinds["limit"].Query)
+	}
+	if kinds["time_range"].End != "1704067300000" {
+		t.Errorf("time range suggestion = %+v", kinds["time_range"])
+	}
+}
+
+func TestRangeSuggestion_OnlyWhenSeriesChurn(t *testing.T) {
+	series := func(last ...float64) map[string]any {
+		result := make([]any, 0, len(last))
+		for _, ts := range last {
+			result = append(result, map[string]any{"values": []any{[]any{1000.0, "1"}, []any{ts, "1"}}})
+		}
+		return map[string]any{"result": result}
+	}
+
+	got, ok := rangeSuggestion(series(4000, 4000, 2000, 1500), "1000", "4000", 2)
+	if !ok || got.Start != "2001" || got.EstimatedResults != 2 {
+		t.Fatalf("expected range starting after the 3rd most recent series, got %+v ok=%v", got, ok)
+	}
+	if _, ok := rangeSuggestion(series(4000, 4000, 4000), "1000", "4000", 2); ok {
+		t.Fatal("suggested a shorter range although every series is still active")
+	}
+}
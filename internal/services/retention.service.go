@@ -0,0 +1,533 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/qos"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const (
+	retentionPoliciesKey = "cfg:retention_policies"
+	retentionAuditKey    = "retention:audit"
+
+	// Cross-replica locks. The schedule lock is never released: it expires
+	// after one interval, so only one replica runs each scheduled purge.
+	retentionScheduleLockKey = "retention:lock:schedule"
+	retentionPurgeLockPrefix = "retention:lock:purge:"
+	retentionPoliciesLockKey = "retention:lock:policies"
+	retentionAuditLockKey    = "retention:lock:audit"
+	// retentionPurgeLockTTL bounds how long a crashed replica blocks purges
+	// of a policy; retentionLockWait is how long a call waits for a lock.
+	retentionPurgeLockTTL = 30 * time.Minute
+	retentionLockTTL      = 10 * time.Second
+	retentionLockWait     = 2 * time.Second
+
+	// maxRetentionAudit caps the purge audit kept in Valkey.
+	maxRetentionAudit = 500
+	// minRetention keeps a policy from purging data that is still being
+	// written.
+	minRetention              = 24 * time.Hour
+	defaultRetentionMaxSeries = 10000
+	// retentionDeleteBatch is the number of series selectors per delete call.
+	retentionDeleteBatch  = 100
+	retentionPreviewLimit = 20
+)
+
+var (
+	ErrInvalidRetentionPolicy  = errors.New("invalid retention policy")
+	ErrRetentionPolicyNotFound = errors.New("retention policy not found")
+	ErrRetentionUnsupported    = errors.New("backend for this signal is not configured")
+	ErrRetentionBusy           = errors.New("retention policy is being purged or updated by another replica")
+)
+
+// RetentionMetricsBackend lists and deletes metrics series.
+type RetentionMetricsBackend interface {
+	GetSeries(ctx context.Context, request *models.SeriesRequest) ([]map[string]string, error)
+	DeleteSeries(ctx context.Context, matches []string) error
+}
+
+// RetentionLogsBackend counts and deletes logs.
+type RetentionLogsBackend interface {
+	ExecuteQuery(ctx context.Context, req *models.LogsQLQueryRequest) (*models.LogsQLQueryResult, error)
+	DeleteLogs(ctx context.Context, filter string) ([]string, error)
+}
+
+// RetentionService stores retention policies that keep selected metrics or
+// logs for less time than the cluster default, and enforces them by issuing
+// deletes to VictoriaMetrics and VictoriaLogs. Every executed purge is
+// recorded in an audit trail.
+type RetentionService struct {
+	metrics RetentionMetricsBackend
+	logs    RetentionLogsBackend
+	cache   cache.ValkeyCluster
+	cfg     config.RetentionConfig
+	logger  logging.Logger
+
+	// purgeMu serializes purges from the schedule and the API in this
+	// replica; the Valkey purge lock covers the others.
+	purgeMu sync.Mutex
+}
+
+// NewRetentionService creates a new retention policy service. A nil backend
+// rejects policies for its signal.
+func NewRetentionService(metrics RetentionMetricsBackend, logs RetentionLogsBackend, cache cache.ValkeyCluster, cfg config.RetentionConfig, logger corelogger.Logger) *RetentionService {
+	if cfg.MaxSeriesPerRun <= 0 {
+		cfg.MaxSeriesPerRun = defaultRetentionMaxSeries
+	}
+	return &RetentionService{
+		metrics: metrics,
+		logs:    logs,
+		cache:   cache,
+		cfg:     cfg,
+		logger:  logging.FromCoreLogger(logger),
+	}
+}
+
+// ListPolicies returns the stored policies, oldest first.
+func (s *RetentionService) ListPolicies(ctx context.Context) ([]models.RetentionPolicy, error) {
+	data, err := s.cache.Get(ctx, retentionPoliciesKey)
+	if err != nil || len(data) == 0 {
+		return []models.RetentionPolicy{}, nil
+	}
+	var policies []models.RetentionPolicy
+	if err := json.Unmarshal(data, &policies); err != nil {
+		return nil, fmt.Errorf("decode retention policies: %w", err)
+	}
+	return policies, nil
+}
+
+// CreatePolicy validates and stores a new policy.
+func (s *RetentionService) CreatePolicy(ctx context.Context, p models.RetentionPolicy, createdBy string) (*models.RetentionPolicy, error) {
+	if err := s.validatePolicy(&p); err != nil {
+		return nil, err
+	}
+	p.ID = uuid.NewString()
+	p.CreatedBy = createdBy
+	p.CreatedAt = time.Now().UTC()
+	p.LastPurgeAt = nil
+	if err := s.updatePolicies(ctx, func(policies []models.RetentionPolicy) ([]models.RetentionPolicy, error) {
+		return append(policies, p), nil
+	}); err != nil {
+		return nil, err
+	}
+	s.logger.Info("Retention policy created", "id", p.ID, "signal", p.Signal, "selector", p.Selector, "retention", p.Retention)
+	return &p, nil
+}
+
+// UpdatePolicy replaces the selector, retention, description and enabled
+// flag of a policy.
+func (s *RetentionService) UpdatePolicy(ctx context.Context, id string, p models.RetentionPolicy) (*models.RetentionPolicy, error) {
+	if err := s.validatePolicy(&p); err != nil {
+		return nil, err
+	}
+	var updated *models.RetentionPolicy
+	err := s.updatePolicies(ctx, func(policies []models.RetentionPolicy) ([]models.RetentionPolicy, error) {
+		for i := range policies {
+			if policies[i].ID == id {
+				cur := &policies[i]
+				cur.Signal, cur.Selector, cur.Retention = p.Signal, p.Selector, p.Retention
+				cur.Enabled, cur.Description = p.Enabled, p.Description
+				cp := *cur
+				updated = &cp
+				return policies, nil
+			}
+		}
+		return nil, ErrRetentionPolicyNotFound
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("Retention policy updated", "id", id, "enabled", updated.Enabled, "retention", updated.Retention)
+	return updated, nil
+}
+
+// DeletePolicy removes a policy. Its audit records are kept.
+func (s *RetentionService) DeletePolicy(ctx context.Context, id string) error {
+	return s.updatePolicies(ctx, func(policies []models.RetentionPolicy) ([]models.RetentionPolicy, error) {
+		for i := range policies {
+			if policies[i].ID == id {
+				return append(policies[:i], policies[i+1:]...), nil
+			}
+		}
+		return nil, ErrRetentionPolicyNotFound
+	})
+}
+
+// Preview reports what purging the policy now would delete, without
+// deleting anything.
+func (s *RetentionService) Preview(ctx context.Context, id string) (*models.RetentionPreview, error) {
+	p, err := s.policy(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	cutoff := time.Now().UTC().Add(-policyRetention(p))
+	preview := &models.RetentionPreview{PolicyID: p.ID, Signal: p.Signal, Cutoff: cutoff}
+	switch p.Signal {
+	case models.RetentionSignalLogs:
+		preview.Filter = logsRetentionFilter(p.Selector, cutoff)
+		preview.Matched, err = s.countLogs(ctx, preview.Filter)
+	default:
+		preview.Filter = p.Selector
+		var stale []string
+		stale, err = s.staleSeries(ctx, p, cutoff)
+		preview.Matched = len(stale)
+		if len(stale) > retentionPreviewLimit {
+			stale = stale[:retentionPreviewLimit]
+		}
+		preview.Sample = stale
+	}
+	if err != nil {
+		return nil, err
+	}
+	return preview, nil
+}
+
+// Purge deletes the data the policy no longer retains and records the
+// purge, successful or not, in the audit trail. ErrRetentionBusy is
+// returned while another replica purges the policy.
+func (s *RetentionService) Purge(ctx context.Context, id, executedBy string) (*models.RetentionPurge, error) {
+	p, err := s.policy(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.purge(ctx, p, executedBy)
+}
+
+// GetPolicy returns a retention policy.
+func (s *RetentionService) GetPolicy(ctx context.Context, id string) (*models.RetentionPolicy, error) {
+	return s.policy(ctx, id)
+}
+
+// Audit returns executed purges, newest first.
+func (s *RetentionService) Audit(ctx context.Context) ([]models.RetentionPurge, error) {
+	data, err := s.cache.Get(ctx, retentionAuditKey)
+	if err != nil || len(data) == 0 {
+		return []models.RetentionPurge{}, nil
+	}
+	var audit []models.RetentionPurge
+	if err := json.Unmarshal(data, &audit); err != nil {
+		return nil, fmt.Errorf("decode retention audit: %w", err)
+	}
+	return audit, nil
+}
+
+// Start purges every enabled policy each Interval until ctx ends. With
+// several replicas, each interval's purge runs on one of them.
+func (s *RetentionService) Start(ctx context.Context) {
+	if s.cfg.Interval <= 0 {
+		return
+	}
+	ctx = qos.WithClass(ctx, qos.Batch)
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Expire the lock just before the next tick, so the next
+			// interval's purge can run on any replica.
+			acquired, err := s.cache.AcquireLock(ctx, retentionScheduleLockKey, s.cfg.Interval-s.cfg.Interval/10)
+			if err != nil {
+				s.logger.Warn("Failed to lock the retention schedule", "error", err)
+				continue
+			}
+			if !acquired {
+				continue
+			}
+			policies, err := s.ListPolicies(ctx)
+			if err != nil {
+				s.logger.Warn("Failed to load retention policies", "error", err)
+				continue
+			}
+			for i := range policies {
+				if !policies[i].Enabled || ctx.Err() != nil {
+					continue
+				}
+				if _, err := s.purge(ctx, &policies[i], "scheduler"); err != nil {
+					s.logger.Warn("Skipped scheduled retention purge", "policy", policies[i].ID, "error", err)
+				}
+			}
+		}
+	}
+}
+
+func (s *RetentionService) purge(ctx context.Context, p *models.RetentionPolicy, executedBy string) (*models.RetentionPurge, error) {
+	s.purgeMu.Lock()
+	defer s.purgeMu.Unlock()
+	unlock, err := lockCache(ctx, s.cache, retentionPurgeLockPrefix+p.ID, retentionPurgeLockTTL, retentionLockWait, ErrRetentionBusy, s.logger)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	now := time.Now().UTC()
+	rec := &models.RetentionPurge{
+		ID:         uuid.NewString(),
+		PolicyID:   p.ID,
+		Signal:     p.Signal,
+		Selector:   p.Selector,
+		Retention:  p.Retention,
+		Cutoff:     now.Add(-policyRetention(p)),
+		ExecutedBy: executedBy,
+		StartedAt:  now,
+	}
+	switch p.Signal {
+	case models.RetentionSignalLogs:
+		err = s.purgeLogs(ctx, rec)
+	default:
+		err = s.purgeMetrics(ctx, p, rec)
+	}
+	rec.FinishedAt = time.Now().UTC()
+	rec.Status = "succeeded"
+	if err != nil {
+		rec.Status, rec.Error = "failed", err.Error()
+		s.logger.Warn("Retention purge failed", "policy", p.ID, "signal", p.Signal, "error", err)
+	} else {
+		s.logger.Info("Retention purge completed", "policy", p.ID, "signal", p.Signal, "matched", rec.Matched, "executed_by", executedBy)
+	}
+
+	s.appendAudit(ctx, rec)
+	if err := s.updatePolicies(ctx, func(policies []models.RetentionPolicy) ([]models.RetentionPolicy, error) {
+		for i := range policies {
+			if policies[i].ID == p.ID {
+				policies[i].LastPurgeAt = &rec.FinishedAt
+			}
+		}
+		return policies, nil
+	}); err != nil {
+		s.logger.Warn("Failed to record retention purge time", "policy", p.ID, "error", err)
+	}
+	return rec, nil
+}
+
+// purgeLogs counts and deletes the expired logs. With tenant_labels set,
+// the logs service scopes both the count and the delete to the tenant.
+func (s *RetentionService) purgeLogs(ctx context.Context, rec *models.RetentionPurge) error {
+	if s.logs == nil {
+		return ErrRetentionUnsupported
+	}
+	filter := logsRetentionFilter(rec.Selector, rec.Cutoff)
+	n, err := s.countLogs(ctx, filter)
+	if err != nil {
+		return err
+	}
+	rec.Matched = n
+	if n == 0 {
+		return nil
+	}
+	rec.Tasks, err = s.logs.DeleteLogs(ctx, filter)
+	return err
+}
+
+func (s *RetentionService) purgeMetrics(ctx context.Context, p *models.RetentionPolicy, rec *models.RetentionPurge) error {
+	stale, err := s.staleSeries(ctx, p, rec.Cutoff)
+	if err != nil {
+		return err
+	}
+	rec.Matched = len(stale)
+	for start := 0; start < len(stale); start += retentionDeleteBatch {
+		end := min(start+retentionDeleteBatch, len(stale))
+		if err := s.metrics.DeleteSeries(ctx, stale[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// staleSeries returns exact selectors for the series matching the policy
+// that had samples in the retention period before cutoff but none since.
+// A series is skipped when its selector would also match an active series.
+func (s *RetentionService) staleSeries(ctx context.Context, p *models.RetentionPolicy, cutoff time.Time) ([]string, error) {
+	if s.metrics == nil {
+		return nil, ErrRetentionUnsupported
+	}
+	retention := policyRetention(p)
+	unix := func(t time.Time) string { return strconv.FormatInt(t.Unix(), 10) }
+	active, err := s.metrics.GetSeries(ctx, &models.SeriesRequest{Match: []string{p.Selector}, Start: unix(cutoff), End: unix(time.Now())})
+	if err != nil {
+		return nil, fmt.Errorf("list active series: %w", err)
+	}
+	older, err := s.metrics.GetSeries(ctx, &models.SeriesRequest{Match: []string{p.Selector}, Start: unix(cutoff.Add(-retention)), End: unix(cutoff)})
+	if err != nil {
+		return nil, fmt.Errorf("list expired series: %w", err)
+	}
+
+	activeKeys := make(map[string]bool, len(active))
+	for _, labels := range active {
+		activeKeys[seriesKey(labels)] = true
+	}
+	seen := map[string]bool{}
+	var out []string
+	for _, labels := range older {
+		key := seriesKey(labels)
+		if activeKeys[key] || seen[key] || selectsAny(labels, active) {
+			continue
+		}
+		seen[key] = true
+		out = append(out, exactSelector(labels))
+		if len(out) >= s.cfg.MaxSeriesPerRun {
+			break
+		}
+	}
+	return out, nil
+}
+
+func (s *RetentionService) countLogs(ctx context.Context, filter string) (int, error) {
+	if s.logs == nil {
+		return 0, ErrRetentionUnsupported
+	}
+	res, err := s.logs.ExecuteQuery(ctx, &models.LogsQLQueryRequest{Query: filter + " | stats count() rows"})
+	if err != nil {
+		return 0, fmt.Errorf("count expired logs: %w", err)
+	}
+	total := 0
+	for _, row := range res.Logs {
+		if n, ok := toInt(row["rows"]); ok {
+			total += n
+		} else if str, ok := row["rows"].(string); ok {
+			n, _ := strconv.Atoi(str)
+			total += n
+		}
+	}
+	return total, nil
+}
+
+func (s *RetentionService) validatePolicy(p *models.RetentionPolicy) error {
+	p.Signal = strings.ToLower(strings.TrimSpace(p.Signal))
+	p.Selector = strings.TrimSpace(p.Selector)
+	p.Retention = strings.TrimSpace(p.Retention)
+	switch p.Signal {
+	case models.RetentionSignalMetrics:
+		if s.metrics == nil {
+			return fmt.Errorf("%w: metrics: %v", ErrInvalidRetentionPolicy, ErrRetentionUnsupported)
+		}
+	case models.RetentionSignalLogs:
+		if s.logs == nil {
+			return fmt.Errorf("%w: logs: %v", ErrInvalidRetentionPolicy, ErrRetentionUnsupported)
+		}
+	default:
+		return fmt.Errorf("%w: signal must be metrics or logs", ErrInvalidRetentionPolicy)
+	}
+	if p.Selector == "" {
+		return fmt.Errorf("%w: selector is required", ErrInvalidRetentionPolicy)
+	}
+	d, ok := parseDayDuration(p.Retention)
+	if !ok || d < minRetention {
+		return fmt.Errorf("%w: retention must be a duration of at least 1d, such as 30d or 12w", ErrInvalidRetentionPolicy)
+	}
+	return nil
+}
+
+func (s *RetentionService) policy(ctx context.Context, id string) (*models.RetentionPolicy, error) {
+	policies, err := s.ListPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range policies {
+		if policies[i].ID == id {
+			return &policies[i], nil
+		}
+	}
+	return nil, ErrRetentionPolicyNotFound
+}
+
+// updatePolicies applies fn to the stored policies under the cross-replica
+// policies lock.
+func (s *RetentionService) updatePolicies(ctx context.Context, fn func([]models.RetentionPolicy) ([]models.RetentionPolicy, error)) error {
+	unlock, err := lockCache(ctx, s.cache, retentionPoliciesLockKey, retentionLockTTL, retentionLockWait, ErrRetentionBusy, s.logger)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	policies, err := s.ListPolicies(ctx)
+	if err != nil {
+		return err
+	}
+	if policies, err = fn(policies); err != nil {
+		return err
+	}
+	if err := s.cache.Set(ctx, retentionPoliciesKey, policies, 0); err != nil {
+		return fmt.Errorf("store retention policies: %w", err)
+	}
+	return nil
+}
+
+// appendAudit records rec under the cross-replica audit lock.
+func (s *RetentionService) appendAudit(ctx context.Context, rec *models.RetentionPurge) {
+	unlock, err := lockCache(ctx, s.cache, retentionAuditLockKey, retentionLockTTL, retentionLockWait, ErrRetentionBusy, s.logger)
+	if err != nil {
+		s.logger.Error("Failed to record retention purge", "policy", rec.PolicyID, "error", err)
+		return
+	}
+	defer unlock()
+	audit, err := s.Audit(ctx)
+	if err != nil {
+		s.logger.Warn("Resetting unreadable retention audit", "error", err)
+		audit = nil
+	}
+	audit = append([]models.RetentionPurge{*rec}, audit...)
+	if len(audit) > maxRetentionAudit {
+		audit = audit[:maxRetentionAudit]
+	}
+	if err := s.cache.Set(ctx, retentionAuditKey, audit, 0); err != nil {
+		s.logger.Error("Failed to record retention purge", "policy", rec.PolicyID, "error", err)
+	}
+}
+
+// policyRetention returns the validated retention period of p.
+func policyRetention(p *models.RetentionPolicy) time.Duration {
+	d, _ := parseDayDuration(p.Retention)
+	return d
+}
+
+func logsRetentionFilter(selector string, cutoff time.Time) string {
+	return fmt.Sprintf("(%s) _time:<%s", selector, cutoff.Format(time.RFC3339))
+}
+
+// exactSelector renders a selector matching the labels of one series.
+func exactSelector(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = name + "=" + strconv.Quote(labels[name])
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// selectsAny reports whether the exact selector of labels would also match
+// one of the series, i.e. a series carrying all of labels plus others.
+func selectsAny(labels map[string]string, series []map[string]string) bool {
+	for _, other := range series {
+		match := true
+		for k, v := range labels {
+			if other[k] != v {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
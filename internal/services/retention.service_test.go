@@ -0,0 +1,261 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// fakeRetentionMetrics returns active series on the first GetSeries call and
+// expired-window series on the second, matching staleSeries' call order.
+type fakeRetentionMetrics struct {
+	active, older []map[string]string
+	calls         int
+	deleted       []string
+}
+
+func (f *fakeRetentionMetrics) GetSeries(_ context.Context, _ *models.SeriesRequest) ([]map[string]string, error) {
+	f.calls++
+	if f.calls%2 == 1 {
+		return f.active, nil
+	}
+	return f.older, nil
+}
+
+func (f *fakeRetentionMetrics) DeleteSeries(_ context.Context, matches []string) error {
+	f.deleted = append(f.deleted, matches...)
+	return nil
+}
+
+type fakeRetentionLogs struct {
+	rows    any
+	filters []string
+	fail    error
+}
+
+func (f *fakeRetentionLogs) ExecuteQuery(_ context.Context, _ *models.LogsQLQueryRequest) (*models.LogsQLQueryResult, error) {
+	return &models.LogsQLQueryResult{Logs: []map[string]any{{"rows": f.rows}}}, nil
+}
+
+func (f *fakeRetentionLogs) DeleteLogs(_ context.Context, filter string) ([]string, error) {
+	f.filters = append(f.filters, filter)
+	if f.fail != nil {
+		return nil, f.fail
+	}
+	return []string{"task-1"}, nil
+}
+
+func TestRetentionService_Policies(t *testing.T) {
+	log := logger.New("error")
+	ctx := context.Background()
+	svc := NewRetentionService(&fakeRetentionMetrics{}, nil, cache.NewNoopValkeyCache(log), config.RetentionConfig{}, log)
+
+	for _, p := range []models.RetentionPolicy{
+		{Signal: "traces", Selector: `{job="a"}`, Retention: "30d"},
+		{Signal: "metrics", Retention: "30d"},
+		{Signal: "metrics", Selector: `{job="a"}`, Retention: "2h"},
+		{Signal: "logs", Selector: `app:a`, Retention: "30d"},
+	} {
+		if _, err := svc.CreatePolicy(ctx, p, ""); !errors.Is(err, ErrInvalidRetentionPolicy) {
+			t.Fatalf("expected ErrInvalidRetentionPolicy for %+v, got %v", p, err)
+		}
+	}
+	p, err := svc.CreatePolicy(ctx, models.RetentionPolicy{Signal: " Metrics ", Selector: `{job="batch"}`, Retention: "2w"}, "ops")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.ID == "" || p.Signal != models.RetentionSignalMetrics || p.CreatedBy != "ops" {
+		t.Fatalf("unexpected policy %+v", p)
+	}
+	p.Enabled = true
+	if _, err := svc.UpdatePolicy(ctx, p.ID, *p); err != nil {
+		t.Fatal(err)
+	}
+	if policies, _ := svc.ListPolicies(ctx); len(policies) != 1 || !policies[0].Enabled {
+		t.Fatalf("expected one enabled policy, got %+v", policies)
+	}
+	if err := svc.DeletePolicy(ctx, "missing"); !errors.Is(err, ErrRetentionPolicyNotFound) {
+		t.Fatalf("expected ErrRetentionPolicyNotFound, got %v", err)
+	}
+	if err := svc.DeletePolicy(ctx, p.ID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRetentionService_PurgeMetrics(t *testing.T) {
+	log := logger.New("error")
+	ctx := context.Background()
+	metrics := &fakeRetentionMetrics{
+		active: []map[string]string{
+			{"__name__": "up", "job": "batch", "instance": "a"},
+			{"__name__": "up", "job": "batch", "instance": "b", "zone": "z1"},
+		},
+		older: []map[string]string{
+			{"__name__": "up", "job": "batch", "instance": "a"},
+			{"__name__": "up", "job": "batch", "instance": "b"},
+			{"__name__": "up", "job": "batch", "instance": "c"},
+		},
+	}
+	svc := NewRetentionService(metrics, nil, cache.NewNoopValkeyCache(log), config.RetentionConfig{}, log)
+	p, err := svc.CreatePolicy(ctx, models.RetentionPolicy{Signal: "metrics", Selector: `{job="batch"}`, Retention: "30d"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	preview, err := svc.Preview(ctx, p.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{__name__="up",instance="c",job="batch"}`
+	// instance b is stale but its selector would also match the active series in zone z1.
+	if preview.Matched != 1 || len(preview.Sample) != 1 || preview.Sample[0] != want {
+		t.Fatalf("unexpected preview %+v", preview)
+	}
+	if len(metrics.deleted) != 0 {
+		t.Fatalf("preview must not delete, deleted %v", metrics.deleted)
+	}
+
+	purge, err := svc.Purge(ctx, p.ID, "ops")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if purge.Status != "succeeded" || purge.Matched != 1 || purge.ExecutedBy != "ops" {
+		t.Fatalf("unexpected purge %+v", purge)
+	}
+	if len(metrics.deleted) != 1 || metrics.deleted[0] != want {
+		t.Fatalf("unexpected deletes %v", metrics.deleted)
+	}
+	audit, _ := svc.Audit(ctx)
+	if len(audit) != 1 || audit[0].ID != purge.ID {
+		t.Fatalf("expected the purge in the audit, got %+v", audit)
+	}
+	if policies, _ := svc.ListPolicies(ctx); policies[0].LastPurgeAt == nil {
+		t.Fatal("expected last purge time to be recorded")
+	}
+}
+
+func TestRetentionService_PurgeLogsAuditsFailures(t *testing.T) {
+	log := logger.New("error")
+	ctx := context.Background()
+	logs := &fakeRetentionLogs{rows: "42", fail: errors.New("delete disabled")}
+	svc := NewRetentionService(nil, logs, cache.NewNoopValkeyCache(log), config.RetentionConfig{}, log)
+	p, err := svc.CreatePolicy(ctx, models.RetentionPolicy{Signal: "logs", Selector: `app:debug`, Retention: "7d"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	preview, err := svc.Preview(ctx, p.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preview.Matched != 42 || !strings.HasPrefix(preview.Filter, "(app:debug) _time:<") {
+		t.Fatalf("unexpected preview %+v", preview)
+	}
+
+	purge, err := svc.Purge(ctx, p.ID, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if purge.Status != "failed" || purge.Error == "" || len(logs.filters) != 1 {
+		t.Fatalf("expected a failed purge, got %+v", purge)
+	}
+	if audit, _ := svc.Audit(ctx); len(audit) != 1 || audit[0].Status != "failed" {
+		t.Fatalf("expected the failed purge in the audit, got %+v", audit)
+	}
+}
+
+func TestRetentionService_PurgeAcrossReplicas(t *testing.T) {
+	log := logger.New("error")
+	ctx := context.Background()
+	shared := newLockingCache()
+	logsA, logsB := &fakeRetentionLogs{rows: "3"}, &fakeRetentionLogs{rows: "3"}
+	cfg := config.RetentionConfig{Interval: 20 * time.Millisecond}
+	a := NewRetentionService(nil, logsA, shared, cfg, log)
+	b := NewRetentionService(nil, logsB, shared, cfg, log)
+	p, err := a.CreatePolicy(ctx, models.RetentionPolicy{Signal: "logs", Selector: `app:debug`, Retention: "7d", Enabled: true}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A purge running on another replica holds the policy's lock.
+	if ok, _ := shared.AcquireLock(ctx, retentionPurgeLockPrefix+p.ID, time.Minute); !ok {
+		t.Fatal("expected to take the purge lock")
+	}
+	if _, err := b.Purge(ctx, p.ID, ""); !errors.Is(err, ErrRetentionBusy) {
+		t.Fatalf("expected ErrRetentionBusy, got %v", err)
+	}
+	if len(logsB.filters) != 0 {
+		t.Fatal("no delete may run while another replica purges")
+	}
+	_ = shared.ReleaseLock(ctx, retentionPurgeLockPrefix+p.ID)
+
+	// Each scheduled interval purges on one replica only.
+	runCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	var wg sync.WaitGroup
+	for _, svc := range []*RetentionService{a, b} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			svc.Start(runCtx)
+		}()
+	}
+	wg.Wait()
+	if n := len(logsA.filters) + len(logsB.filters); n != 1 {
+		t.Fatalf("expected one scheduled purge across replicas, got %d", n)
+	}
+	if audit, _ := a.Audit(ctx); len(audit) != 1 {
+		t.Fatalf("expected one audit record, got %d", len(audit))
+	}
+}
+
+func TestRetentionService_PurgeLogsScopedToTenant(t *testing.T) {
+	log := logger.New("error")
+	ctx := context.Background()
+	var countScope, deleteFilter string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/select/"):
+			countScope = r.URL.Query().Get("extra_filters")
+			_, _ = w.Write([]byte(`{"rows":"3"}` + "\n"))
+		case r.URL.Path == "/delete/run_task":
+			_ = r.ParseForm()
+			deleteFilter = r.PostForm.Get("filter")
+			_, _ = w.Write([]byte(`{"task_id":"t1"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	logs := NewVictoriaLogsService(config.VictoriaLogsConfig{Endpoints: []string{srv.URL}, Timeout: 2000}, log)
+	logs.SetTenantLabels(map[string]string{"environment": "prod"})
+	svc := NewRetentionService(nil, logs, cache.NewNoopValkeyCache(log), config.RetentionConfig{}, log)
+	p, err := svc.CreatePolicy(ctx, models.RetentionPolicy{Signal: "logs", Selector: `app:debug`, Retention: "7d"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	purge, err := svc.Purge(ctx, p.ID, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if purge.Status != "succeeded" || purge.Matched != 3 {
+		t.Fatalf("unexpected purge %+v", purge)
+	}
+	if countScope != `environment:="prod"` {
+		t.Fatalf("count not scoped to the tenant: %q", countScope)
+	}
+	if !strings.HasPrefix(deleteFilter, `environment:="prod" ((app:debug) _time:<`) {
+		t.Fatalf("delete not scoped to the tenant: %q", deleteFilter)
+	}
+}
@@ -4,6 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
 	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
@@ -15,10 +20,54 @@ type RuntimeFeatureFlags struct {
 	UserSettingsEnabled bool `json:"user_settings_enabled" yaml:"user_settings_enabled"`
 }
 
-// RuntimeFeatureFlagService manages runtime feature flags stored in cache
+// FeatureFlag is a rollout flag for a new capability. A flag is on for a
+// caller when it is enabled and the caller is explicitly targeted (tenant or
+// user allow-list) or falls into the rollout percentage bucket.
+type FeatureFlag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Enabled     bool   `json:"enabled"`
+	// RolloutPercentage (0-100) enables the flag for a stable subset of
+	// callers, bucketed by user ID (or tenant ID when no user is known).
+	RolloutPercentage int       `json:"rolloutPercentage"`
+	Tenants           []string  `json:"tenants,omitempty"`
+	Users             []string  `json:"users,omitempty"`
+	UpdatedAt         time.Time `json:"updatedAt"`
+}
+
+// FeatureFlagContext identifies the caller a flag is evaluated for.
+type FeatureFlagContext struct {
+	TenantID string `json:"tenantId,omitempty"`
+	UserID   string `json:"userId,omitempty"`
+}
+
+var featureFlagNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]{0,63}$`)
+
+// featureFlagCacheTTL bounds how long a replica serves a cached flag set;
+// changes made through another replica (or a rollback) show up within it.
+const featureFlagCacheTTL = 5 * time.Second
+
+// featureFlagDefaults is the state of flags gating routes that shipped
+// before the flag existed, used until an admin defines the flag. Undefined
+// flags not listed here evaluate to false.
+var featureFlagDefaults = map[string]bool{
+	"live_correlation": true,
+}
+
+// RuntimeFeatureFlagService manages runtime feature flags stored in cache:
+// the system toggles (RuntimeFeatureFlags) and rollout flags (FeatureFlag)
+// with tenant/user targeting and percentage rollouts. Rollout flags are
+// persisted through DynamicConfigService so they are versioned and can be
+// flipped at runtime; evaluations are served from a short-lived in-memory
+// copy of the flag set.
 type RuntimeFeatureFlagService struct {
 	cache  cache.ValkeyCluster
 	logger logger.Logger
+	store  *DynamicConfigService
+
+	mu       sync.Mutex
+	flags    map[string]*FeatureFlag
+	loadedAt time.Time
 }
 
 // NewRuntimeFeatureFlagService creates a new runtime feature flag service
@@ -26,9 +75,19 @@ func NewRuntimeFeatureFlagService(cache cache.ValkeyCluster, logger logger.Logge
 	return &RuntimeFeatureFlagService{
 		cache:  cache,
 		logger: logger,
+		store:  NewDynamicConfigService(cache, logger),
 	}
 }
 
+// SetConfigStore shares a DynamicConfigService with other subsystems, so
+// rollout flag updates use its lock and version history.
+func (s *RuntimeFeatureFlagService) SetConfigStore(store *DynamicConfigService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.store = store
+	s.flags = nil
+}
+
 // GetFeatureFlags retrieves the current runtime feature flags
 func (s *RuntimeFeatureFlagService) GetFeatureFlags(ctx context.Context) (*RuntimeFeatureFlags, error) {
 	cacheKey := "runtime_features:system"
@@ -100,3 +159,146 @@ func (s *RuntimeFeatureFlagService) getDefaultFeatureFlags() *RuntimeFeatureFlag
 		UserSettingsEnabled: true,
 	}
 }
+
+// ListFlags returns all flag definitions sorted by name.
+func (s *RuntimeFeatureFlagService) ListFlags(ctx context.Context) ([]*FeatureFlag, error) {
+	flags, err := s.flagSet(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*FeatureFlag, 0, len(flags))
+	for _, f := range flags {
+		out = append(out, f)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+// UpsertFlag creates or replaces a flag definition on behalf of author.
+func (s *RuntimeFeatureFlagService) UpsertFlag(ctx context.Context, flag *FeatureFlag, author string) (*FeatureFlag, error) {
+	if !featureFlagNamePattern.MatchString(flag.Name) {
+		return nil, fmt.Errorf("invalid flag name %q: use lowercase letters, digits, '.', '_' or '-'", flag.Name)
+	}
+	if flag.RolloutPercentage < 0 || flag.RolloutPercentage > 100 {
+		return nil, fmt.Errorf("rolloutPercentage must be between 0 and 100, got %d", flag.RolloutPercentage)
+	}
+
+	_, err := s.store.UpdateFeatureFlagSet(ctx, author, func(flags map[string]*FeatureFlag) error {
+		flag.UpdatedAt = time.Now().UTC()
+		flags[flag.Name] = flag
+		return nil
+	})
+	s.invalidate()
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("Feature flag updated", "flag", flag.Name, "enabled", flag.Enabled, "rollout", flag.RolloutPercentage)
+	return flag, nil
+}
+
+// DeleteFlag removes a flag definition. Deleted flags evaluate to false.
+func (s *RuntimeFeatureFlagService) DeleteFlag(ctx context.Context, name, author string) (bool, error) {
+	v, err := s.store.UpdateFeatureFlagSet(ctx, author, func(flags map[string]*FeatureFlag) error {
+		if _, ok := flags[name]; !ok {
+			return errConfigUnchanged
+		}
+		delete(flags, name)
+		return nil
+	})
+	s.invalidate()
+	if err != nil || v == nil {
+		return false, err
+	}
+	s.logger.Info("Feature flag deleted", "flag", name)
+	return true, nil
+}
+
+// Evaluate returns the state of the named flags (all flags when names is
+// empty) for the given caller. Undefined flags take their featureFlagDefaults
+// state, false when they have none.
+func (s *RuntimeFeatureFlagService) Evaluate(ctx context.Context, fctx FeatureFlagContext, names ...string) (map[string]bool, error) {
+	flags, err := s.flagSet(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		for name := range flags {
+			names = append(names, name)
+		}
+	}
+	out := make(map[string]bool, len(names))
+	for _, name := range names {
+		flag, ok := flags[name]
+		if !ok {
+			out[name] = featureFlagDefaults[name]
+			continue
+		}
+		out[name] = evaluateFeatureFlag(flag, fctx)
+	}
+	return out, nil
+}
+
+// flagSet returns the rollout flag set, reloading it from the store once it
+// is older than featureFlagCacheTTL. The set is shared and must not be
+// modified.
+func (s *RuntimeFeatureFlagService) flagSet(ctx context.Context) (map[string]*FeatureFlag, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.flags != nil && time.Since(s.loadedAt) < featureFlagCacheTTL {
+		return s.flags, nil
+	}
+	flags, err := s.store.GetFeatureFlagSet(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.flags, s.loadedAt = flags, time.Now()
+	return flags, nil
+}
+
+// invalidate drops the cached flag set after a local update.
+func (s *RuntimeFeatureFlagService) invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.flags = nil
+}
+
+// IsFeatureEnabled evaluates a single flag. Storage errors fail closed.
+func (s *RuntimeFeatureFlagService) IsFeatureEnabled(ctx context.Context, name, tenantID, userID string) bool {
+	res, err := s.Evaluate(ctx, FeatureFlagContext{TenantID: tenantID, UserID: userID}, name)
+	if err != nil {
+		s.logger.Warn("Feature flag evaluation failed, treating as disabled", "flag", name, "error", err)
+		return false
+	}
+	return res[name]
+}
+
+func evaluateFeatureFlag(flag *FeatureFlag, fctx FeatureFlagContext) bool {
+	if flag == nil || !flag.Enabled {
+		return false
+	}
+	if fctx.UserID != "" && containsString(flag.Users, fctx.UserID) {
+		return true
+	}
+	if fctx.TenantID != "" && containsString(flag.Tenants, fctx.TenantID) {
+		return true
+	}
+	if flag.RolloutPercentage >= 100 {
+		return true
+	}
+	if flag.RolloutPercentage <= 0 {
+		return false
+	}
+	subject := fctx.UserID
+	if subject == "" {
+		subject = fctx.TenantID
+	}
+	return rolloutBucket(flag.Name, subject) < flag.RolloutPercentage
+}
+
+// rolloutBucket maps a flag/subject pair onto a stable bucket in [0,100).
+// Including the flag name keeps rollouts of different flags independent.
+func rolloutBucket(flag, subject string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(flag + ":" + subject))
+	return int(h.Sum32() % 100)
+}
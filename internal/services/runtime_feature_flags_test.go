@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func newTestFeatureFlagService() *RuntimeFeatureFlagService {
+	log := logger.New("error")
+	return NewRuntimeFeatureFlagService(cache.NewNoopValkeyCache(log), log)
+}
+
+func TestRuntimeFeatureFlagService_Targeting(t *testing.T) {
+	svc := newTestFeatureFlagService()
+	ctx := context.Background()
+
+	if _, err := svc.UpsertFlag(ctx, &FeatureFlag{
+		Name:    "uql_v2",
+		Enabled: true,
+		Tenants: []string{"acme"},
+		Users:   []string{"alice"},
+	}, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		tenant, user string
+		want         bool
+	}{
+		{"acme", "", true},
+		{"other", "alice", true},
+		{"other", "bob", false},
+	}
+	for _, tc := range cases {
+		if got := svc.IsFeatureEnabled(ctx, "uql_v2", tc.tenant, tc.user); got != tc.want {
+			t.Fatalf("tenant=%s user=%s: expected %v, got %v", tc.tenant, tc.user, tc.want, got)
+		}
+	}
+
+	if svc.IsFeatureEnabled(ctx, "unknown_flag", "acme", "alice") {
+		t.Fatal("unknown flags must evaluate to false")
+	}
+
+	deleted, err := svc.DeleteFlag(ctx, "uql_v2", "")
+	if err != nil || !deleted {
+		t.Fatalf("expected delete, got %v err=%v", deleted, err)
+	}
+	if svc.IsFeatureEnabled(ctx, "uql_v2", "acme", "") {
+		t.Fatal("deleted flag must evaluate to false")
+	}
+}
+
+func TestRuntimeFeatureFlagService_PercentageRollout(t *testing.T) {
+	svc := newTestFeatureFlagService()
+	ctx := context.Background()
+
+	if _, err := svc.UpsertFlag(ctx, &FeatureFlag{Name: "live_correlation", Enabled: true, RolloutPercentage: 30}, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	on := 0
+	for i := 0; i < 2000; i++ {
+		user := fmt.Sprintf("user-%d", i)
+		first := svc.IsFeatureEnabled(ctx, "live_correlation", "t", user)
+		if first != svc.IsFeatureEnabled(ctx, "live_correlation", "t", user) {
+			t.Fatalf("rollout must be stable for %s", user)
+		}
+		if first {
+			on++
+		}
+	}
+	if on < 450 || on > 750 {
+		t.Fatalf("expected roughly 30%% of users enabled, got %d/2000", on)
+	}
+}
+
+func TestRuntimeFeatureFlagService_Validation(t *testing.T) {
+	svc := newTestFeatureFlagService()
+	ctx := context.Background()
+
+	if _, err := svc.UpsertFlag(ctx, &FeatureFlag{Name: "Bad Name", Enabled: true}, ""); err == nil {
+		t.Fatal("expected invalid name error")
+	}
+	if _, err := svc.UpsertFlag(ctx, &FeatureFlag{Name: "ok", RolloutPercentage: 101}, ""); err == nil {
+		t.Fatal("expected invalid percentage error")
+	}
+}
+
+func TestRuntimeFeatureFlagService_CachesFlagSet(t *testing.T) {
+	log := logger.New("error")
+	shared := cache.NewNoopValkeyCache(log)
+	reader := NewRuntimeFeatureFlagService(shared, log)
+	writer := NewRuntimeFeatureFlagService(shared, log)
+	ctx := context.Background()
+
+	if reader.IsFeatureEnabled(ctx, "uql_v2", "acme", "") {
+		t.Fatal("undefined flag must evaluate to false")
+	}
+	if _, err := writer.UpsertFlag(ctx, &FeatureFlag{Name: "uql_v2", Enabled: true, RolloutPercentage: 100}, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !writer.IsFeatureEnabled(ctx, "uql_v2", "acme", "") {
+		t.Fatal("a local update must be visible immediately")
+	}
+	if reader.IsFeatureEnabled(ctx, "uql_v2", "acme", "") {
+		t.Fatal("another replica must serve its cached flag set until it expires")
+	}
+
+	reader.loadedAt = time.Now().Add(-featureFlagCacheTTL)
+	if !reader.IsFeatureEnabled(ctx, "uql_v2", "acme", "") {
+		t.Fatal("an expired flag set must be reloaded")
+	}
+}
+
+func TestRuntimeFeatureFlagService_Defaults(t *testing.T) {
+	svc := newTestFeatureFlagService()
+	ctx := context.Background()
+
+	if !svc.IsFeatureEnabled(ctx, "live_correlation", "acme", "") {
+		t.Fatal("live_correlation must default to enabled until defined")
+	}
+	if _, err := svc.UpsertFlag(ctx, &FeatureFlag{Name: "live_correlation", Enabled: true, Tenants: []string{"beta"}}, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if svc.IsFeatureEnabled(ctx, "live_correlation", "acme", "") {
+		t.Fatal("a defined flag must override its default")
+	}
+	if !svc.IsFeatureEnabled(ctx, "live_correlation", "beta", "") {
+		t.Fatal("targeted tenant must get the flag")
+	}
+}
@@ -0,0 +1,328 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const (
+	httpRequestsMetric        = "mirador_core_http_requests_total"
+	httpRequestDurationMetric = "mirador_core_http_request_duration_seconds"
+
+	selfSLONotificationType = "self_slo"
+)
+
+// SelfSLONotifier delivers breach notifications; NotificationService
+// satisfies it.
+type SelfSLONotifier interface {
+	SendNotification(ctx context.Context, notification *models.Notification) error
+}
+
+// selfSLOCounts are the cumulative counters of one target.
+type selfSLOCounts struct {
+	requests uint64
+	errors   uint64
+	fast     uint64 // requests within the latency bucket
+}
+
+type selfSLOSnapshot struct {
+	at     time.Time
+	counts []selfSLOCounts // indexed like SelfSLOService.targets
+}
+
+// SelfSLOService measures mirador-core's own endpoints against their latency
+// and availability objectives. Counters come from the in-process HTTP
+// metrics; the rolling window is the difference to a snapshot taken at
+// least Window ago.
+type SelfSLOService struct {
+	gatherer prometheus.Gatherer
+	notifier SelfSLONotifier
+	cfg      config.SelfSLOConfig
+	targets  []config.SelfSLOTarget
+	logger   logging.Logger
+
+	mu        sync.Mutex
+	started   time.Time
+	snapshots []selfSLOSnapshot
+	breached  map[string]bool
+}
+
+// NewSelfSLOService creates a new self-SLO service. notifier may be nil.
+func NewSelfSLOService(gatherer prometheus.Gatherer, notifier SelfSLONotifier, cfg config.SelfSLOConfig, logger corelogger.Logger) *SelfSLOService {
+	if cfg.Window <= 0 {
+		cfg.Window = time.Hour
+	}
+	targets := cfg.Targets
+	if len(targets) == 0 {
+		targets = config.DefaultSelfSLOTargets
+	}
+	return &SelfSLOService{
+		gatherer: gatherer,
+		notifier: notifier,
+		cfg:      cfg,
+		targets:  targets,
+		logger:   logging.FromCoreLogger(logger),
+		started:  time.Now(),
+		breached: map[string]bool{},
+	}
+}
+
+// Status reports every target over the current window.
+func (s *SelfSLOService) Status(ctx context.Context) (*models.SelfSLOReport, error) {
+	counts, bounds, err := s.collect()
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.report(time.Now(), counts, bounds), nil
+}
+
+// Start samples the counters every EvaluationInterval and notifies when a
+// target starts or stops breaching.
+func (s *SelfSLOService) Start(ctx context.Context) {
+	if s.cfg.EvaluationInterval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(s.cfg.EvaluationInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.evaluate(ctx, now); err != nil && ctx.Err() == nil {
+				s.logger.Warn("Self-SLO evaluation failed", "error", err)
+			}
+		}
+	}
+}
+
+func (s *SelfSLOService) evaluate(ctx context.Context, now time.Time) error {
+	counts, bounds, err := s.collect()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	report := s.report(now, counts, bounds)
+	s.snapshots = append(s.snapshots, selfSLOSnapshot{at: now, counts: counts})
+	var changed []models.SelfSLOStatus
+	for _, t := range report.Targets {
+		if t.Breached != s.breached[t.Name] {
+			s.breached[t.Name] = t.Breached
+			changed = append(changed, t)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, t := range changed {
+		if t.Breached {
+			s.logger.Warn("Self-SLO breached", "target", t.Name, "reasons", t.Reasons)
+		} else {
+			s.logger.Info("Self-SLO recovered", "target", t.Name)
+		}
+		if !s.cfg.Notify || s.notifier == nil {
+			continue
+		}
+		if err := s.notifier.SendNotification(ctx, selfSLONotification(t, report.Window, now)); err != nil {
+			s.logger.Warn("Self-SLO notification failed", "target", t.Name, "error", err)
+		}
+	}
+	return nil
+}
+
+// report diffs counts against the window baseline. Callers hold s.mu.
+func (s *SelfSLOService) report(now time.Time, counts []selfSLOCounts, bounds []float64) *models.SelfSLOReport {
+	baseline, since := s.baseline(now)
+	report := &models.SelfSLOReport{
+		Window:      s.cfg.Window.String(),
+		Coverage:    now.Sub(since).Truncate(time.Second).String(),
+		Targets:     make([]models.SelfSLOStatus, 0, len(s.targets)),
+		EvaluatedAt: now,
+	}
+	for i, t := range s.targets {
+		cur := counts[i]
+		var base selfSLOCounts
+		if baseline != nil {
+			base = baseline[i]
+		}
+		st := models.SelfSLOStatus{
+			Name:                  t.Name,
+			Method:                t.Method,
+			Endpoint:              t.Endpoint,
+			Requests:              counterDelta(cur.requests, base.requests),
+			Errors:                counterDelta(cur.errors, base.errors),
+			Availability:          1,
+			AvailabilityObjective: t.AvailabilityObjective,
+			ErrorBudgetRemaining:  1,
+			LatencyThreshold:      t.LatencyThreshold.String(),
+			LatencyCompliance:     1,
+			LatencyObjective:      t.LatencyObjective,
+		}
+		if !math.IsInf(bounds[i], 1) {
+			st.LatencyThreshold = time.Duration(bounds[i] * float64(time.Second)).String()
+		}
+		if st.Requests > 0 {
+			total := float64(st.Requests)
+			errRate := float64(st.Errors) / total
+			st.Availability = 1 - errRate
+			if allowed := 1 - t.AvailabilityObjective; allowed > 0 {
+				st.ErrorBudgetRemaining = 1 - errRate/allowed
+			}
+			st.LatencyCompliance = math.Min(1, float64(counterDelta(cur.fast, base.fast))/total)
+		}
+		if st.Requests >= uint64(s.cfg.MinRequests) && st.Requests > 0 {
+			if st.Availability < t.AvailabilityObjective {
+				st.Reasons = append(st.Reasons, fmt.Sprintf("availability %.4f below objective %.4f", st.Availability, t.AvailabilityObjective))
+			}
+			if st.LatencyCompliance < t.LatencyObjective {
+				st.Reasons = append(st.Reasons, fmt.Sprintf("%.2f%% of requests within %s, objective %.2f%%", st.LatencyCompliance*100, st.LatencyThreshold, t.LatencyObjective*100))
+			}
+			st.Breached = len(st.Reasons) > 0
+		}
+		if st.Breached {
+			report.Breached++
+		}
+		report.Targets = append(report.Targets, st)
+	}
+	return report
+}
+
+// baseline returns the newest snapshot at least Window old, dropping older
+// ones. Until one exists the window starts at service creation, when every
+// counter was zero.
+func (s *SelfSLOService) baseline(now time.Time) ([]selfSLOCounts, time.Time) {
+	cutoff := now.Add(-s.cfg.Window)
+	idx := -1
+	for i, snap := range s.snapshots {
+		if snap.at.After(cutoff) {
+			break
+		}
+		idx = i
+	}
+	if idx < 0 {
+		return nil, s.started
+	}
+	s.snapshots = s.snapshots[idx:]
+	return s.snapshots[0].counts, s.snapshots[0].at
+}
+
+// collect reads the cumulative counters of every target, plus the histogram
+// bucket bound (seconds) used as each target's latency threshold.
+func (s *SelfSLOService) collect() ([]selfSLOCounts, []float64, error) {
+	families, err := s.gatherer.Gather()
+	if err != nil {
+		return nil, nil, fmt.Errorf("gather metrics: %w", err)
+	}
+	counts := make([]selfSLOCounts, len(s.targets))
+	bounds := make([]float64, len(s.targets))
+	for i := range bounds {
+		bounds[i] = math.Inf(1)
+	}
+	for _, mf := range families {
+		switch mf.GetName() {
+		case httpRequestsMetric:
+			for _, m := range mf.GetMetric() {
+				i := s.targetIndex(m)
+				if i < 0 {
+					continue
+				}
+				n := uint64(m.GetCounter().GetValue())
+				counts[i].requests += n
+				if code, _ := strconv.Atoi(labelValue(m, "status_code")); code >= 500 {
+					counts[i].errors += n
+				}
+			}
+		case httpRequestDurationMetric:
+			for _, m := range mf.GetMetric() {
+				i := s.targetIndex(m)
+				if i < 0 {
+					continue
+				}
+				h := m.GetHistogram()
+				fast, bound := h.GetSampleCount(), math.Inf(1)
+				limit := s.targets[i].LatencyThreshold.Seconds()
+				for _, b := range h.GetBucket() {
+					// Small tolerance so 2.5s matches the 2.5 bucket exactly.
+					if b.GetUpperBound() >= limit-1e-9 {
+						fast, bound = b.GetCumulativeCount(), b.GetUpperBound()
+						break
+					}
+				}
+				counts[i].fast += fast
+				bounds[i] = bound
+			}
+		}
+	}
+	return counts, bounds, nil
+}
+
+func (s *SelfSLOService) targetIndex(m *dto.Metric) int {
+	method, endpoint := labelValue(m, "method"), labelValue(m, "endpoint")
+	for i, t := range s.targets {
+		if t.Endpoint == endpoint && strings.EqualFold(t.Method, method) {
+			return i
+		}
+	}
+	return -1
+}
+
+func labelValue(m *dto.Metric, name string) string {
+	for _, lp := range m.GetLabel() {
+		if lp.GetName() == name {
+			return lp.GetValue()
+		}
+	}
+	return ""
+}
+
+func counterDelta(cur, base uint64) uint64 {
+	if cur < base {
+		return cur
+	}
+	return cur - base
+}
+
+func selfSLONotification(t models.SelfSLOStatus, window string, now time.Time) *models.Notification {
+	n := &models.Notification{
+		ID:        fmt.Sprintf("self-slo-%s-%d", t.Name, now.Unix()),
+		Type:      selfSLONotificationType,
+		Component: "mirador-core",
+		Timestamp: now,
+		Data: map[string]interface{}{
+			"slo":      t.Name,
+			"method":   t.Method,
+			"endpoint": t.Endpoint,
+			"window":   window,
+			"breached": t.Breached,
+			"reasons":  t.Reasons,
+		},
+	}
+	if !t.Breached {
+		n.Title = fmt.Sprintf("mirador-core SLO recovered: %s", t.Name)
+		n.Message = fmt.Sprintf("%s %s is back within its objectives over the last %s.", t.Method, t.Endpoint, window)
+		n.Severity = "info"
+		return n
+	}
+	n.Title = fmt.Sprintf("mirador-core SLO breached: %s", t.Name)
+	n.Message = fmt.Sprintf("%s %s over the last %s: %s.", t.Method, t.Endpoint, window, strings.Join(t.Reasons, "; "))
+	n.Severity = "warning"
+	if t.Availability < t.AvailabilityObjective {
+		n.Severity = "critical"
+	}
+	return n
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+type recordingNotifier struct {
+	sent []*models.Notification
+}
+
+func (r *recordingNotifier) SendNotification(ctx context.Context, n *models.Notification) error {
+	r.sent = append(r.sent, n)
+	return nil
+}
+
+// selfSLORegistry mirrors the HTTP metrics registered by internal/metrics.
+func selfSLORegistry() (*prometheus.Registry, *prometheus.CounterVec, *prometheus.HistogramVec) {
+	reg := prometheus.NewRegistry()
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{Name: httpRequestsMetric, Help: "requests"}, []string{"method", "endpoint", "status_code"})
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: httpRequestDurationMetric, Help: "duration", Buckets: prometheus.DefBuckets}, []string{"method", "endpoint"})
+	reg.MustRegister(requests, duration)
+	return reg, requests, duration
+}
+
+func observeRequests(requests *prometheus.CounterVec, duration *prometheus.HistogramVec, method, endpoint, code string, n int, latency time.Duration) {
+	for i := 0; i < n; i++ {
+		requests.WithLabelValues(method, endpoint, code).Inc()
+		duration.WithLabelValues(method, endpoint).Observe(latency.Seconds())
+	}
+}
+
+func TestSelfSLOService_Status(t *testing.T) {
+	reg, requests, duration := selfSLORegistry()
+	observeRequests(requests, duration, "POST", "/api/v1/unified/query", "200", 90, 100*time.Millisecond)
+	observeRequests(requests, duration, "POST", "/api/v1/unified/query", "500", 5, 100*time.Millisecond)
+	observeRequests(requests, duration, "POST", "/api/v1/unified/query", "400", 5, 3*time.Second)
+	observeRequests(requests, duration, "GET", "/api/v1/unified/query", "500", 50, time.Millisecond) // other method
+
+	svc := NewSelfSLOService(reg, nil, config.SelfSLOConfig{
+		MinRequests: 50,
+		Targets: []config.SelfSLOTarget{
+			{Name: "query", Method: "post", Endpoint: "/api/v1/unified/query", LatencyThreshold: 2 * time.Second, LatencyObjective: 0.9, AvailabilityObjective: 0.99},
+			{Name: "idle", Method: "GET", Endpoint: "/api/v1/kpi/defs", LatencyThreshold: time.Second, LatencyObjective: 0.9, AvailabilityObjective: 0.99},
+		},
+	}, logger.New("error"))
+
+	report, err := svc.Status(context.Background())
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if len(report.Targets) != 2 || report.Breached != 1 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	q := report.Targets[0]
+	if q.Requests != 100 || q.Errors != 5 {
+		t.Fatalf("expected 100 requests / 5 errors, got %d / %d", q.Requests, q.Errors)
+	}
+	if q.Availability != 0.95 || q.LatencyCompliance != 0.95 || q.LatencyThreshold != "2.5s" {
+		t.Fatalf("unexpected measurements: %+v", q)
+	}
+	if !q.Breached || len(q.Reasons) != 1 || q.ErrorBudgetRemaining >= 0 {
+		t.Fatalf("expected availability breach only, got %+v", q)
+	}
+	if idle := report.Targets[1]; idle.Breached || idle.Requests != 0 || idle.Availability != 1 {
+		t.Fatalf("idle target should be healthy: %+v", idle)
+	}
+}
+
+func TestSelfSLOService_WindowAndNotifications(t *testing.T) {
+	reg, requests, duration := selfSLORegistry()
+	notifier := &recordingNotifier{}
+	svc := NewSelfSLOService(reg, notifier, config.SelfSLOConfig{
+		Window:      time.Hour,
+		MinRequests: 10,
+		Notify:      true,
+		Targets: []config.SelfSLOTarget{
+			{Name: "query", Method: "POST", Endpoint: "/q", LatencyThreshold: time.Second, LatencyObjective: 0.9, AvailabilityObjective: 0.9},
+		},
+	}, logger.New("error"))
+	ctx := context.Background()
+	start := svc.started
+
+	observeRequests(requests, duration, "POST", "/q", "503", 20, time.Millisecond)
+	if err := svc.evaluate(ctx, start.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.evaluate(ctx, start.Add(2*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if len(notifier.sent) != 1 || notifier.sent[0].Severity != "critical" || notifier.sent[0].Type != selfSLONotificationType {
+		t.Fatalf("expected one critical breach notification, got %+v", notifier.sent)
+	}
+
+	// An hour later the failures fall out of the window.
+	observeRequests(requests, duration, "POST", "/q", "200", 20, time.Millisecond)
+	if err := svc.evaluate(ctx, start.Add(62*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if len(notifier.sent) != 2 || notifier.sent[1].Severity != "info" {
+		t.Fatalf("expected a recovery notification, got %+v", notifier.sent)
+	}
+	svc.mu.Lock()
+	report := svc.report(start.Add(62*time.Minute), []selfSLOCounts{{requests: 40, errors: 20, fast: 40}}, []float64{1})
+	svc.mu.Unlock()
+	if report.Targets[0].Requests != 20 || report.Targets[0].Errors != 0 {
+		t.Fatalf("expected only the last hour to count, got %+v", report.Targets[0])
+	}
+}
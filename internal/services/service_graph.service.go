@@ -135,8 +135,12 @@ func (s *ServiceGraphService) runInstantVector(ctx context.Context, query, evalT
 	if res == nil || res.Data == nil {
 		return nil, nil
 	}
+	return decodeInstantVector(res.Data)
+}
 
-	raw, err := json.Marshal(res.Data)
+// decodeInstantVector parses the data of an instant vector query result.
+func decodeInstantVector(data interface{}) ([]promSample, error) {
+	raw, err := json.Marshal(data)
 	if err != nil {
 		return nil, err
 	}
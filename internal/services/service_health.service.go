@@ -0,0 +1,380 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/datastore"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/qos"
+	"github.com/mirastacklabs-ai/mirador-core/internal/repo"
+	"github.com/mirastacklabs-ai/mirador-core/internal/weavstore"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const (
+	healthScoreKeyPrefix = "health:score:"
+
+	// servicePlaceholder is substituted with the service name in queries.
+	servicePlaceholder = "{service}"
+
+	// maxHealthKPIs bounds the instant queries issued per score.
+	maxHealthKPIs = 50
+)
+
+// Component weights; unavailable components are left out and the rest
+// renormalized.
+var healthWeights = map[string]float64{
+	"kpis":         0.40,
+	"alerts":       0.25,
+	"incidents":    0.20,
+	"error_budget": 0.15,
+}
+
+// HealthMetricsQuerier runs MetricsQL instant queries.
+type HealthMetricsQuerier interface {
+	ExecuteQuery(ctx context.Context, request *models.MetricsQLQueryRequest) (*models.MetricsQLQueryResult, error)
+}
+
+// HealthIncidentSource lists failure records (incidents).
+type HealthIncidentSource interface {
+	ListFailures(ctx context.Context, limit, offset int) ([]*weavstore.FailureRecord, int64, error)
+}
+
+// ServiceHealthService computes and caches per-service health scores.
+type ServiceHealthService struct {
+	metrics   HealthMetricsQuerier
+	kpis      repo.KPIRepo
+	incidents HealthIncidentSource
+	cache     cache.ValkeyCluster
+	cfg       config.HealthScoreConfig
+	logger    logging.Logger
+
+	// warehouses for SQL KPIs; see SetDatastores
+	datastores *datastore.Registry
+}
+
+// NewServiceHealthService creates a new service health service. kpis and
+// incidents may be nil; their components are then reported unavailable.
+func NewServiceHealthService(metrics HealthMetricsQuerier, kpis repo.KPIRepo, incidents HealthIncidentSource, cache cache.ValkeyCluster, cfg config.HealthScoreConfig, logger corelogger.Logger) *ServiceHealthService {
+	return &ServiceHealthService{
+		metrics:   metrics,
+		kpis:      kpis,
+		incidents: incidents,
+		cache:     cache,
+		cfg:       cfg,
+		logger:    logging.FromCoreLogger(logger),
+	}
+}
+
+// SetDatastores lets KPIs stored in the registered warehouses contribute to
+// scores; their SQL gets the service name bound to {service}.
+func (s *ServiceHealthService) SetDatastores(stores *datastore.Registry) {
+	s.datastores = stores
+}
+
+// Score returns the service's cached health score, computing it when absent,
+// expired or refresh is set.
+func (s *ServiceHealthService) Score(ctx context.Context, service string, refresh bool) (*models.ServiceHealthScore, error) {
+	if !refresh {
+		if data, err := s.cache.Get(ctx, healthScoreKeyPrefix+service); err == nil && len(data) > 0 {
+			var score models.ServiceHealthScore
+			if err := json.Unmarshal(data, &score); err == nil {
+				return &score, nil
+			}
+		}
+	}
+	score := s.compute(ctx, service)
+	if err := s.cache.Set(ctx, healthScoreKeyPrefix+service, score, s.cfg.CacheTTL); err != nil {
+		s.logger.Warn("Failed to cache service health score", "service", service, "error", err)
+	}
+	return score, nil
+}
+
+// Start refreshes the configured services every RefreshInterval until ctx
+// ends.
+func (s *ServiceHealthService) Start(ctx context.Context) {
+	if len(s.cfg.Services) == 0 || s.cfg.RefreshInterval <= 0 {
+		return
+	}
+	ctx = qos.WithClass(ctx, qos.Background)
+	ticker := time.NewTicker(s.cfg.RefreshInterval)
+	defer ticker.Stop()
+	for {
+		for _, svc := range s.cfg.Services {
+			if ctx.Err() != nil {
+				return
+			}
+			if _, err := s.Score(ctx, svc, true); err != nil {
+				s.logger.Warn("Failed to refresh service health score", "service", svc, "error", err)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ServiceHealthService) compute(ctx context.Context, service string) *models.ServiceHealthScore {
+	components := []models.HealthComponent{
+		s.kpiComponent(ctx, service),
+		s.alertsComponent(ctx, service),
+		s.incidentsComponent(ctx, service),
+		s.errorBudgetComponent(ctx, service),
+	}
+
+	var sum, weights float64
+	for i := range components {
+		c := &components[i]
+		c.Weight = healthWeights[c.Name]
+		c.Score = math.Round(c.Score*10) / 10
+		if c.Available {
+			sum += c.Score * c.Weight
+			weights += c.Weight
+		}
+	}
+	out := &models.ServiceHealthScore{
+		Service:    service,
+		Status:     models.HealthStatusUnknown,
+		Components: components,
+		ComputedAt: time.Now().UTC(),
+	}
+	if weights > 0 {
+		out.Score = int(math.Round(sum / weights))
+		out.Status = healthStatus(out.Score)
+	}
+	return out
+}
+
+func healthStatus(score int) string {
+	switch {
+	case score >= 80:
+		return models.HealthStatusHealthy
+	case score >= 50:
+		return models.HealthStatusDegraded
+	default:
+		return models.HealthStatusCritical
+	}
+}
+
+func unavailable(name, detail string) models.HealthComponent {
+	return models.HealthComponent{Name: name, Detail: detail}
+}
+
+// kpiComponent evaluates the thresholds of KPIs belonging to the service
+// (matched by service family or tag): 100 when none is breached, 50 for a
+// non-critical breach and 0 for a critical one, averaged across KPIs.
+func (s *ServiceHealthService) kpiComponent(ctx context.Context, service string) models.HealthComponent {
+	if s.kpis == nil {
+		return unavailable("kpis", "KPI registry not configured")
+	}
+	all, _, err := s.kpis.ListKPIs(ctx, models.KPIListRequest{Limit: catalogScanLimit})
+	if err != nil {
+		return unavailable("kpis", err.Error())
+	}
+
+	var total float64
+	evaluated := 0
+	breaches := []map[string]any{}
+	for _, k := range all {
+		if evaluated >= maxHealthKPIs {
+			break
+		}
+		if k == nil || k.Formula == "" || len(k.Thresholds) == 0 || !kpiBelongsTo(k, service) {
+			continue
+		}
+		samples, err := s.kpiQuery(ctx, k, service)
+		if err != nil || len(samples) == 0 {
+			continue
+		}
+		evaluated++
+		level, value := worstBreach(k.Thresholds, samples)
+		switch {
+		case level == "":
+			total += 100
+		case strings.EqualFold(level, "critical"):
+			breaches = append(breaches, map[string]any{"id": k.ID, "name": k.Name, "level": level, "value": value})
+		default:
+			total += 50
+			breaches = append(breaches, map[string]any{"id": k.ID, "name": k.Name, "level": level, "value": value})
+		}
+	}
+	if evaluated == 0 {
+		return unavailable("kpis", "no KPIs with thresholds could be evaluated for this service")
+	}
+	return models.HealthComponent{
+		Name:      "kpis",
+		Score:     total / float64(evaluated),
+		Available: true,
+		Details:   map[string]any{"evaluated": evaluated, "breaches": breaches},
+	}
+}
+
+// alertsComponent deducts 40 points per firing critical alert and 20 per
+// other firing alert.
+func (s *ServiceHealthService) alertsComponent(ctx context.Context, service string) models.HealthComponent {
+	if s.cfg.AlertsQuery == "" {
+		return unavailable("alerts", "alerts_query not configured")
+	}
+	samples, err := s.query(ctx, s.cfg.AlertsQuery, service)
+	if err != nil {
+		return unavailable("alerts", err.Error())
+	}
+	critical := 0
+	for _, smp := range samples {
+		if sev := strings.ToLower(smp.labels["severity"]); sev == "critical" || sev == "page" {
+			critical++
+		}
+	}
+	other := len(samples) - critical
+	return models.HealthComponent{
+		Name:      "alerts",
+		Score:     math.Max(0, 100-40*float64(critical)-20*float64(other)),
+		Available: true,
+		Details:   map[string]any{"firing": len(samples), "critical": critical},
+	}
+}
+
+// incidentsComponent deducts 30 points per failure record affecting the
+// service within the incident window.
+func (s *ServiceHealthService) incidentsComponent(ctx context.Context, service string) models.HealthComponent {
+	if s.incidents == nil {
+		return unavailable("incidents", "failure store not configured")
+	}
+	records, _, err := s.incidents.ListFailures(ctx, catalogScanLimit, 0)
+	if err != nil {
+		return unavailable("incidents", err.Error())
+	}
+	since := time.Now().Add(-s.cfg.IncidentWindow)
+	recent := 0
+	for _, r := range records {
+		if r == nil || r.DetectionTimestamp.Before(since) {
+			continue
+		}
+		for _, svc := range r.Services {
+			if strings.EqualFold(svc, service) {
+				recent++
+				break
+			}
+		}
+	}
+	return models.HealthComponent{
+		Name:      "incidents",
+		Score:     math.Max(0, 100-30*float64(recent)),
+		Available: true,
+		Details:   map[string]any{"recent": recent, "window": s.cfg.IncidentWindow.String()},
+	}
+}
+
+// errorBudgetComponent scores the error-budget burn rate: 100 up to a burn
+// rate of 1 (spending exactly the budget), falling linearly to 0 at 10.
+func (s *ServiceHealthService) errorBudgetComponent(ctx context.Context, service string) models.HealthComponent {
+	if s.cfg.ErrorRatioQuery == "" || s.cfg.SLOTarget <= 0 || s.cfg.SLOTarget >= 1 {
+		return unavailable("error_budget", "error_ratio_query or slo_target not configured")
+	}
+	samples, err := s.query(ctx, s.cfg.ErrorRatioQuery, service)
+	if err != nil {
+		return unavailable("error_budget", err.Error())
+	}
+	if len(samples) == 0 || math.IsNaN(samples[0].value) {
+		return unavailable("error_budget", "error ratio query returned no data")
+	}
+	burn := samples[0].value / (1 - s.cfg.SLOTarget)
+	score := 100.0
+	if burn > 1 {
+		score = math.Max(0, 100-(burn-1)*100/9)
+	}
+	return models.HealthComponent{
+		Name:      "error_budget",
+		Score:     score,
+		Available: true,
+		Details:   map[string]any{"burnRate": math.Round(burn*100) / 100, "sloTarget": s.cfg.SLOTarget},
+	}
+}
+
+func kpiBelongsTo(k *models.KPIDefinition, service string) bool {
+	if strings.EqualFold(k.ServiceFamily, service) {
+		return true
+	}
+	for _, t := range k.Tags {
+		if strings.EqualFold(t, service) {
+			return true
+		}
+	}
+	return false
+}
+
+// worstBreach returns the most severe threshold level breached by any
+// sample ("" when none) and the breaching value.
+func worstBreach(thresholds []models.Threshold, samples []promSample) (string, float64) {
+	level, value := "", 0.0
+	for _, smp := range samples {
+		for _, t := range thresholds {
+			if !thresholdBreached(t, smp.value) {
+				continue
+			}
+			if level == "" || (strings.EqualFold(t.Level, "critical") && !strings.EqualFold(level, "critical")) {
+				level, value = t.Level, smp.value
+			}
+		}
+	}
+	return level, value
+}
+
+func thresholdBreached(t models.Threshold, v float64) bool {
+	switch strings.ToLower(t.Operator) {
+	case "gt":
+		return v > t.Value
+	case "gte":
+		return v >= t.Value
+	case "lt":
+		return v < t.Value
+	case "lte":
+		return v <= t.Value
+	case "eq":
+		return v == t.Value
+	}
+	return false
+}
+
+func (s *ServiceHealthService) query(ctx context.Context, q, service string) ([]promSample, error) {
+	return instantQuery(ctx, s.metrics, strings.ReplaceAll(q, servicePlaceholder, service), time.Time{})
+}
+
+// kpiQuery evaluates k for service: SQL KPIs get the service bound to
+// {service}, MetricsQL KPIs have {service} substituted.
+func (s *ServiceHealthService) kpiQuery(ctx context.Context, k *models.KPIDefinition, service string) ([]promSample, error) {
+	if _, ok := s.datastores.Get(k.Datastore); ok {
+		return kpiInstant(ctx, s.metrics, s.datastores, k, k.Formula, map[string]any{"service": service}, time.Time{})
+	}
+	return s.query(ctx, k.Formula, service)
+}
+
+// instantQuery evaluates q at the given time (now when zero).
+func instantQuery(ctx context.Context, metrics HealthMetricsQuerier, q string, at time.Time) ([]promSample, error) {
+	if metrics == nil {
+		return nil, fmt.Errorf("metrics backend not configured")
+	}
+	req := &models.MetricsQLQueryRequest{Query: q}
+	if !at.IsZero() {
+		req.Time = strconv.FormatInt(at.Unix(), 10)
+	}
+	res, err := metrics.ExecuteQuery(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil || res.Data == nil {
+		return nil, nil
+	}
+	return decodeInstantVector(res.Data)
+}
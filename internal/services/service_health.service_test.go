@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/datastore"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/weavstore"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// healthQuerier answers instant queries from a query -> samples table.
+type healthQuerier map[string][]map[string]interface{}
+
+func (q healthQuerier) ExecuteQuery(ctx context.Context, req *models.MetricsQLQueryRequest) (*models.MetricsQLQueryResult, error) {
+	series := []interface{}{}
+	for _, s := range q[req.Query] {
+		series = append(series, s)
+	}
+	return &models.MetricsQLQueryResult{Data: map[string]interface{}{"resultType": "vector", "result": series}}, nil
+}
+
+func vecSample(value string, labels map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{"metric": labels, "value": []interface{}{float64(time.Now().Unix()), value}}
+}
+
+type healthIncidents []*weavstore.FailureRecord
+
+func (h healthIncidents) ListFailures(ctx context.Context, limit, offset int) ([]*weavstore.FailureRecord, int64, error) {
+	return h, int64(len(h)), nil
+}
+
+func TestServiceHealthService_Score(t *testing.T) {
+	log := logger.New("error")
+	kpis := newFakeKPIRepo()
+	kpis.kpis["lat"] = &models.KPIDefinition{ID: "lat", Name: "p99 latency", ServiceFamily: "checkout", Formula: "latency_p99",
+		Thresholds: []models.Threshold{{Level: "warning", Operator: "gt", Value: 0.5}, {Level: "critical", Operator: "gt", Value: 2}}}
+	kpis.kpis["err"] = &models.KPIDefinition{ID: "err", Name: "errors", Tags: []string{"checkout"}, Formula: "errors",
+		Thresholds: []models.Threshold{{Level: "critical", Operator: "gt", Value: 10}}}
+	kpis.kpis["other"] = &models.KPIDefinition{ID: "other", ServiceFamily: "payments", Formula: "latency_p99",
+		Thresholds: []models.Threshold{{Level: "critical", Operator: "gt", Value: 0}}}
+
+	querier := healthQuerier{
+		"latency_p99": {vecSample("0.8", nil)}, // warning breach -> 50
+		"errors":      {vecSample("1", nil)},   // ok -> 100
+		`ALERTS{service="checkout"}`: {
+			vecSample("1", map[string]interface{}{"severity": "critical"}),
+		},
+		`ratio{service="checkout"}`: {vecSample("0.002", nil)}, // burn 2 at 99.9%
+	}
+	incidents := healthIncidents{
+		{Services: []string{"checkout"}, DetectionTimestamp: time.Now().Add(-time.Hour)},
+		{Services: []string{"checkout"}, DetectionTimestamp: time.Now().Add(-72 * time.Hour)}, // outside window
+		{Services: []string{"payments"}, DetectionTimestamp: time.Now()},
+	}
+	cfg := config.HealthScoreConfig{
+		CacheTTL:        time.Minute,
+		AlertsQuery:     `ALERTS{service="{service}"}`,
+		IncidentWindow:  24 * time.Hour,
+		ErrorRatioQuery: `ratio{service="{service}"}`,
+		SLOTarget:       0.999,
+	}
+	svc := NewServiceHealthService(querier, kpis, incidents, cache.NewNoopValkeyCache(log), cfg, log)
+
+	score, err := svc.Score(context.Background(), "checkout", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]float64{"kpis": 75, "alerts": 60, "incidents": 70, "error_budget": 88.9}
+	for _, c := range score.Components {
+		if !c.Available || c.Score != want[c.Name] {
+			t.Fatalf("component %s: got %+v, want score %v", c.Name, c, want[c.Name])
+		}
+	}
+	// 0.4*75 + 0.25*60 + 0.2*70 + 0.15*88.9 = 72.3
+	if score.Score != 72 || score.Status != models.HealthStatusDegraded {
+		t.Fatalf("unexpected overall score: %+v", score)
+	}
+}
+
+func TestServiceHealthService_RenormalizesMissingComponents(t *testing.T) {
+	log := logger.New("error")
+	querier := healthQuerier{}
+	svc := NewServiceHealthService(querier, nil, nil, cache.NewNoopValkeyCache(log),
+		config.HealthScoreConfig{AlertsQuery: "ALERTS"}, log)
+
+	score, _ := svc.Score(context.Background(), "checkout", true)
+	if score.Score != 100 || score.Status != models.HealthStatusHealthy {
+		t.Fatalf("only the alerts component is available and clean, got %+v", score)
+	}
+	for _, c := range score.Components {
+		if c.Name != "alerts" && (c.Available || !strings.Contains(c.Detail, "not configured")) {
+			t.Fatalf("expected %s to be unavailable, got %+v", c.Name, c)
+		}
+	}
+}
+
+// warehouse is a datastore.Adapter returning fixed samples and recording the
+// parameters it was called with.
+type warehouse struct {
+	samples []datastore.Sample
+	params  map[string]any
+}
+
+func (w *warehouse) Name() string               { return "finance_dw" }
+func (w *warehouse) Type() string               { return datastore.TypePostgreSQL }
+func (w *warehouse) Ping(context.Context) error { return nil }
+func (w *warehouse) Close() error               { return nil }
+func (w *warehouse) Query(_ context.Context, _ string, params map[string]any) ([]datastore.Sample, error) {
+	w.params = params
+	return w.samples, nil
+}
+
+func TestServiceHealthService_WarehouseKPI(t *testing.T) {
+	log := logger.New("error")
+	kpis := newFakeKPIRepo()
+	kpis.kpis["rev"] = &models.KPIDefinition{ID: "rev", ServiceFamily: "checkout", Datastore: "finance_dw", QueryType: "SQL",
+		Formula:    "SELECT failed_ratio AS value FROM orders WHERE service = {service}",
+		Thresholds: []models.Threshold{{Level: "warning", Operator: "gt", Value: 0.05}}}
+	dw := &warehouse{samples: []datastore.Sample{{Value: 0.08}}}
+	stores, _ := datastore.NewRegistry(nil)
+	stores.Register(dw)
+
+	svc := NewServiceHealthService(healthQuerier{}, kpis, nil, cache.NewNoopValkeyCache(log), config.HealthScoreConfig{}, log)
+	svc.SetDatastores(stores)
+	score, _ := svc.Score(context.Background(), "checkout", true)
+
+	if dw.params["service"] != "checkout" || dw.params["at"] == nil {
+		t.Fatalf("expected service and evaluation time bound, got %v", dw.params)
+	}
+	for _, c := range score.Components {
+		if c.Name == "kpis" && (!c.Available || c.Score != 50) {
+			t.Fatalf("expected the warehouse KPI's warning breach to score 50, got %+v", c)
+		}
+	}
+}
@@ -0,0 +1,286 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const (
+	// Events are stored in hourly buckets under signalEventsKeyPrefix plus
+	// the bucket's Unix start time.
+	signalEventsKeyPrefix = "events:"
+	signalEventBucket     = time.Hour
+	// maxEventsPerBucket bounds one bucket; further events that hour are
+	// dropped.
+	maxEventsPerBucket      = 5000
+	defaultEventRetention   = 7 * 24 * time.Hour
+	maxEventTimelineEntries = 200
+
+	// signalEventsLockPrefix plus the bucket's Unix start time locks the
+	// bucket's read-modify-write across replicas.
+	signalEventsLockPrefix = "events:lock:"
+	signalEventsLockTTL    = 10 * time.Second
+	signalEventsLockWait   = 2 * time.Second
+)
+
+var (
+	ErrInvalidSignalEvent = errors.New("invalid event")
+	ErrSignalEventsBusy   = errors.New("event bucket is locked by another replica")
+)
+
+// Generated name suffixes: the ReplicaSet hash and pod suffix of a
+// Deployment pod (checkout-7d9f8b6c4-x2kqz), the hash of a ReplicaSet and the
+// ordinal of a StatefulSet pod (db-0).
+var (
+	deploymentPodSuffix = regexp.MustCompile(`-[a-z0-9]{5,10}-[a-z0-9]{5}$`)
+	replicaSetSuffix    = regexp.MustCompile(`-[a-z0-9]{5,10}$`)
+	ordinalSuffix       = regexp.MustCompile(`-[0-9]+$`)
+)
+
+// SignalEventService ingests Kubernetes and CI/CD events for correlation.
+// Events are kept in Valkey for the configured retention and read by the
+// correlation engine on every run.
+type SignalEventService struct {
+	cache  cache.ValkeyCluster
+	cfg    config.EventSignalConfig
+	logger logging.Logger
+}
+
+// NewSignalEventService creates a new event ingestion service.
+func NewSignalEventService(cache cache.ValkeyCluster, cfg config.EventSignalConfig, logger corelogger.Logger) *SignalEventService {
+	if cfg.Retention <= 0 {
+		cfg.Retention = defaultEventRetention
+	}
+	return &SignalEventService{
+		cache:  cache,
+		cfg:    cfg,
+		logger: logging.FromCoreLogger(logger),
+	}
+}
+
+// Ingest validates and stores events. Events that reuse a stored ID replace
+// it, so exporters may resend updated events. It returns the number stored;
+// events older than the retention or over a bucket's cap are dropped.
+func (s *SignalEventService) Ingest(ctx context.Context, events []models.SignalEvent) (int, error) {
+	now := time.Now().UTC()
+	buckets := map[int64][]models.SignalEvent{}
+	for i := range events {
+		ev := events[i]
+		ev.Service = strings.TrimSpace(ev.Service)
+		ev.Type = strings.TrimSpace(ev.Type)
+		ev.Source = strings.ToLower(strings.TrimSpace(ev.Source))
+		if ev.Service == "" || ev.Type == "" {
+			return 0, fmt.Errorf("%w: event %d: service and type are required", ErrInvalidSignalEvent, i)
+		}
+		if ev.Source == "" {
+			ev.Source = "api"
+		}
+		if ev.ID == "" {
+			ev.ID = uuid.NewString()
+		}
+		if ev.Time.IsZero() {
+			ev.Time = now
+		}
+		ev.Time = ev.Time.UTC()
+		if ev.Time.After(now.Add(signalEventBucket)) {
+			return 0, fmt.Errorf("%w: event %d is in the future", ErrInvalidSignalEvent, i)
+		}
+		if now.Sub(ev.Time) > s.cfg.Retention {
+			continue
+		}
+		b := ev.Time.Truncate(signalEventBucket).Unix()
+		buckets[b] = append(buckets[b], ev)
+	}
+
+	stored := 0
+	for b, incoming := range buckets {
+		n, err := s.storeBucket(ctx, b, incoming, now)
+		stored += n
+		if err != nil {
+			return stored, err
+		}
+	}
+	if dropped := len(events) - stored; dropped > 0 {
+		s.logger.Warn("Dropped events outside retention or over the hourly cap", "dropped", dropped)
+	}
+	return stored, nil
+}
+
+// storeBucket merges incoming into the hourly bucket starting at b under the
+// bucket's lock, so concurrent ingests on other replicas are not lost. It
+// returns the number of events stored.
+func (s *SignalEventService) storeBucket(ctx context.Context, b int64, incoming []models.SignalEvent, now time.Time) (int, error) {
+	suffix := strconv.FormatInt(b, 10)
+	unlock, err := lockCache(ctx, s.cache, signalEventsLockPrefix+suffix,
+		signalEventsLockTTL, signalEventsLockWait, ErrSignalEventsBusy, s.logger)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	key := signalEventsKeyPrefix + suffix
+	existing, err := readEventBucket(ctx, s.cache, key)
+	if err != nil {
+		s.logger.Warn("Replacing unreadable event bucket", "key", key, "error", err)
+	}
+	index := make(map[string]int, len(existing))
+	for i, ev := range existing {
+		index[ev.ID] = i
+	}
+	stored := 0
+	for _, ev := range incoming {
+		if i, ok := index[ev.ID]; ok {
+			existing[i] = ev
+		} else if len(existing) < maxEventsPerBucket {
+			index[ev.ID] = len(existing)
+			existing = append(existing, ev)
+		} else {
+			continue
+		}
+		stored++
+	}
+	ttl := time.Unix(b, 0).Add(signalEventBucket + s.cfg.Retention).Sub(now)
+	if err := s.cache.Set(ctx, key, existing, ttl); err != nil {
+		return 0, fmt.Errorf("store events: %w", err)
+	}
+	return stored, nil
+}
+
+// IngestKubernetes converts core/v1 Events and stores them.
+func (s *SignalEventService) IngestKubernetes(ctx context.Context, events []models.KubernetesEvent) (int, error) {
+	out := make([]models.SignalEvent, 0, len(events))
+	for _, k := range events {
+		out = append(out, kubernetesSignalEvent(k, s.cfg.ServiceLabels))
+	}
+	return s.Ingest(ctx, out)
+}
+
+// List returns events between start and end, oldest first, optionally for
+// one service.
+func (s *SignalEventService) List(ctx context.Context, start, end time.Time, service string) ([]models.SignalEvent, error) {
+	if !end.After(start) {
+		return nil, fmt.Errorf("%w: end must be after start", ErrInvalidSignalEvent)
+	}
+	if end.Sub(start) > s.cfg.Retention {
+		start = end.Add(-s.cfg.Retention)
+	}
+	events := loadSignalEvents(ctx, s.cache, start, end)
+	if service == "" {
+		return events, nil
+	}
+	kept := events[:0]
+	for _, ev := range events {
+		if strings.EqualFold(ev.Service, service) {
+			kept = append(kept, ev)
+		}
+	}
+	return kept, nil
+}
+
+// loadSignalEvents reads the events between start and end, oldest first.
+// Unreadable buckets are skipped.
+func loadSignalEvents(ctx context.Context, c cache.ValkeyCluster, start, end time.Time) []models.SignalEvent {
+	events := []models.SignalEvent{}
+	if c == nil {
+		return events
+	}
+	for b := start.UTC().Truncate(signalEventBucket); !b.After(end); b = b.Add(signalEventBucket) {
+		bucket, err := readEventBucket(ctx, c, signalEventsKeyPrefix+strconv.FormatInt(b.Unix(), 10))
+		if err != nil {
+			continue
+		}
+		for _, ev := range bucket {
+			if !ev.Time.Before(start) && !ev.Time.After(end) {
+				events = append(events, ev)
+			}
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Time.Before(events[j].Time) })
+	return events
+}
+
+func readEventBucket(ctx context.Context, c cache.ValkeyCluster, key string) ([]models.SignalEvent, error) {
+	data, err := c.Get(ctx, key)
+	if err != nil || len(data) == 0 {
+		return nil, nil
+	}
+	var events []models.SignalEvent
+	if err := json.Unmarshal(data, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// kubernetesSignalEvent converts a core/v1 Event. Its service is the first of
+// serviceLabels set on the involved object, else the owning workload's name.
+func kubernetesSignalEvent(k models.KubernetesEvent, serviceLabels []string) models.SignalEvent {
+	ev := models.SignalEvent{
+		ID:        k.Metadata.UID,
+		Source:    models.EventSourceKubernetes,
+		Type:      k.Type,
+		Reason:    k.Reason,
+		Namespace: k.InvolvedObject.Namespace,
+		Message:   k.Message,
+		Count:     k.Count,
+		Labels:    k.InvolvedObject.Labels,
+	}
+	if ev.Namespace == "" {
+		ev.Namespace = k.Metadata.Namespace
+	}
+	if ev.Type == "" {
+		ev.Type = "Normal"
+	}
+	if k.InvolvedObject.Name != "" {
+		ev.Object = k.InvolvedObject.Kind + "/" + k.InvolvedObject.Name
+	}
+	for _, name := range serviceLabels {
+		if v := k.InvolvedObject.Labels[name]; v != "" {
+			ev.Service = v
+			break
+		}
+	}
+	if ev.Service == "" {
+		ev.Service = kubernetesWorkload(k.InvolvedObject.Kind, k.InvolvedObject.Name)
+	}
+	for _, t := range []time.Time{k.LastTimestamp, k.EventTime, k.FirstTimestamp, k.Metadata.CreationTimestamp} {
+		if !t.IsZero() {
+			ev.Time = t
+			break
+		}
+	}
+	return ev
+}
+
+// kubernetesWorkload strips generated suffixes from pod and ReplicaSet
+// names to recover the owning workload, which is usually the service name.
+func kubernetesWorkload(kind, name string) string {
+	switch kind {
+	case "Pod":
+		if loc := deploymentPodSuffix.FindStringIndex(name); loc != nil {
+			return name[:loc[0]]
+		}
+		if loc := ordinalSuffix.FindStringIndex(name); loc != nil {
+			return name[:loc[0]]
+		}
+	case "ReplicaSet":
+		if loc := replicaSetSuffix.FindStringIndex(name); loc != nil {
+			return name[:loc[0]]
+		}
+	}
+	return name
+}
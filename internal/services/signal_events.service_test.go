@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func TestSignalEventService_IngestAndList(t *testing.T) {
+	log := logger.New("error")
+	ctx := context.Background()
+	svc := NewSignalEventService(cache.NewNoopValkeyCache(log), config.EventSignalConfig{}, log)
+	now := time.Now().UTC()
+
+	if _, err := svc.Ingest(ctx, []models.SignalEvent{{Type: "deploy"}}); !errors.Is(err, ErrInvalidSignalEvent) {
+		t.Fatalf("expected ErrInvalidSignalEvent without service, got %v", err)
+	}
+	stored, err := svc.Ingest(ctx, []models.SignalEvent{
+		{ID: "d1", Source: "CI", Type: "deploy", Service: "checkout", Time: now.Add(-10 * time.Minute)},
+		{Type: "Warning", Reason: "BackOff", Service: "payments", Time: now.Add(-2 * time.Hour)},
+		{Type: "deploy", Service: "checkout", Time: now.Add(-30 * 24 * time.Hour)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored != 2 {
+		t.Fatalf("expected the event outside retention to be dropped, stored %d", stored)
+	}
+	// Resending an ID replaces the stored event.
+	if _, err := svc.Ingest(ctx, []models.SignalEvent{{ID: "d1", Type: "deploy", Service: "checkout", Reason: "v2", Time: now.Add(-10 * time.Minute)}}); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := svc.List(ctx, now.Add(-3*time.Hour), now, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[0].Service != "payments" || events[1].Reason != "v2" || events[1].Source != "api" {
+		t.Fatalf("unexpected events %+v", events)
+	}
+	if events, _ := svc.List(ctx, now.Add(-3*time.Hour), now, "CHECKOUT"); len(events) != 1 {
+		t.Fatalf("expected one checkout event, got %+v", events)
+	}
+}
+
+func TestSignalEventService_ConcurrentIngest(t *testing.T) {
+	log := logger.New("error")
+	ctx := context.Background()
+	shared := newLockingCache()
+	replicas := []*SignalEventService{
+		NewSignalEventService(shared, config.EventSignalConfig{}, log),
+		NewSignalEventService(shared, config.EventSignalConfig{}, log),
+	}
+	now := time.Now().UTC()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ev := models.SignalEvent{ID: fmt.Sprintf("e%d", i), Type: "deploy", Service: "checkout", Time: now}
+			if _, err := replicas[i%2].Ingest(ctx, []models.SignalEvent{ev}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	events, err := replicas[0].List(ctx, now.Add(-time.Minute), now.Add(time.Minute), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 20 {
+		t.Fatalf("expected every concurrently ingested event to be kept, got %d", len(events))
+	}
+}
+
+func TestKubernetesSignalEvent(t *testing.T) {
+	var k models.KubernetesEvent
+	k.Metadata.UID = "uid-1"
+	k.InvolvedObject.Kind = "Pod"
+	k.InvolvedObject.Namespace = "shop"
+	k.InvolvedObject.Name = "checkout-7d9f8b6c4-x2kqz"
+	k.Type, k.Reason, k.Count = "Warning", "BackOff", 4
+	k.LastTimestamp = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	serviceLabels := []string{"app.kubernetes.io/name", "app"}
+	ev := kubernetesSignalEvent(k, serviceLabels)
+	if ev.ID != "uid-1" || ev.Service != "checkout" || ev.Object != "Pod/checkout-7d9f8b6c4-x2kqz" || ev.Count != 4 || !ev.Time.Equal(k.LastTimestamp) {
+		t.Fatalf("unexpected event %+v", ev)
+	}
+	k.InvolvedObject.Labels = map[string]string{"app": "shop-checkout", "team": "payments"}
+	if ev := kubernetesSignalEvent(k, serviceLabels); ev.Service != "shop-checkout" {
+		t.Fatalf("expected the service from the app label, got %q", ev.Service)
+	}
+	if ev := kubernetesSignalEvent(k, []string{"team"}); ev.Service != "payments" {
+		t.Fatalf("expected the service from the configured label, got %q", ev.Service)
+	}
+	for name, want := range map[string]string{"db-0": "db", "payments-api-6b7c9d5f8d-abcde": "payments-api", "standalone": "standalone"} {
+		if got := kubernetesWorkload("Pod", name); got != want {
+			t.Errorf("kubernetesWorkload(Pod, %s) = %s, want %s", name, got, want)
+		}
+	}
+}
+
+func TestCorrelationEngine_ApplyEventSignals(t *testing.T) {
+	log := logger.New("error")
+	ctx := context.Background()
+	c := cache.NewNoopValkeyCache(log)
+	events := NewSignalEventService(c, config.EventSignalConfig{}, log)
+	end := time.Now().UTC()
+	tr := models.TimeRange{Start: end.Add(-15 * time.Minute), End: end}
+	if _, err := events.Ingest(ctx, []models.SignalEvent{
+		{Type: "deploy", Service: "checkout", Time: tr.Start.Add(-5 * time.Minute)},
+		{Type: "Warning", Reason: "BackOff", Service: "payments", Count: 2, Time: tr.Start.Add(time.Minute)},
+		{Type: "deploy", Service: "search", Time: tr.Start.Add(-2 * time.Hour)},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	ce := NewCorrelationEngine(nil, nil, nil, nil, c, log, config.EngineConfig{}).(*CorrelationEngineImpl)
+	corr := &models.CorrelationResult{Causes: []models.CauseCandidate{
+		{Service: "Checkout", SuspicionScore: 0.5},
+		{Service: "payments", SuspicionScore: 0.5},
+		{Service: "search", SuspicionScore: 0.5},
+	}}
+	ce.applyEventSignals(ctx, corr, tr)
+
+	if got := corr.Causes[0]; got.SuspicionScore <= 0.5 || !containsString(got.Reasons, "deploy_near_impact") {
+		t.Fatalf("expected the deployed service to be boosted, got %+v", got)
+	}
+	if got := corr.Causes[1]; got.SuspicionScore != 0.5 {
+		t.Fatalf("two events are below the burst threshold, got %+v", got)
+	}
+	if got := corr.Causes[2]; got.SuspicionScore != 0.5 {
+		t.Fatalf("deploys before the lookback must not count, got %+v", got)
+	}
+	if len(corr.Timeline) != 2 || corr.Timeline[0].Service != "checkout" || corr.Timeline[1].Severity != "warning" {
+		t.Fatalf("unexpected timeline %+v", corr.Timeline)
+	}
+}
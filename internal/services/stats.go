@@ -2,6 +2,8 @@ package services
 
 import (
 	"math"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
 )
 
 const MIN_SAMPLES = 3
@@ -66,6 +68,38 @@ func ComputeSpearman(x, y []float64) float64 {
 	return ComputePearson(rx, ry)
 }
 
+// LinearFit fits y = slope*x + intercept by least squares and returns the
+// coefficient of determination r2. Returns zeros for fewer than 2 points or
+// constant x; r2 is 1 when y is constant (the fit is exact).
+func LinearFit(x, y []float64) (slope, intercept, r2 float64) {
+	n := len(x)
+	if n < 2 || n != len(y) {
+		return 0, 0, 0
+	}
+	var sx, sy float64
+	for i := 0; i < n; i++ {
+		sx += x[i]
+		sy += y[i]
+	}
+	meanX, meanY := sx/float64(n), sy/float64(n)
+	var sxx, sxy, syy float64
+	for i := 0; i < n; i++ {
+		dx, dy := x[i]-meanX, y[i]-meanY
+		sxx += dx * dx
+		sxy += dx * dy
+		syy += dy * dy
+	}
+	if sxx == 0 {
+		return 0, 0, 0
+	}
+	slope = sxy / sxx
+	intercept = meanY - slope*meanX
+	if syy == 0 {
+		return slope, intercept, 1
+	}
+	return slope, intercept, (sxy * sxy) / (sxx * syy)
+}
+
 // CrossCorrelScan scans lags from -maxLag..+maxLag (inclusive) and returns
 // the maximum cross-correlation (normalized) and the lag that produced it.
 // lag > 0 means y lags behind x (x leads y by lag samples).
@@ -201,10 +235,16 @@ func PartialCorrelation(x, y []float64, control []float64) float64 {
 // NOTE(AT-007): This is a Stage-01 implementation — refinements (e.g. using
 // partial correlation and anomaly-density signals) are tracked in AT-007.
 func ComputeSuspicionScore(pearson, spearman, crossMax float64, crossLag int, sampleSize int, cfgMinCorrelation float64, partial float64, anomalyDensity float64) float64 {
-	// Weigh components: Pearson (50%), Spearman (30%), CrossCorr (20%)
-	wP := 0.5
-	wS := 0.3
-	wC := 0.2
+	return ComputeSuspicionScoreWeighted(config.DefaultScoringWeights, pearson, spearman, crossMax, crossLag, sampleSize, cfgMinCorrelation, partial, anomalyDensity)
+}
+
+// ComputeSuspicionScoreWeighted is ComputeSuspicionScore with configurable
+// component weights (engine.scoring).
+func ComputeSuspicionScoreWeighted(w config.ScoringWeights, pearson, spearman, crossMax float64, crossLag int, sampleSize int, cfgMinCorrelation float64, partial float64, anomalyDensity float64) float64 {
+	// Weigh components (defaults: Pearson 50%, Spearman 30%, CrossCorr 20%)
+	wP := w.Pearson
+	wS := w.Spearman
+	wC := w.CrossCorr
 
 	ap := math.Abs(pearson)
 	as := math.Abs(spearman)
@@ -222,7 +262,7 @@ func ComputeSuspicionScore(pearson, spearman, crossMax float64, crossLag int, sa
 	// Lag bonus: only reward when cross-corr indicates cause leads impact.
 	lagBonus := 0.0
 	if crossLag > 0 { // crossLag > 0 means x leads y per CrossCorrelScan contract
-		lagBonus = 0.12
+		lagBonus = w.LagBonus
 	}
 
 	// Base score is weighted sum of absolute correlations and cross-corr
@@ -245,7 +285,7 @@ func ComputeSuspicionScore(pearson, spearman, crossMax float64, crossLag int, sa
 
 	// Apply lag bonus and clamp
 	// Add anomaly density small positive contribution (normalized 0..1)
-	anomalyWeight := 0.12
+	anomalyWeight := w.AnomalyDensity
 	score := base*(1.0-confoundingPenalty) + lagBonus + anomalyWeight*anomalyDensity
 	if score > 1.0 {
 		score = 1.0
@@ -165,3 +165,16 @@ func TestComputeSuspicionScore_WithAnomalyAndPartial(t *testing.T) {
 		t.Fatalf("expected partial-supporting score >= confounded score; scoreA=%v scoreB=%v", scoreA, scoreB)
 	}
 }
+
+func TestLinearFit(t *testing.T) {
+	slope, intercept, r2 := LinearFit([]float64{0, 1, 2, 3}, []float64{1, 3, 5, 7})
+	if !approxEqual(slope, 2, 1e-9) || !approxEqual(intercept, 1, 1e-9) || !approxEqual(r2, 1, 1e-9) {
+		t.Fatalf("expected y=2x+1 with r2 1, got slope=%v intercept=%v r2=%v", slope, intercept, r2)
+	}
+	if _, _, r2 := LinearFit([]float64{0, 1, 2, 3}, []float64{1, 3, 1, 3}); r2 > 0.5 {
+		t.Fatalf("expected poor fit for alternating series, got r2=%v", r2)
+	}
+	if s, i, r := LinearFit([]float64{1}, []float64{1}); s != 0 || i != 0 || r != 0 {
+		t.Fatalf("expected zeros for a single point")
+	}
+}
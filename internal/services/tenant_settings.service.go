@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const tenantSettingsKey = "cfg:tenant_settings"
+
+var hexColorPattern = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// TenantSettingsService stores branding and localization defaults for the
+// tenant served by this deployment. Settings live in Valkey alongside the
+// other dynamic configuration documents.
+type TenantSettingsService struct {
+	cache   cache.ValkeyCluster
+	catalog *MessageCatalog
+	logger  logging.Logger
+}
+
+// NewTenantSettingsService creates a tenant settings service backed by the cache.
+func NewTenantSettingsService(cache cache.ValkeyCluster, catalog *MessageCatalog, logger corelogger.Logger) *TenantSettingsService {
+	if catalog == nil {
+		catalog = NewMessageCatalog()
+	}
+	return &TenantSettingsService{
+		cache:   cache,
+		catalog: catalog,
+		logger:  logging.FromCoreLogger(logger),
+	}
+}
+
+// Catalog returns the message catalog used to validate and resolve locales.
+func (s *TenantSettingsService) Catalog() *MessageCatalog {
+	return s.catalog
+}
+
+// GetSettings returns the stored settings or the defaults when none are stored.
+func (s *TenantSettingsService) GetSettings(ctx context.Context) (*models.TenantSettings, error) {
+	data, err := s.cache.Get(ctx, tenantSettingsKey)
+	if err != nil || len(data) == 0 {
+		return DefaultTenantSettings(), nil
+	}
+	var settings models.TenantSettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		s.logger.Warn("Failed to unmarshal tenant settings, using defaults", "error", err)
+		return DefaultTenantSettings(), nil
+	}
+	return &settings, nil
+}
+
+// SetSettings validates and stores the tenant settings.
+func (s *TenantSettingsService) SetSettings(ctx context.Context, settings *models.TenantSettings) (*models.TenantSettings, error) {
+	if settings == nil {
+		return nil, fmt.Errorf("settings are required")
+	}
+	applyTenantSettingsDefaults(settings)
+	if err := s.ValidateSettings(settings); err != nil {
+		return nil, err
+	}
+	settings.DefaultLocale = normalizeLocale(settings.DefaultLocale)
+	settings.UpdatedAt = time.Now().UTC()
+
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal tenant settings: %w", err)
+	}
+	// Settings are durable configuration; store without expiry.
+	if err := s.cache.Set(ctx, tenantSettingsKey, data, 0); err != nil {
+		return nil, fmt.Errorf("failed to store tenant settings: %w", err)
+	}
+	s.logger.Info("Tenant settings updated", "locale", settings.DefaultLocale, "timezone", settings.Timezone)
+	return settings, nil
+}
+
+// ValidateSettings checks locale, timezone, colours, logo URL and origins.
+func (s *TenantSettingsService) ValidateSettings(settings *models.TenantSettings) error {
+	var problems []string
+	if !s.catalog.Supports(settings.DefaultLocale) {
+		problems = append(problems, fmt.Sprintf("defaultLocale %q is not supported (available: %s)",
+			settings.DefaultLocale, strings.Join(s.catalog.Locales(), ", ")))
+	}
+	if _, err := time.LoadLocation(settings.Timezone); err != nil {
+		problems = append(problems, fmt.Sprintf("timezone %q is not a valid IANA zone", settings.Timezone))
+	}
+	for name, color := range map[string]string{
+		"branding.primaryColor":   settings.Branding.PrimaryColor,
+		"branding.secondaryColor": settings.Branding.SecondaryColor,
+	} {
+		if color != "" && !hexColorPattern.MatchString(color) {
+			problems = append(problems, fmt.Sprintf("%s %q must be a hex colour like #1F6FEB", name, color))
+		}
+	}
+	if logo := settings.Branding.LogoURL; logo != "" {
+		u, err := url.Parse(logo)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			problems = append(problems, "branding.logoUrl must be an absolute http(s) URL")
+		}
+	}
+	for _, origin := range settings.AllowedOrigins {
+		if !validCORSOrigin(origin) {
+			problems = append(problems, fmt.Sprintf("allowedOrigins entry %q must be an http(s) origin like https://ops.example.com or *.example.com", origin))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid tenant settings: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// FormatTimestamp renders t with the tenant's date/time layouts and timezone.
+func FormatTimestamp(settings *models.TenantSettings, t time.Time) string {
+	if settings == nil {
+		settings = DefaultTenantSettings()
+	}
+	if loc, err := time.LoadLocation(settings.Timezone); err == nil {
+		t = t.In(loc)
+	}
+	return t.Format(settings.DateFormat + " " + settings.TimeFormat)
+}
+
+// DefaultTenantSettings returns the built-in defaults.
+func DefaultTenantSettings() *models.TenantSettings {
+	s := &models.TenantSettings{}
+	applyTenantSettingsDefaults(s)
+	return s
+}
+
+func applyTenantSettingsDefaults(s *models.TenantSettings) {
+	if s.DefaultLocale == "" {
+		s.DefaultLocale = DefaultLocale
+	}
+	if s.DateFormat == "" {
+		s.DateFormat = "2006-01-02"
+	}
+	if s.TimeFormat == "" {
+		s.TimeFormat = "15:04:05"
+	}
+	if s.Timezone == "" {
+		s.Timezone = "UTC"
+	}
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func TestMessageCatalog_FallbackChain(t *testing.T) {
+	c := NewMessageCatalog()
+
+	if got := c.T("de-AT", MsgCorrelationTitle, "INC-1"); got != "Ursache gefunden: INC-1" {
+		t.Fatalf("expected base-language fallback, got %q", got)
+	}
+	if got := c.T("ja", MsgCorrelationTitle, "INC-1"); got != "Root Cause Found: INC-1" {
+		t.Fatalf("expected english fallback, got %q", got)
+	}
+	if got := c.T("en", "missing.key"); got != "missing.key" {
+		t.Fatalf("expected key echo for missing message, got %q", got)
+	}
+}
+
+func TestMessageCatalog_ResolveLocale(t *testing.T) {
+	c := NewMessageCatalog()
+
+	if got := c.ResolveLocale("fr", "de", "es"); got != "fr" {
+		t.Fatalf("override should win, got %q", got)
+	}
+	if got := c.ResolveLocale("", "ja;q=0.9, de-CH;q=0.8", "es"); got != "de-ch" {
+		t.Fatalf("first supported Accept-Language tag should win, got %q", got)
+	}
+	if got := c.ResolveLocale("xx", "", "es"); got != "es" {
+		t.Fatalf("tenant default should apply, got %q", got)
+	}
+	if got := c.ResolveLocale("", "", ""); got != DefaultLocale {
+		t.Fatalf("expected default locale, got %q", got)
+	}
+}
+
+func TestTenantSettingsService_RoundTripAndValidation(t *testing.T) {
+	log := logger.New("error")
+	svc := NewTenantSettingsService(cache.NewNoopValkeyCache(log), nil, log)
+	ctx := context.Background()
+
+	got, err := svc.GetSettings(ctx)
+	if err != nil || got.DefaultLocale != DefaultLocale || got.Timezone != "UTC" {
+		t.Fatalf("expected defaults, got %+v err=%v", got, err)
+	}
+
+	_, err = svc.SetSettings(ctx, &models.TenantSettings{
+		DefaultLocale:  "klingon",
+		Timezone:       "Mars/Olympus",
+		Branding:       models.BrandingSettings{PrimaryColor: "blue", LogoURL: "/logo.png"},
+		AllowedOrigins: []string{"https://ops.example.com/app"},
+	})
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	for _, want := range []string{"defaultLocale", "timezone", "primaryColor", "logoUrl", "allowedOrigins"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q in error, got %v", want, err)
+		}
+	}
+
+	if _, err := svc.SetSettings(ctx, &models.TenantSettings{
+		DefaultLocale: "DE",
+		Timezone:      "Europe/Berlin",
+		Branding:      models.BrandingSettings{PrimaryColor: "#1F6FEB", LogoURL: "https://cdn.example.com/logo.png"},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, _ = svc.GetSettings(ctx)
+	if got.DefaultLocale != "de" || got.DateFormat == "" || got.Branding.PrimaryColor != "#1F6FEB" {
+		t.Fatalf("unexpected stored settings: %+v", got)
+	}
+
+	ts := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	if out := FormatTimestamp(got, ts); out != "2025-01-02 11:00:00" {
+		t.Fatalf("unexpected formatted timestamp %q", out)
+	}
+}
+
+func TestNotificationService_LocalizedCorrelation(t *testing.T) {
+	svc := NewNotificationService(config.IntegrationsConfig{}, logger.New("error"))
+	svc.SetLocalization(nil, "es")
+	if got := svc.catalog.T(svc.locale, MsgCorrelationTitle, "INC-9"); got != "Causa raíz encontrada: INC-9" {
+		t.Fatalf("unexpected localized title %q", got)
+	}
+}
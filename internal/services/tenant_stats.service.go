@@ -0,0 +1,231 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/qos"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const (
+	// The latest overview is stored on its own so the admin overview is a
+	// single small read; the history under tenantStatsSnapshotsKey, newest
+	// first.
+	tenantStatsLatestKey    = "stats:tenant:latest"
+	tenantStatsSnapshotsKey = "stats:tenant:snapshots"
+
+	// tenantStatsCounterTimeout bounds each statistic's collection.
+	tenantStatsCounterTimeout = 30 * time.Second
+)
+
+// TenantStatsCounter collects one statistic of the tenant.
+type TenantStatsCounter func(ctx context.Context) (int64, error)
+
+// TenantStatsService materializes the tenant's object counts and sizes
+// (KPIs, Weaviate objects, templates, cache keys, ...) into snapshots on a
+// schedule, so the admin overview reads one stored object instead of
+// querying every store on each load. Statistics are registered by the
+// stores that own them.
+type TenantStatsService struct {
+	cache  cache.ValkeyCluster
+	cfg    config.StatsSnapshotConfig
+	tenant string
+	logger logging.Logger
+
+	mu       sync.RWMutex
+	counters map[string]TenantStatsCounter
+}
+
+// NewTenantStatsService creates a new tenant statistics service.
+func NewTenantStatsService(cache cache.ValkeyCluster, cfg config.StatsSnapshotConfig, tenant string, logger corelogger.Logger) *TenantStatsService {
+	return &TenantStatsService{
+		cache:    cache,
+		cfg:      cfg,
+		tenant:   tenant,
+		logger:   logging.FromCoreLogger(logger),
+		counters: map[string]TenantStatsCounter{},
+	}
+}
+
+// Register adds a statistic collected in every snapshot.
+func (s *TenantStatsService) Register(name string, counter TenantStatsCounter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.counters[name] = counter
+}
+
+// Start takes a snapshot every Interval until ctx ends. Every instance runs
+// the job; a tick is skipped when another instance took a snapshot within
+// the last half interval.
+func (s *TenantStatsService) Start(ctx context.Context) {
+	if s.cfg.Interval <= 0 {
+		return
+	}
+	ctx = qos.WithClass(ctx, qos.Background)
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		if !s.recent(ctx, time.Now()) {
+			s.Snapshot(ctx)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Overview returns the latest snapshot with its trend deltas, taking a
+// snapshot first when none is stored or refresh is set.
+func (s *TenantStatsService) Overview(ctx context.Context, refresh bool) (*models.TenantStatsOverview, error) {
+	if !refresh {
+		if data, err := s.cache.Get(ctx, tenantStatsLatestKey); err == nil && len(data) > 0 {
+			var overview models.TenantStatsOverview
+			if err := json.Unmarshal(data, &overview); err == nil {
+				return &overview, nil
+			}
+		}
+	}
+	return s.Snapshot(ctx), nil
+}
+
+// History returns the stored snapshots, newest first, at most limit of them
+// when limit is positive.
+func (s *TenantStatsService) History(ctx context.Context, limit int) ([]models.TenantStatsSnapshot, error) {
+	data, err := s.cache.Get(ctx, tenantStatsSnapshotsKey)
+	if err != nil || len(data) == 0 {
+		return []models.TenantStatsSnapshot{}, nil
+	}
+	var snapshots []models.TenantStatsSnapshot
+	if err := json.Unmarshal(data, &snapshots); err != nil {
+		return nil, fmt.Errorf("decode tenant statistics snapshots: %w", err)
+	}
+	if limit > 0 && len(snapshots) > limit {
+		snapshots = snapshots[:limit]
+	}
+	return snapshots, nil
+}
+
+// Snapshot collects every statistic, stores the snapshot in the history
+// and returns the new overview.
+func (s *TenantStatsService) Snapshot(ctx context.Context) *models.TenantStatsOverview {
+	snap := s.collect(ctx, time.Now().UTC())
+
+	history, err := s.History(ctx, 0)
+	if err != nil {
+		s.logger.Warn("Resetting unreadable tenant statistics snapshots", "error", err)
+		history = nil
+	}
+	history = append([]models.TenantStatsSnapshot{snap}, history...)
+	if s.cfg.Retention > 0 && len(history) > s.cfg.Retention {
+		history = history[:s.cfg.Retention]
+	}
+	overview := tenantStatsOverview(history, s.cfg.Interval)
+
+	if err := s.cache.Set(ctx, tenantStatsSnapshotsKey, history, 0); err != nil {
+		s.logger.Warn("Failed to store tenant statistics snapshots", "error", err)
+	}
+	if err := s.cache.Set(ctx, tenantStatsLatestKey, overview, 0); err != nil {
+		s.logger.Warn("Failed to store tenant statistics overview", "error", err)
+	}
+	return overview
+}
+
+// recent reports whether the latest stored snapshot is younger than half
+// an interval.
+func (s *TenantStatsService) recent(ctx context.Context, now time.Time) bool {
+	data, err := s.cache.Get(ctx, tenantStatsLatestKey)
+	if err != nil || len(data) == 0 {
+		return false
+	}
+	var overview models.TenantStatsOverview
+	if err := json.Unmarshal(data, &overview); err != nil {
+		return false
+	}
+	return now.Sub(overview.Snapshot.TakenAt) < s.cfg.Interval/2
+}
+
+func (s *TenantStatsService) collect(ctx context.Context, now time.Time) models.TenantStatsSnapshot {
+	s.mu.RLock()
+	counters := make(map[string]TenantStatsCounter, len(s.counters))
+	for name, fn := range s.counters {
+		counters[name] = fn
+	}
+	s.mu.RUnlock()
+
+	snap := models.TenantStatsSnapshot{Tenant: s.tenant, TakenAt: now, Counts: map[string]int64{}}
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for name, fn := range counters {
+		wg.Add(1)
+		go func(name string, fn TenantStatsCounter) {
+			defer wg.Done()
+			cctx, cancel := context.WithTimeout(ctx, tenantStatsCounterTimeout)
+			defer cancel()
+			n, err := fn(cctx)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				snap.Unavailable = append(snap.Unavailable, name+": "+err.Error())
+				return
+			}
+			snap.Counts[name] = n
+		}(name, fn)
+	}
+	wg.Wait()
+	sort.Strings(snap.Unavailable)
+	return snap
+}
+
+// tenantStatsOverview computes the deltas of history[0] against the
+// previous snapshot and those about a day and a week older. A snapshot
+// counts as old enough within half an interval of the mark.
+func tenantStatsOverview(history []models.TenantStatsSnapshot, interval time.Duration) *models.TenantStatsOverview {
+	latest := history[0]
+	overview := &models.TenantStatsOverview{Snapshot: latest, Deltas: map[string]models.TenantStatsDelta{}}
+
+	var previous, day, week *models.TenantStatsSnapshot
+	if len(history) > 1 {
+		previous = &history[1]
+	}
+	for i := 1; i < len(history); i++ {
+		age := latest.TakenAt.Sub(history[i].TakenAt) + interval/2
+		if day == nil && age >= 24*time.Hour {
+			day = &history[i]
+		}
+		if week == nil && age >= 7*24*time.Hour {
+			week = &history[i]
+		}
+	}
+	delta := func(old *models.TenantStatsSnapshot, name string, cur int64) *int64 {
+		if old == nil {
+			return nil
+		}
+		prev, ok := old.Counts[name]
+		if !ok {
+			return nil
+		}
+		d := cur - prev
+		return &d
+	}
+	for name, cur := range latest.Counts {
+		overview.Deltas[name] = models.TenantStatsDelta{
+			Previous: delta(previous, name, cur),
+			Day:      delta(day, name, cur),
+			Week:     delta(week, name, cur),
+		}
+	}
+	return overview
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func TestTenantStatsService_SnapshotsAndDeltas(t *testing.T) {
+	log := logger.New("error")
+	svc := NewTenantStatsService(cache.NewNoopValkeyCache(log), config.StatsSnapshotConfig{Interval: time.Hour, Retention: 2}, "acme", log)
+	ctx := context.Background()
+
+	kpis := int64(10)
+	svc.Register("kpis", func(context.Context) (int64, error) { return kpis, nil })
+	svc.Register("weaviate.FailureRecord", func(context.Context) (int64, error) { return 0, errors.New("weaviate down") })
+
+	// The first read takes a snapshot.
+	overview, err := svc.Overview(ctx, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if overview.Snapshot.Tenant != "acme" || overview.Snapshot.Counts["kpis"] != 10 {
+		t.Fatalf("unexpected snapshot %+v", overview.Snapshot)
+	}
+	if len(overview.Snapshot.Unavailable) != 1 || overview.Snapshot.Unavailable[0] != "weaviate.FailureRecord: weaviate down" {
+		t.Fatalf("unexpected unavailable %v", overview.Snapshot.Unavailable)
+	}
+	if d := overview.Deltas["kpis"]; d.Previous != nil || d.Day != nil {
+		t.Fatalf("expected no deltas without history, got %+v", d)
+	}
+	if !svc.recent(ctx, time.Now()) {
+		t.Fatal("expected the stored snapshot to be recent")
+	}
+
+	// Later reads serve the stored overview until refreshed.
+	kpis = 13
+	if cached, _ := svc.Overview(ctx, false); cached.Snapshot.Counts["kpis"] != 10 {
+		t.Fatalf("expected the stored overview, got %+v", cached.Snapshot)
+	}
+	overview, _ = svc.Overview(ctx, true)
+	if d := overview.Deltas["kpis"]; d.Previous == nil || *d.Previous != 3 {
+		t.Fatalf("expected a delta of 3, got %+v", d)
+	}
+
+	svc.Snapshot(ctx)
+	history, err := svc.History(ctx, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("expected retention to keep 2 snapshots, got %d", len(history))
+	}
+	if limited, _ := svc.History(ctx, 1); len(limited) != 1 {
+		t.Fatalf("expected 1 snapshot, got %d", len(limited))
+	}
+}
+
+func TestTenantStatsOverview_DayAndWeek(t *testing.T) {
+	now := time.Now().UTC()
+	snap := func(age time.Duration, kpis int64) models.TenantStatsSnapshot {
+		return models.TenantStatsSnapshot{TakenAt: now.Add(-age), Counts: map[string]int64{"kpis": kpis}}
+	}
+	history := []models.TenantStatsSnapshot{
+		snap(0, 50),
+		snap(time.Hour, 48),
+		// Within half an interval of a day.
+		snap(24*time.Hour-20*time.Minute, 45),
+		snap(48*time.Hour, 40),
+		snap(7*24*time.Hour, 20),
+		snap(8*24*time.Hour, 10),
+	}
+	d := tenantStatsOverview(history, time.Hour).Deltas["kpis"]
+	if d.Previous == nil || *d.Previous != 2 || d.Day == nil || *d.Day != 5 || d.Week == nil || *d.Week != 30 {
+		t.Fatalf("unexpected deltas %+v", d)
+	}
+
+	d = tenantStatsOverview(history[:4], time.Hour).Deltas["kpis"]
+	if d.Week != nil {
+		t.Fatalf("expected no week delta without a week of history, got %d", *d.Week)
+	}
+}
@@ -0,0 +1,253 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/qos"
+	"github.com/mirastacklabs-ai/mirador-core/internal/ticketing"
+	"github.com/mirastacklabs-ai/mirador-core/internal/weavstore"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+const (
+	// Ticket links are stored under ticketLinkKeyPrefix plus the failure
+	// UUID; ticketKeyIndexPrefix plus the ticket key maps back to it.
+	ticketLinkKeyPrefix  = "ticket:failure:"
+	ticketKeyIndexPrefix = "ticket:key:"
+
+	// maxTicketSyncAge stops polling tickets opened longer ago than this.
+	maxTicketSyncAge = 30 * 24 * time.Hour
+)
+
+var ErrTicketingDisabled = errors.New("ticketing not configured")
+
+// TicketingService opens a ticket in the configured Jira or ServiceNow
+// instance for every new incident (failure record), notes re-detections on
+// it and keeps the ticket's status, received by webhook or polled, on the
+// incident. Links are stored in Valkey without expiry.
+type TicketingService struct {
+	provider ticketing.Provider
+	cache    cache.ValkeyCluster
+	cfg      config.TicketingConfig
+	logger   logging.Logger
+
+	// serializes Open so concurrent detections open one ticket per incident
+	mu sync.Mutex
+}
+
+// NewTicketingService creates a new ticketing service. provider may be nil;
+// ticketing is then disabled.
+func NewTicketingService(provider ticketing.Provider, cache cache.ValkeyCluster, cfg config.TicketingConfig, logger corelogger.Logger) *TicketingService {
+	return &TicketingService{
+		provider: provider,
+		cache:    cache,
+		cfg:      cfg,
+		logger:   logging.FromCoreLogger(logger),
+	}
+}
+
+// Enabled reports whether a ticketing provider is configured.
+func (s *TicketingService) Enabled() bool {
+	return s != nil && s.provider != nil
+}
+
+// Start polls the status of open tickets every SyncInterval until ctx ends.
+func (s *TicketingService) Start(ctx context.Context) {
+	if !s.Enabled() || s.cfg.SyncInterval <= 0 {
+		return
+	}
+	ctx = qos.WithClass(ctx, qos.Background)
+	ticker := time.NewTicker(s.cfg.SyncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if n, err := s.Sync(ctx); err != nil {
+				s.logger.Warn("Failed to sync incident tickets", "error", err)
+			} else if n > 0 {
+				s.logger.Info("Synced incident ticket statuses", "updated", n)
+			}
+		}
+	}
+}
+
+// Ticket returns the ticket linked to the incident, or nil.
+func (s *TicketingService) Ticket(ctx context.Context, failureUUID string) (*models.IncidentTicket, error) {
+	data, err := s.cache.Get(ctx, ticketLinkKeyPrefix+failureUUID)
+	if err != nil || len(data) == 0 {
+		return nil, nil
+	}
+	var t models.IncidentTicket
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal incident ticket: %w", err)
+	}
+	return &t, nil
+}
+
+// Open opens a ticket for the incident and links it. An incident that
+// already has a ticket keeps it.
+func (s *TicketingService) Open(ctx context.Context, f *weavstore.FailureRecord) (*models.IncidentTicket, error) {
+	if !s.Enabled() {
+		return nil, ErrTicketingDisabled
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t, err := s.Ticket(ctx, f.FailureUUID); err != nil || t != nil {
+		return t, err
+	}
+
+	expand := ticketPlaceholders(f)
+	ticket := ticketing.Ticket{
+		Summary:     expand.Replace("Incident {failure_id}: {services}"),
+		Description: expand.Replace("Incident {failure_id} detected by mirador-core.\nServices: {services}\nComponents: {components}\nWindow: {start} to {end}\nConfidence: {confidence}"),
+		Labels:      []string{"mirador", "incident"},
+		Fields:      make(map[string]string, len(s.cfg.FieldMapping)),
+	}
+	for field, value := range s.cfg.FieldMapping {
+		ticket.Fields[field] = expand.Replace(value)
+	}
+	ref, err := s.provider.Create(ctx, ticket)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	t := &models.IncidentTicket{
+		FailureUUID: f.FailureUUID,
+		FailureID:   f.FailureID,
+		Provider:    s.provider.Name(),
+		TicketID:    ref.ID,
+		Key:         ref.Key,
+		URL:         ref.URL,
+		CreatedAt:   now,
+		SyncedAt:    now,
+	}
+	if err := s.store(ctx, t); err != nil {
+		return nil, err
+	}
+	if err := s.cache.Set(ctx, ticketKeyIndexPrefix+ref.Key, f.FailureUUID, 0); err != nil {
+		return nil, fmt.Errorf("failed to index incident ticket: %w", err)
+	}
+	s.logger.Info("Incident ticket opened", "failure_id", f.FailureID, "provider", t.Provider, "key", t.Key)
+	return t, nil
+}
+
+// NoteRedetected comments on the incident's ticket that it was detected
+// again. Incidents without a ticket are ignored.
+func (s *TicketingService) NoteRedetected(ctx context.Context, f *weavstore.FailureRecord) error {
+	if !s.Enabled() {
+		return ErrTicketingDisabled
+	}
+	t, err := s.Ticket(ctx, f.FailureUUID)
+	if err != nil || t == nil {
+		return err
+	}
+	text := ticketPlaceholders(f).Replace("Incident {failure_id} detected again by mirador-core for {start} to {end} (confidence {confidence}).")
+	return s.provider.Comment(ctx, ticketing.Ref{ID: t.TicketID, Key: t.Key, URL: t.URL}, text)
+}
+
+// VerifyWebhookSecret reports whether secret matches the configured webhook
+// secret. Webhooks are refused while no secret is configured.
+func (s *TicketingService) VerifyWebhookSecret(secret string) bool {
+	return s.cfg.WebhookSecret != "" && subtle.ConstantTimeCompare([]byte(secret), []byte(s.cfg.WebhookSecret)) == 1
+}
+
+// ApplyWebhook records the status carried by a provider webhook on the
+// linked incident. It returns nil for tickets not opened by mirador-core.
+func (s *TicketingService) ApplyWebhook(ctx context.Context, body []byte) (*models.IncidentTicket, error) {
+	if !s.Enabled() {
+		return nil, ErrTicketingDisabled
+	}
+	key, status, err := s.provider.ParseWebhook(body)
+	if err != nil {
+		return nil, err
+	}
+	uuid, err := s.cache.Get(ctx, ticketKeyIndexPrefix+key)
+	if err != nil || len(uuid) == 0 {
+		return nil, nil
+	}
+	t, err := s.Ticket(ctx, string(uuid))
+	if err != nil || t == nil {
+		return nil, err
+	}
+	t.Status, t.SyncedAt = status, time.Now().UTC()
+	return t, s.store(ctx, t)
+}
+
+// Sync polls the status of every ticket opened within the last 30 days and
+// returns how many changed.
+func (s *TicketingService) Sync(ctx context.Context) (int, error) {
+	if !s.Enabled() {
+		return 0, ErrTicketingDisabled
+	}
+	var keys []string
+	if err := s.cache.ScanKeys(ctx, ticketLinkKeyPrefix, func(batch []string) error {
+		keys = append(keys, batch...)
+		return nil
+	}); err != nil {
+		return 0, fmt.Errorf("failed to list incident tickets: %w", err)
+	}
+	cutoff := time.Now().Add(-maxTicketSyncAge)
+	updated := 0
+	for _, key := range keys {
+		if ctx.Err() != nil {
+			return updated, ctx.Err()
+		}
+		t, err := s.Ticket(ctx, strings.TrimPrefix(key, ticketLinkKeyPrefix))
+		if err != nil || t == nil || t.CreatedAt.Before(cutoff) {
+			continue
+		}
+		status, err := s.provider.Status(ctx, ticketing.Ref{ID: t.TicketID, Key: t.Key, URL: t.URL})
+		if err != nil {
+			s.logger.Warn("Failed to poll incident ticket", "key", t.Key, "error", err)
+			continue
+		}
+		if status == t.Status {
+			continue
+		}
+		t.Status, t.SyncedAt = status, time.Now().UTC()
+		if err := s.store(ctx, t); err != nil {
+			return updated, err
+		}
+		updated++
+	}
+	return updated, nil
+}
+
+func (s *TicketingService) store(ctx context.Context, t *models.IncidentTicket) error {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return fmt.Errorf("failed to marshal incident ticket: %w", err)
+	}
+	if err := s.cache.Set(ctx, ticketLinkKeyPrefix+t.FailureUUID, data, 0); err != nil {
+		return fmt.Errorf("failed to store incident ticket: %w", err)
+	}
+	return nil
+}
+
+// ticketPlaceholders fills the placeholders of ticket templates and field
+// mappings from f.
+func ticketPlaceholders(f *weavstore.FailureRecord) *strings.Replacer {
+	return strings.NewReplacer(
+		"{failure_id}", f.FailureID,
+		"{services}", strings.Join(f.Services, ", "),
+		"{components}", strings.Join(f.Components, ", "),
+		"{confidence}", strconv.FormatFloat(f.ConfidenceScore, 'f', 2, 64),
+		"{start}", f.TimeRange.Start.UTC().Format(time.RFC3339),
+		"{end}", f.TimeRange.End.UTC().Format(time.RFC3339),
+	)
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/ticketing"
+	"github.com/mirastacklabs-ai/mirador-core/internal/weavstore"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// fakeTicketProvider keeps tickets in memory; webhooks carry "key=status".
+type fakeTicketProvider struct {
+	created  []ticketing.Ticket
+	comments []string
+	status   map[string]string
+}
+
+func (f *fakeTicketProvider) Name() string { return "fake" }
+
+func (f *fakeTicketProvider) Create(ctx context.Context, t ticketing.Ticket) (*ticketing.Ref, error) {
+	f.created = append(f.created, t)
+	return &ticketing.Ref{ID: "1", Key: "OPS-1", URL: "https://tickets.example/OPS-1"}, nil
+}
+
+func (f *fakeTicketProvider) Status(ctx context.Context, ref ticketing.Ref) (string, error) {
+	return f.status[ref.Key], nil
+}
+
+func (f *fakeTicketProvider) Comment(ctx context.Context, ref ticketing.Ref, text string) error {
+	f.comments = append(f.comments, text)
+	return nil
+}
+
+func (f *fakeTicketProvider) ParseWebhook(body []byte) (string, string, error) {
+	key, status, ok := strings.Cut(string(body), "=")
+	if !ok {
+		return "", "", ticketing.ErrInvalidWebhook
+	}
+	return key, status, nil
+}
+
+func TestTicketingService(t *testing.T) {
+	log := logger.New("error")
+	ctx := context.Background()
+	provider := &fakeTicketProvider{status: map[string]string{}}
+	svc := NewTicketingService(provider, cache.NewNoopValkeyCache(log), config.TicketingConfig{
+		FieldMapping:  map[string]string{"component": "{components}"},
+		WebhookSecret: "s3cret",
+	}, log)
+
+	start := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	f := &weavstore.FailureRecord{FailureUUID: "u1", FailureID: "checkout-db-1", Services: []string{"checkout"}, Components: []string{"db"},
+		TimeRange: weavstore.TimeRange{Start: start, End: start.Add(time.Hour)}, ConfidenceScore: 0.8}
+
+	ticket, err := svc.Open(ctx, f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ticket.Key != "OPS-1" || ticket.FailureID != "checkout-db-1" || ticket.Provider != "fake" {
+		t.Fatalf("unexpected ticket %+v", ticket)
+	}
+	if got := provider.created[0]; got.Summary != "Incident checkout-db-1: checkout" || got.Fields["component"] != "db" {
+		t.Fatalf("unexpected ticket content %+v", got)
+	}
+	if _, err := svc.Open(ctx, f); err != nil || len(provider.created) != 1 {
+		t.Fatalf("expected the linked ticket to be reused, got %d tickets, %v", len(provider.created), err)
+	}
+
+	if err := svc.NoteRedetected(ctx, f); err != nil || len(provider.comments) != 1 {
+		t.Fatalf("expected a re-detection comment, got %v, %v", provider.comments, err)
+	}
+
+	if svc.VerifyWebhookSecret("wrong") || !svc.VerifyWebhookSecret("s3cret") {
+		t.Fatal("unexpected webhook secret verification")
+	}
+	if got, err := svc.ApplyWebhook(ctx, []byte("OPS-1=In Progress")); err != nil || got == nil || got.Status != "In Progress" {
+		t.Fatalf("unexpected webhook result %+v, %v", got, err)
+	}
+	if got, err := svc.ApplyWebhook(ctx, []byte("OPS-99=Done")); err != nil || got != nil {
+		t.Fatalf("expected unknown tickets to be ignored, got %+v, %v", got, err)
+	}
+	if _, err := svc.ApplyWebhook(ctx, []byte("garbage")); !errors.Is(err, ticketing.ErrInvalidWebhook) {
+		t.Fatalf("expected ErrInvalidWebhook, got %v", err)
+	}
+
+	provider.status["OPS-1"] = "Done"
+	if n, err := svc.Sync(ctx); err != nil || n != 1 {
+		t.Fatalf("expected one synced ticket, got %d, %v", n, err)
+	}
+	if got, _ := svc.Ticket(ctx, "u1"); got.Status != "Done" {
+		t.Fatalf("expected the polled status, got %+v", got)
+	}
+
+	disabled := NewTicketingService(nil, cache.NewNoopValkeyCache(log), config.TicketingConfig{}, log)
+	if _, err := disabled.Open(ctx, f); !errors.Is(err, ErrTicketingDisabled) {
+		t.Fatalf("expected ErrTicketingDisabled, got %v", err)
+	}
+	if disabled.VerifyWebhookSecret("") {
+		t.Fatal("webhooks must be refused without a secret")
+	}
+}
@@ -845,10 +845,11 @@ func (u *UnifiedQueryEngineImpl) executeLogsQuery(ctx context.Context, query *mo
 	}
 
 	logsQuery := &models.LogsQLQueryRequest{
-		Query: query.Query,
-		Start: startTime,
-		End:   endTime,
-		Limit: 1000, // default limit
+		Query:   query.Query,
+		Start:   startTime,
+		End:     endTime,
+		Limit:   1000, // default limit
+		Columns: computedColumnsParam(query.Parameters),
 	}
 
 	result, err := u.logsService.ExecuteQuery(ctx, logsQuery)
@@ -876,6 +877,19 @@ func (u *UnifiedQueryEngineImpl) executeLogsQuery(ctx context.Context, query *mo
 	}, nil
 }
 
+// computedColumnsParam reads the computed columns requested through the
+// "columns" parameter of a logs query.
+func computedColumnsParam(params map[string]interface{}) []string {
+	raw, _ := params["columns"].([]interface{})
+	var columns []string
+	for _, c := range raw {
+		if name, ok := c.(string); ok {
+			columns = append(columns, name)
+		}
+	}
+	return columns
+}
+
 // shouldUseBleveForLogs determines if a query should use Bleve instead of VictoriaLogs
 func (u *UnifiedQueryEngineImpl) shouldUseBleveForLogs(queryStr string) bool {
 	// Use Bleve for full-text search patterns
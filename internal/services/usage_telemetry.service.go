@@ -0,0 +1,255 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/version"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+// usageDeploymentIDKey holds the random deployment ID shared by all
+// instances of a deployment.
+const usageDeploymentIDKey = "telemetry:deployment_id"
+
+type usageKey struct{ method, route string }
+
+type usageCount struct{ requests, errors int64 }
+
+// UsageTelemetryService counts requests per route template for opt-in
+// product telemetry. Nothing identifying is recorded: no path parameters,
+// query strings, tenants, users or addresses, and the deployment is
+// identified by a random UUID.
+type UsageTelemetryService struct {
+	cfg    config.UsageTelemetryConfig
+	cache  cache.ValkeyCluster
+	client *http.Client
+	logger logging.Logger
+
+	mu           sync.Mutex
+	counts       map[usageKey]*usageCount
+	periodStart  time.Time
+	deploymentID string
+	lastSentAt   *time.Time
+	lastError    string
+}
+
+// NewUsageTelemetryService creates a usage telemetry service.
+func NewUsageTelemetryService(cfg config.UsageTelemetryConfig, cache cache.ValkeyCluster, logger corelogger.Logger) *UsageTelemetryService {
+	return &UsageTelemetryService{
+		cfg:         cfg,
+		cache:       cache,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		logger:      logging.FromCoreLogger(logger),
+		counts:      map[usageKey]*usageCount{},
+		periodStart: time.Now().UTC(),
+	}
+}
+
+// Enabled reports whether usage is being recorded.
+func (s *UsageTelemetryService) Enabled() bool {
+	return s.cfg.Enabled
+}
+
+// Record counts one request. Requests that matched no route are ignored so
+// arbitrary paths never enter the payload.
+func (s *UsageTelemetryService) Record(method, route string, status int) {
+	if !s.cfg.Enabled || route == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	k := usageKey{method, route}
+	c := s.counts[k]
+	if c == nil {
+		c = &usageCount{}
+		s.counts[k] = c
+	}
+	c.requests++
+	if status >= 500 {
+		c.errors++
+	}
+}
+
+// Status returns the telemetry settings and the payload of the current
+// period, exactly as it would be reported.
+func (s *UsageTelemetryService) Status(ctx context.Context) *models.UsageTelemetryStatus {
+	var id string
+	if s.cfg.Enabled {
+		id = s.deployment(ctx)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &models.UsageTelemetryStatus{
+		Enabled:      s.cfg.Enabled,
+		CollectorURL: s.cfg.CollectorURL,
+		LastSentAt:   s.lastSentAt,
+		LastError:    s.lastError,
+		Pending:      s.reportLocked(id, time.Now().UTC()),
+	}
+}
+
+// Start reports to the collector every ReportInterval until ctx ends.
+func (s *UsageTelemetryService) Start(ctx context.Context) {
+	if !s.cfg.Enabled || s.cfg.CollectorURL == "" || s.cfg.ReportInterval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(s.cfg.ReportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Flush(ctx); err != nil {
+				s.logger.Warn("Failed to report usage telemetry", "error", err)
+			}
+		}
+	}
+}
+
+// Flush sends the current period to the collector and starts a new one. On
+// failure the counts are kept for the next attempt.
+func (s *UsageTelemetryService) Flush(ctx context.Context) error {
+	id := s.deployment(ctx)
+	s.mu.Lock()
+	now := time.Now().UTC()
+	report := s.reportLocked(id, now)
+	sent := s.counts
+	s.counts, s.periodStart = map[usageKey]*usageCount{}, now
+	s.mu.Unlock()
+
+	err := s.send(ctx, &report)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		s.lastError = err.Error()
+		for k, c := range sent {
+			cur := s.counts[k]
+			if cur == nil {
+				s.counts[k] = c
+				continue
+			}
+			cur.requests += c.requests
+			cur.errors += c.errors
+		}
+		s.periodStart = report.PeriodStart
+		return err
+	}
+	s.lastSentAt, s.lastError = &now, ""
+	return nil
+}
+
+func (s *UsageTelemetryService) send(ctx context.Context, report *models.UsageReport) error {
+	body, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.CollectorURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("collector returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *UsageTelemetryService) reportLocked(id string, now time.Time) models.UsageReport {
+	report := models.UsageReport{
+		DeploymentID: id,
+		Version:      version.Version,
+		PeriodStart:  s.periodStart,
+		PeriodEnd:    now,
+		Features:     []models.FeatureUsage{},
+		Endpoints:    make([]models.EndpointUsage, 0, len(s.counts)),
+	}
+	features := map[string]int64{}
+	for k, c := range s.counts {
+		report.Endpoints = append(report.Endpoints, models.EndpointUsage{Method: k.method, Route: k.route, Requests: c.requests, Errors: c.errors})
+		features[usageFeature(k.route)] += c.requests
+	}
+	for f, n := range features {
+		report.Features = append(report.Features, models.FeatureUsage{Feature: f, Requests: n})
+	}
+	sort.Slice(report.Endpoints, func(i, j int) bool {
+		a, b := report.Endpoints[i], report.Endpoints[j]
+		if a.Requests != b.Requests {
+			return a.Requests > b.Requests
+		}
+		return a.Route+" "+a.Method < b.Route+" "+b.Method
+	})
+	sort.Slice(report.Features, func(i, j int) bool {
+		a, b := report.Features[i], report.Features[j]
+		if a.Requests != b.Requests {
+			return a.Requests > b.Requests
+		}
+		return a.Feature < b.Feature
+	})
+	return report
+}
+
+// deployment returns the deployment ID, creating and storing it on first
+// use so every instance and restart reports under the same ID.
+func (s *UsageTelemetryService) deployment(ctx context.Context) string {
+	s.mu.Lock()
+	id := s.deploymentID
+	s.mu.Unlock()
+	if id != "" {
+		return id
+	}
+	if s.cache != nil {
+		if data, err := s.cache.Get(ctx, usageDeploymentIDKey); err == nil && len(data) > 0 {
+			id = string(data)
+		}
+	}
+	if id == "" {
+		id = uuid.NewString()
+		if s.cache != nil {
+			if err := s.cache.Set(ctx, usageDeploymentIDKey, id, 0); err != nil {
+				s.logger.Warn("Failed to store telemetry deployment ID", "error", err)
+			}
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.deploymentID == "" {
+		s.deploymentID = id
+	}
+	return s.deploymentID
+}
+
+// usageFeature maps a route template to its feature area.
+func usageFeature(route string) string {
+	rest := strings.TrimPrefix(route, "/api/v1/")
+	if rest == route {
+		return "other"
+	}
+	if i := strings.IndexByte(rest, '/'); i >= 0 {
+		rest = rest[:i]
+	}
+	if rest == "" || strings.HasPrefix(rest, ":") || strings.HasPrefix(rest, "*") {
+		return "other"
+	}
+	return rest
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/cache"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func TestUsageTelemetryService_Flush(t *testing.T) {
+	log := logger.New("error")
+	ctx := context.Background()
+
+	var received []models.UsageReport
+	fail := true
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var rep models.UsageReport
+		if err := json.NewDecoder(r.Body).Decode(&rep); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		received = append(received, rep)
+	}))
+	defer collector.Close()
+
+	svc := NewUsageTelemetryService(config.UsageTelemetryConfig{Enabled: true, CollectorURL: collector.URL}, cache.NewNoopValkeyCache(log), log)
+	svc.Record(http.MethodGet, "/api/v1/kpi/defs/:id", http.StatusOK)
+	svc.Record(http.MethodGet, "/api/v1/kpi/defs/:id", http.StatusInternalServerError)
+	svc.Record(http.MethodPost, "/api/v1/unified/query", http.StatusOK)
+	svc.Record(http.MethodGet, "", http.StatusNotFound)
+
+	pending := svc.Status(ctx).Pending
+	if pending.DeploymentID == "" || len(pending.Endpoints) != 2 {
+		t.Fatalf("unexpected pending payload %+v", pending)
+	}
+	if e := pending.Endpoints[0]; e.Route != "/api/v1/kpi/defs/:id" || e.Requests != 2 || e.Errors != 1 {
+		t.Fatalf("unexpected top endpoint %+v", e)
+	}
+	if f := pending.Features[0]; f.Feature != "kpi" || f.Requests != 2 {
+		t.Fatalf("unexpected top feature %+v", f)
+	}
+
+	if err := svc.Flush(ctx); err == nil {
+		t.Fatal("expected collector failure")
+	}
+	if st := svc.Status(ctx); st.LastError == "" || len(st.Pending.Endpoints) != 2 {
+		t.Fatalf("counts must be kept after a failed report, got %+v", st)
+	}
+
+	fail = false
+	if err := svc.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(received) != 1 || received[0].DeploymentID != pending.DeploymentID || len(received[0].Endpoints) != 2 {
+		t.Fatalf("unexpected reports %+v", received)
+	}
+	if st := svc.Status(ctx); st.LastSentAt == nil || len(st.Pending.Endpoints) != 0 {
+		t.Fatalf("expected a fresh period after reporting, got %+v", st)
+	}
+}
+
+func TestUsageTelemetryService_DisabledRecordsNothing(t *testing.T) {
+	log := logger.New("error")
+	svc := NewUsageTelemetryService(config.UsageTelemetryConfig{}, cache.NewNoopValkeyCache(log), log)
+	svc.Record(http.MethodGet, "/api/v1/health", http.StatusOK)
+	st := svc.Status(context.Background())
+	if st.Enabled || st.Pending.DeploymentID != "" || len(st.Pending.Endpoints) != 0 {
+		t.Fatalf("expected nothing recorded while disabled, got %+v", st)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -19,7 +20,12 @@ import (
 	"sync"
 
 	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/faultinject"
 	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/qos"
+	"github.com/mirastacklabs-ai/mirador-core/internal/residency"
+	"github.com/mirastacklabs-ai/mirador-core/internal/tenantscope"
+	"github.com/mirastacklabs-ai/mirador-core/internal/tracing"
 	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
 )
 
@@ -41,6 +47,18 @@ type VictoriaLogsService struct {
 
 	// Optional child services for multi-source aggregation
 	children []*VictoriaLogsService
+
+	// export memory budget; see SetExportLimits
+	export config.ExportConfig
+
+	// row limit for query results; see SetResultLimits
+	maxLogRows int
+
+	// profiles applied to exports; see SetAnonymization
+	anonymization *AnonymizationService
+
+	// columns added to query results; see SetComputedColumns
+	computedColumns *ComputedColumnService
 }
 
 func NewVictoriaLogsService(cfg config.VictoriaLogsConfig, logger logger.Logger) *VictoriaLogsService {
@@ -49,7 +67,8 @@ func NewVictoriaLogsService(cfg config.VictoriaLogsConfig, logger logger.Logger)
 		endpoints: cfg.Endpoints,
 		timeout:   time.Duration(cfg.Timeout) * time.Millisecond,
 		client: &http.Client{
-			Timeout: time.Duration(cfg.Timeout) * time.Millisecond,
+			Timeout:   time.Duration(cfg.Timeout) * time.Millisecond,
+			Transport: qos.WrapTransport(faultinject.WrapTransport(tracing.NewTransport(nil, tracing.BackendVictoriaLogs), faultinject.TargetVictoriaLogs)),
 		},
 		logger:    logger,
 		username:  cfg.Username,
@@ -59,6 +78,50 @@ func NewVictoriaLogsService(cfg config.VictoriaLogsConfig, logger logger.Logger)
 	}
 }
 
+// SetExportLimits bounds the memory ExportLogs uses: payloads beyond
+// MemoryMB spill to a temp file in SpillDir, and payloads beyond MaxMB are
+// rejected with a MemoryBudgetError.
+func (s *VictoriaLogsService) SetExportLimits(cfg config.ExportConfig) {
+	s.export = cfg
+}
+
+// SetAnonymization lets exports name an anonymization profile to apply to
+// their rows.
+func (s *VictoriaLogsService) SetAnonymization(a *AnonymizationService) {
+	s.anonymization = a
+}
+
+// SetComputedColumns lets queries name computed columns to add to their
+// rows. Columns are applied to buffered ExecuteQuery results only.
+func (s *VictoriaLogsService) SetComputedColumns(c *ComputedColumnService) {
+	s.computedColumns = c
+}
+
+// SetResultLimits makes queries returning more than MaxLogRows rows fail
+// with a TooManyResultsError instead of returning them.
+func (s *VictoriaLogsService) SetResultLimits(cfg config.ResultLimitsConfig) {
+	s.maxLogRows = cfg.MaxLogRows
+}
+
+// SetTenantLabels restricts the selects and deletes of this service and its
+// children to entries carrying labels, and adds labels to the entries it
+// inserts; see package tenantscope. Call it once at startup.
+func (s *VictoriaLogsService) SetTenantLabels(labels map[string]string) {
+	s.client.Transport = tenantscope.WrapLogsTransport(s.client.Transport, labels)
+	for _, child := range s.children {
+		child.SetTenantLabels(labels)
+	}
+}
+
+// SetResidency refuses requests of this service and its children to hosts
+// outside the tenant's region; see package residency. Call it once at startup.
+func (s *VictoriaLogsService) SetResidency(policy config.ResidencyConfig) {
+	s.client.Transport = residency.WrapTransport(s.client.Transport, policy, "victoria_logs")
+	for _, child := range s.children {
+		child.SetResidency(policy)
+	}
+}
+
 // SetChildren configures downstream services used for aggregation
 func (s *VictoriaLogsService) SetChildren(children []*VictoriaLogsService) {
 	s.mu.Lock()
@@ -76,6 +139,53 @@ func (s *VictoriaLogsService) ExecuteQuery(
 	ctx context.Context,
 	req *models.LogsQLQueryRequest,
 ) (*models.LogsQLQueryResult, error) {
+	columns := req.Columns
+	if len(columns) > 0 {
+		if s.computedColumns == nil {
+			return nil, fmt.Errorf("%w: computed columns are not configured", ErrInvalidComputedColumn)
+		}
+		// Columns are added once to the merged result, not by each source.
+		r := *req
+		r.Columns = nil
+		req = &r
+	}
+	res, err := s.executeQuery(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if s.maxLogRows > 0 && len(res.Logs) > s.maxLogRows {
+		return nil, s.tooManyRows(req, res.Logs, false)
+	}
+	if len(columns) > 0 {
+		if err := s.computedColumns.Apply(ctx, columns, res.Logs); err != nil {
+			return nil, err
+		}
+		for _, c := range columns {
+			if !slices.Contains(res.Fields, c) {
+				res.Fields = append(res.Fields, c)
+			}
+		}
+	}
+	return res, nil
+}
+
+// tooManyRows builds the error for a result over maxLogRows; atLeast marks
+// rows as a prefix of the result.
+func (s *VictoriaLogsService) tooManyRows(req *models.LogsQLQueryRequest, rows []map[string]any, atLeast bool) error {
+	return &TooManyResultsError{
+		Query:       req.Query,
+		Unit:        "rows",
+		Count:       len(rows),
+		Limit:       s.maxLogRows,
+		AtLeast:     atLeast,
+		Suggestions: logsSuggestions(req.Query, rows, s.maxLogRows, req.Start, req.End),
+	}
+}
+
+func (s *VictoriaLogsService) executeQuery(
+	ctx context.Context,
+	req *models.LogsQLQueryRequest,
+) (*models.LogsQLQueryResult, error) {
 
 	// Multi-endpoint aggregation when multiple endpoints configured in this service
 	if func() bool { s.mu.Lock(); defer s.mu.Unlock(); return len(s.endpoints) > 1 }() {
@@ -154,6 +264,11 @@ func (s *VictoriaLogsService) ExecuteQuery(
 			cp[k] = v
 		}
 		rows = append(rows, cp)
+		// Stop reading once the limit is passed; the rows so far are
+		// enough to compute suggestions.
+		if s.maxLogRows > 0 && len(rows) > s.maxLogRows {
+			return s.tooManyRows(req, rows, true)
+		}
 		return nil
 	}
 
@@ -674,6 +789,58 @@ func (s *VictoriaLogsService) StoreJSONEvent(ctx context.Context, event map[stri
 	return nil
 }
 
+// DeleteLogs starts a background task deleting the logs matching filter on
+// each configured endpoint and child source, and returns the task IDs. It
+// needs a VictoriaLogs release with the /delete/run_task API.
+func (s *VictoriaLogsService) DeleteLogs(ctx context.Context, filter string) ([]string, error) {
+	if strings.TrimSpace(filter) == "" {
+		return nil, errors.New("a filter is required")
+	}
+	s.mu.Lock()
+	endpoints := append([]string(nil), s.endpoints...)
+	children := s.children
+	s.mu.Unlock()
+
+	var tasks []string
+	for _, ep := range endpoints {
+		form := url.Values{"filter": {filter}}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(ep, "/")+"/delete/run_task", strings.NewReader(form.Encode()))
+		if err != nil {
+			return tasks, err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if s.username != "" {
+			req.SetBasicAuth(s.username, s.password)
+		}
+		// Not retried: a failed delete is reported and picked up by the next run.
+		resp, err := s.client.Do(req)
+		if err != nil {
+			return tasks, fmt.Errorf("delete logs on %s: %w", ep, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			msg := readErrBody(resp.Body)
+			resp.Body.Close()
+			return tasks, fmt.Errorf("delete logs on %s: status %d: %s", ep, resp.StatusCode, msg)
+		}
+		var out struct {
+			TaskID string `json:"task_id"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&out)
+		resp.Body.Close()
+		if out.TaskID != "" {
+			tasks = append(tasks, out.TaskID)
+		}
+	}
+	for _, child := range children {
+		ids, err := child.DeleteLogs(ctx, filter)
+		tasks = append(tasks, ids...)
+		if err != nil {
+			return tasks, err
+		}
+	}
+	return tasks, nil
+}
+
 func (s *VictoriaLogsService) selectEndpoint() string {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -1096,6 +1263,17 @@ func (s *VictoriaLogsService) ExportLogs(ctx context.Context, request *models.Lo
 	if request == nil {
 		return nil, fmt.Errorf("nil export request")
 	}
+	var anon *Anonymizer
+	if request.Profile != "" {
+		if s.anonymization == nil {
+			return nil, fmt.Errorf("%w: anonymization is not configured", ErrInvalidAnonymizationProfile)
+		}
+		a, err := s.anonymization.Anonymizer(ctx, request.Profile)
+		if err != nil {
+			return nil, err
+		}
+		anon = a
+	}
 	endpoint := s.selectEndpoint()
 	if endpoint == "" {
 		return nil, fmt.Errorf("no victoria logs endpoint configured")
@@ -1119,7 +1297,13 @@ func (s *VictoriaLogsService) ExportLogs(ctx context.Context, request *models.Lo
 		// Default to CSV for downloads
 		format = "csv"
 	}
-	params.Set("format", format)
+	// Anonymized exports are fetched as NDJSON so rows can be rewritten,
+	// then converted to the requested format.
+	fetchFormat := format
+	if anon != nil {
+		fetchFormat = "json"
+	}
+	params.Set("format", fetchFormat)
 
 	// Use the documented query endpoint and pass the desired format.
 	// There is no /export for VictoriaLogs HTTP API.
@@ -1130,7 +1314,7 @@ func (s *VictoriaLogsService) ExportLogs(ctx context.Context, request *models.Lo
 		return nil, err
 	}
 	// Hint desired response type to VictoriaLogs.
-	if format == "csv" {
+	if fetchFormat == "csv" {
 		req.Header.Set("Accept", "text/csv")
 	} else {
 		req.Header.Set("Accept", "application/json, */*")
@@ -1154,12 +1338,42 @@ func (s *VictoriaLogsService) ExportLogs(ctx context.Context, request *models.Lo
 			r = gr
 		}
 	}
-	data, err := io.ReadAll(r)
-	if err != nil {
+	buf := newSpillBuffer("log export", int64(s.export.MemoryMB)<<20, int64(s.export.MaxMB)<<20, s.export.SpillDir)
+	if _, err := io.Copy(buf, r); err != nil {
+		buf.Discard()
 		return nil, fmt.Errorf("read export: %w", err)
 	}
 
 	ct := strings.ToLower(resp.Header.Get("Content-Type"))
+	var report *models.AnonymizationReport
+	if anon != nil {
+		out, err := s.anonymizeExport(buf, anon)
+		if err != nil {
+			return nil, err
+		}
+		buf, ct = out, "application/x-ndjson"
+		if report, err = s.anonymization.SaveReport(ctx, anon.Report("logs_export")); err != nil {
+			buf.Discard()
+			return nil, err
+		}
+	}
+	filename := fmt.Sprintf("logs-%d.%s", time.Now().Unix(), format)
+	if buf.Spilled() {
+		// Too large for memory: convert and serve from temp files.
+		body, size, err := s.spilledExportBody(buf, format == "csv" && !strings.Contains(ct, "csv"))
+		if err != nil {
+			return nil, err
+		}
+		return &models.LogsExportResult{
+			Filename:      filename,
+			Format:        format,
+			Size:          int(size),
+			Body:          body,
+			Anonymization: report,
+		}, nil
+	}
+	data := buf.Bytes()
+
 	if format == "csv" && !strings.Contains(ct, "csv") {
 		// Convert JSON/NDJSON payload to CSV
 		csvData, convErr := toCSV(data)
@@ -1171,15 +1385,143 @@ func (s *VictoriaLogsService) ExportLogs(ctx context.Context, request *models.Lo
 		}
 	}
 
-	filename := fmt.Sprintf("logs-%d.%s", time.Now().Unix(), format)
 	return &models.LogsExportResult{
-		Filename: filename,
-		Format:   format,
-		Size:     len(data),
-		Data:     data,
+		Filename:      filename,
+		Format:        format,
+		Size:          len(data),
+		Data:          data,
+		Anonymization: report,
 	}, nil
 }
 
+// anonymizeExport rewrites the NDJSON rows in buf with anon into a new
+// spill buffer. buf is consumed.
+func (s *VictoriaLogsService) anonymizeExport(buf *spillBuffer, anon *Anonymizer) (*spillBuffer, error) {
+	src, err := buf.Reader()
+	if err != nil {
+		buf.Discard()
+		return nil, fmt.Errorf("read export: %w", err)
+	}
+	defer src.Close()
+	out := newSpillBuffer("log export", int64(s.export.MemoryMB)<<20, int64(s.export.MaxMB)<<20, s.export.SpillDir)
+	dec := json.NewDecoder(src)
+	dec.UseNumber()
+	enc := json.NewEncoder(out)
+	enc.SetEscapeHTML(false)
+	for {
+		var row map[string]any
+		if err := dec.Decode(&row); err != nil {
+			if errors.Is(err, io.EOF) {
+				return out, nil
+			}
+			out.Discard()
+			return nil, fmt.Errorf("anonymize export: %w", err)
+		}
+		if len(row) == 0 {
+			continue
+		}
+		anon.Apply(row)
+		if err := enc.Encode(row); err != nil {
+			out.Discard()
+			return nil, err
+		}
+	}
+}
+
+// spilledExportBody returns a reader over a spilled export, converting NDJSON
+// to CSV through a second spill buffer when convert is set. The reader removes
+// its temp file on Close.
+func (s *VictoriaLogsService) spilledExportBody(buf *spillBuffer, convert bool) (io.ReadCloser, int64, error) {
+	src, err := buf.Reader()
+	if err != nil {
+		buf.Discard()
+		return nil, 0, fmt.Errorf("read spilled export: %w", err)
+	}
+	if !convert {
+		return src, buf.Size(), nil
+	}
+	out := newSpillBuffer("log export", int64(s.export.MemoryMB)<<20, int64(s.export.MaxMB)<<20, s.export.SpillDir)
+	convErr := streamNDJSONToCSV(src, out)
+	if convErr == nil {
+		src.Close()
+		body, err := out.Reader()
+		if err != nil {
+			out.Discard()
+			return nil, 0, fmt.Errorf("read spilled export: %w", err)
+		}
+		return body, out.Size(), nil
+	}
+	out.Discard()
+	if errors.Is(convErr, ErrMemoryBudgetExceeded) {
+		src.Close()
+		return nil, 0, convErr
+	}
+	s.logger.Warn("CSV conversion failed; returning original payload", "error", convErr)
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		src.Close()
+		return nil, 0, fmt.Errorf("read spilled export: %w", err)
+	}
+	return src, buf.Size(), nil
+}
+
+// streamNDJSONToCSV is the NDJSON branch of toCSV for payloads that do not
+// fit in memory. The first pass collects the column set, the second writes
+// one row per object.
+func streamNDJSONToCSV(src io.ReadSeeker, dst io.Writer) error {
+	seen := map[string]struct{}{}
+	dec := json.NewDecoder(src)
+	for {
+		var m map[string]json.RawMessage
+		if err := dec.Decode(&m); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return err
+		}
+		for k := range m {
+			seen[k] = struct{}{}
+		}
+	}
+	if len(seen) == 0 {
+		return fmt.Errorf("unrecognized payload shape for CSV conversion")
+	}
+	fields := make([]string, 0, len(seen))
+	for k := range seen {
+		fields = append(fields, k)
+	}
+	sort.Strings(fields)
+
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	w := csv.NewWriter(dst)
+	if err := w.Write(fields); err != nil {
+		return err
+	}
+	dec = json.NewDecoder(src)
+	rec := make([]string, len(fields))
+	for {
+		var m map[string]any
+		if err := dec.Decode(&m); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return err
+		}
+		if len(m) == 0 {
+			continue
+		}
+		for i, f := range fields {
+			rec[i] = toScalarString(m[f])
+		}
+		if err := w.Write(rec); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
 // toCSV converts a VictoriaLogs JSON/NDJSON query response into CSV bytes.
 // It supports these shapes:
 // 1) {"fields":[...],"data":[[...], ...]}
@@ -1357,56 +1699,38 @@ func svcName(stats map[string]any) string {
 	return ""
 }
 
-// doRequestWithRetry sends an HTTP request and retries on 5xx or transport errors.
-// It logs every retry attempt to stdout via s.logger so operators can see timeouts/errors.
+// doRequestWithRetry sends an HTTP request and retries on 5xx or transport
+// errors; see backendRetry.
 func (s *VictoriaLogsService) doRequestWithRetry(
 	ctx context.Context,
 	req *http.Request,
 ) (*http.Response, error) {
-
-	var lastErr error
-	backoff := time.Duration(s.backoffMS) * time.Millisecond
-
-	for attempt := 1; attempt <= s.retries; attempt++ {
+	var resp *http.Response
+	retry := backendRetry(tracing.BackendVictoriaLogs, s.retries, s.backoffMS, s.logger, req.Method, req.URL.String())
+	err := retry.Execute(ctx, func(ctx context.Context) error {
 		// Clone the request for each attempt
 		reqCopy := req.Clone(ctx)
 		if s.username != "" && reqCopy.Header.Get("Authorization") == "" {
 			reqCopy.SetBasicAuth(s.username, s.password)
 		}
 
-		resp, err := s.client.Do(reqCopy)
-		// transport error (timeout, connection refused, etc.)
+		r, err := s.client.Do(reqCopy)
 		if err != nil {
-			lastErr = err
-			s.logger.Warn("VictoriaLogs request failed (transport)",
-				"attempt", attempt, "method", req.Method, "url", req.URL.String(), "error", err)
-		} else if resp.StatusCode >= 500 {
-			// server error -> retry
-			lastErr = fmt.Errorf("status %d: %s", resp.StatusCode, readErrBody(resp.Body))
-			_ = resp.Body.Close()
-			s.logger.Warn("VictoriaLogs 5xx response — retrying",
-				"attempt", attempt, "method", req.Method, "url", req.URL.String(), "status", resp.StatusCode)
-		} else {
-			// success or non-retryable status
-			return resp, nil
-		}
-
-		// no more retries?
-		if attempt == s.retries || ctx.Err() != nil {
-			break
+			return err
 		}
-
-		// backoff (exponential)
-		select {
-		case <-time.After(backoff):
-			backoff *= 2
-		case <-ctx.Done():
-			return nil, ctx.Err()
+		if r.StatusCode >= 500 {
+			err := &backendStatusError{code: r.StatusCode, body: readErrBody(r.Body)}
+			_ = r.Body.Close()
+			return err
 		}
+		// success or non-retryable status
+		resp = r
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("VictoriaLogs request failed",
+			"method", req.Method, "url", req.URL.String(), "retries", s.retries, "error", err)
+		return nil, err
 	}
-
-	// Final log with summary so it's visible in stdout
-	s.logger.Error("VictoriaLogs request exhausted retries",
-		"method", req.Method, "url", req.URL.String(), "retries", s.retries, "error", lastErr)
-	return nil, lastErr
+	return resp, nil
 }
@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -15,9 +16,15 @@ import (
 	"sync"
 
 	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+	"github.com/mirastacklabs-ai/mirador-core/internal/faultinject"
 	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
 	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/qos"
+	"github.com/mirastacklabs-ai/mirador-core/internal/residency"
+	"github.com/mirastacklabs-ai/mirador-core/internal/tenantscope"
+	"github.com/mirastacklabs-ai/mirador-core/internal/tracing"
 	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/resilience"
 )
 
 type VictoriaMetricsService struct {
@@ -44,6 +51,12 @@ type VictoriaMetricsService struct {
 	// this service will fan-out queries to each child (and optionally itself)
 	// and aggregate results.
 	children []*VictoriaMetricsService
+
+	// series limit for query results; see SetResultLimits
+	maxSeries int
+
+	// labels flagged by cardinality scans; see SetCardinality
+	cardinality HighCardinalityLabels
 }
 
 func NewVictoriaMetricsService(cfg config.VictoriaMetricsConfig, logger corelogger.Logger) *VictoriaMetricsService {
@@ -52,7 +65,8 @@ func NewVictoriaMetricsService(cfg config.VictoriaMetricsConfig, logger corelogg
 		endpoints: cfg.Endpoints,
 		timeout:   time.Duration(cfg.Timeout) * time.Millisecond,
 		client: &http.Client{
-			Timeout: time.Duration(cfg.Timeout) * time.Millisecond,
+			Timeout:   time.Duration(cfg.Timeout) * time.Millisecond,
+			Transport: qos.WrapTransport(faultinject.WrapTransport(tracing.NewTransport(nil, tracing.BackendVictoriaMetrics), faultinject.TargetVictoriaMetrics)),
 		},
 		logger:      logging.FromCoreLogger(logger),
 		retries:     3,    // total attempts
@@ -74,6 +88,36 @@ func (s *VictoriaMetricsService) SetChildren(children []*VictoriaMetricsService)
 	}
 }
 
+// SetResultLimits makes queries returning more than MaxSeries series fail
+// with a TooManyResultsError instead of returning them.
+func (s *VictoriaMetricsService) SetResultLimits(cfg config.ResultLimitsConfig) {
+	s.maxSeries = cfg.MaxSeries
+}
+
+// SetCardinality adds a suggestion to aggregate away labels flagged as high
+// cardinality to queries over the series limit.
+func (s *VictoriaMetricsService) SetCardinality(c HighCardinalityLabels) {
+	s.cardinality = c
+}
+
+// SetTenantLabels restricts every request of this service and its children
+// to series carrying labels; see package tenantscope. Call it once at startup.
+func (s *VictoriaMetricsService) SetTenantLabels(labels map[string]string) {
+	s.client.Transport = tenantscope.WrapMetricsTransport(s.client.Transport, labels)
+	for _, child := range s.children {
+		child.SetTenantLabels(labels)
+	}
+}
+
+// SetResidency refuses requests of this service and its children to hosts
+// outside the tenant's region; see package residency. Call it once at startup.
+func (s *VictoriaMetricsService) SetResidency(policy config.ResidencyConfig) {
+	s.client.Transport = residency.WrapTransport(s.client.Transport, policy, "victoria_metrics")
+	for _, child := range s.children {
+		child.SetResidency(policy)
+	}
+}
+
 // ReplaceEndpoints swaps the list used for round-robin (used by discovery)
 func (s *VictoriaMetricsService) ReplaceEndpoints(eps []string) {
 	s.mu.Lock()
@@ -84,6 +128,37 @@ func (s *VictoriaMetricsService) ReplaceEndpoints(eps []string) {
 }
 
 func (s *VictoriaMetricsService) ExecuteQuery(ctx context.Context, request *models.MetricsQLQueryRequest) (*models.MetricsQLQueryResult, error) {
+	result, err := s.executeQuery(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if series := countSeries(result.Data); s.maxSeries > 0 && series > s.maxSeries {
+		labels := seriesLabels(result.Data)
+		suggestions := metricsSuggestions(request.Query, labels, s.maxSeries)
+		if flagged, ok := s.flaggedLabelSuggestion(ctx, request.Query, labels); ok {
+			suggestions = append(suggestions, flagged)
+		}
+		return nil, &TooManyResultsError{
+			Query:       request.Query,
+			Unit:        "series",
+			Count:       series,
+			Limit:       s.maxSeries,
+			Suggestions: suggestions,
+		}
+	}
+	return result, nil
+}
+
+// flaggedLabelSuggestion proposes "sum without" the result's labels that
+// cardinality scans flagged, when that brings it under the series limit.
+func (s *VictoriaMetricsService) flaggedLabelSuggestion(ctx context.Context, query string, series []map[string]string) (ResultSuggestion, bool) {
+	if s.cardinality == nil {
+		return ResultSuggestion{}, false
+	}
+	return highCardinalitySuggestion(query, series, s.cardinality.FlaggedLabels(ctx), s.maxSeries)
+}
+
+func (s *VictoriaMetricsService) executeQuery(ctx context.Context, request *models.MetricsQLQueryRequest) (*models.MetricsQLQueryResult, error) {
 	// Aggregation path when multiple endpoints configured in this service
 	if func() bool { s.mu.Lock(); defer s.mu.Unlock(); return len(s.endpoints) > 1 }() {
 		return s.executeQueryMultiEndpoint(ctx, request)
@@ -321,6 +396,31 @@ func (s *VictoriaMetricsService) executeQuerySingleEndpoint(ctx context.Context,
 }
 
 func (s *VictoriaMetricsService) ExecuteRangeQuery(ctx context.Context, request *models.MetricsQLRangeQueryRequest) (*models.MetricsQLRangeQueryResult, error) {
+	result, err := s.executeRangeQuery(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if series := countSeries(result.Data); s.maxSeries > 0 && series > s.maxSeries {
+		labels := seriesLabels(result.Data)
+		suggestions := metricsSuggestions(request.Query, labels, s.maxSeries)
+		if flagged, ok := s.flaggedLabelSuggestion(ctx, request.Query, labels); ok {
+			suggestions = append(suggestions, flagged)
+		}
+		if narrower, ok := rangeSuggestion(result.Data, request.Start, request.End, s.maxSeries); ok {
+			suggestions = append(suggestions, narrower)
+		}
+		return nil, &TooManyResultsError{
+			Query:       request.Query,
+			Unit:        "series",
+			Count:       series,
+			Limit:       s.maxSeries,
+			Suggestions: suggestions,
+		}
+	}
+	return result, nil
+}
+
+func (s *VictoriaMetricsService) executeRangeQuery(ctx context.Context, request *models.MetricsQLRangeQueryRequest) (*models.MetricsQLRangeQueryResult, error) {
 	// Aggregation path when multiple endpoints configured in this service
 	if func() bool { s.mu.Lock(); defer s.mu.Unlock(); return len(s.endpoints) > 1 }() {
 		return s.executeRangeMultiEndpoint(ctx, request)
@@ -695,6 +795,55 @@ func (s *VictoriaMetricsService) getSeriesSingleEndpoint(ctx context.Context, re
 	return vmResponse.Data, nil
 }
 
+// DeleteSeries deletes every sample of the series matching the selectors
+// from each configured endpoint and child source. VictoriaMetrics cannot
+// delete a time range of a series, only whole series.
+func (s *VictoriaMetricsService) DeleteSeries(ctx context.Context, matches []string) error {
+	if len(matches) == 0 {
+		return errors.New("at least one series selector is required")
+	}
+	s.mu.Lock()
+	endpoints := append([]string(nil), s.endpoints...)
+	children := s.children
+	s.mu.Unlock()
+
+	params := url.Values{}
+	for _, m := range matches {
+		params.Add("match[]", m)
+	}
+	path := "/api/v1/admin/tsdb/delete_series"
+	if s.clusterMode {
+		path = "/delete/0/prometheus" + path
+	}
+	for _, ep := range endpoints {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, ep+path, strings.NewReader(params.Encode()))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if s.username != "" {
+			req.SetBasicAuth(s.username, s.password)
+		}
+		// Not retried: a failed delete is reported and picked up by the next run.
+		resp, err := s.client.Do(req)
+		if err != nil {
+			return fmt.Errorf("delete series on %s: %w", ep, err)
+		}
+		if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+			msg := readBodySnippet(resp.Body)
+			resp.Body.Close()
+			return fmt.Errorf("delete series on %s: status %d: %s", ep, resp.StatusCode, msg)
+		}
+		resp.Body.Close()
+	}
+	for _, child := range children {
+		if err := child.DeleteSeries(ctx, matches); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *VictoriaMetricsService) GetLabels(ctx context.Context, request *models.LabelsRequest) ([]string, error) {
 	// Multi-endpoint aggregation when multiple endpoints configured in this service
 	if func() bool { s.mu.Lock(); defer s.mu.Unlock(); return len(s.endpoints) > 1 }() {
@@ -1119,6 +1268,109 @@ func (s *VictoriaMetricsService) getLabelValuesSingleEndpoint(ctx context.Contex
 	return vmResponse.Data, nil
 }
 
+// GetTSDBStatus returns the cardinality statistics of one day. With several
+// sources, series counts are summed and label value counts take the largest
+// source's, a lower bound since sources may share values.
+func (s *VictoriaMetricsService) GetTSDBStatus(ctx context.Context, request *models.TSDBStatusRequest) (*models.TSDBStatus, error) {
+	if len(s.children) == 0 {
+		return s.getTSDBStatusSingle(ctx, request)
+	}
+	services := make([]*VictoriaMetricsService, 0, len(s.children)+1)
+	if func() bool { s.mu.Lock(); defer s.mu.Unlock(); return len(s.endpoints) > 0 }() {
+		services = append(services, s)
+	}
+	services = append(services, s.children...)
+	var (
+		merged    *models.TSDBStatus
+		successes int
+	)
+	for _, svc := range services {
+		st, err := svc.getTSDBStatusSingle(ctx, request)
+		if err != nil {
+			s.logger.Warn("tsdb status from source failed", "error", err)
+			continue
+		}
+		successes++
+		if merged == nil {
+			merged = st
+			continue
+		}
+		merged.TotalSeries += st.TotalSeries
+		merged.TotalLabelValuePairs += st.TotalLabelValuePairs
+		merged.SeriesCountByMetricName = mergeTSDBEntries(merged.SeriesCountByMetricName, st.SeriesCountByMetricName, true)
+		merged.SeriesCountByLabelName = mergeTSDBEntries(merged.SeriesCountByLabelName, st.SeriesCountByLabelName, true)
+		merged.SeriesCountByFocusLabelValue = mergeTSDBEntries(merged.SeriesCountByFocusLabelValue, st.SeriesCountByFocusLabelValue, true)
+		merged.SeriesCountByLabelValuePair = mergeTSDBEntries(merged.SeriesCountByLabelValuePair, st.SeriesCountByLabelValuePair, true)
+		merged.LabelValueCountByLabelName = mergeTSDBEntries(merged.LabelValueCountByLabelName, st.LabelValueCountByLabelName, false)
+	}
+	if successes == 0 {
+		return nil, fmt.Errorf("all metrics sources failed")
+	}
+	return merged, nil
+}
+
+func (s *VictoriaMetricsService) getTSDBStatusSingle(ctx context.Context, request *models.TSDBStatusRequest) (*models.TSDBStatus, error) {
+	endpoint := s.selectEndpoint()
+	if endpoint == "" {
+		return nil, errors.New("no VictoriaMetrics endpoint configured")
+	}
+	params := url.Values{}
+	if request.Date != "" {
+		params.Set("date", request.Date)
+	}
+	if request.TopN > 0 {
+		params.Set("topN", strconv.Itoa(request.TopN))
+	}
+	if request.FocusLabel != "" {
+		params.Set("focusLabel", request.FocusLabel)
+	}
+	for _, match := range request.Match {
+		params.Add("match[]", match)
+	}
+
+	url := endpoint + s.buildAPIPath("/api/v1/status/tsdb") + "?" + params.Encode()
+	resp, err := s.doRequestWithRetry(ctx, http.MethodGet, url, nil, map[string]string{"Accept": "application/json"})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("VictoriaMetrics returned status %d: %s", resp.StatusCode, readBodySnippet(resp.Body))
+	}
+	var vmResponse struct {
+		Status string            `json:"status"`
+		Data   models.TSDBStatus `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&vmResponse); err != nil {
+		return nil, err
+	}
+	return &vmResponse.Data, nil
+}
+
+// mergeTSDBEntries combines two top-N lists by name, summing or keeping the
+// larger count, ordered by count.
+func mergeTSDBEntries(a, b []models.TSDBStatusEntry, sum bool) []models.TSDBStatusEntry {
+	idx := make(map[string]int, len(a))
+	out := append([]models.TSDBStatusEntry(nil), a...)
+	for i, e := range out {
+		idx[e.Name] = i
+	}
+	for _, e := range b {
+		i, ok := idx[e.Name]
+		switch {
+		case !ok:
+			idx[e.Name] = len(out)
+			out = append(out, e)
+		case sum:
+			out[i].Value += e.Value
+		case e.Value > out[i].Value:
+			out[i].Value = e.Value
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Value > out[j].Value })
+	return out
+}
+
 func (s *VictoriaMetricsService) HealthCheck(ctx context.Context) error {
 	// Multi-endpoint health check when multiple endpoints configured in this service
 	if func() bool { s.mu.Lock(); defer s.mu.Unlock(); return len(s.endpoints) > 1 }() {
@@ -1205,22 +1457,20 @@ func (s *VictoriaMetricsService) selectEndpoint() string {
 
 /* ----------------------------- retry + helpers ----------------------------- */
 
-// doRequestWithRetry sends an HTTP request and retries on 5xx or transport errors.
-// It logs every retry attempt to stdout via s.logger so operators can see timeouts/errors.
+// doRequestWithRetry sends an HTTP request and retries on 5xx or transport
+// errors; see backendRetry.
 func (s *VictoriaMetricsService) doRequestWithRetry(
 	ctx context.Context,
 	method, urlStr string,
 	body io.Reader,
 	headers map[string]string,
 ) (*http.Response, error) {
-
-	var lastErr error
-	backoff := time.Duration(s.backoffMS) * time.Millisecond
-
-	for attempt := 1; attempt <= s.retries; attempt++ {
+	var resp *http.Response
+	retry := backendRetry(tracing.BackendVictoriaMetrics, s.retries, s.backoffMS, s.logger, method, urlStr)
+	err := retry.Execute(ctx, func(ctx context.Context) error {
 		req, err := http.NewRequestWithContext(ctx, method, urlStr, body)
 		if err != nil {
-			return nil, err
+			return resilience.Permanent(err)
 		}
 		for k, v := range headers {
 			req.Header.Set(k, v)
@@ -1229,41 +1479,25 @@ func (s *VictoriaMetricsService) doRequestWithRetry(
 			req.SetBasicAuth(s.username, s.password)
 		}
 
-		resp, err := s.client.Do(req)
-		// transport error (timeout, connection refused, etc.)
+		r, err := s.client.Do(req)
 		if err != nil {
-			lastErr = err
-			s.logger.Warn("VictoriaMetrics request failed (transport)",
-				"attempt", attempt, "method", method, "url", urlStr, "error", err)
-		} else if resp.StatusCode >= 500 {
-			// server error -> retry
-			lastErr = fmt.Errorf("status %d: %s", resp.StatusCode, readBodySnippet(resp.Body))
-			_ = resp.Body.Close()
-			s.logger.Warn("VictoriaMetrics 5xx response — retrying",
-				"attempt", attempt, "method", method, "url", urlStr, "status", resp.StatusCode)
-		} else {
-			// success or non-retryable status
-			return resp, nil
-		}
-
-		// no more retries?
-		if attempt == s.retries || ctx.Err() != nil {
-			break
+			return err
 		}
-
-		// backoff (exponential)
-		select {
-		case <-time.After(backoff):
-			backoff *= 2
-		case <-ctx.Done():
-			return nil, ctx.Err()
+		if r.StatusCode >= 500 {
+			err := &backendStatusError{code: r.StatusCode, body: readBodySnippet(r.Body)}
+			_ = r.Body.Close()
+			return err
 		}
+		// success or non-retryable status
+		resp = r
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("VictoriaMetrics request failed",
+			"method", method, "url", urlStr, "retries", s.retries, "error", err)
+		return nil, err
 	}
-
-	// Final log with summary so it's visible in stdout
-	s.logger.Error("VictoriaMetrics request exhausted retries",
-		"method", method, "url", urlStr, "retries", s.retries, "error", lastErr)
-	return nil, lastErr
+	return resp, nil
 }
 
 // readBodySnippet returns a short text excerpt from an HTTP body for error messages.
@@ -254,3 +254,28 @@ func TestMetrics_Aggregated_AllSourcesFail_Error(t *testing.T) {
 		t.Fatalf("expected error when all sources fail")
 	}
 }
+
+func TestMetrics_TenantLabels_ScopeChildren(t *testing.T) {
+	var got []string
+	srv := newFakeVM(t, map[string]http.HandlerFunc{
+		"/api/v1/query": func(w http.ResponseWriter, r *http.Request) {
+			got = r.URL.Query()["extra_label"]
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(models.VictoriaMetricsResponse{Status: "success", Data: map[string]any{"result": []any{}}})
+		},
+	})
+	defer srv.Close()
+
+	log := logger.New("error")
+	parent := NewVictoriaMetricsService(config.VictoriaMetricsConfig{Name: "parent", Endpoints: []string{}, Timeout: 2000}, log)
+	child := NewVictoriaMetricsService(config.VictoriaMetricsConfig{Name: "shared", Endpoints: []string{srv.URL}, Timeout: 2000}, log)
+	parent.SetChildren([]*VictoriaMetricsService{child})
+	parent.SetTenantLabels(map[string]string{"cost_center": "cc-12", "environment": "prod"})
+
+	if _, err := parent.ExecuteQuery(context.Background(), &models.MetricsQLQueryRequest{Query: "up"}); err != nil {
+		t.Fatalf("ExecuteQuery: %v", err)
+	}
+	if len(got) != 2 || got[0] != "cost_center=cc-12" || got[1] != "environment=prod" {
+		t.Fatalf("expected tenant labels on the child query, got %v", got)
+	}
+}
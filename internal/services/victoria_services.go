@@ -14,12 +14,19 @@ import (
 
 	"github.com/mirastacklabs-ai/mirador-core/internal/config"
 	"github.com/mirastacklabs-ai/mirador-core/internal/discovery"
+	"github.com/mirastacklabs-ai/mirador-core/internal/faultinject"
 	"github.com/mirastacklabs-ai/mirador-core/internal/logging"
 	"github.com/mirastacklabs-ai/mirador-core/internal/mariadb"
 	"github.com/mirastacklabs-ai/mirador-core/internal/models"
+	"github.com/mirastacklabs-ai/mirador-core/internal/qos"
+	"github.com/mirastacklabs-ai/mirador-core/internal/residency"
+	"github.com/mirastacklabs-ai/mirador-core/internal/tracing"
 	corelogger "github.com/mirastacklabs-ai/mirador-core/pkg/logger"
 )
 
+// ErrTraceNotFound is returned by GetTrace for unknown trace IDs.
+var ErrTraceNotFound = errors.New("trace not found")
+
 // VictoriaMetricsServices contains all VictoriaMetrics ecosystem services
 type VictoriaMetricsServices struct {
 	Metrics *VictoriaMetricsService
@@ -56,7 +63,8 @@ func NewVictoriaTracesService(cfg config.VictoriaTracesConfig, logger corelogger
 		endpoints: cfg.Endpoints,
 		timeout:   time.Duration(cfg.Timeout) * time.Millisecond,
 		client: &http.Client{
-			Timeout: time.Duration(cfg.Timeout) * time.Millisecond,
+			Timeout:   time.Duration(cfg.Timeout) * time.Millisecond,
+			Transport: qos.WrapTransport(faultinject.WrapTransport(tracing.NewTransport(nil, tracing.BackendVictoriaTraces), faultinject.TargetVictoriaTraces)),
 		},
 		logger:    logging.FromCoreLogger(logger),
 		username:  cfg.Username,
@@ -76,6 +84,15 @@ func (s *VictoriaTracesService) SetChildren(children []*VictoriaTracesService) {
 	}
 }
 
+// SetResidency refuses requests of this service and its children to hosts
+// outside the tenant's region; see package residency. Call it once at startup.
+func (s *VictoriaTracesService) SetResidency(policy config.ResidencyConfig) {
+	s.client.Transport = residency.WrapTransport(s.client.Transport, policy, "victoria_traces")
+	for _, child := range s.children {
+		child.SetResidency(policy)
+	}
+}
+
 // GetOperations returns all operations for a specific service from VictoriaTraces
 func (s *VictoriaTracesService) GetOperations(ctx context.Context, serviceName string) ([]string, error) {
 	// Multi-endpoint aggregation when multiple endpoints configured in this service
@@ -446,7 +463,7 @@ func (s *VictoriaTracesService) GetTrace(ctx context.Context, traceID string) (*
 			}
 		}
 		if firstErr == nil {
-			firstErr = fmt.Errorf("trace not found")
+			firstErr = ErrTraceNotFound
 		}
 		return nil, firstErr
 	}
@@ -470,7 +487,7 @@ func (s *VictoriaTracesService) GetTrace(ctx context.Context, traceID string) (*
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("trace not found")
+		return nil, ErrTraceNotFound
 	}
 
 	// VictoriaTraces (Jaeger API) returns {"data":[{ trace }]}
@@ -481,7 +498,7 @@ func (s *VictoriaTracesService) GetTrace(ctx context.Context, traceID string) (*
 		return nil, fmt.Errorf("failed to parse trace response: %w", err)
 	}
 	if len(wrap.Data) == 0 {
-		return nil, fmt.Errorf("trace not found in response")
+		return nil, fmt.Errorf("%w in response", ErrTraceNotFound)
 	}
 	return &wrap.Data[0], nil
 }
@@ -886,23 +903,16 @@ func NewVictoriaMetricsServices(dbConfig config.DatabaseConfig, logger corelogge
 	}, nil
 }
 
-// StartDiscovery enables periodic DNS discovery for Victoria* services when configured.
-// It relies on headless Services (A/AAAA records per pod) or SRV records.
+// StartDiscovery enables endpoint discovery for Victoria* services when
+// configured, via DNS (headless Service A/AAAA or SRV records) or by watching
+// the Service's EndpointSlices. Endpoint changes apply without a restart.
 func (s *VictoriaMetricsServices) StartDiscovery(ctx context.Context, dbConfig config.DatabaseConfig, log corelogger.Logger) {
 	// VictoriaMetrics
 	if dbConfig.VictoriaMetrics.Discovery.Enabled && s.Metrics != nil {
-		cfg := dbConfig.VictoriaMetrics.Discovery
 		if len(dbConfig.VictoriaMetrics.Endpoints) > 0 {
 			s.Metrics.ReplaceEndpoints(dbConfig.VictoriaMetrics.Endpoints)
 		}
-		discovery.StartDNSDiscovery(ctx, discovery.DNSConfig{
-			Enabled:        true,
-			Service:        cfg.Service,
-			Port:           cfg.Port,
-			Scheme:         cfg.Scheme,
-			RefreshSeconds: cfg.RefreshSeconds,
-			UseSRV:         cfg.UseSRV,
-		}, s.Metrics, log)
+		discovery.Start(ctx, "victoria_metrics", DiscoveryConfig(dbConfig.VictoriaMetrics.Discovery), s.Metrics, log)
 	}
 	// Discovery for additional metrics sources
 	if len(dbConfig.MetricsSources) > 0 && s.Metrics != nil && s.Metrics.children != nil {
@@ -912,33 +922,17 @@ func (s *VictoriaMetricsServices) StartDiscovery(ctx context.Context, dbConfig c
 				if len(mc.Endpoints) > 0 {
 					child.ReplaceEndpoints(mc.Endpoints)
 				}
-				cfg := mc.Discovery
-				discovery.StartDNSDiscovery(ctx, discovery.DNSConfig{
-					Enabled:        true,
-					Service:        cfg.Service,
-					Port:           cfg.Port,
-					Scheme:         cfg.Scheme,
-					RefreshSeconds: cfg.RefreshSeconds,
-					UseSRV:         cfg.UseSRV,
-				}, child, log)
+				discovery.Start(ctx, fmt.Sprintf("victoria_metrics[%d]", i), DiscoveryConfig(mc.Discovery), child, log)
 			}
 		}
 	}
 
 	// VictoriaLogs
 	if dbConfig.VictoriaLogs.Discovery.Enabled && s.Logs != nil {
-		cfg := dbConfig.VictoriaLogs.Discovery
 		if len(dbConfig.VictoriaLogs.Endpoints) > 0 {
 			s.Logs.ReplaceEndpoints(dbConfig.VictoriaLogs.Endpoints)
 		}
-		discovery.StartDNSDiscovery(ctx, discovery.DNSConfig{
-			Enabled:        true,
-			Service:        cfg.Service,
-			Port:           cfg.Port,
-			Scheme:         cfg.Scheme,
-			RefreshSeconds: cfg.RefreshSeconds,
-			UseSRV:         cfg.UseSRV,
-		}, s.Logs, log)
+		discovery.Start(ctx, "victoria_logs", DiscoveryConfig(dbConfig.VictoriaLogs.Discovery), s.Logs, log)
 	}
 	// Discovery for additional VictoriaLogs sources
 	if len(dbConfig.LogsSources) > 0 && s.Logs != nil && s.Logs.children != nil {
@@ -948,33 +942,17 @@ func (s *VictoriaMetricsServices) StartDiscovery(ctx context.Context, dbConfig c
 				if len(lc.Endpoints) > 0 {
 					child.ReplaceEndpoints(lc.Endpoints)
 				}
-				cfg := lc.Discovery
-				discovery.StartDNSDiscovery(ctx, discovery.DNSConfig{
-					Enabled:        true,
-					Service:        cfg.Service,
-					Port:           cfg.Port,
-					Scheme:         cfg.Scheme,
-					RefreshSeconds: cfg.RefreshSeconds,
-					UseSRV:         cfg.UseSRV,
-				}, child, log)
+				discovery.Start(ctx, fmt.Sprintf("victoria_logs[%d]", i), DiscoveryConfig(lc.Discovery), child, log)
 			}
 		}
 	}
 
 	// VictoriaTraces
 	if dbConfig.VictoriaTraces.Discovery.Enabled && s.Traces != nil {
-		cfg := dbConfig.VictoriaTraces.Discovery
 		if len(dbConfig.VictoriaTraces.Endpoints) > 0 {
 			s.Traces.ReplaceEndpoints(dbConfig.VictoriaTraces.Endpoints)
 		}
-		discovery.StartDNSDiscovery(ctx, discovery.DNSConfig{
-			Enabled:        true,
-			Service:        cfg.Service,
-			Port:           cfg.Port,
-			Scheme:         cfg.Scheme,
-			RefreshSeconds: cfg.RefreshSeconds,
-			UseSRV:         cfg.UseSRV,
-		}, s.Traces, log)
+		discovery.Start(ctx, "victoria_traces", DiscoveryConfig(dbConfig.VictoriaTraces.Discovery), s.Traces, log)
 	}
 	// Discovery for additional VictoriaTraces sources
 	if len(dbConfig.TracesSources) > 0 && s.Traces != nil && s.Traces.children != nil {
@@ -984,20 +962,25 @@ func (s *VictoriaMetricsServices) StartDiscovery(ctx context.Context, dbConfig c
 				if len(tc.Endpoints) > 0 {
 					child.ReplaceEndpoints(tc.Endpoints)
 				}
-				cfg := tc.Discovery
-				discovery.StartDNSDiscovery(ctx, discovery.DNSConfig{
-					Enabled:        true,
-					Service:        cfg.Service,
-					Port:           cfg.Port,
-					Scheme:         cfg.Scheme,
-					RefreshSeconds: cfg.RefreshSeconds,
-					UseSRV:         cfg.UseSRV,
-				}, child, log)
+				discovery.Start(ctx, fmt.Sprintf("victoria_traces[%d]", i), DiscoveryConfig(tc.Discovery), child, log)
 			}
 		}
 	}
 }
 
+// DiscoveryConfig converts a K8sDiscoveryConfig into a discovery.Config.
+func DiscoveryConfig(c config.K8sDiscoveryConfig) discovery.Config {
+	return discovery.Config{
+		Mode:           c.Mode,
+		Service:        c.Service,
+		Namespace:      c.Namespace,
+		Port:           c.Port,
+		Scheme:         c.Scheme,
+		RefreshSeconds: c.RefreshSeconds,
+		UseSRV:         c.UseSRV,
+	}
+}
+
 // ReplaceEndpoints allows dynamic update from discovery
 func (s *VictoriaTracesService) ReplaceEndpoints(eps []string) {
 	s.mu.Lock()
@@ -0,0 +1,93 @@
+package tenantscope
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func roundTrip(t *testing.T, rt http.RoundTripper, target string) url.Values {
+	t.Helper()
+	var got url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query()
+	}))
+	defer srv.Close()
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+target, nil)
+	before := req.URL.RawQuery
+	resp, err := (&http.Client{Transport: rt}).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if req.URL.RawQuery != before {
+		t.Fatalf("caller's request was modified: %s", req.URL)
+	}
+	return got
+}
+
+func TestWrapMetricsTransport(t *testing.T) {
+	labels := map[string]string{"environment": "prod", "cost_center": "cc-12"}
+	rt := WrapMetricsTransport(nil, labels)
+
+	q := roundTrip(t, rt, "/api/v1/query?query=up")
+	if got := q["extra_label"]; len(got) != 2 || got[0] != "cost_center=cc-12" || got[1] != "environment=prod" {
+		t.Fatalf("unexpected extra_label %v", got)
+	}
+	if q.Get("query") != "up" {
+		t.Fatalf("query lost: %v", q)
+	}
+
+	// A caller cannot widen its scope by passing its own extra_label.
+	q = roundTrip(t, rt, "/api/v1/query?query=up&extra_label=environment=dev")
+	if got := q["extra_label"]; len(got) != 2 || got[1] != "environment=prod" {
+		t.Fatalf("caller extra_label not replaced: %v", got)
+	}
+
+	if q := roundTrip(t, rt, "/health"); q.Has("extra_label") {
+		t.Fatalf("health check should not be scoped: %v", q)
+	}
+	if WrapMetricsTransport(http.DefaultTransport, nil) != http.DefaultTransport {
+		t.Fatal("no labels should leave the transport unwrapped")
+	}
+}
+
+func TestWrapLogsTransport(t *testing.T) {
+	rt := WrapLogsTransport(nil, map[string]string{"environment": "prod", "cost_center": `cc "12"`})
+
+	q := roundTrip(t, rt, "/select/logsql/query?query=error")
+	if got := q.Get("extra_filters"); got != `cost_center:="cc \"12\"" environment:="prod"` {
+		t.Fatalf("unexpected extra_filters %q", got)
+	}
+	q = roundTrip(t, rt, "/insert/jsonline?extra_fields=environment=dev")
+	if q.Has("extra_filters") || q.Get("extra_fields") != `cost_center=cc "12",environment=prod` {
+		t.Fatalf("inserts should be tagged with the labels, got %v", q)
+	}
+}
+
+func TestWrapLogsTransport_Delete(t *testing.T) {
+	rt := WrapLogsTransport(nil, map[string]string{"environment": "prod"})
+	var got url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		got = r.PostForm
+	}))
+	defer srv.Close()
+	client := &http.Client{Transport: rt}
+
+	form := url.Values{"filter": {`_time:<2024-01-01Z app:="api"`}}
+	resp, err := client.Post(srv.URL+"/delete/run_task", "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if f := got.Get("filter"); f != `environment:="prod" (_time:<2024-01-01Z app:="api")` {
+		t.Fatalf("delete filter not scoped: %q", f)
+	}
+
+	if _, err := client.Post(srv.URL+"/delete/run_task", "application/x-www-form-urlencoded", strings.NewReader("")); err == nil {
+		t.Fatal("expected a delete without a filter to be refused")
+	}
+}
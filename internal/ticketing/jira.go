@@ -0,0 +1,95 @@
+package ticketing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+)
+
+// jira talks to the Jira REST API v2 (Cloud and Data Center).
+type jira struct {
+	cfg    config.TicketingConfig
+	base   string
+	client *http.Client
+}
+
+func (j *jira) Name() string { return ProviderJira }
+
+func (j *jira) Create(ctx context.Context, t Ticket) (*Ref, error) {
+	fields := map[string]any{
+		"project":     map[string]string{"key": j.cfg.Project},
+		"issuetype":   map[string]string{"name": j.cfg.IssueType},
+		"summary":     t.Summary,
+		"description": t.Description,
+	}
+	if len(t.Labels) > 0 {
+		labels := make([]string, 0, len(t.Labels))
+		for _, l := range t.Labels {
+			// Jira labels cannot contain spaces.
+			labels = append(labels, strings.Join(strings.Fields(l), "-"))
+		}
+		fields["labels"] = labels
+	}
+	for k, v := range t.Fields {
+		fields[k] = v
+	}
+	var out struct {
+		ID  string `json:"id"`
+		Key string `json:"key"`
+	}
+	if err := doJSON(ctx, j.client, j.cfg, http.MethodPost, j.base+"/rest/api/2/issue", map[string]any{"fields": fields}, &out); err != nil {
+		return nil, fmt.Errorf("create jira issue: %w", err)
+	}
+	if out.Key == "" {
+		return nil, fmt.Errorf("create jira issue: no key in response")
+	}
+	return &Ref{ID: out.ID, Key: out.Key, URL: j.base + "/browse/" + out.Key}, nil
+}
+
+func (j *jira) Status(ctx context.Context, ref Ref) (string, error) {
+	var out struct {
+		Fields struct {
+			Status struct {
+				Name string `json:"name"`
+			} `json:"status"`
+		} `json:"fields"`
+	}
+	if err := doJSON(ctx, j.client, j.cfg, http.MethodGet, j.base+"/rest/api/2/issue/"+url.PathEscape(ref.Key)+"?fields=status", nil, &out); err != nil {
+		return "", fmt.Errorf("get jira issue: %w", err)
+	}
+	return out.Fields.Status.Name, nil
+}
+
+func (j *jira) Comment(ctx context.Context, ref Ref, text string) error {
+	if err := doJSON(ctx, j.client, j.cfg, http.MethodPost, j.base+"/rest/api/2/issue/"+url.PathEscape(ref.Key)+"/comment", map[string]string{"body": text}, nil); err != nil {
+		return fmt.Errorf("comment on jira issue: %w", err)
+	}
+	return nil
+}
+
+// ParseWebhook reads a Jira issue webhook (jira:issue_created,
+// jira:issue_updated).
+func (j *jira) ParseWebhook(body []byte) (string, string, error) {
+	var ev struct {
+		Issue struct {
+			Key    string `json:"key"`
+			Fields struct {
+				Status struct {
+					Name string `json:"name"`
+				} `json:"status"`
+			} `json:"fields"`
+		} `json:"issue"`
+	}
+	if err := json.Unmarshal(body, &ev); err != nil {
+		return "", "", fmt.Errorf("%w: %v", ErrInvalidWebhook, err)
+	}
+	if ev.Issue.Key == "" || ev.Issue.Fields.Status.Name == "" {
+		return "", "", fmt.Errorf("%w: issue key and status are required", ErrInvalidWebhook)
+	}
+	return ev.Issue.Key, ev.Issue.Fields.Status.Name, nil
+}
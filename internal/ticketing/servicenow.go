@@ -0,0 +1,86 @@
+package ticketing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+)
+
+// serviceNow talks to the ServiceNow Table API.
+type serviceNow struct {
+	cfg    config.TicketingConfig
+	base   string
+	client *http.Client
+}
+
+func (s *serviceNow) Name() string { return ProviderServiceNow }
+
+func (s *serviceNow) recordURL(sysID string) string {
+	return s.base + "/api/now/table/" + url.PathEscape(s.cfg.Table) + "/" + url.PathEscape(sysID)
+}
+
+func (s *serviceNow) Create(ctx context.Context, t Ticket) (*Ref, error) {
+	record := map[string]string{
+		"short_description": t.Summary,
+		"description":       t.Description,
+	}
+	for k, v := range t.Fields {
+		record[k] = v
+	}
+	var out struct {
+		Result struct {
+			SysID  string `json:"sys_id"`
+			Number string `json:"number"`
+		} `json:"result"`
+	}
+	if err := doJSON(ctx, s.client, s.cfg, http.MethodPost, s.base+"/api/now/table/"+url.PathEscape(s.cfg.Table), record, &out); err != nil {
+		return nil, fmt.Errorf("create servicenow record: %w", err)
+	}
+	if out.Result.SysID == "" {
+		return nil, fmt.Errorf("create servicenow record: no sys_id in response")
+	}
+	return &Ref{
+		ID:  out.Result.SysID,
+		Key: out.Result.Number,
+		URL: s.base + "/nav_to.do?uri=" + url.QueryEscape(s.cfg.Table+".do?sys_id="+out.Result.SysID),
+	}, nil
+}
+
+func (s *serviceNow) Status(ctx context.Context, ref Ref) (string, error) {
+	var out struct {
+		Result struct {
+			State string `json:"state"`
+		} `json:"result"`
+	}
+	if err := doJSON(ctx, s.client, s.cfg, http.MethodGet, s.recordURL(ref.ID)+"?sysparm_fields=state&sysparm_display_value=true", nil, &out); err != nil {
+		return "", fmt.Errorf("get servicenow record: %w", err)
+	}
+	return out.Result.State, nil
+}
+
+func (s *serviceNow) Comment(ctx context.Context, ref Ref, text string) error {
+	if err := doJSON(ctx, s.client, s.cfg, http.MethodPatch, s.recordURL(ref.ID), map[string]string{"work_notes": text}, nil); err != nil {
+		return fmt.Errorf("add servicenow work note: %w", err)
+	}
+	return nil
+}
+
+// ParseWebhook reads the body posted by a ServiceNow business rule:
+// {"number": "INC0010001", "state": "Resolved"}.
+func (s *serviceNow) ParseWebhook(body []byte) (string, string, error) {
+	var ev struct {
+		Number string `json:"number"`
+		State  string `json:"state"`
+	}
+	if err := json.Unmarshal(body, &ev); err != nil {
+		return "", "", fmt.Errorf("%w: %v", ErrInvalidWebhook, err)
+	}
+	if ev.Number == "" || ev.State == "" {
+		return "", "", fmt.Errorf("%w: number and state are required", ErrInvalidWebhook)
+	}
+	return ev.Number, ev.State, nil
+}
@@ -0,0 +1,107 @@
+// Package ticketing opens and tracks incident tickets in Jira or ServiceNow.
+package ticketing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+)
+
+// Supported providers (config integrations.ticketing.provider).
+const (
+	ProviderJira       = "jira"
+	ProviderServiceNow = "servicenow"
+)
+
+var (
+	ErrUnknownProvider = errors.New("unknown ticketing provider")
+	ErrInvalidWebhook  = errors.New("invalid ticketing webhook")
+)
+
+// Ticket is the content of a ticket to open. Fields sets additional
+// provider fields by name (Jira customfield_10010, ServiceNow
+// assignment_group, ...).
+type Ticket struct {
+	Summary     string
+	Description string
+	Labels      []string
+	Fields      map[string]string
+}
+
+// Ref identifies an opened ticket. ID is the provider's internal ID (the
+// ServiceNow sys_id), Key the human-readable one (PROJ-123, INC0010001).
+type Ref struct {
+	ID  string
+	Key string
+	URL string
+}
+
+// Provider creates tickets and reads their status.
+type Provider interface {
+	Name() string
+	Create(ctx context.Context, t Ticket) (*Ref, error)
+	Status(ctx context.Context, ref Ref) (string, error)
+	Comment(ctx context.Context, ref Ref, text string) error
+	// ParseWebhook returns the ticket key and status carried by a webhook
+	// body sent by the provider.
+	ParseWebhook(body []byte) (key, status string, err error)
+}
+
+// New returns the provider configured by cfg, or nil when ticketing is
+// disabled.
+func New(cfg config.TicketingConfig, client *http.Client) (Provider, error) {
+	base := strings.TrimRight(cfg.BaseURL, "/")
+	switch strings.ToLower(cfg.Provider) {
+	case "":
+		return nil, nil
+	case ProviderJira:
+		return &jira{cfg: cfg, base: base, client: client}, nil
+	case ProviderServiceNow:
+		return &serviceNow{cfg: cfg, base: base, client: client}, nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownProvider, cfg.Provider)
+}
+
+// doJSON sends body (if not nil) as JSON with basic auth and decodes a JSON
+// response into out (if not nil).
+func doJSON(ctx context.Context, client *http.Client, cfg config.TicketingConfig, method, url string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if cfg.Username != "" || cfg.APIToken != "" {
+		req.SetBasicAuth(cfg.Username, cfg.APIToken)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s %s: status %d: %s", method, url, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,118 @@
+package ticketing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/config"
+)
+
+func TestNew(t *testing.T) {
+	if p, err := New(config.TicketingConfig{}, http.DefaultClient); p != nil || err != nil {
+		t.Fatalf("expected ticketing disabled, got %v, %v", p, err)
+	}
+	if _, err := New(config.TicketingConfig{Provider: "trello"}, http.DefaultClient); !errors.Is(err, ErrUnknownProvider) {
+		t.Fatalf("expected ErrUnknownProvider, got %v", err)
+	}
+}
+
+func TestJira(t *testing.T) {
+	var created map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, _ := r.BasicAuth(); user != "bot@example.com" || pass != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/rest/api/2/issue":
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			_, _ = w.Write([]byte(`{"id":"10001","key":"OPS-7"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/2/issue/OPS-7":
+			_, _ = w.Write([]byte(`{"fields":{"status":{"name":"In Progress"}}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/rest/api/2/issue/OPS-7/comment":
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	p, err := New(config.TicketingConfig{Provider: "jira", BaseURL: srv.URL + "/", Username: "bot@example.com", APIToken: "token", Project: "OPS", IssueType: "Incident"}, srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	ref, err := p.Create(ctx, Ticket{Summary: "disk full", Labels: []string{"on call"}, Fields: map[string]string{"customfield_1": "checkout"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ref.Key != "OPS-7" || ref.URL != srv.URL+"/browse/OPS-7" {
+		t.Fatalf("unexpected ref %+v", ref)
+	}
+	fields := created["fields"].(map[string]any)
+	if fields["project"].(map[string]any)["key"] != "OPS" || fields["customfield_1"] != "checkout" || fields["labels"].([]any)[0] != "on-call" {
+		t.Fatalf("unexpected issue fields %+v", fields)
+	}
+	if status, err := p.Status(ctx, *ref); err != nil || status != "In Progress" {
+		t.Fatalf("unexpected status %q, %v", status, err)
+	}
+	if err := p.Comment(ctx, *ref, "seen again"); err != nil {
+		t.Fatal(err)
+	}
+
+	key, status, err := p.ParseWebhook([]byte(`{"webhookEvent":"jira:issue_updated","issue":{"key":"OPS-7","fields":{"status":{"name":"Done"}}}}`))
+	if err != nil || key != "OPS-7" || status != "Done" {
+		t.Fatalf("unexpected webhook %q %q %v", key, status, err)
+	}
+	if _, _, err := p.ParseWebhook([]byte(`{}`)); !errors.Is(err, ErrInvalidWebhook) {
+		t.Fatalf("expected ErrInvalidWebhook, got %v", err)
+	}
+}
+
+func TestServiceNow(t *testing.T) {
+	var notes map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/now/table/incident":
+			_, _ = w.Write([]byte(`{"result":{"sys_id":"abc123","number":"INC0010001"}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/now/table/incident/abc123":
+			if r.URL.Query().Get("sysparm_display_value") != "true" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write([]byte(`{"result":{"state":"Resolved"}}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/now/table/incident/abc123":
+			_ = json.NewDecoder(r.Body).Decode(&notes)
+			_, _ = w.Write([]byte(`{"result":{}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	p, err := New(config.TicketingConfig{Provider: "servicenow", BaseURL: srv.URL, Table: "incident"}, srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	ref, err := p.Create(ctx, Ticket{Summary: "disk full"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ref.ID != "abc123" || ref.Key != "INC0010001" || ref.URL != srv.URL+"/nav_to.do?uri=incident.do%3Fsys_id%3Dabc123" {
+		t.Fatalf("unexpected ref %+v", ref)
+	}
+	if status, err := p.Status(ctx, *ref); err != nil || status != "Resolved" {
+		t.Fatalf("unexpected status %q, %v", status, err)
+	}
+	if err := p.Comment(ctx, *ref, "seen again"); err != nil || notes["work_notes"] != "seen again" {
+		t.Fatalf("unexpected work notes %+v, %v", notes, err)
+	}
+	if key, status, err := p.ParseWebhook([]byte(`{"number":"INC0010001","state":"Closed"}`)); err != nil || key != "INC0010001" || status != "Closed" {
+		t.Fatalf("unexpected webhook %q %q %v", key, status, err)
+	}
+}
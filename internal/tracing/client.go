@@ -0,0 +1,144 @@
+package tracing
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Backend names recorded as peer.service on client spans.
+const (
+	BackendVictoriaMetrics = "victoriametrics"
+	BackendVictoriaLogs    = "victorialogs"
+	BackendVictoriaTraces  = "victoriatraces"
+	BackendWeaviate        = "weaviate"
+)
+
+// maxQueryHashBody caps how much of a form body is read to find the query.
+const maxQueryHashBody = 1 << 20
+
+// QueryHash identifies a query text on spans without recording the query.
+func QueryHash(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:8])
+}
+
+type clientTransport struct {
+	base    http.RoundTripper
+	backend string
+	tracer  trace.Tracer
+}
+
+// NewTransport returns a RoundTripper that records a client span for every
+// request to backend and propagates the W3C trace context (traceparent) to
+// it. base defaults to http.DefaultTransport.
+func NewTransport(base http.RoundTripper, backend string) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &clientTransport{base: base, backend: backend, tracer: otel.Tracer("mirador-core/client")}
+}
+
+func (t *clientTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := t.tracer.Start(req.Context(), fmt.Sprintf("%s %s", t.backend, req.Method),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("peer.service", t.backend),
+			attribute.String("http.request.method", req.Method),
+			attribute.String("server.address", req.URL.Host),
+			attribute.String("url.path", req.URL.Path),
+		),
+	)
+	defer span.End()
+	// Deriving attributes may read the body; skip it when nothing records.
+	if span.IsRecording() {
+		span.SetAttributes(t.backendAttributes(req)...)
+	}
+
+	req = req.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= 500 {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	return resp, nil
+}
+
+// backendAttributes derives the query hash (Victoria backends) or class
+// (Weaviate REST requests) from the request.
+func (t *clientTransport) backendAttributes(req *http.Request) []attribute.KeyValue {
+	if t.backend == BackendWeaviate {
+		if class := weaviateClass(req.URL); class != "" {
+			return []attribute.KeyValue{attribute.String("db.collection.name", class)}
+		}
+		return nil
+	}
+	if q := requestQuery(req); q != "" {
+		return []attribute.KeyValue{attribute.String("db.query.hash", QueryHash(q))}
+	}
+	return nil
+}
+
+// weaviateClass extracts the class from /v1/objects/<class>/... and
+// /v1/schema/<class>/... paths or the class parameter of object listings.
+func weaviateClass(u *url.URL) string {
+	if class := u.Query().Get("class"); class != "" {
+		return class
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 3 || parts[0] != "v1" || (parts[1] != "objects" && parts[1] != "schema") {
+		return ""
+	}
+	// /v1/objects/<id> without a class
+	if len(parts[2]) == 36 && strings.Count(parts[2], "-") == 4 {
+		return ""
+	}
+	return parts[2]
+}
+
+// requestQuery returns the "query" parameter from the URL or, for form
+// posts, from the body, which is read through GetBody so the request itself
+// is untouched.
+func requestQuery(req *http.Request) string {
+	if q := req.URL.Query().Get("query"); q != "" {
+		return q
+	}
+	if req.GetBody == nil {
+		return ""
+	}
+	if mt, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type")); mt != "application/x-www-form-urlencoded" {
+		return ""
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+	data, err := io.ReadAll(io.LimitReader(body, maxQueryHashBody))
+	if err != nil {
+		return ""
+	}
+	form, err := url.ParseQuery(string(data))
+	if err != nil {
+		return ""
+	}
+	return form.Get("query")
+}
@@ -0,0 +1,85 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTransport_PropagatesAndRecordsClientSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	var traceparent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "engine_query")
+	client := &http.Client{Transport: NewTransport(nil, BackendVictoriaLogs)}
+	form := url.Values{"query": {"error | stats count()"}}
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, srv.URL+"/select/logsql/query", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected client and parent spans, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.SpanKind() != trace.SpanKindClient || span.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Fatalf("client span not nested under the caller: kind=%v parent=%v", span.SpanKind(), span.Parent().SpanID())
+	}
+	if !strings.Contains(traceparent, span.SpanContext().SpanID().String()) {
+		t.Fatalf("traceparent %q does not carry the client span", traceparent)
+	}
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	if attrs["peer.service"].AsString() != BackendVictoriaLogs || attrs["url.path"].AsString() != "/select/logsql/query" {
+		t.Fatalf("missing backend attributes: %v", attrs)
+	}
+	if attrs["db.query.hash"].AsString() != QueryHash("error | stats count()") {
+		t.Fatalf("expected query hash from the form body, got %v", attrs["db.query.hash"])
+	}
+	if attrs["http.response.status_code"].AsInt64() != http.StatusOK {
+		t.Fatalf("missing status code: %v", attrs)
+	}
+}
+
+func TestWeaviateClass(t *testing.T) {
+	cases := map[string]string{
+		"/v1/objects/FailureRecord/6f1c2b7e-3a7d-4c1e-9b8e-2f0a1d3c4b5a": "FailureRecord",
+		"/v1/objects?class=MIRARCATask&limit=10":                         "MIRARCATask",
+		"/v1/schema/Kpi_definition":                                      "Kpi_definition",
+		"/v1/objects/6f1c2b7e-3a7d-4c1e-9b8e-2f0a1d3c4b5a":               "",
+		"/v1/graphql": "",
+	}
+	for raw, want := range cases {
+		u, _ := url.Parse(raw)
+		if got := weaviateClass(u); got != want {
+			t.Errorf("weaviateClass(%s) = %q, want %q", raw, got, want)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
@@ -58,6 +59,8 @@ func NewTracerProvider(serviceName, serviceVersion, otlpEndpoint string) (*Trace
 	)
 
 	otel.SetTracerProvider(tp)
+	// W3C traceparent/baggage on outgoing backend calls (see NewTransport)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
 
 	return &TracerProvider{tp: tp}, nil
 }
@@ -111,6 +114,25 @@ func (qt *QueryTracer) StartCorrelationSpan(ctx context.Context, correlationID,
 	return ctx, span
 }
 
+// StartCorrelationMatchSpan starts the span that correlates engine results,
+// linked to the engine query spans whose results it consumes.
+func (qt *QueryTracer) StartCorrelationMatchSpan(ctx context.Context, engineSpans []trace.SpanContext) (context.Context, trace.Span) {
+	links := make([]trace.Link, 0, len(engineSpans))
+	for _, sc := range engineSpans {
+		if sc.IsValid() {
+			links = append(links, trace.Link{SpanContext: sc})
+		}
+	}
+	ctx, span := qt.tracer.Start(ctx, "correlation_match",
+		trace.WithLinks(links...),
+		trace.WithAttributes(
+			attribute.Int("correlation.engine_spans", len(links)),
+			attribute.String("component", "correlation-engine"),
+		),
+	)
+	return ctx, span
+}
+
 // StartCacheOperationSpan starts a span for cache operations
 func (qt *QueryTracer) StartCacheOperationSpan(ctx context.Context, operation, key string) (context.Context, trace.Span) {
 	ctx, span := qt.tracer.Start(ctx, "cache_operation",
@@ -0,0 +1,106 @@
+// Package hll implements a HyperLogLog sketch for approximate distinct
+// counts.
+//
+// A sketch uses 2^precision one-byte registers whatever the number of
+// values added; at the default precision of 14 that is 16KiB with a
+// standard error of about 0.8%. Sketches of the same precision merge into
+// the sketch of the union of their values, so counts over several days or
+// sources can be combined without keeping the values.
+package hll
+
+import (
+	"hash/fnv"
+	"math"
+	"math/bits"
+)
+
+// DefaultPrecision is the precision used by New.
+const DefaultPrecision = 14
+
+// Sketch is a HyperLogLog sketch. It is not safe for concurrent use.
+type Sketch struct {
+	p         uint8
+	registers []uint8
+}
+
+// New returns an empty sketch of DefaultPrecision.
+func New() *Sketch {
+	return NewWithPrecision(DefaultPrecision)
+}
+
+// NewWithPrecision returns an empty sketch with 2^p registers; p is clamped
+// to [4, 18].
+func NewWithPrecision(p uint8) *Sketch {
+	p = min(max(p, 4), 18)
+	return &Sketch{p: p, registers: make([]uint8, 1<<p)}
+}
+
+// Add adds a value.
+func (s *Sketch) Add(value string) {
+	h := fnv.New64a()
+	h.Write([]byte(value))
+	x := mix(h.Sum64())
+	idx := x >> (64 - s.p)
+	// Rank of the first set bit in the remaining bits; the sentinel bit
+	// bounds it when they are all zero.
+	rank := uint8(bits.LeadingZeros64(x<<s.p|1<<(s.p-1)) + 1)
+	if rank > s.registers[idx] {
+		s.registers[idx] = rank
+	}
+}
+
+// Merge folds other into s. It reports false, leaving s unchanged, when the
+// precisions differ.
+func (s *Sketch) Merge(other *Sketch) bool {
+	if other == nil || other.p != s.p {
+		return false
+	}
+	for i, r := range other.registers {
+		if r > s.registers[i] {
+			s.registers[i] = r
+		}
+	}
+	return true
+}
+
+// Estimate returns the approximate number of distinct values added.
+func (s *Sketch) Estimate() uint64 {
+	m := float64(len(s.registers))
+	var sum float64
+	zeros := 0
+	for _, r := range s.registers {
+		sum += 1 / float64(uint64(1)<<r)
+		if r == 0 {
+			zeros++
+		}
+	}
+	est := alpha(len(s.registers)) * m * m / sum
+	// Linear counting is more accurate while many registers are empty.
+	if est <= 2.5*m && zeros > 0 {
+		est = m * math.Log(m/float64(zeros))
+	}
+	return uint64(est + 0.5)
+}
+
+func alpha(m int) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	}
+	return 0.7213 / (1 + 1.079/float64(m))
+}
+
+// mix is the splitmix64 finalizer; FNV alone spreads short, similar
+// strings poorly over the high bits used for the register index.
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,46 @@
+package hll
+
+import (
+	"strconv"
+	"testing"
+)
+
+func within(t *testing.T, got uint64, want int, tolerance float64) {
+	t.Helper()
+	if diff := float64(got) - float64(want); diff > tolerance*float64(want) || -diff > tolerance*float64(want) {
+		t.Fatalf("estimate %d, want %d ±%.0f%%", got, want, tolerance*100)
+	}
+}
+
+func TestSketch_Estimate(t *testing.T) {
+	if got := New().Estimate(); got != 0 {
+		t.Fatalf("empty sketch estimate %d", got)
+	}
+	for _, n := range []int{10, 1000, 100000} {
+		s := New()
+		for i := 0; i < n; i++ {
+			v := "pod-" + strconv.Itoa(i)
+			s.Add(v)
+			s.Add(v) // duplicates do not count
+		}
+		within(t, s.Estimate(), n, 0.03)
+	}
+}
+
+func TestSketch_Merge(t *testing.T) {
+	a, b := New(), New()
+	for i := 0; i < 6000; i++ {
+		a.Add(strconv.Itoa(i))
+	}
+	for i := 4000; i < 10000; i++ {
+		b.Add(strconv.Itoa(i))
+	}
+	if !a.Merge(b) {
+		t.Fatal("merge of equal precisions failed")
+	}
+	within(t, a.Estimate(), 10000, 0.03)
+
+	if a.Merge(NewWithPrecision(10)) {
+		t.Fatal("merge of different precisions succeeded")
+	}
+}
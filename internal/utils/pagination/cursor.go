@@ -0,0 +1,143 @@
+// Package pagination implements opaque cursor tokens for list endpoints.
+//
+// Offset pagination skips or repeats items when objects are inserted or
+// deleted between page requests. A cursor instead records the sort key of
+// the last item returned, so the next page resumes strictly after it no
+// matter what changed before it. Tokens also carry a hash of the filters
+// they were issued for and are rejected if reused with different filters.
+package pagination
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// QueryParam is the query (or JSON body) field carrying the page token.
+	QueryParam = "cursor"
+
+	// MaxScan bounds how many items a store is asked for when building a
+	// cursor listing; it matches the stores' own object listing limit.
+	MaxScan = 10000
+)
+
+// ErrInvalidCursor is returned for malformed tokens and for tokens issued
+// under different filters.
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+type token struct {
+	After   string `json:"a"`
+	Filters string `json:"f,omitempty"`
+}
+
+// FiltersHash fingerprints the filters of a listing request. Pass the
+// filter values in a fixed order; empty values still count positionally.
+func FiltersHash(filters ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(filters, "\x1f")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Encode returns the opaque token that resumes after sort key after.
+func Encode(after, filtersHash string) string {
+	b, _ := json.Marshal(token{After: after, Filters: filtersHash})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Decode returns the sort key a token resumes after. An empty token starts
+// from the beginning.
+func Decode(tok, filtersHash string) (string, error) {
+	if tok == "" {
+		return "", nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(tok)
+	if err != nil {
+		return "", ErrInvalidCursor
+	}
+	var t token
+	if err := json.Unmarshal(b, &t); err != nil || t.After == "" {
+		return "", ErrInvalidCursor
+	}
+	if t.Filters != filtersHash {
+		return "", fmt.Errorf("%w: filters changed since the cursor was issued", ErrInvalidCursor)
+	}
+	return t.After, nil
+}
+
+// AscKey builds a sort key ordering by s (case-insensitively), then id.
+func AscKey(s, id string) string {
+	return strings.ToLower(s) + "\x00" + id
+}
+
+// OldestFirstKey builds a sort key ordering by t ascending, then id. Zero
+// times sort first.
+func OldestFirstKey(t time.Time, id string) string {
+	var ns int64
+	if !t.IsZero() && t.Unix() > 0 {
+		ns = t.UnixNano()
+	}
+	return fmt.Sprintf("%019d\x00%s", ns, id)
+}
+
+// NewestFirstKey builds a sort key ordering by t descending, then id. Zero
+// times sort last.
+func NewestFirstKey(t time.Time, id string) string {
+	var ns int64
+	if !t.IsZero() && t.Unix() > 0 {
+		ns = t.UnixNano()
+	}
+	return fmt.Sprintf("%019d\x00%s", math.MaxInt64-ns, id)
+}
+
+// Page is one page of a cursor listing.
+type Page[T any] struct {
+	Items []T
+	// Total is the size of the full listing, not of this page.
+	Total int
+	// NextCursor resumes after the last item; empty on the final page.
+	NextCursor string
+}
+
+// Paginate orders items by key and returns up to limit items after the
+// position encoded in tok. key must be unique per item and must not change
+// between requests (include the item ID as a tiebreaker; see AscKey and
+// NewestFirstKey); that is what makes the ordering stable across pages.
+func Paginate[T any](items []T, key func(T) string, tok, filtersHash string, limit int) (Page[T], error) {
+	after, err := Decode(tok, filtersHash)
+	if err != nil {
+		return Page[T]{}, err
+	}
+
+	keys := make([]string, len(items))
+	idx := make([]int, len(items))
+	for i, it := range items {
+		keys[i] = key(it)
+		idx[i] = i
+	}
+	sort.Slice(idx, func(a, b int) bool { return keys[idx[a]] < keys[idx[b]] })
+
+	start := 0
+	if after != "" {
+		start = sort.Search(len(idx), func(i int) bool { return keys[idx[i]] > after })
+	}
+	end := len(idx)
+	if limit > 0 && start+limit < end {
+		end = start + limit
+	}
+
+	page := Page[T]{Items: make([]T, 0, end-start), Total: len(items)}
+	for _, i := range idx[start:end] {
+		page.Items = append(page.Items, items[i])
+	}
+	if end < len(idx) && end > start {
+		page.NextCursor = Encode(keys[idx[end-1]], filtersHash)
+	}
+	return page, nil
+}
@@ -0,0 +1,68 @@
+package pagination
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type item struct {
+	ID   string
+	Name string
+}
+
+func nameKey(it item) string { return AscKey(it.Name, it.ID) }
+
+func names(items []item) []string {
+	out := make([]string, len(items))
+	for i, it := range items {
+		out[i] = it.Name
+	}
+	return out
+}
+
+func TestPaginate_StableAcrossInserts(t *testing.T) {
+	items := []item{{"3", "cpu"}, {"1", "apdex"}, {"2", "Bytes"}, {"4", "errors"}}
+	filters := FiltersHash("kind=impact")
+
+	p1, err := Paginate(items, nameKey, "", filters, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(names(p1.Items), []string{"apdex", "Bytes"}) || p1.Total != 4 || p1.NextCursor == "" {
+		t.Fatalf("unexpected first page: %+v", p1)
+	}
+
+	// An item inserted before the cursor must not shift the next page.
+	items = append(items, item{"0", "aaa"})
+	p2, err := Paginate(items, nameKey, p1.NextCursor, filters, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(names(p2.Items), []string{"cpu", "errors"}) || p2.NextCursor != "" {
+		t.Fatalf("unexpected second page: %+v", p2)
+	}
+}
+
+func TestPaginate_RejectsForeignCursors(t *testing.T) {
+	items := []item{{"1", "a"}, {"2", "b"}}
+	p, _ := Paginate(items, nameKey, "", FiltersHash("tags=x"), 1)
+
+	if _, err := Paginate(items, nameKey, p.NextCursor, FiltersHash("tags=y"), 1); !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("expected ErrInvalidCursor for changed filters, got %v", err)
+	}
+	if _, err := Paginate(items, nameKey, "not-a-cursor!", "", 1); !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("expected ErrInvalidCursor for garbage, got %v", err)
+	}
+}
+
+func TestNewestFirstKey(t *testing.T) {
+	now := time.Now()
+	if NewestFirstKey(now, "a") >= NewestFirstKey(now.Add(-time.Second), "a") {
+		t.Fatal("newer timestamps must sort first")
+	}
+	if NewestFirstKey(now, "a") >= NewestFirstKey(now, "b") {
+		t.Fatal("equal timestamps must fall back to the id")
+	}
+}
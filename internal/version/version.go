@@ -7,3 +7,13 @@ var (
 	CommitHash = ""
 	BuildTime  = ""
 )
+
+// SchemaVersion is the Weaviate schema layout this build applies. Bump it
+// with every schema change. MinSchemaVersion is the oldest layout other
+// instances may run while this build's layout is applied: raise it to
+// SchemaVersion for breaking changes, so the migration waits until
+// instances of older builds have drained.
+const (
+	SchemaVersion    = 1
+	MinSchemaVersion = 1
+)
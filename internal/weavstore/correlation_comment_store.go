@@ -0,0 +1,199 @@
+package weavstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	wv "github.com/weaviate/weaviate-go-client/v5/weaviate"
+	"github.com/weaviate/weaviate-go-client/v5/weaviate/graphql"
+	wm "github.com/weaviate/weaviate/entities/models"
+	"go.uber.org/zap"
+)
+
+const correlationCommentClass = "CorrelationComment"
+
+var (
+	ErrCorrelationCommentIsNil = errors.New("correlation comment is nil")
+	ErrCorrelationIDEmpty      = errors.New("correlation id is empty")
+)
+
+// CorrelationComment is a comment on a correlation run, or on one of its
+// cause candidates when CandidateKPIID is set. ParentID threads replies.
+type CorrelationComment struct {
+	ID             string    `json:"id"`
+	Tenant         string    `json:"tenant,omitempty"`
+	CorrelationID  string    `json:"correlationId"`
+	CandidateKPIID string    `json:"candidateKpiId,omitempty"`
+	ParentID       string    `json:"parentId,omitempty"`
+	Author         string    `json:"author,omitempty"`
+	Body           string    `json:"body"`
+	Mentions       []string  `json:"mentions,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// WeaviateCorrelationCommentStore stores correlation run comments in the
+// CorrelationComment class.
+type WeaviateCorrelationCommentStore struct {
+	client      *wv.Client
+	logger      *zap.Logger
+	schemaInit  sync.Once
+	schemaErr   error
+	replication ReplicationPolicy
+}
+
+// NewWeaviateCorrelationCommentStore constructs a new comment store.
+func NewWeaviateCorrelationCommentStore(client *wv.Client, logger *zap.Logger) *WeaviateCorrelationCommentStore {
+	return &WeaviateCorrelationCommentStore{client: client, logger: logger}
+}
+
+// SetReplicationPolicy configures replication factor and write consistency.
+// Call before the first operation; the factor only applies at class creation.
+func (s *WeaviateCorrelationCommentStore) SetReplicationPolicy(p ReplicationPolicy) {
+	s.replication = p
+}
+
+func makeCorrelationCommentObjectID(id string) string {
+	return uuid.NewV5(nsMirador, fmt.Sprintf("%s|%s", correlationCommentClass, id)).String()
+}
+
+// CreateCorrelationComment stores a new comment.
+func (s *WeaviateCorrelationCommentStore) CreateCorrelationComment(ctx context.Context, c *CorrelationComment) error {
+	if c == nil {
+		return ErrCorrelationCommentIsNil
+	}
+	if c.CorrelationID == "" {
+		return ErrCorrelationIDEmpty
+	}
+	if err := s.ensureSchema(ctx); err != nil {
+		return err
+	}
+	props := map[string]any{
+		"commentId":      c.ID,
+		"tenant":         storedTenant(c.Tenant),
+		"correlationId":  c.CorrelationID,
+		"candidateKpiId": c.CandidateKPIID,
+		"parentId":       c.ParentID,
+		"author":         c.Author,
+		"body":           c.Body,
+		"mentions":       c.Mentions,
+		"createdAt":      c.CreatedAt.Format(time.RFC3339Nano),
+	}
+	if _, err := s.client.Data().Creator().WithClassName(correlationCommentClass).
+		WithConsistencyLevel(s.replication.Consistency(correlationCommentClass)).
+		WithID(makeCorrelationCommentObjectID(c.ID)).WithProperties(props).Do(ctx); err != nil {
+		return fmt.Errorf("failed to create correlation comment: %w", err)
+	}
+	return nil
+}
+
+// correlationCommentProperties are the properties of a CorrelationComment.
+var correlationCommentProperties = []string{
+	"commentId", "tenant", "correlationId", "candidateKpiId", "parentId", "author", "body", "mentions", "createdAt",
+}
+
+// ListCorrelationComments returns the comments of a run for tenant, oldest
+// first, up to maxObjectsLimit. Weaviate filters and sorts them.
+func (s *WeaviateCorrelationCommentStore) ListCorrelationComments(ctx context.Context, tenant, correlationID string) ([]*CorrelationComment, error) {
+	if correlationID == "" {
+		return nil, ErrCorrelationIDEmpty
+	}
+	if err := s.ensureSchema(ctx); err != nil {
+		return nil, err
+	}
+	rows, err := getObjects(ctx, s.client, correlationCommentClass, correlationCommentProperties,
+		allOf(textEqual("tenant", storedTenant(tenant)), textEqual("correlationId", correlationID)),
+		[]graphql.Sort{{Path: []string{"createdAt"}, Order: graphql.Asc}}, maxObjectsLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list correlation comments: %w", err)
+	}
+	out := make([]*CorrelationComment, 0, len(rows))
+	for _, props := range rows {
+		// Exact check in case the class predates field tokenization.
+		if c := correlationCommentFromProps(props); c.CorrelationID == correlationID && c.Tenant == tenant {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+func correlationCommentFromProps(props map[string]any) *CorrelationComment {
+	str := func(k string) string {
+		v, _ := props[k].(string)
+		return v
+	}
+	c := &CorrelationComment{
+		ID:             str("commentId"),
+		Tenant:         loadedTenant(str("tenant")),
+		CorrelationID:  str("correlationId"),
+		CandidateKPIID: str("candidateKpiId"),
+		ParentID:       str("parentId"),
+		Author:         str("author"),
+		Body:           str("body"),
+	}
+	if raw, ok := props["mentions"].([]any); ok {
+		for _, m := range raw {
+			if s, ok := m.(string); ok {
+				c.Mentions = append(c.Mentions, s)
+			}
+		}
+	}
+	if t, err := time.Parse(time.RFC3339Nano, str("createdAt")); err == nil {
+		c.CreatedAt = t
+	}
+	return c
+}
+
+func (s *WeaviateCorrelationCommentStore) ensureSchema(ctx context.Context) error {
+	s.schemaInit.Do(func() {
+		s.schemaErr = s.ensureCorrelationCommentClass(ctx)
+		if s.schemaErr != nil && s.logger != nil {
+			s.logger.Sugar().Warnf("weavstore: failed ensuring %s class: %v", correlationCommentClass, s.schemaErr)
+		}
+	})
+	return s.schemaErr
+}
+
+// ensureCorrelationCommentClass creates the CorrelationComment class if it
+// does not exist yet.
+func (s *WeaviateCorrelationCommentStore) ensureCorrelationCommentClass(ctx context.Context) error {
+	if s.client == nil {
+		return ErrWeaviateClientNil
+	}
+	classDef := correlationCommentClassDef(s.replication)
+	if err := s.client.Schema().ClassCreator().WithClass(classDef).Do(ctx); err != nil {
+		if strings.Contains(err.Error(), "already exists") {
+			return nil
+		}
+		return fmt.Errorf("failed to create %s class in Weaviate: %w", correlationCommentClass, err)
+	}
+	if s.logger != nil {
+		s.logger.Sugar().Infof("weavstore: created %s class in Weaviate runtime schema", correlationCommentClass)
+	}
+	return nil
+}
+
+// correlationCommentClassDef is the expected definition of the
+// CorrelationComment class.
+func correlationCommentClassDef(replication ReplicationPolicy) *wm.Class {
+	return &wm.Class{
+		Class:             correlationCommentClass,
+		Vectorizer:        "none",
+		ReplicationConfig: replication.replicationConfig(correlationCommentClass),
+		Properties: []*wm.Property{
+			{Name: "commentId", DataType: []string{"text"}},
+			{Name: "tenant", DataType: []string{"text"}, Tokenization: "field"},
+			{Name: "correlationId", DataType: []string{"text"}, Tokenization: "field"},
+			{Name: "candidateKpiId", DataType: []string{"text"}},
+			{Name: "parentId", DataType: []string{"text"}},
+			{Name: "author", DataType: []string{"text"}},
+			{Name: "body", DataType: []string{"text"}},
+			{Name: "mentions", DataType: []string{"text[]"}},
+			{Name: "createdAt", DataType: []string{"date"}},
+		},
+	}
+}
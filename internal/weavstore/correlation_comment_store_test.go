@@ -0,0 +1,85 @@
+package weavstore
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	wv "github.com/weaviate/weaviate-go-client/v5/weaviate"
+)
+
+func TestCorrelationCommentFromProps(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	c := correlationCommentFromProps(map[string]any{
+		"commentId":      "c1",
+		"tenant":         "acme",
+		"correlationId":  "run-1",
+		"candidateKpiId": "db_latency",
+		"parentId":       "c0",
+		"author":         "alice",
+		"body":           "cc @bob",
+		"mentions":       []any{"bob"},
+		"createdAt":      now.Format(time.RFC3339Nano),
+	})
+	assert.Equal(t, &CorrelationComment{
+		ID: "c1", Tenant: "acme", CorrelationID: "run-1", CandidateKPIID: "db_latency",
+		ParentID: "c0", Author: "alice", Body: "cc @bob", Mentions: []string{"bob"}, CreatedAt: now,
+	}, c)
+	assert.NotEqual(t, makeCorrelationCommentObjectID("c1"), makeCorrelationCommentObjectID("c2"))
+}
+
+func TestListCorrelationComments_QueriesWeaviate(t *testing.T) {
+	client, queries := fakeGraphQL(t, correlationCommentClass, []map[string]any{
+		{"commentId": "c1", "tenant": "acme", "correlationId": "run-1", "createdAt": "2024-05-01T00:00:00Z"},
+		{"commentId": "c2", "tenant": "acme", "correlationId": "run-1", "createdAt": "2024-05-02T00:00:00Z"},
+	})
+	store := NewWeaviateCorrelationCommentStore(client, nil)
+
+	comments, err := store.ListCorrelationComments(context.Background(), "acme", "run-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comments) != 2 || comments[0].ID != "c1" || comments[1].ID != "c2" {
+		t.Fatalf("unexpected comments %+v", comments)
+	}
+	q := (*queries)[0]
+	for _, want := range []string{`path: ["tenant"] valueText: "acme"`, `path: ["correlationId"] valueText: "run-1"`, `order:asc`} {
+		if !strings.Contains(q, want) {
+			t.Errorf("query lacks %s: %s", want, q)
+		}
+	}
+}
+
+// fakeGraphQL serves schema creation and answers every GraphQL query with
+// rows for class, recording the queries it received.
+func fakeGraphQL(t *testing.T, class string, rows []map[string]any) (*wv.Client, *[]string) {
+	t.Helper()
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/schema":
+			_, _ = w.Write([]byte(`{}`))
+		case "/v1/graphql":
+			var body struct {
+				Query string `json:"query"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			queries = append(queries, body.Query)
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"Get": map[string]any{class: rows}}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	client, err := wv.NewClient(wv.Config{Scheme: "http", Host: strings.TrimPrefix(srv.URL, "http://")})
+	if err != nil {
+		t.Fatalf("client: %v", err)
+	}
+	return client, &queries
+}
@@ -0,0 +1,293 @@
+package weavstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	wv "github.com/weaviate/weaviate-go-client/v5/weaviate"
+	"github.com/weaviate/weaviate-go-client/v5/weaviate/fault"
+	"github.com/weaviate/weaviate-go-client/v5/weaviate/filters"
+	"github.com/weaviate/weaviate-go-client/v5/weaviate/graphql"
+	wm "github.com/weaviate/weaviate/entities/models"
+	"go.uber.org/zap"
+)
+
+const correlationHistoryClass = "CorrelationHistory"
+
+var ErrCorrelationHistoryIsNil = errors.New("correlation history record is nil")
+
+// CorrelationHistoryRecord is a persisted correlation result. Result holds
+// the full result as JSON; the summary fields are stored alongside for
+// listing. The annotation fields are set once an operator has confirmed the
+// result or marked it a false positive.
+type CorrelationHistoryRecord struct {
+	CorrelationID    string    `json:"correlationId"`
+	Tenant           string    `json:"tenant,omitempty"`
+	IncidentIDs      []string  `json:"incidentIds,omitempty"`
+	RootCause        string    `json:"rootCause,omitempty"`
+	Confidence       float64   `json:"confidence"`
+	AffectedServices []string  `json:"affectedServices,omitempty"`
+	StartTime        time.Time `json:"startTime"`
+	EndTime          time.Time `json:"endTime"`
+	Result           string    `json:"result"`
+	Verdict          string    `json:"verdict,omitempty"`
+	CauseKPI         string    `json:"causeKpi,omitempty"`
+	Notes            string    `json:"notes,omitempty"`
+	AnnotatedBy      string    `json:"annotatedBy,omitempty"`
+	AnnotatedAt      time.Time `json:"annotatedAt,omitempty"`
+	CreatedAt        time.Time `json:"createdAt"`
+}
+
+// CorrelationHistoryQuery selects the records ListCorrelationHistory
+// returns. Zero fields other than Tenant match everything.
+type CorrelationHistoryQuery struct {
+	Tenant     string
+	IncidentID string
+	Verdict    string
+	Since      time.Time
+	// Limit caps the records returned; 0 or more than maxObjectsLimit
+	// means maxObjectsLimit.
+	Limit int
+}
+
+// correlationHistoryListProperties are the properties listings fetch: all
+// but the full result.
+var correlationHistoryListProperties = []string{
+	"correlationId", "tenant", "incidentIds", "rootCause", "confidence", "affectedServices",
+	"startTime", "endTime", "verdict", "causeKpi", "notes", "annotatedBy", "annotatedAt", "createdAt",
+}
+
+// WeaviateCorrelationHistoryStore stores correlation results in the
+// CorrelationHistory class, one object per tenant and correlation ID.
+type WeaviateCorrelationHistoryStore struct {
+	client      *wv.Client
+	logger      *zap.Logger
+	schemaInit  sync.Once
+	schemaErr   error
+	replication ReplicationPolicy
+}
+
+// NewWeaviateCorrelationHistoryStore constructs a new correlation history store.
+func NewWeaviateCorrelationHistoryStore(client *wv.Client, logger *zap.Logger) *WeaviateCorrelationHistoryStore {
+	return &WeaviateCorrelationHistoryStore{client: client, logger: logger}
+}
+
+// SetReplicationPolicy configures replication factor and write consistency.
+// Call before the first operation; the factor only applies at class creation.
+func (s *WeaviateCorrelationHistoryStore) SetReplicationPolicy(p ReplicationPolicy) {
+	s.replication = p
+}
+
+func makeCorrelationHistoryObjectID(tenant, correlationID string) string {
+	return uuid.NewV5(nsMirador, fmt.Sprintf("%s|%s|%s", correlationHistoryClass, tenant, correlationID)).String()
+}
+
+// PutCorrelationHistory creates or replaces the record of r.CorrelationID.
+func (s *WeaviateCorrelationHistoryStore) PutCorrelationHistory(ctx context.Context, r *CorrelationHistoryRecord) error {
+	if r == nil {
+		return ErrCorrelationHistoryIsNil
+	}
+	if r.CorrelationID == "" {
+		return ErrCorrelationIDEmpty
+	}
+	if err := s.ensureSchema(ctx); err != nil {
+		return err
+	}
+	props := map[string]any{
+		"correlationId":    r.CorrelationID,
+		"tenant":           storedTenant(r.Tenant),
+		"incidentIds":      r.IncidentIDs,
+		"rootCause":        r.RootCause,
+		"confidence":       r.Confidence,
+		"affectedServices": r.AffectedServices,
+		"startTime":        r.StartTime.Format(time.RFC3339Nano),
+		"endTime":          r.EndTime.Format(time.RFC3339Nano),
+		"result":           r.Result,
+		"verdict":          r.Verdict,
+		"causeKpi":         r.CauseKPI,
+		"notes":            r.Notes,
+		"annotatedBy":      r.AnnotatedBy,
+		"createdAt":        r.CreatedAt.Format(time.RFC3339Nano),
+	}
+	if !r.AnnotatedAt.IsZero() {
+		props["annotatedAt"] = r.AnnotatedAt.Format(time.RFC3339Nano)
+	}
+	objID := makeCorrelationHistoryObjectID(r.Tenant, r.CorrelationID)
+	consistency := s.replication.Consistency(correlationHistoryClass)
+	if _, err := s.client.Data().Creator().WithClassName(correlationHistoryClass).WithConsistencyLevel(consistency).
+		WithID(objID).WithProperties(props).Do(ctx); err != nil {
+		if !strings.Contains(err.Error(), "already exists") {
+			return fmt.Errorf("failed to store correlation history: %w", err)
+		}
+		if err := s.client.Data().Updater().WithClassName(correlationHistoryClass).WithConsistencyLevel(consistency).
+			WithID(objID).WithProperties(props).Do(ctx); err != nil {
+			return fmt.Errorf("failed to update correlation history: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetCorrelationHistory returns the record of correlationID for tenant, or
+// nil when none was stored.
+func (s *WeaviateCorrelationHistoryStore) GetCorrelationHistory(ctx context.Context, tenant, correlationID string) (*CorrelationHistoryRecord, error) {
+	if correlationID == "" {
+		return nil, ErrCorrelationIDEmpty
+	}
+	if err := s.ensureSchema(ctx); err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Data().ObjectsGetter().WithClassName(correlationHistoryClass).
+		WithID(makeCorrelationHistoryObjectID(tenant, correlationID)).Do(ctx)
+	if err != nil {
+		var werr *fault.WeaviateClientError
+		if errors.As(err, &werr) && werr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get correlation history: %w", err)
+	}
+	for _, o := range resp {
+		if o == nil {
+			continue
+		}
+		if props, ok := o.Properties.(map[string]any); ok {
+			return correlationHistoryFromProps(props), nil
+		}
+	}
+	return nil, nil
+}
+
+// ListCorrelationHistory returns the records matching q, newest first,
+// without their Result. Filtering, sorting and the limit are applied by
+// Weaviate.
+func (s *WeaviateCorrelationHistoryStore) ListCorrelationHistory(ctx context.Context, q CorrelationHistoryQuery) ([]*CorrelationHistoryRecord, error) {
+	if err := s.ensureSchema(ctx); err != nil {
+		return nil, err
+	}
+	where := []*filters.WhereBuilder{textEqual("tenant", storedTenant(q.Tenant))}
+	if q.IncidentID != "" {
+		where = append(where, filters.Where().WithPath([]string{"incidentIds"}).WithOperator(filters.ContainsAny).WithValueText(q.IncidentID))
+	}
+	if q.Verdict != "" {
+		where = append(where, textEqual("verdict", q.Verdict))
+	}
+	if !q.Since.IsZero() {
+		where = append(where, filters.Where().WithPath([]string{"createdAt"}).WithOperator(filters.GreaterThanEqual).WithValueDate(q.Since))
+	}
+	rows, err := getObjects(ctx, s.client, correlationHistoryClass, correlationHistoryListProperties, allOf(where...),
+		[]graphql.Sort{{Path: []string{"createdAt"}, Order: graphql.Desc}}, q.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list correlation history: %w", err)
+	}
+	out := make([]*CorrelationHistoryRecord, 0, len(rows))
+	for _, props := range rows {
+		// Exact check in case the class predates field tokenization.
+		if r := correlationHistoryFromProps(props); r.Tenant == q.Tenant {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func correlationHistoryFromProps(props map[string]any) *CorrelationHistoryRecord {
+	str := func(k string) string {
+		v, _ := props[k].(string)
+		return v
+	}
+	strs := func(k string) []string {
+		var out []string
+		if raw, ok := props[k].([]any); ok {
+			for _, v := range raw {
+				if s, ok := v.(string); ok {
+					out = append(out, s)
+				}
+			}
+		}
+		return out
+	}
+	ts := func(k string) time.Time {
+		t, _ := time.Parse(time.RFC3339Nano, str(k))
+		return t
+	}
+	r := &CorrelationHistoryRecord{
+		CorrelationID:    str("correlationId"),
+		Tenant:           loadedTenant(str("tenant")),
+		IncidentIDs:      strs("incidentIds"),
+		RootCause:        str("rootCause"),
+		AffectedServices: strs("affectedServices"),
+		StartTime:        ts("startTime"),
+		EndTime:          ts("endTime"),
+		Result:           str("result"),
+		Verdict:          str("verdict"),
+		CauseKPI:         str("causeKpi"),
+		Notes:            str("notes"),
+		AnnotatedBy:      str("annotatedBy"),
+		AnnotatedAt:      ts("annotatedAt"),
+		CreatedAt:        ts("createdAt"),
+	}
+	if v, ok := props["confidence"].(float64); ok {
+		r.Confidence = v
+	}
+	return r
+}
+
+func (s *WeaviateCorrelationHistoryStore) ensureSchema(ctx context.Context) error {
+	s.schemaInit.Do(func() {
+		s.schemaErr = s.ensureCorrelationHistoryClass(ctx)
+		if s.schemaErr != nil && s.logger != nil {
+			s.logger.Sugar().Warnf("weavstore: failed ensuring %s class: %v", correlationHistoryClass, s.schemaErr)
+		}
+	})
+	return s.schemaErr
+}
+
+// ensureCorrelationHistoryClass creates the CorrelationHistory class if it
+// does not exist yet.
+func (s *WeaviateCorrelationHistoryStore) ensureCorrelationHistoryClass(ctx context.Context) error {
+	if s.client == nil {
+		return ErrWeaviateClientNil
+	}
+	classDef := correlationHistoryClassDef(s.replication)
+	if err := s.client.Schema().ClassCreator().WithClass(classDef).Do(ctx); err != nil {
+		if strings.Contains(err.Error(), "already exists") {
+			return nil
+		}
+		return fmt.Errorf("failed to create %s class in Weaviate: %w", correlationHistoryClass, err)
+	}
+	if s.logger != nil {
+		s.logger.Sugar().Infof("weavstore: created %s class in Weaviate runtime schema", correlationHistoryClass)
+	}
+	return nil
+}
+
+// correlationHistoryClassDef is the expected definition of the
+// CorrelationHistory class.
+func correlationHistoryClassDef(replication ReplicationPolicy) *wm.Class {
+	return &wm.Class{
+		Class:             correlationHistoryClass,
+		Vectorizer:        "none",
+		ReplicationConfig: replication.replicationConfig(correlationHistoryClass),
+		Properties: []*wm.Property{
+			{Name: "correlationId", DataType: []string{"text"}, Tokenization: "field"},
+			{Name: "tenant", DataType: []string{"text"}, Tokenization: "field"},
+			{Name: "incidentIds", DataType: []string{"text[]"}, Tokenization: "field"},
+			{Name: "rootCause", DataType: []string{"text"}},
+			{Name: "confidence", DataType: []string{"number"}},
+			{Name: "affectedServices", DataType: []string{"text[]"}},
+			{Name: "startTime", DataType: []string{"date"}},
+			{Name: "endTime", DataType: []string{"date"}},
+			{Name: "result", DataType: []string{"text"}},
+			{Name: "verdict", DataType: []string{"text"}, Tokenization: "field"},
+			{Name: "causeKpi", DataType: []string{"text"}},
+			{Name: "notes", DataType: []string{"text"}},
+			{Name: "annotatedBy", DataType: []string{"text"}},
+			{Name: "annotatedAt", DataType: []string{"date"}},
+			{Name: "createdAt", DataType: []string{"date"}},
+		},
+	}
+}
@@ -0,0 +1,72 @@
+package weavstore
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCorrelationHistoryFromProps(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	r := correlationHistoryFromProps(map[string]any{
+		"correlationId":    "corr_1",
+		"tenant":           "acme",
+		"incidentIds":      []any{"INC-1"},
+		"rootCause":        "db_latency",
+		"confidence":       0.75,
+		"affectedServices": []any{"checkout"},
+		"startTime":        now.Add(-time.Hour).Format(time.RFC3339Nano),
+		"endTime":          now.Format(time.RFC3339Nano),
+		"result":           `{"correlation_id":"corr_1"}`,
+		"verdict":          "confirmed",
+		"annotatedBy":      "alice",
+		"annotatedAt":      now.Format(time.RFC3339Nano),
+		"createdAt":        now.Format(time.RFC3339Nano),
+	})
+	assert.Equal(t, &CorrelationHistoryRecord{
+		CorrelationID: "corr_1", Tenant: "acme", IncidentIDs: []string{"INC-1"}, RootCause: "db_latency",
+		Confidence: 0.75, AffectedServices: []string{"checkout"}, StartTime: now.Add(-time.Hour), EndTime: now,
+		Result: `{"correlation_id":"corr_1"}`, Verdict: "confirmed", AnnotatedBy: "alice", AnnotatedAt: now, CreatedAt: now,
+	}, r)
+	assert.NotEqual(t, makeCorrelationHistoryObjectID("acme", "corr_1"), makeCorrelationHistoryObjectID("other", "corr_1"))
+}
+
+func TestListCorrelationHistory_QueriesWeaviate(t *testing.T) {
+	client, queries := fakeGraphQL(t, correlationHistoryClass, []map[string]any{
+		{"correlationId": "corr_2", "tenant": "acme", "createdAt": "2024-05-02T00:00:00Z"},
+		{"correlationId": "corr_x", "tenant": "acme-prod", "createdAt": "2024-05-01T12:00:00Z"},
+		{"correlationId": "corr_1", "tenant": "acme", "createdAt": "2024-05-01T00:00:00Z"},
+	})
+	store := NewWeaviateCorrelationHistoryStore(client, nil)
+
+	records, err := store.ListCorrelationHistory(context.Background(), CorrelationHistoryQuery{Tenant: "acme", IncidentID: "INC-1", Limit: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[0].CorrelationID != "corr_2" || records[1].CorrelationID != "corr_1" {
+		t.Fatalf("unexpected records %+v", records)
+	}
+	if len(*queries) != 1 {
+		t.Fatalf("expected one query, got %v", *queries)
+	}
+	q := (*queries)[0]
+	for _, want := range []string{`path: ["tenant"] valueText: "acme"`, `operator: ContainsAny path: ["incidentIds"] valueText: ["INC-1"]`, `order:desc`, `limit: 20`} {
+		if !strings.Contains(q, want) {
+			t.Errorf("query lacks %s: %s", want, q)
+		}
+	}
+	if strings.Contains(q, "result") {
+		t.Errorf("listing should not fetch the full result: %s", q)
+	}
+
+	// Records without a tenant are stored and matched under noTenant.
+	if _, err := store.ListCorrelationHistory(context.Background(), CorrelationHistoryQuery{}); err != nil {
+		t.Fatal(err)
+	}
+	if q := (*queries)[1]; !strings.Contains(q, `valueText: "~"`) {
+		t.Errorf("empty tenant should match noTenant: %s", q)
+	}
+}
@@ -22,6 +22,8 @@ type WeaviateFailureStore struct {
 	// schemaInit ensures we attempt to create the required class only once
 	schemaInit sync.Once
 	schemaErr  error
+	// replication controls class replication factor and write consistency
+	replication ReplicationPolicy
 }
 
 // NewWeaviateFailureStore constructs a new Failure store.
@@ -29,6 +31,12 @@ func NewWeaviateFailureStore(client *wv.Client, logger *zap.Logger) *WeaviateFai
 	return &WeaviateFailureStore{client: client, logger: logger}
 }
 
+// SetReplicationPolicy configures replication factor and write consistency.
+// Call before the first operation; the factor only applies at class creation.
+func (s *WeaviateFailureStore) SetReplicationPolicy(p ReplicationPolicy) {
+	s.replication = p
+}
+
 // Static errors for err113 compliance
 var (
 	ErrFailureIsNil                       = errors.New("failure is nil")
@@ -102,7 +110,7 @@ func (s *WeaviateFailureStore) CreateOrUpdateFailure(ctx context.Context, f *Fai
 			return existing, statusNoChange, nil
 		}
 		// There is a modification: perform update
-		if err := s.client.Data().Updater().WithClassName("FailureRecord").WithID(objID).WithProperties(props).Do(ctx); err != nil {
+		if err := s.client.Data().Updater().WithClassName("FailureRecord").WithConsistencyLevel(s.replication.Consistency("FailureRecord")).WithID(objID).WithProperties(props).Do(ctx); err != nil {
 			return nil, "", err
 		}
 		return f, statusUpdated, nil
@@ -112,12 +120,12 @@ func (s *WeaviateFailureStore) CreateOrUpdateFailure(ctx context.Context, f *Fai
 	// fall back to updating the existing object. This handles races where the
 	// object was created between the GetFailure call and the Creator() call and
 	// avoids returning a 422 'id already exists' to callers.
-	if _, err := s.client.Data().Creator().WithClassName("FailureRecord").WithID(objID).WithProperties(props).Do(ctx); err != nil {
+	if _, err := s.client.Data().Creator().WithClassName("FailureRecord").WithConsistencyLevel(s.replication.Consistency("FailureRecord")).WithID(objID).WithProperties(props).Do(ctx); err != nil {
 		// Some Weaviate error responses include messages like "id '...' already exists"
 		// or mention "already exists"; handle those conservatively by attempting
 		// an update instead of failing the whole operation.
 		if strings.Contains(err.Error(), "already exists") || strings.Contains(err.Error(), "id already exists") {
-			if err2 := s.client.Data().Updater().WithClassName("FailureRecord").WithID(objID).WithProperties(props).Do(ctx); err2 != nil {
+			if err2 := s.client.Data().Updater().WithClassName("FailureRecord").WithConsistencyLevel(s.replication.Consistency("FailureRecord")).WithID(objID).WithProperties(props).Do(ctx); err2 != nil {
 				return nil, "", fmt.Errorf("create conflict: update also failed: %w (create err: %v)", err2, err)
 			}
 			return f, statusUpdated, nil
@@ -282,7 +290,7 @@ func (s *WeaviateFailureStore) DeleteFailureByID(ctx context.Context, failureID
 		// Check if this object's failureId matches
 		if fid, ok := props["failureId"].(string); ok && fid == failureID {
 			oid := o.ID.String()
-			if err := s.client.Data().Deleter().WithClassName("FailureRecord").WithID(oid).Do(ctx); err == nil {
+			if err := s.client.Data().Deleter().WithClassName("FailureRecord").WithConsistencyLevel(s.replication.Consistency("FailureRecord")).WithID(oid).Do(ctx); err == nil {
 				s.logf("weavstore: deleted failure by ID=%s (objID=%s)", failureID, oid)
 				return nil
 			} else {
@@ -299,7 +307,7 @@ func (s *WeaviateFailureStore) DeleteFailureByID(ctx context.Context, failureID
 func (s *WeaviateFailureStore) tryDeleteFailureByObjectID(ctx context.Context, failureUUID, objID string) bool {
 	s.logf("weavstore: attempting delete for failure uuid=%s (objID=%s)", failureUUID, objID)
 
-	if err := s.client.Data().Deleter().WithClassName("FailureRecord").WithID(objID).Do(ctx); err == nil {
+	if err := s.client.Data().Deleter().WithClassName("FailureRecord").WithConsistencyLevel(s.replication.Consistency("FailureRecord")).WithID(objID).Do(ctx); err == nil {
 		s.logf("weavstore: deleted failure by objID=%s", objID)
 		if s.verifyFailureDeletion(ctx, failureUUID, objID) {
 			return true
@@ -341,7 +349,7 @@ func (s *WeaviateFailureStore) tryDeleteFailureByScan(ctx context.Context, failu
 
 // tryDeleteFailureAndVerify attempts a delete and verifies it succeeded
 func (s *WeaviateFailureStore) tryDeleteFailureAndVerify(ctx context.Context, failureUUID, oid string) bool {
-	if derr := s.client.Data().Deleter().WithClassName("FailureRecord").WithID(oid).Do(ctx); derr == nil {
+	if derr := s.client.Data().Deleter().WithClassName("FailureRecord").WithConsistencyLevel(s.replication.Consistency("FailureRecord")).WithID(oid).Do(ctx); derr == nil {
 		s.logf("weavstore: deleted failure by scanning object id=%s", oid)
 		if s.verifyFailureDeletion(ctx, failureUUID, oid) {
 			return true
@@ -369,7 +377,7 @@ func (s *WeaviateFailureStore) tryDeleteFailureByProperties(ctx context.Context,
 	}
 
 	oid := o.ID.String()
-	if derr := s.client.Data().Deleter().WithClassName("FailureRecord").WithID(oid).Do(ctx); derr == nil {
+	if derr := s.client.Data().Deleter().WithClassName("FailureRecord").WithConsistencyLevel(s.replication.Consistency("FailureRecord")).WithID(oid).Do(ctx); derr == nil {
 		s.logf("weavstore: deleted failure by scanning props match uuid=%s -> oid=%s", failureUUID, oid)
 		if s.verifyFailureDeletion(ctx, failureUUID, oid) {
 			return true
@@ -631,10 +639,37 @@ func (s *WeaviateFailureStore) ensureFailureRecordClass(ctx context.Context) err
 
 	// Try creating the class directly. If it already exists, treat as success.
 	// This avoids relying on Getter API variations across client versions.
-	// Build a minimal class definition matching runtime expectations.
-	classDef := &wm.Class{
-		Class:      "FailureRecord",
-		Vectorizer: "none",
+	classDef := failureRecordClassDef(s.replication)
+
+	// Attempt to create the class. Some client Do() implementations return only
+	// an error; handle both cases conservatively.
+	if err := s.client.Schema().ClassCreator().WithClass(classDef).Do(ctx); err != nil {
+		errMsg := err.Error()
+		if strings.Contains(errMsg, "already exists") || strings.Contains(errMsg, "class already exists") {
+			// Another process created it concurrently; treat as success.
+			if s.logger != nil {
+				s.logger.Sugar().Info("weavstore: FailureRecord class already exists in Weaviate")
+			}
+			return nil
+		}
+		if s.logger != nil {
+			s.logger.Sugar().Errorf("weavstore: failed to create FailureRecord class: %v", err)
+		}
+		return fmt.Errorf("failed to create FailureRecord class in Weaviate: %w", err)
+	}
+
+	if s.logger != nil {
+		s.logger.Sugar().Info("weavstore: successfully created FailureRecord class in Weaviate runtime schema")
+	}
+	return nil
+}
+
+// failureRecordClassDef is the expected definition of the FailureRecord class.
+func failureRecordClassDef(replication ReplicationPolicy) *wm.Class {
+	return &wm.Class{
+		Class:             "FailureRecord",
+		Vectorizer:        "none",
+		ReplicationConfig: replication.replicationConfig("FailureRecord"),
 		Properties: []*wm.Property{
 			{Name: "failureUuid", DataType: []string{"text"}},
 			{Name: "failureId", DataType: []string{"text"}},
@@ -673,28 +708,6 @@ func (s *WeaviateFailureStore) ensureFailureRecordClass(ctx context.Context) err
 			{Name: "updatedAt", DataType: []string{"date"}},
 		},
 	}
-
-	// Attempt to create the class. Some client Do() implementations return only
-	// an error; handle both cases conservatively.
-	if err := s.client.Schema().ClassCreator().WithClass(classDef).Do(ctx); err != nil {
-		errMsg := err.Error()
-		if strings.Contains(errMsg, "already exists") || strings.Contains(errMsg, "class already exists") {
-			// Another process created it concurrently; treat as success.
-			if s.logger != nil {
-				s.logger.Sugar().Info("weavstore: FailureRecord class already exists in Weaviate")
-			}
-			return nil
-		}
-		if s.logger != nil {
-			s.logger.Sugar().Errorf("weavstore: failed to create FailureRecord class: %v", err)
-		}
-		return fmt.Errorf("failed to create FailureRecord class in Weaviate: %w", err)
-	}
-
-	if s.logger != nil {
-		s.logger.Sugar().Info("weavstore: successfully created FailureRecord class in Weaviate runtime schema")
-	}
-	return nil
 }
 
 // ListFailures returns failure records with pagination
@@ -0,0 +1,215 @@
+package weavstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	wv "github.com/weaviate/weaviate-go-client/v5/weaviate"
+	"github.com/weaviate/weaviate-go-client/v5/weaviate/fault"
+	"github.com/weaviate/weaviate-go-client/v5/weaviate/filters"
+	wm "github.com/weaviate/weaviate/entities/models"
+	"go.uber.org/zap"
+)
+
+const kpiEvaluationClass = "KPIEvaluationSummary"
+
+// KPIEvaluationDayFormat is the layout of KPIEvaluationDay.Day (UTC).
+const KPIEvaluationDayFormat = "2006-01-02"
+
+var ErrKPIEvaluationDayInvalid = errors.New("kpi evaluation day needs a KPI id and a day")
+
+// KPIEvaluationPoint is one recorded evaluation of a KPI.
+type KPIEvaluationPoint struct {
+	Time  time.Time `json:"t"`
+	Value float64   `json:"v"`
+	State string    `json:"s"`
+}
+
+// KPIEvaluationDay holds the evaluations of one KPI on one UTC day. Points
+// are stored as a single JSON property to keep one object per KPI and day.
+type KPIEvaluationDay struct {
+	KPIID  string               `json:"kpiId"`
+	Day    string               `json:"day"`
+	Points []KPIEvaluationPoint `json:"points"`
+}
+
+// WeaviateKPIEvaluationStore stores KPI evaluation history in the
+// KPIEvaluationSummary class.
+type WeaviateKPIEvaluationStore struct {
+	client      *wv.Client
+	logger      *zap.Logger
+	schemaInit  sync.Once
+	schemaErr   error
+	replication ReplicationPolicy
+}
+
+// NewWeaviateKPIEvaluationStore constructs a new KPI evaluation store.
+func NewWeaviateKPIEvaluationStore(client *wv.Client, logger *zap.Logger) *WeaviateKPIEvaluationStore {
+	return &WeaviateKPIEvaluationStore{client: client, logger: logger}
+}
+
+// SetReplicationPolicy configures replication factor and write consistency.
+// Call before the first operation; the factor only applies at class creation.
+func (s *WeaviateKPIEvaluationStore) SetReplicationPolicy(p ReplicationPolicy) {
+	s.replication = p
+}
+
+func makeKPIEvaluationObjectID(kpiID, day string) string {
+	return uuid.NewV5(nsMirador, fmt.Sprintf("%s|%s|%s", kpiEvaluationClass, kpiID, day)).String()
+}
+
+// GetKPIEvaluationDay returns the evaluations of kpiID on day, or nil when
+// none were recorded.
+func (s *WeaviateKPIEvaluationStore) GetKPIEvaluationDay(ctx context.Context, kpiID, day string) (*KPIEvaluationDay, error) {
+	if kpiID == "" || day == "" {
+		return nil, ErrKPIEvaluationDayInvalid
+	}
+	if err := s.ensureSchema(ctx); err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Data().ObjectsGetter().WithClassName(kpiEvaluationClass).
+		WithID(makeKPIEvaluationObjectID(kpiID, day)).Do(ctx)
+	if err != nil {
+		var werr *fault.WeaviateClientError
+		if errors.As(err, &werr) && werr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get kpi evaluations: %w", err)
+	}
+	for _, o := range resp {
+		if o == nil {
+			continue
+		}
+		if props, ok := o.Properties.(map[string]any); ok {
+			return kpiEvaluationDayFromProps(props), nil
+		}
+	}
+	return nil, nil
+}
+
+// PutKPIEvaluationDay creates or replaces the evaluations of d.KPIID on d.Day.
+func (s *WeaviateKPIEvaluationStore) PutKPIEvaluationDay(ctx context.Context, d *KPIEvaluationDay) error {
+	if d == nil || d.KPIID == "" || d.Day == "" {
+		return ErrKPIEvaluationDayInvalid
+	}
+	if err := s.ensureSchema(ctx); err != nil {
+		return err
+	}
+	points, err := json.Marshal(d.Points)
+	if err != nil {
+		return err
+	}
+	props := map[string]any{
+		"kpiId":     d.KPIID,
+		"day":       d.Day,
+		"points":    string(points),
+		"updatedAt": time.Now().UTC().Format(time.RFC3339Nano),
+	}
+	objID := makeKPIEvaluationObjectID(d.KPIID, d.Day)
+	consistency := s.replication.Consistency(kpiEvaluationClass)
+	if _, err := s.client.Data().Creator().WithClassName(kpiEvaluationClass).WithConsistencyLevel(consistency).
+		WithID(objID).WithProperties(props).Do(ctx); err != nil {
+		if !strings.Contains(err.Error(), "already exists") {
+			return fmt.Errorf("failed to store kpi evaluations: %w", err)
+		}
+		if err := s.client.Data().Updater().WithClassName(kpiEvaluationClass).WithConsistencyLevel(consistency).
+			WithID(objID).WithProperties(props).Do(ctx); err != nil {
+			return fmt.Errorf("failed to update kpi evaluations: %w", err)
+		}
+	}
+	return nil
+}
+
+// PruneKPIEvaluations deletes the history of days before the given day and
+// returns how many day objects were removed. Weaviate selects the days with
+// a batch delete, repeated while a batch hits the server's per-request limit.
+func (s *WeaviateKPIEvaluationStore) PruneKPIEvaluations(ctx context.Context, before string) (int, error) {
+	if err := s.ensureSchema(ctx); err != nil {
+		return 0, err
+	}
+	where := filters.Where().WithPath([]string{"day"}).WithOperator(filters.LessThan).WithValueText(before)
+	deleted := 0
+	for {
+		resp, err := s.client.Batch().ObjectsBatchDeleter().WithClassName(kpiEvaluationClass).
+			WithConsistencyLevel(s.replication.Consistency(kpiEvaluationClass)).
+			WithOutput("minimal").WithWhere(where).Do(ctx)
+		if err != nil {
+			return deleted, fmt.Errorf("failed to delete kpi evaluations: %w", err)
+		}
+		if resp == nil || resp.Results == nil {
+			return deleted, nil
+		}
+		res := resp.Results
+		deleted += int(res.Successful)
+		if res.Failed > 0 {
+			return deleted, fmt.Errorf("failed to delete %d kpi evaluation days", res.Failed)
+		}
+		if res.Successful == 0 || res.Limit <= 0 || res.Matches < res.Limit {
+			return deleted, nil
+		}
+	}
+}
+
+func kpiEvaluationDayFromProps(props map[string]any) *KPIEvaluationDay {
+	d := &KPIEvaluationDay{Points: []KPIEvaluationPoint{}}
+	d.KPIID, _ = props["kpiId"].(string)
+	d.Day, _ = props["day"].(string)
+	if raw, ok := props["points"].(string); ok && raw != "" {
+		_ = json.Unmarshal([]byte(raw), &d.Points)
+	}
+	return d
+}
+
+func (s *WeaviateKPIEvaluationStore) ensureSchema(ctx context.Context) error {
+	s.schemaInit.Do(func() {
+		s.schemaErr = s.ensureKPIEvaluationClass(ctx)
+		if s.schemaErr != nil && s.logger != nil {
+			s.logger.Sugar().Warnf("weavstore: failed ensuring %s class: %v", kpiEvaluationClass, s.schemaErr)
+		}
+	})
+	return s.schemaErr
+}
+
+// ensureKPIEvaluationClass creates the KPIEvaluationSummary class if it does
+// not exist yet.
+func (s *WeaviateKPIEvaluationStore) ensureKPIEvaluationClass(ctx context.Context) error {
+	if s.client == nil {
+		return ErrWeaviateClientNil
+	}
+	classDef := kpiEvaluationClassDef(s.replication)
+	if err := s.client.Schema().ClassCreator().WithClass(classDef).Do(ctx); err != nil {
+		if strings.Contains(err.Error(), "already exists") {
+			return nil
+		}
+		return fmt.Errorf("failed to create %s class in Weaviate: %w", kpiEvaluationClass, err)
+	}
+	if s.logger != nil {
+		s.logger.Sugar().Infof("weavstore: created %s class in Weaviate runtime schema", kpiEvaluationClass)
+	}
+	return nil
+}
+
+// kpiEvaluationClassDef is the expected definition of the
+// KPIEvaluationSummary class.
+func kpiEvaluationClassDef(replication ReplicationPolicy) *wm.Class {
+	return &wm.Class{
+		Class:             kpiEvaluationClass,
+		Vectorizer:        "none",
+		ReplicationConfig: replication.replicationConfig(kpiEvaluationClass),
+		Properties: []*wm.Property{
+			{Name: "kpiId", DataType: []string{"text"}},
+			// Field tokenization keeps the day whole for the range filter
+			// of PruneKPIEvaluations.
+			{Name: "day", DataType: []string{"text"}, Tokenization: "field"},
+			{Name: "points", DataType: []string{"text"}},
+			{Name: "updatedAt", DataType: []string{"date"}},
+		},
+	}
+}
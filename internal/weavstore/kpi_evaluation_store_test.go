@@ -0,0 +1,71 @@
+package weavstore
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	wv "github.com/weaviate/weaviate-go-client/v5/weaviate"
+)
+
+func TestKPIEvaluationDayFromProps(t *testing.T) {
+	at := time.Date(2026, 3, 1, 10, 5, 0, 0, time.UTC)
+	d := kpiEvaluationDayFromProps(map[string]any{
+		"kpiId":  "errors",
+		"day":    "2026-03-01",
+		"points": `[{"t":"2026-03-01T10:05:00Z","v":12.5,"s":"warning"}]`,
+	})
+	assert.Equal(t, &KPIEvaluationDay{
+		KPIID:  "errors",
+		Day:    "2026-03-01",
+		Points: []KPIEvaluationPoint{{Time: at, Value: 12.5, State: "warning"}},
+	}, d)
+	assert.Empty(t, kpiEvaluationDayFromProps(map[string]any{"kpiId": "errors"}).Points)
+	assert.NotEqual(t, makeKPIEvaluationObjectID("errors", "2026-03-01"), makeKPIEvaluationObjectID("errors", "2026-03-02"))
+}
+
+func TestPruneKPIEvaluations_BatchDeletesOlderDays(t *testing.T) {
+	var bodies []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/v1/schema":
+			_, _ = w.Write([]byte(`{}`))
+		case r.URL.Path == "/v1/batch/objects" && r.Method == http.MethodDelete:
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			bodies = append(bodies, body)
+			// The first batch hits the limit, the second deletes the rest.
+			results := `{"matches":2,"limit":2,"successful":2}`
+			if len(bodies) > 1 {
+				results = `{"matches":1,"limit":2,"successful":1}`
+			}
+			_, _ = w.Write([]byte(`{"results":` + results + `}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	client, err := wv.NewClient(wv.Config{Scheme: "http", Host: strings.TrimPrefix(srv.URL, "http://")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := NewWeaviateKPIEvaluationStore(client, nil).PruneKPIEvaluations(context.Background(), "2026-03-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 || len(bodies) != 2 {
+		t.Fatalf("expected 3 days deleted in 2 batches, got %d in %d", n, len(bodies))
+	}
+	match, _ := bodies[0]["match"].(map[string]any)
+	where, _ := json.Marshal(match["where"])
+	for _, want := range []string{`"operator":"LessThan"`, `"path":["day"]`, `"valueText":"2026-03-01"`} {
+		assert.Contains(t, string(where), want)
+	}
+}
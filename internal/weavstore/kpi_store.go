@@ -30,6 +30,8 @@ type WeaviateKPIStore struct {
 	vectorizerProvider string
 	vectorizerModel    string
 	vectorizerUseGPU   bool
+	// replication controls class replication factor and write consistency
+	replication ReplicationPolicy
 }
 
 // KPIStore describes the subset of operations a KPI store must implement.
@@ -68,6 +70,12 @@ func NewWeaviateKPIStore(client *wv.Client, logger *zap.Logger, vectorizerProvid
 	return &WeaviateKPIStore{client: client, logger: logger, vectorizerProvider: vectorizerProvider, vectorizerModel: vectorizerModel, vectorizerUseGPU: vectorizerUseGPU}
 }
 
+// SetReplicationPolicy configures replication factor and write consistency.
+// Call before the first operation; the factor only applies at class creation.
+func (s *WeaviateKPIStore) SetReplicationPolicy(p ReplicationPolicy) {
+	s.replication = p
+}
+
 // helper: object id generation consistent with previous behavior
 var (
 	nsMirador = func() uuid.UUID {
@@ -212,7 +220,7 @@ func (s *WeaviateKPIStore) CreateOrUpdateKPI(ctx context.Context, k *KPIDefiniti
 		if foundClass != "" {
 			targetClass = foundClass
 		}
-		if err := s.client.Data().Updater().WithClassName(targetClass).WithID(targetID).WithProperties(props).Do(ctx); err != nil {
+		if err := s.client.Data().Updater().WithClassName(targetClass).WithConsistencyLevel(s.replication.Consistency(targetClass)).WithID(targetID).WithProperties(props).Do(ctx); err != nil {
 			return nil, "", err
 		}
 		return k, statusUpdated, nil
@@ -222,7 +230,7 @@ func (s *WeaviateKPIStore) CreateOrUpdateKPI(ctx context.Context, k *KPIDefiniti
 	// fall back to updating the existing object. This handles races where the
 	// object was created between the GetKPI call and the Creator() call and
 	// avoids returning a 422 'id already exists' to callers.
-	if _, err := s.client.Data().Creator().WithClassName(kpiClassNew).WithID(objID).WithProperties(props).Do(ctx); err != nil {
+	if _, err := s.client.Data().Creator().WithClassName(kpiClassNew).WithConsistencyLevel(s.replication.Consistency(kpiClassNew)).WithID(objID).WithProperties(props).Do(ctx); err != nil {
 		// Some Weaviate error responses include messages like "id '...' already exists"
 		// or mention "already exists"; handle those conservatively by attempting
 		// an update instead of failing the whole operation.
@@ -230,10 +238,10 @@ func (s *WeaviateKPIStore) CreateOrUpdateKPI(ctx context.Context, k *KPIDefiniti
 			// Try an update in the new class first, then fall back to the legacy
 			// class if that fails. Capture the last error to report if both
 			// update attempts fail.
-			if updateErr := s.client.Data().Updater().WithClassName(kpiClassNew).WithID(objID).WithProperties(props).Do(ctx); updateErr == nil {
+			if updateErr := s.client.Data().Updater().WithClassName(kpiClassNew).WithConsistencyLevel(s.replication.Consistency(kpiClassNew)).WithID(objID).WithProperties(props).Do(ctx); updateErr == nil {
 				return k, statusUpdated, nil
 			}
-			if updateErr := s.client.Data().Updater().WithClassName(kpiClassOld).WithID(objID).WithProperties(props).Do(ctx); updateErr == nil {
+			if updateErr := s.client.Data().Updater().WithClassName(kpiClassOld).WithConsistencyLevel(s.replication.Consistency(kpiClassOld)).WithID(objID).WithProperties(props).Do(ctx); updateErr == nil {
 				return k, statusUpdated, nil
 			}
 			// Both update attempts failed
@@ -374,7 +382,7 @@ func (s *WeaviateKPIStore) DeleteKPI(ctx context.Context, id string) error {
 func (s *WeaviateKPIStore) tryDeleteByObjectID(ctx context.Context, id, objID string) bool {
 	s.logf("weavstore: attempting delete for KPI id=%s (objID=%s)", id, objID)
 
-	if err := s.client.Data().Deleter().WithClassName(kpiClassNew).WithID(objID).Do(ctx); err == nil {
+	if err := s.client.Data().Deleter().WithClassName(kpiClassNew).WithConsistencyLevel(s.replication.Consistency(kpiClassNew)).WithID(objID).Do(ctx); err == nil {
 		s.logf("weavstore: deleted by v5 objID=%s", objID)
 		if s.verifyDeletion(ctx, id, objID) {
 			return true
@@ -393,7 +401,7 @@ func (s *WeaviateKPIStore) tryDeleteByRawID(ctx context.Context, id string) bool
 	}
 
 	// Try new class first for raw ids, then fall back to legacy class
-	if err2 := s.client.Data().Deleter().WithClassName(kpiClassNew).WithID(rawID).Do(ctx); err2 == nil {
+	if err2 := s.client.Data().Deleter().WithClassName(kpiClassNew).WithConsistencyLevel(s.replication.Consistency(kpiClassNew)).WithID(rawID).Do(ctx); err2 == nil {
 		s.logf("weavstore: deleted by raw id=%s (new class)", rawID)
 		if s.verifyDeletion(ctx, id, rawID) {
 			return true
@@ -401,7 +409,7 @@ func (s *WeaviateKPIStore) tryDeleteByRawID(ctx context.Context, id string) bool
 	} else {
 		s.logf("weavstore: delete by raw id (new class) failed: %v", err2)
 	}
-	if errOld := s.client.Data().Deleter().WithClassName(kpiClassOld).WithID(rawID).Do(ctx); errOld == nil {
+	if errOld := s.client.Data().Deleter().WithClassName(kpiClassOld).WithConsistencyLevel(s.replication.Consistency(kpiClassOld)).WithID(rawID).Do(ctx); errOld == nil {
 		s.logf("weavstore: deleted by raw id=%s (legacy class)", rawID)
 		if s.verifyDeletion(ctx, id, rawID) {
 			return true
@@ -453,7 +461,7 @@ func (s *WeaviateKPIStore) tryDeleteByScan(ctx context.Context, id, objID string
 // tryDeleteAndVerify attempts a delete and verifies it succeeded
 func (s *WeaviateKPIStore) tryDeleteAndVerify(ctx context.Context, id, oid string) bool {
 	// attempt deletion on new class then legacy class
-	if derr := s.client.Data().Deleter().WithClassName(kpiClassNew).WithID(oid).Do(ctx); derr == nil {
+	if derr := s.client.Data().Deleter().WithClassName(kpiClassNew).WithConsistencyLevel(s.replication.Consistency(kpiClassNew)).WithID(oid).Do(ctx); derr == nil {
 		s.logf("weavstore: deleted by scanning object id=%s (new class)", oid)
 		if s.verifyDeletion(ctx, id, oid) {
 			return true
@@ -461,7 +469,7 @@ func (s *WeaviateKPIStore) tryDeleteAndVerify(ctx context.Context, id, oid strin
 	} else {
 		s.logf("weavstore: scan-delete (new class) attempt for oid=%s failed: %v", oid, derr)
 	}
-	if derrOld := s.client.Data().Deleter().WithClassName(kpiClassOld).WithID(oid).Do(ctx); derrOld == nil {
+	if derrOld := s.client.Data().Deleter().WithClassName(kpiClassOld).WithConsistencyLevel(s.replication.Consistency(kpiClassOld)).WithID(oid).Do(ctx); derrOld == nil {
 		s.logf("weavstore: deleted by scanning object id=%s (legacy class)", oid)
 		if s.verifyDeletion(ctx, id, oid) {
 			return true
@@ -490,7 +498,7 @@ func (s *WeaviateKPIStore) tryDeleteByProperties(ctx context.Context, id string,
 
 	oid := o.ID.String()
 	// attempt delete in new class then legacy class
-	if derr := s.client.Data().Deleter().WithClassName(kpiClassNew).WithID(oid).Do(ctx); derr == nil {
+	if derr := s.client.Data().Deleter().WithClassName(kpiClassNew).WithConsistencyLevel(s.replication.Consistency(kpiClassNew)).WithID(oid).Do(ctx); derr == nil {
 		s.logf("weavstore: deleted by scanning props match id=%s -> oid=%s (new class)", id, oid)
 		if s.verifyDeletion(ctx, id, oid) {
 			return true
@@ -498,7 +506,7 @@ func (s *WeaviateKPIStore) tryDeleteByProperties(ctx context.Context, id string,
 	} else {
 		s.logf("weaviate: scan-delete (new class) by props for oid=%s failed: %v", oid, derr)
 	}
-	if derrOld := s.client.Data().Deleter().WithClassName(kpiClassOld).WithID(oid).Do(ctx); derrOld == nil {
+	if derrOld := s.client.Data().Deleter().WithClassName(kpiClassOld).WithConsistencyLevel(s.replication.Consistency(kpiClassOld)).WithID(oid).Do(ctx); derrOld == nil {
 		s.logf("weavstore: deleted by scanning props match id=%s -> oid=%s (legacy class)", id, oid)
 		if s.verifyDeletion(ctx, id, oid) {
 			return true
@@ -861,15 +869,34 @@ func (s *WeaviateKPIStore) ensureKPIDefinitionClass(ctx context.Context) error {
 
 	// Try creating the class directly. If it already exists, treat as success.
 	// This avoids relying on Getter API variations across client versions.
-	// Build a minimal class definition matching runtime expectations.
-	vec := s.vectorizerProvider
-	if vec == "" {
-		vec = "none"
+	classDef := kpiDefinitionClassDef(s.vectorizerProvider, s.replication)
+
+	// Attempt to create the class. Some client Do() implementations return only
+	// an error; handle both cases conservatively.
+	if err := s.client.Schema().ClassCreator().WithClass(classDef).Do(ctx); err != nil {
+		if strings.Contains(err.Error(), "already exists") || strings.Contains(err.Error(), "class already exists") {
+			// Another process created it concurrently; treat as success.
+			return nil
+		}
+		return fmt.Errorf("failed to create %s class in Weaviate: %w", kpiClassNew, err)
+	}
+
+	if s.logger != nil {
+		s.logger.Sugar().Info("weavstore: created ", kpiClassNew, " class in Weaviate runtime schema")
 	}
+	return nil
+}
 
-	classDef := &wm.Class{
-		Class:      kpiClassNew,
-		Vectorizer: vec,
+// kpiDefinitionClassDef is the expected definition of the KPI definition
+// class, vectorized with vectorizer ("none" when empty).
+func kpiDefinitionClassDef(vectorizer string, replication ReplicationPolicy) *wm.Class {
+	if vectorizer == "" {
+		vectorizer = "none"
+	}
+	return &wm.Class{
+		Class:             kpiClassNew,
+		Vectorizer:        vectorizer,
+		ReplicationConfig: replication.replicationConfig(kpiClassNew),
 		Properties: []*wm.Property{
 			{Name: "name", DataType: []string{"string"}},
 			{Name: "kind", DataType: []string{"string"}},
@@ -912,21 +939,6 @@ func (s *WeaviateKPIStore) ensureKPIDefinitionClass(ctx context.Context) error {
 			{Name: "updatedAt", DataType: []string{"date"}},
 		},
 	}
-
-	// Attempt to create the class. Some client Do() implementations return only
-	// an error; handle both cases conservatively.
-	if err := s.client.Schema().ClassCreator().WithClass(classDef).Do(ctx); err != nil {
-		if strings.Contains(err.Error(), "already exists") || strings.Contains(err.Error(), "class already exists") {
-			// Another process created it concurrently; treat as success.
-			return nil
-		}
-		return fmt.Errorf("failed to create %s class in Weaviate: %w", kpiClassNew, err)
-	}
-
-	if s.logger != nil {
-		s.logger.Sugar().Info("weavstore: created ", kpiClassNew, " class in Weaviate runtime schema")
-	}
-	return nil
 }
 
 // ListKPIs returns objects for a simple pagination/filters request.
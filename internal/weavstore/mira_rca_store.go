@@ -25,6 +25,8 @@ type WeaviateMIRARCAStore struct {
 	// schemaInit ensures we attempt to create the required class only once
 	schemaInit sync.Once
 	schemaErr  error
+	// replication controls class replication factor and write consistency
+	replication ReplicationPolicy
 }
 
 // MIRARCATaskStore is the minimal interface for MIRA RCA task storage used by handlers.
@@ -42,6 +44,12 @@ func NewWeaviateMIRARCAStore(client *wv.Client, logger *zap.Logger) *WeaviateMIR
 	return &WeaviateMIRARCAStore{client: client, logger: logger}
 }
 
+// SetReplicationPolicy configures replication factor and write consistency.
+// Call before the first operation; the factor only applies at class creation.
+func (s *WeaviateMIRARCAStore) SetReplicationPolicy(p ReplicationPolicy) {
+	s.replication = p
+}
+
 // Static errors for err113 compliance
 var (
 	ErrMIRARCATaskIsNil            = errors.New("mira rca task is nil")
@@ -135,12 +143,12 @@ func (s *WeaviateMIRARCAStore) CreateOrUpdateMIRARCATask(ctx context.Context, ta
 			return existing, statusNoChange, nil
 		}
 		// There is a modification: perform update
-		if err := s.client.Data().Updater().WithClassName("MIRARCATask").WithID(objID).WithProperties(props).Do(ctx); err != nil {
+		if err := s.client.Data().Updater().WithClassName("MIRARCATask").WithConsistencyLevel(s.replication.Consistency("MIRARCATask")).WithID(objID).WithProperties(props).Do(ctx); err != nil {
 			// Attempt to add missing 'name' property if update failed due to schema mismatch,
 			// then retry once. Best-effort: ignore errors when property creation is unsupported.
 			if perr := s.client.Schema().PropertyCreator().WithClassName("MIRARCATask").WithProperty(&wm.Property{Name: "name", DataType: []string{"string"}}).Do(ctx); perr == nil {
 				// retry update after ensuring property
-				if err2 := s.client.Data().Updater().WithClassName("MIRARCATask").WithID(objID).WithProperties(props).Do(ctx); err2 == nil {
+				if err2 := s.client.Data().Updater().WithClassName("MIRARCATask").WithConsistencyLevel(s.replication.Consistency("MIRARCATask")).WithID(objID).WithProperties(props).Do(ctx); err2 == nil {
 					return task, statusUpdated, nil
 				}
 			}
@@ -151,16 +159,16 @@ func (s *WeaviateMIRARCAStore) CreateOrUpdateMIRARCATask(ctx context.Context, ta
 
 	// Not found -> create. If create fails because the object already exists,
 	// fall back to updating the existing object.
-	if _, err := s.client.Data().Creator().WithClassName("MIRARCATask").WithID(objID).WithProperties(props).Do(ctx); err != nil {
+	if _, err := s.client.Data().Creator().WithClassName("MIRARCATask").WithConsistencyLevel(s.replication.Consistency("MIRARCATask")).WithID(objID).WithProperties(props).Do(ctx); err != nil {
 		if strings.Contains(err.Error(), "already exists") || strings.Contains(err.Error(), "id already exists") {
-			if err2 := s.client.Data().Updater().WithClassName("MIRARCATask").WithID(objID).WithProperties(props).Do(ctx); err2 != nil {
+			if err2 := s.client.Data().Updater().WithClassName("MIRARCATask").WithConsistencyLevel(s.replication.Consistency("MIRARCATask")).WithID(objID).WithProperties(props).Do(ctx); err2 != nil {
 				return nil, "", fmt.Errorf("create conflict: update also failed: %w (create err: %v)", err2, err)
 			}
 			return task, statusUpdated, nil
 		}
 		// Try to add missing 'name' property if create failed due to schema mismatch, then retry create
 		if perr := s.client.Schema().PropertyCreator().WithClassName("MIRARCATask").WithProperty(&wm.Property{Name: "name", DataType: []string{"string"}}).Do(ctx); perr == nil {
-			if _, err3 := s.client.Data().Creator().WithClassName("MIRARCATask").WithID(objID).WithProperties(props).Do(ctx); err3 == nil {
+			if _, err3 := s.client.Data().Creator().WithClassName("MIRARCATask").WithConsistencyLevel(s.replication.Consistency("MIRARCATask")).WithID(objID).WithProperties(props).Do(ctx); err3 == nil {
 				return task, statusCreated, nil
 			}
 		}
@@ -328,7 +336,7 @@ func (s *WeaviateMIRARCAStore) DeleteMIRARCATask(ctx context.Context, taskID str
 func (s *WeaviateMIRARCAStore) tryDeleteMIRARCAByObjectID(ctx context.Context, taskID, objID string) bool {
 	s.logf("weavstore: attempting delete for MIRA RCA task taskId=%s (objID=%s)", taskID, objID)
 
-	if err := s.client.Data().Deleter().WithClassName("MIRARCATask").WithID(objID).Do(ctx); err == nil {
+	if err := s.client.Data().Deleter().WithClassName("MIRARCATask").WithConsistencyLevel(s.replication.Consistency("MIRARCATask")).WithID(objID).Do(ctx); err == nil {
 		s.logf("weavstore: deleted MIRA RCA task by v5 objID=%s", objID)
 		if s.verifyMIRARCADeletion(ctx, taskID, objID) {
 			return true
@@ -341,7 +349,7 @@ func (s *WeaviateMIRARCAStore) tryDeleteMIRARCAByObjectID(ctx context.Context, t
 
 // tryDeleteMIRARCAByRawID attempts to delete using the raw ID (legacy objects)
 func (s *WeaviateMIRARCAStore) tryDeleteMIRARCAByRawID(ctx context.Context, taskID string) bool {
-	if err := s.client.Data().Deleter().WithClassName("MIRARCATask").WithID(taskID).Do(ctx); err == nil {
+	if err := s.client.Data().Deleter().WithClassName("MIRARCATask").WithConsistencyLevel(s.replication.Consistency("MIRARCATask")).WithID(taskID).Do(ctx); err == nil {
 		s.logf("weavstore: deleted MIRA RCA task by raw id=%s", taskID)
 		if s.verifyMIRARCADeletion(ctx, taskID, taskID) {
 			return true
@@ -383,7 +391,7 @@ func (s *WeaviateMIRARCAStore) tryDeleteMIRARCAByScan(ctx context.Context, taskI
 
 // tryDeleteMIRARCAAndVerify attempts a delete and verifies it succeeded
 func (s *WeaviateMIRARCAStore) tryDeleteMIRARCAAndVerify(ctx context.Context, taskID, oid string) bool {
-	if derr := s.client.Data().Deleter().WithClassName("MIRARCATask").WithID(oid).Do(ctx); derr == nil {
+	if derr := s.client.Data().Deleter().WithClassName("MIRARCATask").WithConsistencyLevel(s.replication.Consistency("MIRARCATask")).WithID(oid).Do(ctx); derr == nil {
 		s.logf("weavstore: deleted MIRA RCA task by scanning object id=%s", oid)
 		if s.verifyMIRARCADeletion(ctx, taskID, oid) {
 			return true
@@ -411,7 +419,7 @@ func (s *WeaviateMIRARCAStore) tryDeleteMIRARCAByProperties(ctx context.Context,
 	}
 
 	oid := o.ID.String()
-	if derr := s.client.Data().Deleter().WithClassName("MIRARCATask").WithID(oid).Do(ctx); derr == nil {
+	if derr := s.client.Data().Deleter().WithClassName("MIRARCATask").WithConsistencyLevel(s.replication.Consistency("MIRARCATask")).WithID(oid).Do(ctx); derr == nil {
 		s.logf("weavstore: deleted MIRA RCA task by scanning props match taskId=%s -> oid=%s", taskID, oid)
 		if s.verifyMIRARCADeletion(ctx, taskID, oid) {
 			return true
@@ -598,10 +606,29 @@ func (s *WeaviateMIRARCAStore) ensureMIRARCATaskClass(ctx context.Context) error
 		return ErrWeaviateClientNil
 	}
 
-	// Build a minimal class definition matching runtime expectations
-	classDef := &wm.Class{
-		Class:      "MIRARCATask",
-		Vectorizer: "none",
+	classDef := miraRCATaskClassDef(s.replication)
+
+	// Attempt to create the class
+	if err := s.client.Schema().ClassCreator().WithClass(classDef).Do(ctx); err != nil {
+		if strings.Contains(err.Error(), "already exists") || strings.Contains(err.Error(), "class already exists") {
+			// Another process created it concurrently; treat as success
+			return nil
+		}
+		return fmt.Errorf("failed to create MIRARCATask class in Weaviate: %w", err)
+	}
+
+	if s.logger != nil {
+		s.logger.Sugar().Info("weavstore: created MIRARCATask class in Weaviate runtime schema")
+	}
+	return nil
+}
+
+// miraRCATaskClassDef is the expected definition of the MIRARCATask class.
+func miraRCATaskClassDef(replication ReplicationPolicy) *wm.Class {
+	return &wm.Class{
+		Class:             "MIRARCATask",
+		Vectorizer:        "none",
+		ReplicationConfig: replication.replicationConfig("MIRARCATask"),
 		Properties: []*wm.Property{
 			{Name: "taskId", DataType: []string{"string"}},
 			{Name: "name", DataType: []string{"string"}},
@@ -617,20 +644,6 @@ func (s *WeaviateMIRARCAStore) ensureMIRARCATaskClass(ctx context.Context) error
 			{Name: "updatedAt", DataType: []string{"date"}},
 		},
 	}
-
-	// Attempt to create the class
-	if err := s.client.Schema().ClassCreator().WithClass(classDef).Do(ctx); err != nil {
-		if strings.Contains(err.Error(), "already exists") || strings.Contains(err.Error(), "class already exists") {
-			// Another process created it concurrently; treat as success
-			return nil
-		}
-		return fmt.Errorf("failed to create MIRARCATask class in Weaviate: %w", err)
-	}
-
-	if s.logger != nil {
-		s.logger.Sugar().Info("weavstore: created MIRARCATask class in Weaviate runtime schema")
-	}
-	return nil
 }
 
 // logf is a helper to log via zap if available
@@ -0,0 +1,93 @@
+package weavstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	wv "github.com/weaviate/weaviate-go-client/v5/weaviate"
+	"github.com/weaviate/weaviate-go-client/v5/weaviate/filters"
+	"github.com/weaviate/weaviate-go-client/v5/weaviate/graphql"
+)
+
+// noTenant is stored as the tenant of objects written without one: Weaviate
+// cannot filter on an empty string, and it is not a valid tenant ID.
+const noTenant = "~"
+
+func storedTenant(tenant string) string {
+	if tenant == "" {
+		return noTenant
+	}
+	return tenant
+}
+
+func loadedTenant(stored string) string {
+	if stored == noTenant {
+		return ""
+	}
+	return stored
+}
+
+// textEqual matches objects whose text property equals value. The filtered
+// properties use field tokenization, so this is an exact match.
+func textEqual(property, value string) *filters.WhereBuilder {
+	return filters.Where().WithPath([]string{property}).WithOperator(filters.Equal).WithValueText(value)
+}
+
+// allOf combines filters with And, skipping nil ones.
+func allOf(operands ...*filters.WhereBuilder) *filters.WhereBuilder {
+	var set []*filters.WhereBuilder
+	for _, o := range operands {
+		if o != nil {
+			set = append(set, o)
+		}
+	}
+	switch len(set) {
+	case 0:
+		return nil
+	case 1:
+		return set[0]
+	}
+	return filters.Where().WithOperator(filters.And).WithOperands(set)
+}
+
+// getObjects runs a GraphQL Get on class and returns the given properties of
+// the objects matching where, in sortBy order. limit <= 0 means
+// maxObjectsLimit.
+func getObjects(ctx context.Context, client *wv.Client, class string, properties []string, where *filters.WhereBuilder, sortBy []graphql.Sort, limit int) ([]map[string]any, error) {
+	if client == nil {
+		return nil, ErrWeaviateClientNil
+	}
+	if limit <= 0 || limit > maxObjectsLimit {
+		limit = maxObjectsLimit
+	}
+	fields := make([]graphql.Field, len(properties))
+	for i, p := range properties {
+		fields[i] = graphql.Field{Name: p}
+	}
+	get := client.GraphQL().Get().WithClassName(class).WithFields(fields...).WithLimit(limit)
+	if where != nil {
+		get = get.WithWhere(where)
+	}
+	if len(sortBy) > 0 {
+		get = get.WithSort(sortBy...)
+	}
+	resp, err := get.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Errors) > 0 && resp.Errors[0] != nil {
+		return nil, errors.New(resp.Errors[0].Message)
+	}
+	data, _ := resp.Data["Get"].(map[string]any)
+	rows, _ := data[class].([]any)
+	out := make([]map[string]any, 0, len(rows))
+	for _, row := range rows {
+		props, ok := row.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unexpected %s object %T", class, row)
+		}
+		out = append(out, props)
+	}
+	return out, nil
+}
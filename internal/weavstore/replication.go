@@ -0,0 +1,52 @@
+package weavstore
+
+import (
+	"strings"
+
+	wm "github.com/weaviate/weaviate/entities/models"
+)
+
+// ClassReplication holds the replication settings applied to one class.
+type ClassReplication struct {
+	// Factor is the replication factor used when the class is created.
+	// Zero leaves the cluster default in place.
+	Factor int
+	// Consistency is the level (ONE, QUORUM, ALL) required for writes and
+	// deletes on the class. Empty falls back to the policy default.
+	Consistency string
+}
+
+// ReplicationPolicy maps Weaviate classes to replication settings. The zero
+// value sends no consistency level and keeps the cluster defaults.
+type ReplicationPolicy struct {
+	DefaultConsistency string
+	// Classes is keyed by class name; lookups are case-insensitive.
+	Classes map[string]ClassReplication
+}
+
+func (p ReplicationPolicy) class(name string) (ClassReplication, bool) {
+	for k, v := range p.Classes {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+	return ClassReplication{}, false
+}
+
+// Consistency returns the consistency level to send for mutations on class.
+func (p ReplicationPolicy) Consistency(class string) string {
+	if c, ok := p.class(class); ok && c.Consistency != "" {
+		return strings.ToUpper(c.Consistency)
+	}
+	return strings.ToUpper(p.DefaultConsistency)
+}
+
+// replicationConfig returns the class-level replication config, or nil when
+// the class should use the cluster default.
+func (p ReplicationPolicy) replicationConfig(class string) *wm.ReplicationConfig {
+	c, ok := p.class(class)
+	if !ok || c.Factor <= 0 {
+		return nil
+	}
+	return &wm.ReplicationConfig{Factor: int64(c.Factor)}
+}
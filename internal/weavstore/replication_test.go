@@ -0,0 +1,34 @@
+package weavstore
+
+import "testing"
+
+func TestReplicationPolicy(t *testing.T) {
+	var zero ReplicationPolicy
+	if got := zero.Consistency(kpiClassNew); got != "" {
+		t.Fatalf("zero policy should send no consistency level, got %q", got)
+	}
+	if rc := zero.replicationConfig(kpiClassNew); rc != nil {
+		t.Fatalf("zero policy should keep cluster default, got %+v", rc)
+	}
+
+	// Viper lowercases map keys, so lookups must ignore case.
+	p := ReplicationPolicy{
+		DefaultConsistency: "quorum",
+		Classes: map[string]ClassReplication{
+			"kpi_definition": {Factor: 3, Consistency: "one"},
+			"miraracatask":   {Factor: 0},
+		},
+	}
+	if got := p.Consistency(kpiClassNew); got != "ONE" {
+		t.Fatalf("expected class override ONE, got %q", got)
+	}
+	if got := p.Consistency("MIRARCATask"); got != "QUORUM" {
+		t.Fatalf("expected default QUORUM, got %q", got)
+	}
+	if rc := p.replicationConfig(kpiClassNew); rc == nil || rc.Factor != 3 {
+		t.Fatalf("expected factor 3, got %+v", rc)
+	}
+	if rc := p.replicationConfig("MIRARCATask"); rc != nil {
+		t.Fatalf("factor 0 should keep cluster default, got %+v", rc)
+	}
+}
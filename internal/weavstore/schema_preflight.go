@@ -0,0 +1,223 @@
+package weavstore
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	wv "github.com/weaviate/weaviate-go-client/v5/weaviate"
+	wm "github.com/weaviate/weaviate/entities/models"
+	"go.uber.org/zap"
+)
+
+// Drift kinds reported by CompareSchema.
+const (
+	DriftMissingClass       = "missing_class"
+	DriftMissingProperty    = "missing_property"
+	DriftTypeMismatch       = "type_mismatch"
+	DriftVectorizerMismatch = "vectorizer_mismatch"
+	DriftReplicationFactor  = "replication_factor_mismatch"
+)
+
+// Drift severities. Critical drift breaks reads or writes against the class;
+// warnings are either repaired on first use (missing classes) or degrade a
+// feature without failing requests.
+const (
+	DriftWarning  = "warning"
+	DriftCritical = "critical"
+)
+
+// SchemaDrift is one difference between the expected and live schema.
+type SchemaDrift struct {
+	Class    string `json:"class"`
+	Property string `json:"property,omitempty"`
+	Kind     string `json:"kind"`
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+	Severity string `json:"severity"`
+}
+
+// SchemaReport is the outcome of one preflight run.
+type SchemaReport struct {
+	CheckedAt time.Time     `json:"checked_at"`
+	Classes   []string      `json:"classes"`
+	Drift     []SchemaDrift `json:"drift"`
+	Critical  int           `json:"critical"`
+	Warnings  int           `json:"warnings"`
+	// Error is set when the live schema could not be read; Drift is then empty.
+	Error string `json:"error,omitempty"`
+}
+
+// ExpectedClasses returns the class definitions the weavstore stores create,
+// ordered by class name.
+func ExpectedClasses(vectorizer string, replication ReplicationPolicy) []*wm.Class {
+	classes := []*wm.Class{
+		correlationCommentClassDef(replication),
+		correlationHistoryClassDef(replication),
+		failureRecordClassDef(replication),
+		kpiEvaluationClassDef(replication),
+		kpiDefinitionClassDef(vectorizer, replication),
+		miraRCATaskClassDef(replication),
+	}
+	sort.Slice(classes, func(i, j int) bool { return classes[i].Class < classes[j].Class })
+	return classes
+}
+
+// CompareSchema reports how actual differs from expected. Classes and
+// properties present only in actual are ignored: extra fields do not affect
+// the stores. Class and property names are compared case-insensitively, as
+// Weaviate does.
+func CompareSchema(expected, actual []*wm.Class) []SchemaDrift {
+	live := make(map[string]*wm.Class, len(actual))
+	for _, c := range actual {
+		if c != nil {
+			live[strings.ToLower(c.Class)] = c
+		}
+	}
+
+	drift := []SchemaDrift{}
+	for _, want := range expected {
+		got, ok := live[strings.ToLower(want.Class)]
+		if !ok {
+			drift = append(drift, SchemaDrift{Class: want.Class, Kind: DriftMissingClass, Severity: DriftWarning})
+			continue
+		}
+		if want.Vectorizer != "" && got.Vectorizer != "" && !strings.EqualFold(want.Vectorizer, got.Vectorizer) {
+			drift = append(drift, SchemaDrift{
+				Class: want.Class, Kind: DriftVectorizerMismatch,
+				Expected: want.Vectorizer, Actual: got.Vectorizer, Severity: DriftWarning,
+			})
+		}
+		if want.ReplicationConfig != nil && got.ReplicationConfig != nil && want.ReplicationConfig.Factor != got.ReplicationConfig.Factor {
+			drift = append(drift, SchemaDrift{
+				Class: want.Class, Kind: DriftReplicationFactor,
+				Expected: fmt.Sprint(want.ReplicationConfig.Factor), Actual: fmt.Sprint(got.ReplicationConfig.Factor),
+				Severity: DriftWarning,
+			})
+		}
+
+		props := make(map[string]*wm.Property, len(got.Properties))
+		for _, p := range got.Properties {
+			if p != nil {
+				props[strings.ToLower(p.Name)] = p
+			}
+		}
+		for _, p := range want.Properties {
+			gp, ok := props[strings.ToLower(p.Name)]
+			if !ok {
+				drift = append(drift, SchemaDrift{
+					Class: want.Class, Property: p.Name, Kind: DriftMissingProperty,
+					Expected: strings.Join(p.DataType, ","), Severity: DriftWarning,
+				})
+				continue
+			}
+			if !sameDataType(p.DataType, gp.DataType) {
+				drift = append(drift, SchemaDrift{
+					Class: want.Class, Property: p.Name, Kind: DriftTypeMismatch,
+					Expected: strings.Join(p.DataType, ","), Actual: strings.Join(gp.DataType, ","),
+					Severity: DriftCritical,
+				})
+			}
+		}
+	}
+	return drift
+}
+
+// sameDataType compares property data types, treating the deprecated
+// string types as the text types Weaviate migrates them to.
+func sameDataType(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if normalizeDataType(a[i]) != normalizeDataType(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func normalizeDataType(t string) string {
+	switch t {
+	case "string":
+		return "text"
+	case "string[]":
+		return "text[]"
+	}
+	return t
+}
+
+// SchemaPreflight compares the live Weaviate schema with the classes the
+// stores expect and keeps the latest report.
+type SchemaPreflight struct {
+	client   *wv.Client
+	expected []*wm.Class
+	logger   *zap.Logger
+
+	mu   sync.RWMutex
+	last *SchemaReport
+}
+
+// NewSchemaPreflight creates a preflight checking expected against the
+// schema served by client.
+func NewSchemaPreflight(client *wv.Client, expected []*wm.Class, logger *zap.Logger) *SchemaPreflight {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &SchemaPreflight{client: client, expected: expected, logger: logger}
+}
+
+// Run reads the live schema, logs each drift entry and stores the report.
+func (p *SchemaPreflight) Run(ctx context.Context) *SchemaReport {
+	report := &SchemaReport{CheckedAt: time.Now().UTC(), Drift: []SchemaDrift{}}
+	for _, c := range p.expected {
+		report.Classes = append(report.Classes, c.Class)
+	}
+
+	dump, err := p.client.Schema().Getter().Do(ctx)
+	if err != nil {
+		report.Error = err.Error()
+		p.logger.Warn("weavstore: schema preflight could not read live schema", zap.Error(err))
+	} else {
+		report.Drift = CompareSchema(p.expected, dump.Classes)
+		for _, d := range report.Drift {
+			if d.Severity == DriftCritical {
+				report.Critical++
+			} else {
+				report.Warnings++
+			}
+			fields := []zap.Field{
+				zap.String("class", d.Class),
+				zap.String("property", d.Property),
+				zap.String("kind", d.Kind),
+				zap.String("expected", d.Expected),
+				zap.String("actual", d.Actual),
+				zap.String("severity", d.Severity),
+			}
+			if d.Severity == DriftCritical {
+				p.logger.Error("weavstore: schema drift", fields...)
+			} else {
+				p.logger.Warn("weavstore: schema drift", fields...)
+			}
+		}
+		p.logger.Info("weavstore: schema preflight complete",
+			zap.Int("classes", len(report.Classes)),
+			zap.Int("critical", report.Critical),
+			zap.Int("warnings", report.Warnings))
+	}
+
+	p.mu.Lock()
+	p.last = report
+	p.mu.Unlock()
+	return report
+}
+
+// Last returns the most recent report, or nil before the first run.
+func (p *SchemaPreflight) Last() *SchemaReport {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.last
+}
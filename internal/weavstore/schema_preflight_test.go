@@ -0,0 +1,120 @@
+package weavstore
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	wv "github.com/weaviate/weaviate-go-client/v5/weaviate"
+	wm "github.com/weaviate/weaviate/entities/models"
+)
+
+func TestCompareSchema(t *testing.T) {
+	expected := ExpectedClasses("", ReplicationPolicy{
+		Classes: map[string]ClassReplication{"failurerecord": {Factor: 3}},
+	})
+	if len(expected) != 6 {
+		t.Fatalf("expected 6 classes, got %d", len(expected))
+	}
+
+	// A live schema matching expected reports nothing.
+	if d := CompareSchema(expected, expected); len(d) != 0 {
+		t.Fatalf("expected no drift, got %+v", d)
+	}
+
+	// Legacy string types are equivalent to text.
+	kpi := kpiDefinitionClassDef("none", ReplicationPolicy{})
+	legacy := *kpi
+	legacy.Class = strings.ToLower(kpi.Class)
+	legacy.Properties = nil
+	for _, p := range kpi.Properties {
+		cp := *p
+		switch p.DataType[0] {
+		case "text":
+			cp.DataType = []string{"string"}
+		case "text[]":
+			cp.DataType = []string{"string[]"}
+		}
+		legacy.Properties = append(legacy.Properties, &cp)
+	}
+	if d := CompareSchema([]*wm.Class{kpi}, []*wm.Class{&legacy}); len(d) != 0 {
+		t.Fatalf("expected string/text to match, got %+v", d)
+	}
+
+	failure := *failureRecordClassDef(ReplicationPolicy{})
+	failure.ReplicationConfig = &wm.ReplicationConfig{Factor: 1}
+	failure.Vectorizer = "text2vec-transformers"
+	failure.Properties = nil
+	for _, p := range failureRecordClassDef(ReplicationPolicy{}).Properties {
+		switch p.Name {
+		case "endTime":
+			continue
+		case "confidenceScore":
+			failure.Properties = append(failure.Properties, &wm.Property{Name: p.Name, DataType: []string{"text"}})
+		default:
+			failure.Properties = append(failure.Properties, p)
+		}
+	}
+	failure.Properties = append(failure.Properties, &wm.Property{Name: "extra", DataType: []string{"int"}})
+
+	drift := CompareSchema(expected, []*wm.Class{&failure})
+	kinds := map[string]SchemaDrift{}
+	for _, d := range drift {
+		kinds[d.Class+"/"+d.Property+"/"+d.Kind] = d
+	}
+	for key, severity := range map[string]string{
+		"CorrelationComment//missing_class":           DriftWarning,
+		"CorrelationHistory//missing_class":           DriftWarning,
+		"KPIEvaluationSummary//missing_class":         DriftWarning,
+		"Kpi_definition//missing_class":               DriftWarning,
+		"MIRARCATask//missing_class":                  DriftWarning,
+		"FailureRecord//vectorizer_mismatch":          DriftWarning,
+		"FailureRecord//replication_factor_mismatch":  DriftWarning,
+		"FailureRecord/endTime/missing_property":      DriftWarning,
+		"FailureRecord/confidenceScore/type_mismatch": DriftCritical,
+	} {
+		d, ok := kinds[key]
+		if !ok {
+			t.Fatalf("missing drift %s in %+v", key, drift)
+		}
+		if d.Severity != severity {
+			t.Fatalf("%s: expected severity %s, got %s", key, severity, d.Severity)
+		}
+	}
+	if len(drift) != 9 {
+		t.Fatalf("expected 9 drift entries, got %d: %+v", len(drift), drift)
+	}
+}
+
+func TestSchemaPreflight_Run(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/schema" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"classes":[{"class":"MIRARCATask","vectorizer":"none","properties":[{"name":"taskId","dataType":["int"]}]}]}`))
+	}))
+	defer srv.Close()
+
+	client, err := wv.NewClient(wv.Config{Scheme: "http", Host: strings.TrimPrefix(srv.URL, "http://")})
+	if err != nil {
+		t.Fatalf("client: %v", err)
+	}
+	p := NewSchemaPreflight(client, []*wm.Class{miraRCATaskClassDef(ReplicationPolicy{})}, nil)
+	if p.Last() != nil {
+		t.Fatalf("expected no report before the first run")
+	}
+	report := p.Run(context.Background())
+	if report.Error != "" {
+		t.Fatalf("unexpected error: %s", report.Error)
+	}
+	if report.Critical != 1 || report.Warnings == 0 {
+		t.Fatalf("expected one critical mismatch and missing properties, got %+v", report)
+	}
+	if p.Last() != report {
+		t.Fatalf("expected Last to return the latest report")
+	}
+}
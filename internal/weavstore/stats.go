@@ -0,0 +1,43 @@
+package weavstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	wv "github.com/weaviate/weaviate-go-client/v5/weaviate"
+	"github.com/weaviate/weaviate-go-client/v5/weaviate/graphql"
+)
+
+// CountObjects returns the number of objects stored in class with a single
+// Aggregate query, without listing them.
+func CountObjects(ctx context.Context, client *wv.Client, class string) (int64, error) {
+	resp, err := client.GraphQL().Aggregate().
+		WithClassName(class).
+		WithFields(graphql.Field{Name: "meta", Fields: []graphql.Field{{Name: "count"}}}).
+		Do(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if len(resp.Errors) > 0 && resp.Errors[0] != nil {
+		return 0, errors.New(resp.Errors[0].Message)
+	}
+	data, err := json.Marshal(resp.Data["Aggregate"])
+	if err != nil {
+		return 0, err
+	}
+	var agg map[string][]struct {
+		Meta struct {
+			Count int64 `json:"count"`
+		} `json:"meta"`
+	}
+	if err := json.Unmarshal(data, &agg); err != nil {
+		return 0, fmt.Errorf("decode aggregate: %w", err)
+	}
+	groups := agg[class]
+	if len(groups) == 0 {
+		return 0, nil
+	}
+	return groups[0].Meta.Count, nil
+}
@@ -0,0 +1,37 @@
+package weavstore
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	wv "github.com/weaviate/weaviate-go-client/v5/weaviate"
+)
+
+func TestCountObjects(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.URL.Path != "/v1/graphql" || !strings.Contains(string(body), "FailureRecord") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"Aggregate":{"FailureRecord":[{"meta":{"count":42}}]}}}`))
+	}))
+	defer srv.Close()
+
+	client, err := wv.NewClient(wv.Config{Scheme: "http", Host: strings.TrimPrefix(srv.URL, "http://")})
+	if err != nil {
+		t.Fatalf("client: %v", err)
+	}
+	n, err := CountObjects(context.Background(), client, "FailureRecord")
+	if err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	if n != 42 {
+		t.Fatalf("expected 42 objects, got %d", n)
+	}
+}
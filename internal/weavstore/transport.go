@@ -0,0 +1,210 @@
+package weavstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/metrics"
+	"github.com/mirastacklabs-ai/mirador-core/pkg/resilience"
+)
+
+// Circuit breaker states reported by Transport.State.
+const (
+	CircuitClosed   = string(resilience.StateClosed)
+	CircuitHalfOpen = string(resilience.StateHalfOpen)
+	CircuitOpen     = string(resilience.StateOpen)
+)
+
+// ErrCircuitOpen is returned without contacting Weaviate while the circuit
+// breaker is open.
+var ErrCircuitOpen = resilience.ErrCircuitOpen
+
+// TransportOptions configures connection pooling, retries and the circuit
+// breaker of the Weaviate HTTP transport.
+type TransportOptions struct {
+	MaxIdleConnsPerHost int
+	MaxConnsPerHost     int // 0 = unlimited
+	IdleConnTimeout     time.Duration
+
+	// MaxAttempts bounds the tries of one idempotent request; 1 or less
+	// disables retries. Waits grow from InitialBackoff to MaxBackoff.
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+
+	// BreakerFailures consecutive failures open the breaker for
+	// BreakerCooldown; 0 disables it.
+	BreakerFailures int
+	BreakerCooldown time.Duration
+}
+
+// NewPooledTransport returns an HTTP transport keeping up to
+// MaxIdleConnsPerHost connections per Weaviate node open for reuse. The
+// default transport keeps only two, so concurrent requests keep opening new
+// connections.
+func NewPooledTransport(opts TransportOptions) *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	if opts.MaxIdleConnsPerHost > 0 {
+		t.MaxIdleConnsPerHost = opts.MaxIdleConnsPerHost
+		t.MaxIdleConns = max(t.MaxIdleConns, opts.MaxIdleConnsPerHost)
+	}
+	t.MaxConnsPerHost = opts.MaxConnsPerHost
+	if opts.IdleConnTimeout > 0 {
+		t.IdleConnTimeout = opts.IdleConnTimeout
+	}
+	return t
+}
+
+// Transport retries idempotent Weaviate requests that fail transiently
+// (connection errors, timeouts, 502/503/504) with jittered exponential
+// backoff, and stops calling Weaviate for a cooldown after consecutive
+// failures; see resilience.CircuitBreaker.
+//
+// GraphQL queries are POSTs but read-only, so they are retried too; other
+// POSTs and PATCHes are sent once.
+type Transport struct {
+	base    http.RoundTripper
+	retry   resilience.Retry
+	breaker *resilience.CircuitBreaker
+	logger  *zap.Logger
+}
+
+// NewTransport wraps base (http.DefaultTransport when nil).
+func NewTransport(base http.RoundTripper, opts TransportOptions, logger *zap.Logger) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	if opts.InitialBackoff <= 0 {
+		opts.InitialBackoff = 100 * time.Millisecond
+	}
+	metrics.WeaviateCircuitState.Set(0)
+	return &Transport{
+		base: base,
+		retry: resilience.Retry{
+			MaxAttempts: opts.MaxAttempts,
+			Backoff:     resilience.Backoff{Initial: opts.InitialBackoff, Max: opts.MaxBackoff, Jitter: true},
+		},
+		breaker: resilience.NewCircuitBreaker(resilience.BreakerOptions{
+			Failures:      opts.BreakerFailures,
+			Cooldown:      opts.BreakerCooldown,
+			OnStateChange: breakerStateLogger(logger, opts),
+			OnReject:      metrics.WeaviateCircuitRejectionsTotal.Inc,
+		}),
+		logger: logger,
+	}
+}
+
+// State returns the circuit breaker state. An open breaker whose cooldown
+// has passed reports half_open: the next request is a probe.
+func (t *Transport) State() string {
+	return string(t.breaker.State())
+}
+
+// statusError is an attempt Weaviate answered with a transient status. The
+// response is returned as is when no attempt is left.
+type statusError struct{ code int }
+
+func (e *statusError) Error() string { return "weaviate responded " + strconv.Itoa(e.code) }
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	retry := t.retry
+	if !retryableRequest(req) {
+		retry.MaxAttempts = 1
+	}
+	var (
+		resp    *http.Response
+		attempt int
+	)
+	retry.OnRetry = func(n int, err error, wait time.Duration) {
+		reason := "transport"
+		var se *statusError
+		if errors.As(err, &se) {
+			reason = strconv.Itoa(se.code)
+			err = nil
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+			_ = resp.Body.Close()
+			resp = nil
+		}
+		metrics.WeaviateRetriesTotal.WithLabelValues(reason).Inc()
+		t.logger.Warn("Weaviate request failed, retrying",
+			zap.String("method", req.Method), zap.String("path", req.URL.Path),
+			zap.String("reason", reason), zap.Error(err),
+			zap.Int("attempt", n), zap.Duration("backoff", wait))
+	}
+
+	err := retry.Execute(req.Context(), func(ctx context.Context) error {
+		attempt++
+		out := req
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return resilience.Permanent(err)
+			}
+			out = req.Clone(ctx)
+			out.Body = body
+		}
+		return t.breaker.Execute(ctx, func(context.Context) error {
+			r, err := t.base.RoundTrip(out)
+			if err != nil {
+				return err
+			}
+			resp = r
+			switch r.StatusCode {
+			case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+				return &statusError{code: r.StatusCode}
+			}
+			return nil
+		})
+	})
+	var se *statusError
+	if err != nil && !errors.As(err, &se) {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// retryableRequest reports whether req may safely be sent again: its method
+// is idempotent (or it is a GraphQL query) and its body can be replayed.
+func retryableRequest(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		return strings.HasSuffix(req.URL.Path, "/v1/graphql")
+	}
+	return false
+}
+
+// breakerStateLogger reports circuit breaker state changes in the
+// mirador_core_weaviate_circuit_state gauge and the log.
+func breakerStateLogger(logger *zap.Logger, opts TransportOptions) func(from, to resilience.State) {
+	return func(_, to resilience.State) {
+		switch to {
+		case resilience.StateClosed:
+			metrics.WeaviateCircuitState.Set(0)
+			logger.Info("Weaviate circuit breaker closed")
+		case resilience.StateHalfOpen:
+			metrics.WeaviateCircuitState.Set(1)
+			logger.Info("Weaviate circuit breaker half-open, probing")
+		case resilience.StateOpen:
+			metrics.WeaviateCircuitState.Set(2)
+			logger.Warn("Weaviate circuit breaker opened",
+				zap.Int("consecutive_failures", opts.BreakerFailures), zap.Duration("cooldown", opts.BreakerCooldown))
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package weavstore
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTransport_RetriesIdempotentRequests(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write(body)
+	}))
+	defer srv.Close()
+
+	tr := NewTransport(NewPooledTransport(TransportOptions{MaxIdleConnsPerHost: 4}), TransportOptions{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     2 * time.Millisecond,
+	}, nil)
+	client := &http.Client{Transport: tr}
+
+	resp, err := client.Post(srv.URL+"/v1/graphql", "application/json", strings.NewReader(`{"query":"{Get{KPI{name}}}"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || string(body) != `{"query":"{Get{KPI{name}}}"}` {
+		t.Fatalf("got %d %q, want the replayed body after two retries", resp.StatusCode, body)
+	}
+	if calls.Load() != 3 {
+		t.Fatalf("calls = %d, want 3", calls.Load())
+	}
+
+	// Object creation is not idempotent and is sent once.
+	calls.Store(0)
+	resp, err = client.Post(srv.URL+"/v1/objects", "application/json", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadGateway || calls.Load() != 1 {
+		t.Fatalf("got %d after %d calls, want one 502", resp.StatusCode, calls.Load())
+	}
+}
+
+func TestTransport_CircuitBreaker(t *testing.T) {
+	var failing atomic.Bool
+	var calls atomic.Int32
+	failing.Store(true)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	tr := NewTransport(nil, TransportOptions{
+		MaxAttempts:     1,
+		BreakerFailures: 2,
+		BreakerCooldown: 50 * time.Millisecond,
+	}, nil)
+	client := &http.Client{Transport: tr}
+	get := func() error {
+		resp, err := client.Get(srv.URL + "/v1/schema")
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := get(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if tr.State() != CircuitOpen {
+		t.Fatalf("state = %s after 2 failures, want open", tr.State())
+	}
+	if err := get(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("err = %v, want ErrCircuitOpen", err)
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("calls = %d, the open breaker must not reach Weaviate", calls.Load())
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if tr.State() != CircuitHalfOpen {
+		t.Fatalf("state = %s after cooldown, want half_open", tr.State())
+	}
+	failing.Store(false)
+	if err := get(); err != nil {
+		t.Fatal(err)
+	}
+	if tr.State() != CircuitClosed {
+		t.Fatalf("state = %s after a successful probe, want closed", tr.State())
+	}
+}
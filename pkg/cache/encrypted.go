@@ -0,0 +1,182 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// encryptedMagic starts every encrypted value, followed by the key version
+// and a colon: "mcenc:<version>:<nonce><ciphertext>".
+const encryptedMagic = "mcenc:"
+
+// encryptedCache encrypts the values of sensitive keys with AES-GCM before
+// they reach Valkey. Each tenant's data key is derived from the key
+// encryption key (KEK) with HKDF, so values are useless without the KEK and
+// one tenant's key cannot decrypt another's. The full key is authenticated
+// with the value, so ciphertexts cannot be moved between keys.
+type encryptedCache struct {
+	ValkeyCluster
+	keks     map[int][]byte
+	active   int
+	prefixes []string
+
+	mu   sync.Mutex
+	aead map[string]cipher.AEAD // by "<version>:<tenant>"
+}
+
+// NewEncrypted wraps inner so values of keys under one of prefixes are
+// encrypted. keks holds 32-byte key encryption keys by version; new values
+// use active and the others still decrypt, so keys can be rotated. Prefixes
+// are matched below the tenant namespace; the tenant is taken from the key.
+// Plaintext values written before encryption was enabled are returned
+// unchanged and encrypted on their next write.
+//
+// Wrap beneath NewTenantNamespaced so the tenant is visible in the key.
+func NewEncrypted(inner ValkeyCluster, keks map[int][]byte, active int, prefixes []string) (ValkeyCluster, error) {
+	if _, ok := keks[active]; !ok {
+		return nil, fmt.Errorf("active key version %d not configured", active)
+	}
+	for v, k := range keks {
+		if len(k) != 32 {
+			return nil, fmt.Errorf("key version %d: must be 32 bytes, got %d", v, len(k))
+		}
+	}
+	return &encryptedCache{
+		ValkeyCluster: inner,
+		keks:          keks,
+		active:        active,
+		prefixes:      prefixes,
+		aead:          map[string]cipher.AEAD{},
+	}, nil
+}
+
+// sensitive reports whether key's value is encrypted, and for which tenant.
+func (e *encryptedCache) sensitive(key string) (string, bool) {
+	tenant, _ := TenantFromKey(key)
+	rest := key
+	if tenant != "" {
+		rest = strings.TrimPrefix(key, TenantPrefix(tenant))
+	}
+	for _, p := range e.prefixes {
+		if strings.HasPrefix(rest, p) {
+			return tenant, true
+		}
+	}
+	return tenant, false
+}
+
+func (e *encryptedCache) cipherFor(version int, tenant string) (cipher.AEAD, error) {
+	id := strconv.Itoa(version) + ":" + tenant
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if a, ok := e.aead[id]; ok {
+		return a, nil
+	}
+	kek, ok := e.keks[version]
+	if !ok {
+		return nil, fmt.Errorf("unknown cache encryption key version %d", version)
+	}
+	dek, err := hkdf.Key(sha256.New, kek, nil, "mirador-core cache tenant:"+tenant, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(dek)
+	if err != nil {
+		return nil, err
+	}
+	a, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	e.aead[id] = a
+	return a, nil
+}
+
+func (e *encryptedCache) Get(ctx context.Context, key string) ([]byte, error) {
+	b, err := e.ValkeyCluster.Get(ctx, key)
+	if err != nil || !bytes.HasPrefix(b, []byte(encryptedMagic)) {
+		return b, err
+	}
+	// Decrypt regardless of the configured prefixes, so shrinking the list
+	// does not expose ciphertext to callers.
+	tenant, _ := TenantFromKey(key)
+	rest := b[len(encryptedMagic):]
+	sep := bytes.IndexByte(rest, ':')
+	if sep <= 0 {
+		return nil, fmt.Errorf("decrypt %s: malformed value", key)
+	}
+	version, err := strconv.Atoi(string(rest[:sep]))
+	if err != nil {
+		return nil, fmt.Errorf("decrypt %s: malformed key version", key)
+	}
+	a, err := e.cipherFor(version, tenant)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt %s: %w", key, err)
+	}
+	sealed := rest[sep+1:]
+	if len(sealed) < a.NonceSize() {
+		return nil, fmt.Errorf("decrypt %s: malformed value", key)
+	}
+	plain, err := a.Open(nil, sealed[:a.NonceSize()], sealed[a.NonceSize():], []byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("decrypt %s: %w", key, err)
+	}
+	return plain, nil
+}
+
+func (e *encryptedCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	tenant, ok := e.sensitive(key)
+	if !ok {
+		return e.ValkeyCluster.Set(ctx, key, value, ttl)
+	}
+	var plain []byte
+	switch x := value.(type) {
+	case []byte:
+		plain = x
+	case string:
+		plain = []byte(x)
+	default:
+		j, err := json.Marshal(x)
+		if err != nil {
+			return fmt.Errorf("marshal value for key %s: %w", key, err)
+		}
+		plain = j
+	}
+	a, err := e.cipherFor(e.active, tenant)
+	if err != nil {
+		return err
+	}
+	out := []byte(encryptedMagic + strconv.Itoa(e.active) + ":")
+	nonce := make([]byte, a.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	out = append(out, nonce...)
+	out = a.Seal(out, nonce, plain, []byte(key))
+	return e.ValkeyCluster.Set(ctx, key, out, ttl)
+}
+
+// HealthCheck and Stop are forwarded when the wrapped cache supports them.
+func (e *encryptedCache) HealthCheck(ctx context.Context) error {
+	if hc, ok := e.ValkeyCluster.(interface{ HealthCheck(context.Context) error }); ok {
+		return hc.HealthCheck(ctx)
+	}
+	return nil
+}
+
+func (e *encryptedCache) Stop() {
+	if s, ok := e.ValkeyCluster.(interface{ Stop() }); ok {
+		s.Stop()
+	}
+}
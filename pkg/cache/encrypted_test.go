@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func TestEncrypted_SensitivePrefixesPerTenant(t *testing.T) {
+	ctx := context.Background()
+	inner := NewNoopValkeyCache(logger.New("error"))
+	v1 := bytes.Repeat([]byte{1}, 32)
+	enc, err := NewEncrypted(inner, map[int][]byte{1: v1}, 1, []string{"cfg:"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	acme := NewTenantNamespaced(enc, "acme")
+
+	if err := acme.Set(ctx, "cfg:config_overrides", map[string]string{"token": "s3cret"}, time.Minute); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	raw, _ := inner.Get(ctx, "tenant:acme:cfg:config_overrides")
+	if !strings.HasPrefix(string(raw), "mcenc:1:") || bytes.Contains(raw, []byte("s3cret")) {
+		t.Fatalf("expected an encrypted value, got %q", raw)
+	}
+	if b, err := acme.Get(ctx, "cfg:config_overrides"); err != nil || string(b) != `{"token":"s3cret"}` {
+		t.Fatalf("get: %q err=%v", b, err)
+	}
+
+	// Other keys and plaintext written before encryption pass through.
+	_ = acme.Set(ctx, "kpi:def:cpu", "v", time.Minute)
+	if b, _ := inner.Get(ctx, "tenant:acme:kpi:def:cpu"); string(b) != "v" {
+		t.Fatalf("expected plaintext for non-sensitive key, got %q", b)
+	}
+	_ = inner.Set(ctx, "tenant:acme:cfg:legacy", "old", time.Minute)
+	if b, err := acme.Get(ctx, "cfg:legacy"); err != nil || string(b) != "old" {
+		t.Fatalf("legacy plaintext: %q err=%v", b, err)
+	}
+
+	// A ciphertext copied into another tenant's key does not decrypt.
+	_ = inner.Set(ctx, "tenant:globex:cfg:config_overrides", raw, time.Minute)
+	if _, err := NewTenantNamespaced(enc, "globex").Get(ctx, "cfg:config_overrides"); err == nil {
+		t.Fatal("expected a ciphertext moved across tenants to fail")
+	}
+}
+
+func TestEncrypted_KeyRotation(t *testing.T) {
+	ctx := context.Background()
+	inner := NewNoopValkeyCache(logger.New("error"))
+	v1, v2 := bytes.Repeat([]byte{1}, 32), bytes.Repeat([]byte{2}, 32)
+	old, _ := NewEncrypted(inner, map[int][]byte{1: v1}, 1, []string{"cfg:"})
+	_ = old.Set(ctx, "cfg:a", "one", time.Minute)
+
+	rotated, err := NewEncrypted(inner, map[int][]byte{1: v1, 2: v2}, 2, []string{"cfg:"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, err := rotated.Get(ctx, "cfg:a"); err != nil || string(b) != "one" {
+		t.Fatalf("old version should still decrypt: %q err=%v", b, err)
+	}
+	_ = rotated.Set(ctx, "cfg:b", "two", time.Minute)
+	if raw, _ := inner.Get(ctx, "cfg:b"); !strings.HasPrefix(string(raw), "mcenc:2:") {
+		t.Fatalf("expected the active version, got %q", raw)
+	}
+
+	retired, _ := NewEncrypted(inner, map[int][]byte{2: v2}, 2, []string{"cfg:"})
+	if _, err := retired.Get(ctx, "cfg:a"); err == nil {
+		t.Fatal("expected a retired key version to fail")
+	}
+	if _, err := NewEncrypted(inner, map[int][]byte{1: v1}, 2, nil); err == nil {
+		t.Fatal("expected a missing active version to be rejected")
+	}
+}
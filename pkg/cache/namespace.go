@@ -0,0 +1,171 @@
+package cache
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/monitoring"
+)
+
+// TenantKeyPrefix is the root of all tenant-namespaced keys. A tenant's keys
+// look like "tenant:<id>:<domain>:...", e.g. "tenant:acme:kpi:def:cpu".
+const TenantKeyPrefix = "tenant:"
+
+// TenantPrefix returns the key prefix owned by tenant.
+func TenantPrefix(tenant string) string {
+	return TenantKeyPrefix + tenant + ":"
+}
+
+// TenantFromKey extracts the tenant from a namespaced key.
+func TenantFromKey(key string) (string, bool) {
+	rest, ok := strings.CutPrefix(key, TenantKeyPrefix)
+	if !ok {
+		return "", false
+	}
+	tenant, _, ok := strings.Cut(rest, ":")
+	return tenant, ok && tenant != ""
+}
+
+// namespacedCache prefixes every key with a tenant namespace so deployments
+// sharing a Valkey keyspace stay isolated and can be flushed independently.
+type namespacedCache struct {
+	inner  ValkeyCluster
+	tenant string
+	prefix string
+}
+
+// NewTenantNamespaced wraps inner so all keys live under TenantPrefix(tenant).
+// An empty tenant returns inner unchanged (legacy, unprefixed keys).
+func NewTenantNamespaced(inner ValkeyCluster, tenant string) ValkeyCluster {
+	if tenant == "" {
+		return inner
+	}
+	return &namespacedCache{inner: inner, tenant: tenant, prefix: TenantPrefix(tenant)}
+}
+
+// Unwrap returns the cache beneath a tenant namespace, for admin operations
+// that must see every tenant's keys.
+func Unwrap(c ValkeyCluster) ValkeyCluster {
+	if n, ok := c.(*namespacedCache); ok {
+		return n.inner
+	}
+	return c
+}
+
+func (n *namespacedCache) key(k string) string { return n.prefix + k }
+
+func (n *namespacedCache) keys(ks []string) []string {
+	out := make([]string, len(ks))
+	for i, k := range ks {
+		out[i] = n.prefix + k
+	}
+	return out
+}
+
+func (n *namespacedCache) Get(ctx context.Context, key string) ([]byte, error) {
+	b, err := n.inner.Get(ctx, n.key(key))
+	if err != nil {
+		monitoring.RecordCacheTenantOperation(n.tenant, "get", "miss")
+	} else {
+		monitoring.RecordCacheTenantOperation(n.tenant, "get", "hit")
+	}
+	return b, err
+}
+
+func (n *namespacedCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	err := n.inner.Set(ctx, n.key(key), value, ttl)
+	monitoring.RecordCacheTenantOperation(n.tenant, "set", resultOf(err))
+	return err
+}
+
+func (n *namespacedCache) Delete(ctx context.Context, key string) error {
+	return n.inner.Delete(ctx, n.key(key))
+}
+
+func (n *namespacedCache) AcquireLock(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return n.inner.AcquireLock(ctx, n.key(key), ttl)
+}
+
+func (n *namespacedCache) ReleaseLock(ctx context.Context, key string) error {
+	return n.inner.ReleaseLock(ctx, n.key(key))
+}
+
+// Query results are stored as "<prefix>query_cache:<hash>" (rather than
+// delegating, which would put the namespace after "query_cache:") so a tenant
+// flush also drops its cached query results.
+func (n *namespacedCache) CacheQueryResult(ctx context.Context, queryHash string, result interface{}, ttl time.Duration) error {
+	return n.Set(ctx, "query_cache:"+queryHash, result, ttl)
+}
+
+func (n *namespacedCache) GetCachedQueryResult(ctx context.Context, queryHash string) ([]byte, error) {
+	return n.Get(ctx, "query_cache:"+queryHash)
+}
+
+func (n *namespacedCache) AddToPatternIndex(ctx context.Context, patternKey string, cacheKey string) error {
+	return n.inner.AddToPatternIndex(ctx, n.key(patternKey), n.key(cacheKey))
+}
+
+// GetPatternIndexKeys strips the namespace so callers can pass the keys back
+// to DeleteMultiple unchanged.
+func (n *namespacedCache) GetPatternIndexKeys(ctx context.Context, patternKey string) ([]string, error) {
+	ks, err := n.inner.GetPatternIndexKeys(ctx, n.key(patternKey))
+	for i, k := range ks {
+		ks[i] = strings.TrimPrefix(k, n.prefix)
+	}
+	return ks, err
+}
+
+func (n *namespacedCache) DeletePatternIndex(ctx context.Context, patternKey string) error {
+	return n.inner.DeletePatternIndex(ctx, n.key(patternKey))
+}
+
+func (n *namespacedCache) DeleteMultiple(ctx context.Context, keys []string) error {
+	return n.inner.DeleteMultiple(ctx, n.keys(keys))
+}
+
+func (n *namespacedCache) GetMemoryInfo(ctx context.Context) (*CacheMemoryInfo, error) {
+	return n.inner.GetMemoryInfo(ctx)
+}
+
+func (n *namespacedCache) AdjustCacheTTL(ctx context.Context, keyPattern string, newTTL time.Duration) error {
+	return n.inner.AdjustCacheTTL(ctx, n.key(keyPattern), newTTL)
+}
+
+func (n *namespacedCache) CleanupExpiredEntries(ctx context.Context, keyPattern string) (int64, error) {
+	return n.inner.CleanupExpiredEntries(ctx, n.key(keyPattern))
+}
+
+func (n *namespacedCache) ScanKeys(ctx context.Context, prefix string, fn func(keys []string) error) error {
+	return n.inner.ScanKeys(ctx, n.key(prefix), func(ks []string) error {
+		for i, k := range ks {
+			ks[i] = strings.TrimPrefix(k, n.prefix)
+		}
+		return fn(ks)
+	})
+}
+
+func (n *namespacedCache) DeleteByPrefix(ctx context.Context, prefix string) (int64, error) {
+	return n.inner.DeleteByPrefix(ctx, n.key(prefix))
+}
+
+// HealthCheck and Stop are forwarded when the wrapped cache supports them.
+func (n *namespacedCache) HealthCheck(ctx context.Context) error {
+	if hc, ok := n.inner.(interface{ HealthCheck(context.Context) error }); ok {
+		return hc.HealthCheck(ctx)
+	}
+	return nil
+}
+
+func (n *namespacedCache) Stop() {
+	if s, ok := n.inner.(interface{ Stop() }); ok {
+		s.Stop()
+	}
+}
+
+func resultOf(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "success"
+}
@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mirastacklabs-ai/mirador-core/pkg/logger"
+)
+
+func TestTenantNamespaced_PrefixesKeys(t *testing.T) {
+	ctx := context.Background()
+	inner := NewNoopValkeyCache(logger.New("error"))
+	acme := NewTenantNamespaced(inner, "acme")
+
+	if err := acme.Set(ctx, "kpi:def:cpu", "v", time.Minute); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if b, err := inner.Get(ctx, "tenant:acme:kpi:def:cpu"); err != nil || string(b) != "v" {
+		t.Fatalf("expected namespaced key in inner cache, got %q err=%v", b, err)
+	}
+	if b, err := acme.Get(ctx, "kpi:def:cpu"); err != nil || string(b) != "v" {
+		t.Fatalf("get through namespace: %q err=%v", b, err)
+	}
+	if err := acme.CacheQueryResult(ctx, "h1", "r", time.Minute); err != nil {
+		t.Fatalf("cache query: %v", err)
+	}
+	if _, err := inner.Get(ctx, "tenant:acme:query_cache:h1"); err != nil {
+		t.Fatalf("expected query result under tenant namespace: %v", err)
+	}
+
+	_ = acme.AddToPatternIndex(ctx, "idx", "kpi:def:cpu")
+	keys, err := acme.GetPatternIndexKeys(ctx, "idx")
+	if err != nil || len(keys) != 1 || keys[0] != "kpi:def:cpu" {
+		t.Fatalf("expected un-namespaced pattern keys, got %v err=%v", keys, err)
+	}
+
+	if NewTenantNamespaced(inner, "") != inner || Unwrap(acme) != inner {
+		t.Fatal("empty tenant and Unwrap should return the inner cache")
+	}
+}
+
+func TestTenantNamespaced_FlushIsolated(t *testing.T) {
+	ctx := context.Background()
+	inner := NewNoopValkeyCache(logger.New("error"))
+	acme := NewTenantNamespaced(inner, "acme")
+	globex := NewTenantNamespaced(inner, "globex")
+
+	_ = acme.Set(ctx, "a", "1", time.Minute)
+	_ = acme.Set(ctx, "b", "2", time.Minute)
+	_ = globex.Set(ctx, "a", "3", time.Minute)
+	_ = inner.Set(ctx, "legacy", "4", time.Minute)
+
+	n, err := inner.DeleteByPrefix(ctx, TenantPrefix("acme"))
+	if err != nil || n != 2 {
+		t.Fatalf("expected 2 keys flushed, got %d err=%v", n, err)
+	}
+	if _, err := acme.Get(ctx, "a"); err == nil {
+		t.Fatal("expected acme key to be flushed")
+	}
+	if _, err := globex.Get(ctx, "a"); err != nil {
+		t.Fatalf("globex key should survive: %v", err)
+	}
+	if _, err := inner.Get(ctx, "legacy"); err != nil {
+		t.Fatalf("unprefixed key should survive: %v", err)
+	}
+}
+
+func TestTenantFromKey(t *testing.T) {
+	if tenant, ok := TenantFromKey("tenant:acme:kpi:x"); !ok || tenant != "acme" {
+		t.Fatalf("got %q %v", tenant, ok)
+	}
+	for _, k := range []string{"kpi:x", "tenant:", "tenant::x", "tenant:acme"} {
+		if _, ok := TenantFromKey(k); ok {
+			t.Fatalf("expected %q not to parse as a tenant key", k)
+		}
+	}
+}
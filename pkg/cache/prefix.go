@@ -0,0 +1,101 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mirastacklabs-ai/mirador-core/internal/monitoring"
+	valkey "github.com/valkey-io/valkey-go"
+)
+
+// scanBatchSize is the SCAN COUNT hint and the UNLINK batch size.
+const scanBatchSize = 500
+
+// escapeGlob escapes SCAN MATCH metacharacters so prefix is matched literally.
+func escapeGlob(prefix string) string {
+	r := strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`)
+	return r.Replace(prefix)
+}
+
+// scanPrefix runs SCAN MATCH <prefix>* on every primary known to cli (a
+// single node or each cluster primary) and passes each batch of keys to fn.
+func scanPrefix(ctx context.Context, cli valkey.Client, prefix string, fn func(keys []string) error) error {
+	match := escapeGlob(prefix) + "*"
+	for addr, node := range cli.Nodes() {
+		if isReplica(ctx, node) {
+			continue
+		}
+		var cursor uint64
+		for {
+			entry, err := node.Do(ctx, node.B().Scan().Cursor(cursor).Match(match).Count(scanBatchSize).Build()).AsScanEntry()
+			if err != nil {
+				return fmt.Errorf("scan %s on %s: %w", prefix, addr, err)
+			}
+			if len(entry.Elements) > 0 {
+				if err := fn(entry.Elements); err != nil {
+					return err
+				}
+			}
+			cursor = entry.Cursor
+			if cursor == 0 {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// isReplica reports whether node is a replica. Errors are treated as primary
+// so a node that rejects ROLE is still scanned.
+func isReplica(ctx context.Context, node valkey.Client) bool {
+	role, err := node.Do(ctx, node.B().Role().Build()).ToArray()
+	if err != nil || len(role) == 0 {
+		return false
+	}
+	s, _ := role[0].ToString()
+	return s == "slave" || s == "replica"
+}
+
+// deleteByPrefix UNLINKs every key under prefix. UNLINK frees memory in the
+// background so large tenants do not block the server; keys are sent one per
+// command so cluster routing never hits CROSSSLOT.
+func deleteByPrefix(ctx context.Context, cli valkey.Client, prefix string) (int64, error) {
+	var deleted int64
+	err := scanPrefix(ctx, cli, prefix, func(keys []string) error {
+		cmds := make(valkey.Commands, 0, len(keys))
+		for _, k := range keys {
+			cmds = append(cmds, cli.B().Unlink().Key(k).Build())
+		}
+		for _, resp := range cli.DoMulti(ctx, cmds...) {
+			n, err := resp.AsInt64()
+			if err != nil {
+				return err
+			}
+			deleted += n
+		}
+		return nil
+	})
+	if err != nil {
+		monitoring.RecordCacheOperation("delete_prefix", "error")
+		return deleted, err
+	}
+	monitoring.RecordCacheOperation("delete_prefix", "success")
+	return deleted, nil
+}
+
+func (v *valkeyClusterImpl) ScanKeys(ctx context.Context, prefix string, fn func(keys []string) error) error {
+	return scanPrefix(ctx, v.raw, prefix, fn)
+}
+
+func (v *valkeyClusterImpl) DeleteByPrefix(ctx context.Context, prefix string) (int64, error) {
+	return deleteByPrefix(ctx, v.raw, prefix)
+}
+
+func (v *valkeySingleImpl) ScanKeys(ctx context.Context, prefix string, fn func(keys []string) error) error {
+	return scanPrefix(ctx, v.raw, prefix, fn)
+}
+
+func (v *valkeySingleImpl) DeleteByPrefix(ctx context.Context, prefix string) (int64, error) {
+	return deleteByPrefix(ctx, v.raw, prefix)
+}
@@ -194,6 +194,22 @@ func (a *autoSwapCache) DeleteMultiple(ctx context.Context, keys []string) error
 	return a.withCurrent(func(c ValkeyCluster) error { return c.DeleteMultiple(ctx, keys) })
 }
 
+/* --------------------------- prefix-scoped operations --------------------------- */
+
+func (a *autoSwapCache) ScanKeys(ctx context.Context, prefix string, fn func(keys []string) error) error {
+	return a.withCurrent(func(c ValkeyCluster) error { return c.ScanKeys(ctx, prefix, fn) })
+}
+
+func (a *autoSwapCache) DeleteByPrefix(ctx context.Context, prefix string) (int64, error) {
+	var n int64
+	err := a.withCurrent(func(c ValkeyCluster) error {
+		var e error
+		n, e = c.DeleteByPrefix(ctx, prefix)
+		return e
+	})
+	return n, err
+}
+
 // NewAutoSwapForSingle creates an auto-swapping cache that upgrades from
 // in-memory to a single-node Valkey client when reachable.
 func NewAutoSwapForSingle(addr string, db int, password string, ttl time.Duration, log logger.Logger, fallback ValkeyCluster) ValkeyCluster {
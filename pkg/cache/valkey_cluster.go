@@ -3,6 +3,7 @@ package cache
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -14,6 +15,9 @@ import (
 	"github.com/valkey-io/valkey-go/valkeycompat"
 )
 
+// ErrNotFound is returned by Get for a missing key.
+var ErrNotFound = errors.New("key not found")
+
 // CacheMemoryInfo contains memory usage information for adaptive cache sizing
 type CacheMemoryInfo struct {
 	UsedMemory          int64   `json:"used_memory_bytes"`
@@ -50,10 +54,15 @@ type ValkeyCluster interface {
 	GetMemoryInfo(ctx context.Context) (*CacheMemoryInfo, error)
 	AdjustCacheTTL(ctx context.Context, keyPattern string, newTTL time.Duration) error
 	CleanupExpiredEntries(ctx context.Context, keyPattern string) (int64, error)
+
+	// Prefix-scoped key operations (SCAN-based; used for tenant namespaces)
+	ScanKeys(ctx context.Context, prefix string, fn func(keys []string) error) error
+	DeleteByPrefix(ctx context.Context, prefix string) (int64, error)
 }
 
 type valkeyClusterImpl struct {
 	client valkeycompat.Cmdable
+	raw    valkey.Client // for per-node SCAN
 	logger logger.Logger
 	ttl    time.Duration
 }
@@ -80,6 +89,7 @@ func NewValkeyCluster(nodes []string, defaultTTL time.Duration) (ValkeyCluster,
 
 	return &valkeyClusterImpl{
 		client: adapter,
+		raw:    cli,
 		logger: logger.New("info"),
 		ttl:    defaultTTL,
 	}, nil
@@ -102,7 +112,7 @@ func (v *valkeyClusterImpl) Get(ctx context.Context, key string) ([]byte, error)
 
 	if err == valkeycompat.Nil {
 		monitoring.RecordCacheOperation("get", "miss")
-		return nil, fmt.Errorf("key not found: %s", key)
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
 	}
 
 	if err != nil {
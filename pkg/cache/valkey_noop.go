@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -30,7 +31,7 @@ func (n *noopValkeyCache) Get(ctx context.Context, key string) ([]byte, error) {
 	defer n.mu.RUnlock()
 	b, ok := n.m[key]
 	if !ok {
-		return nil, fmt.Errorf("key not found: %s", key)
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
 	}
 	return b, nil
 }
@@ -170,3 +171,33 @@ func (n *noopValkeyCache) DeleteMultiple(ctx context.Context, keys []string) err
 	}
 	return nil
 }
+
+/* --------------------------- prefix-scoped operations --------------------------- */
+
+func (n *noopValkeyCache) ScanKeys(ctx context.Context, prefix string, fn func(keys []string) error) error {
+	n.mu.RLock()
+	var keys []string
+	for k := range n.m {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	n.mu.RUnlock()
+	if len(keys) == 0 {
+		return nil
+	}
+	return fn(keys)
+}
+
+func (n *noopValkeyCache) DeleteByPrefix(ctx context.Context, prefix string) (int64, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var deleted int64
+	for k := range n.m {
+		if strings.HasPrefix(k, prefix) {
+			delete(n.m, k)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
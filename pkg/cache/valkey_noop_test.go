@@ -2,6 +2,7 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -23,6 +24,9 @@ func TestNoopValkey_BasicOps(t *testing.T) {
 	if err := cch.Delete(ctx, "k1"); err != nil {
 		t.Fatalf("del: %v", err)
 	}
+	if _, err := cch.Get(ctx, "k1"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound after delete, got %v", err)
+	}
 
 	// query cache
 	if err := cch.CacheQueryResult(ctx, "h", map[string]int{"a": 1}, time.Second); err != nil {
@@ -15,6 +15,7 @@ import (
 // valkeySingleImpl implements ValkeyCluster against a single-node Valkey instance.
 type valkeySingleImpl struct {
 	client valkeycompat.Cmdable
+	raw    valkey.Client // for SCAN
 	logger logger.Logger
 	ttl    time.Duration
 }
@@ -41,6 +42,7 @@ func NewValkeySingle(addr string, db int, password string, defaultTTL time.Durat
 
 	return &valkeySingleImpl{
 		client: adapter,
+		raw:    cli,
 		logger: logger.New("info"),
 		ttl:    defaultTTL,
 	}, nil
@@ -50,7 +52,7 @@ func (v *valkeySingleImpl) Get(ctx context.Context, key string) ([]byte, error)
 	b, err := v.client.Get(ctx, key).Bytes()
 	if err == valkeycompat.Nil {
 		monitoring.RecordCacheOperation("get", "miss")
-		return nil, fmt.Errorf("key not found: %s", key)
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
 	}
 
 	if err != nil {
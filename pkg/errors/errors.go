@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+
+	"github.com/mirastacklabs-ai/mirador-core/pkg/redact"
 )
 
 // Category represents the type/category of an error for classification.
@@ -32,12 +34,13 @@ type AppError struct {
 	Cause    error    // Underlying error (for wrapping)
 }
 
-// Error implements the error interface.
+// Error implements the error interface. Secrets in the message or the cause,
+// such as connection string credentials, are redacted.
 func (e *AppError) Error() string {
 	if e.Cause != nil {
-		return fmt.Sprintf("%s: %s (%s)", e.Code, e.Message, e.Cause.Error())
+		return redact.String(fmt.Sprintf("%s: %s (%s)", e.Code, e.Message, e.Cause.Error()))
 	}
-	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+	return redact.String(fmt.Sprintf("%s: %s", e.Code, e.Message))
 }
 
 // Unwrap returns the underlying cause for errors.Is/As support.
@@ -102,9 +105,9 @@ type ErrorResponse struct {
 // ToResponse converts an AppError to an ErrorResponse.
 func (e *AppError) ToResponse() ErrorResponse {
 	return ErrorResponse{
-		Error:   e.Message,
+		Error:   redact.String(e.Message),
 		Code:    e.Code,
-		Details: e.Details,
+		Details: redact.String(e.Details),
 	}
 }
 
@@ -328,7 +331,7 @@ func ToErrorResponse(err error) ErrorResponse {
 		return appErr.ToResponse()
 	}
 	return ErrorResponse{
-		Error: err.Error(),
+		Error: redact.String(err.Error()),
 		Code:  "INTERNAL_ERROR",
 	}
 }
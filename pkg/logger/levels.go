@@ -0,0 +1,128 @@
+package logger
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Subsystems have their own log level, adjustable at runtime.
+const (
+	SubsystemAPI         = "api"
+	SubsystemCache       = "cache"
+	SubsystemCorrelation = "correlation"
+	SubsystemWeaviate    = "weaviate"
+)
+
+// Subsystems lists the subsystems in a stable order.
+var Subsystems = []string{SubsystemAPI, SubsystemCache, SubsystemCorrelation, SubsystemWeaviate}
+
+// LevelController is implemented by loggers whose subsystem levels can be
+// changed at runtime.
+type LevelController interface {
+	// Named returns the logger of a subsystem. Its level follows the global
+	// level until SetLevel overrides it.
+	Named(subsystem string) Logger
+	// SetLevel sets the level of a subsystem; an empty level makes it follow
+	// the global level again.
+	SetLevel(subsystem, level string) error
+	// Levels returns the effective level of every subsystem.
+	Levels() map[string]string
+}
+
+// Named returns the subsystem logger of l, or l itself when l has no
+// subsystem levels (e.g. test loggers).
+func Named(l Logger, subsystem string) Logger {
+	if lc, ok := l.(LevelController); ok {
+		return lc.Named(subsystem)
+	}
+	return l
+}
+
+// ParseLevel parses debug, info, warn or error.
+func ParseLevel(level string) (zapcore.Level, bool) {
+	switch level {
+	case "debug":
+		return zapcore.DebugLevel, true
+	case "info":
+		return zapcore.InfoLevel, true
+	case "warn":
+		return zapcore.WarnLevel, true
+	case "error":
+		return zapcore.ErrorLevel, true
+	}
+	return zapcore.InfoLevel, false
+}
+
+// levelRegistry holds the global level and one atomic level per subsystem.
+// The set of subsystems is fixed at construction, so only the atomic levels
+// change afterwards.
+type levelRegistry struct {
+	global     zap.AtomicLevel
+	subsystems map[string]zap.AtomicLevel
+}
+
+func newLevelRegistry(global zapcore.Level) *levelRegistry {
+	r := &levelRegistry{
+		global:     zap.NewAtomicLevelAt(global),
+		subsystems: make(map[string]zap.AtomicLevel, len(Subsystems)),
+	}
+	for _, s := range Subsystems {
+		r.subsystems[s] = zap.NewAtomicLevelAt(global)
+	}
+	return r
+}
+
+func (r *levelRegistry) set(subsystem, level string) error {
+	lvl, ok := r.subsystems[subsystem]
+	if !ok {
+		return fmt.Errorf("unknown log subsystem %q", subsystem)
+	}
+	if level == "" {
+		lvl.SetLevel(r.global.Level())
+		return nil
+	}
+	parsed, ok := ParseLevel(level)
+	if !ok {
+		return fmt.Errorf("invalid log level %q for %s: expected debug, info, warn or error", level, subsystem)
+	}
+	lvl.SetLevel(parsed)
+	return nil
+}
+
+func (r *levelRegistry) levels() map[string]string {
+	out := make(map[string]string, len(r.subsystems))
+	for name, lvl := range r.subsystems {
+		out[name] = lvl.Level().String()
+	}
+	return out
+}
+
+// levelFilterCore applies a runtime level on top of a core built to accept
+// every level.
+type levelFilterCore struct {
+	zapcore.Core
+	level zapcore.LevelEnabler
+}
+
+func (c *levelFilterCore) Enabled(l zapcore.Level) bool {
+	return c.level.Enabled(l)
+}
+
+func (c *levelFilterCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelFilterCore{Core: c.Core.With(fields), level: c.level}
+}
+
+func (c *levelFilterCore) Check(e zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.level.Enabled(e.Level) {
+		return ce
+	}
+	return c.Core.Check(e, ce)
+}
+
+func withLevel(l *zap.Logger, level zapcore.LevelEnabler) *zap.Logger {
+	return l.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &levelFilterCore{Core: core, level: level}
+	}))
+}
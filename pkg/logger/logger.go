@@ -21,6 +21,9 @@ type Logger interface {
 
 type zapLogger struct {
 	logger *zap.SugaredLogger
+	// base is the unfiltered logger; levels filters it per subsystem.
+	base   *zap.Logger
+	levels *levelRegistry
 }
 
 func New(level string) Logger {
@@ -31,16 +34,12 @@ func New(level string) Logger {
 	// to avoid any accidental console encoders or environment-based switches.
 	config.Encoding = "json"
 
-	// Set log level
-	switch level {
-	case "debug":
-		config.Level = zap.NewAtomicLevelAt(zapcore.DebugLevel)
-	case "info":
-		config.Level = zap.NewAtomicLevelAt(zapcore.InfoLevel)
-	case "warn":
-		config.Level = zap.NewAtomicLevelAt(zapcore.WarnLevel)
-	case "error":
-		config.Level = zap.NewAtomicLevelAt(zapcore.ErrorLevel)
+	// Build at debug and filter with atomic levels so subsystem levels can
+	// be raised or lowered at runtime independently of the global level.
+	config.Level = zap.NewAtomicLevelAt(zapcore.DebugLevel)
+	global, ok := ParseLevel(level)
+	if !ok {
+		global = zapcore.InfoLevel
 	}
 
 	// Custom encoder config for MIRADOR
@@ -63,11 +62,55 @@ func New(level string) Logger {
 		panic(err)
 	}
 
+	return newZapLogger(logger, global)
+}
+
+// NewWithCore returns a logger writing to core at level, e.g. to capture
+// output in tests. Secret redaction and subsystem levels apply as with New.
+func NewWithCore(level string, core zapcore.Core) Logger {
+	global, ok := ParseLevel(level)
+	if !ok {
+		global = zapcore.InfoLevel
+	}
+	return newZapLogger(zap.New(core), global)
+}
+
+// newZapLogger wraps logger with secret redaction and the runtime levels.
+func newZapLogger(logger *zap.Logger, global zapcore.Level) *zapLogger {
+	logger = logger.WithOptions(zap.WrapCore(newRedactCore))
+	levels := newLevelRegistry(global)
 	return &zapLogger{
-		logger: logger.Sugar(),
+		logger: withLevel(logger, levels.global).Sugar(),
+		base:   logger,
+		levels: levels,
 	}
 }
 
+// Named returns the logger of a subsystem, tagged with its name.
+func (l *zapLogger) Named(subsystem string) Logger {
+	lvl, ok := l.levels.subsystems[subsystem]
+	if !ok {
+		return l
+	}
+	return &zapLogger{
+		logger: withLevel(l.base.Named(subsystem), lvl).Sugar(),
+		base:   l.base,
+		levels: l.levels,
+	}
+}
+
+// SetLevel sets the level of a subsystem; an empty level makes it follow the
+// global level again. It takes effect immediately for every logger returned
+// by Named.
+func (l *zapLogger) SetLevel(subsystem, level string) error {
+	return l.levels.set(subsystem, level)
+}
+
+// Levels returns the effective level of every subsystem.
+func (l *zapLogger) Levels() map[string]string {
+	return l.levels.levels()
+}
+
 func (l *zapLogger) Info(msg string, fields ...interface{}) {
 	l.logger.Infow(msg, fields...)
 }
@@ -88,18 +131,13 @@ func (l *zapLogger) Fatal(msg string, fields ...interface{}) {
 	l.logger.Fatalw(msg, fields...)
 }
 
-// ZapLogger exposes the underlying *zap.Logger used by this implementation.
+// ZapLogger exposes the underlying *zap.Logger used by this implementation,
+// filtered at the same level as l.
 func (l *zapLogger) ZapLogger() *zap.Logger {
 	if l == nil || l.logger == nil {
 		return zap.NewNop()
 	}
-	// We built the logger using zap.Config.Build() and then called Sugar(),
-	// but we can access the underlying *zap.Logger by rebuilding a non-sugared
-	// logger or by storing one. For simplicity and to avoid changing the
-	// construction path, return a no-op here if underlying structured logger
-	// can't be recovered; callers that need the zap logger should prefer the
-	// adapter path or use ExtractZapLogger.
-	return zap.NewNop()
+	return l.logger.Desugar()
 }
 
 // MockLogger is a test logger that captures output to a buffer
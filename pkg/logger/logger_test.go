@@ -1,6 +1,14 @@
 package logger
 
-import "testing"
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
 
 func TestLogger_BasicLevels(t *testing.T) {
 	l := New("debug")
@@ -12,3 +20,66 @@ func TestLogger_BasicLevels(t *testing.T) {
 	l.Warn("warn")
 	l.Error("err")
 }
+
+func TestLogger_SubsystemLevels(t *testing.T) {
+	root := New("info")
+	lc, ok := root.(LevelController)
+	if !ok {
+		t.Fatalf("zap logger should support subsystem levels")
+	}
+	correlation := Named(root, SubsystemCorrelation).ZapLogger().Core()
+	cache := Named(root, SubsystemCache).ZapLogger().Core()
+	if correlation.Enabled(zapcore.DebugLevel) || !correlation.Enabled(zapcore.InfoLevel) {
+		t.Fatalf("subsystems should follow the global level by default")
+	}
+
+	// Existing subsystem loggers pick up the change immediately.
+	if err := lc.SetLevel(SubsystemCorrelation, "debug"); err != nil {
+		t.Fatal(err)
+	}
+	if err := lc.SetLevel(SubsystemCache, "error"); err != nil {
+		t.Fatal(err)
+	}
+	if !correlation.Enabled(zapcore.DebugLevel) || cache.Enabled(zapcore.WarnLevel) {
+		t.Fatalf("subsystem levels not applied: %v", lc.Levels())
+	}
+	if root.ZapLogger().Core().Enabled(zapcore.DebugLevel) {
+		t.Fatalf("subsystem levels must not change the global level")
+	}
+
+	if err := lc.SetLevel(SubsystemCorrelation, ""); err != nil {
+		t.Fatal(err)
+	}
+	if got := lc.Levels()[SubsystemCorrelation]; got != "info" {
+		t.Fatalf("expected correlation to follow the global level again, got %s", got)
+	}
+	if err := lc.SetLevel("rbac", "debug"); err == nil {
+		t.Fatalf("expected an error for an unknown subsystem")
+	}
+	if err := lc.SetLevel(SubsystemAPI, "verbose"); err == nil {
+		t.Fatalf("expected an error for an invalid level")
+	}
+	if Named(NewMockLogger(nil), SubsystemAPI) == nil {
+		t.Fatalf("Named should fall back to the logger itself")
+	}
+}
+
+func TestLogger_RedactsSecrets(t *testing.T) {
+	var buf bytes.Buffer
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(&buf), zapcore.DebugLevel)
+	l := NewWithCore("info", core)
+
+	l.Info("connecting to redis://:hunter22@cache:6379", "password", "hunter22", "max_tokens", 512)
+	Named(l, SubsystemCache).Warn("retry", "error", errors.New("auth failed: token=abc123"))
+	l.Debug("dropped by the global level", "password", "hunter22")
+
+	out := buf.String()
+	for _, secret := range []string{"hunter22", "abc123"} {
+		if strings.Contains(out, secret) {
+			t.Fatalf("secret %q leaked into %s", secret, out)
+		}
+	}
+	if !strings.Contains(out, `"max_tokens":512`) || strings.Count(out, "\n") != 2 {
+		t.Fatalf("unexpected output %s", out)
+	}
+}
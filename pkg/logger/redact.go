@@ -0,0 +1,98 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/mirastacklabs-ai/mirador-core/pkg/redact"
+)
+
+// redactCore scrubs secrets from messages and fields before they reach the
+// wrapped core; see package redact.
+type redactCore struct {
+	zapcore.Core
+}
+
+func newRedactCore(core zapcore.Core) zapcore.Core {
+	return &redactCore{Core: core}
+}
+
+func (c *redactCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactCore{Core: c.Core.With(redactFields(fields))}
+}
+
+// Check defers to the wrapped core (so sampling still applies) but registers
+// c, so the entry is written through Write.
+func (c *redactCore) Check(e zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Core.Check(e, nil) == nil {
+		return ce
+	}
+	return ce.AddCore(e, c)
+}
+
+func (c *redactCore) Write(e zapcore.Entry, fields []zapcore.Field) error {
+	e.Message = redact.String(e.Message)
+	return c.Core.Write(e, redactFields(fields))
+}
+
+func redactFields(fields []zapcore.Field) []zapcore.Field {
+	out := make([]zapcore.Field, len(fields))
+	for i, f := range fields {
+		out[i] = redactField(f)
+	}
+	return out
+}
+
+func redactField(f zapcore.Field) zapcore.Field {
+	if redact.IsSecretKey(f.Key) && f.Type != zapcore.SkipType && f.Type != zapcore.NamespaceType {
+		return zap.String(f.Key, redact.Placeholder)
+	}
+	switch f.Type {
+	case zapcore.StringType:
+		f.String = redact.String(f.String)
+	case zapcore.ByteStringType:
+		if b, ok := f.Interface.([]byte); ok {
+			return zap.String(f.Key, redact.String(string(b)))
+		}
+	case zapcore.ErrorType:
+		if err, ok := f.Interface.(error); ok {
+			if msg, ok := safeString(err.Error); ok {
+				return zap.String(f.Key, redact.String(msg))
+			}
+		}
+	case zapcore.StringerType:
+		if s, ok := f.Interface.(fmt.Stringer); ok {
+			if str, ok := safeString(s.String); ok {
+				return zap.String(f.Key, redact.String(str))
+			}
+		}
+	case zapcore.ReflectType:
+		// Maps and structs are scrubbed through their JSON form; the value
+		// is only replaced when something was redacted.
+		raw, err := json.Marshal(f.Interface)
+		if err != nil {
+			return f
+		}
+		if scrubbed := redact.String(string(raw)); scrubbed != string(raw) {
+			if json.Valid([]byte(scrubbed)) {
+				return zap.Reflect(f.Key, json.RawMessage(scrubbed))
+			}
+			return zap.String(f.Key, scrubbed)
+		}
+	}
+	return f
+}
+
+// safeString calls fn, reporting false if it panics (e.g. a nil pointer
+// receiver); zap encodes such values itself.
+func safeString(fn func() string) (s string, ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+	return fn(), true
+}
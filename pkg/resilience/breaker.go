@@ -0,0 +1,169 @@
+package resilience
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// State is a circuit breaker state.
+type State string
+
+const (
+	StateClosed   State = "closed"
+	StateHalfOpen State = "half_open"
+	StateOpen     State = "open"
+)
+
+// BreakerOptions configures a CircuitBreaker.
+type BreakerOptions struct {
+	// Failures consecutive failures open the breaker for Cooldown; 0
+	// disables it.
+	Failures int
+	Cooldown time.Duration
+	// IsFailure reports whether an error counts against the backend; nil
+	// counts every error. Errors that do not count are successes: the
+	// backend answered.
+	IsFailure func(err error) bool
+	// OnStateChange is called with the breaker's lock held whenever its
+	// state changes.
+	OnStateChange func(from, to State)
+	// OnReject is called for every call failed fast with ErrCircuitOpen.
+	OnReject func()
+}
+
+// CircuitBreaker stops calling a backend for a cooldown after consecutive
+// failures. Once the cooldown has passed one probe call is let through:
+// its success closes the breaker, its failure opens it again. Calls whose
+// caller gave up say nothing about the backend and are not counted.
+type CircuitBreaker struct {
+	opts BreakerOptions
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewCircuitBreaker creates a closed circuit breaker.
+func NewCircuitBreaker(opts BreakerOptions) *CircuitBreaker {
+	return &CircuitBreaker{opts: opts, state: StateClosed}
+}
+
+// State returns the breaker state. An open breaker whose cooldown has
+// passed reports half_open: the next call is a probe.
+func (b *CircuitBreaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == StateOpen && time.Since(b.openedAt) >= b.opts.Cooldown {
+		return StateHalfOpen
+	}
+	return b.state
+}
+
+// Execute runs fn unless the breaker is open and records its outcome.
+func (b *CircuitBreaker) Execute(ctx context.Context, fn func(context.Context) error) error {
+	if err := b.Allow(); err != nil {
+		return err
+	}
+	err := fn(ctx)
+	switch {
+	case err != nil && ctx.Err() != nil:
+		b.Release()
+	case err != nil && (b.opts.IsFailure == nil || b.opts.IsFailure(err)):
+		b.Failure()
+	default:
+		b.Success()
+	}
+	return err
+}
+
+// Allow lets a call through unless the breaker is open, turning an open
+// breaker whose cooldown has passed half-open for a single probe. A call
+// let through must be followed by Success, Failure or Release.
+func (b *CircuitBreaker) Allow() error {
+	if b.opts.Failures <= 0 {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case StateOpen:
+		if time.Since(b.openedAt) < b.opts.Cooldown {
+			b.reject()
+			return ErrCircuitOpen
+		}
+		b.setState(StateHalfOpen)
+		b.probing = true
+	case StateHalfOpen:
+		if b.probing {
+			b.reject()
+			return ErrCircuitOpen
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// Success records a call the backend answered.
+func (b *CircuitBreaker) Success() { b.record(true, true) }
+
+// Failure records a failed call.
+func (b *CircuitBreaker) Failure() { b.record(false, true) }
+
+// Release records a call whose outcome does not count, such as one
+// cancelled by its caller. It only frees a pending probe.
+func (b *CircuitBreaker) Release() { b.record(false, false) }
+
+func (b *CircuitBreaker) record(ok, counted bool) {
+	if b.opts.Failures <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case StateHalfOpen:
+		b.probing = false
+		switch {
+		case !counted:
+		case ok:
+			b.failures = 0
+			b.setState(StateClosed)
+		default:
+			b.openedAt = time.Now()
+			b.setState(StateOpen)
+		}
+	case StateClosed:
+		if !counted {
+			return
+		}
+		if ok {
+			b.failures = 0
+			return
+		}
+		b.failures++
+		if b.failures >= b.opts.Failures {
+			b.openedAt = time.Now()
+			b.setState(StateOpen)
+		}
+	}
+}
+
+func (b *CircuitBreaker) reject() {
+	if b.opts.OnReject != nil {
+		b.opts.OnReject()
+	}
+}
+
+// setState must be called with mu held.
+func (b *CircuitBreaker) setState(state State) {
+	if b.state == state {
+		return
+	}
+	from := b.state
+	b.state = state
+	if b.opts.OnStateChange != nil {
+		b.opts.OnStateChange(from, state)
+	}
+}
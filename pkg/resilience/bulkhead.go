@@ -0,0 +1,73 @@
+package resilience
+
+import (
+	"context"
+	"time"
+)
+
+// BulkheadOptions configures a Bulkhead.
+type BulkheadOptions struct {
+	// MaxConcurrent bounds the calls running at once; 0 disables the
+	// bulkhead.
+	MaxConcurrent int
+	// MaxWait is how long a call waits for a free slot; 0 rejects it at
+	// once when the bulkhead is full.
+	MaxWait time.Duration
+	// OnReject is called for every call rejected with ErrBulkheadFull.
+	OnReject func()
+}
+
+// Bulkhead bounds how many calls run at once, so one slow backend cannot
+// take up every goroutine of its callers.
+type Bulkhead struct {
+	opts  BulkheadOptions
+	slots chan struct{}
+}
+
+// NewBulkhead creates a bulkhead.
+func NewBulkhead(opts BulkheadOptions) *Bulkhead {
+	b := &Bulkhead{opts: opts}
+	if opts.MaxConcurrent > 0 {
+		b.slots = make(chan struct{}, opts.MaxConcurrent)
+	}
+	return b
+}
+
+// InFlight returns how many calls are running.
+func (b *Bulkhead) InFlight() int {
+	return len(b.slots)
+}
+
+// Execute runs fn once a slot is free. It returns ErrBulkheadFull when none
+// frees up within MaxWait, and ctx's error when ctx ends first.
+func (b *Bulkhead) Execute(ctx context.Context, fn func(context.Context) error) error {
+	if b.slots == nil {
+		return fn(ctx)
+	}
+	select {
+	case b.slots <- struct{}{}:
+	default:
+		if b.opts.MaxWait <= 0 {
+			return b.reject()
+		}
+		timer := time.NewTimer(b.opts.MaxWait)
+		select {
+		case b.slots <- struct{}{}:
+			timer.Stop()
+		case <-timer.C:
+			return b.reject()
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+	defer func() { <-b.slots }()
+	return fn(ctx)
+}
+
+func (b *Bulkhead) reject() error {
+	if b.opts.OnReject != nil {
+		b.opts.OnReject()
+	}
+	return ErrBulkheadFull
+}
@@ -0,0 +1,50 @@
+// Package resilience provides the retry, backoff, timeout, circuit breaker
+// and bulkhead policies shared by MIRADOR-CORE's backend clients.
+//
+// # Policies
+//
+// Policies implement [Policy]:
+//
+//	type Policy interface {
+//	    Execute(ctx context.Context, fn func(context.Context) error) error
+//	}
+//
+// The policies are:
+//   - [Retry] runs fn again after failures, waiting exponential delays
+//     with optional jitter computed by [Backoff]
+//   - [Timeout] bounds each call with a deadline
+//   - [CircuitBreaker] fails fast after consecutive failures
+//   - [Bulkhead] bounds how many calls run at once
+//
+// # Composition
+//
+// [Chain] nests policies, the first one outermost. A client typically
+// bounds concurrency around retries, and checks the breaker and applies the
+// timeout per attempt:
+//
+//	policy := resilience.Chain(
+//	    resilience.NewBulkhead(resilience.BulkheadOptions{MaxConcurrent: 32}),
+//	    resilience.Retry{MaxAttempts: 3, Backoff: resilience.Backoff{
+//	        Initial: 100 * time.Millisecond, Max: 2 * time.Second, Jitter: true,
+//	    }},
+//	    resilience.NewCircuitBreaker(resilience.BreakerOptions{Failures: 5, Cooldown: 30 * time.Second}),
+//	    resilience.Timeout(10*time.Second),
+//	)
+//	err := policy.Execute(ctx, func(ctx context.Context) error {
+//	    return client.Call(ctx)
+//	})
+//
+// # Errors
+//
+// [Retry] stops once the caller's context ends and does not retry
+// rejections by other policies ([ErrCircuitOpen], [ErrBulkheadFull]) or
+// errors wrapped with [Permanent]. [Timeout] reports expired deadlines as
+// [ErrTimeout].
+//
+// # Metrics
+//
+// The package does not register metrics. Policies expose hooks
+// ([Retry.OnRetry], [BreakerOptions.OnStateChange], [BreakerOptions.OnReject],
+// [BulkheadOptions.OnReject]) that clients wire to their own counters and
+// logs.
+package resilience
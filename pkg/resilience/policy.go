@@ -0,0 +1,77 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrTimeout is returned when a call did not finish within its Timeout.
+	ErrTimeout = errors.New("call timed out")
+	// ErrCircuitOpen is returned without calling fn while a circuit breaker
+	// is open.
+	ErrCircuitOpen = errors.New("circuit breaker open")
+	// ErrBulkheadFull is returned without calling fn when a bulkhead has no
+	// free slot.
+	ErrBulkheadFull = errors.New("bulkhead full")
+)
+
+// Policy runs a call under a resilience behavior.
+type Policy interface {
+	Execute(ctx context.Context, fn func(context.Context) error) error
+}
+
+// PolicyFunc adapts a function to Policy.
+type PolicyFunc func(ctx context.Context, fn func(context.Context) error) error
+
+func (f PolicyFunc) Execute(ctx context.Context, fn func(context.Context) error) error {
+	return f(ctx, fn)
+}
+
+// Chain nests policies, the first one outermost. Nil policies are skipped.
+func Chain(policies ...Policy) Policy {
+	return PolicyFunc(func(ctx context.Context, fn func(context.Context) error) error {
+		call := fn
+		for i := len(policies) - 1; i >= 0; i-- {
+			p, next := policies[i], call
+			if p == nil {
+				continue
+			}
+			call = func(ctx context.Context) error { return p.Execute(ctx, next) }
+		}
+		return call(ctx)
+	})
+}
+
+// Timeout returns a policy running each call with a deadline d after its
+// start. It is a no-op when d is not positive.
+func Timeout(d time.Duration) Policy {
+	return PolicyFunc(func(ctx context.Context, fn func(context.Context) error) error {
+		if d <= 0 {
+			return fn(ctx)
+		}
+		tctx, cancel := context.WithTimeout(ctx, d)
+		defer cancel()
+		err := fn(tctx)
+		if err != nil && ctx.Err() == nil && errors.Is(tctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("%w after %s: %w", ErrTimeout, d, err)
+		}
+		return err
+	})
+}
+
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err as not worth retrying. Retry returns the error it
+// wraps.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
@@ -0,0 +1,213 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var errBackend = errors.New("backend down")
+
+func TestBackoff_Delay(t *testing.T) {
+	b := Backoff{Initial: 100 * time.Millisecond, Max: 300 * time.Millisecond}
+	for attempt, want := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 300 * time.Millisecond, 40: 300 * time.Millisecond} {
+		if got := b.Delay(attempt); got != want {
+			t.Errorf("Delay(%d) = %s, want %s", attempt, got, want)
+		}
+	}
+	b.Jitter = true
+	for i := 0; i < 100; i++ {
+		if d := b.Delay(2); d < 100*time.Millisecond || d > 200*time.Millisecond {
+			t.Fatalf("jittered Delay(2) = %s, want within [100ms, 200ms]", d)
+		}
+	}
+	if d := (Backoff{}).Delay(3); d != 0 {
+		t.Fatalf("zero Backoff delay = %s, want 0", d)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	ctx := context.Background()
+	var calls, retries int
+	r := Retry{
+		MaxAttempts: 3,
+		Backoff:     Backoff{Initial: time.Millisecond},
+		OnRetry:     func(int, error, time.Duration) { retries++ },
+	}
+	err := r.Execute(ctx, func(context.Context) error {
+		if calls++; calls < 3 {
+			return errBackend
+		}
+		return nil
+	})
+	if err != nil || calls != 3 || retries != 2 {
+		t.Fatalf("got err=%v after %d calls and %d retries, want success on the third call", err, calls, retries)
+	}
+
+	calls = 0
+	if err := r.Execute(ctx, func(context.Context) error { calls++; return errBackend }); !errors.Is(err, errBackend) || calls != 3 {
+		t.Fatalf("got %v after %d calls, want the last error after 3", err, calls)
+	}
+
+	for name, fail := range map[string]error{
+		"permanent":    Permanent(errBackend),
+		"circuit open": ErrCircuitOpen,
+	} {
+		calls = 0
+		if err := r.Execute(ctx, func(context.Context) error { calls++; return fail }); err == nil || calls != 1 {
+			t.Fatalf("%s: got %v after %d calls, want one call", name, err, calls)
+		}
+	}
+	notRetryable := r
+	notRetryable.Retryable = func(err error) bool { return !errors.Is(err, errBackend) }
+	calls = 0
+	if err := notRetryable.Execute(ctx, func(context.Context) error { calls++; return errBackend }); !errors.Is(err, errBackend) || calls != 1 {
+		t.Fatalf("got %v after %d calls, want one call for an error Retryable rejects", err, calls)
+	}
+
+	cctx, cancel := context.WithCancel(ctx)
+	calls = 0
+	err = r.Execute(cctx, func(context.Context) error { calls++; cancel(); return errBackend })
+	if !errors.Is(err, errBackend) || calls != 1 {
+		t.Fatalf("got %v after %d calls, want no retry once the context ended", err, calls)
+	}
+}
+
+func TestTimeoutAndChain(t *testing.T) {
+	var attempts atomic.Int32
+	p := Chain(
+		Retry{MaxAttempts: 2, Backoff: Backoff{Initial: time.Millisecond}},
+		nil,
+		Timeout(10*time.Millisecond),
+	)
+	err := p.Execute(context.Background(), func(ctx context.Context) error {
+		attempts.Add(1)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if !errors.Is(err, ErrTimeout) || attempts.Load() != 2 {
+		t.Fatalf("got %v after %d attempts, want ErrTimeout after retrying the timed out attempt", err, attempts.Load())
+	}
+	if err := Timeout(0).Execute(context.Background(), func(ctx context.Context) error {
+		if _, ok := ctx.Deadline(); ok {
+			return errors.New("unexpected deadline")
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	ctx := context.Background()
+	var rejects int
+	var changes []State
+	b := NewCircuitBreaker(BreakerOptions{
+		Failures:      2,
+		Cooldown:      20 * time.Millisecond,
+		IsFailure:     func(err error) bool { return errors.Is(err, errBackend) },
+		OnStateChange: func(_, to State) { changes = append(changes, to) },
+		OnReject:      func() { rejects++ },
+	})
+	fail := func(context.Context) error { return errBackend }
+	ok := func(context.Context) error { return nil }
+
+	// Errors that do not count keep the breaker closed.
+	_ = b.Execute(ctx, func(context.Context) error { return errors.New("not found") })
+	_ = b.Execute(ctx, fail)
+	_ = b.Execute(ctx, func(context.Context) error { return errors.New("not found") })
+	_ = b.Execute(ctx, fail)
+	if b.State() != StateClosed {
+		t.Fatalf("state = %s, want closed after non-consecutive failures", b.State())
+	}
+	_ = b.Execute(ctx, fail)
+	if b.State() != StateOpen {
+		t.Fatalf("state = %s, want open", b.State())
+	}
+	if err := b.Execute(ctx, ok); !errors.Is(err, ErrCircuitOpen) || rejects != 1 {
+		t.Fatalf("got %v with %d rejects, want a fast failure", err, rejects)
+	}
+
+	time.Sleep(25 * time.Millisecond)
+	if b.State() != StateHalfOpen {
+		t.Fatalf("state = %s, want half_open after the cooldown", b.State())
+	}
+	// A probe cancelled by its caller is not counted.
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	_ = b.Execute(cctx, func(ctx context.Context) error { return ctx.Err() })
+	if err := b.Allow(); err != nil {
+		t.Fatalf("expected a new probe after a cancelled one, got %v", err)
+	}
+	if err := b.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected one probe at a time, got %v", err)
+	}
+	b.Success()
+	if b.State() != StateClosed {
+		t.Fatalf("state = %s, want closed after a successful probe", b.State())
+	}
+	want := []State{StateOpen, StateHalfOpen, StateClosed}
+	if len(changes) != len(want) {
+		t.Fatalf("state changes = %v, want %v", changes, want)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Fatalf("state changes = %v, want %v", changes, want)
+		}
+	}
+
+	disabled := NewCircuitBreaker(BreakerOptions{})
+	for i := 0; i < 5; i++ {
+		_ = disabled.Execute(ctx, fail)
+	}
+	if err := disabled.Allow(); err != nil {
+		t.Fatalf("disabled breaker rejected a call: %v", err)
+	}
+}
+
+func TestBulkhead(t *testing.T) {
+	ctx := context.Background()
+	var rejects atomic.Int32
+	b := NewBulkhead(BulkheadOptions{MaxConcurrent: 1, MaxWait: 10 * time.Millisecond, OnReject: func() { rejects.Add(1) }})
+
+	started, release := make(chan struct{}), make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- b.Execute(ctx, func(context.Context) error {
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+	<-started
+	if b.InFlight() != 1 {
+		t.Fatalf("in flight = %d, want 1", b.InFlight())
+	}
+	if err := b.Execute(ctx, func(context.Context) error { return nil }); !errors.Is(err, ErrBulkheadFull) || rejects.Load() != 1 {
+		t.Fatalf("got %v with %d rejects, want ErrBulkheadFull", err, rejects.Load())
+	}
+
+	// A waiting call gets the slot once it frees up.
+	waited := make(chan error)
+	wb := NewBulkhead(BulkheadOptions{MaxConcurrent: 1, MaxWait: time.Second})
+	wstarted, wrelease := make(chan struct{}), make(chan struct{})
+	go func() {
+		_ = wb.Execute(ctx, func(context.Context) error { close(wstarted); <-wrelease; return nil })
+	}()
+	<-wstarted
+	go func() { waited <- wb.Execute(ctx, func(context.Context) error { return nil }) }()
+	close(wrelease)
+	if err := <-waited; err != nil {
+		t.Fatalf("waiting call failed: %v", err)
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if err := NewBulkhead(BulkheadOptions{}).Execute(ctx, func(context.Context) error { return nil }); err != nil {
+		t.Fatalf("disabled bulkhead rejected a call: %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+// Backoff computes the wait after a failed attempt: Initial doubled per
+// attempt, capped at Max. With Jitter the wait is half of that delay plus a
+// random share of the other half, so clients failing together do not retry
+// in lockstep.
+type Backoff struct {
+	Initial time.Duration
+	Max     time.Duration
+	Jitter  bool
+}
+
+// Delay returns the wait after the given failed attempt, counted from 1.
+func (b Backoff) Delay(attempt int) time.Duration {
+	if b.Initial <= 0 {
+		return 0
+	}
+	maxDelay := max(b.Max, b.Initial)
+	d := b.Initial << max(attempt-1, 0)
+	if d <= 0 || d > maxDelay {
+		d = maxDelay
+	}
+	if b.Jitter {
+		return d/2 + rand.N(d/2+1)
+	}
+	return d
+}
+
+// Retry runs a call up to MaxAttempts times, waiting per Backoff between
+// attempts. 1 or less runs it once.
+type Retry struct {
+	MaxAttempts int
+	Backoff     Backoff
+	// Retryable reports whether a failed attempt is worth retrying. nil
+	// retries every error; see Execute for the errors never retried.
+	Retryable func(err error) bool
+	// OnRetry is called after a failed attempt, before waiting wait for the
+	// next one.
+	OnRetry func(attempt int, err error, wait time.Duration)
+}
+
+// Execute runs fn until it succeeds, fails permanently or runs out of
+// attempts, and returns its last error. Rejections by a circuit breaker or
+// bulkhead and errors wrapped with Permanent are never retried, nor is
+// anything once ctx has ended; a timed out attempt is.
+func (r Retry) Execute(ctx context.Context, fn func(context.Context) error) error {
+	attempts := max(r.MaxAttempts, 1)
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+		var perm *permanentError
+		if errors.As(err, &perm) {
+			return perm.err
+		}
+		if attempt >= attempts || ctx.Err() != nil || !r.retryable(err) {
+			return err
+		}
+
+		wait := r.Backoff.Delay(attempt)
+		if r.OnRetry != nil {
+			r.OnRetry(attempt, err, wait)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (r Retry) retryable(err error) bool {
+	switch {
+	case errors.Is(err, ErrCircuitOpen), errors.Is(err, ErrBulkheadFull):
+		return false
+	case r.Retryable != nil:
+		return r.Retryable(err)
+	}
+	return true
+}